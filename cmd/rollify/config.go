@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
+
+	"github.com/rollify/rollify/internal/ratelimit"
 )
 
 const (
@@ -15,6 +17,18 @@ const (
 	EventSubsTypeMemory = "memory"
 	// EventSubsNATS is the NATS event subscription type.
 	EventSubsNATS = "nats"
+	// RollerTypeRandom is the pseudo random (math/rand) roller type.
+	RollerTypeRandom = "random"
+	// RollerTypeCrypto is the cryptographically secure (crypto/rand) roller type.
+	RollerTypeCrypto = "crypto"
+	// RollerTypeProvablyFair is the provably fair (commit-reveal seeds) roller type.
+	RollerTypeProvablyFair = "provably-fair"
+	// RollerTypeSeeded is the deterministic seeded roller type, only for development.
+	RollerTypeSeeded = "seeded"
+	// RateLimiterTypeMemory is the memory rate limiter type, the limits are not shared between instances.
+	RateLimiterTypeMemory = "memory"
+	// RateLimiterTypeNATS is the NATS key-value store rate limiter type, the limits are shared between instances.
+	RateLimiterTypeNATS = "nats"
 )
 
 // CmdConfig represents the configuration of the command.
@@ -39,12 +53,18 @@ type CmdConfig struct {
 		MaxOpenConns    int
 		OpTimeout       time.Duration
 	}
+	RollerType    string
+	RollerSeed    string
 	EventSubsType string
 	NATS          struct {
 		Username string
 		Password string
 		Address  string
 	}
+	RateLimiterType  string
+	UserDiceRollRate ratelimit.Rate
+	RoomDiceRollRate ratelimit.Rate
+	SessionKey       string
 }
 
 // NewCmdConfig returns a new command configuration.
@@ -80,12 +100,26 @@ func NewCmdConfig(args []string) (*CmdConfig, error) {
 	app.Flag("mysql.max-open-conns", "the max open connections for MySQL.").Default("25").IntVar(&c.MySQL.MaxOpenConns)
 	app.Flag("mysql.operations-timeout", "timeout duration for MySQL operations.").Default("1s").DurationVar(&c.MySQL.OpTimeout)
 
+	// Roller.
+	app.Flag("roller-type", "the dice roller type used on the application.").Default(RollerTypeRandom).EnumVar(&c.RollerType, RollerTypeRandom, RollerTypeCrypto, RollerTypeProvablyFair, RollerTypeSeeded)
+	app.Flag("roller-seed", "the seed used by the seeded roller type, the same seed and requests will replay the same dice rolls.").Default("rollify").StringVar(&c.RollerSeed)
+
 	// Event subscription.
 	app.Flag("event-subscription-type", "the event subscription type used on the application.").Default(EventSubsTypeMemory).EnumVar(&c.EventSubsType, EventSubsTypeMemory, EventSubsNATS)
 	app.Flag("nats.username", "the username for NATS connection.").StringVar(&c.NATS.Username)
 	app.Flag("nats.password", "the password for NATS connection.").StringVar(&c.NATS.Password)
 	app.Flag("nats.address", "the address for NATS connection.").Default("localhost:4222").StringVar(&c.NATS.Address)
 
+	// Rate limit.
+	app.Flag("rate-limiter-type", "the rate limiter type used to limit the dice rolls.").Default(RateLimiterTypeMemory).EnumVar(&c.RateLimiterType, RateLimiterTypeMemory, RateLimiterTypeNATS)
+	app.Flag("user-dice-roll-rate.burst", "the max dice rolls a user can make at once, 0 disables the user rate limit.").Default("10").UintVar(&c.UserDiceRollRate.Burst)
+	app.Flag("user-dice-roll-rate.interval", "the time a user needs to recover a dice roll.").Default("1s").DurationVar(&c.UserDiceRollRate.Interval)
+	app.Flag("room-dice-roll-rate.burst", "the max dice rolls that can be made at once on a room, 0 disables the room rate limit.").Default("50").UintVar(&c.RoomDiceRollRate.Burst)
+	app.Flag("room-dice-roll-rate.interval", "the time a room needs to recover a dice roll.").Default("250ms").DurationVar(&c.RoomDiceRollRate.Interval)
+
+	// Users.
+	app.Flag("session-key", "the key used to sign the user sessions, all the instances need the same key. By default a random one (the sessions are lost on restarts).").StringVar(&c.SessionKey)
+
 	_, err := app.Parse(args[1:])
 	if err != nil {
 		return nil, err
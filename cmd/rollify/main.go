@@ -3,16 +3,20 @@ package main
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/pprof"
 	"os"
 	"os/signal"
+	"strconv"
+	"sync"
 	"syscall"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
 	"github.com/nats-io/nats.go"
 	"github.com/oklog/run"
 	"github.com/prometheus/client_golang/prometheus"
@@ -20,6 +24,7 @@ import (
 	"github.com/r3labs/sse/v2"
 	"github.com/sirupsen/logrus"
 
+	"github.com/rollify/rollify/internal/deck"
 	"github.com/rollify/rollify/internal/dice"
 	"github.com/rollify/rollify/internal/event"
 	eventmemory "github.com/rollify/rollify/internal/event/memory"
@@ -27,9 +32,16 @@ import (
 	"github.com/rollify/rollify/internal/http/apiv1"
 	"github.com/rollify/rollify/internal/http/ui"
 	httpui "github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative"
 	"github.com/rollify/rollify/internal/log"
+	"github.com/rollify/rollify/internal/macro"
 	metrics "github.com/rollify/rollify/internal/metrics/prometheus"
+	"github.com/rollify/rollify/internal/oracle"
+	"github.com/rollify/rollify/internal/ratelimit"
+	ratelimitmemory "github.com/rollify/rollify/internal/ratelimit/memory"
+	ratelimitnats "github.com/rollify/rollify/internal/ratelimit/nats"
 	"github.com/rollify/rollify/internal/room"
+	"github.com/rollify/rollify/internal/stats"
 	"github.com/rollify/rollify/internal/storage"
 	storagememory "github.com/rollify/rollify/internal/storage/memory"
 	"github.com/rollify/rollify/internal/storage/mysql"
@@ -74,16 +86,37 @@ func Run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
 
 	// Create storage.
 	var (
-		roomRepo     storage.RoomRepository
-		diceRollRepo storage.DiceRollRepository
-		userRepo     storage.UserRepository
+		roomRepo          storage.RoomRepository
+		diceRollRepo      storage.DiceRollRepository
+		userRepo          storage.UserRepository
+		customDieTypeRepo storage.CustomDieTypeRepository
+		serverSeedRepo    storage.ServerSeedRepository
+		macroRepo         storage.MacroRepository
+		initiativeRepo    storage.InitiativeRepository
+		deckRepo          storage.DeckRepository
+		oracleTableRepo   storage.OracleTableRepository
 	)
 	switch cmdCfg.StorageType {
 	// Memory storage.
 	case StorageTypeMemory:
-		diceRollRepo = storagememory.NewDiceRollRepository()
-		roomRepo = storagememory.NewRoomRepository()
-		userRepo = storagememory.NewUserRepository()
+		memRoomRepo := storagememory.NewRoomRepository()
+		memRoomRepo.UserRepository = storagememory.NewUserRepository()
+		memRoomRepo.DiceRollRepository = storagememory.NewDiceRollRepository()
+		memRoomRepo.CustomDieTypeRepository = storagememory.NewCustomDieTypeRepository()
+		memRoomRepo.ServerSeedRepository = storagememory.NewServerSeedRepository()
+		memRoomRepo.MacroRepository = storagememory.NewMacroRepository()
+		memRoomRepo.InitiativeRepository = storagememory.NewInitiativeRepository()
+		memRoomRepo.DeckRepository = storagememory.NewDeckRepository()
+		memRoomRepo.OracleTableRepository = storagememory.NewOracleTableRepository()
+		roomRepo = memRoomRepo
+		userRepo = memRoomRepo.UserRepository
+		diceRollRepo = memRoomRepo.DiceRollRepository
+		customDieTypeRepo = memRoomRepo.CustomDieTypeRepository
+		serverSeedRepo = memRoomRepo.ServerSeedRepository
+		macroRepo = memRoomRepo.MacroRepository
+		initiativeRepo = memRoomRepo.InitiativeRepository
+		deckRepo = memRoomRepo.DeckRepository
+		oracleTableRepo = memRoomRepo.OracleTableRepository
 
 	// MySQL storage.
 	case StorageTypeMySQL:
@@ -116,20 +149,68 @@ func Run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
 			return fmt.Errorf("could not create mysql dice roll repository: %w", err)
 		}
 
+		customDieTypeRepo, err = mysql.NewCustomDieTypeRepository(mysql.CustomDieTypeRepositoryConfig{
+			DBClient: db,
+			Logger:   logger,
+		})
+		if err != nil {
+			return fmt.Errorf("could not create mysql custom die type repository: %w", err)
+		}
+
+		serverSeedRepo, err = mysql.NewServerSeedRepository(mysql.ServerSeedRepositoryConfig{
+			DBClient: db,
+			Logger:   logger,
+		})
+		if err != nil {
+			return fmt.Errorf("could not create mysql server seed repository: %w", err)
+		}
+
+		macroRepo, err = mysql.NewMacroRepository(mysql.MacroRepositoryConfig{
+			DBClient: db,
+			Logger:   logger,
+		})
+		if err != nil {
+			return fmt.Errorf("could not create mysql macro repository: %w", err)
+		}
+
+		initiativeRepo, err = mysql.NewInitiativeRepository(mysql.InitiativeRepositoryConfig{
+			DBClient: db,
+			Logger:   logger,
+		})
+		if err != nil {
+			return fmt.Errorf("could not create mysql initiative repository: %w", err)
+		}
+
+		deckRepo, err = mysql.NewDeckRepository(mysql.DeckRepositoryConfig{
+			DBClient: db,
+			Logger:   logger,
+		})
+		if err != nil {
+			return fmt.Errorf("could not create mysql deck repository: %w", err)
+		}
+
+		oracleTableRepo, err = mysql.NewOracleTableRepository(mysql.OracleTableRepositoryConfig{
+			DBClient: db,
+			Logger:   logger,
+		})
+		if err != nil {
+			return fmt.Errorf("could not create mysql oracle table repository: %w", err)
+		}
+
 	// Unsuported storage type.
 	default:
 		return fmt.Errorf("storage type '%s' unknown", cmdCfg.StorageType)
 	}
 
 	// Wrap repos with cache.
-	roomRepo, err = storage.NewCachedRoomRepository(roomRepo)
+	userRepo, err = storage.NewCachedUserRepository(userRepo)
 	if err != nil {
-		return fmt.Errorf("could not add room cache to repository: %w", err)
+		return fmt.Errorf("could not add user cache to repository: %w", err)
 	}
 
-	userRepo, err = storage.NewCachedUserRepository(userRepo)
+	roomRepo, err = storage.NewCachedRoomRepository(roomRepo, userRepo)
 	if err != nil {
-		return fmt.Errorf("could not add user cache to repository: %w", err)
+		return fmt.Errorf("could not add room cache to repository: %w", err)
 	}
 
 	// Wrap repos with metrics.
@@ -139,10 +220,48 @@ func Run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
 		storage.NewTimeoutRoomRepository(cmdCfg.MySQL.OpTimeout, roomRepo))
 	userRepo = storage.NewMeasuredUserRepository(cmdCfg.StorageType, metricsRecorder,
 		storage.NewTimeoutUserRepository(cmdCfg.MySQL.OpTimeout, userRepo))
+	customDieTypeRepo = storage.NewMeasuredCustomDieTypeRepository(cmdCfg.StorageType, metricsRecorder,
+		storage.NewTimeoutCustomDieTypeRepository(cmdCfg.MySQL.OpTimeout, customDieTypeRepo))
+	serverSeedRepo = storage.NewMeasuredServerSeedRepository(cmdCfg.StorageType, metricsRecorder,
+		storage.NewTimeoutServerSeedRepository(cmdCfg.MySQL.OpTimeout, serverSeedRepo))
+	macroRepo = storage.NewMeasuredMacroRepository(cmdCfg.StorageType, metricsRecorder,
+		storage.NewTimeoutMacroRepository(cmdCfg.MySQL.OpTimeout, macroRepo))
+	initiativeRepo = storage.NewMeasuredInitiativeRepository(cmdCfg.StorageType, metricsRecorder,
+		storage.NewTimeoutInitiativeRepository(cmdCfg.MySQL.OpTimeout, initiativeRepo))
+	deckRepo = storage.NewMeasuredDeckRepository(cmdCfg.StorageType, metricsRecorder,
+		storage.NewTimeoutDeckRepository(cmdCfg.MySQL.OpTimeout, deckRepo))
+	oracleTableRepo = storage.NewMeasuredOracleTableRepository(cmdCfg.StorageType, metricsRecorder,
+		storage.NewTimeoutOracleTableRepository(cmdCfg.MySQL.OpTimeout, oracleTableRepo))
 
 	// Roller.
-	roller := dice.NewRandomRoller()
-	roller = dice.NewMeasureRoller("random", metricsRecorder, roller)
+	var roller dice.Roller
+	var idGen func() string          // Nil will use the default random IDs.
+	var timeNowFunc func() time.Time // Nil will use the wall clock.
+	switch cmdCfg.RollerType {
+	case RollerTypeRandom:
+		roller = dice.NewRandomRoller()
+	case RollerTypeCrypto:
+		roller = dice.NewCryptoRoller()
+	case RollerTypeProvablyFair:
+		roller, err = dice.NewProvablyFairRoller(dice.ProvablyFairRollerConfig{
+			ServerSeedRepository: serverSeedRepo,
+		})
+		if err != nil {
+			return fmt.Errorf("could not create provably fair roller: %w", err)
+		}
+	case RollerTypeSeeded:
+		if !cmdCfg.Development {
+			return fmt.Errorf("roller type '%s' can only be used in development mode", cmdCfg.RollerType)
+		}
+		roller = dice.NewSeededRoller(cmdCfg.RollerSeed)
+		// The seeded roller derives the dice from the dice roll IDs, so the IDs need to be deterministic,
+		// and to replay whole sessions with the same data, the timestamps too.
+		idGen = newSeededIDGenerator(cmdCfg.RollerSeed)
+		timeNowFunc = newSeededTimeNow()
+	default:
+		return fmt.Errorf("roller type '%s' unknown", cmdCfg.RollerType)
+	}
+	roller = dice.NewMeasureRoller(cmdCfg.RollerType, metricsRecorder, roller)
 
 	// Events.
 	var notifier event.Notifier
@@ -181,15 +300,73 @@ func Run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
 	notifier = event.NewMeasuredNotifier(cmdCfg.EventSubsType, metricsRecorder, notifier)
 	subscriber = event.NewMeasuredSubscriber(cmdCfg.EventSubsType, metricsRecorder, subscriber)
 
+	// Rate limiters.
+	var newRateLimiter func(keyPrefix string, r ratelimit.Rate) (ratelimit.Limiter, error)
+	switch cmdCfg.RateLimiterType {
+	// Memory rate limiter.
+	case RateLimiterTypeMemory:
+		newRateLimiter = func(_ string, r ratelimit.Rate) (ratelimit.Limiter, error) {
+			return ratelimitmemory.NewTokenBucketLimiter(ratelimitmemory.TokenBucketLimiterConfig{Rate: r})
+		}
+
+	// NATS rate limiter.
+	case RateLimiterTypeNATS:
+		natsConn, err := createNATSConnection(*cmdCfg)
+		if err != nil {
+			return fmt.Errorf("could not create NATS connnection: %w", err)
+		}
+
+		// The buckets are refilled after the refill duration, so we don't need to store them longer.
+		ttl := max(cmdCfg.UserDiceRollRate.RefillDuration(), cmdCfg.RoomDiceRollRate.RefillDuration())
+		kv, err := createNATSKeyValue(natsConn, "rollify-rate-limit", ttl)
+		if err != nil {
+			return fmt.Errorf("could not create NATS key-value store: %w", err)
+		}
+
+		newRateLimiter = func(keyPrefix string, r ratelimit.Rate) (ratelimit.Limiter, error) {
+			return ratelimitnats.NewTokenBucketLimiter(ratelimitnats.TokenBucketLimiterConfig{
+				KeyValue:  kv,
+				KeyPrefix: keyPrefix,
+				Rate:      r,
+				Logger:    logger,
+			})
+		}
+
+	// Unsuported rate limiter type.
+	default:
+		return fmt.Errorf("rate limiter type '%s' unknown", cmdCfg.RateLimiterType)
+	}
+
+	// A rate without burst disables the rate limit.
+	var userRateLimiter, roomRateLimiter ratelimit.Limiter = ratelimit.Noop, ratelimit.Noop
+	if cmdCfg.UserDiceRollRate.Burst > 0 {
+		userRateLimiter, err = newRateLimiter("user.", cmdCfg.UserDiceRollRate)
+		if err != nil {
+			return fmt.Errorf("could not create user dice roll rate limiter: %w", err)
+		}
+	}
+	if cmdCfg.RoomDiceRollRate.Burst > 0 {
+		roomRateLimiter, err = newRateLimiter("room.", cmdCfg.RoomDiceRollRate)
+		if err != nil {
+			return fmt.Errorf("could not create room dice roll rate limiter: %w", err)
+		}
+	}
+
 	// Create app services.
 	diceAppService, err := dice.NewService(dice.ServiceConfig{
-		DiceRollRepository: diceRollRepo,
-		RoomRepository:     roomRepo,
-		UserRepository:     userRepo,
-		Roller:             roller,
-		EventNotifier:      notifier,
-		EventSubscriber:    subscriber,
-		Logger:             logger,
+		DiceRollRepository:      diceRollRepo,
+		RoomRepository:          roomRepo,
+		UserRepository:          userRepo,
+		CustomDieTypeRepository: customDieTypeRepo,
+		ServerSeedRepository:    serverSeedRepo,
+		Roller:                  roller,
+		EventNotifier:           notifier,
+		EventSubscriber:         subscriber,
+		UserRateLimiter:         userRateLimiter,
+		RoomRateLimiter:         roomRateLimiter,
+		IDGenerator:             idGen,
+		TimeNowFunc:             timeNowFunc,
+		Logger:                  logger,
 	})
 	if err != nil {
 		return fmt.Errorf("could not create dice application service: %w", err)
@@ -197,8 +374,13 @@ func Run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
 	diceAppService = dice.NewMeasureService(metricsRecorder, diceAppService)
 
 	roomAppService, err := room.NewService(room.ServiceConfig{
-		RoomRepository: roomRepo,
-		Logger:         logger,
+		RoomRepository:  roomRepo,
+		UserRepository:  userRepo,
+		EventNotifier:   notifier,
+		EventSubscriber: subscriber,
+		IDGenerator:     idGen,
+		TimeNowFunc:     timeNowFunc,
+		Logger:          logger,
 	})
 	if err != nil {
 		return fmt.Errorf("could not create room application service: %w", err)
@@ -208,6 +390,9 @@ func Run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
 	userAppService, err := user.NewService(user.ServiceConfig{
 		UserRepository: userRepo,
 		RoomRepository: roomRepo,
+		SessionKey:     []byte(cmdCfg.SessionKey),
+		IDGenerator:    idGen,
+		TimeNowFunc:    timeNowFunc,
 		Logger:         logger,
 	})
 	if err != nil {
@@ -215,6 +400,77 @@ func Run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
 	}
 	userAppService = user.NewMeasureService(metricsRecorder, userAppService)
 
+	macroAppService, err := macro.NewService(macro.ServiceConfig{
+		MacroRepository: macroRepo,
+		RoomRepository:  roomRepo,
+		UserRepository:  userRepo,
+		IDGenerator:     idGen,
+		TimeNowFunc:     timeNowFunc,
+		Logger:          logger,
+	})
+	if err != nil {
+		return fmt.Errorf("could not create macro application service: %w", err)
+	}
+	macroAppService = macro.NewMeasureService(metricsRecorder, macroAppService)
+
+	initiativeAppService, err := initiative.NewService(initiative.ServiceConfig{
+		InitiativeRepository: initiativeRepo,
+		RoomRepository:       roomRepo,
+		UserRepository:       userRepo,
+		DiceAppService:       diceAppService,
+		EventNotifier:        notifier,
+		EventSubscriber:      subscriber,
+		IDGenerator:          idGen,
+		TimeNowFunc:          timeNowFunc,
+		Logger:               logger,
+	})
+	if err != nil {
+		return fmt.Errorf("could not create initiative application service: %w", err)
+	}
+	initiativeAppService = initiative.NewMeasureService(metricsRecorder, initiativeAppService)
+
+	deckAppService, err := deck.NewService(deck.ServiceConfig{
+		DeckRepository:  deckRepo,
+		RoomRepository:  roomRepo,
+		UserRepository:  userRepo,
+		EventNotifier:   notifier,
+		EventSubscriber: subscriber,
+		IDGenerator:     idGen,
+		TimeNowFunc:     timeNowFunc,
+		Logger:          logger,
+	})
+	if err != nil {
+		return fmt.Errorf("could not create deck application service: %w", err)
+	}
+	deckAppService = deck.NewMeasureService(metricsRecorder, deckAppService)
+
+	oracleAppService, err := oracle.NewService(oracle.ServiceConfig{
+		OracleTableRepository: oracleTableRepo,
+		RoomRepository:        roomRepo,
+		UserRepository:        userRepo,
+		DiceAppService:        diceAppService,
+		EventNotifier:         notifier,
+		EventSubscriber:       subscriber,
+		IDGenerator:           idGen,
+		TimeNowFunc:           timeNowFunc,
+		Logger:                logger,
+	})
+	if err != nil {
+		return fmt.Errorf("could not create oracle application service: %w", err)
+	}
+	oracleAppService = oracle.NewMeasureService(metricsRecorder, oracleAppService)
+
+	statsAppService, err := stats.NewService(stats.ServiceConfig{
+		DiceRollRepository: diceRollRepo,
+		RoomRepository:     roomRepo,
+		UserRepository:     userRepo,
+		Logger:             logger,
+	})
+	if err != nil {
+		return fmt.Errorf("could not create stats application service: %w", err)
+	}
+	statsAppService = stats.NewMeasureService(metricsRecorder, statsAppService)
+
 	// Prepare our main runner.
 	var g run.Group
 
@@ -228,11 +484,16 @@ func Run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
 
 		// API.
 		apiv1Handler, err := apiv1.New(apiv1.Config{
-			DiceAppService:  diceAppService,
-			RoomAppService:  roomAppService,
-			UserAppService:  userAppService,
-			MetricsRecorder: metricsRecorder,
-			Logger:          logger,
+			DiceAppService:       diceAppService,
+			RoomAppService:       roomAppService,
+			UserAppService:       userAppService,
+			MacroAppService:      macroAppService,
+			InitiativeAppService: initiativeAppService,
+			DeckAppService:       deckAppService,
+			OracleAppService:     oracleAppService,
+			StatsAppService:      statsAppService,
+			MetricsRecorder:      metricsRecorder,
+			Logger:               logger,
 		})
 		if err != nil {
 			return fmt.Errorf("could not create apiv1 handler: %w", err)
@@ -244,13 +505,18 @@ func Run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
 
 		uiPrefix := "/u"
 		uiHandler, err := ui.New(httpui.Config{
-			DiceAppService:  diceAppService,
-			RoomAppService:  roomAppService,
-			UserAppService:  userAppService,
-			MetricsRecorder: metricsRecorder,
-			SSEServer:       sseServer,
-			Logger:          logger,
-			ServerPrefix:    uiPrefix,
+			DiceAppService:       diceAppService,
+			RoomAppService:       roomAppService,
+			UserAppService:       userAppService,
+			MacroAppService:      macroAppService,
+			InitiativeAppService: initiativeAppService,
+			DeckAppService:       deckAppService,
+			OracleAppService:     oracleAppService,
+			StatsAppService:      statsAppService,
+			MetricsRecorder:      metricsRecorder,
+			SSEServer:            sseServer,
+			Logger:               logger,
+			ServerPrefix:         uiPrefix,
 		})
 		if err != nil {
 			return fmt.Errorf("could not create ui handler: %w", err)
@@ -367,6 +633,32 @@ func Run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
 	return nil
 }
 
+// newSeededIDGenerator returns an ID generator that will return the same sequence of UUIDs
+// for the same seed.
+func newSeededIDGenerator(seed string) func() string {
+	var mu sync.Mutex
+	var n uint64
+	return func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		n++
+		return uuid.NewSHA1(uuid.NameSpaceOID, []byte(seed+":"+strconv.FormatUint(n, 10))).String()
+	}
+}
+
+// newSeededTimeNow returns a clock that starts always at the same time and advances a
+// millisecond each time is called, so the timestamps are the same on every replay.
+func newSeededTimeNow() func() time.Time {
+	var mu sync.Mutex
+	t := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		t = t.Add(time.Millisecond)
+		return t
+	}
+}
+
 func createMySQLConnection(cfg CmdConfig) (*sql.DB, error) {
 	conn := fmt.Sprintf("%s:%s@%s(%s)/%s?%s",
 		cfg.MySQL.Username,
@@ -396,6 +688,28 @@ func createNATSConnection(cfg CmdConfig) (*nats.Conn, error) {
 	return c, nil
 }
 
+func createNATSKeyValue(conn *nats.Conn, bucket string, ttl time.Duration) (nats.KeyValue, error) {
+	js, err := conn.JetStream()
+	if err != nil {
+		return nil, fmt.Errorf("could not create JetStream context: %w", err)
+	}
+
+	kv, err := js.KeyValue(bucket)
+	if err == nil {
+		return kv, nil
+	}
+	if !errors.Is(err, nats.ErrBucketNotFound) {
+		return nil, fmt.Errorf("could not get %q key-value store: %w", bucket, err)
+	}
+
+	kv, err = js.CreateKeyValue(&nats.KeyValueConfig{Bucket: bucket, TTL: ttl})
+	if err != nil {
+		return nil, fmt.Errorf("could not create %q key-value store: %w", bucket, err)
+	}
+
+	return kv, nil
+}
+
 func main() {
 	ctx := context.Background()
 
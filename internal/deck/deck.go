@@ -0,0 +1,586 @@
+package deck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/rollify/rollify/internal/event"
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/log"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/room/roomcheck"
+	"github.com/rollify/rollify/internal/storage"
+)
+
+// Service is the application service of the room card decks logic.
+type Service interface {
+	// CreateDeck creates a new shuffled deck on a room.
+	CreateDeck(ctx context.Context, r CreateDeckRequest) (*CreateDeckResponse, error)
+	// ListDecks lists the decks of a room.
+	ListDecks(ctx context.Context, r ListDecksRequest) (*ListDecksResponse, error)
+	// DrawCards draws cards from the top of a deck draw pile, the drawn cards will not be
+	// drawn again until they are reshuffled into the deck.
+	DrawCards(ctx context.Context, r DrawCardsRequest) (*DrawCardsResponse, error)
+	// DiscardCards moves drawn cards to the deck discard pile.
+	DiscardCards(ctx context.Context, r DiscardCardsRequest) (*DiscardCardsResponse, error)
+	// ReshuffleDeck shuffles the discarded cards back into the deck draw pile.
+	ReshuffleDeck(ctx context.Context, r ReshuffleDeckRequest) (*ReshuffleDeckResponse, error)
+	// SubscribeCardsDrawn subscribes to the room cards drawn events.
+	SubscribeCardsDrawn(ctx context.Context, r SubscribeCardsDrawnRequest) (*SubscribeCardsDrawnResponse, error)
+}
+
+//go:generate mockery --case underscore --output deckmock --outpkg deckmock --name Service
+
+// ServiceConfig is the service configuration.
+type ServiceConfig struct {
+	DeckRepository  storage.DeckRepository
+	RoomRepository  storage.RoomRepository
+	UserRepository  storage.UserRepository
+	Shuffler        Shuffler
+	EventNotifier   event.Notifier
+	EventSubscriber event.Subscriber
+	Logger          log.Logger
+	IDGenerator     func() string
+	TimeNowFunc     func() time.Time
+}
+
+func (c *ServiceConfig) defaults() error {
+	if c.DeckRepository == nil {
+		return fmt.Errorf("config.DeckRepository is required")
+	}
+
+	if c.RoomRepository == nil {
+		return fmt.Errorf("config.RoomRepository is required")
+	}
+
+	if c.UserRepository == nil {
+		return fmt.Errorf("config.UserRepository is required")
+	}
+
+	if c.Shuffler == nil {
+		c.Shuffler = NewCryptoShuffler()
+	}
+
+	if c.EventNotifier == nil {
+		return fmt.Errorf("config.EventNotifier is required")
+	}
+
+	if c.EventSubscriber == nil {
+		return fmt.Errorf("config.EventSubscriber is required")
+	}
+
+	if c.Logger == nil {
+		c.Logger = log.Dummy
+	}
+	c.Logger = c.Logger.WithKV(log.KV{"svc": "deck.Service"})
+
+	if c.IDGenerator == nil {
+		c.IDGenerator = func() string { return uuid.New().String() }
+	}
+
+	if c.TimeNowFunc == nil {
+		c.TimeNowFunc = time.Now
+	}
+
+	return nil
+}
+
+type service struct {
+	deckRepo        storage.DeckRepository
+	roomRepo        storage.RoomRepository
+	userRepo        storage.UserRepository
+	shuffler        Shuffler
+	eventNotifier   event.Notifier
+	eventSubscriber event.Subscriber
+	logger          log.Logger
+	idGen           func() string
+	timeNow         func() time.Time
+}
+
+// NewService returns a new deck.Service.
+func NewService(cfg ServiceConfig) (Service, error) {
+	err := cfg.defaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return service{
+		deckRepo:        cfg.DeckRepository,
+		roomRepo:        cfg.RoomRepository,
+		userRepo:        cfg.UserRepository,
+		shuffler:        cfg.Shuffler,
+		eventNotifier:   cfg.EventNotifier,
+		eventSubscriber: cfg.EventSubscriber,
+		logger:          cfg.Logger,
+		idGen:           cfg.IDGenerator,
+		timeNow:         cfg.TimeNowFunc,
+	}, nil
+}
+
+const (
+	maxRoomDecks      = 20
+	maxNameLength     = 100
+	maxCustomCards    = 200
+	maxCardNameLength = 50
+	maxDrawQuantity   = 54
+	// maxUpdateAttempts are the times a deck update is retried when someone else
+	// updated the deck at the same time (e.g: 2 users drawing at the same time).
+	maxUpdateAttempts = 3
+)
+
+// CreateDeckRequest is the request for CreateDeck.
+type CreateDeckRequest struct {
+	RoomID string
+	UserID string
+	Name   string
+	Type   model.DeckType
+	// Cards are the cards of the custom decks, ignored on the other deck types.
+	Cards []string
+}
+
+func (r CreateDeckRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	if r.UserID == "" {
+		return fmt.Errorf("config.UserID is required")
+	}
+
+	if r.Name == "" {
+		return fmt.Errorf("config.Name is required")
+	}
+
+	if utf8.RuneCountInString(r.Name) > maxNameLength {
+		return fmt.Errorf("max config.Name length is %d", maxNameLength)
+	}
+
+	switch r.Type {
+	case model.DeckTypeStandard, model.DeckTypeStandardJokers, model.DeckTypeTarot:
+	case model.DeckTypeCustom:
+		if len(r.Cards) == 0 {
+			return fmt.Errorf("config.Cards are required on custom decks")
+		}
+
+		if len(r.Cards) > maxCustomCards {
+			return fmt.Errorf("max config.Cards quantity is %d", maxCustomCards)
+		}
+
+		for _, c := range r.Cards {
+			if strings.TrimSpace(c) == "" {
+				return fmt.Errorf("config.Cards can't have empty cards")
+			}
+
+			if utf8.RuneCountInString(c) > maxCardNameLength {
+				return fmt.Errorf("max config.Cards card length is %d", maxCardNameLength)
+			}
+		}
+	default:
+		return fmt.Errorf("config.Type %q is unknown", r.Type)
+	}
+
+	return nil
+}
+
+// CreateDeckResponse is the response for CreateDeck.
+type CreateDeckResponse struct {
+	Deck model.Deck
+}
+
+func (s service) CreateDeck(ctx context.Context, r CreateDeckRequest) (*CreateDeckResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	err = roomcheck.WritableRoomUser(ctx, s.userRepo, s.roomRepo, r.RoomID, r.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	decks, err := s.deckRepo.ListRoomDecks(ctx, r.RoomID)
+	if err != nil {
+		return nil, fmt.Errorf("could not list room decks: %w", err)
+	}
+	if len(decks.Items) >= maxRoomDecks {
+		return nil, fmt.Errorf("max decks per room is %d: %w", maxRoomDecks, internalerrors.ErrNotValid)
+	}
+
+	cards := r.Type.Cards()
+	if r.Type == model.DeckTypeCustom {
+		cards = slices.Clone(r.Cards)
+	}
+
+	d := model.Deck{
+		ID:          s.idGen(),
+		CreatedAt:   s.timeNow().UTC(),
+		RoomID:      r.RoomID,
+		Name:        r.Name,
+		Type:        r.Type,
+		Cards:       cards,
+		DrawPile:    slices.Clone(cards),
+		DiscardPile: []string{},
+	}
+
+	err = s.shuffler.Shuffle(ctx, d.DrawPile)
+	if err != nil {
+		return nil, fmt.Errorf("could not shuffle deck: %w", err)
+	}
+
+	err = s.deckRepo.CreateDeck(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("could not store deck: %w", err)
+	}
+
+	return &CreateDeckResponse{
+		Deck: d,
+	}, nil
+}
+
+// ListDecksRequest is the request for ListDecks.
+type ListDecksRequest struct {
+	RoomID string
+}
+
+func (r ListDecksRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	return nil
+}
+
+// ListDecksResponse is the response for ListDecks.
+type ListDecksResponse struct {
+	Decks []model.Deck
+}
+
+func (s service) ListDecks(ctx context.Context, r ListDecksRequest) (*ListDecksResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	exists, err := s.roomRepo.RoomExists(ctx, r.RoomID)
+	if err != nil {
+		return nil, fmt.Errorf("could not check if room exists: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("room does not exists: %w", internalerrors.ErrNotValid)
+	}
+
+	decks, err := s.deckRepo.ListRoomDecks(ctx, r.RoomID)
+	if err != nil {
+		return nil, fmt.Errorf("could not list room decks: %w", err)
+	}
+
+	return &ListDecksResponse{
+		Decks: decks.Items,
+	}, nil
+}
+
+// DrawCardsRequest is the request for DrawCards.
+type DrawCardsRequest struct {
+	RoomID string
+	UserID string
+	DeckID string
+	// Quantity is the number of cards to draw, by default 1.
+	Quantity int
+}
+
+func (r DrawCardsRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	if r.UserID == "" {
+		return fmt.Errorf("config.UserID is required")
+	}
+
+	if r.DeckID == "" {
+		return fmt.Errorf("config.DeckID is required")
+	}
+
+	if r.Quantity < 1 || r.Quantity > maxDrawQuantity {
+		return fmt.Errorf("config.Quantity must be between 1 and %d", maxDrawQuantity)
+	}
+
+	return nil
+}
+
+// DrawCardsResponse is the response for DrawCards.
+type DrawCardsResponse struct {
+	CardDraw model.CardDraw
+	Deck     model.Deck
+}
+
+func (s service) DrawCards(ctx context.Context, r DrawCardsRequest) (*DrawCardsResponse, error) {
+	if r.Quantity == 0 {
+		r.Quantity = 1
+	}
+
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	err = roomcheck.WritableRoomUser(ctx, s.userRepo, s.roomRepo, r.RoomID, r.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var cd model.CardDraw
+	d, err := s.updateDeck(ctx, r.RoomID, r.DeckID, func(d *model.Deck) error {
+		if len(d.DrawPile) < r.Quantity {
+			return fmt.Errorf("the deck has %d cards left, it needs to be reshuffled: %w", len(d.DrawPile), internalerrors.ErrNotValid)
+		}
+
+		cd = model.CardDraw{
+			ID:        s.idGen(),
+			CreatedAt: s.timeNow().UTC(),
+			RoomID:    r.RoomID,
+			UserID:    r.UserID,
+			DeckID:    d.ID,
+			DeckName:  d.Name,
+			Cards:     slices.Clone(d.DrawPile[:r.Quantity]),
+		}
+		d.DrawPile = slices.Delete(d.DrawPile, 0, r.Quantity)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.eventNotifier.NotifyCardsDrawn(ctx, model.EventCardsDrawn{CardDraw: cd})
+	if err != nil {
+		return nil, fmt.Errorf("could not send cards drawn event: %w", err)
+	}
+
+	return &DrawCardsResponse{
+		CardDraw: cd,
+		Deck:     *d,
+	}, nil
+}
+
+// DiscardCardsRequest is the request for DiscardCards.
+type DiscardCardsRequest struct {
+	RoomID string
+	UserID string
+	DeckID string
+	// Cards are the drawn cards to discard, if empty all the drawn cards are discarded.
+	Cards []string
+}
+
+func (r DiscardCardsRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	if r.UserID == "" {
+		return fmt.Errorf("config.UserID is required")
+	}
+
+	if r.DeckID == "" {
+		return fmt.Errorf("config.DeckID is required")
+	}
+
+	return nil
+}
+
+// DiscardCardsResponse is the response for DiscardCards.
+type DiscardCardsResponse struct {
+	Deck model.Deck
+}
+
+func (s service) DiscardCards(ctx context.Context, r DiscardCardsRequest) (*DiscardCardsResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	err = roomcheck.WritableRoomUser(ctx, s.userRepo, s.roomRepo, r.RoomID, r.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := s.updateDeck(ctx, r.RoomID, r.DeckID, func(d *model.Deck) error {
+		inPlay := d.InPlay()
+		if len(r.Cards) == 0 {
+			d.DiscardPile = append(d.DiscardPile, inPlay...)
+			return nil
+		}
+
+		for _, c := range r.Cards {
+			idx := slices.Index(inPlay, c)
+			if idx < 0 {
+				return fmt.Errorf("card %q has not been drawn: %w", c, internalerrors.ErrNotValid)
+			}
+			inPlay = slices.Delete(inPlay, idx, idx+1)
+			d.DiscardPile = append(d.DiscardPile, c)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiscardCardsResponse{
+		Deck: *d,
+	}, nil
+}
+
+// ReshuffleDeckRequest is the request for ReshuffleDeck.
+type ReshuffleDeckRequest struct {
+	RoomID string
+	UserID string
+	DeckID string
+	// All will return all the cards to the deck, including the drawn cards that have not been discarded.
+	All bool
+}
+
+func (r ReshuffleDeckRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	if r.UserID == "" {
+		return fmt.Errorf("config.UserID is required")
+	}
+
+	if r.DeckID == "" {
+		return fmt.Errorf("config.DeckID is required")
+	}
+
+	return nil
+}
+
+// ReshuffleDeckResponse is the response for ReshuffleDeck.
+type ReshuffleDeckResponse struct {
+	Deck model.Deck
+}
+
+func (s service) ReshuffleDeck(ctx context.Context, r ReshuffleDeckRequest) (*ReshuffleDeckResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	err = roomcheck.WritableRoomUser(ctx, s.userRepo, s.roomRepo, r.RoomID, r.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := s.updateDeck(ctx, r.RoomID, r.DeckID, func(d *model.Deck) error {
+		if r.All {
+			d.DrawPile = slices.Clone(d.Cards)
+		} else {
+			d.DrawPile = append(d.DrawPile, d.DiscardPile...)
+		}
+		d.DiscardPile = []string{}
+
+		err := s.shuffler.Shuffle(ctx, d.DrawPile)
+		if err != nil {
+			return fmt.Errorf("could not shuffle deck: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReshuffleDeckResponse{
+		Deck: *d,
+	}, nil
+}
+
+// SubscribeCardsDrawnRequest is the request for SubscribeCardsDrawn.
+type SubscribeCardsDrawnRequest struct {
+	RoomID       string
+	EventHandler func(context.Context, model.EventCardsDrawn) error
+}
+
+func (r SubscribeCardsDrawnRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("roomID is required")
+	}
+
+	if r.EventHandler == nil {
+		return fmt.Errorf("eventHandler is required")
+	}
+
+	return nil
+}
+
+// SubscribeCardsDrawnResponse is the response for SubscribeCardsDrawn.
+type SubscribeCardsDrawnResponse struct {
+	UnsubscribeFunc func() error
+}
+
+func (s service) SubscribeCardsDrawn(ctx context.Context, r SubscribeCardsDrawnRequest) (*SubscribeCardsDrawnResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	exists, err := s.roomRepo.RoomExists(ctx, r.RoomID)
+	if err != nil {
+		return nil, fmt.Errorf("could not check if room exists: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("room does not exists: %w", internalerrors.ErrNotValid)
+	}
+
+	// Create a subscription ID and subscribe.
+	subscriptionID := s.idGen()
+	err = s.eventSubscriber.SubscribeCardsDrawn(ctx, subscriptionID, r.RoomID, r.EventHandler)
+	if err != nil {
+		return nil, fmt.Errorf("could not subscribe to cardsDrawn events: %w", err)
+	}
+
+	return &SubscribeCardsDrawnResponse{
+		UnsubscribeFunc: func() error {
+			return s.eventSubscriber.UnsubscribeCardsDrawn(ctx, subscriptionID, r.RoomID)
+		},
+	}, nil
+}
+
+// updateDeck gets the room deck, applies the update and stores it, if the deck was updated
+// by someone else in the meantime, the update is retried with the new deck state.
+func (s service) updateDeck(ctx context.Context, roomID, deckID string, update func(d *model.Deck) error) (*model.Deck, error) {
+	for attempt := 1; ; attempt++ {
+		d, err := s.deckRepo.GetDeck(ctx, deckID)
+		if err != nil {
+			return nil, fmt.Errorf("could not get deck: %w", err)
+		}
+
+		if d.RoomID != roomID {
+			return nil, fmt.Errorf("deck is not from the room: %w", internalerrors.ErrMissing)
+		}
+
+		err = update(d)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.deckRepo.UpdateDeck(ctx, *d)
+		if err != nil {
+			if errors.Is(err, internalerrors.ErrConflict) && attempt < maxUpdateAttempts {
+				continue
+			}
+			return nil, fmt.Errorf("could not update deck: %w", err)
+		}
+		d.Version++
+
+		return d, nil
+	}
+}
@@ -0,0 +1,524 @@
+package deck_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/deck"
+	"github.com/rollify/rollify/internal/event/eventmock"
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+	"github.com/rollify/rollify/internal/storage/storagemock"
+)
+
+type mocks struct {
+	md *storagemock.DeckRepository
+	mr *storagemock.RoomRepository
+	mu *storagemock.UserRepository
+	mn *eventmock.Notifier
+	ms *eventmock.Subscriber
+}
+
+func newMocks() mocks {
+	return mocks{
+		md: &storagemock.DeckRepository{},
+		mr: &storagemock.RoomRepository{},
+		mu: &storagemock.UserRepository{},
+		mn: &eventmock.Notifier{},
+		ms: &eventmock.Subscriber{},
+	}
+}
+
+func (m mocks) assertExpectations(t *testing.T) {
+	m.md.AssertExpectations(t)
+	m.mr.AssertExpectations(t)
+	m.mu.AssertExpectations(t)
+	m.mn.AssertExpectations(t)
+	m.ms.AssertExpectations(t)
+}
+
+// reverseShuffler is a predictable shuffler for the tests.
+var reverseShuffler = deck.ShufflerFunc(func(_ context.Context, cards []string) error {
+	slices.Reverse(cards)
+	return nil
+})
+
+func newService(t *testing.T, m mocks, t0 time.Time) deck.Service {
+	svc, err := deck.NewService(deck.ServiceConfig{
+		DeckRepository:  m.md,
+		RoomRepository:  m.mr,
+		UserRepository:  m.mu,
+		Shuffler:        reverseShuffler,
+		EventNotifier:   m.mn,
+		EventSubscriber: m.ms,
+		IDGenerator:     func() string { return "test" },
+		TimeNowFunc:     func() time.Time { return t0 },
+	})
+	require.NoError(t, err)
+
+	return svc
+}
+
+func getDeck() *model.Deck {
+	return &model.Deck{
+		ID:          "deck-id",
+		RoomID:      "room-id",
+		Name:        "Fate",
+		Type:        model.DeckTypeCustom,
+		Cards:       []string{"A", "B", "C", "D", "E"},
+		DrawPile:    []string{"E", "D", "C"},
+		DiscardPile: []string{"A"},
+		Version:     4,
+	}
+}
+
+func TestServiceCreateDeck(t *testing.T) {
+	t0 := time.Now().UTC()
+	u := &model.User{ID: "user-id", Name: "Bilbo", RoomID: "room-id"}
+
+	tests := map[string]struct {
+		mock    func(m mocks)
+		req     deck.CreateDeckRequest
+		expResp *deck.CreateDeckResponse
+		expErr  error
+	}{
+		"Having a deck on an archived room, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id", Archived: true}, nil)
+			},
+			req:    deck.CreateDeckRequest{RoomID: "room-id", UserID: "user-id", Name: "Cards", Type: model.DeckTypeStandard},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having a request without name, should fail.": {
+			mock:   func(m mocks) {},
+			req:    deck.CreateDeckRequest{RoomID: "room-id", UserID: "user-id", Type: model.DeckTypeStandard},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request with an unknown deck type, should fail.": {
+			mock:   func(m mocks) {},
+			req:    deck.CreateDeckRequest{RoomID: "room-id", UserID: "user-id", Name: "Cards", Type: "uno"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a custom deck without cards, should fail.": {
+			mock:   func(m mocks) {},
+			req:    deck.CreateDeckRequest{RoomID: "room-id", UserID: "user-id", Name: "Cards", Type: model.DeckTypeCustom},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a custom deck with empty cards, should fail.": {
+			mock:   func(m mocks) {},
+			req:    deck.CreateDeckRequest{RoomID: "room-id", UserID: "user-id", Name: "Cards", Type: model.DeckTypeCustom, Cards: []string{"A", " "}},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a user from another room, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(&model.User{ID: "user-id", RoomID: "other-room"}, nil)
+			},
+			req:    deck.CreateDeckRequest{RoomID: "room-id", UserID: "user-id", Name: "Cards", Type: model.DeckTypeStandard},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a room with the max decks, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.md.On("ListRoomDecks", mock.Anything, "room-id").Once().Return(&storage.DeckList{Items: make([]model.Deck, 20)}, nil)
+			},
+			req:    deck.CreateDeckRequest{RoomID: "room-id", UserID: "user-id", Name: "Cards", Type: model.DeckTypeStandard},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Creating a standard deck should create a shuffled deck with the standard cards.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.md.On("ListRoomDecks", mock.Anything, "room-id").Once().Return(&storage.DeckList{}, nil)
+				m.md.On("CreateDeck", mock.Anything, mock.Anything).Once().Return(nil)
+			},
+			req: deck.CreateDeckRequest{RoomID: "room-id", UserID: "user-id", Name: "Action deck", Type: model.DeckTypeStandardJokers},
+			expResp: func() *deck.CreateDeckResponse {
+				cards := model.DeckTypeStandardJokers.Cards()
+				drawPile := slices.Clone(cards)
+				slices.Reverse(drawPile)
+				return &deck.CreateDeckResponse{Deck: model.Deck{
+					ID:          "test",
+					CreatedAt:   t0,
+					RoomID:      "room-id",
+					Name:        "Action deck",
+					Type:        model.DeckTypeStandardJokers,
+					Cards:       cards,
+					DrawPile:    drawPile,
+					DiscardPile: []string{},
+				}}
+			}(),
+		},
+
+		"Creating a custom deck should create a shuffled deck with the custom cards.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.md.On("ListRoomDecks", mock.Anything, "room-id").Once().Return(&storage.DeckList{}, nil)
+				exp := model.Deck{
+					ID:          "test",
+					CreatedAt:   t0,
+					RoomID:      "room-id",
+					Name:        "Fate",
+					Type:        model.DeckTypeCustom,
+					Cards:       []string{"A", "B", "C"},
+					DrawPile:    []string{"C", "B", "A"},
+					DiscardPile: []string{},
+				}
+				m.md.On("CreateDeck", mock.Anything, exp).Once().Return(nil)
+			},
+			req: deck.CreateDeckRequest{RoomID: "room-id", UserID: "user-id", Name: "Fate", Type: model.DeckTypeCustom, Cards: []string{"A", "B", "C"}},
+			expResp: &deck.CreateDeckResponse{Deck: model.Deck{
+				ID:          "test",
+				CreatedAt:   t0,
+				RoomID:      "room-id",
+				Name:        "Fate",
+				Type:        model.DeckTypeCustom,
+				Cards:       []string{"A", "B", "C"},
+				DrawPile:    []string{"C", "B", "A"},
+				DiscardPile: []string{},
+			}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := newMocks()
+			test.mock(m)
+			svc := newService(t, m, t0)
+
+			gotResp, err := svc.CreateDeck(context.TODO(), test.req)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				assert.Equal(test.expResp, gotResp)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestServiceDrawCards(t *testing.T) {
+	t0 := time.Now().UTC()
+	u := &model.User{ID: "user-id", Name: "Bilbo", RoomID: "room-id"}
+
+	tests := map[string]struct {
+		mock    func(m mocks)
+		req     deck.DrawCardsRequest
+		expResp *deck.DrawCardsResponse
+		expErr  error
+	}{
+		"Drawing cards on an archived room, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id", Archived: true}, nil)
+			},
+			req:    deck.DrawCardsRequest{RoomID: "room-id", UserID: "user-id", DeckID: "deck-id"},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having a request without deck, should fail.": {
+			mock:   func(m mocks) {},
+			req:    deck.DrawCardsRequest{RoomID: "room-id", UserID: "user-id"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a deck from another room, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				d := getDeck()
+				d.RoomID = "other-room"
+				m.md.On("GetDeck", mock.Anything, "deck-id").Once().Return(d, nil)
+			},
+			req:    deck.DrawCardsRequest{RoomID: "room-id", UserID: "user-id", DeckID: "deck-id"},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Drawing more cards than the remaining ones, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.md.On("GetDeck", mock.Anything, "deck-id").Once().Return(getDeck(), nil)
+			},
+			req:    deck.DrawCardsRequest{RoomID: "room-id", UserID: "user-id", DeckID: "deck-id", Quantity: 4},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Drawing cards should remove the cards from the top of the draw pile and notify the draw.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.md.On("GetDeck", mock.Anything, "deck-id").Once().Return(getDeck(), nil)
+
+				expDeck := getDeck()
+				expDeck.DrawPile = []string{"C"}
+				m.md.On("UpdateDeck", mock.Anything, *expDeck).Once().Return(nil)
+
+				expEvent := model.EventCardsDrawn{CardDraw: model.CardDraw{ID: "test", CreatedAt: t0, RoomID: "room-id", UserID: "user-id", DeckID: "deck-id", DeckName: "Fate", Cards: []string{"E", "D"}}}
+				m.mn.On("NotifyCardsDrawn", mock.Anything, expEvent).Once().Return(nil)
+			},
+			req: deck.DrawCardsRequest{RoomID: "room-id", UserID: "user-id", DeckID: "deck-id", Quantity: 2},
+			expResp: &deck.DrawCardsResponse{
+				CardDraw: model.CardDraw{ID: "test", CreatedAt: t0, RoomID: "room-id", UserID: "user-id", DeckID: "deck-id", DeckName: "Fate", Cards: []string{"E", "D"}},
+				Deck: func() model.Deck {
+					d := getDeck()
+					d.DrawPile = []string{"C"}
+					d.Version = 5
+					return *d
+				}(),
+			},
+		},
+
+		"Drawing cards while someone else updated the deck should retry the draw with the updated deck.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.md.On("GetDeck", mock.Anything, "deck-id").Once().Return(getDeck(), nil)
+				m.md.On("UpdateDeck", mock.Anything, mock.Anything).Once().Return(internalerrors.ErrConflict)
+
+				// Someone drew the top card in the meantime.
+				updated := getDeck()
+				updated.DrawPile = []string{"D", "C"}
+				updated.Version = 5
+				m.md.On("GetDeck", mock.Anything, "deck-id").Once().Return(updated, nil)
+				expDeck := getDeck()
+				expDeck.DrawPile = []string{"C"}
+				expDeck.Version = 5
+				m.md.On("UpdateDeck", mock.Anything, *expDeck).Once().Return(nil)
+
+				m.mn.On("NotifyCardsDrawn", mock.Anything, mock.Anything).Once().Return(nil)
+			},
+			req: deck.DrawCardsRequest{RoomID: "room-id", UserID: "user-id", DeckID: "deck-id"},
+			expResp: &deck.DrawCardsResponse{
+				CardDraw: model.CardDraw{ID: "test", CreatedAt: t0, RoomID: "room-id", UserID: "user-id", DeckID: "deck-id", DeckName: "Fate", Cards: []string{"D"}},
+				Deck: func() model.Deck {
+					d := getDeck()
+					d.DrawPile = []string{"C"}
+					d.Version = 6
+					return *d
+				}(),
+			},
+		},
+
+		"Drawing cards while the deck is being continuously updated, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.md.On("GetDeck", mock.Anything, "deck-id").Times(3).Return(getDeck(), nil)
+				m.md.On("UpdateDeck", mock.Anything, mock.Anything).Times(3).Return(internalerrors.ErrConflict)
+			},
+			req:    deck.DrawCardsRequest{RoomID: "room-id", UserID: "user-id", DeckID: "deck-id"},
+			expErr: internalerrors.ErrConflict,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := newMocks()
+			test.mock(m)
+			svc := newService(t, m, t0)
+
+			gotResp, err := svc.DrawCards(context.TODO(), test.req)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				assert.Equal(test.expResp, gotResp)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestServiceDiscardCards(t *testing.T) {
+	t0 := time.Now().UTC()
+	u := &model.User{ID: "user-id", Name: "Bilbo", RoomID: "room-id"}
+
+	tests := map[string]struct {
+		mock       func(m mocks)
+		req        deck.DiscardCardsRequest
+		expDiscard []string
+		expErr     error
+	}{
+		"Discarding cards on an archived room, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id", Archived: true}, nil)
+			},
+			req:    deck.DiscardCardsRequest{RoomID: "room-id", UserID: "user-id", DeckID: "deck-id"},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Discarding a card that is not in play, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.md.On("GetDeck", mock.Anything, "deck-id").Once().Return(getDeck(), nil)
+			},
+			req:    deck.DiscardCardsRequest{RoomID: "room-id", UserID: "user-id", DeckID: "deck-id", Cards: []string{"C"}},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Discarding the same card in play twice, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.md.On("GetDeck", mock.Anything, "deck-id").Once().Return(getDeck(), nil)
+			},
+			req:    deck.DiscardCardsRequest{RoomID: "room-id", UserID: "user-id", DeckID: "deck-id", Cards: []string{"B", "B"}},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Discarding cards in play should move them to the discard pile.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.md.On("GetDeck", mock.Anything, "deck-id").Once().Return(getDeck(), nil)
+				m.md.On("UpdateDeck", mock.Anything, mock.Anything).Once().Return(nil)
+			},
+			req:        deck.DiscardCardsRequest{RoomID: "room-id", UserID: "user-id", DeckID: "deck-id", Cards: []string{"B"}},
+			expDiscard: []string{"A", "B"},
+		},
+
+		"Discarding without cards should discard all the cards in play.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.md.On("GetDeck", mock.Anything, "deck-id").Once().Return(getDeck(), nil)
+				m.md.On("UpdateDeck", mock.Anything, mock.Anything).Once().Return(nil)
+			},
+			req:        deck.DiscardCardsRequest{RoomID: "room-id", UserID: "user-id", DeckID: "deck-id"},
+			expDiscard: []string{"A", "B"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := newMocks()
+			test.mock(m)
+			svc := newService(t, m, t0)
+
+			gotResp, err := svc.DiscardCards(context.TODO(), test.req)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				assert.Equal(test.expDiscard, gotResp.Deck.DiscardPile)
+				assert.Empty(gotResp.Deck.InPlay())
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestServiceReshuffleDeck(t *testing.T) {
+	t0 := time.Now().UTC()
+	u := &model.User{ID: "user-id", Name: "Bilbo", RoomID: "room-id"}
+
+	tests := map[string]struct {
+		mock    func(m mocks)
+		req     deck.ReshuffleDeckRequest
+		expDeck func() model.Deck
+		expErr  error
+	}{
+		"Reshuffling a deck on an archived room, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id", Archived: true}, nil)
+			},
+			req:    deck.ReshuffleDeckRequest{RoomID: "room-id", UserID: "user-id", DeckID: "deck-id"},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Reshuffling a deck should shuffle the discarded cards into the draw pile.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.md.On("GetDeck", mock.Anything, "deck-id").Once().Return(getDeck(), nil)
+				exp := getDeck()
+				exp.DrawPile = []string{"A", "C", "D", "E"}
+				exp.DiscardPile = []string{}
+				m.md.On("UpdateDeck", mock.Anything, *exp).Once().Return(nil)
+			},
+			req: deck.ReshuffleDeckRequest{RoomID: "room-id", UserID: "user-id", DeckID: "deck-id"},
+			expDeck: func() model.Deck {
+				d := getDeck()
+				d.DrawPile = []string{"A", "C", "D", "E"}
+				d.DiscardPile = []string{}
+				d.Version = 5
+				return *d
+			},
+		},
+
+		"Reshuffling all the deck should shuffle all the cards into the draw pile.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.md.On("GetDeck", mock.Anything, "deck-id").Once().Return(getDeck(), nil)
+				m.md.On("UpdateDeck", mock.Anything, mock.Anything).Once().Return(nil)
+			},
+			req: deck.ReshuffleDeckRequest{RoomID: "room-id", UserID: "user-id", DeckID: "deck-id", All: true},
+			expDeck: func() model.Deck {
+				d := getDeck()
+				d.DrawPile = []string{"E", "D", "C", "B", "A"}
+				d.DiscardPile = []string{}
+				d.Version = 5
+				return *d
+			},
+		},
+
+		"Having an error while storing the deck, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.md.On("GetDeck", mock.Anything, "deck-id").Once().Return(getDeck(), nil)
+				m.md.On("UpdateDeck", mock.Anything, mock.Anything).Once().Return(internalerrors.ErrMissing)
+			},
+			req:    deck.ReshuffleDeckRequest{RoomID: "room-id", UserID: "user-id", DeckID: "deck-id"},
+			expErr: internalerrors.ErrMissing,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := newMocks()
+			test.mock(m)
+			svc := newService(t, m, t0)
+
+			gotResp, err := svc.ReshuffleDeck(context.TODO(), test.req)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				assert.Equal(test.expDeck(), gotResp.Deck)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
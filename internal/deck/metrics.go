@@ -0,0 +1,74 @@
+package deck
+
+import (
+	"context"
+	"time"
+)
+
+// ServiceMetricsRecorder knows how to record Service metrics.
+type ServiceMetricsRecorder interface {
+	MeasureDeckServiceOpDuration(ctx context.Context, op string, success bool, t time.Duration)
+}
+
+//go:generate mockery --case underscore --output deckmock --outpkg deckmock --name ServiceMetricsRecorder
+
+type measuredService struct {
+	rec  ServiceMetricsRecorder
+	next Service
+}
+
+// NewMeasureService wraps a service and measures.
+func NewMeasureService(rec ServiceMetricsRecorder, next Service) Service {
+	return &measuredService{
+		rec:  rec,
+		next: next,
+	}
+}
+
+func (m measuredService) CreateDeck(ctx context.Context, req CreateDeckRequest) (resp *CreateDeckResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureDeckServiceOpDuration(ctx, "CreateDeck", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.CreateDeck(ctx, req)
+}
+
+func (m measuredService) ListDecks(ctx context.Context, req ListDecksRequest) (resp *ListDecksResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureDeckServiceOpDuration(ctx, "ListDecks", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.ListDecks(ctx, req)
+}
+
+func (m measuredService) DrawCards(ctx context.Context, req DrawCardsRequest) (resp *DrawCardsResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureDeckServiceOpDuration(ctx, "DrawCards", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.DrawCards(ctx, req)
+}
+
+func (m measuredService) DiscardCards(ctx context.Context, req DiscardCardsRequest) (resp *DiscardCardsResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureDeckServiceOpDuration(ctx, "DiscardCards", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.DiscardCards(ctx, req)
+}
+
+func (m measuredService) ReshuffleDeck(ctx context.Context, req ReshuffleDeckRequest) (resp *ReshuffleDeckResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureDeckServiceOpDuration(ctx, "ReshuffleDeck", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.ReshuffleDeck(ctx, req)
+}
+
+func (m measuredService) SubscribeCardsDrawn(ctx context.Context, req SubscribeCardsDrawnRequest) (resp *SubscribeCardsDrawnResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureDeckServiceOpDuration(ctx, "SubscribeCardsDrawn", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.SubscribeCardsDrawn(ctx, req)
+}
@@ -0,0 +1,48 @@
+package deck
+
+import (
+	"context"
+	cryptorand "crypto/rand"
+	"fmt"
+	"io"
+
+	"github.com/rollify/rollify/internal/random"
+)
+
+// Shuffler knows how to shuffle cards.
+type Shuffler interface {
+	Shuffle(ctx context.Context, cards []string) error
+}
+
+//go:generate mockery --case underscore --output deckmock --outpkg deckmock --name Shuffler
+
+// ShufflerFunc is a helper type to create shufflers from funcs.
+type ShufflerFunc func(ctx context.Context, cards []string) error
+
+// Shuffle satisfies Shuffler interface.
+func (f ShufflerFunc) Shuffle(ctx context.Context, cards []string) error { return f(ctx, cards) }
+
+var _ Shuffler = ShufflerFunc(nil)
+
+type cryptoShuffler struct {
+	rand io.Reader
+}
+
+// NewCryptoShuffler returns a shuffler that shuffles the cards in place with the Fisher-Yates
+// algorithm using a cryptographically secure random number generator.
+func NewCryptoShuffler() Shuffler {
+	return cryptoShuffler{rand: cryptorand.Reader}
+}
+
+func (c cryptoShuffler) Shuffle(ctx context.Context, cards []string) error {
+	for i := len(cards) - 1; i > 0; i-- {
+		// Rejection sampling, a plain modulo would favor the first positions.
+		j, err := random.Uint(c.rand, uint64(i+1))
+		if err != nil {
+			return fmt.Errorf("could not shuffle cards: %w", err)
+		}
+		cards[i], cards[j] = cards[j], cards[i]
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package deck_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/deck"
+	"github.com/rollify/rollify/internal/model"
+)
+
+func TestCryptoShuffler(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	cards := model.DeckTypeTarot.Cards()
+	shuffled := slices.Clone(cards)
+
+	s := deck.NewCryptoShuffler()
+	err := s.Shuffle(context.TODO(), shuffled)
+	require.NoError(err)
+
+	// The shuffled deck should have the same cards, in a different order.
+	assert.NotEqual(cards, shuffled)
+	slices.Sort(cards)
+	slices.Sort(shuffled)
+	assert.Equal(cards, shuffled)
+}
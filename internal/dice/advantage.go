@@ -0,0 +1,86 @@
+package dice
+
+import (
+	"fmt"
+
+	"github.com/rollify/rollify/internal/model"
+)
+
+// AdvantageMode rolls the D20 twice and keeps the higher (advantage) or the lower (disadvantage)
+// result (e.g: D&D 5e), the zero value rolls the D20 once.
+type AdvantageMode int
+
+const (
+	// AdvantageModeNone rolls the D20 once.
+	AdvantageModeNone AdvantageMode = iota
+	// AdvantageModeAdvantage rolls an extra D20 and keeps the higher one.
+	AdvantageModeAdvantage
+	// AdvantageModeDisadvantage rolls an extra D20 and keeps the lower one.
+	AdvantageModeDisadvantage
+)
+
+func (a AdvantageMode) validate(dice []model.DieType) error {
+	switch a {
+	case AdvantageModeNone:
+		return nil
+	case AdvantageModeAdvantage, AdvantageModeDisadvantage:
+	default:
+		return fmt.Errorf("config.Advantage %d is not valid", a)
+	}
+
+	d20s := 0
+	for _, d := range dice {
+		if d.ID() == model.DieTypeD20.ID() {
+			d20s++
+		}
+	}
+	if d20s != 1 {
+		return fmt.Errorf("config.Advantage requires exactly one D20 on config.Dice, got %d", d20s)
+	}
+
+	return nil
+}
+
+// addAdvantageDie adds the extra D20 next to the dice roll D20.
+func (a AdvantageMode) addAdvantageDie(dice []model.DieRoll, idGen func() string) []model.DieRoll {
+	if a == AdvantageModeNone {
+		return dice
+	}
+
+	res := make([]model.DieRoll, 0, len(dice)+1)
+	for _, d := range dice {
+		res = append(res, d)
+		if d.Type.ID() == model.DieTypeD20.ID() {
+			res = append(res, model.DieRoll{ID: idGen(), Type: d.Type})
+		}
+	}
+
+	return res
+}
+
+// apply drops the D20 that doesn't count, on a tie the extra D20 is dropped.
+func (a AdvantageMode) apply(dice []model.DieRoll) {
+	if a == AdvantageModeNone {
+		return
+	}
+
+	var d20s []int
+	for i, d := range dice {
+		if d.Type.ID() == model.DieTypeD20.ID() && !d.Discarded() {
+			d20s = append(d20s, i)
+		}
+	}
+	if len(d20s) != 2 {
+		return
+	}
+
+	first, extra := d20s[0], d20s[1]
+	drop := extra
+	switch {
+	case a == AdvantageModeAdvantage && dice[extra].Side > dice[first].Side:
+		drop = first
+	case a == AdvantageModeDisadvantage && dice[extra].Side < dice[first].Side:
+		drop = first
+	}
+	dice[drop].Status = model.DieRollStatusDropped
+}
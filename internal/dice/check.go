@@ -0,0 +1,100 @@
+package dice
+
+import (
+	"fmt"
+
+	"github.com/rollify/rollify/internal/model"
+)
+
+// DiceCheck is the difficulty the dice roll total is checked against (e.g: DC 15), the zero value
+// means the dice roll is not a difficulty check.
+type DiceCheck struct {
+	// Target is the difficulty the total is compared with.
+	Target int
+	// Comparison is how the total is compared with the target.
+	Comparison model.DiceRollCheckComparison
+}
+
+const maxCheckTarget = 10000
+
+func (c DiceCheck) validate() error {
+	if c.Comparison == 0 {
+		if c != (DiceCheck{}) {
+			return fmt.Errorf("config.Check.Comparison is required")
+		}
+		return nil
+	}
+
+	switch c.Comparison {
+	case model.DiceRollCheckComparisonGreaterOrEqual,
+		model.DiceRollCheckComparisonGreater,
+		model.DiceRollCheckComparisonLessOrEqual,
+		model.DiceRollCheckComparisonLess:
+	default:
+		return fmt.Errorf("config.Check.Comparison %d is not valid", c.Comparison)
+	}
+
+	if c.Target > maxCheckTarget || c.Target < -maxCheckTarget {
+		return fmt.Errorf("config.Check.Target must be between -%d and %d, got %d", maxCheckTarget, maxCheckTarget, c.Target)
+	}
+
+	return nil
+}
+
+// evaluate compares the dice roll total with the target and returns the outcome. Getting the max
+// side on all the dice that are not discarded (e.g: a natural 20) is a critical success, and getting
+// a 1 on all of them a critical failure, regardless of the total. On roll-under checks the natural
+// max and min are swapped.
+func (c DiceCheck) evaluate(total int, dice []model.DieRoll) model.DiceRollCheck {
+	res := model.DiceRollCheck{Target: c.Target, Comparison: c.Comparison}
+
+	rollUnder := c.Comparison == model.DiceRollCheckComparisonLessOrEqual || c.Comparison == model.DiceRollCheckComparisonLess
+	var success bool
+	switch c.Comparison {
+	case model.DiceRollCheckComparisonGreaterOrEqual:
+		res.Margin = total - c.Target
+		success = res.Margin >= 0
+	case model.DiceRollCheckComparisonGreater:
+		res.Margin = total - c.Target
+		success = res.Margin > 0
+	case model.DiceRollCheckComparisonLessOrEqual:
+		res.Margin = c.Target - total
+		success = res.Margin >= 0
+	case model.DiceRollCheckComparisonLess:
+		res.Margin = c.Target - total
+		success = res.Margin > 0
+	}
+
+	var kept, maxs, mins int
+	for _, d := range dice {
+		if d.Discarded() {
+			continue
+		}
+
+		kept++
+		if d.Side == d.Type.Sides() {
+			maxs++
+		}
+		if d.Side == 1 {
+			mins++
+		}
+	}
+	naturalMax := kept > 0 && maxs == kept
+	naturalMin := kept > 0 && mins == kept
+	if rollUnder {
+		naturalMax, naturalMin = naturalMin, naturalMax
+	}
+
+	switch {
+	case naturalMax:
+		res.Outcome = model.DiceRollCheckOutcomeCriticalSuccess
+	case naturalMin:
+		res.Outcome = model.DiceRollCheckOutcomeCriticalFailure
+	case success:
+		res.Outcome = model.DiceRollCheckOutcomeSuccess
+	default:
+		res.Outcome = model.DiceRollCheckOutcomeFailure
+	}
+
+	return res
+}
@@ -2,43 +2,75 @@ package dice
 
 import (
 	"context"
+	cryptorand "crypto/rand"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 
+	"github.com/rollify/rollify/internal/dice/notation"
 	"github.com/rollify/rollify/internal/event"
 	"github.com/rollify/rollify/internal/internalerrors"
 	"github.com/rollify/rollify/internal/log"
 	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/ratelimit"
+	"github.com/rollify/rollify/internal/room/roomcheck"
 	"github.com/rollify/rollify/internal/storage"
 )
 
 // Service is the application service of dice logic.
 type Service interface {
-	// ListDiceTypes lists all the dice types supported by the app.
-	ListDiceTypes(ctx context.Context) (*ListDiceTypesResponse, error)
+	// ListDiceTypes lists all the dice types supported by the app, and the custom ones of a room.
+	ListDiceTypes(ctx context.Context, r ListDiceTypesRequest) (*ListDiceTypesResponse, error)
+	// CreateCustomDieType registers a custom die type with symbolic faces on a room.
+	CreateCustomDieType(ctx context.Context, r CreateCustomDieTypeRequest) (*CreateCustomDieTypeResponse, error)
 	// CreateDiceRoll creates dice rolls.
 	CreateDiceRoll(ctx context.Context, r CreateDiceRollRequest) (*CreateDiceRollResponse, error)
+	// CreateDiceRolls creates multiple independent dice rolls at once, all or none of them are created.
+	CreateDiceRolls(ctx context.Context, r CreateDiceRollsRequest) (*CreateDiceRollsResponse, error)
+	// CreateDiceRollSequence creates dice rolls that depend on the previous ones (e.g: nested oracle
+	// tables), all of them are rolled before creating them at once, all or none of them are created.
+	CreateDiceRollSequence(ctx context.Context, r CreateDiceRollSequenceRequest) (*CreateDiceRollSequenceResponse, error)
 	// ListDiceRolls lists dice rolls.
 	ListDiceRolls(ctx context.Context, r ListDiceRollsRequest) (*ListDiceRollsResponse, error)
 	// Subscribes to a diceroll created events
 	SubscribeDiceRollCreated(ctx context.Context, r SubscribeDiceRollCreatedRequest) (*SubscribeDiceRollCreatedResponse, error)
+	// GetRoomServerSeed returns the public information of the room active server seed used on provably fair dice rolls.
+	GetRoomServerSeed(ctx context.Context, r GetRoomServerSeedRequest) (*GetRoomServerSeedResponse, error)
+	// RotateRoomServerSeed reveals the room active server seed and replaces it with a new one, only
+	// the room game master can rotate it.
+	RotateRoomServerSeed(ctx context.Context, r RotateRoomServerSeedRequest) (*RotateRoomServerSeedResponse, error)
+	// VerifyDiceRoll recomputes a provably fair dice roll and checks it matches the stored one.
+	VerifyDiceRoll(ctx context.Context, r VerifyDiceRollRequest) (*VerifyDiceRollResponse, error)
+	// RerollDice rerolls some dice of a dice roll into a new dice roll linked to the original one.
+	RerollDice(ctx context.Context, r RerollDiceRequest) (*RerollDiceResponse, error)
+	// CalculateProbabilities calculates the probability distribution of a dice roll total without rolling it.
+	CalculateProbabilities(ctx context.Context, r CalculateProbabilitiesRequest) (*CalculateProbabilitiesResponse, error)
 }
 
 //go:generate mockery --case underscore --output dicemock --outpkg dicemock --name Service
 
 // ServiceConfig is the service configuration.
 type ServiceConfig struct {
-	DiceRollRepository storage.DiceRollRepository
-	RoomRepository     storage.RoomRepository
-	UserRepository     storage.UserRepository
-	Roller             Roller
-	EventNotifier      event.Notifier
-	EventSubscriber    event.Subscriber
-	Logger             log.Logger
-	IDGenerator        func() string
-	TimeNowFunc        func() time.Time
+	DiceRollRepository      storage.DiceRollRepository
+	RoomRepository          storage.RoomRepository
+	UserRepository          storage.UserRepository
+	CustomDieTypeRepository storage.CustomDieTypeRepository
+	ServerSeedRepository    storage.ServerSeedRepository
+	Roller                  Roller
+	EventNotifier           event.Notifier
+	EventSubscriber         event.Subscriber
+	// UserRateLimiter limits the dice rolls made by each user, by default unlimited.
+	UserRateLimiter ratelimit.Limiter
+	// RoomRateLimiter limits the dice rolls made on each room, by default unlimited.
+	RoomRateLimiter ratelimit.Limiter
+	Logger          log.Logger
+	IDGenerator     func() string
+	TimeNowFunc     func() time.Time
 }
 
 func (c *ServiceConfig) defaults() error {
@@ -54,6 +86,14 @@ func (c *ServiceConfig) defaults() error {
 		return fmt.Errorf("storage.UserRepository is required")
 	}
 
+	if c.CustomDieTypeRepository == nil {
+		return fmt.Errorf("storage.CustomDieTypeRepository is required")
+	}
+
+	if c.ServerSeedRepository == nil {
+		return fmt.Errorf("storage.ServerSeedRepository is required")
+	}
+
 	if c.Roller == nil {
 		return fmt.Errorf("dice.Roller is required")
 	}
@@ -66,6 +106,14 @@ func (c *ServiceConfig) defaults() error {
 		return fmt.Errorf("event.Subscriber is required")
 	}
 
+	if c.UserRateLimiter == nil {
+		c.UserRateLimiter = ratelimit.Noop
+	}
+
+	if c.RoomRateLimiter == nil {
+		c.RoomRateLimiter = ratelimit.Noop
+	}
+
 	if c.Logger == nil {
 		c.Logger = log.Dummy
 	}
@@ -83,15 +131,20 @@ func (c *ServiceConfig) defaults() error {
 }
 
 type service struct {
-	diceRollRepository storage.DiceRollRepository
-	roomRepository     storage.RoomRepository
-	userRepository     storage.UserRepository
-	roller             Roller
-	eventNotifier      event.Notifier
-	eventSubscriber    event.Subscriber
-	logger             log.Logger
-	idGen              func() string
-	timeNow            func() time.Time
+	diceRollRepository      storage.DiceRollRepository
+	roomRepository          storage.RoomRepository
+	userRepository          storage.UserRepository
+	customDieTypeRepository storage.CustomDieTypeRepository
+	serverSeedRepository    storage.ServerSeedRepository
+	roller                  Roller
+	eventNotifier           event.Notifier
+	eventSubscriber         event.Subscriber
+	userRateLimiter         ratelimit.Limiter
+	roomRateLimiter         ratelimit.Limiter
+	logger                  log.Logger
+	idGen                   func() string
+	timeNow                 func() time.Time
+	serverSeeds             serverSeedManager
 }
 
 // NewService returns a new dice.Service.
@@ -102,33 +155,134 @@ func NewService(cfg ServiceConfig) (Service, error) {
 	}
 
 	return service{
-		diceRollRepository: cfg.DiceRollRepository,
-		roomRepository:     cfg.RoomRepository,
-		userRepository:     cfg.UserRepository,
-		roller:             cfg.Roller,
-		eventNotifier:      cfg.EventNotifier,
-		eventSubscriber:    cfg.EventSubscriber,
-		logger:             cfg.Logger,
-		idGen:              cfg.IDGenerator,
-		timeNow:            cfg.TimeNowFunc,
+		diceRollRepository:      cfg.DiceRollRepository,
+		roomRepository:          cfg.RoomRepository,
+		userRepository:          cfg.UserRepository,
+		customDieTypeRepository: cfg.CustomDieTypeRepository,
+		serverSeedRepository:    cfg.ServerSeedRepository,
+		roller:                  cfg.Roller,
+		eventNotifier:           cfg.EventNotifier,
+		eventSubscriber:         cfg.EventSubscriber,
+		userRateLimiter:         cfg.UserRateLimiter,
+		roomRateLimiter:         cfg.RoomRateLimiter,
+		logger:                  cfg.Logger,
+		idGen:                   cfg.IDGenerator,
+		timeNow:                 cfg.TimeNowFunc,
+		serverSeeds: serverSeedManager{
+			repo:    cfg.ServerSeedRepository,
+			idGen:   cfg.IDGenerator,
+			timeNow: cfg.TimeNowFunc,
+			rand:    cryptorand.Reader,
+		},
 	}, nil
 }
 
+// ListDiceTypesRequest is the request for ListDiceTypes.
+type ListDiceTypesRequest struct {
+	// RoomID is optional, if set the custom die types of the room will be listed also.
+	RoomID string
+}
+
 // ListDiceTypesResponse is the response for ListDiceTypes.
 type ListDiceTypesResponse struct {
 	DiceTypes []model.DieType
 }
 
-func (s service) ListDiceTypes(ctx context.Context) (*ListDiceTypesResponse, error) {
+func (s service) ListDiceTypes(ctx context.Context, r ListDiceTypesRequest) (*ListDiceTypesResponse, error) {
+	if r.RoomID == "" {
+		return &ListDiceTypesResponse{
+			DiceTypes: model.DiceTypes,
+		}, nil
+	}
+
+	cdts, err := s.customDieTypeRepository.ListRoomCustomDieTypes(ctx, r.RoomID)
+	if err != nil {
+		return nil, fmt.Errorf("could not list room custom die types: %w", err)
+	}
+
+	dts := make([]model.DieType, 0, len(model.DiceTypes)+len(cdts.Items))
+	dts = append(dts, model.DiceTypes...)
+	for _, dt := range cdts.Items {
+		dts = append(dts, dt)
+	}
+
 	return &ListDiceTypesResponse{
-		DiceTypes: []model.DieType{
-			model.DieTypeD4,
-			model.DieTypeD6,
-			model.DieTypeD8,
-			model.DieTypeD10,
-			model.DieTypeD12,
-			model.DieTypeD20,
-		},
+		DiceTypes: dts,
+	}, nil
+}
+
+// CreateCustomDieTypeRequest is the request for CreateCustomDieType.
+type CreateCustomDieTypeRequest struct {
+	RoomID string
+	Name   string
+	// Faces are the faces of the die, the first face is the side 1, the second the side 2...
+	Faces []model.DieFace
+}
+
+// Custom die type limits.
+const (
+	maxCustomDieTypeNameLength      = 50
+	maxCustomDieTypeFaces           = 100
+	maxCustomDieTypeFaceLabelLength = 20
+)
+
+func (r CreateCustomDieTypeRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	if r.Name == "" {
+		return fmt.Errorf("config.Name is required")
+	}
+
+	if len(r.Name) > maxCustomDieTypeNameLength {
+		return fmt.Errorf("config.Name max length is %d", maxCustomDieTypeNameLength)
+	}
+
+	if len(r.Faces) < 2 || len(r.Faces) > maxCustomDieTypeFaces {
+		return fmt.Errorf("config.Faces quantity must be between 2 and %d, got %d", maxCustomDieTypeFaces, len(r.Faces))
+	}
+
+	for i, f := range r.Faces {
+		if len(f.Label) > maxCustomDieTypeFaceLabelLength {
+			return fmt.Errorf("config.Faces[%d] label max length is %d", i, maxCustomDieTypeFaceLabelLength)
+		}
+	}
+
+	return nil
+}
+
+// CreateCustomDieTypeResponse is the response for CreateCustomDieType.
+type CreateCustomDieTypeResponse struct {
+	DieType model.CustomDieType
+}
+
+func (s service) CreateCustomDieType(ctx context.Context, r CreateCustomDieTypeRequest) (*CreateCustomDieTypeResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	// Check the room exists and accepts changes.
+	err = roomcheck.Writable(ctx, s.roomRepository, r.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	dt := model.CustomDieType{
+		TypeID:    s.idGen(),
+		RoomID:    r.RoomID,
+		TypeName:  r.Name,
+		FaceTable: r.Faces,
+	}
+
+	err = s.customDieTypeRepository.CreateCustomDieType(ctx, dt)
+	if err != nil {
+		return nil, fmt.Errorf("could not store custom die type: %w", err)
+	}
+
+	return &CreateCustomDieTypeResponse{
+		DieType: dt,
 	}, nil
 }
 
@@ -136,9 +290,43 @@ func (s service) ListDiceTypes(ctx context.Context) (*ListDiceTypesResponse, err
 type CreateDiceRollRequest struct {
 	UserID string
 	RoomID string
-	Dice   []model.DieType
+	// Dice are the dice to roll, custom die types must be registered on the room.
+	Dice []model.DieType
+	// Expression is a dice notation expression (e.g: `4d6kh3`), if set, Dice must be empty.
+	Expression string
+	// Modifiers are the mechanics applied to the rolled dice, can't be used with Expression
+	// (use the expression keep/drop selectors instead).
+	Modifiers DiceRollModifiers
+	// Pool makes the dice roll a success-counting dice pool, the total will be the successes
+	// instead of the sum of the dice, can't be used with Expression.
+	Pool DicePool
+	// Check compares the dice roll total with a difficulty and stores the outcome, optional and
+	// can't be used with Pool.
+	Check DiceCheck
+	// Advantage rolls an extra D20 and keeps the higher or lower one, requires exactly one D20
+	// on Dice and can't be used with Expression (use `2d20kh1` instead), Modifiers nor Pool.
+	Advantage AdvantageMode
+	// Label is a free-text description of the dice roll (e.g: `Longsword damage`), optional.
+	Label string
+	// Modifier is a flat bonus (or penalty if negative) added to the dice total, can't be used
+	// with Expression (use the expression constants instead) nor Pool.
+	Modifier int
+	// ClientSeed is mixed with the server seed on provably fair dice rolls, optional.
+	ClientSeed string
+	// Visibility is who can see the dice roll, by default public.
+	Visibility model.DiceRollVisibility
+	// WhisperUserIDs are the room users the dice roll is whispered to, required (and only
+	// allowed) on whispered dice rolls.
+	WhisperUserIDs []string
 }
 
+const (
+	maxDiceQuantity     = 100
+	maxClientSeedLength = 64
+	maxDiceRollModifier = 1000
+	maxLabelLength      = 100
+)
+
 func (r CreateDiceRollRequest) validate() error {
 	if r.RoomID == "" {
 		return fmt.Errorf("config.RoomID is required")
@@ -148,17 +336,127 @@ func (r CreateDiceRollRequest) validate() error {
 		return fmt.Errorf("config.UserID is required")
 	}
 
+	if len(r.ClientSeed) > maxClientSeedLength {
+		return fmt.Errorf("max config.ClientSeed length is %d, got %d", maxClientSeedLength, len(r.ClientSeed))
+	}
+
+	if utf8.RuneCountInString(r.Label) > maxLabelLength {
+		return fmt.Errorf("max config.Label length is %d", maxLabelLength)
+	}
+
+	switch r.Visibility {
+	case model.DiceRollVisibilityPublic, model.DiceRollVisibilityGameMaster:
+		if len(r.WhisperUserIDs) > 0 {
+			return fmt.Errorf("config.WhisperUserIDs can only be used on whispered dice rolls")
+		}
+	case model.DiceRollVisibilityWhisper:
+		if len(r.WhisperUserIDs) == 0 {
+			return fmt.Errorf("config.WhisperUserIDs are required on whispered dice rolls")
+		}
+	default:
+		return fmt.Errorf("config.Visibility %d is not valid", r.Visibility)
+	}
+
+	return r.validateDefinition()
+}
+
+// validateDefinition validates what is rolled (dice, expression and mechanics), regardless of who
+// rolls it.
+func (r CreateDiceRollRequest) validateDefinition() error {
+	err := r.Check.validate()
+	if err != nil {
+		return err
+	}
+
+	if r.Expression != "" {
+		if len(r.Dice) != 0 {
+			return fmt.Errorf("config.Dice and config.Expression can't be used at the same time")
+		}
+		if r.Modifiers != (DiceRollModifiers{}) {
+			return fmt.Errorf("config.Modifiers and config.Expression can't be used at the same time")
+		}
+		if r.Pool != (DicePool{}) {
+			return fmt.Errorf("config.Pool and config.Expression can't be used at the same time")
+		}
+		if r.Modifier != 0 {
+			return fmt.Errorf("config.Modifier and config.Expression can't be used at the same time")
+		}
+		if r.Advantage != AdvantageModeNone {
+			return fmt.Errorf("config.Advantage and config.Expression can't be used at the same time")
+		}
+		return nil
+	}
+
 	if len(r.Dice) == 0 {
 		return fmt.Errorf("minimum config.Dice quantity is 1")
 	}
 
-	if len(r.Dice) > 100 {
-		return fmt.Errorf("max config.Dice quantity is 100, got %d", len(r.Dice))
+	if len(r.Dice) > maxDiceQuantity {
+		return fmt.Errorf("max config.Dice quantity is %d, got %d", maxDiceQuantity, len(r.Dice))
+	}
+
+	err = r.Modifiers.validate(len(r.Dice))
+	if err != nil {
+		return err
+	}
+
+	err = r.Pool.validate()
+	if err != nil {
+		return err
+	}
+
+	err = r.Advantage.validate(r.Dice)
+	if err != nil {
+		return err
+	}
+
+	if r.Advantage != AdvantageModeNone && r.Modifiers != (DiceRollModifiers{}) {
+		return fmt.Errorf("config.Advantage and config.Modifiers can't be used at the same time")
+	}
+
+	if r.Advantage != AdvantageModeNone && r.Pool != (DicePool{}) {
+		return fmt.Errorf("config.Advantage and config.Pool can't be used at the same time")
+	}
+
+	if r.Modifier != 0 && r.Pool != (DicePool{}) {
+		return fmt.Errorf("config.Modifier and config.Pool can't be used at the same time")
+	}
+
+	if r.Check != (DiceCheck{}) && r.Pool != (DicePool{}) {
+		return fmt.Errorf("config.Check and config.Pool can't be used at the same time")
+	}
+
+	if r.Modifier > maxDiceRollModifier || r.Modifier < -maxDiceRollModifier {
+		return fmt.Errorf("config.Modifier must be between -%d and %d, got %d", maxDiceRollModifier, maxDiceRollModifier, r.Modifier)
 	}
 
 	return nil
 }
 
+// parseExpression parses the request dice notation expression and checks the
+// dice of the expression are supported.
+func (r CreateDiceRollRequest) parseExpression() (*notation.Expression, error) {
+	exp, err := notation.Parse(r.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config.Expression: %w", err)
+	}
+
+	var q uint
+	for _, d := range exp.Dice() {
+		_, err := model.NewDieType(d.Sides)
+		if err != nil {
+			return nil, fmt.Errorf("invalid config.Expression: %w", err)
+		}
+		q += d.Quantity
+	}
+
+	if q > maxDiceQuantity {
+		return nil, fmt.Errorf("max config.Expression dice quantity is %d, got %d", maxDiceQuantity, q)
+	}
+
+	return exp, nil
+}
+
 // CreateDiceRollResponse is the response for CreateDiceRoll.
 type CreateDiceRollResponse struct {
 	DiceRoll model.DiceRoll
@@ -170,13 +468,23 @@ func (s service) CreateDiceRoll(ctx context.Context, r CreateDiceRollRequest) (*
 		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
 	}
 
-	// Check the room exists.
-	roomExists, err := s.roomRepository.RoomExists(ctx, r.RoomID)
+	var exp *notation.Expression
+	if r.Expression != "" {
+		exp, err = r.parseExpression()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+		}
+	}
+
+	err = s.checkDiceRollRate(ctx, r.RoomID, r.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("could not check if room exists: %w", err)
+		return nil, err
 	}
-	if !roomExists {
-		return nil, fmt.Errorf("room does not exists: %w", internalerrors.ErrNotValid)
+
+	// Check the room exists and accepts changes.
+	err = roomcheck.Writable(ctx, s.roomRepository, r.RoomID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Check the user exists.
@@ -188,27 +496,17 @@ func (s service) CreateDiceRoll(ctx context.Context, r CreateDiceRollRequest) (*
 		return nil, fmt.Errorf("user does not exists: %w", internalerrors.ErrNotValid)
 	}
 
-	// Create a dice roll.
-	dice := []model.DieRoll{}
-	for _, d := range r.Dice {
-		dice = append(dice, model.DieRoll{
-			ID:   s.idGen(),
-			Type: d,
-		})
-	}
-
-	dr := &model.DiceRoll{
-		ID:        s.idGen(),
-		CreatedAt: s.timeNow().UTC(),
-		RoomID:    r.RoomID,
-		UserID:    r.UserID,
-		Dice:      dice,
+	// Check the whispered users are from the room.
+	if len(r.WhisperUserIDs) > 0 {
+		err := s.checkRoomUsers(ctx, r.RoomID, r.WhisperUserIDs)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// Roll'em all!
-	err = s.roller.Roll(ctx, dr)
+	dr, err := s.newDiceRoll(ctx, r, exp)
 	if err != nil {
-		return nil, fmt.Errorf("could not roll the dice: %w", err)
+		return nil, err
 	}
 
 	// Store the dice roll.
@@ -230,105 +528,1175 @@ func (s service) CreateDiceRoll(ctx context.Context, r CreateDiceRollRequest) (*
 	}, nil
 }
 
-// ListDiceRollsRequest is the request for ListDiceRolls.
-type ListDiceRollsRequest struct {
-	UserID   string
-	RoomID   string
-	PageOpts model.PaginationOpts
+// newDiceRoll rolls a new dice roll of an already validated request.
+func (s service) newDiceRoll(ctx context.Context, r CreateDiceRollRequest, exp *notation.Expression) (*model.DiceRoll, error) {
+	// Use the room registered custom die types.
+	dieTypes, err := s.roomDieTypes(ctx, r.RoomID, r.Dice)
+	if err != nil {
+		return nil, err
+	}
+
+	// The client seed is given to the roller using the proof, only the provably
+	// fair rollers will complete it.
+	var proof *model.DiceRollProof
+	if r.ClientSeed != "" {
+		proof = &model.DiceRollProof{ClientSeed: r.ClientSeed}
+	}
+
+	// Create a dice roll.
+	var dr *model.DiceRoll
+	if exp != nil {
+		dr = &model.DiceRoll{
+			ID:         s.idGen(),
+			CreatedAt:  s.timeNow().UTC(),
+			RoomID:     r.RoomID,
+			UserID:     r.UserID,
+			Dice:       []model.DieRoll{},
+			Expression: strings.TrimSpace(r.Expression),
+			Label:      strings.TrimSpace(r.Label),
+			Proof:      proof,
+		}
+
+		// Roll'em all!
+		err = s.rollExpression(ctx, dr, exp)
+		if err != nil {
+			return nil, fmt.Errorf("could not roll the dice: %w", err)
+		}
+	} else {
+		dice := []model.DieRoll{}
+		for _, d := range dieTypes {
+			dice = append(dice, model.DieRoll{
+				ID:   s.idGen(),
+				Type: d,
+			})
+		}
+
+		dr = &model.DiceRoll{
+			ID:        s.idGen(),
+			CreatedAt: s.timeNow().UTC(),
+			RoomID:    r.RoomID,
+			UserID:    r.UserID,
+			Dice:      r.Advantage.addAdvantageDie(dice, s.idGen),
+			Label:     strings.TrimSpace(r.Label),
+			Modifier:  r.Modifier,
+			Proof:     proof,
+		}
+
+		// Roll'em all!
+		err = s.rollWithModifiers(ctx, dr, r.Modifiers)
+		if err != nil {
+			return nil, fmt.Errorf("could not roll the dice: %w", err)
+		}
+		r.Advantage.apply(dr.Dice)
+		setDiceRollTotal(dr, r.Pool)
+	}
+
+	if r.Check != (DiceCheck{}) {
+		check := r.Check.evaluate(dr.Total, dr.Dice)
+		dr.Check = &check
+	}
+
+	// Not provably fair rollers ignore the proof.
+	if dr.Proof != nil && dr.Proof.ServerSeedID == "" {
+		dr.Proof = nil
+	}
+
+	dr.Visibility = r.Visibility
+	if len(r.WhisperUserIDs) > 0 {
+		ids := slices.Clone(r.WhisperUserIDs)
+		slices.Sort(ids)
+		dr.WhisperUserIDs = slices.Compact(ids)
+	}
+
+	return dr, nil
 }
 
-func (r ListDiceRollsRequest) validate() error {
-	if r.RoomID == "" {
-		return fmt.Errorf("config.RoomID is required")
+// setDiceRollTotal sets the total of the rolled dice, the successes on dice pools, otherwise the
+// sum of the dice that are not discarded and the modifier.
+func setDiceRollTotal(dr *model.DiceRoll, pool DicePool) {
+	if pool.Target > 0 {
+		res := pool.evaluate(dr.Dice)
+		dr.Pool = &res
+		dr.Total = res.Successes
+		return
+	}
+
+	dr.Total = dr.Modifier
+	for _, d := range dr.Dice {
+		if !d.Discarded() {
+			dr.Total += d.Value()
+		}
+	}
+}
+
+// CreateDiceRollsRequest is the request for CreateDiceRolls.
+type CreateDiceRollsRequest struct {
+	// Items are the independent dice rolls to create in order (e.g: 8 attacks of `1d20+5`), all
+	// of them must be from the same room and user.
+	Items []CreateDiceRollRequest
+}
+
+const maxBatchDiceRolls = 50
+
+func (r CreateDiceRollsRequest) validate() error {
+	if len(r.Items) == 0 {
+		return fmt.Errorf("minimum config.Items quantity is 1")
+	}
+
+	if len(r.Items) > maxBatchDiceRolls {
+		return fmt.Errorf("max config.Items quantity is %d, got %d", maxBatchDiceRolls, len(r.Items))
+	}
+
+	for i, item := range r.Items {
+		err := item.validate()
+		if err != nil {
+			return fmt.Errorf("invalid config.Items[%d]: %w", i, err)
+		}
+
+		if item.RoomID != r.Items[0].RoomID {
+			return fmt.Errorf("config.Items[%d] must be from the same room as the other items", i)
+		}
+
+		if item.UserID != r.Items[0].UserID {
+			return fmt.Errorf("config.Items[%d] must be from the same user as the other items", i)
+		}
 	}
 
 	return nil
 }
 
-// ListDiceRollsResponse is the response for ListDiceRolls.
-type ListDiceRollsResponse struct {
+// CreateDiceRollsResponse is the response for CreateDiceRolls.
+type CreateDiceRollsResponse struct {
+	// DiceRolls are the created dice rolls in the same order as the request items.
 	DiceRolls []model.DiceRoll
-	Cursors   model.PaginationCursors
 }
 
-func (s service) ListDiceRolls(ctx context.Context, r ListDiceRollsRequest) (*ListDiceRollsResponse, error) {
+func (s service) CreateDiceRolls(ctx context.Context, r CreateDiceRollsRequest) (*CreateDiceRollsResponse, error) {
 	err := r.validate()
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
 	}
 
-	// Set up pagination defaults.
-	if r.PageOpts.Size <= 0 || r.PageOpts.Size > 100 {
-		r.PageOpts.Size = 100
+	exps := make([]*notation.Expression, len(r.Items))
+	whisperUserIDs := []string{}
+	for i, item := range r.Items {
+		if item.Expression != "" {
+			exps[i], err = item.parseExpression()
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid config.Items[%d]: %s", internalerrors.ErrNotValid, i, err)
+			}
+		}
+		whisperUserIDs = append(whisperUserIDs, item.WhisperUserIDs...)
 	}
-	if r.PageOpts.Order == model.PaginationOrderDefault {
-		r.PageOpts.Order = model.PaginationOrderDesc
+
+	roomID, userID := r.Items[0].RoomID, r.Items[0].UserID
+
+	// Check the room exists and accepts changes.
+	err = roomcheck.Writable(ctx, s.roomRepository, roomID)
+	if err != nil {
+		return nil, err
 	}
 
-	drs, err := s.diceRollRepository.ListDiceRolls(ctx, r.PageOpts, storage.ListDiceRollsOpts{
-		RoomID: r.RoomID,
-		UserID: r.UserID,
-	})
+	// Check the user exists.
+	userExist, err := s.userRepository.UserExists(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("could not check if user exists: %w", err)
+	}
+	if !userExist {
+		return nil, fmt.Errorf("user does not exists: %w", internalerrors.ErrNotValid)
+	}
+
+	// Check the whispered users of all the items are from the room.
+	if len(whisperUserIDs) > 0 {
+		err := s.checkRoomUsers(ctx, roomID, whisperUserIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
 
+	// Each item is a dice roll for the rate limits, all of them are allowed or none.
+	err = s.checkDiceRollsRate(ctx, roomID, userID, uint(len(r.Items)))
 	if err != nil {
-		return nil, fmt.Errorf("could not get dice roll list: %w", err)
+		return nil, err
 	}
 
-	return &ListDiceRollsResponse{
-		DiceRolls: drs.Items,
-		Cursors:   drs.Cursors,
+	drs := make([]model.DiceRoll, 0, len(r.Items))
+	for i, item := range r.Items {
+		dr, err := s.newDiceRoll(ctx, item, exps[i])
+		if err != nil {
+			return nil, err
+		}
+		drs = append(drs, *dr)
+	}
+
+	// Store all the dice rolls at once.
+	err = s.diceRollRepository.CreateDiceRolls(ctx, drs)
+	if err != nil {
+		return nil, fmt.Errorf("could not store dice rolls: %w", err)
+	}
+
+	// Send dice roll events in the same order they were requested.
+	for _, dr := range drs {
+		err = s.eventNotifier.NotifyDiceRollCreated(ctx, model.EventDiceRollCreated{DiceRoll: dr})
+		if err != nil {
+			return nil, fmt.Errorf("could not send dice roll created event: %w", err)
+		}
+	}
+
+	return &CreateDiceRollsResponse{
+		DiceRolls: drs,
 	}, nil
 }
 
-// SubscribeDiceRollCreatedRequest is the request for SubscribeDiceRollCreated.
-type SubscribeDiceRollCreatedRequest struct {
-	RoomID       string
-	EventHandler func(context.Context, model.EventDiceRollCreated) error
+// CreateDiceRollSequenceRequest is the request for CreateDiceRollSequence.
+type CreateDiceRollSequenceRequest struct {
+	// DiceRoll is the first dice roll of the sequence.
+	DiceRoll CreateDiceRollRequest
+	// Next returns the next dice roll of the sequence from the previous rolled one, nil ends the
+	// sequence. The next dice rolls must be from the same room and user as the first one, an
+	// error cancels the sequence and none of the dice rolls are created.
+	Next func(ctx context.Context, prev model.DiceRoll) (*CreateDiceRollRequest, error)
+	// BeforeCreate is optional, it's called with all the rolled dice rolls before creating them
+	// (e.g: store the data that references them), an error cancels the sequence and none of the
+	// dice rolls are created nor notified.
+	BeforeCreate func(ctx context.Context, drs []model.DiceRoll) error
 }
 
-func (r SubscribeDiceRollCreatedRequest) validate() error {
-	if r.RoomID == "" {
-		return fmt.Errorf("roomID is required")
+func (r CreateDiceRollSequenceRequest) validate() error {
+	err := r.DiceRoll.validate()
+	if err != nil {
+		return fmt.Errorf("invalid config.DiceRoll: %w", err)
 	}
 
-	if r.EventHandler == nil {
-		return fmt.Errorf("eventHandler is required")
+	if r.Next == nil {
+		return fmt.Errorf("config.Next is required")
 	}
 
 	return nil
 }
 
-// SubscribeDiceRollCreatedResponse is the response for SubscribeDiceRollCreated.
-type SubscribeDiceRollCreatedResponse struct {
-	UnsubscribeFunc func() error
+// CreateDiceRollSequenceResponse is the response for CreateDiceRollSequence.
+type CreateDiceRollSequenceResponse struct {
+	// DiceRolls are the created dice rolls in the sequence order.
+	DiceRolls []model.DiceRoll
 }
 
-func (s service) SubscribeDiceRollCreated(ctx context.Context, r SubscribeDiceRollCreatedRequest) (*SubscribeDiceRollCreatedResponse, error) {
+func (s service) CreateDiceRollSequence(ctx context.Context, r CreateDiceRollSequenceRequest) (*CreateDiceRollSequenceResponse, error) {
 	err := r.validate()
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
 	}
 
-	// Check the room exists.
-	roomExists, err := s.roomRepository.RoomExists(ctx, r.RoomID)
+	roomID, userID := r.DiceRoll.RoomID, r.DiceRoll.UserID
+
+	// Check the room exists and accepts changes.
+	err = roomcheck.Writable(ctx, s.roomRepository, roomID)
 	if err != nil {
-		return nil, fmt.Errorf("could not check if room exists: %w", err)
+		return nil, err
 	}
-	if !roomExists {
-		return nil, fmt.Errorf("room does not exists: %w", internalerrors.ErrNotValid)
+
+	// Check the user exists.
+	userExist, err := s.userRepository.UserExists(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("could not check if user exists: %w", err)
+	}
+	if !userExist {
+		return nil, fmt.Errorf("user does not exists: %w", internalerrors.ErrNotValid)
 	}
 
-	// Create a subscription ID and subscribe.
-	subscriptionID := s.idGen()
-	err = s.eventSubscriber.SubscribeDiceRollCreated(ctx, subscriptionID, r.RoomID, r.EventHandler)
+	// Roll all the sequence before creating any dice roll.
+	drs := []model.DiceRoll{}
+	next := &r.DiceRoll
+	for next != nil {
+		if len(drs) >= maxBatchDiceRolls {
+			return nil, fmt.Errorf("max dice rolls on a sequence is %d: %w", maxBatchDiceRolls, internalerrors.ErrNotValid)
+		}
+
+		if len(drs) > 0 {
+			err := next.validate()
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid next dice roll: %s", internalerrors.ErrNotValid, err)
+			}
+
+			if next.RoomID != roomID || next.UserID != userID {
+				return nil, fmt.Errorf("next dice roll must be from the same room and user: %w", internalerrors.ErrNotValid)
+			}
+		}
+
+		var exp *notation.Expression
+		if next.Expression != "" {
+			exp, err = next.parseExpression()
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+			}
+		}
+
+		err = s.checkDiceRollRate(ctx, roomID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Check the whispered users are from the room.
+		if len(next.WhisperUserIDs) > 0 {
+			err := s.checkRoomUsers(ctx, roomID, next.WhisperUserIDs)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		dr, err := s.newDiceRoll(ctx, *next, exp)
+		if err != nil {
+			return nil, err
+		}
+		drs = append(drs, *dr)
+
+		next, err = r.Next(ctx, *dr)
+		if err != nil {
+			return nil, fmt.Errorf("could not get the next dice roll: %w", err)
+		}
+	}
+
+	if r.BeforeCreate != nil {
+		err = r.BeforeCreate(ctx, drs)
+		if err != nil {
+			return nil, fmt.Errorf("could not prepare the dice rolls creation: %w", err)
+		}
+	}
+
+	// Store all the dice rolls at once.
+	err = s.diceRollRepository.CreateDiceRolls(ctx, drs)
 	if err != nil {
-		return nil, fmt.Errorf("could not subscribe to diceRollCreated events: %w", err)
+		return nil, fmt.Errorf("could not store dice rolls: %w", err)
 	}
 
-	return &SubscribeDiceRollCreatedResponse{
-		UnsubscribeFunc: func() error {
-			return s.eventSubscriber.UnsubscribeDiceRollCreated(ctx, subscriptionID, r.RoomID)
-		},
+	// Send dice roll events in the sequence order.
+	for _, dr := range drs {
+		err = s.eventNotifier.NotifyDiceRollCreated(ctx, model.EventDiceRollCreated{DiceRoll: dr})
+		if err != nil {
+			return nil, fmt.Errorf("could not send dice roll created event: %w", err)
+		}
+	}
+
+	return &CreateDiceRollSequenceResponse{
+		DiceRolls: drs,
 	}, nil
+}
+
+// checkDiceRollRate consumes a dice roll from the user and room rates, if any of them has been
+// exceeded the dice roll is rate limited.
+func (s service) checkDiceRollRate(ctx context.Context, roomID, userID string) error {
+	allowed, err := s.userRateLimiter.Allow(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("could not check user dice roll rate: %w", err)
+	}
+	if !allowed {
+		return fmt.Errorf("too many dice rolls by the user: %w", internalerrors.ErrRateLimited)
+	}
+
+	allowed, err = s.roomRateLimiter.Allow(ctx, roomID)
+	if err != nil {
+		return fmt.Errorf("could not check room dice roll rate: %w", err)
+	}
+	if !allowed {
+		return fmt.Errorf("too many dice rolls on the room: %w", internalerrors.ErrRateLimited)
+	}
+
+	return nil
+}
+
+// checkDiceRollsRate is like checkDiceRollRate but checks n dice rolls at once.
+func (s service) checkDiceRollsRate(ctx context.Context, roomID, userID string, n uint) error {
+	allowed, err := s.userRateLimiter.AllowN(ctx, userID, n)
+	if err != nil {
+		return fmt.Errorf("could not check user dice roll rate: %w", err)
+	}
+	if !allowed {
+		return fmt.Errorf("too many dice rolls by the user: %w", internalerrors.ErrRateLimited)
+	}
+
+	allowed, err = s.roomRateLimiter.AllowN(ctx, roomID, n)
+	if err != nil {
+		return fmt.Errorf("could not check room dice roll rate: %w", err)
+	}
+	if !allowed {
+		return fmt.Errorf("too many dice rolls on the room: %w", internalerrors.ErrRateLimited)
+	}
+
+	return nil
+}
+
+// checkRoomUsers checks all the users are from the room.
+func (s service) checkRoomUsers(ctx context.Context, roomID string, userIDs []string) error {
+	us, err := s.userRepository.ListRoomUsers(ctx, roomID)
+	if err != nil {
+		return fmt.Errorf("could not list room users: %w", err)
+	}
+
+	roomUsers := map[string]struct{}{}
+	for _, u := range us.Items {
+		roomUsers[u.ID] = struct{}{}
+	}
+
+	for _, id := range userIDs {
+		if _, ok := roomUsers[id]; !ok {
+			return fmt.Errorf("%s user is not from the room: %w", id, internalerrors.ErrNotValid)
+		}
+	}
+
+	return nil
+}
+
+// roomDieTypes returns the die types replacing the custom ones with the ones registered on the room,
+// if any of the custom die types is not registered on the room it will fail.
+func (s service) roomDieTypes(ctx context.Context, roomID string, dts []model.DieType) ([]model.DieType, error) {
+	hasCustom := false
+	for _, dt := range dts {
+		if len(dt.Faces()) > 0 {
+			hasCustom = true
+			break
+		}
+	}
+	if !hasCustom {
+		return dts, nil
+	}
+
+	cdts, err := s.customDieTypeRepository.ListRoomCustomDieTypes(ctx, roomID)
+	if err != nil {
+		return nil, fmt.Errorf("could not list room custom die types: %w", err)
+	}
+	roomDTs := map[string]model.CustomDieType{}
+	for _, dt := range cdts.Items {
+		roomDTs[dt.TypeID] = dt
+	}
+
+	res := make([]model.DieType, 0, len(dts))
+	for _, dt := range dts {
+		if len(dt.Faces()) == 0 {
+			res = append(res, dt)
+			continue
+		}
+
+		cdt, ok := roomDTs[dt.ID()]
+		if !ok {
+			return nil, fmt.Errorf("%s custom die type is not registered on the room: %w", dt.ID(), internalerrors.ErrNotValid)
+		}
+		res = append(res, cdt)
+	}
+
+	return res, nil
+}
+
+// rollExpression rolls all the expression dice with the roller at once (in the same order they are
+// evaluated) and evaluates the expression with them, the rolled dice and the expression total are set
+// on the dice roll.
+func (s service) rollExpression(ctx context.Context, dr *model.DiceRoll, exp *notation.Expression) error {
+	for _, d := range exp.Dice() {
+		dt, err := model.NewDieType(d.Sides)
+		if err != nil {
+			return err
+		}
+
+		for i := uint(0); i < d.Quantity; i++ {
+			dr.Dice = append(dr.Dice, model.DieRoll{
+				ID:   s.idGen(),
+				Type: dt,
+			})
+		}
+	}
+
+	err := s.roller.Roll(ctx, dr)
+	if err != nil {
+		return err
+	}
+
+	slots := make([]int, 0, len(dr.Dice))
+	for i := range dr.Dice {
+		slots = append(slots, i)
+	}
+
+	return evalExpression(dr, exp, slots)
+}
+
+// evalExpression evaluates the expression using for each of the expression dice the dice roll die
+// of the same slot (slots are indexes of the dice roll dice, in the same order they are evaluated),
+// the evaluated dice status and the expression total are set on the dice roll.
+func evalExpression(dr *model.DiceRoll, exp *notation.Expression, slots []int) error {
+	pending := slots
+	res, err := exp.Eval(func(sides, quantity uint) ([]uint, error) {
+		if uint(len(pending)) < quantity {
+			return nil, fmt.Errorf("missing rolled dice")
+		}
+
+		values := make([]uint, 0, quantity)
+		for _, i := range pending[:quantity] {
+			d := dr.Dice[i]
+			if d.Type.Sides() != sides {
+				return nil, fmt.Errorf("rolled die type %s doesn't match the expression dice", d.Type.ID())
+			}
+			values = append(values, d.Side)
+		}
+		pending = pending[quantity:]
+
+		return values, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// The result dice are in the same order as the slots.
+	for i, d := range res.Dice {
+		status := model.DieRollStatusKept
+		if d.Dropped {
+			status = model.DieRollStatusDropped
+		}
+		dr.Dice[slots[i]].Status = status
+	}
+	dr.Total = res.Total
+
+	return nil
+}
+
+// ListDiceRollsRequest is the request for ListDiceRolls.
+type ListDiceRollsRequest struct {
+	UserID string
+	RoomID string
+	// ViewerUserID is the user that will see the dice rolls, the dice rolls it can't see will be hidden.
+	// If missing, only the public dice rolls will be visible.
+	ViewerUserID string
+	PageOpts     model.PaginationOpts
+}
+
+func (r ListDiceRollsRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	return nil
+}
+
+// ListDiceRollsResponse is the response for ListDiceRolls.
+type ListDiceRollsResponse struct {
+	DiceRolls []model.DiceRoll
+	Cursors   model.PaginationCursors
+}
+
+func (s service) ListDiceRolls(ctx context.Context, r ListDiceRollsRequest) (*ListDiceRollsResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	// Set up pagination defaults.
+	if r.PageOpts.Size <= 0 || r.PageOpts.Size > 100 {
+		r.PageOpts.Size = 100
+	}
+	if r.PageOpts.Order == model.PaginationOrderDefault {
+		r.PageOpts.Order = model.PaginationOrderDesc
+	}
+
+	drs, err := s.diceRollRepository.ListDiceRolls(ctx, r.PageOpts, storage.ListDiceRollsOpts{
+		RoomID: r.RoomID,
+		UserID: r.UserID,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("could not get dice roll list: %w", err)
+	}
+
+	// Hide the dice rolls the viewer can't see, only get the viewer if required.
+	var viewer *model.User
+	for i, dr := range drs.Items {
+		if dr.Visibility == model.DiceRollVisibilityPublic {
+			continue
+		}
+
+		if viewer == nil {
+			viewer, err = s.getViewer(ctx, r.ViewerUserID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if !dr.VisibleTo(*viewer) {
+			drs.Items[i] = dr.HiddenCopy()
+		}
+	}
+
+	return &ListDiceRollsResponse{
+		DiceRolls: drs.Items,
+		Cursors:   drs.Cursors,
+	}, nil
+}
+
+// SubscribeDiceRollCreatedRequest is the request for SubscribeDiceRollCreated.
+type SubscribeDiceRollCreatedRequest struct {
+	RoomID string
+	// ViewerUserID is the user that will receive the events, the dice rolls it can't see will be hidden.
+	// If missing, only the public dice rolls will be visible.
+	ViewerUserID string
+	EventHandler func(context.Context, model.EventDiceRollCreated) error
+}
+
+func (r SubscribeDiceRollCreatedRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("roomID is required")
+	}
+
+	if r.EventHandler == nil {
+		return fmt.Errorf("eventHandler is required")
+	}
+
+	return nil
+}
+
+// SubscribeDiceRollCreatedResponse is the response for SubscribeDiceRollCreated.
+type SubscribeDiceRollCreatedResponse struct {
+	UnsubscribeFunc func() error
+}
+
+func (s service) SubscribeDiceRollCreated(ctx context.Context, r SubscribeDiceRollCreatedRequest) (*SubscribeDiceRollCreatedResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	// Check the room exists.
+	roomExists, err := s.roomRepository.RoomExists(ctx, r.RoomID)
+	if err != nil {
+		return nil, fmt.Errorf("could not check if room exists: %w", err)
+	}
+	if !roomExists {
+		return nil, fmt.Errorf("room does not exists: %w", internalerrors.ErrNotValid)
+	}
+
+	// Hide the dice rolls the viewer can't see before handling the events.
+	viewer, err := s.getViewer(ctx, r.ViewerUserID)
+	if err != nil {
+		return nil, err
+	}
+	handler := func(ctx context.Context, ev model.EventDiceRollCreated) error {
+		if !ev.DiceRoll.VisibleTo(*viewer) {
+			ev.DiceRoll = ev.DiceRoll.HiddenCopy()
+		}
+		return r.EventHandler(ctx, ev)
+	}
+
+	// Create a subscription ID and subscribe.
+	subscriptionID := s.idGen()
+	err = s.eventSubscriber.SubscribeDiceRollCreated(ctx, subscriptionID, r.RoomID, handler)
+	if err != nil {
+		return nil, fmt.Errorf("could not subscribe to diceRollCreated events: %w", err)
+	}
+
+	return &SubscribeDiceRollCreatedResponse{
+		UnsubscribeFunc: func() error {
+			return s.eventSubscriber.UnsubscribeDiceRollCreated(ctx, subscriptionID, r.RoomID)
+		},
+	}, nil
+
+}
+
+// getViewer returns the user that will see the dice rolls, if there is no user, it will
+// return an anonymous user that can only see the public dice rolls.
+func (s service) getViewer(ctx context.Context, userID string) (*model.User, error) {
+	if userID == "" {
+		return &model.User{}, nil
+	}
+
+	u, err := s.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, internalerrors.ErrMissing) {
+			return nil, fmt.Errorf("viewer user does not exists: %w", internalerrors.ErrNotValid)
+		}
+		return nil, fmt.Errorf("could not get viewer user: %w", err)
+	}
+
+	return u, nil
+}
+
+// GetRoomServerSeedRequest is the request for GetRoomServerSeed.
+type GetRoomServerSeedRequest struct {
+	RoomID string
+}
+
+func (r GetRoomServerSeedRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	return nil
+}
+
+// GetRoomServerSeedResponse is the response for GetRoomServerSeed.
+type GetRoomServerSeedResponse struct {
+	// ServerSeed is the room active server seed, the seed is secret so it's not set.
+	ServerSeed model.ServerSeed
+}
+
+func (s service) GetRoomServerSeed(ctx context.Context, r GetRoomServerSeedRequest) (*GetRoomServerSeedResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	// Check the room exists.
+	roomExists, err := s.roomRepository.RoomExists(ctx, r.RoomID)
+	if err != nil {
+		return nil, fmt.Errorf("could not check if room exists: %w", err)
+	}
+	if !roomExists {
+		return nil, fmt.Errorf("room does not exists: %w", internalerrors.ErrNotValid)
+	}
+
+	seed, err := s.serverSeeds.active(ctx, r.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	seed.Seed = ""
+
+	return &GetRoomServerSeedResponse{
+		ServerSeed: *seed,
+	}, nil
+}
+
+// RotateRoomServerSeedRequest is the request for RotateRoomServerSeed.
+type RotateRoomServerSeedRequest struct {
+	RoomID string
+	// UserID is the user requesting it, only the room game master can rotate the server seed.
+	UserID string
+}
+
+func (r RotateRoomServerSeedRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	if r.UserID == "" {
+		return fmt.Errorf("config.UserID is required")
+	}
+
+	return nil
+}
+
+// RotateRoomServerSeedResponse is the response for RotateRoomServerSeed.
+type RotateRoomServerSeedResponse struct {
+	// RevealedServerSeed is the revealed server seed, nil if the room didn't have an active server seed.
+	RevealedServerSeed *model.ServerSeed
+	// ServerSeed is the new room active server seed, the seed is secret so it's not set.
+	ServerSeed model.ServerSeed
+}
+
+func (s service) RotateRoomServerSeed(ctx context.Context, r RotateRoomServerSeedRequest) (*RotateRoomServerSeedResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	// Check the room exists, archived rooms can rotate too, this way the last server
+	// seed can be revealed to verify their dice rolls.
+	roomExists, err := s.roomRepository.RoomExists(ctx, r.RoomID)
+	if err != nil {
+		return nil, fmt.Errorf("could not check if room exists: %w", err)
+	}
+	if !roomExists {
+		return nil, fmt.Errorf("room does not exists: %w", internalerrors.ErrNotValid)
+	}
+
+	err = roomcheck.GameMaster(ctx, s.userRepository, r.RoomID, r.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	revealed, current, err := s.serverSeeds.rotate(ctx, r.RoomID)
+	if err != nil {
+		return nil, fmt.Errorf("could not rotate server seed: %w", err)
+	}
+	current.Seed = ""
+
+	return &RotateRoomServerSeedResponse{
+		RevealedServerSeed: revealed,
+		ServerSeed:         *current,
+	}, nil
+}
+
+// VerifyDiceRollRequest is the request for VerifyDiceRoll.
+type VerifyDiceRollRequest struct {
+	DiceRollID string
+	// ViewerUserID is the user verifying the dice roll, the users can only verify the dice rolls they can see.
+	// If missing, only the public dice rolls can be verified.
+	ViewerUserID string
+}
+
+func (r VerifyDiceRollRequest) validate() error {
+	if r.DiceRollID == "" {
+		return fmt.Errorf("config.DiceRollID is required")
+	}
+
+	return nil
+}
+
+// VerifyDiceRollResponse is the response for VerifyDiceRoll.
+type VerifyDiceRollResponse struct {
+	// DiceRoll is the stored dice roll.
+	DiceRoll model.DiceRoll
+	// ServerSeed is the revealed server seed used on the dice roll.
+	ServerSeed model.ServerSeed
+	// Dice are the dice recomputed from the dice roll proof, the rerolls keep the dice of their
+	// parent dice roll.
+	Dice []model.DieRoll
+	// Valid is true when the server seed matches the dice roll proof hash and the recomputed
+	// dice are the same as the stored ones.
+	Valid bool
+}
+
+func (s service) VerifyDiceRoll(ctx context.Context, r VerifyDiceRollRequest) (*VerifyDiceRollResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	dr, err := s.diceRollRepository.GetDiceRoll(ctx, r.DiceRollID)
+	if err != nil {
+		if errors.Is(err, internalerrors.ErrMissing) {
+			return nil, fmt.Errorf("dice roll does not exists: %w", internalerrors.ErrNotValid)
+		}
+		return nil, fmt.Errorf("could not get dice roll: %w", err)
+	}
+
+	// The verification has the dice roll dice, so only the users that can see it can verify it.
+	if dr.Visibility != model.DiceRollVisibilityPublic {
+		viewer, err := s.getViewer(ctx, r.ViewerUserID)
+		if err != nil {
+			return nil, err
+		}
+		if !dr.VisibleTo(*viewer) {
+			return nil, fmt.Errorf("viewer can't see the dice roll: %w", internalerrors.ErrNotAllowed)
+		}
+	}
+
+	if dr.Proof == nil {
+		return nil, fmt.Errorf("dice roll is not provably fair: %w", internalerrors.ErrNotValid)
+	}
+
+	seed, err := s.serverSeedRepository.GetServerSeed(ctx, dr.Proof.ServerSeedID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get server seed: %w", err)
+	}
+
+	// Until the server seed is rotated, the seed is secret.
+	if !seed.Revealed {
+		return nil, fmt.Errorf("server seed is not revealed yet, it needs to be rotated: %w", internalerrors.ErrNotValid)
+	}
+
+	// The rerolls only roll their new dice with their proof, the kept dice are the ones of the
+	// parent dice roll (verified on its own).
+	from := 0
+	valid := seed.RoomID == dr.RoomID && hashServerSeed(seed.Seed) == dr.Proof.ServerSeedHash
+	if dr.ParentID != "" {
+		parent, err := s.diceRollRepository.GetDiceRoll(ctx, dr.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("could not get parent dice roll: %w", err)
+		}
+
+		from = len(parent.Dice)
+		if from > len(dr.Dice) {
+			return nil, fmt.Errorf("reroll has less dice than its parent dice roll: %w", internalerrors.ErrNotValid)
+		}
+		for i, d := range parent.Dice {
+			if d.Side != dr.Dice[i].Side {
+				valid = false
+				break
+			}
+		}
+	}
+
+	// Recompute the dice.
+	dice := make([]model.DieRoll, 0, len(dr.Dice)-from)
+	for _, d := range dr.Dice[from:] {
+		dice = append(dice, model.DieRoll{ID: d.ID, Type: d.Type, Status: d.Status})
+	}
+	dice, err = rollProvablyFairDice(seed.Seed, dr.Proof.ClientSeed, dr.Proof.Nonce, dice)
+	if err != nil {
+		return nil, fmt.Errorf("could not recompute dice roll: %w", err)
+	}
+
+	for i, d := range dice {
+		if d.Side != dr.Dice[from+i].Side {
+			valid = false
+			break
+		}
+	}
+	dice = append(slices.Clone(dr.Dice[:from]), dice...)
+
+	return &VerifyDiceRollResponse{
+		DiceRoll:   *dr,
+		ServerSeed: *seed,
+		Dice:       dice,
+		Valid:      valid,
+	}, nil
+}
+
+// RerollDiceRequest is the request for RerollDice.
+type RerollDiceRequest struct {
+	// DiceRollID is the ID of the dice roll the dice are rerolled from.
+	DiceRollID string
+	// UserID is the user rerolling the dice, only the user that made the dice roll can reroll it.
+	UserID string
+	// DieIDs are the IDs of the dice roll dice to reroll.
+	DieIDs []string
+}
+
+func (r RerollDiceRequest) validate() error {
+	if r.DiceRollID == "" {
+		return fmt.Errorf("config.DiceRollID is required")
+	}
+
+	if r.UserID == "" {
+		return fmt.Errorf("config.UserID is required")
+	}
+
+	if len(r.DieIDs) == 0 {
+		return fmt.Errorf("config.DieIDs are required")
+	}
+
+	if len(r.DieIDs) > maxDiceQuantity {
+		return fmt.Errorf("max config.DieIDs quantity is %d, got %d", maxDiceQuantity, len(r.DieIDs))
+	}
+
+	ids := map[string]struct{}{}
+	for _, id := range r.DieIDs {
+		if _, ok := ids[id]; ok {
+			return fmt.Errorf("config.DieIDs has the %s die ID repeated", id)
+		}
+		ids[id] = struct{}{}
+	}
+
+	return nil
+}
+
+// RerollDiceResponse is the response for RerollDice.
+type RerollDiceResponse struct {
+	DiceRoll model.DiceRoll
+}
+
+// RerollDice creates a new dice roll with the same dice as the original one, the rerolled dice are
+// discarded (like the reroll modifier) and their new rolls are appended to the dice roll. The new
+// dice roll references the original one so the lineage can be followed.
+//
+// Expression dice rolls are evaluated again with the new dice, the rest of the dice rolls sum the
+// dice that are not discarded plus the modifier (the roll modifiers and advantage rules are not
+// stored, so they are not applied again). Difficulty checks are evaluated again against the same
+// difficulty. Dice pools can't be rerolled.
+//
+// The new dice are rolled on their own, provably fair dice rolls get a fresh proof (a new nonce
+// of the room active server seed) for them, so each reroll gets new sides and can be verified too,
+// the kept dice are verified with the original dice roll.
+func (s service) RerollDice(ctx context.Context, r RerollDiceRequest) (*RerollDiceResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	parent, err := s.diceRollRepository.GetDiceRoll(ctx, r.DiceRollID)
+	if err != nil {
+		if errors.Is(err, internalerrors.ErrMissing) {
+			return nil, fmt.Errorf("dice roll does not exists: %w", internalerrors.ErrNotValid)
+		}
+		return nil, fmt.Errorf("could not get dice roll: %w", err)
+	}
+
+	if parent.UserID != r.UserID {
+		return nil, fmt.Errorf("only the user that made the dice roll can reroll it: %w", internalerrors.ErrNotValid)
+	}
+
+	if parent.Pool != nil {
+		return nil, fmt.Errorf("dice pools can't be rerolled: %w", internalerrors.ErrNotValid)
+	}
+
+	// Check the room accepts changes.
+	err = roomcheck.Writable(ctx, s.roomRepository, parent.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkDiceRollRate(ctx, parent.RoomID, r.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the new dice roll with the original dice.
+	dr := &model.DiceRoll{
+		ID:             s.idGen(),
+		CreatedAt:      s.timeNow().UTC(),
+		RoomID:         parent.RoomID,
+		UserID:         parent.UserID,
+		Dice:           make([]model.DieRoll, 0, len(parent.Dice)+len(r.DieIDs)),
+		Expression:     parent.Expression,
+		Label:          parent.Label,
+		Modifier:       parent.Modifier,
+		Visibility:     parent.Visibility,
+		WhisperUserIDs: parent.WhisperUserIDs,
+		ParentID:       parent.ID,
+	}
+	for _, d := range parent.Dice {
+		d.ID = s.idGen()
+		dr.Dice = append(dr.Dice, d)
+	}
+
+	// Discard the rerolled dice and add the new ones.
+	reroll := map[string]struct{}{}
+	for _, id := range r.DieIDs {
+		reroll[id] = struct{}{}
+	}
+	n := len(dr.Dice)
+	for i, d := range parent.Dice {
+		if _, ok := reroll[d.ID]; !ok {
+			continue
+		}
+		delete(reroll, d.ID)
+
+		if d.Discarded() {
+			return nil, fmt.Errorf("%s die is discarded, it can't be rerolled: %w", d.ID, internalerrors.ErrNotValid)
+		}
+		dr.Dice[i].Status = model.DieRollStatusRerolled
+		dr.Dice = append(dr.Dice, model.DieRoll{ID: s.idGen(), Type: d.Type})
+	}
+	for _, id := range r.DieIDs {
+		if _, ok := reroll[id]; ok {
+			return nil, fmt.Errorf("%s die is not from the dice roll: %w", id, internalerrors.ErrNotValid)
+		}
+	}
+
+	// Roll'em all!
+	err = s.rollRerolledDice(ctx, *parent, dr, n)
+	if err != nil {
+		return nil, fmt.Errorf("could not roll the dice: %w", err)
+	}
+
+	if dr.Expression != "" {
+		err := s.evalRerolledExpression(ctx, *parent, dr)
+		if err != nil {
+			return nil, fmt.Errorf("could not evaluate the dice roll expression: %w", err)
+		}
+	} else {
+		dr.Total = dr.Modifier
+		for _, d := range dr.Dice {
+			if !d.Discarded() {
+				dr.Total += d.Value()
+			}
+		}
+	}
+
+	// Check the new total against the same difficulty.
+	if c := parent.Check; c != nil {
+		check := DiceCheck{Target: c.Target, Comparison: c.Comparison}.evaluate(dr.Total, dr.Dice)
+		dr.Check = &check
+	}
+
+	// Store the dice roll.
+	err = s.diceRollRepository.CreateDiceRoll(ctx, *dr)
+	if err != nil {
+		return nil, fmt.Errorf("could not store dice roll: %w", err)
+	}
+
+	// Send dice roll event.
+	ev := model.EventDiceRollCreated{DiceRoll: *dr}
+	err = s.eventNotifier.NotifyDiceRollCreated(ctx, ev)
+	if err != nil {
+		return nil, fmt.Errorf("could not send dice roll created event: %w", err)
+	}
+
+	return &RerollDiceResponse{
+		DiceRoll: *dr,
+	}, nil
+}
+
+// rollRerolledDice rolls the reroll dice starting at `from` index as a new dice roll, this way
+// the provably fair rollers use a new nonce instead of repeating the parent dice roll sequence.
+func (s service) rollRerolledDice(ctx context.Context, parent model.DiceRoll, dr *model.DiceRoll, from int) error {
+	rolled := &model.DiceRoll{
+		ID:     dr.ID,
+		RoomID: dr.RoomID,
+		Dice:   slices.Clone(dr.Dice[from:]),
+	}
+	if parent.Proof != nil {
+		rolled.Proof = &model.DiceRollProof{ClientSeed: parent.Proof.ClientSeed}
+	}
+
+	err := s.roller.Roll(ctx, rolled)
+	if err != nil {
+		return err
+	}
+	copy(dr.Dice[from:], rolled.Dice)
+
+	// Not provably fair rollers ignore the proof.
+	if rolled.Proof != nil && rolled.Proof.ServerSeedID != "" {
+		dr.Proof = rolled.Proof
+	}
+
+	return nil
+}
+
+// evalRerolledExpression evaluates the expression of a rerolled dice roll, the rerolled dice are
+// replaced by the new ones on the expression evaluation.
+func (s service) evalRerolledExpression(ctx context.Context, parent model.DiceRoll, dr *model.DiceRoll) error {
+	exp, err := notation.Parse(dr.Expression)
+	if err != nil {
+		return err
+	}
+
+	slots, err := s.expressionSlots(ctx, parent)
+	if err != nil {
+		return err
+	}
+
+	return evalExpression(dr, exp, rerolledSlots(slots, parent.Dice, dr.Dice))
+}
+
+// expressionSlots returns the dice roll dice indexes that are used on the evaluation of the
+// expression. The dice don't know what die they replaced, so the rerolls use the dice roll
+// lineage to resolve them.
+func (s service) expressionSlots(ctx context.Context, dr model.DiceRoll) ([]int, error) {
+	if dr.ParentID == "" {
+		slots := make([]int, 0, len(dr.Dice))
+		for i := range dr.Dice {
+			slots = append(slots, i)
+		}
+		return slots, nil
+	}
+
+	parent, err := s.diceRollRepository.GetDiceRoll(ctx, dr.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get parent dice roll: %w", err)
+	}
+
+	slots, err := s.expressionSlots(ctx, *parent)
+	if err != nil {
+		return nil, err
+	}
+
+	return rerolledSlots(slots, parent.Dice, dr.Dice), nil
+}
+
+// rerolledSlots replaces the slots of the dice rerolled on the reroll dice with the dice appended
+// on the reroll, the new dice are appended in the same order as the rerolled dice.
+func rerolledSlots(slots []int, parentDice, rerollDice []model.DieRoll) []int {
+	replaced := map[int]int{}
+	next := len(parentDice)
+	for i, d := range parentDice {
+		if d.Status != model.DieRollStatusRerolled && rerollDice[i].Status == model.DieRollStatusRerolled {
+			replaced[i] = next
+			next++
+		}
+	}
+
+	res := make([]int, 0, len(slots))
+	for _, i := range slots {
+		if j, ok := replaced[i]; ok {
+			i = j
+		}
+		res = append(res, i)
+	}
 
+	return res
 }
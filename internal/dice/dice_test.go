@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,31 +15,73 @@ import (
 	"github.com/rollify/rollify/internal/dice"
 	"github.com/rollify/rollify/internal/dice/dicemock"
 	"github.com/rollify/rollify/internal/event/eventmock"
+	eventmemory "github.com/rollify/rollify/internal/event/memory"
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/log"
 	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/ratelimit/ratelimitmock"
 	"github.com/rollify/rollify/internal/storage"
+	"github.com/rollify/rollify/internal/storage/memory"
 	"github.com/rollify/rollify/internal/storage/storagemock"
 )
 
 func TestServiceListDiceTypes(t *testing.T) {
+	commonDiceTypes := []model.DieType{
+		model.DieTypeD2,
+		model.DieTypeD3,
+		model.DieTypeD4,
+		model.DieTypeD6,
+		model.DieTypeD8,
+		model.DieTypeD10,
+		model.DieTypeD12,
+		model.DieTypeD20,
+		model.DieTypeD100,
+	}
+	coin := model.CustomDieType{
+		TypeID:    "coin-id",
+		RoomID:    "test-room",
+		TypeName:  "Coin",
+		FaceTable: []model.DieFace{{Label: "Heads", Value: 1}, {Label: "Tails", Value: 0}},
+	}
+
 	tests := map[string]struct {
 		config  dice.ServiceConfig
+		mock    func(dieTypeRepo *storagemock.CustomDieTypeRepository)
+		req     dice.ListDiceTypesRequest
 		expResp func() *dice.ListDiceTypesResponse
 		expErr  bool
 	}{
 		"Listing dice types should return all the available dice types.": {
+			mock: func(dieTypeRepo *storagemock.CustomDieTypeRepository) {},
+			req:  dice.ListDiceTypesRequest{},
 			expResp: func() *dice.ListDiceTypesResponse {
 				return &dice.ListDiceTypesResponse{
-					DiceTypes: []model.DieType{
-						model.DieTypeD4,
-						model.DieTypeD6,
-						model.DieTypeD8,
-						model.DieTypeD10,
-						model.DieTypeD12,
-						model.DieTypeD20,
-					},
+					DiceTypes: commonDiceTypes,
+				}
+			},
+		},
+
+		"Listing dice types of a room should return all the available dice types and the room custom die types.": {
+			mock: func(dieTypeRepo *storagemock.CustomDieTypeRepository) {
+				dieTypeRepo.On("ListRoomCustomDieTypes", mock.Anything, "test-room").Once().Return(&storage.CustomDieTypeList{
+					Items: []model.CustomDieType{coin},
+				}, nil)
+			},
+			req: dice.ListDiceTypesRequest{RoomID: "test-room"},
+			expResp: func() *dice.ListDiceTypesResponse {
+				return &dice.ListDiceTypesResponse{
+					DiceTypes: append(append([]model.DieType{}, commonDiceTypes...), coin),
 				}
 			},
 		},
+
+		"Having an error while listing the room custom die types should fail.": {
+			mock: func(dieTypeRepo *storagemock.CustomDieTypeRepository) {
+				dieTypeRepo.On("ListRoomCustomDieTypes", mock.Anything, "test-room").Once().Return(nil, fmt.Errorf("wanted error"))
+			},
+			req:    dice.ListDiceTypesRequest{RoomID: "test-room"},
+			expErr: true,
+		},
 	}
 
 	for name, test := range tests {
@@ -46,38 +89,165 @@ func TestServiceListDiceTypes(t *testing.T) {
 			assert := assert.New(t)
 			require := require.New(t)
 
+			// Mocks.
+			mdtrep := &storagemock.CustomDieTypeRepository{}
+			test.mock(mdtrep)
+
 			test.config.Roller = &dicemock.Roller{}
 			test.config.DiceRollRepository = &storagemock.DiceRollRepository{}
 			test.config.RoomRepository = &storagemock.RoomRepository{}
 			test.config.UserRepository = &storagemock.UserRepository{}
+			test.config.CustomDieTypeRepository = mdtrep
+			test.config.ServerSeedRepository = &storagemock.ServerSeedRepository{}
 			test.config.EventNotifier = &eventmock.Notifier{}
 			test.config.EventSubscriber = &eventmock.Subscriber{}
 			svc, err := dice.NewService(test.config)
 			require.NoError(err)
 
-			gotResp, err := svc.ListDiceTypes(context.TODO())
+			gotResp, err := svc.ListDiceTypes(context.TODO(), test.req)
 
 			if test.expErr {
 				assert.Error(err)
 			} else if assert.NoError(err) {
+				mdtrep.AssertExpectations(t)
 				assert.Equal(test.expResp(), gotResp)
 			}
 		})
 	}
 }
 
+func TestServiceCreateCustomDieType(t *testing.T) {
+	coinFaces := []model.DieFace{{Label: "Heads", Value: 1}, {Label: "Tails", Value: 0}}
+
+	tests := map[string]struct {
+		config  dice.ServiceConfig
+		mock    func(roomRepo *storagemock.RoomRepository, dieTypeRepo *storagemock.CustomDieTypeRepository)
+		req     dice.CreateCustomDieTypeRequest
+		expResp *dice.CreateCustomDieTypeResponse
+		expErr  error
+	}{
+		"Having a request without room should fail.": {
+			mock:   func(roomRepo *storagemock.RoomRepository, dieTypeRepo *storagemock.CustomDieTypeRepository) {},
+			req:    dice.CreateCustomDieTypeRequest{Name: "Coin", Faces: coinFaces},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request without name should fail.": {
+			mock:   func(roomRepo *storagemock.RoomRepository, dieTypeRepo *storagemock.CustomDieTypeRepository) {},
+			req:    dice.CreateCustomDieTypeRequest{RoomID: "test-room", Faces: coinFaces},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request with less than 2 faces should fail.": {
+			mock:   func(roomRepo *storagemock.RoomRepository, dieTypeRepo *storagemock.CustomDieTypeRepository) {},
+			req:    dice.CreateCustomDieTypeRequest{RoomID: "test-room", Name: "Coin", Faces: coinFaces[:1]},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request with a too long face label should fail.": {
+			mock: func(roomRepo *storagemock.RoomRepository, dieTypeRepo *storagemock.CustomDieTypeRepository) {},
+			req: dice.CreateCustomDieTypeRequest{RoomID: "test-room", Name: "Coin", Faces: []model.DieFace{
+				{Label: "Heads"},
+				{Label: "Tails, but a very long tails label"},
+			}},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request on a missing room should fail.": {
+			mock: func(roomRepo *storagemock.RoomRepository, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+				roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(nil, internalerrors.ErrMissing)
+			},
+			req:    dice.CreateCustomDieTypeRequest{RoomID: "test-room", Name: "Coin", Faces: coinFaces},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request on an archived room should fail.": {
+			mock: func(roomRepo *storagemock.RoomRepository, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+				roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room", Archived: true}, nil)
+			},
+			req:    dice.CreateCustomDieTypeRequest{RoomID: "test-room", Name: "Coin", Faces: coinFaces},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having an error while storing the die type should fail.": {
+			mock: func(roomRepo *storagemock.RoomRepository, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+				roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
+				dieTypeRepo.On("CreateCustomDieType", mock.Anything, mock.Anything).Once().Return(internalerrors.ErrAlreadyExists)
+			},
+			req:    dice.CreateCustomDieTypeRequest{RoomID: "test-room", Name: "Coin", Faces: coinFaces},
+			expErr: internalerrors.ErrAlreadyExists,
+		},
+
+		"Having a request should create the custom die type on the room.": {
+			mock: func(roomRepo *storagemock.RoomRepository, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+				exp := model.CustomDieType{
+					TypeID:    "test",
+					RoomID:    "test-room",
+					TypeName:  "Coin",
+					FaceTable: coinFaces,
+				}
+				roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
+				dieTypeRepo.On("CreateCustomDieType", mock.Anything, exp).Once().Return(nil)
+			},
+			req: dice.CreateCustomDieTypeRequest{RoomID: "test-room", Name: "Coin", Faces: coinFaces},
+			expResp: &dice.CreateCustomDieTypeResponse{
+				DieType: model.CustomDieType{
+					TypeID:    "test",
+					RoomID:    "test-room",
+					TypeName:  "Coin",
+					FaceTable: coinFaces,
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mrrep := &storagemock.RoomRepository{}
+			mdtrep := &storagemock.CustomDieTypeRepository{}
+			test.mock(mrrep, mdtrep)
+
+			test.config.Roller = &dicemock.Roller{}
+			test.config.DiceRollRepository = &storagemock.DiceRollRepository{}
+			test.config.RoomRepository = mrrep
+			test.config.UserRepository = &storagemock.UserRepository{}
+			test.config.CustomDieTypeRepository = mdtrep
+			test.config.ServerSeedRepository = &storagemock.ServerSeedRepository{}
+			test.config.EventNotifier = &eventmock.Notifier{}
+			test.config.EventSubscriber = &eventmock.Subscriber{}
+			test.config.IDGenerator = func() string { return "test" }
+			svc, err := dice.NewService(test.config)
+			require.NoError(err)
+
+			gotResp, err := svc.CreateCustomDieType(context.TODO(), test.req)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mrrep.AssertExpectations(t)
+				mdtrep.AssertExpectations(t)
+				assert.Equal(test.expResp, gotResp)
+			}
+		})
+	}
+}
+
 func TestServiceCreateDiceRoll(t *testing.T) {
 	t0 := time.Now().UTC()
 
 	tests := map[string]struct {
 		config  dice.ServiceConfig
-		mock    func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier)
+		mock    func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository)
 		req     func() dice.CreateDiceRollRequest
 		expResp func() *dice.CreateDiceRollResponse
 		expErr  bool
 	}{
 		"Having a dice roll request without room should fail.": {
-			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier) {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
 			},
 			req: func() dice.CreateDiceRollRequest {
 				return dice.CreateDiceRollRequest{
@@ -90,7 +260,7 @@ func TestServiceCreateDiceRoll(t *testing.T) {
 		},
 
 		"Having a dice roll request without user should fail.": {
-			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier) {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
 			},
 			req: func() dice.CreateDiceRollRequest {
 				return dice.CreateDiceRollRequest{
@@ -103,7 +273,7 @@ func TestServiceCreateDiceRoll(t *testing.T) {
 		},
 
 		"Having a dice roll request without dice should fail.": {
-			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier) {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
 			},
 			req: func() dice.CreateDiceRollRequest {
 				return dice.CreateDiceRollRequest{
@@ -116,7 +286,7 @@ func TestServiceCreateDiceRoll(t *testing.T) {
 		},
 
 		"Having a dice roll request with too much dice should fail.": {
-			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier) {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
 			},
 			req: func() dice.CreateDiceRollRequest {
 				ds := []model.DieType{}
@@ -133,8 +303,22 @@ func TestServiceCreateDiceRoll(t *testing.T) {
 		},
 
 		"Having a dice roll request with a room that does not exists it should fail.": {
-			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier) {
-				roomRepo.On("RoomExists", mock.Anything, "test-room").Once().Return(false, nil)
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+				roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(nil, internalerrors.ErrMissing)
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID: "test-room",
+					UserID: "user-id",
+					Dice:   []model.DieType{model.DieTypeD6},
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a dice roll request on an archived room it should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+				roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room", Archived: true}, nil)
 			},
 			req: func() dice.CreateDiceRollRequest {
 				return dice.CreateDiceRollRequest{
@@ -147,8 +331,8 @@ func TestServiceCreateDiceRoll(t *testing.T) {
 		},
 
 		"Having a dice roll request if checking if the room exists fail, it should fail.": {
-			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier) {
-				roomRepo.On("RoomExists", mock.Anything, "test-room").Once().Return(false, fmt.Errorf("wanted error"))
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+				roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(nil, fmt.Errorf("wanted error"))
 			},
 			req: func() dice.CreateDiceRollRequest {
 				return dice.CreateDiceRollRequest{
@@ -161,8 +345,8 @@ func TestServiceCreateDiceRoll(t *testing.T) {
 		},
 
 		"Having a dice roll request with a user that does not exists it should fail.": {
-			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier) {
-				roomRepo.On("RoomExists", mock.Anything, mock.Anything).Once().Return(true, nil)
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+				roomRepo.On("GetRoom", mock.Anything, mock.Anything).Once().Return(&model.Room{ID: "test-room"}, nil)
 				userRepo.On("UserExists", mock.Anything, "user-id").Once().Return(false, nil)
 			},
 			req: func() dice.CreateDiceRollRequest {
@@ -176,8 +360,8 @@ func TestServiceCreateDiceRoll(t *testing.T) {
 		},
 
 		"Having a dice roll request if checking if the user exists fail, it should fail.": {
-			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier) {
-				roomRepo.On("RoomExists", mock.Anything, mock.Anything).Once().Return(true, nil)
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+				roomRepo.On("GetRoom", mock.Anything, mock.Anything).Once().Return(&model.Room{ID: "test-room"}, nil)
 				userRepo.On("UserExists", mock.Anything, "user-id").Once().Return(false, fmt.Errorf("wanted error"))
 			},
 			req: func() dice.CreateDiceRollRequest {
@@ -191,7 +375,7 @@ func TestServiceCreateDiceRoll(t *testing.T) {
 		},
 
 		"Having a dice roll request it should create a dice roll, roll them, store and notify.": {
-			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier) {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
 				// Expexted dice roll call.
 				exp := &model.DiceRoll{
 					ID:        "test",
@@ -204,7 +388,7 @@ func TestServiceCreateDiceRoll(t *testing.T) {
 						{ID: "test", Type: model.DieTypeD10},
 					},
 				}
-				roomRepo.On("RoomExists", mock.Anything, "test-room").Once().Return(true, nil)
+				roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
 				userRepo.On("UserExists", mock.Anything, "user-id").Once().Return(true, nil)
 				roller.On("Roll", mock.Anything, exp).Once().Return(nil)
 				diceRollRepo.On("CreateDiceRoll", mock.Anything, *exp).Once().Return(nil)
@@ -239,237 +423,1948 @@ func TestServiceCreateDiceRoll(t *testing.T) {
 			},
 		},
 
-		"Having a dice roll request and failing the dice roll process, it should fail.": {
-			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier) {
-				roomRepo.On("RoomExists", mock.Anything, mock.Anything).Once().Return(true, nil)
-				userRepo.On("UserExists", mock.Anything, mock.Anything).Once().Return(true, nil)
-				roller.On("Roll", mock.Anything, mock.Anything).Once().Return(fmt.Errorf("wanted error"))
+		"Having a dice roll request with custom die types not registered on the room should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+				roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
+				userRepo.On("UserExists", mock.Anything, "user-id").Once().Return(true, nil)
+				dieTypeRepo.On("ListRoomCustomDieTypes", mock.Anything, "test-room").Once().Return(&storage.CustomDieTypeList{}, nil)
 			},
 			req: func() dice.CreateDiceRollRequest {
 				return dice.CreateDiceRollRequest{
 					RoomID: "test-room",
 					UserID: "user-id",
-					Dice:   []model.DieType{model.DieTypeD6},
+					Dice: []model.DieType{
+						model.CustomDieType{TypeID: "coin-id", FaceTable: []model.DieFace{{Label: "Heads"}, {Label: "Tails"}}},
+					},
 				}
 			},
 			expErr: true,
 		},
 
-		"Having a dice roll request if storage fails, it should fail.": {
-			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier) {
-				roomRepo.On("RoomExists", mock.Anything, mock.Anything).Once().Return(true, nil)
-				userRepo.On("UserExists", mock.Anything, mock.Anything).Once().Return(true, nil)
-				roller.On("Roll", mock.Anything, mock.Anything).Once().Return(nil)
-				diceRollRepo.On("CreateDiceRoll", mock.Anything, mock.Anything).Once().Return(errors.New("wanted error"))
+		"Having a dice roll request with custom die types it should roll the room die types and sum the face values.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+				fate := model.CustomDieType{
+					TypeID:    "fate-id",
+					RoomID:    "test-room",
+					TypeName:  "Fate",
+					FaceTable: []model.DieFace{{Label: "-", Value: -1}, {Label: "", Value: 0}, {Label: "+", Value: 1}},
+				}
+				roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
+				userRepo.On("UserExists", mock.Anything, "user-id").Once().Return(true, nil)
+				dieTypeRepo.On("ListRoomCustomDieTypes", mock.Anything, "test-room").Once().Return(&storage.CustomDieTypeList{
+					Items: []model.CustomDieType{fate},
+				}, nil)
+
+				expRoll := &model.DiceRoll{
+					ID:        "test",
+					CreatedAt: t0,
+					RoomID:    "test-room",
+					UserID:    "user-id",
+					Dice: []model.DieRoll{
+						{ID: "test", Type: fate},
+						{ID: "test", Type: fate},
+						{ID: "test", Type: fate},
+						{ID: "test", Type: model.DieTypeD6},
+					},
+				}
+				roller.On("Roll", mock.Anything, expRoll).Once().Return(nil).Run(func(args mock.Arguments) {
+					dr := args.Get(1).(*model.DiceRoll)
+					for i, side := range []uint{3, 3, 1, 4} {
+						dr.Dice[i].Side = side
+					}
+				})
+
+				exp := model.DiceRoll{
+					ID:        "test",
+					CreatedAt: t0,
+					RoomID:    "test-room",
+					UserID:    "user-id",
+					Dice: []model.DieRoll{
+						{ID: "test", Type: fate, Side: 3},
+						{ID: "test", Type: fate, Side: 3},
+						{ID: "test", Type: fate, Side: 1},
+						{ID: "test", Type: model.DieTypeD6, Side: 4},
+					},
+					Total: 5,
+				}
+				diceRollRepo.On("CreateDiceRoll", mock.Anything, exp).Once().Return(nil)
+				notifier.On("NotifyDiceRollCreated", mock.Anything, model.EventDiceRollCreated{DiceRoll: exp}).Once().Return(nil)
 			},
 			req: func() dice.CreateDiceRollRequest {
+				// The request only needs the ID of the room custom die types.
+				fate := model.CustomDieType{TypeID: "fate-id", FaceTable: []model.DieFace{{}, {}, {}}}
 				return dice.CreateDiceRollRequest{
 					RoomID: "test-room",
 					UserID: "user-id",
-					Dice:   []model.DieType{model.DieTypeD6},
+					Dice:   []model.DieType{fate, fate, fate, model.DieTypeD6},
+				}
+			},
+			expResp: func() *dice.CreateDiceRollResponse {
+				fate := model.CustomDieType{
+					TypeID:    "fate-id",
+					RoomID:    "test-room",
+					TypeName:  "Fate",
+					FaceTable: []model.DieFace{{Label: "-", Value: -1}, {Label: "", Value: 0}, {Label: "+", Value: 1}},
+				}
+				return &dice.CreateDiceRollResponse{
+					DiceRoll: model.DiceRoll{
+						ID:        "test",
+						CreatedAt: t0,
+						RoomID:    "test-room",
+						UserID:    "user-id",
+						Dice: []model.DieRoll{
+							{ID: "test", Type: fate, Side: 3},
+							{ID: "test", Type: fate, Side: 3},
+							{ID: "test", Type: fate, Side: 1},
+							{ID: "test", Type: model.DieTypeD6, Side: 4},
+						},
+						Total: 5,
+					},
 				}
 			},
-			expErr: true,
 		},
 
-		"Having a dice roll request if notification fails, it should fail.": {
-			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier) {
-				roomRepo.On("RoomExists", mock.Anything, mock.Anything).Once().Return(true, nil)
-				userRepo.On("UserExists", mock.Anything, mock.Anything).Once().Return(true, nil)
-				roller.On("Roll", mock.Anything, mock.Anything).Once().Return(nil)
-				diceRollRepo.On("CreateDiceRoll", mock.Anything, mock.Anything).Once().Return(nil)
-				notifier.On("NotifyDiceRollCreated", mock.Anything, mock.Anything).Once().Return(errors.New("wanted error"))
-
+		"Having a dice roll request with dice and expression at the same time should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
 			},
 			req: func() dice.CreateDiceRollRequest {
 				return dice.CreateDiceRollRequest{
-					RoomID: "test-room",
-					UserID: "user-id",
-					Dice:   []model.DieType{model.DieTypeD6},
+					RoomID:     "test-room",
+					UserID:     "user-id",
+					Dice:       []model.DieType{model.DieTypeD6},
+					Expression: "1d6",
 				}
 			},
 			expErr: true,
 		},
-	}
-
-	for name, test := range tests {
-		t.Run(name, func(t *testing.T) {
-			assert := assert.New(t)
-			require := require.New(t)
-
-			// Mocks
-			mrol := &dicemock.Roller{}
-			mdrrep := &storagemock.DiceRollRepository{}
-			mrrep := &storagemock.RoomRepository{}
-			murep := &storagemock.UserRepository{}
-			mevn := &eventmock.Notifier{}
-			test.mock(mrol, mdrrep, mrrep, murep, mevn)
-
-			test.config.Roller = mrol
-			test.config.DiceRollRepository = mdrrep
-			test.config.RoomRepository = mrrep
-			test.config.UserRepository = murep
-			test.config.EventNotifier = mevn
-			test.config.EventSubscriber = &eventmock.Subscriber{}
-			test.config.IDGenerator = func() string { return "test" }
-			test.config.TimeNowFunc = func() time.Time { return t0 }
-
-			svc, err := dice.NewService(test.config)
-			require.NoError(err)
 
-			gotResp, err := svc.CreateDiceRoll(context.TODO(), test.req())
-
-			if test.expErr {
-				assert.Error(err)
-			} else if assert.NoError(err) {
-				assert.Equal(test.expResp(), gotResp)
-			}
-		})
-	}
-}
+		"Having a dice roll request with an invalid expression should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID:     "test-room",
+					UserID:     "user-id",
+					Expression: "2d6+",
+				}
+			},
+			expErr: true,
+		},
 
-func TestServiceListDiceRolls(t *testing.T) {
-	tests := map[string]struct {
-		config  dice.ServiceConfig
-		mock    func(diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository)
-		req     func() dice.ListDiceRollsRequest
-		expResp func() *dice.ListDiceRollsResponse
-		expErr  bool
-	}{
-		"Having a list dice roll request without room should fail.": {
-			mock: func(diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository) {
+		"Having a dice roll request with an expression with invalid dice should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
 			},
-			req: func() dice.ListDiceRollsRequest {
-				return dice.ListDiceRollsRequest{
-					RoomID: "",
-					UserID: "user-id",
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID:     "test-room",
+					UserID:     "user-id",
+					Expression: "1d1",
 				}
 			},
 			expErr: true,
 		},
 
-		"Having a list dice roll request with an error listing dice rolls, should fail.": {
-			mock: func(diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository) {
-				diceRollRepo.On("ListDiceRolls", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error"))
+		"Having a dice roll request with an expression with too much dice should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
 			},
-			req: func() dice.ListDiceRollsRequest {
-				return dice.ListDiceRollsRequest{
-					RoomID: "room-id",
-					UserID: "user-id",
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID:     "test-room",
+					UserID:     "user-id",
+					Expression: "60d6+60d8",
 				}
 			},
 			expErr: true,
 		},
 
-		"Having a list dice roll request should list dice rolls.": {
-			mock: func(diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository) {
-				expOpts := storage.ListDiceRollsOpts{
-					RoomID: "room-id",
-					UserID: "user-id",
+		"Having a dice roll request with an expression it should roll all the dice at once, evaluate the total, store and notify.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+				roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
+				userRepo.On("UserExists", mock.Anything, "user-id").Once().Return(true, nil)
+
+				// Expected single roll call with all the expression dice.
+				expRoll := &model.DiceRoll{
+					ID:         "test",
+					CreatedAt:  t0,
+					RoomID:     "test-room",
+					UserID:     "user-id",
+					Expression: "4d6kh3 + 1d4 - 1",
+					Dice: []model.DieRoll{
+						{ID: "test", Type: model.DieTypeD6},
+						{ID: "test", Type: model.DieTypeD6},
+						{ID: "test", Type: model.DieTypeD6},
+						{ID: "test", Type: model.DieTypeD6},
+						{ID: "test", Type: model.DieTypeD4},
+					},
 				}
-				dr := &storage.DiceRollList{
-					Items: []model.DiceRoll{
-						{ID: "dr1"},
-						{ID: "dr2"},
+				roller.On("Roll", mock.Anything, expRoll).Once().Return(nil).Run(func(args mock.Arguments) {
+					dr := args.Get(1).(*model.DiceRoll)
+					for i, side := range []uint{5, 1, 6, 3, 2} {
+						dr.Dice[i].Side = side
+					}
+				})
+
+				exp := model.DiceRoll{
+					ID:         "test",
+					CreatedAt:  t0,
+					RoomID:     "test-room",
+					UserID:     "user-id",
+					Expression: "4d6kh3 + 1d4 - 1",
+					Total:      15,
+					Dice: []model.DieRoll{
+						{ID: "test", Type: model.DieTypeD6, Side: 5},
+						{ID: "test", Type: model.DieTypeD6, Side: 1, Status: model.DieRollStatusDropped},
+						{ID: "test", Type: model.DieTypeD6, Side: 6},
+						{ID: "test", Type: model.DieTypeD6, Side: 3},
+						{ID: "test", Type: model.DieTypeD4, Side: 2},
 					},
 				}
-				diceRollRepo.On("ListDiceRolls", mock.Anything, mock.Anything, expOpts).Once().Return(dr, nil)
+				diceRollRepo.On("CreateDiceRoll", mock.Anything, exp).Once().Return(nil)
+				expEv := model.EventDiceRollCreated{DiceRoll: exp}
+				notifier.On("NotifyDiceRollCreated", mock.Anything, expEv).Once().Return(nil)
 			},
-			req: func() dice.ListDiceRollsRequest {
-				return dice.ListDiceRollsRequest{
-					RoomID: "room-id",
-					UserID: "user-id",
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID:     "test-room",
+					UserID:     "user-id",
+					Expression: " 4d6kh3 + 1d4 - 1 ",
 				}
 			},
-			expResp: func() *dice.ListDiceRollsResponse {
-				return &dice.ListDiceRollsResponse{
-					DiceRolls: []model.DiceRoll{
-						{ID: "dr1"},
-						{ID: "dr2"},
+			expResp: func() *dice.CreateDiceRollResponse {
+				return &dice.CreateDiceRollResponse{
+					DiceRoll: model.DiceRoll{
+						ID:         "test",
+						CreatedAt:  t0,
+						RoomID:     "test-room",
+						UserID:     "user-id",
+						Expression: "4d6kh3 + 1d4 - 1",
+						Total:      15,
+						Dice: []model.DieRoll{
+							{ID: "test", Type: model.DieTypeD6, Side: 5},
+							{ID: "test", Type: model.DieTypeD6, Side: 1, Status: model.DieRollStatusDropped},
+							{ID: "test", Type: model.DieTypeD6, Side: 6},
+							{ID: "test", Type: model.DieTypeD6, Side: 3},
+							{ID: "test", Type: model.DieTypeD4, Side: 2},
+						},
 					},
 				}
 			},
 		},
 
-		"Not having pagination should set safe defaults.": {
-			mock: func(diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository) {
-				expPageOpts := model.PaginationOpts{
-					Order: model.PaginationOrderDesc,
-					Size:  100,
+		"Having a dice roll request with modifiers and an expression should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID:     "test-room",
+					UserID:     "user-id",
+					Expression: "4d6",
+					Modifiers:  dice.DiceRollModifiers{Explode: true},
 				}
-				dr := &storage.DiceRollList{}
-				diceRollRepo.On("ListDiceRolls", mock.Anything, expPageOpts, mock.Anything).Once().Return(dr, nil)
 			},
-			req: func() dice.ListDiceRollsRequest {
-				return dice.ListDiceRollsRequest{
-					RoomID: "room-id",
+			expErr: true,
+		},
+
+		"Having a dice roll request with multiple keep/drop modifiers should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID:    "test-room",
+					UserID:    "user-id",
+					Dice:      []model.DieType{model.DieTypeD6, model.DieTypeD6},
+					Modifiers: dice.DiceRollModifiers{KeepHighest: 1, DropLowest: 1},
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a dice roll request keeping more dice than rolled should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID:    "test-room",
+					UserID:    "user-id",
+					Dice:      []model.DieType{model.DieTypeD6, model.DieTypeD6},
+					Modifiers: dice.DiceRollModifiers{KeepHighest: 3},
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a dice roll request with a pool and an expression should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID:     "test-room",
+					UserID:     "user-id",
+					Expression: "4d10",
+					Pool:       dice.DicePool{Target: 8},
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a dice roll request with pool rules without target number should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID: "test-room",
+					UserID: "user-id",
+					Dice:   []model.DieType{model.DieTypeD10, model.DieTypeD10},
+					Pool:   dice.DicePool{OnesCancel: true},
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a dice roll request with a check and a pool should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID: "test-room",
+					UserID: "user-id",
+					Dice:   []model.DieType{model.DieTypeD10, model.DieTypeD10},
+					Pool:   dice.DicePool{Target: 8},
+					Check:  dice.DiceCheck{Target: 1, Comparison: model.DiceRollCheckComparisonGreaterOrEqual},
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a dice roll request with a check target without comparison should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID:     "test-room",
+					UserID:     "user-id",
+					Expression: "1d20+5",
+					Check:      dice.DiceCheck{Target: 15},
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a dice roll request with an invalid check comparison should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID: "test-room",
+					UserID: "user-id",
+					Dice:   []model.DieType{model.DieTypeD20},
+					Check:  dice.DiceCheck{Target: 15, Comparison: 99},
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a dice roll request with a too long label should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID: "test-room",
+					UserID: "user-id",
+					Dice:   []model.DieType{model.DieTypeD20},
+					Label:  strings.Repeat("a", 101),
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a dice roll request with a modifier and an expression should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID:     "test-room",
+					UserID:     "user-id",
+					Expression: "1d20",
+					Modifier:   5,
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a dice roll request with a modifier and a pool should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID:   "test-room",
+					UserID:   "user-id",
+					Dice:     []model.DieType{model.DieTypeD10},
+					Pool:     dice.DicePool{Target: 8},
+					Modifier: 1,
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a dice roll request with a too big modifier should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID:   "test-room",
+					UserID:   "user-id",
+					Dice:     []model.DieType{model.DieTypeD20},
+					Modifier: -1001,
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a dice roll request with advantage without a D20 should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID:    "test-room",
+					UserID:    "user-id",
+					Dice:      []model.DieType{model.DieTypeD6},
+					Advantage: dice.AdvantageModeAdvantage,
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a dice roll request with advantage and multiple D20 should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID:    "test-room",
+					UserID:    "user-id",
+					Dice:      []model.DieType{model.DieTypeD20, model.DieTypeD20},
+					Advantage: dice.AdvantageModeDisadvantage,
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a dice roll request with advantage and an expression should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID:     "test-room",
+					UserID:     "user-id",
+					Expression: "1d20",
+					Advantage:  dice.AdvantageModeAdvantage,
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a dice roll request with advantage and modifiers should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID:    "test-room",
+					UserID:    "user-id",
+					Dice:      []model.DieType{model.DieTypeD20},
+					Modifiers: dice.DiceRollModifiers{RerollBelow: 2},
+					Advantage: dice.AdvantageModeAdvantage,
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a whispered dice roll request without whispered users should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID:     "test-room",
+					UserID:     "user-id",
+					Dice:       []model.DieType{model.DieTypeD20},
+					Visibility: model.DiceRollVisibilityWhisper,
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a not whispered dice roll request with whispered users should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID:         "test-room",
+					UserID:         "user-id",
+					Dice:           []model.DieType{model.DieTypeD20},
+					Visibility:     model.DiceRollVisibilityGameMaster,
+					WhisperUserIDs: []string{"user-2"},
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a whispered dice roll request with users that are not from the room should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+				roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
+				userRepo.On("UserExists", mock.Anything, "user-id").Once().Return(true, nil)
+				users := &storage.UserList{Items: []model.User{{ID: "user-id"}, {ID: "user-2"}}}
+				userRepo.On("ListRoomUsers", mock.Anything, "test-room").Once().Return(users, nil)
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID:         "test-room",
+					UserID:         "user-id",
+					Dice:           []model.DieType{model.DieTypeD20},
+					Visibility:     model.DiceRollVisibilityWhisper,
+					WhisperUserIDs: []string{"user-2", "user-3"},
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a whispered dice roll request, it should set the visibility and the whispered users.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+				roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
+				userRepo.On("UserExists", mock.Anything, "user-id").Once().Return(true, nil)
+				users := &storage.UserList{Items: []model.User{{ID: "user-id"}, {ID: "user-2"}, {ID: "user-3"}}}
+				userRepo.On("ListRoomUsers", mock.Anything, "test-room").Once().Return(users, nil)
+				roller.On("Roll", mock.Anything, mock.Anything).Once().Return(nil).Run(func(args mock.Arguments) {
+					dr := args.Get(1).(*model.DiceRoll)
+					dr.Dice[0].Side = 14
+				})
+
+				exp := model.DiceRoll{
+					ID:        "test",
+					CreatedAt: t0,
+					RoomID:    "test-room",
+					UserID:    "user-id",
+					Total:     14,
+					Dice: []model.DieRoll{
+						{ID: "test", Type: model.DieTypeD20, Side: 14},
+					},
+					Visibility:     model.DiceRollVisibilityWhisper,
+					WhisperUserIDs: []string{"user-2", "user-3"},
+				}
+				diceRollRepo.On("CreateDiceRoll", mock.Anything, exp).Once().Return(nil)
+				expEv := model.EventDiceRollCreated{DiceRoll: exp}
+				notifier.On("NotifyDiceRollCreated", mock.Anything, expEv).Once().Return(nil)
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID:         "test-room",
+					UserID:         "user-id",
+					Dice:           []model.DieType{model.DieTypeD20},
+					Visibility:     model.DiceRollVisibilityWhisper,
+					WhisperUserIDs: []string{"user-3", "user-2", "user-3"},
+				}
+			},
+			expResp: func() *dice.CreateDiceRollResponse {
+				return &dice.CreateDiceRollResponse{
+					DiceRoll: model.DiceRoll{
+						ID:        "test",
+						CreatedAt: t0,
+						RoomID:    "test-room",
+						UserID:    "user-id",
+						Total:     14,
+						Dice: []model.DieRoll{
+							{ID: "test", Type: model.DieTypeD20, Side: 14},
+						},
+						Visibility:     model.DiceRollVisibilityWhisper,
+						WhisperUserIDs: []string{"user-2", "user-3"},
+					},
+				}
+			},
+		},
+
+		"Having a dice roll request with a label and a modifier, it should set the label and add the modifier to the total.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+				roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
+				userRepo.On("UserExists", mock.Anything, "user-id").Once().Return(true, nil)
+				roller.On("Roll", mock.Anything, mock.Anything).Once().Return(nil).Run(func(args mock.Arguments) {
+					dr := args.Get(1).(*model.DiceRoll)
+					dr.Dice[0].Side = 3
+					dr.Dice[1].Side = 14
+				})
+
+				exp := model.DiceRoll{
+					ID:        "test",
+					CreatedAt: t0,
+					RoomID:    "test-room",
+					UserID:    "user-id",
+					Label:     "Stealth check",
+					Modifier:  -2,
+					Total:     15,
+					Dice: []model.DieRoll{
+						{ID: "test", Type: model.DieTypeD4, Side: 3},
+						{ID: "test", Type: model.DieTypeD20, Side: 14},
+					},
+				}
+				diceRollRepo.On("CreateDiceRoll", mock.Anything, exp).Once().Return(nil)
+				expEv := model.EventDiceRollCreated{DiceRoll: exp}
+				notifier.On("NotifyDiceRollCreated", mock.Anything, expEv).Once().Return(nil)
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID:   "test-room",
+					UserID:   "user-id",
+					Dice:     []model.DieType{model.DieTypeD4, model.DieTypeD20},
+					Label:    " Stealth check ",
+					Modifier: -2,
+				}
+			},
+			expResp: func() *dice.CreateDiceRollResponse {
+				return &dice.CreateDiceRollResponse{
+					DiceRoll: model.DiceRoll{
+						ID:        "test",
+						CreatedAt: t0,
+						RoomID:    "test-room",
+						UserID:    "user-id",
+						Label:     "Stealth check",
+						Modifier:  -2,
+						Total:     15,
+						Dice: []model.DieRoll{
+							{ID: "test", Type: model.DieTypeD4, Side: 3},
+							{ID: "test", Type: model.DieTypeD20, Side: 14},
+						},
+					},
+				}
+			},
+		},
+
+		"Having a dice roll request with modifiers, it should reroll, explode, keep the dice and record all the dice with their status.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+				roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
+				userRepo.On("UserExists", mock.Anything, "user-id").Once().Return(true, nil)
+
+				// The roller rolls all the dice each time, like the deterministic rollers.
+				sides := []uint{1, 6, 3, 4, 6, 2}
+				roller.On("Roll", mock.Anything, mock.Anything).Times(4).Return(nil).Run(func(args mock.Arguments) {
+					dr := args.Get(1).(*model.DiceRoll)
+					for i := range dr.Dice {
+						dr.Dice[i].Side = sides[i]
+					}
+				})
+
+				exp := model.DiceRoll{
+					ID:        "test",
+					CreatedAt: t0,
+					RoomID:    "test-room",
+					UserID:    "user-id",
+					Total:     12,
+					Dice: []model.DieRoll{
+						{ID: "test", Type: model.DieTypeD6, Side: 1, Status: model.DieRollStatusRerolled},
+						{ID: "test", Type: model.DieTypeD6, Side: 6, Status: model.DieRollStatusExploded},
+						{ID: "test", Type: model.DieTypeD6, Side: 3, Status: model.DieRollStatusDropped},
+						{ID: "test", Type: model.DieTypeD6, Side: 4, Status: model.DieRollStatusDropped},
+						{ID: "test", Type: model.DieTypeD6, Side: 6, Status: model.DieRollStatusExploded},
+						{ID: "test", Type: model.DieTypeD6, Side: 2, Status: model.DieRollStatusDropped},
+					},
+				}
+				diceRollRepo.On("CreateDiceRoll", mock.Anything, exp).Once().Return(nil)
+				expEv := model.EventDiceRollCreated{DiceRoll: exp}
+				notifier.On("NotifyDiceRollCreated", mock.Anything, expEv).Once().Return(nil)
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID: "test-room",
 					UserID: "user-id",
+					Dice:   []model.DieType{model.DieTypeD6, model.DieTypeD6, model.DieTypeD6},
+					Modifiers: dice.DiceRollModifiers{
+						RerollBelow: 2,
+						Explode:     true,
+						KeepHighest: 2,
+					},
 				}
 			},
-			expResp: func() *dice.ListDiceRollsResponse {
-				return &dice.ListDiceRollsResponse{}
+			expResp: func() *dice.CreateDiceRollResponse {
+				return &dice.CreateDiceRollResponse{
+					DiceRoll: model.DiceRoll{
+						ID:        "test",
+						CreatedAt: t0,
+						RoomID:    "test-room",
+						UserID:    "user-id",
+						Total:     12,
+						Dice: []model.DieRoll{
+							{ID: "test", Type: model.DieTypeD6, Side: 1, Status: model.DieRollStatusRerolled},
+							{ID: "test", Type: model.DieTypeD6, Side: 6, Status: model.DieRollStatusExploded},
+							{ID: "test", Type: model.DieTypeD6, Side: 3, Status: model.DieRollStatusDropped},
+							{ID: "test", Type: model.DieTypeD6, Side: 4, Status: model.DieRollStatusDropped},
+							{ID: "test", Type: model.DieTypeD6, Side: 6, Status: model.DieRollStatusExploded},
+							{ID: "test", Type: model.DieTypeD6, Side: 2, Status: model.DieRollStatusDropped},
+						},
+					},
+				}
+			},
+		},
+
+		"Having a dice roll request and failing the dice roll process, it should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+				roomRepo.On("GetRoom", mock.Anything, mock.Anything).Once().Return(&model.Room{ID: "test-room"}, nil)
+				userRepo.On("UserExists", mock.Anything, mock.Anything).Once().Return(true, nil)
+				roller.On("Roll", mock.Anything, mock.Anything).Once().Return(fmt.Errorf("wanted error"))
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID: "test-room",
+					UserID: "user-id",
+					Dice:   []model.DieType{model.DieTypeD6},
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a dice roll request if storage fails, it should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+				roomRepo.On("GetRoom", mock.Anything, mock.Anything).Once().Return(&model.Room{ID: "test-room"}, nil)
+				userRepo.On("UserExists", mock.Anything, mock.Anything).Once().Return(true, nil)
+				roller.On("Roll", mock.Anything, mock.Anything).Once().Return(nil)
+				diceRollRepo.On("CreateDiceRoll", mock.Anything, mock.Anything).Once().Return(errors.New("wanted error"))
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID: "test-room",
+					UserID: "user-id",
+					Dice:   []model.DieType{model.DieTypeD6},
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a dice roll request if notification fails, it should fail.": {
+			mock: func(roller *dicemock.Roller, diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository, userRepo *storagemock.UserRepository, notifier *eventmock.Notifier, dieTypeRepo *storagemock.CustomDieTypeRepository) {
+				roomRepo.On("GetRoom", mock.Anything, mock.Anything).Once().Return(&model.Room{ID: "test-room"}, nil)
+				userRepo.On("UserExists", mock.Anything, mock.Anything).Once().Return(true, nil)
+				roller.On("Roll", mock.Anything, mock.Anything).Once().Return(nil)
+				diceRollRepo.On("CreateDiceRoll", mock.Anything, mock.Anything).Once().Return(nil)
+				notifier.On("NotifyDiceRollCreated", mock.Anything, mock.Anything).Once().Return(errors.New("wanted error"))
+
+			},
+			req: func() dice.CreateDiceRollRequest {
+				return dice.CreateDiceRollRequest{
+					RoomID: "test-room",
+					UserID: "user-id",
+					Dice:   []model.DieType{model.DieTypeD6},
+				}
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks
+			mrol := &dicemock.Roller{}
+			mdrrep := &storagemock.DiceRollRepository{}
+			mrrep := &storagemock.RoomRepository{}
+			murep := &storagemock.UserRepository{}
+			mevn := &eventmock.Notifier{}
+			mdtrep := &storagemock.CustomDieTypeRepository{}
+			test.mock(mrol, mdrrep, mrrep, murep, mevn, mdtrep)
+
+			test.config.Roller = mrol
+			test.config.DiceRollRepository = mdrrep
+			test.config.RoomRepository = mrrep
+			test.config.UserRepository = murep
+			test.config.CustomDieTypeRepository = mdtrep
+			test.config.ServerSeedRepository = &storagemock.ServerSeedRepository{}
+			test.config.EventNotifier = mevn
+			test.config.EventSubscriber = &eventmock.Subscriber{}
+			test.config.IDGenerator = func() string { return "test" }
+			test.config.TimeNowFunc = func() time.Time { return t0 }
+
+			svc, err := dice.NewService(test.config)
+			require.NoError(err)
+
+			gotResp, err := svc.CreateDiceRoll(context.TODO(), test.req())
+
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expResp(), gotResp)
+			}
+		})
+	}
+}
+
+func TestServiceListDiceRolls(t *testing.T) {
+	tests := map[string]struct {
+		config  dice.ServiceConfig
+		mock    func(diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository)
+		req     func() dice.ListDiceRollsRequest
+		expResp func() *dice.ListDiceRollsResponse
+		expErr  bool
+	}{
+		"Having a list dice roll request without room should fail.": {
+			mock: func(diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository) {
+			},
+			req: func() dice.ListDiceRollsRequest {
+				return dice.ListDiceRollsRequest{
+					RoomID: "",
+					UserID: "user-id",
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a list dice roll request with an error listing dice rolls, should fail.": {
+			mock: func(diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository) {
+				diceRollRepo.On("ListDiceRolls", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error"))
+			},
+			req: func() dice.ListDiceRollsRequest {
+				return dice.ListDiceRollsRequest{
+					RoomID: "room-id",
+					UserID: "user-id",
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a list dice roll request should list dice rolls.": {
+			mock: func(diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository) {
+				expOpts := storage.ListDiceRollsOpts{
+					RoomID: "room-id",
+					UserID: "user-id",
+				}
+				dr := &storage.DiceRollList{
+					Items: []model.DiceRoll{
+						{ID: "dr1"},
+						{ID: "dr2"},
+					},
+				}
+				diceRollRepo.On("ListDiceRolls", mock.Anything, mock.Anything, expOpts).Once().Return(dr, nil)
+			},
+			req: func() dice.ListDiceRollsRequest {
+				return dice.ListDiceRollsRequest{
+					RoomID: "room-id",
+					UserID: "user-id",
+				}
+			},
+			expResp: func() *dice.ListDiceRollsResponse {
+				return &dice.ListDiceRollsResponse{
+					DiceRolls: []model.DiceRoll{
+						{ID: "dr1"},
+						{ID: "dr2"},
+					},
+				}
+			},
+		},
+
+		"Not having pagination should set safe defaults.": {
+			mock: func(diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository) {
+				expPageOpts := model.PaginationOpts{
+					Order: model.PaginationOrderDesc,
+					Size:  100,
+				}
+				dr := &storage.DiceRollList{}
+				diceRollRepo.On("ListDiceRolls", mock.Anything, expPageOpts, mock.Anything).Once().Return(dr, nil)
+			},
+			req: func() dice.ListDiceRollsRequest {
+				return dice.ListDiceRollsRequest{
+					RoomID: "room-id",
+					UserID: "user-id",
+				}
+			},
+			expResp: func() *dice.ListDiceRollsResponse {
+				return &dice.ListDiceRollsResponse{}
+			},
+		},
+
+		"Having custom pagination should use it.": {
+			mock: func(diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository) {
+				expPageOpts := model.PaginationOpts{
+					Cursor: "threepwood",
+					Order:  model.PaginationOrderAsc,
+					Size:   93,
+				}
+				dr := &storage.DiceRollList{}
+				diceRollRepo.On("ListDiceRolls", mock.Anything, expPageOpts, mock.Anything).Once().Return(dr, nil)
+			},
+			req: func() dice.ListDiceRollsRequest {
+				return dice.ListDiceRollsRequest{
+					RoomID: "room-id",
+					UserID: "user-id",
+					PageOpts: model.PaginationOpts{
+						Cursor: "threepwood",
+						Order:  model.PaginationOrderAsc,
+						Size:   93,
+					},
+				}
+			},
+			expResp: func() *dice.ListDiceRollsResponse {
+				return &dice.ListDiceRollsResponse{}
+			},
+		},
+
+		"Having a pagination return from the repository, should be returned.": {
+			mock: func(diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository) {
+				dr := &storage.DiceRollList{
+					Cursors: model.PaginationCursors{
+						FirstCursor: "first",
+						LastCursor:  "second",
+						HasNext:     true,
+						HasPrevious: true,
+					},
+				}
+				diceRollRepo.On("ListDiceRolls", mock.Anything, mock.Anything, mock.Anything).Once().Return(dr, nil)
+			},
+			req: func() dice.ListDiceRollsRequest {
+				return dice.ListDiceRollsRequest{
+					RoomID: "room-id",
+					UserID: "user-id",
+					PageOpts: model.PaginationOpts{
+						Cursor: "threepwood",
+						Order:  model.PaginationOrderAsc,
+						Size:   93,
+					},
+				}
+			},
+			expResp: func() *dice.ListDiceRollsResponse {
+				return &dice.ListDiceRollsResponse{
+					Cursors: model.PaginationCursors{
+						FirstCursor: "first",
+						LastCursor:  "second",
+						HasNext:     true,
+						HasPrevious: true,
+					},
+				}
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks
+			mdrrep := &storagemock.DiceRollRepository{}
+			mrrep := &storagemock.RoomRepository{}
+			test.mock(mdrrep, mrrep)
+
+			test.config.Roller = &dicemock.Roller{}
+			test.config.DiceRollRepository = mdrrep
+			test.config.RoomRepository = mrrep
+			test.config.UserRepository = &storagemock.UserRepository{}
+			test.config.CustomDieTypeRepository = &storagemock.CustomDieTypeRepository{}
+			test.config.ServerSeedRepository = &storagemock.ServerSeedRepository{}
+			test.config.EventNotifier = &eventmock.Notifier{}
+			test.config.EventSubscriber = &eventmock.Subscriber{}
+			test.config.IDGenerator = func() string { return "test" }
+
+			svc, err := dice.NewService(test.config)
+			require.NoError(err)
+
+			gotResp, err := svc.ListDiceRolls(context.TODO(), test.req())
+
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expResp(), gotResp)
+			}
+		})
+	}
+}
+
+func TestSubscribeDiceRollCreated(t *testing.T) {
+	tests := map[string]struct {
+		config dice.ServiceConfig
+		mock   func(roomRepo *storagemock.RoomRepository, eventSubscriber *eventmock.Subscriber)
+		req    func() dice.SubscribeDiceRollCreatedRequest
+		expErr bool
+	}{
+		"Having a subscription request without room should fail.": {
+			mock: func(roomRepo *storagemock.RoomRepository, eventSubscriber *eventmock.Subscriber) {},
+			req: func() dice.SubscribeDiceRollCreatedRequest {
+				return dice.SubscribeDiceRollCreatedRequest{
+					RoomID:       "",
+					EventHandler: func(context.Context, model.EventDiceRollCreated) error { return nil },
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a subscription request without event handler should fail.": {
+			mock: func(roomRepo *storagemock.RoomRepository, eventSubscriber *eventmock.Subscriber) {},
+			req: func() dice.SubscribeDiceRollCreatedRequest {
+				return dice.SubscribeDiceRollCreatedRequest{
+					RoomID: "room-id",
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a subscription request that fails while checking the room, should fail.": {
+			mock: func(roomRepo *storagemock.RoomRepository, eventSubscriber *eventmock.Subscriber) {
+				roomRepo.On("RoomExists", mock.Anything, "room-id").Once().Return(false, errors.New("wanted error"))
+			},
+			req: func() dice.SubscribeDiceRollCreatedRequest {
+				return dice.SubscribeDiceRollCreatedRequest{
+					RoomID:       "room-id",
+					EventHandler: func(context.Context, model.EventDiceRollCreated) error { return nil },
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a subscription request of an non-existent room, should fail.": {
+			mock: func(roomRepo *storagemock.RoomRepository, eventSubscriber *eventmock.Subscriber) {
+				roomRepo.On("RoomExists", mock.Anything, "room-id").Once().Return(false, nil)
+			},
+			req: func() dice.SubscribeDiceRollCreatedRequest {
+				return dice.SubscribeDiceRollCreatedRequest{
+					RoomID:       "room-id",
+					EventHandler: func(context.Context, model.EventDiceRollCreated) error { return nil },
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a subscription request with an error while subscribing to the bus to the event bus.": {
+			mock: func(roomRepo *storagemock.RoomRepository, eventSubscriber *eventmock.Subscriber) {
+				roomRepo.On("RoomExists", mock.Anything, "room-id").Once().Return(true, nil)
+				eventSubscriber.On("SubscribeDiceRollCreated", mock.Anything, "test", "room-id", mock.Anything).Once().Return(errors.New("wanted error"))
+			},
+			req: func() dice.SubscribeDiceRollCreatedRequest {
+				return dice.SubscribeDiceRollCreatedRequest{
+					RoomID:       "room-id",
+					EventHandler: func(context.Context, model.EventDiceRollCreated) error { return nil },
+				}
+			},
+			expErr: true,
+		},
+
+		"Having a subscription request should subscribe to the event bus, and the user should (be able to) call unsubscribe.": {
+			mock: func(roomRepo *storagemock.RoomRepository, eventSubscriber *eventmock.Subscriber) {
+				roomRepo.On("RoomExists", mock.Anything, "room-id").Once().Return(true, nil)
+				eventSubscriber.On("SubscribeDiceRollCreated", mock.Anything, "test", "room-id", mock.Anything).Once().Return(nil)
+				eventSubscriber.On("UnsubscribeDiceRollCreated", mock.Anything, "test", "room-id").Once().Return(nil)
+			},
+			req: func() dice.SubscribeDiceRollCreatedRequest {
+				return dice.SubscribeDiceRollCreatedRequest{
+					RoomID:       "room-id",
+					EventHandler: func(context.Context, model.EventDiceRollCreated) error { return nil },
+				}
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks
+			mrrep := &storagemock.RoomRepository{}
+			mevsub := &eventmock.Subscriber{}
+			test.mock(mrrep, mevsub)
+
+			test.config.Roller = &dicemock.Roller{}
+			test.config.DiceRollRepository = &storagemock.DiceRollRepository{}
+			test.config.RoomRepository = mrrep
+			test.config.UserRepository = &storagemock.UserRepository{}
+			test.config.CustomDieTypeRepository = &storagemock.CustomDieTypeRepository{}
+			test.config.ServerSeedRepository = &storagemock.ServerSeedRepository{}
+			test.config.EventNotifier = &eventmock.Notifier{}
+			test.config.EventSubscriber = mevsub
+			test.config.IDGenerator = func() string { return "test" }
+
+			svc, err := dice.NewService(test.config)
+			require.NoError(err)
+
+			gotResp, err := svc.SubscribeDiceRollCreated(context.TODO(), test.req())
+
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.NotNil(gotResp)
+				unsubErr := gotResp.UnsubscribeFunc()
+				assert.NoError(unsubErr)
+			}
+		})
+	}
+}
+
+func TestServiceCreateDiceRollPool(t *testing.T) {
+	tests := map[string]struct {
+		dice    []model.DieType
+		sides   []uint
+		pool    dice.DicePool
+		expPool model.DicePoolResult
+	}{
+		"A dice pool without dice reaching the target number should fail.": {
+			dice:    []model.DieType{model.DieTypeD10, model.DieTypeD10, model.DieTypeD10},
+			sides:   []uint{1, 5, 7},
+			pool:    dice.DicePool{Target: 8},
+			expPool: model.DicePoolResult{Successes: 0, Outcome: model.DicePoolOutcomeFailure},
+		},
+
+		"A dice pool should count the dice reaching the target number as successes.": {
+			dice:    []model.DieType{model.DieTypeD10, model.DieTypeD10, model.DieTypeD10, model.DieTypeD10},
+			sides:   []uint{8, 10, 1, 7},
+			pool:    dice.DicePool{Target: 8},
+			expPool: model.DicePoolResult{Successes: 2, Outcome: model.DicePoolOutcomeSuccess},
+		},
+
+		"A dice pool with ones cancel, should cancel a success with each one.": {
+			dice:    []model.DieType{model.DieTypeD10, model.DieTypeD10, model.DieTypeD10, model.DieTypeD10},
+			sides:   []uint{8, 10, 1, 7},
+			pool:    dice.DicePool{Target: 8, OnesCancel: true},
+			expPool: model.DicePoolResult{Successes: 1, Outcome: model.DicePoolOutcomeSuccess},
+		},
+
+		"A dice pool with ones cancel, should not have negative successes.": {
+			dice:    []model.DieType{model.DieTypeD10, model.DieTypeD10, model.DieTypeD10},
+			sides:   []uint{9, 1, 1},
+			pool:    dice.DicePool{Target: 8, OnesCancel: true},
+			expPool: model.DicePoolResult{Successes: 0, Outcome: model.DicePoolOutcomeFailure},
+		},
+
+		"A dice pool with botch, without successes and more ones than successes should be a critical failure.": {
+			dice:    []model.DieType{model.DieTypeD10, model.DieTypeD10, model.DieTypeD10},
+			sides:   []uint{9, 1, 1},
+			pool:    dice.DicePool{Target: 8, OnesCancel: true, Botch: true},
+			expPool: model.DicePoolResult{Successes: 0, Outcome: model.DicePoolOutcomeCriticalFailure},
+		},
+
+		"A dice pool with botch, without ones should be a failure.": {
+			dice:    []model.DieType{model.DieTypeD10, model.DieTypeD10},
+			sides:   []uint{2, 3},
+			pool:    dice.DicePool{Target: 8, Botch: true},
+			expPool: model.DicePoolResult{Successes: 0, Outcome: model.DicePoolOutcomeFailure},
+		},
+
+		"A dice pool with critical dice, having enough dice with the max side should be a critical success.": {
+			dice:    []model.DieType{model.DieTypeD6, model.DieTypeD6, model.DieTypeD6},
+			sides:   []uint{6, 2, 6},
+			pool:    dice.DicePool{Target: 4, CriticalDice: 2},
+			expPool: model.DicePoolResult{Successes: 2, Outcome: model.DicePoolOutcomeCriticalSuccess},
+		},
+
+		"A dice pool with critical dice, not having enough dice with the max side should be a success.": {
+			dice:    []model.DieType{model.DieTypeD6, model.DieTypeD6, model.DieTypeD6},
+			sides:   []uint{6, 2, 5},
+			pool:    dice.DicePool{Target: 4, CriticalDice: 2},
+			expPool: model.DicePoolResult{Successes: 2, Outcome: model.DicePoolOutcomeSuccess},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mrol := &dicemock.Roller{}
+			mrol.On("Roll", mock.Anything, mock.Anything).Once().Return(nil).Run(func(args mock.Arguments) {
+				dr := args.Get(1).(*model.DiceRoll)
+				for i := range dr.Dice {
+					dr.Dice[i].Side = test.sides[i]
+				}
+			})
+			mrrep := &storagemock.RoomRepository{}
+			mrrep.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
+			murep := &storagemock.UserRepository{}
+			murep.On("UserExists", mock.Anything, "user-id").Once().Return(true, nil)
+			mdrrep := &storagemock.DiceRollRepository{}
+			mdrrep.On("CreateDiceRoll", mock.Anything, mock.Anything).Once().Return(nil)
+			mevn := &eventmock.Notifier{}
+			mevn.On("NotifyDiceRollCreated", mock.Anything, mock.Anything).Once().Return(nil)
+
+			svc, err := dice.NewService(dice.ServiceConfig{
+				Roller:                  mrol,
+				DiceRollRepository:      mdrrep,
+				RoomRepository:          mrrep,
+				UserRepository:          murep,
+				CustomDieTypeRepository: &storagemock.CustomDieTypeRepository{},
+				ServerSeedRepository:    &storagemock.ServerSeedRepository{},
+				EventNotifier:           mevn,
+				EventSubscriber:         &eventmock.Subscriber{},
+			})
+			require.NoError(err)
+
+			gotResp, err := svc.CreateDiceRoll(context.TODO(), dice.CreateDiceRollRequest{
+				RoomID: "test-room",
+				UserID: "user-id",
+				Dice:   test.dice,
+				Pool:   test.pool,
+			})
+			require.NoError(err)
+
+			assert.Equal(&test.expPool, gotResp.DiceRoll.Pool)
+			assert.Equal(test.expPool.Successes, gotResp.DiceRoll.Total)
+		})
+	}
+}
+
+func TestServiceCreateDiceRollCheck(t *testing.T) {
+	tests := map[string]struct {
+		dice     []model.DieType
+		sides    []uint
+		modifier int
+		check    dice.DiceCheck
+		expCheck model.DiceRollCheck
+	}{
+		"A check reaching the target should succeed.": {
+			dice:     []model.DieType{model.DieTypeD20},
+			sides:    []uint{10},
+			modifier: 5,
+			check:    dice.DiceCheck{Target: 15, Comparison: model.DiceRollCheckComparisonGreaterOrEqual},
+			expCheck: model.DiceRollCheck{Target: 15, Comparison: model.DiceRollCheckComparisonGreaterOrEqual, Margin: 0, Outcome: model.DiceRollCheckOutcomeSuccess},
+		},
+
+		"A check not beating the target on a greater comparison should fail.": {
+			dice:     []model.DieType{model.DieTypeD20},
+			sides:    []uint{10},
+			modifier: 5,
+			check:    dice.DiceCheck{Target: 15, Comparison: model.DiceRollCheckComparisonGreater},
+			expCheck: model.DiceRollCheck{Target: 15, Comparison: model.DiceRollCheckComparisonGreater, Margin: 0, Outcome: model.DiceRollCheckOutcomeFailure},
+		},
+
+		"A check missing the target should fail with a negative margin.": {
+			dice:     []model.DieType{model.DieTypeD20},
+			sides:    []uint{4},
+			modifier: 2,
+			check:    dice.DiceCheck{Target: 15, Comparison: model.DiceRollCheckComparisonGreaterOrEqual},
+			expCheck: model.DiceRollCheck{Target: 15, Comparison: model.DiceRollCheckComparisonGreaterOrEqual, Margin: -9, Outcome: model.DiceRollCheckOutcomeFailure},
+		},
+
+		"A check with a natural max should be a critical success even missing the target.": {
+			dice:     []model.DieType{model.DieTypeD20},
+			sides:    []uint{20},
+			modifier: -5,
+			check:    dice.DiceCheck{Target: 18, Comparison: model.DiceRollCheckComparisonGreaterOrEqual},
+			expCheck: model.DiceRollCheck{Target: 18, Comparison: model.DiceRollCheckComparisonGreaterOrEqual, Margin: -3, Outcome: model.DiceRollCheckOutcomeCriticalSuccess},
+		},
+
+		"A check with a natural min should be a critical failure even reaching the target.": {
+			dice:     []model.DieType{model.DieTypeD20},
+			sides:    []uint{1},
+			modifier: 10,
+			check:    dice.DiceCheck{Target: 5, Comparison: model.DiceRollCheckComparisonGreaterOrEqual},
+			expCheck: model.DiceRollCheck{Target: 5, Comparison: model.DiceRollCheckComparisonGreaterOrEqual, Margin: 6, Outcome: model.DiceRollCheckOutcomeCriticalFailure},
+		},
+
+		"A check with multiple dice should only be critical when all the dice got the natural max.": {
+			dice:     []model.DieType{model.DieTypeD6, model.DieTypeD6},
+			sides:    []uint{6, 5},
+			check:    dice.DiceCheck{Target: 12, Comparison: model.DiceRollCheckComparisonGreaterOrEqual},
+			expCheck: model.DiceRollCheck{Target: 12, Comparison: model.DiceRollCheckComparisonGreaterOrEqual, Margin: -1, Outcome: model.DiceRollCheckOutcomeFailure},
+		},
+
+		"A roll-under check below the target should succeed with a positive margin.": {
+			dice:     []model.DieType{model.DieTypeD100},
+			sides:    []uint{30},
+			check:    dice.DiceCheck{Target: 45, Comparison: model.DiceRollCheckComparisonLessOrEqual},
+			expCheck: model.DiceRollCheck{Target: 45, Comparison: model.DiceRollCheckComparisonLessOrEqual, Margin: 15, Outcome: model.DiceRollCheckOutcomeSuccess},
+		},
+
+		"A roll-under check on the target with a less comparison should fail.": {
+			dice:     []model.DieType{model.DieTypeD100},
+			sides:    []uint{45},
+			check:    dice.DiceCheck{Target: 45, Comparison: model.DiceRollCheckComparisonLess},
+			expCheck: model.DiceRollCheck{Target: 45, Comparison: model.DiceRollCheckComparisonLess, Margin: 0, Outcome: model.DiceRollCheckOutcomeFailure},
+		},
+
+		"A roll-under check with a natural min should be a critical success.": {
+			dice:     []model.DieType{model.DieTypeD100},
+			sides:    []uint{1},
+			check:    dice.DiceCheck{Target: 45, Comparison: model.DiceRollCheckComparisonLessOrEqual},
+			expCheck: model.DiceRollCheck{Target: 45, Comparison: model.DiceRollCheckComparisonLessOrEqual, Margin: 44, Outcome: model.DiceRollCheckOutcomeCriticalSuccess},
+		},
+
+		"A roll-under check with a natural max should be a critical failure.": {
+			dice:     []model.DieType{model.DieTypeD100},
+			sides:    []uint{100},
+			check:    dice.DiceCheck{Target: 45, Comparison: model.DiceRollCheckComparisonLessOrEqual},
+			expCheck: model.DiceRollCheck{Target: 45, Comparison: model.DiceRollCheckComparisonLessOrEqual, Margin: -55, Outcome: model.DiceRollCheckOutcomeCriticalFailure},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mrol := &dicemock.Roller{}
+			mrol.On("Roll", mock.Anything, mock.Anything).Once().Return(nil).Run(func(args mock.Arguments) {
+				dr := args.Get(1).(*model.DiceRoll)
+				for i := range dr.Dice {
+					dr.Dice[i].Side = test.sides[i]
+				}
+			})
+			mrrep := &storagemock.RoomRepository{}
+			mrrep.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
+			murep := &storagemock.UserRepository{}
+			murep.On("UserExists", mock.Anything, "user-id").Once().Return(true, nil)
+			mdrrep := &storagemock.DiceRollRepository{}
+			mdrrep.On("CreateDiceRoll", mock.Anything, mock.Anything).Once().Return(nil)
+			mevn := &eventmock.Notifier{}
+			mevn.On("NotifyDiceRollCreated", mock.Anything, mock.Anything).Once().Return(nil)
+
+			svc, err := dice.NewService(dice.ServiceConfig{
+				Roller:                  mrol,
+				DiceRollRepository:      mdrrep,
+				RoomRepository:          mrrep,
+				UserRepository:          murep,
+				CustomDieTypeRepository: &storagemock.CustomDieTypeRepository{},
+				ServerSeedRepository:    &storagemock.ServerSeedRepository{},
+				EventNotifier:           mevn,
+				EventSubscriber:         &eventmock.Subscriber{},
+			})
+			require.NoError(err)
+
+			gotResp, err := svc.CreateDiceRoll(context.TODO(), dice.CreateDiceRollRequest{
+				RoomID:   "test-room",
+				UserID:   "user-id",
+				Dice:     test.dice,
+				Modifier: test.modifier,
+				Check:    test.check,
+			})
+			require.NoError(err)
+
+			assert.Equal(&test.expCheck, gotResp.DiceRoll.Check)
+		})
+	}
+}
+
+func TestServiceCreateDiceRollRateLimit(t *testing.T) {
+	tests := map[string]struct {
+		mock   func(mul, mrl *ratelimitmock.Limiter)
+		expErr error
+	}{
+		"Having a user that exceeded its dice roll rate, it should be rate limited.": {
+			mock: func(mul, mrl *ratelimitmock.Limiter) {
+				mul.On("Allow", mock.Anything, "user-id").Once().Return(false, nil)
+			},
+			expErr: internalerrors.ErrRateLimited,
+		},
+
+		"Having a room that exceeded its dice roll rate, it should be rate limited.": {
+			mock: func(mul, mrl *ratelimitmock.Limiter) {
+				mul.On("Allow", mock.Anything, "user-id").Once().Return(true, nil)
+				mrl.On("Allow", mock.Anything, "test-room").Once().Return(false, nil)
+			},
+			expErr: internalerrors.ErrRateLimited,
+		},
+
+		"Having an error while checking the rate, it should fail.": {
+			mock: func(mul, mrl *ratelimitmock.Limiter) {
+				mul.On("Allow", mock.Anything, "user-id").Once().Return(false, errors.New("whatever"))
+			},
+			expErr: errors.New("whatever"),
+		},
+
+		"Having a user and room under their dice roll rates, it should roll the dice.": {
+			mock: func(mul, mrl *ratelimitmock.Limiter) {
+				mul.On("Allow", mock.Anything, "user-id").Once().Return(true, nil)
+				mrl.On("Allow", mock.Anything, "test-room").Once().Return(true, nil)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mul := &ratelimitmock.Limiter{}
+			mrl := &ratelimitmock.Limiter{}
+			test.mock(mul, mrl)
+			mrol := &dicemock.Roller{}
+			mrol.On("Roll", mock.Anything, mock.Anything).Maybe().Return(nil)
+			mrrep := &storagemock.RoomRepository{}
+			mrrep.On("GetRoom", mock.Anything, "test-room").Maybe().Return(&model.Room{ID: "test-room"}, nil)
+			murep := &storagemock.UserRepository{}
+			murep.On("UserExists", mock.Anything, "user-id").Maybe().Return(true, nil)
+			mdrrep := &storagemock.DiceRollRepository{}
+			mdrrep.On("CreateDiceRoll", mock.Anything, mock.Anything).Maybe().Return(nil)
+			mevn := &eventmock.Notifier{}
+			mevn.On("NotifyDiceRollCreated", mock.Anything, mock.Anything).Maybe().Return(nil)
+
+			svc, err := dice.NewService(dice.ServiceConfig{
+				Roller:                  mrol,
+				DiceRollRepository:      mdrrep,
+				RoomRepository:          mrrep,
+				UserRepository:          murep,
+				CustomDieTypeRepository: &storagemock.CustomDieTypeRepository{},
+				ServerSeedRepository:    &storagemock.ServerSeedRepository{},
+				EventNotifier:           mevn,
+				EventSubscriber:         &eventmock.Subscriber{},
+				UserRateLimiter:         mul,
+				RoomRateLimiter:         mrl,
+			})
+			require.NoError(err)
+
+			_, err = svc.CreateDiceRoll(context.TODO(), dice.CreateDiceRollRequest{
+				RoomID: "test-room",
+				UserID: "user-id",
+				Dice:   []model.DieType{model.DieTypeD20},
+			})
+
+			if test.expErr != nil && assert.Error(err) {
+				if errors.Is(test.expErr, internalerrors.ErrRateLimited) {
+					assert.ErrorIs(err, test.expErr)
+				} else {
+					assert.ErrorContains(err, test.expErr.Error())
+				}
+				mdrrep.AssertNotCalled(t, "CreateDiceRoll", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(err)
+				mdrrep.AssertExpectations(t)
+			}
+			mul.AssertExpectations(t)
+			mrl.AssertExpectations(t)
+		})
+	}
+}
+
+func TestServiceCreateDiceRolls(t *testing.T) {
+	t0 := time.Now().UTC()
+
+	type mocks struct {
+		roller       *dicemock.Roller
+		roomRepo     *storagemock.RoomRepository
+		userRepo     *storagemock.UserRepository
+		diceRollRepo *storagemock.DiceRollRepository
+		notifier     *eventmock.Notifier
+		userLimiter  *ratelimitmock.Limiter
+		// notified are the dice roll IDs of the sent events, in order.
+		notified *[]string
+	}
+
+	attack := func(roomID string) dice.CreateDiceRollRequest {
+		return dice.CreateDiceRollRequest{RoomID: roomID, UserID: "user-id", Expression: "1d20+5", Label: "Attack"}
+	}
+	expAttack := func(id, dieID string, side uint) model.DiceRoll {
+		return model.DiceRoll{
+			ID:         id,
+			CreatedAt:  t0,
+			RoomID:     "test-room",
+			UserID:     "user-id",
+			Expression: "1d20+5",
+			Label:      "Attack",
+			Total:      int(side) + 5,
+			Dice:       []model.DieRoll{{ID: dieID, Type: model.DieTypeD20, Side: side}},
+		}
+	}
+
+	tests := map[string]struct {
+		mock        func(m mocks)
+		req         dice.CreateDiceRollsRequest
+		expResp     *dice.CreateDiceRollsResponse
+		expNotified []string
+		expErr      error
+	}{
+		"Having a batch without items should fail.": {
+			mock:   func(m mocks) {},
+			req:    dice.CreateDiceRollsRequest{},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a batch with items of different rooms should fail.": {
+			mock: func(m mocks) {},
+			req: dice.CreateDiceRollsRequest{Items: []dice.CreateDiceRollRequest{
+				attack("test-room"),
+				attack("other-room"),
+			}},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a batch with an invalid item should fail.": {
+			mock: func(m mocks) {},
+			req: dice.CreateDiceRollsRequest{Items: []dice.CreateDiceRollRequest{
+				attack("test-room"),
+				{RoomID: "test-room", UserID: "user-id", Expression: "1d20+"},
+			}},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a batch that exceeds the dice roll rate should be rate limited.": {
+			mock: func(m mocks) {
+				m.roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
+				m.userRepo.On("UserExists", mock.Anything, "user-id").Once().Return(true, nil)
+				m.userLimiter.On("AllowN", mock.Anything, "user-id", uint(2)).Once().Return(false, nil)
+			},
+			req: dice.CreateDiceRollsRequest{Items: []dice.CreateDiceRollRequest{
+				attack("test-room"),
+				attack("test-room"),
+			}},
+			expErr: internalerrors.ErrRateLimited,
+		},
+
+		"Having a batch on an archived room should fail without taking the dice roll rate.": {
+			mock: func(m mocks) {
+				m.roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room", Archived: true}, nil)
+			},
+			req: dice.CreateDiceRollsRequest{Items: []dice.CreateDiceRollRequest{
+				attack("test-room"),
+				attack("test-room"),
+			}},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having an error while storing the dice rolls, should fail without sending events.": {
+			mock: func(m mocks) {
+				m.userLimiter.On("AllowN", mock.Anything, "user-id", uint(2)).Once().Return(true, nil)
+				m.roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
+				m.userRepo.On("UserExists", mock.Anything, "user-id").Once().Return(true, nil)
+				m.roller.On("Roll", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					args.Get(1).(*model.DiceRoll).Dice[0].Side = 1
+				})
+				m.diceRollRepo.On("CreateDiceRolls", mock.Anything, mock.Anything).Once().Return(errors.New("whatever"))
+			},
+			req: dice.CreateDiceRollsRequest{Items: []dice.CreateDiceRollRequest{
+				attack("test-room"),
+				attack("test-room"),
+			}},
+			expErr: errors.New("whatever"),
+		},
+
+		"Having a correct batch, it should roll, store and send the events of all the dice rolls in order.": {
+			mock: func(m mocks) {
+				m.userLimiter.On("AllowN", mock.Anything, "user-id", uint(3)).Once().Return(true, nil)
+				m.roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
+				m.userRepo.On("UserExists", mock.Anything, "user-id").Once().Return(true, nil)
+
+				sides := []uint{3, 20, 11}
+				m.roller.On("Roll", mock.Anything, mock.Anything).Times(3).Return(nil).Run(func(args mock.Arguments) {
+					dr := args.Get(1).(*model.DiceRoll)
+					dr.Dice[0].Side = sides[0]
+					sides = sides[1:]
+				})
+
+				exp := []model.DiceRoll{
+					expAttack("id-1", "id-2", 3),
+					expAttack("id-3", "id-4", 20),
+					expAttack("id-5", "id-6", 11),
+				}
+				m.diceRollRepo.On("CreateDiceRolls", mock.Anything, exp).Once().Return(nil)
+				for _, dr := range exp {
+					m.notifier.On("NotifyDiceRollCreated", mock.Anything, model.EventDiceRollCreated{DiceRoll: dr}).Once().Return(nil).Run(func(args mock.Arguments) {
+						*m.notified = append(*m.notified, args.Get(1).(model.EventDiceRollCreated).DiceRoll.ID)
+					})
+				}
+			},
+			req: dice.CreateDiceRollsRequest{Items: []dice.CreateDiceRollRequest{
+				attack("test-room"),
+				attack("test-room"),
+				attack("test-room"),
+			}},
+			expResp: &dice.CreateDiceRollsResponse{DiceRolls: []model.DiceRoll{
+				expAttack("id-1", "id-2", 3),
+				expAttack("id-3", "id-4", 20),
+				expAttack("id-5", "id-6", 11),
+			}},
+			expNotified: []string{"id-1", "id-3", "id-5"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			m := mocks{
+				roller:       &dicemock.Roller{},
+				roomRepo:     &storagemock.RoomRepository{},
+				userRepo:     &storagemock.UserRepository{},
+				diceRollRepo: &storagemock.DiceRollRepository{},
+				notifier:     &eventmock.Notifier{},
+				userLimiter:  &ratelimitmock.Limiter{},
+				notified:     &[]string{},
+			}
+			test.mock(m)
+
+			id := 0
+			svc, err := dice.NewService(dice.ServiceConfig{
+				Roller:                  m.roller,
+				DiceRollRepository:      m.diceRollRepo,
+				RoomRepository:          m.roomRepo,
+				UserRepository:          m.userRepo,
+				CustomDieTypeRepository: &storagemock.CustomDieTypeRepository{},
+				ServerSeedRepository:    &storagemock.ServerSeedRepository{},
+				EventNotifier:           m.notifier,
+				EventSubscriber:         &eventmock.Subscriber{},
+				UserRateLimiter:         m.userLimiter,
+				IDGenerator: func() string {
+					id++
+					return fmt.Sprintf("id-%d", id)
+				},
+				TimeNowFunc: func() time.Time { return t0 },
+			})
+			require.NoError(err)
+
+			gotResp, err := svc.CreateDiceRolls(context.TODO(), test.req)
+
+			if test.expErr != nil && assert.Error(err) {
+				if errors.Is(test.expErr, internalerrors.ErrNotValid) || errors.Is(test.expErr, internalerrors.ErrRateLimited) || errors.Is(test.expErr, internalerrors.ErrNotAllowed) {
+					assert.ErrorIs(err, test.expErr)
+				} else {
+					assert.ErrorContains(err, test.expErr.Error())
+				}
+				m.notifier.AssertNotCalled(t, "NotifyDiceRollCreated", mock.Anything, mock.Anything)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expResp, gotResp)
+				assert.Equal(test.expNotified, *m.notified)
+			}
+			m.roller.AssertExpectations(t)
+			m.roomRepo.AssertExpectations(t)
+			m.userRepo.AssertExpectations(t)
+			m.diceRollRepo.AssertExpectations(t)
+			m.notifier.AssertExpectations(t)
+			m.userLimiter.AssertExpectations(t)
+		})
+	}
+}
+
+func TestServiceCreateDiceRollSequence(t *testing.T) {
+	t0 := time.Now().UTC()
+
+	type mocks struct {
+		roller       *dicemock.Roller
+		roomRepo     *storagemock.RoomRepository
+		userRepo     *storagemock.UserRepository
+		diceRollRepo *storagemock.DiceRollRepository
+		notifier     *eventmock.Notifier
+		userLimiter  *ratelimitmock.Limiter
+		// notified are the dice roll IDs of the sent events, in order.
+		notified *[]string
+	}
+
+	attack := dice.CreateDiceRollRequest{RoomID: "test-room", UserID: "user-id", Expression: "1d20+5", Label: "Attack"}
+	damage := dice.CreateDiceRollRequest{RoomID: "test-room", UserID: "user-id", Expression: "1d8", Label: "Damage"}
+
+	// damageOnHit rolls the damage when the attack hits.
+	damageOnHit := func(ctx context.Context, prev model.DiceRoll) (*dice.CreateDiceRollRequest, error) {
+		if prev.Label == "Attack" && prev.Total >= 15 {
+			return &damage, nil
+		}
+		return nil, nil
+	}
+
+	// rollMiddle rolls the middle side of the dice.
+	rollMiddle := func(args mock.Arguments) {
+		dr := args.Get(1).(*model.DiceRoll)
+		for i, d := range dr.Dice {
+			dr.Dice[i].Side = d.Type.Sides() / 2
+		}
+	}
+
+	expAttack := model.DiceRoll{
+		ID:         "id-1",
+		CreatedAt:  t0,
+		RoomID:     "test-room",
+		UserID:     "user-id",
+		Expression: "1d20+5",
+		Label:      "Attack",
+		Total:      15,
+		Dice:       []model.DieRoll{{ID: "id-2", Type: model.DieTypeD20, Side: 10}},
+	}
+	expDamage := model.DiceRoll{
+		ID:         "id-3",
+		CreatedAt:  t0,
+		RoomID:     "test-room",
+		UserID:     "user-id",
+		Expression: "1d8",
+		Label:      "Damage",
+		Total:      4,
+		Dice:       []model.DieRoll{{ID: "id-4", Type: model.DieTypeD8, Side: 4}},
+	}
+
+	tests := map[string]struct {
+		mock        func(m mocks)
+		req         dice.CreateDiceRollSequenceRequest
+		expResp     *dice.CreateDiceRollSequenceResponse
+		expNotified []string
+		expErr      error
+	}{
+		"Having a sequence without next function should fail.": {
+			mock:   func(m mocks) {},
+			req:    dice.CreateDiceRollSequenceRequest{DiceRoll: attack},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a sequence with an invalid first dice roll should fail.": {
+			mock:   func(m mocks) {},
+			req:    dice.CreateDiceRollSequenceRequest{DiceRoll: dice.CreateDiceRollRequest{RoomID: "test-room"}, Next: damageOnHit},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a next dice roll of a different room should fail without creating any dice roll.": {
+			mock: func(m mocks) {
+				m.userLimiter.On("Allow", mock.Anything, "user-id").Return(true, nil)
+				m.roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
+				m.userRepo.On("UserExists", mock.Anything, "user-id").Once().Return(true, nil)
+				m.roller.On("Roll", mock.Anything, mock.Anything).Once().Return(nil).Run(rollMiddle)
+			},
+			req: dice.CreateDiceRollSequenceRequest{
+				DiceRoll: attack,
+				Next: func(ctx context.Context, prev model.DiceRoll) (*dice.CreateDiceRollRequest, error) {
+					if prev.Label != "Attack" {
+						return nil, nil
+					}
+					return &dice.CreateDiceRollRequest{RoomID: "other-room", UserID: "user-id", Expression: "1d8"}, nil
+				},
+			},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having an error on the next dice roll, should fail without creating any dice roll.": {
+			mock: func(m mocks) {
+				m.userLimiter.On("Allow", mock.Anything, "user-id").Return(true, nil)
+				m.roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
+				m.userRepo.On("UserExists", mock.Anything, "user-id").Once().Return(true, nil)
+				m.roller.On("Roll", mock.Anything, mock.Anything).Once().Return(nil).Run(rollMiddle)
+			},
+			req: dice.CreateDiceRollSequenceRequest{
+				DiceRoll: attack,
+				Next: func(ctx context.Context, prev model.DiceRoll) (*dice.CreateDiceRollRequest, error) {
+					return nil, errors.New("whatever")
+				},
+			},
+			expErr: errors.New("whatever"),
+		},
+
+		"Having an endless sequence should fail without creating any dice roll.": {
+			mock: func(m mocks) {
+				m.userLimiter.On("Allow", mock.Anything, "user-id").Return(true, nil)
+				m.roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
+				m.userRepo.On("UserExists", mock.Anything, "user-id").Once().Return(true, nil)
+				m.roller.On("Roll", mock.Anything, mock.Anything).Return(nil).Run(rollMiddle)
+			},
+			req: dice.CreateDiceRollSequenceRequest{
+				DiceRoll: attack,
+				Next: func(ctx context.Context, prev model.DiceRoll) (*dice.CreateDiceRollRequest, error) {
+					return &attack, nil
+				},
+			},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a sequence that exceeds the dice roll rate should be rate limited.": {
+			mock: func(m mocks) {
+				m.userLimiter.On("Allow", mock.Anything, "user-id").Once().Return(true, nil)
+				m.userLimiter.On("Allow", mock.Anything, "user-id").Once().Return(false, nil)
+				m.roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
+				m.userRepo.On("UserExists", mock.Anything, "user-id").Once().Return(true, nil)
+				m.roller.On("Roll", mock.Anything, mock.Anything).Once().Return(nil).Run(rollMiddle)
+			},
+			req:    dice.CreateDiceRollSequenceRequest{DiceRoll: attack, Next: damageOnHit},
+			expErr: internalerrors.ErrRateLimited,
+		},
+
+		"Having a sequence on an archived room should fail.": {
+			mock: func(m mocks) {
+				m.roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room", Archived: true}, nil)
+			},
+			req:    dice.CreateDiceRollSequenceRequest{DiceRoll: attack, Next: damageOnHit},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having an error while storing the dice rolls, should fail without sending events.": {
+			mock: func(m mocks) {
+				m.userLimiter.On("Allow", mock.Anything, "user-id").Return(true, nil)
+				m.roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
+				m.userRepo.On("UserExists", mock.Anything, "user-id").Once().Return(true, nil)
+				m.roller.On("Roll", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					args.Get(1).(*model.DiceRoll).Dice[0].Side = 1
+				})
+				m.diceRollRepo.On("CreateDiceRolls", mock.Anything, mock.Anything).Once().Return(errors.New("whatever"))
 			},
+			req:    dice.CreateDiceRollSequenceRequest{DiceRoll: attack, Next: damageOnHit},
+			expErr: errors.New("whatever"),
 		},
 
-		"Having custom pagination should use it.": {
-			mock: func(diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository) {
-				expPageOpts := model.PaginationOpts{
-					Cursor: "threepwood",
-					Order:  model.PaginationOrderAsc,
-					Size:   93,
-				}
-				dr := &storage.DiceRollList{}
-				diceRollRepo.On("ListDiceRolls", mock.Anything, expPageOpts, mock.Anything).Once().Return(dr, nil)
+		"Having an error before creating the dice rolls, should fail without storing the dice rolls nor sending events.": {
+			mock: func(m mocks) {
+				m.userLimiter.On("Allow", mock.Anything, "user-id").Times(2).Return(true, nil)
+				m.roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
+				m.userRepo.On("UserExists", mock.Anything, "user-id").Once().Return(true, nil)
+				m.roller.On("Roll", mock.Anything, mock.Anything).Times(2).Return(nil).Run(rollMiddle)
 			},
-			req: func() dice.ListDiceRollsRequest {
-				return dice.ListDiceRollsRequest{
-					RoomID: "room-id",
-					UserID: "user-id",
-					PageOpts: model.PaginationOpts{
-						Cursor: "threepwood",
-						Order:  model.PaginationOrderAsc,
-						Size:   93,
-					},
+			req: dice.CreateDiceRollSequenceRequest{
+				DiceRoll: attack,
+				Next:     damageOnHit,
+				BeforeCreate: func(ctx context.Context, drs []model.DiceRoll) error {
+					return errors.New("whatever")
+				},
+			},
+			expErr: errors.New("whatever"),
+		},
+
+		"Having a correct sequence with a before create hook, it should be called with all the dice rolls.": {
+			mock: func(m mocks) {
+				m.userLimiter.On("Allow", mock.Anything, "user-id").Times(2).Return(true, nil)
+				m.roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
+				m.userRepo.On("UserExists", mock.Anything, "user-id").Once().Return(true, nil)
+				m.roller.On("Roll", mock.Anything, mock.Anything).Times(2).Return(nil).Run(rollMiddle)
+
+				exp := []model.DiceRoll{expAttack, expDamage}
+				m.diceRollRepo.On("CreateDiceRolls", mock.Anything, exp).Once().Return(nil)
+				for _, dr := range exp {
+					m.notifier.On("NotifyDiceRollCreated", mock.Anything, model.EventDiceRollCreated{DiceRoll: dr}).Once().Return(nil).Run(func(args mock.Arguments) {
+						*m.notified = append(*m.notified, args.Get(1).(model.EventDiceRollCreated).DiceRoll.ID)
+					})
 				}
 			},
-			expResp: func() *dice.ListDiceRollsResponse {
-				return &dice.ListDiceRollsResponse{}
+			req: dice.CreateDiceRollSequenceRequest{
+				DiceRoll: attack,
+				Next:     damageOnHit,
+				BeforeCreate: func(ctx context.Context, drs []model.DiceRoll) error {
+					if len(drs) != 2 || drs[0].ID != "id-1" || drs[1].ID != "id-3" {
+						return errors.New("unexpected dice rolls")
+					}
+					return nil
+				},
 			},
+			expResp:     &dice.CreateDiceRollSequenceResponse{DiceRolls: []model.DiceRoll{expAttack, expDamage}},
+			expNotified: []string{"id-1", "id-3"},
 		},
 
-		"Having a pagination return from the repository, should be returned.": {
-			mock: func(diceRollRepo *storagemock.DiceRollRepository, roomRepo *storagemock.RoomRepository) {
-				dr := &storage.DiceRollList{
-					Cursors: model.PaginationCursors{
-						FirstCursor: "first",
-						LastCursor:  "second",
-						HasNext:     true,
-						HasPrevious: true,
-					},
+		"Having a correct sequence, it should roll every dice roll from the previous one, store and send the events in order.": {
+			mock: func(m mocks) {
+				m.userLimiter.On("Allow", mock.Anything, "user-id").Times(2).Return(true, nil)
+				m.roomRepo.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
+				m.userRepo.On("UserExists", mock.Anything, "user-id").Once().Return(true, nil)
+				m.roller.On("Roll", mock.Anything, mock.Anything).Times(2).Return(nil).Run(rollMiddle)
+
+				exp := []model.DiceRoll{expAttack, expDamage}
+				m.diceRollRepo.On("CreateDiceRolls", mock.Anything, exp).Once().Return(nil)
+				for _, dr := range exp {
+					m.notifier.On("NotifyDiceRollCreated", mock.Anything, model.EventDiceRollCreated{DiceRoll: dr}).Once().Return(nil).Run(func(args mock.Arguments) {
+						*m.notified = append(*m.notified, args.Get(1).(model.EventDiceRollCreated).DiceRoll.ID)
+					})
 				}
-				diceRollRepo.On("ListDiceRolls", mock.Anything, mock.Anything, mock.Anything).Once().Return(dr, nil)
 			},
-			req: func() dice.ListDiceRollsRequest {
-				return dice.ListDiceRollsRequest{
-					RoomID: "room-id",
-					UserID: "user-id",
-					PageOpts: model.PaginationOpts{
-						Cursor: "threepwood",
-						Order:  model.PaginationOrderAsc,
-						Size:   93,
-					},
+			req:         dice.CreateDiceRollSequenceRequest{DiceRoll: attack, Next: damageOnHit},
+			expResp:     &dice.CreateDiceRollSequenceResponse{DiceRolls: []model.DiceRoll{expAttack, expDamage}},
+			expNotified: []string{"id-1", "id-3"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			m := mocks{
+				roller:       &dicemock.Roller{},
+				roomRepo:     &storagemock.RoomRepository{},
+				userRepo:     &storagemock.UserRepository{},
+				diceRollRepo: &storagemock.DiceRollRepository{},
+				notifier:     &eventmock.Notifier{},
+				userLimiter:  &ratelimitmock.Limiter{},
+				notified:     &[]string{},
+			}
+			test.mock(m)
+
+			id := 0
+			svc, err := dice.NewService(dice.ServiceConfig{
+				Roller:                  m.roller,
+				DiceRollRepository:      m.diceRollRepo,
+				RoomRepository:          m.roomRepo,
+				UserRepository:          m.userRepo,
+				CustomDieTypeRepository: &storagemock.CustomDieTypeRepository{},
+				ServerSeedRepository:    &storagemock.ServerSeedRepository{},
+				EventNotifier:           m.notifier,
+				EventSubscriber:         &eventmock.Subscriber{},
+				UserRateLimiter:         m.userLimiter,
+				IDGenerator: func() string {
+					id++
+					return fmt.Sprintf("id-%d", id)
+				},
+				TimeNowFunc: func() time.Time { return t0 },
+			})
+			require.NoError(err)
+
+			gotResp, err := svc.CreateDiceRollSequence(context.TODO(), test.req)
+
+			if test.expErr != nil && assert.Error(err) {
+				if errors.Is(test.expErr, internalerrors.ErrNotValid) || errors.Is(test.expErr, internalerrors.ErrRateLimited) || errors.Is(test.expErr, internalerrors.ErrNotAllowed) {
+					assert.ErrorIs(err, test.expErr)
+				} else {
+					assert.ErrorContains(err, test.expErr.Error())
 				}
+				m.notifier.AssertNotCalled(t, "NotifyDiceRollCreated", mock.Anything, mock.Anything)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expResp, gotResp)
+				assert.Equal(test.expNotified, *m.notified)
+			}
+			m.roller.AssertExpectations(t)
+			m.roomRepo.AssertExpectations(t)
+			m.userRepo.AssertExpectations(t)
+			m.diceRollRepo.AssertExpectations(t)
+			m.notifier.AssertExpectations(t)
+			m.userLimiter.AssertExpectations(t)
+		})
+	}
+}
+
+func TestServiceCreateDiceRollAdvantage(t *testing.T) {
+	tests := map[string]struct {
+		dice      []model.DieType
+		advantage dice.AdvantageMode
+		modifier  int
+		sides     []uint
+		expDice   []model.DieRoll
+		expTotal  int
+	}{
+		"Rolling with advantage should roll an extra D20 and keep the higher one.": {
+			dice:      []model.DieType{model.DieTypeD20},
+			advantage: dice.AdvantageModeAdvantage,
+			modifier:  3,
+			sides:     []uint{7, 15},
+			expDice: []model.DieRoll{
+				{ID: "test-id", Type: model.DieTypeD20, Side: 7, Status: model.DieRollStatusDropped},
+				{ID: "test-id", Type: model.DieTypeD20, Side: 15},
 			},
-			expResp: func() *dice.ListDiceRollsResponse {
-				return &dice.ListDiceRollsResponse{
-					Cursors: model.PaginationCursors{
-						FirstCursor: "first",
-						LastCursor:  "second",
-						HasNext:     true,
-						HasPrevious: true,
-					},
-				}
+			expTotal: 18,
+		},
+
+		"Rolling with disadvantage should roll an extra D20 and keep the lower one.": {
+			dice:      []model.DieType{model.DieTypeD20},
+			advantage: dice.AdvantageModeDisadvantage,
+			sides:     []uint{7, 15},
+			expDice: []model.DieRoll{
+				{ID: "test-id", Type: model.DieTypeD20, Side: 7},
+				{ID: "test-id", Type: model.DieTypeD20, Side: 15, Status: model.DieRollStatusDropped},
+			},
+			expTotal: 7,
+		},
+
+		"Rolling with advantage and the same result should drop the extra D20.": {
+			dice:      []model.DieType{model.DieTypeD20},
+			advantage: dice.AdvantageModeAdvantage,
+			sides:     []uint{12, 12},
+			expDice: []model.DieRoll{
+				{ID: "test-id", Type: model.DieTypeD20, Side: 12},
+				{ID: "test-id", Type: model.DieTypeD20, Side: 12, Status: model.DieRollStatusDropped},
 			},
+			expTotal: 12,
+		},
+
+		"Rolling with advantage and other dice should only roll the extra D20 and sum the other dice.": {
+			dice:      []model.DieType{model.DieTypeD6, model.DieTypeD20, model.DieTypeD4},
+			advantage: dice.AdvantageModeAdvantage,
+			sides:     []uint{4, 18, 2, 3},
+			expDice: []model.DieRoll{
+				{ID: "test-id", Type: model.DieTypeD6, Side: 4},
+				{ID: "test-id", Type: model.DieTypeD20, Side: 18},
+				{ID: "test-id", Type: model.DieTypeD20, Side: 2, Status: model.DieRollStatusDropped},
+				{ID: "test-id", Type: model.DieTypeD4, Side: 3},
+			},
+			expTotal: 25,
 		},
 	}
 
@@ -478,112 +2373,398 @@ func TestServiceListDiceRolls(t *testing.T) {
 			assert := assert.New(t)
 			require := require.New(t)
 
-			// Mocks
-			mdrrep := &storagemock.DiceRollRepository{}
+			// Mocks.
+			mrol := &dicemock.Roller{}
+			mrol.On("Roll", mock.Anything, mock.Anything).Once().Return(nil).Run(func(args mock.Arguments) {
+				dr := args.Get(1).(*model.DiceRoll)
+				for i := range dr.Dice {
+					dr.Dice[i].Side = test.sides[i]
+				}
+			})
 			mrrep := &storagemock.RoomRepository{}
-			test.mock(mdrrep, mrrep)
+			mrrep.On("GetRoom", mock.Anything, "test-room").Once().Return(&model.Room{ID: "test-room"}, nil)
+			murep := &storagemock.UserRepository{}
+			murep.On("UserExists", mock.Anything, "user-id").Once().Return(true, nil)
+			mdrrep := &storagemock.DiceRollRepository{}
+			mdrrep.On("CreateDiceRoll", mock.Anything, mock.Anything).Once().Return(nil)
+			mevn := &eventmock.Notifier{}
+			mevn.On("NotifyDiceRollCreated", mock.Anything, mock.Anything).Once().Return(nil)
 
-			test.config.Roller = &dicemock.Roller{}
-			test.config.DiceRollRepository = mdrrep
-			test.config.RoomRepository = mrrep
-			test.config.UserRepository = &storagemock.UserRepository{}
-			test.config.EventNotifier = &eventmock.Notifier{}
-			test.config.EventSubscriber = &eventmock.Subscriber{}
-			test.config.IDGenerator = func() string { return "test" }
+			svc, err := dice.NewService(dice.ServiceConfig{
+				Roller:                  mrol,
+				DiceRollRepository:      mdrrep,
+				RoomRepository:          mrrep,
+				UserRepository:          murep,
+				CustomDieTypeRepository: &storagemock.CustomDieTypeRepository{},
+				ServerSeedRepository:    &storagemock.ServerSeedRepository{},
+				EventNotifier:           mevn,
+				EventSubscriber:         &eventmock.Subscriber{},
+				IDGenerator:             func() string { return "test-id" },
+			})
+			require.NoError(err)
 
-			svc, err := dice.NewService(test.config)
+			gotResp, err := svc.CreateDiceRoll(context.TODO(), dice.CreateDiceRollRequest{
+				RoomID:    "test-room",
+				UserID:    "user-id",
+				Dice:      test.dice,
+				Modifier:  test.modifier,
+				Advantage: test.advantage,
+			})
 			require.NoError(err)
 
-			gotResp, err := svc.ListDiceRolls(context.TODO(), test.req())
+			assert.Equal(test.expDice, gotResp.DiceRoll.Dice)
+			assert.Equal(test.expTotal, gotResp.DiceRoll.Total)
+		})
+	}
+}
+
+func TestServiceDiceRollVisibility(t *testing.T) {
+	tests := map[string]struct {
+		viewerUserID string
+		expErr       bool
+		expHidden    map[string]bool
+	}{
+		"Without viewer, only the public dice rolls should be visible.": {
+			viewerUserID: "",
+			expHidden:    map[string]bool{"public": false, "gm": true, "whisper": true},
+		},
+
+		"A missing viewer should fail.": {
+			viewerUserID: "missing",
+			expErr:       true,
+		},
+
+		"The user that rolled the dice should see all its dice rolls.": {
+			viewerUserID: "player1",
+			expHidden:    map[string]bool{"public": false, "gm": false, "whisper": false},
+		},
+
+		"The game master should see all the dice rolls.": {
+			viewerUserID: "gm",
+			expHidden:    map[string]bool{"public": false, "gm": false, "whisper": false},
+		},
+
+		"A whispered user should see the public and the whispered dice rolls.": {
+			viewerUserID: "player2",
+			expHidden:    map[string]bool{"public": false, "gm": true, "whisper": false},
+		},
+
+		"A not whispered user should only see the public dice rolls.": {
+			viewerUserID: "player3",
+			expHidden:    map[string]bool{"public": false, "gm": true, "whisper": true},
+		},
+
+		"A game master of other room should only see the public dice rolls.": {
+			viewerUserID: "other-gm",
+			expHidden:    map[string]bool{"public": false, "gm": true, "whisper": true},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Prepare the service with the room users.
+			roomRepo := memory.NewRoomRepository()
+			userRepo := memory.NewUserRepository()
+			for _, r := range []string{"room-id", "other-room-id"} {
+				err := roomRepo.CreateRoom(context.TODO(), model.Room{ID: r, Name: r})
+				require.NoError(err)
+			}
+			for _, u := range []model.User{
+				{ID: "player1", Name: "player1", RoomID: "room-id"},
+				{ID: "player2", Name: "player2", RoomID: "room-id"},
+				{ID: "player3", Name: "player3", RoomID: "room-id"},
+				{ID: "gm", Name: "gm", RoomID: "room-id", GameMaster: true},
+				{ID: "other-gm", Name: "other-gm", RoomID: "other-room-id", GameMaster: true},
+			} {
+				err := userRepo.CreateUser(context.TODO(), u)
+				require.NoError(err)
+			}
+			hub := eventmemory.NewHub(log.Dummy)
+
+			svc, err := dice.NewService(dice.ServiceConfig{
+				Roller:                  dice.NewRandomRoller(),
+				DiceRollRepository:      memory.NewDiceRollRepository(),
+				RoomRepository:          roomRepo,
+				UserRepository:          userRepo,
+				CustomDieTypeRepository: memory.NewCustomDieTypeRepository(),
+				ServerSeedRepository:    memory.NewServerSeedRepository(),
+				EventNotifier:           hub,
+				EventSubscriber:         hub,
+			})
+			require.NoError(err)
 
+			// Subscribe the viewer.
+			gotEventHidden := map[string]bool{}
+			_, err = svc.SubscribeDiceRollCreated(context.TODO(), dice.SubscribeDiceRollCreatedRequest{
+				RoomID:       "room-id",
+				ViewerUserID: test.viewerUserID,
+				EventHandler: func(_ context.Context, e model.EventDiceRollCreated) error {
+					gotEventHidden[e.DiceRoll.ID] = e.DiceRoll.Hidden
+					return nil
+				},
+			})
 			if test.expErr {
 				assert.Error(err)
-			} else if assert.NoError(err) {
-				assert.Equal(test.expResp(), gotResp)
+				return
+			}
+			require.NoError(err)
+
+			// Roll.
+			ids := map[string]string{}
+			for label, req := range map[string]dice.CreateDiceRollRequest{
+				"public":  {Visibility: model.DiceRollVisibilityPublic},
+				"gm":      {Visibility: model.DiceRollVisibilityGameMaster},
+				"whisper": {Visibility: model.DiceRollVisibilityWhisper, WhisperUserIDs: []string{"player2"}},
+			} {
+				req.RoomID = "room-id"
+				req.UserID = "player1"
+				req.Label = label
+				req.Dice = []model.DieType{model.DieTypeD20}
+				resp, err := svc.CreateDiceRoll(context.TODO(), req)
+				require.NoError(err)
+				ids[resp.DiceRoll.ID] = label
+			}
+
+			// Check the events.
+			expEventHidden := map[string]bool{}
+			for id, label := range ids {
+				expEventHidden[id] = test.expHidden[label]
+			}
+			assert.Equal(expEventHidden, gotEventHidden)
+
+			// Check the list.
+			listResp, err := svc.ListDiceRolls(context.TODO(), dice.ListDiceRollsRequest{
+				RoomID:       "room-id",
+				ViewerUserID: test.viewerUserID,
+			})
+			require.NoError(err)
+			gotHidden := map[string]bool{}
+			for _, dr := range listResp.DiceRolls {
+				label := ids[dr.ID]
+				gotHidden[label] = dr.Hidden
+				if dr.Hidden {
+					assert.Empty(dr.Label)
+					assert.Empty(dr.Dice)
+					assert.Zero(dr.Total)
+				} else {
+					assert.Equal(label, dr.Label)
+					assert.Len(dr.Dice, 1)
+				}
 			}
+			assert.Equal(test.expHidden, gotHidden)
 		})
 	}
 }
 
-func TestSubscribeDiceRollCreated(t *testing.T) {
+func TestServiceRerollDice(t *testing.T) {
+	t0 := time.Now().UTC()
+
 	tests := map[string]struct {
-		config dice.ServiceConfig
-		mock   func(roomRepo *storagemock.RoomRepository, eventSubscriber *eventmock.Subscriber)
-		req    func() dice.SubscribeDiceRollCreatedRequest
-		expErr bool
+		diceRolls   []model.DiceRoll
+		archived    bool
+		req         dice.RerollDiceRequest
+		sides       []uint
+		expDiceRoll *model.DiceRoll
+		expErr      error
 	}{
-		"Having a subscription request without room should fail.": {
-			mock: func(roomRepo *storagemock.RoomRepository, eventSubscriber *eventmock.Subscriber) {},
-			req: func() dice.SubscribeDiceRollCreatedRequest {
-				return dice.SubscribeDiceRollCreatedRequest{
-					RoomID:       "",
-					EventHandler: func(context.Context, model.EventDiceRollCreated) error { return nil },
-				}
+		"A missing dice roll ID should fail.": {
+			req:    dice.RerollDiceRequest{UserID: "user-id", DieIDs: []string{"d1"}},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"A missing user ID should fail.": {
+			req:    dice.RerollDiceRequest{DiceRollID: "dr1", DieIDs: []string{"d1"}},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Without dice to reroll should fail.": {
+			req:    dice.RerollDiceRequest{DiceRollID: "dr1", UserID: "user-id"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Repeated dice to reroll should fail.": {
+			req:    dice.RerollDiceRequest{DiceRollID: "dr1", UserID: "user-id", DieIDs: []string{"d1", "d1"}},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"A missing dice roll should fail.": {
+			req:    dice.RerollDiceRequest{DiceRollID: "dr1", UserID: "user-id", DieIDs: []string{"d1"}},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Rerolling other user dice roll should fail.": {
+			diceRolls: []model.DiceRoll{
+				{ID: "dr1", RoomID: "room-id", UserID: "user-id", Dice: []model.DieRoll{{ID: "d1", Type: model.DieTypeD20, Side: 3}}},
 			},
-			expErr: true,
+			req:    dice.RerollDiceRequest{DiceRollID: "dr1", UserID: "other-user-id", DieIDs: []string{"d1"}},
+			expErr: internalerrors.ErrNotValid,
 		},
 
-		"Having a subscription request without event handler should fail.": {
-			mock: func(roomRepo *storagemock.RoomRepository, eventSubscriber *eventmock.Subscriber) {},
-			req: func() dice.SubscribeDiceRollCreatedRequest {
-				return dice.SubscribeDiceRollCreatedRequest{
-					RoomID: "room-id",
-				}
+		"Rerolling a dice pool should fail.": {
+			diceRolls: []model.DiceRoll{
+				{ID: "dr1", RoomID: "room-id", UserID: "user-id", Dice: []model.DieRoll{{ID: "d1", Type: model.DieTypeD10, Side: 3}}, Pool: &model.DicePoolResult{}},
 			},
-			expErr: true,
+			req:    dice.RerollDiceRequest{DiceRollID: "dr1", UserID: "user-id", DieIDs: []string{"d1"}},
+			expErr: internalerrors.ErrNotValid,
 		},
 
-		"Having a subscription request that fails while checking the room, should fail.": {
-			mock: func(roomRepo *storagemock.RoomRepository, eventSubscriber *eventmock.Subscriber) {
-				roomRepo.On("RoomExists", mock.Anything, "room-id").Once().Return(false, errors.New("wanted error"))
+		"Rerolling a dice roll of an archived room should fail.": {
+			diceRolls: []model.DiceRoll{
+				{ID: "dr1", RoomID: "room-id", UserID: "user-id", Dice: []model.DieRoll{{ID: "d1", Type: model.DieTypeD20, Side: 3}}},
 			},
-			req: func() dice.SubscribeDiceRollCreatedRequest {
-				return dice.SubscribeDiceRollCreatedRequest{
-					RoomID:       "room-id",
-					EventHandler: func(context.Context, model.EventDiceRollCreated) error { return nil },
-				}
+			archived: true,
+			req:      dice.RerollDiceRequest{DiceRollID: "dr1", UserID: "user-id", DieIDs: []string{"d1"}},
+			expErr:   internalerrors.ErrNotAllowed,
+		},
+
+		"Rerolling a die that is not from the dice roll should fail.": {
+			diceRolls: []model.DiceRoll{
+				{ID: "dr1", RoomID: "room-id", UserID: "user-id", Dice: []model.DieRoll{{ID: "d1", Type: model.DieTypeD20, Side: 3}}},
 			},
-			expErr: true,
+			req:    dice.RerollDiceRequest{DiceRollID: "dr1", UserID: "user-id", DieIDs: []string{"d2"}},
+			expErr: internalerrors.ErrNotValid,
 		},
 
-		"Having a subscription request of an non-existent room, should fail.": {
-			mock: func(roomRepo *storagemock.RoomRepository, eventSubscriber *eventmock.Subscriber) {
-				roomRepo.On("RoomExists", mock.Anything, "room-id").Once().Return(false, nil)
+		"Rerolling a discarded die should fail.": {
+			diceRolls: []model.DiceRoll{
+				{ID: "dr1", RoomID: "room-id", UserID: "user-id", Dice: []model.DieRoll{
+					{ID: "d1", Type: model.DieTypeD20, Side: 3, Status: model.DieRollStatusDropped},
+					{ID: "d2", Type: model.DieTypeD20, Side: 13},
+				}},
 			},
-			req: func() dice.SubscribeDiceRollCreatedRequest {
-				return dice.SubscribeDiceRollCreatedRequest{
-					RoomID:       "room-id",
-					EventHandler: func(context.Context, model.EventDiceRollCreated) error { return nil },
-				}
+			req:    dice.RerollDiceRequest{DiceRollID: "dr1", UserID: "user-id", DieIDs: []string{"d1"}},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Rerolling dice should discard the rerolled dice, add the new ones and reference the original dice roll.": {
+			diceRolls: []model.DiceRoll{
+				{
+					ID:             "dr1",
+					RoomID:         "room-id",
+					UserID:         "user-id",
+					Label:          "Stealth check",
+					Modifier:       3,
+					Total:          12,
+					Visibility:     model.DiceRollVisibilityWhisper,
+					WhisperUserIDs: []string{"user-2"},
+					Dice: []model.DieRoll{
+						{ID: "d1", Type: model.DieTypeD20, Side: 7},
+						{ID: "d2", Type: model.DieTypeD6, Side: 2},
+					},
+				},
+			},
+			req:   dice.RerollDiceRequest{DiceRollID: "dr1", UserID: "user-id", DieIDs: []string{"d1"}},
+			sides: []uint{15},
+			expDiceRoll: &model.DiceRoll{
+				ID:             "test-id",
+				CreatedAt:      t0,
+				RoomID:         "room-id",
+				UserID:         "user-id",
+				Label:          "Stealth check",
+				Modifier:       3,
+				Total:          20,
+				Visibility:     model.DiceRollVisibilityWhisper,
+				WhisperUserIDs: []string{"user-2"},
+				ParentID:       "dr1",
+				Dice: []model.DieRoll{
+					{ID: "test-id", Type: model.DieTypeD20, Side: 7, Status: model.DieRollStatusRerolled},
+					{ID: "test-id", Type: model.DieTypeD6, Side: 2},
+					{ID: "test-id", Type: model.DieTypeD20, Side: 15},
+				},
 			},
-			expErr: true,
 		},
 
-		"Having a subscription request with an error while subscribing to the bus to the event bus.": {
-			mock: func(roomRepo *storagemock.RoomRepository, eventSubscriber *eventmock.Subscriber) {
-				roomRepo.On("RoomExists", mock.Anything, "room-id").Once().Return(true, nil)
-				eventSubscriber.On("SubscribeDiceRollCreated", mock.Anything, "test", "room-id", mock.Anything).Once().Return(errors.New("wanted error"))
+		"Rerolling the dice of a check should evaluate the check again with the new total.": {
+			diceRolls: []model.DiceRoll{
+				{
+					ID:       "dr1",
+					RoomID:   "room-id",
+					UserID:   "user-id",
+					Modifier: 5,
+					Total:    8,
+					Check:    &model.DiceRollCheck{Target: 15, Comparison: model.DiceRollCheckComparisonGreaterOrEqual, Margin: -7, Outcome: model.DiceRollCheckOutcomeFailure},
+					Dice:     []model.DieRoll{{ID: "d1", Type: model.DieTypeD20, Side: 3}},
+				},
 			},
-			req: func() dice.SubscribeDiceRollCreatedRequest {
-				return dice.SubscribeDiceRollCreatedRequest{
-					RoomID:       "room-id",
-					EventHandler: func(context.Context, model.EventDiceRollCreated) error { return nil },
-				}
+			req:   dice.RerollDiceRequest{DiceRollID: "dr1", UserID: "user-id", DieIDs: []string{"d1"}},
+			sides: []uint{12},
+			expDiceRoll: &model.DiceRoll{
+				ID:        "test-id",
+				CreatedAt: t0,
+				RoomID:    "room-id",
+				UserID:    "user-id",
+				Modifier:  5,
+				Total:     17,
+				Check:     &model.DiceRollCheck{Target: 15, Comparison: model.DiceRollCheckComparisonGreaterOrEqual, Margin: 2, Outcome: model.DiceRollCheckOutcomeSuccess},
+				ParentID:  "dr1",
+				Dice: []model.DieRoll{
+					{ID: "test-id", Type: model.DieTypeD20, Side: 3, Status: model.DieRollStatusRerolled},
+					{ID: "test-id", Type: model.DieTypeD20, Side: 12},
+				},
 			},
-			expErr: true,
 		},
 
-		"Having a subscription request should subscribe to the event bus, and the user should (be able to) call unsubscribe.": {
-			mock: func(roomRepo *storagemock.RoomRepository, eventSubscriber *eventmock.Subscriber) {
-				roomRepo.On("RoomExists", mock.Anything, "room-id").Once().Return(true, nil)
-				eventSubscriber.On("SubscribeDiceRollCreated", mock.Anything, "test", "room-id", mock.Anything).Once().Return(nil)
-				eventSubscriber.On("UnsubscribeDiceRollCreated", mock.Anything, "test", "room-id").Once().Return(nil)
+		"Rerolling expression dice should evaluate the expression again with the new dice.": {
+			diceRolls: []model.DiceRoll{
+				{ID: "dr1", RoomID: "room-id", UserID: "user-id", Expression: "2d20kh1+1d6", Total: 18, Dice: []model.DieRoll{
+					{ID: "d1", Type: model.DieTypeD20, Side: 15},
+					{ID: "d2", Type: model.DieTypeD20, Side: 5, Status: model.DieRollStatusDropped},
+					{ID: "d3", Type: model.DieTypeD6, Side: 3},
+				}},
 			},
-			req: func() dice.SubscribeDiceRollCreatedRequest {
-				return dice.SubscribeDiceRollCreatedRequest{
-					RoomID:       "room-id",
-					EventHandler: func(context.Context, model.EventDiceRollCreated) error { return nil },
-				}
+			req:   dice.RerollDiceRequest{DiceRollID: "dr1", UserID: "user-id", DieIDs: []string{"d1"}},
+			sides: []uint{2},
+			expDiceRoll: &model.DiceRoll{
+				ID:         "test-id",
+				CreatedAt:  t0,
+				RoomID:     "room-id",
+				UserID:     "user-id",
+				Expression: "2d20kh1+1d6",
+				Total:      8,
+				ParentID:   "dr1",
+				Dice: []model.DieRoll{
+					{ID: "test-id", Type: model.DieTypeD20, Side: 15, Status: model.DieRollStatusRerolled},
+					{ID: "test-id", Type: model.DieTypeD20, Side: 5},
+					{ID: "test-id", Type: model.DieTypeD6, Side: 3},
+					{ID: "test-id", Type: model.DieTypeD20, Side: 2, Status: model.DieRollStatusDropped},
+				},
+			},
+		},
+
+		"Rerolling expression dice of a reroll should replace the dice on the expression following the dice roll lineage.": {
+			diceRolls: []model.DiceRoll{
+				{ID: "dr1", RoomID: "room-id", UserID: "user-id", Expression: "1d6+1d6-1d6", Total: 0, Dice: []model.DieRoll{
+					{ID: "d1", Type: model.DieTypeD6, Side: 1},
+					{ID: "d2", Type: model.DieTypeD6, Side: 2},
+					{ID: "d3", Type: model.DieTypeD6, Side: 3},
+				}},
+				{ID: "dr2", RoomID: "room-id", UserID: "user-id", Expression: "1d6+1d6-1d6", Total: -1, ParentID: "dr1", Dice: []model.DieRoll{
+					{ID: "d21", Type: model.DieTypeD6, Side: 1},
+					{ID: "d22", Type: model.DieTypeD6, Side: 2},
+					{ID: "d23", Type: model.DieTypeD6, Side: 3, Status: model.DieRollStatusRerolled},
+					{ID: "d24", Type: model.DieTypeD6, Side: 4},
+				}},
+			},
+			req:   dice.RerollDiceRequest{DiceRollID: "dr2", UserID: "user-id", DieIDs: []string{"d21"}},
+			sides: []uint{6},
+			expDiceRoll: &model.DiceRoll{
+				ID:         "test-id",
+				CreatedAt:  t0,
+				RoomID:     "room-id",
+				UserID:     "user-id",
+				Expression: "1d6+1d6-1d6",
+				Total:      4,
+				ParentID:   "dr2",
+				Dice: []model.DieRoll{
+					{ID: "test-id", Type: model.DieTypeD6, Side: 1, Status: model.DieRollStatusRerolled},
+					{ID: "test-id", Type: model.DieTypeD6, Side: 2},
+					{ID: "test-id", Type: model.DieTypeD6, Side: 3, Status: model.DieRollStatusRerolled},
+					{ID: "test-id", Type: model.DieTypeD6, Side: 4},
+					{ID: "test-id", Type: model.DieTypeD6, Side: 6},
+				},
 			},
 		},
 	}
@@ -593,31 +2774,59 @@ func TestSubscribeDiceRollCreated(t *testing.T) {
 			assert := assert.New(t)
 			require := require.New(t)
 
-			// Mocks
-			mrrep := &storagemock.RoomRepository{}
-			mevsub := &eventmock.Subscriber{}
-			test.mock(mrrep, mevsub)
+			diceRollRepo := memory.NewDiceRollRepository()
+			for _, dr := range test.diceRolls {
+				err := diceRollRepo.CreateDiceRoll(context.TODO(), dr)
+				require.NoError(err)
+			}
 
-			test.config.Roller = &dicemock.Roller{}
-			test.config.DiceRollRepository = &storagemock.DiceRollRepository{}
-			test.config.RoomRepository = mrrep
-			test.config.UserRepository = &storagemock.UserRepository{}
-			test.config.EventNotifier = &eventmock.Notifier{}
-			test.config.EventSubscriber = mevsub
-			test.config.IDGenerator = func() string { return "test" }
+			// Mocks.
+			mrol := &dicemock.Roller{}
+			mrol.On("Roll", mock.Anything, mock.Anything).Maybe().Return(nil).Run(func(args mock.Arguments) {
+				dr := args.Get(1).(*model.DiceRoll)
+				sides := test.sides
+				for i := range dr.Dice {
+					dr.Dice[i].Side = 1
+					if i >= len(dr.Dice)-len(sides) {
+						dr.Dice[i].Side = sides[i-len(dr.Dice)+len(sides)]
+					}
+				}
+			})
+			mevn := &eventmock.Notifier{}
+			if test.expDiceRoll != nil {
+				mevn.On("NotifyDiceRollCreated", mock.Anything, model.EventDiceRollCreated{DiceRoll: *test.expDiceRoll}).Once().Return(nil)
+			}
 
-			svc, err := dice.NewService(test.config)
+			mrrep := &storagemock.RoomRepository{}
+			mrrep.On("GetRoom", mock.Anything, "room-id").Maybe().Return(&model.Room{ID: "room-id", Archived: test.archived}, nil)
+
+			svc, err := dice.NewService(dice.ServiceConfig{
+				Roller:                  mrol,
+				DiceRollRepository:      diceRollRepo,
+				RoomRepository:          mrrep,
+				UserRepository:          &storagemock.UserRepository{},
+				CustomDieTypeRepository: &storagemock.CustomDieTypeRepository{},
+				ServerSeedRepository:    &storagemock.ServerSeedRepository{},
+				EventNotifier:           mevn,
+				EventSubscriber:         &eventmock.Subscriber{},
+				IDGenerator:             func() string { return "test-id" },
+				TimeNowFunc:             func() time.Time { return t0 },
+			})
 			require.NoError(err)
 
-			gotResp, err := svc.SubscribeDiceRollCreated(context.TODO(), test.req())
+			gotResp, err := svc.RerollDice(context.TODO(), test.req)
 
-			if test.expErr {
-				assert.Error(err)
-			} else if assert.NoError(err) {
-				assert.NotNil(gotResp)
-				unsubErr := gotResp.UnsubscribeFunc()
-				assert.NoError(unsubErr)
+			if test.expErr != nil {
+				assert.ErrorIs(err, test.expErr)
+				return
 			}
+			require.NoError(err)
+			assert.Equal(*test.expDiceRoll, gotResp.DiceRoll)
+			mevn.AssertExpectations(t)
+
+			stored, err := diceRollRepo.GetDiceRoll(context.TODO(), gotResp.DiceRoll.ID)
+			require.NoError(err)
+			assert.Equal(test.expDiceRoll.ParentID, stored.ParentID)
 		})
 	}
 }
@@ -14,6 +14,58 @@ type Service struct {
 	mock.Mock
 }
 
+// CalculateProbabilities provides a mock function with given fields: ctx, r
+func (_m *Service) CalculateProbabilities(ctx context.Context, r dice.CalculateProbabilitiesRequest) (*dice.CalculateProbabilitiesResponse, error) {
+	ret := _m.Called(ctx, r)
+
+	var r0 *dice.CalculateProbabilitiesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dice.CalculateProbabilitiesRequest) (*dice.CalculateProbabilitiesResponse, error)); ok {
+		return rf(ctx, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dice.CalculateProbabilitiesRequest) *dice.CalculateProbabilitiesResponse); ok {
+		r0 = rf(ctx, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dice.CalculateProbabilitiesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dice.CalculateProbabilitiesRequest) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateCustomDieType provides a mock function with given fields: ctx, r
+func (_m *Service) CreateCustomDieType(ctx context.Context, r dice.CreateCustomDieTypeRequest) (*dice.CreateCustomDieTypeResponse, error) {
+	ret := _m.Called(ctx, r)
+
+	var r0 *dice.CreateCustomDieTypeResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dice.CreateCustomDieTypeRequest) (*dice.CreateCustomDieTypeResponse, error)); ok {
+		return rf(ctx, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dice.CreateCustomDieTypeRequest) *dice.CreateCustomDieTypeResponse); ok {
+		r0 = rf(ctx, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dice.CreateCustomDieTypeResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dice.CreateCustomDieTypeRequest) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateDiceRoll provides a mock function with given fields: ctx, r
 func (_m *Service) CreateDiceRoll(ctx context.Context, r dice.CreateDiceRollRequest) (*dice.CreateDiceRollResponse, error) {
 	ret := _m.Called(ctx, r)
@@ -40,6 +92,84 @@ func (_m *Service) CreateDiceRoll(ctx context.Context, r dice.CreateDiceRollRequ
 	return r0, r1
 }
 
+// CreateDiceRollSequence provides a mock function with given fields: ctx, r
+func (_m *Service) CreateDiceRollSequence(ctx context.Context, r dice.CreateDiceRollSequenceRequest) (*dice.CreateDiceRollSequenceResponse, error) {
+	ret := _m.Called(ctx, r)
+
+	var r0 *dice.CreateDiceRollSequenceResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dice.CreateDiceRollSequenceRequest) (*dice.CreateDiceRollSequenceResponse, error)); ok {
+		return rf(ctx, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dice.CreateDiceRollSequenceRequest) *dice.CreateDiceRollSequenceResponse); ok {
+		r0 = rf(ctx, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dice.CreateDiceRollSequenceResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dice.CreateDiceRollSequenceRequest) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateDiceRolls provides a mock function with given fields: ctx, r
+func (_m *Service) CreateDiceRolls(ctx context.Context, r dice.CreateDiceRollsRequest) (*dice.CreateDiceRollsResponse, error) {
+	ret := _m.Called(ctx, r)
+
+	var r0 *dice.CreateDiceRollsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dice.CreateDiceRollsRequest) (*dice.CreateDiceRollsResponse, error)); ok {
+		return rf(ctx, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dice.CreateDiceRollsRequest) *dice.CreateDiceRollsResponse); ok {
+		r0 = rf(ctx, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dice.CreateDiceRollsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dice.CreateDiceRollsRequest) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoomServerSeed provides a mock function with given fields: ctx, r
+func (_m *Service) GetRoomServerSeed(ctx context.Context, r dice.GetRoomServerSeedRequest) (*dice.GetRoomServerSeedResponse, error) {
+	ret := _m.Called(ctx, r)
+
+	var r0 *dice.GetRoomServerSeedResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dice.GetRoomServerSeedRequest) (*dice.GetRoomServerSeedResponse, error)); ok {
+		return rf(ctx, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dice.GetRoomServerSeedRequest) *dice.GetRoomServerSeedResponse); ok {
+		r0 = rf(ctx, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dice.GetRoomServerSeedResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dice.GetRoomServerSeedRequest) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListDiceRolls provides a mock function with given fields: ctx, r
 func (_m *Service) ListDiceRolls(ctx context.Context, r dice.ListDiceRollsRequest) (*dice.ListDiceRollsResponse, error) {
 	ret := _m.Called(ctx, r)
@@ -66,25 +196,77 @@ func (_m *Service) ListDiceRolls(ctx context.Context, r dice.ListDiceRollsReques
 	return r0, r1
 }
 
-// ListDiceTypes provides a mock function with given fields: ctx
-func (_m *Service) ListDiceTypes(ctx context.Context) (*dice.ListDiceTypesResponse, error) {
-	ret := _m.Called(ctx)
+// ListDiceTypes provides a mock function with given fields: ctx, r
+func (_m *Service) ListDiceTypes(ctx context.Context, r dice.ListDiceTypesRequest) (*dice.ListDiceTypesResponse, error) {
+	ret := _m.Called(ctx, r)
 
 	var r0 *dice.ListDiceTypesResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*dice.ListDiceTypesResponse, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, dice.ListDiceTypesRequest) (*dice.ListDiceTypesResponse, error)); ok {
+		return rf(ctx, r)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) *dice.ListDiceTypesResponse); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, dice.ListDiceTypesRequest) *dice.ListDiceTypesResponse); ok {
+		r0 = rf(ctx, r)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*dice.ListDiceTypesResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, dice.ListDiceTypesRequest) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RerollDice provides a mock function with given fields: ctx, r
+func (_m *Service) RerollDice(ctx context.Context, r dice.RerollDiceRequest) (*dice.RerollDiceResponse, error) {
+	ret := _m.Called(ctx, r)
+
+	var r0 *dice.RerollDiceResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dice.RerollDiceRequest) (*dice.RerollDiceResponse, error)); ok {
+		return rf(ctx, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dice.RerollDiceRequest) *dice.RerollDiceResponse); ok {
+		r0 = rf(ctx, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dice.RerollDiceResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dice.RerollDiceRequest) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RotateRoomServerSeed provides a mock function with given fields: ctx, r
+func (_m *Service) RotateRoomServerSeed(ctx context.Context, r dice.RotateRoomServerSeedRequest) (*dice.RotateRoomServerSeedResponse, error) {
+	ret := _m.Called(ctx, r)
+
+	var r0 *dice.RotateRoomServerSeedResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dice.RotateRoomServerSeedRequest) (*dice.RotateRoomServerSeedResponse, error)); ok {
+		return rf(ctx, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dice.RotateRoomServerSeedRequest) *dice.RotateRoomServerSeedResponse); ok {
+		r0 = rf(ctx, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dice.RotateRoomServerSeedResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dice.RotateRoomServerSeedRequest) error); ok {
+		r1 = rf(ctx, r)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -118,6 +300,32 @@ func (_m *Service) SubscribeDiceRollCreated(ctx context.Context, r dice.Subscrib
 	return r0, r1
 }
 
+// VerifyDiceRoll provides a mock function with given fields: ctx, r
+func (_m *Service) VerifyDiceRoll(ctx context.Context, r dice.VerifyDiceRollRequest) (*dice.VerifyDiceRollResponse, error) {
+	ret := _m.Called(ctx, r)
+
+	var r0 *dice.VerifyDiceRollResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dice.VerifyDiceRollRequest) (*dice.VerifyDiceRollResponse, error)); ok {
+		return rf(ctx, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dice.VerifyDiceRollRequest) *dice.VerifyDiceRollResponse); ok {
+		r0 = rf(ctx, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dice.VerifyDiceRollResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dice.VerifyDiceRollRequest) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewService(t interface {
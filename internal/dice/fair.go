@@ -0,0 +1,246 @@
+package dice
+
+import (
+	"context"
+	"crypto/hmac"
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+)
+
+// ProvablyFairRollerConfig is the provably fair roller configuration.
+type ProvablyFairRollerConfig struct {
+	ServerSeedRepository storage.ServerSeedRepository
+	IDGenerator          func() string
+	TimeNowFunc          func() time.Time
+}
+
+func (c *ProvablyFairRollerConfig) defaults() error {
+	if c.ServerSeedRepository == nil {
+		return fmt.Errorf("storage.ServerSeedRepository is required")
+	}
+
+	if c.IDGenerator == nil {
+		c.IDGenerator = func() string { return uuid.New().String() }
+	}
+
+	if c.TimeNowFunc == nil {
+		c.TimeNowFunc = time.Now
+	}
+
+	return nil
+}
+
+type provablyFairRoller struct {
+	repo  storage.ServerSeedRepository
+	seeds serverSeedManager
+}
+
+// NewProvablyFairRoller returns a roller that rolls the dice deterministically from the room
+// active server seed, the dice roll client seed and a nonce, so once the server seed is revealed
+// anyone can verify the dice roll.
+//
+// The dice sides are taken from the HMAC-SHA256(serverSeed, "clientSeed:nonce:block") blocks
+// (block starts at 0), using each 4 bytes as a big endian uint32 and rejecting the ones that would
+// bias the sides (see cryptoRoller).
+func NewProvablyFairRoller(cfg ProvablyFairRollerConfig) (Roller, error) {
+	err := cfg.defaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return provablyFairRoller{
+		repo: cfg.ServerSeedRepository,
+		seeds: serverSeedManager{
+			repo:    cfg.ServerSeedRepository,
+			idGen:   cfg.IDGenerator,
+			timeNow: cfg.TimeNowFunc,
+			rand:    cryptorand.Reader,
+		},
+	}, nil
+}
+
+func (p provablyFairRoller) Roll(ctx context.Context, dr *model.DiceRoll) error {
+	// Dice rolls that already have a proof continue with the same seed and nonce (e.g: extra dice
+	// of the roll modifiers), the dice are rolled in order so the already rolled dice get the same
+	// sides and the new ones continue the sequence.
+	if dr.Proof != nil && dr.Proof.ServerSeedID != "" {
+		seed, err := p.repo.GetServerSeed(ctx, dr.Proof.ServerSeedID)
+		if err != nil {
+			return fmt.Errorf("could not get server seed: %w", err)
+		}
+
+		dice, err := rollProvablyFairDice(seed.Seed, dr.Proof.ClientSeed, dr.Proof.Nonce, dr.Dice)
+		if err != nil {
+			return err
+		}
+		dr.Dice = dice
+
+		return nil
+	}
+
+	clientSeed := ""
+	if dr.Proof != nil {
+		clientSeed = dr.Proof.ClientSeed
+	}
+
+	// Retry in case the seed is rotated between getting it and using it.
+	const maxRetries = 3
+	for i := 0; ; i++ {
+		seed, err := p.seeds.active(ctx, dr.RoomID)
+		if err != nil {
+			return fmt.Errorf("could not get room server seed: %w", err)
+		}
+
+		nonce, err := p.repo.IncreaseServerSeedNonce(ctx, seed.ID)
+		if err != nil {
+			if errors.Is(err, internalerrors.ErrMissing) && i < maxRetries {
+				continue
+			}
+			return fmt.Errorf("could not get server seed nonce: %w", err)
+		}
+
+		dice, err := rollProvablyFairDice(seed.Seed, clientSeed, nonce, dr.Dice)
+		if err != nil {
+			return err
+		}
+
+		dr.Dice = dice
+		dr.Proof = &model.DiceRollProof{
+			ServerSeedID:   seed.ID,
+			ServerSeedHash: seed.Hash,
+			ClientSeed:     clientSeed,
+			Nonce:          nonce,
+		}
+
+		return nil
+	}
+}
+
+// rollProvablyFairDice rolls the dice deterministically based on the seeds and the nonce.
+func rollProvablyFairDice(serverSeed, clientSeed string, nonce uint, dice []model.DieRoll) ([]model.DieRoll, error) {
+	r := cryptoRoller{rand: &hmacStream{
+		key: []byte(serverSeed),
+		msg: clientSeed + ":" + strconv.FormatUint(uint64(nonce), 10) + ":",
+	}}
+
+	dr := &model.DiceRoll{Dice: dice}
+	err := r.Roll(context.Background(), dr)
+	if err != nil {
+		return nil, err
+	}
+
+	return dr.Dice, nil
+}
+
+// hmacStream is an endless deterministic stream of bytes made of
+// HMAC-SHA256(key, msg+block) blocks.
+type hmacStream struct {
+	key   []byte
+	msg   string
+	block uint64
+	buf   []byte
+}
+
+func (h *hmacStream) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(h.buf) == 0 {
+			mac := hmac.New(sha256.New, h.key)
+			_, _ = mac.Write([]byte(h.msg + strconv.FormatUint(h.block, 10)))
+			h.buf = mac.Sum(nil)
+			h.block++
+		}
+
+		c := copy(p[n:], h.buf)
+		h.buf = h.buf[c:]
+		n += c
+	}
+
+	return n, nil
+}
+
+// hashServerSeed returns the public hash of a server seed.
+func hashServerSeed(seed string) string {
+	h := sha256.Sum256([]byte(seed))
+	return hex.EncodeToString(h[:])
+}
+
+// serverSeedManager manages the provably fair server seeds of the rooms.
+type serverSeedManager struct {
+	repo    storage.ServerSeedRepository
+	idGen   func() string
+	timeNow func() time.Time
+	rand    io.Reader
+}
+
+// active returns the active server seed of a room, if the room doesn't have one, it will create it.
+func (s serverSeedManager) active(ctx context.Context, roomID string) (*model.ServerSeed, error) {
+	seed, err := s.repo.GetRoomActiveServerSeed(ctx, roomID)
+	if err == nil {
+		return seed, nil
+	}
+	if !errors.Is(err, internalerrors.ErrMissing) {
+		return nil, fmt.Errorf("could not get active server seed: %w", err)
+	}
+
+	return s.create(ctx, roomID)
+}
+
+// rotate reveals the active server seed of a room (if any) and creates a new one.
+func (s serverSeedManager) rotate(ctx context.Context, roomID string) (revealed *model.ServerSeed, current *model.ServerSeed, err error) {
+	revealed, err = s.repo.GetRoomActiveServerSeed(ctx, roomID)
+	if err != nil && !errors.Is(err, internalerrors.ErrMissing) {
+		return nil, nil, fmt.Errorf("could not get active server seed: %w", err)
+	}
+
+	if revealed != nil {
+		err := s.repo.RevealServerSeed(ctx, revealed.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not reveal server seed: %w", err)
+		}
+		revealed.Revealed = true
+	}
+
+	current, err = s.create(ctx, roomID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return revealed, current, nil
+}
+
+func (s serverSeedManager) create(ctx context.Context, roomID string) (*model.ServerSeed, error) {
+	b := make([]byte, 32)
+	_, err := io.ReadFull(s.rand, b)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate server seed: %w", err)
+	}
+	seed := hex.EncodeToString(b)
+
+	ss := model.ServerSeed{
+		ID:        s.idGen(),
+		RoomID:    roomID,
+		CreatedAt: s.timeNow().UTC(),
+		Seed:      seed,
+		Hash:      hashServerSeed(seed),
+	}
+
+	err = s.repo.CreateServerSeed(ctx, ss)
+	if err != nil {
+		return nil, fmt.Errorf("could not store server seed: %w", err)
+	}
+
+	return &ss, nil
+}
@@ -0,0 +1,267 @@
+package dice_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/dice"
+	"github.com/rollify/rollify/internal/event/eventmock"
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage/memory"
+)
+
+func TestProvablyFairRoller(t *testing.T) {
+	tests := map[string]struct {
+		diceRoll   func() model.DiceRoll
+		clientSeed string
+	}{
+		"Having a dice roll without client seed, it should roll with an empty client seed.": {
+			diceRoll: func() model.DiceRoll {
+				return model.DiceRoll{
+					RoomID: "room-id",
+					Dice: []model.DieRoll{
+						{Type: model.DieTypeD6},
+						{Type: model.DieTypeD20},
+					},
+				}
+			},
+		},
+
+		"Having a dice roll with client seed, it should roll with the client seed.": {
+			diceRoll: func() model.DiceRoll {
+				return model.DiceRoll{
+					RoomID: "room-id",
+					Proof:  &model.DiceRollProof{ClientSeed: "lucky"},
+					Dice: []model.DieRoll{
+						{Type: model.DieTypeD4},
+						{Type: model.DieTypeD100},
+						{Type: mustDieType(t, 997)},
+					},
+				}
+			},
+			clientSeed: "lucky",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			repo := memory.NewServerSeedRepository()
+			roller, err := dice.NewProvablyFairRoller(dice.ProvablyFairRollerConfig{
+				ServerSeedRepository: repo,
+				IDGenerator:          func() string { return "seed-id" },
+			})
+			require.NoError(err)
+
+			// Roll twice, so we check the nonce increases.
+			dr1 := test.diceRoll()
+			err = roller.Roll(context.TODO(), &dr1)
+			require.NoError(err)
+			dr2 := test.diceRoll()
+			err = roller.Roll(context.TODO(), &dr2)
+			require.NoError(err)
+
+			// Check the server seed is created and committed.
+			seed := repo.ServerSeedsByID["seed-id"]
+			require.NotNil(seed)
+			hash := sha256.Sum256([]byte(seed.Seed))
+			assert.Equal(hex.EncodeToString(hash[:]), seed.Hash)
+			assert.False(seed.Revealed)
+
+			// Check the proofs.
+			assert.Equal(&model.DiceRollProof{ServerSeedID: "seed-id", ServerSeedHash: seed.Hash, ClientSeed: test.clientSeed, Nonce: 1}, dr1.Proof)
+			assert.Equal(&model.DiceRollProof{ServerSeedID: "seed-id", ServerSeedHash: seed.Hash, ClientSeed: test.clientSeed, Nonce: 2}, dr2.Proof)
+			for _, d := range append(dr1.Dice, dr2.Dice...) {
+				assert.True(d.Side >= 1 && d.Side <= d.Type.Sides(), "side %d out of range for %s", d.Side, d.Type.ID())
+			}
+
+			// Rolling with the same server seed, client seed and nonce should be deterministic.
+			repo2 := memory.NewServerSeedRepository()
+			seed2 := *seed
+			seed2.Nonce = 0
+			err = repo2.CreateServerSeed(context.TODO(), seed2)
+			require.NoError(err)
+			roller2, err := dice.NewProvablyFairRoller(dice.ProvablyFairRollerConfig{ServerSeedRepository: repo2})
+			require.NoError(err)
+
+			dr3 := test.diceRoll()
+			err = roller2.Roll(context.TODO(), &dr3)
+			require.NoError(err)
+			assert.Equal(dr1, dr3)
+		})
+	}
+}
+
+func TestProvablyFairDiceRollVerification(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	// Prepare the service with the provably fair roller.
+	roomRepo := memory.NewRoomRepository()
+	err := roomRepo.CreateRoom(context.TODO(), model.Room{ID: "room-id", Name: "test"})
+	require.NoError(err)
+	userRepo := memory.NewUserRepository()
+	err = userRepo.CreateUser(context.TODO(), model.User{ID: "user-id", RoomID: "room-id", Name: "user", GameMaster: true})
+	require.NoError(err)
+	err = userRepo.CreateUser(context.TODO(), model.User{ID: "user2-id", RoomID: "room-id", Name: "user2"})
+	require.NoError(err)
+	diceRollRepo := memory.NewDiceRollRepository()
+	serverSeedRepo := memory.NewServerSeedRepository()
+	notifier := &eventmock.Notifier{}
+	notifier.On("NotifyDiceRollCreated", mock.Anything, mock.Anything).Return(nil)
+
+	roller, err := dice.NewProvablyFairRoller(dice.ProvablyFairRollerConfig{ServerSeedRepository: serverSeedRepo})
+	require.NoError(err)
+	svc, err := dice.NewService(dice.ServiceConfig{
+		Roller:                  roller,
+		DiceRollRepository:      diceRollRepo,
+		RoomRepository:          roomRepo,
+		UserRepository:          userRepo,
+		CustomDieTypeRepository: memory.NewCustomDieTypeRepository(),
+		ServerSeedRepository:    serverSeedRepo,
+		EventNotifier:           notifier,
+		EventSubscriber:         &eventmock.Subscriber{},
+	})
+	require.NoError(err)
+
+	// Get the committed server seed and roll.
+	seedResp, err := svc.GetRoomServerSeed(context.TODO(), dice.GetRoomServerSeedRequest{RoomID: "room-id"})
+	require.NoError(err)
+	assert.Empty(seedResp.ServerSeed.Seed)
+
+	createResp, err := svc.CreateDiceRoll(context.TODO(), dice.CreateDiceRollRequest{
+		RoomID:     "room-id",
+		UserID:     "user-id",
+		Expression: "4d6kh3+1d20",
+		ClientSeed: "lucky",
+	})
+	require.NoError(err)
+	require.NotNil(createResp.DiceRoll.Proof)
+	assert.Equal(seedResp.ServerSeed.ID, createResp.DiceRoll.Proof.ServerSeedID)
+	assert.Equal(seedResp.ServerSeed.Hash, createResp.DiceRoll.Proof.ServerSeedHash)
+	assert.Equal("lucky", createResp.DiceRoll.Proof.ClientSeed)
+
+	// The modifiers extra dice should be verifiable too.
+	modResp, err := svc.CreateDiceRoll(context.TODO(), dice.CreateDiceRollRequest{
+		RoomID:    "room-id",
+		UserID:    "user-id",
+		Dice:      []model.DieType{model.DieTypeD2, model.DieTypeD2, model.DieTypeD4, model.DieTypeD4},
+		Modifiers: dice.DiceRollModifiers{RerollBelow: 2, Explode: true, DropLowest: 1},
+	})
+	require.NoError(err)
+	require.NotNil(modResp.DiceRoll.Proof)
+
+	// The game master dice rolls can only be verified by the users that can see them.
+	gmResp, err := svc.CreateDiceRoll(context.TODO(), dice.CreateDiceRollRequest{
+		RoomID:     "room-id",
+		UserID:     "user-id",
+		Dice:       []model.DieType{model.DieTypeD20},
+		Visibility: model.DiceRollVisibilityGameMaster,
+	})
+	require.NoError(err)
+
+	// Rerolls get a fresh proof, so rerolling the same dice gets new sides.
+	rerollReq := dice.RerollDiceRequest{
+		DiceRollID: createResp.DiceRoll.ID,
+		UserID:     "user-id",
+		DieIDs:     []string{createResp.DiceRoll.Dice[4].ID},
+	}
+	rerollResp, err := svc.RerollDice(context.TODO(), rerollReq)
+	require.NoError(err)
+	require.NotNil(rerollResp.DiceRoll.Proof)
+	assert.Equal(createResp.DiceRoll.ID, rerollResp.DiceRoll.ParentID)
+	assert.Equal(seedResp.ServerSeed.ID, rerollResp.DiceRoll.Proof.ServerSeedID)
+	assert.Equal("lucky", rerollResp.DiceRoll.Proof.ClientSeed)
+	assert.NotEqual(createResp.DiceRoll.Proof.Nonce, rerollResp.DiceRoll.Proof.Nonce)
+
+	rerolls := []model.DiceRoll{rerollResp.DiceRoll}
+	differentSides := false
+	for i := 0; i < 20 && !differentSides; i++ {
+		resp, err := svc.RerollDice(context.TODO(), rerollReq)
+		require.NoError(err)
+		assert.NotEqual(rerollResp.DiceRoll.Proof.Nonce, resp.DiceRoll.Proof.Nonce)
+		differentSides = resp.DiceRoll.Dice[5].Side != rerollResp.DiceRoll.Dice[5].Side
+		rerolls = append(rerolls, resp.DiceRoll)
+	}
+	assert.True(differentSides, "the rerolls of the same dice should get different sides")
+
+	// Until the server seed is revealed we can't verify.
+	_, err = svc.VerifyDiceRoll(context.TODO(), dice.VerifyDiceRollRequest{DiceRollID: createResp.DiceRoll.ID})
+	assert.ErrorIs(err, internalerrors.ErrNotValid)
+
+	// Only the game master can rotate.
+	_, err = svc.RotateRoomServerSeed(context.TODO(), dice.RotateRoomServerSeedRequest{RoomID: "room-id", UserID: "user2-id"})
+	assert.ErrorIs(err, internalerrors.ErrNotAllowed)
+
+	// Rotate and verify.
+	rotateResp, err := svc.RotateRoomServerSeed(context.TODO(), dice.RotateRoomServerSeedRequest{RoomID: "room-id", UserID: "user-id"})
+	require.NoError(err)
+	require.NotNil(rotateResp.RevealedServerSeed)
+	assert.Equal(seedResp.ServerSeed.ID, rotateResp.RevealedServerSeed.ID)
+	assert.NotEmpty(rotateResp.RevealedServerSeed.Seed)
+	assert.NotEqual(seedResp.ServerSeed.ID, rotateResp.ServerSeed.ID)
+	assert.Empty(rotateResp.ServerSeed.Seed)
+
+	verifyResp, err := svc.VerifyDiceRoll(context.TODO(), dice.VerifyDiceRollRequest{DiceRollID: createResp.DiceRoll.ID})
+	require.NoError(err)
+	assert.True(verifyResp.Valid)
+	assert.Equal(createResp.DiceRoll.Dice, verifyResp.Dice)
+
+	verifyResp, err = svc.VerifyDiceRoll(context.TODO(), dice.VerifyDiceRollRequest{DiceRollID: modResp.DiceRoll.ID})
+	require.NoError(err)
+	assert.True(verifyResp.Valid)
+	assert.Equal(modResp.DiceRoll.Dice, verifyResp.Dice)
+
+	_, err = svc.VerifyDiceRoll(context.TODO(), dice.VerifyDiceRollRequest{DiceRollID: gmResp.DiceRoll.ID})
+	assert.ErrorIs(err, internalerrors.ErrNotAllowed)
+	_, err = svc.VerifyDiceRoll(context.TODO(), dice.VerifyDiceRollRequest{DiceRollID: gmResp.DiceRoll.ID, ViewerUserID: "user2-id"})
+	assert.ErrorIs(err, internalerrors.ErrNotAllowed)
+	verifyResp, err = svc.VerifyDiceRoll(context.TODO(), dice.VerifyDiceRollRequest{DiceRollID: gmResp.DiceRoll.ID, ViewerUserID: "user-id"})
+	require.NoError(err)
+	assert.True(verifyResp.Valid)
+
+	for _, reroll := range rerolls {
+		verifyResp, err = svc.VerifyDiceRoll(context.TODO(), dice.VerifyDiceRollRequest{DiceRollID: reroll.ID})
+		require.NoError(err)
+		assert.True(verifyResp.Valid)
+		assert.Equal(reroll.Dice, verifyResp.Dice)
+	}
+
+	// Once revealed, the rerolls use the new server seed, so they are not known in advance.
+	rerollResp, err = svc.RerollDice(context.TODO(), dice.RerollDiceRequest{
+		DiceRollID: createResp.DiceRoll.ID,
+		UserID:     "user-id",
+		DieIDs:     []string{createResp.DiceRoll.Dice[4].ID},
+	})
+	require.NoError(err)
+	assert.Equal(rotateResp.ServerSeed.ID, rerollResp.DiceRoll.Proof.ServerSeedID)
+	_, err = svc.VerifyDiceRoll(context.TODO(), dice.VerifyDiceRollRequest{DiceRollID: rerollResp.DiceRoll.ID})
+	assert.ErrorIs(err, internalerrors.ErrNotValid)
+
+	// Archived rooms don't accept dice rolls, but can reveal the server seed to verify them.
+	err = roomRepo.ArchiveRoom(context.TODO(), "room-id")
+	require.NoError(err)
+	_, err = svc.RerollDice(context.TODO(), rerollReq)
+	assert.ErrorIs(err, internalerrors.ErrNotAllowed)
+	_, err = svc.RotateRoomServerSeed(context.TODO(), dice.RotateRoomServerSeedRequest{RoomID: "room-id", UserID: "user-id"})
+	require.NoError(err)
+	verifyResp, err = svc.VerifyDiceRoll(context.TODO(), dice.VerifyDiceRollRequest{DiceRollID: rerollResp.DiceRoll.ID})
+	require.NoError(err)
+	assert.True(verifyResp.Valid)
+
+	// Tamper the stored dice roll, it should not be valid.
+	stored := diceRollRepo.DiceRollsByID[createResp.DiceRoll.ID]
+	stored.Dice[0].Side = stored.Dice[0].Side%6 + 1
+	verifyResp, err = svc.VerifyDiceRoll(context.TODO(), dice.VerifyDiceRollRequest{DiceRollID: createResp.DiceRoll.ID})
+	require.NoError(err)
+	assert.False(verifyResp.Valid)
+}
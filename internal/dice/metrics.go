@@ -61,12 +61,20 @@ func NewMeasureService(rec ServiceMetricsRecorder, next Service) Service {
 	}
 }
 
-func (m measuredService) ListDiceTypes(ctx context.Context) (resp *ListDiceTypesResponse, err error) {
+func (m measuredService) ListDiceTypes(ctx context.Context, r ListDiceTypesRequest) (resp *ListDiceTypesResponse, err error) {
 	defer func(t0 time.Time) {
 		m.rec.MeasureDiceServiceOpDuration(ctx, "ListDiceTypes", err == nil, time.Since(t0))
 	}(time.Now())
 
-	return m.next.ListDiceTypes(ctx)
+	return m.next.ListDiceTypes(ctx, r)
+}
+
+func (m measuredService) CreateCustomDieType(ctx context.Context, r CreateCustomDieTypeRequest) (resp *CreateCustomDieTypeResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureDiceServiceOpDuration(ctx, "CreateCustomDieType", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.CreateCustomDieType(ctx, r)
 }
 
 func (m measuredService) CreateDiceRoll(ctx context.Context, r CreateDiceRollRequest) (resp *CreateDiceRollResponse, err error) {
@@ -77,6 +85,22 @@ func (m measuredService) CreateDiceRoll(ctx context.Context, r CreateDiceRollReq
 	return m.next.CreateDiceRoll(ctx, r)
 }
 
+func (m measuredService) CreateDiceRolls(ctx context.Context, r CreateDiceRollsRequest) (resp *CreateDiceRollsResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureDiceServiceOpDuration(ctx, "CreateDiceRolls", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.CreateDiceRolls(ctx, r)
+}
+
+func (m measuredService) CreateDiceRollSequence(ctx context.Context, r CreateDiceRollSequenceRequest) (resp *CreateDiceRollSequenceResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureDiceServiceOpDuration(ctx, "CreateDiceRollSequence", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.CreateDiceRollSequence(ctx, r)
+}
+
 func (m measuredService) ListDiceRolls(ctx context.Context, r ListDiceRollsRequest) (resp *ListDiceRollsResponse, err error) {
 	defer func(t0 time.Time) {
 		m.rec.MeasureDiceServiceOpDuration(ctx, "ListDiceRolls", err == nil, time.Since(t0))
@@ -92,3 +116,43 @@ func (m measuredService) SubscribeDiceRollCreated(ctx context.Context, r Subscri
 
 	return m.next.SubscribeDiceRollCreated(ctx, r)
 }
+
+func (m measuredService) GetRoomServerSeed(ctx context.Context, r GetRoomServerSeedRequest) (resp *GetRoomServerSeedResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureDiceServiceOpDuration(ctx, "GetRoomServerSeed", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.GetRoomServerSeed(ctx, r)
+}
+
+func (m measuredService) RotateRoomServerSeed(ctx context.Context, r RotateRoomServerSeedRequest) (resp *RotateRoomServerSeedResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureDiceServiceOpDuration(ctx, "RotateRoomServerSeed", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.RotateRoomServerSeed(ctx, r)
+}
+
+func (m measuredService) VerifyDiceRoll(ctx context.Context, r VerifyDiceRollRequest) (resp *VerifyDiceRollResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureDiceServiceOpDuration(ctx, "VerifyDiceRoll", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.VerifyDiceRoll(ctx, r)
+}
+
+func (m measuredService) RerollDice(ctx context.Context, r RerollDiceRequest) (resp *RerollDiceResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureDiceServiceOpDuration(ctx, "RerollDice", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.RerollDice(ctx, r)
+}
+
+func (m measuredService) CalculateProbabilities(ctx context.Context, r CalculateProbabilitiesRequest) (resp *CalculateProbabilitiesResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureDiceServiceOpDuration(ctx, "CalculateProbabilities", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.CalculateProbabilities(ctx, r)
+}
@@ -0,0 +1,154 @@
+package dice
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/rollify/rollify/internal/model"
+)
+
+// DiceRollModifiers are the mechanics applied to the rolled dice, the zero value doesn't modify the dice roll.
+//
+// The modifiers are applied in order: first the rerolls, then the explosions and at last the keep/drop.
+type DiceRollModifiers struct {
+	// RerollBelow rerolls once the dice whose side is below N.
+	RerollBelow uint
+	// Explode rolls an extra die each time a die gets its max side, the extra dice can explode too.
+	Explode bool
+	// KeepHighest keeps the N highest dice, dropping the rest.
+	KeepHighest uint
+	// KeepLowest keeps the N lowest dice, dropping the rest.
+	KeepLowest uint
+	// DropHighest drops the N highest dice.
+	DropHighest uint
+	// DropLowest drops the N lowest dice.
+	DropLowest uint
+}
+
+// maxExplodedDice is the max number of extra dice that explosions can roll on a single dice roll.
+const maxExplodedDice = 100
+
+func (m DiceRollModifiers) validate(dice int) error {
+	if m.RerollBelow > model.MaxDieTypeSides {
+		return fmt.Errorf("max config.Modifiers.RerollBelow is %d, got %d", model.MaxDieTypeSides, m.RerollBelow)
+	}
+
+	selectors := 0
+	for _, q := range []uint{m.KeepHighest, m.KeepLowest, m.DropHighest, m.DropLowest} {
+		if q == 0 {
+			continue
+		}
+		selectors++
+
+		if q > uint(dice) {
+			return fmt.Errorf("config.Modifiers keep/drop quantity can't be greater than the dice quantity (%d), got %d", dice, q)
+		}
+	}
+
+	if selectors > 1 {
+		return fmt.Errorf("only one of config.Modifiers keep/drop can be used at the same time")
+	}
+
+	return nil
+}
+
+// rollWithModifiers rolls the dice roll dice and applies the modifiers, the rerolled and exploded dice
+// are appended to the dice roll, the discarded dice are kept with their status.
+func (s service) rollWithModifiers(ctx context.Context, dr *model.DiceRoll, m DiceRollModifiers) error {
+	err := s.roller.Roll(ctx, dr)
+	if err != nil {
+		return err
+	}
+
+	// Reroll once the dice below N.
+	if m.RerollBelow > 0 {
+		n := len(dr.Dice)
+		for i := 0; i < n; i++ {
+			if dr.Dice[i].Side >= m.RerollBelow {
+				continue
+			}
+			dr.Dice[i].Status = model.DieRollStatusRerolled
+			dr.Dice = append(dr.Dice, model.DieRoll{ID: s.idGen(), Type: dr.Dice[i].Type})
+		}
+
+		err := s.rollNewDice(ctx, dr, n)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Explode the dice with max side, until there are no more explosions.
+	if m.Explode {
+		exploded := 0
+		for from := 0; from < len(dr.Dice); {
+			n := len(dr.Dice)
+			for i := from; i < n && exploded < maxExplodedDice; i++ {
+				if dr.Dice[i].Status != model.DieRollStatusKept || dr.Dice[i].Side != dr.Dice[i].Type.Sides() {
+					continue
+				}
+				dr.Dice[i].Status = model.DieRollStatusExploded
+				dr.Dice = append(dr.Dice, model.DieRoll{ID: s.idGen(), Type: dr.Dice[i].Type})
+				exploded++
+			}
+
+			err := s.rollNewDice(ctx, dr, n)
+			if err != nil {
+				return err
+			}
+			from = n
+		}
+	}
+
+	keepDropDice(dr.Dice, m)
+
+	return nil
+}
+
+// rollNewDice rolls the dice roll dice starting at `from` index. The roller rolls all the dice and
+// the already rolled ones are restored, this way the deterministic rollers (e.g: provably fair)
+// continue the same sequence and the dice roll can be verified.
+func (s service) rollNewDice(ctx context.Context, dr *model.DiceRoll, from int) error {
+	if from >= len(dr.Dice) {
+		return nil
+	}
+
+	rolled := slices.Clone(dr.Dice[:from])
+	err := s.roller.Roll(ctx, dr)
+	if err != nil {
+		return err
+	}
+	copy(dr.Dice, rolled)
+
+	return nil
+}
+
+// keepDropDice drops the dice based on the keep/drop modifiers, only the dice that are
+// not discarded are taken into account.
+func keepDropDice(dice []model.DieRoll, m DiceRollModifiers) {
+	// Sort the dice indexes by value (stable, so equal values are dropped in roll order).
+	idxs := []int{}
+	for i, d := range dice {
+		if !d.Discarded() {
+			idxs = append(idxs, i)
+		}
+	}
+	sort.SliceStable(idxs, func(i, j int) bool { return dice[idxs[i]].Value() < dice[idxs[j]].Value() })
+
+	// Get the dice we need to drop.
+	var drop []int
+	switch {
+	case m.KeepHighest > 0:
+		drop = idxs[:len(idxs)-min(int(m.KeepHighest), len(idxs))]
+	case m.KeepLowest > 0:
+		drop = idxs[min(int(m.KeepLowest), len(idxs)):]
+	case m.DropHighest > 0:
+		drop = idxs[len(idxs)-min(int(m.DropHighest), len(idxs)):]
+	case m.DropLowest > 0:
+		drop = idxs[:min(int(m.DropLowest), len(idxs))]
+	}
+	for _, i := range drop {
+		dice[i].Status = model.DieRollStatusDropped
+	}
+}
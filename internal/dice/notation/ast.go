@@ -0,0 +1,107 @@
+package notation
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Node is a node of a dice notation expression AST.
+type Node interface {
+	// String returns the node in dice notation.
+	String() string
+}
+
+// NumberNode is a constant number, e.g: `3`.
+type NumberNode struct {
+	Value int
+}
+
+func (n NumberNode) String() string { return strconv.Itoa(n.Value) }
+
+// SelectorKind is the kind of dice selection made after rolling a group of dice.
+type SelectorKind int
+
+const (
+	// SelectorKeepHighest keeps the N highest dice, e.g: `4d6kh3`.
+	SelectorKeepHighest SelectorKind = iota
+	// SelectorKeepLowest keeps the N lowest dice, e.g: `2d20kl1`.
+	SelectorKeepLowest
+	// SelectorDropHighest drops the N highest dice, e.g: `4d6dh1`.
+	SelectorDropHighest
+	// SelectorDropLowest drops the N lowest dice, e.g: `4d6dl1`.
+	SelectorDropLowest
+)
+
+func (s SelectorKind) String() string {
+	switch s {
+	case SelectorKeepHighest:
+		return "kh"
+	case SelectorKeepLowest:
+		return "kl"
+	case SelectorDropHighest:
+		return "dh"
+	case SelectorDropLowest:
+		return "dl"
+	}
+	return "unknown"
+}
+
+// Selector selects what dice of a group will be used on the total.
+type Selector struct {
+	Kind     SelectorKind
+	Quantity uint
+}
+
+// DiceNode is a group of dice of the same type, e.g: `4d6kh3`.
+type DiceNode struct {
+	Quantity uint
+	Sides    uint
+	// Selector is optional.
+	Selector *Selector
+}
+
+func (d DiceNode) String() string {
+	s := fmt.Sprintf("%dd%d", d.Quantity, d.Sides)
+	if d.Selector != nil {
+		s += fmt.Sprintf("%s%d", d.Selector.Kind, d.Selector.Quantity)
+	}
+	return s
+}
+
+// Operator is an arithmetic operator.
+type Operator int
+
+const (
+	// OperatorAdd adds two nodes.
+	OperatorAdd Operator = iota
+	// OperatorSubtract subtracts two nodes.
+	OperatorSubtract
+)
+
+func (o Operator) String() string {
+	switch o {
+	case OperatorAdd:
+		return "+"
+	case OperatorSubtract:
+		return "-"
+	}
+	return "unknown"
+}
+
+// BinaryNode is an operation between two nodes, e.g: `1d20+5`.
+type BinaryNode struct {
+	Operator Operator
+	Left     Node
+	Right    Node
+}
+
+func (b BinaryNode) String() string {
+	return b.Left.String() + b.Operator.String() + b.Right.String()
+}
+
+// NegateNode negates a node, e.g: `-1d4`.
+type NegateNode struct {
+	Node Node
+}
+
+func (n NegateNode) String() string { return "-" + n.Node.String() }
@@ -0,0 +1,115 @@
+package notation
+
+import (
+	"fmt"
+	"sort"
+)
+
+// RollFunc knows how to roll a quantity of dice with the same number of sides. It must
+// return one value per die and each value must be between 1 and sides.
+type RollFunc func(sides, quantity uint) ([]uint, error)
+
+// DieResult is the result of a single rolled die.
+type DieResult struct {
+	Sides uint
+	Value uint
+	// Dropped is true when a selector discarded the die, so it doesn't count on the total.
+	Dropped bool
+}
+
+// Result is the result of evaluating an expression.
+type Result struct {
+	Total int
+	// Dice are all the rolled dice in the same order they were rolled.
+	Dice []DieResult
+}
+
+func eval(n Node, roll RollFunc, res *Result) (int, error) {
+	switch n := n.(type) {
+	case NumberNode:
+		return n.Value, nil
+
+	case NegateNode:
+		v, err := eval(n.Node, roll, res)
+		if err != nil {
+			return 0, err
+		}
+		return -v, nil
+
+	case BinaryNode:
+		l, err := eval(n.Left, roll, res)
+		if err != nil {
+			return 0, err
+		}
+		r, err := eval(n.Right, roll, res)
+		if err != nil {
+			return 0, err
+		}
+		switch n.Operator {
+		case OperatorAdd:
+			return l + r, nil
+		case OperatorSubtract:
+			return l - r, nil
+		}
+		return 0, fmt.Errorf("unknown operator %d", n.Operator)
+
+	case DiceNode:
+		return evalDice(n, roll, res)
+	}
+
+	return 0, fmt.Errorf("unknown node %T", n)
+}
+
+func evalDice(n DiceNode, roll RollFunc, res *Result) (int, error) {
+	values, err := roll(n.Sides, n.Quantity)
+	if err != nil {
+		return 0, fmt.Errorf("could not roll %s: %w", n, err)
+	}
+	if uint(len(values)) != n.Quantity {
+		return 0, fmt.Errorf("expected %d rolled dice for %s, got %d", n.Quantity, n, len(values))
+	}
+
+	dice := make([]DieResult, 0, len(values))
+	for _, v := range values {
+		if v < 1 || v > n.Sides {
+			return 0, fmt.Errorf("invalid rolled value %d for %s", v, n)
+		}
+		dice = append(dice, DieResult{Sides: n.Sides, Value: v})
+	}
+
+	if n.Selector != nil {
+		// Sort the dice indexes by value (stable, so equal values are dropped in roll order).
+		idxs := make([]int, len(dice))
+		for i := range idxs {
+			idxs[i] = i
+		}
+		sort.SliceStable(idxs, func(i, j int) bool { return dice[idxs[i]].Value < dice[idxs[j]].Value })
+
+		// Get the dice we need to drop.
+		q := int(n.Selector.Quantity)
+		var drop []int
+		switch n.Selector.Kind {
+		case SelectorKeepHighest:
+			drop = idxs[:len(idxs)-q]
+		case SelectorKeepLowest:
+			drop = idxs[q:]
+		case SelectorDropHighest:
+			drop = idxs[len(idxs)-q:]
+		case SelectorDropLowest:
+			drop = idxs[:q]
+		}
+		for _, i := range drop {
+			dice[i].Dropped = true
+		}
+	}
+
+	total := 0
+	for _, d := range dice {
+		if !d.Dropped {
+			total += int(d.Value)
+		}
+	}
+	res.Dice = append(res.Dice, dice...)
+
+	return total, nil
+}
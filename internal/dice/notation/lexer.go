@@ -0,0 +1,127 @@
+package notation
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenDice
+	tokenPlus
+	tokenMinus
+	tokenKeepHighest
+	tokenKeepLowest
+	tokenDropHighest
+	tokenDropLowest
+)
+
+func (t tokenKind) String() string {
+	switch t {
+	case tokenEOF:
+		return "end of expression"
+	case tokenNumber:
+		return "number"
+	case tokenDice:
+		return "'d'"
+	case tokenPlus:
+		return "'+'"
+	case tokenMinus:
+		return "'-'"
+	case tokenKeepHighest:
+		return "'kh'"
+	case tokenKeepLowest:
+		return "'kl'"
+	case tokenDropHighest:
+		return "'dh'"
+	case tokenDropLowest:
+		return "'dl'"
+	}
+	return "unknown"
+}
+
+type token struct {
+	kind  tokenKind
+	value int
+	// pos is the position of the token on the expression (starting from 1).
+	pos int
+}
+
+// maxNumber is the maximum number we accept on an expression, this way we avoid
+// overflows and absurd expressions.
+const maxNumber = 1000000
+
+// lex splits the dice notation expression in tokens, the last
+// token is always an EOF token.
+func lex(s string) ([]token, error) {
+	tokens := []token{}
+	for i := 0; i < len(s); {
+		c := s[i]
+		pos := i + 1
+
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+
+		case c >= '0' && c <= '9':
+			j := i
+			for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+				j++
+			}
+			n, err := strconv.Atoi(s[i:j])
+			if err != nil || n > maxNumber {
+				return nil, fmt.Errorf("number at position %d is too big, max is %d", pos, maxNumber)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, value: n, pos: pos})
+			i = j
+
+		case c == '+':
+			tokens = append(tokens, token{kind: tokenPlus, pos: pos})
+			i++
+
+		case c == '-':
+			tokens = append(tokens, token{kind: tokenMinus, pos: pos})
+			i++
+
+		case c == 'k' || c == 'K':
+			// `k` alone is a shortcut for `kh`.
+			kind := tokenKeepHighest
+			i++
+			if i < len(s) {
+				switch s[i] {
+				case 'h', 'H':
+					i++
+				case 'l', 'L':
+					kind = tokenKeepLowest
+					i++
+				}
+			}
+			tokens = append(tokens, token{kind: kind, pos: pos})
+
+		case c == 'd' || c == 'D':
+			kind := tokenDice
+			i++
+			if i < len(s) {
+				switch s[i] {
+				case 'h', 'H':
+					kind = tokenDropHighest
+					i++
+				case 'l', 'L':
+					kind = tokenDropLowest
+					i++
+				}
+			}
+			tokens = append(tokens, token{kind: kind, pos: pos})
+
+		default:
+			return nil, fmt.Errorf("invalid character %q at position %d", c, pos)
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(s) + 1})
+
+	return tokens, nil
+}
@@ -0,0 +1,118 @@
+// Package notation implements the dice notation used by players to describe dice rolls,
+// e.g: `2d6+3`, `4d6kh3` or `1d20+1d4-1`.
+package notation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxExpressionLength is the maximum length of an expression.
+const MaxExpressionLength = 255
+
+// Expression is a parsed dice notation expression.
+type Expression struct {
+	Root Node
+}
+
+// Parse parses a dice notation expression.
+func Parse(s string) (*Expression, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, fmt.Errorf("expression is empty")
+	}
+
+	if len(s) > MaxExpressionLength {
+		return nil, fmt.Errorf("max expression length is %d, got %d", MaxExpressionLength, len(s))
+	}
+
+	tokens, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %s at position %d", t.kind, t.pos)
+	}
+
+	return &Expression{Root: root}, nil
+}
+
+// String returns the expression in normalized dice notation.
+func (e Expression) String() string { return e.Root.String() }
+
+// Dice returns all the dice groups of the expression in the order they will be rolled.
+func (e Expression) Dice() []DiceNode {
+	ds := []DiceNode{}
+	var walk func(n Node)
+	walk = func(n Node) {
+		switch n := n.(type) {
+		case DiceNode:
+			ds = append(ds, n)
+		case NegateNode:
+			walk(n.Node)
+		case BinaryNode:
+			walk(n.Left)
+			walk(n.Right)
+		}
+	}
+	walk(e.Root)
+
+	return ds
+}
+
+// Eval evaluates the expression rolling the dice using the roll func.
+func (e Expression) Eval(roll RollFunc) (*Result, error) {
+	res := &Result{}
+	total, err := eval(e.Root, roll, res)
+	if err != nil {
+		return nil, err
+	}
+	res.Total = total
+
+	return res, nil
+}
+
+// Range returns the min and max totals the expression can roll, every total between
+// them can be rolled.
+func (e Expression) Range() (min, max int) {
+	return nodeRange(e.Root)
+}
+
+func nodeRange(n Node) (min, max int) {
+	switch n := n.(type) {
+	case NumberNode:
+		return n.Value, n.Value
+
+	case NegateNode:
+		min, max := nodeRange(n.Node)
+		return -max, -min
+
+	case BinaryNode:
+		lMin, lMax := nodeRange(n.Left)
+		rMin, rMax := nodeRange(n.Right)
+		if n.Operator == OperatorSubtract {
+			return lMin - rMax, lMax - rMin
+		}
+		return lMin + rMin, lMax + rMax
+
+	case DiceNode:
+		kept := n.Quantity
+		if n.Selector != nil {
+			switch n.Selector.Kind {
+			case SelectorKeepHighest, SelectorKeepLowest:
+				kept = n.Selector.Quantity
+			case SelectorDropHighest, SelectorDropLowest:
+				kept = n.Quantity - n.Selector.Quantity
+			}
+		}
+		return int(kept), int(kept * n.Sides)
+	}
+
+	return 0, 0
+}
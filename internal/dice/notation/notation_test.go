@@ -0,0 +1,342 @@
+package notation_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/dice/notation"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		expString  string
+		expDice    []notation.DiceNode
+		expErr     bool
+	}{
+		"An empty expression should fail.": {
+			expression: "  ",
+			expErr:     true,
+		},
+
+		"A single number should be parsed.": {
+			expression: "5",
+			expString:  "5",
+			expDice:    []notation.DiceNode{},
+		},
+
+		"A single die without quantity should be parsed.": {
+			expression: "d20",
+			expString:  "1d20",
+			expDice:    []notation.DiceNode{{Quantity: 1, Sides: 20}},
+		},
+
+		"Dice with a constant should be parsed.": {
+			expression: " 2d6 + 3 ",
+			expString:  "2d6+3",
+			expDice:    []notation.DiceNode{{Quantity: 2, Sides: 6}},
+		},
+
+		"Multiple dice groups with additions and subtractions should be parsed.": {
+			expression: "1D20+1d4-1",
+			expString:  "1d20+1d4-1",
+			expDice: []notation.DiceNode{
+				{Quantity: 1, Sides: 20},
+				{Quantity: 1, Sides: 4},
+			},
+		},
+
+		"A leading negation should be parsed.": {
+			expression: "-1d4+2",
+			expString:  "-1d4+2",
+			expDice:    []notation.DiceNode{{Quantity: 1, Sides: 4}},
+		},
+
+		"Keep and drop selectors should be parsed.": {
+			expression: "4d6kh3+2d20kl1+4d6dh1+4d6dl1+2d20k1",
+			expString:  "4d6kh3+2d20kl1+4d6dh1+4d6dl1+2d20kh1",
+			expDice: []notation.DiceNode{
+				{Quantity: 4, Sides: 6, Selector: &notation.Selector{Kind: notation.SelectorKeepHighest, Quantity: 3}},
+				{Quantity: 2, Sides: 20, Selector: &notation.Selector{Kind: notation.SelectorKeepLowest, Quantity: 1}},
+				{Quantity: 4, Sides: 6, Selector: &notation.Selector{Kind: notation.SelectorDropHighest, Quantity: 1}},
+				{Quantity: 4, Sides: 6, Selector: &notation.Selector{Kind: notation.SelectorDropLowest, Quantity: 1}},
+				{Quantity: 2, Sides: 20, Selector: &notation.Selector{Kind: notation.SelectorKeepHighest, Quantity: 1}},
+			},
+		},
+
+		"Invalid characters should fail.": {
+			expression: "2d6*3",
+			expErr:     true,
+		},
+
+		"A missing operand should fail.": {
+			expression: "2d6+",
+			expErr:     true,
+		},
+
+		"Missing die sides should fail.": {
+			expression: "2d",
+			expErr:     true,
+		},
+
+		"Consecutive operands should fail.": {
+			expression: "2d6 3",
+			expErr:     true,
+		},
+
+		"Zero dice should fail.": {
+			expression: "0d6",
+			expErr:     true,
+		},
+
+		"Too many dice should fail.": {
+			expression: "101d6",
+			expErr:     true,
+		},
+
+		"Zero sided dice should fail.": {
+			expression: "1d0",
+			expErr:     true,
+		},
+
+		"Keeping more dice than rolled should fail.": {
+			expression: "2d6kh3",
+			expErr:     true,
+		},
+
+		"Too big numbers should fail.": {
+			expression: "1d20+99999999999999999999",
+			expErr:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			gotExp, err := notation.Parse(test.expression)
+
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expString, gotExp.String())
+				assert.Equal(test.expDice, gotExp.Dice())
+			}
+		})
+	}
+}
+
+func TestExpressionEval(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		roll       func(t *testing.T) notation.RollFunc
+		expResult  *notation.Result
+		expErr     bool
+	}{
+		"A constant expression should not roll dice.": {
+			expression: "3-5",
+			roll: func(t *testing.T) notation.RollFunc {
+				return func(sides, quantity uint) ([]uint, error) {
+					t.Fatalf("roll should not be called")
+					return nil, nil
+				}
+			},
+			expResult: &notation.Result{Total: -2},
+		},
+
+		"Dice with modifiers should add and subtract the rolled values.": {
+			expression: "1d20+1d4-1",
+			roll: func(t *testing.T) notation.RollFunc {
+				values := map[uint][]uint{20: {17}, 4: {3}}
+				return func(sides, quantity uint) ([]uint, error) {
+					return values[sides], nil
+				}
+			},
+			expResult: &notation.Result{
+				Total: 19,
+				Dice: []notation.DieResult{
+					{Sides: 20, Value: 17},
+					{Sides: 4, Value: 3},
+				},
+			},
+		},
+
+		"A negated dice group should subtract its value.": {
+			expression: "10-2d6",
+			roll: func(t *testing.T) notation.RollFunc {
+				return func(sides, quantity uint) ([]uint, error) { return []uint{2, 5}, nil }
+			},
+			expResult: &notation.Result{
+				Total: 3,
+				Dice: []notation.DieResult{
+					{Sides: 6, Value: 2},
+					{Sides: 6, Value: 5},
+				},
+			},
+		},
+
+		"Keeping the highest dice should drop the lowest ones.": {
+			expression: "4d6kh3",
+			roll: func(t *testing.T) notation.RollFunc {
+				return func(sides, quantity uint) ([]uint, error) { return []uint{3, 1, 6, 1}, nil }
+			},
+			expResult: &notation.Result{
+				Total: 10,
+				Dice: []notation.DieResult{
+					{Sides: 6, Value: 3},
+					{Sides: 6, Value: 1, Dropped: true},
+					{Sides: 6, Value: 6},
+					{Sides: 6, Value: 1},
+				},
+			},
+		},
+
+		"Keeping the lowest dice should drop the highest ones.": {
+			expression: "2d20kl1",
+			roll: func(t *testing.T) notation.RollFunc {
+				return func(sides, quantity uint) ([]uint, error) { return []uint{15, 4}, nil }
+			},
+			expResult: &notation.Result{
+				Total: 4,
+				Dice: []notation.DieResult{
+					{Sides: 20, Value: 15, Dropped: true},
+					{Sides: 20, Value: 4},
+				},
+			},
+		},
+
+		"Dropping the highest dice should keep the lowest ones.": {
+			expression: "3d6dh1",
+			roll: func(t *testing.T) notation.RollFunc {
+				return func(sides, quantity uint) ([]uint, error) { return []uint{6, 2, 6}, nil }
+			},
+			expResult: &notation.Result{
+				Total: 8,
+				Dice: []notation.DieResult{
+					{Sides: 6, Value: 6},
+					{Sides: 6, Value: 2},
+					{Sides: 6, Value: 6, Dropped: true},
+				},
+			},
+		},
+
+		"Dropping the lowest dice should keep the highest ones.": {
+			expression: "3d6dl2",
+			roll: func(t *testing.T) notation.RollFunc {
+				return func(sides, quantity uint) ([]uint, error) { return []uint{4, 2, 5}, nil }
+			},
+			expResult: &notation.Result{
+				Total: 5,
+				Dice: []notation.DieResult{
+					{Sides: 6, Value: 4, Dropped: true},
+					{Sides: 6, Value: 2, Dropped: true},
+					{Sides: 6, Value: 5},
+				},
+			},
+		},
+
+		"An error while rolling should fail.": {
+			expression: "1d20",
+			roll: func(t *testing.T) notation.RollFunc {
+				return func(sides, quantity uint) ([]uint, error) { return nil, fmt.Errorf("whatever") }
+			},
+			expErr: true,
+		},
+
+		"Rolling a different number of dice should fail.": {
+			expression: "2d20",
+			roll: func(t *testing.T) notation.RollFunc {
+				return func(sides, quantity uint) ([]uint, error) { return []uint{1}, nil }
+			},
+			expErr: true,
+		},
+
+		"Rolling invalid die values should fail.": {
+			expression: "1d6",
+			roll: func(t *testing.T) notation.RollFunc {
+				return func(sides, quantity uint) ([]uint, error) { return []uint{7}, nil }
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			exp, err := notation.Parse(test.expression)
+			require.NoError(err)
+
+			gotResult, err := exp.Eval(test.roll(t))
+
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expResult, gotResult)
+			}
+		})
+	}
+}
+
+func TestExpressionRange(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		expMin     int
+		expMax     int
+	}{
+		"A constant expression should have a single value range.": {
+			expression: "3-5",
+			expMin:     -2,
+			expMax:     -2,
+		},
+
+		"Dice with modifiers should add the dice ranges.": {
+			expression: "2d6+1d4+1",
+			expMin:     4,
+			expMax:     17,
+		},
+
+		"Subtracted dice should subtract the opposite range limits.": {
+			expression: "1d20-1d6",
+			expMin:     -5,
+			expMax:     19,
+		},
+
+		"A negated dice group should swap its range limits.": {
+			expression: "-2d6",
+			expMin:     -12,
+			expMax:     -2,
+		},
+
+		"Keeping dice should only use the kept dice.": {
+			expression: "4d6kh3",
+			expMin:     3,
+			expMax:     18,
+		},
+
+		"Dropping dice should only use the not dropped dice.": {
+			expression: "4d6dl1+2d20kl1",
+			expMin:     4,
+			expMax:     38,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			exp, err := notation.Parse(test.expression)
+			require.NoError(err)
+
+			gotMin, gotMax := exp.Range()
+
+			assert.Equal(test.expMin, gotMin)
+			assert.Equal(test.expMax, gotMax)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package notation
+
+import (
+	"fmt"
+)
+
+// Grammar:
+//
+//	expression = term { ( "+" | "-" ) term } ;
+//	term       = [ "-" ] ( dice | number ) ;
+//	dice       = [ number ] "d" number [ selector number ] ;
+//	selector   = "k" | "kh" | "kl" | "dh" | "dl" ;
+
+const (
+	maxDiceQuantity = 100
+	maxDieSides     = 1000
+)
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(kind tokenKind) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, fmt.Errorf("expected %s at position %d, got %s", kind, t.pos, t.kind)
+	}
+	return t, nil
+}
+
+func (p *parser) parseExpression() (Node, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		var op Operator
+		switch p.peek().kind {
+		case tokenPlus:
+			op = OperatorAdd
+		case tokenMinus:
+			op = OperatorSubtract
+		default:
+			return left, nil
+		}
+		p.next()
+
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = BinaryNode{Operator: op, Left: left, Right: right}
+	}
+}
+
+func (p *parser) parseTerm() (Node, error) {
+	if p.peek().kind == tokenMinus {
+		p.next()
+		n, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return NegateNode{Node: n}, nil
+	}
+
+	return p.parseOperand()
+}
+
+func (p *parser) parseOperand() (Node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		// A number followed by a `d` is the dice quantity.
+		if p.peek().kind != tokenDice {
+			return NumberNode{Value: t.value}, nil
+		}
+		p.next()
+		return p.parseDice(t.value, t.pos)
+	case tokenDice:
+		// `d20` is the same as `1d20`.
+		return p.parseDice(1, t.pos)
+	}
+
+	return nil, fmt.Errorf("expected number or dice at position %d, got %s", t.pos, t.kind)
+}
+
+func (p *parser) parseDice(quantity int, pos int) (Node, error) {
+	if quantity < 1 || quantity > maxDiceQuantity {
+		return nil, fmt.Errorf("dice quantity at position %d must be between 1 and %d", pos, maxDiceQuantity)
+	}
+
+	t, err := p.expect(tokenNumber)
+	if err != nil {
+		return nil, err
+	}
+	if t.value < 1 || t.value > maxDieSides {
+		return nil, fmt.Errorf("die sides at position %d must be between 1 and %d", t.pos, maxDieSides)
+	}
+
+	d := DiceNode{
+		Quantity: uint(quantity),
+		Sides:    uint(t.value),
+	}
+
+	var kind SelectorKind
+	switch p.peek().kind {
+	case tokenKeepHighest:
+		kind = SelectorKeepHighest
+	case tokenKeepLowest:
+		kind = SelectorKeepLowest
+	case tokenDropHighest:
+		kind = SelectorDropHighest
+	case tokenDropLowest:
+		kind = SelectorDropLowest
+	default:
+		return d, nil
+	}
+	p.next()
+
+	t, err = p.expect(tokenNumber)
+	if err != nil {
+		return nil, err
+	}
+	if t.value > quantity {
+		return nil, fmt.Errorf("selector quantity at position %d can't be greater than the dice quantity (%d)", t.pos, quantity)
+	}
+	d.Selector = &Selector{Kind: kind, Quantity: uint(t.value)}
+
+	return d, nil
+}
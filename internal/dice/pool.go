@@ -0,0 +1,78 @@
+package dice
+
+import (
+	"fmt"
+
+	"github.com/rollify/rollify/internal/model"
+)
+
+// DicePool are the rules of a success-counting dice pool (e.g: World of Darkness, Shadowrun,
+// Blades in the Dark), the zero value means the dice roll is not a dice pool.
+type DicePool struct {
+	// Target is the target number, each die whose value is equal or greater counts as a success.
+	Target uint
+	// OnesCancel makes each die that got a 1 cancel a success.
+	OnesCancel bool
+	// CriticalDice is the number of dice that need to get their max side to be a critical success,
+	// 0 disables critical successes.
+	CriticalDice uint
+	// Botch makes a critical failure the dice pools without successes that got more ones than successes.
+	Botch bool
+}
+
+func (p DicePool) validate() error {
+	if p.Target == 0 {
+		if p != (DicePool{}) {
+			return fmt.Errorf("config.Pool.Target is required")
+		}
+		return nil
+	}
+
+	if p.Target > model.MaxDieTypeSides {
+		return fmt.Errorf("max config.Pool.Target is %d, got %d", model.MaxDieTypeSides, p.Target)
+	}
+
+	if p.CriticalDice > maxDiceQuantity {
+		return fmt.Errorf("max config.Pool.CriticalDice is %d, got %d", maxDiceQuantity, p.CriticalDice)
+	}
+
+	return nil
+}
+
+// evaluate counts the successes of the dice that are not discarded and returns the outcome.
+func (p DicePool) evaluate(dice []model.DieRoll) model.DicePoolResult {
+	var successes, ones, criticals int
+	for _, d := range dice {
+		if d.Discarded() {
+			continue
+		}
+
+		if d.Value() >= int(p.Target) {
+			successes++
+		}
+		if d.Side == 1 {
+			ones++
+		}
+		if d.Side == d.Type.Sides() {
+			criticals++
+		}
+	}
+
+	res := model.DicePoolResult{Successes: successes}
+	if p.OnesCancel {
+		res.Successes = max(successes-ones, 0)
+	}
+
+	switch {
+	case res.Successes > 0 && p.CriticalDice > 0 && criticals >= int(p.CriticalDice):
+		res.Outcome = model.DicePoolOutcomeCriticalSuccess
+	case res.Successes > 0:
+		res.Outcome = model.DicePoolOutcomeSuccess
+	case p.Botch && ones > successes:
+		res.Outcome = model.DicePoolOutcomeCriticalFailure
+	default:
+		res.Outcome = model.DicePoolOutcomeFailure
+	}
+
+	return res
+}
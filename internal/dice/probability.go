@@ -0,0 +1,492 @@
+package dice
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/rollify/rollify/internal/dice/notation"
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+)
+
+// Distribution is the probability distribution of a dice roll total.
+type Distribution struct {
+	// Totals are the possible totals with their probability, sorted by total.
+	Totals []TotalProbability
+	// Exact is false when the distribution is estimated simulating dice rolls (e.g: exploding dice).
+	Exact bool
+}
+
+// TotalProbability is the probability of getting a dice roll total.
+type TotalProbability struct {
+	Total       int
+	Probability float64
+}
+
+// Mean returns the expected dice roll total.
+func (d Distribution) Mean() float64 {
+	var mean float64
+	for _, t := range d.Totals {
+		mean += float64(t.Total) * t.Probability
+	}
+
+	return mean
+}
+
+// CheckProbability returns the probability of the total passing the difficulty check, the critical
+// successes and failures of the natural max and min rolls are not taken into account.
+func (d Distribution) CheckProbability(c DiceCheck) float64 {
+	var p float64
+	for _, t := range d.Totals {
+		if c.evaluate(t.Total, nil).Outcome == model.DiceRollCheckOutcomeSuccess {
+			p += t.Probability
+		}
+	}
+
+	return min(p, 1)
+}
+
+const (
+	// maxExactTotals is the max number of different totals calculated exactly, bigger
+	// distributions (e.g: 100d1000) are simulated.
+	maxExactTotals = 10000
+	// probabilitySimulations is the number of dice rolls simulated to estimate a distribution.
+	probabilitySimulations = 20000
+	// maxProbabilityDice is the max number of dice of a probabilities calculation.
+	maxProbabilityDice = 50
+	// maxProbabilityDieSides is the max sides of the dice of a probabilities calculation.
+	maxProbabilityDieSides = 100
+)
+
+// distribution is a discrete probability distribution, p[i] is the probability of getting `min + i`.
+type distribution struct {
+	min int
+	p   []float64
+}
+
+func constantDistribution(v int) distribution {
+	return distribution{min: v, p: []float64{1}}
+}
+
+// newDistribution returns the uniform distribution of the values (e.g: the sides of a die).
+func newDistribution(values []int) distribution {
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+
+	d := distribution{min: lo, p: make([]float64, hi-lo+1)}
+	for _, v := range values {
+		d.p[v-lo] += 1 / float64(len(values))
+	}
+
+	return d
+}
+
+// dieValues returns what each side of the die type counts.
+func dieValues(dt model.DieType) []int {
+	vs := make([]int, 0, dt.Sides())
+	for side := uint(1); side <= dt.Sides(); side++ {
+		vs = append(vs, model.DieRoll{Type: dt, Side: side}.Value())
+	}
+
+	return vs
+}
+
+// add returns the distribution of the sum of both distributions, false if the result would have
+// too many totals to be calculated exactly.
+func (d distribution) add(o distribution) (distribution, bool) {
+	n := len(d.p) + len(o.p) - 1
+	if n > maxExactTotals {
+		return distribution{}, false
+	}
+
+	res := distribution{min: d.min + o.min, p: make([]float64, n)}
+	for i, pi := range d.p {
+		if pi == 0 {
+			continue
+		}
+		for j, pj := range o.p {
+			res.p[i+j] += pi * pj
+		}
+	}
+
+	return res, true
+}
+
+func (d distribution) negate() distribution {
+	res := distribution{min: -(d.min + len(d.p) - 1), p: make([]float64, len(d.p))}
+	for i, p := range d.p {
+		res.p[len(d.p)-1-i] = p
+	}
+
+	return res
+}
+
+func (d distribution) toModel() Distribution {
+	res := Distribution{Exact: true}
+	for i, p := range d.p {
+		if p > 0 {
+			res.Totals = append(res.Totals, TotalProbability{Total: d.min + i, Probability: p})
+		}
+	}
+
+	return res
+}
+
+// CalculateProbabilitiesRequest is the request for CalculateProbabilities, the dice roll is
+// described in the same way as on CreateDiceRoll.
+type CalculateProbabilitiesRequest struct {
+	// RoomID and UserID are required, the calculations are limited with the same room and
+	// user rates as the dice rolls.
+	RoomID     string
+	UserID     string
+	Dice       []model.DieType
+	Expression string
+	Modifiers  DiceRollModifiers
+	Pool       DicePool
+	Advantage  AdvantageMode
+	Modifier   int
+	// Check is optional, if set the probability of passing it is calculated.
+	Check DiceCheck
+}
+
+// diceRoll returns the dice roll definition of the request.
+func (r CalculateProbabilitiesRequest) diceRoll() CreateDiceRollRequest {
+	return CreateDiceRollRequest{
+		RoomID:     r.RoomID,
+		Dice:       r.Dice,
+		Expression: r.Expression,
+		Modifiers:  r.Modifiers,
+		Pool:       r.Pool,
+		Advantage:  r.Advantage,
+		Modifier:   r.Modifier,
+		Check:      r.Check,
+	}
+}
+
+func (r CalculateProbabilitiesRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	if r.UserID == "" {
+		return fmt.Errorf("config.UserID is required")
+	}
+
+	return r.diceRoll().validateDefinition()
+}
+
+// CalculateProbabilitiesResponse is the response for CalculateProbabilities.
+type CalculateProbabilitiesResponse struct {
+	Distribution Distribution
+	// CheckProbability is the probability of passing the request check, 0 if there is no check.
+	CheckProbability float64
+}
+
+// CalculateProbabilities calculates the probability distribution of the dice roll total. Sums of dice,
+// constants and advantage rolls are calculated exactly convolving the dice distributions, the rest of
+// mechanics (e.g: exploding dice or keep/drop selectors) are estimated simulating the dice rolls.
+func (s service) CalculateProbabilities(ctx context.Context, r CalculateProbabilitiesRequest) (*CalculateProbabilitiesResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	var exp *notation.Expression
+	if r.Expression != "" {
+		exp, err = r.diceRoll().parseExpression()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+		}
+
+		var quantity, sides uint
+		for _, d := range exp.Dice() {
+			quantity += d.Quantity
+			sides = max(sides, d.Sides)
+		}
+		err := validateProbabilityDice(quantity, sides)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+		}
+	} else {
+		dts, err := s.roomDieTypes(ctx, r.RoomID, r.Dice)
+		if err != nil {
+			return nil, err
+		}
+		r.Dice = dts
+
+		var sides uint
+		for _, dt := range r.Dice {
+			sides = max(sides, dt.Sides())
+		}
+		err = validateProbabilityDice(uint(len(r.Dice)), sides)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+		}
+	}
+
+	err = s.checkCalculationRate(ctx, r.RoomID, r.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var d Distribution
+	if exp != nil {
+		d, err = s.expressionDistribution(exp)
+		if err != nil {
+			return nil, fmt.Errorf("could not calculate expression probabilities: %w", err)
+		}
+	} else {
+		d, err = s.diceDistribution(ctx, r)
+		if err != nil {
+			return nil, fmt.Errorf("could not calculate dice probabilities: %w", err)
+		}
+	}
+
+	res := &CalculateProbabilitiesResponse{Distribution: d}
+	if r.Check != (DiceCheck{}) {
+		res.CheckProbability = d.CheckProbability(r.Check)
+	}
+
+	return res, nil
+}
+
+// validateProbabilityDice validates the dice are small enough to calculate their probabilities,
+// these limits are tighter than the dice roll ones because every calculation can roll thousands
+// of simulated dice.
+func validateProbabilityDice(quantity, sides uint) error {
+	if quantity > maxProbabilityDice {
+		return fmt.Errorf("max dice quantity to calculate probabilities is %d, got %d", maxProbabilityDice, quantity)
+	}
+
+	if sides > maxProbabilityDieSides {
+		return fmt.Errorf("max die sides to calculate probabilities is %d, got %d", maxProbabilityDieSides, sides)
+	}
+
+	return nil
+}
+
+// checkCalculationRate checks the room and user exist and consumes a dice roll from their rates.
+func (s service) checkCalculationRate(ctx context.Context, roomID, userID string) error {
+	roomExists, err := s.roomRepository.RoomExists(ctx, roomID)
+	if err != nil {
+		return fmt.Errorf("could not check if room exists: %w", err)
+	}
+	if !roomExists {
+		return fmt.Errorf("room does not exists: %w", internalerrors.ErrNotValid)
+	}
+
+	userExists, err := s.userRepository.UserExists(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("could not check if user exists: %w", err)
+	}
+	if !userExists {
+		return fmt.Errorf("user does not exists: %w", internalerrors.ErrNotValid)
+	}
+
+	return s.checkDiceRollRate(ctx, roomID, userID)
+}
+
+// expressionDistribution returns the distribution of the expression, the expressions with keep/drop
+// selectors are simulated.
+func (s service) expressionDistribution(exp *notation.Expression) (Distribution, error) {
+	if d, ok := exactExpressionDistribution(exp.Root); ok {
+		return d.toModel(), nil
+	}
+
+	rnd := s.probabilityRand()
+	return simulateDistribution(func() (int, error) {
+		res, err := exp.Eval(func(sides, quantity uint) ([]uint, error) {
+			vs := make([]uint, 0, quantity)
+			for i := uint(0); i < quantity; i++ {
+				vs = append(vs, uint(rnd.Intn(int(sides)))+1)
+			}
+			return vs, nil
+		})
+		if err != nil {
+			return 0, err
+		}
+		return res.Total, nil
+	})
+}
+
+func exactExpressionDistribution(n notation.Node) (distribution, bool) {
+	switch n := n.(type) {
+	case notation.NumberNode:
+		return constantDistribution(n.Value), true
+
+	case notation.NegateNode:
+		d, ok := exactExpressionDistribution(n.Node)
+		return d.negate(), ok
+
+	case notation.BinaryNode:
+		l, ok := exactExpressionDistribution(n.Left)
+		if !ok {
+			return distribution{}, false
+		}
+		r, ok := exactExpressionDistribution(n.Right)
+		if !ok {
+			return distribution{}, false
+		}
+		if n.Operator == notation.OperatorSubtract {
+			r = r.negate()
+		}
+		return l.add(r)
+
+	case notation.DiceNode:
+		if n.Selector != nil {
+			return distribution{}, false
+		}
+		dt, err := model.NewDieType(n.Sides)
+		if err != nil {
+			return distribution{}, false
+		}
+		dts := make([]model.DieType, 0, n.Quantity)
+		for i := uint(0); i < n.Quantity; i++ {
+			dts = append(dts, dt)
+		}
+		return sumDistribution(dts, 0, AdvantageModeNone)
+	}
+
+	return distribution{}, false
+}
+
+// diceDistribution returns the distribution of the dice, the dice rolls with modifiers are simulated.
+func (s service) diceDistribution(ctx context.Context, r CalculateProbabilitiesRequest) (Distribution, error) {
+	if r.Modifiers == (DiceRollModifiers{}) {
+		var d distribution
+		var ok bool
+		if r.Pool.Target > 0 {
+			d, ok = poolDistribution(r.Dice, r.Pool)
+		} else {
+			d, ok = sumDistribution(r.Dice, r.Modifier, r.Advantage)
+		}
+		if ok {
+			return d.toModel(), nil
+		}
+	}
+
+	// The simulated dice are rolled with the same mechanics as the real ones.
+	sim := service{
+		roller: &randomRoller{rand: s.probabilityRand()},
+		idGen:  func() string { return "" },
+	}
+	return simulateDistribution(func() (int, error) {
+		dice := make([]model.DieRoll, 0, len(r.Dice))
+		for _, dt := range r.Dice {
+			dice = append(dice, model.DieRoll{Type: dt})
+		}
+		dr := &model.DiceRoll{
+			Dice:     r.Advantage.addAdvantageDie(dice, sim.idGen),
+			Modifier: r.Modifier,
+		}
+
+		err := sim.rollWithModifiers(ctx, dr, r.Modifiers)
+		if err != nil {
+			return 0, err
+		}
+		r.Advantage.apply(dr.Dice)
+		setDiceRollTotal(dr, r.Pool)
+
+		return dr.Total, nil
+	})
+}
+
+// sumDistribution returns the distribution of the sum of the dice plus the modifier, with advantage
+// the D20 is replaced by the distribution of the kept D20.
+func sumDistribution(dts []model.DieType, modifier int, adv AdvantageMode) (distribution, bool) {
+	d := constantDistribution(modifier)
+	for _, dt := range dts {
+		dd := newDistribution(dieValues(dt))
+		if adv != AdvantageModeNone && dt.ID() == model.DieTypeD20.ID() {
+			dd = advantageDistribution(dt, adv)
+		}
+
+		var ok bool
+		d, ok = d.add(dd)
+		if !ok {
+			return distribution{}, false
+		}
+	}
+
+	return d, true
+}
+
+// advantageDistribution returns the distribution of the kept die after rolling it twice.
+func advantageDistribution(dt model.DieType, adv AdvantageMode) distribution {
+	vs := make([]int, 0, dt.Sides()*dt.Sides())
+	for a := uint(1); a <= dt.Sides(); a++ {
+		for b := uint(1); b <= dt.Sides(); b++ {
+			side := max(a, b)
+			if adv == AdvantageModeDisadvantage {
+				side = min(a, b)
+			}
+			vs = append(vs, model.DieRoll{Type: dt, Side: side}.Value())
+		}
+	}
+
+	return newDistribution(vs)
+}
+
+// poolDistribution returns the distribution of the dice pool successes, each die adds a success
+// and, if ones cancel, a 1 removes one. The canceled successes can't go below 0.
+func poolDistribution(dts []model.DieType, pool DicePool) (distribution, bool) {
+	d := constantDistribution(0)
+	for _, dt := range dts {
+		vs := make([]int, 0, dt.Sides())
+		for side := uint(1); side <= dt.Sides(); side++ {
+			v := 0
+			if (model.DieRoll{Type: dt, Side: side}).Value() >= int(pool.Target) {
+				v++
+			}
+			if pool.OnesCancel && side == 1 {
+				v--
+			}
+			vs = append(vs, v)
+		}
+
+		var ok bool
+		d, ok = d.add(newDistribution(vs))
+		if !ok {
+			return distribution{}, false
+		}
+	}
+
+	// Negative successes are 0 successes.
+	if d.min < 0 {
+		res := distribution{min: 0, p: make([]float64, max(len(d.p)+d.min, 1))}
+		for i, p := range d.p {
+			res.p[max(d.min+i, 0)] += p
+		}
+		d = res
+	}
+
+	return d, true
+}
+
+// simulateDistribution estimates the distribution of the dice roll totals returned by the roll func.
+func simulateDistribution(roll func() (int, error)) (Distribution, error) {
+	counts := map[int]int{}
+	for i := 0; i < probabilitySimulations; i++ {
+		total, err := roll()
+		if err != nil {
+			return Distribution{}, err
+		}
+		counts[total]++
+	}
+
+	res := Distribution{Totals: make([]TotalProbability, 0, len(counts))}
+	for total, c := range counts {
+		res.Totals = append(res.Totals, TotalProbability{Total: total, Probability: float64(c) / probabilitySimulations})
+	}
+	sort.Slice(res.Totals, func(i, j int) bool { return res.Totals[i].Total < res.Totals[j].Total })
+
+	return res, nil
+}
+
+func (s service) probabilityRand() *rand.Rand {
+	return rand.New(rand.NewSource(s.timeNow().UnixNano()))
+}
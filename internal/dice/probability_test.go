@@ -0,0 +1,385 @@
+package dice_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/dice"
+	"github.com/rollify/rollify/internal/dice/dicemock"
+	"github.com/rollify/rollify/internal/event/eventmock"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/ratelimit/ratelimitmock"
+	"github.com/rollify/rollify/internal/storage"
+	"github.com/rollify/rollify/internal/storage/storagemock"
+)
+
+func TestServiceCalculateProbabilities(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	fate := model.CustomDieType{
+		TypeID:    "fate-id",
+		RoomID:    "test-room",
+		TypeName:  "Fate",
+		FaceTable: []model.DieFace{{Label: "-", Value: -1}, {Label: "", Value: 0}, {Label: "+", Value: 1}},
+	}
+	fateRef := model.CustomDieType{TypeID: "fate-id", FaceTable: fate.FaceTable}
+
+	tooManyDice := make([]model.DieType, 51)
+	for i := range tooManyDice {
+		tooManyDice[i] = model.DieTypeD6
+	}
+
+	type mocks struct {
+		dieTypeRepo *storagemock.CustomDieTypeRepository
+		roomRepo    *storagemock.RoomRepository
+		userRepo    *storagemock.UserRepository
+		userLimiter *ratelimitmock.Limiter
+		roomLimiter *ratelimitmock.Limiter
+	}
+
+	tests := map[string]struct {
+		mock                func(m mocks)
+		req                 dice.CalculateProbabilitiesRequest
+		expExact            bool
+		expTotals           int
+		expMean             float64
+		expCheckProbability float64
+		delta               float64
+		expErr              bool
+	}{
+		"Having a request without dice should fail.": {
+			mock:   func(m mocks) {},
+			req:    dice.CalculateProbabilitiesRequest{},
+			expErr: true,
+		},
+
+		"Having a request with dice and expression should fail.": {
+			mock: func(m mocks) {},
+			req: dice.CalculateProbabilitiesRequest{
+				RoomID:     "test-room",
+				UserID:     "user-id",
+				Dice:       []model.DieType{model.DieTypeD6},
+				Expression: "1d6",
+			},
+			expErr: true,
+		},
+
+		"Having a request with an invalid expression should fail.": {
+			mock:   func(m mocks) {},
+			req:    dice.CalculateProbabilitiesRequest{RoomID: "test-room", UserID: "user-id", Expression: "1d6+"},
+			expErr: true,
+		},
+
+		"An expression with dice and constants should be calculated exactly.": {
+			mock: func(m mocks) {},
+			req: dice.CalculateProbabilitiesRequest{
+				RoomID:     "test-room",
+				UserID:     "user-id",
+				Expression: "2d8+3",
+				Check:      dice.DiceCheck{Target: 15, Comparison: model.DiceRollCheckComparisonGreaterOrEqual},
+			},
+			expExact:            true,
+			expTotals:           15,
+			expMean:             12,
+			expCheckProbability: 15.0 / 64.0,
+		},
+
+		"An expression with subtracted dice should be calculated exactly.": {
+			mock: func(m mocks) {},
+			req: dice.CalculateProbabilitiesRequest{
+				RoomID:     "test-room",
+				UserID:     "user-id",
+				Expression: "1d20-1d4+1",
+				Check:      dice.DiceCheck{Target: 0, Comparison: model.DiceRollCheckComparisonLess},
+			},
+			expExact:            true,
+			expTotals:           23,
+			expMean:             9,
+			expCheckProbability: 3.0 / 80.0,
+		},
+
+		"Dice with a modifier should be calculated exactly.": {
+			mock: func(m mocks) {},
+			req: dice.CalculateProbabilitiesRequest{
+				RoomID:   "test-room",
+				UserID:   "user-id",
+				Dice:     []model.DieType{model.DieTypeD8, model.DieTypeD8},
+				Modifier: 3,
+				Check:    dice.DiceCheck{Target: 15, Comparison: model.DiceRollCheckComparisonGreaterOrEqual},
+			},
+			expExact:            true,
+			expTotals:           15,
+			expMean:             12,
+			expCheckProbability: 15.0 / 64.0,
+		},
+
+		"A D20 with advantage should be calculated exactly.": {
+			mock: func(m mocks) {},
+			req: dice.CalculateProbabilitiesRequest{
+				RoomID:    "test-room",
+				UserID:    "user-id",
+				Dice:      []model.DieType{model.DieTypeD20},
+				Advantage: dice.AdvantageModeAdvantage,
+				Check:     dice.DiceCheck{Target: 15, Comparison: model.DiceRollCheckComparisonGreaterOrEqual},
+			},
+			expExact:            true,
+			expTotals:           20,
+			expMean:             13.825,
+			expCheckProbability: 0.51,
+		},
+
+		"A D20 with disadvantage should be calculated exactly.": {
+			mock: func(m mocks) {},
+			req: dice.CalculateProbabilitiesRequest{
+				RoomID:    "test-room",
+				UserID:    "user-id",
+				Dice:      []model.DieType{model.DieTypeD20},
+				Advantage: dice.AdvantageModeDisadvantage,
+				Check:     dice.DiceCheck{Target: 15, Comparison: model.DiceRollCheckComparisonGreaterOrEqual},
+			},
+			expExact:            true,
+			expTotals:           20,
+			expMean:             7.175,
+			expCheckProbability: 0.09,
+		},
+
+		"Room custom dice should be calculated exactly with their face values.": {
+			mock: func(m mocks) {
+				m.dieTypeRepo.On("ListRoomCustomDieTypes", mock.Anything, "test-room").Once().Return(&storage.CustomDieTypeList{
+					Items: []model.CustomDieType{fate},
+				}, nil)
+			},
+			req: dice.CalculateProbabilitiesRequest{
+				RoomID: "test-room",
+				UserID: "user-id",
+				Dice:   []model.DieType{fateRef, fateRef, fateRef, fateRef},
+				Check:  dice.DiceCheck{Target: 4, Comparison: model.DiceRollCheckComparisonGreaterOrEqual},
+			},
+			expExact:            true,
+			expTotals:           9,
+			expMean:             0,
+			expCheckProbability: 1.0 / 81.0,
+		},
+
+		"Custom dice that are not registered on the room should fail.": {
+			mock: func(m mocks) {
+				m.dieTypeRepo.On("ListRoomCustomDieTypes", mock.Anything, "test-room").Once().Return(&storage.CustomDieTypeList{}, nil)
+			},
+			req: dice.CalculateProbabilitiesRequest{
+				RoomID: "test-room",
+				UserID: "user-id",
+				Dice:   []model.DieType{fateRef},
+			},
+			expErr: true,
+		},
+
+		"Having an error while listing the room custom dice should fail.": {
+			mock: func(m mocks) {
+				m.dieTypeRepo.On("ListRoomCustomDieTypes", mock.Anything, "test-room").Once().Return(nil, fmt.Errorf("wanted error"))
+			},
+			req: dice.CalculateProbabilitiesRequest{
+				RoomID: "test-room",
+				UserID: "user-id",
+				Dice:   []model.DieType{fateRef},
+			},
+			expErr: true,
+		},
+
+		"A dice pool should calculate the successes distribution exactly.": {
+			mock: func(m mocks) {},
+			req: dice.CalculateProbabilitiesRequest{
+				RoomID: "test-room",
+				UserID: "user-id",
+				Dice:   []model.DieType{model.DieTypeD10, model.DieTypeD10, model.DieTypeD10},
+				Pool:   dice.DicePool{Target: 8},
+			},
+			expExact:  true,
+			expTotals: 4,
+			expMean:   0.9,
+		},
+
+		"A dice pool whose ones cancel successes should not have negative successes.": {
+			mock: func(m mocks) {},
+			req: dice.CalculateProbabilitiesRequest{
+				RoomID: "test-room",
+				UserID: "user-id",
+				Dice:   []model.DieType{model.DieTypeD10},
+				Pool:   dice.DicePool{Target: 8, OnesCancel: true},
+			},
+			expExact:  true,
+			expTotals: 2,
+			expMean:   0.3,
+		},
+
+		"An expression with keep/drop selectors should be simulated.": {
+			mock: func(m mocks) {},
+			req: dice.CalculateProbabilitiesRequest{
+				RoomID:     "test-room",
+				UserID:     "user-id",
+				Expression: "4d6kh3",
+				Check:      dice.DiceCheck{Target: 18, Comparison: model.DiceRollCheckComparisonGreaterOrEqual},
+			},
+			expExact:            false,
+			expTotals:           16,
+			expMean:             12.24,
+			expCheckProbability: 21.0 / 1296.0,
+			delta:               0.1,
+		},
+
+		"Exploding dice should be simulated.": {
+			mock: func(m mocks) {},
+			req: dice.CalculateProbabilitiesRequest{
+				RoomID:    "test-room",
+				UserID:    "user-id",
+				Dice:      []model.DieType{model.DieTypeD6},
+				Modifiers: dice.DiceRollModifiers{Explode: true},
+			},
+			expExact: false,
+			expMean:  4.2,
+			delta:    0.1,
+		},
+
+		"Having a request without user should fail.": {
+			mock: func(m mocks) {},
+			req: dice.CalculateProbabilitiesRequest{
+				RoomID:     "test-room",
+				Expression: "1d6",
+			},
+			expErr: true,
+		},
+
+		"Having an expression with too many dice should fail.": {
+			mock: func(m mocks) {},
+			req: dice.CalculateProbabilitiesRequest{
+				RoomID:     "test-room",
+				UserID:     "user-id",
+				Expression: "30d6+30d6",
+			},
+			expErr: true,
+		},
+
+		"Having an expression with dice of too many sides should fail.": {
+			mock: func(m mocks) {},
+			req: dice.CalculateProbabilitiesRequest{
+				RoomID:     "test-room",
+				UserID:     "user-id",
+				Expression: "20d1000",
+			},
+			expErr: true,
+		},
+
+		"Having too many dice should fail.": {
+			mock: func(m mocks) {},
+			req: dice.CalculateProbabilitiesRequest{
+				RoomID: "test-room",
+				UserID: "user-id",
+				Dice:   tooManyDice,
+			},
+			expErr: true,
+		},
+
+		"Having a missing room should fail.": {
+			mock: func(m mocks) {
+				m.roomRepo.On("RoomExists", mock.Anything, "test-room").Once().Return(false, nil)
+			},
+			req: dice.CalculateProbabilitiesRequest{
+				RoomID:     "test-room",
+				UserID:     "user-id",
+				Expression: "1d6",
+			},
+			expErr: true,
+		},
+
+		"Exceeding the user dice roll rate should be rate limited.": {
+			mock: func(m mocks) {
+				m.userLimiter.On("Allow", mock.Anything, "user-id").Once().Return(false, nil)
+			},
+			req: dice.CalculateProbabilitiesRequest{
+				RoomID:     "test-room",
+				UserID:     "user-id",
+				Expression: "1d6",
+			},
+			expErr: true,
+		},
+
+		"Exceeding the room dice roll rate should be rate limited.": {
+			mock: func(m mocks) {
+				m.roomLimiter.On("Allow", mock.Anything, "test-room").Once().Return(false, nil)
+			},
+			req: dice.CalculateProbabilitiesRequest{
+				RoomID:     "test-room",
+				UserID:     "user-id",
+				Expression: "1d6",
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks, by default the room and user exist and the rates allow the calculation.
+			m := mocks{
+				dieTypeRepo: &storagemock.CustomDieTypeRepository{},
+				roomRepo:    &storagemock.RoomRepository{},
+				userRepo:    &storagemock.UserRepository{},
+				userLimiter: &ratelimitmock.Limiter{},
+				roomLimiter: &ratelimitmock.Limiter{},
+			}
+			test.mock(m)
+			m.roomRepo.On("RoomExists", mock.Anything, "test-room").Maybe().Return(true, nil)
+			m.userRepo.On("UserExists", mock.Anything, "user-id").Maybe().Return(true, nil)
+			m.userLimiter.On("Allow", mock.Anything, "user-id").Maybe().Return(true, nil)
+			m.roomLimiter.On("Allow", mock.Anything, "test-room").Maybe().Return(true, nil)
+
+			svc, err := dice.NewService(dice.ServiceConfig{
+				Roller:                  &dicemock.Roller{},
+				DiceRollRepository:      &storagemock.DiceRollRepository{},
+				RoomRepository:          m.roomRepo,
+				UserRepository:          m.userRepo,
+				CustomDieTypeRepository: m.dieTypeRepo,
+				ServerSeedRepository:    &storagemock.ServerSeedRepository{},
+				EventNotifier:           &eventmock.Notifier{},
+				EventSubscriber:         &eventmock.Subscriber{},
+				UserRateLimiter:         m.userLimiter,
+				RoomRateLimiter:         m.roomLimiter,
+				TimeNowFunc:             func() time.Time { return t0 },
+			})
+			require.NoError(err)
+
+			gotResp, err := svc.CalculateProbabilities(context.TODO(), test.req)
+
+			m.dieTypeRepo.AssertExpectations(t)
+			m.roomRepo.AssertExpectations(t)
+			m.userLimiter.AssertExpectations(t)
+			m.roomLimiter.AssertExpectations(t)
+			if test.expErr {
+				assert.Error(err)
+				return
+			}
+			require.NoError(err)
+
+			delta := max(test.delta, 1e-9)
+			d := gotResp.Distribution
+			assert.Equal(test.expExact, d.Exact)
+			if test.expTotals > 0 {
+				assert.Len(d.Totals, test.expTotals)
+			}
+			assert.InDelta(test.expMean, d.Mean(), delta)
+			assert.InDelta(test.expCheckProbability, gotResp.CheckProbability, max(delta/10, 1e-9))
+
+			var sum float64
+			for _, t := range d.Totals {
+				sum += t.Probability
+			}
+			assert.InDelta(1, sum, 1e-9)
+		})
+	}
+}
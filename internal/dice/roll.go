@@ -2,11 +2,15 @@ package dice
 
 import (
 	"context"
+	cryptorand "crypto/rand"
+	"fmt"
+	"io"
 	"math/rand"
 	"sync"
 	"time"
 
 	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/random"
 )
 
 // Roller knows how to  process dice rolls.
@@ -43,7 +47,8 @@ func (r *randomRoller) Roll(ctx context.Context, dr *model.DiceRoll) error {
 	// Roll each of the dice using our seeded rand.
 	ds := make([]model.DieRoll, 0, len(dr.Dice))
 	for _, d := range dr.Dice {
-		d.Side = uint(r.randInt(int(d.Type.Sides())))
+		// Sides go from 1 to N.
+		d.Side = uint(r.randInt(int(d.Type.Sides()))) + 1
 		ds = append(ds, d)
 	}
 
@@ -57,3 +62,59 @@ func (r *randomRoller) randInt(n int) int {
 	defer r.mu.Unlock()
 	return r.rand.Intn(n)
 }
+
+type cryptoRoller struct {
+	rand io.Reader
+}
+
+// NewCryptoRoller returns a roller that knows how to roll dice using a cryptographically secure
+// random number generator setting the side that we got from the roll.
+func NewCryptoRoller() Roller {
+	return cryptoRoller{rand: cryptorand.Reader}
+}
+
+func (c cryptoRoller) Roll(ctx context.Context, dr *model.DiceRoll) error {
+	ds := make([]model.DieRoll, 0, len(dr.Dice))
+	for _, d := range dr.Dice {
+		// Rejection sampling, a plain modulo would favor the lower sides on the dice whose
+		// sides are not a power of 2.
+		n, err := random.Uint(c.rand, uint64(d.Type.Sides()))
+		if err != nil {
+			return fmt.Errorf("could not roll %s die: %w", d.Type.ID(), err)
+		}
+
+		// Sides go from 1 to N.
+		d.Side = uint(n) + 1
+		ds = append(ds, d)
+	}
+
+	dr.Dice = ds
+
+	return nil
+}
+
+type seededRoller struct {
+	seed []byte
+}
+
+// NewSeededRoller returns a roller that derives the dice deterministically from the seed and the
+// dice roll ID. Replaying the same dice rolls with the same seed and IDs (e.g: using a seeded ID
+// generator) will always get the same sides.
+//
+// This is useful to replay sessions and test, never use it in production because anyone knowing
+// the seed can predict the dice rolls.
+func NewSeededRoller(seed string) Roller {
+	return seededRoller{seed: []byte(seed)}
+}
+
+// Roll rolls the dice with the random stream of the dice roll. Rolling again the same dice roll
+// (e.g: exploding dice) uses the same stream, so the already rolled dice get the same sides and the
+// new ones continue the sequence.
+func (s seededRoller) Roll(ctx context.Context, dr *model.DiceRoll) error {
+	r := cryptoRoller{rand: &hmacStream{
+		key: s.seed,
+		msg: dr.ID + ":",
+	}}
+
+	return r.Roll(ctx, dr)
+}
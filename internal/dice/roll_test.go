@@ -2,15 +2,26 @@ package dice_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/rollify/rollify/internal/dice"
+	"github.com/rollify/rollify/internal/event/eventmock"
 	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage/memory"
 )
 
-func TestRandomRoller(t *testing.T) {
+func TestRollers(t *testing.T) {
+	rollers := map[string]func() dice.Roller{
+		"random": dice.NewRandomRoller,
+		"crypto": dice.NewCryptoRoller,
+		"seeded": func() dice.Roller { return dice.NewSeededRoller("test") },
+	}
+
 	tests := map[string]struct {
 		diceRoll func() model.DiceRoll
 		expErr   bool
@@ -53,26 +64,236 @@ func TestRandomRoller(t *testing.T) {
 				}
 			},
 		},
+
+		"Having dice with a number of sides that are not a power of 2 it should roll randomly": {
+			diceRoll: func() model.DiceRoll {
+				return model.DiceRoll{
+					Dice: []model.DieRoll{
+						{Type: model.DieTypeD3, Side: 99999},
+						{Type: model.DieTypeD100, Side: 99999},
+						{Type: mustDieType(t, 997), Side: 99999},
+						{Type: mustDieType(t, 1000), Side: 99999},
+					},
+				}
+			},
+		},
+	}
+
+	for rollerName, newRoller := range rollers {
+		for name, test := range tests {
+			t.Run(rollerName+"/"+name, func(t *testing.T) {
+				assert := assert.New(t)
+
+				r := newRoller()
+
+				gotDice := test.diceRoll()
+				err := r.Roll(context.TODO(), &gotDice)
+
+				if test.expErr {
+					assert.Error(err)
+				} else if assert.NoError(err) {
+					// Check roll values are valid sides.
+					for _, die := range gotDice.Dice {
+						assert.GreaterOrEqual(die.Side, uint(1))
+						assert.LessOrEqual(die.Side, die.Type.Sides())
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestCryptoRollerDistribution(t *testing.T) {
+	assert := assert.New(t)
+
+	// Roll a lot of d6 and check every side appears roughly the same number of times.
+	const rolls = 60000
+	dr := model.DiceRoll{Dice: make([]model.DieRoll, rolls)}
+	for i := range dr.Dice {
+		dr.Dice[i].Type = model.DieTypeD6
+	}
+
+	err := dice.NewCryptoRoller().Roll(context.TODO(), &dr)
+	if !assert.NoError(err) {
+		return
+	}
+
+	got := map[uint]int{}
+	for _, d := range dr.Dice {
+		got[d.Side]++
+	}
+
+	assert.Len(got, 6)
+	for side, n := range got {
+		assert.InDelta(rolls/6, n, rolls/6*0.1, "side %d", side)
+	}
+}
+
+func TestSeededRoller(t *testing.T) {
+	newDiceRoll := func(roomID, id string) model.DiceRoll {
+		return model.DiceRoll{
+			ID:     id,
+			RoomID: roomID,
+			Dice: []model.DieRoll{
+				{Type: model.DieTypeD4},
+				{Type: model.DieTypeD6},
+				{Type: model.DieTypeD20},
+				{Type: model.DieTypeD100},
+				{Type: mustDieType(t, 1000)},
+			},
+		}
+	}
+
+	sides := func(dr model.DiceRoll) []uint {
+		res := make([]uint, 0, len(dr.Dice))
+		for _, d := range dr.Dice {
+			res = append(res, d.Side)
+		}
+		return res
+	}
+
+	tests := map[string]struct {
+		seedA, seedB string
+		// rolls are the dice rolls (room and dice roll ID) rolled before the checked one.
+		rollsA, rollsB [][2]string
+		rollA, rollB   [2]string
+		expSame        bool
+	}{
+		"Having the same seed and dice roll, it should roll the same sides.": {
+			seedA: "test", rollA: [2]string{"room-1", "dice-roll-1"},
+			seedB: "test", rollB: [2]string{"room-1", "dice-roll-1"},
+			expSame: true,
+		},
+
+		"Having the same seed and different dice rolls, it should roll different sides.": {
+			seedA: "test", rollA: [2]string{"room-1", "dice-roll-1"},
+			seedB: "test", rollB: [2]string{"room-1", "dice-roll-2"},
+			expSame: false,
+		},
+
+		"Having the same seed and dice roll with other dice rolls in between, it should roll the same sides.": {
+			seedA: "test", rollsA: [][2]string{{"room-1", "dice-roll-2"}}, rollA: [2]string{"room-1", "dice-roll-1"},
+			seedB: "test", rollsB: [][2]string{{"room-2", "dice-roll-3"}, {"room-1", "dice-roll-4"}}, rollB: [2]string{"room-1", "dice-roll-1"},
+			expSame: true,
+		},
+
+		"Having the same seed and dice roll rolled again, it should roll the same sides.": {
+			seedA: "test", rollA: [2]string{"room-1", "dice-roll-1"},
+			seedB: "test", rollsB: [][2]string{{"room-1", "dice-roll-1"}}, rollB: [2]string{"room-1", "dice-roll-1"},
+			expSame: true,
+		},
+
+		"Having a different seed and the same dice roll, it should roll different sides.": {
+			seedA: "test", rollA: [2]string{"room-1", "dice-roll-1"},
+			seedB: "test2", rollB: [2]string{"room-1", "dice-roll-1"},
+			expSame: false,
+		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			assert := assert.New(t)
+			require := require.New(t)
 
-			r := dice.NewRandomRoller()
+			roll := func(seed string, previous [][2]string, last [2]string) model.DiceRoll {
+				r := dice.NewSeededRoller(seed)
+				for _, p := range previous {
+					dr := newDiceRoll(p[0], p[1])
+					require.NoError(r.Roll(context.TODO(), &dr))
+				}
+
+				dr := newDiceRoll(last[0], last[1])
+				require.NoError(r.Roll(context.TODO(), &dr))
+				return dr
+			}
 
-			gotDice := test.diceRoll()
-			err := r.Roll(context.TODO(), &gotDice)
+			drA := roll(test.seedA, test.rollsA, test.rollA)
+			drB := roll(test.seedB, test.rollsB, test.rollB)
 
-			if test.expErr {
-				assert.Error(err)
-			} else if assert.NoError(err) {
-				// Check roll values are valid sides.
-				for _, die := range gotDice.Dice {
-					assert.GreaterOrEqual(die.Side, uint(0))
-					assert.LessOrEqual(die.Side, die.Type.Sides()-1)
-				}
+			if test.expSame {
+				assert.Equal(sides(drA), sides(drB))
+			} else {
+				assert.NotEqual(sides(drA), sides(drB))
 			}
 		})
 	}
 }
+
+func TestSeededRollerSessionReplay(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	// playSession plays the same session on a new application with a seeded ID generator.
+	playSession := func(seed string) []model.DiceRoll {
+		var n int
+		idGen := func() string {
+			n++
+			return fmt.Sprintf("id-%d", n)
+		}
+		roomRepo := memory.NewRoomRepository()
+		userRepo := memory.NewUserRepository()
+		for _, roomID := range []string{"room-1", "room-2"} {
+			require.NoError(roomRepo.CreateRoom(context.TODO(), model.Room{ID: roomID, Name: roomID}))
+			require.NoError(userRepo.CreateUser(context.TODO(), model.User{ID: "user-" + roomID, RoomID: roomID, Name: "user"}))
+		}
+		notifier := &eventmock.Notifier{}
+		notifier.On("NotifyDiceRollCreated", mock.Anything, mock.Anything).Return(nil)
+
+		svc, err := dice.NewService(dice.ServiceConfig{
+			Roller:                  dice.NewSeededRoller(seed),
+			DiceRollRepository:      memory.NewDiceRollRepository(),
+			RoomRepository:          roomRepo,
+			UserRepository:          userRepo,
+			CustomDieTypeRepository: memory.NewCustomDieTypeRepository(),
+			ServerSeedRepository:    memory.NewServerSeedRepository(),
+			EventNotifier:           notifier,
+			EventSubscriber:         &eventmock.Subscriber{},
+			IDGenerator:             idGen,
+		})
+		require.NoError(err)
+
+		reqs := []dice.CreateDiceRollRequest{
+			{RoomID: "room-1", UserID: "user-room-1", Expression: "4d6kh3"},
+			{RoomID: "room-2", UserID: "user-room-2", Expression: "1d20+5"},
+			{RoomID: "room-1", UserID: "user-room-1", Dice: []model.DieType{model.DieTypeD6, model.DieTypeD6}, Modifiers: dice.DiceRollModifiers{Explode: true}},
+			{RoomID: "room-1", UserID: "user-room-1", Expression: "3d10+1d100"},
+			{RoomID: "room-2", UserID: "user-room-2", Expression: "2d20kl1"},
+		}
+
+		res := []model.DiceRoll{}
+		for _, req := range reqs {
+			resp, err := svc.CreateDiceRoll(context.TODO(), req)
+			require.NoError(err)
+			res = append(res, resp.DiceRoll)
+		}
+
+		return res
+	}
+
+	got1 := playSession("replay")
+	got2 := playSession("replay")
+	got3 := playSession("other")
+
+	// The same seed should replay the same dice.
+	require.Len(got2, len(got1))
+	for i := range got1 {
+		assert.Equal(got1[i].ID, got2[i].ID)
+		assert.Equal(got1[i].Total, got2[i].Total)
+		require.Len(got2[i].Dice, len(got1[i].Dice))
+		for j := range got1[i].Dice {
+			assert.Equal(got1[i].Dice[j].Side, got2[i].Dice[j].Side)
+			assert.Equal(got1[i].Dice[j].Status, got2[i].Dice[j].Status)
+		}
+	}
+
+	// A different seed should roll different dice.
+	assert.NotEqual(got1, got3)
+}
+
+func mustDieType(t *testing.T, sides uint) model.DieType {
+	dt, err := model.NewDieType(sides)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dt
+}
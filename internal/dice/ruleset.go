@@ -0,0 +1,270 @@
+package dice
+
+import (
+	"github.com/rollify/rollify/internal/model"
+)
+
+// Ruleset are the rules of a game system (e.g: D&D 5e), it has the preset dice rolls of the game
+// system and interprets the dice roll results with its rules.
+type Ruleset interface {
+	// ID is the unique ID of the ruleset, the one stored on the rooms.
+	ID() string
+	// Name is the human readable name of the game system.
+	Name() string
+	// Rolls are the preset dice rolls of the game system.
+	Rolls() []RulesetRoll
+	// Interpret returns the outcome of the dice roll following the game system rules, false if the
+	// rules don't apply to the dice roll (e.g: a d6 damage roll on a D&D 5e room).
+	Interpret(dr model.DiceRoll) (*RulesetOutcome, bool)
+}
+
+// RulesetRoll is a preset dice roll of a game system (e.g: a D&D 5e roll with advantage).
+type RulesetRoll struct {
+	// ID is the unique ID of the roll on the ruleset.
+	ID string
+	// Name is the human readable name of the roll.
+	Name      string
+	Dice      []model.DieType
+	Modifiers DiceRollModifiers
+	Advantage AdvantageMode
+	// CheckComparison is how the total is compared with the difficulty when the roll is
+	// checked against one, by default `>=`.
+	CheckComparison model.DiceRollCheckComparison
+}
+
+// RulesetOutcome is the outcome of a dice roll following the game system rules.
+type RulesetOutcome struct {
+	// Label is the game system name of the outcome (e.g: `Weak hit`).
+	Label string
+	Tier  RulesetOutcomeTier
+}
+
+// RulesetOutcomeTier is the tier of a ruleset outcome, used to compare the outcomes of different game systems.
+type RulesetOutcomeTier int
+
+const (
+	// RulesetOutcomeTierCriticalFailure is the worst outcome (e.g: a fumble).
+	RulesetOutcomeTierCriticalFailure RulesetOutcomeTier = iota + 1
+	// RulesetOutcomeTierFailure is a failed outcome (e.g: a miss).
+	RulesetOutcomeTierFailure
+	// RulesetOutcomeTierPartialSuccess is a success with a cost (e.g: a weak hit).
+	RulesetOutcomeTierPartialSuccess
+	// RulesetOutcomeTierSuccess is a success outcome (e.g: a strong hit).
+	RulesetOutcomeTierSuccess
+	// RulesetOutcomeTierCriticalSuccess is the best outcome (e.g: a natural 20).
+	RulesetOutcomeTierCriticalSuccess
+)
+
+var rulesets = []Ruleset{
+	dnd5eRuleset{},
+	pbtaRuleset{},
+	bladesRuleset{},
+	cocRuleset{},
+}
+
+// Rulesets returns the supported game system rulesets.
+func Rulesets() []Ruleset {
+	return rulesets
+}
+
+// GetRuleset returns the ruleset of the ID, false if it is not supported.
+func GetRuleset(id string) (Ruleset, bool) {
+	for _, r := range rulesets {
+		if r.ID() == id {
+			return r, true
+		}
+	}
+
+	return nil, false
+}
+
+// GetRulesetRoll returns the preset dice roll of the ruleset, false if the ruleset doesn't have it.
+func GetRulesetRoll(r Ruleset, id string) (RulesetRoll, bool) {
+	for _, roll := range r.Rolls() {
+		if roll.ID == id {
+			return roll, true
+		}
+	}
+
+	return RulesetRoll{}, false
+}
+
+// keptDice returns the dice roll dice that are not discarded.
+func keptDice(dr model.DiceRoll) []model.DieRoll {
+	res := make([]model.DieRoll, 0, len(dr.Dice))
+	for _, d := range dr.Dice {
+		if !d.Discarded() {
+			res = append(res, d)
+		}
+	}
+
+	return res
+}
+
+// onlyDieType returns true if all the dice are of the die type and there is at least one.
+func onlyDieType(dice []model.DieRoll, dt model.DieType) bool {
+	if len(dice) == 0 {
+		return false
+	}
+
+	for _, d := range dice {
+		if d.Type.ID() != dt.ID() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dnd5eRuleset are the D&D 5e rules, natural 20s and 1s of the D20 rolls are critical.
+type dnd5eRuleset struct{}
+
+func (dnd5eRuleset) ID() string   { return "dnd5e" }
+func (dnd5eRuleset) Name() string { return "D&D 5e" }
+
+func (dnd5eRuleset) Rolls() []RulesetRoll {
+	return []RulesetRoll{
+		{ID: "d20", Name: "D20", Dice: []model.DieType{model.DieTypeD20}},
+		{ID: "advantage", Name: "Advantage", Dice: []model.DieType{model.DieTypeD20}, Advantage: AdvantageModeAdvantage},
+		{ID: "disadvantage", Name: "Disadvantage", Dice: []model.DieType{model.DieTypeD20}, Advantage: AdvantageModeDisadvantage},
+	}
+}
+
+func (dnd5eRuleset) Interpret(dr model.DiceRoll) (*RulesetOutcome, bool) {
+	d20s := []model.DieRoll{}
+	for _, d := range keptDice(dr) {
+		if d.Type.ID() == model.DieTypeD20.ID() {
+			d20s = append(d20s, d)
+		}
+	}
+	if len(d20s) != 1 {
+		return nil, false
+	}
+
+	switch d20s[0].Side {
+	case 20:
+		return &RulesetOutcome{Label: "Natural 20", Tier: RulesetOutcomeTierCriticalSuccess}, true
+	case 1:
+		return &RulesetOutcome{Label: "Natural 1", Tier: RulesetOutcomeTierCriticalFailure}, true
+	default:
+		return nil, false
+	}
+}
+
+// pbtaRuleset are the Powered by the Apocalypse rules, moves are 2d6 plus a stat with 6-, 7-9 and 10+ tiers.
+type pbtaRuleset struct{}
+
+func (pbtaRuleset) ID() string   { return "pbta" }
+func (pbtaRuleset) Name() string { return "Powered by the Apocalypse" }
+
+func (pbtaRuleset) Rolls() []RulesetRoll {
+	return []RulesetRoll{
+		{ID: "move", Name: "Move (2d6)", Dice: []model.DieType{model.DieTypeD6, model.DieTypeD6}},
+	}
+}
+
+func (pbtaRuleset) Interpret(dr model.DiceRoll) (*RulesetOutcome, bool) {
+	dice := keptDice(dr)
+	if len(dice) != 2 || !onlyDieType(dice, model.DieTypeD6) {
+		return nil, false
+	}
+
+	switch {
+	case dr.Total >= 10:
+		return &RulesetOutcome{Label: "Strong hit (10+)", Tier: RulesetOutcomeTierSuccess}, true
+	case dr.Total >= 7:
+		return &RulesetOutcome{Label: "Weak hit (7-9)", Tier: RulesetOutcomeTierPartialSuccess}, true
+	default:
+		return &RulesetOutcome{Label: "Miss (6-)", Tier: RulesetOutcomeTierFailure}, true
+	}
+}
+
+// bladesRuleset are the Blades in the Dark rules, the highest d6 of the pool is the result and
+// rolling more than one 6 is a critical. Without dice, 2d6 are rolled keeping the lowest.
+type bladesRuleset struct{}
+
+func (bladesRuleset) ID() string   { return "blades" }
+func (bladesRuleset) Name() string { return "Blades in the Dark" }
+
+func (bladesRuleset) Rolls() []RulesetRoll {
+	d6 := model.DieTypeD6
+	return []RulesetRoll{
+		{ID: "0d", Name: "0d", Dice: []model.DieType{d6, d6}, Modifiers: DiceRollModifiers{KeepLowest: 1}},
+		{ID: "1d", Name: "1d", Dice: []model.DieType{d6}},
+		{ID: "2d", Name: "2d", Dice: []model.DieType{d6, d6}},
+		{ID: "3d", Name: "3d", Dice: []model.DieType{d6, d6, d6}},
+		{ID: "4d", Name: "4d", Dice: []model.DieType{d6, d6, d6, d6}},
+	}
+}
+
+func (bladesRuleset) Interpret(dr model.DiceRoll) (*RulesetOutcome, bool) {
+	dice := keptDice(dr)
+	if !onlyDieType(dice, model.DieTypeD6) {
+		return nil, false
+	}
+
+	var best uint
+	sixes := 0
+	for _, d := range dice {
+		best = max(best, d.Side)
+		if d.Side == 6 {
+			sixes++
+		}
+	}
+
+	switch {
+	case sixes > 1:
+		return &RulesetOutcome{Label: "Critical", Tier: RulesetOutcomeTierCriticalSuccess}, true
+	case best == 6:
+		return &RulesetOutcome{Label: "Full success", Tier: RulesetOutcomeTierSuccess}, true
+	case best >= 4:
+		return &RulesetOutcome{Label: "Partial success", Tier: RulesetOutcomeTierPartialSuccess}, true
+	default:
+		return &RulesetOutcome{Label: "Bad outcome", Tier: RulesetOutcomeTierFailure}, true
+	}
+}
+
+// cocRuleset are the Call of Cthulhu 7e rules, skill rolls are a d100 rolled under the skill
+// (the roll-under difficulty check target) with regular, hard and extreme successes.
+type cocRuleset struct{}
+
+func (cocRuleset) ID() string   { return "coc" }
+func (cocRuleset) Name() string { return "Call of Cthulhu" }
+
+func (cocRuleset) Rolls() []RulesetRoll {
+	return []RulesetRoll{
+		{ID: "skill", Name: "Skill roll (d100)", Dice: []model.DieType{model.DieTypeD100}, CheckComparison: model.DiceRollCheckComparisonLessOrEqual},
+	}
+}
+
+func (cocRuleset) Interpret(dr model.DiceRoll) (*RulesetOutcome, bool) {
+	dice := keptDice(dr)
+	if len(dice) != 1 || !onlyDieType(dice, model.DieTypeD100) {
+		return nil, false
+	}
+	v := int(dice[0].Side)
+
+	// Without skill only the critical and fumble rolls are known.
+	skill := -1
+	if c := dr.Check; c != nil && c.Comparison == model.DiceRollCheckComparisonLessOrEqual {
+		skill = c.Target
+	}
+	fumble := v == 100 || (skill >= 0 && skill < 50 && v >= 96)
+
+	switch {
+	case v == 1:
+		return &RulesetOutcome{Label: "Critical success", Tier: RulesetOutcomeTierCriticalSuccess}, true
+	case fumble:
+		return &RulesetOutcome{Label: "Fumble", Tier: RulesetOutcomeTierCriticalFailure}, true
+	case skill < 0:
+		return nil, false
+	case v <= skill/5:
+		return &RulesetOutcome{Label: "Extreme success", Tier: RulesetOutcomeTierSuccess}, true
+	case v <= skill/2:
+		return &RulesetOutcome{Label: "Hard success", Tier: RulesetOutcomeTierSuccess}, true
+	case v <= skill:
+		return &RulesetOutcome{Label: "Regular success", Tier: RulesetOutcomeTierSuccess}, true
+	default:
+		return &RulesetOutcome{Label: "Failure", Tier: RulesetOutcomeTierFailure}, true
+	}
+}
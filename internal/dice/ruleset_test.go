@@ -0,0 +1,193 @@
+package dice_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/dice"
+	"github.com/rollify/rollify/internal/model"
+)
+
+func TestRulesetInterpret(t *testing.T) {
+	d6 := func(side uint) model.DieRoll { return model.DieRoll{Type: model.DieTypeD6, Side: side} }
+
+	tests := map[string]struct {
+		ruleset    string
+		diceRoll   model.DiceRoll
+		expOutcome *dice.RulesetOutcome
+	}{
+		"D&D 5e, a natural 20 should be a critical success.": {
+			ruleset:    "dnd5e",
+			diceRoll:   model.DiceRoll{Total: 25, Dice: []model.DieRoll{{Type: model.DieTypeD20, Side: 20}}},
+			expOutcome: &dice.RulesetOutcome{Label: "Natural 20", Tier: dice.RulesetOutcomeTierCriticalSuccess},
+		},
+
+		"D&D 5e, a natural 1 kept with disadvantage should be a critical failure.": {
+			ruleset: "dnd5e",
+			diceRoll: model.DiceRoll{Total: 1, Dice: []model.DieRoll{
+				{Type: model.DieTypeD20, Side: 20, Status: model.DieRollStatusDropped},
+				{Type: model.DieTypeD20, Side: 1},
+			}},
+			expOutcome: &dice.RulesetOutcome{Label: "Natural 1", Tier: dice.RulesetOutcomeTierCriticalFailure},
+		},
+
+		"D&D 5e, a regular D20 roll should not have outcome.": {
+			ruleset:  "dnd5e",
+			diceRoll: model.DiceRoll{Total: 12, Dice: []model.DieRoll{{Type: model.DieTypeD20, Side: 12}}},
+		},
+
+		"D&D 5e, a roll without D20 should not have outcome.": {
+			ruleset:  "dnd5e",
+			diceRoll: model.DiceRoll{Total: 6, Dice: []model.DieRoll{d6(6)}},
+		},
+
+		"PbtA, a 6 or less should be a miss.": {
+			ruleset:    "pbta",
+			diceRoll:   model.DiceRoll{Total: 6, Modifier: 1, Dice: []model.DieRoll{d6(2), d6(3)}},
+			expOutcome: &dice.RulesetOutcome{Label: "Miss (6-)", Tier: dice.RulesetOutcomeTierFailure},
+		},
+
+		"PbtA, a 7 to 9 should be a weak hit.": {
+			ruleset:    "pbta",
+			diceRoll:   model.DiceRoll{Total: 9, Modifier: 2, Dice: []model.DieRoll{d6(4), d6(3)}},
+			expOutcome: &dice.RulesetOutcome{Label: "Weak hit (7-9)", Tier: dice.RulesetOutcomeTierPartialSuccess},
+		},
+
+		"PbtA, a 10 or more should be a strong hit.": {
+			ruleset:    "pbta",
+			diceRoll:   model.DiceRoll{Total: 10, Dice: []model.DieRoll{d6(5), d6(5)}},
+			expOutcome: &dice.RulesetOutcome{Label: "Strong hit (10+)", Tier: dice.RulesetOutcomeTierSuccess},
+		},
+
+		"PbtA, a roll that is not 2d6 should not have outcome.": {
+			ruleset:  "pbta",
+			diceRoll: model.DiceRoll{Total: 15, Dice: []model.DieRoll{d6(5), d6(5), d6(5)}},
+		},
+
+		"Blades in the Dark, the highest die being a 1 to 3 should be a bad outcome.": {
+			ruleset:    "blades",
+			diceRoll:   model.DiceRoll{Dice: []model.DieRoll{d6(3), d6(1)}},
+			expOutcome: &dice.RulesetOutcome{Label: "Bad outcome", Tier: dice.RulesetOutcomeTierFailure},
+		},
+
+		"Blades in the Dark, the highest die being a 4 or 5 should be a partial success.": {
+			ruleset:    "blades",
+			diceRoll:   model.DiceRoll{Dice: []model.DieRoll{d6(3), d6(5), d6(2)}},
+			expOutcome: &dice.RulesetOutcome{Label: "Partial success", Tier: dice.RulesetOutcomeTierPartialSuccess},
+		},
+
+		"Blades in the Dark, the highest die being a 6 should be a full success.": {
+			ruleset:    "blades",
+			diceRoll:   model.DiceRoll{Dice: []model.DieRoll{d6(6), d6(5)}},
+			expOutcome: &dice.RulesetOutcome{Label: "Full success", Tier: dice.RulesetOutcomeTierSuccess},
+		},
+
+		"Blades in the Dark, multiple 6s should be a critical.": {
+			ruleset:    "blades",
+			diceRoll:   model.DiceRoll{Dice: []model.DieRoll{d6(6), d6(2), d6(6)}},
+			expOutcome: &dice.RulesetOutcome{Label: "Critical", Tier: dice.RulesetOutcomeTierCriticalSuccess},
+		},
+
+		"Blades in the Dark, without dice the lowest die should be the result.": {
+			ruleset: "blades",
+			diceRoll: model.DiceRoll{Dice: []model.DieRoll{
+				{Type: model.DieTypeD6, Side: 6, Status: model.DieRollStatusDropped},
+				d6(2),
+			}},
+			expOutcome: &dice.RulesetOutcome{Label: "Bad outcome", Tier: dice.RulesetOutcomeTierFailure},
+		},
+
+		"Blades in the Dark, a roll with other dice should not have outcome.": {
+			ruleset:  "blades",
+			diceRoll: model.DiceRoll{Dice: []model.DieRoll{d6(6), {Type: model.DieTypeD8, Side: 2}}},
+		},
+
+		"Call of Cthulhu, a roll under the fifth of the skill should be an extreme success.": {
+			ruleset: "coc",
+			diceRoll: model.DiceRoll{
+				Dice:  []model.DieRoll{{Type: model.DieTypeD100, Side: 9}},
+				Check: &model.DiceRollCheck{Target: 50, Comparison: model.DiceRollCheckComparisonLessOrEqual},
+			},
+			expOutcome: &dice.RulesetOutcome{Label: "Extreme success", Tier: dice.RulesetOutcomeTierSuccess},
+		},
+
+		"Call of Cthulhu, a roll under the half of the skill should be a hard success.": {
+			ruleset: "coc",
+			diceRoll: model.DiceRoll{
+				Dice:  []model.DieRoll{{Type: model.DieTypeD100, Side: 25}},
+				Check: &model.DiceRollCheck{Target: 50, Comparison: model.DiceRollCheckComparisonLessOrEqual},
+			},
+			expOutcome: &dice.RulesetOutcome{Label: "Hard success", Tier: dice.RulesetOutcomeTierSuccess},
+		},
+
+		"Call of Cthulhu, a roll under the skill should be a regular success.": {
+			ruleset: "coc",
+			diceRoll: model.DiceRoll{
+				Dice:  []model.DieRoll{{Type: model.DieTypeD100, Side: 50}},
+				Check: &model.DiceRollCheck{Target: 50, Comparison: model.DiceRollCheckComparisonLessOrEqual},
+			},
+			expOutcome: &dice.RulesetOutcome{Label: "Regular success", Tier: dice.RulesetOutcomeTierSuccess},
+		},
+
+		"Call of Cthulhu, a roll over the skill should be a failure.": {
+			ruleset: "coc",
+			diceRoll: model.DiceRoll{
+				Dice:  []model.DieRoll{{Type: model.DieTypeD100, Side: 51}},
+				Check: &model.DiceRollCheck{Target: 50, Comparison: model.DiceRollCheckComparisonLessOrEqual},
+			},
+			expOutcome: &dice.RulesetOutcome{Label: "Failure", Tier: dice.RulesetOutcomeTierFailure},
+		},
+
+		"Call of Cthulhu, a 96 or more with a skill under 50 should be a fumble.": {
+			ruleset: "coc",
+			diceRoll: model.DiceRoll{
+				Dice:  []model.DieRoll{{Type: model.DieTypeD100, Side: 97}},
+				Check: &model.DiceRollCheck{Target: 40, Comparison: model.DiceRollCheckComparisonLessOrEqual},
+			},
+			expOutcome: &dice.RulesetOutcome{Label: "Fumble", Tier: dice.RulesetOutcomeTierCriticalFailure},
+		},
+
+		"Call of Cthulhu, a 01 should be a critical success even without skill.": {
+			ruleset:    "coc",
+			diceRoll:   model.DiceRoll{Dice: []model.DieRoll{{Type: model.DieTypeD100, Side: 1}}},
+			expOutcome: &dice.RulesetOutcome{Label: "Critical success", Tier: dice.RulesetOutcomeTierCriticalSuccess},
+		},
+
+		"Call of Cthulhu, a regular roll without skill should not have outcome.": {
+			ruleset:  "coc",
+			diceRoll: model.DiceRoll{Dice: []model.DieRoll{{Type: model.DieTypeD100, Side: 97}}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			rs, ok := dice.GetRuleset(test.ruleset)
+			require.True(ok)
+
+			gotOutcome, ok := rs.Interpret(test.diceRoll)
+			assert.Equal(test.expOutcome != nil, ok)
+			assert.Equal(test.expOutcome, gotOutcome)
+		})
+	}
+}
+
+func TestRulesetRolls(t *testing.T) {
+	for _, rs := range dice.Rulesets() {
+		t.Run(rs.ID(), func(t *testing.T) {
+			assert := assert.New(t)
+
+			assert.NotEmpty(rs.Name())
+			assert.NotEmpty(rs.Rolls())
+			for _, roll := range rs.Rolls() {
+				got, ok := dice.GetRulesetRoll(rs, roll.ID)
+				assert.True(ok)
+				assert.Equal(roll.Name, got.Name)
+			}
+		})
+	}
+}
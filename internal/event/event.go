@@ -9,6 +9,10 @@ import (
 // Notifier knows how to notify events.
 type Notifier interface {
 	NotifyDiceRollCreated(ctx context.Context, e model.EventDiceRollCreated) error
+	NotifyInitiativeUpdated(ctx context.Context, e model.EventInitiativeUpdated) error
+	NotifyCardsDrawn(ctx context.Context, e model.EventCardsDrawn) error
+	NotifyOracleTableRolled(ctx context.Context, e model.EventOracleTableRolled) error
+	NotifyRoomClosed(ctx context.Context, e model.EventRoomClosed) error
 }
 
 //go:generate mockery --case underscore --output eventmock --outpkg eventmock --name Notifier
@@ -17,6 +21,14 @@ type Notifier interface {
 type Subscriber interface {
 	SubscribeDiceRollCreated(ctx context.Context, subscribeID, roomID string, h func(context.Context, model.EventDiceRollCreated) error) error
 	UnsubscribeDiceRollCreated(ctx context.Context, subscribeID, roomID string) error
+	SubscribeInitiativeUpdated(ctx context.Context, subscribeID, roomID string, h func(context.Context, model.EventInitiativeUpdated) error) error
+	UnsubscribeInitiativeUpdated(ctx context.Context, subscribeID, roomID string) error
+	SubscribeCardsDrawn(ctx context.Context, subscribeID, roomID string, h func(context.Context, model.EventCardsDrawn) error) error
+	UnsubscribeCardsDrawn(ctx context.Context, subscribeID, roomID string) error
+	SubscribeOracleTableRolled(ctx context.Context, subscribeID, roomID string, h func(context.Context, model.EventOracleTableRolled) error) error
+	UnsubscribeOracleTableRolled(ctx context.Context, subscribeID, roomID string) error
+	SubscribeRoomClosed(ctx context.Context, subscribeID, roomID string, h func(context.Context, model.EventRoomClosed) error) error
+	UnsubscribeRoomClosed(ctx context.Context, subscribeID, roomID string) error
 }
 
 //go:generate mockery --case underscore --output eventmock --outpkg eventmock --name Subscriber
@@ -15,6 +15,20 @@ type Notifier struct {
 	mock.Mock
 }
 
+// NotifyCardsDrawn provides a mock function with given fields: ctx, e
+func (_m *Notifier) NotifyCardsDrawn(ctx context.Context, e model.EventCardsDrawn) error {
+	ret := _m.Called(ctx, e)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.EventCardsDrawn) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NotifyDiceRollCreated provides a mock function with given fields: ctx, e
 func (_m *Notifier) NotifyDiceRollCreated(ctx context.Context, e model.EventDiceRollCreated) error {
 	ret := _m.Called(ctx, e)
@@ -29,6 +43,48 @@ func (_m *Notifier) NotifyDiceRollCreated(ctx context.Context, e model.EventDice
 	return r0
 }
 
+// NotifyInitiativeUpdated provides a mock function with given fields: ctx, e
+func (_m *Notifier) NotifyInitiativeUpdated(ctx context.Context, e model.EventInitiativeUpdated) error {
+	ret := _m.Called(ctx, e)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.EventInitiativeUpdated) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotifyOracleTableRolled provides a mock function with given fields: ctx, e
+func (_m *Notifier) NotifyOracleTableRolled(ctx context.Context, e model.EventOracleTableRolled) error {
+	ret := _m.Called(ctx, e)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.EventOracleTableRolled) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotifyRoomClosed provides a mock function with given fields: ctx, e
+func (_m *Notifier) NotifyRoomClosed(ctx context.Context, e model.EventRoomClosed) error {
+	ret := _m.Called(ctx, e)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.EventRoomClosed) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewNotifier creates a new instance of Notifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewNotifier(t interface {
@@ -15,6 +15,20 @@ type Subscriber struct {
 	mock.Mock
 }
 
+// SubscribeCardsDrawn provides a mock function with given fields: ctx, subscribeID, roomID, h
+func (_m *Subscriber) SubscribeCardsDrawn(ctx context.Context, subscribeID string, roomID string, h func(context.Context, model.EventCardsDrawn) error) error {
+	ret := _m.Called(ctx, subscribeID, roomID, h)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, func(context.Context, model.EventCardsDrawn) error) error); ok {
+		r0 = rf(ctx, subscribeID, roomID, h)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SubscribeDiceRollCreated provides a mock function with given fields: ctx, subscribeID, roomID, h
 func (_m *Subscriber) SubscribeDiceRollCreated(ctx context.Context, subscribeID string, roomID string, h func(context.Context, model.EventDiceRollCreated) error) error {
 	ret := _m.Called(ctx, subscribeID, roomID, h)
@@ -29,6 +43,62 @@ func (_m *Subscriber) SubscribeDiceRollCreated(ctx context.Context, subscribeID
 	return r0
 }
 
+// SubscribeInitiativeUpdated provides a mock function with given fields: ctx, subscribeID, roomID, h
+func (_m *Subscriber) SubscribeInitiativeUpdated(ctx context.Context, subscribeID string, roomID string, h func(context.Context, model.EventInitiativeUpdated) error) error {
+	ret := _m.Called(ctx, subscribeID, roomID, h)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, func(context.Context, model.EventInitiativeUpdated) error) error); ok {
+		r0 = rf(ctx, subscribeID, roomID, h)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SubscribeOracleTableRolled provides a mock function with given fields: ctx, subscribeID, roomID, h
+func (_m *Subscriber) SubscribeOracleTableRolled(ctx context.Context, subscribeID string, roomID string, h func(context.Context, model.EventOracleTableRolled) error) error {
+	ret := _m.Called(ctx, subscribeID, roomID, h)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, func(context.Context, model.EventOracleTableRolled) error) error); ok {
+		r0 = rf(ctx, subscribeID, roomID, h)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SubscribeRoomClosed provides a mock function with given fields: ctx, subscribeID, roomID, h
+func (_m *Subscriber) SubscribeRoomClosed(ctx context.Context, subscribeID string, roomID string, h func(context.Context, model.EventRoomClosed) error) error {
+	ret := _m.Called(ctx, subscribeID, roomID, h)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, func(context.Context, model.EventRoomClosed) error) error); ok {
+		r0 = rf(ctx, subscribeID, roomID, h)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnsubscribeCardsDrawn provides a mock function with given fields: ctx, subscribeID, roomID
+func (_m *Subscriber) UnsubscribeCardsDrawn(ctx context.Context, subscribeID string, roomID string) error {
+	ret := _m.Called(ctx, subscribeID, roomID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, subscribeID, roomID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UnsubscribeDiceRollCreated provides a mock function with given fields: ctx, subscribeID, roomID
 func (_m *Subscriber) UnsubscribeDiceRollCreated(ctx context.Context, subscribeID string, roomID string) error {
 	ret := _m.Called(ctx, subscribeID, roomID)
@@ -43,6 +113,48 @@ func (_m *Subscriber) UnsubscribeDiceRollCreated(ctx context.Context, subscribeI
 	return r0
 }
 
+// UnsubscribeInitiativeUpdated provides a mock function with given fields: ctx, subscribeID, roomID
+func (_m *Subscriber) UnsubscribeInitiativeUpdated(ctx context.Context, subscribeID string, roomID string) error {
+	ret := _m.Called(ctx, subscribeID, roomID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, subscribeID, roomID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnsubscribeOracleTableRolled provides a mock function with given fields: ctx, subscribeID, roomID
+func (_m *Subscriber) UnsubscribeOracleTableRolled(ctx context.Context, subscribeID string, roomID string) error {
+	ret := _m.Called(ctx, subscribeID, roomID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, subscribeID, roomID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnsubscribeRoomClosed provides a mock function with given fields: ctx, subscribeID, roomID
+func (_m *Subscriber) UnsubscribeRoomClosed(ctx context.Context, subscribeID string, roomID string) error {
+	ret := _m.Called(ctx, subscribeID, roomID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, subscribeID, roomID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewSubscriber creates a new instance of Subscriber. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewSubscriber(t interface {
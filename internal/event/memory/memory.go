@@ -2,6 +2,7 @@ package memory
 
 import (
 	"context"
+	"maps"
 	"sync"
 
 	"github.com/rollify/rollify/internal/event"
@@ -10,6 +11,10 @@ import (
 )
 
 type diceRollCreatedFunc func(context.Context, model.EventDiceRollCreated) error
+type initiativeUpdatedFunc func(context.Context, model.EventInitiativeUpdated) error
+type cardsDrawnFunc func(context.Context, model.EventCardsDrawn) error
+type oracleTableRolledFunc func(context.Context, model.EventOracleTableRolled) error
+type roomClosedFunc func(context.Context, model.EventRoomClosed) error
 
 // Hub implements event.notifier and event.subscriber interfaces with
 // a memory implementation. Normally this will be used for single instances
@@ -19,15 +24,27 @@ type diceRollCreatedFunc func(context.Context, model.EventDiceRollCreated) error
 type Hub struct {
 	// diceRollCreatedHandlers are the funcs stored by roomID, then UserID
 	diceRollCreatedHandlers map[string]map[string]diceRollCreatedFunc
-	logger                  log.Logger
-	mu                      sync.Mutex
+	// initiativeUpdatedHandlers are the funcs stored by roomID, then subscription ID.
+	initiativeUpdatedHandlers map[string]map[string]initiativeUpdatedFunc
+	// cardsDrawnHandlers are the funcs stored by roomID, then subscription ID.
+	cardsDrawnHandlers map[string]map[string]cardsDrawnFunc
+	// oracleTableRolledHandlers are the funcs stored by roomID, then subscription ID.
+	oracleTableRolledHandlers map[string]map[string]oracleTableRolledFunc
+	// roomClosedHandlers are the funcs stored by roomID, then subscription ID.
+	roomClosedHandlers map[string]map[string]roomClosedFunc
+	logger             log.Logger
+	mu                 sync.Mutex
 }
 
 // NewHub returns a new hub based on a memory implementation.
 func NewHub(logger log.Logger) *Hub {
 	h := &Hub{
-		diceRollCreatedHandlers: map[string]map[string]diceRollCreatedFunc{},
-		logger:                  logger.WithKV(log.KV{"service": "memory.Hub"}),
+		diceRollCreatedHandlers:   map[string]map[string]diceRollCreatedFunc{},
+		initiativeUpdatedHandlers: map[string]map[string]initiativeUpdatedFunc{},
+		cardsDrawnHandlers:        map[string]map[string]cardsDrawnFunc{},
+		oracleTableRolledHandlers: map[string]map[string]oracleTableRolledFunc{},
+		roomClosedHandlers:        map[string]map[string]roomClosedFunc{},
+		logger:                    logger.WithKV(log.KV{"service": "memory.Hub"}),
 	}
 
 	return h
@@ -91,6 +108,213 @@ func (h *Hub) UnsubscribeDiceRollCreated(ctx context.Context, subscribeID, roomI
 	return nil
 }
 
+// NotifyInitiativeUpdated satisfies event.Notifier interface.
+func (h *Hub) NotifyInitiativeUpdated(ctx context.Context, e model.EventInitiativeUpdated) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	logger := h.logger.WithKV(log.KV{"event": "InitiativeUpdated"})
+
+	// Broadcast.
+	for _, handler := range h.initiativeUpdatedHandlers[e.Initiative.RoomID] {
+		err := handler(ctx, e)
+		if err != nil {
+			logger.Errorf("error executing hub event handler : %s", err)
+		}
+	}
+
+	return nil
+}
+
+// SubscribeInitiativeUpdated satisfies event.Subscriber interface.
+func (h *Hub) SubscribeInitiativeUpdated(ctx context.Context, subscribeID, roomID string, handler func(context.Context, model.EventInitiativeUpdated) error) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	logger := h.logger.WithKV(log.KV{"event": "InitiativeUpdated"})
+
+	hs, ok := h.initiativeUpdatedHandlers[roomID]
+	if !ok {
+		hs = map[string]initiativeUpdatedFunc{}
+	}
+
+	hs[subscribeID] = handler
+	h.initiativeUpdatedHandlers[roomID] = hs
+	logger.Debugf("subscribed to InitiativeUpdated events")
+
+	return nil
+}
+
+// UnsubscribeInitiativeUpdated satisfies event.Subscriber interface.
+func (h *Hub) UnsubscribeInitiativeUpdated(ctx context.Context, subscribeID, roomID string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	logger := h.logger.WithKV(log.KV{"event": "InitiativeUpdated"})
+
+	hs, ok := h.initiativeUpdatedHandlers[roomID]
+	if ok {
+		delete(hs, subscribeID)
+	}
+
+	logger.Debugf("unsubscribed to InitiativeUpdated events")
+	return nil
+}
+
+// NotifyCardsDrawn satisfies event.Notifier interface.
+func (h *Hub) NotifyCardsDrawn(ctx context.Context, e model.EventCardsDrawn) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	logger := h.logger.WithKV(log.KV{"event": "CardsDrawn"})
+
+	// Broadcast.
+	for _, handler := range h.cardsDrawnHandlers[e.CardDraw.RoomID] {
+		err := handler(ctx, e)
+		if err != nil {
+			logger.Errorf("error executing hub event handler : %s", err)
+		}
+	}
+
+	return nil
+}
+
+// SubscribeCardsDrawn satisfies event.Subscriber interface.
+func (h *Hub) SubscribeCardsDrawn(ctx context.Context, subscribeID, roomID string, handler func(context.Context, model.EventCardsDrawn) error) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	logger := h.logger.WithKV(log.KV{"event": "CardsDrawn"})
+
+	hs, ok := h.cardsDrawnHandlers[roomID]
+	if !ok {
+		hs = map[string]cardsDrawnFunc{}
+	}
+
+	hs[subscribeID] = handler
+	h.cardsDrawnHandlers[roomID] = hs
+	logger.Debugf("subscribed to CardsDrawn events")
+
+	return nil
+}
+
+// UnsubscribeCardsDrawn satisfies event.Subscriber interface.
+func (h *Hub) UnsubscribeCardsDrawn(ctx context.Context, subscribeID, roomID string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	logger := h.logger.WithKV(log.KV{"event": "CardsDrawn"})
+
+	hs, ok := h.cardsDrawnHandlers[roomID]
+	if ok {
+		delete(hs, subscribeID)
+	}
+
+	logger.Debugf("unsubscribed to CardsDrawn events")
+	return nil
+}
+
+// NotifyOracleTableRolled satisfies event.Notifier interface.
+func (h *Hub) NotifyOracleTableRolled(ctx context.Context, e model.EventOracleTableRolled) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	logger := h.logger.WithKV(log.KV{"event": "OracleTableRolled"})
+
+	// Broadcast.
+	for _, handler := range h.oracleTableRolledHandlers[e.OracleTableRoll.RoomID] {
+		err := handler(ctx, e)
+		if err != nil {
+			logger.Errorf("error executing hub event handler : %s", err)
+		}
+	}
+
+	return nil
+}
+
+// SubscribeOracleTableRolled satisfies event.Subscriber interface.
+func (h *Hub) SubscribeOracleTableRolled(ctx context.Context, subscribeID, roomID string, handler func(context.Context, model.EventOracleTableRolled) error) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	logger := h.logger.WithKV(log.KV{"event": "OracleTableRolled"})
+
+	hs, ok := h.oracleTableRolledHandlers[roomID]
+	if !ok {
+		hs = map[string]oracleTableRolledFunc{}
+	}
+
+	hs[subscribeID] = handler
+	h.oracleTableRolledHandlers[roomID] = hs
+	logger.Debugf("subscribed to OracleTableRolled events")
+
+	return nil
+}
+
+// UnsubscribeOracleTableRolled satisfies event.Subscriber interface.
+func (h *Hub) UnsubscribeOracleTableRolled(ctx context.Context, subscribeID, roomID string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	logger := h.logger.WithKV(log.KV{"event": "OracleTableRolled"})
+
+	hs, ok := h.oracleTableRolledHandlers[roomID]
+	if ok {
+		delete(hs, subscribeID)
+	}
+
+	logger.Debugf("unsubscribed to OracleTableRolled events")
+	return nil
+}
+
+// NotifyRoomClosed satisfies event.Notifier interface.
+func (h *Hub) NotifyRoomClosed(ctx context.Context, e model.EventRoomClosed) error {
+	logger := h.logger.WithKV(log.KV{"event": "RoomClosed"})
+
+	// The subscribers unsubscribe from the room events when the room is closed, so the
+	// handlers are called without the lock.
+	h.mu.Lock()
+	handlers := maps.Clone(h.roomClosedHandlers[e.RoomID])
+	h.mu.Unlock()
+
+	// Broadcast.
+	for _, handler := range handlers {
+		err := handler(ctx, e)
+		if err != nil {
+			logger.Errorf("error executing hub event handler : %s", err)
+		}
+	}
+
+	return nil
+}
+
+// SubscribeRoomClosed satisfies event.Subscriber interface.
+func (h *Hub) SubscribeRoomClosed(ctx context.Context, subscribeID, roomID string, handler func(context.Context, model.EventRoomClosed) error) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	logger := h.logger.WithKV(log.KV{"event": "RoomClosed"})
+
+	hs, ok := h.roomClosedHandlers[roomID]
+	if !ok {
+		hs = map[string]roomClosedFunc{}
+	}
+
+	hs[subscribeID] = handler
+	h.roomClosedHandlers[roomID] = hs
+	logger.Debugf("subscribed to RoomClosed events")
+
+	return nil
+}
+
+// UnsubscribeRoomClosed satisfies event.Subscriber interface.
+func (h *Hub) UnsubscribeRoomClosed(ctx context.Context, subscribeID, roomID string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	logger := h.logger.WithKV(log.KV{"event": "RoomClosed"})
+
+	hs, ok := h.roomClosedHandlers[roomID]
+	if ok {
+		delete(hs, subscribeID)
+	}
+
+	logger.Debugf("unsubscribed to RoomClosed events")
+	return nil
+}
+
 var (
 	_ event.Notifier   = &Hub{}
 	_ event.Subscriber = &Hub{}
@@ -126,3 +126,273 @@ func TestHubDiceRollCreatedEventsFlow(t *testing.T) {
 		})
 	}
 }
+
+// TestHubInitiativeUpdatedEventsFlow tests all the hub flow form InitiativeUpdated event
+// this involves event notification and reception using via subscribing and unsubscribing.
+func TestHubInitiativeUpdatedEventsFlow(t *testing.T) {
+	tests := map[string]struct {
+		roomID      string
+		id          string
+		unsubscribe bool
+		events      []model.EventInitiativeUpdated
+		expEvents   []model.EventInitiativeUpdated
+	}{
+		"Having a subscription on a room, we should receive only the notifications of that room.": {
+			roomID: "room0-id",
+			id:     "sub0-id",
+			events: []model.EventInitiativeUpdated{
+				{Initiative: model.Initiative{RoomID: "room0-id", Round: 1}},
+				{Initiative: model.Initiative{RoomID: "room1-id", Round: 1}},
+				{Initiative: model.Initiative{RoomID: "room0-id", Round: 2}},
+			},
+			expEvents: []model.EventInitiativeUpdated{
+				{Initiative: model.Initiative{RoomID: "room0-id", Round: 1}},
+				{Initiative: model.Initiative{RoomID: "room0-id", Round: 2}},
+			},
+		},
+
+		"Having a subscription and then unsubscribing on a room, we shouldn't receive events.": {
+			roomID:      "room0-id",
+			id:          "sub0-id",
+			unsubscribe: true,
+			events: []model.EventInitiativeUpdated{
+				{Initiative: model.Initiative{RoomID: "room0-id", Round: 1}},
+			},
+			expEvents: []model.EventInitiativeUpdated{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			hub := memory.NewHub(log.Dummy)
+
+			// Subscribe with our check.
+			gotEvents := []model.EventInitiativeUpdated{}
+			err := hub.SubscribeInitiativeUpdated(context.TODO(), test.id, test.roomID, func(_ context.Context, e model.EventInitiativeUpdated) error {
+				gotEvents = append(gotEvents, e)
+				return nil
+			})
+			require.NoError(err)
+
+			// In case we want to unsubscribe after subscription.
+			if test.unsubscribe {
+				err := hub.UnsubscribeInitiativeUpdated(context.TODO(), test.id, test.roomID)
+				require.NoError(err)
+			}
+
+			// Send
+			for _, e := range test.events {
+				err := hub.NotifyInitiativeUpdated(context.TODO(), e)
+				require.NoError(err)
+			}
+
+			// Check.
+			assert.Equal(test.expEvents, gotEvents)
+		})
+	}
+}
+
+// TestHubCardsDrawnEventsFlow tests all the hub flow form CardsDrawn event
+// this involves event notification and reception using via subscribing and unsubscribing.
+func TestHubCardsDrawnEventsFlow(t *testing.T) {
+	tests := map[string]struct {
+		roomID      string
+		id          string
+		unsubscribe bool
+		events      []model.EventCardsDrawn
+		expEvents   []model.EventCardsDrawn
+	}{
+		"Having a subscription on a room, we should receive only the notifications of that room.": {
+			roomID: "room0-id",
+			id:     "sub0-id",
+			events: []model.EventCardsDrawn{
+				{CardDraw: model.CardDraw{RoomID: "room0-id", Cards: []string{"A♠"}}},
+				{CardDraw: model.CardDraw{RoomID: "room1-id", Cards: []string{"K♥"}}},
+				{CardDraw: model.CardDraw{RoomID: "room0-id", Cards: []string{"Red Joker"}}},
+			},
+			expEvents: []model.EventCardsDrawn{
+				{CardDraw: model.CardDraw{RoomID: "room0-id", Cards: []string{"A♠"}}},
+				{CardDraw: model.CardDraw{RoomID: "room0-id", Cards: []string{"Red Joker"}}},
+			},
+		},
+
+		"Having a subscription and then unsubscribing on a room, we shouldn't receive events.": {
+			roomID:      "room0-id",
+			id:          "sub0-id",
+			unsubscribe: true,
+			events: []model.EventCardsDrawn{
+				{CardDraw: model.CardDraw{RoomID: "room0-id", Cards: []string{"A♠"}}},
+			},
+			expEvents: []model.EventCardsDrawn{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			hub := memory.NewHub(log.Dummy)
+
+			// Subscribe with our check.
+			gotEvents := []model.EventCardsDrawn{}
+			err := hub.SubscribeCardsDrawn(context.TODO(), test.id, test.roomID, func(_ context.Context, e model.EventCardsDrawn) error {
+				gotEvents = append(gotEvents, e)
+				return nil
+			})
+			require.NoError(err)
+
+			// In case we want to unsubscribe after subscription.
+			if test.unsubscribe {
+				err := hub.UnsubscribeCardsDrawn(context.TODO(), test.id, test.roomID)
+				require.NoError(err)
+			}
+
+			// Send
+			for _, e := range test.events {
+				err := hub.NotifyCardsDrawn(context.TODO(), e)
+				require.NoError(err)
+			}
+
+			// Check.
+			assert.Equal(test.expEvents, gotEvents)
+		})
+	}
+}
+
+// TestHubOracleTableRolledEventsFlow tests all the hub flow form OracleTableRolled event
+// this involves event notification and reception using via subscribing and unsubscribing.
+func TestHubOracleTableRolledEventsFlow(t *testing.T) {
+	tests := map[string]struct {
+		roomID      string
+		id          string
+		unsubscribe bool
+		events      []model.EventOracleTableRolled
+		expEvents   []model.EventOracleTableRolled
+	}{
+		"Having a subscription on a room, we should receive only the notifications of that room.": {
+			roomID: "room0-id",
+			id:     "sub0-id",
+			events: []model.EventOracleTableRolled{
+				{OracleTableRoll: model.OracleTableRoll{RoomID: "room0-id", TableID: "table0-id"}},
+				{OracleTableRoll: model.OracleTableRoll{RoomID: "room1-id", TableID: "table1-id"}},
+				{OracleTableRoll: model.OracleTableRoll{RoomID: "room0-id", TableID: "table2-id"}},
+			},
+			expEvents: []model.EventOracleTableRolled{
+				{OracleTableRoll: model.OracleTableRoll{RoomID: "room0-id", TableID: "table0-id"}},
+				{OracleTableRoll: model.OracleTableRoll{RoomID: "room0-id", TableID: "table2-id"}},
+			},
+		},
+
+		"Having a subscription and then unsubscribing on a room, we shouldn't receive events.": {
+			roomID:      "room0-id",
+			id:          "sub0-id",
+			unsubscribe: true,
+			events: []model.EventOracleTableRolled{
+				{OracleTableRoll: model.OracleTableRoll{RoomID: "room0-id", TableID: "table0-id"}},
+			},
+			expEvents: []model.EventOracleTableRolled{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			hub := memory.NewHub(log.Dummy)
+
+			// Subscribe with our check.
+			gotEvents := []model.EventOracleTableRolled{}
+			err := hub.SubscribeOracleTableRolled(context.TODO(), test.id, test.roomID, func(_ context.Context, e model.EventOracleTableRolled) error {
+				gotEvents = append(gotEvents, e)
+				return nil
+			})
+			require.NoError(err)
+
+			// In case we want to unsubscribe after subscription.
+			if test.unsubscribe {
+				err := hub.UnsubscribeOracleTableRolled(context.TODO(), test.id, test.roomID)
+				require.NoError(err)
+			}
+
+			// Send
+			for _, e := range test.events {
+				err := hub.NotifyOracleTableRolled(context.TODO(), e)
+				require.NoError(err)
+			}
+
+			// Check.
+			assert.Equal(test.expEvents, gotEvents)
+		})
+	}
+}
+
+func TestHubRoomClosedEventsFlow(t *testing.T) {
+	tests := map[string]struct {
+		roomID      string
+		id          string
+		unsubscribe bool
+		events      []model.EventRoomClosed
+		expEvents   []model.EventRoomClosed
+	}{
+		"Having a subscription on a room, we should receive only the notifications of that room.": {
+			roomID: "room0-id",
+			id:     "sub0-id",
+			events: []model.EventRoomClosed{
+				{RoomID: "room0-id"},
+				{RoomID: "room1-id", Deleted: true},
+				{RoomID: "room0-id", Deleted: true},
+			},
+			expEvents: []model.EventRoomClosed{
+				{RoomID: "room0-id"},
+				{RoomID: "room0-id", Deleted: true},
+			},
+		},
+
+		"Having a subscription and then unsubscribing on a room, we shouldn't receive events.": {
+			roomID:      "room0-id",
+			id:          "sub0-id",
+			unsubscribe: true,
+			events: []model.EventRoomClosed{
+				{RoomID: "room0-id", Deleted: true},
+			},
+			expEvents: []model.EventRoomClosed{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			hub := memory.NewHub(log.Dummy)
+
+			// Subscribe with our check, unsubscribing on the handler like the real subscribers.
+			gotEvents := []model.EventRoomClosed{}
+			err := hub.SubscribeRoomClosed(context.TODO(), test.id, test.roomID, func(ctx context.Context, e model.EventRoomClosed) error {
+				gotEvents = append(gotEvents, e)
+				return hub.UnsubscribeRoomClosed(ctx, "other-id", test.roomID)
+			})
+			require.NoError(err)
+
+			// In case we want to unsubscribe after subscription.
+			if test.unsubscribe {
+				err := hub.UnsubscribeRoomClosed(context.TODO(), test.id, test.roomID)
+				require.NoError(err)
+			}
+
+			// Send
+			for _, e := range test.events {
+				err := hub.NotifyRoomClosed(context.TODO(), e)
+				require.NoError(err)
+			}
+
+			// Check.
+			assert.Equal(test.expEvents, gotEvents)
+		})
+	}
+}
@@ -35,6 +35,38 @@ func (m measuredNotifier) NotifyDiceRollCreated(ctx context.Context, e model.Eve
 	return m.next.NotifyDiceRollCreated(ctx, e)
 }
 
+func (m measuredNotifier) NotifyInitiativeUpdated(ctx context.Context, e model.EventInitiativeUpdated) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureNotifyOpDuration(ctx, m.notifierType, "NotifyInitiativeUpdated", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.NotifyInitiativeUpdated(ctx, e)
+}
+
+func (m measuredNotifier) NotifyCardsDrawn(ctx context.Context, e model.EventCardsDrawn) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureNotifyOpDuration(ctx, m.notifierType, "NotifyCardsDrawn", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.NotifyCardsDrawn(ctx, e)
+}
+
+func (m measuredNotifier) NotifyOracleTableRolled(ctx context.Context, e model.EventOracleTableRolled) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureNotifyOpDuration(ctx, m.notifierType, "NotifyOracleTableRolled", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.NotifyOracleTableRolled(ctx, e)
+}
+
+func (m measuredNotifier) NotifyRoomClosed(ctx context.Context, e model.EventRoomClosed) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureNotifyOpDuration(ctx, m.notifierType, "NotifyRoomClosed", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.NotifyRoomClosed(ctx, e)
+}
+
 // SubscriberMetricsRecorder knows how to measure Subscriber.
 type SubscriberMetricsRecorder interface {
 	MeasureSubscriberSubscribeOpDuration(ctx context.Context, subscriberType, subscription string, success bool, t time.Duration)
@@ -94,3 +126,151 @@ func (m measuredSubscriber) UnsubscribeDiceRollCreated(ctx context.Context, subs
 
 	return m.next.UnsubscribeDiceRollCreated(ctx, subscribeID, roomID)
 }
+
+func (m measuredSubscriber) SubscribeInitiativeUpdated(ctx context.Context, subscribeID, roomID string, h func(context.Context, model.EventInitiativeUpdated) error) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureSubscriberSubscribeOpDuration(ctx, m.subscriberType, "InitiativeUpdated", err == nil, time.Since(t0))
+	}(time.Now())
+
+	defer func() {
+		if err == nil {
+			m.rec.AddSubscriberQuantity(ctx, m.subscriberType, "InitiativeUpdated", 1)
+		}
+	}()
+
+	// Wrap also the handler so it measures handle of events.
+	measuredHandler := func(ctx context.Context, e model.EventInitiativeUpdated) (err error) {
+		defer func(t0 time.Time) {
+			m.rec.MeasureSubscriberEventHandleOpDuration(ctx, m.subscriberType, "InitiativeUpdated", err == nil, time.Since(t0))
+		}(time.Now())
+
+		return h(ctx, e)
+	}
+
+	return m.next.SubscribeInitiativeUpdated(ctx, subscribeID, roomID, measuredHandler)
+}
+
+func (m measuredSubscriber) UnsubscribeInitiativeUpdated(ctx context.Context, subscribeID, roomID string) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureSubscriberUnsubscribeOpDuration(ctx, m.subscriberType, "InitiativeUpdated", err == nil, time.Since(t0))
+	}(time.Now())
+
+	defer func() {
+		if err == nil {
+			m.rec.AddSubscriberQuantity(ctx, m.subscriberType, "InitiativeUpdated", -1)
+		}
+	}()
+
+	return m.next.UnsubscribeInitiativeUpdated(ctx, subscribeID, roomID)
+}
+
+func (m measuredSubscriber) SubscribeCardsDrawn(ctx context.Context, subscribeID, roomID string, h func(context.Context, model.EventCardsDrawn) error) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureSubscriberSubscribeOpDuration(ctx, m.subscriberType, "CardsDrawn", err == nil, time.Since(t0))
+	}(time.Now())
+
+	defer func() {
+		if err == nil {
+			m.rec.AddSubscriberQuantity(ctx, m.subscriberType, "CardsDrawn", 1)
+		}
+	}()
+
+	// Wrap also the handler so it measures handle of events.
+	measuredHandler := func(ctx context.Context, e model.EventCardsDrawn) (err error) {
+		defer func(t0 time.Time) {
+			m.rec.MeasureSubscriberEventHandleOpDuration(ctx, m.subscriberType, "CardsDrawn", err == nil, time.Since(t0))
+		}(time.Now())
+
+		return h(ctx, e)
+	}
+
+	return m.next.SubscribeCardsDrawn(ctx, subscribeID, roomID, measuredHandler)
+}
+
+func (m measuredSubscriber) UnsubscribeCardsDrawn(ctx context.Context, subscribeID, roomID string) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureSubscriberUnsubscribeOpDuration(ctx, m.subscriberType, "CardsDrawn", err == nil, time.Since(t0))
+	}(time.Now())
+
+	defer func() {
+		if err == nil {
+			m.rec.AddSubscriberQuantity(ctx, m.subscriberType, "CardsDrawn", -1)
+		}
+	}()
+
+	return m.next.UnsubscribeCardsDrawn(ctx, subscribeID, roomID)
+}
+
+func (m measuredSubscriber) SubscribeOracleTableRolled(ctx context.Context, subscribeID, roomID string, h func(context.Context, model.EventOracleTableRolled) error) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureSubscriberSubscribeOpDuration(ctx, m.subscriberType, "OracleTableRolled", err == nil, time.Since(t0))
+	}(time.Now())
+
+	defer func() {
+		if err == nil {
+			m.rec.AddSubscriberQuantity(ctx, m.subscriberType, "OracleTableRolled", 1)
+		}
+	}()
+
+	// Wrap also the handler so it measures handle of events.
+	measuredHandler := func(ctx context.Context, e model.EventOracleTableRolled) (err error) {
+		defer func(t0 time.Time) {
+			m.rec.MeasureSubscriberEventHandleOpDuration(ctx, m.subscriberType, "OracleTableRolled", err == nil, time.Since(t0))
+		}(time.Now())
+
+		return h(ctx, e)
+	}
+
+	return m.next.SubscribeOracleTableRolled(ctx, subscribeID, roomID, measuredHandler)
+}
+
+func (m measuredSubscriber) UnsubscribeOracleTableRolled(ctx context.Context, subscribeID, roomID string) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureSubscriberUnsubscribeOpDuration(ctx, m.subscriberType, "OracleTableRolled", err == nil, time.Since(t0))
+	}(time.Now())
+
+	defer func() {
+		if err == nil {
+			m.rec.AddSubscriberQuantity(ctx, m.subscriberType, "OracleTableRolled", -1)
+		}
+	}()
+
+	return m.next.UnsubscribeOracleTableRolled(ctx, subscribeID, roomID)
+}
+
+func (m measuredSubscriber) SubscribeRoomClosed(ctx context.Context, subscribeID, roomID string, h func(context.Context, model.EventRoomClosed) error) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureSubscriberSubscribeOpDuration(ctx, m.subscriberType, "RoomClosed", err == nil, time.Since(t0))
+	}(time.Now())
+
+	defer func() {
+		if err == nil {
+			m.rec.AddSubscriberQuantity(ctx, m.subscriberType, "RoomClosed", 1)
+		}
+	}()
+
+	// Wrap also the handler so it measures handle of events.
+	measuredHandler := func(ctx context.Context, e model.EventRoomClosed) (err error) {
+		defer func(t0 time.Time) {
+			m.rec.MeasureSubscriberEventHandleOpDuration(ctx, m.subscriberType, "RoomClosed", err == nil, time.Since(t0))
+		}(time.Now())
+
+		return h(ctx, e)
+	}
+
+	return m.next.SubscribeRoomClosed(ctx, subscribeID, roomID, measuredHandler)
+}
+
+func (m measuredSubscriber) UnsubscribeRoomClosed(ctx context.Context, subscribeID, roomID string) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureSubscriberUnsubscribeOpDuration(ctx, m.subscriberType, "RoomClosed", err == nil, time.Since(t0))
+	}(time.Now())
+
+	defer func() {
+		if err == nil {
+			m.rec.AddSubscriberQuantity(ctx, m.subscriberType, "RoomClosed", -1)
+		}
+	}()
+
+	return m.next.UnsubscribeRoomClosed(ctx, subscribeID, roomID)
+}
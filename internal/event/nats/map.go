@@ -13,39 +13,116 @@ type eventDiceRollCreated struct {
 }
 
 type diceRoll struct {
-	ID        string
-	Serial    uint
-	CreatedAt time.Time
-	RoomID    string
-	UserID    string
-	Dice      []dieRoll
+	ID         string
+	Serial     uint
+	CreatedAt  time.Time
+	RoomID     string
+	UserID     string
+	Dice       []dieRoll
+	Expression string
+	Label      string
+	Modifier   int
+	Total      int
+	// Pool is only set on dice pools.
+	Pool *diceRollPool `json:",omitempty"`
+	// Check is only set on difficulty checks.
+	Check      *diceRollCheck `json:",omitempty"`
+	Visibility int
+	// WhisperUserIDs are only set on whispered dice rolls.
+	WhisperUserIDs []string `json:",omitempty"`
+	// ParentID is only set on rerolls.
+	ParentID string `json:",omitempty"`
+	// Proof is only set on provably fair dice rolls.
+	Proof *diceRollProof `json:",omitempty"`
+}
+
+type diceRollProof struct {
+	ServerSeedID   string
+	ServerSeedHash string
+	ClientSeed     string
+	Nonce          uint
+}
+
+type diceRollPool struct {
+	Successes int
+	Outcome   int
+}
+
+type diceRollCheck struct {
+	Target     int
+	Comparison int
+	Margin     int
+	Outcome    int
 }
 
 type dieRoll struct {
-	ID   string
-	Type string
-	Side uint
+	ID     string
+	Type   string
+	Side   uint
+	Status int
+	// Custom die types are sent inline, they are only set on custom die types.
+	TypeName  string    `json:",omitempty"`
+	TypeFaces []dieFace `json:",omitempty"`
+}
+
+type dieFace struct {
+	Label string
+	Value int
 }
 
 // maps model to a stream model used to be shared.
 func mapModelToBytesEventDiceRollCreated(e model.EventDiceRollCreated) ([]byte, error) {
 	res := eventDiceRollCreated{
 		DiceRoll: diceRoll{
-			ID:        e.DiceRoll.ID,
-			Serial:    e.DiceRoll.Serial,
-			CreatedAt: e.DiceRoll.CreatedAt,
-			RoomID:    e.DiceRoll.RoomID,
-			UserID:    e.DiceRoll.UserID,
-			Dice:      make([]dieRoll, 0, len(e.DiceRoll.Dice)),
+			ID:             e.DiceRoll.ID,
+			Serial:         e.DiceRoll.Serial,
+			CreatedAt:      e.DiceRoll.CreatedAt,
+			RoomID:         e.DiceRoll.RoomID,
+			UserID:         e.DiceRoll.UserID,
+			Dice:           make([]dieRoll, 0, len(e.DiceRoll.Dice)),
+			Expression:     e.DiceRoll.Expression,
+			Label:          e.DiceRoll.Label,
+			Modifier:       e.DiceRoll.Modifier,
+			Total:          e.DiceRoll.Total,
+			Visibility:     int(e.DiceRoll.Visibility),
+			WhisperUserIDs: e.DiceRoll.WhisperUserIDs,
+			ParentID:       e.DiceRoll.ParentID,
 		},
 	}
 
+	if p := e.DiceRoll.Pool; p != nil {
+		res.DiceRoll.Pool = &diceRollPool{Successes: p.Successes, Outcome: int(p.Outcome)}
+	}
+
+	if c := e.DiceRoll.Check; c != nil {
+		res.DiceRoll.Check = &diceRollCheck{Target: c.Target, Comparison: int(c.Comparison), Margin: c.Margin, Outcome: int(c.Outcome)}
+	}
+
+	if p := e.DiceRoll.Proof; p != nil {
+		res.DiceRoll.Proof = &diceRollProof{
+			ServerSeedID:   p.ServerSeedID,
+			ServerSeedHash: p.ServerSeedHash,
+			ClientSeed:     p.ClientSeed,
+			Nonce:          p.Nonce,
+		}
+	}
+
 	for _, dr := range e.DiceRoll.Dice {
-		res.DiceRoll.Dice = append(res.DiceRoll.Dice, dieRoll{
-			ID:   dr.ID,
-			Type: dr.Type.ID(),
-			Side: dr.Side,
-		})
+		d := dieRoll{
+			ID:     dr.ID,
+			Type:   dr.Type.ID(),
+			Side:   dr.Side,
+			Status: int(dr.Status),
+		}
+
+		if faces := dr.Type.Faces(); len(faces) > 0 {
+			d.TypeName = dr.Type.Name()
+			for _, f := range faces {
+				d.TypeFaces = append(d.TypeFaces, dieFace{Label: f.Label, Value: f.Value})
+			}
+		}
+
+		res.DiceRoll.Dice = append(res.DiceRoll.Dice, d)
 	}
 
 	bs, err := json.Marshal(&res)
@@ -65,26 +142,321 @@ func mapBytesToModelEventDiceRollCreated(data []byte) (*model.EventDiceRollCreat
 
 	res := &model.EventDiceRollCreated{
 		DiceRoll: model.DiceRoll{
-			ID:        e.DiceRoll.ID,
-			Serial:    e.DiceRoll.Serial,
-			CreatedAt: e.DiceRoll.CreatedAt,
-			RoomID:    e.DiceRoll.RoomID,
-			UserID:    e.DiceRoll.UserID,
-			Dice:      make([]model.DieRoll, 0, len(e.DiceRoll.Dice)),
+			ID:             e.DiceRoll.ID,
+			Serial:         e.DiceRoll.Serial,
+			CreatedAt:      e.DiceRoll.CreatedAt,
+			RoomID:         e.DiceRoll.RoomID,
+			UserID:         e.DiceRoll.UserID,
+			Dice:           make([]model.DieRoll, 0, len(e.DiceRoll.Dice)),
+			Expression:     e.DiceRoll.Expression,
+			Label:          e.DiceRoll.Label,
+			Modifier:       e.DiceRoll.Modifier,
+			Total:          e.DiceRoll.Total,
+			Visibility:     model.DiceRollVisibility(e.DiceRoll.Visibility),
+			WhisperUserIDs: e.DiceRoll.WhisperUserIDs,
+			ParentID:       e.DiceRoll.ParentID,
 		},
 	}
 
+	if p := e.DiceRoll.Pool; p != nil {
+		res.DiceRoll.Pool = &model.DicePoolResult{Successes: p.Successes, Outcome: model.DicePoolOutcome(p.Outcome)}
+	}
+
+	if c := e.DiceRoll.Check; c != nil {
+		res.DiceRoll.Check = &model.DiceRollCheck{
+			Target:     c.Target,
+			Comparison: model.DiceRollCheckComparison(c.Comparison),
+			Margin:     c.Margin,
+			Outcome:    model.DiceRollCheckOutcome(c.Outcome),
+		}
+	}
+
+	if p := e.DiceRoll.Proof; p != nil {
+		res.DiceRoll.Proof = &model.DiceRollProof{
+			ServerSeedID:   p.ServerSeedID,
+			ServerSeedHash: p.ServerSeedHash,
+			ClientSeed:     p.ClientSeed,
+			Nonce:          p.Nonce,
+		}
+	}
+
 	for _, dr := range e.DiceRoll.Dice {
-		dt, ok := model.DiceTypes[dr.Type]
-		if !ok {
-			return nil, fmt.Errorf("%s die type is not valid", dr.Type)
+		dt, err := mapDieRollToModelDieType(e.DiceRoll.RoomID, dr)
+		if err != nil {
+			return nil, fmt.Errorf("%s die type is not valid: %w", dr.Type, err)
 		}
 		res.DiceRoll.Dice = append(res.DiceRoll.Dice, model.DieRoll{
-			ID:   dr.ID,
-			Type: dt,
-			Side: dr.Side,
+			ID:     dr.ID,
+			Type:   dt,
+			Side:   dr.Side,
+			Status: model.DieRollStatus(dr.Status),
+		})
+	}
+
+	return res, nil
+}
+
+func mapDieRollToModelDieType(roomID string, dr dieRoll) (model.DieType, error) {
+	if len(dr.TypeFaces) == 0 {
+		return model.DieTypeFromID(dr.Type)
+	}
+
+	faces := make([]model.DieFace, 0, len(dr.TypeFaces))
+	for _, f := range dr.TypeFaces {
+		faces = append(faces, model.DieFace{Label: f.Label, Value: f.Value})
+	}
+
+	return model.CustomDieType{
+		TypeID:    dr.Type,
+		RoomID:    roomID,
+		TypeName:  dr.TypeName,
+		FaceTable: faces,
+	}, nil
+}
+
+type eventInitiativeUpdated struct {
+	Initiative initiative
+}
+
+type initiative struct {
+	RoomID     string
+	UpdatedAt  time.Time
+	Round      uint
+	Turn       int
+	Combatants []initiativeCombatant
+}
+
+type initiativeCombatant struct {
+	ID         string
+	Name       string
+	UserID     string `json:",omitempty"`
+	Initiative int
+	DiceRollID string `json:",omitempty"`
+}
+
+// maps model to a stream model used to be shared.
+func mapModelToBytesEventInitiativeUpdated(e model.EventInitiativeUpdated) ([]byte, error) {
+	res := eventInitiativeUpdated{
+		Initiative: initiative{
+			RoomID:     e.Initiative.RoomID,
+			UpdatedAt:  e.Initiative.UpdatedAt,
+			Round:      e.Initiative.Round,
+			Turn:       e.Initiative.Turn,
+			Combatants: make([]initiativeCombatant, 0, len(e.Initiative.Combatants)),
+		},
+	}
+
+	for _, c := range e.Initiative.Combatants {
+		res.Initiative.Combatants = append(res.Initiative.Combatants, initiativeCombatant{
+			ID:         c.ID,
+			Name:       c.Name,
+			UserID:     c.UserID,
+			Initiative: c.Initiative,
+			DiceRollID: c.DiceRollID,
+		})
+	}
+
+	bs, err := json.Marshal(&res)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshall event to bytes: %w", err)
+	}
+
+	return bs, nil
+}
+
+func mapBytesToModelEventInitiativeUpdated(data []byte) (*model.EventInitiativeUpdated, error) {
+	e := &eventInitiativeUpdated{}
+	err := json.Unmarshal(data, e)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshall bytes to event: %w", err)
+	}
+
+	res := &model.EventInitiativeUpdated{
+		Initiative: model.Initiative{
+			RoomID:     e.Initiative.RoomID,
+			UpdatedAt:  e.Initiative.UpdatedAt,
+			Round:      e.Initiative.Round,
+			Turn:       e.Initiative.Turn,
+			Combatants: make([]model.InitiativeCombatant, 0, len(e.Initiative.Combatants)),
+		},
+	}
+
+	for _, c := range e.Initiative.Combatants {
+		res.Initiative.Combatants = append(res.Initiative.Combatants, model.InitiativeCombatant{
+			ID:         c.ID,
+			Name:       c.Name,
+			UserID:     c.UserID,
+			Initiative: c.Initiative,
+			DiceRollID: c.DiceRollID,
+		})
+	}
+
+	return res, nil
+}
+
+type eventCardsDrawn struct {
+	CardDraw cardDraw
+}
+
+type cardDraw struct {
+	ID        string
+	CreatedAt time.Time
+	RoomID    string
+	UserID    string
+	DeckID    string
+	DeckName  string
+	Cards     []string
+}
+
+// maps model to a stream model used to be shared.
+func mapModelToBytesEventCardsDrawn(e model.EventCardsDrawn) ([]byte, error) {
+	res := eventCardsDrawn{
+		CardDraw: cardDraw{
+			ID:        e.CardDraw.ID,
+			CreatedAt: e.CardDraw.CreatedAt,
+			RoomID:    e.CardDraw.RoomID,
+			UserID:    e.CardDraw.UserID,
+			DeckID:    e.CardDraw.DeckID,
+			DeckName:  e.CardDraw.DeckName,
+			Cards:     e.CardDraw.Cards,
+		},
+	}
+
+	bs, err := json.Marshal(&res)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshall event to bytes: %w", err)
+	}
+
+	return bs, nil
+}
+
+func mapBytesToModelEventCardsDrawn(data []byte) (*model.EventCardsDrawn, error) {
+	e := &eventCardsDrawn{}
+	err := json.Unmarshal(data, e)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshall bytes to event: %w", err)
+	}
+
+	return &model.EventCardsDrawn{
+		CardDraw: model.CardDraw{
+			ID:        e.CardDraw.ID,
+			CreatedAt: e.CardDraw.CreatedAt,
+			RoomID:    e.CardDraw.RoomID,
+			UserID:    e.CardDraw.UserID,
+			DeckID:    e.CardDraw.DeckID,
+			DeckName:  e.CardDraw.DeckName,
+			Cards:     e.CardDraw.Cards,
+		},
+	}, nil
+}
+
+type eventOracleTableRolled struct {
+	OracleTableRoll oracleTableRoll
+}
+
+type oracleTableRoll struct {
+	ID        string
+	CreatedAt time.Time
+	RoomID    string
+	UserID    string
+	TableID   string
+	Results   []oracleTableResult
+}
+
+type oracleTableResult struct {
+	TableName  string
+	DiceRollID string
+	Value      int
+	Result     string
+}
+
+// maps model to a stream model used to be shared.
+func mapModelToBytesEventOracleTableRolled(e model.EventOracleTableRolled) ([]byte, error) {
+	res := eventOracleTableRolled{
+		OracleTableRoll: oracleTableRoll{
+			ID:        e.OracleTableRoll.ID,
+			CreatedAt: e.OracleTableRoll.CreatedAt,
+			RoomID:    e.OracleTableRoll.RoomID,
+			UserID:    e.OracleTableRoll.UserID,
+			TableID:   e.OracleTableRoll.TableID,
+		},
+	}
+
+	for _, r := range e.OracleTableRoll.Results {
+		res.OracleTableRoll.Results = append(res.OracleTableRoll.Results, oracleTableResult{
+			TableName:  r.TableName,
+			DiceRollID: r.DiceRollID,
+			Value:      r.Value,
+			Result:     r.Result,
+		})
+	}
+
+	bs, err := json.Marshal(&res)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshall event to bytes: %w", err)
+	}
+
+	return bs, nil
+}
+
+func mapBytesToModelEventOracleTableRolled(data []byte) (*model.EventOracleTableRolled, error) {
+	e := &eventOracleTableRolled{}
+	err := json.Unmarshal(data, e)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshall bytes to event: %w", err)
+	}
+
+	res := &model.EventOracleTableRolled{
+		OracleTableRoll: model.OracleTableRoll{
+			ID:        e.OracleTableRoll.ID,
+			CreatedAt: e.OracleTableRoll.CreatedAt,
+			RoomID:    e.OracleTableRoll.RoomID,
+			UserID:    e.OracleTableRoll.UserID,
+			TableID:   e.OracleTableRoll.TableID,
+		},
+	}
+
+	for _, r := range e.OracleTableRoll.Results {
+		res.OracleTableRoll.Results = append(res.OracleTableRoll.Results, model.OracleTableResult{
+			TableName:  r.TableName,
+			DiceRollID: r.DiceRollID,
+			Value:      r.Value,
+			Result:     r.Result,
 		})
 	}
 
 	return res, nil
 }
+
+type eventRoomClosed struct {
+	RoomID  string
+	Deleted bool
+}
+
+// maps model to a stream model used to be shared.
+func mapModelToBytesEventRoomClosed(e model.EventRoomClosed) ([]byte, error) {
+	res := eventRoomClosed{
+		RoomID:  e.RoomID,
+		Deleted: e.Deleted,
+	}
+
+	bs, err := json.Marshal(&res)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshall event to bytes: %w", err)
+	}
+
+	return bs, nil
+}
+
+func mapBytesToModelEventRoomClosed(data []byte) (*model.EventRoomClosed, error) {
+	e := &eventRoomClosed{}
+	err := json.Unmarshal(data, e)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshall bytes to event: %w", err)
+	}
+
+	return &model.EventRoomClosed{
+		RoomID:  e.RoomID,
+		Deleted: e.Deleted,
+	}, nil
+}
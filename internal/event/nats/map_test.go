@@ -0,0 +1,74 @@
+package nats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/model"
+)
+
+func TestMapEventDiceRollCreated(t *testing.T) {
+	t0 := time.Date(2023, 1, 21, 11, 5, 45, 0, time.UTC)
+
+	tests := map[string]struct {
+		event model.EventDiceRollCreated
+	}{
+		"A regular dice roll should be mapped back.": {
+			event: model.EventDiceRollCreated{
+				DiceRoll: model.DiceRoll{
+					ID:         "dice-roll-id",
+					Serial:     4,
+					CreatedAt:  t0,
+					RoomID:     "room-id",
+					UserID:     "user-id",
+					Expression: "2d6+1",
+					Modifier:   1,
+					Total:      8,
+					Dice: []model.DieRoll{
+						{ID: "die-roll-1", Type: model.DieTypeD6, Side: 3},
+						{ID: "die-roll-2", Type: model.DieTypeD6, Side: 4},
+					},
+				},
+			},
+		},
+
+		"A provably fair dice roll should be mapped back with its proof.": {
+			event: model.EventDiceRollCreated{
+				DiceRoll: model.DiceRoll{
+					ID:        "dice-roll-id",
+					Serial:    4,
+					CreatedAt: t0,
+					RoomID:    "room-id",
+					UserID:    "user-id",
+					Total:     17,
+					Dice: []model.DieRoll{
+						{ID: "die-roll-1", Type: model.DieTypeD20, Side: 17},
+					},
+					Proof: &model.DiceRollProof{
+						ServerSeedID:   "server-seed-id",
+						ServerSeedHash: "server-seed-hash",
+						ClientSeed:     "client-seed",
+						Nonce:          42,
+					},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			data, err := mapModelToBytesEventDiceRollCreated(test.event)
+			require.NoError(err)
+
+			got, err := mapBytesToModelEventDiceRollCreated(data)
+			require.NoError(err)
+			assert.Equal(test.event, *got)
+		})
+	}
+}
@@ -13,7 +13,11 @@ import (
 )
 
 const (
-	natsSubjectDiceRollCreated = "rollify.room.diceroll.create"
+	natsSubjectDiceRollCreated   = "rollify.room.diceroll.create"
+	natsSubjectInitiativeUpdated = "rollify.room.initiative.update"
+	natsSubjectCardsDrawn        = "rollify.room.deck.draw"
+	natsSubjectOracleTableRolled = "rollify.room.oracle.roll"
+	natsSubjectRoomClosed        = "rollify.room.close"
 )
 
 // Client is the client used for NATS connections.
@@ -23,6 +27,10 @@ type Client interface {
 }
 
 type diceRollCreatedFunc = func(context.Context, model.EventDiceRollCreated) error
+type initiativeUpdatedFunc = func(context.Context, model.EventInitiativeUpdated) error
+type cardsDrawnFunc = func(context.Context, model.EventCardsDrawn) error
+type oracleTableRolledFunc = func(context.Context, model.EventOracleTableRolled) error
+type roomClosedFunc = func(context.Context, model.EventRoomClosed) error
 
 // HubConfig is the hub configuration.
 type HubConfig struct {
@@ -63,7 +71,24 @@ type Hub struct {
 	diceRollCreatedHandlers map[string]map[string]diceRollCreatedFunc
 	diceRollCreatedChan     chan *nats.Msg
 	diceRollCreatedSubs     *nats.Subscription
-	mu                      sync.Mutex
+
+	initiativeUpdatedHandlers map[string]map[string]initiativeUpdatedFunc
+	initiativeUpdatedChan     chan *nats.Msg
+	initiativeUpdatedSubs     *nats.Subscription
+
+	cardsDrawnHandlers map[string]map[string]cardsDrawnFunc
+	cardsDrawnChan     chan *nats.Msg
+	cardsDrawnSubs     *nats.Subscription
+
+	oracleTableRolledHandlers map[string]map[string]oracleTableRolledFunc
+	oracleTableRolledChan     chan *nats.Msg
+	oracleTableRolledSubs     *nats.Subscription
+
+	roomClosedHandlers map[string]map[string]roomClosedFunc
+	roomClosedChan     chan *nats.Msg
+	roomClosedSubs     *nats.Subscription
+
+	mu sync.Mutex
 }
 
 // NewHub returns a new hub based on a memory implementation.
@@ -79,6 +104,18 @@ func NewHub(cfg HubConfig) (*Hub, error) {
 
 		diceRollCreatedHandlers: map[string]map[string]diceRollCreatedFunc{},
 		diceRollCreatedChan:     make(chan *nats.Msg, 15),
+
+		initiativeUpdatedHandlers: map[string]map[string]initiativeUpdatedFunc{},
+		initiativeUpdatedChan:     make(chan *nats.Msg, 15),
+
+		cardsDrawnHandlers: map[string]map[string]cardsDrawnFunc{},
+		cardsDrawnChan:     make(chan *nats.Msg, 15),
+
+		oracleTableRolledHandlers: map[string]map[string]oracleTableRolledFunc{},
+		oracleTableRolledChan:     make(chan *nats.Msg, 15),
+
+		roomClosedHandlers: map[string]map[string]roomClosedFunc{},
+		roomClosedChan:     make(chan *nats.Msg, 15),
 	}
 
 	// Subscribe and run event handling.
@@ -119,6 +156,34 @@ func (h *Hub) run(ctx context.Context) {
 			if err != nil {
 				h.logger.Errorf("could not handle diceRollCreated event: %s", err)
 			}
+
+		case msg := <-h.initiativeUpdatedChan:
+			h.logger.Debugf("initiativeUpdated NATS event received, broadcasting")
+			err := h.handleInitiativeUpdatedEvent(loopCtx, msg.Data)
+			if err != nil {
+				h.logger.Errorf("could not handle initiativeUpdated event: %s", err)
+			}
+
+		case msg := <-h.cardsDrawnChan:
+			h.logger.Debugf("cardsDrawn NATS event received, broadcasting")
+			err := h.handleCardsDrawnEvent(loopCtx, msg.Data)
+			if err != nil {
+				h.logger.Errorf("could not handle cardsDrawn event: %s", err)
+			}
+
+		case msg := <-h.oracleTableRolledChan:
+			h.logger.Debugf("oracleTableRolled NATS event received, broadcasting")
+			err := h.handleOracleTableRolledEvent(loopCtx, msg.Data)
+			if err != nil {
+				h.logger.Errorf("could not handle oracleTableRolled event: %s", err)
+			}
+
+		case msg := <-h.roomClosedChan:
+			h.logger.Debugf("roomClosed NATS event received, broadcasting")
+			err := h.handleRoomClosedEvent(loopCtx, msg.Data)
+			if err != nil {
+				h.logger.Errorf("could not handle roomClosed event: %s", err)
+			}
 		}
 	}
 }
@@ -131,6 +196,30 @@ func (h *Hub) subscribeOnNATSSubects() error {
 	}
 	h.diceRollCreatedSubs = sub
 
+	sub, err = h.cli.ChanSubscribe(natsSubjectInitiativeUpdated, h.initiativeUpdatedChan)
+	if err != nil {
+		return fmt.Errorf("could not subscribe on updated initiative event subject: %w", err)
+	}
+	h.initiativeUpdatedSubs = sub
+
+	sub, err = h.cli.ChanSubscribe(natsSubjectCardsDrawn, h.cardsDrawnChan)
+	if err != nil {
+		return fmt.Errorf("could not subscribe on drawn cards event subject: %w", err)
+	}
+	h.cardsDrawnSubs = sub
+
+	sub, err = h.cli.ChanSubscribe(natsSubjectOracleTableRolled, h.oracleTableRolledChan)
+	if err != nil {
+		return fmt.Errorf("could not subscribe on rolled oracle table event subject: %w", err)
+	}
+	h.oracleTableRolledSubs = sub
+
+	sub, err = h.cli.ChanSubscribe(natsSubjectRoomClosed, h.roomClosedChan)
+	if err != nil {
+		return fmt.Errorf("could not subscribe on closed room event subject: %w", err)
+	}
+	h.roomClosedSubs = sub
+
 	return nil
 }
 
@@ -140,6 +229,26 @@ func (h *Hub) unsubscribeOnNATSSubects() error {
 		return fmt.Errorf("could not unsubscribe on created dice roll event subject: %w", err)
 	}
 
+	err = h.initiativeUpdatedSubs.Unsubscribe()
+	if err != nil {
+		return fmt.Errorf("could not unsubscribe on updated initiative event subject: %w", err)
+	}
+
+	err = h.cardsDrawnSubs.Unsubscribe()
+	if err != nil {
+		return fmt.Errorf("could not unsubscribe on drawn cards event subject: %w", err)
+	}
+
+	err = h.oracleTableRolledSubs.Unsubscribe()
+	if err != nil {
+		return fmt.Errorf("could not unsubscribe on rolled oracle table event subject: %w", err)
+	}
+
+	err = h.roomClosedSubs.Unsubscribe()
+	if err != nil {
+		return fmt.Errorf("could not unsubscribe on closed room event subject: %w", err)
+	}
+
 	return nil
 }
 
@@ -221,6 +330,302 @@ func (h *Hub) UnsubscribeDiceRollCreated(ctx context.Context, subscribeID, roomI
 	return nil
 }
 
+// NotifyInitiativeUpdated satisfies event.Notifier interface by pusblishing the event
+// in a NATS pubsub stream, serialized in JSON.
+func (h *Hub) NotifyInitiativeUpdated(ctx context.Context, e model.EventInitiativeUpdated) error {
+	bs, err := mapModelToBytesEventInitiativeUpdated(e)
+	if err != nil {
+		return fmt.Errorf("could not marshall event: %w", err)
+	}
+
+	h.logger.Debugf("initiativeUpdated NATS event published")
+	err = h.cli.Publish(natsSubjectInitiativeUpdated, bs)
+	if err != nil {
+		return fmt.Errorf("could not pusblish message on NATS: %w", err)
+	}
+
+	return nil
+}
+
+func (h *Hub) handleInitiativeUpdatedEvent(ctx context.Context, data []byte) error {
+	e, err := mapBytesToModelEventInitiativeUpdated(data)
+	if err != nil {
+		return fmt.Errorf("could not unmarshall event: %w", err)
+	}
+
+	logger := h.logger.WithKV(log.KV{"event": "InitiativeUpdated"})
+
+	// Get subscribed handlers.
+	h.mu.Lock()
+	handlers := h.initiativeUpdatedHandlers[e.Initiative.RoomID]
+	h.mu.Unlock()
+
+	// Broadcast to al subscribers.
+	for _, handler := range handlers {
+		err := handler(ctx, *e)
+		if err != nil {
+			logger.Errorf("error executing hub event handler : %s", err)
+		}
+	}
+
+	return nil
+}
+
+// SubscribeInitiativeUpdated satisfies event.Subscriber interface.
+func (h *Hub) SubscribeInitiativeUpdated(ctx context.Context, subscribeID, roomID string, handler func(context.Context, model.EventInitiativeUpdated) error) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	logger := h.logger.WithKV(log.KV{"event": "InitiativeUpdated"})
+
+	hs, ok := h.initiativeUpdatedHandlers[roomID]
+	if !ok {
+		hs = map[string]initiativeUpdatedFunc{}
+	}
+
+	hs[subscribeID] = handler
+	h.initiativeUpdatedHandlers[roomID] = hs
+	logger.Debugf("subscribed to InitiativeUpdated events")
+
+	return nil
+}
+
+// UnsubscribeInitiativeUpdated satisfies event.Subscriber interface.
+func (h *Hub) UnsubscribeInitiativeUpdated(ctx context.Context, subscribeID, roomID string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	logger := h.logger.WithKV(log.KV{"event": "InitiativeUpdated"})
+
+	hs, ok := h.initiativeUpdatedHandlers[roomID]
+	if ok {
+		delete(hs, subscribeID)
+	}
+
+	logger.Debugf("unsubscribed to InitiativeUpdated events")
+	return nil
+}
+
+// NotifyCardsDrawn satisfies event.Notifier interface by pusblishing the event
+// in a NATS pubsub stream, serialized in JSON.
+func (h *Hub) NotifyCardsDrawn(ctx context.Context, e model.EventCardsDrawn) error {
+	bs, err := mapModelToBytesEventCardsDrawn(e)
+	if err != nil {
+		return fmt.Errorf("could not marshall event: %w", err)
+	}
+
+	h.logger.Debugf("cardsDrawn NATS event published")
+	err = h.cli.Publish(natsSubjectCardsDrawn, bs)
+	if err != nil {
+		return fmt.Errorf("could not pusblish message on NATS: %w", err)
+	}
+
+	return nil
+}
+
+func (h *Hub) handleCardsDrawnEvent(ctx context.Context, data []byte) error {
+	e, err := mapBytesToModelEventCardsDrawn(data)
+	if err != nil {
+		return fmt.Errorf("could not unmarshall event: %w", err)
+	}
+
+	logger := h.logger.WithKV(log.KV{"event": "CardsDrawn"})
+
+	// Get subscribed handlers.
+	h.mu.Lock()
+	handlers := h.cardsDrawnHandlers[e.CardDraw.RoomID]
+	h.mu.Unlock()
+
+	// Broadcast to al subscribers.
+	for _, handler := range handlers {
+		err := handler(ctx, *e)
+		if err != nil {
+			logger.Errorf("error executing hub event handler : %s", err)
+		}
+	}
+
+	return nil
+}
+
+// SubscribeCardsDrawn satisfies event.Subscriber interface.
+func (h *Hub) SubscribeCardsDrawn(ctx context.Context, subscribeID, roomID string, handler func(context.Context, model.EventCardsDrawn) error) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	logger := h.logger.WithKV(log.KV{"event": "CardsDrawn"})
+
+	hs, ok := h.cardsDrawnHandlers[roomID]
+	if !ok {
+		hs = map[string]cardsDrawnFunc{}
+	}
+
+	hs[subscribeID] = handler
+	h.cardsDrawnHandlers[roomID] = hs
+	logger.Debugf("subscribed to CardsDrawn events")
+
+	return nil
+}
+
+// UnsubscribeCardsDrawn satisfies event.Subscriber interface.
+func (h *Hub) UnsubscribeCardsDrawn(ctx context.Context, subscribeID, roomID string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	logger := h.logger.WithKV(log.KV{"event": "CardsDrawn"})
+
+	hs, ok := h.cardsDrawnHandlers[roomID]
+	if ok {
+		delete(hs, subscribeID)
+	}
+
+	logger.Debugf("unsubscribed to CardsDrawn events")
+	return nil
+}
+
+// NotifyOracleTableRolled satisfies event.Notifier interface by pusblishing the event
+// in a NATS pubsub stream, serialized in JSON.
+func (h *Hub) NotifyOracleTableRolled(ctx context.Context, e model.EventOracleTableRolled) error {
+	bs, err := mapModelToBytesEventOracleTableRolled(e)
+	if err != nil {
+		return fmt.Errorf("could not marshall event: %w", err)
+	}
+
+	h.logger.Debugf("oracleTableRolled NATS event published")
+	err = h.cli.Publish(natsSubjectOracleTableRolled, bs)
+	if err != nil {
+		return fmt.Errorf("could not pusblish message on NATS: %w", err)
+	}
+
+	return nil
+}
+
+func (h *Hub) handleOracleTableRolledEvent(ctx context.Context, data []byte) error {
+	e, err := mapBytesToModelEventOracleTableRolled(data)
+	if err != nil {
+		return fmt.Errorf("could not unmarshall event: %w", err)
+	}
+
+	logger := h.logger.WithKV(log.KV{"event": "OracleTableRolled"})
+
+	// Get subscribed handlers.
+	h.mu.Lock()
+	handlers := h.oracleTableRolledHandlers[e.OracleTableRoll.RoomID]
+	h.mu.Unlock()
+
+	// Broadcast to al subscribers.
+	for _, handler := range handlers {
+		err := handler(ctx, *e)
+		if err != nil {
+			logger.Errorf("error executing hub event handler : %s", err)
+		}
+	}
+
+	return nil
+}
+
+// SubscribeOracleTableRolled satisfies event.Subscriber interface.
+func (h *Hub) SubscribeOracleTableRolled(ctx context.Context, subscribeID, roomID string, handler func(context.Context, model.EventOracleTableRolled) error) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	logger := h.logger.WithKV(log.KV{"event": "OracleTableRolled"})
+
+	hs, ok := h.oracleTableRolledHandlers[roomID]
+	if !ok {
+		hs = map[string]oracleTableRolledFunc{}
+	}
+
+	hs[subscribeID] = handler
+	h.oracleTableRolledHandlers[roomID] = hs
+	logger.Debugf("subscribed to OracleTableRolled events")
+
+	return nil
+}
+
+// UnsubscribeOracleTableRolled satisfies event.Subscriber interface.
+func (h *Hub) UnsubscribeOracleTableRolled(ctx context.Context, subscribeID, roomID string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	logger := h.logger.WithKV(log.KV{"event": "OracleTableRolled"})
+
+	hs, ok := h.oracleTableRolledHandlers[roomID]
+	if ok {
+		delete(hs, subscribeID)
+	}
+
+	logger.Debugf("unsubscribed to OracleTableRolled events")
+	return nil
+}
+
+// NotifyRoomClosed satisfies event.Notifier interface by pusblishing the event
+// in a NATS pubsub stream, serialized in JSON.
+func (h *Hub) NotifyRoomClosed(ctx context.Context, e model.EventRoomClosed) error {
+	bs, err := mapModelToBytesEventRoomClosed(e)
+	if err != nil {
+		return fmt.Errorf("could not marshall event: %w", err)
+	}
+
+	h.logger.Debugf("roomClosed NATS event published")
+	err = h.cli.Publish(natsSubjectRoomClosed, bs)
+	if err != nil {
+		return fmt.Errorf("could not pusblish message on NATS: %w", err)
+	}
+
+	return nil
+}
+
+func (h *Hub) handleRoomClosedEvent(ctx context.Context, data []byte) error {
+	e, err := mapBytesToModelEventRoomClosed(data)
+	if err != nil {
+		return fmt.Errorf("could not unmarshall event: %w", err)
+	}
+
+	logger := h.logger.WithKV(log.KV{"event": "RoomClosed"})
+
+	// Get subscribed handlers.
+	h.mu.Lock()
+	handlers := h.roomClosedHandlers[e.RoomID]
+	h.mu.Unlock()
+
+	// Broadcast to al subscribers.
+	for _, handler := range handlers {
+		err := handler(ctx, *e)
+		if err != nil {
+			logger.Errorf("error executing hub event handler : %s", err)
+		}
+	}
+
+	return nil
+}
+
+// SubscribeRoomClosed satisfies event.Subscriber interface.
+func (h *Hub) SubscribeRoomClosed(ctx context.Context, subscribeID, roomID string, handler func(context.Context, model.EventRoomClosed) error) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	logger := h.logger.WithKV(log.KV{"event": "RoomClosed"})
+
+	hs, ok := h.roomClosedHandlers[roomID]
+	if !ok {
+		hs = map[string]roomClosedFunc{}
+	}
+
+	hs[subscribeID] = handler
+	h.roomClosedHandlers[roomID] = hs
+	logger.Debugf("subscribed to RoomClosed events")
+
+	return nil
+}
+
+// UnsubscribeRoomClosed satisfies event.Subscriber interface.
+func (h *Hub) UnsubscribeRoomClosed(ctx context.Context, subscribeID, roomID string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	logger := h.logger.WithKV(log.KV{"event": "RoomClosed"})
+
+	hs, ok := h.roomClosedHandlers[roomID]
+	if ok {
+		delete(hs, subscribeID)
+	}
+
+	logger.Debugf("unsubscribed to RoomClosed events")
+	return nil
+}
+
 var (
 	_ event.Notifier   = &Hub{}
 	_ event.Subscriber = &Hub{}
@@ -7,20 +7,30 @@ import (
 	"github.com/emicklei/go-restful/v3"
 	gohttmetrics "github.com/slok/go-http-metrics/middleware"
 
+	"github.com/rollify/rollify/internal/deck"
 	"github.com/rollify/rollify/internal/dice"
+	"github.com/rollify/rollify/internal/initiative"
 	"github.com/rollify/rollify/internal/log"
+	"github.com/rollify/rollify/internal/macro"
+	"github.com/rollify/rollify/internal/oracle"
 	"github.com/rollify/rollify/internal/room"
+	"github.com/rollify/rollify/internal/stats"
 	"github.com/rollify/rollify/internal/user"
 )
 
 // Config is the configuration to serve the API.
 type Config struct {
-	DiceAppService  dice.Service
-	RoomAppService  room.Service
-	UserAppService  user.Service
-	MetricsRecorder MetricsRecorder
-	ServePefix      string
-	Logger          log.Logger
+	DiceAppService       dice.Service
+	RoomAppService       room.Service
+	UserAppService       user.Service
+	MacroAppService      macro.Service
+	InitiativeAppService initiative.Service
+	DeckAppService       deck.Service
+	OracleAppService     oracle.Service
+	StatsAppService      stats.Service
+	MetricsRecorder      MetricsRecorder
+	ServePefix           string
+	Logger               log.Logger
 }
 
 func (c *Config) defaults() error {
@@ -36,6 +46,26 @@ func (c *Config) defaults() error {
 		return fmt.Errorf("user.Service application service is required")
 	}
 
+	if c.MacroAppService == nil {
+		return fmt.Errorf("macro.Service application service is required")
+	}
+
+	if c.InitiativeAppService == nil {
+		return fmt.Errorf("initiative.Service application service is required")
+	}
+
+	if c.DeckAppService == nil {
+		return fmt.Errorf("deck.Service application service is required")
+	}
+
+	if c.OracleAppService == nil {
+		return fmt.Errorf("oracle.Service application service is required")
+	}
+
+	if c.StatsAppService == nil {
+		return fmt.Errorf("stats.Service application service is required")
+	}
+
 	if c.Logger == nil {
 		c.Logger = log.Dummy
 	}
@@ -60,6 +90,11 @@ type apiv1 struct {
 	diceAppSvc        dice.Service
 	roomAppSvc        room.Service
 	userAppSvc        user.Service
+	macroAppSvc       macro.Service
+	initiativeAppSvc  initiative.Service
+	deckAppSvc        deck.Service
+	oracleAppSvc      oracle.Service
+	statsAppSvc       stats.Service
 	logger            log.Logger
 	apiws             *restful.WebService
 	restContainer     *restful.Container
@@ -74,10 +109,15 @@ func New(cfg Config) (http.Handler, error) {
 	}
 
 	a := apiv1{
-		diceAppSvc: cfg.DiceAppService,
-		roomAppSvc: cfg.RoomAppService,
-		userAppSvc: cfg.UserAppService,
-		logger:     cfg.Logger,
+		diceAppSvc:       cfg.DiceAppService,
+		roomAppSvc:       cfg.RoomAppService,
+		userAppSvc:       cfg.UserAppService,
+		macroAppSvc:      cfg.MacroAppService,
+		initiativeAppSvc: cfg.InitiativeAppService,
+		deckAppSvc:       cfg.DeckAppService,
+		oracleAppSvc:     cfg.OracleAppService,
+		statsAppSvc:      cfg.StatsAppService,
+		logger:           cfg.Logger,
 	}
 
 	// Create router.
@@ -100,7 +140,7 @@ func New(cfg Config) (http.Handler, error) {
 	// Enable cors.
 	cors := restful.CrossOriginResourceSharing{
 		AllowedHeaders: []string{"Content-Type", "Accept"},
-		AllowedMethods: []string{"GET", "POST"},
+		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE"},
 		CookiesAllowed: false,
 		Container:      a.restContainer}
 	a.restContainer.Filter(cors.Filter)
@@ -17,17 +17,45 @@ import (
 	"github.com/stretchr/testify/require"
 	"nhooyr.io/websocket"
 
+	"github.com/rollify/rollify/internal/deck"
+	"github.com/rollify/rollify/internal/deck/deckmock"
 	"github.com/rollify/rollify/internal/dice"
 	"github.com/rollify/rollify/internal/dice/dicemock"
 	"github.com/rollify/rollify/internal/http/apiv1"
+	"github.com/rollify/rollify/internal/initiative"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
 	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/macro"
+	"github.com/rollify/rollify/internal/macro/macromock"
 	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
 	"github.com/rollify/rollify/internal/room"
 	"github.com/rollify/rollify/internal/room/roommock"
+	"github.com/rollify/rollify/internal/stats"
+	"github.com/rollify/rollify/internal/stats/statsmock"
 	"github.com/rollify/rollify/internal/user"
 	"github.com/rollify/rollify/internal/user/usermock"
 )
 
+// newOpenRoomAppService returns a room service mock whose rooms are not protected, used by the
+// endpoints that read the rooms.
+func newOpenRoomAppService() *roommock.Service {
+	m := &roommock.Service{}
+	m.On("GetRoom", mock.Anything, mock.Anything).Maybe().Return(&room.GetRoomResponse{}, nil)
+	return m
+}
+
+// newSessionUserAppService returns a user app service that authenticates the session tokens as
+// the users with the same ID.
+func newSessionUserAppService() *usermock.Service {
+	m := &usermock.Service{}
+	m.On("AuthenticateUser", mock.Anything, mock.Anything).Maybe().Return(func(_ context.Context, r user.AuthenticateUserRequest) (*user.AuthenticateUserResponse, error) {
+		return &user.AuthenticateUserResponse{User: model.User{ID: r.SessionToken, RoomID: r.RoomID}}, nil
+	})
+	return m
+}
+
 func TestAPIV1Pong(t *testing.T) {
 	tests := map[string]struct {
 		req           func() *http.Request
@@ -51,9 +79,14 @@ func TestAPIV1Pong(t *testing.T) {
 
 			// Prepare.
 			cfg := apiv1.Config{
-				DiceAppService: &dicemock.Service{},
-				RoomAppService: &roommock.Service{},
-				UserAppService: &usermock.Service{},
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       &roommock.Service{},
+				UserAppService:       &usermock.Service{},
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
 			}
 			h, err := apiv1.New(cfg)
 			require.NoError(err)
@@ -86,7 +119,7 @@ func TestAPIV1ErrorMappings(t *testing.T) {
 		"Having no ErrNotValid, should return 400.": {
 			mock: func(m *dicemock.Service) {
 				r := &dice.ListDiceTypesResponse{}
-				m.On("ListDiceTypes", mock.Anything).Once().Return(r, fmt.Errorf("wanted error: %w", internalerrors.ErrNotValid))
+				m.On("ListDiceTypes", mock.Anything, mock.Anything).Once().Return(r, fmt.Errorf("wanted error: %w", internalerrors.ErrNotValid))
 			},
 			req: func() *http.Request {
 				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/types", nil)
@@ -99,7 +132,7 @@ func TestAPIV1ErrorMappings(t *testing.T) {
 		"Having no ErrMissing, should return 404.": {
 			mock: func(m *dicemock.Service) {
 				r := &dice.ListDiceTypesResponse{}
-				m.On("ListDiceTypes", mock.Anything).Once().Return(r, fmt.Errorf("wanted error: %w", internalerrors.ErrMissing))
+				m.On("ListDiceTypes", mock.Anything, mock.Anything).Once().Return(r, fmt.Errorf("wanted error: %w", internalerrors.ErrMissing))
 			},
 			req: func() *http.Request {
 				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/types", nil)
@@ -112,7 +145,7 @@ func TestAPIV1ErrorMappings(t *testing.T) {
 		"Having no ErrAlreadyExists, should return 409.": {
 			mock: func(m *dicemock.Service) {
 				r := &dice.ListDiceTypesResponse{}
-				m.On("ListDiceTypes", mock.Anything).Once().Return(r, fmt.Errorf("wanted error: %w", internalerrors.ErrAlreadyExists))
+				m.On("ListDiceTypes", mock.Anything, mock.Anything).Once().Return(r, fmt.Errorf("wanted error: %w", internalerrors.ErrAlreadyExists))
 			},
 			req: func() *http.Request {
 				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/types", nil)
@@ -121,6 +154,19 @@ func TestAPIV1ErrorMappings(t *testing.T) {
 			expStatusCode: http.StatusConflict,
 			expBody:       "{\n \"Code\": 409,\n \"Message\": \"wanted error: already exists\",\n \"Header\": null\n}",
 		},
+
+		"Having no ErrRateLimited, should return 429.": {
+			mock: func(m *dicemock.Service) {
+				r := &dice.ListDiceTypesResponse{}
+				m.On("ListDiceTypes", mock.Anything, mock.Anything).Once().Return(r, fmt.Errorf("wanted error: %w", internalerrors.ErrRateLimited))
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/types", nil)
+				return r
+			},
+			expStatusCode: http.StatusTooManyRequests,
+			expBody:       "{\n \"Code\": 429,\n \"Message\": \"wanted error: rate limited\",\n \"Header\": null\n}",
+		},
 	}
 
 	for name, test := range tests {
@@ -133,9 +179,14 @@ func TestAPIV1ErrorMappings(t *testing.T) {
 
 			// Prepare.
 			cfg := apiv1.Config{
-				DiceAppService: md,
-				RoomAppService: &roommock.Service{},
-				UserAppService: &usermock.Service{},
+				DiceAppService:       md,
+				RoomAppService:       newOpenRoomAppService(),
+				UserAppService:       &usermock.Service{},
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
 			}
 			h, err := apiv1.New(cfg)
 			require.NoError(err)
@@ -166,7 +217,7 @@ func TestAPIV1ListDiceTypes(t *testing.T) {
 				exp := &dice.ListDiceTypesResponse{
 					DiceTypes: []model.DieType{model.DieTypeD4},
 				}
-				m.On("ListDiceTypes", mock.Anything).Once().Return(exp, nil)
+				m.On("ListDiceTypes", mock.Anything, mock.Anything).Once().Return(exp, nil)
 			},
 			req: func() *http.Request {
 				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/types", nil)
@@ -184,9 +235,56 @@ func TestAPIV1ListDiceTypes(t *testing.T) {
 }`,
 		},
 
+		"Having a request with a room, should list the room custom die types with their faces.": {
+			mock: func(m *dicemock.Service) {
+				expReq := dice.ListDiceTypesRequest{RoomID: "test-room"}
+				exp := &dice.ListDiceTypesResponse{
+					DiceTypes: []model.DieType{
+						model.DieTypeD4,
+						model.CustomDieType{
+							TypeID:    "coin-id",
+							RoomID:    "test-room",
+							TypeName:  "Coin",
+							FaceTable: []model.DieFace{{Label: "Heads", Value: 1}, {Label: "Tails", Value: 0}},
+						},
+					},
+				}
+				m.On("ListDiceTypes", mock.Anything, expReq).Once().Return(exp, nil)
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/types?room-id=test-room", nil)
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody: `{
+ "items": [
+  {
+   "id": "d4",
+   "name": "d4",
+   "sides": 4
+  },
+  {
+   "id": "coin-id",
+   "name": "Coin",
+   "sides": 2,
+   "faces": [
+    {
+     "label": "Heads",
+     "value": 1
+    },
+    {
+     "label": "Tails",
+     "value": 0
+    }
+   ]
+  }
+ ]
+}`,
+		},
+
 		"Having an internal error on the application service should return an internal error.": {
 			mock: func(m *dicemock.Service) {
-				m.On("ListDiceTypes", mock.Anything).Once().Return(nil, errors.New("wanted error"))
+				m.On("ListDiceTypes", mock.Anything, mock.Anything).Once().Return(nil, errors.New("wanted error"))
 			},
 			req: func() *http.Request {
 				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/types", nil)
@@ -207,9 +305,14 @@ func TestAPIV1ListDiceTypes(t *testing.T) {
 
 			// Prepare.
 			cfg := apiv1.Config{
-				DiceAppService: md,
-				RoomAppService: &roommock.Service{},
-				UserAppService: &usermock.Service{},
+				DiceAppService:       md,
+				RoomAppService:       newOpenRoomAppService(),
+				UserAppService:       &usermock.Service{},
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
 			}
 			h, err := apiv1.New(cfg)
 			require.NoError(err)
@@ -249,12 +352,38 @@ func TestAPIV1CreateDiceRoll(t *testing.T) {
 			expBody:       "{\n \"Code\": 400,\n \"Message\": \"user_id is required\",\n \"Header\": null\n}",
 		},
 
+		"Having a request without session should fail.": {
+			mock: func(m *dicemock.Service) {},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user","room_id": "test-room", "dice_type_ids": ["d20"]}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"session token is required: not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Having a request with a user that is not the session user should fail.": {
+			mock: func(m *dicemock.Service) {},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user","room_id": "test-room", "dice_type_ids": ["d20"]}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer other-user")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"request user is not the session user: not allowed\",\n \"Header\": null\n}",
+		},
+
 		"Having a request without room ID should fail.": {
 			mock: func(m *dicemock.Service) {},
 			req: func() *http.Request {
 				body := `{"user_id": "test-user","room_id": "", "dice_type_ids": ["d20"]}`
 				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
 				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
 				return r
 			},
 			expStatusCode: http.StatusBadRequest,
@@ -267,6 +396,7 @@ func TestAPIV1CreateDiceRoll(t *testing.T) {
 				body := `{"user_id": "test-user","room_id": "test-room", "dice_type_ids": []}`
 				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
 				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
 				return r
 			},
 			expStatusCode: http.StatusBadRequest,
@@ -274,11 +404,16 @@ func TestAPIV1CreateDiceRoll(t *testing.T) {
 		},
 
 		"Having a request with invalid dice types should fail .": {
-			mock: func(m *dicemock.Service) {},
+			mock: func(m *dicemock.Service) {
+				expReq := dice.ListDiceTypesRequest{RoomID: "test-room"}
+				resp := &dice.ListDiceTypesResponse{DiceTypes: model.DiceTypes}
+				m.On("ListDiceTypes", mock.Anything, expReq).Once().Return(resp, nil)
+			},
 			req: func() *http.Request {
 				body := `{"user_id": "test-user","room_id": "test-room", "dice_type_ids": ["d99999"]}`
 				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
 				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
 				return r
 			},
 			expStatusCode: http.StatusBadRequest,
@@ -293,6 +428,7 @@ func TestAPIV1CreateDiceRoll(t *testing.T) {
 				body := `{"user_id": "test-user","room_id": "test-room", "dice_type_ids": ["d6", "d20"]}`
 				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
 				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
 				return r
 			},
 			expStatusCode: http.StatusInternalServerError,
@@ -316,6 +452,7 @@ func TestAPIV1CreateDiceRoll(t *testing.T) {
 							{ID: "dice-1", Type: model.DieTypeD6, Side: 5},
 							{ID: "dice-2", Type: model.DieTypeD20, Side: 18},
 						},
+						Total: 23,
 					},
 				}
 				m.On("CreateDiceRoll", mock.Anything, expReq).Once().Return(resp, nil)
@@ -324,6 +461,7 @@ func TestAPIV1CreateDiceRoll(t *testing.T) {
 				body := `{"user_id": "test-user","room_id": "test-room", "dice_type_ids": ["d6", "d20"]}`
 				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
 				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
 				return r
 			},
 			expStatusCode: http.StatusCreated,
@@ -343,160 +481,1268 @@ func TestAPIV1CreateDiceRoll(t *testing.T) {
    "dice_type_id": "d20",
    "side": 18
   }
- ]
+ ],
+ "expression": "",
+ "label": "",
+ "modifier": 0,
+ "total": 23,
+ "visibility": "public"
 }`,
 		},
-	}
-
-	for name, test := range tests {
-		t.Run(name, func(t *testing.T) {
-			assert := assert.New(t)
-			require := require.New(t)
-
-			md := &dicemock.Service{}
-			test.mock(md)
-
-			// Prepare.
-			cfg := apiv1.Config{
-				DiceAppService: md,
-				RoomAppService: &roommock.Service{},
-				UserAppService: &usermock.Service{},
-			}
-			h, err := apiv1.New(cfg)
-			require.NoError(err)
-
-			// Execute.
-			w := httptest.NewRecorder()
-			h.ServeHTTP(w, test.req())
-
-			// Check.
-			res := w.Result()
-			gotBody, err := io.ReadAll(res.Body)
-			require.NoError(err)
-			assert.Equal(test.expStatusCode, res.StatusCode)
-			assert.Equal(test.expBody, string(gotBody))
-		})
-	}
-}
 
-func TestAPIV1ListDiceRolls(t *testing.T) {
-	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+		"Having a correct request with custom die types should create the dice roll with the rolled faces.": {
+			mock: func(m *dicemock.Service) {
+				coin := model.CustomDieType{
+					TypeID:    "coin-id",
+					RoomID:    "test-room",
+					TypeName:  "Coin",
+					FaceTable: []model.DieFace{{Label: "Heads", Value: 1}, {Label: "Tails", Value: 0}},
+				}
+				dtResp := &dice.ListDiceTypesResponse{DiceTypes: append(append([]model.DieType{}, model.DiceTypes...), coin)}
+				m.On("ListDiceTypes", mock.Anything, dice.ListDiceTypesRequest{RoomID: "test-room"}).Once().Return(dtResp, nil)
 
-	tests := map[string]struct {
-		mock          func(*dicemock.Service)
-		req           func() *http.Request
-		expStatusCode int
-		expBody       string
-	}{
-		"Having a request without room id should fail.": {
-			mock: func(m *dicemock.Service) {},
+				expReq := dice.CreateDiceRollRequest{
+					UserID: "test-user",
+					RoomID: "test-room",
+					Dice:   []model.DieType{coin, model.DieTypeD6},
+				}
+				resp := &dice.CreateDiceRollResponse{
+					DiceRoll: model.DiceRoll{
+						ID:        "test-dice-roll",
+						CreatedAt: t0,
+						UserID:    "test-user",
+						RoomID:    "test-room",
+						Dice: []model.DieRoll{
+							{ID: "dice-1", Type: coin, Side: 1},
+							{ID: "dice-2", Type: model.DieTypeD6, Side: 3},
+						},
+						Total: 4,
+					},
+				}
+				m.On("CreateDiceRoll", mock.Anything, expReq).Once().Return(resp, nil)
+			},
 			req: func() *http.Request {
-				q := url.Values{}
-				q.Add("room-id", "")
-				q.Add("user-id", "user-id")
-				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/rolls", nil)
-				r.URL.RawQuery = q.Encode()
+				body := `{"user_id": "test-user","room_id": "test-room", "dice_type_ids": ["coin-id", "d6"]}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
 				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
 				return r
 			},
-			expStatusCode: http.StatusBadRequest,
-			expBody:       "{\n \"Code\": 400,\n \"Message\": \"room-id is required\",\n \"Header\": null\n}",
+			expStatusCode: http.StatusCreated,
+			expBody: `{
+ "id": "test-dice-roll",
+ "created_at": "1912-06-23T01:02:03Z",
+ "room_id": "test-room",
+ "user_id": "test-user",
+ "dice": [
+  {
+   "id": "dice-1",
+   "dice_type_id": "coin-id",
+   "side": 1,
+   "face": {
+    "label": "Heads",
+    "value": 1
+   }
+  },
+  {
+   "id": "dice-2",
+   "dice_type_id": "d6",
+   "side": 3
+  }
+ ],
+ "expression": "",
+ "label": "",
+ "modifier": 0,
+ "total": 4,
+ "visibility": "public"
+}`,
 		},
 
-		"Having a wrong order query should fail.": {
-			mock: func(m *dicemock.Service) {},
+		"Having a correct request with arbitrary sided dice should create the dice roll correctly.": {
+			mock: func(m *dicemock.Service) {
+				d7, _ := model.NewDieType(7)
+				expReq := dice.CreateDiceRollRequest{
+					UserID: "test-user",
+					RoomID: "test-room",
+					Dice:   []model.DieType{model.DieTypeD100, d7},
+				}
+				resp := &dice.CreateDiceRollResponse{
+					DiceRoll: model.DiceRoll{
+						ID:        "test-dice-roll",
+						CreatedAt: t0,
+						UserID:    "test-user",
+						RoomID:    "test-room",
+						Dice: []model.DieRoll{
+							{ID: "dice-1", Type: model.DieTypeD100, Side: 42},
+							{ID: "dice-2", Type: d7, Side: 7},
+						},
+						Total: 49,
+					},
+				}
+				m.On("CreateDiceRoll", mock.Anything, expReq).Once().Return(resp, nil)
+			},
 			req: func() *http.Request {
-				q := url.Values{}
-				q.Add("room-id", "room-id")
-				q.Add("user-id", "user-id")
-				q.Add("order", "wrong")
-				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/rolls", nil)
-				r.URL.RawQuery = q.Encode()
+				body := `{"user_id": "test-user","room_id": "test-room", "dice_type_ids": ["d100", "d7"]}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
 				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
 				return r
 			},
-			expStatusCode: http.StatusBadRequest,
-			expBody:       "{\n \"Code\": 400,\n \"Message\": \"pagination order 'wrong' is invalid\",\n \"Header\": null\n}",
+			expStatusCode: http.StatusCreated,
+			expBody: `{
+ "id": "test-dice-roll",
+ "created_at": "1912-06-23T01:02:03Z",
+ "room_id": "test-room",
+ "user_id": "test-user",
+ "dice": [
+  {
+   "id": "dice-1",
+   "dice_type_id": "d100",
+   "side": 42
+  },
+  {
+   "id": "dice-2",
+   "dice_type_id": "d7",
+   "side": 7
+  }
+ ],
+ "expression": "",
+ "label": "",
+ "modifier": 0,
+ "total": 49,
+ "visibility": "public"
+}`,
 		},
 
-		"Having a request with an error form the app service, should fail.": {
-			mock: func(m *dicemock.Service) {
-				m.On("ListDiceRolls", mock.Anything, mock.Anything).Once().Return(nil, errors.New("wanted error"))
-			},
+		"Having a request with dice types and expression should fail.": {
+			mock: func(m *dicemock.Service) {},
 			req: func() *http.Request {
-				q := url.Values{}
-				q.Add("room-id", "room-id")
-				q.Add("user-id", "user-id")
-				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/rolls", nil)
-				r.URL.RawQuery = q.Encode()
+				body := `{"user_id": "test-user","room_id": "test-room", "dice_type_ids": ["d20"], "expression": "1d20+5"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
 				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
 				return r
 			},
-			expStatusCode: http.StatusInternalServerError,
-			expBody:       "{\n \"Code\": 500,\n \"Message\": \"wanted error\",\n \"Header\": null\n}",
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"dice_type_ids and expression can't be used at the same time\",\n \"Header\": null\n}",
 		},
 
-		"Having a request should return dice rolls.": {
+		"Having a correct request with an expression should create the dice roll correctly.": {
 			mock: func(m *dicemock.Service) {
-				expReq := dice.ListDiceRollsRequest{
-					RoomID: "room-id",
-					UserID: "user-id",
-					PageOpts: model.PaginationOpts{
-						Cursor: "threepwood",
-						Order:  model.PaginationOrderAsc,
-					},
+				expReq := dice.CreateDiceRollRequest{
+					UserID:     "test-user",
+					RoomID:     "test-room",
+					Expression: "1d20+5",
 				}
-				resp := &dice.ListDiceRollsResponse{
-					Cursors: model.PaginationCursors{
-						FirstCursor: "first",
-						LastCursor:  "last",
-						HasNext:     true,
-						HasPrevious: true,
-					},
-					DiceRolls: []model.DiceRoll{
-						{
-							ID:        "dr1",
-							CreatedAt: t0,
-							UserID:    "user-1",
-							RoomID:    "room-1",
-							Dice: []model.DieRoll{
-								{ID: "d1", Type: model.DieTypeD6, Side: 4},
-								{ID: "d2", Type: model.DieTypeD6, Side: 5},
-							},
-						},
-						{
-							ID:        "dr2",
-							CreatedAt: t0,
-							UserID:    "user-2",
-							RoomID:    "room-2",
-							Dice: []model.DieRoll{
-								{ID: "d3", Type: model.DieTypeD20, Side: 18},
-							},
+				resp := &dice.CreateDiceRollResponse{
+					DiceRoll: model.DiceRoll{
+						ID:         "test-dice-roll",
+						CreatedAt:  t0,
+						UserID:     "test-user",
+						RoomID:     "test-room",
+						Expression: "1d20+5",
+						Total:      23,
+						Dice: []model.DieRoll{
+							{ID: "dice-1", Type: model.DieTypeD20, Side: 18},
 						},
 					},
 				}
-				m.On("ListDiceRolls", mock.Anything, expReq).Once().Return(resp, nil)
+				m.On("CreateDiceRoll", mock.Anything, expReq).Once().Return(resp, nil)
 			},
 			req: func() *http.Request {
-				q := url.Values{}
-				q.Add("room-id", "room-id")
-				q.Add("user-id", "user-id")
-				q.Add("cursor", "threepwood")
-				q.Add("order", "asc")
-
-				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/rolls", nil)
-				r.URL.RawQuery = q.Encode()
+				body := `{"user_id": "test-user","room_id": "test-room", "expression": "1d20+5"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
 				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
 				return r
 			},
-			expStatusCode: http.StatusOK,
+			expStatusCode: http.StatusCreated,
 			expBody: `{
- "items": [
+ "id": "test-dice-roll",
+ "created_at": "1912-06-23T01:02:03Z",
+ "room_id": "test-room",
+ "user_id": "test-user",
+ "dice": [
   {
-   "id": "dr1",
-   "created_at": "1912-06-23T01:02:03Z",
-   "user_id": "user-1",
-   "room_id": "room-1",
+   "id": "dice-1",
+   "dice_type_id": "d20",
+   "side": 18
+  }
+ ],
+ "expression": "1d20+5",
+ "label": "",
+ "modifier": 0,
+ "total": 23,
+ "visibility": "public"
+}`,
+		},
+
+		"Having a request with modifiers and expression should fail.": {
+			mock: func(m *dicemock.Service) {},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user","room_id": "test-room", "expression": "1d20+5", "modifiers": {"explode": true}}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"modifiers and expression can't be used at the same time\",\n \"Header\": null\n}",
+		},
+
+		"Having a correct request with modifiers should create the dice roll with the dice status.": {
+			mock: func(m *dicemock.Service) {
+				expReq := dice.CreateDiceRollRequest{
+					UserID: "test-user",
+					RoomID: "test-room",
+					Dice:   []model.DieType{model.DieTypeD6, model.DieTypeD6},
+					Modifiers: dice.DiceRollModifiers{
+						RerollBelow: 2,
+						Explode:     true,
+						KeepHighest: 2,
+					},
+				}
+				resp := &dice.CreateDiceRollResponse{
+					DiceRoll: model.DiceRoll{
+						ID:        "test-dice-roll",
+						CreatedAt: t0,
+						UserID:    "test-user",
+						RoomID:    "test-room",
+						Total:     9,
+						Dice: []model.DieRoll{
+							{ID: "dice-1", Type: model.DieTypeD6, Side: 1, Status: model.DieRollStatusRerolled},
+							{ID: "dice-2", Type: model.DieTypeD6, Side: 6, Status: model.DieRollStatusExploded},
+							{ID: "dice-3", Type: model.DieTypeD6, Side: 2, Status: model.DieRollStatusDropped},
+							{ID: "dice-4", Type: model.DieTypeD6, Side: 3},
+						},
+					},
+				}
+				m.On("CreateDiceRoll", mock.Anything, expReq).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user","room_id": "test-room", "dice_type_ids": ["d6", "d6"], "modifiers": {"reroll_below": 2, "explode": true, "keep_highest": 2}}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
+				return r
+			},
+			expStatusCode: http.StatusCreated,
+			expBody: `{
+ "id": "test-dice-roll",
+ "created_at": "1912-06-23T01:02:03Z",
+ "room_id": "test-room",
+ "user_id": "test-user",
+ "dice": [
+  {
+   "id": "dice-1",
+   "dice_type_id": "d6",
+   "side": 1,
+   "status": "rerolled"
+  },
+  {
+   "id": "dice-2",
+   "dice_type_id": "d6",
+   "side": 6,
+   "status": "exploded"
+  },
+  {
+   "id": "dice-3",
+   "dice_type_id": "d6",
+   "side": 2,
+   "status": "dropped"
+  },
+  {
+   "id": "dice-4",
+   "dice_type_id": "d6",
+   "side": 3
+  }
+ ],
+ "expression": "",
+ "label": "",
+ "modifier": 0,
+ "total": 9,
+ "visibility": "public"
+}`,
+		},
+
+		"Having a request with pool and expression should fail.": {
+			mock: func(m *dicemock.Service) {},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user","room_id": "test-room", "expression": "4d10", "pool": {"target": 8}}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"pool and expression can't be used at the same time\",\n \"Header\": null\n}",
+		},
+
+		"Having a correct request with a pool should create the dice roll with the pool outcome.": {
+			mock: func(m *dicemock.Service) {
+				expReq := dice.CreateDiceRollRequest{
+					UserID: "test-user",
+					RoomID: "test-room",
+					Dice:   []model.DieType{model.DieTypeD10, model.DieTypeD10, model.DieTypeD10},
+					Pool: dice.DicePool{
+						Target:     8,
+						OnesCancel: true,
+						Botch:      true,
+					},
+				}
+				resp := &dice.CreateDiceRollResponse{
+					DiceRoll: model.DiceRoll{
+						ID:        "test-dice-roll",
+						CreatedAt: t0,
+						UserID:    "test-user",
+						RoomID:    "test-room",
+						Total:     1,
+						Dice: []model.DieRoll{
+							{ID: "dice-1", Type: model.DieTypeD10, Side: 9},
+							{ID: "dice-2", Type: model.DieTypeD10, Side: 10},
+							{ID: "dice-3", Type: model.DieTypeD10, Side: 1},
+						},
+						Pool: &model.DicePoolResult{Successes: 1, Outcome: model.DicePoolOutcomeSuccess},
+					},
+				}
+				m.On("CreateDiceRoll", mock.Anything, expReq).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user","room_id": "test-room", "dice_type_ids": ["d10", "d10", "d10"], "pool": {"target": 8, "ones_cancel": true, "botch": true}}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
+				return r
+			},
+			expStatusCode: http.StatusCreated,
+			expBody: `{
+ "id": "test-dice-roll",
+ "created_at": "1912-06-23T01:02:03Z",
+ "room_id": "test-room",
+ "user_id": "test-user",
+ "dice": [
+  {
+   "id": "dice-1",
+   "dice_type_id": "d10",
+   "side": 9
+  },
+  {
+   "id": "dice-2",
+   "dice_type_id": "d10",
+   "side": 10
+  },
+  {
+   "id": "dice-3",
+   "dice_type_id": "d10",
+   "side": 1
+  }
+ ],
+ "expression": "",
+ "label": "",
+ "modifier": 0,
+ "total": 1,
+ "pool": {
+  "successes": 1,
+  "outcome": "success"
+ },
+ "visibility": "public"
+}`,
+		},
+
+		"Having a request with an invalid check comparison should fail.": {
+			mock: func(m *dicemock.Service) {},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user","room_id": "test-room", "expression": "1d20+5", "check": {"target": 15, "comparison": "=="}}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"check comparison '==' is invalid\",\n \"Header\": null\n}",
+		},
+
+		"Having a correct request with a check should create the dice roll with the check outcome.": {
+			mock: func(m *dicemock.Service) {
+				expReq := dice.CreateDiceRollRequest{
+					UserID:     "test-user",
+					RoomID:     "test-room",
+					Expression: "1d20+5",
+					Check:      dice.DiceCheck{Target: 15, Comparison: model.DiceRollCheckComparisonGreaterOrEqual},
+				}
+				resp := &dice.CreateDiceRollResponse{
+					DiceRoll: model.DiceRoll{
+						ID:         "test-dice-roll",
+						CreatedAt:  t0,
+						UserID:     "test-user",
+						RoomID:     "test-room",
+						Expression: "1d20+5",
+						Total:      25,
+						Dice: []model.DieRoll{
+							{ID: "dice-1", Type: model.DieTypeD20, Side: 20},
+						},
+						Check: &model.DiceRollCheck{Target: 15, Comparison: model.DiceRollCheckComparisonGreaterOrEqual, Margin: 10, Outcome: model.DiceRollCheckOutcomeCriticalSuccess},
+					},
+				}
+				m.On("CreateDiceRoll", mock.Anything, expReq).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user","room_id": "test-room", "expression": "1d20+5", "check": {"target": 15}}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
+				return r
+			},
+			expStatusCode: http.StatusCreated,
+			expBody: `{
+ "id": "test-dice-roll",
+ "created_at": "1912-06-23T01:02:03Z",
+ "room_id": "test-room",
+ "user_id": "test-user",
+ "dice": [
+  {
+   "id": "dice-1",
+   "dice_type_id": "d20",
+   "side": 20
+  }
+ ],
+ "expression": "1d20+5",
+ "label": "",
+ "modifier": 0,
+ "total": 25,
+ "check": {
+  "target": 15,
+  "comparison": "\u003e=",
+  "margin": 10,
+  "success": true,
+  "outcome": "critical_success"
+ },
+ "visibility": "public"
+}`,
+		},
+
+		"Having a request with modifier and expression should fail.": {
+			mock: func(m *dicemock.Service) {},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user","room_id": "test-room", "expression": "1d20", "modifier": 5}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"modifier and expression can't be used at the same time\",\n \"Header\": null\n}",
+		},
+
+		"Having a correct request with a label and a modifier should create the dice roll with the label, modifier and total.": {
+			mock: func(m *dicemock.Service) {
+				expReq := dice.CreateDiceRollRequest{
+					UserID:   "test-user",
+					RoomID:   "test-room",
+					Dice:     []model.DieType{model.DieTypeD20},
+					Label:    "Attack",
+					Modifier: 5,
+				}
+				resp := &dice.CreateDiceRollResponse{
+					DiceRoll: model.DiceRoll{
+						ID:        "test-dice-roll",
+						CreatedAt: t0,
+						UserID:    "test-user",
+						RoomID:    "test-room",
+						Label:     "Attack",
+						Modifier:  5,
+						Total:     16,
+						Dice: []model.DieRoll{
+							{ID: "dice-1", Type: model.DieTypeD20, Side: 11},
+						},
+					},
+				}
+				m.On("CreateDiceRoll", mock.Anything, expReq).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user","room_id": "test-room", "dice_type_ids": ["d20"], "modifier": 5, "label": "Attack"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
+				return r
+			},
+			expStatusCode: http.StatusCreated,
+			expBody: `{
+ "id": "test-dice-roll",
+ "created_at": "1912-06-23T01:02:03Z",
+ "room_id": "test-room",
+ "user_id": "test-user",
+ "dice": [
+  {
+   "id": "dice-1",
+   "dice_type_id": "d20",
+   "side": 11
+  }
+ ],
+ "expression": "",
+ "label": "Attack",
+ "modifier": 5,
+ "total": 16,
+ "visibility": "public"
+}`,
+		},
+
+		"Having a request with an invalid visibility should fail.": {
+			mock: func(m *dicemock.Service) {},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user","room_id": "test-room", "dice_type_ids": ["d20"], "visibility": "secret"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"visibility 'secret' is invalid\",\n \"Header\": null\n}",
+		},
+
+		"Having a correct request with a whisper visibility should create the whispered dice roll.": {
+			mock: func(m *dicemock.Service) {
+				expReq := dice.CreateDiceRollRequest{
+					UserID:         "test-user",
+					RoomID:         "test-room",
+					Dice:           []model.DieType{model.DieTypeD20},
+					Visibility:     model.DiceRollVisibilityWhisper,
+					WhisperUserIDs: []string{"user-1", "user-2"},
+				}
+				resp := &dice.CreateDiceRollResponse{
+					DiceRoll: model.DiceRoll{
+						ID:        "test-dice-roll",
+						CreatedAt: t0,
+						UserID:    "test-user",
+						RoomID:    "test-room",
+						Total:     11,
+						Dice: []model.DieRoll{
+							{ID: "dice-1", Type: model.DieTypeD20, Side: 11},
+						},
+						Visibility:     model.DiceRollVisibilityWhisper,
+						WhisperUserIDs: []string{"user-1", "user-2"},
+					},
+				}
+				m.On("CreateDiceRoll", mock.Anything, expReq).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user","room_id": "test-room", "dice_type_ids": ["d20"], "visibility": "whisper", "whisper_user_ids": ["user-1", "user-2"]}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
+				return r
+			},
+			expStatusCode: http.StatusCreated,
+			expBody: `{
+ "id": "test-dice-roll",
+ "created_at": "1912-06-23T01:02:03Z",
+ "room_id": "test-room",
+ "user_id": "test-user",
+ "dice": [
+  {
+   "id": "dice-1",
+   "dice_type_id": "d20",
+   "side": 11
+  }
+ ],
+ "expression": "",
+ "label": "",
+ "modifier": 0,
+ "total": 11,
+ "visibility": "whisper",
+ "whisper_user_ids": [
+  "user-1",
+  "user-2"
+ ]
+}`,
+		},
+
+		"Having a request with an invalid advantage should fail.": {
+			mock: func(m *dicemock.Service) {},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user","room_id": "test-room", "dice_type_ids": ["d20"], "advantage": "lucky"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"advantage 'lucky' is invalid\",\n \"Header\": null\n}",
+		},
+
+		"Having a request with advantage and expression should fail.": {
+			mock: func(m *dicemock.Service) {},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user","room_id": "test-room", "expression": "1d20", "advantage": "advantage"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"advantage and expression can't be used at the same time\",\n \"Header\": null\n}",
+		},
+
+		"Having a correct request with disadvantage should create the dice roll with the dropped D20.": {
+			mock: func(m *dicemock.Service) {
+				expReq := dice.CreateDiceRollRequest{
+					UserID:    "test-user",
+					RoomID:    "test-room",
+					Dice:      []model.DieType{model.DieTypeD20},
+					Advantage: dice.AdvantageModeDisadvantage,
+				}
+				resp := &dice.CreateDiceRollResponse{
+					DiceRoll: model.DiceRoll{
+						ID:        "test-dice-roll",
+						CreatedAt: t0,
+						UserID:    "test-user",
+						RoomID:    "test-room",
+						Total:     4,
+						Dice: []model.DieRoll{
+							{ID: "dice-1", Type: model.DieTypeD20, Side: 4},
+							{ID: "dice-2", Type: model.DieTypeD20, Side: 17, Status: model.DieRollStatusDropped},
+						},
+					},
+				}
+				m.On("CreateDiceRoll", mock.Anything, expReq).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user","room_id": "test-room", "dice_type_ids": ["d20"], "advantage": "disadvantage"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
+				return r
+			},
+			expStatusCode: http.StatusCreated,
+			expBody: `{
+ "id": "test-dice-roll",
+ "created_at": "1912-06-23T01:02:03Z",
+ "room_id": "test-room",
+ "user_id": "test-user",
+ "dice": [
+  {
+   "id": "dice-1",
+   "dice_type_id": "d20",
+   "side": 4
+  },
+  {
+   "id": "dice-2",
+   "dice_type_id": "d20",
+   "side": 17,
+   "status": "dropped"
+  }
+ ],
+ "expression": "",
+ "label": "",
+ "modifier": 0,
+ "total": 4,
+ "visibility": "public"
+}`,
+		},
+
+		"Having a correct request with a client seed should create the provably fair dice roll correctly.": {
+			mock: func(m *dicemock.Service) {
+				expReq := dice.CreateDiceRollRequest{
+					UserID:     "test-user",
+					RoomID:     "test-room",
+					Dice:       []model.DieType{model.DieTypeD20},
+					ClientSeed: "lucky",
+				}
+				resp := &dice.CreateDiceRollResponse{
+					DiceRoll: model.DiceRoll{
+						ID:        "test-dice-roll",
+						CreatedAt: t0,
+						UserID:    "test-user",
+						RoomID:    "test-room",
+						Total:     18,
+						Dice: []model.DieRoll{
+							{ID: "dice-1", Type: model.DieTypeD20, Side: 18},
+						},
+						Proof: &model.DiceRollProof{
+							ServerSeedID:   "seed-id",
+							ServerSeedHash: "seed-hash",
+							ClientSeed:     "lucky",
+							Nonce:          7,
+						},
+					},
+				}
+				m.On("CreateDiceRoll", mock.Anything, expReq).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user","room_id": "test-room", "dice_type_ids": ["d20"], "client_seed": "lucky"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
+				return r
+			},
+			expStatusCode: http.StatusCreated,
+			expBody: `{
+ "id": "test-dice-roll",
+ "created_at": "1912-06-23T01:02:03Z",
+ "room_id": "test-room",
+ "user_id": "test-user",
+ "dice": [
+  {
+   "id": "dice-1",
+   "dice_type_id": "d20",
+   "side": 18
+  }
+ ],
+ "expression": "",
+ "label": "",
+ "modifier": 0,
+ "total": 18,
+ "proof": {
+  "server_seed_id": "seed-id",
+  "server_seed_hash": "seed-hash",
+  "client_seed": "lucky",
+  "nonce": 7
+ },
+ "visibility": "public"
+}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			md := &dicemock.Service{}
+			test.mock(md)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       md,
+				RoomAppService:       &roommock.Service{},
+				UserAppService:       newSessionUserAppService(),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+		})
+	}
+}
+
+func TestAPIV1CreateDiceRolls(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+
+	tests := map[string]struct {
+		mock          func(*dicemock.Service)
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Having a request without items should fail.": {
+			mock: func(m *dicemock.Service) {},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user", "room_id": "test-room", "items": []}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls:batch", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"items are required\",\n \"Header\": null\n}",
+		},
+
+		"Having a request with a user that is not the session user should fail.": {
+			mock: func(m *dicemock.Service) {},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user", "room_id": "test-room", "items": [{"expression": "1d20+5"}]}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls:batch", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer other-user")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"request user is not the session user: not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Having a request with an item of another room should fail.": {
+			mock: func(m *dicemock.Service) {},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user", "room_id": "test-room", "items": [{"expression": "1d20+5"}, {"room_id": "other-room", "expression": "1d20+5"}]}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls:batch", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"items[1]: room_id must be the request one\",\n \"Header\": null\n}",
+		},
+
+		"Having a request with an invalid item should fail.": {
+			mock: func(m *dicemock.Service) {},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user", "room_id": "test-room", "items": [{"expression": "1d20+5"}, {}]}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls:batch", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"items[1]: dice_type_ids are required\",\n \"Header\": null\n}",
+		},
+
+		"Having a rate limited batch should fail.": {
+			mock: func(m *dicemock.Service) {
+				m.On("CreateDiceRolls", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error: %w", internalerrors.ErrRateLimited))
+			},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user", "room_id": "test-room", "items": [{"expression": "1d20+5"}]}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls:batch", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
+				return r
+			},
+			expStatusCode: http.StatusTooManyRequests,
+			expBody:       "{\n \"Code\": 429,\n \"Message\": \"wanted error: rate limited\",\n \"Header\": null\n}",
+		},
+
+		"Having a correct request should create all the dice rolls in order.": {
+			mock: func(m *dicemock.Service) {
+				expReq := dice.CreateDiceRollsRequest{Items: []dice.CreateDiceRollRequest{
+					{UserID: "test-user", RoomID: "test-room", Expression: "1d20+5", Label: "Attack"},
+					{UserID: "test-user", RoomID: "test-room", Dice: []model.DieType{model.DieTypeD20}, Modifier: 5, Label: "Attack"},
+				}}
+				resp := &dice.CreateDiceRollsResponse{DiceRolls: []model.DiceRoll{
+					{
+						ID:         "test-dice-roll-1",
+						CreatedAt:  t0,
+						UserID:     "test-user",
+						RoomID:     "test-room",
+						Expression: "1d20+5",
+						Label:      "Attack",
+						Dice:       []model.DieRoll{{ID: "dice-1", Type: model.DieTypeD20, Side: 3}},
+						Total:      8,
+					},
+					{
+						ID:        "test-dice-roll-2",
+						CreatedAt: t0,
+						UserID:    "test-user",
+						RoomID:    "test-room",
+						Label:     "Attack",
+						Modifier:  5,
+						Dice:      []model.DieRoll{{ID: "dice-2", Type: model.DieTypeD20, Side: 18}},
+						Total:     23,
+					},
+				}}
+				m.On("CreateDiceRolls", mock.Anything, expReq).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user", "room_id": "test-room", "items": [{"expression": "1d20+5", "label": "Attack"}, {"dice_type_ids": ["d20"], "modifier": 5, "label": "Attack"}]}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls:batch", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
+				return r
+			},
+			expStatusCode: http.StatusCreated,
+			expBody: `{
+ "items": [
+  {
+   "id": "test-dice-roll-1",
+   "created_at": "1912-06-23T01:02:03Z",
+   "room_id": "test-room",
+   "user_id": "test-user",
+   "dice": [
+    {
+     "id": "dice-1",
+     "dice_type_id": "d20",
+     "side": 3
+    }
+   ],
+   "expression": "1d20+5",
+   "label": "Attack",
+   "modifier": 0,
+   "total": 8,
+   "visibility": "public"
+  },
+  {
+   "id": "test-dice-roll-2",
+   "created_at": "1912-06-23T01:02:03Z",
+   "room_id": "test-room",
+   "user_id": "test-user",
+   "dice": [
+    {
+     "id": "dice-2",
+     "dice_type_id": "d20",
+     "side": 18
+    }
+   ],
+   "expression": "",
+   "label": "Attack",
+   "modifier": 5,
+   "total": 23,
+   "visibility": "public"
+  }
+ ]
+}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			md := &dicemock.Service{}
+			test.mock(md)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       md,
+				RoomAppService:       &roommock.Service{},
+				UserAppService:       newSessionUserAppService(),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+		})
+	}
+}
+
+func TestAPIV1CreateCustomDieType(t *testing.T) {
+	tests := map[string]struct {
+		mock          func(*dicemock.Service)
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Having a request without room ID should fail.": {
+			mock: func(m *dicemock.Service) {},
+			req: func() *http.Request {
+				body := `{"room_id": "", "name": "Coin", "faces": [{"label": "Heads", "value": 1}, {"label": "Tails"}]}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/types", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-id")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"room_id is required\",\n \"Header\": null\n}",
+		},
+
+		"Having a request without session should fail.": {
+			mock: func(m *dicemock.Service) {},
+			req: func() *http.Request {
+				body := `{"room_id": "test-room", "name": "Coin", "faces": [{"label": "Heads", "value": 1}, {"label": "Tails"}]}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/types", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"session token is required: not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Having a request without faces should fail.": {
+			mock: func(m *dicemock.Service) {},
+			req: func() *http.Request {
+				body := `{"room_id": "test-room", "name": "Coin"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/types", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-id")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"faces are required\",\n \"Header\": null\n}",
+		},
+
+		"Having a not valid die type on the application service should fail.": {
+			mock: func(m *dicemock.Service) {
+				m.On("CreateCustomDieType", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error: %w", internalerrors.ErrNotValid))
+			},
+			req: func() *http.Request {
+				body := `{"room_id": "test-room", "name": "Coin", "faces": [{"label": "Heads", "value": 1}]}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/types", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-id")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"wanted error: not valid\",\n \"Header\": null\n}",
+		},
+
+		"Having a correct request should create the custom die type.": {
+			mock: func(m *dicemock.Service) {
+				faces := []model.DieFace{{Label: "Heads", Value: 1}, {Label: "Tails", Value: 0}}
+				expReq := dice.CreateCustomDieTypeRequest{
+					RoomID: "test-room",
+					Name:   "Coin",
+					Faces:  faces,
+				}
+				resp := &dice.CreateCustomDieTypeResponse{
+					DieType: model.CustomDieType{
+						TypeID:    "coin-id",
+						RoomID:    "test-room",
+						TypeName:  "Coin",
+						FaceTable: faces,
+					},
+				}
+				m.On("CreateCustomDieType", mock.Anything, expReq).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				body := `{"room_id": "test-room", "name": "Coin", "faces": [{"label": "Heads", "value": 1}, {"label": "Tails"}]}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/types", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-id")
+				return r
+			},
+			expStatusCode: http.StatusCreated,
+			expBody: `{
+ "id": "coin-id",
+ "name": "Coin",
+ "sides": 2,
+ "faces": [
+  {
+   "label": "Heads",
+   "value": 1
+  },
+  {
+   "label": "Tails",
+   "value": 0
+  }
+ ]
+}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			md := &dicemock.Service{}
+			test.mock(md)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       md,
+				RoomAppService:       &roommock.Service{},
+				UserAppService:       newSessionUserAppService(),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+		})
+	}
+}
+
+func TestAPIV1ListDiceRolls(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+
+	tests := map[string]struct {
+		mock          func(*dicemock.Service, *usermock.Service)
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Having a request without room id should fail.": {
+			mock: func(m *dicemock.Service, mu *usermock.Service) {},
+			req: func() *http.Request {
+				q := url.Values{}
+				q.Add("room-id", "")
+				q.Add("user-id", "user-id")
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/rolls", nil)
+				r.URL.RawQuery = q.Encode()
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"room-id is required\",\n \"Header\": null\n}",
+		},
+
+		"Having a wrong order query should fail.": {
+			mock: func(m *dicemock.Service, mu *usermock.Service) {},
+			req: func() *http.Request {
+				q := url.Values{}
+				q.Add("room-id", "room-id")
+				q.Add("user-id", "user-id")
+				q.Add("order", "wrong")
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/rolls", nil)
+				r.URL.RawQuery = q.Encode()
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"pagination order 'wrong' is invalid\",\n \"Header\": null\n}",
+		},
+
+		"Having a request with an error form the app service, should fail.": {
+			mock: func(m *dicemock.Service, mu *usermock.Service) {
+				m.On("ListDiceRolls", mock.Anything, mock.Anything).Once().Return(nil, errors.New("wanted error"))
+			},
+			req: func() *http.Request {
+				q := url.Values{}
+				q.Add("room-id", "room-id")
+				q.Add("user-id", "user-id")
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/rolls", nil)
+				r.URL.RawQuery = q.Encode()
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusInternalServerError,
+			expBody:       "{\n \"Code\": 500,\n \"Message\": \"wanted error\",\n \"Header\": null\n}",
+		},
+
+		"Having a request with an invalid session should fail.": {
+			mock: func(m *dicemock.Service, mu *usermock.Service) {
+				expReq := user.AuthenticateUserRequest{SessionToken: "wrong-token", RoomID: "room-id"}
+				mu.On("AuthenticateUser", mock.Anything, expReq).Once().Return(nil, internalerrors.ErrNotAllowed)
+			},
+			req: func() *http.Request {
+				q := url.Values{}
+				q.Add("room-id", "room-id")
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/rolls", nil)
+				r.URL.RawQuery = q.Encode()
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer wrong-token")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Having a request without session, should list the dice rolls as an anonymous viewer, ignoring the viewer query param.": {
+			mock: func(m *dicemock.Service, mu *usermock.Service) {
+				expReq := dice.ListDiceRollsRequest{RoomID: "room-id"}
+				m.On("ListDiceRolls", mock.Anything, expReq).Once().Return(&dice.ListDiceRollsResponse{}, nil)
+			},
+			req: func() *http.Request {
+				q := url.Values{}
+				q.Add("room-id", "room-id")
+				q.Add("viewer-user-id", "gm-id")
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/rolls", nil)
+				r.URL.RawQuery = q.Encode()
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody:       "{\n \"items\": [],\n \"metadata\": {\n  \"first_cursor\": \"\",\n  \"last_cursor\": \"\",\n  \"has_next\": false,\n  \"has_previous\": false\n }\n}",
+		},
+
+		"Having a request should return dice rolls.": {
+			mock: func(m *dicemock.Service, mu *usermock.Service) {
+				authReq := user.AuthenticateUserRequest{SessionToken: "viewer-token", RoomID: "room-id"}
+				mu.On("AuthenticateUser", mock.Anything, authReq).Once().Return(&user.AuthenticateUserResponse{User: model.User{ID: "viewer-id", RoomID: "room-id"}}, nil)
+
+				expReq := dice.ListDiceRollsRequest{
+					RoomID:       "room-id",
+					UserID:       "user-id",
+					ViewerUserID: "viewer-id",
+					PageOpts: model.PaginationOpts{
+						Cursor: "threepwood",
+						Order:  model.PaginationOrderAsc,
+					},
+				}
+				resp := &dice.ListDiceRollsResponse{
+					Cursors: model.PaginationCursors{
+						FirstCursor: "first",
+						LastCursor:  "last",
+						HasNext:     true,
+						HasPrevious: true,
+					},
+					DiceRolls: []model.DiceRoll{
+						{
+							ID:        "dr1",
+							CreatedAt: t0,
+							UserID:    "user-1",
+							RoomID:    "room-1",
+							Dice: []model.DieRoll{
+								{ID: "d1", Type: model.DieTypeD6, Side: 4},
+								{ID: "d2", Type: model.DieTypeD6, Side: 5},
+							},
+							Total: 9,
+						},
+						{
+							ID:         "dr2",
+							CreatedAt:  t0,
+							UserID:     "user-2",
+							RoomID:     "room-2",
+							Expression: "1d20+2",
+							Total:      20,
+							Dice: []model.DieRoll{
+								{ID: "d3", Type: model.DieTypeD20, Side: 18},
+							},
+						},
+						{
+							ID:         "dr3",
+							CreatedAt:  t0,
+							UserID:     "user-2",
+							RoomID:     "room-2",
+							Dice:       []model.DieRoll{},
+							Visibility: model.DiceRollVisibilityGameMaster,
+							Hidden:     true,
+						},
+					},
+				}
+				m.On("ListDiceRolls", mock.Anything, expReq).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				q := url.Values{}
+				q.Add("room-id", "room-id")
+				q.Add("user-id", "user-id")
+				q.Add("cursor", "threepwood")
+				q.Add("order", "asc")
+
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/rolls", nil)
+				r.URL.RawQuery = q.Encode()
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer viewer-token")
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody: `{
+ "items": [
+  {
+   "id": "dr1",
+   "created_at": "1912-06-23T01:02:03Z",
+   "user_id": "user-1",
+   "room_id": "room-1",
    "dice": [
     {
      "id": "d1",
@@ -508,28 +1754,3175 @@ func TestAPIV1ListDiceRolls(t *testing.T) {
      "type_id": "d6",
      "side": 5
     }
-   ]
+   ],
+   "expression": "",
+   "label": "",
+   "modifier": 0,
+   "total": 9,
+   "visibility": "public"
+  },
+  {
+   "id": "dr2",
+   "created_at": "1912-06-23T01:02:03Z",
+   "user_id": "user-2",
+   "room_id": "room-2",
+   "dice": [
+    {
+     "id": "d3",
+     "type_id": "d20",
+     "side": 18
+    }
+   ],
+   "expression": "1d20+2",
+   "label": "",
+   "modifier": 0,
+   "total": 20,
+   "visibility": "public"
+  },
+  {
+   "id": "dr3",
+   "created_at": "1912-06-23T01:02:03Z",
+   "user_id": "user-2",
+   "room_id": "room-2",
+   "dice": [],
+   "expression": "",
+   "label": "",
+   "modifier": 0,
+   "total": 0,
+   "visibility": "game_master",
+   "hidden": true
+  }
+ ],
+ "metadata": {
+  "first_cursor": "first",
+  "last_cursor": "last",
+  "has_next": true,
+  "has_previous": true
+ }
+}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			md := &dicemock.Service{}
+			mu := &usermock.Service{}
+			test.mock(md, mu)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       md,
+				RoomAppService:       newOpenRoomAppService(),
+				UserAppService:       mu,
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+		})
+	}
+}
+
+func TestAPIV1VerifyDiceRoll(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+
+	tests := map[string]struct {
+		mock          func(*dicemock.Service, *usermock.Service)
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Having a dice roll that can't be verified should fail.": {
+			mock: func(m *dicemock.Service, mu *usermock.Service) {
+				m.On("VerifyDiceRoll", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("not revealed: %w", internalerrors.ErrNotValid))
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/rolls/test-dice-roll/verify", nil)
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"not revealed: not valid\",\n \"Header\": null\n}",
+		},
+
+		"Having a dice roll that the session user can't see should be forbidden.": {
+			mock: func(m *dicemock.Service, mu *usermock.Service) {
+				mu.On("AuthenticateUser", mock.Anything, user.AuthenticateUserRequest{SessionToken: "test-token"}).Once().Return(&user.AuthenticateUserResponse{User: model.User{ID: "test-user", RoomID: "test-room"}}, nil)
+				expReq := dice.VerifyDiceRollRequest{DiceRollID: "test-dice-roll", ViewerUserID: "test-user"}
+				m.On("VerifyDiceRoll", mock.Anything, expReq).Once().Return(nil, fmt.Errorf("not visible: %w", internalerrors.ErrNotAllowed))
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/rolls/test-dice-roll/verify", nil)
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-token")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"not visible: not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Having an invalid session should be forbidden.": {
+			mock: func(m *dicemock.Service, mu *usermock.Service) {
+				mu.On("AuthenticateUser", mock.Anything, user.AuthenticateUserRequest{SessionToken: "test-token"}).Once().Return(nil, fmt.Errorf("invalid: %w", internalerrors.ErrNotAllowed))
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/rolls/test-dice-roll/verify", nil)
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-token")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"invalid: not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Having a correct request should verify the dice roll.": {
+			mock: func(m *dicemock.Service, mu *usermock.Service) {
+				expReq := dice.VerifyDiceRollRequest{DiceRollID: "test-dice-roll"}
+				resp := &dice.VerifyDiceRollResponse{
+					DiceRoll: model.DiceRoll{
+						ID:        "test-dice-roll",
+						CreatedAt: t0,
+						UserID:    "test-user",
+						RoomID:    "test-room",
+						Total:     18,
+						Dice: []model.DieRoll{
+							{ID: "dice-1", Type: model.DieTypeD20, Side: 18},
+						},
+						Proof: &model.DiceRollProof{
+							ServerSeedID:   "seed-id",
+							ServerSeedHash: "seed-hash",
+							ClientSeed:     "lucky",
+							Nonce:          7,
+						},
+					},
+					ServerSeed: model.ServerSeed{
+						ID:        "seed-id",
+						RoomID:    "test-room",
+						CreatedAt: t0,
+						Seed:      "seed",
+						Hash:      "seed-hash",
+						Nonce:     9,
+						Revealed:  true,
+					},
+					Dice: []model.DieRoll{
+						{ID: "dice-1", Type: model.DieTypeD20, Side: 18},
+					},
+					Valid: true,
+				}
+				m.On("VerifyDiceRoll", mock.Anything, expReq).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/rolls/test-dice-roll/verify", nil)
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody: `{
+ "dice_roll": {
+  "id": "test-dice-roll",
+  "created_at": "1912-06-23T01:02:03Z",
+  "user_id": "test-user",
+  "room_id": "test-room",
+  "dice": [
+   {
+    "id": "dice-1",
+    "type_id": "d20",
+    "side": 18
+   }
+  ],
+  "expression": "",
+  "label": "",
+  "modifier": 0,
+  "total": 18,
+  "proof": {
+   "server_seed_id": "seed-id",
+   "server_seed_hash": "seed-hash",
+   "client_seed": "lucky",
+   "nonce": 7
+  },
+  "visibility": "public"
+ },
+ "server_seed": {
+  "id": "seed-id",
+  "room_id": "test-room",
+  "created_at": "1912-06-23T01:02:03Z",
+  "seed": "seed",
+  "hash": "seed-hash",
+  "nonce": 9,
+  "revealed": true
+ },
+ "dice": [
+  {
+   "id": "dice-1",
+   "type_id": "d20",
+   "side": 18
+  }
+ ],
+ "valid": true
+}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			md := &dicemock.Service{}
+			mu := &usermock.Service{}
+			test.mock(md, mu)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       md,
+				RoomAppService:       newOpenRoomAppService(),
+				UserAppService:       mu,
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+			md.AssertExpectations(t)
+			mu.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAPIV1RerollDice(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+
+	tests := map[string]struct {
+		mock          func(*dicemock.Service)
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Having a request without dice should fail.": {
+			mock: func(m *dicemock.Service) {},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls/test-dice-roll/reroll", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"die_ids are required\",\n \"Header\": null\n}",
+		},
+
+		"Having a request with a user that is not the session user should fail.": {
+			mock: func(m *dicemock.Service) {},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user", "die_ids": ["dice-1"]}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls/test-dice-roll/reroll", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer other-user")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"request user is not the session user: not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Having an error while rerolling the dice should fail.": {
+			mock: func(m *dicemock.Service) {
+				m.On("RerollDice", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("not the owner: %w", internalerrors.ErrNotValid))
+			},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user", "die_ids": ["dice-1"]}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls/test-dice-roll/reroll", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"not the owner: not valid\",\n \"Header\": null\n}",
+		},
+
+		"Having a correct request should reroll the dice.": {
+			mock: func(m *dicemock.Service) {
+				expReq := dice.RerollDiceRequest{DiceRollID: "test-dice-roll", UserID: "test-user", DieIDs: []string{"dice-1"}}
+				resp := &dice.RerollDiceResponse{
+					DiceRoll: model.DiceRoll{
+						ID:        "test-reroll",
+						CreatedAt: t0,
+						UserID:    "test-user",
+						RoomID:    "test-room",
+						Label:     "Lucky",
+						Total:     17,
+						Dice: []model.DieRoll{
+							{ID: "dice-2", Type: model.DieTypeD20, Side: 1, Status: model.DieRollStatusRerolled},
+							{ID: "dice-3", Type: model.DieTypeD20, Side: 17},
+						},
+						ParentID: "test-dice-roll",
+					},
+				}
+				m.On("RerollDice", mock.Anything, expReq).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				body := `{"user_id": "test-user", "die_ids": ["dice-1"]}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/dice/rolls/test-dice-roll/reroll", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-user")
+				return r
+			},
+			expStatusCode: http.StatusCreated,
+			expBody: `{
+ "id": "test-reroll",
+ "created_at": "1912-06-23T01:02:03Z",
+ "user_id": "test-user",
+ "room_id": "test-room",
+ "dice": [
+  {
+   "id": "dice-2",
+   "type_id": "d20",
+   "side": 1,
+   "status": "rerolled"
+  },
+  {
+   "id": "dice-3",
+   "type_id": "d20",
+   "side": 17
+  }
+ ],
+ "expression": "",
+ "label": "Lucky",
+ "modifier": 0,
+ "total": 17,
+ "visibility": "public",
+ "parent_id": "test-dice-roll"
+}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			md := &dicemock.Service{}
+			test.mock(md)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       md,
+				RoomAppService:       &roommock.Service{},
+				UserAppService:       newSessionUserAppService(),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+		})
+	}
+}
+
+func TestAPIV1GetDiceStats(t *testing.T) {
+	tests := map[string]struct {
+		mock          func(*statsmock.Service)
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Having a request without room id should fail.": {
+			mock: func(m *statsmock.Service) {},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/stats", nil)
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"room-id is required\",\n \"Header\": null\n}",
+		},
+
+		"Having a request of a user from another room should fail.": {
+			mock: func(m *statsmock.Service) {
+				m.On("GetDiceStats", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error: %w", internalerrors.ErrNotValid))
+			},
+			req: func() *http.Request {
+				q := url.Values{}
+				q.Add("room-id", "room-id")
+				q.Add("user-id", "user-id")
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/stats", nil)
+				r.URL.RawQuery = q.Encode()
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"wanted error: not valid\",\n \"Header\": null\n}",
+		},
+
+		"Having a request should return the dice stats.": {
+			mock: func(m *statsmock.Service) {
+				exp := stats.GetDiceStatsRequest{RoomID: "room-id", UserID: "user-id"}
+				resp := &stats.GetDiceStatsResponse{
+					DieTypes: []stats.DieTypeStats{
+						{
+							DieType:             model.DieTypeD4,
+							Rolls:               8,
+							Distribution:        []uint{4, 2, 1, 1},
+							Mean:                1.875,
+							ExpectedMean:        2.5,
+							ChiSquare:           3,
+							DegreesOfFreedom:    3,
+							PValue:              0.25,
+							LongestRepeatStreak: 3,
+							LongestHighStreak:   1,
+							LongestLowStreak:    5,
+						},
+					},
+				}
+				m.On("GetDiceStats", mock.Anything, exp).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				q := url.Values{}
+				q.Add("room-id", "room-id")
+				q.Add("user-id", "user-id")
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/stats", nil)
+				r.URL.RawQuery = q.Encode()
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody: `{
+ "items": [
+  {
+   "die_type_id": "d4",
+   "rolls": 8,
+   "distribution": [
+    4,
+    2,
+    1,
+    1
+   ],
+   "mean": 1.875,
+   "expected_mean": 2.5,
+   "chi_square": 3,
+   "degrees_of_freedom": 3,
+   "p_value": 0.25,
+   "enough_rolls": false,
+   "longest_repeat_streak": 3,
+   "longest_high_streak": 1,
+   "longest_low_streak": 5
+  }
+ ]
+}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			ms := &statsmock.Service{}
+			test.mock(ms)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       newOpenRoomAppService(),
+				UserAppService:       &usermock.Service{},
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      ms,
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+		})
+	}
+}
+
+func TestAPIV1CalculateDiceProbabilities(t *testing.T) {
+	fate := model.CustomDieType{
+		TypeID:    "fate-id",
+		TypeName:  "Fate",
+		FaceTable: []model.DieFace{{Label: "-", Value: -1}, {Label: "", Value: 0}, {Label: "+", Value: 1}},
+	}
+
+	tests := map[string]struct {
+		mock          func(*dicemock.Service)
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Having a request without dice nor expression should fail.": {
+			mock: func(m *dicemock.Service) {},
+			req: func() *http.Request {
+				q := url.Values{}
+				q.Add("room-id", "room-id")
+				q.Add("user-id", "user-id")
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/probabilities", nil)
+				r.URL.RawQuery = q.Encode()
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"dice-type-ids or expression are required\",\n \"Header\": null\n}",
+		},
+
+		"Having a request with dice and expression should fail.": {
+			mock: func(m *dicemock.Service) {},
+			req: func() *http.Request {
+				q := url.Values{}
+				q.Add("room-id", "room-id")
+				q.Add("user-id", "user-id")
+				q.Add("dice-type-ids", "d8,d8")
+				q.Add("expression", "2d8")
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/probabilities", nil)
+				r.URL.RawQuery = q.Encode()
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"dice-type-ids and expression can't be used at the same time\",\n \"Header\": null\n}",
+		},
+
+		"Having a request with an invalid modifier should fail.": {
+			mock: func(m *dicemock.Service) {},
+			req: func() *http.Request {
+				q := url.Values{}
+				q.Add("room-id", "room-id")
+				q.Add("user-id", "user-id")
+				q.Add("dice-type-ids", "d8")
+				q.Add("keep-highest", "-1")
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/probabilities", nil)
+				r.URL.RawQuery = q.Encode()
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"keep-highest '-1' is invalid\",\n \"Header\": null\n}",
+		},
+
+		"Having an error while calculating the probabilities should fail.": {
+			mock: func(m *dicemock.Service) {
+				m.On("CalculateProbabilities", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error: %w", internalerrors.ErrNotValid))
+			},
+			req: func() *http.Request {
+				q := url.Values{}
+				q.Add("room-id", "room-id")
+				q.Add("user-id", "user-id")
+				q.Add("expression", "2d8+3")
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/probabilities", nil)
+				r.URL.RawQuery = q.Encode()
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"wanted error: not valid\",\n \"Header\": null\n}",
+		},
+
+		"Having an expression with a check should return the distribution and the check probability.": {
+			mock: func(m *dicemock.Service) {
+				exp := dice.CalculateProbabilitiesRequest{
+					RoomID:     "room-id",
+					UserID:     "user-id",
+					Expression: "1d4+1",
+					Check:      dice.DiceCheck{Target: 4, Comparison: model.DiceRollCheckComparisonGreaterOrEqual},
+				}
+				resp := &dice.CalculateProbabilitiesResponse{
+					Distribution: dice.Distribution{
+						Exact: true,
+						Totals: []dice.TotalProbability{
+							{Total: 2, Probability: 0.25},
+							{Total: 3, Probability: 0.25},
+							{Total: 4, Probability: 0.25},
+							{Total: 5, Probability: 0.25},
+						},
+					},
+					CheckProbability: 0.5,
+				}
+				m.On("CalculateProbabilities", mock.Anything, exp).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				q := url.Values{}
+				q.Add("room-id", "room-id")
+				q.Add("user-id", "user-id")
+				q.Add("expression", "1d4+1")
+				q.Add("check-target", "4")
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/probabilities", nil)
+				r.URL.RawQuery = q.Encode()
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody: `{
+ "exact": true,
+ "mean": 3.5,
+ "check_probability": 0.5,
+ "totals": [
+  {
+   "total": 2,
+   "probability": 0.25
+  },
+  {
+   "total": 3,
+   "probability": 0.25
+  },
+  {
+   "total": 4,
+   "probability": 0.25
+  },
+  {
+   "total": 5,
+   "probability": 0.25
+  }
+ ]
+}`,
+		},
+
+		"Having room custom dice with mechanics should map the request with the room dice.": {
+			mock: func(m *dicemock.Service) {
+				m.On("ListDiceTypes", mock.Anything, dice.ListDiceTypesRequest{RoomID: "room-id"}).Once().Return(&dice.ListDiceTypesResponse{
+					DiceTypes: []model.DieType{fate},
+				}, nil)
+				exp := dice.CalculateProbabilitiesRequest{
+					RoomID:    "room-id",
+					UserID:    "user-id",
+					Dice:      []model.DieType{fate, model.DieTypeD6, model.DieTypeD6},
+					Modifiers: dice.DiceRollModifiers{Explode: true, KeepHighest: 2},
+					Modifier:  -2,
+				}
+				resp := &dice.CalculateProbabilitiesResponse{
+					Distribution: dice.Distribution{
+						Exact:  false,
+						Totals: []dice.TotalProbability{{Total: 1, Probability: 1}},
+					},
+				}
+				m.On("CalculateProbabilities", mock.Anything, exp).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				q := url.Values{}
+				q.Add("room-id", "room-id")
+				q.Add("user-id", "user-id")
+				q.Add("dice-type-ids", "fate-id,d6,d6")
+				q.Add("explode", "true")
+				q.Add("keep-highest", "2")
+				q.Add("modifier", "-2")
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/dice/probabilities", nil)
+				r.URL.RawQuery = q.Encode()
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody: `{
+ "exact": false,
+ "mean": 1,
+ "totals": [
+  {
+   "total": 1,
+   "probability": 1
+  }
+ ]
+}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			md := &dicemock.Service{}
+			test.mock(md)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       md,
+				RoomAppService:       newOpenRoomAppService(),
+				UserAppService:       &usermock.Service{},
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+			md.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAPIV1CreateRoom(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+
+	tests := map[string]struct {
+		mock          func(*roommock.Service)
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Having a request without name should fail.": {
+			mock: func(m *roommock.Service) {},
+			req: func() *http.Request {
+				body := `{"name": ""}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"name is required\",\n \"Header\": null\n}",
+		},
+
+		"Having a correct request that fails creating the the room should fail.": {
+			mock: func(m *roommock.Service) {
+				m.On("CreateRoom", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error"))
+			},
+			req: func() *http.Request {
+				body := `{"name": "test-room"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusInternalServerError,
+			expBody:       "{\n \"Code\": 500,\n \"Message\": \"wanted error\",\n \"Header\": null\n}",
+		},
+
+		"Having a correct request should create the room.": {
+			mock: func(m *roommock.Service) {
+				exp := room.CreateRoomRequest{Name: "test-room"}
+				resp := &room.CreateRoomResponse{Room: model.Room{
+					Name:      "test-room",
+					CreatedAt: t0,
+					ID:        "room-id",
+				}}
+				m.On("CreateRoom", mock.Anything, exp).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				body := `{"name": "test-room"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusCreated,
+			expBody: `{
+ "id": "room-id",
+ "created_at": "1912-06-23T01:02:03Z",
+ "name": "test-room"
+}`,
+		},
+
+		"Having a correct request with a password should create the password protected room.": {
+			mock: func(m *roommock.Service) {
+				exp := room.CreateRoomRequest{Name: "test-room", Password: "test-password"}
+				resp := &room.CreateRoomResponse{Room: model.Room{
+					Name:         "test-room",
+					CreatedAt:    t0,
+					ID:           "room-id",
+					PasswordHash: "hash",
+				}}
+				m.On("CreateRoom", mock.Anything, exp).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				body := `{"name": "test-room", "password": "test-password"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusCreated,
+			expBody: `{
+ "id": "room-id",
+ "created_at": "1912-06-23T01:02:03Z",
+ "name": "test-room"
+}`,
+		},
+
+		"Having a correct request with a ruleset should create the room with the ruleset.": {
+			mock: func(m *roommock.Service) {
+				exp := room.CreateRoomRequest{Name: "test-room", Ruleset: "pbta"}
+				resp := &room.CreateRoomResponse{Room: model.Room{
+					Name:      "test-room",
+					CreatedAt: t0,
+					ID:        "room-id",
+					Ruleset:   "pbta",
+				}}
+				m.On("CreateRoom", mock.Anything, exp).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				body := `{"name": "test-room", "ruleset": "pbta"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusCreated,
+			expBody: `{
+ "id": "room-id",
+ "created_at": "1912-06-23T01:02:03Z",
+ "name": "test-room",
+ "ruleset": "pbta"
+}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			mr := &roommock.Service{}
+			test.mock(mr)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       mr,
+				UserAppService:       &usermock.Service{},
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+		})
+	}
+}
+
+func TestAPIV1GetRoom(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+
+	tests := map[string]struct {
+		mock          func(mr *roommock.Service, mu *usermock.Service)
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Having an error while getting the room should fail.": {
+			mock: func(m *roommock.Service, mu *usermock.Service) {
+				m.On("GetRoom", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error"))
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/rooms/test-id", nil)
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusInternalServerError,
+			expBody:       "{\n \"Code\": 500,\n \"Message\": \"wanted error\",\n \"Header\": null\n}",
+		},
+
+		"Having a correct request should get the room.": {
+			mock: func(m *roommock.Service, mu *usermock.Service) {
+				exp := room.GetRoomRequest{ID: "test-id"}
+				resp := &room.GetRoomResponse{Room: model.Room{
+					Name:      "test-room",
+					CreatedAt: t0,
+					ID:        "room-id",
+				}}
+				m.On("GetRoom", mock.Anything, exp).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/rooms/test-id", nil)
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody: `{
+ "id": "room-id",
+ "created_at": "1912-06-23T01:02:03Z",
+ "name": "test-room",
+ "archived": false,
+ "protected": false
+}`,
+		},
+
+		"Having a correct request of an archived room should get the archived room.": {
+			mock: func(m *roommock.Service, mu *usermock.Service) {
+				exp := room.GetRoomRequest{ID: "test-id"}
+				resp := &room.GetRoomResponse{Room: model.Room{
+					Name:      "test-room",
+					CreatedAt: t0,
+					ID:        "room-id",
+					Archived:  true,
+				}}
+				m.On("GetRoom", mock.Anything, exp).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/rooms/test-id", nil)
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody: `{
+ "id": "room-id",
+ "created_at": "1912-06-23T01:02:03Z",
+ "name": "test-room",
+ "archived": true,
+ "protected": false
+}`,
+		},
+
+		"Having a request of a password protected room without session should fail.": {
+			mock: func(m *roommock.Service, mu *usermock.Service) {
+				resp := &room.GetRoomResponse{Room: model.Room{ID: "room-id", PasswordHash: "hash"}}
+				m.On("GetRoom", mock.Anything, room.GetRoomRequest{ID: "test-id"}).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/rooms/test-id", nil)
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"session token is required: not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Having a request of a password protected room with the session of another room user should fail.": {
+			mock: func(m *roommock.Service, mu *usermock.Service) {
+				resp := &room.GetRoomResponse{Room: model.Room{ID: "room-id", PasswordHash: "hash"}}
+				m.On("GetRoom", mock.Anything, room.GetRoomRequest{ID: "test-id"}).Once().Return(resp, nil)
+				authReq := user.AuthenticateUserRequest{SessionToken: "user-token", RoomID: "room-id"}
+				mu.On("AuthenticateUser", mock.Anything, authReq).Once().Return(nil, internalerrors.ErrNotAllowed)
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/rooms/test-id", nil)
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-token")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Having a request of a password protected room with the session of a room user should get the protected room.": {
+			mock: func(m *roommock.Service, mu *usermock.Service) {
+				exp := room.GetRoomRequest{ID: "test-id"}
+				resp := &room.GetRoomResponse{Room: model.Room{
+					Name:         "test-room",
+					CreatedAt:    t0,
+					ID:           "room-id",
+					PasswordHash: "hash",
+					InviteTokens: []model.RoomInviteToken{{Token: "token1"}},
+				}}
+				m.On("GetRoom", mock.Anything, exp).Once().Return(resp, nil)
+				authReq := user.AuthenticateUserRequest{SessionToken: "user-token", RoomID: "room-id"}
+				mu.On("AuthenticateUser", mock.Anything, authReq).Once().Return(&user.AuthenticateUserResponse{User: model.User{ID: "user-id", RoomID: "room-id"}}, nil)
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/rooms/test-id", nil)
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-token")
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody: `{
+ "id": "room-id",
+ "created_at": "1912-06-23T01:02:03Z",
+ "name": "test-room",
+ "archived": false,
+ "protected": true
+}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			mr := &roommock.Service{}
+			mu := &usermock.Service{}
+			test.mock(mr, mu)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       mr,
+				UserAppService:       mu,
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+			mu.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAPIV1ProtectedRoomReads(t *testing.T) {
+	type mocks struct {
+		md *dicemock.Service
+		mr *roommock.Service
+		mu *usermock.Service
+		mm *macromock.Service
+		mi *initiativemock.Service
+		mk *deckmock.Service
+		mo *oraclemock.Service
+		ms *statsmock.Service
+	}
+
+	newRequest := func(path, session string) func() *http.Request {
+		return func() *http.Request {
+			r, _ := http.NewRequest(http.MethodGet, path, nil)
+			r.Header.Set("Content-Type", "application/json")
+			if session != "" {
+				r.Header.Set("Authorization", "Bearer "+session)
+			}
+			return r
+		}
+	}
+
+	authenticate := func(m mocks) {
+		authReq := user.AuthenticateUserRequest{SessionToken: "user-token", RoomID: "room-id"}
+		m.mu.On("AuthenticateUser", mock.Anything, authReq).Return(&user.AuthenticateUserResponse{User: model.User{ID: "user-id", RoomID: "room-id"}}, nil)
+	}
+
+	tests := map[string]struct {
+		mock          func(m mocks)
+		req           func() *http.Request
+		expStatusCode int
+	}{
+		"Listing the dice types of a protected room without session should be forbidden.": {
+			mock:          func(m mocks) {},
+			req:           newRequest("/api/v1/dice/types?room-id=room-id", ""),
+			expStatusCode: http.StatusForbidden,
+		},
+
+		"Listing the dice rolls of a protected room without session should be forbidden.": {
+			mock:          func(m mocks) {},
+			req:           newRequest("/api/v1/dice/rolls?room-id=room-id", ""),
+			expStatusCode: http.StatusForbidden,
+		},
+
+		"Listing the dice rolls of a protected room with the session of another room user should be forbidden.": {
+			mock: func(m mocks) {
+				authReq := user.AuthenticateUserRequest{SessionToken: "user-token", RoomID: "room-id"}
+				m.mu.On("AuthenticateUser", mock.Anything, authReq).Once().Return(nil, fmt.Errorf("session user is not of the room: %w", internalerrors.ErrNotAllowed))
+			},
+			req:           newRequest("/api/v1/dice/rolls?room-id=room-id", "user-token"),
+			expStatusCode: http.StatusForbidden,
+		},
+
+		"Listing the dice rolls of a protected room with the session of a room user should list them.": {
+			mock: func(m mocks) {
+				authenticate(m)
+				exp := dice.ListDiceRollsRequest{RoomID: "room-id", ViewerUserID: "user-id"}
+				m.md.On("ListDiceRolls", mock.Anything, exp).Once().Return(&dice.ListDiceRollsResponse{}, nil)
+			},
+			req:           newRequest("/api/v1/dice/rolls?room-id=room-id", "user-token"),
+			expStatusCode: http.StatusOK,
+		},
+
+		"Verifying a dice roll of a protected room without session should be forbidden.": {
+			mock: func(m mocks) {
+				resp := &dice.VerifyDiceRollResponse{DiceRoll: model.DiceRoll{ID: "dice-roll-id", RoomID: "room-id"}}
+				m.md.On("VerifyDiceRoll", mock.Anything, mock.Anything).Once().Return(resp, nil)
+			},
+			req:           newRequest("/api/v1/dice/rolls/dice-roll-id/verify", ""),
+			expStatusCode: http.StatusForbidden,
+		},
+
+		"Getting the dice stats of a protected room without session should be forbidden.": {
+			mock:          func(m mocks) {},
+			req:           newRequest("/api/v1/dice/stats?room-id=room-id", ""),
+			expStatusCode: http.StatusForbidden,
+		},
+
+		"Calculating dice probabilities on a protected room without session should be forbidden.": {
+			mock:          func(m mocks) {},
+			req:           newRequest("/api/v1/dice/probabilities?room-id=room-id&user-id=user-id&expression=1d20", ""),
+			expStatusCode: http.StatusForbidden,
+		},
+
+		"Getting the server seed of a protected room without session should be forbidden.": {
+			mock:          func(m mocks) {},
+			req:           newRequest("/api/v1/rooms/room-id/server-seed", ""),
+			expStatusCode: http.StatusForbidden,
+		},
+
+		"Listing the macros of a protected room without session should be forbidden.": {
+			mock:          func(m mocks) {},
+			req:           newRequest("/api/v1/macros?room-id=room-id", ""),
+			expStatusCode: http.StatusForbidden,
+		},
+
+		"Getting a macro of a protected room without session should be forbidden.": {
+			mock: func(m mocks) {
+				resp := &macro.GetMacroResponse{Macro: model.Macro{ID: "macro-id", RoomID: "room-id"}}
+				m.mm.On("GetMacro", mock.Anything, mock.Anything).Once().Return(resp, nil)
+			},
+			req:           newRequest("/api/v1/macros/macro-id?user-id=user-id", ""),
+			expStatusCode: http.StatusForbidden,
+		},
+
+		"Getting the initiative of a protected room without session should be forbidden.": {
+			mock:          func(m mocks) {},
+			req:           newRequest("/api/v1/rooms/room-id/initiative", ""),
+			expStatusCode: http.StatusForbidden,
+		},
+
+		"Getting the initiative of a protected room with the session of a room user should get it.": {
+			mock: func(m mocks) {
+				authenticate(m)
+				exp := initiative.GetInitiativeRequest{RoomID: "room-id"}
+				m.mi.On("GetInitiative", mock.Anything, exp).Once().Return(&initiative.GetInitiativeResponse{}, nil)
+			},
+			req:           newRequest("/api/v1/rooms/room-id/initiative", "user-token"),
+			expStatusCode: http.StatusOK,
+		},
+
+		"Listing the decks of a protected room without session should be forbidden.": {
+			mock:          func(m mocks) {},
+			req:           newRequest("/api/v1/rooms/room-id/decks", ""),
+			expStatusCode: http.StatusForbidden,
+		},
+
+		"Listing the oracle tables of a protected room without session should be forbidden.": {
+			mock:          func(m mocks) {},
+			req:           newRequest("/api/v1/rooms/room-id/oracle-tables", ""),
+			expStatusCode: http.StatusForbidden,
+		},
+
+		"Listing the oracle table rolls of a protected room without session should be forbidden.": {
+			mock:          func(m mocks) {},
+			req:           newRequest("/api/v1/rooms/room-id/oracle-rolls", ""),
+			expStatusCode: http.StatusForbidden,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			m := mocks{
+				md: &dicemock.Service{},
+				mr: &roommock.Service{},
+				mu: &usermock.Service{},
+				mm: &macromock.Service{},
+				mi: &initiativemock.Service{},
+				mk: &deckmock.Service{},
+				mo: &oraclemock.Service{},
+				ms: &statsmock.Service{},
+			}
+			m.mr.On("GetRoom", mock.Anything, room.GetRoomRequest{ID: "room-id"}).Return(&room.GetRoomResponse{Room: model.Room{ID: "room-id", PasswordHash: "hash"}}, nil)
+			test.mock(m)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       m.md,
+				RoomAppService:       m.mr,
+				UserAppService:       m.mu,
+				MacroAppService:      m.mm,
+				InitiativeAppService: m.mi,
+				DeckAppService:       m.mk,
+				OracleAppService:     m.mo,
+				StatsAppService:      m.ms,
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			assert.Equal(test.expStatusCode, w.Result().StatusCode)
+			m.md.AssertExpectations(t)
+			m.mu.AssertExpectations(t)
+			m.mm.AssertExpectations(t)
+			m.mi.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAPIV1DeleteRoom(t *testing.T) {
+	tests := map[string]struct {
+		mock          func(mr *roommock.Service, mu *usermock.Service)
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Having a request without session token should fail.": {
+			mock: func(mr *roommock.Service, mu *usermock.Service) {},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodDelete, "/api/v1/rooms/test-id", nil)
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"session token is required: not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Having a request with a wrong session token should fail.": {
+			mock: func(mr *roommock.Service, mu *usermock.Service) {
+				mu.On("AuthenticateUser", mock.Anything, mock.Anything).Once().Return(nil, internalerrors.ErrNotAllowed)
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodDelete, "/api/v1/rooms/test-id", nil)
+				r.Header.Set("Authorization", "Bearer wrong-token")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Having a request of a user that is not the game master should fail.": {
+			mock: func(mr *roommock.Service, mu *usermock.Service) {
+				authReq := user.AuthenticateUserRequest{SessionToken: "user-token", RoomID: "test-id"}
+				mu.On("AuthenticateUser", mock.Anything, authReq).Once().Return(&user.AuthenticateUserResponse{User: model.User{ID: "user-id", RoomID: "test-id"}}, nil)
+				exp := room.DeleteRoomRequest{ID: "test-id", UserID: "user-id"}
+				mr.On("DeleteRoom", mock.Anything, exp).Once().Return(fmt.Errorf("wanted error: %w", internalerrors.ErrNotAllowed))
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodDelete, "/api/v1/rooms/test-id", nil)
+				r.Header.Set("Authorization", "Bearer user-token")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"wanted error: not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Having a missing room should fail.": {
+			mock: func(mr *roommock.Service, mu *usermock.Service) {
+				authReq := user.AuthenticateUserRequest{SessionToken: "gm-token", RoomID: "test-id"}
+				mu.On("AuthenticateUser", mock.Anything, authReq).Once().Return(&user.AuthenticateUserResponse{User: model.User{ID: "gm-id", RoomID: "test-id", GameMaster: true}}, nil)
+				mr.On("DeleteRoom", mock.Anything, mock.Anything).Once().Return(fmt.Errorf("wanted error: %w", internalerrors.ErrMissing))
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodDelete, "/api/v1/rooms/test-id", nil)
+				r.Header.Set("Authorization", "Bearer gm-token")
+				return r
+			},
+			expStatusCode: http.StatusNotFound,
+			expBody:       "{\n \"Code\": 404,\n \"Message\": \"wanted error: is missing\",\n \"Header\": null\n}",
+		},
+
+		"Having a correct request should delete the room.": {
+			mock: func(mr *roommock.Service, mu *usermock.Service) {
+				authReq := user.AuthenticateUserRequest{SessionToken: "gm-token", RoomID: "test-id"}
+				mu.On("AuthenticateUser", mock.Anything, authReq).Once().Return(&user.AuthenticateUserResponse{User: model.User{ID: "gm-id", RoomID: "test-id", GameMaster: true}}, nil)
+				exp := room.DeleteRoomRequest{ID: "test-id", UserID: "gm-id"}
+				mr.On("DeleteRoom", mock.Anything, exp).Once().Return(nil)
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodDelete, "/api/v1/rooms/test-id", nil)
+				r.Header.Set("Authorization", "Bearer gm-token")
+				return r
+			},
+			expStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			mr := &roommock.Service{}
+			mu := &usermock.Service{}
+			test.mock(mr, mu)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       mr,
+				UserAppService:       mu,
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+			mr.AssertExpectations(t)
+			mu.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAPIV1ArchiveRoom(t *testing.T) {
+	tests := map[string]struct {
+		mock          func(mr *roommock.Service, mu *usermock.Service)
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Having a request without session token should fail.": {
+			mock: func(mr *roommock.Service, mu *usermock.Service) {},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/test-id/archive", nil)
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"session token is required: not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Having a request with a wrong session token should fail.": {
+			mock: func(mr *roommock.Service, mu *usermock.Service) {
+				mu.On("AuthenticateUser", mock.Anything, mock.Anything).Once().Return(nil, internalerrors.ErrNotAllowed)
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/test-id/archive", nil)
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer wrong-token")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Having a request of a user that is not the game master should fail.": {
+			mock: func(mr *roommock.Service, mu *usermock.Service) {
+				authReq := user.AuthenticateUserRequest{SessionToken: "user-token", RoomID: "test-id"}
+				mu.On("AuthenticateUser", mock.Anything, authReq).Once().Return(&user.AuthenticateUserResponse{User: model.User{ID: "user-id", RoomID: "test-id"}}, nil)
+				exp := room.ArchiveRoomRequest{ID: "test-id", UserID: "user-id"}
+				mr.On("ArchiveRoom", mock.Anything, exp).Once().Return(fmt.Errorf("wanted error: %w", internalerrors.ErrNotAllowed))
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/test-id/archive", nil)
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-token")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"wanted error: not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Having a missing room should fail.": {
+			mock: func(mr *roommock.Service, mu *usermock.Service) {
+				authReq := user.AuthenticateUserRequest{SessionToken: "gm-token", RoomID: "test-id"}
+				mu.On("AuthenticateUser", mock.Anything, authReq).Once().Return(&user.AuthenticateUserResponse{User: model.User{ID: "gm-id", RoomID: "test-id", GameMaster: true}}, nil)
+				mr.On("ArchiveRoom", mock.Anything, mock.Anything).Once().Return(fmt.Errorf("wanted error: %w", internalerrors.ErrMissing))
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/test-id/archive", nil)
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer gm-token")
+				return r
+			},
+			expStatusCode: http.StatusNotFound,
+			expBody:       "{\n \"Code\": 404,\n \"Message\": \"wanted error: is missing\",\n \"Header\": null\n}",
+		},
+
+		"Having a correct request should archive the room.": {
+			mock: func(mr *roommock.Service, mu *usermock.Service) {
+				authReq := user.AuthenticateUserRequest{SessionToken: "gm-token", RoomID: "test-id"}
+				mu.On("AuthenticateUser", mock.Anything, authReq).Once().Return(&user.AuthenticateUserResponse{User: model.User{ID: "gm-id", RoomID: "test-id", GameMaster: true}}, nil)
+				exp := room.ArchiveRoomRequest{ID: "test-id", UserID: "gm-id"}
+				mr.On("ArchiveRoom", mock.Anything, exp).Once().Return(nil)
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/test-id/archive", nil)
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer gm-token")
+				return r
+			},
+			expStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			mr := &roommock.Service{}
+			mu := &usermock.Service{}
+			test.mock(mr, mu)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       mr,
+				UserAppService:       mu,
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+			mr.AssertExpectations(t)
+			mu.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAPIV1GetRoomServerSeed(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+
+	tests := map[string]struct {
+		mock          func(*dicemock.Service)
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Having an error while getting the server seed should fail.": {
+			mock: func(m *dicemock.Service) {
+				m.On("GetRoomServerSeed", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error"))
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/rooms/test-room/server-seed", nil)
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusInternalServerError,
+			expBody:       "{\n \"Code\": 500,\n \"Message\": \"wanted error\",\n \"Header\": null\n}",
+		},
+
+		"Having a correct request should get the server seed without the secret seed.": {
+			mock: func(m *dicemock.Service) {
+				expReq := dice.GetRoomServerSeedRequest{RoomID: "test-room"}
+				resp := &dice.GetRoomServerSeedResponse{
+					ServerSeed: model.ServerSeed{
+						ID:        "seed-id",
+						RoomID:    "test-room",
+						CreatedAt: t0,
+						Hash:      "seed-hash",
+						Nonce:     3,
+					},
+				}
+				m.On("GetRoomServerSeed", mock.Anything, expReq).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/rooms/test-room/server-seed", nil)
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody: `{
+ "id": "seed-id",
+ "room_id": "test-room",
+ "created_at": "1912-06-23T01:02:03Z",
+ "hash": "seed-hash",
+ "nonce": 3,
+ "revealed": false
+}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			md := &dicemock.Service{}
+			test.mock(md)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       md,
+				RoomAppService:       newOpenRoomAppService(),
+				UserAppService:       &usermock.Service{},
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+		})
+	}
+}
+
+func TestAPIV1RotateRoomServerSeed(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+
+	tests := map[string]struct {
+		mock          func(*dicemock.Service, *usermock.Service)
+		protected     bool
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Rotating the server seed without session should be forbidden.": {
+			mock: func(m *dicemock.Service, mu *usermock.Service) {},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/test-room/server-seed/rotate", nil)
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"session token is required: not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Rotating the server seed without being the game master should be forbidden.": {
+			mock: func(m *dicemock.Service, mu *usermock.Service) {
+				mu.On("AuthenticateUser", mock.Anything, user.AuthenticateUserRequest{SessionToken: "test-token", RoomID: "test-room"}).Once().Return(&user.AuthenticateUserResponse{User: model.User{ID: "test-user", RoomID: "test-room"}}, nil)
+				m.On("RotateRoomServerSeed", mock.Anything, dice.RotateRoomServerSeedRequest{RoomID: "test-room", UserID: "test-user"}).Once().Return(nil, fmt.Errorf("not gm: %w", internalerrors.ErrNotAllowed))
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/test-room/server-seed/rotate", nil)
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-token")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"not gm: not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Rotating the server seed of a protected room without being a room user should be forbidden.": {
+			mock: func(m *dicemock.Service, mu *usermock.Service) {
+				mu.On("AuthenticateUser", mock.Anything, user.AuthenticateUserRequest{SessionToken: "test-token", RoomID: "test-room"}).Once().Return(nil, fmt.Errorf("not of the room: %w", internalerrors.ErrNotAllowed))
+			},
+			protected: true,
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/test-room/server-seed/rotate", nil)
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-token")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"not of the room: not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Having an error while rotating the server seed should fail.": {
+			mock: func(m *dicemock.Service, mu *usermock.Service) {
+				mu.On("AuthenticateUser", mock.Anything, user.AuthenticateUserRequest{SessionToken: "test-token", RoomID: "test-room"}).Once().Return(&user.AuthenticateUserResponse{User: model.User{ID: "test-user", RoomID: "test-room", GameMaster: true}}, nil)
+				m.On("RotateRoomServerSeed", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error"))
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/test-room/server-seed/rotate", nil)
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-token")
+				return r
+			},
+			expStatusCode: http.StatusInternalServerError,
+			expBody:       "{\n \"Code\": 500,\n \"Message\": \"wanted error\",\n \"Header\": null\n}",
+		},
+
+		"Having a correct request should reveal the server seed and return the new one.": {
+			mock: func(m *dicemock.Service, mu *usermock.Service) {
+				mu.On("AuthenticateUser", mock.Anything, user.AuthenticateUserRequest{SessionToken: "test-token", RoomID: "test-room"}).Once().Return(&user.AuthenticateUserResponse{User: model.User{ID: "test-user", RoomID: "test-room", GameMaster: true}}, nil)
+				expReq := dice.RotateRoomServerSeedRequest{RoomID: "test-room", UserID: "test-user"}
+				resp := &dice.RotateRoomServerSeedResponse{
+					RevealedServerSeed: &model.ServerSeed{
+						ID:        "old-seed-id",
+						RoomID:    "test-room",
+						CreatedAt: t0,
+						Seed:      "old-seed",
+						Hash:      "old-seed-hash",
+						Nonce:     3,
+						Revealed:  true,
+					},
+					ServerSeed: model.ServerSeed{
+						ID:        "seed-id",
+						RoomID:    "test-room",
+						CreatedAt: t0,
+						Hash:      "seed-hash",
+					},
+				}
+				m.On("RotateRoomServerSeed", mock.Anything, expReq).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/test-room/server-seed/rotate", nil)
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer test-token")
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody: `{
+ "revealed_server_seed": {
+  "id": "old-seed-id",
+  "room_id": "test-room",
+  "created_at": "1912-06-23T01:02:03Z",
+  "seed": "old-seed",
+  "hash": "old-seed-hash",
+  "nonce": 3,
+  "revealed": true
+ },
+ "server_seed": {
+  "id": "seed-id",
+  "room_id": "test-room",
+  "created_at": "1912-06-23T01:02:03Z",
+  "hash": "seed-hash",
+  "nonce": 0,
+  "revealed": false
+ }
+}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			md := &dicemock.Service{}
+			mu := &usermock.Service{}
+			test.mock(md, mu)
+			mr := newOpenRoomAppService()
+			if test.protected {
+				mr = &roommock.Service{}
+				mr.On("GetRoom", mock.Anything, mock.Anything).Return(&room.GetRoomResponse{Room: model.Room{ID: "test-room", PasswordHash: "hash"}}, nil)
+			}
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       md,
+				RoomAppService:       mr,
+				UserAppService:       mu,
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+			md.AssertExpectations(t)
+			mu.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAPIV1CreateUser(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+
+	tests := map[string]struct {
+		mock          func(*usermock.Service)
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Having a request without name should fail.": {
+			mock: func(m *usermock.Service) {},
+			req: func() *http.Request {
+				body := `{"name": "", "room_id": "room1-id"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"name is required\",\n \"Header\": null\n}",
+		},
+
+		"Having a request without room id should fail.": {
+			mock: func(m *usermock.Service) {},
+			req: func() *http.Request {
+				body := `{"name": "test1", "room_id": ""}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"room_id is required\",\n \"Header\": null\n}",
+		},
+
+		"Having a correct request that fails creating the the user should fail.": {
+			mock: func(m *usermock.Service) {
+				m.On("CreateUser", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error"))
+			},
+			req: func() *http.Request {
+				body := `{"name": "test-room", "room_id": "test1-id"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusInternalServerError,
+			expBody:       "{\n \"Code\": 500,\n \"Message\": \"wanted error\",\n \"Header\": null\n}",
+		},
+
+		"Having a request with wrong room credentials should fail.": {
+			mock: func(m *usermock.Service) {
+				exp := user.CreateUserRequest{Name: "test1", RoomID: "test1-id", Password: "wrong", InviteToken: "token1"}
+				m.On("CreateUser", mock.Anything, exp).Once().Return(nil, internalerrors.ErrNotAllowed)
+			},
+			req: func() *http.Request {
+				body := `{"name": "test1", "room_id": "test1-id", "password": "wrong", "invite_token": "token1"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Having a request of an existing user without its session should fail.": {
+			mock: func(m *usermock.Service) {
+				exp := user.CreateUserRequest{Name: "test1", RoomID: "test1-id", SessionToken: "other-token"}
+				m.On("CreateUser", mock.Anything, exp).Once().Return(nil, internalerrors.ErrAlreadyExists)
+			},
+			req: func() *http.Request {
+				body := `{"name": "test1", "room_id": "test1-id"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer other-token")
+				return r
+			},
+			expStatusCode: http.StatusConflict,
+			expBody:       "{\n \"Code\": 409,\n \"Message\": \"already exists\",\n \"Header\": null\n}",
+		},
+
+		"Having a correct request should create the user.": {
+			mock: func(m *usermock.Service) {
+				exp := user.CreateUserRequest{Name: "test1", RoomID: "test1-id"}
+				resp := &user.CreateUserResponse{User: model.User{
+					ID:        "test1-id",
+					RoomID:    "test1-id",
+					Name:      "test1",
+					CreatedAt: t0,
+				}, SessionToken: "test1-token"}
+				m.On("CreateUser", mock.Anything, exp).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				body := `{"name": "test1", "room_id": "test1-id"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusCreated,
+			expBody: `{
+ "id": "test1-id",
+ "created_at": "1912-06-23T01:02:03Z",
+ "name": "test1",
+ "room_id": "test1-id",
+ "game_master": false,
+ "session_token": "test1-token"
+}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			mu := &usermock.Service{}
+			test.mock(mu)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       &roommock.Service{},
+				UserAppService:       mu,
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+		})
+	}
+}
+
+func TestAPIV1ListUsers(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+
+	tests := map[string]struct {
+		mock          func(*usermock.Service)
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Having a request without room id should fail.": {
+			mock: func(m *usermock.Service) {},
+			req: func() *http.Request {
+				q := url.Values{}
+				q.Add("room-id", "")
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/users", nil)
+				r.URL.RawQuery = q.Encode()
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"room-id is required\",\n \"Header\": null\n}",
+		},
+
+		"Having a request that fails while listing users, should fail.": {
+			mock: func(m *usermock.Service) {
+				m.On("ListUsers", mock.Anything, mock.Anything).Once().Return(nil, errors.New("wanted error"))
+			},
+			req: func() *http.Request {
+				q := url.Values{}
+				q.Add("room-id", "room-id")
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/users", nil)
+				r.URL.RawQuery = q.Encode()
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusInternalServerError,
+			expBody:       "{\n \"Code\": 500,\n \"Message\": \"wanted error\",\n \"Header\": null\n}",
+		},
+
+		"Having a request of a protected room without session should be forbidden.": {
+			mock: func(m *usermock.Service) {
+				exp := user.ListUsersRequest{RoomID: "room-id"}
+				m.On("ListUsers", mock.Anything, exp).Once().Return(nil, fmt.Errorf("session token is required on protected rooms: %w", internalerrors.ErrNotAllowed))
+			},
+			req: func() *http.Request {
+				q := url.Values{}
+				q.Add("room-id", "room-id")
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/users", nil)
+				r.URL.RawQuery = q.Encode()
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"session token is required on protected rooms: not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Having a request with a session should list the users with the session.": {
+			mock: func(m *usermock.Service) {
+				exp := user.ListUsersRequest{RoomID: "room-id", SessionToken: "user-token"}
+				m.On("ListUsers", mock.Anything, exp).Once().Return(&user.ListUsersResponse{}, nil)
+			},
+			req: func() *http.Request {
+				q := url.Values{}
+				q.Add("room-id", "room-id")
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/users", nil)
+				r.URL.RawQuery = q.Encode()
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-token")
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody: `{
+ "items": []
+}`,
+		},
+
+		"Having a request should list the users.": {
+			mock: func(m *usermock.Service) {
+				exp := user.ListUsersRequest{RoomID: "room-id"}
+				resp := &user.ListUsersResponse{
+					Users: []model.User{
+						{
+							ID:        "test1-id",
+							RoomID:    "test1-id",
+							Name:      "test1",
+							CreatedAt: t0,
+						},
+						{
+							ID:         "test2-id",
+							RoomID:     "test2-id",
+							Name:       "test2",
+							CreatedAt:  t0,
+							GameMaster: true,
+						},
+					}}
+				m.On("ListUsers", mock.Anything, exp).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				q := url.Values{}
+				q.Add("room-id", "room-id")
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/users", nil)
+				r.URL.RawQuery = q.Encode()
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody: `{
+ "items": [
+  {
+   "id": "test1-id",
+   "name": "test1",
+   "created_at": "1912-06-23T01:02:03Z",
+   "game_master": false
+  },
+  {
+   "id": "test2-id",
+   "name": "test2",
+   "created_at": "1912-06-23T01:02:03Z",
+   "game_master": true
+  }
+ ]
+}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			mu := &usermock.Service{}
+			test.mock(mu)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       &roommock.Service{},
+				UserAppService:       mu,
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+		})
+	}
+}
+
+func TestAPIV1CreateMacro(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+
+	tests := map[string]struct {
+		mock          func(*macromock.Service)
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Having a request without user ID should fail.": {
+			mock: func(m *macromock.Service) {},
+			req: func() *http.Request {
+				body := `{"room_id": "room-id", "name": "Attack", "expression": "1d20+5"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/macros", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"user_id is required\",\n \"Header\": null\n}",
+		},
+
+		"Having a request with a user that is not the session user should fail.": {
+			mock: func(m *macromock.Service) {},
+			req: func() *http.Request {
+				body := `{"room_id": "room-id", "user_id": "user-id", "name": "Attack", "expression": "1d20+5"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/macros", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer other-user")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"request user is not the session user: not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Having a not valid macro on the application service should fail.": {
+			mock: func(m *macromock.Service) {
+				m.On("CreateMacro", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error: %w", internalerrors.ErrNotValid))
+			},
+			req: func() *http.Request {
+				body := `{"room_id": "room-id", "user_id": "user-id", "name": "Attack", "expression": "1d20+"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/macros", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-id")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"wanted error: not valid\",\n \"Header\": null\n}",
+		},
+
+		"Having a correct request should create the macro.": {
+			mock: func(m *macromock.Service) {
+				expReq := macro.CreateMacroRequest{RoomID: "room-id", UserID: "user-id", Name: "Attack", Expression: "1d20+5", Shared: true}
+				resp := &macro.CreateMacroResponse{
+					Macro: model.Macro{ID: "macro-id", CreatedAt: t0, RoomID: "room-id", UserID: "user-id", Name: "Attack", Expression: "1d20+5", Shared: true},
+				}
+				m.On("CreateMacro", mock.Anything, expReq).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				body := `{"room_id": "room-id", "user_id": "user-id", "name": "Attack", "expression": "1d20+5", "shared": true}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/macros", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-id")
+				return r
+			},
+			expStatusCode: http.StatusCreated,
+			expBody: `{
+ "id": "macro-id",
+ "created_at": "1912-06-23T01:02:03Z",
+ "room_id": "room-id",
+ "user_id": "user-id",
+ "name": "Attack",
+ "expression": "1d20+5",
+ "shared": true
+}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			mm := &macromock.Service{}
+			test.mock(mm)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       &roommock.Service{},
+				UserAppService:       newSessionUserAppService(),
+				MacroAppService:      mm,
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+		})
+	}
+}
+
+func TestAPIV1ListMacros(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+
+	tests := map[string]struct {
+		mock          func(*macromock.Service)
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Having a request without room id should fail.": {
+			mock: func(m *macromock.Service) {},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/macros", nil)
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"room-id is required\",\n \"Header\": null\n}",
+		},
+
+		"Having a request should list the macros.": {
+			mock: func(m *macromock.Service) {
+				exp := macro.ListMacrosRequest{RoomID: "room-id", UserID: "user-id"}
+				resp := &macro.ListMacrosResponse{
+					Macros: []model.Macro{
+						{ID: "macro-id", CreatedAt: t0, RoomID: "room-id", UserID: "user-id", Name: "Fireball", Expression: "8d6"},
+					},
+				}
+				m.On("ListMacros", mock.Anything, exp).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				q := url.Values{}
+				q.Add("room-id", "room-id")
+				q.Add("user-id", "user-id")
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/macros", nil)
+				r.URL.RawQuery = q.Encode()
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody: `{
+ "items": [
+  {
+   "id": "macro-id",
+   "created_at": "1912-06-23T01:02:03Z",
+   "room_id": "room-id",
+   "user_id": "user-id",
+   "name": "Fireball",
+   "expression": "8d6",
+   "shared": false
+  }
+ ]
+}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			mm := &macromock.Service{}
+			test.mock(mm)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       newOpenRoomAppService(),
+				UserAppService:       &usermock.Service{},
+				MacroAppService:      mm,
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+		})
+	}
+}
+
+func TestAPIV1GetMacro(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+
+	tests := map[string]struct {
+		mock          func(*macromock.Service)
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Having a request without user id should fail.": {
+			mock: func(m *macromock.Service) {},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/macros/macro-id", nil)
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"user-id is required\",\n \"Header\": null\n}",
+		},
+
+		"Having a request of a macro that can't be used by the user should fail.": {
+			mock: func(m *macromock.Service) {
+				m.On("GetMacro", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error: %w", internalerrors.ErrMissing))
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/macros/macro-id?user-id=user-id", nil)
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusNotFound,
+			expBody:       "{\n \"Code\": 404,\n \"Message\": \"wanted error: is missing\",\n \"Header\": null\n}",
+		},
+
+		"Having a request should get the macro.": {
+			mock: func(m *macromock.Service) {
+				exp := macro.GetMacroRequest{MacroID: "macro-id", UserID: "user-id"}
+				resp := &macro.GetMacroResponse{
+					Macro: model.Macro{ID: "macro-id", CreatedAt: t0, RoomID: "room-id", UserID: "user-id", Name: "Fireball", Expression: "8d6"},
+				}
+				m.On("GetMacro", mock.Anything, exp).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/macros/macro-id?user-id=user-id", nil)
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody: `{
+ "id": "macro-id",
+ "created_at": "1912-06-23T01:02:03Z",
+ "room_id": "room-id",
+ "user_id": "user-id",
+ "name": "Fireball",
+ "expression": "8d6",
+ "shared": false
+}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			mm := &macromock.Service{}
+			test.mock(mm)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       newOpenRoomAppService(),
+				UserAppService:       &usermock.Service{},
+				MacroAppService:      mm,
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+		})
+	}
+}
+
+func TestAPIV1UpdateMacro(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+
+	tests := map[string]struct {
+		mock          func(*macromock.Service)
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Having a request without user ID should fail.": {
+			mock: func(m *macromock.Service) {},
+			req: func() *http.Request {
+				body := `{"name": "Fireball", "expression": "8d6"}`
+				r, _ := http.NewRequest(http.MethodPut, "/api/v1/macros/macro-id", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"user_id is required\",\n \"Header\": null\n}",
+		},
+
+		"Having a request with a user that is not the session user should fail.": {
+			mock: func(m *macromock.Service) {},
+			req: func() *http.Request {
+				body := `{"user_id": "user-id", "name": "Fireball", "expression": "8d6"}`
+				r, _ := http.NewRequest(http.MethodPut, "/api/v1/macros/macro-id", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer other-user")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"request user is not the session user: not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Having a correct request should update the macro.": {
+			mock: func(m *macromock.Service) {
+				expReq := macro.UpdateMacroRequest{MacroID: "macro-id", UserID: "user-id", Name: "Fireball", Expression: "8d6", Shared: true}
+				resp := &macro.UpdateMacroResponse{
+					Macro: model.Macro{ID: "macro-id", CreatedAt: t0, RoomID: "room-id", UserID: "user-id", Name: "Fireball", Expression: "8d6", Shared: true},
+				}
+				m.On("UpdateMacro", mock.Anything, expReq).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				body := `{"user_id": "user-id", "name": "Fireball", "expression": "8d6", "shared": true}`
+				r, _ := http.NewRequest(http.MethodPut, "/api/v1/macros/macro-id", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-id")
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody: `{
+ "id": "macro-id",
+ "created_at": "1912-06-23T01:02:03Z",
+ "room_id": "room-id",
+ "user_id": "user-id",
+ "name": "Fireball",
+ "expression": "8d6",
+ "shared": true
+}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			mm := &macromock.Service{}
+			test.mock(mm)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       &roommock.Service{},
+				UserAppService:       newSessionUserAppService(),
+				MacroAppService:      mm,
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+		})
+	}
+}
+
+func TestAPIV1DeleteMacro(t *testing.T) {
+	tests := map[string]struct {
+		mock          func(*macromock.Service)
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Having a request without user id should fail.": {
+			mock: func(m *macromock.Service) {},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodDelete, "/api/v1/macros/macro-id", nil)
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"user-id is required\",\n \"Header\": null\n}",
+		},
+
+		"Having a request with a user that is not the session user should fail.": {
+			mock: func(m *macromock.Service) {},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodDelete, "/api/v1/macros/macro-id?user-id=user-id", nil)
+				r.Header.Set("Authorization", "Bearer other-user")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"request user is not the session user: not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Having a request from a user that is not the owner should fail.": {
+			mock: func(m *macromock.Service) {
+				m.On("DeleteMacro", mock.Anything, mock.Anything).Once().Return(fmt.Errorf("wanted error: %w", internalerrors.ErrNotValid))
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodDelete, "/api/v1/macros/macro-id?user-id=user-id", nil)
+				r.Header.Set("Authorization", "Bearer user-id")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"wanted error: not valid\",\n \"Header\": null\n}",
+		},
+
+		"Having a request should delete the macro.": {
+			mock: func(m *macromock.Service) {
+				exp := macro.DeleteMacroRequest{MacroID: "macro-id", UserID: "user-id"}
+				m.On("DeleteMacro", mock.Anything, exp).Once().Return(nil)
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodDelete, "/api/v1/macros/macro-id?user-id=user-id", nil)
+				r.Header.Set("Authorization", "Bearer user-id")
+				return r
+			},
+			expStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			mm := &macromock.Service{}
+			test.mock(mm)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       &roommock.Service{},
+				UserAppService:       newSessionUserAppService(),
+				MacroAppService:      mm,
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+		})
+	}
+}
+
+func TestAPIV1GetInitiative(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+
+	tests := map[string]struct {
+		mock          func(*initiativemock.Service)
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Having a missing room on the application service should fail.": {
+			mock: func(m *initiativemock.Service) {
+				m.On("GetInitiative", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error: %w", internalerrors.ErrNotValid))
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/rooms/room-id/initiative", nil)
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"wanted error: not valid\",\n \"Header\": null\n}",
+		},
+
+		"Having a room without combat should return an empty tracker.": {
+			mock: func(m *initiativemock.Service) {
+				expReq := initiative.GetInitiativeRequest{RoomID: "room-id"}
+				resp := &initiative.GetInitiativeResponse{Initiative: model.Initiative{RoomID: "room-id"}}
+				m.On("GetInitiative", mock.Anything, expReq).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/rooms/room-id/initiative", nil)
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody: `{
+ "room_id": "room-id",
+ "round": 0,
+ "turn": 0,
+ "combatants": []
+}`,
+		},
+
+		"Having a room with combat should return the tracker.": {
+			mock: func(m *initiativemock.Service) {
+				expReq := initiative.GetInitiativeRequest{RoomID: "room-id"}
+				resp := &initiative.GetInitiativeResponse{Initiative: model.Initiative{
+					RoomID:    "room-id",
+					UpdatedAt: t0,
+					Round:     2,
+					Turn:      1,
+					Combatants: []model.InitiativeCombatant{
+						{ID: "c1", Name: "Goblin", Initiative: 18, DiceRollID: "dr1"},
+						{ID: "c2", Name: "Bilbo", UserID: "user-id", Initiative: 12},
+					},
+				}}
+				m.On("GetInitiative", mock.Anything, expReq).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/rooms/room-id/initiative", nil)
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody: `{
+ "room_id": "room-id",
+ "updated_at": "1912-06-23T01:02:03Z",
+ "round": 2,
+ "turn": 1,
+ "combatants": [
+  {
+   "id": "c1",
+   "name": "Goblin",
+   "initiative": 18,
+   "dice_roll_id": "dr1"
   },
   {
-   "id": "dr2",
+   "id": "c2",
+   "name": "Bilbo",
+   "user_id": "user-id",
+   "initiative": 12
+  }
+ ]
+}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			mi := &initiativemock.Service{}
+			test.mock(mi)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       newOpenRoomAppService(),
+				UserAppService:       &usermock.Service{},
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: mi,
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+		})
+	}
+}
+
+func TestAPIV1AddCombatant(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+
+	tests := map[string]struct {
+		mock          func(*initiativemock.Service)
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Having a request without user ID should fail.": {
+			mock: func(m *initiativemock.Service) {},
+			req: func() *http.Request {
+				body := `{"name": "Goblin", "initiative": 12}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/initiative/combatants", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"user_id is required\",\n \"Header\": null\n}",
+		},
+
+		"Having a request with a user that is not the session user should fail.": {
+			mock: func(m *initiativemock.Service) {},
+			req: func() *http.Request {
+				body := `{"user_id": "user-id", "combatant_user_id": "user-id"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/initiative/combatants", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer other-user")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"request user is not the session user: not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Having a user that is already a combatant should fail.": {
+			mock: func(m *initiativemock.Service) {
+				m.On("AddCombatant", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error: %w", internalerrors.ErrAlreadyExists))
+			},
+			req: func() *http.Request {
+				body := `{"user_id": "user-id", "combatant_user_id": "user-id"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/initiative/combatants", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-id")
+				return r
+			},
+			expStatusCode: http.StatusConflict,
+			expBody:       "{\n \"Code\": 409,\n \"Message\": \"wanted error: already exists\",\n \"Header\": null\n}",
+		},
+
+		"Having a correct request should add the combatant.": {
+			mock: func(m *initiativemock.Service) {
+				expReq := initiative.AddCombatantRequest{RoomID: "room-id", UserID: "user-id", Name: "Goblin", Expression: "1d20+2"}
+				c := model.InitiativeCombatant{ID: "c1", Name: "Goblin", Initiative: 18, DiceRollID: "dr1"}
+				resp := &initiative.AddCombatantResponse{
+					Initiative: model.Initiative{RoomID: "room-id", UpdatedAt: t0, Round: 1, Combatants: []model.InitiativeCombatant{c}},
+					Combatant:  c,
+				}
+				m.On("AddCombatant", mock.Anything, expReq).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				body := `{"user_id": "user-id", "name": "Goblin", "expression": "1d20+2"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/initiative/combatants", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-id")
+				return r
+			},
+			expStatusCode: http.StatusCreated,
+			expBody: `{
+ "initiative": {
+  "room_id": "room-id",
+  "updated_at": "1912-06-23T01:02:03Z",
+  "round": 1,
+  "turn": 0,
+  "combatants": [
+   {
+    "id": "c1",
+    "name": "Goblin",
+    "initiative": 18,
+    "dice_roll_id": "dr1"
+   }
+  ]
+ },
+ "combatant": {
+  "id": "c1",
+  "name": "Goblin",
+  "initiative": 18,
+  "dice_roll_id": "dr1"
+ }
+}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			mi := &initiativemock.Service{}
+			test.mock(mi)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       &roommock.Service{},
+				UserAppService:       newSessionUserAppService(),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: mi,
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+		})
+	}
+}
+
+func TestAPIV1InitiativeTurns(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	i := model.Initiative{RoomID: "room-id", UpdatedAt: t0, Round: 3, Turn: 0, Combatants: []model.InitiativeCombatant{{ID: "c1", Name: "Goblin", Initiative: 18}}}
+	expBody := `{
+ "room_id": "room-id",
+ "updated_at": "1912-06-23T01:02:03Z",
+ "round": 3,
+ "turn": 0,
+ "combatants": [
+  {
+   "id": "c1",
+   "name": "Goblin",
+   "initiative": 18
+  }
+ ]
+}`
+
+	tests := map[string]struct {
+		mock          func(*initiativemock.Service)
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Having a next turn request without user ID should fail.": {
+			mock: func(m *initiativemock.Service) {},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/initiative/next", strings.NewReader(`{}`))
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"user_id is required\",\n \"Header\": null\n}",
+		},
+
+		"Having a next turn request without session should fail.": {
+			mock: func(m *initiativemock.Service) {},
+			req: func() *http.Request {
+				body := `{"user_id": "user-id"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/initiative/next", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"session token is required: not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Having a next turn request should move the turn to the next combatant.": {
+			mock: func(m *initiativemock.Service) {
+				expReq := initiative.NextTurnRequest{RoomID: "room-id", UserID: "user-id"}
+				m.On("NextTurn", mock.Anything, expReq).Once().Return(&initiative.NextTurnResponse{Initiative: i}, nil)
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/initiative/next", strings.NewReader(`{"user_id": "user-id"}`))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-id")
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody:       expBody,
+		},
+
+		"Having a previous turn request on the first combat turn should fail.": {
+			mock: func(m *initiativemock.Service) {
+				m.On("PreviousTurn", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error: %w", internalerrors.ErrNotValid))
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/initiative/previous", strings.NewReader(`{"user_id": "user-id"}`))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-id")
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"wanted error: not valid\",\n \"Header\": null\n}",
+		},
+
+		"Having a previous turn request should move the turn to the previous combatant.": {
+			mock: func(m *initiativemock.Service) {
+				expReq := initiative.PreviousTurnRequest{RoomID: "room-id", UserID: "user-id"}
+				m.On("PreviousTurn", mock.Anything, expReq).Once().Return(&initiative.PreviousTurnResponse{Initiative: i}, nil)
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/initiative/previous", strings.NewReader(`{"user_id": "user-id"}`))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-id")
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody:       expBody,
+		},
+
+		"Having a remove combatant request without user ID should fail.": {
+			mock: func(m *initiativemock.Service) {},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodDelete, "/api/v1/rooms/room-id/initiative/combatants/c2", nil)
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"user-id is required\",\n \"Header\": null\n}",
+		},
+
+		"Having a remove combatant request should remove the combatant.": {
+			mock: func(m *initiativemock.Service) {
+				expReq := initiative.RemoveCombatantRequest{RoomID: "room-id", UserID: "user-id", CombatantID: "c2"}
+				m.On("RemoveCombatant", mock.Anything, expReq).Once().Return(&initiative.RemoveCombatantResponse{Initiative: i}, nil)
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodDelete, "/api/v1/rooms/room-id/initiative/combatants/c2?user-id=user-id", nil)
+				r.Header.Set("Authorization", "Bearer user-id")
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody:       expBody,
+		},
+
+		"Having a roll initiative request of a missing combatant should fail.": {
+			mock: func(m *initiativemock.Service) {
+				m.On("RollInitiative", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error: %w", internalerrors.ErrMissing))
+			},
+			req: func() *http.Request {
+				body := `{"user_id": "user-id", "expression": "1d20"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/initiative/combatants/c2/roll", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-id")
+				return r
+			},
+			expStatusCode: http.StatusNotFound,
+			expBody:       "{\n \"Code\": 404,\n \"Message\": \"wanted error: is missing\",\n \"Header\": null\n}",
+		},
+
+		"Having an end combat request should end the combat.": {
+			mock: func(m *initiativemock.Service) {
+				expReq := initiative.EndCombatRequest{RoomID: "room-id", UserID: "user-id"}
+				m.On("EndCombat", mock.Anything, expReq).Once().Return(nil)
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodDelete, "/api/v1/rooms/room-id/initiative?user-id=user-id", nil)
+				r.Header.Set("Authorization", "Bearer user-id")
+				return r
+			},
+			expStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			mi := &initiativemock.Service{}
+			test.mock(mi)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       &roommock.Service{},
+				UserAppService:       newSessionUserAppService(),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: mi,
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Execute.
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.req())
+
+			// Check.
+			res := w.Result()
+			gotBody, err := io.ReadAll(res.Body)
+			require.NoError(err)
+			assert.Equal(test.expStatusCode, res.StatusCode)
+			assert.Equal(test.expBody, string(gotBody))
+			mi.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAPIV1WSRoomEvents(t *testing.T) {
+	unsubscribe := func() error { return nil }
+
+	tests := map[string]struct {
+		mock           func(*dicemock.Service, *roommock.Service, *usermock.Service)
+		query          string
+		expBody        string
+		expDialErr     bool
+		expErr         bool
+		expCloseStatus websocket.StatusCode
+	}{
+		"Subscribing to dice roll created events in a room using websocket should subscribe and use the handler to send the events.": {
+			mock: func(m *dicemock.Service, mr *roommock.Service, mu *usermock.Service) {
+				// Expect subscription and send a dice roll created event in the moment the subscription is made.
+				m.On("SubscribeDiceRollCreated", mock.Anything, mock.Anything).Once().Return(&dice.SubscribeDiceRollCreatedResponse{UnsubscribeFunc: unsubscribe}, nil).Run(func(args mock.Arguments) {
+					req := args[1].(dice.SubscribeDiceRollCreatedRequest)
+					_ = req.EventHandler(context.TODO(), model.EventDiceRollCreated{
+						DiceRoll: model.DiceRoll{},
+					})
+				})
+				mr.On("SubscribeRoomClosed", mock.Anything, mock.Anything).Once().Return(&room.SubscribeRoomClosedResponse{UnsubscribeFunc: unsubscribe}, nil)
+			},
+			expBody: "{\"metadata\":{\"type\":\"EventDiceRollCreated\"}}\n",
+		},
+
+		"Subscribing with a session should receive the events as the session user.": {
+			mock: func(m *dicemock.Service, mr *roommock.Service, mu *usermock.Service) {
+				authReq := user.AuthenticateUserRequest{SessionToken: "user-token", RoomID: "test-id"}
+				mu.On("AuthenticateUser", mock.Anything, authReq).Once().Return(&user.AuthenticateUserResponse{User: model.User{ID: "user-id", RoomID: "test-id"}}, nil)
+				expSub := mock.MatchedBy(func(r dice.SubscribeDiceRollCreatedRequest) bool {
+					return r.RoomID == "test-id" && r.ViewerUserID == "user-id"
+				})
+				m.On("SubscribeDiceRollCreated", mock.Anything, expSub).Once().Return(&dice.SubscribeDiceRollCreatedResponse{UnsubscribeFunc: unsubscribe}, nil).Run(func(args mock.Arguments) {
+					req := args[1].(dice.SubscribeDiceRollCreatedRequest)
+					_ = req.EventHandler(context.TODO(), model.EventDiceRollCreated{
+						DiceRoll: model.DiceRoll{},
+					})
+				})
+				mr.On("SubscribeRoomClosed", mock.Anything, mock.Anything).Once().Return(&room.SubscribeRoomClosedResponse{UnsubscribeFunc: unsubscribe}, nil)
+			},
+			query:   "?session-token=user-token",
+			expBody: "{\"metadata\":{\"type\":\"EventDiceRollCreated\"}}\n",
+		},
+
+		"Subscribing with an invalid session should fail.": {
+			mock: func(m *dicemock.Service, mr *roommock.Service, mu *usermock.Service) {
+				mu.On("AuthenticateUser", mock.Anything, mock.Anything).Once().Return(nil, internalerrors.ErrNotAllowed)
+			},
+			query:      "?session-token=wrong-token",
+			expDialErr: true,
+		},
+
+		"Subscribing to a protected room without session should fail.": {
+			mock: func(m *dicemock.Service, mr *roommock.Service, mu *usermock.Service) {
+				resp := &room.GetRoomResponse{Room: model.Room{ID: "test-id", PasswordHash: "hash"}}
+				mr.On("GetRoom", mock.Anything, room.GetRoomRequest{ID: "test-id"}).Once().Return(resp, nil)
+			},
+			expDialErr: true,
+		},
+
+		"Having an error while subscribing should return a websocket error.": {
+			mock: func(m *dicemock.Service, mr *roommock.Service, mu *usermock.Service) {
+				// Expect subscription and send a dice roll created event in the moment the subscription is made.
+				m.On("SubscribeDiceRollCreated", mock.Anything, mock.Anything).Once().Return(&dice.SubscribeDiceRollCreatedResponse{}, errors.New("wanted error"))
+			},
+			expErr:         true,
+			expCloseStatus: websocket.StatusInternalError,
+		},
+
+		"Having the room closed should close the websocket.": {
+			mock: func(m *dicemock.Service, mr *roommock.Service, mu *usermock.Service) {
+				m.On("SubscribeDiceRollCreated", mock.Anything, mock.Anything).Once().Return(&dice.SubscribeDiceRollCreatedResponse{UnsubscribeFunc: unsubscribe}, nil)
+				// Close the room in the moment the subscription is made.
+				mr.On("SubscribeRoomClosed", mock.Anything, mock.Anything).Once().Return(&room.SubscribeRoomClosedResponse{UnsubscribeFunc: unsubscribe}, nil).Run(func(args mock.Arguments) {
+					req := args[1].(room.SubscribeRoomClosedRequest)
+					_ = req.EventHandler(context.TODO(), model.EventRoomClosed{RoomID: "test-id", Deleted: true})
+				})
+			},
+			expErr:         true,
+			expCloseStatus: websocket.StatusGoingAway,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			md := &dicemock.Service{}
+			mr := &roommock.Service{}
+			mu := &usermock.Service{}
+			test.mock(md, mr, mu)
+			mr.On("GetRoom", mock.Anything, mock.Anything).Maybe().Return(&room.GetRoomResponse{}, nil)
+
+			// Prepare.
+			cfg := apiv1.Config{
+				DiceAppService:       md,
+				RoomAppService:       mr,
+				UserAppService:       mu,
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+			}
+			h, err := apiv1.New(cfg)
+			require.NoError(err)
+
+			// Prepare server and run, We use the server instead the regular handler because
+			// we need a full websocket handshake.
+			server := httptest.NewServer(h)
+			defer server.Close()
+
+			// Create a websocket connection.
+			c, _, err := websocket.Dial(context.TODO(), server.URL+"/api/v1/ws/rooms/test-id"+test.query, nil)
+			if test.expDialErr {
+				assert.Error(err)
+				return
+			}
+			require.NoError(err)
+			defer c.Close(websocket.StatusNormalClosure, "")
+
+			// Read a single message.
+			_, gotBody, err := c.Read(context.TODO())
+
+			// Check.
+			if test.expErr {
+				assert.Error(err)
+				assert.Equal(test.expCloseStatus, websocket.CloseStatus(err))
+			} else if assert.NoError(err) {
+				assert.Equal(test.expBody, string(gotBody))
+			}
+		})
+	}
+}
+
+func TestAPIV1ListDecks(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+
+	tests := map[string]struct {
+		mock          func(*deckmock.Service)
+		req           func() *http.Request
+		expStatusCode int
+		expBody       string
+	}{
+		"Having an error on the service should fail.": {
+			mock: func(m *deckmock.Service) {
+				m.On("ListDecks", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error: %w", internalerrors.ErrNotValid))
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/rooms/room-id/decks", nil)
+				return r
+			},
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"wanted error: not valid\",\n \"Header\": null\n}",
+		},
+
+		"Having a correct request should list the room decks without exposing the draw pile order.": {
+			mock: func(m *deckmock.Service) {
+				expReq := deck.ListDecksRequest{RoomID: "room-id"}
+				resp := &deck.ListDecksResponse{Decks: []model.Deck{
+					{
+						ID:          "deck-id",
+						CreatedAt:   t0,
+						RoomID:      "room-id",
+						Name:        "Fate",
+						Type:        model.DeckTypeCustom,
+						Cards:       []string{"A", "B", "C"},
+						DrawPile:    []string{"C"},
+						DiscardPile: []string{"A"},
+					},
+				}}
+				m.On("ListDecks", mock.Anything, expReq).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/rooms/room-id/decks", nil)
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody: `{
+ "items": [
+  {
+   "id": "deck-id",
    "created_at": "1912-06-23T01:02:03Z",
-   "user_id": "user-2",
-   "room_id": "room-2",
-   "dice": [
-    {
-     "id": "d3",
-     "type_id": "d20",
-     "side": 18
-    }
+   "room_id": "room-id",
+   "name": "Fate",
+   "type": "custom",
+   "cards": [
+    "A",
+    "B",
+    "C"
+   ],
+   "draw_pile_count": 1,
+   "discard_pile": [
+    "A"
+   ],
+   "in_play": [
+    "B"
    ]
   }
- ],
- "metadata": {
-  "first_cursor": "first",
-  "last_cursor": "last",
-  "has_next": true,
-  "has_previous": true
- }
+ ]
 }`,
 		},
 	}
@@ -539,14 +4932,19 @@ func TestAPIV1ListDiceRolls(t *testing.T) {
 			assert := assert.New(t)
 			require := require.New(t)
 
-			md := &dicemock.Service{}
+			md := &deckmock.Service{}
 			test.mock(md)
 
 			// Prepare.
 			cfg := apiv1.Config{
-				DiceAppService: md,
-				RoomAppService: &roommock.Service{},
-				UserAppService: &usermock.Service{},
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       newOpenRoomAppService(),
+				UserAppService:       &usermock.Service{},
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       md,
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
 			}
 			h, err := apiv1.New(cfg)
 			require.NoError(err)
@@ -565,63 +4963,197 @@ func TestAPIV1ListDiceRolls(t *testing.T) {
 	}
 }
 
-func TestAPIV1CreateRoom(t *testing.T) {
+func TestAPIV1DeckOperations(t *testing.T) {
 	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	d := model.Deck{
+		ID:          "deck-id",
+		CreatedAt:   t0,
+		RoomID:      "room-id",
+		Name:        "Action deck",
+		Type:        model.DeckTypeStandard,
+		Cards:       []string{"A♠", "2♠", "3♠"},
+		DrawPile:    []string{"3♠", "2♠"},
+		DiscardPile: []string{},
+	}
+	expDeckBody := `{
+  "id": "deck-id",
+  "created_at": "1912-06-23T01:02:03Z",
+  "room_id": "room-id",
+  "name": "Action deck",
+  "type": "standard",
+  "cards": [
+   "A♠",
+   "2♠",
+   "3♠"
+  ],
+  "draw_pile_count": 2,
+  "discard_pile": [],
+  "in_play": [
+   "A♠"
+  ]
+ }`
 
 	tests := map[string]struct {
-		mock          func(*roommock.Service)
+		mock          func(*deckmock.Service)
 		req           func() *http.Request
 		expStatusCode int
 		expBody       string
 	}{
-		"Having a request without name should fail.": {
-			mock: func(m *roommock.Service) {},
+		"Creating a deck without user ID should fail.": {
+			mock: func(m *deckmock.Service) {},
 			req: func() *http.Request {
-				body := `{"name": ""}`
-				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms", strings.NewReader(body))
+				body := `{"name": "Action deck", "type": "standard"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/decks", strings.NewReader(body))
 				r.Header.Set("Content-Type", "application/json")
 				return r
 			},
 			expStatusCode: http.StatusBadRequest,
-			expBody:       "{\n \"Code\": 400,\n \"Message\": \"name is required\",\n \"Header\": null\n}",
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"user_id is required\",\n \"Header\": null\n}",
 		},
 
-		"Having a correct request that fails creating the the room should fail.": {
-			mock: func(m *roommock.Service) {
-				m.On("CreateRoom", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error"))
+		"Drawing cards with a user that is not the session user should fail.": {
+			mock: func(m *deckmock.Service) {},
+			req: func() *http.Request {
+				body := `{"user_id": "user-id", "quantity": 1}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/decks/deck-id/draw", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer other-user")
+				return r
+			},
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"request user is not the session user: not allowed\",\n \"Header\": null\n}",
+		},
+
+		"Creating a deck should create the deck.": {
+			mock: func(m *deckmock.Service) {
+				expReq := deck.CreateDeckRequest{RoomID: "room-id", UserID: "user-id", Name: "Fate", Type: model.DeckTypeCustom, Cards: []string{"A", "B"}}
+				resp := &deck.CreateDeckResponse{Deck: model.Deck{
+					ID:          "deck-id",
+					CreatedAt:   t0,
+					RoomID:      "room-id",
+					Name:        "Fate",
+					Type:        model.DeckTypeCustom,
+					Cards:       []string{"A", "B"},
+					DrawPile:    []string{"B", "A"},
+					DiscardPile: []string{},
+				}}
+				m.On("CreateDeck", mock.Anything, expReq).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				body := `{"user_id": "user-id", "name": "Fate", "type": "custom", "cards": ["A", "B"]}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/decks", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-id")
+				return r
+			},
+			expStatusCode: http.StatusCreated,
+			expBody: `{
+ "id": "deck-id",
+ "created_at": "1912-06-23T01:02:03Z",
+ "room_id": "room-id",
+ "name": "Fate",
+ "type": "custom",
+ "cards": [
+  "A",
+  "B"
+ ],
+ "draw_pile_count": 2,
+ "discard_pile": [],
+ "in_play": []
+}`,
+		},
+
+		"Drawing cards on a concurrently modified deck should fail.": {
+			mock: func(m *deckmock.Service) {
+				m.On("DrawCards", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error: %w", internalerrors.ErrConflict))
+			},
+			req: func() *http.Request {
+				body := `{"user_id": "user-id"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/decks/deck-id/draw", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-id")
+				return r
+			},
+			expStatusCode: http.StatusConflict,
+			expBody:       "{\n \"Code\": 409,\n \"Message\": \"wanted error: conflict\",\n \"Header\": null\n}",
+		},
+
+		"Drawing cards should return the drawn cards and the deck.": {
+			mock: func(m *deckmock.Service) {
+				expReq := deck.DrawCardsRequest{RoomID: "room-id", UserID: "user-id", DeckID: "deck-id", Quantity: 1}
+				resp := &deck.DrawCardsResponse{
+					CardDraw: model.CardDraw{ID: "draw-id", CreatedAt: t0, RoomID: "room-id", UserID: "user-id", DeckID: "deck-id", DeckName: "Action deck", Cards: []string{"A♠"}},
+					Deck:     d,
+				}
+				m.On("DrawCards", mock.Anything, expReq).Once().Return(resp, nil)
+			},
+			req: func() *http.Request {
+				body := `{"user_id": "user-id", "quantity": 1}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/decks/deck-id/draw", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-id")
+				return r
+			},
+			expStatusCode: http.StatusOK,
+			expBody: `{
+ "card_draw": {
+  "id": "draw-id",
+  "created_at": "1912-06-23T01:02:03Z",
+  "user_id": "user-id",
+  "deck_id": "deck-id",
+  "cards": [
+   "A♠"
+  ]
+ },
+ "deck": ` + expDeckBody + `
+}`,
+		},
+
+		"Discarding cards of a missing deck should fail.": {
+			mock: func(m *deckmock.Service) {
+				m.On("DiscardCards", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error: %w", internalerrors.ErrMissing))
+			},
+			req: func() *http.Request {
+				body := `{"user_id": "user-id"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/decks/deck-id/discard", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-id")
+				return r
+			},
+			expStatusCode: http.StatusNotFound,
+			expBody:       "{\n \"Code\": 404,\n \"Message\": \"wanted error: is missing\",\n \"Header\": null\n}",
+		},
+
+		"Discarding cards should discard the cards.": {
+			mock: func(m *deckmock.Service) {
+				expReq := deck.DiscardCardsRequest{RoomID: "room-id", UserID: "user-id", DeckID: "deck-id", Cards: []string{"2♠"}}
+				m.On("DiscardCards", mock.Anything, expReq).Once().Return(&deck.DiscardCardsResponse{Deck: d}, nil)
 			},
 			req: func() *http.Request {
-				body := `{"name": "test-room"}`
-				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms", strings.NewReader(body))
+				body := `{"user_id": "user-id", "cards": ["2♠"]}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/decks/deck-id/discard", strings.NewReader(body))
 				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-id")
 				return r
 			},
-			expStatusCode: http.StatusInternalServerError,
-			expBody:       "{\n \"Code\": 500,\n \"Message\": \"wanted error\",\n \"Header\": null\n}",
+			expStatusCode: http.StatusOK,
+			expBody:       strings.ReplaceAll(expDeckBody, "\n ", "\n"),
 		},
 
-		"Having a correct request should create the room.": {
-			mock: func(m *roommock.Service) {
-				exp := room.CreateRoomRequest{Name: "test-room"}
-				resp := &room.CreateRoomResponse{Room: model.Room{
-					Name:      "test-room",
-					CreatedAt: t0,
-					ID:        "room-id",
-				}}
-				m.On("CreateRoom", mock.Anything, exp).Once().Return(resp, nil)
+		"Reshuffling a deck should reshuffle the deck.": {
+			mock: func(m *deckmock.Service) {
+				expReq := deck.ReshuffleDeckRequest{RoomID: "room-id", UserID: "user-id", DeckID: "deck-id", All: true}
+				m.On("ReshuffleDeck", mock.Anything, expReq).Once().Return(&deck.ReshuffleDeckResponse{Deck: d}, nil)
 			},
 			req: func() *http.Request {
-				body := `{"name": "test-room"}`
-				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms", strings.NewReader(body))
+				body := `{"user_id": "user-id", "all": true}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/decks/deck-id/reshuffle", strings.NewReader(body))
 				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-id")
 				return r
 			},
-			expStatusCode: http.StatusCreated,
-			expBody: `{
- "id": "room-id",
- "created_at": "1912-06-23T01:02:03Z",
- "name": "test-room"
-}`,
+			expStatusCode: http.StatusOK,
+			expBody:       strings.ReplaceAll(expDeckBody, "\n ", "\n"),
 		},
 	}
 
@@ -630,14 +5162,19 @@ func TestAPIV1CreateRoom(t *testing.T) {
 			assert := assert.New(t)
 			require := require.New(t)
 
-			mr := &roommock.Service{}
-			test.mock(mr)
+			md := &deckmock.Service{}
+			test.mock(md)
 
 			// Prepare.
 			cfg := apiv1.Config{
-				DiceAppService: &dicemock.Service{},
-				RoomAppService: mr,
-				UserAppService: &usermock.Service{},
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       &roommock.Service{},
+				UserAppService:       newSessionUserAppService(),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       md,
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
 			}
 			h, err := apiv1.New(cfg)
 			require.NoError(err)
@@ -652,52 +5189,78 @@ func TestAPIV1CreateRoom(t *testing.T) {
 			require.NoError(err)
 			assert.Equal(test.expStatusCode, res.StatusCode)
 			assert.Equal(test.expBody, string(gotBody))
+			md.AssertExpectations(t)
 		})
 	}
 }
 
-func TestAPIV1GetRoom(t *testing.T) {
+func TestAPIV1ListOracleTables(t *testing.T) {
 	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
 
 	tests := map[string]struct {
-		mock          func(*roommock.Service)
+		mock          func(*oraclemock.Service)
 		req           func() *http.Request
 		expStatusCode int
 		expBody       string
 	}{
-		"Having an error while getting the room should fail.": {
-			mock: func(m *roommock.Service) {
-				m.On("GetRoom", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error"))
+		"Having an error on the service should fail.": {
+			mock: func(m *oraclemock.Service) {
+				m.On("ListTables", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error: %w", internalerrors.ErrNotValid))
 			},
 			req: func() *http.Request {
-				r, _ := http.NewRequest(http.MethodGet, "/api/v1/rooms/test-id", nil)
-				r.Header.Set("Content-Type", "application/json")
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/rooms/room-id/oracle-tables", nil)
 				return r
 			},
-			expStatusCode: http.StatusInternalServerError,
-			expBody:       "{\n \"Code\": 500,\n \"Message\": \"wanted error\",\n \"Header\": null\n}",
+			expStatusCode: http.StatusBadRequest,
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"wanted error: not valid\",\n \"Header\": null\n}",
 		},
 
-		"Having a correct request should get the room.": {
-			mock: func(m *roommock.Service) {
-				exp := room.GetRoomRequest{ID: "test-id"}
-				resp := &room.GetRoomResponse{Room: model.Room{
-					Name:      "test-room",
-					CreatedAt: t0,
-					ID:        "room-id",
+		"Having a correct request should list the room oracle tables.": {
+			mock: func(m *oraclemock.Service) {
+				expReq := oracle.ListTablesRequest{RoomID: "room-id"}
+				resp := &oracle.ListTablesResponse{Tables: []model.OracleTable{
+					{
+						ID:        "table-id",
+						CreatedAt: t0,
+						RoomID:    "room-id",
+						Name:      "Encounters",
+						Dice:      "1d6",
+						Entries: []model.OracleTableEntry{
+							{Min: 1, Max: 3, Result: "Nothing"},
+							{Min: 4, Max: 6, Result: "Monsters!", TableName: "Monsters"},
+						},
+					},
 				}}
-				m.On("GetRoom", mock.Anything, exp).Once().Return(resp, nil)
+				m.On("ListTables", mock.Anything, expReq).Once().Return(resp, nil)
 			},
 			req: func() *http.Request {
-				r, _ := http.NewRequest(http.MethodGet, "/api/v1/rooms/test-id", nil)
-				r.Header.Set("Content-Type", "application/json")
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/rooms/room-id/oracle-tables", nil)
 				return r
 			},
 			expStatusCode: http.StatusOK,
 			expBody: `{
- "id": "room-id",
- "created_at": "1912-06-23T01:02:03Z",
- "name": "test-room"
+ "items": [
+  {
+   "id": "table-id",
+   "created_at": "1912-06-23T01:02:03Z",
+   "room_id": "room-id",
+   "name": "Encounters",
+   "dice": "1d6",
+   "entries": [
+    {
+     "min": 1,
+     "max": 3,
+     "result": "Nothing"
+    },
+    {
+     "min": 4,
+     "max": 6,
+     "result": "Monsters!",
+     "table": "Monsters"
+    }
+   ]
+  }
+ ]
 }`,
 		},
 	}
@@ -707,14 +5270,19 @@ func TestAPIV1GetRoom(t *testing.T) {
 			assert := assert.New(t)
 			require := require.New(t)
 
-			mr := &roommock.Service{}
-			test.mock(mr)
+			mo := &oraclemock.Service{}
+			test.mock(mo)
 
 			// Prepare.
 			cfg := apiv1.Config{
-				DiceAppService: &dicemock.Service{},
-				RoomAppService: mr,
-				UserAppService: &usermock.Service{},
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       newOpenRoomAppService(),
+				UserAppService:       &usermock.Service{},
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     mo,
+				StatsAppService:      &statsmock.Service{},
 			}
 			h, err := apiv1.New(cfg)
 			require.NoError(err)
@@ -733,191 +5301,172 @@ func TestAPIV1GetRoom(t *testing.T) {
 	}
 }
 
-func TestAPIV1CreateUser(t *testing.T) {
+func TestAPIV1OracleTableOperations(t *testing.T) {
 	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	tr := model.OracleTableRoll{
+		ID:        "table-roll-id",
+		CreatedAt: t0,
+		RoomID:    "room-id",
+		UserID:    "user-id",
+		TableID:   "table-id",
+		Results: []model.OracleTableResult{
+			{TableName: "Encounters", DiceRollID: "dice-roll-id-1", Value: 5, Result: "Monsters!"},
+			{TableName: "Monsters", DiceRollID: "dice-roll-id-2", Value: 1, Result: "Goblins"},
+		},
+	}
+	expTableRollBody := `{
+  "id": "table-roll-id",
+  "created_at": "1912-06-23T01:02:03Z",
+  "user_id": "user-id",
+  "table_id": "table-id",
+  "results": [
+   {
+    "table": "Encounters",
+    "dice_roll_id": "dice-roll-id-1",
+    "value": 5,
+    "result": "Monsters!"
+   },
+   {
+    "table": "Monsters",
+    "dice_roll_id": "dice-roll-id-2",
+    "value": 1,
+    "result": "Goblins"
+   }
+  ]
+ }`
 
 	tests := map[string]struct {
-		mock          func(*usermock.Service)
+		mock          func(*oraclemock.Service)
 		req           func() *http.Request
 		expStatusCode int
 		expBody       string
 	}{
-		"Having a request without name should fail.": {
-			mock: func(m *usermock.Service) {},
+		"Creating a table without user ID should fail.": {
+			mock: func(m *oraclemock.Service) {},
 			req: func() *http.Request {
-				body := `{"name": "", "room_id": "room1-id"}`
-				r, _ := http.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(body))
+				body := `{"name": "Encounters", "dice": "1d6", "format": "csv", "data": "1-6,Nothing"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/oracle-tables", strings.NewReader(body))
 				r.Header.Set("Content-Type", "application/json")
 				return r
 			},
 			expStatusCode: http.StatusBadRequest,
-			expBody:       "{\n \"Code\": 400,\n \"Message\": \"name is required\",\n \"Header\": null\n}",
+			expBody:       "{\n \"Code\": 400,\n \"Message\": \"user_id is required\",\n \"Header\": null\n}",
 		},
 
-		"Having a request without room id should fail.": {
-			mock: func(m *usermock.Service) {},
+		"Rolling a table with a user that is not the session user should fail.": {
+			mock: func(m *oraclemock.Service) {},
 			req: func() *http.Request {
-				body := `{"name": "test1", "room_id": ""}`
-				r, _ := http.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(body))
+				body := `{"user_id": "user-id"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/oracle-tables/table-id/roll", strings.NewReader(body))
 				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer other-user")
 				return r
 			},
-			expStatusCode: http.StatusBadRequest,
-			expBody:       "{\n \"Code\": 400,\n \"Message\": \"room_id is required\",\n \"Header\": null\n}",
+			expStatusCode: http.StatusForbidden,
+			expBody:       "{\n \"Code\": 403,\n \"Message\": \"request user is not the session user: not allowed\",\n \"Header\": null\n}",
 		},
 
-		"Having a correct request that fails creating the the user should fail.": {
-			mock: func(m *usermock.Service) {
-				m.On("CreateUser", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error"))
+		"Creating a table with an existing name should fail.": {
+			mock: func(m *oraclemock.Service) {
+				m.On("CreateTable", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error: %w", internalerrors.ErrAlreadyExists))
 			},
 			req: func() *http.Request {
-				body := `{"name": "test-room", "room_id": "test1-id"}`
-				r, _ := http.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(body))
+				body := `{"user_id": "user-id", "name": "Encounters", "dice": "1d6", "format": "csv", "data": "1-6,Nothing"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/oracle-tables", strings.NewReader(body))
 				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-id")
 				return r
 			},
-			expStatusCode: http.StatusInternalServerError,
-			expBody:       "{\n \"Code\": 500,\n \"Message\": \"wanted error\",\n \"Header\": null\n}",
+			expStatusCode: http.StatusConflict,
+			expBody:       "{\n \"Code\": 409,\n \"Message\": \"wanted error: already exists\",\n \"Header\": null\n}",
 		},
 
-		"Having a correct request should create the user.": {
-			mock: func(m *usermock.Service) {
-				exp := user.CreateUserRequest{Name: "test1", RoomID: "test1-id"}
-				resp := &user.CreateUserResponse{User: model.User{
-					ID:        "test1-id",
-					RoomID:    "test1-id",
-					Name:      "test1",
+		"Creating a table should create the table.": {
+			mock: func(m *oraclemock.Service) {
+				expReq := oracle.CreateTableRequest{RoomID: "room-id", UserID: "user-id", Name: "Encounters", Dice: "1d6", Format: oracle.TableFormatCSV, Data: "1-6,Nothing"}
+				resp := &oracle.CreateTableResponse{Table: model.OracleTable{
+					ID:        "table-id",
 					CreatedAt: t0,
+					RoomID:    "room-id",
+					Name:      "Encounters",
+					Dice:      "1d6",
+					Entries:   []model.OracleTableEntry{{Min: 1, Max: 6, Result: "Nothing"}},
 				}}
-				m.On("CreateUser", mock.Anything, exp).Once().Return(resp, nil)
+				m.On("CreateTable", mock.Anything, expReq).Once().Return(resp, nil)
 			},
 			req: func() *http.Request {
-				body := `{"name": "test1", "room_id": "test1-id"}`
-				r, _ := http.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(body))
+				body := `{"user_id": "user-id", "name": "Encounters", "dice": "1d6", "format": "csv", "data": "1-6,Nothing"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/oracle-tables", strings.NewReader(body))
 				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-id")
 				return r
 			},
 			expStatusCode: http.StatusCreated,
 			expBody: `{
- "id": "test1-id",
+ "id": "table-id",
  "created_at": "1912-06-23T01:02:03Z",
- "name": "test1",
- "room_id": "test1-id"
+ "room_id": "room-id",
+ "name": "Encounters",
+ "dice": "1d6",
+ "entries": [
+  {
+   "min": 1,
+   "max": 6,
+   "result": "Nothing"
+  }
+ ]
 }`,
 		},
-	}
-
-	for name, test := range tests {
-		t.Run(name, func(t *testing.T) {
-			assert := assert.New(t)
-			require := require.New(t)
-
-			mu := &usermock.Service{}
-			test.mock(mu)
-
-			// Prepare.
-			cfg := apiv1.Config{
-				DiceAppService: &dicemock.Service{},
-				RoomAppService: &roommock.Service{},
-				UserAppService: mu,
-			}
-			h, err := apiv1.New(cfg)
-			require.NoError(err)
-
-			// Execute.
-			w := httptest.NewRecorder()
-			h.ServeHTTP(w, test.req())
-
-			// Check.
-			res := w.Result()
-			gotBody, err := io.ReadAll(res.Body)
-			require.NoError(err)
-			assert.Equal(test.expStatusCode, res.StatusCode)
-			assert.Equal(test.expBody, string(gotBody))
-		})
-	}
-}
-
-func TestAPIV1ListUsers(t *testing.T) {
-	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
 
-	tests := map[string]struct {
-		mock          func(*usermock.Service)
-		req           func() *http.Request
-		expStatusCode int
-		expBody       string
-	}{
-		"Having a request without room id should fail.": {
-			mock: func(m *usermock.Service) {},
+		"Rolling a missing table should fail.": {
+			mock: func(m *oraclemock.Service) {
+				m.On("RollTable", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("wanted error: %w", internalerrors.ErrMissing))
+			},
 			req: func() *http.Request {
-				q := url.Values{}
-				q.Add("room-id", "")
-				r, _ := http.NewRequest(http.MethodGet, "/api/v1/users", nil)
-				r.URL.RawQuery = q.Encode()
+				body := `{"user_id": "user-id"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/oracle-tables/table-id/roll", strings.NewReader(body))
 				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-id")
 				return r
 			},
-			expStatusCode: http.StatusBadRequest,
-			expBody:       "{\n \"Code\": 400,\n \"Message\": \"room-id is required\",\n \"Header\": null\n}",
+			expStatusCode: http.StatusNotFound,
+			expBody:       "{\n \"Code\": 404,\n \"Message\": \"wanted error: is missing\",\n \"Header\": null\n}",
 		},
 
-		"Having a request that fails while listing users, should fail.": {
-			mock: func(m *usermock.Service) {
-				m.On("ListUsers", mock.Anything, mock.Anything).Once().Return(nil, errors.New("wanted error"))
+		"Rolling a table should return the table roll and the dice rolls.": {
+			mock: func(m *oraclemock.Service) {
+				expReq := oracle.RollTableRequest{RoomID: "room-id", UserID: "user-id", TableID: "table-id"}
+				resp := &oracle.RollTableResponse{TableRoll: tr}
+				m.On("RollTable", mock.Anything, expReq).Once().Return(resp, nil)
 			},
 			req: func() *http.Request {
-				q := url.Values{}
-				q.Add("room-id", "room-id")
-				r, _ := http.NewRequest(http.MethodGet, "/api/v1/users", nil)
-				r.URL.RawQuery = q.Encode()
+				body := `{"user_id": "user-id"}`
+				r, _ := http.NewRequest(http.MethodPost, "/api/v1/rooms/room-id/oracle-tables/table-id/roll", strings.NewReader(body))
 				r.Header.Set("Content-Type", "application/json")
+				r.Header.Set("Authorization", "Bearer user-id")
 				return r
 			},
-			expStatusCode: http.StatusInternalServerError,
-			expBody:       "{\n \"Code\": 500,\n \"Message\": \"wanted error\",\n \"Header\": null\n}",
+			expStatusCode: http.StatusCreated,
+			expBody: `{
+ "table_roll": ` + expTableRollBody + `,
+ "dice_rolls": []
+}`,
 		},
 
-		"Having a request should list the users.": {
-			mock: func(m *usermock.Service) {
-				exp := user.ListUsersRequest{RoomID: "room-id"}
-				resp := &user.ListUsersResponse{
-					Users: []model.User{
-						{
-							ID:        "test1-id",
-							RoomID:    "test1-id",
-							Name:      "test1",
-							CreatedAt: t0,
-						},
-						{
-							ID:        "test2-id",
-							RoomID:    "test2-id",
-							Name:      "test2",
-							CreatedAt: t0,
-						},
-					}}
-				m.On("ListUsers", mock.Anything, exp).Once().Return(resp, nil)
+		"Listing the table rolls should return the room table rolls.": {
+			mock: func(m *oraclemock.Service) {
+				expReq := oracle.ListTableRollsRequest{RoomID: "room-id"}
+				resp := &oracle.ListTableRollsResponse{TableRolls: []model.OracleTableRoll{tr}}
+				m.On("ListTableRolls", mock.Anything, expReq).Once().Return(resp, nil)
 			},
 			req: func() *http.Request {
-				q := url.Values{}
-				q.Add("room-id", "room-id")
-				r, _ := http.NewRequest(http.MethodGet, "/api/v1/users", nil)
-				r.URL.RawQuery = q.Encode()
-				r.Header.Set("Content-Type", "application/json")
+				r, _ := http.NewRequest(http.MethodGet, "/api/v1/rooms/room-id/oracle-rolls", nil)
 				return r
 			},
 			expStatusCode: http.StatusOK,
 			expBody: `{
  "items": [
-  {
-   "id": "test1-id",
-   "name": "test1",
-   "created_at": "1912-06-23T01:02:03Z"
-  },
-  {
-   "id": "test2-id",
-   "name": "test2",
-   "created_at": "1912-06-23T01:02:03Z"
-  }
+  ` + strings.ReplaceAll(expTableRollBody, "\n ", "\n  ") + `
  ]
 }`,
 		},
@@ -928,14 +5477,19 @@ func TestAPIV1ListUsers(t *testing.T) {
 			assert := assert.New(t)
 			require := require.New(t)
 
-			mu := &usermock.Service{}
-			test.mock(mu)
+			mo := &oraclemock.Service{}
+			test.mock(mo)
 
 			// Prepare.
 			cfg := apiv1.Config{
-				DiceAppService: &dicemock.Service{},
-				RoomAppService: &roommock.Service{},
-				UserAppService: mu,
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       newOpenRoomAppService(),
+				UserAppService:       newSessionUserAppService(),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     mo,
+				StatsAppService:      &statsmock.Service{},
 			}
 			h, err := apiv1.New(cfg)
 			require.NoError(err)
@@ -949,75 +5503,8 @@ func TestAPIV1ListUsers(t *testing.T) {
 			gotBody, err := io.ReadAll(res.Body)
 			require.NoError(err)
 			assert.Equal(test.expStatusCode, res.StatusCode)
+			mo.AssertExpectations(t)
 			assert.Equal(test.expBody, string(gotBody))
 		})
 	}
 }
-
-func TestAPIV1WSRoomEvents(t *testing.T) {
-	tests := map[string]struct {
-		mock    func(*dicemock.Service)
-		expBody string
-		expErr  bool
-	}{
-		"Subscribing to dice roll created events in a room using websocket should subscribe and use the handler to send the events.": {
-			mock: func(m *dicemock.Service) {
-				// Expect subscription and send a dice roll created event in the moment the subscription is made.
-				m.On("SubscribeDiceRollCreated", mock.Anything, mock.Anything).Once().Return(&dice.SubscribeDiceRollCreatedResponse{}, nil).Run(func(args mock.Arguments) {
-					req := args[1].(dice.SubscribeDiceRollCreatedRequest)
-					_ = req.EventHandler(context.TODO(), model.EventDiceRollCreated{
-						DiceRoll: model.DiceRoll{},
-					})
-				})
-			},
-			expBody: "{\"metadata\":{\"type\":\"EventDiceRollCreated\"}}\n",
-		},
-
-		"Having an error while subscribing should return a websocket error.": {
-			mock: func(m *dicemock.Service) {
-				// Expect subscription and send a dice roll created event in the moment the subscription is made.
-				m.On("SubscribeDiceRollCreated", mock.Anything, mock.Anything).Once().Return(&dice.SubscribeDiceRollCreatedResponse{}, errors.New("wanted error"))
-			},
-			expErr: true,
-		},
-	}
-
-	for name, test := range tests {
-		t.Run(name, func(t *testing.T) {
-			assert := assert.New(t)
-			require := require.New(t)
-
-			md := &dicemock.Service{}
-			test.mock(md)
-
-			// Prepare.
-			cfg := apiv1.Config{
-				DiceAppService: md,
-				RoomAppService: &roommock.Service{},
-				UserAppService: &usermock.Service{},
-			}
-			h, err := apiv1.New(cfg)
-			require.NoError(err)
-
-			// Prepare server and run, We use the server instead the regular handler because
-			// we need a full websocket handshake.
-			server := httptest.NewServer(h)
-			defer server.Close()
-
-			// Create a websocket connection.
-			c, _, err := websocket.Dial(context.TODO(), server.URL+"/api/v1/ws/rooms/test-id", nil)
-			require.NoError(err)
-			defer c.Close(websocket.StatusNormalClosure, "")
-
-			// Read a single message.
-			_, gotBody, err := c.Read(context.TODO())
-
-			// Check.
-			if test.expErr {
-				assert.Error(err)
-			} else if assert.NoError(err) {
-				assert.Equal(test.expBody, string(gotBody))
-			}
-		})
-	}
-}
@@ -3,7 +3,9 @@ package apiv1
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/emicklei/go-restful/v3"
 	"nhooyr.io/websocket"
@@ -13,6 +15,8 @@ import (
 	"github.com/rollify/rollify/internal/internalerrors"
 	"github.com/rollify/rollify/internal/log"
 	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/room"
+	"github.com/rollify/rollify/internal/user"
 )
 
 func (a *apiv1) pong() restful.RouteFunction {
@@ -34,8 +38,20 @@ func (a *apiv1) listDiceTypes() restful.RouteFunction {
 	return func(req *restful.Request, resp *restful.Response) {
 		logger.Debugf("handler called")
 
+		// Map request.
+		mReq := mapAPIToModelListDiceTypes(req.Request.URL.Query())
+
+		// Protected rooms can only be read by their users.
+		if mReq.RoomID != "" {
+			err := a.checkRoomAccess(req, mReq.RoomID)
+			if err != nil {
+				writeResponseError(logger, resp, errToStatusCode(err), err)
+				return
+			}
+		}
+
 		// Execute.
-		mResp, err := a.diceAppSvc.ListDiceTypes(req.Request.Context())
+		mResp, err := a.diceAppSvc.ListDiceTypes(req.Request.Context(), mReq)
 		if err != nil {
 			writeResponseError(logger, resp, errToStatusCode(err), err)
 			logger.Warningf("error processing request: %s", err)
@@ -64,12 +80,32 @@ func (a *apiv1) createDiceRoll() restful.RouteFunction {
 			writeResponseError(logger, resp, http.StatusBadRequest, err)
 			return
 		}
-		mReq, err := mapAPIToModelcreateDiceRoll(*entReq)
+
+		// Custom die types are registered on the rooms, we need them to map the request.
+		var roomDieTypes []model.DieType
+		if entReq.RoomID != "" && hasCustomDieTypeIDs(entReq.DiceTypeIDs) {
+			dtResp, err := a.diceAppSvc.ListDiceTypes(req.Request.Context(), dice.ListDiceTypesRequest{RoomID: entReq.RoomID})
+			if err != nil {
+				writeResponseError(logger, resp, errToStatusCode(err), err)
+				logger.Warningf("error processing request: %s", err)
+				return
+			}
+			roomDieTypes = dtResp.DiceTypes
+		}
+
+		mReq, err := mapAPIToModelcreateDiceRoll(*entReq, roomDieTypes)
 		if err != nil {
 			writeResponseError(logger, resp, http.StatusBadRequest, err)
 			return
 		}
 
+		// The request user needs to be the session user.
+		err = a.checkSessionUser(req, mReq.RoomID, mReq.UserID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
 		// Execute.
 		mResp, err := a.diceAppSvc.CreateDiceRoll(req.Request.Context(), *mReq)
 		if err != nil {
@@ -87,21 +123,51 @@ func (a *apiv1) createDiceRoll() restful.RouteFunction {
 	}
 }
 
-func (a *apiv1) listDiceRolls() restful.RouteFunction {
-	logger := a.logger.WithKV(log.KV{"handler": "listDiceRolls"})
+func (a *apiv1) createDiceRolls() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "createDiceRolls"})
 
 	return func(req *restful.Request, resp *restful.Response) {
 		logger.Debugf("handler called")
 
 		// Map request.
-		mReq, err := mapAPIToModelListDiceRolls(req.Request.URL.Query())
+		entReq := &createDiceRollsRequest{}
+		err := req.ReadEntity(entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// Custom die types are registered on the rooms, we need them to map the request.
+		hasCustom := false
+		for _, item := range entReq.Items {
+			hasCustom = hasCustom || hasCustomDieTypeIDs(item.DiceTypeIDs)
+		}
+		var roomDieTypes []model.DieType
+		if entReq.RoomID != "" && hasCustom {
+			dtResp, err := a.diceAppSvc.ListDiceTypes(req.Request.Context(), dice.ListDiceTypesRequest{RoomID: entReq.RoomID})
+			if err != nil {
+				writeResponseError(logger, resp, errToStatusCode(err), err)
+				logger.Warningf("error processing request: %s", err)
+				return
+			}
+			roomDieTypes = dtResp.DiceTypes
+		}
+
+		mReq, err := mapAPIToModelCreateDiceRolls(*entReq, roomDieTypes)
 		if err != nil {
 			writeResponseError(logger, resp, http.StatusBadRequest, err)
 			return
 		}
 
+		// The request user needs to be the session user.
+		err = a.checkSessionUser(req, entReq.RoomID, entReq.UserID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
 		// Execute.
-		mResp, err := a.diceAppSvc.ListDiceRolls(req.Request.Context(), *mReq)
+		mResp, err := a.diceAppSvc.CreateDiceRolls(req.Request.Context(), *mReq)
 		if err != nil {
 			writeResponseError(logger, resp, errToStatusCode(err), err)
 			logger.Warningf("error processing request: %s", err)
@@ -109,35 +175,42 @@ func (a *apiv1) listDiceRolls() restful.RouteFunction {
 		}
 
 		// Map response.
-		r := mapModelToAPIListDiceRolls(*mResp)
-		err = resp.WriteHeaderAndEntity(http.StatusOK, r)
+		r := mapModelToAPICreateDiceRolls(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusCreated, r)
 		if err != nil {
 			logger.Errorf("could not write http response: %w", err)
 		}
 	}
 }
 
-func (a *apiv1) createRoom() restful.RouteFunction {
-	logger := a.logger.WithKV(log.KV{"handler": "createRoom"})
+func (a *apiv1) createCustomDieType() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "createCustomDieType"})
 
 	return func(req *restful.Request, resp *restful.Response) {
 		logger.Debugf("handler called")
 
 		// Map request.
-		entReq := &createRoomRequest{}
+		entReq := &createCustomDieTypeRequest{}
 		err := req.ReadEntity(entReq)
 		if err != nil {
 			writeResponseError(logger, resp, http.StatusBadRequest, err)
 			return
 		}
-		mReq, err := mapAPIToModelCreateRoom(*entReq)
+		mReq, err := mapAPIToModelCreateCustomDieType(*entReq)
 		if err != nil {
 			writeResponseError(logger, resp, http.StatusBadRequest, err)
 			return
 		}
 
+		// Only the room users can create the room custom die types.
+		_, err = a.requiredSessionUser(req, mReq.RoomID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
 		// Execute.
-		mResp, err := a.roomAppSvc.CreateRoom(req.Request.Context(), *mReq)
+		mResp, err := a.diceAppSvc.CreateCustomDieType(req.Request.Context(), *mReq)
 		if err != nil {
 			writeResponseError(logger, resp, errToStatusCode(err), err)
 			logger.Warningf("error processing request: %s", err)
@@ -145,7 +218,7 @@ func (a *apiv1) createRoom() restful.RouteFunction {
 		}
 
 		// Map response.
-		r := mapModelToAPICreateRoom(*mResp)
+		r := mapModelToAPICreateCustomDieType(*mResp)
 		err = resp.WriteHeaderAndEntity(http.StatusCreated, r)
 		if err != nil {
 			logger.Errorf("could not write http response: %w", err)
@@ -153,21 +226,37 @@ func (a *apiv1) createRoom() restful.RouteFunction {
 	}
 }
 
-func (a *apiv1) getRoom() restful.RouteFunction {
-	logger := a.logger.WithKV(log.KV{"handler": "getRoom"})
+func (a *apiv1) listDiceRolls() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "listDiceRolls"})
 
 	return func(req *restful.Request, resp *restful.Response) {
 		logger.Debugf("handler called")
 
 		// Map request.
-		mReq, err := mapAPIToModelGetRoom(req.PathParameters())
+		mReq, err := mapAPIToModelListDiceRolls(req.Request.URL.Query())
 		if err != nil {
 			writeResponseError(logger, resp, http.StatusBadRequest, err)
 			return
 		}
 
+		// Protected rooms can only be read by their users.
+		err = a.checkRoomAccess(req, mReq.RoomID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		viewer, err := a.sessionUser(req, mReq.RoomID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+		if viewer != nil {
+			mReq.ViewerUserID = viewer.ID
+		}
+
 		// Execute.
-		mResp, err := a.roomAppSvc.GetRoom(req.Request.Context(), *mReq)
+		mResp, err := a.diceAppSvc.ListDiceRolls(req.Request.Context(), *mReq)
 		if err != nil {
 			writeResponseError(logger, resp, errToStatusCode(err), err)
 			logger.Warningf("error processing request: %s", err)
@@ -175,7 +264,7 @@ func (a *apiv1) getRoom() restful.RouteFunction {
 		}
 
 		// Map response.
-		r := mapModelToAPIGetRoom(*mResp)
+		r := mapModelToAPIListDiceRolls(*mResp)
 		err = resp.WriteHeaderAndEntity(http.StatusOK, r)
 		if err != nil {
 			logger.Errorf("could not write http response: %w", err)
@@ -183,27 +272,34 @@ func (a *apiv1) getRoom() restful.RouteFunction {
 	}
 }
 
-func (a *apiv1) createUser() restful.RouteFunction {
-	logger := a.logger.WithKV(log.KV{"handler": "createUser"})
+func (a *apiv1) rerollDice() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "rerollDice"})
 
 	return func(req *restful.Request, resp *restful.Response) {
 		logger.Debugf("handler called")
 
 		// Map request.
-		entReq := &createUserRequest{}
+		entReq := &rerollDiceRequest{}
 		err := req.ReadEntity(entReq)
 		if err != nil {
 			writeResponseError(logger, resp, http.StatusBadRequest, err)
 			return
 		}
-		mReq, err := mapAPIToModelCreateUser(*entReq)
+		mReq, err := mapAPIToModelRerollDice(req.PathParameters(), *entReq)
 		if err != nil {
 			writeResponseError(logger, resp, http.StatusBadRequest, err)
 			return
 		}
 
+		// The request user needs to be the session user.
+		err = a.checkSessionUser(req, "", mReq.UserID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
 		// Execute.
-		mResp, err := a.userAppSvc.CreateUser(req.Request.Context(), *mReq)
+		mResp, err := a.diceAppSvc.RerollDice(req.Request.Context(), *mReq)
 		if err != nil {
 			writeResponseError(logger, resp, errToStatusCode(err), err)
 			logger.Warningf("error processing request: %s", err)
@@ -211,7 +307,7 @@ func (a *apiv1) createUser() restful.RouteFunction {
 		}
 
 		// Map response.
-		r := mapModelToAPICreateUser(*mResp)
+		r := mapModelToAPIRerollDice(*mResp)
 		err = resp.WriteHeaderAndEntity(http.StatusCreated, r)
 		if err != nil {
 			logger.Errorf("could not write http response: %w", err)
@@ -219,29 +315,46 @@ func (a *apiv1) createUser() restful.RouteFunction {
 	}
 }
 
-func (a *apiv1) listUsers() restful.RouteFunction {
-	logger := a.logger.WithKV(log.KV{"handler": "listUsers"})
+func (a *apiv1) verifyDiceRoll() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "verifyDiceRoll"})
 
 	return func(req *restful.Request, resp *restful.Response) {
 		logger.Debugf("handler called")
 
 		// Map request.
-		mReq, err := mapAPIToModelListUsers(req.Request.URL.Query())
+		mReq, err := mapAPIToModelVerifyDiceRoll(req.PathParameters())
 		if err != nil {
 			writeResponseError(logger, resp, http.StatusBadRequest, err)
 			return
 		}
 
+		// The room is unknown until we get the dice roll, the service checks the viewer can see it.
+		viewer, err := a.sessionUser(req, "")
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+		if viewer != nil {
+			mReq.ViewerUserID = viewer.ID
+		}
+
 		// Execute.
-		mResp, err := a.userAppSvc.ListUsers(req.Request.Context(), *mReq)
+		mResp, err := a.diceAppSvc.VerifyDiceRoll(req.Request.Context(), *mReq)
 		if err != nil {
 			writeResponseError(logger, resp, errToStatusCode(err), err)
 			logger.Warningf("error processing request: %s", err)
 			return
 		}
 
+		// Protected rooms can only be read by their users.
+		err = a.checkRoomAccess(req, mResp.DiceRoll.RoomID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
 		// Map response.
-		r := mapModelToAPIListUsers(*mResp)
+		r := mapModelToAPIVerifyDiceRoll(*mResp)
 		err = resp.WriteHeaderAndEntity(http.StatusOK, r)
 		if err != nil {
 			logger.Errorf("could not write http response: %w", err)
@@ -249,74 +362,1425 @@ func (a *apiv1) listUsers() restful.RouteFunction {
 	}
 }
 
-func (a *apiv1) wsRoomEvents() restful.RouteFunction {
-	const wsRoomEventsRoomID = "id"
+func (a *apiv1) deleteRoom() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "deleteRoom"})
 
-	logger := a.logger.WithKV(log.KV{"handler": "wsRoomEvents"})
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		mReq, err := mapAPIToModelDeleteRoom(req.PathParameters())
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// Only the room game master can delete the room.
+		gm, err := a.requiredSessionUser(req, mReq.ID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+		mReq.UserID = gm.ID
+
+		// Execute.
+		err = a.roomAppSvc.DeleteRoom(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		resp.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (a *apiv1) archiveRoom() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "archiveRoom"})
 
 	return func(req *restful.Request, resp *restful.Response) {
 		logger.Debugf("handler called")
 
-		// Get correct data.
-		roomID := req.PathParameters()[wsRoomEventsRoomID]
+		// Map request.
+		mReq, err := mapAPIToModelArchiveRoom(req.PathParameters())
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
 
-		// Upgrade connection to websocket.
-		c, err := websocket.Accept(resp.ResponseWriter, req.Request, &websocket.AcceptOptions{InsecureSkipVerify: true})
+		// Only the room game master can archive the room.
+		gm, err := a.requiredSessionUser(req, mReq.ID)
 		if err != nil {
 			writeResponseError(logger, resp, errToStatusCode(err), err)
-			logger.Warningf("error processing websocket request: %s", err)
 			return
 		}
-		defer c.Close(websocket.StatusInternalError, "")
-		logger.Debugf("websocket connected")
-		defer logger.Debugf("websocket disconnected")
+		mReq.UserID = gm.ID
 
-		// Subscribe user to room events.
-		modelReq := dice.SubscribeDiceRollCreatedRequest{
-			RoomID: roomID,
-			EventHandler: func(ctx context.Context, e model.EventDiceRollCreated) error {
-				resp := mapModelToAPIWSDiceRollCreatedEvent(e)
-				return wsjson.Write(ctx, c, resp)
-			},
+		// Execute.
+		err = a.roomAppSvc.ArchiveRoom(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
 		}
-		modelResp, err := a.diceAppSvc.SubscribeDiceRollCreated(req.Request.Context(), modelReq)
+
+		resp.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (a *apiv1) getRoomServerSeed() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "getRoomServerSeed"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		mReq, err := mapAPIToModelGetRoomServerSeed(req.PathParameters())
 		if err != nil {
-			logger.Warningf("error subscribing websocket to dice roll created events: %s", err)
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
 			return
 		}
-		defer func() {
-			err := modelResp.UnsubscribeFunc()
-			if err != nil {
-				logger.Warningf("error subscribing websocket to dice roll created events: %s", err)
-			}
-		}()
 
-		// We don't plan to receive any message from the websocket, only send,
-		// that's why we use `CloseRead` and wait until we are done.
-		ctx := c.CloseRead(req.Request.Context())
-		<-ctx.Done()
-		c.Close(websocket.StatusNormalClosure, "")
+		// Protected rooms can only be read by their users.
+		err = a.checkRoomAccess(req, mReq.RoomID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.diceAppSvc.GetRoomServerSeed(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPIGetRoomServerSeed(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusOK, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
 	}
 }
 
-func writeResponseError(logger log.Logger, resp *restful.Response, status int, err error) {
-	err = resp.WriteServiceError(status, restful.NewError(status, err.Error()))
-	if err != nil {
-		logger.Errorf("could not write http response: %w", err)
+func (a *apiv1) rotateRoomServerSeed() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "rotateRoomServerSeed"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		mReq, err := mapAPIToModelRotateRoomServerSeed(req.PathParameters())
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// Protected rooms can only be read by their users.
+		err = a.checkRoomAccess(req, mReq.RoomID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Only the room game master can rotate the server seed.
+		gm, err := a.requiredSessionUser(req, mReq.RoomID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+		mReq.UserID = gm.ID
+
+		// Execute.
+		mResp, err := a.diceAppSvc.RotateRoomServerSeed(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPIRotateRoomServerSeed(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusOK, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
 	}
 }
 
-func errToStatusCode(err error) int {
-	switch {
-	case err == nil:
-		return http.StatusOK
-	case errors.Is(err, internalerrors.ErrNotValid):
-		return http.StatusBadRequest
-	case errors.Is(err, internalerrors.ErrMissing):
-		return http.StatusNotFound
-	case errors.Is(err, internalerrors.ErrAlreadyExists):
-		return http.StatusConflict
-	default:
-		return http.StatusInternalServerError
+func (a *apiv1) createRoom() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "createRoom"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		entReq := &createRoomRequest{}
+		err := req.ReadEntity(entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+		mReq, err := mapAPIToModelCreateRoom(*entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.roomAppSvc.CreateRoom(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPICreateRoom(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusCreated, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) getRoom() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "getRoom"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		mReq, err := mapAPIToModelGetRoom(req.PathParameters())
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.roomAppSvc.GetRoom(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Protected rooms can only be read by their users.
+		err = a.checkRoomReader(req, mResp.Room)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPIGetRoom(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusOK, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) createUser() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "createUser"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		entReq := &createUserRequest{}
+		err := req.ReadEntity(entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+		mReq, err := mapAPIToModelCreateUser(*entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// Logging in again as an existing user needs its session.
+		mReq.SessionToken = sessionToken(req)
+
+		// Execute.
+		mResp, err := a.userAppSvc.CreateUser(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPICreateUser(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusCreated, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) listUsers() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "listUsers"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		mReq, err := mapAPIToModelListUsers(req.Request.URL.Query())
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		mReq.SessionToken = sessionToken(req)
+
+		// Execute.
+		mResp, err := a.userAppSvc.ListUsers(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPIListUsers(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusOK, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) getDiceStats() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "getDiceStats"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		mReq, err := mapAPIToModelGetDiceStats(req.Request.URL.Query())
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// Protected rooms can only be read by their users.
+		err = a.checkRoomAccess(req, mReq.RoomID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.statsAppSvc.GetDiceStats(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPIGetDiceStats(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusOK, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) calculateDiceProbabilities() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "calculateDiceProbabilities"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Protected rooms can only be read by their users.
+		query := req.Request.URL.Query()
+		err := a.checkRoomAccess(req, query.Get(diceProbabilitiesParamRoomID))
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Custom die types are registered on the rooms, we need them to map the request.
+		var roomDieTypes []model.DieType
+		if roomID := query.Get(diceProbabilitiesParamRoomID); roomID != "" && hasCustomDieTypeIDs(diceProbabilitiesDiceTypeIDs(query)) {
+			dtResp, err := a.diceAppSvc.ListDiceTypes(req.Request.Context(), dice.ListDiceTypesRequest{RoomID: roomID})
+			if err != nil {
+				writeResponseError(logger, resp, errToStatusCode(err), err)
+				logger.Warningf("error processing request: %s", err)
+				return
+			}
+			roomDieTypes = dtResp.DiceTypes
+		}
+
+		// Map request.
+		mReq, err := mapAPIToModelCalculateDiceProbabilities(query, roomDieTypes)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.diceAppSvc.CalculateProbabilities(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPICalculateDiceProbabilities(*mResp, mReq.Check != (dice.DiceCheck{}))
+		err = resp.WriteHeaderAndEntity(http.StatusOK, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) createMacro() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "createMacro"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		entReq := &createMacroRequest{}
+		err := req.ReadEntity(entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+		mReq, err := mapAPIToModelCreateMacro(*entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// The request user needs to be the session user.
+		err = a.checkSessionUser(req, mReq.RoomID, mReq.UserID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.macroAppSvc.CreateMacro(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPICreateMacro(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusCreated, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) listMacros() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "listMacros"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		mReq, err := mapAPIToModelListMacros(req.Request.URL.Query())
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// Protected rooms can only be read by their users.
+		err = a.checkRoomAccess(req, mReq.RoomID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.macroAppSvc.ListMacros(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPIListMacros(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusOK, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) getMacro() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "getMacro"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		mReq, err := mapAPIToModelGetMacro(req.PathParameters(), req.Request.URL.Query())
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.macroAppSvc.GetMacro(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Protected rooms can only be read by their users.
+		err = a.checkRoomAccess(req, mResp.Macro.RoomID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPIGetMacro(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusOK, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) updateMacro() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "updateMacro"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		entReq := &updateMacroRequest{}
+		err := req.ReadEntity(entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+		mReq, err := mapAPIToModelUpdateMacro(req.PathParameters(), *entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// The request user needs to be the session user.
+		err = a.checkSessionUser(req, "", mReq.UserID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.macroAppSvc.UpdateMacro(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPIUpdateMacro(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusOK, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) deleteMacro() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "deleteMacro"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		mReq, err := mapAPIToModelDeleteMacro(req.PathParameters(), req.Request.URL.Query())
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// The request user needs to be the session user.
+		err = a.checkSessionUser(req, "", mReq.UserID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Execute.
+		err = a.macroAppSvc.DeleteMacro(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		resp.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (a *apiv1) getInitiative() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "getInitiative"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		mReq, err := mapAPIToModelGetInitiative(req.PathParameters())
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// Protected rooms can only be read by their users.
+		err = a.checkRoomAccess(req, mReq.RoomID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.initiativeAppSvc.GetInitiative(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPIGetInitiative(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusOK, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) addCombatant() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "addCombatant"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		entReq := &addCombatantRequest{}
+		err := req.ReadEntity(entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+		mReq, err := mapAPIToModelAddCombatant(req.PathParameters(), *entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// The request user needs to be the session user.
+		err = a.checkSessionUser(req, mReq.RoomID, mReq.UserID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.initiativeAppSvc.AddCombatant(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPIAddCombatant(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusCreated, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) rollInitiative() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "rollInitiative"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		entReq := &rollInitiativeRequest{}
+		err := req.ReadEntity(entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+		mReq, err := mapAPIToModelRollInitiative(req.PathParameters(), *entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// The request user needs to be the session user.
+		err = a.checkSessionUser(req, mReq.RoomID, mReq.UserID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.initiativeAppSvc.RollInitiative(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPIRollInitiative(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusOK, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) removeCombatant() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "removeCombatant"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		mReq, err := mapAPIToModelRemoveCombatant(req.PathParameters(), req.Request.URL.Query())
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// The request user needs to be the session user.
+		err = a.checkSessionUser(req, mReq.RoomID, mReq.UserID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.initiativeAppSvc.RemoveCombatant(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPIRemoveCombatant(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusOK, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) nextTurn() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "nextTurn"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		entReq := &changeTurnRequest{}
+		err := req.ReadEntity(entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+		mReq, err := mapAPIToModelNextTurn(req.PathParameters(), *entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// The request user needs to be the session user.
+		err = a.checkSessionUser(req, mReq.RoomID, mReq.UserID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.initiativeAppSvc.NextTurn(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPINextTurn(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusOK, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) previousTurn() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "previousTurn"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		entReq := &changeTurnRequest{}
+		err := req.ReadEntity(entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+		mReq, err := mapAPIToModelPreviousTurn(req.PathParameters(), *entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// The request user needs to be the session user.
+		err = a.checkSessionUser(req, mReq.RoomID, mReq.UserID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.initiativeAppSvc.PreviousTurn(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPIPreviousTurn(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusOK, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) endCombat() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "endCombat"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		mReq, err := mapAPIToModelEndCombat(req.PathParameters(), req.Request.URL.Query())
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// The request user needs to be the session user.
+		err = a.checkSessionUser(req, mReq.RoomID, mReq.UserID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Execute.
+		err = a.initiativeAppSvc.EndCombat(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		resp.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (a *apiv1) createDeck() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "createDeck"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		entReq := &createDeckRequest{}
+		err := req.ReadEntity(entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+		mReq, err := mapAPIToModelCreateDeck(req.PathParameters(), *entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// The request user needs to be the session user.
+		err = a.checkSessionUser(req, mReq.RoomID, mReq.UserID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.deckAppSvc.CreateDeck(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPICreateDeck(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusCreated, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) listDecks() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "listDecks"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		mReq, err := mapAPIToModelListDecks(req.PathParameters())
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// Protected rooms can only be read by their users.
+		err = a.checkRoomAccess(req, mReq.RoomID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.deckAppSvc.ListDecks(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPIListDecks(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusOK, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) drawCards() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "drawCards"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		entReq := &drawCardsRequest{}
+		err := req.ReadEntity(entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+		mReq, err := mapAPIToModelDrawCards(req.PathParameters(), *entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// The request user needs to be the session user.
+		err = a.checkSessionUser(req, mReq.RoomID, mReq.UserID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.deckAppSvc.DrawCards(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPIDrawCards(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusOK, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) discardCards() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "discardCards"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		entReq := &discardCardsRequest{}
+		err := req.ReadEntity(entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+		mReq, err := mapAPIToModelDiscardCards(req.PathParameters(), *entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// The request user needs to be the session user.
+		err = a.checkSessionUser(req, mReq.RoomID, mReq.UserID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.deckAppSvc.DiscardCards(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPIDiscardCards(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusOK, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) reshuffleDeck() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "reshuffleDeck"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		entReq := &reshuffleDeckRequest{}
+		err := req.ReadEntity(entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+		mReq, err := mapAPIToModelReshuffleDeck(req.PathParameters(), *entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// The request user needs to be the session user.
+		err = a.checkSessionUser(req, mReq.RoomID, mReq.UserID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.deckAppSvc.ReshuffleDeck(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPIReshuffleDeck(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusOK, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) wsRoomEvents() restful.RouteFunction {
+	const wsRoomEventsRoomID = "id"
+
+	logger := a.logger.WithKV(log.KV{"handler": "wsRoomEvents"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Get correct data.
+		roomID := req.PathParameters()[wsRoomEventsRoomID]
+		err := a.checkRoomAccess(req, roomID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+		viewer, err := a.sessionUser(req, roomID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+		viewerID := ""
+		if viewer != nil {
+			viewerID = viewer.ID
+		}
+
+		// Upgrade connection to websocket.
+		c, err := websocket.Accept(resp.ResponseWriter, req.Request, &websocket.AcceptOptions{InsecureSkipVerify: true})
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing websocket request: %s", err)
+			return
+		}
+		defer c.Close(websocket.StatusInternalError, "")
+		logger.Debugf("websocket connected")
+		defer logger.Debugf("websocket disconnected")
+
+		// Subscribe user to room events.
+		modelReq := dice.SubscribeDiceRollCreatedRequest{
+			RoomID:       roomID,
+			ViewerUserID: viewerID,
+			EventHandler: func(ctx context.Context, e model.EventDiceRollCreated) error {
+				resp := mapModelToAPIWSDiceRollCreatedEvent(e)
+				return wsjson.Write(ctx, c, resp)
+			},
+		}
+		modelResp, err := a.diceAppSvc.SubscribeDiceRollCreated(req.Request.Context(), modelReq)
+		if err != nil {
+			logger.Warningf("error subscribing websocket to dice roll created events: %s", err)
+			return
+		}
+		defer func() {
+			err := modelResp.UnsubscribeFunc()
+			if err != nil {
+				logger.Warningf("error subscribing websocket to dice roll created events: %s", err)
+			}
+		}()
+
+		// Deleted and archived rooms don't have more events, close the websocket when that happens.
+		roomClosed := make(chan struct{}, 1)
+		roomClosedResp, err := a.roomAppSvc.SubscribeRoomClosed(req.Request.Context(), room.SubscribeRoomClosedRequest{
+			RoomID: roomID,
+			EventHandler: func(_ context.Context, _ model.EventRoomClosed) error {
+				select {
+				case roomClosed <- struct{}{}:
+				default:
+				}
+				return nil
+			},
+		})
+		if err != nil {
+			logger.Warningf("error subscribing websocket to room closed events: %s", err)
+			return
+		}
+		defer func() {
+			err := roomClosedResp.UnsubscribeFunc()
+			if err != nil {
+				logger.Warningf("error unsubscribing websocket from room closed events: %s", err)
+			}
+		}()
+
+		// We don't plan to receive any message from the websocket, only send,
+		// that's why we use `CloseRead` and wait until we are done.
+		ctx := c.CloseRead(req.Request.Context())
+		select {
+		case <-ctx.Done():
+			c.Close(websocket.StatusNormalClosure, "")
+		case <-roomClosed:
+			c.Close(websocket.StatusGoingAway, "room closed")
+		}
+	}
+}
+
+const (
+	sessionHeaderPrefix = "Bearer "
+	sessionParamToken   = "session-token"
+	sessionHeaderAuthz  = "Authorization"
+)
+
+// sessionUser returns the user authenticated by the request session token, nil if the
+// request doesn't have a session.
+//
+// The token is read from the `Authorization: Bearer <token>` header, the browser websockets
+// can't set headers, so as a fallback it's also read from the `session-token` query param.
+func (a *apiv1) sessionUser(req *restful.Request, roomID string) (*model.User, error) {
+	token := sessionToken(req)
+	if token == "" {
+		return nil, nil
+	}
+
+	resp, err := a.userAppSvc.AuthenticateUser(req.Request.Context(), user.AuthenticateUserRequest{
+		SessionToken: token,
+		RoomID:       roomID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.User, nil
+}
+
+// sessionToken returns the request session token, empty if the request doesn't have a session.
+func sessionToken(req *restful.Request) string {
+	token := strings.TrimPrefix(req.HeaderParameter(sessionHeaderAuthz), sessionHeaderPrefix)
+	if token == "" {
+		token = req.QueryParameter(sessionParamToken)
+	}
+
+	return token
+}
+
+// checkRoomAccess checks the request can read the room, see checkRoomReader.
+func (a *apiv1) checkRoomAccess(req *restful.Request, roomID string) error {
+	rm, err := a.roomAppSvc.GetRoom(req.Request.Context(), room.GetRoomRequest{ID: roomID})
+	if err != nil {
+		return fmt.Errorf("could not get room: %w", err)
+	}
+
+	return a.checkRoomReader(req, rm.Room)
+}
+
+// checkRoomReader checks the request can read the room, anyone can read the rooms without password
+// but the protected rooms need the session of a room user.
+func (a *apiv1) checkRoomReader(req *restful.Request, rm model.Room) error {
+	if !rm.Protected() {
+		return nil
+	}
+
+	_, err := a.requiredSessionUser(req, rm.ID)
+	return err
+}
+
+// requiredSessionUser is like sessionUser but the requests without session token are not allowed.
+func (a *apiv1) requiredSessionUser(req *restful.Request, roomID string) (*model.User, error) {
+	u, err := a.sessionUser(req, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, fmt.Errorf("session token is required: %w", internalerrors.ErrNotAllowed)
+	}
+
+	return u, nil
+}
+
+// checkSessionUser checks the request user is the session user, anyone can know the users IDs, so
+// the users on the requests can't be trusted without their session.
+func (a *apiv1) checkSessionUser(req *restful.Request, roomID, userID string) error {
+	u, err := a.requiredSessionUser(req, roomID)
+	if err != nil {
+		return err
+	}
+
+	if u.ID != userID {
+		return fmt.Errorf("request user is not the session user: %w", internalerrors.ErrNotAllowed)
+	}
+
+	return nil
+}
+
+func writeResponseError(logger log.Logger, resp *restful.Response, status int, err error) {
+	err = resp.WriteServiceError(status, restful.NewError(status, err.Error()))
+	if err != nil {
+		logger.Errorf("could not write http response: %w", err)
+	}
+}
+
+func errToStatusCode(err error) int {
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case errors.Is(err, internalerrors.ErrNotValid):
+		return http.StatusBadRequest
+	case errors.Is(err, internalerrors.ErrMissing):
+		return http.StatusNotFound
+	case errors.Is(err, internalerrors.ErrNotAllowed):
+		return http.StatusForbidden
+	case errors.Is(err, internalerrors.ErrAlreadyExists), errors.Is(err, internalerrors.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, internalerrors.ErrRateLimited):
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (a *apiv1) createOracleTable() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "createOracleTable"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		entReq := &createOracleTableRequest{}
+		err := req.ReadEntity(entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+		mReq, err := mapAPIToModelCreateOracleTable(req.PathParameters(), *entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// The request user needs to be the session user.
+		err = a.checkSessionUser(req, mReq.RoomID, mReq.UserID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.oracleAppSvc.CreateTable(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPICreateOracleTable(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusCreated, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) listOracleTables() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "listOracleTables"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		mReq, err := mapAPIToModelListOracleTables(req.PathParameters())
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// Protected rooms can only be read by their users.
+		err = a.checkRoomAccess(req, mReq.RoomID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.oracleAppSvc.ListTables(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPIListOracleTables(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusOK, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) rollOracleTable() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "rollOracleTable"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		entReq := &rollOracleTableRequest{}
+		err := req.ReadEntity(entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+		mReq, err := mapAPIToModelRollOracleTable(req.PathParameters(), *entReq)
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// The request user needs to be the session user.
+		err = a.checkSessionUser(req, mReq.RoomID, mReq.UserID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.oracleAppSvc.RollTable(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPIRollOracleTable(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusCreated, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
+	}
+}
+
+func (a *apiv1) listOracleTableRolls() restful.RouteFunction {
+	logger := a.logger.WithKV(log.KV{"handler": "listOracleTableRolls"})
+
+	return func(req *restful.Request, resp *restful.Response) {
+		logger.Debugf("handler called")
+
+		// Map request.
+		mReq, err := mapAPIToModelListOracleTableRolls(req.PathParameters())
+		if err != nil {
+			writeResponseError(logger, resp, http.StatusBadRequest, err)
+			return
+		}
+
+		// Protected rooms can only be read by their users.
+		err = a.checkRoomAccess(req, mReq.RoomID)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			return
+		}
+
+		// Execute.
+		mResp, err := a.oracleAppSvc.ListTableRolls(req.Request.Context(), *mReq)
+		if err != nil {
+			writeResponseError(logger, resp, errToStatusCode(err), err)
+			logger.Warningf("error processing request: %s", err)
+			return
+		}
+
+		// Map response.
+		r := mapModelToAPIListOracleTableRolls(*mResp)
+		err = resp.WriteHeaderAndEntity(http.StatusOK, r)
+		if err != nil {
+			logger.Errorf("could not write http response: %w", err)
+		}
 	}
 }
@@ -3,11 +3,19 @@ package apiv1
 import (
 	"fmt"
 	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/rollify/rollify/internal/deck"
 	"github.com/rollify/rollify/internal/dice"
+	"github.com/rollify/rollify/internal/initiative"
+	"github.com/rollify/rollify/internal/macro"
 	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle"
 	"github.com/rollify/rollify/internal/room"
+	"github.com/rollify/rollify/internal/stats"
 	"github.com/rollify/rollify/internal/user"
 )
 
@@ -19,41 +27,379 @@ type diceTypeResponse struct {
 	ID    string `json:"id"`
 	Name  string `json:"name"`
 	Sides int    `json:"sides"`
+	// Faces are only set on the custom die types, the first face is the side 1.
+	Faces []dieFace `json:"faces,omitempty"`
+}
+
+type dieFace struct {
+	Label string `json:"label"`
+	Value int    `json:"value"`
+}
+
+func mapModelToAPIDiceType(d model.DieType) diceTypeResponse {
+	dt := diceTypeResponse{
+		ID:    d.ID(),
+		Name:  d.ID(),
+		Sides: int(d.Sides()),
+	}
+
+	// Custom die types IDs are not human friendly, use the name.
+	if faces := d.Faces(); len(faces) > 0 {
+		dt.Name = d.Name()
+		dt.Faces = make([]dieFace, 0, len(faces))
+		for _, f := range faces {
+			dt.Faces = append(dt.Faces, dieFace{Label: f.Label, Value: f.Value})
+		}
+	}
+
+	return dt
 }
 
 func mapModelToAPIListDiceTypes(r dice.ListDiceTypesResponse) listDiceTypesResponse {
 	dt := make([]diceTypeResponse, 0, len(r.DiceTypes))
 	for _, d := range r.DiceTypes {
-		dt = append(dt, diceTypeResponse{
-			ID:    d.ID(),
-			Name:  d.ID(),
-			Sides: int(d.Sides()),
-		})
+		dt = append(dt, mapModelToAPIDiceType(d))
 	}
 	return listDiceTypesResponse{
 		Items: dt,
 	}
 }
 
+const listDiceTypesurlParamRoomID = "room-id"
+
+func mapAPIToModelListDiceTypes(p url.Values) dice.ListDiceTypesRequest {
+	return dice.ListDiceTypesRequest{
+		RoomID: p.Get(listDiceTypesurlParamRoomID),
+	}
+}
+
+type createCustomDieTypeRequest struct {
+	RoomID string    `json:"room_id"`
+	Name   string    `json:"name"`
+	Faces  []dieFace `json:"faces"`
+}
+
+func mapAPIToModelCreateCustomDieType(r createCustomDieTypeRequest) (*dice.CreateCustomDieTypeRequest, error) {
+	if r.RoomID == "" {
+		return nil, fmt.Errorf("room_id is required")
+	}
+
+	if r.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+
+	if len(r.Faces) == 0 {
+		return nil, fmt.Errorf("faces are required")
+	}
+
+	faces := make([]model.DieFace, 0, len(r.Faces))
+	for _, f := range r.Faces {
+		faces = append(faces, model.DieFace{Label: f.Label, Value: f.Value})
+	}
+
+	return &dice.CreateCustomDieTypeRequest{
+		RoomID: r.RoomID,
+		Name:   r.Name,
+		Faces:  faces,
+	}, nil
+}
+
+func mapModelToAPICreateCustomDieType(r dice.CreateCustomDieTypeResponse) diceTypeResponse {
+	return mapModelToAPIDiceType(r.DieType)
+}
+
 type createDiceRollResponse struct {
 	ID string `json:"id"`
 	// Representation in RFC3339.
-	CreateAt string    `json:"created_at"`
-	RoomID   string    `json:"room_id"`
-	UserID   string    `json:"user_id"`
-	Dice     []dieRoll `json:"dice"`
+	CreateAt   string    `json:"created_at"`
+	RoomID     string    `json:"room_id"`
+	UserID     string    `json:"user_id"`
+	Dice       []dieRoll `json:"dice"`
+	Expression string    `json:"expression"`
+	Label      string    `json:"label"`
+	Modifier   int       `json:"modifier"`
+	Total      int       `json:"total"`
+	// Proof is only set on the provably fair dice rolls.
+	Proof *diceRollProof `json:"proof,omitempty"`
+	// Pool is only set on the dice pools.
+	Pool *diceRollPoolResult `json:"pool,omitempty"`
+	// Check is only set on the difficulty checks.
+	Check *diceRollCheckResult `json:"check,omitempty"`
+	// Visibility is `public`, `game_master` or `whisper`.
+	Visibility string `json:"visibility"`
+	// WhisperUserIDs are only set on the whispered dice rolls.
+	WhisperUserIDs []string `json:"whisper_user_ids,omitempty"`
+}
+
+type diceRollProof struct {
+	ServerSeedID   string `json:"server_seed_id"`
+	ServerSeedHash string `json:"server_seed_hash"`
+	ClientSeed     string `json:"client_seed"`
+	Nonce          uint   `json:"nonce"`
+}
+
+func mapModelToAPIDiceRollProof(p *model.DiceRollProof) *diceRollProof {
+	if p == nil {
+		return nil
+	}
+
+	return &diceRollProof{
+		ServerSeedID:   p.ServerSeedID,
+		ServerSeedHash: p.ServerSeedHash,
+		ClientSeed:     p.ClientSeed,
+		Nonce:          p.Nonce,
+	}
+}
+
+type diceRollPoolResult struct {
+	Successes int `json:"successes"`
+	// Outcome is `critical_failure`, `failure`, `success` or `critical_success`.
+	Outcome string `json:"outcome"`
+}
+
+func mapModelToAPIDiceRollPoolResult(p *model.DicePoolResult) *diceRollPoolResult {
+	if p == nil {
+		return nil
+	}
+
+	res := &diceRollPoolResult{Successes: p.Successes}
+	switch p.Outcome {
+	case model.DicePoolOutcomeCriticalFailure:
+		res.Outcome = "critical_failure"
+	case model.DicePoolOutcomeFailure:
+		res.Outcome = "failure"
+	case model.DicePoolOutcomeSuccess:
+		res.Outcome = "success"
+	case model.DicePoolOutcomeCriticalSuccess:
+		res.Outcome = "critical_success"
+	}
+
+	return res
+}
+
+type diceRollCheckResult struct {
+	Target int `json:"target"`
+	// Comparison is `>=`, `>`, `<=` or `<`.
+	Comparison string `json:"comparison"`
+	// Margin is by how much the total beat (positive) or missed (negative) the target.
+	Margin  int  `json:"margin"`
+	Success bool `json:"success"`
+	// Outcome is `critical_failure`, `failure`, `success` or `critical_success`.
+	Outcome string `json:"outcome"`
+}
+
+func mapModelToAPIDiceRollCheckResult(c *model.DiceRollCheck) *diceRollCheckResult {
+	if c == nil {
+		return nil
+	}
+
+	res := &diceRollCheckResult{
+		Target:     c.Target,
+		Comparison: mapModelToAPIDiceRollCheckComparison(c.Comparison),
+		Margin:     c.Margin,
+	}
+	switch c.Outcome {
+	case model.DiceRollCheckOutcomeCriticalFailure:
+		res.Outcome = "critical_failure"
+	case model.DiceRollCheckOutcomeFailure:
+		res.Outcome = "failure"
+	case model.DiceRollCheckOutcomeSuccess:
+		res.Outcome = "success"
+		res.Success = true
+	case model.DiceRollCheckOutcomeCriticalSuccess:
+		res.Outcome = "critical_success"
+		res.Success = true
+	}
+
+	return res
+}
+
+func mapModelToAPIDiceRollCheckComparison(c model.DiceRollCheckComparison) string {
+	switch c {
+	case model.DiceRollCheckComparisonGreaterOrEqual:
+		return ">="
+	case model.DiceRollCheckComparisonGreater:
+		return ">"
+	case model.DiceRollCheckComparisonLessOrEqual:
+		return "<="
+	case model.DiceRollCheckComparisonLess:
+		return "<"
+	default:
+		return ""
+	}
 }
 
 type dieRoll struct {
 	ID         string `json:"id"`
 	DiceTypeID string `json:"dice_type_id"`
 	Side       uint   `json:"side"`
+	// Face is only set on the custom die types.
+	Face *dieFace `json:"face,omitempty"`
+	// Status is not set on the dice that count normally (`exploded`, `rerolled` or `dropped`).
+	Status string `json:"status,omitempty"`
+}
+
+func mapModelToAPIDieRollStatus(s model.DieRollStatus) string {
+	switch s {
+	case model.DieRollStatusExploded:
+		return "exploded"
+	case model.DieRollStatusRerolled:
+		return "rerolled"
+	case model.DieRollStatusDropped:
+		return "dropped"
+	default:
+		return ""
+	}
+}
+
+func mapModelToAPIDieFace(d model.DieRoll) *dieFace {
+	f, ok := d.Face()
+	if !ok {
+		return nil
+	}
+
+	return &dieFace{Label: f.Label, Value: f.Value}
 }
 
 type createDiceRollRequest struct {
 	UserID      string   `json:"user_id"`
 	RoomID      string   `json:"room_id"`
 	DiceTypeIDs []string `json:"dice_type_ids"`
+	// Expression is a dice notation expression (e.g: `2d6+3`), can't be used with dice_type_ids.
+	Expression string `json:"expression"`
+	// ClientSeed is mixed with the room server seed on provably fair dice rolls.
+	ClientSeed string `json:"client_seed"`
+	// Label is a free-text description of the dice roll (e.g: `Stealth check`).
+	Label string `json:"label"`
+	// Modifiers can only be used with dice_type_ids.
+	Modifiers *diceRollModifiers `json:"modifiers,omitempty"`
+	// Pool makes the dice roll a success-counting dice pool, can only be used with dice_type_ids.
+	Pool *diceRollPool `json:"pool,omitempty"`
+	// Check compares the total with a difficulty (e.g: DC 15) and returns the outcome.
+	Check *diceRollCheck `json:"check,omitempty"`
+	// Modifier is a flat bonus (or penalty if negative) added to the total, can only be used with dice_type_ids.
+	Modifier int `json:"modifier"`
+	// Advantage is `advantage` or `disadvantage`, rolls an extra D20 and keeps the higher or lower one,
+	// can only be used with dice_type_ids.
+	Advantage string `json:"advantage"`
+	// Visibility is `public` (default), `game_master` or `whisper`.
+	Visibility string `json:"visibility"`
+	// WhisperUserIDs are the room users the dice roll is whispered to, only used with `whisper` visibility.
+	WhisperUserIDs []string `json:"whisper_user_ids"`
+}
+
+func mapModelToAPIDiceRollVisibility(v model.DiceRollVisibility) string {
+	switch v {
+	case model.DiceRollVisibilityGameMaster:
+		return "game_master"
+	case model.DiceRollVisibilityWhisper:
+		return "whisper"
+	default:
+		return "public"
+	}
+}
+
+func mapAPIToModelDiceRollVisibility(v string) (model.DiceRollVisibility, error) {
+	switch v {
+	case "", "public":
+		return model.DiceRollVisibilityPublic, nil
+	case "game_master":
+		return model.DiceRollVisibilityGameMaster, nil
+	case "whisper":
+		return model.DiceRollVisibilityWhisper, nil
+	default:
+		return 0, fmt.Errorf("visibility '%s' is invalid", v)
+	}
+}
+
+func mapAPIToModelAdvantageMode(a string) (dice.AdvantageMode, error) {
+	switch a {
+	case "":
+		return dice.AdvantageModeNone, nil
+	case "advantage":
+		return dice.AdvantageModeAdvantage, nil
+	case "disadvantage":
+		return dice.AdvantageModeDisadvantage, nil
+	default:
+		return 0, fmt.Errorf("advantage '%s' is invalid", a)
+	}
+}
+
+type diceRollPool struct {
+	// Target is the target number a die needs to count as a success.
+	Target     uint `json:"target"`
+	OnesCancel bool `json:"ones_cancel"`
+	// CriticalDice are the dice with max side needed for a critical success.
+	CriticalDice uint `json:"critical_dice"`
+	// Botch makes a critical failure getting more ones than successes without successes.
+	Botch bool `json:"botch"`
+}
+
+type diceRollCheck struct {
+	Target int `json:"target"`
+	// Comparison is `>=` (default), `>`, `<=` or `<`.
+	Comparison string `json:"comparison"`
+}
+
+func mapAPIToModelDiceCheck(c *diceRollCheck) (dice.DiceCheck, error) {
+	if c == nil {
+		return dice.DiceCheck{}, nil
+	}
+
+	res := dice.DiceCheck{Target: c.Target}
+	switch c.Comparison {
+	case "", ">=":
+		res.Comparison = model.DiceRollCheckComparisonGreaterOrEqual
+	case ">":
+		res.Comparison = model.DiceRollCheckComparisonGreater
+	case "<=":
+		res.Comparison = model.DiceRollCheckComparisonLessOrEqual
+	case "<":
+		res.Comparison = model.DiceRollCheckComparisonLess
+	default:
+		return dice.DiceCheck{}, fmt.Errorf("check comparison '%s' is invalid", c.Comparison)
+	}
+
+	return res, nil
+}
+
+func mapAPIToModelDicePool(p *diceRollPool) dice.DicePool {
+	if p == nil {
+		return dice.DicePool{}
+	}
+
+	return dice.DicePool{
+		Target:       p.Target,
+		OnesCancel:   p.OnesCancel,
+		CriticalDice: p.CriticalDice,
+		Botch:        p.Botch,
+	}
+}
+
+type diceRollModifiers struct {
+	// RerollBelow rerolls once the dice whose side is below N.
+	RerollBelow uint `json:"reroll_below"`
+	// Explode rolls an extra die each time a die gets its max side.
+	Explode     bool `json:"explode"`
+	KeepHighest uint `json:"keep_highest"`
+	KeepLowest  uint `json:"keep_lowest"`
+	DropHighest uint `json:"drop_highest"`
+	DropLowest  uint `json:"drop_lowest"`
+}
+
+func mapAPIToModelDiceRollModifiers(m *diceRollModifiers) dice.DiceRollModifiers {
+	if m == nil {
+		return dice.DiceRollModifiers{}
+	}
+
+	return dice.DiceRollModifiers{
+		RerollBelow: m.RerollBelow,
+		Explode:     m.Explode,
+		KeepHighest: m.KeepHighest,
+		KeepLowest:  m.KeepLowest,
+		DropHighest: m.DropHighest,
+		DropLowest:  m.DropLowest,
+	}
 }
 
 func mapModelToAPIcreateDiceRoll(r dice.CreateDiceRollResponse) createDiceRollResponse {
@@ -63,18 +409,42 @@ func mapModelToAPIcreateDiceRoll(r dice.CreateDiceRollResponse) createDiceRollRe
 			ID:         d.ID,
 			DiceTypeID: d.Type.ID(),
 			Side:       d.Side,
+			Face:       mapModelToAPIDieFace(d),
+			Status:     mapModelToAPIDieRollStatus(d.Status),
 		})
 	}
 	return createDiceRollResponse{
-		ID:       r.DiceRoll.ID,
-		CreateAt: r.DiceRoll.CreatedAt.Format(time.RFC3339),
-		RoomID:   r.DiceRoll.RoomID,
-		UserID:   r.DiceRoll.UserID,
-		Dice:     ds,
+		ID:             r.DiceRoll.ID,
+		CreateAt:       r.DiceRoll.CreatedAt.Format(time.RFC3339),
+		RoomID:         r.DiceRoll.RoomID,
+		UserID:         r.DiceRoll.UserID,
+		Dice:           ds,
+		Expression:     r.DiceRoll.Expression,
+		Label:          r.DiceRoll.Label,
+		Modifier:       r.DiceRoll.Modifier,
+		Total:          r.DiceRoll.Total,
+		Proof:          mapModelToAPIDiceRollProof(r.DiceRoll.Proof),
+		Pool:           mapModelToAPIDiceRollPoolResult(r.DiceRoll.Pool),
+		Check:          mapModelToAPIDiceRollCheckResult(r.DiceRoll.Check),
+		Visibility:     mapModelToAPIDiceRollVisibility(r.DiceRoll.Visibility),
+		WhisperUserIDs: r.DiceRoll.WhisperUserIDs,
+	}
+}
+
+// hasCustomDieTypeIDs returns true if any of the die type IDs is not a dN die type.
+func hasCustomDieTypeIDs(ids []string) bool {
+	for _, id := range ids {
+		if _, err := model.DieTypeFromID(id); err != nil {
+			return true
+		}
 	}
+
+	return false
 }
 
-func mapAPIToModelcreateDiceRoll(r createDiceRollRequest) (*dice.CreateDiceRollRequest, error) {
+// mapAPIToModelcreateDiceRoll maps the request, the die type IDs that are not dN die types
+// will be searched on the room die types.
+func mapAPIToModelcreateDiceRoll(r createDiceRollRequest, roomDieTypes []model.DieType) (*dice.CreateDiceRollRequest, error) {
 	if r.UserID == "" {
 		return nil, fmt.Errorf("user_id is required")
 	}
@@ -83,26 +453,145 @@ func mapAPIToModelcreateDiceRoll(r createDiceRollRequest) (*dice.CreateDiceRollR
 		return nil, fmt.Errorf("room_id is required")
 	}
 
+	visibility, err := mapAPIToModelDiceRollVisibility(r.Visibility)
+	if err != nil {
+		return nil, err
+	}
+
+	check, err := mapAPIToModelDiceCheck(r.Check)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Expression != "" {
+		if len(r.DiceTypeIDs) != 0 {
+			return nil, fmt.Errorf("dice_type_ids and expression can't be used at the same time")
+		}
+
+		if r.Modifiers != nil {
+			return nil, fmt.Errorf("modifiers and expression can't be used at the same time")
+		}
+
+		if r.Pool != nil {
+			return nil, fmt.Errorf("pool and expression can't be used at the same time")
+		}
+
+		if r.Modifier != 0 {
+			return nil, fmt.Errorf("modifier and expression can't be used at the same time")
+		}
+
+		if r.Advantage != "" {
+			return nil, fmt.Errorf("advantage and expression can't be used at the same time")
+		}
+
+		return &dice.CreateDiceRollRequest{
+			UserID:         r.UserID,
+			RoomID:         r.RoomID,
+			Expression:     r.Expression,
+			ClientSeed:     r.ClientSeed,
+			Label:          r.Label,
+			Check:          check,
+			Visibility:     visibility,
+			WhisperUserIDs: r.WhisperUserIDs,
+		}, nil
+	}
+
 	if len(r.DiceTypeIDs) == 0 {
 		return nil, fmt.Errorf("dice_type_ids are required")
 	}
 
+	advantage, err := mapAPIToModelAdvantageMode(r.Advantage)
+	if err != nil {
+		return nil, err
+	}
+
 	dts := make([]model.DieType, 0, len(r.DiceTypeIDs))
 	for _, id := range r.DiceTypeIDs {
-		dt, ok := model.DiceTypes[id]
-		if !ok {
-			return nil, fmt.Errorf("%s die type is not valid", id)
+		dt, err := model.DieTypeFromID(id)
+		if err != nil {
+			idx := slices.IndexFunc(roomDieTypes, func(dt model.DieType) bool { return dt.ID() == id })
+			if idx < 0 {
+				return nil, fmt.Errorf("%s die type is not valid", id)
+			}
+			dt = roomDieTypes[idx]
 		}
 		dts = append(dts, dt)
 	}
 
 	return &dice.CreateDiceRollRequest{
-		UserID: r.UserID,
-		RoomID: r.RoomID,
-		Dice:   dts,
+		UserID:         r.UserID,
+		RoomID:         r.RoomID,
+		Dice:           dts,
+		ClientSeed:     r.ClientSeed,
+		Label:          r.Label,
+		Modifiers:      mapAPIToModelDiceRollModifiers(r.Modifiers),
+		Pool:           mapAPIToModelDicePool(r.Pool),
+		Check:          check,
+		Modifier:       r.Modifier,
+		Advantage:      advantage,
+		Visibility:     visibility,
+		WhisperUserIDs: r.WhisperUserIDs,
 	}, nil
 }
 
+type createDiceRollsRequest struct {
+	UserID string `json:"user_id"`
+	RoomID string `json:"room_id"`
+	// Items are the independent dice rolls created in order (e.g: 8 attacks of `1d20+5`), the
+	// items user and room can be omitted, they are the ones of the request.
+	Items []createDiceRollRequest `json:"items"`
+}
+
+type createDiceRollsResponse struct {
+	Items []createDiceRollResponse `json:"items"`
+}
+
+// mapAPIToModelCreateDiceRolls maps the request, the die type IDs that are not dN die types
+// will be searched on the room die types.
+func mapAPIToModelCreateDiceRolls(r createDiceRollsRequest, roomDieTypes []model.DieType) (*dice.CreateDiceRollsRequest, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+
+	if r.RoomID == "" {
+		return nil, fmt.Errorf("room_id is required")
+	}
+
+	if len(r.Items) == 0 {
+		return nil, fmt.Errorf("items are required")
+	}
+
+	items := make([]dice.CreateDiceRollRequest, 0, len(r.Items))
+	for i, item := range r.Items {
+		if item.UserID != "" && item.UserID != r.UserID {
+			return nil, fmt.Errorf("items[%d]: user_id must be the request one", i)
+		}
+
+		if item.RoomID != "" && item.RoomID != r.RoomID {
+			return nil, fmt.Errorf("items[%d]: room_id must be the request one", i)
+		}
+
+		item.UserID = r.UserID
+		item.RoomID = r.RoomID
+		mItem, err := mapAPIToModelcreateDiceRoll(item, roomDieTypes)
+		if err != nil {
+			return nil, fmt.Errorf("items[%d]: %w", i, err)
+		}
+		items = append(items, *mItem)
+	}
+
+	return &dice.CreateDiceRollsRequest{Items: items}, nil
+}
+
+func mapModelToAPICreateDiceRolls(r dice.CreateDiceRollsResponse) createDiceRollsResponse {
+	items := make([]createDiceRollResponse, 0, len(r.DiceRolls))
+	for _, dr := range r.DiceRolls {
+		items = append(items, mapModelToAPIcreateDiceRoll(dice.CreateDiceRollResponse{DiceRoll: dr}))
+	}
+
+	return createDiceRollsResponse{Items: items}
+}
+
 type listDiceRollsResponse struct {
 	Items []diceRollResponse `json:"items"`
 	Meta  metadata           `json:"metadata"`
@@ -118,36 +607,44 @@ type metadata struct {
 type diceRollResponse struct {
 	ID string `json:"id"`
 	// Representation in RFC3339.
-	CreateAt string            `json:"created_at"`
-	UserID   string            `json:"user_id"`
-	RoomID   string            `json:"room_id"`
-	Dice     []dieRollResponse `json:"dice"`
+	CreateAt   string            `json:"created_at"`
+	UserID     string            `json:"user_id"`
+	RoomID     string            `json:"room_id"`
+	Dice       []dieRollResponse `json:"dice"`
+	Expression string            `json:"expression"`
+	Label      string            `json:"label"`
+	Modifier   int               `json:"modifier"`
+	Total      int               `json:"total"`
+	// Proof is only set on the provably fair dice rolls.
+	Proof *diceRollProof `json:"proof,omitempty"`
+	// Pool is only set on the dice pools.
+	Pool *diceRollPoolResult `json:"pool,omitempty"`
+	// Check is only set on the difficulty checks.
+	Check *diceRollCheckResult `json:"check,omitempty"`
+	// Visibility is `public`, `game_master` or `whisper`.
+	Visibility string `json:"visibility"`
+	// WhisperUserIDs are only set on the whispered dice rolls.
+	WhisperUserIDs []string `json:"whisper_user_ids,omitempty"`
+	// ParentID is only set on the rerolls, it's the rerolled dice roll.
+	ParentID string `json:"parent_id,omitempty"`
+	// Hidden is set when the viewer can't see the dice roll, in that case only its metadata is set.
+	Hidden bool `json:"hidden,omitempty"`
 }
 
 type dieRollResponse struct {
 	ID     string `json:"id"`
 	TypeID string `json:"type_id"`
 	Side   uint   `json:"side"`
+	// Face is only set on the custom die types.
+	Face *dieFace `json:"face,omitempty"`
+	// Status is not set on the dice that count normally (`exploded`, `rerolled` or `dropped`).
+	Status string `json:"status,omitempty"`
 }
 
 func mapModelToAPIListDiceRolls(r dice.ListDiceRollsResponse) listDiceRollsResponse {
 	items := make([]diceRollResponse, 0, len(r.DiceRolls))
 	for _, dr := range r.DiceRolls {
-		ds := make([]dieRollResponse, 0, len(dr.Dice))
-		for _, d := range dr.Dice {
-			ds = append(ds, dieRollResponse{
-				ID:     d.ID,
-				TypeID: d.Type.ID(),
-				Side:   d.Side,
-			})
-		}
-		items = append(items, diceRollResponse{
-			ID:       dr.ID,
-			CreateAt: dr.CreatedAt.Format(time.RFC3339),
-			RoomID:   dr.RoomID,
-			UserID:   dr.UserID,
-			Dice:     ds,
-		})
+		items = append(items, mapModelToAPIDiceRoll(dr))
 	}
 
 	return listDiceRollsResponse{
@@ -161,6 +658,42 @@ func mapModelToAPIListDiceRolls(r dice.ListDiceRollsResponse) listDiceRollsRespo
 	}
 }
 
+func mapModelToAPIDiceRoll(dr model.DiceRoll) diceRollResponse {
+	return diceRollResponse{
+		ID:             dr.ID,
+		CreateAt:       dr.CreatedAt.Format(time.RFC3339),
+		RoomID:         dr.RoomID,
+		UserID:         dr.UserID,
+		Dice:           mapModelToAPIDieRolls(dr.Dice),
+		Expression:     dr.Expression,
+		Label:          dr.Label,
+		Modifier:       dr.Modifier,
+		Total:          dr.Total,
+		Proof:          mapModelToAPIDiceRollProof(dr.Proof),
+		Pool:           mapModelToAPIDiceRollPoolResult(dr.Pool),
+		Check:          mapModelToAPIDiceRollCheckResult(dr.Check),
+		Visibility:     mapModelToAPIDiceRollVisibility(dr.Visibility),
+		WhisperUserIDs: dr.WhisperUserIDs,
+		ParentID:       dr.ParentID,
+		Hidden:         dr.Hidden,
+	}
+}
+
+func mapModelToAPIDieRolls(dice []model.DieRoll) []dieRollResponse {
+	ds := make([]dieRollResponse, 0, len(dice))
+	for _, d := range dice {
+		ds = append(ds, dieRollResponse{
+			ID:     d.ID,
+			TypeID: d.Type.ID(),
+			Side:   d.Side,
+			Face:   mapModelToAPIDieFace(d),
+			Status: mapModelToAPIDieRollStatus(d.Status),
+		})
+	}
+
+	return ds
+}
+
 const (
 	listDiceRollsParamUserID      = "user-id"
 	listDiceRollsurlParamRoomID   = "room-id"
@@ -205,14 +738,153 @@ func mapAPIToModelListDiceRolls(p url.Values) (*dice.ListDiceRollsRequest, error
 	}, nil
 }
 
+type serverSeedResponse struct {
+	ID     string `json:"id"`
+	RoomID string `json:"room_id"`
+	// Representation in RFC3339.
+	CreateAt string `json:"created_at"`
+	// Seed is only set once the server seed has been revealed.
+	Seed     string `json:"seed,omitempty"`
+	Hash     string `json:"hash"`
+	Nonce    uint   `json:"nonce"`
+	Revealed bool   `json:"revealed"`
+}
+
+func mapModelToAPIServerSeed(s model.ServerSeed) serverSeedResponse {
+	return serverSeedResponse{
+		ID:       s.ID,
+		RoomID:   s.RoomID,
+		CreateAt: s.CreatedAt.Format(time.RFC3339),
+		Seed:     s.Seed,
+		Hash:     s.Hash,
+		Nonce:    s.Nonce,
+		Revealed: s.Revealed,
+	}
+}
+
+const roomServerSeedurlParamRoomID = "id"
+
+func mapAPIToModelGetRoomServerSeed(params map[string]string) (*dice.GetRoomServerSeedRequest, error) {
+	id, ok := params[roomServerSeedurlParamRoomID]
+	if !ok {
+		return nil, fmt.Errorf("room id is required")
+	}
+
+	return &dice.GetRoomServerSeedRequest{
+		RoomID: id,
+	}, nil
+}
+
+func mapModelToAPIGetRoomServerSeed(r dice.GetRoomServerSeedResponse) serverSeedResponse {
+	return mapModelToAPIServerSeed(r.ServerSeed)
+}
+
+type rotateRoomServerSeedResponse struct {
+	// RevealedServerSeed is not set if the room didn't have a server seed.
+	RevealedServerSeed *serverSeedResponse `json:"revealed_server_seed,omitempty"`
+	ServerSeed         serverSeedResponse  `json:"server_seed"`
+}
+
+func mapAPIToModelRotateRoomServerSeed(params map[string]string) (*dice.RotateRoomServerSeedRequest, error) {
+	id, ok := params[roomServerSeedurlParamRoomID]
+	if !ok {
+		return nil, fmt.Errorf("room id is required")
+	}
+
+	return &dice.RotateRoomServerSeedRequest{
+		RoomID: id,
+	}, nil
+}
+
+func mapModelToAPIRotateRoomServerSeed(r dice.RotateRoomServerSeedResponse) rotateRoomServerSeedResponse {
+	var revealed *serverSeedResponse
+	if r.RevealedServerSeed != nil {
+		s := mapModelToAPIServerSeed(*r.RevealedServerSeed)
+		revealed = &s
+	}
+
+	return rotateRoomServerSeedResponse{
+		RevealedServerSeed: revealed,
+		ServerSeed:         mapModelToAPIServerSeed(r.ServerSeed),
+	}
+}
+
+type verifyDiceRollResponse struct {
+	DiceRoll   diceRollResponse   `json:"dice_roll"`
+	ServerSeed serverSeedResponse `json:"server_seed"`
+	// Dice are the dice recomputed from the dice roll proof.
+	Dice  []dieRollResponse `json:"dice"`
+	Valid bool              `json:"valid"`
+}
+
+const verifyDiceRollurlParamDiceRollID = "id"
+
+func mapAPIToModelVerifyDiceRoll(params map[string]string) (*dice.VerifyDiceRollRequest, error) {
+	id, ok := params[verifyDiceRollurlParamDiceRollID]
+	if !ok {
+		return nil, fmt.Errorf("dice roll id is required")
+	}
+
+	return &dice.VerifyDiceRollRequest{
+		DiceRollID: id,
+	}, nil
+}
+
+func mapModelToAPIVerifyDiceRoll(r dice.VerifyDiceRollResponse) verifyDiceRollResponse {
+	return verifyDiceRollResponse{
+		DiceRoll:   mapModelToAPIDiceRoll(r.DiceRoll),
+		ServerSeed: mapModelToAPIServerSeed(r.ServerSeed),
+		Dice:       mapModelToAPIDieRolls(r.Dice),
+		Valid:      r.Valid,
+	}
+}
+
+type rerollDiceRequest struct {
+	UserID string   `json:"user_id"`
+	DieIDs []string `json:"die_ids"`
+}
+
+const rerollDiceurlParamDiceRollID = "id"
+
+func mapAPIToModelRerollDice(params map[string]string, r rerollDiceRequest) (*dice.RerollDiceRequest, error) {
+	id, ok := params[rerollDiceurlParamDiceRollID]
+	if !ok {
+		return nil, fmt.Errorf("dice roll id is required")
+	}
+
+	if r.UserID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+
+	if len(r.DieIDs) == 0 {
+		return nil, fmt.Errorf("die_ids are required")
+	}
+
+	return &dice.RerollDiceRequest{
+		DiceRollID: id,
+		UserID:     r.UserID,
+		DieIDs:     r.DieIDs,
+	}, nil
+}
+
+func mapModelToAPIRerollDice(r dice.RerollDiceResponse) diceRollResponse {
+	return mapModelToAPIDiceRoll(r.DiceRoll)
+}
+
 type createRoomResponse struct {
 	ID string `json:"id"`
 	// Representation in RFC3339.
 	CreateAt string `json:"created_at"`
 	Name     string `json:"name"`
+	// Ruleset is the game system ruleset ID of the room (e.g: `dnd5e`), if any.
+	Ruleset string `json:"ruleset,omitempty"`
 }
 type createRoomRequest struct {
 	Name string `json:"name"`
+	// Ruleset is the game system ruleset ID of the room: `dnd5e`, `pbta`, `blades` or `coc`, optional.
+	Ruleset string `json:"ruleset"`
+	// Password protects the room, the users will need it to join the room, optional.
+	Password string `json:"password"`
 }
 
 func mapModelToAPICreateRoom(r room.CreateRoomResponse) createRoomResponse {
@@ -220,6 +892,7 @@ func mapModelToAPICreateRoom(r room.CreateRoomResponse) createRoomResponse {
 		ID:       r.Room.ID,
 		CreateAt: r.Room.CreatedAt.Format(time.RFC3339),
 		Name:     r.Room.Name,
+		Ruleset:  r.Room.Ruleset,
 	}
 }
 
@@ -229,7 +902,9 @@ func mapAPIToModelCreateRoom(r createRoomRequest) (*room.CreateRoomRequest, erro
 	}
 
 	return &room.CreateRoomRequest{
-		Name: r.Name,
+		Name:     r.Name,
+		Ruleset:  r.Ruleset,
+		Password: r.Password,
 	}, nil
 }
 
@@ -238,13 +913,22 @@ type getRoomResponse struct {
 	// Representation in RFC3339.
 	CreateAt string `json:"created_at"`
 	Name     string `json:"name"`
+	// Ruleset is the game system ruleset ID of the room (e.g: `dnd5e`), if any.
+	Ruleset string `json:"ruleset,omitempty"`
+	// Archived rooms are read-only.
+	Archived bool `json:"archived"`
+	// Protected rooms need a password or an invite token to join them.
+	Protected bool `json:"protected"`
 }
 
 func mapModelToAPIGetRoom(r room.GetRoomResponse) getRoomResponse {
 	return getRoomResponse{
-		ID:       r.Room.ID,
-		CreateAt: r.Room.CreatedAt.Format(time.RFC3339),
-		Name:     r.Room.Name,
+		ID:        r.Room.ID,
+		CreateAt:  r.Room.CreatedAt.Format(time.RFC3339),
+		Name:      r.Room.Name,
+		Ruleset:   r.Room.Ruleset,
+		Archived:  r.Room.Archived,
+		Protected: r.Room.Protected(),
 	}
 }
 
@@ -261,24 +945,57 @@ func mapAPIToModelGetRoom(params map[string]string) (*room.GetRoomRequest, error
 	}, nil
 }
 
-type createUserResponse struct {
-	ID string `json:"id"`
-	// Representation in RFC3339.
-	CreateAt string `json:"created_at"`
-	Name     string `json:"name"`
-	RoomID   string `json:"room_id"`
+func mapAPIToModelDeleteRoom(params map[string]string) (*room.DeleteRoomRequest, error) {
+	id, ok := params[getRoomurlParamRoomID]
+	if !ok {
+		return nil, fmt.Errorf("room id is required")
+	}
+
+	return &room.DeleteRoomRequest{
+		ID: id,
+	}, nil
 }
-type createUserRequest struct {
+
+func mapAPIToModelArchiveRoom(params map[string]string) (*room.ArchiveRoomRequest, error) {
+	id, ok := params[getRoomurlParamRoomID]
+	if !ok {
+		return nil, fmt.Errorf("room id is required")
+	}
+
+	return &room.ArchiveRoomRequest{
+		ID: id,
+	}, nil
+}
+
+type createUserResponse struct {
+	ID string `json:"id"`
+	// Representation in RFC3339.
+	CreateAt   string `json:"created_at"`
+	Name       string `json:"name"`
+	RoomID     string `json:"room_id"`
+	GameMaster bool   `json:"game_master"`
+	// SessionToken authenticates the user on the next requests (`Authorization: Bearer <token>` header).
+	SessionToken string `json:"session_token"`
+}
+type createUserRequest struct {
 	Name   string `json:"name"`
 	RoomID string `json:"room_id"`
+	// GameMaster is only allowed for the first user of the room (the room creator).
+	GameMaster bool `json:"game_master"`
+	// Password or InviteToken are required to join protected rooms.
+	Password    string `json:"password"`
+	InviteToken string `json:"invite_token"`
 }
 
 func mapModelToAPICreateUser(r user.CreateUserResponse) createUserResponse {
 	return createUserResponse{
-		ID:       r.User.ID,
-		CreateAt: r.User.CreatedAt.Format(time.RFC3339),
-		Name:     r.User.Name,
-		RoomID:   r.User.RoomID,
+		ID:         r.User.ID,
+		CreateAt:   r.User.CreatedAt.Format(time.RFC3339),
+		Name:       r.User.Name,
+		RoomID:     r.User.RoomID,
+		GameMaster: r.User.GameMaster,
+
+		SessionToken: r.SessionToken,
 	}
 }
 
@@ -292,8 +1009,11 @@ func mapAPIToModelCreateUser(r createUserRequest) (*user.CreateUserRequest, erro
 	}
 
 	return &user.CreateUserRequest{
-		Name:   r.Name,
-		RoomID: r.RoomID,
+		Name:        r.Name,
+		RoomID:      r.RoomID,
+		GameMaster:  r.GameMaster,
+		Password:    r.Password,
+		InviteToken: r.InviteToken,
 	}, nil
 }
 
@@ -305,16 +1025,18 @@ type userResponse struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
 	// Representation in RFC3339.
-	CreateAt string `json:"created_at"`
+	CreateAt   string `json:"created_at"`
+	GameMaster bool   `json:"game_master"`
 }
 
 func mapModelToAPIListUsers(r user.ListUsersResponse) listUsersResponse {
 	items := make([]userResponse, 0, len(r.Users))
 	for _, u := range r.Users {
 		items = append(items, userResponse{
-			ID:       u.ID,
-			Name:     u.Name,
-			CreateAt: u.CreatedAt.Format(time.RFC3339),
+			ID:         u.ID,
+			Name:       u.Name,
+			CreateAt:   u.CreatedAt.Format(time.RFC3339),
+			GameMaster: u.GameMaster,
 		})
 	}
 	return listUsersResponse{
@@ -335,6 +1057,649 @@ func mapAPIToModelListUsers(p url.Values) (*user.ListUsersRequest, error) {
 	}, nil
 }
 
+type diceStatsResponse struct {
+	Items []dieTypeStatsResponse `json:"items"`
+}
+
+type dieTypeStatsResponse struct {
+	DieTypeID string `json:"die_type_id"`
+	Rolls     uint   `json:"rolls"`
+	// Distribution are the times each side has been rolled, the first one is the side 1.
+	Distribution     []uint  `json:"distribution"`
+	Mean             float64 `json:"mean"`
+	ExpectedMean     float64 `json:"expected_mean"`
+	ChiSquare        float64 `json:"chi_square"`
+	DegreesOfFreedom uint    `json:"degrees_of_freedom"`
+	// PValue is the probability of a fair die getting the same or a bigger chi-square.
+	PValue float64 `json:"p_value"`
+	// EnoughRolls is false when there are not enough rolls to trust the chi-square test.
+	EnoughRolls         bool `json:"enough_rolls"`
+	LongestRepeatStreak uint `json:"longest_repeat_streak"`
+	LongestHighStreak   uint `json:"longest_high_streak"`
+	LongestLowStreak    uint `json:"longest_low_streak"`
+}
+
+const (
+	diceStatsurlParamRoomID = "room-id"
+	diceStatsurlParamUserID = "user-id"
+)
+
+func mapAPIToModelGetDiceStats(p url.Values) (*stats.GetDiceStatsRequest, error) {
+	roomID := p.Get(diceStatsurlParamRoomID)
+	if roomID == "" {
+		return nil, fmt.Errorf("room-id is required")
+	}
+
+	return &stats.GetDiceStatsRequest{
+		RoomID: roomID,
+		UserID: p.Get(diceStatsurlParamUserID),
+	}, nil
+}
+
+func mapModelToAPIGetDiceStats(r stats.GetDiceStatsResponse) diceStatsResponse {
+	items := make([]dieTypeStatsResponse, 0, len(r.DieTypes))
+	for _, d := range r.DieTypes {
+		items = append(items, dieTypeStatsResponse{
+			DieTypeID:           d.DieType.ID(),
+			Rolls:               d.Rolls,
+			Distribution:        d.Distribution,
+			Mean:                d.Mean,
+			ExpectedMean:        d.ExpectedMean,
+			ChiSquare:           d.ChiSquare,
+			DegreesOfFreedom:    d.DegreesOfFreedom,
+			PValue:              d.PValue,
+			EnoughRolls:         d.EnoughRolls,
+			LongestRepeatStreak: d.LongestRepeatStreak,
+			LongestHighStreak:   d.LongestHighStreak,
+			LongestLowStreak:    d.LongestLowStreak,
+		})
+	}
+
+	return diceStatsResponse{
+		Items: items,
+	}
+}
+
+const (
+	diceProbabilitiesParamRoomID          = "room-id"
+	diceProbabilitiesParamUserID          = "user-id"
+	diceProbabilitiesParamDiceTypeIDs     = "dice-type-ids"
+	diceProbabilitiesParamExpression      = "expression"
+	diceProbabilitiesParamModifier        = "modifier"
+	diceProbabilitiesParamAdvantage       = "advantage"
+	diceProbabilitiesParamRerollBelow     = "reroll-below"
+	diceProbabilitiesParamExplode         = "explode"
+	diceProbabilitiesParamKeepHighest     = "keep-highest"
+	diceProbabilitiesParamKeepLowest      = "keep-lowest"
+	diceProbabilitiesParamDropHighest     = "drop-highest"
+	diceProbabilitiesParamDropLowest      = "drop-lowest"
+	diceProbabilitiesParamPoolTarget      = "pool-target"
+	diceProbabilitiesParamPoolOnesCancel  = "pool-ones-cancel"
+	diceProbabilitiesParamCheckTarget     = "check-target"
+	diceProbabilitiesParamCheckComparison = "check-comparison"
+)
+
+// diceProbabilitiesDiceTypeIDs returns the comma separated die type IDs of the query.
+func diceProbabilitiesDiceTypeIDs(p url.Values) []string {
+	v := p.Get(diceProbabilitiesParamDiceTypeIDs)
+	if v == "" {
+		return nil
+	}
+
+	return strings.Split(v, ",")
+}
+
+// mapAPIToModelCalculateDiceProbabilities maps the query, the dice roll is described like on the dice
+// roll creation but with query params (e.g: `?dice-type-ids=d8,d8&modifier=3&check-target=15`).
+func mapAPIToModelCalculateDiceProbabilities(p url.Values, roomDieTypes []model.DieType) (*dice.CalculateProbabilitiesRequest, error) {
+	queryInt := func(key string) (int, error) {
+		v := p.Get(key)
+		if v == "" {
+			return 0, nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("%s '%s' is invalid", key, v)
+		}
+		return n, nil
+	}
+	queryUint := func(key string) (uint, error) {
+		n, err := queryInt(key)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("%s '%s' is invalid", key, p.Get(key))
+		}
+		return uint(n), nil
+	}
+
+	var err error
+	mods := &diceRollModifiers{Explode: p.Get(diceProbabilitiesParamExplode) == "true"}
+	for _, m := range []struct {
+		key string
+		v   *uint
+	}{
+		{key: diceProbabilitiesParamRerollBelow, v: &mods.RerollBelow},
+		{key: diceProbabilitiesParamKeepHighest, v: &mods.KeepHighest},
+		{key: diceProbabilitiesParamKeepLowest, v: &mods.KeepLowest},
+		{key: diceProbabilitiesParamDropHighest, v: &mods.DropHighest},
+		{key: diceProbabilitiesParamDropLowest, v: &mods.DropLowest},
+	} {
+		*m.v, err = queryUint(m.key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pool := &diceRollPool{OnesCancel: p.Get(diceProbabilitiesParamPoolOnesCancel) == "true"}
+	pool.Target, err = queryUint(diceProbabilitiesParamPoolTarget)
+	if err != nil {
+		return nil, err
+	}
+
+	modifier, err := queryInt(diceProbabilitiesParamModifier)
+	if err != nil {
+		return nil, err
+	}
+
+	var check dice.DiceCheck
+	if p.Get(diceProbabilitiesParamCheckTarget) != "" {
+		target, err := queryInt(diceProbabilitiesParamCheckTarget)
+		if err != nil {
+			return nil, err
+		}
+		check, err = mapAPIToModelDiceCheck(&diceRollCheck{Target: target, Comparison: p.Get(diceProbabilitiesParamCheckComparison)})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	roomID, userID := p.Get(diceProbabilitiesParamRoomID), p.Get(diceProbabilitiesParamUserID)
+	if roomID == "" || userID == "" {
+		return nil, fmt.Errorf("room-id and user-id are required")
+	}
+
+	expression := p.Get(diceProbabilitiesParamExpression)
+	ids := diceProbabilitiesDiceTypeIDs(p)
+	if expression != "" {
+		if len(ids) != 0 {
+			return nil, fmt.Errorf("dice-type-ids and expression can't be used at the same time")
+		}
+
+		return &dice.CalculateProbabilitiesRequest{
+			RoomID:     roomID,
+			UserID:     userID,
+			Expression: expression,
+			Check:      check,
+		}, nil
+	}
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("dice-type-ids or expression are required")
+	}
+
+	advantage, err := mapAPIToModelAdvantageMode(p.Get(diceProbabilitiesParamAdvantage))
+	if err != nil {
+		return nil, err
+	}
+
+	dts := make([]model.DieType, 0, len(ids))
+	for _, id := range ids {
+		dt, err := model.DieTypeFromID(id)
+		if err != nil {
+			idx := slices.IndexFunc(roomDieTypes, func(dt model.DieType) bool { return dt.ID() == id })
+			if idx < 0 {
+				return nil, fmt.Errorf("%s die type is not valid", id)
+			}
+			dt = roomDieTypes[idx]
+		}
+		dts = append(dts, dt)
+	}
+
+	return &dice.CalculateProbabilitiesRequest{
+		RoomID:    roomID,
+		UserID:    userID,
+		Dice:      dts,
+		Modifiers: mapAPIToModelDiceRollModifiers(mods),
+		Pool:      mapAPIToModelDicePool(pool),
+		Advantage: advantage,
+		Modifier:  modifier,
+		Check:     check,
+	}, nil
+}
+
+type diceProbabilitiesResponse struct {
+	// Exact is false when the probabilities are estimated simulating dice rolls (e.g: exploding dice).
+	Exact bool    `json:"exact"`
+	Mean  float64 `json:"mean"`
+	// CheckProbability is the probability of passing the check, only when the request has a check.
+	CheckProbability *float64 `json:"check_probability,omitempty"`
+	// Totals are the possible totals with their probability, sorted by total.
+	Totals []totalProbabilityResponse `json:"totals"`
+}
+
+type totalProbabilityResponse struct {
+	Total       int     `json:"total"`
+	Probability float64 `json:"probability"`
+}
+
+func mapModelToAPICalculateDiceProbabilities(r dice.CalculateProbabilitiesResponse, hasCheck bool) diceProbabilitiesResponse {
+	totals := make([]totalProbabilityResponse, 0, len(r.Distribution.Totals))
+	for _, t := range r.Distribution.Totals {
+		totals = append(totals, totalProbabilityResponse{Total: t.Total, Probability: t.Probability})
+	}
+
+	res := diceProbabilitiesResponse{
+		Exact:  r.Distribution.Exact,
+		Mean:   r.Distribution.Mean(),
+		Totals: totals,
+	}
+	if hasCheck {
+		p := r.CheckProbability
+		res.CheckProbability = &p
+	}
+
+	return res
+}
+
+type macroResponse struct {
+	ID string `json:"id"`
+	// Representation in RFC3339.
+	CreateAt   string `json:"created_at"`
+	RoomID     string `json:"room_id"`
+	UserID     string `json:"user_id"`
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+	Shared     bool   `json:"shared"`
+}
+
+func mapModelToAPIMacro(m model.Macro) macroResponse {
+	return macroResponse{
+		ID:         m.ID,
+		CreateAt:   m.CreatedAt.Format(time.RFC3339),
+		RoomID:     m.RoomID,
+		UserID:     m.UserID,
+		Name:       m.Name,
+		Expression: m.Expression,
+		Shared:     m.Shared,
+	}
+}
+
+type createMacroRequest struct {
+	RoomID     string `json:"room_id"`
+	UserID     string `json:"user_id"`
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+	// Shared makes the macro usable by all the room users.
+	Shared bool `json:"shared"`
+}
+
+func mapAPIToModelCreateMacro(r createMacroRequest) (*macro.CreateMacroRequest, error) {
+	if r.RoomID == "" {
+		return nil, fmt.Errorf("room_id is required")
+	}
+
+	if r.UserID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+
+	return &macro.CreateMacroRequest{
+		RoomID:     r.RoomID,
+		UserID:     r.UserID,
+		Name:       r.Name,
+		Expression: r.Expression,
+		Shared:     r.Shared,
+	}, nil
+}
+
+func mapModelToAPICreateMacro(r macro.CreateMacroResponse) macroResponse {
+	return mapModelToAPIMacro(r.Macro)
+}
+
+type listMacrosResponse struct {
+	Items []macroResponse `json:"items"`
+}
+
+const (
+	macrosurlParamMacroID = "id"
+	macrosurlParamRoomID  = "room-id"
+	macrosurlParamUserID  = "user-id"
+)
+
+func mapAPIToModelListMacros(p url.Values) (*macro.ListMacrosRequest, error) {
+	roomID := p.Get(macrosurlParamRoomID)
+	if roomID == "" {
+		return nil, fmt.Errorf("room-id is required")
+	}
+
+	return &macro.ListMacrosRequest{
+		RoomID: roomID,
+		UserID: p.Get(macrosurlParamUserID),
+	}, nil
+}
+
+func mapModelToAPIListMacros(r macro.ListMacrosResponse) listMacrosResponse {
+	items := make([]macroResponse, 0, len(r.Macros))
+	for _, m := range r.Macros {
+		items = append(items, mapModelToAPIMacro(m))
+	}
+
+	return listMacrosResponse{
+		Items: items,
+	}
+}
+
+func mapAPIToModelGetMacro(params map[string]string, p url.Values) (*macro.GetMacroRequest, error) {
+	id, ok := params[macrosurlParamMacroID]
+	if !ok {
+		return nil, fmt.Errorf("macro id is required")
+	}
+
+	userID := p.Get(macrosurlParamUserID)
+	if userID == "" {
+		return nil, fmt.Errorf("user-id is required")
+	}
+
+	return &macro.GetMacroRequest{
+		MacroID: id,
+		UserID:  userID,
+	}, nil
+}
+
+func mapModelToAPIGetMacro(r macro.GetMacroResponse) macroResponse {
+	return mapModelToAPIMacro(r.Macro)
+}
+
+type updateMacroRequest struct {
+	UserID     string `json:"user_id"`
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+	Shared     bool   `json:"shared"`
+}
+
+func mapAPIToModelUpdateMacro(params map[string]string, r updateMacroRequest) (*macro.UpdateMacroRequest, error) {
+	id, ok := params[macrosurlParamMacroID]
+	if !ok {
+		return nil, fmt.Errorf("macro id is required")
+	}
+
+	if r.UserID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+
+	return &macro.UpdateMacroRequest{
+		MacroID:    id,
+		UserID:     r.UserID,
+		Name:       r.Name,
+		Expression: r.Expression,
+		Shared:     r.Shared,
+	}, nil
+}
+
+func mapModelToAPIUpdateMacro(r macro.UpdateMacroResponse) macroResponse {
+	return mapModelToAPIMacro(r.Macro)
+}
+
+func mapAPIToModelDeleteMacro(params map[string]string, p url.Values) (*macro.DeleteMacroRequest, error) {
+	id, ok := params[macrosurlParamMacroID]
+	if !ok {
+		return nil, fmt.Errorf("macro id is required")
+	}
+
+	userID := p.Get(macrosurlParamUserID)
+	if userID == "" {
+		return nil, fmt.Errorf("user-id is required")
+	}
+
+	return &macro.DeleteMacroRequest{
+		MacroID: id,
+		UserID:  userID,
+	}, nil
+}
+
+type initiativeResponse struct {
+	RoomID string `json:"room_id"`
+	// Representation in RFC3339, empty if the room doesn't have a combat.
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// Round is 0 if the room doesn't have a combat.
+	Round uint `json:"round"`
+	// Turn is the position on the combatants of the combatant that has the current turn.
+	Turn       int                 `json:"turn"`
+	Combatants []combatantResponse `json:"combatants"`
+}
+
+type combatantResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// UserID is only set on the user combatants.
+	UserID     string `json:"user_id,omitempty"`
+	Initiative int    `json:"initiative"`
+	// DiceRollID is only set on the combatants with a rolled initiative.
+	DiceRollID string `json:"dice_roll_id,omitempty"`
+}
+
+func mapModelToAPIInitiative(i model.Initiative) initiativeResponse {
+	updatedAt := ""
+	if !i.UpdatedAt.IsZero() {
+		updatedAt = i.UpdatedAt.Format(time.RFC3339)
+	}
+
+	cs := make([]combatantResponse, 0, len(i.Combatants))
+	for _, c := range i.Combatants {
+		cs = append(cs, mapModelToAPICombatant(c))
+	}
+
+	return initiativeResponse{
+		RoomID:     i.RoomID,
+		UpdatedAt:  updatedAt,
+		Round:      i.Round,
+		Turn:       i.Turn,
+		Combatants: cs,
+	}
+}
+
+func mapModelToAPICombatant(c model.InitiativeCombatant) combatantResponse {
+	return combatantResponse{
+		ID:         c.ID,
+		Name:       c.Name,
+		UserID:     c.UserID,
+		Initiative: c.Initiative,
+		DiceRollID: c.DiceRollID,
+	}
+}
+
+const (
+	initiativeurlParamRoomID      = "id"
+	initiativeurlParamCombatantID = "combatant-id"
+	initiativeurlParamUserID      = "user-id"
+)
+
+func mapAPIToModelGetInitiative(params map[string]string) (*initiative.GetInitiativeRequest, error) {
+	id, ok := params[initiativeurlParamRoomID]
+	if !ok {
+		return nil, fmt.Errorf("room id is required")
+	}
+
+	return &initiative.GetInitiativeRequest{
+		RoomID: id,
+	}, nil
+}
+
+func mapModelToAPIGetInitiative(r initiative.GetInitiativeResponse) initiativeResponse {
+	return mapModelToAPIInitiative(r.Initiative)
+}
+
+type addCombatantRequest struct {
+	// UserID is the room user adding the combatant.
+	UserID string `json:"user_id"`
+	// Name is required on NPCs, by default the user name on user combatants.
+	Name string `json:"name"`
+	// CombatantUserID is the room user the combatant is, empty on NPCs.
+	CombatantUserID string `json:"combatant_user_id"`
+	// Initiative is ignored if Expression is set.
+	Initiative int `json:"initiative"`
+	// Expression is a dice notation expression used to roll the initiative (e.g: `1d20+2`).
+	Expression string `json:"expression"`
+}
+
+type addCombatantResponse struct {
+	Initiative initiativeResponse `json:"initiative"`
+	Combatant  combatantResponse  `json:"combatant"`
+}
+
+func mapAPIToModelAddCombatant(params map[string]string, r addCombatantRequest) (*initiative.AddCombatantRequest, error) {
+	id, ok := params[initiativeurlParamRoomID]
+	if !ok {
+		return nil, fmt.Errorf("room id is required")
+	}
+
+	if r.UserID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+
+	return &initiative.AddCombatantRequest{
+		RoomID:          id,
+		UserID:          r.UserID,
+		Name:            r.Name,
+		CombatantUserID: r.CombatantUserID,
+		Initiative:      r.Initiative,
+		Expression:      r.Expression,
+	}, nil
+}
+
+func mapModelToAPIAddCombatant(r initiative.AddCombatantResponse) addCombatantResponse {
+	return addCombatantResponse{
+		Initiative: mapModelToAPIInitiative(r.Initiative),
+		Combatant:  mapModelToAPICombatant(r.Combatant),
+	}
+}
+
+type rollInitiativeRequest struct {
+	UserID     string `json:"user_id"`
+	Expression string `json:"expression"`
+}
+
+type rollInitiativeResponse struct {
+	Initiative initiativeResponse `json:"initiative"`
+	DiceRoll   diceRollResponse   `json:"dice_roll"`
+}
+
+func mapAPIToModelRollInitiative(params map[string]string, r rollInitiativeRequest) (*initiative.RollInitiativeRequest, error) {
+	id, ok := params[initiativeurlParamRoomID]
+	if !ok {
+		return nil, fmt.Errorf("room id is required")
+	}
+
+	combatantID, ok := params[initiativeurlParamCombatantID]
+	if !ok {
+		return nil, fmt.Errorf("combatant id is required")
+	}
+
+	if r.UserID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+
+	return &initiative.RollInitiativeRequest{
+		RoomID:      id,
+		UserID:      r.UserID,
+		CombatantID: combatantID,
+		Expression:  r.Expression,
+	}, nil
+}
+
+func mapModelToAPIRollInitiative(r initiative.RollInitiativeResponse) rollInitiativeResponse {
+	return rollInitiativeResponse{
+		Initiative: mapModelToAPIInitiative(r.Initiative),
+		DiceRoll:   mapModelToAPIDiceRoll(r.DiceRoll),
+	}
+}
+
+func mapAPIToModelRemoveCombatant(params map[string]string, p url.Values) (*initiative.RemoveCombatantRequest, error) {
+	id, ok := params[initiativeurlParamRoomID]
+	if !ok {
+		return nil, fmt.Errorf("room id is required")
+	}
+
+	combatantID, ok := params[initiativeurlParamCombatantID]
+	if !ok {
+		return nil, fmt.Errorf("combatant id is required")
+	}
+
+	userID := p.Get(initiativeurlParamUserID)
+	if userID == "" {
+		return nil, fmt.Errorf("user-id is required")
+	}
+
+	return &initiative.RemoveCombatantRequest{
+		RoomID:      id,
+		UserID:      userID,
+		CombatantID: combatantID,
+	}, nil
+}
+
+func mapModelToAPIRemoveCombatant(r initiative.RemoveCombatantResponse) initiativeResponse {
+	return mapModelToAPIInitiative(r.Initiative)
+}
+
+type changeTurnRequest struct {
+	UserID string `json:"user_id"`
+}
+
+func mapAPIToModelNextTurn(params map[string]string, r changeTurnRequest) (*initiative.NextTurnRequest, error) {
+	id, ok := params[initiativeurlParamRoomID]
+	if !ok {
+		return nil, fmt.Errorf("room id is required")
+	}
+
+	if r.UserID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+
+	return &initiative.NextTurnRequest{
+		RoomID: id,
+		UserID: r.UserID,
+	}, nil
+}
+
+func mapModelToAPINextTurn(r initiative.NextTurnResponse) initiativeResponse {
+	return mapModelToAPIInitiative(r.Initiative)
+}
+
+func mapAPIToModelPreviousTurn(params map[string]string, r changeTurnRequest) (*initiative.PreviousTurnRequest, error) {
+	id, ok := params[initiativeurlParamRoomID]
+	if !ok {
+		return nil, fmt.Errorf("room id is required")
+	}
+
+	if r.UserID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+
+	return &initiative.PreviousTurnRequest{
+		RoomID: id,
+		UserID: r.UserID,
+	}, nil
+}
+
+func mapModelToAPIPreviousTurn(r initiative.PreviousTurnResponse) initiativeResponse {
+	return mapModelToAPIInitiative(r.Initiative)
+}
+
+func mapAPIToModelEndCombat(params map[string]string, p url.Values) (*initiative.EndCombatRequest, error) {
+	id, ok := params[initiativeurlParamRoomID]
+	if !ok {
+		return nil, fmt.Errorf("room id is required")
+	}
+
+	userID := p.Get(initiativeurlParamUserID)
+	if userID == "" {
+		return nil, fmt.Errorf("user-id is required")
+	}
+
+	return &initiative.EndCombatRequest{
+		RoomID: id,
+		UserID: userID,
+	}, nil
+}
+
 type wsEventMeta struct {
 	Type string `json:"type"`
 }
@@ -350,3 +1715,421 @@ func mapModelToAPIWSDiceRollCreatedEvent(e model.EventDiceRollCreated) wsDiceRol
 		},
 	}
 }
+
+type deckResponse struct {
+	ID        string `json:"id"`
+	CreatedAt string `json:"created_at"` // Representation in RFC3339.
+	RoomID    string `json:"room_id"`
+	Name      string `json:"name"`
+	// Type is `standard`, `standard-jokers`, `tarot` or `custom`.
+	Type  string   `json:"type"`
+	Cards []string `json:"cards"`
+	// DrawPileCount is the quantity of cards left to draw, the draw pile order is not exposed.
+	DrawPileCount int      `json:"draw_pile_count"`
+	DiscardPile   []string `json:"discard_pile"`
+	// InPlay are the drawn cards that have not been discarded.
+	InPlay []string `json:"in_play"`
+}
+
+func mapModelToAPIDeck(d model.Deck) deckResponse {
+	return deckResponse{
+		ID:            d.ID,
+		CreatedAt:     d.CreatedAt.Format(time.RFC3339),
+		RoomID:        d.RoomID,
+		Name:          d.Name,
+		Type:          string(d.Type),
+		Cards:         d.Cards,
+		DrawPileCount: len(d.DrawPile),
+		DiscardPile:   d.DiscardPile,
+		InPlay:        d.InPlay(),
+	}
+}
+
+const (
+	decksurlParamRoomID = "id"
+	decksurlParamDeckID = "deck-id"
+)
+
+type createDeckRequest struct {
+	UserID string `json:"user_id"`
+	Name   string `json:"name"`
+	// Type is `standard`, `standard-jokers`, `tarot` or `custom`.
+	Type string `json:"type"`
+	// Cards are only used (and required) on `custom` decks.
+	Cards []string `json:"cards"`
+}
+
+func mapAPIToModelCreateDeck(params map[string]string, r createDeckRequest) (*deck.CreateDeckRequest, error) {
+	id, ok := params[decksurlParamRoomID]
+	if !ok {
+		return nil, fmt.Errorf("room id is required")
+	}
+
+	if r.UserID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+
+	return &deck.CreateDeckRequest{
+		RoomID: id,
+		UserID: r.UserID,
+		Name:   r.Name,
+		Type:   model.DeckType(r.Type),
+		Cards:  r.Cards,
+	}, nil
+}
+
+func mapModelToAPICreateDeck(r deck.CreateDeckResponse) deckResponse {
+	return mapModelToAPIDeck(r.Deck)
+}
+
+type listDecksResponse struct {
+	Items []deckResponse `json:"items"`
+}
+
+func mapAPIToModelListDecks(params map[string]string) (*deck.ListDecksRequest, error) {
+	id, ok := params[decksurlParamRoomID]
+	if !ok {
+		return nil, fmt.Errorf("room id is required")
+	}
+
+	return &deck.ListDecksRequest{
+		RoomID: id,
+	}, nil
+}
+
+func mapModelToAPIListDecks(r deck.ListDecksResponse) listDecksResponse {
+	items := make([]deckResponse, 0, len(r.Decks))
+	for _, d := range r.Decks {
+		items = append(items, mapModelToAPIDeck(d))
+	}
+
+	return listDecksResponse{Items: items}
+}
+
+type drawCardsRequest struct {
+	UserID string `json:"user_id"`
+	// Quantity is the number of cards to draw, by default 1.
+	Quantity int `json:"quantity"`
+}
+
+type cardDrawResponse struct {
+	ID        string   `json:"id"`
+	CreatedAt string   `json:"created_at"` // Representation in RFC3339.
+	UserID    string   `json:"user_id"`
+	DeckID    string   `json:"deck_id"`
+	Cards     []string `json:"cards"`
+}
+
+type drawCardsResponse struct {
+	CardDraw cardDrawResponse `json:"card_draw"`
+	Deck     deckResponse     `json:"deck"`
+}
+
+func mapAPIToModelDrawCards(params map[string]string, r drawCardsRequest) (*deck.DrawCardsRequest, error) {
+	id, ok := params[decksurlParamRoomID]
+	if !ok {
+		return nil, fmt.Errorf("room id is required")
+	}
+
+	deckID, ok := params[decksurlParamDeckID]
+	if !ok {
+		return nil, fmt.Errorf("deck id is required")
+	}
+
+	if r.UserID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+
+	return &deck.DrawCardsRequest{
+		RoomID:   id,
+		UserID:   r.UserID,
+		DeckID:   deckID,
+		Quantity: r.Quantity,
+	}, nil
+}
+
+func mapModelToAPIDrawCards(r deck.DrawCardsResponse) drawCardsResponse {
+	return drawCardsResponse{
+		CardDraw: cardDrawResponse{
+			ID:        r.CardDraw.ID,
+			CreatedAt: r.CardDraw.CreatedAt.Format(time.RFC3339),
+			UserID:    r.CardDraw.UserID,
+			DeckID:    r.CardDraw.DeckID,
+			Cards:     r.CardDraw.Cards,
+		},
+		Deck: mapModelToAPIDeck(r.Deck),
+	}
+}
+
+type discardCardsRequest struct {
+	UserID string `json:"user_id"`
+	// Cards are the cards in play to discard, if empty all the cards in play will be discarded.
+	Cards []string `json:"cards"`
+}
+
+func mapAPIToModelDiscardCards(params map[string]string, r discardCardsRequest) (*deck.DiscardCardsRequest, error) {
+	id, ok := params[decksurlParamRoomID]
+	if !ok {
+		return nil, fmt.Errorf("room id is required")
+	}
+
+	deckID, ok := params[decksurlParamDeckID]
+	if !ok {
+		return nil, fmt.Errorf("deck id is required")
+	}
+
+	if r.UserID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+
+	return &deck.DiscardCardsRequest{
+		RoomID: id,
+		UserID: r.UserID,
+		DeckID: deckID,
+		Cards:  r.Cards,
+	}, nil
+}
+
+func mapModelToAPIDiscardCards(r deck.DiscardCardsResponse) deckResponse {
+	return mapModelToAPIDeck(r.Deck)
+}
+
+type reshuffleDeckRequest struct {
+	UserID string `json:"user_id"`
+	// All shuffles all the deck cards (including the ones in play) instead of only the discard pile.
+	All bool `json:"all"`
+}
+
+func mapAPIToModelReshuffleDeck(params map[string]string, r reshuffleDeckRequest) (*deck.ReshuffleDeckRequest, error) {
+	id, ok := params[decksurlParamRoomID]
+	if !ok {
+		return nil, fmt.Errorf("room id is required")
+	}
+
+	deckID, ok := params[decksurlParamDeckID]
+	if !ok {
+		return nil, fmt.Errorf("deck id is required")
+	}
+
+	if r.UserID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+
+	return &deck.ReshuffleDeckRequest{
+		RoomID: id,
+		UserID: r.UserID,
+		DeckID: deckID,
+		All:    r.All,
+	}, nil
+}
+
+func mapModelToAPIReshuffleDeck(r deck.ReshuffleDeckResponse) deckResponse {
+	return mapModelToAPIDeck(r.Deck)
+}
+
+type oracleTableEntryResponse struct {
+	Min    int    `json:"min"`
+	Max    int    `json:"max"`
+	Result string `json:"result,omitempty"`
+	// Table is the name of the room table that is rolled too when the entry is selected.
+	Table string `json:"table,omitempty"`
+}
+
+type oracleTableResponse struct {
+	ID        string                     `json:"id"`
+	CreatedAt string                     `json:"created_at"` // Representation in RFC3339.
+	RoomID    string                     `json:"room_id"`
+	Name      string                     `json:"name"`
+	Dice      string                     `json:"dice"`
+	Entries   []oracleTableEntryResponse `json:"entries"`
+}
+
+func mapModelToAPIOracleTable(t model.OracleTable) oracleTableResponse {
+	entries := make([]oracleTableEntryResponse, 0, len(t.Entries))
+	for _, e := range t.Entries {
+		entries = append(entries, oracleTableEntryResponse{
+			Min:    e.Min,
+			Max:    e.Max,
+			Result: e.Result,
+			Table:  e.TableName,
+		})
+	}
+
+	return oracleTableResponse{
+		ID:        t.ID,
+		CreatedAt: t.CreatedAt.Format(time.RFC3339),
+		RoomID:    t.RoomID,
+		Name:      t.Name,
+		Dice:      t.Dice,
+		Entries:   entries,
+	}
+}
+
+type oracleTableResultResponse struct {
+	Table      string `json:"table"`
+	DiceRollID string `json:"dice_roll_id"`
+	Value      int    `json:"value"`
+	Result     string `json:"result"`
+}
+
+type oracleTableRollResponse struct {
+	ID        string `json:"id"`
+	CreatedAt string `json:"created_at"` // Representation in RFC3339.
+	UserID    string `json:"user_id"`
+	TableID   string `json:"table_id"`
+	// Results are the rolled table result followed by the nested tables results.
+	Results []oracleTableResultResponse `json:"results"`
+}
+
+func mapModelToAPIOracleTableRoll(tr model.OracleTableRoll) oracleTableRollResponse {
+	results := make([]oracleTableResultResponse, 0, len(tr.Results))
+	for _, r := range tr.Results {
+		results = append(results, oracleTableResultResponse{
+			Table:      r.TableName,
+			DiceRollID: r.DiceRollID,
+			Value:      r.Value,
+			Result:     r.Result,
+		})
+	}
+
+	return oracleTableRollResponse{
+		ID:        tr.ID,
+		CreatedAt: tr.CreatedAt.Format(time.RFC3339),
+		UserID:    tr.UserID,
+		TableID:   tr.TableID,
+		Results:   results,
+	}
+}
+
+const (
+	oracleurlParamRoomID  = "id"
+	oracleurlParamTableID = "table-id"
+)
+
+type createOracleTableRequest struct {
+	UserID string `json:"user_id"`
+	Name   string `json:"name"`
+	// Dice is the dice notation expression rolled on the table (e.g: `2d6`), or `d66`.
+	Dice string `json:"dice"`
+	// Format is `yaml` or `csv`.
+	Format string `json:"format"`
+	// Data are the table entries in the format, ranges of rolled values mapped to a result and
+	// optionally a room table that will be rolled too (e.g: `1-3,Nothing` or `4-6,Monsters!,Monsters`).
+	Data string `json:"data"`
+}
+
+func mapAPIToModelCreateOracleTable(params map[string]string, r createOracleTableRequest) (*oracle.CreateTableRequest, error) {
+	id, ok := params[oracleurlParamRoomID]
+	if !ok {
+		return nil, fmt.Errorf("room id is required")
+	}
+
+	if r.UserID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+
+	return &oracle.CreateTableRequest{
+		RoomID: id,
+		UserID: r.UserID,
+		Name:   r.Name,
+		Dice:   r.Dice,
+		Format: oracle.TableFormat(r.Format),
+		Data:   r.Data,
+	}, nil
+}
+
+func mapModelToAPICreateOracleTable(r oracle.CreateTableResponse) oracleTableResponse {
+	return mapModelToAPIOracleTable(r.Table)
+}
+
+type listOracleTablesResponse struct {
+	Items []oracleTableResponse `json:"items"`
+}
+
+func mapAPIToModelListOracleTables(params map[string]string) (*oracle.ListTablesRequest, error) {
+	id, ok := params[oracleurlParamRoomID]
+	if !ok {
+		return nil, fmt.Errorf("room id is required")
+	}
+
+	return &oracle.ListTablesRequest{
+		RoomID: id,
+	}, nil
+}
+
+func mapModelToAPIListOracleTables(r oracle.ListTablesResponse) listOracleTablesResponse {
+	items := make([]oracleTableResponse, 0, len(r.Tables))
+	for _, t := range r.Tables {
+		items = append(items, mapModelToAPIOracleTable(t))
+	}
+
+	return listOracleTablesResponse{Items: items}
+}
+
+type rollOracleTableRequest struct {
+	UserID string `json:"user_id"`
+}
+
+type rollOracleTableResponse struct {
+	TableRoll oracleTableRollResponse `json:"table_roll"`
+	// DiceRolls are the room dice rolls made on the table roll, in the same order as the results.
+	DiceRolls []diceRollResponse `json:"dice_rolls"`
+}
+
+func mapAPIToModelRollOracleTable(params map[string]string, r rollOracleTableRequest) (*oracle.RollTableRequest, error) {
+	id, ok := params[oracleurlParamRoomID]
+	if !ok {
+		return nil, fmt.Errorf("room id is required")
+	}
+
+	tableID, ok := params[oracleurlParamTableID]
+	if !ok {
+		return nil, fmt.Errorf("table id is required")
+	}
+
+	if r.UserID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+
+	return &oracle.RollTableRequest{
+		RoomID:  id,
+		UserID:  r.UserID,
+		TableID: tableID,
+	}, nil
+}
+
+func mapModelToAPIRollOracleTable(r oracle.RollTableResponse) rollOracleTableResponse {
+	drs := make([]diceRollResponse, 0, len(r.DiceRolls))
+	for _, dr := range r.DiceRolls {
+		drs = append(drs, mapModelToAPIDiceRoll(dr))
+	}
+
+	return rollOracleTableResponse{
+		TableRoll: mapModelToAPIOracleTableRoll(r.TableRoll),
+		DiceRolls: drs,
+	}
+}
+
+type listOracleTableRollsResponse struct {
+	Items []oracleTableRollResponse `json:"items"`
+}
+
+func mapAPIToModelListOracleTableRolls(params map[string]string) (*oracle.ListTableRollsRequest, error) {
+	id, ok := params[oracleurlParamRoomID]
+	if !ok {
+		return nil, fmt.Errorf("room id is required")
+	}
+
+	return &oracle.ListTableRollsRequest{
+		RoomID: id,
+	}, nil
+}
+
+func mapModelToAPIListOracleTableRolls(r oracle.ListTableRollsResponse) listOracleTableRollsResponse {
+	items := make([]oracleTableRollResponse, 0, len(r.TableRolls))
+	for _, tr := range r.TableRolls {
+		items = append(items, mapModelToAPIOracleTableRoll(tr))
+	}
+
+	return listOracleTableRollsResponse{Items: items}
+}
@@ -21,30 +21,124 @@ func (a apiv1) registerRoutes(prefix string) {
 		To(a.listDiceTypes()).
 		Metadata(restfulspec.KeyOpenAPITags, []string{"dice"}).
 		Doc("lists available dice types").
+		Param(a.apiws.QueryParameter(listDiceTypesurlParamRoomID, "identifier of the room to list also its custom die types").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of a room user, required on password protected rooms").DataType("string")).
 		Writes(listDiceTypesResponse{}).
-		Returns(http.StatusOK, "OK", listDiceTypesResponse{}))
+		Returns(http.StatusOK, "OK", listDiceTypesResponse{}).
+		Returns(http.StatusForbidden, "the room is password protected and the request does not have the session of a room user", nil))
+
+	a.apiws.Route(a.wrapWSPost("/dice/types").
+		To(a.createCustomDieType()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"dice"}).
+		Doc("registers a custom die type with symbolic faces in a room").
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of a room user").DataType("string").Required(true)).
+		Writes(diceTypeResponse{}).
+		Reads(createCustomDieTypeRequest{}).
+		Returns(http.StatusCreated, "Created", diceTypeResponse{}).
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusForbidden, "the request does not have the session of a room user", nil))
 
 	a.apiws.Route(a.wrapWSPost("/dice/rolls").
 		To(a.createDiceRoll()).
 		Metadata(restfulspec.KeyOpenAPITags, []string{"dice"}).
 		Doc("creates a dice roll").
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of the request user").DataType("string").Required(true)).
 		Writes(createDiceRollResponse{}).
 		Reads(createDiceRollRequest{}).
 		Returns(http.StatusCreated, "Created", createDiceRollResponse{}).
-		Returns(http.StatusBadRequest, "", nil))
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusTooManyRequests, "too many dice rolls", nil).
+		Returns(http.StatusForbidden, "the request does not have the session of the request user", nil))
+
+	a.apiws.Route(a.wrapWSPost("/dice/rolls:batch").
+		To(a.createDiceRolls()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"dice"}).
+		Doc("creates multiple independent dice rolls at once (e.g: 8 attacks of 1d20+5), all or none of them are created").
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of the request user").DataType("string").Required(true)).
+		Writes(createDiceRollsResponse{}).
+		Reads(createDiceRollsRequest{}).
+		Returns(http.StatusCreated, "Created", createDiceRollsResponse{}).
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusTooManyRequests, "too many dice rolls", nil).
+		Returns(http.StatusForbidden, "the request does not have the session of the request user", nil))
 
 	a.apiws.Route(a.wrapWSGet("/dice/rolls").
 		To(a.listDiceRolls()).
 		Metadata(restfulspec.KeyOpenAPITags, []string{"dice"}).
 		Doc("lists dice rolls").
 		Param(a.apiws.QueryParameter(listDiceRollsParamUserID, "identifier of the user").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of the user that sees the dice rolls, the ones it can't see will be hidden, required on password protected rooms").DataType("string")).
 		Param(a.apiws.QueryParameter(listDiceRollsurlParamRoomID, "identifier of the room").DataType("string")).
 		Param(a.apiws.QueryParameter(listDiceRollsPaginationCursor, "cursor for next page of dice rolls").DataType("string")).
 		Param(a.apiws.QueryParameter(listDiceRollsPaginationOrder, "order of the cursor, 'desc' or 'asc'").DataType("string")).
 		Writes(listDiceRollsResponse{}).
 		Returns(http.StatusOK, "OK", listDiceRollsResponse{}).
+		Returns(http.StatusForbidden, "the room is password protected and the request does not have the session of a room user", nil).
 		Returns(http.StatusBadRequest, "", nil))
 
+	a.apiws.Route(a.wrapWSPost("/dice/rolls/{id}/reroll").
+		To(a.rerollDice()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"dice"}).
+		Doc("rerolls dice of a dice roll into a new dice roll that references the original one").
+		Param(a.apiws.PathParameter("id", "identifier of the dice roll").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of the request user").DataType("string").Required(true)).
+		Writes(diceRollResponse{}).
+		Reads(rerollDiceRequest{}).
+		Returns(http.StatusCreated, "Created", diceRollResponse{}).
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusTooManyRequests, "too many dice rolls", nil).
+		Returns(http.StatusForbidden, "the request does not have the session of the request user", nil))
+
+	a.apiws.Route(a.wrapWSGet("/dice/rolls/{id}/verify").
+		To(a.verifyDiceRoll()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"dice"}).
+		Doc("recomputes a provably fair dice roll with the revealed server seed and verifies it").
+		Param(a.apiws.PathParameter("id", "identifier of the dice roll").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of a room user, required on password protected rooms and to verify the not public dice rolls").DataType("string")).
+		Writes(verifyDiceRollResponse{}).
+		Returns(http.StatusOK, "OK", verifyDiceRollResponse{}).
+		Returns(http.StatusForbidden, "the room is password protected or the dice roll is not visible to the session user", nil).
+		Returns(http.StatusBadRequest, "", nil))
+
+	a.apiws.Route(a.wrapWSGet("/dice/stats").
+		To(a.getDiceStats()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"dice"}).
+		Doc("gets the fairness statistics of the room public dice rolls by die type, with a chi-square goodness-of-fit test against fair dice").
+		Param(a.apiws.QueryParameter(diceStatsurlParamRoomID, "identifier of the room").DataType("string")).
+		Param(a.apiws.QueryParameter(diceStatsurlParamUserID, "identifier of the user, if set only the user dice rolls will be used").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of a room user, required on password protected rooms").DataType("string")).
+		Writes(diceStatsResponse{}).
+		Returns(http.StatusOK, "OK", diceStatsResponse{}).
+		Returns(http.StatusForbidden, "the room is password protected and the request does not have the session of a room user", nil).
+		Returns(http.StatusBadRequest, "", nil))
+
+	a.apiws.Route(a.wrapWSGet("/dice/probabilities").
+		To(a.calculateDiceProbabilities()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"dice"}).
+		Doc("calculates the probability distribution of a dice roll total without rolling it, exact for dice sums and advantage, estimated with simulations for the rest of mechanics").
+		Param(a.apiws.QueryParameter(diceProbabilitiesParamRoomID, "identifier of the room (required), its custom die types can be used").DataType("string")).
+		Param(a.apiws.QueryParameter(diceProbabilitiesParamUserID, "identifier of the user (required), the calculations count on the user and room dice roll rates").DataType("string")).
+		Param(a.apiws.QueryParameter(diceProbabilitiesParamDiceTypeIDs, "comma separated die type identifiers (e.g: 'd8,d8')").DataType("string")).
+		Param(a.apiws.QueryParameter(diceProbabilitiesParamExpression, "dice notation expression (e.g: '2d8+3'), can't be used with dice-type-ids").DataType("string")).
+		Param(a.apiws.QueryParameter(diceProbabilitiesParamModifier, "flat modifier added to the total").DataType("integer")).
+		Param(a.apiws.QueryParameter(diceProbabilitiesParamAdvantage, "'advantage' or 'disadvantage'").DataType("string")).
+		Param(a.apiws.QueryParameter(diceProbabilitiesParamRerollBelow, "rerolls once the dice below N").DataType("integer")).
+		Param(a.apiws.QueryParameter(diceProbabilitiesParamExplode, "'true' to explode the dice with max side").DataType("boolean")).
+		Param(a.apiws.QueryParameter(diceProbabilitiesParamKeepHighest, "keeps the N highest dice").DataType("integer")).
+		Param(a.apiws.QueryParameter(diceProbabilitiesParamKeepLowest, "keeps the N lowest dice").DataType("integer")).
+		Param(a.apiws.QueryParameter(diceProbabilitiesParamDropHighest, "drops the N highest dice").DataType("integer")).
+		Param(a.apiws.QueryParameter(diceProbabilitiesParamDropLowest, "drops the N lowest dice").DataType("integer")).
+		Param(a.apiws.QueryParameter(diceProbabilitiesParamPoolTarget, "target number of a success-counting dice pool").DataType("integer")).
+		Param(a.apiws.QueryParameter(diceProbabilitiesParamPoolOnesCancel, "'true' to cancel a success for each 1 on the dice pool").DataType("boolean")).
+		Param(a.apiws.QueryParameter(diceProbabilitiesParamCheckTarget, "difficulty to calculate the probability of passing it").DataType("integer")).
+		Param(a.apiws.QueryParameter(diceProbabilitiesParamCheckComparison, "'>=' (default), '>', '<=' or '<'").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of a room user, required on password protected rooms").DataType("string")).
+		Writes(diceProbabilitiesResponse{}).
+		Returns(http.StatusOK, "OK", diceProbabilitiesResponse{}).
+		Returns(http.StatusForbidden, "the room is password protected and the request does not have the session of a room user", nil).
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusTooManyRequests, "too many dice rolls", nil))
+
 	a.apiws.Route(a.wrapWSPost("/rooms").
 		To(a.createRoom()).
 		Metadata(restfulspec.KeyOpenAPITags, []string{"room"}).
@@ -60,35 +154,353 @@ func (a apiv1) registerRoutes(prefix string) {
 		Metadata(restfulspec.KeyOpenAPITags, []string{"room"}).
 		Doc("gets a room").
 		Param(a.apiws.PathParameter("id", "identifier of the room").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of a room user, required on password protected rooms").DataType("string")).
 		Writes(createRoomResponse{}).
 		Returns(http.StatusOK, "OK", getRoomResponse{}).
+		Returns(http.StatusForbidden, "the room is password protected and the request does not have the session of a room user", nil).
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusNotFound, "room does not exists", nil))
+
+	a.apiws.Route(a.wrapWSDelete("/rooms/{id}").
+		To(a.deleteRoom()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"room"}).
+		Doc("deletes a room with all its users and dice rolls, only the room game master can do it").
+		Param(a.apiws.PathParameter("id", "identifier of the room").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of the room game master").DataType("string").Required(true)).
+		Returns(http.StatusNoContent, "No Content", nil).
 		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusForbidden, "the user is not the room game master", nil).
 		Returns(http.StatusNotFound, "room does not exists", nil))
 
+	a.apiws.Route(a.wrapWSPost("/rooms/{id}/archive").
+		To(a.archiveRoom()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"room"}).
+		Doc("archives a room, archived rooms keep their history but are read-only, only the room game master can do it").
+		Param(a.apiws.PathParameter("id", "identifier of the room").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of the room game master").DataType("string").Required(true)).
+		Returns(http.StatusNoContent, "No Content", nil).
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusForbidden, "the user is not the room game master", nil).
+		Returns(http.StatusNotFound, "room does not exists or is already archived", nil))
+
+	a.apiws.Route(a.wrapWSGet("/rooms/{id}/server-seed").
+		To(a.getRoomServerSeed()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"room"}).
+		Doc("gets the room active server seed hash used on provably fair dice rolls").
+		Param(a.apiws.PathParameter("id", "identifier of the room").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of a room user, required on password protected rooms").DataType("string")).
+		Writes(serverSeedResponse{}).
+		Returns(http.StatusOK, "OK", serverSeedResponse{}).
+		Returns(http.StatusForbidden, "the room is password protected and the request does not have the session of a room user", nil).
+		Returns(http.StatusBadRequest, "", nil))
+
+	a.apiws.Route(a.wrapWSPost("/rooms/{id}/server-seed/rotate").
+		To(a.rotateRoomServerSeed()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"room"}).
+		Doc("reveals the room active server seed and replaces it with a new one, only the room game master can do it").
+		Param(a.apiws.PathParameter("id", "identifier of the room").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of the room game master").DataType("string").Required(true)).
+		Writes(rotateRoomServerSeedResponse{}).
+		Returns(http.StatusOK, "OK", rotateRoomServerSeedResponse{}).
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusForbidden, "the user is not the room game master", nil))
+
 	a.apiws.Route(a.wrapWSPost("/users").
 		To(a.createUser()).
 		Metadata(restfulspec.KeyOpenAPITags, []string{"user"}).
-		Doc("creates a user in a room").
+		Doc("creates a user in a room, if the user already exists it logs in again as the user with its session").
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of the user, required to log in again as an existing user").DataType("string")).
 		Writes(createUserResponse{}).
 		Reads(createUserRequest{}).
 		Returns(http.StatusCreated, "Created", createUserResponse{}).
 		Returns(http.StatusBadRequest, "", nil).
-		Returns(http.StatusConflict, "user already exists", nil))
+		Returns(http.StatusForbidden, "wrong room password or invite token, or game master on a room that already has users", nil).
+		Returns(http.StatusConflict, "user already exists and the request does not have its session", nil))
 
 	a.apiws.Route(a.wrapWSGet("/users").
 		To(a.listUsers()).
 		Metadata(restfulspec.KeyOpenAPITags, []string{"user"}).
 		Doc("list room users").
 		Param(a.apiws.QueryParameter(listUsersurlParamRoomID, "identifier of the room").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of a room user, required on password protected rooms").DataType("string")).
 		Writes(listUsersResponse{}).
 		Returns(http.StatusOK, "OK", listUsersResponse{}).
+		Returns(http.StatusForbidden, "the room is password protected and the request does not have the session of a room user", nil).
+		Returns(http.StatusBadRequest, "", nil))
+
+	a.apiws.Route(a.wrapWSPost("/macros").
+		To(a.createMacro()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"macro"}).
+		Doc("saves a dice roll macro of a user, optionally shared with the room users").
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of the request user").DataType("string").Required(true)).
+		Writes(macroResponse{}).
+		Reads(createMacroRequest{}).
+		Returns(http.StatusCreated, "Created", macroResponse{}).
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusForbidden, "the request does not have the session of the request user", nil))
+
+	a.apiws.Route(a.wrapWSGet("/macros").
+		To(a.listMacros()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"macro"}).
+		Doc("lists room macros, the user ones and the room shared ones").
+		Param(a.apiws.QueryParameter(macrosurlParamRoomID, "identifier of the room").DataType("string")).
+		Param(a.apiws.QueryParameter(macrosurlParamUserID, "identifier of the user, if missing only the room shared macros will be listed").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of a room user, required on password protected rooms").DataType("string")).
+		Writes(listMacrosResponse{}).
+		Returns(http.StatusOK, "OK", listMacrosResponse{}).
+		Returns(http.StatusForbidden, "the room is password protected and the request does not have the session of a room user", nil).
+		Returns(http.StatusBadRequest, "", nil))
+
+	a.apiws.Route(a.wrapWSGet("/macros/{id}").
+		To(a.getMacro()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"macro"}).
+		Doc("gets a macro").
+		Param(a.apiws.PathParameter(macrosurlParamMacroID, "identifier of the macro").DataType("string")).
+		Param(a.apiws.QueryParameter(macrosurlParamUserID, "identifier of the user that uses the macro").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of a room user, required on password protected rooms").DataType("string")).
+		Writes(macroResponse{}).
+		Returns(http.StatusOK, "OK", macroResponse{}).
+		Returns(http.StatusForbidden, "the room is password protected and the request does not have the session of a room user", nil).
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusNotFound, "macro does not exists", nil))
+
+	a.apiws.Route(a.wrapWSPut("/macros/{id}").
+		To(a.updateMacro()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"macro"}).
+		Doc("updates a macro, only its owner can update it").
+		Param(a.apiws.PathParameter(macrosurlParamMacroID, "identifier of the macro").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of the request user").DataType("string").Required(true)).
+		Writes(macroResponse{}).
+		Reads(updateMacroRequest{}).
+		Returns(http.StatusOK, "OK", macroResponse{}).
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusNotFound, "macro does not exists", nil).
+		Returns(http.StatusForbidden, "the request does not have the session of the request user", nil))
+
+	a.apiws.Route(a.wrapWSDelete("/macros/{id}").
+		To(a.deleteMacro()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"macro"}).
+		Doc("deletes a macro, only its owner can delete it").
+		Param(a.apiws.PathParameter(macrosurlParamMacroID, "identifier of the macro").DataType("string")).
+		Param(a.apiws.QueryParameter(macrosurlParamUserID, "identifier of the macro owner").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of the request user").DataType("string").Required(true)).
+		Returns(http.StatusNoContent, "No Content", nil).
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusNotFound, "macro does not exists", nil).
+		Returns(http.StatusForbidden, "the request does not have the session of the request user", nil))
+
+	a.apiws.Route(a.wrapWSGet("/rooms/{id}/initiative").
+		To(a.getInitiative()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"initiative"}).
+		Doc("gets the room initiative tracker, empty if the room doesn't have a combat").
+		Param(a.apiws.PathParameter(initiativeurlParamRoomID, "identifier of the room").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of a room user, required on password protected rooms").DataType("string")).
+		Writes(initiativeResponse{}).
+		Returns(http.StatusOK, "OK", initiativeResponse{}).
+		Returns(http.StatusForbidden, "the room is password protected and the request does not have the session of a room user", nil).
+		Returns(http.StatusBadRequest, "", nil))
+
+	a.apiws.Route(a.wrapWSDelete("/rooms/{id}/initiative").
+		To(a.endCombat()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"initiative"}).
+		Doc("ends the room combat removing its initiative tracker").
+		Param(a.apiws.PathParameter(initiativeurlParamRoomID, "identifier of the room").DataType("string")).
+		Param(a.apiws.QueryParameter(initiativeurlParamUserID, "identifier of the room user ending the combat").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of the request user").DataType("string").Required(true)).
+		Returns(http.StatusNoContent, "No Content", nil).
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusNotFound, "the room doesn't have a combat", nil).
+		Returns(http.StatusForbidden, "the request does not have the session of the request user", nil))
+
+	a.apiws.Route(a.wrapWSPost("/rooms/{id}/initiative/combatants").
+		To(a.addCombatant()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"initiative"}).
+		Doc("adds a room user or a named NPC to the room initiative tracker, optionally rolling its initiative").
+		Param(a.apiws.PathParameter(initiativeurlParamRoomID, "identifier of the room").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of the request user").DataType("string").Required(true)).
+		Writes(addCombatantResponse{}).
+		Reads(addCombatantRequest{}).
+		Returns(http.StatusCreated, "Created", addCombatantResponse{}).
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusConflict, "user is already a combatant", nil).
+		Returns(http.StatusForbidden, "the request does not have the session of the request user", nil))
+
+	a.apiws.Route(a.wrapWSDelete("/rooms/{id}/initiative/combatants/{combatant-id}").
+		To(a.removeCombatant()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"initiative"}).
+		Doc("removes a combatant from the room initiative tracker").
+		Param(a.apiws.PathParameter(initiativeurlParamRoomID, "identifier of the room").DataType("string")).
+		Param(a.apiws.PathParameter(initiativeurlParamCombatantID, "identifier of the combatant").DataType("string")).
+		Param(a.apiws.QueryParameter(initiativeurlParamUserID, "identifier of the room user removing the combatant").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of the request user").DataType("string").Required(true)).
+		Writes(initiativeResponse{}).
+		Returns(http.StatusOK, "OK", initiativeResponse{}).
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusNotFound, "combatant does not exists", nil).
+		Returns(http.StatusForbidden, "the request does not have the session of the request user", nil))
+
+	a.apiws.Route(a.wrapWSPost("/rooms/{id}/initiative/combatants/{combatant-id}/roll").
+		To(a.rollInitiative()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"initiative"}).
+		Doc("rolls the initiative of a combatant as a room dice roll").
+		Param(a.apiws.PathParameter(initiativeurlParamRoomID, "identifier of the room").DataType("string")).
+		Param(a.apiws.PathParameter(initiativeurlParamCombatantID, "identifier of the combatant").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of the request user").DataType("string").Required(true)).
+		Writes(rollInitiativeResponse{}).
+		Reads(rollInitiativeRequest{}).
+		Returns(http.StatusOK, "OK", rollInitiativeResponse{}).
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusNotFound, "combatant does not exists", nil).
+		Returns(http.StatusForbidden, "the request does not have the session of the request user", nil))
+
+	a.apiws.Route(a.wrapWSPost("/rooms/{id}/initiative/next").
+		To(a.nextTurn()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"initiative"}).
+		Doc("moves the current turn to the next combatant, starting a new round after the last one").
+		Param(a.apiws.PathParameter(initiativeurlParamRoomID, "identifier of the room").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of the request user").DataType("string").Required(true)).
+		Writes(initiativeResponse{}).
+		Reads(changeTurnRequest{}).
+		Returns(http.StatusOK, "OK", initiativeResponse{}).
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusForbidden, "the request does not have the session of the request user", nil))
+
+	a.apiws.Route(a.wrapWSPost("/rooms/{id}/initiative/previous").
+		To(a.previousTurn()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"initiative"}).
+		Doc("moves the current turn back to the previous combatant").
+		Param(a.apiws.PathParameter(initiativeurlParamRoomID, "identifier of the room").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of the request user").DataType("string").Required(true)).
+		Writes(initiativeResponse{}).
+		Reads(changeTurnRequest{}).
+		Returns(http.StatusOK, "OK", initiativeResponse{}).
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusForbidden, "the request does not have the session of the request user", nil))
+
+	a.apiws.Route(a.wrapWSGet("/rooms/{id}/decks").
+		To(a.listDecks()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"deck"}).
+		Doc("lists the room card decks").
+		Param(a.apiws.PathParameter(decksurlParamRoomID, "identifier of the room").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of a room user, required on password protected rooms").DataType("string")).
+		Writes(listDecksResponse{}).
+		Returns(http.StatusOK, "OK", listDecksResponse{}).
+		Returns(http.StatusForbidden, "the room is password protected and the request does not have the session of a room user", nil).
+		Returns(http.StatusBadRequest, "", nil))
+
+	a.apiws.Route(a.wrapWSPost("/rooms/{id}/decks").
+		To(a.createDeck()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"deck"}).
+		Doc("creates a shuffled standard, tarot or custom card deck on the room").
+		Param(a.apiws.PathParameter(decksurlParamRoomID, "identifier of the room").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of the request user").DataType("string").Required(true)).
+		Writes(deckResponse{}).
+		Reads(createDeckRequest{}).
+		Returns(http.StatusCreated, "Created", deckResponse{}).
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusForbidden, "the request does not have the session of the request user", nil))
+
+	a.apiws.Route(a.wrapWSPost("/rooms/{id}/decks/{deck-id}/draw").
+		To(a.drawCards()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"deck"}).
+		Doc("draws cards from the top of the deck draw pile, the cards will not be drawn again until the deck is reshuffled").
+		Param(a.apiws.PathParameter(decksurlParamRoomID, "identifier of the room").DataType("string")).
+		Param(a.apiws.PathParameter(decksurlParamDeckID, "identifier of the deck").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of the request user").DataType("string").Required(true)).
+		Writes(drawCardsResponse{}).
+		Reads(drawCardsRequest{}).
+		Returns(http.StatusOK, "OK", drawCardsResponse{}).
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusNotFound, "deck does not exists", nil).
+		Returns(http.StatusConflict, "deck is being modified concurrently", nil).
+		Returns(http.StatusForbidden, "the request does not have the session of the request user", nil))
+
+	a.apiws.Route(a.wrapWSPost("/rooms/{id}/decks/{deck-id}/discard").
+		To(a.discardCards()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"deck"}).
+		Doc("moves cards in play to the deck discard pile").
+		Param(a.apiws.PathParameter(decksurlParamRoomID, "identifier of the room").DataType("string")).
+		Param(a.apiws.PathParameter(decksurlParamDeckID, "identifier of the deck").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of the request user").DataType("string").Required(true)).
+		Writes(deckResponse{}).
+		Reads(discardCardsRequest{}).
+		Returns(http.StatusOK, "OK", deckResponse{}).
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusNotFound, "deck does not exists", nil).
+		Returns(http.StatusConflict, "deck is being modified concurrently", nil).
+		Returns(http.StatusForbidden, "the request does not have the session of the request user", nil))
+
+	a.apiws.Route(a.wrapWSPost("/rooms/{id}/decks/{deck-id}/reshuffle").
+		To(a.reshuffleDeck()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"deck"}).
+		Doc("shuffles the discard pile (or all the cards) back into the deck draw pile").
+		Param(a.apiws.PathParameter(decksurlParamRoomID, "identifier of the room").DataType("string")).
+		Param(a.apiws.PathParameter(decksurlParamDeckID, "identifier of the deck").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of the request user").DataType("string").Required(true)).
+		Writes(deckResponse{}).
+		Reads(reshuffleDeckRequest{}).
+		Returns(http.StatusOK, "OK", deckResponse{}).
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusNotFound, "deck does not exists", nil).
+		Returns(http.StatusConflict, "deck is being modified concurrently", nil).
+		Returns(http.StatusForbidden, "the request does not have the session of the request user", nil))
+
+	a.apiws.Route(a.wrapWSGet("/rooms/{id}/oracle-tables").
+		To(a.listOracleTables()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"oracle"}).
+		Doc("lists the room oracle (random) tables").
+		Param(a.apiws.PathParameter(oracleurlParamRoomID, "identifier of the room").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of a room user, required on password protected rooms").DataType("string")).
+		Writes(listOracleTablesResponse{}).
+		Returns(http.StatusOK, "OK", listOracleTablesResponse{}).
+		Returns(http.StatusForbidden, "the room is password protected and the request does not have the session of a room user", nil).
+		Returns(http.StatusBadRequest, "", nil))
+
+	a.apiws.Route(a.wrapWSPost("/rooms/{id}/oracle-tables").
+		To(a.createOracleTable()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"oracle"}).
+		Doc("uploads a YAML or CSV oracle table on the room, the entries can reference other room tables that will be rolled too").
+		Param(a.apiws.PathParameter(oracleurlParamRoomID, "identifier of the room").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of the request user").DataType("string").Required(true)).
+		Writes(oracleTableResponse{}).
+		Reads(createOracleTableRequest{}).
+		Returns(http.StatusCreated, "Created", oracleTableResponse{}).
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusConflict, "table name already exists on the room", nil).
+		Returns(http.StatusForbidden, "the request does not have the session of the request user", nil))
+
+	a.apiws.Route(a.wrapWSPost("/rooms/{id}/oracle-tables/{table-id}/roll").
+		To(a.rollOracleTable()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"oracle"}).
+		Doc("rolls on the oracle table (and its nested tables) using regular room dice rolls").
+		Param(a.apiws.PathParameter(oracleurlParamRoomID, "identifier of the room").DataType("string")).
+		Param(a.apiws.PathParameter(oracleurlParamTableID, "identifier of the oracle table").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of the request user").DataType("string").Required(true)).
+		Writes(rollOracleTableResponse{}).
+		Reads(rollOracleTableRequest{}).
+		Returns(http.StatusCreated, "Created", rollOracleTableResponse{}).
+		Returns(http.StatusBadRequest, "", nil).
+		Returns(http.StatusNotFound, "oracle table does not exists", nil).
+		Returns(http.StatusTooManyRequests, "too many dice rolls", nil).
+		Returns(http.StatusForbidden, "the request does not have the session of the request user", nil))
+
+	a.apiws.Route(a.wrapWSGet("/rooms/{id}/oracle-rolls").
+		To(a.listOracleTableRolls()).
+		Metadata(restfulspec.KeyOpenAPITags, []string{"oracle"}).
+		Doc("lists the latest oracle table rolls of the room, newest first").
+		Param(a.apiws.PathParameter(oracleurlParamRoomID, "identifier of the room").DataType("string")).
+		Param(a.apiws.HeaderParameter(sessionHeaderAuthz, "'Bearer <session token>' of a room user, required on password protected rooms").DataType("string")).
+		Writes(listOracleTableRollsResponse{}).
+		Returns(http.StatusOK, "OK", listOracleTableRollsResponse{}).
+		Returns(http.StatusForbidden, "the room is password protected and the request does not have the session of a room user", nil).
 		Returns(http.StatusBadRequest, "", nil))
 
 	a.apiws.Route(a.wrapWSGet("/ws/rooms/{id}").
 		To(a.wsRoomEvents()).
 		Metadata(restfulspec.KeyOpenAPITags, []string{"websocket"}).
 		Doc("websocket connection for room events").
-		Param(a.apiws.PathParameter("id", "identifier of the room").DataType("string")))
+		Param(a.apiws.PathParameter("id", "identifier of the room").DataType("string")).
+		Param(a.apiws.QueryParameter(sessionParamToken, "session token of the user that receives the events, the dice rolls it can't see will be hidden, required on password protected rooms").DataType("string")))
 
 	// Register docs.
 	// Important: Needs to be the last route registered, because it needs to know what were
@@ -108,6 +520,14 @@ func (a *apiv1) wrapWSPost(route string) *restful.RouteBuilder {
 	return a.wrapMiddleware(route, a.apiws.POST(route))
 }
 
+func (a *apiv1) wrapWSPut(route string) *restful.RouteBuilder {
+	return a.wrapMiddleware(route, a.apiws.PUT(route))
+}
+
+func (a *apiv1) wrapWSDelete(route string) *restful.RouteBuilder {
+	return a.wrapMiddleware(route, a.apiws.DELETE(route))
+}
+
 // wrapMiddleware wraps a routebuilder with filters/middlewares.
 func (a *apiv1) wrapMiddleware(route string, rb *restful.RouteBuilder) *restful.RouteBuilder {
 	rb = rb.Filter(gohttpmetrics.Handler(route, a.metricsMiddleware))
@@ -1,9 +1,14 @@
 package ui
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/rollify/rollify/internal/http/ui/htmx"
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/user"
 )
 
 func (u ui) handleError(w http.ResponseWriter, err error) {
@@ -20,3 +25,57 @@ func (u ui) redirectToURL(w http.ResponseWriter, r *http.Request, url string) {
 
 	http.Redirect(w, r, url, http.StatusTemporaryRedirect)
 }
+
+type contextKey string
+
+const contextKeySessionUser = contextKey("sessionUser")
+
+// authenticateSession returns the user of the room session cookie, nil if the request doesn't
+// have a valid session for the room.
+func (u ui) authenticateSession(r *http.Request, roomID string) (*model.User, error) {
+	return u.authenticateSessionToken(r, roomID, cookies.GetSession(r, roomID))
+}
+
+// authenticateSessionToken returns the user of the room session token, nil if the token is not
+// a valid session for the room.
+func (u ui) authenticateSessionToken(r *http.Request, roomID, token string) (*model.User, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	resp, err := u.userAppSvc.AuthenticateUser(r.Context(), user.AuthenticateUserRequest{
+		SessionToken: token,
+		RoomID:       roomID,
+	})
+	if err != nil {
+		// Invalid sessions are like not having one.
+		if errors.Is(err, internalerrors.ErrNotAllowed) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not authenticate user: %w", err)
+	}
+
+	return &resp.User, nil
+}
+
+// sessionUser returns the user authenticated by the session middleware, nil if anonymous.
+func sessionUser(r *http.Request) *model.User {
+	us, _ := r.Context().Value(contextKeySessionUser).(*model.User)
+	return us
+}
+
+// sessionUserID returns the ID of the user authenticated by the session middleware, empty if anonymous.
+func sessionUserID(r *http.Request) string {
+	us := sessionUser(r)
+	if us == nil {
+		return ""
+	}
+
+	return us.ID
+}
+
+// isRoomGameMaster returns true if the user of the request is a game master of the room.
+func isRoomGameMaster(r *http.Request, roomID string) bool {
+	us := sessionUser(r)
+	return us != nil && us.RoomID == roomID && us.GameMaster
+}
@@ -6,31 +6,36 @@ import (
 	"time"
 )
 
-const cookieUserID = "_room_user_id_%s"
+const cookieSession = "_room_session_%s"
+
+// sessionDuration is the time the users stay logged in a room.
+const sessionDuration = 14 * 24 * time.Hour
 
 // cookies knows how to handle cookies for our application.
 var cookies = cookieManager{}
 
 type cookieManager struct{}
 
-func (c cookieManager) SetUserID(w http.ResponseWriter, roomID, userID string, expiration time.Time) {
-	c.set(w, fmt.Sprintf(cookieUserID, roomID), userID, expiration)
+func (c cookieManager) SetSession(w http.ResponseWriter, roomID, sessionToken string, expiration time.Time) {
+	c.set(w, fmt.Sprintf(cookieSession, roomID), sessionToken, expiration)
 }
 
-func (c cookieManager) GetUserID(r *http.Request, roomID string) string {
-	return c.get(r, fmt.Sprintf(cookieUserID, roomID))
+func (c cookieManager) GetSession(r *http.Request, roomID string) string {
+	return c.get(r, fmt.Sprintf(cookieSession, roomID))
 }
 
-func (c cookieManager) DeleteUserID(w http.ResponseWriter, roomID string) {
-	c.delete(w, fmt.Sprintf(cookieUserID, roomID))
+func (c cookieManager) DeleteSession(w http.ResponseWriter, roomID string) {
+	c.delete(w, fmt.Sprintf(cookieSession, roomID))
 }
 
 func (c cookieManager) set(w http.ResponseWriter, k, v string, expiration time.Time) {
 	http.SetCookie(w, &http.Cookie{
-		Name:    k,
-		Value:   v,
-		Path:    "/",
-		Expires: expiration,
+		Name:     k,
+		Value:    v,
+		Path:     "/",
+		Expires:  expiration,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
 	})
 }
 
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/rollify/rollify/internal/deck"
+	"github.com/rollify/rollify/internal/model"
+)
+
+type cardDeck struct {
+	ID        string
+	Name      string
+	Left      int
+	Discarded int
+	InPlay    []string
+}
+
+func mapDecksToTplModel(ds []model.Deck) []cardDeck {
+	cds := make([]cardDeck, 0, len(ds))
+	for _, d := range ds {
+		cds = append(cds, cardDeck{
+			ID:        d.ID,
+			Name:      d.Name,
+			Left:      len(d.DrawPile),
+			Discarded: len(d.DiscardPile),
+			InPlay:    d.InPlay(),
+		})
+	}
+
+	return cds
+}
+
+var deckTypes = []model.DeckType{
+	model.DeckTypeStandard,
+	model.DeckTypeStandardJokers,
+	model.DeckTypeTarot,
+	model.DeckTypeCustom,
+}
+
+type cardDecksTplData struct {
+	Decks     []cardDeck
+	DeckTypes []model.DeckType
+}
+
+type cardDrawTplData struct {
+	UserName string
+	DeckName string
+	Cards    []string
+}
+
+// renderCardDecks renders the room card decks snippet with the current state of the decks.
+func (u ui) renderCardDecks(w http.ResponseWriter, r *http.Request, roomID string) {
+	decks, err := u.deckAppSvc.ListDecks(r.Context(), deck.ListDecksRequest{RoomID: roomID})
+	if err != nil {
+		u.handleError(w, fmt.Errorf("could not list room decks: %w", err))
+		return
+	}
+
+	u.tplRenderer.withRoom(roomID).RenderResponse(r.Context(), w, "card_decks", cardDecksTplData{
+		Decks:     mapDecksToTplModel(decks.Decks),
+		DeckTypes: deckTypes,
+	})
+}
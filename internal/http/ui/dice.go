@@ -1,6 +1,12 @@
 package ui
 
-import "github.com/rollify/rollify/internal/model"
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/rollify/rollify/internal/model"
+)
 
 var diceQuantity = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
 
@@ -12,6 +18,8 @@ type die struct {
 }
 
 const (
+	dieSVGD2  = `M50,12A38,38,0,1,1,49.99,12ZM50,18A32,32,0,1,0,50.01,18Z`
+	dieSVGD3  = `M50,10,90,80,10,80ZM50,22,20.4,74,79.6,74Z`
 	dieSVGD4  = `M90.68,68.81,46.28,6.93a2,2,0,0,0-3.4.25L13.16,64.86a2,2,0,0,0,.17,2.1L32.56,93.18a2,2,0,0,0,1.61.82,2.11,2.11,0,0,0,.74-.14l54.89-22a2,2,0,0,0,.88-3ZM17.29,65.59,42.61,16.44l-8.94,71.5ZM35.51,89.31l9.64-77.1L86,69.07Zm30.81-28-2.47.82,2.24,6.41-4.53,1.66L59.51,63.6,50,66.77,48.82,61.6,53,44.51l4.35-1,5,14.45,2.41-.74Zm-8.15-2.08-3.3-10.67L52.44,61Z`
 	dieSVGD6  = `M86.08,30.84,49.32,7.32A2,2,0,0,0,47,7.4l-33.24,25A2,2,0,0,0,13,34.1l1,30a2,2,0,0,0,.83,1.55L51.6,92.09a2,2,0,0,0,1.17.38A2,2,0,0,0,54.12,92L85.35,63.48A2,2,0,0,0,86,62.07l1-29.48A2,2,0,0,0,86.08,30.84ZM48.34,11.43l34.21,21.9-29.85,26L17.56,34.61ZM18,63l-.88-26.23L51.77,61.08V87.29Zm35.8,23.9V61L82.89,35.68,82,61.09ZM47.46,28.31a2.69,2.69,0,0,0-1.28-.5,2.46,2.46,0,0,0-2.29.87c-.94,1.05-.94,2.36,0,3.94a17.91,17.91,0,0,0,3,3.29,4.79,4.79,0,0,1,0-2.47,5.89,5.89,0,0,1,1.23-2.19A6.33,6.33,0,0,1,53,29a9.14,9.14,0,0,1,5.75,2.12,10.92,10.92,0,0,1,3.85,5.33c.71,2.14.24,4.28-1.43,6.42a6.79,6.79,0,0,1-7.39,2.47,18.48,18.48,0,0,1-7.55-4.12A42,42,0,0,1,42.7,38a14.83,14.83,0,0,1-3.06-4.57,7.54,7.54,0,0,1-.49-3.69,6.07,6.07,0,0,1,1.65-3.39,7.17,7.17,0,0,1,4.56-2.5,7.28,7.28,0,0,1,4.89,1.3Zm7.76,13a3,3,0,0,0,2.65-1.14,2.67,2.67,0,0,0,.47-2.65,5.76,5.76,0,0,0-2.06-2.71A5.4,5.4,0,0,0,53,33.39a3,3,0,0,0-2.44,1.07,2.92,2.92,0,0,0-.71,2,4.7,4.7,0,0,0,2,3.39A5.57,5.57,0,0,0,55.22,41.28Z`
 	dieSVGD8  = `M46.37,35.69a4.13,4.13,0,0,1,.54-3.21,8,8,0,0,1-3.75-.81A9,9,0,0,1,40.91,30a5,5,0,0,1-1.49-4.4q.38-2.39,3.48-4.16A9.32,9.32,0,0,1,49,20.11a8.09,8.09,0,0,1,4.89,2.46,7.07,7.07,0,0,1,1.36,2.19A3.54,3.54,0,0,1,55,27.67a9.41,9.41,0,0,1,4.18.71,9.55,9.55,0,0,1,3.39,2.37,5.93,5.93,0,0,1,1.76,5.06q-.42,2.74-3.88,4.72A9.64,9.64,0,0,1,48.2,39,7.12,7.12,0,0,1,46.37,35.69ZM55,38a4,4,0,0,0,2.88-.5,2.56,2.56,0,0,0,1.49-2,3.5,3.5,0,0,0-1.17-2.56,5.21,5.21,0,0,0-2.8-1.69,4.09,4.09,0,0,0-2.86.52,2.61,2.61,0,0,0-1.49,2,3.49,3.49,0,0,0,1.19,2.6A5.16,5.16,0,0,0,55,38Zm-7.56-8.54A3.6,3.6,0,0,0,49.91,29a2.28,2.28,0,0,0,1.33-1.74,2.63,2.63,0,0,0-.85-2,4.13,4.13,0,0,0-2.27-1.33,3.66,3.66,0,0,0-2.5.48,2.36,2.36,0,0,0-1.34,1.72,2.69,2.69,0,0,0,.9,2.11A3.94,3.94,0,0,0,47.39,29.42ZM93,26.72a2,2,0,0,0-1.19-1L27.64,6.69A2,2,0,0,0,25.15,8L6.09,70.24A2,2,0,0,0,7.4,72.73L71.62,92.8a1.8,1.8,0,0,0,.6.1,1.92,1.92,0,0,0,.93-.24,2,2,0,0,0,1-1.19l19-63.22A2,2,0,0,0,93,26.72Zm-5.41,2L37.8,58.2,28.59,11.15ZM27.26,14.82l8.68,44.33-25.2,9.57ZM12.57,70.16l24.31-9.23,29.35,26ZM70.88,88.38,38.78,59.94,88.26,30.59Z`
@@ -21,10 +29,164 @@ const (
 )
 
 var (
-	dieD4  = die{DieType: model.DieTypeD4, Color: "currentColor", RangedQuantity: diceQuantity, SVG: dieSVGD4}
-	dieD6  = die{DieType: model.DieTypeD6, Color: "currentColor", RangedQuantity: diceQuantity, SVG: dieSVGD6}
-	dieD8  = die{DieType: model.DieTypeD8, Color: "currentColor", RangedQuantity: diceQuantity, SVG: dieSVGD8}
-	dieD10 = die{DieType: model.DieTypeD10, Color: "currentColor", RangedQuantity: diceQuantity, SVG: dieSVGD10}
-	dieD12 = die{DieType: model.DieTypeD12, Color: "currentColor", RangedQuantity: diceQuantity, SVG: dieSVGD12}
-	dieD20 = die{DieType: model.DieTypeD20, Color: "currentColor", RangedQuantity: diceQuantity, SVG: dieSVGD20}
+	dieD2   = die{DieType: model.DieTypeD2, Color: "currentColor", RangedQuantity: diceQuantity, SVG: dieSVGD2}
+	dieD3   = die{DieType: model.DieTypeD3, Color: "currentColor", RangedQuantity: diceQuantity, SVG: dieSVGD3}
+	dieD4   = die{DieType: model.DieTypeD4, Color: "currentColor", RangedQuantity: diceQuantity, SVG: dieSVGD4}
+	dieD6   = die{DieType: model.DieTypeD6, Color: "currentColor", RangedQuantity: diceQuantity, SVG: dieSVGD6}
+	dieD8   = die{DieType: model.DieTypeD8, Color: "currentColor", RangedQuantity: diceQuantity, SVG: dieSVGD8}
+	dieD10  = die{DieType: model.DieTypeD10, Color: "currentColor", RangedQuantity: diceQuantity, SVG: dieSVGD10}
+	dieD12  = die{DieType: model.DieTypeD12, Color: "currentColor", RangedQuantity: diceQuantity, SVG: dieSVGD12}
+	dieD20  = die{DieType: model.DieTypeD20, Color: "currentColor", RangedQuantity: diceQuantity, SVG: dieSVGD20}
+	dieD100 = die{DieType: model.DieTypeD100, Color: "currentColor", RangedQuantity: diceQuantity, SVG: dieSVGD10}
 )
+
+// rollerDice are the dice that can be selected on the dice roller.
+var rollerDice = []die{dieD2, dieD3, dieD4, dieD6, dieD8, dieD10, dieD12, dieD20, dieD100}
+
+// historyDice are the dice that have their own column on the dice roll history, the
+// rest of the dice are shown together.
+var historyDice = []die{dieD4, dieD6, dieD8, dieD10, dieD12, dieD20}
+
+// newCustomDie returns a die for die types that we don't have a custom representation.
+func newCustomDie(dt model.DieType) die {
+	return die{DieType: dt, Color: "currentColor", RangedQuantity: diceQuantity, SVG: dieSVGD20}
+}
+
+// roomFacedDice returns the dice of the die types with faces (registered by the rooms).
+func roomFacedDice(dts []model.DieType) []die {
+	ds := []die{}
+	for _, dt := range dts {
+		if len(dt.Faces()) > 0 {
+			ds = append(ds, newCustomDie(dt))
+		}
+	}
+	return ds
+}
+
+type diceResult struct {
+	Dice    die
+	Results []dieResult
+}
+
+// dieResult is the result of a die roll, the dice with faces show the face label instead of the side.
+type dieResult struct {
+	Side  uint
+	Label string
+	// Discarded are the dice that don't count on the dice roll (e.g: rerolled, dropped).
+	Discarded bool
+}
+
+func (d dieResult) String() string { return d.Label }
+
+func newDieResult(d model.DieRoll) dieResult {
+	if f, ok := d.Face(); ok {
+		return dieResult{Side: d.Side, Label: f.Label, Discarded: d.Discarded()}
+	}
+	return dieResult{Side: d.Side, Label: strconv.Itoa(int(d.Side)), Discarded: d.Discarded()}
+}
+
+// poolOutcomeText returns the human readable outcome of a dice pool, empty if the dice roll is not a dice pool.
+func poolOutcomeText(p *model.DicePoolResult) string {
+	if p == nil {
+		return ""
+	}
+
+	switch p.Outcome {
+	case model.DicePoolOutcomeCriticalFailure:
+		return "Critical failure"
+	case model.DicePoolOutcomeFailure:
+		return "Failure"
+	case model.DicePoolOutcomeSuccess:
+		return "Success"
+	case model.DicePoolOutcomeCriticalSuccess:
+		return "Critical success"
+	default:
+		return ""
+	}
+}
+
+// visibilityText returns the human readable visibility of a dice roll, empty if the dice roll is public.
+func visibilityText(d model.DiceRoll) string {
+	switch d.Visibility {
+	case model.DiceRollVisibilityGameMaster:
+		return "GM only"
+	case model.DiceRollVisibilityWhisper:
+		return "Whisper"
+	default:
+		return ""
+	}
+}
+
+// diceRollCheck is the template model of a difficulty check outcome.
+type diceRollCheck struct {
+	Target  string // The comparison and the target (e.g: `>= 15`).
+	Outcome string
+	Margin  string
+	Class   string // CSS class used to colour the dice roll by its outcome.
+}
+
+// newDiceRollCheck returns the template model of the difficulty check, nil if the dice roll is not a difficulty check.
+func newDiceRollCheck(c *model.DiceRollCheck) *diceRollCheck {
+	if c == nil {
+		return nil
+	}
+
+	cmp := ""
+	switch c.Comparison {
+	case model.DiceRollCheckComparisonGreaterOrEqual:
+		cmp = ">="
+	case model.DiceRollCheckComparisonGreater:
+		cmp = ">"
+	case model.DiceRollCheckComparisonLessOrEqual:
+		cmp = "<="
+	case model.DiceRollCheckComparisonLess:
+		cmp = "<"
+	}
+
+	res := &diceRollCheck{
+		Target: fmt.Sprintf("%s %d", cmp, c.Target),
+		Margin: fmt.Sprintf("%+d", c.Margin),
+	}
+	switch c.Outcome {
+	case model.DiceRollCheckOutcomeCriticalFailure:
+		res.Outcome, res.Class = "Critical failure", "check-critical-failure"
+	case model.DiceRollCheckOutcomeFailure:
+		res.Outcome, res.Class = "Failure", "check-failure"
+	case model.DiceRollCheckOutcomeSuccess:
+		res.Outcome, res.Class = "Success", "check-success"
+	case model.DiceRollCheckOutcomeCriticalSuccess:
+		res.Outcome, res.Class = "Critical success", "check-critical-success"
+	}
+
+	return res
+}
+
+// groupDiceResults groups the die rolls sorted results by die type. The results of the known dice
+// are returned in the same order as the known dice, the rest of dice types results are returned
+// apart ordered by the number of sides.
+func groupDiceResults(dice []model.DieRoll, known []die) (knownResults []diceResult, otherResults []diceResult) {
+	groupedResults := map[string][]dieResult{}
+	others := map[string]model.DieType{}
+	for _, d := range dice {
+		groupedResults[d.Type.ID()] = append(groupedResults[d.Type.ID()], newDieResult(d))
+		others[d.Type.ID()] = d.Type
+	}
+
+	for _, v := range groupedResults {
+		slices.SortFunc(v, func(a, b dieResult) int { return int(a.Side) - int(b.Side) })
+	}
+
+	knownResults = make([]diceResult, 0, len(known))
+	for _, d := range known {
+		knownResults = append(knownResults, diceResult{Dice: d, Results: groupedResults[d.ID()]})
+		delete(others, d.ID())
+	}
+
+	otherResults = make([]diceResult, 0, len(others))
+	for id, dt := range others {
+		otherResults = append(otherResults, diceResult{Dice: newCustomDie(dt), Results: groupedResults[id]})
+	}
+	slices.SortFunc(otherResults, func(a, b diceResult) int { return int(a.Dice.Sides()) - int(b.Dice.Sides()) })
+
+	return knownResults, otherResults
+}
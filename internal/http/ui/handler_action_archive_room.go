@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rollify/rollify/internal/room"
+)
+
+func (u ui) handlerActionArchiveRoom() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roomID := chi.URLParam(r, urlParamRoomID)
+
+		// Only the game masters can archive the room.
+		if !isRoomGameMaster(r, roomID) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		err := u.roomAppSvc.ArchiveRoom(r.Context(), room.ArchiveRoomRequest{ID: roomID, UserID: sessionUserID(r)})
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not archive room: %w", err))
+			return
+		}
+
+		// Redirect to the room, now read-only.
+		u.redirectToURL(w, r, u.servePrefix+"/room/"+roomID)
+	})
+}
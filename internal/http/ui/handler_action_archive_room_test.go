@@ -0,0 +1,128 @@
+package ui_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/r3labs/sse/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/deck/deckmock"
+	"github.com/rollify/rollify/internal/dice/dicemock"
+	"github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
+	"github.com/rollify/rollify/internal/macro/macromock"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
+	"github.com/rollify/rollify/internal/room"
+	"github.com/rollify/rollify/internal/room/roommock"
+	"github.com/rollify/rollify/internal/stats/statsmock"
+	"github.com/rollify/rollify/internal/user"
+	"github.com/rollify/rollify/internal/user/usermock"
+)
+
+func TestHandlerActionArchiveRoom(t *testing.T) {
+	type mocks struct {
+		mr *roommock.Service
+		mu *usermock.Service
+	}
+
+	newRequest := func(withSession bool) func() *http.Request {
+		return func() *http.Request {
+			req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/archive", nil)
+			req.Header.Add("HX-Request", "true")
+			if withSession {
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+			}
+			return req
+		}
+	}
+
+	authenticate := func(m mocks, gameMaster bool) {
+		m.mu.On("AuthenticateUser", mock.Anything, user.AuthenticateUserRequest{SessionToken: "user1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}).Once().Return(&user.AuthenticateUserResponse{
+			User: model.User{ID: "user1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", GameMaster: gameMaster},
+		}, nil)
+	}
+
+	tests := map[string]struct {
+		request    func() *http.Request
+		mock       func(m mocks)
+		expHeaders http.Header
+		expCode    int
+	}{
+		"Archiving a room without session should be forbidden.": {
+			request:    newRequest(false),
+			mock:       func(m mocks) {},
+			expHeaders: http.Header{},
+			expCode:    403,
+		},
+
+		"Archiving a room without being a game master should be forbidden.": {
+			request:    newRequest(true),
+			mock:       func(m mocks) { authenticate(m, false) },
+			expHeaders: http.Header{},
+			expCode:    403,
+		},
+
+		"Having an error while archiving the room should fail.": {
+			request: newRequest(true),
+			mock: func(m mocks) {
+				authenticate(m, true)
+				m.mr.On("ArchiveRoom", mock.Anything, mock.Anything).Once().Return(fmt.Errorf("something"))
+			},
+			expHeaders: http.Header{},
+			expCode:    500,
+		},
+
+		"Archiving a room as game master should archive the room and redirect to the read-only room.": {
+			request: newRequest(true),
+			mock: func(m mocks) {
+				authenticate(m, true)
+				m.mr.On("ArchiveRoom", mock.Anything, room.ArchiveRoomRequest{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", UserID: "user1"}).Once().Return(nil)
+			},
+			expHeaders: http.Header{
+				"Hx-Redirect": {"/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b"},
+			},
+			expCode: 200,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			m := mocks{
+				mr: &roommock.Service{},
+				mu: &usermock.Service{},
+			}
+			test.mock(m)
+
+			s := sse.New()
+			defer s.Close()
+			h, err := ui.New(ui.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       m.mr,
+				UserAppService:       mockSessionUsers(m.mu),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+				SSEServer:            s,
+			})
+			require.NoError(err)
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.request())
+
+			assert.Equal(test.expCode, w.Code)
+			assert.Equal(test.expHeaders, w.Header())
+			m.mr.AssertExpectations(t)
+		})
+	}
+}
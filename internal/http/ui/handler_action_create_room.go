@@ -1,19 +1,27 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
+	"github.com/rollify/rollify/internal/dice"
+	"github.com/rollify/rollify/internal/internalerrors"
 	"github.com/rollify/rollify/internal/room"
+	"github.com/rollify/rollify/internal/user"
 )
 
 const (
-	formFieldCreateRoomRoomName = "roomName"
+	formFieldCreateRoomRoomName     = "roomName"
+	formFieldCreateRoomRoomRuleset  = "roomRuleset"
+	formFieldCreateRoomRoomPassword = "roomPassword"
+	formFieldCreateRoomGameMaster   = "gameMasterName"
 )
 
 type tplDataCreateRoom struct {
 	FormErrors []string
+	Rulesets   []dice.Ruleset
 }
 
 func (u ui) handlerActionCreateRoom() http.HandlerFunc {
@@ -21,22 +29,55 @@ func (u ui) handlerActionCreateRoom() http.HandlerFunc {
 		// Parse form.
 		roomName := r.FormValue(formFieldCreateRoomRoomName)
 		roomName = strings.TrimSpace(roomName)
-		if roomName == "" {
-			d := tplDataCreateRoom{
-				FormErrors: []string{"Room name can't be empty"},
-			}
-			u.tplRenderer.RenderResponse(r.Context(), w, "create_room_form", d)
+		gameMasterName := strings.TrimSpace(r.FormValue(formFieldCreateRoomGameMaster))
+		password := r.FormValue(formFieldCreateRoomRoomPassword)
+		switch {
+		case roomName == "":
+			u.renderCreateRoomFormError(w, r, "Room name can't be empty")
+			return
+		case gameMasterName == "":
+			u.renderCreateRoomFormError(w, r, "Game master name can't be empty")
 			return
 		}
 
 		// Create the room.
-		resp, err := u.roomAppSvc.CreateRoom(r.Context(), room.CreateRoomRequest{Name: roomName})
+		resp, err := u.roomAppSvc.CreateRoom(r.Context(), room.CreateRoomRequest{
+			Name:     roomName,
+			Ruleset:  r.FormValue(formFieldCreateRoomRoomRuleset),
+			Password: password,
+		})
 		if err != nil {
 			u.handleError(w, fmt.Errorf("could not create room: %w", err))
 			return
 		}
+		roomID := resp.Room.ID
 
-		// Room created, redirect to the room login.
-		u.redirectToURL(w, r, u.servePrefix+"/login/"+resp.Room.ID)
+		// The room creator is the game master, being the first user of the room.
+		us, err := u.userAppSvc.CreateUser(r.Context(), user.CreateUserRequest{
+			Name:       gameMasterName,
+			RoomID:     roomID,
+			GameMaster: true,
+			Password:   password,
+		})
+		if err != nil {
+			if errors.Is(err, internalerrors.ErrNotValid) {
+				u.renderCreateRoomFormError(w, r, "Game master name is not valid")
+				return
+			}
+			u.handleError(w, fmt.Errorf("could not create game master: %w", err))
+			return
+		}
+
+		// Room created, log in the game master and redirect to the room.
+		cookies.SetSession(w, roomID, us.SessionToken, u.timeNow().Add(sessionDuration))
+		u.redirectToURL(w, r, u.servePrefix+"/room/"+roomID)
 	})
 }
+
+func (u ui) renderCreateRoomFormError(w http.ResponseWriter, r *http.Request, formError string) {
+	d := tplDataCreateRoom{
+		FormErrors: []string{formError},
+		Rulesets:   dice.Rulesets(),
+	}
+	u.tplRenderer.RenderResponse(r.Context(), w, "create_room_form", d)
+}
@@ -6,21 +6,29 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/r3labs/sse/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/rollify/rollify/internal/deck/deckmock"
 	"github.com/rollify/rollify/internal/dice/dicemock"
 	"github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
+	"github.com/rollify/rollify/internal/macro/macromock"
 	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
 	"github.com/rollify/rollify/internal/room"
 	"github.com/rollify/rollify/internal/room/roommock"
+	"github.com/rollify/rollify/internal/stats/statsmock"
+	"github.com/rollify/rollify/internal/user"
 	"github.com/rollify/rollify/internal/user/usermock"
 )
 
 func TestHandlerActionCreateRoom(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "2023-01-21T11:05:45Z")
 	type mocks struct {
 		md *dicemock.Service
 		mr *roommock.Service
@@ -34,10 +42,11 @@ func TestHandlerActionCreateRoom(t *testing.T) {
 		expHeaders http.Header
 		expCode    int
 	}{
-		"Creating a new room, should create the room and redirect to the login page with HTMX.": {
+		"Creating a new room, should create the room with its creator as game master and redirect to the room with HTMX.": {
 			request: func() *http.Request {
 				form := url.Values{}
 				form.Add("roomName", "test1")
+				form.Add("gameMasterName", "gm1")
 				req := httptest.NewRequest(http.MethodPost, "/u/create-room", strings.NewReader(form.Encode()))
 				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 				req.Header.Add("HX-Request", "true")
@@ -49,15 +58,95 @@ func TestHandlerActionCreateRoom(t *testing.T) {
 					ID:   "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
 					Name: "test1",
 				}}, nil)
+				cur := user.CreateUserRequest{Name: "gm1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", GameMaster: true}
+				m.mu.On("CreateUser", mock.Anything, cur).Once().Return(&user.CreateUserResponse{
+					User:         model.User{ID: "gm1-id", Name: "gm1", GameMaster: true},
+					SessionToken: "session1",
+				}, nil)
+			},
+			expHeaders: http.Header{
+				"Hx-Redirect": {"/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b"},
+				"Set-Cookie":  {"_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b=session1; Path=/; Expires=Sat, 04 Feb 2023 11:05:45 GMT; HttpOnly; SameSite=Lax"},
+			},
+			expCode: 200,
+			expBody: []string{},
+		},
+
+		"Creating a new room with a game system, should create the room with the ruleset.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("roomName", "test1")
+				form.Add("roomRuleset", "blades")
+				form.Add("gameMasterName", "gm1")
+				req := httptest.NewRequest(http.MethodPost, "/u/create-room", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.Header.Add("HX-Request", "true")
+				return req
+			},
+			mock: func(m mocks) {
+				rgr := room.CreateRoomRequest{Name: "test1", Ruleset: "blades"}
+				m.mr.On("CreateRoom", mock.Anything, rgr).Once().Return(&room.CreateRoomResponse{Room: model.Room{
+					ID:      "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					Name:    "test1",
+					Ruleset: "blades",
+				}}, nil)
+				m.mu.On("CreateUser", mock.Anything, mock.Anything).Once().Return(&user.CreateUserResponse{SessionToken: "session1"}, nil)
+			},
+			expHeaders: http.Header{
+				"Hx-Redirect": {"/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b"},
+				"Set-Cookie":  {"_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b=session1; Path=/; Expires=Sat, 04 Feb 2023 11:05:45 GMT; HttpOnly; SameSite=Lax"},
+			},
+			expCode: 200,
+			expBody: []string{},
+		},
 
+		"Creating a new room with a password, should create the game master with the room password.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("roomName", "test1")
+				form.Add("roomPassword", "secret")
+				form.Add("gameMasterName", "gm1")
+				req := httptest.NewRequest(http.MethodPost, "/u/create-room", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.Header.Add("HX-Request", "true")
+				return req
+			},
+			mock: func(m mocks) {
+				rgr := room.CreateRoomRequest{Name: "test1", Password: "secret"}
+				m.mr.On("CreateRoom", mock.Anything, rgr).Once().Return(&room.CreateRoomResponse{Room: model.Room{
+					ID:   "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					Name: "test1",
+				}}, nil)
+				cur := user.CreateUserRequest{Name: "gm1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", GameMaster: true, Password: "secret"}
+				m.mu.On("CreateUser", mock.Anything, cur).Once().Return(&user.CreateUserResponse{SessionToken: "session1"}, nil)
 			},
 			expHeaders: http.Header{
-				"Hx-Redirect": {"/u/login/e02b402d-c23b-45b2-a5ea-583a566a9a6b"},
+				"Hx-Redirect": {"/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b"},
+				"Set-Cookie":  {"_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b=session1; Path=/; Expires=Sat, 04 Feb 2023 11:05:45 GMT; HttpOnly; SameSite=Lax"},
 			},
 			expCode: 200,
 			expBody: []string{},
 		},
 
+		"An empty game master name should error and return the form with the errors.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("roomName", "test1")
+				req := httptest.NewRequest(http.MethodPost, "/u/create-room", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				return req
+			},
+			mock: func(m mocks) {},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<input type="text" name="gameMasterName" id="gameMasterName" placeholder="Your game master name" required/>`, // Check The form has the game master field.
+				`Game master name can't be empty`, // We have the error message on the form.
+			},
+		},
+
 		"An empty room name should error and return the form with the errors.": {
 			request: func() *http.Request {
 				form := url.Values{}
@@ -73,9 +162,9 @@ func TestHandlerActionCreateRoom(t *testing.T) {
 			},
 			expCode: 200,
 			expBody: []string{
-				`<div id="createRoomFormSection">`,                                                    // HTMX swap Target.
+				`<div id="createRoomFormSection">`, // HTMX swap Target.
 				`<input type="text" name="roomName" id="roomName" placeholder="Room name" required/>`, // Check The form has the important correct fields.
-				`Room name can't be empty`,                                                            // We have the error message on the form.
+				`Room name can't be empty`, // We have the error message on the form.
 			},
 		},
 	}
@@ -95,10 +184,16 @@ func TestHandlerActionCreateRoom(t *testing.T) {
 			s := sse.New()
 			defer s.Close()
 			h, err := ui.New(ui.Config{
-				DiceAppService: m.md,
-				RoomAppService: m.mr,
-				UserAppService: m.mu,
-				SSEServer:      s,
+				DiceAppService:       m.md,
+				RoomAppService:       m.mr,
+				UserAppService:       mockSessionUsers(m.mu),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+				TimeNow:              func() time.Time { return t0.UTC() },
+				SSEServer:            s,
 			})
 			require.NoError(err)
 
@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rollify/rollify/internal/room"
+)
+
+func (u ui) handlerActionDeleteRoom() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roomID := chi.URLParam(r, urlParamRoomID)
+
+		// Only the game masters can delete the room.
+		if !isRoomGameMaster(r, roomID) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		err := u.roomAppSvc.DeleteRoom(r.Context(), room.DeleteRoomRequest{ID: roomID, UserID: sessionUserID(r)})
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not delete room: %w", err))
+			return
+		}
+
+		// The room user doesn't exist anymore.
+		cookies.DeleteSession(w, roomID)
+
+		// Redirect to the index.
+		u.redirectToURL(w, r, u.servePrefix)
+	})
+}
@@ -9,7 +9,7 @@ import (
 func (u ui) handlerActionLogout() http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		roomID := chi.URLParam(r, urlParamRoomID)
-		userID := cookies.GetUserID(r, roomID)
+		userID := cookies.GetSession(r, roomID)
 
 		roomRedirectURL := u.servePrefix + "/login/" + roomID
 
@@ -23,7 +23,7 @@ func (u ui) handlerActionLogout() http.HandlerFunc {
 		}
 
 		// Logout.
-		cookies.DeleteUserID(w, roomID)
+		cookies.DeleteSession(w, roomID)
 
 		// Redirect to the room login.
 		u.redirectToURL(w, r, roomRedirectURL)
@@ -9,9 +9,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/rollify/rollify/internal/deck/deckmock"
 	"github.com/rollify/rollify/internal/dice/dicemock"
 	"github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
+	"github.com/rollify/rollify/internal/macro/macromock"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
 	"github.com/rollify/rollify/internal/room/roommock"
+	"github.com/rollify/rollify/internal/stats/statsmock"
 	"github.com/rollify/rollify/internal/user/usermock"
 )
 
@@ -60,10 +65,15 @@ func TestHandlerActionLogout(t *testing.T) {
 			s := sse.New()
 			defer s.Close()
 			h, err := ui.New(ui.Config{
-				DiceAppService: m.md,
-				RoomAppService: m.mr,
-				UserAppService: m.mu,
-				SSEServer:      s,
+				DiceAppService:       m.md,
+				RoomAppService:       m.mr,
+				UserAppService:       mockSessionUsers(m.mu),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+				SSEServer:            s,
 			})
 			require.NoError(err)
 
@@ -1,17 +1,20 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
-	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/rollify/rollify/internal/internalerrors"
 	"github.com/rollify/rollify/internal/user"
 )
 
 const (
-	formFieldManageUserUsername = "username"
-	formFieldManageUserID       = "userID"
+	formFieldManageUserUsername    = "username"
+	formFieldManageUserID          = "userID"
+	formFieldManageUserPassword    = "password"
+	formFieldManageUserInviteToken = "inviteToken"
 )
 
 func (u ui) handlerActionManageUser() http.HandlerFunc {
@@ -19,41 +22,90 @@ func (u ui) handlerActionManageUser() http.HandlerFunc {
 		// Parse form info and return validation errors if any.
 		username := r.FormValue(formFieldManageUserUsername)
 		userID := r.FormValue(formFieldManageUserID)
+		password := r.FormValue(formFieldManageUserPassword)
+		inviteToken := r.FormValue(formFieldManageUserInviteToken)
 		roomID := chi.URLParam(r, urlParamRoomID)
 
 		// If we have a username then create a user.
+		var sessionToken string
 		switch {
 		case username != "":
 			// Create user.
 			us, err := u.userAppSvc.CreateUser(r.Context(), user.CreateUserRequest{
-				Name:   username,
-				RoomID: roomID,
+				Name:        username,
+				RoomID:      roomID,
+				Password:    password,
+				InviteToken: inviteToken,
+				// Logging in again as an existing user needs its session.
+				SessionToken: cookies.GetSession(r, roomID),
 			})
 			if err != nil {
-				u.handleError(w, fmt.Errorf("could not create user: %w", err))
+				u.handleLoginError(w, r, roomID, fmt.Errorf("could not create user: %w", err))
 				return
 			}
 
-			userID = us.User.ID
+			sessionToken = us.SessionToken
 
 		case userID != "":
 			// Check user exists.
-			_, err := u.userAppSvc.GetUser(r.Context(), user.GetUserRequest{UserID: userID})
+			existing, err := u.userAppSvc.GetUser(r.Context(), user.GetUserRequest{UserID: userID})
 			if err != nil {
 				u.handleError(w, fmt.Errorf("invalid user ID: %w", err))
 				return
 			}
 
+			// The existing users also need the credentials of the protected rooms, creating an
+			// existing user checks them and returns the user.
+			us, err := u.userAppSvc.CreateUser(r.Context(), user.CreateUserRequest{
+				Name:        existing.User.Name,
+				RoomID:      roomID,
+				Password:    password,
+				InviteToken: inviteToken,
+				// Logging in again as an existing user needs its session.
+				SessionToken: cookies.GetSession(r, roomID),
+			})
+			if err != nil {
+				u.handleLoginError(w, r, roomID, fmt.Errorf("could not log in user: %w", err))
+				return
+			}
+
+			sessionToken = us.SessionToken
+
 		default:
 			// Data missing, fail.
 			u.handleError(w, fmt.Errorf("user ID or username missing"))
 			return
 		}
 
-		// Set user Id on cookie.
-		cookies.SetUserID(w, roomID, userID, u.timeNow().Add(14*24*time.Hour))
+		// Set the user session on cookie.
+		cookies.SetSession(w, roomID, sessionToken, u.timeNow().Add(sessionDuration))
 
 		// Redirect to the room.
 		u.redirectToURL(w, r, u.servePrefix+"/room/"+roomID)
 	})
 }
+
+// handleLoginError shows the wrong credentials on the login form, the rest of errors are handled as regular errors.
+func (u ui) handleLoginError(w http.ResponseWriter, r *http.Request, roomID string, err error) {
+	var formError string
+	switch {
+	case errors.Is(err, internalerrors.ErrNotAllowed):
+		// Ask for the password, the invite token is not valid anymore.
+		formError = "Wrong room password or invite token"
+	case errors.Is(err, internalerrors.ErrAlreadyExists):
+		// Only the user session can log in again as an existing user.
+		formError = "The user already exists, use another name"
+	default:
+		u.handleError(w, err)
+		return
+	}
+
+	d, err := u.loginTplData(r, roomID)
+	if err != nil {
+		u.handleError(w, err)
+		return
+	}
+	d.FormErrors = []string{formError}
+
+	u.tplRenderer.withRoom(roomID).RenderResponse(r.Context(), w, "login_form", d)
+}
@@ -13,10 +13,17 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/rollify/rollify/internal/deck/deckmock"
 	"github.com/rollify/rollify/internal/dice/dicemock"
 	"github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/macro/macromock"
 	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
+	"github.com/rollify/rollify/internal/room"
 	"github.com/rollify/rollify/internal/room/roommock"
+	"github.com/rollify/rollify/internal/stats/statsmock"
 	"github.com/rollify/rollify/internal/user"
 	"github.com/rollify/rollify/internal/user/usermock"
 )
@@ -50,6 +57,28 @@ func TestHandlerActionManageUser(t *testing.T) {
 			expBody:    []string{},
 		},
 
+		"Logging in on an archived room should redirect to the read-only room.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("username", "user1")
+				req := httptest.NewRequest(http.MethodPost, "/u/login/e02b402d-c23b-45b2-a5ea-583a566a9a6b/manage-user", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.Header.Add("HX-Request", "true")
+				return req
+			},
+			mock: func(m mocks) {
+				m.mr.On("GetRoom", mock.Anything, room.GetRoomRequest{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}).Once().Return(&room.GetRoomResponse{Room: model.Room{
+					ID:       "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					Archived: true,
+				}}, nil)
+			},
+			expHeaders: http.Header{
+				"Hx-Redirect": {"/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b"},
+			},
+			expCode: 200,
+			expBody: []string{},
+		},
+
 		"Creating a new user should create a new user and redirect the to the room.": {
 			request: func() *http.Request {
 				form := url.Values{}
@@ -64,20 +93,21 @@ func TestHandlerActionManageUser(t *testing.T) {
 				m.mu.On("CreateUser", mock.Anything, r).Once().Return(&user.CreateUserResponse{User: model.User{
 					ID:   "u1",
 					Name: "user1",
-				}}, nil)
+				}, SessionToken: "session1"}, nil)
 			},
 			expHeaders: http.Header{
 				"Hx-Redirect": {"/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b"},
-				"Set-Cookie":  {"_room_user_id_e02b402d-c23b-45b2-a5ea-583a566a9a6b=u1; Path=/; Expires=Sat, 04 Feb 2023 11:05:45 GMT"},
+				"Set-Cookie":  {"_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b=session1; Path=/; Expires=Sat, 04 Feb 2023 11:05:45 GMT; HttpOnly; SameSite=Lax"},
 			},
 			expCode: 200,
 			expBody: []string{},
 		},
 
-		"Creating a new user that already exists should not fail and use the existing user instead.": {
+		"Creating a new user as game master should ignore it and create a regular user, only the room creator is game master.": {
 			request: func() *http.Request {
 				form := url.Values{}
 				form.Add("username", "user1")
+				form.Add("gameMaster", "on")
 				req := httptest.NewRequest(http.MethodPost, "/u/login/e02b402d-c23b-45b2-a5ea-583a566a9a6b/manage-user", strings.NewReader(form.Encode()))
 				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 				req.Header.Add("HX-Request", "true")
@@ -88,23 +118,72 @@ func TestHandlerActionManageUser(t *testing.T) {
 				m.mu.On("CreateUser", mock.Anything, r).Once().Return(&user.CreateUserResponse{User: model.User{
 					ID:   "u1",
 					Name: "user1",
-				}}, nil)
+				}, SessionToken: "session1"}, nil)
+			},
+			expHeaders: http.Header{
+				"Hx-Redirect": {"/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b"},
+				"Set-Cookie":  {"_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b=session1; Path=/; Expires=Sat, 04 Feb 2023 11:05:45 GMT; HttpOnly; SameSite=Lax"},
+			},
+			expCode: 200,
+			expBody: []string{},
+		},
+
+		"Creating a new user that already exists with its session should not fail and use the existing user instead.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("username", "user1")
+				req := httptest.NewRequest(http.MethodPost, "/u/login/e02b402d-c23b-45b2-a5ea-583a566a9a6b/manage-user", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.Header.Add("HX-Request", "true")
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "u1", MaxAge: 999999999999})
+				return req
+			},
+			mock: func(m mocks) {
+				r := user.CreateUserRequest{Name: "user1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", SessionToken: "u1"}
+				m.mu.On("CreateUser", mock.Anything, r).Once().Return(&user.CreateUserResponse{User: model.User{
+					ID:   "u1",
+					Name: "user1",
+				}, SessionToken: "session1"}, nil)
 			},
 			expHeaders: http.Header{
 				"Hx-Redirect": {"/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b"},
-				"Set-Cookie":  {"_room_user_id_e02b402d-c23b-45b2-a5ea-583a566a9a6b=u1; Path=/; Expires=Sat, 04 Feb 2023 11:05:45 GMT"},
+				"Set-Cookie":  {"_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b=session1; Path=/; Expires=Sat, 04 Feb 2023 11:05:45 GMT; HttpOnly; SameSite=Lax"},
 			},
 			expCode: 200,
 			expBody: []string{},
 		},
 
-		"Using an existing user should select a the user and redirect the to the room.": {
+		"Creating a new user that already exists without its session should show the error on the login form.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("username", "user1")
+				req := httptest.NewRequest(http.MethodPost, "/u/login/e02b402d-c23b-45b2-a5ea-583a566a9a6b/manage-user", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.Header.Add("HX-Request", "true")
+				return req
+			},
+			mock: func(m mocks) {
+				r := user.CreateUserRequest{Name: "user1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
+				m.mu.On("CreateUser", mock.Anything, r).Once().Return(nil, internalerrors.ErrAlreadyExists)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<li class="form-error-message"><strong>The user already exists, use another name</strong></li>`, // We have the error.
+			},
+		},
+
+		"Using an existing user should select a the user with its session and redirect the to the room.": {
 			request: func() *http.Request {
 				form := url.Values{}
 				form.Add("userID", "12345")
+				form.Add("inviteToken", "token1")
 				req := httptest.NewRequest(http.MethodPost, "/u/login/e02b402d-c23b-45b2-a5ea-583a566a9a6b/manage-user", strings.NewReader(form.Encode()))
 				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 				req.Header.Add("HX-Request", "true")
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "12345", MaxAge: 999999999999})
 				return req
 			},
 			mock: func(m mocks) {
@@ -113,14 +192,105 @@ func TestHandlerActionManageUser(t *testing.T) {
 					ID:   "12345",
 					Name: "user1",
 				}}, nil)
+				cr := user.CreateUserRequest{Name: "user1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", InviteToken: "token1", SessionToken: "12345"}
+				m.mu.On("CreateUser", mock.Anything, cr).Once().Return(&user.CreateUserResponse{User: model.User{
+					ID:   "12345",
+					Name: "user1",
+				}, SessionToken: "session1"}, nil)
 			},
 			expHeaders: http.Header{
 				"Hx-Redirect": {"/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b"},
-				"Set-Cookie":  {"_room_user_id_e02b402d-c23b-45b2-a5ea-583a566a9a6b=12345; Path=/; Expires=Sat, 04 Feb 2023 11:05:45 GMT"},
+				"Set-Cookie":  {"_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b=session1; Path=/; Expires=Sat, 04 Feb 2023 11:05:45 GMT; HttpOnly; SameSite=Lax"},
 			},
 			expCode: 200,
 			expBody: []string{},
 		},
+
+		"Using an existing user of a protected room with a wrong password should show the error on the login form.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("userID", "12345")
+				form.Add("password", "wrong")
+				req := httptest.NewRequest(http.MethodPost, "/u/login/e02b402d-c23b-45b2-a5ea-583a566a9a6b/manage-user", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.Header.Add("HX-Request", "true")
+				return req
+			},
+			mock: func(m mocks) {
+				m.mu.On("GetUser", mock.Anything, user.GetUserRequest{UserID: "12345"}).Once().Return(&user.GetUserResponse{User: model.User{
+					ID:   "12345",
+					Name: "user1",
+				}}, nil)
+				cr := user.CreateUserRequest{Name: "user1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", Password: "wrong"}
+				m.mu.On("CreateUser", mock.Anything, cr).Once().Return(nil, internalerrors.ErrNotAllowed)
+				m.mr.On("GetRoom", mock.Anything, room.GetRoomRequest{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}).Twice().Return(&room.GetRoomResponse{Room: model.Room{
+					ID:           "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					PasswordHash: "hash",
+				}}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<li class="form-error-message"><strong>Wrong room password or invite token</strong></li>`, // We have the error.
+				`<input type="password" name="password" id="password" placeholder="Password" required/>`,   // We are asked for the password.
+			},
+		},
+
+		"Joining a protected room with an invite token should create the user and redirect to the room.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("username", "user1")
+				form.Add("inviteToken", "token1")
+				req := httptest.NewRequest(http.MethodPost, "/u/login/e02b402d-c23b-45b2-a5ea-583a566a9a6b/manage-user", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.Header.Add("HX-Request", "true")
+				return req
+			},
+			mock: func(m mocks) {
+				cr := user.CreateUserRequest{Name: "user1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", InviteToken: "token1"}
+				m.mu.On("CreateUser", mock.Anything, cr).Once().Return(&user.CreateUserResponse{User: model.User{
+					ID:   "12345",
+					Name: "user1",
+				}, SessionToken: "session1"}, nil)
+			},
+			expHeaders: http.Header{
+				"Hx-Redirect": {"/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b"},
+				"Set-Cookie":  {"_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b=session1; Path=/; Expires=Sat, 04 Feb 2023 11:05:45 GMT; HttpOnly; SameSite=Lax"},
+			},
+			expCode: 200,
+			expBody: []string{},
+		},
+
+		"Joining a protected room with an unknown invite token and a wrong password should show the error on the login form.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("username", "user1")
+				form.Add("password", "wrong")
+				form.Add("inviteToken", "token3")
+				req := httptest.NewRequest(http.MethodPost, "/u/login/e02b402d-c23b-45b2-a5ea-583a566a9a6b/manage-user", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.Header.Add("HX-Request", "true")
+				return req
+			},
+			mock: func(m mocks) {
+				cr := user.CreateUserRequest{Name: "user1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", Password: "wrong", InviteToken: "token3"}
+				m.mu.On("CreateUser", mock.Anything, cr).Once().Return(nil, internalerrors.ErrNotAllowed)
+				m.mr.On("GetRoom", mock.Anything, room.GetRoomRequest{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}).Twice().Return(&room.GetRoomResponse{Room: model.Room{
+					ID:           "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					PasswordHash: "hash",
+				}}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<li class="form-error-message"><strong>Wrong room password or invite token</strong></li>`, // We have the error.
+				`<input type="password" name="password" id="password" placeholder="Password" required/>`,   // We are asked for the password.
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -134,15 +304,21 @@ func TestHandlerActionManageUser(t *testing.T) {
 				mu: &usermock.Service{},
 			}
 			test.mock(m)
+			m.mr.On("GetRoom", mock.Anything, mock.Anything).Maybe().Return(&room.GetRoomResponse{}, nil)
 
 			s := sse.New()
 			defer s.Close()
 			h, err := ui.New(ui.Config{
-				DiceAppService: m.md,
-				RoomAppService: m.mr,
-				UserAppService: m.mu,
-				TimeNow:        func() time.Time { return t0.UTC() },
-				SSEServer:      s,
+				DiceAppService:       m.md,
+				RoomAppService:       m.mr,
+				UserAppService:       mockSessionUsers(m.mu),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+				TimeNow:              func() time.Time { return t0.UTC() },
+				SSEServer:            s,
 			})
 			require.NoError(err)
 
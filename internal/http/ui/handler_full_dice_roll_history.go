@@ -3,7 +3,6 @@ package ui
 import (
 	"fmt"
 	"net/http"
-	"slices"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/rollify/rollify/internal/dice"
@@ -15,11 +14,22 @@ import (
 const maxDiceResults = 10
 
 type userDiceRoll struct {
-	Username     string
-	UnixTS       int64
-	PrettyTS     string
-	DiceResults  []diceResult
-	IsPushUpdate bool
+	Username         string
+	UnixTS           int64
+	PrettyTS         string
+	Label            string
+	DiceResults      []diceResult
+	OtherDiceResults []diceResult
+	Expression       string
+	Modifier         int
+	Total            int
+	PoolOutcome      string         // Only set on dice pools, the Total are the successes.
+	Check            *diceRollCheck // Only set on difficulty checks.
+	Visibility       string         // Only set on the not public dice rolls.
+	IsReroll         bool
+	ParentUnixTS     int64 // Only set on the rerolls when the rerolled dice roll is known.
+	Hidden           bool  // The user can't see the dice roll, only the metadata is set.
+	IsPushUpdate     bool
 }
 
 func (u ui) handlerFullDiceRollHistory() http.HandlerFunc {
@@ -36,7 +46,7 @@ func (u ui) handlerFullDiceRollHistory() http.HandlerFunc {
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		roomID := chi.URLParam(r, urlParamRoomID)
-		userID := cookies.GetUserID(r, roomID)
+		userID := sessionUserID(r)
 
 		// If not user ID, redirect to room selection.
 		if userID == "" {
@@ -51,15 +61,16 @@ func (u ui) handlerFullDiceRollHistory() http.HandlerFunc {
 		}
 
 		res, err := u.diceAppSvc.ListDiceRolls(r.Context(), dice.ListDiceRollsRequest{
-			RoomID:   roomID,
-			PageOpts: model.PaginationOpts{Size: maxDiceResults},
+			RoomID:       roomID,
+			ViewerUserID: userID,
+			PageOpts:     model.PaginationOpts{Size: maxDiceResults},
 		})
 		if err != nil {
 			u.handleError(w, fmt.Errorf("could not list dice rolls: %w", err))
 			return
 		}
 
-		roomUsers, err := u.userAppSvc.ListUsers(r.Context(), user.ListUsersRequest{RoomID: roomID})
+		roomUsers, err := u.userAppSvc.ListUsers(r.Context(), user.ListUsersRequest{RoomID: roomID, SessionToken: cookies.GetSession(r, roomID)})
 		if err != nil {
 			u.handleError(w, fmt.Errorf("could list room users: %w", err))
 			return
@@ -75,7 +86,7 @@ func (u ui) handlerFullDiceRollHistory() http.HandlerFunc {
 			RoomID:         room.Room.Name,
 			NewDiceRollURL: u.servePrefix + "/room/" + room.Room.ID,
 			IsDiceHistory:  true,
-			Dice:           []die{dieD4, dieD6, dieD8, dieD10, dieD12, dieD20},
+			Dice:           historyDice,
 			Results:        u.formatDiceHistory(*res, roomUsers.Users),
 			SSEURL:         fmt.Sprintf("%s/subscribe/room/dice-roll-history?%s=%s%s", u.servePrefix, queryParamSSEStream, sseStreamPrefixHTML, roomID),
 			NextItemsURL:   nextItemsURL,
@@ -88,35 +99,38 @@ func (u ui) formatDiceHistory(m dice.ListDiceRollsResponse, users []model.User)
 	for _, u := range users {
 		us[u.ID] = u
 	}
+	createdAts := map[string]int64{}
+	for _, d := range m.DiceRolls {
+		createdAts[d.ID] = d.CreatedAt.UTC().Unix()
+	}
+
 	res := []userDiceRoll{}
 	for _, d := range m.DiceRolls {
-		res = append(res, u.mapDiceRollToTplModel(d, us[d.UserID], false))
+		dr := u.mapDiceRollToTplModel(d, us[d.UserID], false)
+		dr.ParentUnixTS = createdAts[d.ParentID]
+		res = append(res, dr)
 	}
 
 	return res
 }
 
 func (u ui) mapDiceRollToTplModel(d model.DiceRoll, user model.User, isPush bool) userDiceRoll {
-	groupedResults := map[string][]uint{}
-	for _, r := range d.Dice {
-		groupedResults[r.Type.ID()] = append(groupedResults[r.Type.ID()], r.Side)
-	}
-
-	for _, v := range groupedResults {
-		slices.Sort(v)
-	}
+	results, otherResults := groupDiceResults(d.Dice, historyDice)
 
 	return userDiceRoll{
-		Username: user.Name,
-		UnixTS:   d.CreatedAt.UTC().Unix(),
-		DiceResults: []diceResult{
-			{Dice: dieD4, Results: groupedResults[dieD4.ID()]},
-			{Dice: dieD6, Results: groupedResults[dieD6.ID()]},
-			{Dice: dieD8, Results: groupedResults[dieD8.ID()]},
-			{Dice: dieD10, Results: groupedResults[dieD10.ID()]},
-			{Dice: dieD12, Results: groupedResults[dieD12.ID()]},
-			{Dice: dieD20, Results: groupedResults[dieD20.ID()]},
-		},
-		IsPushUpdate: isPush,
+		Username:         user.Name,
+		UnixTS:           d.CreatedAt.UTC().Unix(),
+		Label:            d.Label,
+		DiceResults:      results,
+		OtherDiceResults: otherResults,
+		Expression:       d.Expression,
+		Modifier:         d.Modifier,
+		Total:            d.Total,
+		PoolOutcome:      poolOutcomeText(d.Pool),
+		Check:            newDiceRollCheck(d.Check),
+		Visibility:       visibilityText(d),
+		IsReroll:         d.ParentID != "",
+		Hidden:           d.Hidden,
+		IsPushUpdate:     isPush,
 	}
 }
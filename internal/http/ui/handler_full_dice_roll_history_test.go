@@ -11,12 +11,18 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/rollify/rollify/internal/deck/deckmock"
 	"github.com/rollify/rollify/internal/dice"
 	"github.com/rollify/rollify/internal/dice/dicemock"
 	"github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/macro/macromock"
 	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
 	"github.com/rollify/rollify/internal/room"
 	"github.com/rollify/rollify/internal/room/roommock"
+	"github.com/rollify/rollify/internal/stats/statsmock"
 	"github.com/rollify/rollify/internal/user"
 	"github.com/rollify/rollify/internal/user/usermock"
 )
@@ -40,7 +46,7 @@ func TestHandlerFullDiceRollHistory(t *testing.T) {
 		"Asking for the dice roll history items should return the page with the list and have pagination in place when there is a cursor.": {
 			request: func() *http.Request {
 				req := httptest.NewRequest(http.MethodGet, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/dice-roll-history", nil)
-				req.AddCookie(&http.Cookie{Name: "_room_user_id_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
 
 				return req
 			},
@@ -52,8 +58,9 @@ func TestHandlerFullDiceRollHistory(t *testing.T) {
 				}}, nil)
 
 				r2 := dice.ListDiceRollsRequest{
-					RoomID:   "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
-					PageOpts: model.PaginationOpts{Size: 10},
+					RoomID:       "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					ViewerUserID: "user1",
+					PageOpts:     model.PaginationOpts{Size: 10},
 				}
 				m.md.On("ListDiceRolls", mock.Anything, r2).Once().Return(&dice.ListDiceRollsResponse{
 					Cursors: model.PaginationCursors{
@@ -86,12 +93,14 @@ func TestHandlerFullDiceRollHistory(t *testing.T) {
 								{ID: "7", Type: model.DieTypeD8, Side: 6},
 								{ID: "8", Type: model.DieTypeD20, Side: 20}, // Force sort.
 								{ID: "9", Type: model.DieTypeD20, Side: 1},
+								{ID: "10", Type: model.DieTypeD100, Side: 57},
+								{ID: "11", Type: model.DieTypeD2, Side: 2},
 							},
 						},
 					},
 				}, nil)
 
-				r3 := user.ListUsersRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
+				r3 := user.ListUsersRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", SessionToken: "user1"}
 				m.mu.On("ListUsers", mock.Anything, r3).Once().Return(&user.ListUsersResponse{
 					Users: []model.User{
 						{ID: "user-id1", Name: "user1"},
@@ -115,14 +124,287 @@ func TestHandlerFullDiceRollHistory(t *testing.T) {
 				`<title>D10</title>`, // We have d10 header on dice roll history table.
 				`<title>D12</title>`, // We have d12 header on dice roll history table.
 				`<title>D20</title>`, // We have d20 header on dice roll history table.
-				`<tr id="history-dice-roll-row"> <td> <div> <strong>user1</strong> </div> <div> <small class="timestamp-ago" unix-ts="1674299140"></small> </div> </td> <td> <kbd>1</kbd> <kbd>2</kbd> </td> <td> </td> <td> </td> <td> </td> <td> </td> <td> <kbd>3</kbd> </td> </tr>`,                                                            // We have the results of 1st Dice roll.
-				`<tr id="history-dice-roll-row"> <td> <div> <strong>user2</strong> </div> <div> <small class="timestamp-ago" unix-ts="1674299135"></small> </div> </td> <td> </td> <td> <kbd>4</kbd> </td> <td> </td> <td> <kbd>8</kbd> </td> <td> <kbd>11</kbd> </td> <td> </td> </tr>`,                                                           // We have the results of 2nd Dice roll.
-				`<tr id="history-dice-roll-row"> <td> <div> <strong>user3</strong> </div> <div> <small class="timestamp-ago" unix-ts="1674299105"></small> </div> </td> <td> </td> <td> </td> <td> <kbd>6</kbd> </td> <td> </td> <td> </td> <td> <kbd>1</kbd> <kbd>20</kbd> </td>`,                                                                 // We have the results of last dice roll.
-				`<tr id="history-dice-roll-more-button"> <td></td> <td></td> <td></td> <td> <a hx-get="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/dice-roll-history/more-items?cursor=cursor12345" hx-target="#history-dice-roll-more-button" hx-swap="outerHTML"> <strong>Load more...</strong> </a> </td> <td></td> <td></td> <td></td> </tr>`, // We have the pagination load more button.
+				`<tr id="history-dice-roll-row"> <td> <div> <strong>user1</strong> </div> <div> <small class="timestamp-ago" unix-ts="1674299140"></small> </div> </td> <td> <kbd>1</kbd> <kbd>2</kbd> </td> <td> </td> <td> </td> <td> </td> <td> </td> <td> <kbd>3</kbd> </td> <td> </td> </tr>`,                                                                   // We have the results of 1st Dice roll.
+				`<tr id="history-dice-roll-row"> <td> <div> <strong>user2</strong> </div> <div> <small class="timestamp-ago" unix-ts="1674299135"></small> </div> </td> <td> </td> <td> <kbd>4</kbd> </td> <td> </td> <td> <kbd>8</kbd> </td> <td> <kbd>11</kbd> </td> <td> </td> <td> </td> </tr>`,                                                                  // We have the results of 2nd Dice roll.
+				`<tr id="history-dice-roll-row"> <td> <div> <strong>user3</strong> </div> <div> <small class="timestamp-ago" unix-ts="1674299105"></small> </div> </td> <td> </td> <td> </td> <td> <kbd>6</kbd> </td> <td> </td> <td> </td> <td> <kbd>1</kbd> <kbd>20</kbd> </td> <td> <small>D2</small> <kbd>2</kbd> <small>D100</small> <kbd>57</kbd> </td> </tr>`, // We have the results of 3rd Dice roll with the other dice sorted by sides.
+				`<tr id="history-dice-roll-row"> <td> <div> <strong>user3</strong> </div> <div> <small class="timestamp-ago" unix-ts="1674299105"></small> </div> </td> <td> </td> <td> </td> <td> <kbd>6</kbd> </td> <td> </td> <td> </td> <td> <kbd>1</kbd> <kbd>20</kbd> </td>`,                                                                                   // We have the results of last dice roll.
+				`<tr id="history-dice-roll-more-button"> <td></td> <td></td> <td></td> <td> <a hx-get="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/dice-roll-history/more-items?cursor=cursor12345" hx-target="#history-dice-roll-more-button" hx-swap="outerHTML"> <strong>Load more...</strong> </a> </td> <td></td> <td></td> <td></td> <td></td> </tr>`,         // We have the pagination load more button.
 				`<nav class="container-fluid">`,    // We have a nav bar.
 				`<footer class="container-fluid">`, // We have a footer.
 			},
 		},
+
+		"Asking for the dice roll history items with dice pools, labels and modifiers should return the list with their labels and totals.": {
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/dice-roll-history", nil)
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				r1 := room.GetRoomRequest{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
+				m.mr.On("GetRoom", mock.Anything, r1).Once().Return(&room.GetRoomResponse{Room: model.Room{
+					ID:   "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					Name: "test",
+				}}, nil)
+
+				r2 := dice.ListDiceRollsRequest{
+					RoomID:       "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					ViewerUserID: "user1",
+					PageOpts:     model.PaginationOpts{Size: 10},
+				}
+				m.md.On("ListDiceRolls", mock.Anything, r2).Once().Return(&dice.ListDiceRollsResponse{
+					DiceRolls: []model.DiceRoll{
+						{
+							UserID:    "user-id1",
+							CreatedAt: t0.Add(-5 * time.Second),
+							Total:     2,
+							Pool:      &model.DicePoolResult{Successes: 2, Outcome: model.DicePoolOutcomeCriticalSuccess},
+							Dice: []model.DieRoll{
+								{ID: "1", Type: model.DieTypeD6, Side: 6},
+								{ID: "2", Type: model.DieTypeD6, Side: 6},
+							},
+						},
+						{
+							UserID:    "user-id1",
+							CreatedAt: t0.Add(-10 * time.Second),
+							Label:     "Longsword damage",
+							Modifier:  5,
+							Total:     17,
+							Dice: []model.DieRoll{
+								{ID: "3", Type: model.DieTypeD20, Side: 12},
+							},
+						},
+					},
+				}, nil)
+
+				r3 := user.ListUsersRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", SessionToken: "user1"}
+				m.mu.On("ListUsers", mock.Anything, r3).Once().Return(&user.ListUsersResponse{
+					Users: []model.User{
+						{ID: "user-id1", Name: "user1"},
+					},
+				}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<tr id="history-dice-roll-row"> <td> <div> <strong>user1</strong> </div> <div> <small class="timestamp-ago" unix-ts="1674299140"></small> </div> <div> <small><strong>2</strong> successes: <mark>Critical success</mark></small> </div> </td> <td> </td> <td> <kbd>6</kbd> <kbd>6</kbd> </td>`,
+				`<tr id="history-dice-roll-row"> <td> <div> <strong>user1</strong> </div> <div> <small class="timestamp-ago" unix-ts="1674299135"></small> </div> <div> <small><em>Longsword damage</em></small> </div> <div> <small><code>+5</code> = <strong>17</strong></small> </div> </td>`,
+			},
+		},
+
+		"Asking for the dice roll history items with difficulty checks should return the list coloured by the check outcome.": {
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/dice-roll-history", nil)
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				r1 := room.GetRoomRequest{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
+				m.mr.On("GetRoom", mock.Anything, r1).Once().Return(&room.GetRoomResponse{Room: model.Room{
+					ID:   "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					Name: "test",
+				}}, nil)
+
+				r2 := dice.ListDiceRollsRequest{
+					RoomID:       "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					ViewerUserID: "user1",
+					PageOpts:     model.PaginationOpts{Size: 10},
+				}
+				m.md.On("ListDiceRolls", mock.Anything, r2).Once().Return(&dice.ListDiceRollsResponse{
+					DiceRolls: []model.DiceRoll{
+						{
+							UserID:     "user-id1",
+							CreatedAt:  t0.Add(-5 * time.Second),
+							Expression: "1d20+2",
+							Total:      22,
+							Check:      &model.DiceRollCheck{Target: 15, Comparison: model.DiceRollCheckComparisonGreaterOrEqual, Margin: 7, Outcome: model.DiceRollCheckOutcomeCriticalSuccess},
+							Dice: []model.DieRoll{
+								{ID: "1", Type: model.DieTypeD20, Side: 20},
+							},
+						},
+						{
+							UserID:     "user-id1",
+							CreatedAt:  t0.Add(-10 * time.Second),
+							Expression: "1d100",
+							Total:      62,
+							Check:      &model.DiceRollCheck{Target: 45, Comparison: model.DiceRollCheckComparisonLessOrEqual, Margin: -17, Outcome: model.DiceRollCheckOutcomeFailure},
+							Dice: []model.DieRoll{
+								{ID: "2", Type: model.DieTypeD100, Side: 62},
+							},
+						},
+					},
+				}, nil)
+
+				r3 := user.ListUsersRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", SessionToken: "user1"}
+				m.mu.On("ListUsers", mock.Anything, r3).Once().Return(&user.ListUsersResponse{
+					Users: []model.User{
+						{ID: "user-id1", Name: "user1"},
+					},
+				}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<tr id="history-dice-roll-row" class="check-critical-success"> <td> <div> <strong>user1</strong> </div> <div> <small class="timestamp-ago" unix-ts="1674299140"></small> </div> <div> <small><code>1d20+2</code> = <strong>22</strong></small> </div> <div> <small><code>>= 15</code>: <mark>Critical success</mark> (+7)</small> </div> </td>`,
+				`<tr id="history-dice-roll-row" class="check-failure"> <td> <div> <strong>user1</strong> </div> <div> <small class="timestamp-ago" unix-ts="1674299135"></small> </div> <div> <small><code>1d100</code> = <strong>62</strong></small> </div> <div> <small><code><= 45</code>: <mark>Failure</mark> (-17)</small> </div> </td>`,
+			},
+		},
+
+		"Asking for the dice roll history items with not public dice rolls should return the list with the visibility and the hidden dice rolls without results.": {
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/dice-roll-history", nil)
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				r1 := room.GetRoomRequest{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
+				m.mr.On("GetRoom", mock.Anything, r1).Once().Return(&room.GetRoomResponse{Room: model.Room{
+					ID:   "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					Name: "test",
+				}}, nil)
+
+				r2 := dice.ListDiceRollsRequest{
+					RoomID:       "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					ViewerUserID: "user1",
+					PageOpts:     model.PaginationOpts{Size: 10},
+				}
+				m.md.On("ListDiceRolls", mock.Anything, r2).Once().Return(&dice.ListDiceRollsResponse{
+					DiceRolls: []model.DiceRoll{
+						{
+							UserID:     "user-id2",
+							CreatedAt:  t0.Add(-5 * time.Second),
+							Visibility: model.DiceRollVisibilityGameMaster,
+							Hidden:     true,
+							Dice:       []model.DieRoll{},
+						},
+						{
+							UserID:         "user-id1",
+							CreatedAt:      t0.Add(-10 * time.Second),
+							Visibility:     model.DiceRollVisibilityWhisper,
+							WhisperUserIDs: []string{"user-id2"},
+							Dice: []model.DieRoll{
+								{ID: "1", Type: model.DieTypeD20, Side: 12},
+							},
+						},
+					},
+				}, nil)
+
+				r3 := user.ListUsersRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", SessionToken: "user1"}
+				m.mu.On("ListUsers", mock.Anything, r3).Once().Return(&user.ListUsersResponse{
+					Users: []model.User{
+						{ID: "user-id1", Name: "user1"},
+						{ID: "user-id2", Name: "user2"},
+					},
+				}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<tr id="history-dice-roll-row"> <td> <div> <strong>user2</strong> </div> <div> <small class="timestamp-ago" unix-ts="1674299140"></small> </div> <div> <small><em>Hidden roll</em></small> </div> </td>`,
+				`<tr id="history-dice-roll-row"> <td> <div> <strong>user1</strong> </div> <div> <small class="timestamp-ago" unix-ts="1674299135"></small> </div> <div> <small>(Whisper)</small> </div> </td>`,
+			},
+		},
+
+		"Asking for the dice roll history with a forged session (e.g the game master user ID) should redirect to the login without listing the dice rolls.": {
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/dice-roll-history", nil)
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "gm-id", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				ar := user.AuthenticateUserRequest{SessionToken: "gm-id", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
+				m.mu.On("AuthenticateUser", mock.Anything, ar).Once().Return(nil, internalerrors.ErrNotAllowed)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+				"Location":     {"/u/login/e02b402d-c23b-45b2-a5ea-583a566a9a6b"},
+			},
+			expCode: 307,
+			expBody: []string{},
+		},
+
+		"Asking for the dice roll history items with rerolls should return the list with the rerolled dice roll references.": {
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/dice-roll-history", nil)
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				r1 := room.GetRoomRequest{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
+				m.mr.On("GetRoom", mock.Anything, r1).Once().Return(&room.GetRoomResponse{Room: model.Room{
+					ID:   "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					Name: "test",
+				}}, nil)
+
+				r2 := dice.ListDiceRollsRequest{
+					RoomID:       "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					ViewerUserID: "user1",
+					PageOpts:     model.PaginationOpts{Size: 10},
+				}
+				m.md.On("ListDiceRolls", mock.Anything, r2).Once().Return(&dice.ListDiceRollsResponse{
+					DiceRolls: []model.DiceRoll{
+						{
+							ID:        "dr3",
+							UserID:    "user-id1",
+							CreatedAt: t0.Add(-5 * time.Second),
+							ParentID:  "dr1",
+							Total:     14,
+							Dice: []model.DieRoll{
+								{ID: "4", Type: model.DieTypeD20, Side: 3, Status: model.DieRollStatusRerolled},
+								{ID: "5", Type: model.DieTypeD20, Side: 14},
+							},
+						},
+						{
+							ID:        "dr2",
+							UserID:    "user-id1",
+							CreatedAt: t0.Add(-10 * time.Second),
+							ParentID:  "dr0",
+							Total:     6,
+							Dice: []model.DieRoll{
+								{ID: "2", Type: model.DieTypeD6, Side: 1, Status: model.DieRollStatusRerolled},
+								{ID: "3", Type: model.DieTypeD6, Side: 6},
+							},
+						},
+						{
+							ID:        "dr1",
+							UserID:    "user-id1",
+							CreatedAt: t0.Add(-20 * time.Second),
+							Total:     3,
+							Dice: []model.DieRoll{
+								{ID: "1", Type: model.DieTypeD20, Side: 3},
+							},
+						},
+					},
+				}, nil)
+
+				r3 := user.ListUsersRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", SessionToken: "user1"}
+				m.mu.On("ListUsers", mock.Anything, r3).Once().Return(&user.ListUsersResponse{
+					Users: []model.User{
+						{ID: "user-id1", Name: "user1"},
+					},
+				}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<tr id="history-dice-roll-row"> <td> <div> <strong>user1</strong> </div> <div> <small class="timestamp-ago" unix-ts="1674299140"></small> </div> <div> <small>Reroll of the roll from <span class="timestamp-ago" unix-ts="1674299125"></span></small> </div> </td>`, // The rerolled dice roll is on the page.
+				`<tr id="history-dice-roll-row"> <td> <div> <strong>user1</strong> </div> <div> <small class="timestamp-ago" unix-ts="1674299135"></small> </div> <div> <small>Reroll</small> </div> </td>`,                                                                           // The rerolled dice roll is not on the page.
+				`<td> <del><kbd>3</kbd></del> <kbd>14</kbd> </td>`,
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -140,11 +422,16 @@ func TestHandlerFullDiceRollHistory(t *testing.T) {
 			s := sse.New()
 			defer s.Close()
 			h, err := ui.New(ui.Config{
-				DiceAppService: m.md,
-				RoomAppService: m.mr,
-				UserAppService: m.mu,
-				TimeNow:        func() time.Time { return t0.UTC() },
-				SSEServer:      s,
+				DiceAppService:       m.md,
+				RoomAppService:       m.mr,
+				UserAppService:       mockSessionUsers(m.mu),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+				TimeNow:              func() time.Time { return t0.UTC() },
+				SSEServer:            s,
 			})
 			require.NoError(err)
 
@@ -3,24 +3,50 @@ package ui
 import (
 	"fmt"
 	"net/http"
+	"slices"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/rollify/rollify/internal/deck"
+	"github.com/rollify/rollify/internal/dice"
+	"github.com/rollify/rollify/internal/initiative"
+	"github.com/rollify/rollify/internal/macro"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle"
 	"github.com/rollify/rollify/internal/room"
+	"github.com/rollify/rollify/internal/user"
 )
 
 func (u ui) handlerFullDiceRoller() http.HandlerFunc {
 	type tplData struct {
-		RoomName       string
-		Dice           []die
-		DiceHistoryURL string
-		IsDiceHistory  bool
-		SSEURL         string
+		RoomName           string
+		Ruleset            dice.Ruleset
+		Dice               []die
+		DiceQuantity       []int
+		DiceHistoryURL     string
+		IsDiceHistory      bool
+		SSEURL             string
+		WhisperUsers       []model.User
+		Macros             []model.Macro
+		Initiative         initiativeTracker
+		Decks              []cardDeck
+		DeckTypes          []model.DeckType
+		OracleTables       []oracleTable
+		OracleTableFormats []oracle.TableFormat
+		HTMLSSEURL         string
+		// Archived rooms are read-only.
+		Archived   bool
+		GameMaster bool
+		// GameMasterSessionToken is used by the game master login link to log in again as the game master.
+		GameMasterSessionToken string
+		// Protected rooms have invite tokens managed by the game masters.
+		Protected    bool
+		InviteTokens []model.RoomInviteToken
 	}
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		roomID := chi.URLParam(r, urlParamRoomID)
-		userID := cookies.GetUserID(r, roomID)
+		userID := sessionUserID(r)
 
 		// If not user ID, redirect to room selection.
 		if userID == "" {
@@ -34,19 +60,78 @@ func (u ui) handlerFullDiceRoller() http.HandlerFunc {
 			return
 		}
 
+		dts, err := u.diceAppSvc.ListDiceTypes(r.Context(), dice.ListDiceTypesRequest{RoomID: roomID})
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not list room dice types: %w", err))
+			return
+		}
+
+		roomUsers, err := u.userAppSvc.ListUsers(r.Context(), user.ListUsersRequest{RoomID: roomID, SessionToken: cookies.GetSession(r, roomID)})
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could list room users: %w", err))
+			return
+		}
+
+		macros, err := u.macroAppSvc.ListMacros(r.Context(), macro.ListMacrosRequest{RoomID: roomID, UserID: userID})
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not list user macros: %w", err))
+			return
+		}
+
+		ini, err := u.initiativeAppSvc.GetInitiative(r.Context(), initiative.GetInitiativeRequest{RoomID: roomID})
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not get room initiative: %w", err))
+			return
+		}
+
+		decks, err := u.deckAppSvc.ListDecks(r.Context(), deck.ListDecksRequest{RoomID: roomID})
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not list room decks: %w", err))
+			return
+		}
+
+		oracleTables, err := u.oracleAppSvc.ListTables(r.Context(), oracle.ListTablesRequest{RoomID: roomID})
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not list room oracle tables: %w", err))
+			return
+		}
+
+		// Rooms with a game system have its preset rolls on the dice roller.
+		ruleset, _ := dice.GetRuleset(room.Room.Ruleset)
+
+		// Only the game masters can archive and delete the room.
+		gameMaster := slices.ContainsFunc(roomUsers.Users, func(u model.User) bool { return u.ID == userID && u.GameMaster })
+
+		// The game master logs in again with its session, it doesn't depend on the room password.
+		gmSessionToken := ""
+		if gameMaster {
+			gmSessionToken = cookies.GetSession(r, roomID)
+		}
+
+		// The user can whisper the dice rolls to the rest of the room users.
+		whisperUsers := slices.DeleteFunc(roomUsers.Users, func(u model.User) bool { return u.ID == userID })
+
 		u.tplRenderer.withRoom(roomID).RenderResponse(r.Context(), w, "room_dice_roller", tplData{
-			RoomName:       room.Room.Name,
-			DiceHistoryURL: u.servePrefix + "/room/" + room.Room.ID + "/dice-roll-history",
-			Dice: []die{
-				dieD4,
-				dieD6,
-				dieD8,
-				dieD10,
-				dieD12,
-				dieD20,
-			},
-			IsDiceHistory: false,
-			SSEURL:        fmt.Sprintf("%s/subscribe/room/dice-roll-history?%s=%s%s", u.servePrefix, queryParamSSEStream, sseStreamPrefixNotification, roomID),
+			RoomName:               room.Room.Name,
+			Ruleset:                ruleset,
+			DiceHistoryURL:         u.servePrefix + "/room/" + room.Room.ID + "/dice-roll-history",
+			Dice:                   append(slices.Clip(rollerDice), roomFacedDice(dts.DiceTypes)...),
+			DiceQuantity:           diceQuantity,
+			IsDiceHistory:          false,
+			SSEURL:                 fmt.Sprintf("%s/subscribe/room/dice-roll-history?%s=%s%s", u.servePrefix, queryParamSSEStream, sseStreamPrefixNotification, roomID),
+			WhisperUsers:           whisperUsers,
+			Macros:                 macros.Macros,
+			Initiative:             mapInitiativeToTplModel(ini.Initiative),
+			Decks:                  mapDecksToTplModel(decks.Decks),
+			DeckTypes:              deckTypes,
+			OracleTables:           mapOracleTablesToTplModel(oracleTables.Tables),
+			OracleTableFormats:     oracleTableFormats,
+			HTMLSSEURL:             fmt.Sprintf("%s/subscribe/room/dice-roll-history?%s=%s%s", u.servePrefix, queryParamSSEStream, sseStreamPrefixHTML, roomID),
+			Archived:               room.Room.Archived,
+			GameMaster:             gameMaster,
+			GameMasterSessionToken: gmSessionToken,
+			Protected:              room.Room.Protected(),
+			InviteTokens:           room.Room.InviteTokens,
 		})
 	})
 }
@@ -11,11 +11,22 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/rollify/rollify/internal/deck"
+	"github.com/rollify/rollify/internal/deck/deckmock"
+	"github.com/rollify/rollify/internal/dice"
 	"github.com/rollify/rollify/internal/dice/dicemock"
 	"github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
+	"github.com/rollify/rollify/internal/macro"
+	"github.com/rollify/rollify/internal/macro/macromock"
 	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
 	"github.com/rollify/rollify/internal/room"
 	"github.com/rollify/rollify/internal/room/roommock"
+	"github.com/rollify/rollify/internal/stats/statsmock"
+	"github.com/rollify/rollify/internal/user"
 	"github.com/rollify/rollify/internal/user/usermock"
 )
 
@@ -25,6 +36,10 @@ func TestHanderFullDiceRoller(t *testing.T) {
 		md *dicemock.Service
 		mr *roommock.Service
 		mu *usermock.Service
+		mm *macromock.Service
+		mi *initiativemock.Service
+		mk *deckmock.Service
+		mo *oraclemock.Service
 	}
 
 	tests := map[string]struct {
@@ -38,16 +53,58 @@ func TestHanderFullDiceRoller(t *testing.T) {
 			request: func() *http.Request {
 				req := httptest.NewRequest(http.MethodGet, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b", nil)
 				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-				req.AddCookie(&http.Cookie{Name: "_room_user_id_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
 
 				return req
 			},
 			mock: func(m mocks) {
 				r := room.GetRoomRequest{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
 				m.mr.On("GetRoom", mock.Anything, r).Once().Return(&room.GetRoomResponse{Room: model.Room{
-					ID:   "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
-					Name: "test",
+					ID:      "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					Name:    "test",
+					Ruleset: "pbta",
 				}}, nil)
+				m.md.On("ListDiceTypes", mock.Anything, dice.ListDiceTypesRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}).Once().Return(&dice.ListDiceTypesResponse{
+					DiceTypes: append(append([]model.DieType{}, model.DiceTypes...), model.CustomDieType{
+						TypeID:    "fate-id",
+						TypeName:  "Fate",
+						FaceTable: []model.DieFace{{Label: "-", Value: -1}, {Label: "", Value: 0}, {Label: "+", Value: 1}},
+					}),
+				}, nil)
+				m.mu.On("ListUsers", mock.Anything, user.ListUsersRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", SessionToken: "user1"}).Once().Return(&user.ListUsersResponse{
+					Users: []model.User{{ID: "user1", Name: "Ragnar"}, {ID: "user2", Name: "Lagertha"}},
+				}, nil)
+				m.mm.On("ListMacros", mock.Anything, macro.ListMacrosRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", UserID: "user1"}).Once().Return(&macro.ListMacrosResponse{
+					Macros: []model.Macro{{ID: "macro1", Name: "Longsword", Expression: "1d20+5", Shared: true}},
+				}, nil)
+				m.mi.On("GetInitiative", mock.Anything, initiative.GetInitiativeRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}).Once().Return(&initiative.GetInitiativeResponse{
+					Initiative: model.Initiative{
+						RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+						Round:  2,
+						Turn:   1,
+						Combatants: []model.InitiativeCombatant{
+							{ID: "c1", Name: "Goblin", Initiative: 18},
+							{ID: "c2", Name: "Ragnar", UserID: "user1", Initiative: 12},
+						},
+					},
+				}, nil)
+				m.mk.On("ListDecks", mock.Anything, deck.ListDecksRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}).Once().Return(&deck.ListDecksResponse{
+					Decks: []model.Deck{{
+						ID:          "deck1",
+						Name:        "Action deck",
+						Cards:       []string{"A♠", "2♠", "3♠", "4♠"},
+						DrawPile:    []string{"4♠", "3♠"},
+						DiscardPile: []string{"2♠"},
+					}},
+				}, nil)
+				m.mo.On("ListTables", mock.Anything, oracle.ListTablesRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}).Once().Return(&oracle.ListTablesResponse{
+					Tables: []model.OracleTable{{
+						ID:      "table1",
+						Name:    "Encounters",
+						Dice:    "d66",
+						Entries: []model.OracleTableEntry{{Min: 11, Max: 36, Result: "Nothing"}, {Min: 41, Max: 66, TableName: "Monsters"}},
+					}},
+				}, nil)
 			},
 			expHeaders: http.Header{
 				"Content-Type": {"text/html; charset=utf-8"},
@@ -55,20 +112,84 @@ func TestHanderFullDiceRoller(t *testing.T) {
 			expCode: 200,
 			expBody: []string{
 				`<a role="button" class="contrast" href="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/dice-roll-history" hx-ext="sse" sse-connect="/u/subscribe/room/dice-roll-history?stream=notification-e02b402d-c23b-45b2-a5ea-583a566a9a6b" sse-swap="new_dice_roll" hx-swap="none"> History </a>`, // We have the dice history button.
-				`<div class="notification-badge-container"> <span id="notification-badge">0</span>`,                                                               // We have the bubble notification SSE connection with HTMX.
-				`<a href="/u/logout/e02b402d-c23b-45b2-a5ea-583a566a9a6b" role="button" class="secondary outline"> Logout </a>`,                                   // We have the logout button.
-				`<form id="diceRollerForm" hx-post="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/new-dice-roll" hx-swap="innerHTML" hx-target="#diceRollResult">`, // Check HTMX call is in place.
-				`<select id="d4" name="d4" class="diceRollerSelector">`,                                                                                           // We have a d4 on a the dice roller.
-				`<select id="d6" name="d6" class="diceRollerSelector">`,                                                                                           // We have a d6 on a the dice roller.
-				`<select id="d8" name="d8" class="diceRollerSelector">`,                                                                                           // We have a d8 on a the dice roller.
-				`<select id="d10" name="d10" class="diceRollerSelector">`,                                                                                         // We have a d10 on a the dice roller.
-				`<select id="d12" name="d12" class="diceRollerSelector">`,                                                                                         // We have a d12 on a the dice roller.
-				`<select id="d20" name="d20" class="diceRollerSelector">`,                                                                                         // We have a d20 on a the dice roller.
-				`<a onclick="cleanDiceSelectors()" href="#" role="button" class="secondary">Clear</a> </div> `,                                                    // We have the clear button.
-				`<button type="submit">Roll</button>`,                                                                                                             // We have the submit button.
-				`<footer id="diceRollResult"> <!-- will be replaced by HTMX on dice rolls--> </footer>`,                                                           // We have the empty result of the dice roll.
-				`<nav class="container-fluid">`,                                                                                                                   // We have a nav bar.
-				`<footer class="container-fluid">`,                                                                                                                // We have a footer.
+				`<div class="notification-badge-container"> <span id="notification-badge">0</span>`,                                                                     // We have the bubble notification SSE connection with HTMX.
+				`<a href="/u/logout/e02b402d-c23b-45b2-a5ea-583a566a9a6b" role="button" class="secondary outline"> Logout </a>`,                                         // We have the logout button.
+				`<form id="diceRollerForm" hx-post="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/new-dice-roll" hx-swap="innerHTML" hx-target="#diceRollResult">`,       // Check HTMX call is in place.
+				`<select id="d4" name="d4" class="diceRollerSelector">`,                                                                                                 // We have a d4 on a the dice roller.
+				`<select id="d6" name="d6" class="diceRollerSelector">`,                                                                                                 // We have a d6 on a the dice roller.
+				`<select id="d8" name="d8" class="diceRollerSelector">`,                                                                                                 // We have a d8 on a the dice roller.
+				`<select id="d10" name="d10" class="diceRollerSelector">`,                                                                                               // We have a d10 on a the dice roller.
+				`<select id="d12" name="d12" class="diceRollerSelector">`,                                                                                               // We have a d12 on a the dice roller.
+				`<select id="d20" name="d20" class="diceRollerSelector">`,                                                                                               // We have a d20 on a the dice roller.
+				`<select id="d2" name="d2" class="diceRollerSelector">`,                                                                                                 // We have a d2 on a the dice roller.
+				`<select id="d3" name="d3" class="diceRollerSelector">`,                                                                                                 // We have a d3 on a the dice roller.
+				`<select id="d100" name="d100" class="diceRollerSelector">`,                                                                                             // We have a d100 on a the dice roller.
+				`<select id="fate-id" name="fate-id" class="diceRollerSelector">`,                                                                                       // We have the room custom die on the dice roller.
+				`<input type="number" id="custom-die-sides" name="custom-die-sides" class="diceRollerSelector" min="2" max="1000" placeholder="Custom die sides (dN)">`, // We have the custom die sides.
+				`<select id="custom-die-quantity" name="custom-die-quantity" class="diceRollerSelector">`,                                                               // We have the custom die quantity.
+				`<input type="text" id="label" name="label" maxlength="100" placeholder="Label (e.g: Stealth check), optional">`,                                        // We have the label.
+				`<input type="number" id="modifier" name="modifier" class="diceRollerSelector" min="-1000" max="1000" placeholder="Modifier (e.g: 5, -2)">`,             // We have the modifier.
+				`<a onclick="cleanDiceSelectors()" href="#" role="button" class="secondary">Clear</a> </div> `,                                                          // We have the clear button.
+				`<select id="visibility" name="visibility"> <option value="public" selected>Public</option> <option value="game_master">GM only</option> <option value="whisper:user2">Whisper to Lagertha</option> </select>`, // We have the visibility without the user itself.
+				`<input type="checkbox" id="advantage" name="advantage" value="advantage" class="diceRollerSelector" role="switch"/> Advantage`,                                                                                // We have the D20 advantage toggle.
+				`<input type="checkbox" id="disadvantage" name="advantage" value="disadvantage" class="diceRollerSelector" role="switch"/> Disadvantage`,                                                                       // We have the D20 disadvantage toggle.
+				`<button class="outline" title="1d20+5" hx-post="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/macros/macro1/roll" hx-include="#visibility" hx-swap="innerHTML" hx-target="#diceRollResult">Longsword</button>`, // We have the macro bar.
+				`<p><small>Powered by the Apocalypse</small></p>`, // We have the room game system.
+				`<button class="secondary" hx-post="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/ruleset-rolls/move/roll" hx-include="#label, #modifier, #check-target, #check-comparison, #visibility" hx-swap="innerHTML" hx-target="#diceRollResult">Move (2d6)</button>`, // We have the game system preset rolls.
+				`<form id="saveMacroForm" hx-post="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/macros" hx-swap="outerHTML" hx-target="#macroBar">`,                                                                                                                          // We have the save macro form.
+				`<div hx-ext="sse" sse-connect="/u/subscribe/room/dice-roll-history?stream=html-e02b402d-c23b-45b2-a5ea-583a566a9a6b"> <div sse-swap="room_closed"></div><article sse-swap="initiative_updated">`,                                                            // We have the room HTML SSE connection with HTMX.
+				`<summary>Initiative (round 2)</summary>`,                   // We have the initiative tracker round.
+				`<td>Goblin</td> <td><strong>18</strong></td>`,              // We have the combatants.
+				`<td><mark>Ragnar</mark></td> <td><strong>12</strong></td>`, // We have the combatant with the current turn.
+				`<button hx-post="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/initiative/next" hx-swap="outerHTML" hx-target="#initiativeTracker">Next</button>`,              // We have the next turn action.
+				`<form id="addCombatantForm" hx-post="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/initiative/combatants" hx-swap="outerHTML" hx-target="#initiativeTracker">`, // We have the add combatant form.
+				`<td><strong>Action deck</strong></td> <td>2 left, 1 discarded</td> <td><kbd>A♠</kbd> </td>`,                                                                   // We have the deck with its cards in play.
+				`<a href="#" hx-post="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/decks/deck1/draw" hx-swap="outerHTML" hx-target="#cardDecks">Draw</a>`,                      // We have the draw action.
+				`<form id="createDeckForm" hx-post="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/decks" hx-swap="outerHTML" hx-target="#cardDecks">`,                           // We have the create deck form.
+				`<ul id="cardDraws" sse-swap="cards_drawn" hx-swap="afterbegin"></ul>`,                                                                                         // We have the card draws SSE swap.
+				`<td><strong>Encounters</strong></td> <td>d66, 2 entries</td>`,                                                                                                 // We have the oracle table.
+				`<a href="#" hx-post="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/oracle-tables/table1/roll" hx-swap="outerHTML" hx-target="#oracleTables">Roll</a>`,          // We have the oracle table roll action.
+				`<form id="createOracleTableForm" hx-post="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/oracle-tables" hx-swap="outerHTML" hx-target="#oracleTables">`,         // We have the create oracle table form.
+				`<ul id="oracleTableRolls" sse-swap="oracle_table_rolled" hx-swap="afterbegin"></ul>`,                                                                          // We have the oracle table rolls SSE swap.
+				`<button type="submit">Roll</button>`,                                                   // We have the submit button.
+				`<footer id="diceRollResult"> <!-- will be replaced by HTMX on dice rolls--> </footer>`, // We have the empty result of the dice roll.
+				`<nav class="container-fluid">`,                                                         // We have a nav bar.
+				`<footer class="container-fluid">`,                                                      // We have a footer.
+			},
+		},
+
+		"Entering on an archived room as game master should show the room read-only with the room settings.": {
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b", nil)
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				roomID := "e02b402d-c23b-45b2-a5ea-583a566a9a6b"
+				m.mr.On("GetRoom", mock.Anything, room.GetRoomRequest{ID: roomID}).Once().Return(&room.GetRoomResponse{Room: model.Room{
+					ID:       roomID,
+					Name:     "test",
+					Archived: true,
+				}}, nil)
+				m.md.On("ListDiceTypes", mock.Anything, mock.Anything).Once().Return(&dice.ListDiceTypesResponse{DiceTypes: model.DiceTypes}, nil)
+				m.mu.On("ListUsers", mock.Anything, mock.Anything).Once().Return(&user.ListUsersResponse{
+					Users: []model.User{{ID: "user1", Name: "Ragnar", GameMaster: true}},
+				}, nil)
+				m.mm.On("ListMacros", mock.Anything, mock.Anything).Once().Return(&macro.ListMacrosResponse{}, nil)
+				m.mi.On("GetInitiative", mock.Anything, mock.Anything).Once().Return(&initiative.GetInitiativeResponse{}, nil)
+				m.mk.On("ListDecks", mock.Anything, mock.Anything).Once().Return(&deck.ListDecksResponse{}, nil)
+				m.mo.On("ListTables", mock.Anything, mock.Anything).Once().Return(&oracle.ListTablesResponse{}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<p><mark>This room is archived</mark>, it keeps its history but it's read-only.</p>`,                                                                                                               // We have the archived notice.
+				`<a href="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/dice-roll-history" role="button">Dice roll history</a>`,                                                                                      // We have the history link.
+				`<button class="contrast" hx-post="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/delete" hx-confirm="Delete the room with all its users and dice rolls? This can't be undone.">Delete room</button>`, // We have the delete action.
+				`<a href="/u/login/e02b402d-c23b-45b2-a5ea-583a566a9a6b?session=user1">Game master login link</a>`,                                                                                                  // We have the game master login link.
 			},
 		},
 	}
@@ -82,17 +203,26 @@ func TestHanderFullDiceRoller(t *testing.T) {
 				md: &dicemock.Service{},
 				mr: &roommock.Service{},
 				mu: &usermock.Service{},
+				mm: &macromock.Service{},
+				mi: &initiativemock.Service{},
+				mk: &deckmock.Service{},
+				mo: &oraclemock.Service{},
 			}
 			test.mock(m)
 
 			s := sse.New()
 			defer s.Close()
 			h, err := ui.New(ui.Config{
-				DiceAppService: m.md,
-				RoomAppService: m.mr,
-				UserAppService: m.mu,
-				TimeNow:        func() time.Time { return t0.UTC() },
-				SSEServer:      s,
+				DiceAppService:       m.md,
+				RoomAppService:       m.mr,
+				UserAppService:       mockSessionUsers(m.mu),
+				MacroAppService:      m.mm,
+				InitiativeAppService: m.mi,
+				DeckAppService:       m.mk,
+				OracleAppService:     m.mo,
+				StatsAppService:      &statsmock.Service{},
+				TimeNow:              func() time.Time { return t0.UTC() },
+				SSEServer:            s,
 			})
 			require.NoError(err)
 
@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/room"
+	"github.com/rollify/rollify/internal/stats"
+	"github.com/rollify/rollify/internal/user"
+)
+
+// suspiciousPValue is the chi-square p-value below which a die distribution is too unlikely for a fair die.
+const suspiciousPValue = 0.01
+
+type dieStats struct {
+	Name                string
+	Rolls               uint
+	Sides               []sideStats
+	Mean                string
+	ExpectedMean        string
+	ChiSquare           string
+	DegreesOfFreedom    uint
+	PValue              string
+	Verdict             string
+	LongestRepeatStreak uint
+	LongestHighStreak   uint
+	LongestLowStreak    uint
+}
+
+type sideStats struct {
+	Side    int
+	Count   uint
+	Percent string
+}
+
+type statsUser struct {
+	Name     string
+	URL      string
+	Selected bool
+}
+
+func (u ui) handlerFullDiceStats() http.HandlerFunc {
+	type tplData struct {
+		RoomName       string
+		NewDiceRollURL string
+		IsDiceHistory  bool
+		Users          []statsUser
+		DieTypes       []dieStats
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roomID := chi.URLParam(r, urlParamRoomID)
+		userID := sessionUserID(r)
+
+		// If not user ID, redirect to room selection.
+		if userID == "" {
+			u.redirectToURL(w, r, u.servePrefix+"/login/"+roomID)
+			return
+		}
+
+		room, err := u.roomAppSvc.GetRoom(r.Context(), room.GetRoomRequest{ID: roomID})
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not get room: %w", err))
+			return
+		}
+
+		roomUsers, err := u.userAppSvc.ListUsers(r.Context(), user.ListUsersRequest{RoomID: roomID, SessionToken: cookies.GetSession(r, roomID)})
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could list room users: %w", err))
+			return
+		}
+
+		statsUserID := r.URL.Query().Get(queryParamUser)
+		res, err := u.statsAppSvc.GetDiceStats(r.Context(), stats.GetDiceStatsRequest{RoomID: roomID, UserID: statsUserID})
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not get dice stats: %w", err))
+			return
+		}
+
+		u.tplRenderer.withRoom(roomID).RenderResponse(r.Context(), w, "room_dice_stats", tplData{
+			RoomName:       room.Room.Name,
+			NewDiceRollURL: u.servePrefix + "/room/" + room.Room.ID,
+			IsDiceHistory:  true,
+			Users:          u.statsUsers(roomID, statsUserID, roomUsers.Users),
+			DieTypes:       mapDiceStatsToTplModel(*res),
+		})
+	})
+}
+
+// statsUsers returns the stats filters, the first one is the whole room.
+func (u ui) statsUsers(roomID, selectedUserID string, users []model.User) []statsUser {
+	url := u.servePrefix + "/room/" + roomID + "/stats"
+	res := []statsUser{{Name: "Everyone", URL: url, Selected: selectedUserID == ""}}
+	for _, us := range users {
+		res = append(res, statsUser{
+			Name:     us.Name,
+			URL:      fmt.Sprintf("%s?%s=%s", url, queryParamUser, us.ID),
+			Selected: us.ID == selectedUserID,
+		})
+	}
+
+	return res
+}
+
+func mapDiceStatsToTplModel(r stats.GetDiceStatsResponse) []dieStats {
+	res := make([]dieStats, 0, len(r.DieTypes))
+	for _, d := range r.DieTypes {
+		sides := make([]sideStats, 0, len(d.Distribution))
+		for i, c := range d.Distribution {
+			sides = append(sides, sideStats{
+				Side:    i + 1,
+				Count:   c,
+				Percent: fmt.Sprintf("%.1f", float64(c)*100/float64(max(d.Rolls, 1))),
+			})
+		}
+
+		verdict := "Looks fair"
+		switch {
+		case !d.EnoughRolls:
+			verdict = "Not enough rolls"
+		case d.PValue < suspiciousPValue:
+			verdict = "Suspicious"
+		}
+
+		res = append(res, dieStats{
+			Name:                d.DieType.Name(),
+			Rolls:               d.Rolls,
+			Sides:               sides,
+			Mean:                fmt.Sprintf("%.2f", d.Mean),
+			ExpectedMean:        fmt.Sprintf("%.2f", d.ExpectedMean),
+			ChiSquare:           fmt.Sprintf("%.2f", d.ChiSquare),
+			DegreesOfFreedom:    d.DegreesOfFreedom,
+			PValue:              fmt.Sprintf("%.4f", d.PValue),
+			Verdict:             verdict,
+			LongestRepeatStreak: d.LongestRepeatStreak,
+			LongestHighStreak:   d.LongestHighStreak,
+			LongestLowStreak:    d.LongestLowStreak,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,185 @@
+package ui_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/r3labs/sse/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/deck/deckmock"
+	"github.com/rollify/rollify/internal/dice/dicemock"
+	"github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/macro/macromock"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
+	"github.com/rollify/rollify/internal/room"
+	"github.com/rollify/rollify/internal/room/roommock"
+	"github.com/rollify/rollify/internal/stats"
+	"github.com/rollify/rollify/internal/stats/statsmock"
+	"github.com/rollify/rollify/internal/user"
+	"github.com/rollify/rollify/internal/user/usermock"
+)
+
+func TestHandlerFullDiceStats(t *testing.T) {
+	type mocks struct {
+		mr *roommock.Service
+		mu *usermock.Service
+		ms *statsmock.Service
+	}
+
+	tests := map[string]struct {
+		request    func() *http.Request
+		mock       func(m mocks)
+		expBody    []string
+		expHeaders http.Header
+		expCode    int
+	}{
+		"Asking for the dice stats without user, should redirect to the login.": {
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/stats", nil)
+			},
+			mock: func(m mocks) {},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+				"Location":     {"/u/login/e02b402d-c23b-45b2-a5ea-583a566a9a6b"},
+			},
+			expCode: 307,
+			expBody: []string{},
+		},
+
+		"Asking for the dice stats of a room user, should return the page with the user stats by die type.": {
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/stats?user=user-id2", nil)
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				r1 := room.GetRoomRequest{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
+				m.mr.On("GetRoom", mock.Anything, r1).Once().Return(&room.GetRoomResponse{Room: model.Room{
+					ID:   "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					Name: "test",
+				}}, nil)
+
+				r2 := user.ListUsersRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", SessionToken: "user1"}
+				m.mu.On("ListUsers", mock.Anything, r2).Once().Return(&user.ListUsersResponse{
+					Users: []model.User{
+						{ID: "user-id1", Name: "user1"},
+						{ID: "user-id2", Name: "user2"},
+					},
+				}, nil)
+
+				r3 := stats.GetDiceStatsRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", UserID: "user-id2"}
+				m.ms.On("GetDiceStats", mock.Anything, r3).Once().Return(&stats.GetDiceStatsResponse{
+					DieTypes: []stats.DieTypeStats{
+						{
+							DieType:             model.DieTypeD2,
+							Rolls:               10,
+							Distribution:        []uint{10, 0},
+							Mean:                1,
+							ExpectedMean:        1.5,
+							ChiSquare:           10,
+							DegreesOfFreedom:    1,
+							PValue:              0.0015654,
+							EnoughRolls:         true,
+							LongestRepeatStreak: 10,
+							LongestLowStreak:    10,
+						},
+						{
+							DieType:             model.DieTypeD4,
+							Rolls:               4,
+							Distribution:        []uint{1, 1, 1, 1},
+							Mean:                2.5,
+							ExpectedMean:        2.5,
+							DegreesOfFreedom:    3,
+							PValue:              1,
+							LongestRepeatStreak: 1,
+							LongestHighStreak:   2,
+							LongestLowStreak:    1,
+						},
+					},
+				}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<a href="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b" role="button">Roll dice</a>`,                                // We have the roll dice button on the nav var.
+				`<li><a href="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/stats" class="secondary">Everyone</a></li>`,              // We can see the whole room stats.
+				`<li><a href="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/stats?user=user-id1" class="secondary">user1</a></li>`,   // We can see other user stats.
+				`<li><a href="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/stats?user=user-id2" aria-current="page">user2</a></li>`, // We have the selected user.
+				`<header><strong>D2</strong> (10 rolls): Suspicious</header>`,                                                       // We have the D2 verdict.
+				`<tr> <td>1</td> <td>10</td> <td><progress value="10" max="10"></progress> 100.0%</td> </tr>`,                       // We have the D2 side 1 distribution.
+				`<tr> <td>2</td> <td>0</td> <td><progress value="0" max="10"></progress> 0.0%</td> </tr>`,                           // We have the D2 side 2 distribution.
+				`<li>Chi-square: 10.00 (1 degrees of freedom, p-value 0.0016)</li>`,                                                 // We have the D2 chi-square test.
+				`<li>Longest streaks: 10 same side, 0 high, 10 low</li>`,                                                            // We have the D2 streaks.
+				`<header><strong>D4</strong> (4 rolls): Not enough rolls</header>`,                                                  // We have the D4 verdict.
+				`<li>Mean: 2.50 (expected 2.50)</li>`,                                                                               // We have the D4 mean.
+			},
+		},
+
+		"Asking for the dice stats of a user from another room, should fail.": {
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/stats?user=user-id9", nil)
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				m.mr.On("GetRoom", mock.Anything, mock.Anything).Once().Return(&room.GetRoomResponse{Room: model.Room{
+					ID:   "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					Name: "test",
+				}}, nil)
+				m.mu.On("ListUsers", mock.Anything, mock.Anything).Once().Return(&user.ListUsersResponse{}, nil)
+				m.ms.On("GetDiceStats", mock.Anything, mock.Anything).Once().Return(nil, internalerrors.ErrNotValid)
+			},
+			expHeaders: http.Header{},
+			expCode:    500,
+			expBody:    []string{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			m := mocks{
+				mr: &roommock.Service{},
+				mu: &usermock.Service{},
+				ms: &statsmock.Service{},
+			}
+			test.mock(m)
+
+			s := sse.New()
+			defer s.Close()
+			h, err := ui.New(ui.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       m.mr,
+				UserAppService:       mockSessionUsers(m.mu),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      m.ms,
+				SSEServer:            s,
+			})
+			require.NoError(err)
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.request())
+
+			assert.Equal(test.expCode, w.Code)
+			assert.Equal(test.expHeaders, w.Header())
+			assertContainsHTTPResponseBody(t, test.expBody, w)
+			m.ms.AssertExpectations(t)
+		})
+	}
+}
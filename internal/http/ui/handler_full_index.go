@@ -2,10 +2,12 @@ package ui
 
 import (
 	"net/http"
+
+	"github.com/rollify/rollify/internal/dice"
 )
 
 func (u ui) handlerFullIndex() http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		u.tplRenderer.RenderResponse(r.Context(), w, "index", tplDataCreateRoom{})
+		u.tplRenderer.RenderResponse(r.Context(), w, "index", tplDataCreateRoom{Rulesets: dice.Rulesets()})
 	})
 }
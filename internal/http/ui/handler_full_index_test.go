@@ -11,9 +11,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/rollify/rollify/internal/deck/deckmock"
 	"github.com/rollify/rollify/internal/dice/dicemock"
 	"github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
+	"github.com/rollify/rollify/internal/macro/macromock"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
 	"github.com/rollify/rollify/internal/room/roommock"
+	"github.com/rollify/rollify/internal/stats/statsmock"
 	"github.com/rollify/rollify/internal/user/usermock"
 )
 
@@ -57,8 +62,9 @@ func TestHanderFullIndex(t *testing.T) {
 			expBody: []string{
 				`<h1 id="index-title">The online dice roller for role players</h1>`,                                          // Make sure we are on the index.
 				`<form id="createRoomForm" hx-post="/u/create-room" hx-swap="outerHTML" hx-target="#createRoomFormSection">`, // Check HTMX call is in place.
-				`<div id="createRoomFormSection">`,                                                    // HTMX swap Target.
+				`<div id="createRoomFormSection">`, // HTMX swap Target.
 				`<input type="text" name="roomName" id="roomName" placeholder="Room name" required/>`, // Check The form has the important correct fields.
+				`<option value="pbta">Powered by the Apocalypse</option>`,                             // We have the game system rulesets.
 				`<nav class="container-fluid">`,                                                       // We have a nav bar.
 				`<footer class="container-fluid">`,                                                    // We have a footer.
 			},
@@ -80,10 +86,15 @@ func TestHanderFullIndex(t *testing.T) {
 			s := sse.New()
 			defer s.Close()
 			h, err := ui.New(ui.Config{
-				DiceAppService: m.md,
-				RoomAppService: m.mr,
-				UserAppService: m.mu,
-				SSEServer:      s,
+				DiceAppService:       m.md,
+				RoomAppService:       m.mr,
+				UserAppService:       mockSessionUsers(m.mu),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+				SSEServer:            s,
 			})
 			require.NoError(err)
 
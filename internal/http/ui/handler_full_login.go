@@ -7,35 +7,67 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/rollify/rollify/internal/model"
 	"github.com/rollify/rollify/internal/room"
-	"github.com/rollify/rollify/internal/user"
 )
 
-func (u ui) handlerFullLogin() http.HandlerFunc {
-	type tplData struct {
-		Users    []model.User
-		RoomName string
-	}
+const (
+	queryParamInviteToken  = "invite"
+	queryParamSessionToken = "session"
+)
+
+type tplDataLogin struct {
+	Users    []model.User
+	RoomName string
+	// Protected rooms need the password, unless the user has been invited with an invite token.
+	Protected   bool
+	InviteToken string
+	FormErrors  []string
+}
 
+func (u ui) handlerFullLogin() http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		roomID := chi.URLParam(r, urlParamRoomID)
 
-		// Get room name.
-		room, err := u.roomAppSvc.GetRoom(r.Context(), room.GetRoomRequest{ID: roomID})
+		// Users with a session link (e.g the game master login link) log in again with it, this doesn't
+		// depend on the room password, so the game masters of rooms without password can log in again.
+		us, err := u.authenticateSessionToken(r, roomID, r.URL.Query().Get(queryParamSessionToken))
 		if err != nil {
-			u.handleError(w, fmt.Errorf("could not get room: %w", err))
+			u.handleError(w, err)
+			return
+		}
+		if us != nil {
+			cookies.SetSession(w, roomID, r.URL.Query().Get(queryParamSessionToken), u.timeNow().Add(sessionDuration))
+			u.redirectToURL(w, r, u.servePrefix+"/room/"+roomID)
 			return
 		}
 
-		// List the available users.
-		mresp, err := u.userAppSvc.ListUsers(r.Context(), user.ListUsersRequest{RoomID: roomID})
+		d, err := u.loginTplData(r, roomID)
 		if err != nil {
-			u.handleError(w, fmt.Errorf("could not list users: %w", err))
+			u.handleError(w, err)
 			return
 		}
+		d.InviteToken = r.URL.Query().Get(queryParamInviteToken)
 
-		u.tplRenderer.withRoom(roomID).RenderResponse(r.Context(), w, "login", tplData{
-			Users:    mresp.Users,
-			RoomName: room.Room.Name,
-		})
+		u.tplRenderer.withRoom(roomID).RenderResponse(r.Context(), w, "login", d)
 	})
 }
+
+func (u ui) loginTplData(r *http.Request, roomID string) (*tplDataLogin, error) {
+	// Get room name.
+	room, err := u.roomAppSvc.GetRoom(r.Context(), room.GetRoomRequest{ID: roomID})
+	if err != nil {
+		return nil, fmt.Errorf("could not get room: %w", err)
+	}
+
+	d := &tplDataLogin{
+		RoomName:  room.Room.Name,
+		Protected: room.Room.Protected(),
+	}
+
+	// Logging in again as an existing user needs its session, so the only existing user that can
+	// be used is the one of the session.
+	if us := sessionUser(r); us != nil {
+		d.Users = []model.User{*us}
+	}
+
+	return d, nil
+}
@@ -4,22 +4,31 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/r3labs/sse/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/rollify/rollify/internal/deck/deckmock"
 	"github.com/rollify/rollify/internal/dice/dicemock"
 	"github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/macro/macromock"
 	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
 	"github.com/rollify/rollify/internal/room"
 	"github.com/rollify/rollify/internal/room/roommock"
+	"github.com/rollify/rollify/internal/stats/statsmock"
 	"github.com/rollify/rollify/internal/user"
 	"github.com/rollify/rollify/internal/user/usermock"
 )
 
 func TestHanderFullLogin(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "2023-01-21T11:05:45Z")
+
 	type mocks struct {
 		md *dicemock.Service
 		mr *roommock.Service
@@ -33,7 +42,7 @@ func TestHanderFullLogin(t *testing.T) {
 		expHeaders http.Header
 		expCode    int
 	}{
-		"Calling the login room without logged users should return the login template with no users.": {
+		"Calling the login room without session should return the login template with no users.": {
 			request: func() *http.Request {
 				return httptest.NewRequest(http.MethodGet, "/u/login/e02b402d-c23b-45b2-a5ea-583a566a9a6b", nil)
 			},
@@ -43,9 +52,6 @@ func TestHanderFullLogin(t *testing.T) {
 					ID:   "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
 					Name: "test1",
 				}}, nil)
-
-				rlu := user.ListUsersRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
-				m.mu.On("ListUsers", mock.Anything, rlu).Once().Return(&user.ListUsersResponse{Users: []model.User{}}, nil)
 			},
 			expHeaders: http.Header{
 				"Content-Type": {"text/html; charset=utf-8"},
@@ -60,10 +66,82 @@ func TestHanderFullLogin(t *testing.T) {
 			},
 		},
 
-		"Calling the login room with already logged users should return the login template with the current users loaded.": {
+		"Calling the login of a protected room should ask for the room password without listing the room users.": {
 			request: func() *http.Request {
 				return httptest.NewRequest(http.MethodGet, "/u/login/e02b402d-c23b-45b2-a5ea-583a566a9a6b", nil)
 			},
+			mock: func(m mocks) {
+				rgr := room.GetRoomRequest{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
+				m.mr.On("GetRoom", mock.Anything, rgr).Once().Return(&room.GetRoomResponse{Room: model.Room{
+					ID:           "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					Name:         "test1",
+					PasswordHash: "hash",
+				}}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<input type="password" name="password" id="password" placeholder="Password" required/>`, // We are asked for the password.
+			},
+		},
+
+		"Calling the login of a protected room with an invite should use the invite token instead of the password.": {
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/u/login/e02b402d-c23b-45b2-a5ea-583a566a9a6b?invite=token1", nil)
+			},
+			mock: func(m mocks) {
+				rgr := room.GetRoomRequest{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
+				m.mr.On("GetRoom", mock.Anything, rgr).Once().Return(&room.GetRoomResponse{Room: model.Room{
+					ID:           "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					Name:         "test1",
+					PasswordHash: "hash",
+				}}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<input type="hidden" name="inviteToken" value="token1"/>`, // We have the invite token.
+			},
+		},
+
+		"Calling the login of a protected room with the session of a room user should only list the session user.": {
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/u/login/e02b402d-c23b-45b2-a5ea-583a566a9a6b", nil)
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+				return req
+			},
+			mock: func(m mocks) {
+				rgr := room.GetRoomRequest{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
+				m.mr.On("GetRoom", mock.Anything, rgr).Once().Return(&room.GetRoomResponse{Room: model.Room{
+					ID:           "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					Name:         "test1",
+					PasswordHash: "hash",
+				}}, nil)
+
+				rau := user.AuthenticateUserRequest{SessionToken: "user1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
+				m.mu.On("AuthenticateUser", mock.Anything, rau).Once().Return(&user.AuthenticateUserResponse{User: model.User{
+					ID: "user1", Name: "User 1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+				}}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<select id="userID" name="userID"> <option value="" disabled selected>Select</option> <option value="user1">User 1</option> </select>`, // Existing users are selectable.
+			},
+		},
+
+		"Calling the login room with a session should return the login template with the session user, the rest of users need their session.": {
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/u/login/e02b402d-c23b-45b2-a5ea-583a566a9a6b", nil)
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+				return req
+			},
 			mock: func(m mocks) {
 				rgr := room.GetRoomRequest{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
 				m.mr.On("GetRoom", mock.Anything, rgr).Once().Return(&room.GetRoomResponse{Room: model.Room{
@@ -71,11 +149,9 @@ func TestHanderFullLogin(t *testing.T) {
 					Name: "test1",
 				}}, nil)
 
-				rlu := user.ListUsersRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
-				m.mu.On("ListUsers", mock.Anything, rlu).Once().Return(&user.ListUsersResponse{Users: []model.User{
-					{ID: "user1", Name: "User 1"},
-					{ID: "user2", Name: "User 2"},
-					{ID: "user3", Name: "User 3"},
+				rau := user.AuthenticateUserRequest{SessionToken: "user1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
+				m.mu.On("AuthenticateUser", mock.Anything, rau).Once().Return(&user.AuthenticateUserResponse{User: model.User{
+					ID: "user1", Name: "User 1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
 				}}, nil)
 			},
 			expHeaders: http.Header{
@@ -89,7 +165,49 @@ func TestHanderFullLogin(t *testing.T) {
 				`<nav class="container-fluid">`,    // We have a nav bar.
 				`<footer class="container-fluid">`, // We have a footer.
 				`<h4>Existing user</h4>`,           // We have existing users form part.
-				`<select id="userID" name="userID"> <option value="" disabled selected>Select</option> <option value="user1">User 1</option> <option value="user2">User 2</option> <option value="user3">User 3</option> </select>`, // Existing users are selectable.
+				`<select id="userID" name="userID"> <option value="" disabled selected>Select</option> <option value="user1">User 1</option> </select>`, // Only the session user is selectable.
+			},
+		},
+
+		"Calling the login with a session link should log in with the session and redirect to the room.": {
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/u/login/e02b402d-c23b-45b2-a5ea-583a566a9a6b?session=gm1", nil)
+			},
+			mock: func(m mocks) {
+				rau := user.AuthenticateUserRequest{SessionToken: "gm1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
+				m.mu.On("AuthenticateUser", mock.Anything, rau).Once().Return(&user.AuthenticateUserResponse{User: model.User{
+					ID: "gm1", Name: "GM 1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", GameMaster: true,
+				}}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+				"Location":     {"/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b"},
+				"Set-Cookie":   {"_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b=gm1; Path=/; Expires=Sat, 04 Feb 2023 11:05:45 GMT; HttpOnly; SameSite=Lax"},
+			},
+			expCode: 307,
+			expBody: []string{},
+		},
+
+		"Calling the login with an invalid session link should return the login template without logging in.": {
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/u/login/e02b402d-c23b-45b2-a5ea-583a566a9a6b?session=wrong", nil)
+			},
+			mock: func(m mocks) {
+				rau := user.AuthenticateUserRequest{SessionToken: "wrong", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
+				m.mu.On("AuthenticateUser", mock.Anything, rau).Once().Return(nil, internalerrors.ErrNotAllowed)
+
+				rgr := room.GetRoomRequest{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
+				m.mr.On("GetRoom", mock.Anything, rgr).Once().Return(&room.GetRoomResponse{Room: model.Room{
+					ID:   "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					Name: "test1",
+				}}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<h1>Log in room "test1" </h1>`, // We are on the login page.
 			},
 		},
 	}
@@ -109,10 +227,16 @@ func TestHanderFullLogin(t *testing.T) {
 			s := sse.New()
 			defer s.Close()
 			h, err := ui.New(ui.Config{
-				DiceAppService: m.md,
-				RoomAppService: m.mr,
-				UserAppService: m.mu,
-				SSEServer:      s,
+				DiceAppService:       m.md,
+				RoomAppService:       m.mr,
+				UserAppService:       mockSessionUsers(m.mu),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+				TimeNow:              func() time.Time { return t0.UTC() },
+				SSEServer:            s,
 			})
 			require.NoError(err)
 
@@ -122,6 +246,7 @@ func TestHanderFullLogin(t *testing.T) {
 			assert.Equal(test.expCode, w.Code)
 			assert.Equal(test.expHeaders, w.Header())
 			assertContainsHTTPResponseBody(t, test.expBody, w)
+			m.mu.AssertExpectations(t)
 		})
 	}
 }
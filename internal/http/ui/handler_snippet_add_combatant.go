@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rollify/rollify/internal/initiative"
+)
+
+const (
+	formFieldCombatantName       = "combatant-name"
+	formFieldCombatantInitiative = "combatant-initiative"
+	formFieldCombatantExpression = "combatant-expression"
+)
+
+func (u ui) handlerSnippetAddCombatant() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roomID := chi.URLParam(r, urlParamRoomID)
+		userID := sessionUserID(r)
+
+		req := initiative.AddCombatantRequest{
+			RoomID:     roomID,
+			UserID:     userID,
+			Name:       strings.TrimSpace(r.FormValue(formFieldCombatantName)),
+			Expression: strings.TrimSpace(r.FormValue(formFieldCombatantExpression)),
+		}
+
+		// Without name, the user adds itself to the combat.
+		if req.Name == "" {
+			req.CombatantUserID = userID
+		}
+
+		if i := r.FormValue(formFieldCombatantInitiative); i != "" && req.Expression == "" {
+			var err error
+			req.Initiative, err = strconv.Atoi(i)
+			if err != nil {
+				u.handleError(w, fmt.Errorf("invalid initiative: %w", err))
+				return
+			}
+		}
+
+		resp, err := u.initiativeAppSvc.AddCombatant(r.Context(), req)
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not add combatant: %w", err))
+			return
+		}
+
+		u.renderInitiativeTracker(w, r, roomID, resp.Initiative)
+	})
+}
@@ -0,0 +1,159 @@
+package ui_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/r3labs/sse/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/deck/deckmock"
+	"github.com/rollify/rollify/internal/dice/dicemock"
+	"github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
+	"github.com/rollify/rollify/internal/macro/macromock"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
+	"github.com/rollify/rollify/internal/stats/statsmock"
+	"github.com/rollify/rollify/internal/user/usermock"
+)
+
+func TestHandlerSnippetAddCombatant(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "2023-01-21T11:05:45Z")
+
+	newRequest := func(form url.Values) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/initiative/combatants", strings.NewReader(form.Encode()))
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+		return req
+	}
+
+	tests := map[string]struct {
+		request    func() *http.Request
+		mock       func(m *initiativemock.Service)
+		expBody    []string
+		expHeaders http.Header
+		expCode    int
+	}{
+		"Adding a named combatant with a fixed initiative should return the refreshed tracker as an HTML HTMX snippet.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("combatant-name", " Goblin ")
+				form.Add("combatant-initiative", "18")
+				return newRequest(form)
+			},
+			mock: func(m *initiativemock.Service) {
+				exp := initiative.AddCombatantRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", UserID: "user1", Name: "Goblin", Initiative: 18}
+				m.On("AddCombatant", mock.Anything, exp).Once().Return(&initiative.AddCombatantResponse{
+					Initiative: model.Initiative{
+						RoomID:     "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+						Round:      1,
+						Combatants: []model.InitiativeCombatant{{ID: "c1", Name: "Goblin", Initiative: 18}},
+					},
+				}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<div id="initiativeTracker">`,                              // We have the tracker.
+				`<summary>Initiative (round 1)</summary>`,                   // We have the round.
+				`<td><mark>Goblin</mark></td> <td><strong>18</strong></td>`, // We have the new combatant with the turn.
+			},
+		},
+
+		"Adding a combatant without name should add the user rolling the initiative.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("combatant-initiative", "18")
+				form.Add("combatant-expression", "1d20+2")
+				return newRequest(form)
+			},
+			mock: func(m *initiativemock.Service) {
+				exp := initiative.AddCombatantRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", UserID: "user1", CombatantUserID: "user1", Expression: "1d20+2"}
+				m.On("AddCombatant", mock.Anything, exp).Once().Return(&initiative.AddCombatantResponse{
+					Initiative: model.Initiative{
+						RoomID:     "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+						Round:      1,
+						Combatants: []model.InitiativeCombatant{{ID: "c1", Name: "Ragnar", UserID: "user1", Initiative: 15}},
+					},
+				}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<td><mark>Ragnar</mark></td> <td><strong>15</strong></td>`, // We have the user combatant.
+			},
+		},
+
+		"Adding a combatant with an invalid initiative should fail.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("combatant-name", "Goblin")
+				form.Add("combatant-initiative", "fast")
+				return newRequest(form)
+			},
+			mock:       func(m *initiativemock.Service) {},
+			expHeaders: http.Header{},
+			expCode:    500,
+		},
+
+		"Having an error while adding a combatant should fail.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("combatant-name", "Goblin")
+				return newRequest(form)
+			},
+			mock: func(m *initiativemock.Service) {
+				m.On("AddCombatant", mock.Anything, mock.Anything).Once().Return(nil, errors.New("wanted error"))
+			},
+			expHeaders: http.Header{},
+			expCode:    500,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			mi := &initiativemock.Service{}
+			test.mock(mi)
+
+			s := sse.New()
+			defer s.Close()
+			h, err := ui.New(ui.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       newOpenRoomAppService(),
+				UserAppService:       mockSessionUsers(&usermock.Service{}),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: mi,
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+				TimeNow:              func() time.Time { return t0.UTC() },
+				SSEServer:            s,
+			})
+			require.NoError(err)
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.request())
+
+			assert.Equal(test.expCode, w.Code)
+			assert.Equal(test.expHeaders, w.Header())
+			assertContainsHTTPResponseBody(t, test.expBody, w)
+			mi.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rollify/rollify/internal/deck"
+	"github.com/rollify/rollify/internal/model"
+)
+
+const (
+	formFieldDeckName  = "deck-name"
+	formFieldDeckType  = "deck-type"
+	formFieldDeckCards = "deck-cards"
+)
+
+func (u ui) handlerSnippetCreateDeck() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roomID := chi.URLParam(r, urlParamRoomID)
+		userID := sessionUserID(r)
+
+		req := deck.CreateDeckRequest{
+			RoomID: roomID,
+			UserID: userID,
+			Name:   strings.TrimSpace(r.FormValue(formFieldDeckName)),
+			Type:   model.DeckType(r.FormValue(formFieldDeckType)),
+		}
+
+		// Custom deck cards are comma separated.
+		if req.Type == model.DeckTypeCustom {
+			for _, c := range strings.Split(r.FormValue(formFieldDeckCards), ",") {
+				if c = strings.TrimSpace(c); c != "" {
+					req.Cards = append(req.Cards, c)
+				}
+			}
+		}
+
+		_, err := u.deckAppSvc.CreateDeck(r.Context(), req)
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not create deck: %w", err))
+			return
+		}
+
+		u.renderCardDecks(w, r, roomID)
+	})
+}
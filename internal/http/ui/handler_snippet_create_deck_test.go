@@ -0,0 +1,141 @@
+package ui_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/r3labs/sse/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/deck"
+	"github.com/rollify/rollify/internal/deck/deckmock"
+	"github.com/rollify/rollify/internal/dice/dicemock"
+	"github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
+	"github.com/rollify/rollify/internal/macro/macromock"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
+	"github.com/rollify/rollify/internal/stats/statsmock"
+	"github.com/rollify/rollify/internal/user/usermock"
+)
+
+func TestHandlerSnippetCreateDeck(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "2023-01-21T11:05:45Z")
+
+	newRequest := func(form url.Values) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/decks", strings.NewReader(form.Encode()))
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+		return req
+	}
+
+	tests := map[string]struct {
+		request    func() *http.Request
+		mock       func(m *deckmock.Service)
+		expBody    []string
+		expHeaders http.Header
+		expCode    int
+	}{
+		"Creating a standard deck should return the refreshed decks as an HTML HTMX snippet.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("deck-name", " Action deck ")
+				form.Add("deck-type", "standard-jokers")
+				form.Add("deck-cards", "ignored")
+				return newRequest(form)
+			},
+			mock: func(m *deckmock.Service) {
+				exp := deck.CreateDeckRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", UserID: "user1", Name: "Action deck", Type: model.DeckTypeStandardJokers}
+				m.On("CreateDeck", mock.Anything, exp).Once().Return(&deck.CreateDeckResponse{}, nil)
+				m.On("ListDecks", mock.Anything, deck.ListDecksRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}).Once().Return(&deck.ListDecksResponse{
+					Decks: []model.Deck{{ID: "deck1", Name: "Action deck", Cards: []string{"A♠", "2♠"}, DrawPile: []string{"2♠", "A♠"}}},
+				}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<div id="cardDecks">`, // We have the decks.
+				`<td><strong>Action deck</strong></td> <td>2 left, 0 discarded</td>`, // We have the new deck.
+				`<option value="standard">standard</option><option value="standard-jokers">standard-jokers</option><option value="tarot">tarot</option><option value="custom">custom</option>`, // We have the deck types.
+			},
+		},
+
+		"Creating a custom deck should use the comma separated cards.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("deck-name", "Fate")
+				form.Add("deck-type", "custom")
+				form.Add("deck-cards", "Sun, Moon,, Star ")
+				return newRequest(form)
+			},
+			mock: func(m *deckmock.Service) {
+				exp := deck.CreateDeckRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", UserID: "user1", Name: "Fate", Type: model.DeckTypeCustom, Cards: []string{"Sun", "Moon", "Star"}}
+				m.On("CreateDeck", mock.Anything, exp).Once().Return(&deck.CreateDeckResponse{}, nil)
+				m.On("ListDecks", mock.Anything, mock.Anything).Once().Return(&deck.ListDecksResponse{}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<div id="cardDecks">`, // We have the decks.
+			},
+		},
+
+		"Having an error while creating the deck should fail.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("deck-type", "standard")
+				return newRequest(form)
+			},
+			mock: func(m *deckmock.Service) {
+				m.On("CreateDeck", mock.Anything, mock.Anything).Once().Return(nil, errors.New("wanted error"))
+			},
+			expHeaders: http.Header{},
+			expCode:    500,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			mk := &deckmock.Service{}
+			test.mock(mk)
+
+			s := sse.New()
+			defer s.Close()
+			h, err := ui.New(ui.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       newOpenRoomAppService(),
+				UserAppService:       mockSessionUsers(&usermock.Service{}),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       mk,
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+				TimeNow:              func() time.Time { return t0.UTC() },
+				SSEServer:            s,
+			})
+			require.NoError(err)
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.request())
+
+			assert.Equal(test.expCode, w.Code)
+			assert.Equal(test.expHeaders, w.Header())
+			assertContainsHTTPResponseBody(t, test.expBody, w)
+			mk.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rollify/rollify/internal/room"
+)
+
+func (u ui) handlerSnippetCreateInviteToken() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roomID := chi.URLParam(r, urlParamRoomID)
+
+		// Only the game masters can invite users.
+		if !isRoomGameMaster(r, roomID) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		_, err := u.roomAppSvc.CreateInviteToken(r.Context(), room.CreateInviteTokenRequest{RoomID: roomID, UserID: sessionUserID(r)})
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not create invite token: %w", err))
+			return
+		}
+
+		u.renderRoomInviteTokens(w, r, roomID)
+	})
+}
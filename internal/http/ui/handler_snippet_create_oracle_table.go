@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rollify/rollify/internal/oracle"
+)
+
+const (
+	formFieldOracleTableName   = "oracle-table-name"
+	formFieldOracleTableDice   = "oracle-table-dice"
+	formFieldOracleTableFormat = "oracle-table-format"
+	formFieldOracleTableData   = "oracle-table-data"
+)
+
+func (u ui) handlerSnippetCreateOracleTable() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roomID := chi.URLParam(r, urlParamRoomID)
+		userID := sessionUserID(r)
+
+		_, err := u.oracleAppSvc.CreateTable(r.Context(), oracle.CreateTableRequest{
+			RoomID: roomID,
+			UserID: userID,
+			Name:   strings.TrimSpace(r.FormValue(formFieldOracleTableName)),
+			Dice:   strings.TrimSpace(r.FormValue(formFieldOracleTableDice)),
+			Format: oracle.TableFormat(r.FormValue(formFieldOracleTableFormat)),
+			Data:   r.FormValue(formFieldOracleTableData),
+		})
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not create oracle table: %w", err))
+			return
+		}
+
+		u.renderOracleTables(w, r, roomID)
+	})
+}
@@ -0,0 +1,127 @@
+package ui_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/r3labs/sse/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/deck/deckmock"
+	"github.com/rollify/rollify/internal/dice/dicemock"
+	"github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
+	"github.com/rollify/rollify/internal/macro/macromock"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
+	"github.com/rollify/rollify/internal/stats/statsmock"
+	"github.com/rollify/rollify/internal/user/usermock"
+)
+
+func TestHandlerSnippetCreateOracleTable(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "2023-01-21T11:05:45Z")
+
+	newRequest := func(form url.Values) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/oracle-tables", strings.NewReader(form.Encode()))
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+		return req
+	}
+
+	tests := map[string]struct {
+		request    func() *http.Request
+		mock       func(m *oraclemock.Service)
+		expBody    []string
+		expHeaders http.Header
+		expCode    int
+	}{
+		"Creating a table should return the refreshed tables as an HTML HTMX snippet.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("oracle-table-name", " Encounters ")
+				form.Add("oracle-table-dice", " 1d6 ")
+				form.Add("oracle-table-format", "csv")
+				form.Add("oracle-table-data", "1-3,Nothing\n4-6,Monsters!")
+				return newRequest(form)
+			},
+			mock: func(m *oraclemock.Service) {
+				exp := oracle.CreateTableRequest{
+					RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					UserID: "user1",
+					Name:   "Encounters",
+					Dice:   "1d6",
+					Format: oracle.TableFormatCSV,
+					Data:   "1-3,Nothing\n4-6,Monsters!",
+				}
+				m.On("CreateTable", mock.Anything, exp).Once().Return(&oracle.CreateTableResponse{}, nil)
+				m.On("ListTables", mock.Anything, oracle.ListTablesRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}).Once().Return(&oracle.ListTablesResponse{
+					Tables: []model.OracleTable{{ID: "table1", Name: "Encounters", Dice: "1d6", Entries: []model.OracleTableEntry{{Min: 1, Max: 3}, {Min: 4, Max: 6}}}},
+				}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<div id="oracleTables">`,                                            // We have the tables.
+				`<td><strong>Encounters</strong></td> <td>1d6, 2 entries</td>`,       // We have the new table.
+				`<option value="csv">csv</option><option value="yaml">yaml</option>`, // We have the table formats.
+			},
+		},
+
+		"Having an error while creating the table should fail.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("oracle-table-format", "csv")
+				return newRequest(form)
+			},
+			mock: func(m *oraclemock.Service) {
+				m.On("CreateTable", mock.Anything, mock.Anything).Once().Return(nil, errors.New("wanted error"))
+			},
+			expHeaders: http.Header{},
+			expCode:    500,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			mo := &oraclemock.Service{}
+			test.mock(mo)
+
+			s := sse.New()
+			defer s.Close()
+			h, err := ui.New(ui.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       newOpenRoomAppService(),
+				UserAppService:       mockSessionUsers(&usermock.Service{}),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     mo,
+				StatsAppService:      &statsmock.Service{},
+				TimeNow:              func() time.Time { return t0.UTC() },
+				SSEServer:            s,
+			})
+			require.NoError(err)
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.request())
+
+			assert.Equal(test.expCode, w.Code)
+			assert.Equal(test.expHeaders, w.Header())
+			assertContainsHTTPResponseBody(t, test.expBody, w)
+			mo.AssertExpectations(t)
+		})
+	}
+}
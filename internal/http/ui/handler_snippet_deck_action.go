@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rollify/rollify/internal/deck"
+)
+
+const (
+	deckActionDraw      = "draw"
+	deckActionDiscard   = "discard"
+	deckActionReshuffle = "reshuffle"
+)
+
+func (u ui) handlerSnippetDeckAction() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roomID := chi.URLParam(r, urlParamRoomID)
+		userID := sessionUserID(r)
+		deckID := chi.URLParam(r, urlParamDeckID)
+
+		switch chi.URLParam(r, urlParamDeckAction) {
+		case deckActionDraw:
+			_, err := u.deckAppSvc.DrawCards(r.Context(), deck.DrawCardsRequest{RoomID: roomID, UserID: userID, DeckID: deckID, Quantity: 1})
+			if err != nil {
+				u.handleError(w, fmt.Errorf("could not draw cards: %w", err))
+				return
+			}
+
+		// Discards all the cards in play.
+		case deckActionDiscard:
+			_, err := u.deckAppSvc.DiscardCards(r.Context(), deck.DiscardCardsRequest{RoomID: roomID, UserID: userID, DeckID: deckID})
+			if err != nil {
+				u.handleError(w, fmt.Errorf("could not discard cards: %w", err))
+				return
+			}
+
+		// Shuffles the discard pile back into the deck, the cards in play are kept.
+		case deckActionReshuffle:
+			_, err := u.deckAppSvc.ReshuffleDeck(r.Context(), deck.ReshuffleDeckRequest{RoomID: roomID, UserID: userID, DeckID: deckID})
+			if err != nil {
+				u.handleError(w, fmt.Errorf("could not reshuffle deck: %w", err))
+				return
+			}
+
+		default:
+			http.NotFound(w, r)
+			return
+		}
+
+		u.renderCardDecks(w, r, roomID)
+	})
+}
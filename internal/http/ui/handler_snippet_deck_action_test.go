@@ -0,0 +1,147 @@
+package ui_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/r3labs/sse/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/deck"
+	"github.com/rollify/rollify/internal/deck/deckmock"
+	"github.com/rollify/rollify/internal/dice/dicemock"
+	"github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
+	"github.com/rollify/rollify/internal/macro/macromock"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
+	"github.com/rollify/rollify/internal/stats/statsmock"
+	"github.com/rollify/rollify/internal/user/usermock"
+)
+
+func TestHandlerSnippetDeckAction(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "2023-01-21T11:05:45Z")
+
+	newRequest := func(action string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/decks/deck1/"+action, nil)
+		req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+		return req
+	}
+
+	listDecks := func(m *deckmock.Service) {
+		m.On("ListDecks", mock.Anything, deck.ListDecksRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}).Once().Return(&deck.ListDecksResponse{
+			Decks: []model.Deck{{ID: "deck1", Name: "Action deck", Cards: []string{"A♠", "2♠", "3♠"}, DrawPile: []string{"3♠"}, DiscardPile: []string{}}},
+		}, nil)
+	}
+
+	tests := map[string]struct {
+		request    func() *http.Request
+		mock       func(m *deckmock.Service)
+		expBody    []string
+		expHeaders http.Header
+		expCode    int
+	}{
+		"Drawing a card should return the refreshed decks as an HTML HTMX snippet.": {
+			request: func() *http.Request { return newRequest("draw") },
+			mock: func(m *deckmock.Service) {
+				exp := deck.DrawCardsRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", UserID: "user1", DeckID: "deck1", Quantity: 1}
+				m.On("DrawCards", mock.Anything, exp).Once().Return(&deck.DrawCardsResponse{}, nil)
+				listDecks(m)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<td><strong>Action deck</strong></td> <td>1 left, 0 discarded</td> <td><kbd>A♠</kbd> <kbd>2♠</kbd> </td>`, // We have the deck with the cards in play.
+			},
+		},
+
+		"Discarding should discard all the cards in play.": {
+			request: func() *http.Request { return newRequest("discard") },
+			mock: func(m *deckmock.Service) {
+				exp := deck.DiscardCardsRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", UserID: "user1", DeckID: "deck1"}
+				m.On("DiscardCards", mock.Anything, exp).Once().Return(&deck.DiscardCardsResponse{}, nil)
+				listDecks(m)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<div id="cardDecks">`, // We have the decks.
+			},
+		},
+
+		"Reshuffling should reshuffle the discard pile into the deck.": {
+			request: func() *http.Request { return newRequest("reshuffle") },
+			mock: func(m *deckmock.Service) {
+				exp := deck.ReshuffleDeckRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", UserID: "user1", DeckID: "deck1"}
+				m.On("ReshuffleDeck", mock.Anything, exp).Once().Return(&deck.ReshuffleDeckResponse{}, nil)
+				listDecks(m)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<div id="cardDecks">`, // We have the decks.
+			},
+		},
+
+		"Having an error while drawing should fail.": {
+			request: func() *http.Request { return newRequest("draw") },
+			mock: func(m *deckmock.Service) {
+				m.On("DrawCards", mock.Anything, mock.Anything).Once().Return(nil, errors.New("wanted error"))
+			},
+			expHeaders: http.Header{},
+			expCode:    500,
+		},
+
+		"Using an unknown deck action should return not found.": {
+			request:    func() *http.Request { return newRequest("burn") },
+			mock:       func(m *deckmock.Service) {},
+			expHeaders: http.Header{"Content-Type": {"text/plain; charset=utf-8"}, "X-Content-Type-Options": {"nosniff"}},
+			expCode:    404,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			mk := &deckmock.Service{}
+			test.mock(mk)
+
+			s := sse.New()
+			defer s.Close()
+			h, err := ui.New(ui.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       newOpenRoomAppService(),
+				UserAppService:       mockSessionUsers(&usermock.Service{}),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       mk,
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+				TimeNow:              func() time.Time { return t0.UTC() },
+				SSEServer:            s,
+			})
+			require.NoError(err)
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.request())
+
+			assert.Equal(test.expCode, w.Code)
+			assert.Equal(test.expHeaders, w.Header())
+			assertContainsHTTPResponseBody(t, test.expBody, w)
+			mk.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rollify/rollify/internal/dice"
+	"github.com/rollify/rollify/internal/internalerrors"
+)
+
+// maxProbabilityBars is the maximum number of bars of the probability chart, the
+// distributions with more totals are grouped in ranges of totals.
+const maxProbabilityBars = 40
+
+type probabilityBar struct {
+	Totals      string
+	Probability float64
+	Percent     string
+}
+
+func (u ui) handlerSnippetDiceProbabilities() http.HandlerFunc {
+	type tplData struct {
+		Exact            bool
+		Mean             string
+		HasCheck         bool
+		CheckProbability string
+		MaxProbability   float64
+		Bars             []probabilityBar
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roomID := chi.URLParam(r, urlParamRoomID)
+
+		err := r.ParseForm()
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not calculate probabilities: %w", err))
+			return
+		}
+
+		// The odds are calculated for the dice currently selected on the dice roller.
+		def, err := u.parseFormDiceRollDefinition(r, roomID)
+		if err != nil {
+			u.handleError(w, err)
+			return
+		}
+
+		res, err := u.diceAppSvc.CalculateProbabilities(r.Context(), dice.CalculateProbabilitiesRequest{
+			RoomID:     roomID,
+			UserID:     sessionUserID(r),
+			Dice:       def.Dice,
+			Expression: def.Expression,
+			Advantage:  def.Advantage,
+			Modifier:   def.Modifier,
+			Check:      def.Check,
+		})
+		if err != nil {
+			msg := ""
+			switch {
+			case errors.Is(err, internalerrors.ErrNotValid):
+				msg = "Select some dice or write a valid expression to see the odds."
+			case errors.Is(err, internalerrors.ErrRateLimited):
+				msg = "You are rolling dice too fast, wait a moment to see the odds."
+			default:
+				u.handleError(w, fmt.Errorf("could not calculate probabilities: %w", err))
+				return
+			}
+
+			u.logger.Warningf("HTTP handler error: %s", err)
+			u.tplRenderer.withRoom(roomID).
+				WithErrors([]string{msg}).
+				RenderResponse(r.Context(), w, "dice_probabilities", nil)
+			return
+		}
+
+		bars := newProbabilityBars(res.Distribution, maxProbabilityBars)
+		var maxP float64
+		for _, b := range bars {
+			maxP = max(maxP, b.Probability)
+		}
+
+		u.tplRenderer.withRoom(roomID).RenderResponse(r.Context(), w, "dice_probabilities", tplData{
+			Exact:            res.Distribution.Exact,
+			Mean:             fmt.Sprintf("%.2f", res.Distribution.Mean()),
+			HasCheck:         def.Check.Comparison != 0,
+			CheckProbability: fmt.Sprintf("%.1f", res.CheckProbability*100),
+			MaxProbability:   maxP,
+			Bars:             bars,
+		})
+	})
+}
+
+// newProbabilityBars returns the chart bars of the distribution, if the distribution has more totals
+// than the maximum bars, the totals are grouped in ranges with the same number of totals.
+func newProbabilityBars(d dice.Distribution, maxBars int) []probabilityBar {
+	if len(d.Totals) == 0 {
+		return nil
+	}
+
+	minTotal := d.Totals[0].Total
+	span := d.Totals[len(d.Totals)-1].Total - minTotal + 1
+	size := (span + maxBars - 1) / maxBars
+
+	bars := []probabilityBar{}
+	for _, t := range d.Totals {
+		i := (t.Total - minTotal) / size
+		for len(bars) <= i {
+			from := minTotal + len(bars)*size
+			label := fmt.Sprintf("%d", from)
+			if size > 1 {
+				label = fmt.Sprintf("%d to %d", from, from+size-1)
+			}
+			bars = append(bars, probabilityBar{Totals: label})
+		}
+		bars[i].Probability += t.Probability
+	}
+
+	for i := range bars {
+		bars[i].Percent = fmt.Sprintf("%.1f", bars[i].Probability*100)
+	}
+
+	return bars
+}
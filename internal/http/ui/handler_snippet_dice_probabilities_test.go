@@ -0,0 +1,210 @@
+package ui_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/r3labs/sse/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/deck/deckmock"
+	"github.com/rollify/rollify/internal/dice"
+	"github.com/rollify/rollify/internal/dice/dicemock"
+	"github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/macro/macromock"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
+	"github.com/rollify/rollify/internal/room/roommock"
+	"github.com/rollify/rollify/internal/stats/statsmock"
+	"github.com/rollify/rollify/internal/user/usermock"
+)
+
+func TestHandlerSnippetDiceProbabilities(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "2023-01-21T11:05:45Z")
+	type mocks struct {
+		md *dicemock.Service
+	}
+
+	newRequest := func(form url.Values) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/dice-probabilities", strings.NewReader(form.Encode()))
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+		return req
+	}
+
+	tests := map[string]struct {
+		request    func() *http.Request
+		mock       func(m mocks)
+		expBody    []string
+		expHeaders http.Header
+		expCode    int
+	}{
+		"Asking the odds of the selected dice should render the distribution chart as an HTML HTMX snippet.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("d4", "1")
+				form.Add("modifier", "1")
+				form.Add("check-target", "4")
+				form.Add("check-comparison", ">=")
+				return newRequest(form)
+			},
+			mock: func(m mocks) {
+				m.md.On("ListDiceTypes", mock.Anything, dice.ListDiceTypesRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}).Once().Return(&dice.ListDiceTypesResponse{DiceTypes: model.DiceTypes}, nil)
+				r := dice.CalculateProbabilitiesRequest{
+					RoomID:   "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					UserID:   "user1",
+					Dice:     []model.DieType{model.DieTypeD4},
+					Modifier: 1,
+					Check:    dice.DiceCheck{Target: 4, Comparison: model.DiceRollCheckComparisonGreaterOrEqual},
+				}
+				m.md.On("CalculateProbabilities", mock.Anything, r).Once().Return(&dice.CalculateProbabilitiesResponse{
+					Distribution: dice.Distribution{
+						Exact: true,
+						Totals: []dice.TotalProbability{
+							{Total: 2, Probability: 0.25},
+							{Total: 3, Probability: 0.25},
+							{Total: 4, Probability: 0.25},
+							{Total: 5, Probability: 0.25},
+						},
+					},
+					CheckProbability: 0.5,
+				}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/plain; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<article id="dice-probabilities">`,                                               // We have the odds panel.
+				`Mean: <strong>3.50</strong> , chance to pass the difficulty: <mark>50.0%</mark>`, // We have the mean and the check probability.
+				`<tr> <td>2</td> <td><progress value="0.25" max="0.25"></progress> 25.0%</td> </tr>`,
+				`<tr> <td>5</td> <td><progress value="0.25" max="0.25"></progress> 25.0%</td> </tr>`,
+			},
+		},
+
+		"Asking the odds of an expression with many totals should group the totals on the chart.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("expression", "1d80")
+				return newRequest(form)
+			},
+			mock: func(m mocks) {
+				totals := []dice.TotalProbability{}
+				for i := 1; i <= 80; i++ {
+					totals = append(totals, dice.TotalProbability{Total: i, Probability: 1.0 / 80.0})
+				}
+				r := dice.CalculateProbabilitiesRequest{
+					RoomID:     "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					UserID:     "user1",
+					Expression: "1d80",
+				}
+				m.md.On("CalculateProbabilities", mock.Anything, r).Once().Return(&dice.CalculateProbabilitiesResponse{
+					Distribution: dice.Distribution{Totals: totals},
+				}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/plain; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`Mean: <strong>40.50</strong> <small>(estimated)</small>`, // We have the mean of a simulated distribution.
+				`<tr> <td>1 to 2</td> <td><progress value="0.025" max="0.025"></progress> 2.5%</td> </tr>`,
+				`<tr> <td>79 to 80</td> <td><progress value="0.025" max="0.025"></progress> 2.5%</td> </tr>`,
+			},
+		},
+
+		"Asking the odds without dice should render a friendly error.": {
+			request: func() *http.Request {
+				return newRequest(url.Values{})
+			},
+			mock: func(m mocks) {
+				m.md.On("ListDiceTypes", mock.Anything, mock.Anything).Once().Return(&dice.ListDiceTypesResponse{DiceTypes: model.DiceTypes}, nil)
+				m.md.On("CalculateProbabilities", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("no dice: %w", internalerrors.ErrNotValid))
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/plain; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<li>Select some dice or write a valid expression to see the odds.</li>`,
+			},
+		},
+
+		"Asking the odds too fast should render a friendly error.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("expression", "2d6")
+				return newRequest(form)
+			},
+			mock: func(m mocks) {
+				m.md.On("CalculateProbabilities", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("too many: %w", internalerrors.ErrRateLimited))
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/plain; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<li>You are rolling dice too fast, wait a moment to see the odds.</li>`,
+			},
+		},
+
+		"Having an error while calculating the odds should fail.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("expression", "2d6")
+				return newRequest(form)
+			},
+			mock: func(m mocks) {
+				m.md.On("CalculateProbabilities", mock.Anything, mock.Anything).Once().Return(nil, errors.New("wanted error"))
+			},
+			expHeaders: http.Header{},
+			expCode:    500,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			m := mocks{
+				md: &dicemock.Service{},
+			}
+			test.mock(m)
+
+			s := sse.New()
+			defer s.Close()
+			h, err := ui.New(ui.Config{
+				DiceAppService:       m.md,
+				RoomAppService:       &roommock.Service{},
+				UserAppService:       mockSessionUsers(&usermock.Service{}),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+				TimeNow:              func() time.Time { return t0.UTC() },
+				SSEServer:            s,
+			})
+			require.NoError(err)
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.request())
+
+			assert.Equal(test.expCode, w.Code)
+			assert.Equal(test.expHeaders, w.Header())
+			assertContainsHTTPResponseBody(t, test.expBody, w)
+			m.md.AssertExpectations(t)
+		})
+	}
+}
@@ -19,7 +19,7 @@ func (u ui) handlerSnippetDiceRollHistoryMoreItems() http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		cursor := r.URL.Query().Get(queryParamCursor)
 		roomID := chi.URLParam(r, urlParamRoomID)
-		userID := cookies.GetUserID(r, roomID)
+		userID := sessionUserID(r)
 
 		// If not user ID, redirect to room selection.
 		if userID == "" {
@@ -28,15 +28,16 @@ func (u ui) handlerSnippetDiceRollHistoryMoreItems() http.HandlerFunc {
 		}
 
 		res, err := u.diceAppSvc.ListDiceRolls(r.Context(), dice.ListDiceRollsRequest{
-			RoomID:   roomID,
-			PageOpts: model.PaginationOpts{Cursor: cursor, Size: maxDiceResults},
+			RoomID:       roomID,
+			ViewerUserID: userID,
+			PageOpts:     model.PaginationOpts{Cursor: cursor, Size: maxDiceResults},
 		})
 		if err != nil {
 			u.handleError(w, fmt.Errorf("could not list dice rolls: %w", err))
 			return
 		}
 
-		roomUsers, err := u.userAppSvc.ListUsers(r.Context(), user.ListUsersRequest{RoomID: roomID})
+		roomUsers, err := u.userAppSvc.ListUsers(r.Context(), user.ListUsersRequest{RoomID: roomID, SessionToken: cookies.GetSession(r, roomID)})
 		if err != nil {
 			u.handleError(w, fmt.Errorf("could list room users: %w", err))
 			return
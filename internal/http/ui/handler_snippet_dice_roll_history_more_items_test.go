@@ -11,11 +11,16 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/rollify/rollify/internal/deck/deckmock"
 	"github.com/rollify/rollify/internal/dice"
 	"github.com/rollify/rollify/internal/dice/dicemock"
 	"github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
+	"github.com/rollify/rollify/internal/macro/macromock"
 	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
 	"github.com/rollify/rollify/internal/room/roommock"
+	"github.com/rollify/rollify/internal/stats/statsmock"
 	"github.com/rollify/rollify/internal/user"
 	"github.com/rollify/rollify/internal/user/usermock"
 )
@@ -38,14 +43,15 @@ func TestHandlerSnippetDiceRollHistoryMoreItems(t *testing.T) {
 		"Asking for more dice roll history items should return the HTML HTMX snippet and have pagination in place when there is a cursor.": {
 			request: func() *http.Request {
 				req := httptest.NewRequest(http.MethodGet, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/dice-roll-history/more-items?cursor=12345", nil)
-				req.AddCookie(&http.Cookie{Name: "_room_user_id_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
 
 				return req
 			},
 			mock: func(m mocks) {
 				r := dice.ListDiceRollsRequest{
-					RoomID:   "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
-					PageOpts: model.PaginationOpts{Cursor: "12345", Size: 10},
+					RoomID:       "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					ViewerUserID: "user1",
+					PageOpts:     model.PaginationOpts{Cursor: "12345", Size: 10},
 				}
 				m.md.On("ListDiceRolls", mock.Anything, r).Once().Return(&dice.ListDiceRollsResponse{
 					Cursors: model.PaginationCursors{
@@ -74,7 +80,7 @@ func TestHandlerSnippetDiceRollHistoryMoreItems(t *testing.T) {
 					},
 				}, nil)
 
-				r2 := user.ListUsersRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
+				r2 := user.ListUsersRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", SessionToken: "user1"}
 				m.mu.On("ListUsers", mock.Anything, r2).Once().Return(&user.ListUsersResponse{
 					Users: []model.User{
 						{ID: "user-id1", Name: "user1"},
@@ -87,9 +93,9 @@ func TestHandlerSnippetDiceRollHistoryMoreItems(t *testing.T) {
 			},
 			expCode: 200,
 			expBody: []string{
-				`<tr id="history-dice-roll-row"> <td> <div> <strong>user1</strong> </div> <div> <small class="timestamp-ago" unix-ts="1674299140"></small> </div> </td> <td> <kbd>1</kbd> <kbd>2</kbd> </td> <td> </td> <td> </td> <td> </td> <td> </td> <td> <kbd>3</kbd> </td> </tr>`,                                                            // We have the results of 1st Dice roll with the cursor and HTMX parts.                                                                                                                                                // We have the results of 1st Dice roll.
-				`<tr id="history-dice-roll-row"> <td> <div> <strong>user2</strong> </div> <div> <small class="timestamp-ago" unix-ts="1674299135"></small> </div> </td> <td> </td> <td> <kbd>4</kbd> </td> <td> </td> <td> <kbd>8</kbd> </td> <td> <kbd>11</kbd> </td> <td> </td> </tr>`,                                                           // We have the results of 2nd Dice roll with the cursor and HTMX parts.
-				`<tr id="history-dice-roll-more-button"> <td></td> <td></td> <td></td> <td> <a hx-get="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/dice-roll-history/more-items?cursor=cursor12345" hx-target="#history-dice-roll-more-button" hx-swap="outerHTML"> <strong>Load more...</strong> </a> </td> <td></td> <td></td> <td></td> </tr>`, // We have the pagination load more button.
+				`<tr id="history-dice-roll-row"> <td> <div> <strong>user1</strong> </div> <div> <small class="timestamp-ago" unix-ts="1674299140"></small> </div> </td> <td> <kbd>1</kbd> <kbd>2</kbd> </td> <td> </td> <td> </td> <td> </td> <td> </td> <td> <kbd>3</kbd> </td> <td> </td> </tr>`,                                                           // We have the results of 1st Dice roll with the cursor and HTMX parts.                                                                                                                                                // We have the results of 1st Dice roll.
+				`<tr id="history-dice-roll-row"> <td> <div> <strong>user2</strong> </div> <div> <small class="timestamp-ago" unix-ts="1674299135"></small> </div> </td> <td> </td> <td> <kbd>4</kbd> </td> <td> </td> <td> <kbd>8</kbd> </td> <td> <kbd>11</kbd> </td> <td> </td> <td> </td> </tr>`,                                                          // We have the results of 2nd Dice roll with the cursor and HTMX parts.
+				`<tr id="history-dice-roll-more-button"> <td></td> <td></td> <td></td> <td> <a hx-get="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/dice-roll-history/more-items?cursor=cursor12345" hx-target="#history-dice-roll-more-button" hx-swap="outerHTML"> <strong>Load more...</strong> </a> </td> <td></td> <td></td> <td></td> <td></td> </tr>`, // We have the pagination load more button.
 			},
 		},
 	}
@@ -109,11 +115,16 @@ func TestHandlerSnippetDiceRollHistoryMoreItems(t *testing.T) {
 			s := sse.New()
 			defer s.Close()
 			h, err := ui.New(ui.Config{
-				DiceAppService: m.md,
-				RoomAppService: m.mr,
-				UserAppService: m.mu,
-				TimeNow:        func() time.Time { return t0.UTC() },
-				SSEServer:      s,
+				DiceAppService:       m.md,
+				RoomAppService:       m.mr,
+				UserAppService:       mockSessionUsers(m.mu),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+				TimeNow:              func() time.Time { return t0.UTC() },
+				SSEServer:            s,
 			})
 			require.NoError(err)
 
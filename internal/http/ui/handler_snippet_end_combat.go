@@ -0,0 +1,26 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rollify/rollify/internal/initiative"
+	"github.com/rollify/rollify/internal/model"
+)
+
+func (u ui) handlerSnippetEndCombat() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roomID := chi.URLParam(r, urlParamRoomID)
+		userID := sessionUserID(r)
+
+		err := u.initiativeAppSvc.EndCombat(r.Context(), initiative.EndCombatRequest{RoomID: roomID, UserID: userID})
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not end combat: %w", err))
+			return
+		}
+
+		u.renderInitiativeTracker(w, r, roomID, model.Initiative{RoomID: roomID})
+	})
+}
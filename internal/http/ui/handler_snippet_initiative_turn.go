@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rollify/rollify/internal/initiative"
+	"github.com/rollify/rollify/internal/model"
+)
+
+const (
+	initiativeTurnNext     = "next"
+	initiativeTurnPrevious = "previous"
+)
+
+func (u ui) handlerSnippetInitiativeTurn() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roomID := chi.URLParam(r, urlParamRoomID)
+		userID := sessionUserID(r)
+
+		var i model.Initiative
+		switch chi.URLParam(r, urlParamInitiativeTurn) {
+		case initiativeTurnNext:
+			resp, err := u.initiativeAppSvc.NextTurn(r.Context(), initiative.NextTurnRequest{RoomID: roomID, UserID: userID})
+			if err != nil {
+				u.handleError(w, fmt.Errorf("could not move to the next turn: %w", err))
+				return
+			}
+			i = resp.Initiative
+
+		case initiativeTurnPrevious:
+			resp, err := u.initiativeAppSvc.PreviousTurn(r.Context(), initiative.PreviousTurnRequest{RoomID: roomID, UserID: userID})
+			if err != nil {
+				u.handleError(w, fmt.Errorf("could not move to the previous turn: %w", err))
+				return
+			}
+			i = resp.Initiative
+
+		default:
+			http.NotFound(w, r)
+			return
+		}
+
+		u.renderInitiativeTracker(w, r, roomID, i)
+	})
+}
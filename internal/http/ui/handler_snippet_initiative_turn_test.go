@@ -0,0 +1,134 @@
+package ui_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/r3labs/sse/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/deck/deckmock"
+	"github.com/rollify/rollify/internal/dice/dicemock"
+	"github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
+	"github.com/rollify/rollify/internal/macro/macromock"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
+	"github.com/rollify/rollify/internal/stats/statsmock"
+	"github.com/rollify/rollify/internal/user/usermock"
+)
+
+func TestHandlerSnippetInitiativeTurn(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "2023-01-21T11:05:45Z")
+
+	newRequest := func(turn string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/initiative/"+turn, nil)
+		req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+		return req
+	}
+
+	initiativeResp := model.Initiative{
+		RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+		Round:  3,
+		Turn:   1,
+		Combatants: []model.InitiativeCombatant{
+			{ID: "c1", Name: "Goblin", Initiative: 18},
+			{ID: "c2", Name: "Ragnar", UserID: "user1", Initiative: 12},
+		},
+	}
+
+	tests := map[string]struct {
+		request    func() *http.Request
+		mock       func(m *initiativemock.Service)
+		expBody    []string
+		expHeaders http.Header
+		expCode    int
+	}{
+		"Moving to the next turn should return the refreshed tracker as an HTML HTMX snippet.": {
+			request: func() *http.Request { return newRequest("next") },
+			mock: func(m *initiativemock.Service) {
+				exp := initiative.NextTurnRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", UserID: "user1"}
+				m.On("NextTurn", mock.Anything, exp).Once().Return(&initiative.NextTurnResponse{Initiative: initiativeResp}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<summary>Initiative (round 3)</summary>`,                   // We have the round.
+				`<td><mark>Ragnar</mark></td> <td><strong>12</strong></td>`, // We have the current turn.
+			},
+		},
+
+		"Moving to the previous turn should return the refreshed tracker as an HTML HTMX snippet.": {
+			request: func() *http.Request { return newRequest("previous") },
+			mock: func(m *initiativemock.Service) {
+				exp := initiative.PreviousTurnRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", UserID: "user1"}
+				m.On("PreviousTurn", mock.Anything, exp).Once().Return(&initiative.PreviousTurnResponse{Initiative: initiativeResp}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<td><mark>Ragnar</mark></td> <td><strong>12</strong></td>`, // We have the current turn.
+			},
+		},
+
+		"Having an error while moving the turn should fail.": {
+			request: func() *http.Request { return newRequest("previous") },
+			mock: func(m *initiativemock.Service) {
+				m.On("PreviousTurn", mock.Anything, mock.Anything).Once().Return(nil, errors.New("wanted error"))
+			},
+			expHeaders: http.Header{},
+			expCode:    500,
+		},
+
+		"Using an unknown turn action should return not found.": {
+			request:    func() *http.Request { return newRequest("skip") },
+			mock:       func(m *initiativemock.Service) {},
+			expHeaders: http.Header{"Content-Type": {"text/plain; charset=utf-8"}, "X-Content-Type-Options": {"nosniff"}},
+			expCode:    404,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			mi := &initiativemock.Service{}
+			test.mock(mi)
+
+			s := sse.New()
+			defer s.Close()
+			h, err := ui.New(ui.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       newOpenRoomAppService(),
+				UserAppService:       mockSessionUsers(&usermock.Service{}),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: mi,
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+				TimeNow:              func() time.Time { return t0.UTC() },
+				SSEServer:            s,
+			})
+			require.NoError(err)
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.request())
+
+			assert.Equal(test.expCode, w.Code)
+			assert.Equal(test.expHeaders, w.Header())
+			assertContainsHTTPResponseBody(t, test.expBody, w)
+			mi.AssertExpectations(t)
+		})
+	}
+}
@@ -1,29 +1,34 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/rollify/rollify/internal/dice"
+	"github.com/rollify/rollify/internal/internalerrors"
 	"github.com/rollify/rollify/internal/model"
 )
 
-type diceResult struct {
-	Dice    die
-	Results []uint
-}
+const (
+	formFieldCustomDieSides    = "custom-die-sides"
+	formFieldCustomDieQuantity = "custom-die-quantity"
+	formFieldModifier          = "modifier"
+	formFieldLabel             = "label"
+	formFieldVisibility        = "visibility"
+	formFieldAdvantage         = "advantage"
+	formFieldCheckTarget       = "check-target"
+	formFieldCheckComparison   = "check-comparison"
+)
 
 func (u ui) handlerSnippetNewDiceRoll() http.HandlerFunc {
-	type tplData struct {
-		DiceResult []diceResult
-	}
-
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		roomID := chi.URLParam(r, urlParamRoomID)
-		userID := cookies.GetUserID(r, roomID)
+		userID := sessionUserID(r)
 
 		// Get result from dice.
 		err := r.ParseForm()
@@ -32,58 +37,140 @@ func (u ui) handlerSnippetNewDiceRoll() http.HandlerFunc {
 			return
 		}
 
-		ds := []model.DieType{}
-		if q, err := strconv.Atoi(r.FormValue(dieD4.ID())); err == nil {
-			ds = addDice(ds, dieD4.DieType, q)
-		}
-		if q, err := strconv.Atoi(r.FormValue(dieD6.ID())); err == nil {
-			ds = addDice(ds, dieD6.DieType, q)
+		visibility, whisperUserIDs, err := parseFormVisibility(r.FormValue(formFieldVisibility))
+		if err != nil {
+			u.handleError(w, err)
+			return
 		}
-		if q, err := strconv.Atoi(r.FormValue(dieD8.ID())); err == nil {
-			ds = addDice(ds, dieD8.DieType, q)
+
+		req, err := u.parseFormDiceRollDefinition(r, roomID)
+		if err != nil {
+			u.handleError(w, err)
+			return
 		}
-		if q, err := strconv.Atoi(r.FormValue(dieD10.ID())); err == nil {
-			ds = addDice(ds, dieD10.DieType, q)
+		req.UserID = userID
+		req.RoomID = roomID
+		req.Label = strings.TrimSpace(r.FormValue(formFieldLabel))
+		req.Visibility = visibility
+		req.WhisperUserIDs = whisperUserIDs
+
+		res, err := u.diceAppSvc.CreateDiceRoll(r.Context(), req)
+		if err != nil {
+			u.handleDiceRollError(w, r, roomID, fmt.Errorf("could create dice roll: %w", err))
+			return
 		}
-		if q, err := strconv.Atoi(r.FormValue(dieD12.ID())); err == nil {
-			ds = addDice(ds, dieD12.DieType, q)
+
+		u.renderDiceRollResult(w, r, roomID, res.DiceRoll, nil)
+	})
+}
+
+// renderDiceRollResult renders the result of a dice roll made by the user on the dice roller, if a ruleset
+// is passed the dice roll outcome of the game system is shown.
+func (u ui) renderDiceRollResult(w http.ResponseWriter, r *http.Request, roomID string, dr model.DiceRoll, ruleset dice.Ruleset) {
+	type tplData struct {
+		DiceResult []diceResult
+		Label      string
+		Visibility string
+		Expression string
+		Modifier   int
+		Total      int
+		Check      *diceRollCheck
+		Outcome    string
+	}
+
+	// Bake result.
+	drs, others := groupDiceResults(dr.Dice, rollerDice)
+	drs = append(drs, others...)
+
+	outcome := ""
+	if ruleset != nil {
+		if o, ok := ruleset.Interpret(dr); ok {
+			outcome = o.Label
 		}
-		if q, err := strconv.Atoi(r.FormValue(dieD20.ID())); err == nil {
-			ds = addDice(ds, dieD20.DieType, q)
+	}
+
+	u.tplRenderer.withRoom(roomID).RenderResponse(r.Context(), w, "dice_roll_result", tplData{
+		DiceResult: drs,
+		Label:      dr.Label,
+		Visibility: visibilityText(dr),
+		Expression: dr.Expression,
+		Modifier:   dr.Modifier,
+		Total:      dr.Total,
+		Check:      newDiceRollCheck(dr.Check),
+		Outcome:    outcome,
+	})
+}
+
+// handleDiceRollError shows a friendly error on the dice roll result when the user is rolling dice too fast,
+// the rest of the errors are handled as regular errors.
+func (u ui) handleDiceRollError(w http.ResponseWriter, r *http.Request, roomID string, err error) {
+	if !errors.Is(err, internalerrors.ErrRateLimited) {
+		u.handleError(w, err)
+		return
+	}
+
+	u.logger.Warningf("HTTP handler error: %s", err)
+	u.tplRenderer.withRoom(roomID).
+		WithErrors([]string{"You are rolling dice too fast, wait a moment and roll again."}).
+		RenderResponse(r.Context(), w, "dice_roll_error", nil)
+}
+
+// parseFormDiceRollDefinition parses the dice roller form fields that define what is rolled (the selected
+// dice or the expression, and the difficulty check), an expression has priority over the dice selectors.
+func (u ui) parseFormDiceRollDefinition(r *http.Request, roomID string) (dice.CreateDiceRollRequest, error) {
+	check, err := parseFormCheck(r.FormValue(formFieldCheckTarget), r.FormValue(formFieldCheckComparison))
+	if err != nil {
+		return dice.CreateDiceRollRequest{}, err
+	}
+
+	req := dice.CreateDiceRollRequest{
+		Expression: strings.TrimSpace(r.FormValue("expression")),
+		Check:      check,
+	}
+	if req.Expression != "" {
+		return req, nil
+	}
+
+	ds := []model.DieType{}
+	for _, d := range rollerDice {
+		if q, err := strconv.Atoi(r.FormValue(d.ID())); err == nil {
+			ds = addDice(ds, d.DieType, q)
 		}
-		res, err := u.diceAppSvc.CreateDiceRoll(r.Context(), dice.CreateDiceRollRequest{
-			UserID: userID,
-			RoomID: roomID,
-			Dice:   ds,
-		})
+	}
+
+	// Custom dN dice.
+	if q, err := strconv.Atoi(r.FormValue(formFieldCustomDieQuantity)); err == nil {
+		sides, err := strconv.ParseUint(r.FormValue(formFieldCustomDieSides), 10, 0)
 		if err != nil {
-			u.handleError(w, fmt.Errorf("could create dice roll: %w", err))
-			return
+			return dice.CreateDiceRollRequest{}, fmt.Errorf("invalid custom die sides: %w", err)
 		}
 
-		// Bake result.
-		groupedResults := map[string][]uint{}
-		for _, res := range res.DiceRoll.Dice {
-			groupedResults[res.Type.ID()] = append(groupedResults[res.Type.ID()], res.Side)
+		dt, err := model.NewDieType(uint(sides))
+		if err != nil {
+			return dice.CreateDiceRollRequest{}, fmt.Errorf("invalid custom die: %w", err)
 		}
+		ds = addDice(ds, dt, q)
+	}
 
-		for _, v := range groupedResults {
-			slices.Sort(v)
+	// Room dice with faces.
+	dts, err := u.diceAppSvc.ListDiceTypes(r.Context(), dice.ListDiceTypesRequest{RoomID: roomID})
+	if err != nil {
+		return dice.CreateDiceRollRequest{}, fmt.Errorf("could not list room dice types: %w", err)
+	}
+	for _, d := range roomFacedDice(dts.DiceTypes) {
+		if q, err := strconv.Atoi(r.FormValue(d.ID())); err == nil {
+			ds = addDice(ds, d.DieType, q)
 		}
+	}
 
-		drs := []diceResult{
-			{Dice: dieD4, Results: groupedResults[dieD4.ID()]},
-			{Dice: dieD6, Results: groupedResults[dieD6.ID()]},
-			{Dice: dieD8, Results: groupedResults[dieD8.ID()]},
-			{Dice: dieD10, Results: groupedResults[dieD10.ID()]},
-			{Dice: dieD12, Results: groupedResults[dieD12.ID()]},
-			{Dice: dieD20, Results: groupedResults[dieD20.ID()]},
-		}
+	req.Dice = ds
+	req.Advantage = parseFormAdvantage(r.Form[formFieldAdvantage])
+	req.Modifier, err = parseFormModifier(r.FormValue(formFieldModifier))
+	if err != nil {
+		return dice.CreateDiceRollRequest{}, err
+	}
 
-		u.tplRenderer.withRoom(roomID).RenderResponse(r.Context(), w, "dice_roll_result", tplData{
-			DiceResult: drs,
-		})
-	})
+	return req, nil
 }
 
 func addDice(ds []model.DieType, t model.DieType, q int) []model.DieType {
@@ -92,3 +179,75 @@ func addDice(ds []model.DieType, t model.DieType, q int) []model.DieType {
 	}
 	return ds
 }
+
+// parseFormAdvantage parses the advantage form field toggles, like the game rules, having
+// advantage and disadvantage at the same time cancel each other.
+func parseFormAdvantage(vs []string) dice.AdvantageMode {
+	adv := slices.Contains(vs, "advantage")
+	disadv := slices.Contains(vs, "disadvantage")
+	switch {
+	case adv && !disadv:
+		return dice.AdvantageModeAdvantage
+	case disadv && !adv:
+		return dice.AdvantageModeDisadvantage
+	default:
+		return dice.AdvantageModeNone
+	}
+}
+
+// parseFormVisibility parses the visibility form field, the whispered dice rolls have the
+// whispered user ID in the value (e.g: `whisper:1234`).
+func parseFormVisibility(v string) (model.DiceRollVisibility, []string, error) {
+	switch {
+	case v == "" || v == "public":
+		return model.DiceRollVisibilityPublic, nil, nil
+	case v == "game_master":
+		return model.DiceRollVisibilityGameMaster, nil, nil
+	case strings.HasPrefix(v, "whisper:"):
+		return model.DiceRollVisibilityWhisper, []string{strings.TrimPrefix(v, "whisper:")}, nil
+	default:
+		return 0, nil, fmt.Errorf("invalid visibility: %s", v)
+	}
+}
+
+// parseFormModifier parses the flat modifier form field, empty means no modifier.
+func parseFormModifier(v string) (int, error) {
+	if v == "" {
+		return 0, nil
+	}
+
+	m, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid modifier: %w", err)
+	}
+
+	return m, nil
+}
+
+// parseFormCheck parses the difficulty check form fields, without target the dice roll is not a difficulty check.
+func parseFormCheck(target, comparison string) (dice.DiceCheck, error) {
+	if target == "" {
+		return dice.DiceCheck{}, nil
+	}
+
+	t, err := strconv.Atoi(target)
+	if err != nil {
+		return dice.DiceCheck{}, fmt.Errorf("invalid difficulty: %w", err)
+	}
+
+	res := dice.DiceCheck{Target: t}
+	switch comparison {
+	case "", ">=":
+		res.Comparison = model.DiceRollCheckComparisonGreaterOrEqual
+	case ">":
+		res.Comparison = model.DiceRollCheckComparisonGreater
+	case "<=":
+		res.Comparison = model.DiceRollCheckComparisonLessOrEqual
+	case "<":
+		res.Comparison = model.DiceRollCheckComparisonLess
+	default:
+		return dice.DiceCheck{}, fmt.Errorf("invalid difficulty comparison: %s", comparison)
+	}
+
+	return res, nil
+}
@@ -1,6 +1,7 @@
 package ui_test
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -13,11 +14,18 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/rollify/rollify/internal/deck/deckmock"
 	"github.com/rollify/rollify/internal/dice"
 	"github.com/rollify/rollify/internal/dice/dicemock"
 	"github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/macro/macromock"
 	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
+	"github.com/rollify/rollify/internal/room"
 	"github.com/rollify/rollify/internal/room/roommock"
+	"github.com/rollify/rollify/internal/stats/statsmock"
 	"github.com/rollify/rollify/internal/user/usermock"
 )
 
@@ -43,11 +51,12 @@ func TestHandlerSnippetNewDiceRoll(t *testing.T) {
 				form.Add("d20", "1")
 				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/new-dice-roll", strings.NewReader(form.Encode()))
 				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-				req.AddCookie(&http.Cookie{Name: "_room_user_id_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
 
 				return req
 			},
 			mock: func(m mocks) {
+				m.md.On("ListDiceTypes", mock.Anything, dice.ListDiceTypesRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}).Once().Return(&dice.ListDiceTypesResponse{DiceTypes: model.DiceTypes}, nil)
 				r := dice.CreateDiceRollRequest{UserID: "user1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", Dice: []model.DieType{
 					model.DieTypeD4,
 					model.DieTypeD4,
@@ -73,6 +82,401 @@ func TestHandlerSnippetNewDiceRoll(t *testing.T) {
 				`<tr> <td> <kbd>1</kbd> <kbd>2</kbd> </td> <td> <kbd>3</kbd> </td> </tr>`, // We have all dice roll results (sorted) as a table row.
 			},
 		},
+
+		"Creating a new dice roll too fast should render a friendly error as the dice roll result.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("d20", "1")
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/new-dice-roll", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				m.md.On("ListDiceTypes", mock.Anything, mock.Anything).Once().Return(&dice.ListDiceTypesResponse{DiceTypes: model.DiceTypes}, nil)
+				m.md.On("CreateDiceRoll", mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("too many dice rolls by the user: %w", internalerrors.ErrRateLimited))
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/plain; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<figure id="dice-roll-result">`,                                        // We have the dice roll result.
+				`<li>You are rolling dice too fast, wait a moment and roll again.</li>`, // We have the rate limit error.
+			},
+		},
+
+		"Creating a new dice roll with arbitrary sided dice should render all the dice roll results.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("d100", "1")
+				form.Add("custom-die-sides", "7")
+				form.Add("custom-die-quantity", "2")
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/new-dice-roll", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				m.md.On("ListDiceTypes", mock.Anything, dice.ListDiceTypesRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}).Once().Return(&dice.ListDiceTypesResponse{DiceTypes: model.DiceTypes}, nil)
+				d7, _ := model.NewDieType(7)
+				r := dice.CreateDiceRollRequest{UserID: "user1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", Dice: []model.DieType{
+					model.DieTypeD100,
+					d7,
+					d7,
+				}}
+				m.md.On("CreateDiceRoll", mock.Anything, r).Once().Return(&dice.CreateDiceRollResponse{DiceRoll: model.DiceRoll{
+					ID: "test1",
+					Dice: []model.DieRoll{
+						{ID: "1", Type: model.DieTypeD100, Side: 77},
+						{ID: "2", Type: d7, Side: 6},
+						{ID: "3", Type: d7, Side: 2},
+					},
+				}}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/plain; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<title>D100</title>`, // We have d100 table header title.
+				`<title>D7</title>`,   // We have the custom d7 table header title.
+				`<tr> <td> <kbd>77</kbd> </td> <td> <kbd>2</kbd> <kbd>6</kbd> </td> </tr>`, // We have all dice roll results (sorted) as a table row.
+			},
+		},
+
+		"Creating a new dice roll with room dice with faces should render the face labels.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("fate-id", "3")
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/new-dice-roll", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				fate := model.CustomDieType{
+					TypeID:    "fate-id",
+					TypeName:  "Fate",
+					FaceTable: []model.DieFace{{Label: "-", Value: -1}, {Label: "", Value: 0}, {Label: "+", Value: 1}},
+				}
+				m.md.On("ListDiceTypes", mock.Anything, dice.ListDiceTypesRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}).Once().Return(&dice.ListDiceTypesResponse{
+					DiceTypes: append(append([]model.DieType{}, model.DiceTypes...), fate),
+				}, nil)
+				r := dice.CreateDiceRollRequest{UserID: "user1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", Dice: []model.DieType{fate, fate, fate}}
+				m.md.On("CreateDiceRoll", mock.Anything, r).Once().Return(&dice.CreateDiceRollResponse{DiceRoll: model.DiceRoll{
+					ID: "test1",
+					Dice: []model.DieRoll{
+						{ID: "1", Type: fate, Side: 3},
+						{ID: "2", Type: fate, Side: 1},
+						{ID: "3", Type: fate, Side: 3},
+					},
+				}}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/plain; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<title>Fate</title>`, // We have the room custom die table header title.
+				`<tr> <td> <kbd>-</kbd> <kbd>+</kbd> <kbd>+</kbd> </td> </tr>`, // We have the face labels (sorted by side) as a table row.
+			},
+		},
+
+		"Creating a new dice roll with an invalid custom die should fail.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("custom-die-sides", "1")
+				form.Add("custom-die-quantity", "2")
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/new-dice-roll", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock:       func(m mocks) {},
+			expHeaders: http.Header{},
+			expCode:    500,
+		},
+
+		"Creating a new dice roll with an expression should render the dice roll with the expression total.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("d4", "2")
+				form.Add("expression", " 1d20+5 ")
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/new-dice-roll", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				r := dice.CreateDiceRollRequest{UserID: "user1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", Expression: "1d20+5"}
+				m.md.On("CreateDiceRoll", mock.Anything, r).Once().Return(&dice.CreateDiceRollResponse{DiceRoll: model.DiceRoll{
+					ID:         "test1",
+					Expression: "1d20+5",
+					Total:      22,
+					Dice: []model.DieRoll{
+						{ID: "1", Type: model.DieTypeD20, Side: 17},
+					},
+				}}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/plain; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<p><code>1d20+5</code> = <strong>22</strong></p>`, // We have the expression total.
+				`<title>D20</title>`,                  // We have d20 table header title.
+				`<tr> <td> <kbd>17</kbd> </td> </tr>`, // We have the dice roll results.
+			},
+		},
+
+		"Creating a new dice roll with a label and a modifier should render the dice roll with the label and the modifier total.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("d20", "1")
+				form.Add("modifier", "-2")
+				form.Add("label", " Stealth check ")
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/new-dice-roll", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				m.md.On("ListDiceTypes", mock.Anything, dice.ListDiceTypesRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}).Once().Return(&dice.ListDiceTypesResponse{DiceTypes: model.DiceTypes}, nil)
+				r := dice.CreateDiceRollRequest{UserID: "user1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", Dice: []model.DieType{model.DieTypeD20}, Label: "Stealth check", Modifier: -2}
+				m.md.On("CreateDiceRoll", mock.Anything, r).Once().Return(&dice.CreateDiceRollResponse{DiceRoll: model.DiceRoll{
+					ID:       "test1",
+					Label:    "Stealth check",
+					Modifier: -2,
+					Total:    15,
+					Dice: []model.DieRoll{
+						{ID: "1", Type: model.DieTypeD20, Side: 17},
+					},
+				}}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/plain; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<p><em>Stealth check</em></p>`,                // We have the label.
+				`<p><code>-2</code> = <strong>15</strong></p>`, // We have the modifier total.
+				`<tr> <td> <kbd>17</kbd> </td> </tr>`,          // We have the dice roll results.
+			},
+		},
+
+		"Creating a new dice roll with a difficulty should render the dice roll with the check outcome.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("expression", "1d20+5")
+				form.Add("check-target", "15")
+				form.Add("check-comparison", ">=")
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/new-dice-roll", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				r := dice.CreateDiceRollRequest{
+					UserID:     "user1",
+					RoomID:     "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					Expression: "1d20+5",
+					Check:      dice.DiceCheck{Target: 15, Comparison: model.DiceRollCheckComparisonGreaterOrEqual},
+				}
+				m.md.On("CreateDiceRoll", mock.Anything, r).Once().Return(&dice.CreateDiceRollResponse{DiceRoll: model.DiceRoll{
+					ID:         "test1",
+					Expression: "1d20+5",
+					Total:      12,
+					Check:      &model.DiceRollCheck{Target: 15, Comparison: model.DiceRollCheckComparisonGreaterOrEqual, Margin: -3, Outcome: model.DiceRollCheckOutcomeFailure},
+					Dice: []model.DieRoll{
+						{ID: "1", Type: model.DieTypeD20, Side: 7},
+					},
+				}}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/plain; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<p><code>>= 15</code>: <mark>Failure</mark> (-3)</p>`, // We have the check outcome.
+				`<tr> <td> <kbd>7</kbd> </td> </tr>`,                   // We have the dice roll results.
+			},
+		},
+
+		"Creating a new dice roll with an invalid difficulty should fail.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("d20", "1")
+				form.Add("check-target", "hard")
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/new-dice-roll", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock:       func(m mocks) {},
+			expHeaders: http.Header{},
+			expCode:    500,
+		},
+
+		"Creating a new dice roll with discarded dice should render the discarded dice struck-through.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("expression", "3d6kh2")
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/new-dice-roll", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				r := dice.CreateDiceRollRequest{UserID: "user1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", Expression: "3d6kh2"}
+				m.md.On("CreateDiceRoll", mock.Anything, r).Once().Return(&dice.CreateDiceRollResponse{DiceRoll: model.DiceRoll{
+					ID:         "test1",
+					Expression: "3d6kh2",
+					Total:      9,
+					Dice: []model.DieRoll{
+						{ID: "1", Type: model.DieTypeD6, Side: 5},
+						{ID: "2", Type: model.DieTypeD6, Side: 1, Status: model.DieRollStatusDropped},
+						{ID: "3", Type: model.DieTypeD6, Side: 4},
+					},
+				}}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/plain; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<tr> <td> <del><kbd>1</kbd></del> <kbd>4</kbd> <kbd>5</kbd> </td> </tr>`, // We have the discarded dice struck-through.
+			},
+		},
+
+		"Creating a new dice roll with advantage should render the dropped D20 struck-through.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("d20", "1")
+				form.Add("advantage", "advantage")
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/new-dice-roll", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				m.md.On("ListDiceTypes", mock.Anything, dice.ListDiceTypesRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}).Once().Return(&dice.ListDiceTypesResponse{DiceTypes: model.DiceTypes}, nil)
+				r := dice.CreateDiceRollRequest{UserID: "user1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", Dice: []model.DieType{model.DieTypeD20}, Advantage: dice.AdvantageModeAdvantage}
+				m.md.On("CreateDiceRoll", mock.Anything, r).Once().Return(&dice.CreateDiceRollResponse{DiceRoll: model.DiceRoll{
+					ID:    "test1",
+					Total: 14,
+					Dice: []model.DieRoll{
+						{ID: "1", Type: model.DieTypeD20, Side: 3, Status: model.DieRollStatusDropped},
+						{ID: "2", Type: model.DieTypeD20, Side: 14},
+					},
+				}}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/plain; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<tr> <td> <del><kbd>3</kbd></del> <kbd>14</kbd> </td> </tr>`, // We have the dropped D20 struck-through.
+			},
+		},
+
+		"Creating a new dice roll with advantage and disadvantage should cancel each other.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("d20", "1")
+				form.Add("advantage", "advantage")
+				form.Add("advantage", "disadvantage")
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/new-dice-roll", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				m.md.On("ListDiceTypes", mock.Anything, dice.ListDiceTypesRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}).Once().Return(&dice.ListDiceTypesResponse{DiceTypes: model.DiceTypes}, nil)
+				r := dice.CreateDiceRollRequest{UserID: "user1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", Dice: []model.DieType{model.DieTypeD20}}
+				m.md.On("CreateDiceRoll", mock.Anything, r).Once().Return(&dice.CreateDiceRollResponse{DiceRoll: model.DiceRoll{
+					ID:    "test1",
+					Total: 9,
+					Dice: []model.DieRoll{
+						{ID: "1", Type: model.DieTypeD20, Side: 9},
+					},
+				}}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/plain; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<tr> <td> <kbd>9</kbd> </td> </tr>`, // We have the D20 result.
+			},
+		},
+
+		"Creating a new whispered dice roll should create the dice roll only visible to the whispered user.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("expression", "1d20")
+				form.Add("visibility", "whisper:user2")
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/new-dice-roll", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				r := dice.CreateDiceRollRequest{
+					UserID:         "user1",
+					RoomID:         "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					Expression:     "1d20",
+					Visibility:     model.DiceRollVisibilityWhisper,
+					WhisperUserIDs: []string{"user2"},
+				}
+				m.md.On("CreateDiceRoll", mock.Anything, r).Once().Return(&dice.CreateDiceRollResponse{DiceRoll: model.DiceRoll{
+					ID:             "test1",
+					Expression:     "1d20",
+					Total:          17,
+					Visibility:     model.DiceRollVisibilityWhisper,
+					WhisperUserIDs: []string{"user2"},
+					Dice: []model.DieRoll{
+						{ID: "1", Type: model.DieTypeD20, Side: 17},
+					},
+				}}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/plain; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<p><small>(Whisper)</small></p>`,                // We have the visibility.
+				`<p><code>1d20</code> = <strong>17</strong></p>`, // We have the expression total.
+			},
+		},
+
+		"Creating a new dice roll with an invalid visibility should fail.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("expression", "1d20")
+				form.Add("visibility", "secret")
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/new-dice-roll", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock:       func(m mocks) {},
+			expHeaders: http.Header{},
+			expCode:    500,
+		},
 	}
 
 	for name, test := range tests {
@@ -86,15 +490,21 @@ func TestHandlerSnippetNewDiceRoll(t *testing.T) {
 				mu: &usermock.Service{},
 			}
 			test.mock(m)
+			m.mr.On("GetRoom", mock.Anything, mock.Anything).Maybe().Return(&room.GetRoomResponse{}, nil)
 
 			s := sse.New()
 			defer s.Close()
 			h, err := ui.New(ui.Config{
-				DiceAppService: m.md,
-				RoomAppService: m.mr,
-				UserAppService: m.mu,
-				TimeNow:        func() time.Time { return t0.UTC() },
-				SSEServer:      s,
+				DiceAppService:       m.md,
+				RoomAppService:       m.mr,
+				UserAppService:       mockSessionUsers(m.mu),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+				TimeNow:              func() time.Time { return t0.UTC() },
+				SSEServer:            s,
 			})
 			require.NoError(err)
 
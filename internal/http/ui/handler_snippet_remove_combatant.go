@@ -0,0 +1,29 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rollify/rollify/internal/initiative"
+)
+
+func (u ui) handlerSnippetRemoveCombatant() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roomID := chi.URLParam(r, urlParamRoomID)
+		userID := sessionUserID(r)
+
+		resp, err := u.initiativeAppSvc.RemoveCombatant(r.Context(), initiative.RemoveCombatantRequest{
+			RoomID:      roomID,
+			UserID:      userID,
+			CombatantID: chi.URLParam(r, urlParamCombatantID),
+		})
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not remove combatant: %w", err))
+			return
+		}
+
+		u.renderInitiativeTracker(w, r, roomID, resp.Initiative)
+	})
+}
@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/room"
+)
+
+func (u ui) handlerSnippetRevokeInviteToken() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roomID := chi.URLParam(r, urlParamRoomID)
+		token := chi.URLParam(r, urlParamInviteToken)
+
+		// Only the game masters can revoke the invitations.
+		if !isRoomGameMaster(r, roomID) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		// An unknown token has already been revoked, render the current ones.
+		err := u.roomAppSvc.RevokeInviteToken(r.Context(), room.RevokeInviteTokenRequest{RoomID: roomID, Token: token, UserID: sessionUserID(r)})
+		if err != nil && !errors.Is(err, internalerrors.ErrMissing) {
+			u.handleError(w, fmt.Errorf("could not revoke invite token: %w", err))
+			return
+		}
+
+		u.renderRoomInviteTokens(w, r, roomID)
+	})
+}
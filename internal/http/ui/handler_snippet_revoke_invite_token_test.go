@@ -0,0 +1,166 @@
+package ui_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/r3labs/sse/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/deck/deckmock"
+	"github.com/rollify/rollify/internal/dice/dicemock"
+	"github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/macro/macromock"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
+	"github.com/rollify/rollify/internal/room"
+	"github.com/rollify/rollify/internal/room/roommock"
+	"github.com/rollify/rollify/internal/stats/statsmock"
+	"github.com/rollify/rollify/internal/user"
+	"github.com/rollify/rollify/internal/user/usermock"
+)
+
+func TestHandlerSnippetRevokeInviteToken(t *testing.T) {
+	type mocks struct {
+		mr *roommock.Service
+		mu *usermock.Service
+	}
+
+	newRequest := func(withSession bool) func() *http.Request {
+		return func() *http.Request {
+			req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/invite-tokens/token2/revoke", nil)
+			req.Header.Add("HX-Request", "true")
+			if withSession {
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+			}
+			return req
+		}
+	}
+
+	authenticate := func(m mocks, gameMaster bool) {
+		m.mu.On("AuthenticateUser", mock.Anything, user.AuthenticateUserRequest{SessionToken: "user1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}).Once().Return(&user.AuthenticateUserResponse{
+			User: model.User{ID: "user1", RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", GameMaster: gameMaster},
+		}, nil)
+	}
+
+	getProtectedRoom := func(m mocks) {
+		m.mr.On("GetRoom", mock.Anything, room.GetRoomRequest{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}).Once().Return(&room.GetRoomResponse{Room: model.Room{
+			ID:           "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+			PasswordHash: "hash",
+			InviteTokens: []model.RoomInviteToken{
+				{Token: "token1", CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+			},
+		}}, nil)
+	}
+
+	tests := map[string]struct {
+		request    func() *http.Request
+		mock       func(m mocks)
+		expBody    []string
+		expHeaders http.Header
+		expCode    int
+	}{
+		"Revoking an invite token without session should be forbidden.": {
+			request:    newRequest(false),
+			mock:       func(m mocks) {},
+			expHeaders: http.Header{},
+			expCode:    403,
+			expBody:    []string{},
+		},
+
+		"Revoking an invite token without being a game master should be forbidden.": {
+			request:    newRequest(true),
+			mock:       func(m mocks) { authenticate(m, false) },
+			expHeaders: http.Header{},
+			expCode:    403,
+			expBody:    []string{},
+		},
+
+		"Having an error while revoking the invite token should fail.": {
+			request: newRequest(true),
+			mock: func(m mocks) {
+				authenticate(m, true)
+				m.mr.On("RevokeInviteToken", mock.Anything, mock.Anything).Once().Return(fmt.Errorf("something"))
+			},
+			expHeaders: http.Header{},
+			expCode:    500,
+			expBody:    []string{},
+		},
+
+		"Revoking an invite token as game master should render the remaining room invite links.": {
+			request: newRequest(true),
+			mock: func(m mocks) {
+				authenticate(m, true)
+				m.mr.On("RevokeInviteToken", mock.Anything, room.RevokeInviteTokenRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", Token: "token2", UserID: "user1"}).Once().Return(nil)
+				getProtectedRoom(m)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<td><a href="/u/login/e02b402d-c23b-45b2-a5ea-583a566a9a6b?invite=token1">Invite link</a></td> <td>2024-01-02 03:04</td>`, // The remaining invite links are rendered.
+			},
+		},
+
+		"Revoking an unknown invite token as game master should render the current room invite links.": {
+			request: newRequest(true),
+			mock: func(m mocks) {
+				authenticate(m, true)
+				m.mr.On("RevokeInviteToken", mock.Anything, room.RevokeInviteTokenRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", Token: "token2", UserID: "user1"}).Once().Return(internalerrors.ErrMissing)
+				getProtectedRoom(m)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<td><a href="/u/login/e02b402d-c23b-45b2-a5ea-583a566a9a6b?invite=token1">Invite link</a></td> <td>2024-01-02 03:04</td>`, // The current invite links are rendered.
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			m := mocks{
+				mr: &roommock.Service{},
+				mu: &usermock.Service{},
+			}
+			test.mock(m)
+
+			s := sse.New()
+			defer s.Close()
+			h, err := ui.New(ui.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       m.mr,
+				UserAppService:       mockSessionUsers(m.mu),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+				SSEServer:            s,
+			})
+			require.NoError(err)
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.request())
+
+			assert.Equal(test.expCode, w.Code)
+			assert.Equal(test.expHeaders, w.Header())
+			assertContainsHTTPResponseBody(t, test.expBody, w)
+			m.mr.AssertExpectations(t)
+			m.mu.AssertExpectations(t)
+		})
+	}
+}
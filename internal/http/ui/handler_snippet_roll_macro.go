@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rollify/rollify/internal/dice"
+	"github.com/rollify/rollify/internal/macro"
+)
+
+func (u ui) handlerSnippetRollMacro() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roomID := chi.URLParam(r, urlParamRoomID)
+		macroID := chi.URLParam(r, urlParamMacroID)
+		userID := sessionUserID(r)
+
+		err := r.ParseForm()
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not roll macro: %w", err))
+			return
+		}
+
+		// The macros are rolled with the visibility selected on the dice roller.
+		visibility, whisperUserIDs, err := parseFormVisibility(r.FormValue(formFieldVisibility))
+		if err != nil {
+			u.handleError(w, err)
+			return
+		}
+
+		m, err := u.macroAppSvc.GetMacro(r.Context(), macro.GetMacroRequest{MacroID: macroID, UserID: userID})
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not get macro: %w", err))
+			return
+		}
+
+		res, err := u.diceAppSvc.CreateDiceRoll(r.Context(), dice.CreateDiceRollRequest{
+			UserID:         userID,
+			RoomID:         roomID,
+			Expression:     m.Macro.Expression,
+			Label:          m.Macro.Name,
+			Visibility:     visibility,
+			WhisperUserIDs: whisperUserIDs,
+		})
+		if err != nil {
+			u.handleDiceRollError(w, r, roomID, fmt.Errorf("could create dice roll: %w", err))
+			return
+		}
+
+		u.renderDiceRollResult(w, r, roomID, res.DiceRoll, nil)
+	})
+}
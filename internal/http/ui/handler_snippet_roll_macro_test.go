@@ -0,0 +1,139 @@
+package ui_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/r3labs/sse/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/deck/deckmock"
+	"github.com/rollify/rollify/internal/dice"
+	"github.com/rollify/rollify/internal/dice/dicemock"
+	"github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
+	"github.com/rollify/rollify/internal/macro"
+	"github.com/rollify/rollify/internal/macro/macromock"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
+	"github.com/rollify/rollify/internal/stats/statsmock"
+	"github.com/rollify/rollify/internal/user/usermock"
+)
+
+func TestHandlerSnippetRollMacro(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "2023-01-21T11:05:45Z")
+	type mocks struct {
+		md *dicemock.Service
+		mm *macromock.Service
+	}
+
+	tests := map[string]struct {
+		request    func() *http.Request
+		mock       func(m mocks)
+		expBody    []string
+		expHeaders http.Header
+		expCode    int
+	}{
+		"Rolling a macro should roll the macro expression and return the result as an HTML HTMX snippet.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("visibility", "game_master")
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/macros/macro1/roll", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				m.mm.On("GetMacro", mock.Anything, macro.GetMacroRequest{MacroID: "macro1", UserID: "user1"}).Once().Return(&macro.GetMacroResponse{
+					Macro: model.Macro{ID: "macro1", Name: "Longsword", Expression: "1d20+5"},
+				}, nil)
+				r := dice.CreateDiceRollRequest{
+					UserID:     "user1",
+					RoomID:     "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					Expression: "1d20+5",
+					Label:      "Longsword",
+					Visibility: model.DiceRollVisibilityGameMaster,
+				}
+				m.md.On("CreateDiceRoll", mock.Anything, r).Once().Return(&dice.CreateDiceRollResponse{DiceRoll: model.DiceRoll{
+					ID:         "test1",
+					Expression: "1d20+5",
+					Label:      "Longsword",
+					Total:      17,
+					Visibility: model.DiceRollVisibilityGameMaster,
+					Dice: []model.DieRoll{
+						{ID: "1", Type: model.DieTypeD20, Side: 12},
+					},
+				}}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/plain; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<figure id="dice-roll-result">`,                   // We have the dice roll result.
+				`<p><small>(GM only)</small></p>`,                  // We have the selected visibility.
+				`<p><em>Longsword</em></p>`,                        // We have the macro name as the label.
+				`<p><code>1d20+5</code> = <strong>17</strong></p>`, // We have the macro expression total.
+				`<tr> <td> <kbd>12</kbd> </td> </tr>`,              // We have the dice roll results.
+			},
+		},
+
+		"Rolling a macro that can't be used by the user should fail.": {
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/macros/macro1/roll", nil)
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				m.mm.On("GetMacro", mock.Anything, mock.Anything).Once().Return(nil, errors.New("wanted error"))
+			},
+			expHeaders: http.Header{},
+			expCode:    500,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			m := mocks{
+				md: &dicemock.Service{},
+				mm: &macromock.Service{},
+			}
+			test.mock(m)
+
+			s := sse.New()
+			defer s.Close()
+			h, err := ui.New(ui.Config{
+				DiceAppService:       m.md,
+				RoomAppService:       newOpenRoomAppService(),
+				UserAppService:       mockSessionUsers(&usermock.Service{}),
+				MacroAppService:      m.mm,
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+				TimeNow:              func() time.Time { return t0.UTC() },
+				SSEServer:            s,
+			})
+			require.NoError(err)
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.request())
+
+			assert.Equal(test.expCode, w.Code)
+			assert.Equal(test.expHeaders, w.Header())
+			assertContainsHTTPResponseBody(t, test.expBody, w)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rollify/rollify/internal/oracle"
+)
+
+func (u ui) handlerSnippetRollOracleTable() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roomID := chi.URLParam(r, urlParamRoomID)
+		userID := sessionUserID(r)
+
+		// The results are pushed to all the room users (including this one) with the oracle table rolled events.
+		_, err := u.oracleAppSvc.RollTable(r.Context(), oracle.RollTableRequest{
+			RoomID:  roomID,
+			UserID:  userID,
+			TableID: chi.URLParam(r, urlParamOracleTableID),
+		})
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not roll oracle table: %w", err))
+			return
+		}
+
+		u.renderOracleTables(w, r, roomID)
+	})
+}
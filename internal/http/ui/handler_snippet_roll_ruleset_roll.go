@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rollify/rollify/internal/dice"
+	"github.com/rollify/rollify/internal/room"
+)
+
+func (u ui) handlerSnippetRollRulesetRoll() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roomID := chi.URLParam(r, urlParamRoomID)
+		rollID := chi.URLParam(r, urlParamRulesetRollID)
+		userID := sessionUserID(r)
+
+		err := r.ParseForm()
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not roll ruleset roll: %w", err))
+			return
+		}
+
+		// The preset rolls are rolled with the visibility, modifier and difficulty selected on the dice roller.
+		visibility, whisperUserIDs, err := parseFormVisibility(r.FormValue(formFieldVisibility))
+		if err != nil {
+			u.handleError(w, err)
+			return
+		}
+
+		modifier, err := parseFormModifier(r.FormValue(formFieldModifier))
+		if err != nil {
+			u.handleError(w, err)
+			return
+		}
+
+		check, err := parseFormCheck(r.FormValue(formFieldCheckTarget), r.FormValue(formFieldCheckComparison))
+		if err != nil {
+			u.handleError(w, err)
+			return
+		}
+
+		rm, err := u.roomAppSvc.GetRoom(r.Context(), room.GetRoomRequest{ID: roomID})
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not get room: %w", err))
+			return
+		}
+
+		ruleset, ok := dice.GetRuleset(rm.Room.Ruleset)
+		if !ok {
+			u.handleError(w, fmt.Errorf("room ruleset %q is not supported", rm.Room.Ruleset))
+			return
+		}
+
+		roll, ok := dice.GetRulesetRoll(ruleset, rollID)
+		if !ok {
+			u.handleError(w, fmt.Errorf("ruleset %q doesn't have roll %q", ruleset.ID(), rollID))
+			return
+		}
+
+		// The game system decides how the difficulty is compared (e.g: roll-under skills).
+		if check.Comparison != 0 && roll.CheckComparison != 0 {
+			check.Comparison = roll.CheckComparison
+		}
+
+		label := strings.TrimSpace(r.FormValue(formFieldLabel))
+		if label == "" {
+			label = roll.Name
+		}
+
+		res, err := u.diceAppSvc.CreateDiceRoll(r.Context(), dice.CreateDiceRollRequest{
+			UserID:         userID,
+			RoomID:         roomID,
+			Dice:           roll.Dice,
+			Modifiers:      roll.Modifiers,
+			Advantage:      roll.Advantage,
+			Modifier:       modifier,
+			Check:          check,
+			Label:          label,
+			Visibility:     visibility,
+			WhisperUserIDs: whisperUserIDs,
+		})
+		if err != nil {
+			u.handleDiceRollError(w, r, roomID, fmt.Errorf("could create dice roll: %w", err))
+			return
+		}
+
+		u.renderDiceRollResult(w, r, roomID, res.DiceRoll, ruleset)
+	})
+}
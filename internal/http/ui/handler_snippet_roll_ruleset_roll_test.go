@@ -0,0 +1,222 @@
+package ui_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/r3labs/sse/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/deck/deckmock"
+	"github.com/rollify/rollify/internal/dice"
+	"github.com/rollify/rollify/internal/dice/dicemock"
+	"github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
+	"github.com/rollify/rollify/internal/macro/macromock"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
+	"github.com/rollify/rollify/internal/room"
+	"github.com/rollify/rollify/internal/room/roommock"
+	"github.com/rollify/rollify/internal/stats/statsmock"
+	"github.com/rollify/rollify/internal/user/usermock"
+)
+
+func TestHandlerSnippetRollRulesetRoll(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "2023-01-21T11:05:45Z")
+	type mocks struct {
+		md *dicemock.Service
+		mr *roommock.Service
+	}
+
+	tests := map[string]struct {
+		request    func() *http.Request
+		mock       func(m mocks)
+		expBody    []string
+		expHeaders http.Header
+		expCode    int
+	}{
+		"Rolling a ruleset preset roll should roll the preset dice and return the result with the game system outcome as an HTML HTMX snippet.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("modifier", "2")
+				form.Add("visibility", "game_master")
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/ruleset-rolls/move/roll", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				m.mr.On("GetRoom", mock.Anything, room.GetRoomRequest{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}).Twice().Return(&room.GetRoomResponse{
+					Room: model.Room{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", Name: "test", Ruleset: "pbta"},
+				}, nil)
+				r := dice.CreateDiceRollRequest{
+					UserID:     "user1",
+					RoomID:     "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					Dice:       []model.DieType{model.DieTypeD6, model.DieTypeD6},
+					Modifier:   2,
+					Label:      "Move (2d6)",
+					Visibility: model.DiceRollVisibilityGameMaster,
+				}
+				m.md.On("CreateDiceRoll", mock.Anything, r).Once().Return(&dice.CreateDiceRollResponse{DiceRoll: model.DiceRoll{
+					ID:         "test1",
+					Label:      "Move (2d6)",
+					Modifier:   2,
+					Total:      8,
+					Visibility: model.DiceRollVisibilityGameMaster,
+					Dice: []model.DieRoll{
+						{ID: "1", Type: model.DieTypeD6, Side: 2},
+						{ID: "2", Type: model.DieTypeD6, Side: 4},
+					},
+				}}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/plain; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<figure id="dice-roll-result">`,                      // We have the dice roll result.
+				`<p><small>(GM only)</small></p>`,                     // We have the selected visibility.
+				`<p><em>Move (2d6)</em></p>`,                          // We have the preset roll name as the label.
+				`<p><code>+2</code> = <strong>8</strong></p>`,         // We have the modifier total.
+				`<p><mark><strong>Weak hit (7-9)</strong></mark></p>`, // We have the game system outcome.
+				`<tr> <td> <kbd>2</kbd> <kbd>4</kbd> </td> </tr>`,     // We have the dice roll results.
+			},
+		},
+
+		"Rolling a roll-under ruleset preset roll with a difficulty should use the game system comparison.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("label", "Spot Hidden")
+				form.Add("check-target", "60")
+				form.Add("check-comparison", ">=")
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/ruleset-rolls/skill/roll", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				m.mr.On("GetRoom", mock.Anything, mock.Anything).Twice().Return(&room.GetRoomResponse{
+					Room: model.Room{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", Name: "test", Ruleset: "coc"},
+				}, nil)
+				check := dice.DiceCheck{Target: 60, Comparison: model.DiceRollCheckComparisonLessOrEqual}
+				r := dice.CreateDiceRollRequest{
+					UserID:     "user1",
+					RoomID:     "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					Dice:       []model.DieType{model.DieTypeD100},
+					Check:      check,
+					Label:      "Spot Hidden",
+					Visibility: model.DiceRollVisibilityPublic,
+				}
+				m.md.On("CreateDiceRoll", mock.Anything, r).Once().Return(&dice.CreateDiceRollResponse{DiceRoll: model.DiceRoll{
+					ID:    "test1",
+					Label: "Spot Hidden",
+					Total: 23,
+					Check: &model.DiceRollCheck{Target: 60, Comparison: model.DiceRollCheckComparisonLessOrEqual, Margin: 37, Outcome: model.DiceRollCheckOutcomeSuccess},
+					Dice: []model.DieRoll{
+						{ID: "1", Type: model.DieTypeD100, Side: 23},
+					},
+				}}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/plain; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<p><em>Spot Hidden</em></p>`,                           // We have the label.
+				`<p><mark><strong>Hard success</strong></mark></p>`,     // We have the game system outcome.
+				`<p><code><= 60</code>: <mark>Success</mark> (+37)</p>`, // We have the roll-under difficulty check.
+			},
+		},
+
+		"Rolling a preset roll on a room without ruleset should fail.": {
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/ruleset-rolls/move/roll", nil)
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				m.mr.On("GetRoom", mock.Anything, mock.Anything).Twice().Return(&room.GetRoomResponse{
+					Room: model.Room{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", Name: "test"},
+				}, nil)
+			},
+			expHeaders: http.Header{},
+			expCode:    500,
+		},
+
+		"Rolling a preset roll missing on the room ruleset should fail.": {
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/ruleset-rolls/skill/roll", nil)
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				m.mr.On("GetRoom", mock.Anything, mock.Anything).Twice().Return(&room.GetRoomResponse{
+					Room: model.Room{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", Name: "test", Ruleset: "dnd5e"},
+				}, nil)
+			},
+			expHeaders: http.Header{},
+			expCode:    500,
+		},
+
+		"Having an error while getting the room should fail.": {
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/ruleset-rolls/move/roll", nil)
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m mocks) {
+				m.mr.On("GetRoom", mock.Anything, mock.Anything).Once().Return(nil, errors.New("wanted error"))
+			},
+			expHeaders: http.Header{},
+			expCode:    500,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			m := mocks{
+				md: &dicemock.Service{},
+				mr: &roommock.Service{},
+			}
+			test.mock(m)
+
+			s := sse.New()
+			defer s.Close()
+			h, err := ui.New(ui.Config{
+				DiceAppService:       m.md,
+				RoomAppService:       m.mr,
+				UserAppService:       mockSessionUsers(&usermock.Service{}),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+				TimeNow:              func() time.Time { return t0.UTC() },
+				SSEServer:            s,
+			})
+			require.NoError(err)
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.request())
+
+			assert.Equal(test.expCode, w.Code)
+			assert.Equal(test.expHeaders, w.Header())
+			assertContainsHTTPResponseBody(t, test.expBody, w)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rollify/rollify/internal/macro"
+	"github.com/rollify/rollify/internal/model"
+)
+
+const (
+	formFieldMacroName       = "macro-name"
+	formFieldMacroExpression = "macro-expression"
+	formFieldMacroShared     = "macro-shared"
+)
+
+func (u ui) handlerSnippetSaveMacro() http.HandlerFunc {
+	type tplData struct {
+		Macros []model.Macro
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roomID := chi.URLParam(r, urlParamRoomID)
+		userID := sessionUserID(r)
+
+		_, err := u.macroAppSvc.CreateMacro(r.Context(), macro.CreateMacroRequest{
+			RoomID:     roomID,
+			UserID:     userID,
+			Name:       strings.TrimSpace(r.FormValue(formFieldMacroName)),
+			Expression: strings.TrimSpace(r.FormValue(formFieldMacroExpression)),
+			Shared:     r.FormValue(formFieldMacroShared) != "",
+		})
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not save macro: %w", err))
+			return
+		}
+
+		// Refresh the macro bar with the new macro.
+		macros, err := u.macroAppSvc.ListMacros(r.Context(), macro.ListMacrosRequest{RoomID: roomID, UserID: userID})
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not list user macros: %w", err))
+			return
+		}
+
+		u.tplRenderer.withRoom(roomID).RenderResponse(r.Context(), w, "macro_bar", tplData{
+			Macros: macros.Macros,
+		})
+	})
+}
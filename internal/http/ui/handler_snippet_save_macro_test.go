@@ -0,0 +1,123 @@
+package ui_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/r3labs/sse/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/deck/deckmock"
+	"github.com/rollify/rollify/internal/dice/dicemock"
+	"github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
+	"github.com/rollify/rollify/internal/macro"
+	"github.com/rollify/rollify/internal/macro/macromock"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
+	"github.com/rollify/rollify/internal/stats/statsmock"
+	"github.com/rollify/rollify/internal/user/usermock"
+)
+
+func TestHandlerSnippetSaveMacro(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "2023-01-21T11:05:45Z")
+
+	tests := map[string]struct {
+		request    func() *http.Request
+		mock       func(m *macromock.Service)
+		expBody    []string
+		expHeaders http.Header
+		expCode    int
+	}{
+		"Saving a macro should save the macro and return the refreshed macro bar as an HTML HTMX snippet.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("macro-name", " Longsword ")
+				form.Add("macro-expression", "1d20+5")
+				form.Add("macro-shared", "on")
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/macros", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m *macromock.Service) {
+				exp := macro.CreateMacroRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", UserID: "user1", Name: "Longsword", Expression: "1d20+5", Shared: true}
+				m.On("CreateMacro", mock.Anything, exp).Once().Return(&macro.CreateMacroResponse{}, nil)
+				m.On("ListMacros", mock.Anything, macro.ListMacrosRequest{RoomID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b", UserID: "user1"}).Once().Return(&macro.ListMacrosResponse{
+					Macros: []model.Macro{
+						{ID: "macro1", Name: "Fireball", Expression: "8d6"},
+						{ID: "macro2", Name: "Longsword", Expression: "1d20+5", Shared: true},
+					},
+				}, nil)
+			},
+			expHeaders: http.Header{
+				"Content-Type": {"text/html; charset=utf-8"},
+			},
+			expCode: 200,
+			expBody: []string{
+				`<div id="macroBar">`, // We have the macro bar.
+				`<button class="outline" title="8d6" hx-post="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/macros/macro1/roll" hx-include="#visibility" hx-swap="innerHTML" hx-target="#diceRollResult">Fireball</button>`,     // We have the old macro.
+				`<button class="outline" title="1d20+5" hx-post="/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/macros/macro2/roll" hx-include="#visibility" hx-swap="innerHTML" hx-target="#diceRollResult">Longsword</button>`, // We have the new macro.
+			},
+		},
+
+		"Saving a not valid macro should fail.": {
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Add("macro-name", "Longsword")
+				form.Add("macro-expression", "1d20+")
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/macros", strings.NewReader(form.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+
+				return req
+			},
+			mock: func(m *macromock.Service) {
+				m.On("CreateMacro", mock.Anything, mock.Anything).Once().Return(nil, errors.New("wanted error"))
+			},
+			expHeaders: http.Header{},
+			expCode:    500,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			mm := &macromock.Service{}
+			test.mock(mm)
+
+			s := sse.New()
+			defer s.Close()
+			h, err := ui.New(ui.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       newOpenRoomAppService(),
+				UserAppService:       mockSessionUsers(&usermock.Service{}),
+				MacroAppService:      mm,
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+				TimeNow:              func() time.Time { return t0.UTC() },
+				SSEServer:            s,
+			})
+			require.NoError(err)
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.request())
+
+			assert.Equal(test.expCode, w.Code)
+			assert.Equal(test.expHeaders, w.Header())
+			assertContainsHTTPResponseBody(t, test.expBody, w)
+		})
+	}
+}
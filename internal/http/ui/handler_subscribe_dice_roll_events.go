@@ -2,13 +2,20 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 
 	"github.com/r3labs/sse/v2"
+	"github.com/rollify/rollify/internal/deck"
 	"github.com/rollify/rollify/internal/dice"
+	"github.com/rollify/rollify/internal/initiative"
+	"github.com/rollify/rollify/internal/internalerrors"
 	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle"
+	"github.com/rollify/rollify/internal/room"
 	"github.com/rollify/rollify/internal/user"
 )
 
@@ -17,28 +24,52 @@ const (
 	sseStreamPrefixNotification = "notification-" // Used when we only want to be notified without the transportation of all the rendered HTML over the wire..
 )
 
+// roomClosedTplData is the notice data sent to the room clients when the room is closed.
+type roomClosedTplData struct {
+	// Deleted is false when the room has been archived.
+	Deleted bool
+}
+
 func (u ui) handlerSubscribeDiceRollEvents() http.Handler {
 	type subcription struct {
-		appSubcriptionCancelFunc func() error
+		appSubcriptionCancelFunc           func() error
+		initiativeAppSubcriptionCancelFunc func() error
+		deckAppSubcriptionCancelFunc       func() error
+		oracleAppSubcriptionCancelFunc     func() error
+		roomAppSubcriptionCancelFunc       func() error
 	}
 
-	// TODO(slok): Make it concurrent.
-	subcriptionsCancelByRoomID := map[string]subcription{}
+	// The subscriptions are removed when the room is closed from the event handlers.
+	var mu sync.Mutex
+	subcriptionsCancelByStreamID := map[string]subcription{}
 
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Get room ID from the stream ID.
-		roomID := r.URL.Query().Get(queryParamSSEStream)
-		roomID = strings.TrimPrefix(roomID, sseStreamPrefixHTML)
-		roomID = strings.TrimPrefix(roomID, sseStreamPrefixNotification)
+	// unsubscribe stops the started subscriptions of the stream and removes its streams.
+	unsubscribe := func(subs subcription, streamID string) {
+		for _, cancel := range []func() error{
+			subs.appSubcriptionCancelFunc,
+			subs.initiativeAppSubcriptionCancelFunc,
+			subs.deckAppSubcriptionCancelFunc,
+			subs.oracleAppSubcriptionCancelFunc,
+			subs.roomAppSubcriptionCancelFunc,
+		} {
+			if cancel == nil {
+				continue
+			}
 
-		// If not room subscription already running, create one.
-		// TODO(slok): Stop subscription and delete when no connections left.
-		_, ok := subcriptionsCancelByRoomID[roomID]
-		if ok {
-			u.sseServer.ServeHTTP(w, r)
-			return
+			err := cancel()
+			if err != nil {
+				u.logger.Warningf("Error unsubscribing SSE from room events: %s", err)
+			}
 		}
 
+		// Removing the streams closes the clients connections.
+		u.sseServer.RemoveStream(sseStreamPrefixHTML + streamID)
+		u.sseServer.RemoveStream(sseStreamPrefixNotification + streamID)
+	}
+
+	// subscribe starts the room events subscriptions of the stream, if any of them fails, the
+	// already started ones are stopped.
+	subscribe := func(roomID, userID, streamID string) (subcription, error) {
 		// Prepare subscriptions.
 		subs := subcription{}
 
@@ -55,12 +86,13 @@ func (u ui) handlerSubscribeDiceRollEvents() http.Handler {
 		//	(e.g:  Add directly the HTML of the new dice roll, to the history).
 		// - Notifications stream: Will be used as a notification system on the client
 		// 	(e.g update a bubble that says the user that there are new rolls).
-		u.sseServer.CreateStream(sseStreamPrefixHTML + roomID)
-		u.sseServer.CreateStream(sseStreamPrefixNotification + roomID)
+		u.sseServer.CreateStream(sseStreamPrefixHTML + streamID)
+		u.sseServer.CreateStream(sseStreamPrefixNotification + streamID)
 
 		// Start dice rolls subscription.
 		modelReq := dice.SubscribeDiceRollCreatedRequest{
-			RoomID: roomID,
+			RoomID:       roomID,
+			ViewerUserID: userID,
 			EventHandler: func(ctx context.Context, e model.EventDiceRollCreated) error {
 				user, err := u.userAppSvc.GetUser(ctx, user.GetUserRequest{UserID: e.DiceRoll.UserID})
 				if err != nil {
@@ -74,11 +106,11 @@ func (u ui) handlerSubscribeDiceRollEvents() http.Handler {
 				rendered = strings.ReplaceAll(rendered, "\n", "") // https://github.com/r3labs/sse/issues/62.
 
 				// Send to HTML and notification streams.
-				u.sseServer.Publish(sseStreamPrefixHTML+roomID, &sse.Event{
+				u.sseServer.Publish(sseStreamPrefixHTML+streamID, &sse.Event{
 					Event: []byte("new_dice_roll"),
 					Data:  []byte(rendered),
 				})
-				u.sseServer.Publish(sseStreamPrefixNotification+roomID, &sse.Event{
+				u.sseServer.Publish(sseStreamPrefixNotification+streamID, &sse.Event{
 					Event: []byte("new_dice_roll"),
 					Data:  []byte(e.DiceRoll.ID),
 				})
@@ -88,13 +120,221 @@ func (u ui) handlerSubscribeDiceRollEvents() http.Handler {
 		}
 		modelResp, err := u.diceAppSvc.SubscribeDiceRollCreated(context.Background(), modelReq)
 		if err != nil {
-			u.logger.Warningf("Error subscribing SSE to dice roll created events: %s", err)
-			return
+			unsubscribe(subs, streamID)
+			return subcription{}, fmt.Errorf("could not subscribe to dice roll created events: %w", err)
 		}
 		subs.appSubcriptionCancelFunc = modelResp.UnsubscribeFunc
 
-		// Store subscriptions data.
-		subcriptionsCancelByRoomID[roomID] = subs
+		// Start initiative tracker subscription, the tracker is the same for all the room
+		// users, so it's only sent as HTML.
+		initiativeResp, err := u.initiativeAppSvc.SubscribeInitiativeUpdated(context.Background(), initiative.SubscribeInitiativeUpdatedRequest{
+			RoomID: roomID,
+			EventHandler: func(ctx context.Context, e model.EventInitiativeUpdated) error {
+				rendered, err := u.tplRenderer.withRoom(roomID).Render(ctx, "initiative_tracker", initiativeTrackerTplData{
+					Initiative: mapInitiativeToTplModel(e.Initiative),
+				})
+				if err != nil {
+					return fmt.Errorf("error rendering HTML: %w", err)
+				}
+				rendered = strings.ReplaceAll(rendered, "\n", "") // https://github.com/r3labs/sse/issues/62.
+
+				u.sseServer.Publish(sseStreamPrefixHTML+streamID, &sse.Event{
+					Event: []byte("initiative_updated"),
+					Data:  []byte(rendered),
+				})
+
+				return nil
+			},
+		})
+		if err != nil {
+			unsubscribe(subs, streamID)
+			return subcription{}, fmt.Errorf("could not subscribe to initiative updated events: %w", err)
+		}
+		subs.initiativeAppSubcriptionCancelFunc = initiativeResp.UnsubscribeFunc
+
+		// Start card draws subscription, the draws are public for all the room users.
+		deckResp, err := u.deckAppSvc.SubscribeCardsDrawn(context.Background(), deck.SubscribeCardsDrawnRequest{
+			RoomID: roomID,
+			EventHandler: func(ctx context.Context, e model.EventCardsDrawn) error {
+				user, err := u.userAppSvc.GetUser(ctx, user.GetUserRequest{UserID: e.CardDraw.UserID})
+				if err != nil {
+					return fmt.Errorf("error getting user: %w", err)
+				}
+
+				rendered, err := u.tplRenderer.withRoom(roomID).Render(ctx, "card_draw_push", cardDrawTplData{
+					UserName: user.User.Name,
+					DeckName: e.CardDraw.DeckName,
+					Cards:    e.CardDraw.Cards,
+				})
+				if err != nil {
+					return fmt.Errorf("error rendering HTML: %w", err)
+				}
+				rendered = strings.ReplaceAll(rendered, "\n", "") // https://github.com/r3labs/sse/issues/62.
+
+				u.sseServer.Publish(sseStreamPrefixHTML+streamID, &sse.Event{
+					Event: []byte("cards_drawn"),
+					Data:  []byte(rendered),
+				})
+
+				return nil
+			},
+		})
+		if err != nil {
+			unsubscribe(subs, streamID)
+			return subcription{}, fmt.Errorf("could not subscribe to cards drawn events: %w", err)
+		}
+		subs.deckAppSubcriptionCancelFunc = deckResp.UnsubscribeFunc
+
+		// Start oracle table rolls subscription, the rolls are public for all the room users.
+		oracleResp, err := u.oracleAppSvc.SubscribeOracleTableRolled(context.Background(), oracle.SubscribeOracleTableRolledRequest{
+			RoomID: roomID,
+			EventHandler: func(ctx context.Context, e model.EventOracleTableRolled) error {
+				user, err := u.userAppSvc.GetUser(ctx, user.GetUserRequest{UserID: e.OracleTableRoll.UserID})
+				if err != nil {
+					return fmt.Errorf("error getting user: %w", err)
+				}
+
+				rendered, err := u.tplRenderer.withRoom(roomID).Render(ctx, "oracle_table_roll_push", oracleTableRollTplData{
+					UserName: user.User.Name,
+					Results:  e.OracleTableRoll.Results,
+				})
+				if err != nil {
+					return fmt.Errorf("error rendering HTML: %w", err)
+				}
+				rendered = strings.ReplaceAll(rendered, "\n", "") // https://github.com/r3labs/sse/issues/62.
+
+				u.sseServer.Publish(sseStreamPrefixHTML+streamID, &sse.Event{
+					Event: []byte("oracle_table_rolled"),
+					Data:  []byte(rendered),
+				})
+
+				return nil
+			},
+		})
+		if err != nil {
+			unsubscribe(subs, streamID)
+			return subcription{}, fmt.Errorf("could not subscribe to oracle table rolled events: %w", err)
+		}
+		subs.oracleAppSubcriptionCancelFunc = oracleResp.UnsubscribeFunc
+
+		// Start room closed subscription, when the room is deleted or archived we notify the
+		// clients and remove all the user subscriptions and streams.
+		roomResp, err := u.roomAppSvc.SubscribeRoomClosed(context.Background(), room.SubscribeRoomClosedRequest{
+			RoomID: roomID,
+			EventHandler: func(ctx context.Context, e model.EventRoomClosed) error {
+				rendered, err := u.tplRenderer.withRoom(roomID).Render(ctx, "room_closed_push", roomClosedTplData{
+					Deleted: e.Deleted,
+				})
+				if err != nil {
+					return fmt.Errorf("error rendering HTML: %w", err)
+				}
+				rendered = strings.ReplaceAll(rendered, "\n", "") // https://github.com/r3labs/sse/issues/62.
+
+				u.sseServer.Publish(sseStreamPrefixHTML+streamID, &sse.Event{
+					Event: []byte("room_closed"),
+					Data:  []byte(rendered),
+				})
+
+				mu.Lock()
+				subs, ok := subcriptionsCancelByStreamID[streamID]
+				delete(subcriptionsCancelByStreamID, streamID)
+				mu.Unlock()
+				if !ok {
+					return nil
+				}
+
+				unsubscribe(subs, streamID)
+
+				return nil
+			},
+		})
+		if err != nil {
+			unsubscribe(subs, streamID)
+			return subcription{}, fmt.Errorf("could not subscribe to room closed events: %w", err)
+		}
+		subs.roomAppSubcriptionCancelFunc = roomResp.UnsubscribeFunc
+
+		return subs, nil
+
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Get room ID from the stream ID.
+		query := r.URL.Query()
+		stream := query.Get(queryParamSSEStream)
+		roomID := strings.TrimPrefix(stream, sseStreamPrefixHTML)
+		roomID = strings.TrimPrefix(roomID, sseStreamPrefixNotification)
+		prefix := strings.TrimSuffix(stream, roomID)
+
+		// The dice rolls are hidden depending on the user that sees them, so each user of the
+		// room has its own streams.
+		us, err := u.authenticateSession(r, roomID)
+		if err != nil {
+			u.handleError(w, err)
+			return
+		}
+		userID := ""
+		if us != nil {
+			userID = us.ID
+		}
+
+		// The events of the protected rooms can only be received by the room users.
+		if us == nil {
+			rm, err := u.roomAppSvc.GetRoom(r.Context(), room.GetRoomRequest{ID: roomID})
+			if err != nil && !errors.Is(err, internalerrors.ErrMissing) {
+				u.handleError(w, fmt.Errorf("could not get room: %w", err))
+				return
+			}
+			if err == nil && rm.Room.Protected() {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+		}
+
+		streamID := roomID + "-" + userID
+		query.Set(queryParamSSEStream, prefix+streamID)
+		r.URL.RawQuery = query.Encode()
+
+		// If not user subscription already running, create one. The check and the creation are done holding
+		// the lock, so concurrent connections of the same user don't start duplicated subscriptions.
+		// TODO(slok): Stop subscription and delete when no connections left.
+		roomClosed, err := func() (bool, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if _, ok := subcriptionsCancelByStreamID[streamID]; ok {
+				return false, nil
+			}
+
+			// Deleted and archived rooms don't have events.
+			rm, err := u.roomAppSvc.GetRoom(r.Context(), room.GetRoomRequest{ID: roomID})
+			if err != nil && !errors.Is(err, internalerrors.ErrMissing) {
+				return false, fmt.Errorf("could not get room: %w", err)
+			}
+			if err != nil || rm.Room.Archived {
+				return true, nil
+			}
+
+			subs, err := subscribe(roomID, userID, streamID)
+			if err != nil {
+				return false, err
+			}
+
+			// Store subscriptions data.
+			subcriptionsCancelByStreamID[streamID] = subs
+
+			return false, nil
+		}()
+		if err != nil {
+			u.handleError(w, err)
+			return
+		}
+
+		// A `204` stops the clients reconnections.
+		if roomClosed {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
 
 		// Continue as always.
 		u.sseServer.ServeHTTP(w, r)
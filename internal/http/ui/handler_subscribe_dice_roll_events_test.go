@@ -1,6 +1,7 @@
 package ui_test
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -8,11 +9,22 @@ import (
 
 	"github.com/r3labs/sse/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/rollify/rollify/internal/deck/deckmock"
+	"github.com/rollify/rollify/internal/dice"
 	"github.com/rollify/rollify/internal/dice/dicemock"
 	"github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/macro/macromock"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
+	"github.com/rollify/rollify/internal/room"
 	"github.com/rollify/rollify/internal/room/roommock"
+	"github.com/rollify/rollify/internal/stats/statsmock"
 	"github.com/rollify/rollify/internal/user/usermock"
 )
 
@@ -22,16 +34,88 @@ func TestHandlerSubscribeDiceRollEvents(t *testing.T) {
 		md *dicemock.Service
 		mr *roommock.Service
 		mu *usermock.Service
+		mi *initiativemock.Service
+		// unsubscribed is set when the mocked subscriptions are stopped.
+		unsubscribed *bool
 	}
 
 	tests := map[string]struct {
-		request    func() *http.Request
-		mock       func(m mocks)
-		expBody    string
-		expHeaders http.Header
-		expCode    int
+		request         func() *http.Request
+		mock            func(m mocks)
+		expBody         string
+		expHeaders      http.Header
+		expCode         int
+		expUnsubscribed bool
 	}{
-		// TODO.
+		"Subscribing to an archived room should stop the client reconnections.": {
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/u/subscribe/room/dice-roll-history?stream=html-e02b402d-c23b-45b2-a5ea-583a566a9a6b", nil)
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+				return req
+			},
+			mock: func(m mocks) {
+				r := room.GetRoomRequest{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
+				m.mr.On("GetRoom", mock.Anything, r).Once().Return(&room.GetRoomResponse{Room: model.Room{
+					ID:       "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					Archived: true,
+				}}, nil)
+			},
+			expHeaders: http.Header{},
+			expCode:    204,
+		},
+
+		"Subscribing to a protected room without session should be forbidden.": {
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/u/subscribe/room/dice-roll-history?stream=html-e02b402d-c23b-45b2-a5ea-583a566a9a6b", nil)
+			},
+			mock: func(m mocks) {
+				r := room.GetRoomRequest{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
+				m.mr.On("GetRoom", mock.Anything, r).Once().Return(&room.GetRoomResponse{Room: model.Room{
+					ID:           "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					PasswordHash: "hash",
+				}}, nil)
+			},
+			expHeaders: http.Header{},
+			expCode:    403,
+		},
+
+		"Subscribing to a deleted room should stop the client reconnections.": {
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/u/subscribe/room/dice-roll-history?stream=notification-e02b402d-c23b-45b2-a5ea-583a566a9a6b", nil)
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+				return req
+			},
+			mock: func(m mocks) {
+				r := room.GetRoomRequest{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
+				m.mr.On("GetRoom", mock.Anything, r).Once().Return(nil, internalerrors.ErrMissing)
+			},
+			expHeaders: http.Header{},
+			expCode:    204,
+		},
+
+		"Having an error while subscribing to the room events should stop the already started subscriptions.": {
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/u/subscribe/room/dice-roll-history?stream=html-e02b402d-c23b-45b2-a5ea-583a566a9a6b", nil)
+				req.AddCookie(&http.Cookie{Name: "_room_session_e02b402d-c23b-45b2-a5ea-583a566a9a6b", Value: "user1", MaxAge: 999999999999})
+				return req
+			},
+			mock: func(m mocks) {
+				r := room.GetRoomRequest{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
+				m.mr.On("GetRoom", mock.Anything, r).Once().Return(&room.GetRoomResponse{Room: model.Room{
+					ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+				}}, nil)
+				m.md.On("SubscribeDiceRollCreated", mock.Anything, mock.Anything).Once().Return(&dice.SubscribeDiceRollCreatedResponse{
+					UnsubscribeFunc: func() error {
+						*m.unsubscribed = true
+						return nil
+					},
+				}, nil)
+				m.mi.On("SubscribeInitiativeUpdated", mock.Anything, mock.Anything).Once().Return((*initiative.SubscribeInitiativeUpdatedResponse)(nil), errors.New("something"))
+			},
+			expHeaders:      http.Header{},
+			expCode:         500,
+			expUnsubscribed: true,
+		},
 	}
 
 	for name, test := range tests {
@@ -43,17 +127,25 @@ func TestHandlerSubscribeDiceRollEvents(t *testing.T) {
 				md: &dicemock.Service{},
 				mr: &roommock.Service{},
 				mu: &usermock.Service{},
+				mi: &initiativemock.Service{},
+
+				unsubscribed: new(bool),
 			}
 			test.mock(m)
 
 			s := sse.New()
 			defer s.Close()
 			h, err := ui.New(ui.Config{
-				DiceAppService: m.md,
-				RoomAppService: m.mr,
-				UserAppService: m.mu,
-				TimeNow:        func() time.Time { return t0.UTC() },
-				SSEServer:      s,
+				DiceAppService:       m.md,
+				RoomAppService:       m.mr,
+				UserAppService:       mockSessionUsers(m.mu),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: m.mi,
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+				TimeNow:              func() time.Time { return t0.UTC() },
+				SSEServer:            s,
 			})
 			require.NoError(err)
 
@@ -62,6 +154,7 @@ func TestHandlerSubscribeDiceRollEvents(t *testing.T) {
 
 			assert.Equal(test.expCode, w.Code)
 			assert.Equal(test.expHeaders, w.Header())
+			assert.Equal(test.expUnsubscribed, *m.unsubscribed)
 			// TODO(slok).
 			//assert.Equal(test.expBody, w.Body.String())
 		})
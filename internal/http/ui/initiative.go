@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"net/http"
+
+	"github.com/rollify/rollify/internal/model"
+)
+
+type initiativeTracker struct {
+	Round      uint
+	Combatants []initiativeCombatant
+}
+
+type initiativeCombatant struct {
+	ID         string
+	Name       string
+	Initiative int
+	// Current is set on the combatant that has the current turn.
+	Current bool
+}
+
+func mapInitiativeToTplModel(i model.Initiative) initiativeTracker {
+	cs := make([]initiativeCombatant, 0, len(i.Combatants))
+	for idx, c := range i.Combatants {
+		cs = append(cs, initiativeCombatant{
+			ID:         c.ID,
+			Name:       c.Name,
+			Initiative: c.Initiative,
+			Current:    idx == i.Turn,
+		})
+	}
+
+	return initiativeTracker{
+		Round:      i.Round,
+		Combatants: cs,
+	}
+}
+
+type initiativeTrackerTplData struct {
+	Initiative initiativeTracker
+}
+
+// renderInitiativeTracker renders the room initiative tracker snippet.
+func (u ui) renderInitiativeTracker(w http.ResponseWriter, r *http.Request, roomID string, i model.Initiative) {
+	u.tplRenderer.withRoom(roomID).RenderResponse(r.Context(), w, "initiative_tracker", initiativeTrackerTplData{
+		Initiative: mapInitiativeToTplModel(i),
+	})
+}
@@ -1 +1,55 @@
 package ui
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rollify/rollify/internal/room"
+)
+
+// rejectArchivedRoom rejects the requests that change archived rooms, archived rooms are
+// read-only so the user is redirected to the room page where it will see the room is archived.
+func (u ui) rejectArchivedRoom(next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roomID := chi.URLParam(r, urlParamRoomID)
+
+		rm, err := u.roomAppSvc.GetRoom(r.Context(), room.GetRoomRequest{ID: roomID})
+		if err != nil {
+			u.handleError(w, fmt.Errorf("could not get room: %w", err))
+			return
+		}
+
+		if rm.Room.Archived {
+			u.redirectToURL(w, r, u.servePrefix+"/room/"+roomID)
+			return
+		}
+
+		next(w, r)
+	})
+}
+
+// withSessionUser authenticates the room session of the request, the authenticated user is
+// stored on the request context. Requests without a valid session are anonymous.
+func (u ui) withSessionUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roomID := chi.URLParam(r, urlParamRoomID)
+		if roomID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		us, err := u.authenticateSession(r, roomID)
+		if err != nil {
+			u.handleError(w, err)
+			return
+		}
+		if us != nil {
+			r = r.WithContext(context.WithValue(r.Context(), contextKeySessionUser, us))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,120 @@
+package ui_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/r3labs/sse/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/deck/deckmock"
+	"github.com/rollify/rollify/internal/dice/dicemock"
+	"github.com/rollify/rollify/internal/http/ui"
+	"github.com/rollify/rollify/internal/initiative/initiativemock"
+	"github.com/rollify/rollify/internal/macro/macromock"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle/oraclemock"
+	"github.com/rollify/rollify/internal/room"
+	"github.com/rollify/rollify/internal/room/roommock"
+	"github.com/rollify/rollify/internal/stats/statsmock"
+	"github.com/rollify/rollify/internal/user"
+	"github.com/rollify/rollify/internal/user/usermock"
+)
+
+// newOpenRoomAppService returns a room service mock whose rooms are open (not archived), used
+// by the endpoints that change the rooms.
+func newOpenRoomAppService() *roommock.Service {
+	m := &roommock.Service{}
+	m.On("GetRoom", mock.Anything, mock.Anything).Maybe().Return(&room.GetRoomResponse{}, nil)
+	return m
+}
+
+// mockSessionUsers authenticates the session cookies of the tests as regular users, the session
+// token is used as the user ID. The tests can set their own sessions before calling it.
+func mockSessionUsers(m *usermock.Service) *usermock.Service {
+	m.On("AuthenticateUser", mock.Anything, mock.Anything).Maybe().Return(func(_ context.Context, r user.AuthenticateUserRequest) (*user.AuthenticateUserResponse, error) {
+		return &user.AuthenticateUserResponse{User: model.User{ID: r.SessionToken, RoomID: r.RoomID}}, nil
+	})
+	return m
+}
+
+func TestRejectArchivedRoom(t *testing.T) {
+	tests := map[string]struct {
+		request    func() *http.Request
+		mock       func(mr *roommock.Service)
+		expHeaders http.Header
+		expCode    int
+	}{
+		"Having an error while getting the room should fail.": {
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/new-dice-roll", strings.NewReader(url.Values{}.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				return req
+			},
+			mock: func(mr *roommock.Service) {
+				mr.On("GetRoom", mock.Anything, mock.Anything).Once().Return(nil, errors.New("wanted error"))
+			},
+			expHeaders: http.Header{},
+			expCode:    500,
+		},
+
+		"Changing an archived room should redirect to the read-only room.": {
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b/new-dice-roll", strings.NewReader(url.Values{}.Encode()))
+				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+				req.Header.Add("HX-Request", "true")
+				return req
+			},
+			mock: func(mr *roommock.Service) {
+				exp := room.GetRoomRequest{ID: "e02b402d-c23b-45b2-a5ea-583a566a9a6b"}
+				mr.On("GetRoom", mock.Anything, exp).Once().Return(&room.GetRoomResponse{Room: model.Room{
+					ID:       "e02b402d-c23b-45b2-a5ea-583a566a9a6b",
+					Archived: true,
+				}}, nil)
+			},
+			expHeaders: http.Header{
+				"Hx-Redirect": {"/u/room/e02b402d-c23b-45b2-a5ea-583a566a9a6b"},
+			},
+			expCode: 200,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			mr := &roommock.Service{}
+			test.mock(mr)
+
+			s := sse.New()
+			defer s.Close()
+			h, err := ui.New(ui.Config{
+				DiceAppService:       &dicemock.Service{},
+				RoomAppService:       mr,
+				UserAppService:       mockSessionUsers(&usermock.Service{}),
+				MacroAppService:      &macromock.Service{},
+				InitiativeAppService: &initiativemock.Service{},
+				DeckAppService:       &deckmock.Service{},
+				OracleAppService:     &oraclemock.Service{},
+				StatsAppService:      &statsmock.Service{},
+				SSEServer:            s,
+			})
+			require.NoError(err)
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.request())
+
+			assert.Equal(test.expCode, w.Code)
+			assert.Equal(test.expHeaders, w.Header())
+			mr.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle"
+)
+
+type oracleTable struct {
+	ID      string
+	Name    string
+	Dice    string
+	Entries int
+}
+
+func mapOracleTablesToTplModel(ts []model.OracleTable) []oracleTable {
+	ots := make([]oracleTable, 0, len(ts))
+	for _, t := range ts {
+		ots = append(ots, oracleTable{
+			ID:      t.ID,
+			Name:    t.Name,
+			Dice:    t.Dice,
+			Entries: len(t.Entries),
+		})
+	}
+
+	return ots
+}
+
+var oracleTableFormats = []oracle.TableFormat{
+	oracle.TableFormatCSV,
+	oracle.TableFormatYAML,
+}
+
+type oracleTablesTplData struct {
+	OracleTables       []oracleTable
+	OracleTableFormats []oracle.TableFormat
+}
+
+type oracleTableRollTplData struct {
+	UserName string
+	Results  []model.OracleTableResult
+}
+
+// renderOracleTables renders the room oracle tables snippet.
+func (u ui) renderOracleTables(w http.ResponseWriter, r *http.Request, roomID string) {
+	tables, err := u.oracleAppSvc.ListTables(r.Context(), oracle.ListTablesRequest{RoomID: roomID})
+	if err != nil {
+		u.handleError(w, fmt.Errorf("could not list room oracle tables: %w", err))
+		return
+	}
+
+	u.tplRenderer.withRoom(roomID).RenderResponse(r.Context(), w, "oracle_tables", oracleTablesTplData{
+		OracleTables:       mapOracleTablesToTplModel(tables.Tables),
+		OracleTableFormats: oracleTableFormats,
+	})
+}
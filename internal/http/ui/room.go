@@ -0,0 +1,28 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/room"
+)
+
+type roomInviteTokensTplData struct {
+	Protected    bool
+	InviteTokens []model.RoomInviteToken
+}
+
+// renderRoomInviteTokens renders the room invite tokens snippet.
+func (u ui) renderRoomInviteTokens(w http.ResponseWriter, r *http.Request, roomID string) {
+	rm, err := u.roomAppSvc.GetRoom(r.Context(), room.GetRoomRequest{ID: roomID})
+	if err != nil {
+		u.handleError(w, fmt.Errorf("could not get room: %w", err))
+		return
+	}
+
+	u.tplRenderer.withRoom(roomID).RenderResponse(r.Context(), w, "room_invite_tokens", roomInviteTokensTplData{
+		Protected:    rm.Room.Protected(),
+		InviteTokens: rm.Room.InviteTokens,
+	})
+}
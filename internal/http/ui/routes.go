@@ -8,9 +8,18 @@ import (
 )
 
 const (
-	urlParamRoomID      = "roomID"
-	queryParamSSEStream = "stream"
-	queryParamCursor    = "cursor"
+	urlParamRoomID         = "roomID"
+	urlParamMacroID        = "macroID"
+	urlParamRulesetRollID  = "rulesetRollID"
+	urlParamCombatantID    = "combatantID"
+	urlParamInitiativeTurn = "initiativeTurn"
+	urlParamDeckID         = "deckID"
+	urlParamDeckAction     = "deckAction"
+	urlParamOracleTableID  = "oracleTableID"
+	urlParamInviteToken    = "inviteToken"
+	queryParamSSEStream    = "stream"
+	queryParamCursor       = "cursor"
+	queryParamUser         = "user"
 
 	uuidRegex = "[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}"
 )
@@ -21,11 +30,28 @@ func (u ui) registerRoutes() {
 	u.wrapGet("/", u.handlerFullIndex())
 	u.wrapPost("/create-room", u.handlerActionCreateRoom())
 	u.wrapGet(fmt.Sprintf("/login/{%s:%s}", urlParamRoomID, uuidRegex), u.handlerFullLogin())
-	u.wrapPost(fmt.Sprintf("/login/{%s:%s}/manage-user", urlParamRoomID, uuidRegex), u.handlerActionManageUser())
+	u.wrapRoomPost(fmt.Sprintf("/login/{%s:%s}/manage-user", urlParamRoomID, uuidRegex), u.handlerActionManageUser())
 	u.wrapGet(fmt.Sprintf("/room/{%s:%s}", urlParamRoomID, uuidRegex), u.handlerFullDiceRoller())
-	u.wrapPost(fmt.Sprintf("/room/{%s:%s}/new-dice-roll", urlParamRoomID, uuidRegex), u.handlerSnippetNewDiceRoll())
+	u.wrapRoomPost(fmt.Sprintf("/room/{%s:%s}/new-dice-roll", urlParamRoomID, uuidRegex), u.handlerSnippetNewDiceRoll())
+	u.wrapPost(fmt.Sprintf("/room/{%s:%s}/dice-probabilities", urlParamRoomID, uuidRegex), u.handlerSnippetDiceProbabilities())
+	u.wrapRoomPost(fmt.Sprintf("/room/{%s:%s}/macros", urlParamRoomID, uuidRegex), u.handlerSnippetSaveMacro())
+	u.wrapRoomPost(fmt.Sprintf("/room/{%s:%s}/macros/{%s}/roll", urlParamRoomID, uuidRegex, urlParamMacroID), u.handlerSnippetRollMacro())
+	u.wrapRoomPost(fmt.Sprintf("/room/{%s:%s}/ruleset-rolls/{%s}/roll", urlParamRoomID, uuidRegex, urlParamRulesetRollID), u.handlerSnippetRollRulesetRoll())
+	u.wrapRoomPost(fmt.Sprintf("/room/{%s:%s}/initiative/combatants", urlParamRoomID, uuidRegex), u.handlerSnippetAddCombatant())
+	u.wrapRoomPost(fmt.Sprintf("/room/{%s:%s}/initiative/combatants/{%s}/remove", urlParamRoomID, uuidRegex, urlParamCombatantID), u.handlerSnippetRemoveCombatant())
+	u.wrapRoomPost(fmt.Sprintf("/room/{%s:%s}/initiative/{%s}", urlParamRoomID, uuidRegex, urlParamInitiativeTurn), u.handlerSnippetInitiativeTurn())
+	u.wrapRoomPost(fmt.Sprintf("/room/{%s:%s}/initiative/end", urlParamRoomID, uuidRegex), u.handlerSnippetEndCombat())
+	u.wrapRoomPost(fmt.Sprintf("/room/{%s:%s}/decks", urlParamRoomID, uuidRegex), u.handlerSnippetCreateDeck())
+	u.wrapRoomPost(fmt.Sprintf("/room/{%s:%s}/decks/{%s}/{%s}", urlParamRoomID, uuidRegex, urlParamDeckID, urlParamDeckAction), u.handlerSnippetDeckAction())
+	u.wrapRoomPost(fmt.Sprintf("/room/{%s:%s}/oracle-tables", urlParamRoomID, uuidRegex), u.handlerSnippetCreateOracleTable())
+	u.wrapRoomPost(fmt.Sprintf("/room/{%s:%s}/oracle-tables/{%s}/roll", urlParamRoomID, uuidRegex, urlParamOracleTableID), u.handlerSnippetRollOracleTable())
+	u.wrapPost(fmt.Sprintf("/room/{%s:%s}/archive", urlParamRoomID, uuidRegex), u.handlerActionArchiveRoom())
+	u.wrapPost(fmt.Sprintf("/room/{%s:%s}/delete", urlParamRoomID, uuidRegex), u.handlerActionDeleteRoom())
+	u.wrapPost(fmt.Sprintf("/room/{%s:%s}/invite-tokens", urlParamRoomID, uuidRegex), u.handlerSnippetCreateInviteToken())
+	u.wrapPost(fmt.Sprintf("/room/{%s:%s}/invite-tokens/{%s}/revoke", urlParamRoomID, uuidRegex, urlParamInviteToken), u.handlerSnippetRevokeInviteToken())
 	u.wrapGet(fmt.Sprintf("/room/{%s:%s}/dice-roll-history", urlParamRoomID, uuidRegex), u.handlerFullDiceRollHistory())
 	u.wrapGet(fmt.Sprintf("/room/{%s:%s}/dice-roll-history/more-items", urlParamRoomID, uuidRegex), u.handlerSnippetDiceRollHistoryMoreItems())
+	u.wrapGet(fmt.Sprintf("/room/{%s:%s}/stats", urlParamRoomID, uuidRegex), u.handlerFullDiceStats())
 	u.wrapGet(fmt.Sprintf("/logout/{%s:%s}", urlParamRoomID, uuidRegex), u.handlerActionLogout())
 	u.router.Mount("/subscribe/room/dice-roll-history", u.handlerSubscribeDiceRollEvents())
 }
@@ -34,6 +60,7 @@ func (u ui) wrapGet(pattern string, h http.HandlerFunc) {
 	u.router.With(
 		// Add endpoint middlewares.
 		std.HandlerProvider(pattern, u.metricsMiddleware),
+		u.withSessionUser,
 	).Get(pattern, h)
 }
 
@@ -41,5 +68,11 @@ func (u ui) wrapPost(pattern string, h http.HandlerFunc) {
 	u.router.With(
 		// Add endpoint middlewares.
 		std.HandlerProvider(pattern, u.metricsMiddleware),
+		u.withSessionUser,
 	).Post(pattern, h)
 }
+
+// wrapRoomPost registers an endpoint that changes the room, these are rejected on archived rooms.
+func (u ui) wrapRoomPost(pattern string, h http.HandlerFunc) {
+	u.wrapPost(pattern, u.rejectArchivedRoom(h))
+}
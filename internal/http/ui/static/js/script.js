@@ -1,10 +1,15 @@
 
 // cleanDiceSelectors will set empty value (first value) of the selectors
-// that are for rolling dices (selector has "diceRollerSelector" class).
+// that are for rolling dices (selector has "diceRollerSelector" class), the
+// checkboxes will be unchecked.
 function cleanDiceSelectors(){
   let selects = document.querySelectorAll('.diceRollerSelector')
   for (let x of selects) {
-    x.value = ""
+    if (x.type === "checkbox") {
+      x.checked = false
+    } else {
+      x.value = ""
+    }
   }
 }
 
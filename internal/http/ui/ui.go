@@ -10,22 +10,32 @@ import (
 	"github.com/r3labs/sse/v2"
 	gohttmetrics "github.com/slok/go-http-metrics/middleware"
 
+	"github.com/rollify/rollify/internal/deck"
 	"github.com/rollify/rollify/internal/dice"
+	"github.com/rollify/rollify/internal/initiative"
 	"github.com/rollify/rollify/internal/log"
+	"github.com/rollify/rollify/internal/macro"
+	"github.com/rollify/rollify/internal/oracle"
 	"github.com/rollify/rollify/internal/room"
+	"github.com/rollify/rollify/internal/stats"
 	"github.com/rollify/rollify/internal/user"
 )
 
 // Config is the configuration to serve the API.
 type Config struct {
-	DiceAppService  dice.Service
-	RoomAppService  room.Service
-	UserAppService  user.Service
-	MetricsRecorder MetricsRecorder
-	ServerPrefix    string
-	TimeNow         func() time.Time
-	SSEServer       *sse.Server
-	Logger          log.Logger
+	DiceAppService       dice.Service
+	RoomAppService       room.Service
+	UserAppService       user.Service
+	MacroAppService      macro.Service
+	InitiativeAppService initiative.Service
+	DeckAppService       deck.Service
+	OracleAppService     oracle.Service
+	StatsAppService      stats.Service
+	MetricsRecorder      MetricsRecorder
+	ServerPrefix         string
+	TimeNow              func() time.Time
+	SSEServer            *sse.Server
+	Logger               log.Logger
 }
 
 func (c *Config) defaults() error {
@@ -41,6 +51,26 @@ func (c *Config) defaults() error {
 		return fmt.Errorf("user.Service application service is required")
 	}
 
+	if c.MacroAppService == nil {
+		return fmt.Errorf("macro.Service application service is required")
+	}
+
+	if c.InitiativeAppService == nil {
+		return fmt.Errorf("initiative.Service application service is required")
+	}
+
+	if c.DeckAppService == nil {
+		return fmt.Errorf("deck.Service application service is required")
+	}
+
+	if c.OracleAppService == nil {
+		return fmt.Errorf("oracle.Service application service is required")
+	}
+
+	if c.StatsAppService == nil {
+		return fmt.Errorf("stats.Service application service is required")
+	}
+
 	if c.SSEServer == nil {
 		return fmt.Errorf("an SSE server is required")
 	}
@@ -72,6 +102,11 @@ type ui struct {
 	diceAppSvc        dice.Service
 	roomAppSvc        room.Service
 	userAppSvc        user.Service
+	macroAppSvc       macro.Service
+	initiativeAppSvc  initiative.Service
+	deckAppSvc        deck.Service
+	oracleAppSvc      oracle.Service
+	statsAppSvc       stats.Service
 	router            chi.Router
 	servePrefix       string
 	logger            log.Logger
@@ -101,13 +136,18 @@ func New(cfg Config) (http.Handler, error) {
 	tplRenderer = tplRenderer.WithURLPrefix(cfg.ServerPrefix)
 
 	a := ui{
-		diceAppSvc:  cfg.DiceAppService,
-		roomAppSvc:  cfg.RoomAppService,
-		userAppSvc:  cfg.UserAppService,
-		router:      chi.NewRouter(),
-		servePrefix: cfg.ServerPrefix,
-		staticFS:    sanitizedStaticFS,
-		logger:      cfg.Logger,
+		diceAppSvc:       cfg.DiceAppService,
+		roomAppSvc:       cfg.RoomAppService,
+		userAppSvc:       cfg.UserAppService,
+		macroAppSvc:      cfg.MacroAppService,
+		initiativeAppSvc: cfg.InitiativeAppService,
+		deckAppSvc:       cfg.DeckAppService,
+		oracleAppSvc:     cfg.OracleAppService,
+		statsAppSvc:      cfg.StatsAppService,
+		router:           chi.NewRouter(),
+		servePrefix:      cfg.ServerPrefix,
+		staticFS:         sanitizedStaticFS,
+		logger:           cfg.Logger,
 		metricsMiddleware: gohttmetrics.New(gohttmetrics.Config{
 			Recorder: cfg.MetricsRecorder,
 			Service:  "ui",
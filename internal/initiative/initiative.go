@@ -0,0 +1,751 @@
+package initiative
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/rollify/rollify/internal/dice"
+	"github.com/rollify/rollify/internal/event"
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/log"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/room/roomcheck"
+	"github.com/rollify/rollify/internal/storage"
+)
+
+// Service is the application service of the room combat initiative tracker logic.
+type Service interface {
+	// GetInitiative gets the initiative tracker of a room, if the room doesn't have a combat
+	// it returns an empty tracker.
+	GetInitiative(ctx context.Context, r GetInitiativeRequest) (*GetInitiativeResponse, error)
+	// AddCombatant adds a room user or a named NPC to the room initiative tracker, starting the
+	// combat if it was not started.
+	AddCombatant(ctx context.Context, r AddCombatantRequest) (*AddCombatantResponse, error)
+	// RollInitiative rolls the initiative of a combatant and reorders the tracker.
+	RollInitiative(ctx context.Context, r RollInitiativeRequest) (*RollInitiativeResponse, error)
+	// RemoveCombatant removes a combatant from the room initiative tracker.
+	RemoveCombatant(ctx context.Context, r RemoveCombatantRequest) (*RemoveCombatantResponse, error)
+	// NextTurn moves the current turn to the next combatant, starting a new round after the last one.
+	NextTurn(ctx context.Context, r NextTurnRequest) (*NextTurnResponse, error)
+	// PreviousTurn moves the current turn back to the previous combatant.
+	PreviousTurn(ctx context.Context, r PreviousTurnRequest) (*PreviousTurnResponse, error)
+	// EndCombat ends the room combat removing its initiative tracker.
+	EndCombat(ctx context.Context, r EndCombatRequest) error
+	// SubscribeInitiativeUpdated subscribes to the room initiative tracker updated events.
+	SubscribeInitiativeUpdated(ctx context.Context, r SubscribeInitiativeUpdatedRequest) (*SubscribeInitiativeUpdatedResponse, error)
+}
+
+//go:generate mockery --case underscore --output initiativemock --outpkg initiativemock --name Service
+
+// ServiceConfig is the service configuration.
+type ServiceConfig struct {
+	InitiativeRepository storage.InitiativeRepository
+	RoomRepository       storage.RoomRepository
+	UserRepository       storage.UserRepository
+	// DiceAppService is used to roll the combatants initiative.
+	DiceAppService  dice.Service
+	EventNotifier   event.Notifier
+	EventSubscriber event.Subscriber
+	Logger          log.Logger
+	IDGenerator     func() string
+	TimeNowFunc     func() time.Time
+}
+
+func (c *ServiceConfig) defaults() error {
+	if c.InitiativeRepository == nil {
+		return fmt.Errorf("config.InitiativeRepository is required")
+	}
+
+	if c.RoomRepository == nil {
+		return fmt.Errorf("config.RoomRepository is required")
+	}
+
+	if c.UserRepository == nil {
+		return fmt.Errorf("config.UserRepository is required")
+	}
+
+	if c.DiceAppService == nil {
+		return fmt.Errorf("config.DiceAppService is required")
+	}
+
+	if c.EventNotifier == nil {
+		return fmt.Errorf("config.EventNotifier is required")
+	}
+
+	if c.EventSubscriber == nil {
+		return fmt.Errorf("config.EventSubscriber is required")
+	}
+
+	if c.Logger == nil {
+		c.Logger = log.Dummy
+	}
+	c.Logger = c.Logger.WithKV(log.KV{"svc": "initiative.Service"})
+
+	if c.IDGenerator == nil {
+		c.IDGenerator = func() string { return uuid.New().String() }
+	}
+
+	if c.TimeNowFunc == nil {
+		c.TimeNowFunc = time.Now
+	}
+
+	return nil
+}
+
+type service struct {
+	initiativeRepo  storage.InitiativeRepository
+	roomRepo        storage.RoomRepository
+	userRepo        storage.UserRepository
+	diceAppSvc      dice.Service
+	eventNotifier   event.Notifier
+	eventSubscriber event.Subscriber
+	logger          log.Logger
+	idGen           func() string
+	timeNow         func() time.Time
+}
+
+// NewService returns a new initiative.Service.
+func NewService(cfg ServiceConfig) (Service, error) {
+	err := cfg.defaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return service{
+		initiativeRepo:  cfg.InitiativeRepository,
+		roomRepo:        cfg.RoomRepository,
+		userRepo:        cfg.UserRepository,
+		diceAppSvc:      cfg.DiceAppService,
+		eventNotifier:   cfg.EventNotifier,
+		eventSubscriber: cfg.EventSubscriber,
+		logger:          cfg.Logger,
+		idGen:           cfg.IDGenerator,
+		timeNow:         cfg.TimeNowFunc,
+	}, nil
+}
+
+const (
+	maxCombatants       = 100
+	maxNameLength       = 100
+	maxInitiative       = 1000
+	maxExpressionLength = 255
+)
+
+// GetInitiativeRequest is the request for GetInitiative.
+type GetInitiativeRequest struct {
+	RoomID string
+}
+
+func (r GetInitiativeRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	return nil
+}
+
+// GetInitiativeResponse is the response for GetInitiative.
+type GetInitiativeResponse struct {
+	Initiative model.Initiative
+}
+
+func (s service) GetInitiative(ctx context.Context, r GetInitiativeRequest) (*GetInitiativeResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	exists, err := s.roomRepo.RoomExists(ctx, r.RoomID)
+	if err != nil {
+		return nil, fmt.Errorf("could not check if room exists: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("room does not exists: %w", internalerrors.ErrNotValid)
+	}
+
+	i, err := s.getInitiative(ctx, r.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetInitiativeResponse{
+		Initiative: *i,
+	}, nil
+}
+
+// AddCombatantRequest is the request for AddCombatant.
+type AddCombatantRequest struct {
+	RoomID string
+	// UserID is the room user adding the combatant.
+	UserID string
+	// Name is the combatant name, required on NPCs, by default the user name on user combatants.
+	Name string
+	// CombatantUserID is the room user the combatant is, empty on NPCs.
+	CombatantUserID string
+	// Initiative is the combatant initiative, ignored if Expression is set.
+	Initiative int
+	// Expression is a dice notation expression (e.g: `1d20+2`) used to roll the combatant initiative, optional.
+	Expression string
+}
+
+func (r AddCombatantRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	if r.UserID == "" {
+		return fmt.Errorf("config.UserID is required")
+	}
+
+	if r.Name == "" && r.CombatantUserID == "" {
+		return fmt.Errorf("config.Name is required on NPC combatants")
+	}
+
+	if utf8.RuneCountInString(r.Name) > maxNameLength {
+		return fmt.Errorf("max config.Name length is %d", maxNameLength)
+	}
+
+	if r.Initiative > maxInitiative || r.Initiative < -maxInitiative {
+		return fmt.Errorf("config.Initiative must be between -%d and %d", maxInitiative, maxInitiative)
+	}
+
+	if len(r.Expression) > maxExpressionLength {
+		return fmt.Errorf("max config.Expression length is %d", maxExpressionLength)
+	}
+
+	return nil
+}
+
+// AddCombatantResponse is the response for AddCombatant.
+type AddCombatantResponse struct {
+	Initiative model.Initiative
+	Combatant  model.InitiativeCombatant
+}
+
+func (s service) AddCombatant(ctx context.Context, r AddCombatantRequest) (*AddCombatantResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	err = roomcheck.WritableRoomUser(ctx, s.userRepo, s.roomRepo, r.RoomID, r.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	i, err := s.getInitiative(ctx, r.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(i.Combatants) >= maxCombatants {
+		return nil, fmt.Errorf("max combatants is %d: %w", maxCombatants, internalerrors.ErrNotValid)
+	}
+
+	c := model.InitiativeCombatant{
+		ID:         s.idGen(),
+		Name:       r.Name,
+		UserID:     r.CombatantUserID,
+		Initiative: r.Initiative,
+	}
+
+	// User combatants can only be once on the combat.
+	if c.UserID != "" {
+		u, err := s.getRoomUser(ctx, r.RoomID, c.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ic := range i.Combatants {
+			if ic.UserID == c.UserID {
+				return nil, fmt.Errorf("user is already a combatant: %w", internalerrors.ErrAlreadyExists)
+			}
+		}
+
+		if c.Name == "" {
+			c.Name = u.Name
+		}
+	}
+
+	if r.Expression != "" {
+		dr, err := s.rollInitiative(ctx, r.RoomID, r.UserID, c.Name, r.Expression)
+		if err != nil {
+			return nil, err
+		}
+		c.Initiative = dr.Total
+		c.DiceRollID = dr.ID
+	}
+
+	// The first combatant starts the combat.
+	if i.Round == 0 {
+		i.Round = 1
+		i.Turn = 0
+	}
+	i.Combatants = append(i.Combatants, c)
+	sortCombatants(i)
+
+	err = s.saveInitiative(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AddCombatantResponse{
+		Initiative: *i,
+		Combatant:  c,
+	}, nil
+}
+
+// RollInitiativeRequest is the request for RollInitiative.
+type RollInitiativeRequest struct {
+	RoomID string
+	// UserID is the room user rolling the initiative.
+	UserID      string
+	CombatantID string
+	// Expression is a dice notation expression (e.g: `1d20+2`).
+	Expression string
+}
+
+func (r RollInitiativeRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	if r.UserID == "" {
+		return fmt.Errorf("config.UserID is required")
+	}
+
+	if r.CombatantID == "" {
+		return fmt.Errorf("config.CombatantID is required")
+	}
+
+	if r.Expression == "" {
+		return fmt.Errorf("config.Expression is required")
+	}
+
+	if len(r.Expression) > maxExpressionLength {
+		return fmt.Errorf("max config.Expression length is %d", maxExpressionLength)
+	}
+
+	return nil
+}
+
+// RollInitiativeResponse is the response for RollInitiative.
+type RollInitiativeResponse struct {
+	Initiative model.Initiative
+	DiceRoll   model.DiceRoll
+}
+
+func (s service) RollInitiative(ctx context.Context, r RollInitiativeRequest) (*RollInitiativeResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	err = roomcheck.WritableRoomUser(ctx, s.userRepo, s.roomRepo, r.RoomID, r.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	i, err := s.getInitiative(ctx, r.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := combatantIndex(i, r.CombatantID)
+	if idx < 0 {
+		return nil, fmt.Errorf("combatant does not exists: %w", internalerrors.ErrMissing)
+	}
+
+	dr, err := s.rollInitiative(ctx, r.RoomID, r.UserID, i.Combatants[idx].Name, r.Expression)
+	if err != nil {
+		return nil, err
+	}
+	i.Combatants[idx].Initiative = dr.Total
+	i.Combatants[idx].DiceRollID = dr.ID
+	sortCombatants(i)
+
+	err = s.saveInitiative(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RollInitiativeResponse{
+		Initiative: *i,
+		DiceRoll:   *dr,
+	}, nil
+}
+
+// RemoveCombatantRequest is the request for RemoveCombatant.
+type RemoveCombatantRequest struct {
+	RoomID      string
+	UserID      string
+	CombatantID string
+}
+
+func (r RemoveCombatantRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	if r.UserID == "" {
+		return fmt.Errorf("config.UserID is required")
+	}
+
+	if r.CombatantID == "" {
+		return fmt.Errorf("config.CombatantID is required")
+	}
+
+	return nil
+}
+
+// RemoveCombatantResponse is the response for RemoveCombatant.
+type RemoveCombatantResponse struct {
+	Initiative model.Initiative
+}
+
+func (s service) RemoveCombatant(ctx context.Context, r RemoveCombatantRequest) (*RemoveCombatantResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	err = roomcheck.WritableRoomUser(ctx, s.userRepo, s.roomRepo, r.RoomID, r.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	i, err := s.getInitiative(ctx, r.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := combatantIndex(i, r.CombatantID)
+	if idx < 0 {
+		return nil, fmt.Errorf("combatant does not exists: %w", internalerrors.ErrMissing)
+	}
+
+	// Keep the current turn on the same combatant, if the removed combatant had the turn,
+	// the turn goes to the next one.
+	i.Combatants = slices.Delete(i.Combatants, idx, idx+1)
+	if idx < i.Turn {
+		i.Turn--
+	}
+	if i.Turn >= len(i.Combatants) {
+		i.Turn = 0
+	}
+
+	err = s.saveInitiative(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RemoveCombatantResponse{
+		Initiative: *i,
+	}, nil
+}
+
+// NextTurnRequest is the request for NextTurn.
+type NextTurnRequest struct {
+	RoomID string
+	UserID string
+}
+
+func (r NextTurnRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	if r.UserID == "" {
+		return fmt.Errorf("config.UserID is required")
+	}
+
+	return nil
+}
+
+// NextTurnResponse is the response for NextTurn.
+type NextTurnResponse struct {
+	Initiative model.Initiative
+}
+
+func (s service) NextTurn(ctx context.Context, r NextTurnRequest) (*NextTurnResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	i, err := s.getCombatInitiative(ctx, r.RoomID, r.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	i.Turn++
+	if i.Turn >= len(i.Combatants) {
+		i.Turn = 0
+		i.Round++
+	}
+
+	err = s.saveInitiative(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NextTurnResponse{
+		Initiative: *i,
+	}, nil
+}
+
+// PreviousTurnRequest is the request for PreviousTurn.
+type PreviousTurnRequest struct {
+	RoomID string
+	UserID string
+}
+
+func (r PreviousTurnRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	if r.UserID == "" {
+		return fmt.Errorf("config.UserID is required")
+	}
+
+	return nil
+}
+
+// PreviousTurnResponse is the response for PreviousTurn.
+type PreviousTurnResponse struct {
+	Initiative model.Initiative
+}
+
+func (s service) PreviousTurn(ctx context.Context, r PreviousTurnRequest) (*PreviousTurnResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	i, err := s.getCombatInitiative(ctx, r.RoomID, r.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	i.Turn--
+	if i.Turn < 0 {
+		if i.Round <= 1 {
+			return nil, fmt.Errorf("the combat is on its first turn: %w", internalerrors.ErrNotValid)
+		}
+		i.Turn = len(i.Combatants) - 1
+		i.Round--
+	}
+
+	err = s.saveInitiative(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PreviousTurnResponse{
+		Initiative: *i,
+	}, nil
+}
+
+// EndCombatRequest is the request for EndCombat.
+type EndCombatRequest struct {
+	RoomID string
+	UserID string
+}
+
+func (r EndCombatRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	if r.UserID == "" {
+		return fmt.Errorf("config.UserID is required")
+	}
+
+	return nil
+}
+
+func (s service) EndCombat(ctx context.Context, r EndCombatRequest) error {
+	err := r.validate()
+	if err != nil {
+		return fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	err = roomcheck.WritableRoomUser(ctx, s.userRepo, s.roomRepo, r.RoomID, r.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = s.initiativeRepo.DeleteInitiative(ctx, r.RoomID)
+	if err != nil {
+		return fmt.Errorf("could not delete initiative: %w", err)
+	}
+
+	// Send the empty tracker so the subscribers know the combat ended.
+	ev := model.EventInitiativeUpdated{Initiative: model.Initiative{RoomID: r.RoomID, UpdatedAt: s.timeNow().UTC()}}
+	err = s.eventNotifier.NotifyInitiativeUpdated(ctx, ev)
+	if err != nil {
+		return fmt.Errorf("could not send initiative updated event: %w", err)
+	}
+
+	return nil
+}
+
+// SubscribeInitiativeUpdatedRequest is the request for SubscribeInitiativeUpdated.
+type SubscribeInitiativeUpdatedRequest struct {
+	RoomID       string
+	EventHandler func(context.Context, model.EventInitiativeUpdated) error
+}
+
+func (r SubscribeInitiativeUpdatedRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("roomID is required")
+	}
+
+	if r.EventHandler == nil {
+		return fmt.Errorf("eventHandler is required")
+	}
+
+	return nil
+}
+
+// SubscribeInitiativeUpdatedResponse is the response for SubscribeInitiativeUpdated.
+type SubscribeInitiativeUpdatedResponse struct {
+	UnsubscribeFunc func() error
+}
+
+func (s service) SubscribeInitiativeUpdated(ctx context.Context, r SubscribeInitiativeUpdatedRequest) (*SubscribeInitiativeUpdatedResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	exists, err := s.roomRepo.RoomExists(ctx, r.RoomID)
+	if err != nil {
+		return nil, fmt.Errorf("could not check if room exists: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("room does not exists: %w", internalerrors.ErrNotValid)
+	}
+
+	// Create a subscription ID and subscribe.
+	subscriptionID := s.idGen()
+	err = s.eventSubscriber.SubscribeInitiativeUpdated(ctx, subscriptionID, r.RoomID, r.EventHandler)
+	if err != nil {
+		return nil, fmt.Errorf("could not subscribe to initiativeUpdated events: %w", err)
+	}
+
+	return &SubscribeInitiativeUpdatedResponse{
+		UnsubscribeFunc: func() error {
+			return s.eventSubscriber.UnsubscribeInitiativeUpdated(ctx, subscriptionID, r.RoomID)
+		},
+	}, nil
+}
+
+// getInitiative gets the room initiative tracker, if the room doesn't have one it returns
+// an empty tracker.
+func (s service) getInitiative(ctx context.Context, roomID string) (*model.Initiative, error) {
+	i, err := s.initiativeRepo.GetInitiative(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, internalerrors.ErrMissing) {
+			return &model.Initiative{RoomID: roomID}, nil
+		}
+		return nil, fmt.Errorf("could not get initiative: %w", err)
+	}
+
+	return i, nil
+}
+
+// getCombatInitiative gets the room initiative tracker checking the user is from the room
+// and the combat has combatants.
+func (s service) getCombatInitiative(ctx context.Context, roomID, userID string) (*model.Initiative, error) {
+	err := roomcheck.WritableRoomUser(ctx, s.userRepo, s.roomRepo, roomID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	i, err := s.getInitiative(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(i.Combatants) == 0 {
+		return nil, fmt.Errorf("the combat doesn't have combatants: %w", internalerrors.ErrNotValid)
+	}
+
+	return i, nil
+}
+
+// saveInitiative stores the initiative tracker and notifies the update.
+func (s service) saveInitiative(ctx context.Context, i *model.Initiative) error {
+	i.UpdatedAt = s.timeNow().UTC()
+	err := s.initiativeRepo.SaveInitiative(ctx, *i)
+	if err != nil {
+		return fmt.Errorf("could not store initiative: %w", err)
+	}
+
+	err = s.eventNotifier.NotifyInitiativeUpdated(ctx, model.EventInitiativeUpdated{Initiative: *i})
+	if err != nil {
+		return fmt.Errorf("could not send initiative updated event: %w", err)
+	}
+
+	return nil
+}
+
+// rollInitiative rolls a combatant initiative as a regular room dice roll.
+func (s service) rollInitiative(ctx context.Context, roomID, userID, name, expression string) (*model.DiceRoll, error) {
+	resp, err := s.diceAppSvc.CreateDiceRoll(ctx, dice.CreateDiceRollRequest{
+		RoomID:     roomID,
+		UserID:     userID,
+		Expression: expression,
+		Label:      "Initiative: " + name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not roll initiative: %w", err)
+	}
+
+	return &resp.DiceRoll, nil
+}
+
+// sortCombatants sorts the combatants by initiative (highest first), keeping the turn on the
+// same combatant. Combatants with the same initiative keep their order.
+// Until the first turn passes, the turn stays on the highest initiative combatant.
+func sortCombatants(i *model.Initiative) {
+	current, ok := i.CurrentCombatant()
+	slices.SortStableFunc(i.Combatants, func(a, b model.InitiativeCombatant) int {
+		return b.Initiative - a.Initiative
+	})
+
+	if ok && (i.Round > 1 || i.Turn > 0) {
+		i.Turn = combatantIndex(i, current.ID)
+	}
+}
+
+func combatantIndex(i *model.Initiative, combatantID string) int {
+	return slices.IndexFunc(i.Combatants, func(c model.InitiativeCombatant) bool {
+		return c.ID == combatantID
+	})
+}
+
+// getRoomUser gets the user checking it's from the room.
+func (s service) getRoomUser(ctx context.Context, roomID, userID string) (*model.User, error) {
+	u, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, internalerrors.ErrMissing) {
+			return nil, fmt.Errorf("user does not exists: %w", internalerrors.ErrNotValid)
+		}
+		return nil, fmt.Errorf("could not get user: %w", err)
+	}
+
+	if u.RoomID != roomID {
+		return nil, fmt.Errorf("user is not from the room: %w", internalerrors.ErrNotValid)
+	}
+
+	return u, nil
+}
@@ -0,0 +1,668 @@
+package initiative_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/dice"
+	"github.com/rollify/rollify/internal/dice/dicemock"
+	"github.com/rollify/rollify/internal/event/eventmock"
+	"github.com/rollify/rollify/internal/initiative"
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage/storagemock"
+)
+
+type mocks struct {
+	mi *storagemock.InitiativeRepository
+	mr *storagemock.RoomRepository
+	mu *storagemock.UserRepository
+	md *dicemock.Service
+	mn *eventmock.Notifier
+	ms *eventmock.Subscriber
+}
+
+func newMocks() mocks {
+	return mocks{
+		mi: &storagemock.InitiativeRepository{},
+		mr: &storagemock.RoomRepository{},
+		mu: &storagemock.UserRepository{},
+		md: &dicemock.Service{},
+		mn: &eventmock.Notifier{},
+		ms: &eventmock.Subscriber{},
+	}
+}
+
+func (m mocks) assertExpectations(t *testing.T) {
+	m.mi.AssertExpectations(t)
+	m.mr.AssertExpectations(t)
+	m.mu.AssertExpectations(t)
+	m.md.AssertExpectations(t)
+	m.mn.AssertExpectations(t)
+	m.ms.AssertExpectations(t)
+}
+
+func newService(t *testing.T, m mocks, t0 time.Time) initiative.Service {
+	svc, err := initiative.NewService(initiative.ServiceConfig{
+		InitiativeRepository: m.mi,
+		RoomRepository:       m.mr,
+		UserRepository:       m.mu,
+		DiceAppService:       m.md,
+		EventNotifier:        m.mn,
+		EventSubscriber:      m.ms,
+		IDGenerator:          func() string { return "test" },
+		TimeNowFunc:          func() time.Time { return t0 },
+	})
+	require.NoError(t, err)
+
+	return svc
+}
+
+func TestServiceGetInitiative(t *testing.T) {
+	t0 := time.Now().UTC()
+
+	tests := map[string]struct {
+		mock    func(m mocks)
+		req     initiative.GetInitiativeRequest
+		expResp *initiative.GetInitiativeResponse
+		expErr  bool
+	}{
+		"Having a request without room, should fail.": {
+			mock:   func(m mocks) {},
+			req:    initiative.GetInitiativeRequest{},
+			expErr: true,
+		},
+
+		"Having a missing room, should fail.": {
+			mock: func(m mocks) {
+				m.mr.On("RoomExists", mock.Anything, "room-id").Once().Return(false, nil)
+			},
+			req:    initiative.GetInitiativeRequest{RoomID: "room-id"},
+			expErr: true,
+		},
+
+		"Having a room without combat, should return an empty tracker.": {
+			mock: func(m mocks) {
+				m.mr.On("RoomExists", mock.Anything, "room-id").Once().Return(true, nil)
+				m.mi.On("GetInitiative", mock.Anything, "room-id").Once().Return(nil, internalerrors.ErrMissing)
+			},
+			req: initiative.GetInitiativeRequest{RoomID: "room-id"},
+			expResp: &initiative.GetInitiativeResponse{
+				Initiative: model.Initiative{RoomID: "room-id"},
+			},
+		},
+
+		"Having an error while getting the initiative, should fail.": {
+			mock: func(m mocks) {
+				m.mr.On("RoomExists", mock.Anything, "room-id").Once().Return(true, nil)
+				m.mi.On("GetInitiative", mock.Anything, "room-id").Once().Return(nil, errors.New("whatever"))
+			},
+			req:    initiative.GetInitiativeRequest{RoomID: "room-id"},
+			expErr: true,
+		},
+
+		"Having a room with combat, should return the tracker.": {
+			mock: func(m mocks) {
+				m.mr.On("RoomExists", mock.Anything, "room-id").Once().Return(true, nil)
+				i := &model.Initiative{RoomID: "room-id", Round: 2, Combatants: []model.InitiativeCombatant{{ID: "c1", Name: "Goblin"}}}
+				m.mi.On("GetInitiative", mock.Anything, "room-id").Once().Return(i, nil)
+			},
+			req: initiative.GetInitiativeRequest{RoomID: "room-id"},
+			expResp: &initiative.GetInitiativeResponse{
+				Initiative: model.Initiative{RoomID: "room-id", Round: 2, Combatants: []model.InitiativeCombatant{{ID: "c1", Name: "Goblin"}}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := newMocks()
+			test.mock(m)
+			svc := newService(t, m, t0)
+
+			gotResp, err := svc.GetInitiative(context.TODO(), test.req)
+
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expResp, gotResp)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestServiceAddCombatant(t *testing.T) {
+	t0 := time.Now().UTC()
+	u := &model.User{ID: "user-id", Name: "Bilbo", RoomID: "room-id"}
+	u2 := &model.User{ID: "user2-id", Name: "Frodo", RoomID: "room-id"}
+	current := func() *model.Initiative {
+		return &model.Initiative{
+			RoomID: "room-id",
+			Round:  2,
+			Turn:   1,
+			Combatants: []model.InitiativeCombatant{
+				{ID: "c1", Name: "Goblin", Initiative: 18},
+				{ID: "c2", Name: "Bilbo", UserID: "user-id", Initiative: 10},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		mock    func(m mocks)
+		req     initiative.AddCombatantRequest
+		expResp *initiative.AddCombatantResponse
+		expErr  error
+	}{
+		"Having a request without room, should fail.": {
+			mock:   func(m mocks) {},
+			req:    initiative.AddCombatantRequest{UserID: "user-id", Name: "Orc"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request without user, should fail.": {
+			mock:   func(m mocks) {},
+			req:    initiative.AddCombatantRequest{RoomID: "room-id", Name: "Orc"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having an NPC without name, should fail.": {
+			mock:   func(m mocks) {},
+			req:    initiative.AddCombatantRequest{RoomID: "room-id", UserID: "user-id"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a user from another room, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(&model.User{ID: "user-id", RoomID: "other-room"}, nil)
+			},
+			req:    initiative.AddCombatantRequest{RoomID: "room-id", UserID: "user-id", Name: "Orc"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request on an archived room, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id", Archived: true}, nil)
+			},
+			req:    initiative.AddCombatantRequest{RoomID: "room-id", UserID: "user-id", Name: "Orc"},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having a user combatant that is already on the combat, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Twice().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.mi.On("GetInitiative", mock.Anything, "room-id").Once().Return(current(), nil)
+			},
+			req:    initiative.AddCombatantRequest{RoomID: "room-id", UserID: "user-id", CombatantUserID: "user-id"},
+			expErr: internalerrors.ErrAlreadyExists,
+		},
+
+		"Adding the first combatant should start the combat.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.mi.On("GetInitiative", mock.Anything, "room-id").Once().Return(nil, internalerrors.ErrMissing)
+				exp := model.Initiative{
+					RoomID:     "room-id",
+					UpdatedAt:  t0,
+					Round:      1,
+					Combatants: []model.InitiativeCombatant{{ID: "test", Name: "Orc", Initiative: 12}},
+				}
+				m.mi.On("SaveInitiative", mock.Anything, exp).Once().Return(nil)
+				m.mn.On("NotifyInitiativeUpdated", mock.Anything, model.EventInitiativeUpdated{Initiative: exp}).Once().Return(nil)
+			},
+			req: initiative.AddCombatantRequest{RoomID: "room-id", UserID: "user-id", Name: "Orc", Initiative: 12},
+			expResp: &initiative.AddCombatantResponse{
+				Initiative: model.Initiative{
+					RoomID:     "room-id",
+					UpdatedAt:  t0,
+					Round:      1,
+					Combatants: []model.InitiativeCombatant{{ID: "test", Name: "Orc", Initiative: 12}},
+				},
+				Combatant: model.InitiativeCombatant{ID: "test", Name: "Orc", Initiative: 12},
+			},
+		},
+
+		"Adding a user combatant with a rolled initiative should sort the combatants keeping the current turn.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.mu.On("GetUserByID", mock.Anything, "user2-id").Once().Return(u2, nil)
+				m.mi.On("GetInitiative", mock.Anything, "room-id").Once().Return(current(), nil)
+				expRoll := dice.CreateDiceRollRequest{RoomID: "room-id", UserID: "user-id", Expression: "1d20+2", Label: "Initiative: Frodo"}
+				m.md.On("CreateDiceRoll", mock.Anything, expRoll).Once().Return(&dice.CreateDiceRollResponse{DiceRoll: model.DiceRoll{ID: "dr1", Total: 15}}, nil)
+				m.mi.On("SaveInitiative", mock.Anything, mock.Anything).Once().Return(nil)
+				m.mn.On("NotifyInitiativeUpdated", mock.Anything, mock.Anything).Once().Return(nil)
+			},
+			req: initiative.AddCombatantRequest{RoomID: "room-id", UserID: "user-id", CombatantUserID: "user2-id", Expression: "1d20+2"},
+			expResp: &initiative.AddCombatantResponse{
+				Initiative: model.Initiative{
+					RoomID:    "room-id",
+					UpdatedAt: t0,
+					Round:     2,
+					Turn:      2,
+					Combatants: []model.InitiativeCombatant{
+						{ID: "c1", Name: "Goblin", Initiative: 18},
+						{ID: "test", Name: "Frodo", UserID: "user2-id", Initiative: 15, DiceRollID: "dr1"},
+						{ID: "c2", Name: "Bilbo", UserID: "user-id", Initiative: 10},
+					},
+				},
+				Combatant: model.InitiativeCombatant{ID: "test", Name: "Frodo", UserID: "user2-id", Initiative: 15, DiceRollID: "dr1"},
+			},
+		},
+
+		"Having an error while rolling the initiative, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.mi.On("GetInitiative", mock.Anything, "room-id").Once().Return(current(), nil)
+				m.md.On("CreateDiceRoll", mock.Anything, mock.Anything).Once().Return(nil, internalerrors.ErrNotValid)
+			},
+			req:    initiative.AddCombatantRequest{RoomID: "room-id", UserID: "user-id", Name: "Orc", Expression: "1d20+"},
+			expErr: internalerrors.ErrNotValid,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := newMocks()
+			test.mock(m)
+			svc := newService(t, m, t0)
+
+			gotResp, err := svc.AddCombatant(context.TODO(), test.req)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.ErrorIs(err, test.expErr)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expResp, gotResp)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestServiceRollInitiative(t *testing.T) {
+	t0 := time.Now().UTC()
+	u := &model.User{ID: "user-id", Name: "Bilbo", RoomID: "room-id"}
+	current := func() *model.Initiative {
+		return &model.Initiative{
+			RoomID: "room-id",
+			Round:  1,
+			Combatants: []model.InitiativeCombatant{
+				{ID: "c1", Name: "Goblin", Initiative: 18},
+				{ID: "c2", Name: "Bilbo", UserID: "user-id", Initiative: 10},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		mock    func(m mocks)
+		req     initiative.RollInitiativeRequest
+		expResp *initiative.RollInitiativeResponse
+		expErr  error
+	}{
+		"Having a request without expression, should fail.": {
+			mock:   func(m mocks) {},
+			req:    initiative.RollInitiativeRequest{RoomID: "room-id", UserID: "user-id", CombatantID: "c2"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request on an archived room, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id", Archived: true}, nil)
+			},
+			req:    initiative.RollInitiativeRequest{RoomID: "room-id", UserID: "user-id", CombatantID: "c2", Expression: "1d20"},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having a missing combatant, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.mi.On("GetInitiative", mock.Anything, "room-id").Once().Return(current(), nil)
+			},
+			req:    initiative.RollInitiativeRequest{RoomID: "room-id", UserID: "user-id", CombatantID: "c3", Expression: "1d20"},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Rolling the initiative before the first turn passes, should sort the combatants and keep the turn on the first one.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.mi.On("GetInitiative", mock.Anything, "room-id").Once().Return(current(), nil)
+				expRoll := dice.CreateDiceRollRequest{RoomID: "room-id", UserID: "user-id", Expression: "1d20+5", Label: "Initiative: Bilbo"}
+				m.md.On("CreateDiceRoll", mock.Anything, expRoll).Once().Return(&dice.CreateDiceRollResponse{DiceRoll: model.DiceRoll{ID: "dr1", Total: 22}}, nil)
+				exp := model.Initiative{
+					RoomID:    "room-id",
+					UpdatedAt: t0,
+					Round:     1,
+					Combatants: []model.InitiativeCombatant{
+						{ID: "c2", Name: "Bilbo", UserID: "user-id", Initiative: 22, DiceRollID: "dr1"},
+						{ID: "c1", Name: "Goblin", Initiative: 18},
+					},
+				}
+				m.mi.On("SaveInitiative", mock.Anything, exp).Once().Return(nil)
+				m.mn.On("NotifyInitiativeUpdated", mock.Anything, model.EventInitiativeUpdated{Initiative: exp}).Once().Return(nil)
+			},
+			req: initiative.RollInitiativeRequest{RoomID: "room-id", UserID: "user-id", CombatantID: "c2", Expression: "1d20+5"},
+			expResp: &initiative.RollInitiativeResponse{
+				Initiative: model.Initiative{
+					RoomID:    "room-id",
+					UpdatedAt: t0,
+					Round:     1,
+					Combatants: []model.InitiativeCombatant{
+						{ID: "c2", Name: "Bilbo", UserID: "user-id", Initiative: 22, DiceRollID: "dr1"},
+						{ID: "c1", Name: "Goblin", Initiative: 18},
+					},
+				},
+				DiceRoll: model.DiceRoll{ID: "dr1", Total: 22},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := newMocks()
+			test.mock(m)
+			svc := newService(t, m, t0)
+
+			gotResp, err := svc.RollInitiative(context.TODO(), test.req)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.ErrorIs(err, test.expErr)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expResp, gotResp)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestServiceRemoveCombatant(t *testing.T) {
+	t0 := time.Now().UTC()
+	u := &model.User{ID: "user-id", RoomID: "room-id"}
+	current := func() *model.Initiative {
+		return &model.Initiative{
+			RoomID: "room-id",
+			Round:  3,
+			Turn:   2,
+			Combatants: []model.InitiativeCombatant{
+				{ID: "c1", Name: "Goblin", Initiative: 18},
+				{ID: "c2", Name: "Orc", Initiative: 12},
+				{ID: "c3", Name: "Troll", Initiative: 5},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		mock          func(m mocks)
+		req           initiative.RemoveCombatantRequest
+		expInitiative model.Initiative
+		expErr        error
+	}{
+		"Having a request on an archived room, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id", Archived: true}, nil)
+			},
+			req:    initiative.RemoveCombatantRequest{RoomID: "room-id", UserID: "user-id", CombatantID: "c1"},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having a missing combatant, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.mi.On("GetInitiative", mock.Anything, "room-id").Once().Return(current(), nil)
+			},
+			req:    initiative.RemoveCombatantRequest{RoomID: "room-id", UserID: "user-id", CombatantID: "c4"},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Removing a combatant before the current turn should keep the turn on the same combatant.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.mi.On("GetInitiative", mock.Anything, "room-id").Once().Return(current(), nil)
+				m.mi.On("SaveInitiative", mock.Anything, mock.Anything).Once().Return(nil)
+				m.mn.On("NotifyInitiativeUpdated", mock.Anything, mock.Anything).Once().Return(nil)
+			},
+			req: initiative.RemoveCombatantRequest{RoomID: "room-id", UserID: "user-id", CombatantID: "c1"},
+			expInitiative: model.Initiative{
+				RoomID:    "room-id",
+				UpdatedAt: t0,
+				Round:     3,
+				Turn:      1,
+				Combatants: []model.InitiativeCombatant{
+					{ID: "c2", Name: "Orc", Initiative: 12},
+					{ID: "c3", Name: "Troll", Initiative: 5},
+				},
+			},
+		},
+
+		"Removing the last combatant with the current turn should move the turn to the first combatant.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.mi.On("GetInitiative", mock.Anything, "room-id").Once().Return(current(), nil)
+				m.mi.On("SaveInitiative", mock.Anything, mock.Anything).Once().Return(nil)
+				m.mn.On("NotifyInitiativeUpdated", mock.Anything, mock.Anything).Once().Return(nil)
+			},
+			req: initiative.RemoveCombatantRequest{RoomID: "room-id", UserID: "user-id", CombatantID: "c3"},
+			expInitiative: model.Initiative{
+				RoomID:    "room-id",
+				UpdatedAt: t0,
+				Round:     3,
+				Turn:      0,
+				Combatants: []model.InitiativeCombatant{
+					{ID: "c1", Name: "Goblin", Initiative: 18},
+					{ID: "c2", Name: "Orc", Initiative: 12},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := newMocks()
+			test.mock(m)
+			svc := newService(t, m, t0)
+
+			gotResp, err := svc.RemoveCombatant(context.TODO(), test.req)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.ErrorIs(err, test.expErr)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expInitiative, gotResp.Initiative)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestServiceTurns(t *testing.T) {
+	t0 := time.Now().UTC()
+	u := &model.User{ID: "user-id", RoomID: "room-id"}
+	combatants := []model.InitiativeCombatant{
+		{ID: "c1", Name: "Goblin", Initiative: 18},
+		{ID: "c2", Name: "Orc", Initiative: 12},
+	}
+
+	tests := map[string]struct {
+		previous      bool
+		archived      bool
+		initiative    *model.Initiative
+		expInitiative model.Initiative
+		expErr        error
+	}{
+		"Moving to the next turn on an archived room, should fail.": {
+			archived:   true,
+			initiative: &model.Initiative{RoomID: "room-id", Round: 1, Turn: 0, Combatants: combatants},
+			expErr:     internalerrors.ErrNotAllowed,
+		},
+
+		"Moving to the previous turn on an archived room, should fail.": {
+			previous:   true,
+			archived:   true,
+			initiative: &model.Initiative{RoomID: "room-id", Round: 1, Turn: 1, Combatants: combatants},
+			expErr:     internalerrors.ErrNotAllowed,
+		},
+
+		"Moving to the next turn of a combat without combatants, should fail.": {
+			initiative: &model.Initiative{RoomID: "room-id"},
+			expErr:     internalerrors.ErrNotValid,
+		},
+
+		"Moving to the next turn should move the turn to the next combatant.": {
+			initiative:    &model.Initiative{RoomID: "room-id", Round: 1, Turn: 0, Combatants: combatants},
+			expInitiative: model.Initiative{RoomID: "room-id", UpdatedAt: t0, Round: 1, Turn: 1, Combatants: combatants},
+		},
+
+		"Moving to the next turn from the last combatant should start a new round.": {
+			initiative:    &model.Initiative{RoomID: "room-id", Round: 1, Turn: 1, Combatants: combatants},
+			expInitiative: model.Initiative{RoomID: "room-id", UpdatedAt: t0, Round: 2, Turn: 0, Combatants: combatants},
+		},
+
+		"Moving to the previous turn should move the turn to the previous combatant.": {
+			previous:      true,
+			initiative:    &model.Initiative{RoomID: "room-id", Round: 1, Turn: 1, Combatants: combatants},
+			expInitiative: model.Initiative{RoomID: "room-id", UpdatedAt: t0, Round: 1, Turn: 0, Combatants: combatants},
+		},
+
+		"Moving to the previous turn from the first combatant should go back to the previous round.": {
+			previous:      true,
+			initiative:    &model.Initiative{RoomID: "room-id", Round: 2, Turn: 0, Combatants: combatants},
+			expInitiative: model.Initiative{RoomID: "room-id", UpdatedAt: t0, Round: 1, Turn: 1, Combatants: combatants},
+		},
+
+		"Moving to the previous turn on the first turn of the combat, should fail.": {
+			previous:   true,
+			initiative: &model.Initiative{RoomID: "room-id", Round: 1, Turn: 0, Combatants: combatants},
+			expErr:     internalerrors.ErrNotValid,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := newMocks()
+			m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+			m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id", Archived: test.archived}, nil)
+			if !test.archived {
+				m.mi.On("GetInitiative", mock.Anything, "room-id").Once().Return(test.initiative, nil)
+			}
+			if test.expErr == nil {
+				m.mi.On("SaveInitiative", mock.Anything, test.expInitiative).Once().Return(nil)
+				m.mn.On("NotifyInitiativeUpdated", mock.Anything, model.EventInitiativeUpdated{Initiative: test.expInitiative}).Once().Return(nil)
+			}
+			svc := newService(t, m, t0)
+
+			var gotInitiative model.Initiative
+			var err error
+			if test.previous {
+				var resp *initiative.PreviousTurnResponse
+				resp, err = svc.PreviousTurn(context.TODO(), initiative.PreviousTurnRequest{RoomID: "room-id", UserID: "user-id"})
+				if resp != nil {
+					gotInitiative = resp.Initiative
+				}
+			} else {
+				var resp *initiative.NextTurnResponse
+				resp, err = svc.NextTurn(context.TODO(), initiative.NextTurnRequest{RoomID: "room-id", UserID: "user-id"})
+				if resp != nil {
+					gotInitiative = resp.Initiative
+				}
+			}
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.ErrorIs(err, test.expErr)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expInitiative, gotInitiative)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestServiceEndCombat(t *testing.T) {
+	t0 := time.Now().UTC()
+	u := &model.User{ID: "user-id", RoomID: "room-id"}
+
+	tests := map[string]struct {
+		mock   func(m mocks)
+		req    initiative.EndCombatRequest
+		expErr error
+	}{
+		"Having a request without user, should fail.": {
+			mock:   func(m mocks) {},
+			req:    initiative.EndCombatRequest{RoomID: "room-id"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Ending a combat on an archived room, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id", Archived: true}, nil)
+			},
+			req:    initiative.EndCombatRequest{RoomID: "room-id", UserID: "user-id"},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Ending a missing combat, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.mi.On("DeleteInitiative", mock.Anything, "room-id").Once().Return(internalerrors.ErrMissing)
+			},
+			req:    initiative.EndCombatRequest{RoomID: "room-id", UserID: "user-id"},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Ending a combat should delete the tracker and notify an empty one.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.mi.On("DeleteInitiative", mock.Anything, "room-id").Once().Return(nil)
+				exp := model.EventInitiativeUpdated{Initiative: model.Initiative{RoomID: "room-id", UpdatedAt: t0}}
+				m.mn.On("NotifyInitiativeUpdated", mock.Anything, exp).Once().Return(nil)
+			},
+			req: initiative.EndCombatRequest{RoomID: "room-id", UserID: "user-id"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := newMocks()
+			test.mock(m)
+			svc := newService(t, m, t0)
+
+			err := svc.EndCombat(context.TODO(), test.req)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.ErrorIs(err, test.expErr)
+			} else {
+				assert.NoError(err)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
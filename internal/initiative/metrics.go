@@ -0,0 +1,90 @@
+package initiative
+
+import (
+	"context"
+	"time"
+)
+
+// ServiceMetricsRecorder knows how to record Service metrics.
+type ServiceMetricsRecorder interface {
+	MeasureInitiativeServiceOpDuration(ctx context.Context, op string, success bool, t time.Duration)
+}
+
+//go:generate mockery --case underscore --output initiativemock --outpkg initiativemock --name ServiceMetricsRecorder
+
+type measuredService struct {
+	rec  ServiceMetricsRecorder
+	next Service
+}
+
+// NewMeasureService wraps a service and measures.
+func NewMeasureService(rec ServiceMetricsRecorder, next Service) Service {
+	return &measuredService{
+		rec:  rec,
+		next: next,
+	}
+}
+
+func (m measuredService) GetInitiative(ctx context.Context, req GetInitiativeRequest) (resp *GetInitiativeResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureInitiativeServiceOpDuration(ctx, "GetInitiative", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.GetInitiative(ctx, req)
+}
+
+func (m measuredService) AddCombatant(ctx context.Context, req AddCombatantRequest) (resp *AddCombatantResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureInitiativeServiceOpDuration(ctx, "AddCombatant", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.AddCombatant(ctx, req)
+}
+
+func (m measuredService) RollInitiative(ctx context.Context, req RollInitiativeRequest) (resp *RollInitiativeResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureInitiativeServiceOpDuration(ctx, "RollInitiative", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.RollInitiative(ctx, req)
+}
+
+func (m measuredService) RemoveCombatant(ctx context.Context, req RemoveCombatantRequest) (resp *RemoveCombatantResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureInitiativeServiceOpDuration(ctx, "RemoveCombatant", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.RemoveCombatant(ctx, req)
+}
+
+func (m measuredService) NextTurn(ctx context.Context, req NextTurnRequest) (resp *NextTurnResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureInitiativeServiceOpDuration(ctx, "NextTurn", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.NextTurn(ctx, req)
+}
+
+func (m measuredService) PreviousTurn(ctx context.Context, req PreviousTurnRequest) (resp *PreviousTurnResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureInitiativeServiceOpDuration(ctx, "PreviousTurn", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.PreviousTurn(ctx, req)
+}
+
+func (m measuredService) EndCombat(ctx context.Context, req EndCombatRequest) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureInitiativeServiceOpDuration(ctx, "EndCombat", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.EndCombat(ctx, req)
+}
+
+func (m measuredService) SubscribeInitiativeUpdated(ctx context.Context, req SubscribeInitiativeUpdatedRequest) (resp *SubscribeInitiativeUpdatedResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureInitiativeServiceOpDuration(ctx, "SubscribeInitiativeUpdated", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.SubscribeInitiativeUpdated(ctx, req)
+}
@@ -9,4 +9,10 @@ var (
 	ErrAlreadyExists = errors.New("already exists")
 	// ErrMissing is used when a resource is missing.
 	ErrMissing = errors.New("is missing")
+	// ErrRateLimited is used when an action exceeded its allowed rate.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrNotAllowed is used when the credentials of an action are wrong or missing.
+	ErrNotAllowed = errors.New("not allowed")
+	// ErrConflict is used when a resource was modified concurrently by someone else.
+	ErrConflict = errors.New("conflict")
 )
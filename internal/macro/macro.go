@@ -0,0 +1,446 @@
+package macro
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/rollify/rollify/internal/dice/notation"
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/log"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/room/roomcheck"
+	"github.com/rollify/rollify/internal/storage"
+)
+
+// Service is the application service of the saved dice roll macros logic.
+type Service interface {
+	// CreateMacro saves a dice roll macro of a user, optionally shared with the room.
+	CreateMacro(ctx context.Context, r CreateMacroRequest) (*CreateMacroResponse, error)
+	// GetMacro gets a macro that can be used by the user.
+	GetMacro(ctx context.Context, r GetMacroRequest) (*GetMacroResponse, error)
+	// ListMacros lists the macros of a room that can be used by the user.
+	ListMacros(ctx context.Context, r ListMacrosRequest) (*ListMacrosResponse, error)
+	// UpdateMacro updates a macro, only its owner can update it.
+	UpdateMacro(ctx context.Context, r UpdateMacroRequest) (*UpdateMacroResponse, error)
+	// DeleteMacro deletes a macro, only its owner can delete it.
+	DeleteMacro(ctx context.Context, r DeleteMacroRequest) error
+}
+
+//go:generate mockery --case underscore --output macromock --outpkg macromock --name Service
+
+// ServiceConfig is the service configuration.
+type ServiceConfig struct {
+	MacroRepository storage.MacroRepository
+	RoomRepository  storage.RoomRepository
+	UserRepository  storage.UserRepository
+	Logger          log.Logger
+	IDGenerator     func() string
+	TimeNowFunc     func() time.Time
+}
+
+func (c *ServiceConfig) defaults() error {
+	if c.MacroRepository == nil {
+		return fmt.Errorf("config.MacroRepository is required")
+	}
+
+	if c.RoomRepository == nil {
+		return fmt.Errorf("config.RoomRepository is required")
+	}
+
+	if c.UserRepository == nil {
+		return fmt.Errorf("config.UserRepository is required")
+	}
+
+	if c.Logger == nil {
+		c.Logger = log.Dummy
+	}
+	c.Logger = c.Logger.WithKV(log.KV{"svc": "macro.Service"})
+
+	if c.IDGenerator == nil {
+		c.IDGenerator = func() string { return uuid.New().String() }
+	}
+
+	if c.TimeNowFunc == nil {
+		c.TimeNowFunc = time.Now
+	}
+
+	return nil
+}
+
+type service struct {
+	macroRepo storage.MacroRepository
+	roomRepo  storage.RoomRepository
+	userRepo  storage.UserRepository
+	logger    log.Logger
+	idGen     func() string
+	timeNow   func() time.Time
+}
+
+// NewService returns a new macro.Service.
+func NewService(cfg ServiceConfig) (Service, error) {
+	err := cfg.defaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return service{
+		macroRepo: cfg.MacroRepository,
+		roomRepo:  cfg.RoomRepository,
+		userRepo:  cfg.UserRepository,
+		logger:    cfg.Logger,
+		idGen:     cfg.IDGenerator,
+		timeNow:   cfg.TimeNowFunc,
+	}, nil
+}
+
+const (
+	maxNameLength       = 100
+	maxExpressionLength = 255
+)
+
+// validateDefinition validates the macro name and dice roll definition.
+func validateDefinition(name, expression string) error {
+	if name == "" {
+		return fmt.Errorf("config.Name is required")
+	}
+
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return fmt.Errorf("max config.Name length is %d", maxNameLength)
+	}
+
+	if expression == "" {
+		return fmt.Errorf("config.Expression is required")
+	}
+
+	if len(expression) > maxExpressionLength {
+		return fmt.Errorf("max config.Expression length is %d", maxExpressionLength)
+	}
+
+	exp, err := notation.Parse(expression)
+	if err != nil {
+		return fmt.Errorf("invalid config.Expression: %w", err)
+	}
+
+	for _, d := range exp.Dice() {
+		_, err := model.NewDieType(d.Sides)
+		if err != nil {
+			return fmt.Errorf("invalid config.Expression: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// CreateMacroRequest is the request for CreateMacro.
+type CreateMacroRequest struct {
+	RoomID     string
+	UserID     string
+	Name       string
+	Expression string
+	// Shared makes the macro usable by all the room users.
+	Shared bool
+}
+
+func (r CreateMacroRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	if r.UserID == "" {
+		return fmt.Errorf("config.UserID is required")
+	}
+
+	return validateDefinition(r.Name, r.Expression)
+}
+
+// CreateMacroResponse is the response for CreateMacro.
+type CreateMacroResponse struct {
+	Macro model.Macro
+}
+
+func (s service) CreateMacro(ctx context.Context, r CreateMacroRequest) (*CreateMacroResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	_, err = s.getRoomUser(ctx, r.RoomID, r.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = roomcheck.Writable(ctx, s.roomRepo, r.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	m := model.Macro{
+		ID:         s.idGen(),
+		CreatedAt:  s.timeNow().UTC(),
+		RoomID:     r.RoomID,
+		UserID:     r.UserID,
+		Name:       r.Name,
+		Expression: r.Expression,
+		Shared:     r.Shared,
+	}
+	err = s.macroRepo.CreateMacro(ctx, m)
+	if err != nil {
+		return nil, fmt.Errorf("could not store macro: %w", err)
+	}
+
+	return &CreateMacroResponse{
+		Macro: m,
+	}, nil
+}
+
+// GetMacroRequest is the request for GetMacro.
+type GetMacroRequest struct {
+	MacroID string
+	UserID  string
+}
+
+func (r GetMacroRequest) validate() error {
+	if r.MacroID == "" {
+		return fmt.Errorf("config.MacroID is required")
+	}
+
+	if r.UserID == "" {
+		return fmt.Errorf("config.UserID is required")
+	}
+
+	return nil
+}
+
+// GetMacroResponse is the response for GetMacro.
+type GetMacroResponse struct {
+	Macro model.Macro
+}
+
+func (s service) GetMacro(ctx context.Context, r GetMacroRequest) (*GetMacroResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	m, err := s.getUsableMacro(ctx, r.MacroID, r.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetMacroResponse{
+		Macro: *m,
+	}, nil
+}
+
+// ListMacrosRequest is the request for ListMacros.
+type ListMacrosRequest struct {
+	RoomID string
+	// UserID lists the user macros and the room shared ones, if empty only the shared ones are listed.
+	UserID string
+}
+
+func (r ListMacrosRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	return nil
+}
+
+// ListMacrosResponse is the response for ListMacros.
+type ListMacrosResponse struct {
+	Macros []model.Macro
+}
+
+func (s service) ListMacros(ctx context.Context, r ListMacrosRequest) (*ListMacrosResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	if r.UserID != "" {
+		_, err = s.getRoomUser(ctx, r.RoomID, r.UserID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		exists, err := s.roomRepo.RoomExists(ctx, r.RoomID)
+		if err != nil {
+			return nil, fmt.Errorf("could not check if room exists: %w", err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("room does not exists: %w", internalerrors.ErrNotValid)
+		}
+	}
+
+	ms, err := s.macroRepo.ListMacros(ctx, storage.ListMacrosOpts{RoomID: r.RoomID, UserID: r.UserID})
+	if err != nil {
+		return nil, fmt.Errorf("could not list macros: %w", err)
+	}
+
+	return &ListMacrosResponse{
+		Macros: ms.Items,
+	}, nil
+}
+
+// UpdateMacroRequest is the request for UpdateMacro.
+type UpdateMacroRequest struct {
+	MacroID    string
+	UserID     string
+	Name       string
+	Expression string
+	Shared     bool
+}
+
+func (r UpdateMacroRequest) validate() error {
+	if r.MacroID == "" {
+		return fmt.Errorf("config.MacroID is required")
+	}
+
+	if r.UserID == "" {
+		return fmt.Errorf("config.UserID is required")
+	}
+
+	return validateDefinition(r.Name, r.Expression)
+}
+
+// UpdateMacroResponse is the response for UpdateMacro.
+type UpdateMacroResponse struct {
+	Macro model.Macro
+}
+
+func (s service) UpdateMacro(ctx context.Context, r UpdateMacroRequest) (*UpdateMacroResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	m, err := s.getOwnedMacro(ctx, r.MacroID, r.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = roomcheck.Writable(ctx, s.roomRepo, m.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	m.Name = r.Name
+	m.Expression = r.Expression
+	m.Shared = r.Shared
+	err = s.macroRepo.UpdateMacro(ctx, *m)
+	if err != nil {
+		return nil, fmt.Errorf("could not update macro: %w", err)
+	}
+
+	return &UpdateMacroResponse{
+		Macro: *m,
+	}, nil
+}
+
+// DeleteMacroRequest is the request for DeleteMacro.
+type DeleteMacroRequest struct {
+	MacroID string
+	UserID  string
+}
+
+func (r DeleteMacroRequest) validate() error {
+	if r.MacroID == "" {
+		return fmt.Errorf("config.MacroID is required")
+	}
+
+	if r.UserID == "" {
+		return fmt.Errorf("config.UserID is required")
+	}
+
+	return nil
+}
+
+func (s service) DeleteMacro(ctx context.Context, r DeleteMacroRequest) error {
+	err := r.validate()
+	if err != nil {
+		return fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	m, err := s.getOwnedMacro(ctx, r.MacroID, r.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = roomcheck.Writable(ctx, s.roomRepo, m.RoomID)
+	if err != nil {
+		return err
+	}
+
+	err = s.macroRepo.DeleteMacro(ctx, r.MacroID)
+	if err != nil {
+		return fmt.Errorf("could not delete macro: %w", err)
+	}
+
+	return nil
+}
+
+// getRoomUser gets the user checking it's from the room.
+func (s service) getRoomUser(ctx context.Context, roomID, userID string) (*model.User, error) {
+	u, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.RoomID != roomID {
+		return nil, fmt.Errorf("user is not from the room: %w", internalerrors.ErrNotValid)
+	}
+
+	return u, nil
+}
+
+func (s service) getUser(ctx context.Context, userID string) (*model.User, error) {
+	u, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, internalerrors.ErrMissing) {
+			return nil, fmt.Errorf("user does not exists: %w", internalerrors.ErrNotValid)
+		}
+		return nil, fmt.Errorf("could not get user: %w", err)
+	}
+
+	return u, nil
+}
+
+// getUsableMacro gets the macro checking the user can use it, the macros that can't be
+// used by the user are handled as missing, so they are not leaked.
+func (s service) getUsableMacro(ctx context.Context, macroID, userID string) (*model.Macro, error) {
+	u, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := s.macroRepo.GetMacro(ctx, macroID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get macro: %w", err)
+	}
+
+	if !m.UsableBy(*u) {
+		return nil, fmt.Errorf("macro can't be used by the user: %w", internalerrors.ErrMissing)
+	}
+
+	return m, nil
+}
+
+// getOwnedMacro gets the macro checking the user is its owner.
+func (s service) getOwnedMacro(ctx context.Context, macroID, userID string) (*model.Macro, error) {
+	m, err := s.getUsableMacro(ctx, macroID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.UserID != userID {
+		return nil, fmt.Errorf("only the macro owner can modify it: %w", internalerrors.ErrNotValid)
+	}
+
+	return m, nil
+}
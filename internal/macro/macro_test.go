@@ -0,0 +1,502 @@
+package macro_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/macro"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+	"github.com/rollify/rollify/internal/storage/storagemock"
+)
+
+type mocks struct {
+	mm *storagemock.MacroRepository
+	mr *storagemock.RoomRepository
+	mu *storagemock.UserRepository
+}
+
+func TestServiceCreateMacro(t *testing.T) {
+	t0 := time.Now().UTC()
+	u := &model.User{ID: "user-id", RoomID: "room-id"}
+
+	tests := map[string]struct {
+		mock    func(m mocks)
+		req     macro.CreateMacroRequest
+		expResp *macro.CreateMacroResponse
+		expErr  bool
+	}{
+		"Having a macro without room, should fail.": {
+			mock:   func(m mocks) {},
+			req:    macro.CreateMacroRequest{UserID: "user-id", Name: "Attack", Expression: "1d20+5"},
+			expErr: true,
+		},
+
+		"Having a macro without user, should fail.": {
+			mock:   func(m mocks) {},
+			req:    macro.CreateMacroRequest{RoomID: "room-id", Name: "Attack", Expression: "1d20+5"},
+			expErr: true,
+		},
+
+		"Having a macro without name, should fail.": {
+			mock:   func(m mocks) {},
+			req:    macro.CreateMacroRequest{RoomID: "room-id", UserID: "user-id", Expression: "1d20+5"},
+			expErr: true,
+		},
+
+		"Having a macro without expression, should fail.": {
+			mock:   func(m mocks) {},
+			req:    macro.CreateMacroRequest{RoomID: "room-id", UserID: "user-id", Name: "Attack"},
+			expErr: true,
+		},
+
+		"Having a macro with an invalid expression, should fail.": {
+			mock:   func(m mocks) {},
+			req:    macro.CreateMacroRequest{RoomID: "room-id", UserID: "user-id", Name: "Attack", Expression: "1d20+"},
+			expErr: true,
+		},
+
+		"Having a macro with an expression with unsupported dice, should fail.": {
+			mock:   func(m mocks) {},
+			req:    macro.CreateMacroRequest{RoomID: "room-id", UserID: "user-id", Name: "Attack", Expression: "1d1"},
+			expErr: true,
+		},
+
+		"Having a macro of a missing user, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(nil, internalerrors.ErrMissing)
+			},
+			req:    macro.CreateMacroRequest{RoomID: "room-id", UserID: "user-id", Name: "Attack", Expression: "1d20+5"},
+			expErr: true,
+		},
+
+		"Having a macro of a user from another room, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(&model.User{ID: "user-id", RoomID: "other-room"}, nil)
+			},
+			req:    macro.CreateMacroRequest{RoomID: "room-id", UserID: "user-id", Name: "Attack", Expression: "1d20+5"},
+			expErr: true,
+		},
+
+		"Having an error while storing the macro, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.mm.On("CreateMacro", mock.Anything, mock.Anything).Once().Return(errors.New("whatever"))
+			},
+			req:    macro.CreateMacroRequest{RoomID: "room-id", UserID: "user-id", Name: "Attack", Expression: "1d20+5"},
+			expErr: true,
+		},
+
+		"Having a macro on an archived room, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id", Archived: true}, nil)
+			},
+			req:    macro.CreateMacroRequest{RoomID: "room-id", UserID: "user-id", Name: "Attack", Expression: "1d20+5"},
+			expErr: true,
+		},
+
+		"Having a macro, should store the macro.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				exp := model.Macro{ID: "test", CreatedAt: t0, RoomID: "room-id", UserID: "user-id", Name: "Attack", Expression: "1d20+5", Shared: true}
+				m.mm.On("CreateMacro", mock.Anything, exp).Once().Return(nil)
+			},
+			req: macro.CreateMacroRequest{RoomID: "room-id", UserID: "user-id", Name: "Attack", Expression: "1d20+5", Shared: true},
+			expResp: &macro.CreateMacroResponse{
+				Macro: model.Macro{ID: "test", CreatedAt: t0, RoomID: "room-id", UserID: "user-id", Name: "Attack", Expression: "1d20+5", Shared: true},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			m := mocks{mm: &storagemock.MacroRepository{}, mr: &storagemock.RoomRepository{}, mu: &storagemock.UserRepository{}}
+			test.mock(m)
+
+			// Prepare.
+			svc, err := macro.NewService(macro.ServiceConfig{
+				MacroRepository: m.mm,
+				RoomRepository:  m.mr,
+				UserRepository:  m.mu,
+				IDGenerator:     func() string { return "test" },
+				TimeNowFunc:     func() time.Time { return t0 },
+			})
+			require.NoError(err)
+
+			// Execute.
+			gotResp, err := svc.CreateMacro(context.TODO(), test.req)
+
+			// Check.
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expResp, gotResp)
+			}
+			m.mm.AssertExpectations(t)
+			m.mr.AssertExpectations(t)
+			m.mu.AssertExpectations(t)
+		})
+	}
+}
+
+func TestServiceGetMacro(t *testing.T) {
+	shared := &model.Macro{ID: "macro-id", RoomID: "room-id", UserID: "user2", Name: "Attack", Expression: "1d20+5", Shared: true}
+	private := &model.Macro{ID: "macro-id", RoomID: "room-id", UserID: "user2", Name: "Attack", Expression: "1d20+5"}
+
+	tests := map[string]struct {
+		mock    func(m mocks)
+		req     macro.GetMacroRequest
+		expResp *macro.GetMacroResponse
+		expErr  error
+	}{
+		"Having a request without macro, should fail.": {
+			mock:   func(m mocks) {},
+			req:    macro.GetMacroRequest{UserID: "user1"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request without user, should fail.": {
+			mock:   func(m mocks) {},
+			req:    macro.GetMacroRequest{MacroID: "macro-id"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a missing macro, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user1").Once().Return(&model.User{ID: "user1", RoomID: "room-id"}, nil)
+				m.mm.On("GetMacro", mock.Anything, "macro-id").Once().Return(nil, internalerrors.ErrMissing)
+			},
+			req:    macro.GetMacroRequest{MacroID: "macro-id", UserID: "user1"},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Having a private macro of another user, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user1").Once().Return(&model.User{ID: "user1", RoomID: "room-id"}, nil)
+				m.mm.On("GetMacro", mock.Anything, "macro-id").Once().Return(private, nil)
+			},
+			req:    macro.GetMacroRequest{MacroID: "macro-id", UserID: "user1"},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Having a shared macro of another room, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user1").Once().Return(&model.User{ID: "user1", RoomID: "other-room"}, nil)
+				m.mm.On("GetMacro", mock.Anything, "macro-id").Once().Return(shared, nil)
+			},
+			req:    macro.GetMacroRequest{MacroID: "macro-id", UserID: "user1"},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Having a shared macro of another user, should return the macro.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user1").Once().Return(&model.User{ID: "user1", RoomID: "room-id"}, nil)
+				m.mm.On("GetMacro", mock.Anything, "macro-id").Once().Return(shared, nil)
+			},
+			req:     macro.GetMacroRequest{MacroID: "macro-id", UserID: "user1"},
+			expResp: &macro.GetMacroResponse{Macro: *shared},
+		},
+
+		"Having a private macro of the user, should return the macro.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user2").Once().Return(&model.User{ID: "user2", RoomID: "room-id"}, nil)
+				m.mm.On("GetMacro", mock.Anything, "macro-id").Once().Return(private, nil)
+			},
+			req:     macro.GetMacroRequest{MacroID: "macro-id", UserID: "user2"},
+			expResp: &macro.GetMacroResponse{Macro: *private},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			m := mocks{mm: &storagemock.MacroRepository{}, mr: &storagemock.RoomRepository{}, mu: &storagemock.UserRepository{}}
+			test.mock(m)
+
+			// Prepare.
+			svc, err := macro.NewService(macro.ServiceConfig{
+				MacroRepository: m.mm,
+				RoomRepository:  m.mr,
+				UserRepository:  m.mu,
+			})
+			require.NoError(err)
+
+			// Execute.
+			gotResp, err := svc.GetMacro(context.TODO(), test.req)
+
+			// Check.
+			if test.expErr != nil {
+				assert.ErrorIs(err, test.expErr)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expResp, gotResp)
+			}
+		})
+	}
+}
+
+func TestServiceListMacros(t *testing.T) {
+	ms := &storage.MacroList{Items: []model.Macro{
+		{ID: "m1", RoomID: "room-id", UserID: "user1", Name: "Attack", Expression: "1d20+5", Shared: true},
+		{ID: "m2", RoomID: "room-id", UserID: "user2", Name: "Fireball", Expression: "8d6"},
+	}}
+
+	tests := map[string]struct {
+		mock    func(m mocks)
+		req     macro.ListMacrosRequest
+		expResp *macro.ListMacrosResponse
+		expErr  bool
+	}{
+		"Having a request without room, should fail.": {
+			mock:   func(m mocks) {},
+			req:    macro.ListMacrosRequest{},
+			expErr: true,
+		},
+
+		"Having a request of a missing room, should fail.": {
+			mock: func(m mocks) {
+				m.mr.On("RoomExists", mock.Anything, "room-id").Once().Return(false, nil)
+			},
+			req:    macro.ListMacrosRequest{RoomID: "room-id"},
+			expErr: true,
+		},
+
+		"Having a request with a user from another room, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user2").Once().Return(&model.User{ID: "user2", RoomID: "other-room"}, nil)
+			},
+			req:    macro.ListMacrosRequest{RoomID: "room-id", UserID: "user2"},
+			expErr: true,
+		},
+
+		"Having an error while listing the macros, should fail.": {
+			mock: func(m mocks) {
+				m.mr.On("RoomExists", mock.Anything, "room-id").Once().Return(true, nil)
+				m.mm.On("ListMacros", mock.Anything, mock.Anything).Once().Return(nil, errors.New("whatever"))
+			},
+			req:    macro.ListMacrosRequest{RoomID: "room-id"},
+			expErr: true,
+		},
+
+		"Having a request without user, should list the room shared macros.": {
+			mock: func(m mocks) {
+				m.mr.On("RoomExists", mock.Anything, "room-id").Once().Return(true, nil)
+				m.mm.On("ListMacros", mock.Anything, storage.ListMacrosOpts{RoomID: "room-id"}).Once().Return(ms, nil)
+			},
+			req:     macro.ListMacrosRequest{RoomID: "room-id"},
+			expResp: &macro.ListMacrosResponse{Macros: ms.Items},
+		},
+
+		"Having a request with user, should list the user and room shared macros.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user2").Once().Return(&model.User{ID: "user2", RoomID: "room-id"}, nil)
+				m.mm.On("ListMacros", mock.Anything, storage.ListMacrosOpts{RoomID: "room-id", UserID: "user2"}).Once().Return(ms, nil)
+			},
+			req:     macro.ListMacrosRequest{RoomID: "room-id", UserID: "user2"},
+			expResp: &macro.ListMacrosResponse{Macros: ms.Items},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			m := mocks{mm: &storagemock.MacroRepository{}, mr: &storagemock.RoomRepository{}, mu: &storagemock.UserRepository{}}
+			test.mock(m)
+
+			// Prepare.
+			svc, err := macro.NewService(macro.ServiceConfig{
+				MacroRepository: m.mm,
+				RoomRepository:  m.mr,
+				UserRepository:  m.mu,
+			})
+			require.NoError(err)
+
+			// Execute.
+			gotResp, err := svc.ListMacros(context.TODO(), test.req)
+
+			// Check.
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expResp, gotResp)
+			}
+		})
+	}
+}
+
+func TestServiceUpdateMacro(t *testing.T) {
+	user2 := &model.User{ID: "user2", RoomID: "room-id"}
+	stored := func() *model.Macro {
+		return &model.Macro{ID: "macro-id", RoomID: "room-id", UserID: "user2", Name: "Attack", Expression: "1d20+5", Shared: true}
+	}
+
+	tests := map[string]struct {
+		mock    func(m mocks)
+		req     macro.UpdateMacroRequest
+		expResp *macro.UpdateMacroResponse
+		expErr  error
+	}{
+		"Having a request with an invalid expression, should fail.": {
+			mock:   func(m mocks) {},
+			req:    macro.UpdateMacroRequest{MacroID: "macro-id", UserID: "user2", Name: "Fireball", Expression: "8x6"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request of a shared macro from another user, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user1").Once().Return(&model.User{ID: "user1", RoomID: "room-id"}, nil)
+				m.mm.On("GetMacro", mock.Anything, "macro-id").Once().Return(stored(), nil)
+			},
+			req:    macro.UpdateMacroRequest{MacroID: "macro-id", UserID: "user1", Name: "Fireball", Expression: "8d6"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request of the user macro on an archived room, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user2").Once().Return(user2, nil)
+				m.mm.On("GetMacro", mock.Anything, "macro-id").Once().Return(stored(), nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id", Archived: true}, nil)
+			},
+			req:    macro.UpdateMacroRequest{MacroID: "macro-id", UserID: "user2", Name: "Fireball", Expression: "8d6"},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having a request of the user macro, should update the macro.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user2").Once().Return(user2, nil)
+				m.mm.On("GetMacro", mock.Anything, "macro-id").Once().Return(stored(), nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				exp := model.Macro{ID: "macro-id", RoomID: "room-id", UserID: "user2", Name: "Fireball", Expression: "8d6"}
+				m.mm.On("UpdateMacro", mock.Anything, exp).Once().Return(nil)
+			},
+			req: macro.UpdateMacroRequest{MacroID: "macro-id", UserID: "user2", Name: "Fireball", Expression: "8d6"},
+			expResp: &macro.UpdateMacroResponse{
+				Macro: model.Macro{ID: "macro-id", RoomID: "room-id", UserID: "user2", Name: "Fireball", Expression: "8d6"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			m := mocks{mm: &storagemock.MacroRepository{}, mr: &storagemock.RoomRepository{}, mu: &storagemock.UserRepository{}}
+			test.mock(m)
+
+			// Prepare.
+			svc, err := macro.NewService(macro.ServiceConfig{
+				MacroRepository: m.mm,
+				RoomRepository:  m.mr,
+				UserRepository:  m.mu,
+			})
+			require.NoError(err)
+
+			// Execute.
+			gotResp, err := svc.UpdateMacro(context.TODO(), test.req)
+
+			// Check.
+			if test.expErr != nil {
+				assert.ErrorIs(err, test.expErr)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expResp, gotResp)
+			}
+			m.mm.AssertExpectations(t)
+		})
+	}
+}
+
+func TestServiceDeleteMacro(t *testing.T) {
+	stored := &model.Macro{ID: "macro-id", RoomID: "room-id", UserID: "user2", Name: "Attack", Expression: "1d20+5", Shared: true}
+
+	tests := map[string]struct {
+		mock   func(m mocks)
+		req    macro.DeleteMacroRequest
+		expErr error
+	}{
+		"Having a request without macro, should fail.": {
+			mock:   func(m mocks) {},
+			req:    macro.DeleteMacroRequest{UserID: "user2"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request of a shared macro from another user, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user1").Once().Return(&model.User{ID: "user1", RoomID: "room-id"}, nil)
+				m.mm.On("GetMacro", mock.Anything, "macro-id").Once().Return(stored, nil)
+			},
+			req:    macro.DeleteMacroRequest{MacroID: "macro-id", UserID: "user1"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request of the user macro on an archived room, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user2").Once().Return(&model.User{ID: "user2", RoomID: "room-id"}, nil)
+				m.mm.On("GetMacro", mock.Anything, "macro-id").Once().Return(stored, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id", Archived: true}, nil)
+			},
+			req:    macro.DeleteMacroRequest{MacroID: "macro-id", UserID: "user2"},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having a request of the user macro, should delete the macro.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user2").Once().Return(&model.User{ID: "user2", RoomID: "room-id"}, nil)
+				m.mm.On("GetMacro", mock.Anything, "macro-id").Once().Return(stored, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.mm.On("DeleteMacro", mock.Anything, "macro-id").Once().Return(nil)
+			},
+			req: macro.DeleteMacroRequest{MacroID: "macro-id", UserID: "user2"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			m := mocks{mm: &storagemock.MacroRepository{}, mr: &storagemock.RoomRepository{}, mu: &storagemock.UserRepository{}}
+			test.mock(m)
+
+			// Prepare.
+			svc, err := macro.NewService(macro.ServiceConfig{
+				MacroRepository: m.mm,
+				RoomRepository:  m.mr,
+				UserRepository:  m.mu,
+			})
+			require.NoError(err)
+
+			// Execute.
+			err = svc.DeleteMacro(context.TODO(), test.req)
+
+			// Check.
+			if test.expErr != nil {
+				assert.ErrorIs(err, test.expErr)
+			} else {
+				assert.NoError(err)
+			}
+			m.mm.AssertExpectations(t)
+		})
+	}
+}
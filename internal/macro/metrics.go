@@ -0,0 +1,66 @@
+package macro
+
+import (
+	"context"
+	"time"
+)
+
+// ServiceMetricsRecorder knows how to record Service metrics.
+type ServiceMetricsRecorder interface {
+	MeasureMacroServiceOpDuration(ctx context.Context, op string, success bool, t time.Duration)
+}
+
+//go:generate mockery --case underscore --output macromock --outpkg macromock --name ServiceMetricsRecorder
+
+type measuredService struct {
+	rec  ServiceMetricsRecorder
+	next Service
+}
+
+// NewMeasureService wraps a service and measures.
+func NewMeasureService(rec ServiceMetricsRecorder, next Service) Service {
+	return &measuredService{
+		rec:  rec,
+		next: next,
+	}
+}
+
+func (m measuredService) CreateMacro(ctx context.Context, req CreateMacroRequest) (resp *CreateMacroResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureMacroServiceOpDuration(ctx, "CreateMacro", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.CreateMacro(ctx, req)
+}
+
+func (m measuredService) GetMacro(ctx context.Context, req GetMacroRequest) (resp *GetMacroResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureMacroServiceOpDuration(ctx, "GetMacro", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.GetMacro(ctx, req)
+}
+
+func (m measuredService) ListMacros(ctx context.Context, req ListMacrosRequest) (resp *ListMacrosResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureMacroServiceOpDuration(ctx, "ListMacros", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.ListMacros(ctx, req)
+}
+
+func (m measuredService) UpdateMacro(ctx context.Context, req UpdateMacroRequest) (resp *UpdateMacroResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureMacroServiceOpDuration(ctx, "UpdateMacro", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.UpdateMacro(ctx, req)
+}
+
+func (m measuredService) DeleteMacro(ctx context.Context, req DeleteMacroRequest) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureMacroServiceOpDuration(ctx, "DeleteMacro", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.DeleteMacro(ctx, req)
+}
@@ -9,11 +9,16 @@ import (
 	gohttpmetrics "github.com/slok/go-http-metrics/metrics"
 	gohttpmetricsprom "github.com/slok/go-http-metrics/metrics/prometheus"
 
+	"github.com/rollify/rollify/internal/deck"
 	"github.com/rollify/rollify/internal/dice"
 	"github.com/rollify/rollify/internal/event"
 	"github.com/rollify/rollify/internal/http/apiv1"
+	"github.com/rollify/rollify/internal/initiative"
+	"github.com/rollify/rollify/internal/macro"
 	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle"
 	"github.com/rollify/rollify/internal/room"
+	"github.com/rollify/rollify/internal/stats"
 	"github.com/rollify/rollify/internal/storage"
 	"github.com/rollify/rollify/internal/user"
 )
@@ -33,9 +38,20 @@ type Recorder struct {
 	diceServiceOPDuration           *prometheus.HistogramVec
 	roomServiceOPDuration           *prometheus.HistogramVec
 	userServiceOPDuration           *prometheus.HistogramVec
+	macroServiceOPDuration          *prometheus.HistogramVec
+	initiativeServiceOPDuration     *prometheus.HistogramVec
+	deckServiceOPDuration           *prometheus.HistogramVec
+	oracleServiceOPDuration         *prometheus.HistogramVec
+	statsServiceOPDuration          *prometheus.HistogramVec
 	diceRollRepoOPDuration          *prometheus.HistogramVec
 	roomRepoOPDuration              *prometheus.HistogramVec
 	userRepoOPDuration              *prometheus.HistogramVec
+	customDieTypeRepoOPDuration     *prometheus.HistogramVec
+	serverSeedRepoOPDuration        *prometheus.HistogramVec
+	macroRepoOPDuration             *prometheus.HistogramVec
+	initiativeRepoOPDuration        *prometheus.HistogramVec
+	deckRepoOPDuration              *prometheus.HistogramVec
+	oracleTableRepoOPDuration       *prometheus.HistogramVec
 	notifierOPDuration              *prometheus.HistogramVec
 	subscriberSubscribeOPDuration   *prometheus.HistogramVec
 	subscriberUnsubscribeOPDuration *prometheus.HistogramVec
@@ -84,6 +100,41 @@ func NewRecorder(reg prometheus.Registerer) Recorder {
 			Help:      "The duration of user application service.",
 		}, []string{"op", "success"}),
 
+		macroServiceOPDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prefix,
+			Subsystem: "macro_service",
+			Name:      "operation_duration_seconds",
+			Help:      "The duration of macro application service.",
+		}, []string{"op", "success"}),
+
+		initiativeServiceOPDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prefix,
+			Subsystem: "initiative_service",
+			Name:      "operation_duration_seconds",
+			Help:      "The duration of initiative application service.",
+		}, []string{"op", "success"}),
+
+		deckServiceOPDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prefix,
+			Subsystem: "deck_service",
+			Name:      "operation_duration_seconds",
+			Help:      "The duration of deck application service.",
+		}, []string{"op", "success"}),
+
+		oracleServiceOPDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prefix,
+			Subsystem: "oracle_service",
+			Name:      "operation_duration_seconds",
+			Help:      "The duration of oracle application service.",
+		}, []string{"op", "success"}),
+
+		statsServiceOPDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prefix,
+			Subsystem: "stats_service",
+			Name:      "operation_duration_seconds",
+			Help:      "The duration of stats application service.",
+		}, []string{"op", "success"}),
+
 		diceRollRepoOPDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
 			Namespace: prefix,
 			Subsystem: "dice_roll_repository",
@@ -105,6 +156,48 @@ func NewRecorder(reg prometheus.Registerer) Recorder {
 			Help:      "The duration of user storage repository operations.",
 		}, []string{"storage_type", "op", "success"}),
 
+		customDieTypeRepoOPDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prefix,
+			Subsystem: "custom_die_type_repository",
+			Name:      "operation_duration_seconds",
+			Help:      "The duration of custom die type storage repository operations.",
+		}, []string{"storage_type", "op", "success"}),
+
+		serverSeedRepoOPDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prefix,
+			Subsystem: "server_seed_repository",
+			Name:      "operation_duration_seconds",
+			Help:      "The duration of server seed storage repository operations.",
+		}, []string{"storage_type", "op", "success"}),
+
+		macroRepoOPDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prefix,
+			Subsystem: "macro_repository",
+			Name:      "operation_duration_seconds",
+			Help:      "The duration of macro storage repository operations.",
+		}, []string{"storage_type", "op", "success"}),
+
+		initiativeRepoOPDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prefix,
+			Subsystem: "initiative_repository",
+			Name:      "operation_duration_seconds",
+			Help:      "The duration of initiative storage repository operations.",
+		}, []string{"storage_type", "op", "success"}),
+
+		deckRepoOPDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prefix,
+			Subsystem: "deck_repository",
+			Name:      "operation_duration_seconds",
+			Help:      "The duration of deck storage repository operations.",
+		}, []string{"storage_type", "op", "success"}),
+
+		oracleTableRepoOPDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prefix,
+			Subsystem: "oracle_table_repository",
+			Name:      "operation_duration_seconds",
+			Help:      "The duration of oracle table storage repository operations.",
+		}, []string{"storage_type", "op", "success"}),
+
 		notifierOPDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
 			Namespace: prefix,
 			Subsystem: "notifier",
@@ -147,9 +240,20 @@ func NewRecorder(reg prometheus.Registerer) Recorder {
 		r.diceServiceOPDuration,
 		r.userServiceOPDuration,
 		r.roomServiceOPDuration,
+		r.macroServiceOPDuration,
+		r.initiativeServiceOPDuration,
+		r.deckServiceOPDuration,
+		r.oracleServiceOPDuration,
+		r.statsServiceOPDuration,
 		r.diceRollRepoOPDuration,
 		r.roomRepoOPDuration,
 		r.userRepoOPDuration,
+		r.customDieTypeRepoOPDuration,
+		r.serverSeedRepoOPDuration,
+		r.macroRepoOPDuration,
+		r.initiativeRepoOPDuration,
+		r.deckRepoOPDuration,
+		r.oracleTableRepoOPDuration,
 		r.notifierOPDuration,
 		r.subscriberSubscribeOPDuration,
 		r.subscriberUnsubscribeOPDuration,
@@ -167,7 +271,12 @@ func (r Recorder) MeasureDiceRollQuantity(ctx context.Context, rollerType string
 
 // MeasureDieRollResult satisfies dice.RollerMetricsRecorder interface.
 func (r Recorder) MeasureDieRollResult(ctx context.Context, rollerType string, dieRoll *model.DieRoll) {
-	r.dieRollResult.WithLabelValues(rollerType, dieRoll.Type.ID(), strconv.Itoa(int(dieRoll.Side))).Inc()
+	// Custom die types are registered by the rooms, group them to avoid high cardinality.
+	dieType := dieRoll.Type.ID()
+	if len(dieRoll.Type.Faces()) > 0 {
+		dieType = "custom"
+	}
+	r.dieRollResult.WithLabelValues(rollerType, dieType, strconv.Itoa(int(dieRoll.Side))).Inc()
 }
 
 // MeasureDiceServiceOpDuration satisfies dice.ServiceMetricsRecorder interface.
@@ -185,6 +294,31 @@ func (r Recorder) MeasureUserServiceOpDuration(ctx context.Context, op string, s
 	r.userServiceOPDuration.WithLabelValues(op, strconv.FormatBool(success)).Observe(t.Seconds())
 }
 
+// MeasureMacroServiceOpDuration satisfies macro.ServiceMetricsRecorder interface.
+func (r Recorder) MeasureMacroServiceOpDuration(ctx context.Context, op string, success bool, t time.Duration) {
+	r.macroServiceOPDuration.WithLabelValues(op, strconv.FormatBool(success)).Observe(t.Seconds())
+}
+
+// MeasureInitiativeServiceOpDuration satisfies initiative.ServiceMetricsRecorder interface.
+func (r Recorder) MeasureInitiativeServiceOpDuration(ctx context.Context, op string, success bool, t time.Duration) {
+	r.initiativeServiceOPDuration.WithLabelValues(op, strconv.FormatBool(success)).Observe(t.Seconds())
+}
+
+// MeasureDeckServiceOpDuration satisfies deck.ServiceMetricsRecorder interface.
+func (r Recorder) MeasureDeckServiceOpDuration(ctx context.Context, op string, success bool, t time.Duration) {
+	r.deckServiceOPDuration.WithLabelValues(op, strconv.FormatBool(success)).Observe(t.Seconds())
+}
+
+// MeasureOracleServiceOpDuration satisfies oracle.ServiceMetricsRecorder interface.
+func (r Recorder) MeasureOracleServiceOpDuration(ctx context.Context, op string, success bool, t time.Duration) {
+	r.oracleServiceOPDuration.WithLabelValues(op, strconv.FormatBool(success)).Observe(t.Seconds())
+}
+
+// MeasureStatsServiceOpDuration satisfies stats.ServiceMetricsRecorder interface.
+func (r Recorder) MeasureStatsServiceOpDuration(ctx context.Context, op string, success bool, t time.Duration) {
+	r.statsServiceOPDuration.WithLabelValues(op, strconv.FormatBool(success)).Observe(t.Seconds())
+}
+
 // MeasureDiceRollRepoOpDuration satisfies storage.DiceRollRepositoryMetricsRecorder interface.
 func (r Recorder) MeasureDiceRollRepoOpDuration(ctx context.Context, storageType, op string, success bool, t time.Duration) {
 	r.diceRollRepoOPDuration.WithLabelValues(storageType, op, strconv.FormatBool(success)).Observe(t.Seconds())
@@ -200,6 +334,36 @@ func (r Recorder) MeasureUserRepoOpDuration(ctx context.Context, storageType, op
 	r.userRepoOPDuration.WithLabelValues(storageType, op, strconv.FormatBool(success)).Observe(t.Seconds())
 }
 
+// MeasureCustomDieTypeRepoOpDuration satisfies storage.CustomDieTypeRepositoryMetricsRecorder interface.
+func (r Recorder) MeasureCustomDieTypeRepoOpDuration(ctx context.Context, storageType, op string, success bool, t time.Duration) {
+	r.customDieTypeRepoOPDuration.WithLabelValues(storageType, op, strconv.FormatBool(success)).Observe(t.Seconds())
+}
+
+// MeasureServerSeedRepoOpDuration satisfies storage.ServerSeedRepositoryMetricsRecorder interface.
+func (r Recorder) MeasureServerSeedRepoOpDuration(ctx context.Context, storageType, op string, success bool, t time.Duration) {
+	r.serverSeedRepoOPDuration.WithLabelValues(storageType, op, strconv.FormatBool(success)).Observe(t.Seconds())
+}
+
+// MeasureMacroRepoOpDuration satisfies storage.MacroRepositoryMetricsRecorder interface.
+func (r Recorder) MeasureMacroRepoOpDuration(ctx context.Context, storageType, op string, success bool, t time.Duration) {
+	r.macroRepoOPDuration.WithLabelValues(storageType, op, strconv.FormatBool(success)).Observe(t.Seconds())
+}
+
+// MeasureInitiativeRepoOpDuration satisfies storage.InitiativeRepositoryMetricsRecorder interface.
+func (r Recorder) MeasureInitiativeRepoOpDuration(ctx context.Context, storageType, op string, success bool, t time.Duration) {
+	r.initiativeRepoOPDuration.WithLabelValues(storageType, op, strconv.FormatBool(success)).Observe(t.Seconds())
+}
+
+// MeasureDeckRepoOpDuration satisfies storage.DeckRepositoryMetricsRecorder interface.
+func (r Recorder) MeasureDeckRepoOpDuration(ctx context.Context, storageType, op string, success bool, t time.Duration) {
+	r.deckRepoOPDuration.WithLabelValues(storageType, op, strconv.FormatBool(success)).Observe(t.Seconds())
+}
+
+// MeasureOracleTableRepoOpDuration satisfies storage.OracleTableRepositoryMetricsRecorder interface.
+func (r Recorder) MeasureOracleTableRepoOpDuration(ctx context.Context, storageType, op string, success bool, t time.Duration) {
+	r.oracleTableRepoOPDuration.WithLabelValues(storageType, op, strconv.FormatBool(success)).Observe(t.Seconds())
+}
+
 // MeasureNotifyOpDuration satisfies event.NotifierMetricsRecorder interface.
 func (r Recorder) MeasureNotifyOpDuration(ctx context.Context, notifierType, op string, success bool, t time.Duration) {
 	r.notifierOPDuration.WithLabelValues(notifierType, op, strconv.FormatBool(success)).Observe(t.Seconds())
@@ -226,15 +390,26 @@ func (r Recorder) AddSubscriberQuantity(ctx context.Context, subscriberType, sub
 }
 
 var (
-	_ apiv1.MetricsRecorder                     = Recorder{}
-	_ dice.RollerMetricsRecorder                = Recorder{}
-	_ dice.ServiceMetricsRecorder               = Recorder{}
-	_ room.ServiceMetricsRecorder               = Recorder{}
-	_ user.ServiceMetricsRecorder               = Recorder{}
-	_ user.ServiceMetricsRecorder               = Recorder{}
-	_ storage.DiceRollRepositoryMetricsRecorder = Recorder{}
-	_ storage.RoomRepositoryMetricsRecorder     = Recorder{}
-	_ storage.UserRepositoryMetricsRecorder     = Recorder{}
-	_ event.NotifierMetricsRecorder             = Recorder{}
-	_ event.SubscriberMetricsRecorder           = Recorder{}
+	_ apiv1.MetricsRecorder                          = Recorder{}
+	_ dice.RollerMetricsRecorder                     = Recorder{}
+	_ dice.ServiceMetricsRecorder                    = Recorder{}
+	_ room.ServiceMetricsRecorder                    = Recorder{}
+	_ user.ServiceMetricsRecorder                    = Recorder{}
+	_ user.ServiceMetricsRecorder                    = Recorder{}
+	_ macro.ServiceMetricsRecorder                   = Recorder{}
+	_ initiative.ServiceMetricsRecorder              = Recorder{}
+	_ deck.ServiceMetricsRecorder                    = Recorder{}
+	_ oracle.ServiceMetricsRecorder                  = Recorder{}
+	_ stats.ServiceMetricsRecorder                   = Recorder{}
+	_ storage.DiceRollRepositoryMetricsRecorder      = Recorder{}
+	_ storage.RoomRepositoryMetricsRecorder          = Recorder{}
+	_ storage.UserRepositoryMetricsRecorder          = Recorder{}
+	_ storage.CustomDieTypeRepositoryMetricsRecorder = Recorder{}
+	_ storage.ServerSeedRepositoryMetricsRecorder    = Recorder{}
+	_ storage.MacroRepositoryMetricsRecorder         = Recorder{}
+	_ storage.InitiativeRepositoryMetricsRecorder    = Recorder{}
+	_ storage.DeckRepositoryMetricsRecorder          = Recorder{}
+	_ storage.OracleTableRepositoryMetricsRecorder   = Recorder{}
+	_ event.NotifierMetricsRecorder                  = Recorder{}
+	_ event.SubscriberMetricsRecorder                = Recorder{}
 )
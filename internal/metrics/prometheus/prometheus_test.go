@@ -82,6 +82,7 @@ func TestRecorder(t *testing.T) {
 				r.MeasureDieRollResult(context.TODO(), "test-1", &model.DieRoll{Type: model.DieTypeD20, Side: 8})
 				r.MeasureDieRollResult(context.TODO(), "test-2", &model.DieRoll{Type: model.DieTypeD20, Side: 17})
 				r.MeasureDieRollResult(context.TODO(), "test-2", &model.DieRoll{Type: model.DieTypeD20, Side: 17})
+				r.MeasureDieRollResult(context.TODO(), "test-1", &model.DieRoll{Type: model.CustomDieType{TypeID: "coin-id", FaceTable: []model.DieFace{{Label: "Heads"}, {Label: "Tails"}}}, Side: 2})
 
 			},
 			expMetrics: []string{
@@ -89,6 +90,7 @@ func TestRecorder(t *testing.T) {
 				`# TYPE rollify_dice_roller_die_roll_results_total counter`,
 				`rollify_dice_roller_die_roll_results_total{die_side="10",die_type="d12",roller_type="test-1"} 1`,
 				`rollify_dice_roller_die_roll_results_total{die_side="17",die_type="d20",roller_type="test-2"} 2`,
+				`rollify_dice_roller_die_roll_results_total{die_side="2",die_type="custom",roller_type="test-1"} 1`,
 				`rollify_dice_roller_die_roll_results_total{die_side="2",die_type="d4",roller_type="test-1"} 1`,
 				`rollify_dice_roller_die_roll_results_total{die_side="2",die_type="d6",roller_type="test-1"} 1`,
 				`rollify_dice_roller_die_roll_results_total{die_side="4",die_type="d6",roller_type="test-1"} 3`,
@@ -234,6 +236,206 @@ func TestRecorder(t *testing.T) {
 			},
 		},
 
+		"Measure macro app service operation duration.": {
+			measure: func(r metrics.Recorder) {
+				r.MeasureMacroServiceOpDuration(context.TODO(), "op1", true, 55*time.Millisecond)
+				r.MeasureMacroServiceOpDuration(context.TODO(), "op1", true, 55*time.Millisecond)
+				r.MeasureMacroServiceOpDuration(context.TODO(), "op1", true, 6*time.Second)
+				r.MeasureMacroServiceOpDuration(context.TODO(), "op2", false, 143*time.Millisecond)
+			},
+			expMetrics: []string{
+				`# HELP rollify_macro_service_operation_duration_seconds The duration of macro application service.`,
+				`# TYPE rollify_macro_service_operation_duration_seconds histogram`,
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.005"} 0`,
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.01"} 0`,
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.025"} 0`,
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.05"} 0`,
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.1"} 2`,
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.25"} 2`,
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.5"} 2`,
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op1",success="true",le="1"} 2`,
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op1",success="true",le="2.5"} 2`,
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op1",success="true",le="5"} 2`,
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op1",success="true",le="10"} 3`,
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op1",success="true",le="+Inf"} 3`,
+				`rollify_macro_service_operation_duration_seconds_count{op="op1",success="true"} 3`,
+
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.005"} 0`,
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.01"} 0`,
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.025"} 0`,
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.05"} 0`,
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.1"} 0`,
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.25"} 1`,
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.5"} 1`,
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op2",success="false",le="1"} 1`,
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op2",success="false",le="2.5"} 1`,
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op2",success="false",le="5"} 1`,
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op2",success="false",le="10"} 1`,
+				`rollify_macro_service_operation_duration_seconds_bucket{op="op2",success="false",le="+Inf"} 1`,
+				`rollify_macro_service_operation_duration_seconds_count{op="op2",success="false"} 1`,
+			},
+		},
+
+		"Measure initiative app service operation duration.": {
+			measure: func(r metrics.Recorder) {
+				r.MeasureInitiativeServiceOpDuration(context.TODO(), "op1", true, 55*time.Millisecond)
+				r.MeasureInitiativeServiceOpDuration(context.TODO(), "op1", true, 55*time.Millisecond)
+				r.MeasureInitiativeServiceOpDuration(context.TODO(), "op1", true, 6*time.Second)
+				r.MeasureInitiativeServiceOpDuration(context.TODO(), "op2", false, 143*time.Millisecond)
+			},
+			expMetrics: []string{
+				`# HELP rollify_initiative_service_operation_duration_seconds The duration of initiative application service.`,
+				`# TYPE rollify_initiative_service_operation_duration_seconds histogram`,
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.005"} 0`,
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.01"} 0`,
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.025"} 0`,
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.05"} 0`,
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.1"} 2`,
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.25"} 2`,
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.5"} 2`,
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op1",success="true",le="1"} 2`,
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op1",success="true",le="2.5"} 2`,
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op1",success="true",le="5"} 2`,
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op1",success="true",le="10"} 3`,
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op1",success="true",le="+Inf"} 3`,
+				`rollify_initiative_service_operation_duration_seconds_count{op="op1",success="true"} 3`,
+
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.005"} 0`,
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.01"} 0`,
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.025"} 0`,
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.05"} 0`,
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.1"} 0`,
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.25"} 1`,
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.5"} 1`,
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op2",success="false",le="1"} 1`,
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op2",success="false",le="2.5"} 1`,
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op2",success="false",le="5"} 1`,
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op2",success="false",le="10"} 1`,
+				`rollify_initiative_service_operation_duration_seconds_bucket{op="op2",success="false",le="+Inf"} 1`,
+				`rollify_initiative_service_operation_duration_seconds_count{op="op2",success="false"} 1`,
+			},
+		},
+
+		"Measure deck app service operation duration.": {
+			measure: func(r metrics.Recorder) {
+				r.MeasureDeckServiceOpDuration(context.TODO(), "op1", true, 55*time.Millisecond)
+				r.MeasureDeckServiceOpDuration(context.TODO(), "op1", true, 55*time.Millisecond)
+				r.MeasureDeckServiceOpDuration(context.TODO(), "op1", true, 6*time.Second)
+				r.MeasureDeckServiceOpDuration(context.TODO(), "op2", false, 143*time.Millisecond)
+			},
+			expMetrics: []string{
+				`# HELP rollify_deck_service_operation_duration_seconds The duration of deck application service.`,
+				`# TYPE rollify_deck_service_operation_duration_seconds histogram`,
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.005"} 0`,
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.01"} 0`,
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.025"} 0`,
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.05"} 0`,
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.1"} 2`,
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.25"} 2`,
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.5"} 2`,
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op1",success="true",le="1"} 2`,
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op1",success="true",le="2.5"} 2`,
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op1",success="true",le="5"} 2`,
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op1",success="true",le="10"} 3`,
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op1",success="true",le="+Inf"} 3`,
+				`rollify_deck_service_operation_duration_seconds_count{op="op1",success="true"} 3`,
+
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.005"} 0`,
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.01"} 0`,
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.025"} 0`,
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.05"} 0`,
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.1"} 0`,
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.25"} 1`,
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.5"} 1`,
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op2",success="false",le="1"} 1`,
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op2",success="false",le="2.5"} 1`,
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op2",success="false",le="5"} 1`,
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op2",success="false",le="10"} 1`,
+				`rollify_deck_service_operation_duration_seconds_bucket{op="op2",success="false",le="+Inf"} 1`,
+				`rollify_deck_service_operation_duration_seconds_count{op="op2",success="false"} 1`,
+			},
+		},
+
+		"Measure oracle app service operation duration.": {
+			measure: func(r metrics.Recorder) {
+				r.MeasureOracleServiceOpDuration(context.TODO(), "op1", true, 55*time.Millisecond)
+				r.MeasureOracleServiceOpDuration(context.TODO(), "op1", true, 55*time.Millisecond)
+				r.MeasureOracleServiceOpDuration(context.TODO(), "op1", true, 6*time.Second)
+				r.MeasureOracleServiceOpDuration(context.TODO(), "op2", false, 143*time.Millisecond)
+			},
+			expMetrics: []string{
+				`# HELP rollify_oracle_service_operation_duration_seconds The duration of oracle application service.`,
+				`# TYPE rollify_oracle_service_operation_duration_seconds histogram`,
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.005"} 0`,
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.01"} 0`,
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.025"} 0`,
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.05"} 0`,
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.1"} 2`,
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.25"} 2`,
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.5"} 2`,
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op1",success="true",le="1"} 2`,
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op1",success="true",le="2.5"} 2`,
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op1",success="true",le="5"} 2`,
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op1",success="true",le="10"} 3`,
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op1",success="true",le="+Inf"} 3`,
+				`rollify_oracle_service_operation_duration_seconds_count{op="op1",success="true"} 3`,
+
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.005"} 0`,
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.01"} 0`,
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.025"} 0`,
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.05"} 0`,
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.1"} 0`,
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.25"} 1`,
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.5"} 1`,
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op2",success="false",le="1"} 1`,
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op2",success="false",le="2.5"} 1`,
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op2",success="false",le="5"} 1`,
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op2",success="false",le="10"} 1`,
+				`rollify_oracle_service_operation_duration_seconds_bucket{op="op2",success="false",le="+Inf"} 1`,
+				`rollify_oracle_service_operation_duration_seconds_count{op="op2",success="false"} 1`,
+			},
+		},
+
+		"Measure stats app service operation duration.": {
+			measure: func(r metrics.Recorder) {
+				r.MeasureStatsServiceOpDuration(context.TODO(), "op1", true, 55*time.Millisecond)
+				r.MeasureStatsServiceOpDuration(context.TODO(), "op1", true, 55*time.Millisecond)
+				r.MeasureStatsServiceOpDuration(context.TODO(), "op1", true, 6*time.Second)
+				r.MeasureStatsServiceOpDuration(context.TODO(), "op2", false, 143*time.Millisecond)
+			},
+			expMetrics: []string{
+				`# HELP rollify_stats_service_operation_duration_seconds The duration of stats application service.`,
+				`# TYPE rollify_stats_service_operation_duration_seconds histogram`,
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.005"} 0`,
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.01"} 0`,
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.025"} 0`,
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.05"} 0`,
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.1"} 2`,
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.25"} 2`,
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op1",success="true",le="0.5"} 2`,
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op1",success="true",le="1"} 2`,
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op1",success="true",le="2.5"} 2`,
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op1",success="true",le="5"} 2`,
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op1",success="true",le="10"} 3`,
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op1",success="true",le="+Inf"} 3`,
+				`rollify_stats_service_operation_duration_seconds_count{op="op1",success="true"} 3`,
+
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.005"} 0`,
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.01"} 0`,
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.025"} 0`,
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.05"} 0`,
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.1"} 0`,
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.25"} 1`,
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op2",success="false",le="0.5"} 1`,
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op2",success="false",le="1"} 1`,
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op2",success="false",le="2.5"} 1`,
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op2",success="false",le="5"} 1`,
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op2",success="false",le="10"} 1`,
+				`rollify_stats_service_operation_duration_seconds_bucket{op="op2",success="false",le="+Inf"} 1`,
+				`rollify_stats_service_operation_duration_seconds_count{op="op2",success="false"} 1`,
+			},
+		},
+
 		"Measure dice roll repo operation duration.": {
 			measure: func(r metrics.Recorder) {
 				r.MeasureDiceRollRepoOpDuration(context.TODO(), "t1", "op1", true, 55*time.Millisecond)
@@ -354,6 +556,246 @@ func TestRecorder(t *testing.T) {
 			},
 		},
 
+		"Measure custom die type repo operation duration.": {
+			measure: func(r metrics.Recorder) {
+				r.MeasureCustomDieTypeRepoOpDuration(context.TODO(), "t1", "op1", true, 55*time.Millisecond)
+				r.MeasureCustomDieTypeRepoOpDuration(context.TODO(), "t1", "op1", true, 55*time.Millisecond)
+				r.MeasureCustomDieTypeRepoOpDuration(context.TODO(), "t1", "op1", true, 6*time.Second)
+				r.MeasureCustomDieTypeRepoOpDuration(context.TODO(), "t2", "op2", false, 143*time.Millisecond)
+			},
+			expMetrics: []string{
+				`# HELP rollify_custom_die_type_repository_operation_duration_seconds The duration of custom die type storage repository operations.`,
+				`# TYPE rollify_custom_die_type_repository_operation_duration_seconds histogram`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.005"} 0`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.01"} 0`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.025"} 0`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.05"} 0`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.1"} 2`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.25"} 2`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.5"} 2`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="1"} 2`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="2.5"} 2`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="5"} 2`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="10"} 3`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="+Inf"} 3`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_count{op="op1",storage_type="t1",success="true"} 3`,
+
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.005"} 0`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.01"} 0`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.025"} 0`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.05"} 0`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.1"} 0`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.25"} 1`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.5"} 1`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="1"} 1`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="2.5"} 1`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="5"} 1`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="10"} 1`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="+Inf"} 1`,
+				`rollify_custom_die_type_repository_operation_duration_seconds_count{op="op2",storage_type="t2",success="false"} 1`,
+			},
+		},
+
+		"Measure server seed repo operation duration.": {
+			measure: func(r metrics.Recorder) {
+				r.MeasureServerSeedRepoOpDuration(context.TODO(), "t1", "op1", true, 55*time.Millisecond)
+				r.MeasureServerSeedRepoOpDuration(context.TODO(), "t1", "op1", true, 55*time.Millisecond)
+				r.MeasureServerSeedRepoOpDuration(context.TODO(), "t1", "op1", true, 6*time.Second)
+				r.MeasureServerSeedRepoOpDuration(context.TODO(), "t2", "op2", false, 143*time.Millisecond)
+			},
+			expMetrics: []string{
+				`# HELP rollify_server_seed_repository_operation_duration_seconds The duration of server seed storage repository operations.`,
+				`# TYPE rollify_server_seed_repository_operation_duration_seconds histogram`,
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.005"} 0`,
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.01"} 0`,
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.025"} 0`,
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.05"} 0`,
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.1"} 2`,
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.25"} 2`,
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.5"} 2`,
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="1"} 2`,
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="2.5"} 2`,
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="5"} 2`,
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="10"} 3`,
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="+Inf"} 3`,
+				`rollify_server_seed_repository_operation_duration_seconds_count{op="op1",storage_type="t1",success="true"} 3`,
+
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.005"} 0`,
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.01"} 0`,
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.025"} 0`,
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.05"} 0`,
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.1"} 0`,
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.25"} 1`,
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.5"} 1`,
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="1"} 1`,
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="2.5"} 1`,
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="5"} 1`,
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="10"} 1`,
+				`rollify_server_seed_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="+Inf"} 1`,
+				`rollify_server_seed_repository_operation_duration_seconds_count{op="op2",storage_type="t2",success="false"} 1`,
+			},
+		},
+
+		"Measure macro repo operation duration.": {
+			measure: func(r metrics.Recorder) {
+				r.MeasureMacroRepoOpDuration(context.TODO(), "t1", "op1", true, 55*time.Millisecond)
+				r.MeasureMacroRepoOpDuration(context.TODO(), "t1", "op1", true, 55*time.Millisecond)
+				r.MeasureMacroRepoOpDuration(context.TODO(), "t1", "op1", true, 6*time.Second)
+				r.MeasureMacroRepoOpDuration(context.TODO(), "t2", "op2", false, 143*time.Millisecond)
+			},
+			expMetrics: []string{
+				`# HELP rollify_macro_repository_operation_duration_seconds The duration of macro storage repository operations.`,
+				`# TYPE rollify_macro_repository_operation_duration_seconds histogram`,
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.005"} 0`,
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.01"} 0`,
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.025"} 0`,
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.05"} 0`,
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.1"} 2`,
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.25"} 2`,
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.5"} 2`,
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="1"} 2`,
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="2.5"} 2`,
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="5"} 2`,
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="10"} 3`,
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="+Inf"} 3`,
+				`rollify_macro_repository_operation_duration_seconds_count{op="op1",storage_type="t1",success="true"} 3`,
+
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.005"} 0`,
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.01"} 0`,
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.025"} 0`,
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.05"} 0`,
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.1"} 0`,
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.25"} 1`,
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.5"} 1`,
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="1"} 1`,
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="2.5"} 1`,
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="5"} 1`,
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="10"} 1`,
+				`rollify_macro_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="+Inf"} 1`,
+				`rollify_macro_repository_operation_duration_seconds_count{op="op2",storage_type="t2",success="false"} 1`,
+			},
+		},
+
+		"Measure initiative repo operation duration.": {
+			measure: func(r metrics.Recorder) {
+				r.MeasureInitiativeRepoOpDuration(context.TODO(), "t1", "op1", true, 55*time.Millisecond)
+				r.MeasureInitiativeRepoOpDuration(context.TODO(), "t1", "op1", true, 55*time.Millisecond)
+				r.MeasureInitiativeRepoOpDuration(context.TODO(), "t1", "op1", true, 6*time.Second)
+				r.MeasureInitiativeRepoOpDuration(context.TODO(), "t2", "op2", false, 143*time.Millisecond)
+			},
+			expMetrics: []string{
+				`# HELP rollify_initiative_repository_operation_duration_seconds The duration of initiative storage repository operations.`,
+				`# TYPE rollify_initiative_repository_operation_duration_seconds histogram`,
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.005"} 0`,
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.01"} 0`,
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.025"} 0`,
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.05"} 0`,
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.1"} 2`,
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.25"} 2`,
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.5"} 2`,
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="1"} 2`,
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="2.5"} 2`,
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="5"} 2`,
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="10"} 3`,
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="+Inf"} 3`,
+				`rollify_initiative_repository_operation_duration_seconds_count{op="op1",storage_type="t1",success="true"} 3`,
+
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.005"} 0`,
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.01"} 0`,
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.025"} 0`,
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.05"} 0`,
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.1"} 0`,
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.25"} 1`,
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.5"} 1`,
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="1"} 1`,
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="2.5"} 1`,
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="5"} 1`,
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="10"} 1`,
+				`rollify_initiative_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="+Inf"} 1`,
+				`rollify_initiative_repository_operation_duration_seconds_count{op="op2",storage_type="t2",success="false"} 1`,
+			},
+		},
+
+		"Measure deck repo operation duration.": {
+			measure: func(r metrics.Recorder) {
+				r.MeasureDeckRepoOpDuration(context.TODO(), "t1", "op1", true, 55*time.Millisecond)
+				r.MeasureDeckRepoOpDuration(context.TODO(), "t1", "op1", true, 55*time.Millisecond)
+				r.MeasureDeckRepoOpDuration(context.TODO(), "t1", "op1", true, 6*time.Second)
+				r.MeasureDeckRepoOpDuration(context.TODO(), "t2", "op2", false, 143*time.Millisecond)
+			},
+			expMetrics: []string{
+				`# HELP rollify_deck_repository_operation_duration_seconds The duration of deck storage repository operations.`,
+				`# TYPE rollify_deck_repository_operation_duration_seconds histogram`,
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.005"} 0`,
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.01"} 0`,
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.025"} 0`,
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.05"} 0`,
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.1"} 2`,
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.25"} 2`,
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.5"} 2`,
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="1"} 2`,
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="2.5"} 2`,
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="5"} 2`,
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="10"} 3`,
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="+Inf"} 3`,
+				`rollify_deck_repository_operation_duration_seconds_count{op="op1",storage_type="t1",success="true"} 3`,
+
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.005"} 0`,
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.01"} 0`,
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.025"} 0`,
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.05"} 0`,
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.1"} 0`,
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.25"} 1`,
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.5"} 1`,
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="1"} 1`,
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="2.5"} 1`,
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="5"} 1`,
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="10"} 1`,
+				`rollify_deck_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="+Inf"} 1`,
+				`rollify_deck_repository_operation_duration_seconds_count{op="op2",storage_type="t2",success="false"} 1`,
+			},
+		},
+
+		"Measure oracle table repo operation duration.": {
+			measure: func(r metrics.Recorder) {
+				r.MeasureOracleTableRepoOpDuration(context.TODO(), "t1", "op1", true, 55*time.Millisecond)
+				r.MeasureOracleTableRepoOpDuration(context.TODO(), "t1", "op1", true, 55*time.Millisecond)
+				r.MeasureOracleTableRepoOpDuration(context.TODO(), "t1", "op1", true, 6*time.Second)
+				r.MeasureOracleTableRepoOpDuration(context.TODO(), "t2", "op2", false, 143*time.Millisecond)
+			},
+			expMetrics: []string{
+				`# HELP rollify_oracle_table_repository_operation_duration_seconds The duration of oracle table storage repository operations.`,
+				`# TYPE rollify_oracle_table_repository_operation_duration_seconds histogram`,
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.005"} 0`,
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.01"} 0`,
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.025"} 0`,
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.05"} 0`,
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.1"} 2`,
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.25"} 2`,
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="0.5"} 2`,
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="1"} 2`,
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="2.5"} 2`,
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="5"} 2`,
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="10"} 3`,
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op1",storage_type="t1",success="true",le="+Inf"} 3`,
+				`rollify_oracle_table_repository_operation_duration_seconds_count{op="op1",storage_type="t1",success="true"} 3`,
+
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.005"} 0`,
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.01"} 0`,
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.025"} 0`,
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.05"} 0`,
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.1"} 0`,
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.25"} 1`,
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="0.5"} 1`,
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="1"} 1`,
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="2.5"} 1`,
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="5"} 1`,
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="10"} 1`,
+				`rollify_oracle_table_repository_operation_duration_seconds_bucket{op="op2",storage_type="t2",success="false",le="+Inf"} 1`,
+				`rollify_oracle_table_repository_operation_duration_seconds_count{op="op2",storage_type="t2",success="false"} 1`,
+			},
+		},
+
 		"Measure notifier operation duration.": {
 			measure: func(r metrics.Recorder) {
 				r.MeasureNotifyOpDuration(context.TODO(), "t1", "op1", true, 55*time.Millisecond)
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// DeckType is the type of a card deck.
+type DeckType string
+
+const (
+	// DeckTypeStandard is the standard deck of 52 french-suited playing cards.
+	DeckTypeStandard DeckType = "standard"
+	// DeckTypeStandardJokers is the standard deck with 2 jokers (e.g: Savage Worlds, Deadlands).
+	DeckTypeStandardJokers DeckType = "standard-jokers"
+	// DeckTypeTarot is the deck of 78 tarot cards (22 major arcana and 56 minor arcana).
+	DeckTypeTarot DeckType = "tarot"
+	// DeckTypeCustom is a deck with user defined cards.
+	DeckTypeCustom DeckType = "custom"
+)
+
+// Cards returns the cards of the predefined deck types, custom decks don't have predefined cards.
+func (t DeckType) Cards() []string {
+	switch t {
+	case DeckTypeStandard:
+		return append([]string{}, standardCards...)
+	case DeckTypeStandardJokers:
+		return append(append([]string{}, standardCards...), "Red Joker", "Black Joker")
+	case DeckTypeTarot:
+		return append([]string{}, tarotCards...)
+	default:
+		return nil
+	}
+}
+
+var standardCards = func() []string {
+	cards := []string{}
+	for _, suit := range []string{"♠", "♥", "♦", "♣"} {
+		for _, rank := range []string{"A", "2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K"} {
+			cards = append(cards, rank+suit)
+		}
+	}
+	return cards
+}()
+
+var tarotCards = func() []string {
+	cards := []string{
+		"The Fool", "The Magician", "The High Priestess", "The Empress", "The Emperor",
+		"The Hierophant", "The Lovers", "The Chariot", "Strength", "The Hermit",
+		"Wheel of Fortune", "Justice", "The Hanged Man", "Death", "Temperance",
+		"The Devil", "The Tower", "The Star", "The Moon", "The Sun", "Judgement", "The World",
+	}
+	for _, suit := range []string{"Wands", "Cups", "Swords", "Pentacles"} {
+		for _, rank := range []string{"Ace", "Two", "Three", "Four", "Five", "Six", "Seven", "Eight", "Nine", "Ten", "Page", "Knight", "Queen", "King"} {
+			cards = append(cards, fmt.Sprintf("%s of %s", rank, suit))
+		}
+	}
+	return cards
+}()
+
+// Deck is a room card deck with its shuffled state.
+type Deck struct {
+	ID        string
+	CreatedAt time.Time
+	RoomID    string
+	Name      string
+	Type      DeckType
+	// Cards are all the cards of the deck.
+	Cards []string
+	// DrawPile are the cards that have not been drawn yet, in the order they will be drawn.
+	DrawPile []string
+	// DiscardPile are the drawn cards that have been discarded.
+	DiscardPile []string
+	// Version is increased on every deck update, used to detect concurrent updates.
+	Version uint
+}
+
+// InPlay returns the drawn cards that have not been discarded, in deck order.
+func (d Deck) InPlay() []string {
+	// Count the cards that are not in play, custom decks can have repeated cards.
+	out := map[string]int{}
+	for _, c := range d.DrawPile {
+		out[c]++
+	}
+	for _, c := range d.DiscardPile {
+		out[c]++
+	}
+
+	cards := []string{}
+	for _, c := range d.Cards {
+		if out[c] > 0 {
+			out[c]--
+			continue
+		}
+		cards = append(cards, c)
+	}
+
+	return cards
+}
+
+// CardDraw represents the cards drawn by a user from a room deck.
+type CardDraw struct {
+	ID        string
+	CreatedAt time.Time
+	RoomID    string
+	UserID    string
+	DeckID    string
+	// DeckName is the name of the deck the cards were drawn from.
+	DeckName string
+	// Cards are the drawn cards in draw order.
+	Cards []string
+}
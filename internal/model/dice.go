@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 // DieRoll represents a single die.
 type DieRoll struct {
@@ -8,8 +11,49 @@ type DieRoll struct {
 	ID string
 	// Type is the Die type, e.g: d6, d20.
 	Type DieType
-	// Side is the side we got after a die roll.
+	// Side is the side we got after a die roll (from 1 to the die type sides).
 	Side uint
+	// Status is the status of the die on the dice roll after applying the roll modifiers.
+	Status DieRollStatus
+}
+
+// DieRollStatus is the status of a die on a dice roll.
+type DieRollStatus int
+
+const (
+	// DieRollStatusKept is the status of the dice that count on the dice roll.
+	DieRollStatusKept DieRollStatus = iota
+	// DieRollStatusExploded is the status of the dice that count on the dice roll and
+	// triggered an extra die roll.
+	DieRollStatusExploded
+	// DieRollStatusRerolled is the status of the dice discarded because they were rerolled.
+	DieRollStatusRerolled
+	// DieRollStatusDropped is the status of the dice discarded by keep/drop modifiers.
+	DieRollStatusDropped
+)
+
+// Discarded returns true if the die doesn't count on the dice roll.
+func (d DieRoll) Discarded() bool {
+	return d.Status == DieRollStatusRerolled || d.Status == DieRollStatusDropped
+}
+
+// Face returns the face we got after a die roll, only the die types with face table have faces.
+func (d DieRoll) Face() (DieFace, bool) {
+	faces := d.Type.Faces()
+	if d.Side < 1 || int(d.Side) > len(faces) {
+		return DieFace{}, false
+	}
+
+	return faces[d.Side-1], true
+}
+
+// Value returns what the die roll counts, the face value on die types with face table, otherwise the side.
+func (d DieRoll) Value() int {
+	if f, ok := d.Face(); ok {
+		return f.Value
+	}
+
+	return int(d.Side)
 }
 
 // DiceRoll represents a dice roll.
@@ -28,4 +72,135 @@ type DiceRoll struct {
 	UserID string
 	// Dice are the rolled dice values involved in the dice roll.
 	Dice []DieRoll
+	// Expression is the dice notation expression used to make the dice roll (e.g: `2d6+3`), optional.
+	Expression string
+	// Label is a free-text description of the dice roll (e.g: `Stealth check`), optional.
+	Label string
+	// Modifier is the flat bonus (or penalty if negative) added to the sum of the dice values (e.g: `+5`).
+	Modifier int
+	// Total is the evaluated total of the expression, the successes on dice pools, or the sum of the
+	// dice values plus the modifier when there is no expression.
+	Total int
+	// Pool is the evaluated outcome of a success-counting dice pool, only set on dice pools.
+	Pool *DicePoolResult
+	// Check is the evaluated outcome of the dice roll against a difficulty, only set on difficulty checks.
+	Check *DiceRollCheck
+	// Proof is the provably fair material of the dice roll, only set on provably fair dice rolls.
+	Proof *DiceRollProof
+	// Visibility is who can see the dice roll.
+	Visibility DiceRollVisibility
+	// WhisperUserIDs are the users the dice roll is whispered to, only set on whispered dice rolls.
+	WhisperUserIDs []string
+	// ParentID is the ID of the dice roll this one rerolled some dice from, only set on rerolls.
+	ParentID string
+	// Hidden is set when the dice roll has been hidden to the user that is seeing it, in that
+	// case only the metadata of the dice roll is set (no dice, total...).
+	Hidden bool
+}
+
+// DicePoolResult is the evaluated outcome of a success-counting dice pool.
+type DicePoolResult struct {
+	// Successes are the dice that reached the target number, after the ones cancelled them.
+	Successes int
+	// Outcome is the outcome tier of the dice pool.
+	Outcome DicePoolOutcome
+}
+
+// DicePoolOutcome is the outcome tier of a dice pool.
+type DicePoolOutcome int
+
+const (
+	// DicePoolOutcomeCriticalFailure is the outcome of a dice pool that botched.
+	DicePoolOutcomeCriticalFailure DicePoolOutcome = iota + 1
+	// DicePoolOutcomeFailure is the outcome of a dice pool without successes.
+	DicePoolOutcomeFailure
+	// DicePoolOutcomeSuccess is the outcome of a dice pool with successes.
+	DicePoolOutcomeSuccess
+	// DicePoolOutcomeCriticalSuccess is the outcome of a dice pool with successes and enough critical dice.
+	DicePoolOutcomeCriticalSuccess
+)
+
+// DiceRollCheck is the evaluated outcome of a dice roll total against a difficulty (e.g: a DC 15).
+type DiceRollCheck struct {
+	// Target is the difficulty the total is compared with.
+	Target int
+	// Comparison is how the total is compared with the target.
+	Comparison DiceRollCheckComparison
+	// Margin is by how much the total beat (positive) or missed (negative) the target, on the
+	// direction of the comparison (e.g: a 12 on a `<= 15` check has a margin of 3).
+	Margin int
+	// Outcome is the outcome tier of the check.
+	Outcome DiceRollCheckOutcome
+}
+
+// DiceRollCheckComparison is how a dice roll total is compared with the check target.
+type DiceRollCheckComparison int
+
+const (
+	// DiceRollCheckComparisonGreaterOrEqual succeeds when the total is equal or greater than the target.
+	DiceRollCheckComparisonGreaterOrEqual DiceRollCheckComparison = iota + 1
+	// DiceRollCheckComparisonGreater succeeds when the total is greater than the target.
+	DiceRollCheckComparisonGreater
+	// DiceRollCheckComparisonLessOrEqual succeeds when the total is equal or less than the target (roll-under).
+	DiceRollCheckComparisonLessOrEqual
+	// DiceRollCheckComparisonLess succeeds when the total is less than the target (roll-under).
+	DiceRollCheckComparisonLess
+)
+
+// DiceRollCheckOutcome is the outcome tier of a difficulty check.
+type DiceRollCheckOutcome int
+
+const (
+	// DiceRollCheckOutcomeCriticalFailure is the outcome of a check that got its worst natural roll.
+	DiceRollCheckOutcomeCriticalFailure DiceRollCheckOutcome = iota + 1
+	// DiceRollCheckOutcomeFailure is the outcome of a check that missed the target.
+	DiceRollCheckOutcomeFailure
+	// DiceRollCheckOutcomeSuccess is the outcome of a check that reached the target.
+	DiceRollCheckOutcomeSuccess
+	// DiceRollCheckOutcomeCriticalSuccess is the outcome of a check that got its best natural roll.
+	DiceRollCheckOutcomeCriticalSuccess
+)
+
+// DiceRollVisibility is who can see a dice roll.
+type DiceRollVisibility int
+
+const (
+	// DiceRollVisibilityPublic dice rolls can be seen by all the room users.
+	DiceRollVisibilityPublic DiceRollVisibility = iota
+	// DiceRollVisibilityGameMaster dice rolls can only be seen by the user that rolled them and the room game masters.
+	DiceRollVisibilityGameMaster
+	// DiceRollVisibilityWhisper dice rolls can only be seen by the user that rolled them, the room game masters
+	// and the whispered users.
+	DiceRollVisibilityWhisper
+)
+
+// VisibleTo returns true if the user can see the dice roll.
+func (d DiceRoll) VisibleTo(u User) bool {
+	switch {
+	case d.Visibility == DiceRollVisibilityPublic:
+		return true
+	case u.ID == "" || u.RoomID != d.RoomID:
+		return false
+	case u.ID == d.UserID || u.GameMaster:
+		return true
+	case d.Visibility == DiceRollVisibilityWhisper:
+		return slices.Contains(d.WhisperUserIDs, u.ID)
+	default:
+		return false
+	}
+}
+
+// HiddenCopy returns a copy of the dice roll without the information that can't be seen by
+// the users that can't see the dice roll.
+func (d DiceRoll) HiddenCopy() DiceRoll {
+	return DiceRoll{
+		ID:         d.ID,
+		Serial:     d.Serial,
+		CreatedAt:  d.CreatedAt,
+		RoomID:     d.RoomID,
+		UserID:     d.UserID,
+		Dice:       []DieRoll{},
+		Visibility: d.Visibility,
+		Hidden:     true,
+	}
 }
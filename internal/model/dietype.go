@@ -1,5 +1,11 @@
 package model
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 // DieType is the physical type of Die structure.
 type DieType interface {
 	// ID returns the id of the Die type.
@@ -8,60 +14,98 @@ type DieType interface {
 	Name() string
 	// Sides returns the number of sides our Die has.
 	Sides() uint
+	// Faces returns the face table of the die indexed by side (side 1 is the first face),
+	// numeric dice don't have face table.
+	Faces() []DieFace
 }
 
-type d4 int
-
-func (d d4) ID() string   { return "d4" }
-func (d d4) Name() string { return "D4" }
-func (d d4) Sides() uint  { return 4 }
+// DieFace is a symbolic face of a die, e.g: FATE dice have `+`, `-` and blank faces.
+type DieFace struct {
+	// Label is the symbol of the face.
+	Label string
+	// Value is what the face counts on the dice roll total.
+	Value int
+}
 
-type d6 int
+// MaxDieTypeSides is the maximum number of sides a die type can have.
+const MaxDieTypeSides = 1000
 
-func (d d6) ID() string   { return "d6" }
-func (d d6) Name() string { return "D6" }
-func (d d6) Sides() uint  { return 6 }
+// dieType is a numeric die with N sides, its ID is `dN`.
+type dieType uint
 
-type d8 int
+func (d dieType) ID() string     { return "d" + strconv.Itoa(int(d)) }
+func (d dieType) Name() string   { return "D" + strconv.Itoa(int(d)) }
+func (d dieType) Sides() uint    { return uint(d) }
+func (dieType) Faces() []DieFace { return nil }
 
-func (d d8) ID() string   { return "d8" }
-func (d d8) Name() string { return "D8" }
-func (d d8) Sides() uint  { return 8 }
+// Die types.
+const (
+	DieTypeD2   = dieType(2)
+	DieTypeD3   = dieType(3)
+	DieTypeD4   = dieType(4)
+	DieTypeD6   = dieType(6)
+	DieTypeD8   = dieType(8)
+	DieTypeD10  = dieType(10)
+	DieTypeD12  = dieType(12)
+	DieTypeD20  = dieType(20)
+	DieTypeD100 = dieType(100)
+)
 
-type d10 int
+// DiceTypes are the common dice types, any other dN can be created with NewDieType.
+var DiceTypes = []DieType{
+	DieTypeD2,
+	DieTypeD3,
+	DieTypeD4,
+	DieTypeD6,
+	DieTypeD8,
+	DieTypeD10,
+	DieTypeD12,
+	DieTypeD20,
+	DieTypeD100,
+}
 
-func (d d10) ID() string   { return "d10" }
-func (d d10) Name() string { return "D10" }
-func (d d10) Sides() uint  { return 10 }
+// NewDieType returns a die type with N sides (e.g: 7 for a d7).
+func NewDieType(sides uint) (DieType, error) {
+	if sides < 2 || sides > MaxDieTypeSides {
+		return nil, fmt.Errorf("die sides must be between 2 and %d, got %d", MaxDieTypeSides, sides)
+	}
 
-type d12 int
+	return dieType(sides), nil
+}
 
-func (d d12) ID() string   { return "d12" }
-func (d d12) Name() string { return "D12" }
-func (d d12) Sides() uint  { return 12 }
+// DieTypeFromID returns the die type of a die type ID (e.g: `d100`).
+func DieTypeFromID(id string) (DieType, error) {
+	sides, ok := strings.CutPrefix(id, "d")
+	if !ok {
+		return nil, fmt.Errorf("die type ID %q must start with 'd'", id)
+	}
 
-type d20 int
+	n, err := strconv.ParseUint(sides, 10, 0)
+	if err != nil {
+		return nil, fmt.Errorf("die type ID %q sides are not valid", id)
+	}
 
-func (d d20) ID() string   { return "d20" }
-func (d d20) Name() string { return "D20" }
-func (d d20) Sides() uint  { return 20 }
+	// Be strict so we only have one ID per die type (e.g: `d06` is not valid).
+	if strconv.FormatUint(n, 10) != sides {
+		return nil, fmt.Errorf("die type ID %q sides are not valid", id)
+	}
 
-// Die types.
-const (
-	DieTypeD4  = d4(0)
-	DieTypeD6  = d6(0)
-	DieTypeD8  = d8(0)
-	DieTypeD10 = d10(0)
-	DieTypeD12 = d12(0)
-	DieTypeD20 = d20(0)
-)
+	return NewDieType(uint(n))
+}
 
-// DiceTypes has all the dice type available.
-var DiceTypes = map[string]DieType{
-	DieTypeD4.ID():  DieTypeD4,
-	DieTypeD6.ID():  DieTypeD6,
-	DieTypeD8.ID():  DieTypeD8,
-	DieTypeD10.ID(): DieTypeD10,
-	DieTypeD12.ID(): DieTypeD12,
-	DieTypeD20.ID(): DieTypeD20,
+// CustomDieType is a die type registered by a room whose sides map to symbolic faces.
+type CustomDieType struct {
+	// TypeID is the ID of the die type.
+	TypeID string
+	// RoomID is the ID of the room that registered the die type.
+	RoomID string
+	// TypeName is the name of the die type.
+	TypeName string
+	// FaceTable are the faces of the die, one per side.
+	FaceTable []DieFace
 }
+
+func (c CustomDieType) ID() string       { return c.TypeID }
+func (c CustomDieType) Name() string     { return c.TypeName }
+func (c CustomDieType) Sides() uint      { return uint(len(c.FaceTable)) }
+func (c CustomDieType) Faces() []DieFace { return c.FaceTable }
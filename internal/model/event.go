@@ -12,3 +12,39 @@ type EventDiceRollCreated struct {
 
 // Type satisfies Event interface.
 func (EventDiceRollCreated) Type() string { return "EventDiceRollCreated" }
+
+// EventInitiativeUpdated is a room initiative tracker update event, when the combat
+// ends the initiative tracker is sent without combatants.
+type EventInitiativeUpdated struct {
+	Initiative Initiative
+}
+
+// Type satisfies Event interface.
+func (EventInitiativeUpdated) Type() string { return "EventInitiativeUpdated" }
+
+// EventCardsDrawn is a room deck cards drawn event.
+type EventCardsDrawn struct {
+	CardDraw CardDraw
+}
+
+// Type satisfies Event interface.
+func (EventCardsDrawn) Type() string { return "EventCardsDrawn" }
+
+// EventOracleTableRolled is a room oracle table rolled event.
+type EventOracleTableRolled struct {
+	OracleTableRoll OracleTableRoll
+}
+
+// Type satisfies Event interface.
+func (EventOracleTableRolled) Type() string { return "EventOracleTableRolled" }
+
+// EventRoomClosed is a room closed event, the room has been deleted or archived and the
+// live subscriptions of the room should be closed.
+type EventRoomClosed struct {
+	RoomID string
+	// Deleted is true if the room has been deleted, false if it has been archived.
+	Deleted bool
+}
+
+// Type satisfies Event interface.
+func (EventRoomClosed) Type() string { return "EventRoomClosed" }
@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// ServerSeed is the secret seed the server uses to roll provably fair dice on a room session.
+// Until the seed is revealed (on rotation) only its hash is public, this way the players can check
+// the server didn't change the seed while the dice were being rolled.
+type ServerSeed struct {
+	ID        string
+	RoomID    string
+	CreatedAt time.Time
+	// Seed is the secret seed (hex encoded).
+	Seed string
+	// Hash is the public SHA256 hash of the seed (hex encoded).
+	Hash string
+	// Nonce is the number of dice rolls made with the seed.
+	Nonce uint
+	// Revealed is true when the seed has been rotated, a revealed seed can't be used anymore.
+	Revealed bool
+}
+
+// DiceRollProof is the material required to verify a provably fair dice roll.
+type DiceRollProof struct {
+	// ServerSeedID is the ID of the server seed used on the dice roll.
+	ServerSeedID string
+	// ServerSeedHash is the public hash of the server seed used on the dice roll.
+	ServerSeedHash string
+	// ClientSeed is the seed set by the client to mix with the server seed.
+	ClientSeed string
+	// Nonce is the number of the dice roll made with the server seed.
+	Nonce uint
+}
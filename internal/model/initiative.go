@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// Initiative is the initiative tracker of a room combat, a room has at most one.
+type Initiative struct {
+	RoomID    string
+	UpdatedAt time.Time
+	// Round is the combat round, starts on the first round and increases when the last
+	// combatant turn ends.
+	Round uint
+	// Turn is the position on Combatants of the combatant that has the current turn.
+	Turn int
+	// Combatants are the combatants in turn order (highest initiative first).
+	Combatants []InitiativeCombatant
+}
+
+// InitiativeCombatant is a combatant of an initiative tracker, a room user or a named NPC.
+type InitiativeCombatant struct {
+	ID   string
+	Name string
+	// UserID is the room user the combatant is, empty on NPCs.
+	UserID     string
+	Initiative int
+	// DiceRollID is the dice roll the initiative was rolled with, empty if it was set manually.
+	DiceRollID string
+}
+
+// CurrentCombatant returns the combatant that has the current turn, if there are no combatants
+// it returns false.
+func (i Initiative) CurrentCombatant() (InitiativeCombatant, bool) {
+	if i.Turn < 0 || i.Turn >= len(i.Combatants) {
+		return InitiativeCombatant{}, false
+	}
+
+	return i.Combatants[i.Turn], true
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// Macro is a named dice roll definition saved to be rolled again with a single click.
+type Macro struct {
+	ID        string
+	CreatedAt time.Time
+	RoomID    string
+	// UserID is the user that owns the macro.
+	UserID string
+	Name   string
+	// Expression is the dice notation expression rolled by the macro (e.g: `1d20+5`).
+	Expression string
+	// Shared macros can be used by all the room users, not only by its owner.
+	Shared bool
+}
+
+// UsableBy returns true if the user can use the macro.
+func (m Macro) UsableBy(u User) bool {
+	if m.RoomID != u.RoomID {
+		return false
+	}
+
+	return m.Shared || m.UserID == u.ID
+}
@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+// OracleTableDiceD66 is the oracle table dice that rolls two D6 reading them as tens
+// and units (11 to 66) instead of adding them.
+const OracleTableDiceD66 = "d66"
+
+// OracleTable is a room random table (e.g: encounters, weather, names) where a dice roll
+// selects the result, commonly used on solo and GM-less games.
+type OracleTable struct {
+	ID        string
+	CreatedAt time.Time
+	RoomID    string
+	// Name is unique on the room, used by the entries to reference nested tables.
+	Name string
+	// Dice is the dice notation expression rolled on the table (e.g: `2d6`), or `d66`.
+	Dice    string
+	Entries []OracleTableEntry
+}
+
+// OracleTableEntry is the result of an oracle table for a range of rolled values.
+type OracleTableEntry struct {
+	// Min and Max are the rolled values range (both included) that select the entry.
+	Min    int
+	Max    int
+	Result string
+	// TableName is the name of a room table that will be rolled when the entry is selected, optional.
+	TableName string
+}
+
+// Entry returns the entry selected by the rolled value, if any.
+func (t OracleTable) Entry(value int) (OracleTableEntry, bool) {
+	for _, e := range t.Entries {
+		if value >= e.Min && value <= e.Max {
+			return e, true
+		}
+	}
+
+	return OracleTableEntry{}, false
+}
+
+// OracleTableRoll represents a roll made by a user on a room oracle table.
+type OracleTableRoll struct {
+	ID        string
+	CreatedAt time.Time
+	RoomID    string
+	UserID    string
+	TableID   string
+	// Results are the rolled table result followed by the nested tables results in roll order.
+	Results []OracleTableResult
+}
+
+// OracleTableResult is the result of rolling on a single oracle table.
+type OracleTableResult struct {
+	TableName string
+	// DiceRollID is the room dice roll made to select the result.
+	DiceRollID string
+	Value      int
+	// Result is empty if the table doesn't have an entry for the rolled value.
+	Result string
+}
@@ -7,4 +7,23 @@ type Room struct {
 	ID        string
 	Name      string
 	CreatedAt time.Time
+	// Ruleset is the ID of the game system ruleset of the room, empty if the room doesn't use one.
+	Ruleset string
+	// Archived rooms are read-only, they keep their history but don't accept new changes.
+	Archived bool
+	// PasswordHash is the salted hash of the room password, empty if the room is not password protected.
+	PasswordHash string
+	// InviteTokens are the tokens that can be used to join a password protected room without the password.
+	InviteTokens []RoomInviteToken
+}
+
+// Protected returns true if the users need the room password or an invite token to join the room.
+func (r Room) Protected() bool {
+	return r.PasswordHash != ""
+}
+
+// RoomInviteToken is a revocable token to join a room.
+type RoomInviteToken struct {
+	Token     string
+	CreatedAt time.Time
 }
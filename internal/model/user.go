@@ -9,4 +9,6 @@ type User struct {
 	Name      string
 	RoomID    string
 	CreatedAt time.Time
+	// GameMaster users can see all the dice rolls of the room, including the hidden ones.
+	GameMaster bool
 }
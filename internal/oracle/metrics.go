@@ -0,0 +1,66 @@
+package oracle
+
+import (
+	"context"
+	"time"
+)
+
+// ServiceMetricsRecorder knows how to record Service metrics.
+type ServiceMetricsRecorder interface {
+	MeasureOracleServiceOpDuration(ctx context.Context, op string, success bool, t time.Duration)
+}
+
+//go:generate mockery --case underscore --output oraclemock --outpkg oraclemock --name ServiceMetricsRecorder
+
+type measuredService struct {
+	rec  ServiceMetricsRecorder
+	next Service
+}
+
+// NewMeasureService wraps a service and measures.
+func NewMeasureService(rec ServiceMetricsRecorder, next Service) Service {
+	return &measuredService{
+		rec:  rec,
+		next: next,
+	}
+}
+
+func (m measuredService) CreateTable(ctx context.Context, req CreateTableRequest) (resp *CreateTableResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureOracleServiceOpDuration(ctx, "CreateTable", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.CreateTable(ctx, req)
+}
+
+func (m measuredService) ListTables(ctx context.Context, req ListTablesRequest) (resp *ListTablesResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureOracleServiceOpDuration(ctx, "ListTables", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.ListTables(ctx, req)
+}
+
+func (m measuredService) RollTable(ctx context.Context, req RollTableRequest) (resp *RollTableResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureOracleServiceOpDuration(ctx, "RollTable", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.RollTable(ctx, req)
+}
+
+func (m measuredService) ListTableRolls(ctx context.Context, req ListTableRollsRequest) (resp *ListTableRollsResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureOracleServiceOpDuration(ctx, "ListTableRolls", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.ListTableRolls(ctx, req)
+}
+
+func (m measuredService) SubscribeOracleTableRolled(ctx context.Context, req SubscribeOracleTableRolledRequest) (resp *SubscribeOracleTableRolledResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureOracleServiceOpDuration(ctx, "SubscribeOracleTableRolled", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.SubscribeOracleTableRolled(ctx, req)
+}
@@ -0,0 +1,630 @@
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/rollify/rollify/internal/dice"
+	"github.com/rollify/rollify/internal/dice/notation"
+	"github.com/rollify/rollify/internal/event"
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/log"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/room/roomcheck"
+	"github.com/rollify/rollify/internal/storage"
+)
+
+// Service is the application service of the room oracle (random) tables logic.
+type Service interface {
+	// CreateTable creates a new oracle table on a room from the uploaded table data.
+	CreateTable(ctx context.Context, r CreateTableRequest) (*CreateTableResponse, error)
+	// ListTables lists the oracle tables of a room.
+	ListTables(ctx context.Context, r ListTablesRequest) (*ListTablesResponse, error)
+	// RollTable rolls on an oracle table (and the nested tables of the result) using
+	// regular room dice rolls.
+	RollTable(ctx context.Context, r RollTableRequest) (*RollTableResponse, error)
+	// ListTableRolls lists the latest oracle table rolls of a room.
+	ListTableRolls(ctx context.Context, r ListTableRollsRequest) (*ListTableRollsResponse, error)
+	// SubscribeOracleTableRolled subscribes to the room oracle table rolled events.
+	SubscribeOracleTableRolled(ctx context.Context, r SubscribeOracleTableRolledRequest) (*SubscribeOracleTableRolledResponse, error)
+}
+
+//go:generate mockery --case underscore --output oraclemock --outpkg oraclemock --name Service
+
+// ServiceConfig is the service configuration.
+type ServiceConfig struct {
+	OracleTableRepository storage.OracleTableRepository
+	RoomRepository        storage.RoomRepository
+	UserRepository        storage.UserRepository
+	// DiceAppService is used to roll on the tables.
+	DiceAppService  dice.Service
+	EventNotifier   event.Notifier
+	EventSubscriber event.Subscriber
+	Logger          log.Logger
+	IDGenerator     func() string
+	TimeNowFunc     func() time.Time
+}
+
+func (c *ServiceConfig) defaults() error {
+	if c.OracleTableRepository == nil {
+		return fmt.Errorf("config.OracleTableRepository is required")
+	}
+
+	if c.RoomRepository == nil {
+		return fmt.Errorf("config.RoomRepository is required")
+	}
+
+	if c.UserRepository == nil {
+		return fmt.Errorf("config.UserRepository is required")
+	}
+
+	if c.DiceAppService == nil {
+		return fmt.Errorf("config.DiceAppService is required")
+	}
+
+	if c.EventNotifier == nil {
+		return fmt.Errorf("config.EventNotifier is required")
+	}
+
+	if c.EventSubscriber == nil {
+		return fmt.Errorf("config.EventSubscriber is required")
+	}
+
+	if c.Logger == nil {
+		c.Logger = log.Dummy
+	}
+	c.Logger = c.Logger.WithKV(log.KV{"svc": "oracle.Service"})
+
+	if c.IDGenerator == nil {
+		c.IDGenerator = func() string { return uuid.New().String() }
+	}
+
+	if c.TimeNowFunc == nil {
+		c.TimeNowFunc = time.Now
+	}
+
+	return nil
+}
+
+type service struct {
+	tableRepo       storage.OracleTableRepository
+	roomRepo        storage.RoomRepository
+	userRepo        storage.UserRepository
+	diceAppSvc      dice.Service
+	eventNotifier   event.Notifier
+	eventSubscriber event.Subscriber
+	logger          log.Logger
+	idGen           func() string
+	timeNow         func() time.Time
+}
+
+// NewService returns a new oracle.Service.
+func NewService(cfg ServiceConfig) (Service, error) {
+	err := cfg.defaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return service{
+		tableRepo:       cfg.OracleTableRepository,
+		roomRepo:        cfg.RoomRepository,
+		userRepo:        cfg.UserRepository,
+		diceAppSvc:      cfg.DiceAppService,
+		eventNotifier:   cfg.EventNotifier,
+		eventSubscriber: cfg.EventSubscriber,
+		logger:          cfg.Logger,
+		idGen:           cfg.IDGenerator,
+		timeNow:         cfg.TimeNowFunc,
+	}, nil
+}
+
+const (
+	maxRoomTables   = 50
+	maxNameLength   = 100
+	maxDataLength   = 64 * 1024
+	maxEntries      = 200
+	maxResultLength = 500
+	maxListedRolls  = 50
+	// maxNestedRolls is the max number of tables rolled on a single table roll, this protects
+	// against long chains of nested tables.
+	maxNestedRolls = 10
+)
+
+// CreateTableRequest is the request for CreateTable.
+type CreateTableRequest struct {
+	RoomID string
+	UserID string
+	Name   string
+	// Dice is the dice notation expression rolled on the table (e.g: `2d6`), or `d66`.
+	Dice   string
+	Format TableFormat
+	// Data is the table in the request format, mapping value ranges to results and
+	// (optionally) other room tables that will be rolled too.
+	Data string
+}
+
+func (r CreateTableRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	if r.UserID == "" {
+		return fmt.Errorf("config.UserID is required")
+	}
+
+	if r.Name == "" {
+		return fmt.Errorf("config.Name is required")
+	}
+
+	if utf8.RuneCountInString(r.Name) > maxNameLength {
+		return fmt.Errorf("max config.Name length is %d", maxNameLength)
+	}
+
+	if r.Dice == "" {
+		return fmt.Errorf("config.Dice is required")
+	}
+
+	if r.Dice != model.OracleTableDiceD66 {
+		exp, err := notation.Parse(r.Dice)
+		if err != nil {
+			return fmt.Errorf("invalid config.Dice: %w", err)
+		}
+
+		for _, d := range exp.Dice() {
+			_, err := model.NewDieType(d.Sides)
+			if err != nil {
+				return fmt.Errorf("invalid config.Dice: %w", err)
+			}
+		}
+	}
+
+	if r.Data == "" {
+		return fmt.Errorf("config.Data is required")
+	}
+
+	if len(r.Data) > maxDataLength {
+		return fmt.Errorf("max config.Data length is %d", maxDataLength)
+	}
+
+	return nil
+}
+
+// CreateTableResponse is the response for CreateTable.
+type CreateTableResponse struct {
+	Table model.OracleTable
+}
+
+func (s service) CreateTable(ctx context.Context, r CreateTableRequest) (*CreateTableResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	entries, err := parseEntries(r.Format, r.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid config.Data: %s", internalerrors.ErrNotValid, err)
+	}
+
+	err = validateEntries(r.Name, r.Dice, entries)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid config.Data: %s", internalerrors.ErrNotValid, err)
+	}
+
+	err = roomcheck.WritableRoomUser(ctx, s.userRepo, s.roomRepo, r.RoomID, r.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	tables, err := s.tableRepo.ListRoomOracleTables(ctx, r.RoomID)
+	if err != nil {
+		return nil, fmt.Errorf("could not list room oracle tables: %w", err)
+	}
+	if len(tables.Items) >= maxRoomTables {
+		return nil, fmt.Errorf("max oracle tables per room is %d: %w", maxRoomTables, internalerrors.ErrNotValid)
+	}
+
+	// Nested tables need to exist beforehand, this way a table can't end in a roll loop.
+	for _, e := range entries {
+		if e.TableName == "" {
+			continue
+		}
+
+		found := slices.ContainsFunc(tables.Items, func(t model.OracleTable) bool { return t.Name == e.TableName })
+		if !found {
+			return nil, fmt.Errorf("nested table %q does not exist on the room: %w", e.TableName, internalerrors.ErrNotValid)
+		}
+	}
+
+	t := model.OracleTable{
+		ID:        s.idGen(),
+		CreatedAt: s.timeNow().UTC(),
+		RoomID:    r.RoomID,
+		Name:      r.Name,
+		Dice:      r.Dice,
+		Entries:   entries,
+	}
+
+	err = s.tableRepo.CreateOracleTable(ctx, t)
+	if err != nil {
+		return nil, fmt.Errorf("could not store oracle table: %w", err)
+	}
+
+	return &CreateTableResponse{
+		Table: t,
+	}, nil
+}
+
+// validateEntries validates the parsed table entries, every value the table dice can
+// roll needs to select an entry.
+func validateEntries(tableName, dice string, entries []model.OracleTableEntry) error {
+	if len(entries) == 0 {
+		return fmt.Errorf("at least one entry is required")
+	}
+
+	if len(entries) > maxEntries {
+		return fmt.Errorf("max entries quantity is %d", maxEntries)
+	}
+
+	for i, e := range entries {
+		if e.Min > e.Max {
+			return fmt.Errorf("entry %d range min is greater than max", i+1)
+		}
+
+		if e.Result == "" && e.TableName == "" {
+			return fmt.Errorf("entry %d requires a result or a table", i+1)
+		}
+
+		if utf8.RuneCountInString(e.Result) > maxResultLength {
+			return fmt.Errorf("max entry result length is %d", maxResultLength)
+		}
+
+		if e.TableName == tableName {
+			return fmt.Errorf("entry %d can't reference its own table", i+1)
+		}
+
+		for j, e2 := range entries[:i] {
+			if e.Min <= e2.Max && e2.Min <= e.Max {
+				return fmt.Errorf("entry %d range overlaps with entry %d", i+1, j+1)
+			}
+		}
+	}
+
+	return validateCoverage(dice, entries)
+}
+
+// validateCoverage validates the entries cover all the values the table dice can roll.
+func validateCoverage(dice string, entries []model.OracleTableEntry) error {
+	t := model.OracleTable{Dice: dice, Entries: entries}
+
+	// D66 values are the tens and units of 2 D6.
+	if dice == model.OracleTableDiceD66 {
+		for tens := 1; tens <= 6; tens++ {
+			for units := 1; units <= 6; units++ {
+				value := tens*10 + units
+				if _, ok := t.Entry(value); !ok {
+					return fmt.Errorf("value %d is not covered by any entry", value)
+				}
+			}
+		}
+		return nil
+	}
+
+	exp, err := notation.Parse(dice)
+	if err != nil {
+		return fmt.Errorf("invalid dice: %w", err)
+	}
+	min, max := exp.Range()
+
+	// Walk the entries by range, any gap between the dice min and max is not covered.
+	sorted := slices.Clone(entries)
+	slices.SortFunc(sorted, func(a, b model.OracleTableEntry) int { return a.Min - b.Min })
+	next := min
+	for _, e := range sorted {
+		if next > max {
+			break
+		}
+		if e.Max < next {
+			continue
+		}
+		if e.Min > next {
+			return fmt.Errorf("value %d is not covered by any entry", next)
+		}
+		next = e.Max + 1
+	}
+	if next <= max {
+		return fmt.Errorf("value %d is not covered by any entry", next)
+	}
+
+	return nil
+}
+
+// ListTablesRequest is the request for ListTables.
+type ListTablesRequest struct {
+	RoomID string
+}
+
+func (r ListTablesRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	return nil
+}
+
+// ListTablesResponse is the response for ListTables.
+type ListTablesResponse struct {
+	Tables []model.OracleTable
+}
+
+func (s service) ListTables(ctx context.Context, r ListTablesRequest) (*ListTablesResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	err = s.checkRoomExists(ctx, r.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	tables, err := s.tableRepo.ListRoomOracleTables(ctx, r.RoomID)
+	if err != nil {
+		return nil, fmt.Errorf("could not list room oracle tables: %w", err)
+	}
+
+	return &ListTablesResponse{
+		Tables: tables.Items,
+	}, nil
+}
+
+// RollTableRequest is the request for RollTable.
+type RollTableRequest struct {
+	RoomID  string
+	UserID  string
+	TableID string
+}
+
+func (r RollTableRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	if r.UserID == "" {
+		return fmt.Errorf("config.UserID is required")
+	}
+
+	if r.TableID == "" {
+		return fmt.Errorf("config.TableID is required")
+	}
+
+	return nil
+}
+
+// RollTableResponse is the response for RollTable.
+type RollTableResponse struct {
+	TableRoll model.OracleTableRoll
+	// DiceRolls are the room dice rolls made on the table roll, in the same order as the results.
+	DiceRolls []model.DiceRoll
+}
+
+func (s service) RollTable(ctx context.Context, r RollTableRequest) (*RollTableResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	err = roomcheck.WritableRoomUser(ctx, s.userRepo, s.roomRepo, r.RoomID, r.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := s.tableRepo.GetOracleTable(ctx, r.TableID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get oracle table: %w", err)
+	}
+
+	if t.RoomID != r.RoomID {
+		return nil, fmt.Errorf("oracle table is not from the room: %w", internalerrors.ErrMissing)
+	}
+
+	tr := model.OracleTableRoll{
+		ID:        s.idGen(),
+		CreatedAt: s.timeNow().UTC(),
+		RoomID:    r.RoomID,
+		UserID:    r.UserID,
+		TableID:   t.ID,
+	}
+
+	// Roll on the table and keep rolling on the nested tables of the results, all the dice
+	// are rolled before creating any of them, so a failure doesn't leave orphan dice rolls.
+	// The table roll is stored before the dice rolls, so no event is sent until both are stored.
+	resp, err := s.diceAppSvc.CreateDiceRollSequence(ctx, dice.CreateDiceRollSequenceRequest{
+		DiceRoll: tableDiceRoll(r.RoomID, r.UserID, *t),
+		Next: func(ctx context.Context, dr model.DiceRoll) (*dice.CreateDiceRollRequest, error) {
+			value, err := tableValue(*t, dr)
+			if err != nil {
+				return nil, err
+			}
+
+			e, ok := t.Entry(value)
+			if !ok {
+				return nil, fmt.Errorf("value %d of table %q is not covered by any entry: %w", value, t.Name, internalerrors.ErrNotValid)
+			}
+			tr.Results = append(tr.Results, model.OracleTableResult{
+				TableName:  t.Name,
+				DiceRollID: dr.ID,
+				Value:      value,
+				Result:     e.Result,
+			})
+
+			if e.TableName == "" || len(tr.Results) >= maxNestedRolls {
+				return nil, nil
+			}
+
+			t, err = s.tableRepo.GetOracleTableByName(ctx, r.RoomID, e.TableName)
+			if err != nil {
+				return nil, fmt.Errorf("could not get nested oracle table %q: %w", e.TableName, err)
+			}
+			next := tableDiceRoll(r.RoomID, r.UserID, *t)
+
+			return &next, nil
+		},
+		BeforeCreate: func(ctx context.Context, drs []model.DiceRoll) error {
+			err := s.tableRepo.CreateOracleTableRoll(ctx, tr)
+			if err != nil {
+				return fmt.Errorf("could not store oracle table roll: %w", err)
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not roll on oracle table: %w", err)
+	}
+
+	err = s.eventNotifier.NotifyOracleTableRolled(ctx, model.EventOracleTableRolled{OracleTableRoll: tr})
+	if err != nil {
+		return nil, fmt.Errorf("could not send oracle table rolled event: %w", err)
+	}
+
+	return &RollTableResponse{
+		TableRoll: tr,
+		DiceRolls: resp.DiceRolls,
+	}, nil
+}
+
+// tableDiceRoll returns the table dice as a regular room dice roll.
+func tableDiceRoll(roomID, userID string, t model.OracleTable) dice.CreateDiceRollRequest {
+	req := dice.CreateDiceRollRequest{
+		RoomID:     roomID,
+		UserID:     userID,
+		Expression: t.Dice,
+		Label:      "Oracle: " + t.Name,
+	}
+
+	// D66 are 2 D6 read as tens and units instead of added.
+	if t.Dice == model.OracleTableDiceD66 {
+		req.Expression = ""
+		req.Dice = []model.DieType{model.DieTypeD6, model.DieTypeD6}
+	}
+
+	return req
+}
+
+// tableValue returns the value of the table dice roll used to select the table entry.
+func tableValue(t model.OracleTable, dr model.DiceRoll) (int, error) {
+	if t.Dice != model.OracleTableDiceD66 {
+		return dr.Total, nil
+	}
+
+	if len(dr.Dice) != 2 {
+		return 0, fmt.Errorf("d66 dice roll should have 2 dice, got %d", len(dr.Dice))
+	}
+
+	return int(dr.Dice[0].Side*10 + dr.Dice[1].Side), nil
+}
+
+// ListTableRollsRequest is the request for ListTableRolls.
+type ListTableRollsRequest struct {
+	RoomID string
+}
+
+func (r ListTableRollsRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	return nil
+}
+
+// ListTableRollsResponse is the response for ListTableRolls.
+type ListTableRollsResponse struct {
+	// TableRolls are the latest room table rolls, newest first.
+	TableRolls []model.OracleTableRoll
+}
+
+func (s service) ListTableRolls(ctx context.Context, r ListTableRollsRequest) (*ListTableRollsResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	err = s.checkRoomExists(ctx, r.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	rolls, err := s.tableRepo.ListRoomOracleTableRolls(ctx, r.RoomID, maxListedRolls)
+	if err != nil {
+		return nil, fmt.Errorf("could not list room oracle table rolls: %w", err)
+	}
+
+	return &ListTableRollsResponse{
+		TableRolls: rolls.Items,
+	}, nil
+}
+
+// SubscribeOracleTableRolledRequest is the request for SubscribeOracleTableRolled.
+type SubscribeOracleTableRolledRequest struct {
+	RoomID       string
+	EventHandler func(context.Context, model.EventOracleTableRolled) error
+}
+
+func (r SubscribeOracleTableRolledRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("roomID is required")
+	}
+
+	if r.EventHandler == nil {
+		return fmt.Errorf("eventHandler is required")
+	}
+
+	return nil
+}
+
+// SubscribeOracleTableRolledResponse is the response for SubscribeOracleTableRolled.
+type SubscribeOracleTableRolledResponse struct {
+	UnsubscribeFunc func() error
+}
+
+func (s service) SubscribeOracleTableRolled(ctx context.Context, r SubscribeOracleTableRolledRequest) (*SubscribeOracleTableRolledResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	err = s.checkRoomExists(ctx, r.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a subscription ID and subscribe.
+	subscriptionID := s.idGen()
+	err = s.eventSubscriber.SubscribeOracleTableRolled(ctx, subscriptionID, r.RoomID, r.EventHandler)
+	if err != nil {
+		return nil, fmt.Errorf("could not subscribe to oracleTableRolled events: %w", err)
+	}
+
+	return &SubscribeOracleTableRolledResponse{
+		UnsubscribeFunc: func() error {
+			return s.eventSubscriber.UnsubscribeOracleTableRolled(ctx, subscriptionID, r.RoomID)
+		},
+	}, nil
+}
+
+// checkRoomExists checks the room exists.
+func (s service) checkRoomExists(ctx context.Context, roomID string) error {
+	exists, err := s.roomRepo.RoomExists(ctx, roomID)
+	if err != nil {
+		return fmt.Errorf("could not check if room exists: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("room does not exists: %w", internalerrors.ErrNotValid)
+	}
+
+	return nil
+}
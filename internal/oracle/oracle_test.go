@@ -0,0 +1,557 @@
+package oracle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/dice"
+	"github.com/rollify/rollify/internal/dice/dicemock"
+	"github.com/rollify/rollify/internal/event/eventmock"
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/oracle"
+	"github.com/rollify/rollify/internal/storage"
+	"github.com/rollify/rollify/internal/storage/storagemock"
+)
+
+type mocks struct {
+	mt *storagemock.OracleTableRepository
+	mr *storagemock.RoomRepository
+	mu *storagemock.UserRepository
+	md *dicemock.Service
+	mn *eventmock.Notifier
+	ms *eventmock.Subscriber
+}
+
+func newMocks() mocks {
+	return mocks{
+		mt: &storagemock.OracleTableRepository{},
+		mr: &storagemock.RoomRepository{},
+		mu: &storagemock.UserRepository{},
+		md: &dicemock.Service{},
+		mn: &eventmock.Notifier{},
+		ms: &eventmock.Subscriber{},
+	}
+}
+
+func (m mocks) assertExpectations(t *testing.T) {
+	m.mt.AssertExpectations(t)
+	m.mr.AssertExpectations(t)
+	m.mu.AssertExpectations(t)
+	m.md.AssertExpectations(t)
+	m.mn.AssertExpectations(t)
+	m.ms.AssertExpectations(t)
+}
+
+func newService(t *testing.T, m mocks, t0 time.Time) oracle.Service {
+	svc, err := oracle.NewService(oracle.ServiceConfig{
+		OracleTableRepository: m.mt,
+		RoomRepository:        m.mr,
+		UserRepository:        m.mu,
+		DiceAppService:        m.md,
+		EventNotifier:         m.mn,
+		EventSubscriber:       m.ms,
+		IDGenerator:           func() string { return "test" },
+		TimeNowFunc:           func() time.Time { return t0 },
+	})
+	require.NoError(t, err)
+
+	return svc
+}
+
+func getMonstersTable() *model.OracleTable {
+	return &model.OracleTable{
+		ID:     "table2-id",
+		RoomID: "room-id",
+		Name:   "Monsters",
+		Dice:   model.OracleTableDiceD66,
+		Entries: []model.OracleTableEntry{
+			{Min: 11, Max: 36, Result: "Goblin"},
+			{Min: 41, Max: 66, Result: "Orc"},
+		},
+	}
+}
+
+func getEncountersTable() *model.OracleTable {
+	return &model.OracleTable{
+		ID:     "table1-id",
+		RoomID: "room-id",
+		Name:   "Encounters",
+		Dice:   "1d6+1",
+		Entries: []model.OracleTableEntry{
+			{Min: 2, Max: 4, Result: "Nothing"},
+			{Min: 5, Max: 6, Result: "Monsters!", TableName: "Monsters"},
+			{Min: 7, Max: 7, Result: "A dragon"},
+		},
+	}
+}
+
+// rollSequence returns a dice roll sequence mock that checks the requested dice rolls and
+// uses the dice rolls as the rolled ones in order, calling the before create hook at the end.
+func rollSequence(t *testing.T, expReqs []dice.CreateDiceRollRequest, drs []model.DiceRoll) func(context.Context, dice.CreateDiceRollSequenceRequest) (*dice.CreateDiceRollSequenceResponse, error) {
+	return func(ctx context.Context, r dice.CreateDiceRollSequenceRequest) (*dice.CreateDiceRollSequenceResponse, error) {
+		req := &r.DiceRoll
+		for i, dr := range drs {
+			assert.Equal(t, expReqs[i], *req)
+
+			next, err := r.Next(ctx, dr)
+			if err != nil {
+				return nil, err
+			}
+			if next == nil {
+				if r.BeforeCreate != nil {
+					err := r.BeforeCreate(ctx, drs[:i+1])
+					if err != nil {
+						return nil, err
+					}
+				}
+				return &dice.CreateDiceRollSequenceResponse{DiceRolls: drs[:i+1]}, nil
+			}
+			req = next
+		}
+
+		return nil, errors.New("unexpected dice roll on the sequence")
+	}
+}
+
+func TestServiceCreateTable(t *testing.T) {
+	t0 := time.Now().UTC()
+	u := &model.User{ID: "user-id", Name: "Bilbo", RoomID: "room-id"}
+
+	tests := map[string]struct {
+		mock    func(m mocks)
+		req     oracle.CreateTableRequest
+		expResp *oracle.CreateTableResponse
+		expErr  error
+	}{
+		"Having a table on an archived room, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id", Archived: true}, nil)
+			},
+			req:    oracle.CreateTableRequest{RoomID: "room-id", UserID: "user-id", Name: "Weather", Dice: "1d6", Format: oracle.TableFormatCSV, Data: "1-6,Sun"},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having a request without name, should fail.": {
+			mock:   func(m mocks) {},
+			req:    oracle.CreateTableRequest{RoomID: "room-id", UserID: "user-id", Dice: "1d6", Format: oracle.TableFormatCSV, Data: "1-6,Nothing"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request with invalid dice, should fail.": {
+			mock:   func(m mocks) {},
+			req:    oracle.CreateTableRequest{RoomID: "room-id", UserID: "user-id", Name: "Weather", Dice: "1d6+", Format: oracle.TableFormatCSV, Data: "1-6,Nothing"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request with an unknown format, should fail.": {
+			mock:   func(m mocks) {},
+			req:    oracle.CreateTableRequest{RoomID: "room-id", UserID: "user-id", Name: "Weather", Dice: "1d6", Format: "xml", Data: "1-6,Nothing"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request with an invalid range, should fail.": {
+			mock:   func(m mocks) {},
+			req:    oracle.CreateTableRequest{RoomID: "room-id", UserID: "user-id", Name: "Weather", Dice: "1d6", Format: oracle.TableFormatCSV, Data: "1-a,Nothing"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request with overlapping ranges, should fail.": {
+			mock:   func(m mocks) {},
+			req:    oracle.CreateTableRequest{RoomID: "room-id", UserID: "user-id", Name: "Weather", Dice: "1d6", Format: oracle.TableFormatCSV, Data: "1-3,Sun\n3-6,Rain"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request with an entry without result nor table, should fail.": {
+			mock:   func(m mocks) {},
+			req:    oracle.CreateTableRequest{RoomID: "room-id", UserID: "user-id", Name: "Weather", Dice: "1d6", Format: oracle.TableFormatYAML, Data: "- range: 1-6\n"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request with an entry referencing its own table, should fail.": {
+			mock:   func(m mocks) {},
+			req:    oracle.CreateTableRequest{RoomID: "room-id", UserID: "user-id", Name: "Weather", Dice: "1d6", Format: oracle.TableFormatCSV, Data: "1-6,,Weather"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request with entries that don't cover all the dice values, should fail.": {
+			mock:   func(m mocks) {},
+			req:    oracle.CreateTableRequest{RoomID: "room-id", UserID: "user-id", Name: "Weather", Dice: "1d6+1", Format: oracle.TableFormatCSV, Data: "2-3,Sun\n5-7,Rain"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request with entries that don't cover the dice max value, should fail.": {
+			mock:   func(m mocks) {},
+			req:    oracle.CreateTableRequest{RoomID: "room-id", UserID: "user-id", Name: "Weather", Dice: "2d6", Format: oracle.TableFormatCSV, Data: "1-6,Sun\n7-11,Rain"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a d66 request with entries that don't cover all the d66 values, should fail.": {
+			mock:   func(m mocks) {},
+			req:    oracle.CreateTableRequest{RoomID: "room-id", UserID: "user-id", Name: "Weather", Dice: "d66", Format: oracle.TableFormatCSV, Data: "11-35,Sun\n41-66,Rain"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a user from another room, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(&model.User{ID: "user-id", RoomID: "other-room"}, nil)
+			},
+			req:    oracle.CreateTableRequest{RoomID: "room-id", UserID: "user-id", Name: "Weather", Dice: "1d6", Format: oracle.TableFormatCSV, Data: "1-6,Sun"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having an entry referencing a missing table, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.mt.On("ListRoomOracleTables", mock.Anything, "room-id").Once().Return(&storage.OracleTableList{}, nil)
+			},
+			req:    oracle.CreateTableRequest{RoomID: "room-id", UserID: "user-id", Name: "Encounters", Dice: "1d6", Format: oracle.TableFormatCSV, Data: "1-6,,Monsters"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Creating a table from YAML should create the table with the parsed entries.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.mt.On("ListRoomOracleTables", mock.Anything, "room-id").Once().Return(&storage.OracleTableList{Items: []model.OracleTable{*getMonstersTable()}}, nil)
+				exp := model.OracleTable{
+					ID:        "test",
+					CreatedAt: t0,
+					RoomID:    "room-id",
+					Name:      "Encounters",
+					Dice:      "1d6+1",
+					Entries: []model.OracleTableEntry{
+						{Min: 2, Max: 4, Result: "Nothing"},
+						{Min: 5, Max: 6, Result: "Monsters!", TableName: "Monsters"},
+						{Min: 7, Max: 7, Result: "A dragon"},
+					},
+				}
+				m.mt.On("CreateOracleTable", mock.Anything, exp).Once().Return(nil)
+			},
+			req: oracle.CreateTableRequest{
+				RoomID: "room-id",
+				UserID: "user-id",
+				Name:   "Encounters",
+				Dice:   "1d6+1",
+				Format: oracle.TableFormatYAML,
+				Data: `
+- range: 2-4
+  result: Nothing
+- range: 5-6
+  result: Monsters!
+  table: Monsters
+- range: 7
+  result: A dragon
+`,
+			},
+			expResp: &oracle.CreateTableResponse{
+				Table: model.OracleTable{
+					ID:        "test",
+					CreatedAt: t0,
+					RoomID:    "room-id",
+					Name:      "Encounters",
+					Dice:      "1d6+1",
+					Entries: []model.OracleTableEntry{
+						{Min: 2, Max: 4, Result: "Nothing"},
+						{Min: 5, Max: 6, Result: "Monsters!", TableName: "Monsters"},
+						{Min: 7, Max: 7, Result: "A dragon"},
+					},
+				},
+			},
+		},
+
+		"Creating a table from CSV should create the table with the parsed entries.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.mt.On("ListRoomOracleTables", mock.Anything, "room-id").Once().Return(&storage.OracleTableList{}, nil)
+				m.mt.On("CreateOracleTable", mock.Anything, mock.Anything).Once().Return(nil)
+			},
+			req: oracle.CreateTableRequest{
+				RoomID: "room-id",
+				UserID: "user-id",
+				Name:   "Monsters",
+				Dice:   "d66",
+				Format: oracle.TableFormatCSV,
+				Data:   "range,result\n11-36,Goblin\n41-66,\"Orc, angry\"\n",
+			},
+			expResp: &oracle.CreateTableResponse{
+				Table: model.OracleTable{
+					ID:        "test",
+					CreatedAt: t0,
+					RoomID:    "room-id",
+					Name:      "Monsters",
+					Dice:      model.OracleTableDiceD66,
+					Entries: []model.OracleTableEntry{
+						{Min: 11, Max: 36, Result: "Goblin"},
+						{Min: 41, Max: 66, Result: "Orc, angry"},
+					},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := newMocks()
+			test.mock(m)
+
+			svc := newService(t, m, t0)
+			gotResp, err := svc.CreateTable(context.TODO(), test.req)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				assert.Equal(test.expResp, gotResp)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestServiceRollTable(t *testing.T) {
+	t0 := time.Now().UTC()
+	u := &model.User{ID: "user-id", Name: "Bilbo", RoomID: "room-id"}
+
+	tests := map[string]struct {
+		mock    func(m mocks)
+		req     oracle.RollTableRequest
+		expResp *oracle.RollTableResponse
+		expErr  error
+	}{
+		"Rolling a table on an archived room, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id", Archived: true}, nil)
+			},
+			req:    oracle.RollTableRequest{RoomID: "room-id", UserID: "user-id", TableID: "table1-id"},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having a request without table, should fail.": {
+			mock:   func(m mocks) {},
+			req:    oracle.RollTableRequest{RoomID: "room-id", UserID: "user-id"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a table from another room, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				t := getEncountersTable()
+				t.RoomID = "other-room"
+				m.mt.On("GetOracleTable", mock.Anything, "table1-id").Once().Return(t, nil)
+			},
+			req:    oracle.RollTableRequest{RoomID: "room-id", UserID: "user-id", TableID: "table1-id"},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Rolling on a table should roll the table dice and return the selected result.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.mt.On("GetOracleTable", mock.Anything, "table1-id").Once().Return(getEncountersTable(), nil)
+				expReqs := []dice.CreateDiceRollRequest{{RoomID: "room-id", UserID: "user-id", Expression: "1d6+1", Label: "Oracle: Encounters"}}
+				drs := []model.DiceRoll{{ID: "dr1", Total: 3}}
+				m.md.On("CreateDiceRollSequence", mock.Anything, mock.Anything).Once().Return(rollSequence(t, expReqs, drs), nil)
+				expRoll := model.OracleTableRoll{
+					ID:        "test",
+					CreatedAt: t0,
+					RoomID:    "room-id",
+					UserID:    "user-id",
+					TableID:   "table1-id",
+					Results:   []model.OracleTableResult{{TableName: "Encounters", DiceRollID: "dr1", Value: 3, Result: "Nothing"}},
+				}
+				m.mt.On("CreateOracleTableRoll", mock.Anything, expRoll).Once().Return(nil)
+				m.mn.On("NotifyOracleTableRolled", mock.Anything, model.EventOracleTableRolled{OracleTableRoll: expRoll}).Once().Return(nil)
+			},
+			req: oracle.RollTableRequest{RoomID: "room-id", UserID: "user-id", TableID: "table1-id"},
+			expResp: &oracle.RollTableResponse{
+				TableRoll: model.OracleTableRoll{
+					ID:        "test",
+					CreatedAt: t0,
+					RoomID:    "room-id",
+					UserID:    "user-id",
+					TableID:   "table1-id",
+					Results:   []model.OracleTableResult{{TableName: "Encounters", DiceRollID: "dr1", Value: 3, Result: "Nothing"}},
+				},
+				DiceRolls: []model.DiceRoll{{ID: "dr1", Total: 3}},
+			},
+		},
+
+		"Rolling on a table without an entry for the rolled value, should fail without storing the roll.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				t1 := getEncountersTable()
+				t1.Entries = t1.Entries[:2]
+				m.mt.On("GetOracleTable", mock.Anything, "table1-id").Once().Return(t1, nil)
+				expReqs := []dice.CreateDiceRollRequest{{RoomID: "room-id", UserID: "user-id", Expression: "1d6+1", Label: "Oracle: Encounters"}}
+				drs := []model.DiceRoll{{ID: "dr1", Total: 7}}
+				m.md.On("CreateDiceRollSequence", mock.Anything, mock.Anything).Once().Return(rollSequence(t, expReqs, drs), nil)
+			},
+			req:    oracle.RollTableRequest{RoomID: "room-id", UserID: "user-id", TableID: "table1-id"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Rolling on a table with a nested table result should roll on the nested table too.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.mt.On("GetOracleTable", mock.Anything, "table1-id").Once().Return(getEncountersTable(), nil)
+				m.mt.On("GetOracleTableByName", mock.Anything, "room-id", "Monsters").Once().Return(getMonstersTable(), nil)
+
+				expReqs := []dice.CreateDiceRollRequest{
+					{RoomID: "room-id", UserID: "user-id", Expression: "1d6+1", Label: "Oracle: Encounters"},
+					{RoomID: "room-id", UserID: "user-id", Dice: []model.DieType{model.DieTypeD6, model.DieTypeD6}, Label: "Oracle: Monsters"},
+				}
+				drs := []model.DiceRoll{
+					{ID: "dr1", Total: 6},
+					{ID: "dr2", Total: 7, Dice: []model.DieRoll{{Type: model.DieTypeD6, Side: 5}, {Type: model.DieTypeD6, Side: 2}}},
+				}
+				m.md.On("CreateDiceRollSequence", mock.Anything, mock.Anything).Once().Return(rollSequence(t, expReqs, drs), nil)
+
+				m.mt.On("CreateOracleTableRoll", mock.Anything, mock.Anything).Once().Return(nil)
+				m.mn.On("NotifyOracleTableRolled", mock.Anything, mock.Anything).Once().Return(nil)
+			},
+			req: oracle.RollTableRequest{RoomID: "room-id", UserID: "user-id", TableID: "table1-id"},
+			expResp: &oracle.RollTableResponse{
+				TableRoll: model.OracleTableRoll{
+					ID:        "test",
+					CreatedAt: t0,
+					RoomID:    "room-id",
+					UserID:    "user-id",
+					TableID:   "table1-id",
+					Results: []model.OracleTableResult{
+						{TableName: "Encounters", DiceRollID: "dr1", Value: 6, Result: "Monsters!"},
+						{TableName: "Monsters", DiceRollID: "dr2", Value: 52, Result: "Orc"},
+					},
+				},
+				DiceRolls: []model.DiceRoll{
+					{ID: "dr1", Total: 6},
+					{ID: "dr2", Total: 7, Dice: []model.DieRoll{{Type: model.DieTypeD6, Side: 5}, {Type: model.DieTypeD6, Side: 2}}},
+				},
+			},
+		},
+
+		"Having an error while getting a nested table, should fail without storing the roll.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.mt.On("GetOracleTable", mock.Anything, "table1-id").Once().Return(getEncountersTable(), nil)
+				m.mt.On("GetOracleTableByName", mock.Anything, "room-id", "Monsters").Once().Return(nil, internalerrors.ErrMissing)
+
+				expReqs := []dice.CreateDiceRollRequest{{RoomID: "room-id", UserID: "user-id", Expression: "1d6+1", Label: "Oracle: Encounters"}}
+				drs := []model.DiceRoll{{ID: "dr1", Total: 6}}
+				m.md.On("CreateDiceRollSequence", mock.Anything, mock.Anything).Once().Return(rollSequence(t, expReqs, drs), nil)
+			},
+			req:    oracle.RollTableRequest{RoomID: "room-id", UserID: "user-id", TableID: "table1-id"},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Having an error while storing the table roll, should fail without sending the event.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.mt.On("GetOracleTable", mock.Anything, "table1-id").Once().Return(getEncountersTable(), nil)
+				expReqs := []dice.CreateDiceRollRequest{{RoomID: "room-id", UserID: "user-id", Expression: "1d6+1", Label: "Oracle: Encounters"}}
+				drs := []model.DiceRoll{{ID: "dr1", Total: 3}}
+				m.md.On("CreateDiceRollSequence", mock.Anything, mock.Anything).Once().Return(rollSequence(t, expReqs, drs), nil)
+				m.mt.On("CreateOracleTableRoll", mock.Anything, mock.Anything).Once().Return(internalerrors.ErrConflict)
+			},
+			req:    oracle.RollTableRequest{RoomID: "room-id", UserID: "user-id", TableID: "table1-id"},
+			expErr: internalerrors.ErrConflict,
+		},
+
+		"Having an error while rolling the dice, should fail.": {
+			mock: func(m mocks) {
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+				m.mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				m.mt.On("GetOracleTable", mock.Anything, "table1-id").Once().Return(getEncountersTable(), nil)
+				m.md.On("CreateDiceRollSequence", mock.Anything, mock.Anything).Once().Return(nil, internalerrors.ErrRateLimited)
+			},
+			req:    oracle.RollTableRequest{RoomID: "room-id", UserID: "user-id", TableID: "table1-id"},
+			expErr: internalerrors.ErrRateLimited,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := newMocks()
+			test.mock(m)
+
+			svc := newService(t, m, t0)
+			gotResp, err := svc.RollTable(context.TODO(), test.req)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				assert.Equal(test.expResp, gotResp)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestServiceListTableRolls(t *testing.T) {
+	t0 := time.Now().UTC()
+
+	tests := map[string]struct {
+		mock    func(m mocks)
+		req     oracle.ListTableRollsRequest
+		expResp *oracle.ListTableRollsResponse
+		expErr  error
+	}{
+		"Having a missing room, should fail.": {
+			mock: func(m mocks) {
+				m.mr.On("RoomExists", mock.Anything, "room-id").Once().Return(false, nil)
+			},
+			req:    oracle.ListTableRollsRequest{RoomID: "room-id"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Listing the table rolls should return the latest room table rolls.": {
+			mock: func(m mocks) {
+				m.mr.On("RoomExists", mock.Anything, "room-id").Once().Return(true, nil)
+				m.mt.On("ListRoomOracleTableRolls", mock.Anything, "room-id", 50).Once().Return(&storage.OracleTableRollList{
+					Items: []model.OracleTableRoll{{ID: "roll2"}, {ID: "roll1"}},
+				}, nil)
+			},
+			req: oracle.ListTableRollsRequest{RoomID: "room-id"},
+			expResp: &oracle.ListTableRollsResponse{
+				TableRolls: []model.OracleTableRoll{{ID: "roll2"}, {ID: "roll1"}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := newMocks()
+			test.mock(m)
+
+			svc := newService(t, m, t0)
+			gotResp, err := svc.ListTableRolls(context.TODO(), test.req)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				assert.Equal(test.expResp, gotResp)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
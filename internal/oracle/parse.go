@@ -0,0 +1,154 @@
+package oracle
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/rollify/rollify/internal/model"
+)
+
+// TableFormat is the format of the uploaded oracle table data.
+type TableFormat string
+
+const (
+	// TableFormatYAML is a YAML list of entries, e.g:
+	//
+	//	- range: 1-3
+	//	  result: Nothing happens
+	//	- range: 4-6
+	//	  result: Monsters!
+	//	  table: Monsters
+	TableFormatYAML TableFormat = "yaml"
+	// TableFormatCSV is a CSV with `range,result[,table]` rows, the first row can be
+	// a header (starting with `range`), e.g:
+	//
+	//	1-3,Nothing happens
+	//	4-6,Monsters!,Monsters
+	TableFormatCSV TableFormat = "csv"
+)
+
+// parseEntries parses the uploaded oracle table data into the table entries.
+func parseEntries(format TableFormat, data string) ([]model.OracleTableEntry, error) {
+	switch format {
+	case TableFormatYAML:
+		return parseYAMLEntries(data)
+	case TableFormatCSV:
+		return parseCSVEntries(data)
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+type yamlEntry struct {
+	Range  string `yaml:"range"`
+	Result string `yaml:"result"`
+	Table  string `yaml:"table"`
+}
+
+func parseYAMLEntries(data string) ([]model.OracleTableEntry, error) {
+	yes := []yamlEntry{}
+	err := yaml.Unmarshal([]byte(data), &yes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+
+	es := make([]model.OracleTableEntry, 0, len(yes))
+	for i, ye := range yes {
+		e, err := newEntry(ye.Range, ye.Result, ye.Table)
+		if err != nil {
+			return nil, fmt.Errorf("invalid entry %d: %w", i+1, err)
+		}
+		es = append(es, *e)
+	}
+
+	return es, nil
+}
+
+func parseCSVEntries(data string) ([]model.OracleTableEntry, error) {
+	r := csv.NewReader(strings.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	es := []model.OracleTableEntry{}
+	for line := 1; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		// Ignore the header.
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "range") {
+			continue
+		}
+
+		if len(record) < 2 || len(record) > 3 {
+			return nil, fmt.Errorf("invalid CSV line %d: expected range, result and optional table columns", line)
+		}
+
+		table := ""
+		if len(record) == 3 {
+			table = record[2]
+		}
+
+		e, err := newEntry(record[0], record[1], table)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV line %d: %w", line, err)
+		}
+		es = append(es, *e)
+	}
+
+	return es, nil
+}
+
+func newEntry(rng, result, table string) (*model.OracleTableEntry, error) {
+	min, max, err := parseRange(rng)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.OracleTableEntry{
+		Min:       min,
+		Max:       max,
+		Result:    strings.TrimSpace(result),
+		TableName: strings.TrimSpace(table),
+	}, nil
+}
+
+// parseRange parses a single value (`5`) or an inclusive range (`1-3`, `-2--1`).
+func parseRange(s string) (min, max int, err error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, 0, fmt.Errorf("range is required")
+	}
+
+	// Skip the first char so we don't split on a negative min sign.
+	idx := strings.Index(s[1:], "-")
+	if idx < 0 {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid range %q", s)
+		}
+		return v, v, nil
+	}
+	idx++
+
+	min, err = strconv.Atoi(strings.TrimSpace(s[:idx]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid range %q", s)
+	}
+
+	max, err = strconv.Atoi(strings.TrimSpace(s[idx+1:]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid range %q", s)
+	}
+
+	return min, max, nil
+}
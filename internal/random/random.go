@@ -0,0 +1,35 @@
+// Package random has the helpers to get unbiased random numbers from a random source (e.g:
+// crypto/rand or a deterministic stream).
+package random
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Uint returns an uniform random number in [0, n) read from r using rejection sampling, a plain
+// modulo would favor the lower numbers when n is not a power of 2.
+//
+// Each try reads 4 bytes as a big endian uint32, the deterministic sources (e.g: provably fair
+// dice rolls) depend on it, so it must not change.
+func Uint(r io.Reader, n uint64) (uint64, error) {
+	if n == 0 || n > 1<<32 {
+		return 0, fmt.Errorf("invalid n: %d", n)
+	}
+
+	// Reject the random values from the last incomplete block of N values.
+	limit := (1 << 32) / n * n
+
+	var b [4]byte
+	for {
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return 0, fmt.Errorf("could not read random data: %w", err)
+		}
+
+		v := uint64(binary.BigEndian.Uint32(b[:]))
+		if v < limit {
+			return v % n, nil
+		}
+	}
+}
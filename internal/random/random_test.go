@@ -0,0 +1,69 @@
+package random_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rollify/rollify/internal/random"
+)
+
+func TestUint(t *testing.T) {
+	tests := map[string]struct {
+		data   []byte
+		n      uint64
+		expN   uint64
+		expErr bool
+	}{
+		"Zero should fail.": {
+			data:   []byte{0, 0, 0, 1},
+			n:      0,
+			expErr: true,
+		},
+
+		"More than 2^32 should fail.": {
+			data:   []byte{0, 0, 0, 1},
+			n:      1<<32 + 1,
+			expErr: true,
+		},
+
+		"Not having enough random data should fail.": {
+			data:   []byte{0, 0, 1},
+			n:      6,
+			expErr: true,
+		},
+
+		"A random value should be returned in the range.": {
+			data: []byte{0, 0, 0, 10},
+			n:    6,
+			expN: 4,
+		},
+
+		"A random value on the last incomplete block should be rejected and use the next one.": {
+			data: []byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 3},
+			n:    6,
+			expN: 3,
+		},
+
+		"A power of 2 should not reject values.": {
+			data: []byte{0xff, 0xff, 0xff, 0xff},
+			n:    8,
+			expN: 7,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			gotN, err := random.Uint(bytes.NewReader(test.data), test.n)
+
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expN, gotN)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rollify/rollify/internal/ratelimit"
+)
+
+// TokenBucketLimiterConfig is the configuration of the token bucket limiter.
+type TokenBucketLimiterConfig struct {
+	Rate        ratelimit.Rate
+	TimeNowFunc func() time.Time
+}
+
+func (c *TokenBucketLimiterConfig) defaults() error {
+	err := c.Rate.Validate()
+	if err != nil {
+		return err
+	}
+
+	if c.TimeNowFunc == nil {
+		c.TimeNowFunc = time.Now
+	}
+
+	return nil
+}
+
+// TokenBucketLimiter is a ratelimit.Limiter that stores a token bucket per key in memory,
+// the limits are not shared between application instances.
+type TokenBucketLimiter struct {
+	rate    ratelimit.Rate
+	timeNow func() time.Time
+
+	buckets   map[string]*ratelimit.Bucket
+	cleanedAt time.Time
+	mu        sync.Mutex
+}
+
+// NewTokenBucketLimiter returns a new in memory token bucket limiter.
+func NewTokenBucketLimiter(cfg TokenBucketLimiterConfig) (*TokenBucketLimiter, error) {
+	err := cfg.defaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return &TokenBucketLimiter{
+		rate:      cfg.Rate,
+		timeNow:   cfg.TimeNowFunc,
+		buckets:   map[string]*ratelimit.Bucket{},
+		cleanedAt: cfg.TimeNowFunc(),
+	}, nil
+}
+
+// Allow satisfies ratelimit.Limiter interface.
+func (t *TokenBucketLimiter) Allow(ctx context.Context, key string) (bool, error) {
+	return t.AllowN(ctx, key, 1)
+}
+
+// AllowN satisfies ratelimit.Limiter interface.
+func (t *TokenBucketLimiter) AllowN(_ context.Context, key string, n uint) (bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.timeNow()
+	t.clean(now)
+
+	b, ok := t.buckets[key]
+	if !ok {
+		nb := ratelimit.NewBucket(t.rate, now)
+		b = &nb
+		t.buckets[key] = b
+	}
+
+	return b.TakeN(t.rate, now, n), nil
+}
+
+// clean removes the buckets that have been refilled, these are the same as a new bucket.
+// The buckets are cleaned at most once per refill duration.
+func (t *TokenBucketLimiter) clean(now time.Time) {
+	refill := t.rate.RefillDuration()
+	if now.Sub(t.cleanedAt) < refill {
+		return
+	}
+	t.cleanedAt = now
+
+	for k, b := range t.buckets {
+		if now.Sub(b.UpdatedAt) >= refill {
+			delete(t.buckets, k)
+		}
+	}
+}
+
+var _ ratelimit.Limiter = &TokenBucketLimiter{}
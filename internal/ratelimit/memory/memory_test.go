@@ -0,0 +1,101 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/ratelimit"
+	"github.com/rollify/rollify/internal/ratelimit/memory"
+)
+
+func TestTokenBucketLimiter(t *testing.T) {
+	t0 := time.Now()
+
+	type allow struct {
+		key string
+		at  time.Duration
+	}
+
+	tests := map[string]struct {
+		rate   ratelimit.Rate
+		allows []allow
+		exp    []bool
+	}{
+		"Having multiple actions of a key, it should limit the key after the burst.": {
+			rate:   ratelimit.Rate{Burst: 2, Interval: time.Second},
+			allows: []allow{{"k1", 0}, {"k1", 0}, {"k1", 0}},
+			exp:    []bool{true, true, false},
+		},
+
+		"Having multiple keys, it should limit each key independently.": {
+			rate:   ratelimit.Rate{Burst: 1, Interval: time.Second},
+			allows: []allow{{"k1", 0}, {"k2", 0}, {"k1", 0}, {"k2", 0}},
+			exp:    []bool{true, true, false, false},
+		},
+
+		"Having a limited key, it should allow again after the interval.": {
+			rate:   ratelimit.Rate{Burst: 1, Interval: time.Second},
+			allows: []allow{{"k1", 0}, {"k1", 500 * time.Millisecond}, {"k1", time.Second}},
+			exp:    []bool{true, false, true},
+		},
+
+		"Having cleaned refilled buckets, it should allow the burst again.": {
+			rate:   ratelimit.Rate{Burst: 2, Interval: time.Second},
+			allows: []allow{{"k1", 0}, {"k1", 0}, {"k2", 10 * time.Second}, {"k1", 10 * time.Second}, {"k1", 10 * time.Second}, {"k1", 10 * time.Second}},
+			exp:    []bool{true, true, true, true, true, false},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			now := t0
+			l, err := memory.NewTokenBucketLimiter(memory.TokenBucketLimiterConfig{
+				Rate:        test.rate,
+				TimeNowFunc: func() time.Time { return now },
+			})
+			require.NoError(err)
+
+			got := []bool{}
+			for _, a := range test.allows {
+				now = t0.Add(a.at)
+				ok, err := l.Allow(context.TODO(), a.key)
+				require.NoError(err)
+				got = append(got, ok)
+			}
+
+			assert.Equal(test.exp, got)
+		})
+	}
+}
+
+func TestTokenBucketLimiterAllowN(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	t0 := time.Now()
+	l, err := memory.NewTokenBucketLimiter(memory.TokenBucketLimiterConfig{
+		Rate:        ratelimit.Rate{Burst: 2, Interval: time.Second},
+		TimeNowFunc: func() time.Time { return t0 },
+	})
+	require.NoError(err)
+
+	// More actions than the burst should not consume any action.
+	allowed, err := l.AllowN(context.TODO(), "k1", 3)
+	require.NoError(err)
+	assert.False(allowed)
+
+	allowed, err = l.AllowN(context.TODO(), "k1", 2)
+	require.NoError(err)
+	assert.True(allowed)
+
+	allowed, err = l.Allow(context.TODO(), "k1")
+	require.NoError(err)
+	assert.False(allowed)
+}
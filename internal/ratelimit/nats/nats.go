@@ -0,0 +1,168 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/rollify/rollify/internal/log"
+	"github.com/rollify/rollify/internal/ratelimit"
+)
+
+// maxUpdateRetries are the times a bucket update is retried when other instance updated it at the same time.
+const maxUpdateRetries = 5
+
+// KeyValue is the NATS key-value store where the token buckets are shared.
+type KeyValue interface {
+	Get(key string) (nats.KeyValueEntry, error)
+	Create(key string, value []byte) (uint64, error)
+	Update(key string, value []byte, last uint64) (uint64, error)
+}
+
+// TokenBucketLimiterConfig is the configuration of the token bucket limiter.
+type TokenBucketLimiterConfig struct {
+	// KeyValue is the NATS key-value store, its TTL should be at least the rate refill duration.
+	KeyValue KeyValue
+	// KeyPrefix is used to share the same key-value store between multiple limiters.
+	KeyPrefix   string
+	Rate        ratelimit.Rate
+	TimeNowFunc func() time.Time
+	Logger      log.Logger
+}
+
+func (c *TokenBucketLimiterConfig) defaults() error {
+	if c.KeyValue == nil {
+		return fmt.Errorf("the NATS key-value store is required")
+	}
+
+	err := c.Rate.Validate()
+	if err != nil {
+		return err
+	}
+
+	if c.TimeNowFunc == nil {
+		c.TimeNowFunc = time.Now
+	}
+
+	if c.Logger == nil {
+		c.Logger = log.Dummy
+	}
+	c.Logger = c.Logger.WithKV(log.KV{"service": "nats.TokenBucketLimiter", "prefix": c.KeyPrefix})
+
+	return nil
+}
+
+// TokenBucketLimiter is a ratelimit.Limiter that shares the token buckets between the application
+// instances using a NATS key-value store, the buckets are updated with optimistic concurrency.
+type TokenBucketLimiter struct {
+	kv        KeyValue
+	keyPrefix string
+	rate      ratelimit.Rate
+	timeNow   func() time.Time
+	logger    log.Logger
+}
+
+// NewTokenBucketLimiter returns a new NATS key-value store token bucket limiter.
+func NewTokenBucketLimiter(cfg TokenBucketLimiterConfig) (*TokenBucketLimiter, error) {
+	err := cfg.defaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return &TokenBucketLimiter{
+		kv:        cfg.KeyValue,
+		keyPrefix: cfg.KeyPrefix,
+		rate:      cfg.Rate,
+		timeNow:   cfg.TimeNowFunc,
+		logger:    cfg.Logger,
+	}, nil
+}
+
+type bucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// Allow satisfies ratelimit.Limiter interface.
+func (t *TokenBucketLimiter) Allow(ctx context.Context, key string) (bool, error) {
+	return t.AllowN(ctx, key, 1)
+}
+
+// AllowN satisfies ratelimit.Limiter interface.
+func (t *TokenBucketLimiter) AllowN(ctx context.Context, key string, n uint) (bool, error) {
+	key = t.keyPrefix + key
+
+	for i := 0; i < maxUpdateRetries; i++ {
+		allowed, err := t.take(key, n)
+		if err == nil {
+			return allowed, nil
+		}
+
+		// Retry if other instance updated the bucket in the meantime.
+		if !errors.Is(err, nats.ErrKeyExists) {
+			return false, err
+		}
+		t.logger.Debugf("token bucket updated concurrently, retrying")
+	}
+
+	return false, fmt.Errorf("could not update %q token bucket after %d retries", key, maxUpdateRetries)
+}
+
+// take takes n tokens from the stored bucket, it will return nats.ErrKeyExists if the bucket has been updated
+// by others while taking the tokens.
+func (t *TokenBucketLimiter) take(key string, n uint) (bool, error) {
+	now := t.timeNow()
+
+	entry, err := t.kv.Get(key)
+	if err != nil && !errors.Is(err, nats.ErrKeyNotFound) {
+		return false, fmt.Errorf("could not get token bucket: %w", err)
+	}
+
+	// New bucket.
+	if entry == nil {
+		b := ratelimit.NewBucket(t.rate, now)
+		allowed := b.TakeN(t.rate, now, n)
+		data, err := json.Marshal(bucket(b))
+		if err != nil {
+			return false, fmt.Errorf("could not marshal token bucket: %w", err)
+		}
+
+		_, err = t.kv.Create(key, data)
+		if err != nil {
+			return false, fmt.Errorf("could not create token bucket: %w", err)
+		}
+
+		return allowed, nil
+	}
+
+	// Existing bucket.
+	var sb bucket
+	err = json.Unmarshal(entry.Value(), &sb)
+	if err != nil {
+		return false, fmt.Errorf("could not unmarshal token bucket: %w", err)
+	}
+
+	b := ratelimit.Bucket(sb)
+	allowed := b.TakeN(t.rate, now, n)
+	if !allowed {
+		return false, nil
+	}
+
+	data, err := json.Marshal(bucket(b))
+	if err != nil {
+		return false, fmt.Errorf("could not marshal token bucket: %w", err)
+	}
+
+	_, err = t.kv.Update(key, data, entry.Revision())
+	if err != nil {
+		return false, fmt.Errorf("could not update token bucket: %w", err)
+	}
+
+	return true, nil
+}
+
+var _ ratelimit.Limiter = &TokenBucketLimiter{}
@@ -0,0 +1,170 @@
+package nats_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/ratelimit"
+	"github.com/rollify/rollify/internal/ratelimit/nats"
+)
+
+type entry struct {
+	natsgo.KeyValueEntry
+	value    []byte
+	revision uint64
+}
+
+func (e entry) Value() []byte    { return e.value }
+func (e entry) Revision() uint64 { return e.revision }
+
+// fakeKV is a key-value store with optimistic concurrency, `beforeUpdate` simulates
+// concurrent updates from other instances.
+type fakeKV struct {
+	entries      map[string]entry
+	revision     uint64
+	getErr       error
+	beforeUpdate func(kv *fakeKV, key string)
+}
+
+func (f *fakeKV) Get(key string) (natsgo.KeyValueEntry, error) {
+	if f.getErr != nil {
+		return nil, f.getErr
+	}
+	e, ok := f.entries[key]
+	if !ok {
+		return nil, natsgo.ErrKeyNotFound
+	}
+	return e, nil
+}
+
+func (f *fakeKV) Create(key string, value []byte) (uint64, error) {
+	if _, ok := f.entries[key]; ok {
+		return 0, natsgo.ErrKeyExists
+	}
+	return f.put(key, value), nil
+}
+
+func (f *fakeKV) Update(key string, value []byte, last uint64) (uint64, error) {
+	if f.beforeUpdate != nil {
+		f.beforeUpdate(f, key)
+	}
+	if f.entries[key].revision != last {
+		return 0, natsgo.ErrKeyExists
+	}
+	return f.put(key, value), nil
+}
+
+func (f *fakeKV) put(key string, value []byte) uint64 {
+	f.revision++
+	f.entries[key] = entry{value: value, revision: f.revision}
+	return f.revision
+}
+
+func TestTokenBucketLimiter(t *testing.T) {
+	t0 := time.Now()
+
+	tests := map[string]struct {
+		getErr       error
+		beforeUpdate func(kv *fakeKV, key string)
+		allows       int
+		exp          []bool
+		expErr       bool
+	}{
+		"Having multiple actions of a key, it should limit the key after the burst.": {
+			allows: 3,
+			exp:    []bool{true, true, false},
+		},
+
+		"Having concurrent updates, it should retry the update.": {
+			beforeUpdate: func() func(kv *fakeKV, key string) {
+				conflicts := 2
+				return func(kv *fakeKV, key string) {
+					if conflicts > 0 {
+						conflicts--
+						kv.put(key, kv.entries[key].value)
+					}
+				}
+			}(),
+			allows: 3,
+			exp:    []bool{true, true, false},
+		},
+
+		"Having concurrent updates all the time, it should fail.": {
+			beforeUpdate: func(kv *fakeKV, key string) {
+				kv.put(key, kv.entries[key].value)
+			},
+			allows: 2,
+			exp:    []bool{true},
+			expErr: true,
+		},
+
+		"Having an error getting the bucket, it should fail.": {
+			getErr: errors.New("whatever"),
+			allows: 1,
+			exp:    []bool{},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			kv := &fakeKV{entries: map[string]entry{}, getErr: test.getErr, beforeUpdate: test.beforeUpdate}
+			l, err := nats.NewTokenBucketLimiter(nats.TokenBucketLimiterConfig{
+				KeyValue:    kv,
+				KeyPrefix:   "user.",
+				Rate:        ratelimit.Rate{Burst: 2, Interval: time.Minute},
+				TimeNowFunc: func() time.Time { return t0 },
+			})
+			require.NoError(err)
+
+			got := []bool{}
+			for i := 0; i < test.allows; i++ {
+				var allowed bool
+				allowed, err = l.Allow(context.TODO(), "user1")
+				if err != nil {
+					break
+				}
+				got = append(got, allowed)
+			}
+
+			assert.Equal(test.expErr, err != nil)
+			assert.Equal(test.exp, got)
+		})
+	}
+}
+
+func TestTokenBucketLimiterAllowN(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	t0 := time.Now()
+	l, err := nats.NewTokenBucketLimiter(nats.TokenBucketLimiterConfig{
+		KeyValue:    &fakeKV{entries: map[string]entry{}},
+		KeyPrefix:   "user.",
+		Rate:        ratelimit.Rate{Burst: 2, Interval: time.Minute},
+		TimeNowFunc: func() time.Time { return t0 },
+	})
+	require.NoError(err)
+
+	// More actions than the burst should not consume any action.
+	allowed, err := l.AllowN(context.TODO(), "user1", 3)
+	require.NoError(err)
+	assert.False(allowed)
+
+	allowed, err = l.AllowN(context.TODO(), "user1", 2)
+	require.NoError(err)
+	assert.True(allowed)
+
+	allowed, err = l.Allow(context.TODO(), "user1")
+	require.NoError(err)
+	assert.False(allowed)
+}
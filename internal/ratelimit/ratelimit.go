@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Limiter knows how to limit the rate of the actions made by a key (e.g: a user, a room...).
+type Limiter interface {
+	// Allow consumes an action of the key, it returns false when the key has exceeded its rate.
+	Allow(ctx context.Context, key string) (bool, error)
+	// AllowN consumes n actions of the key at once, it returns false without consuming any
+	// action when the key doesn't have enough actions left.
+	AllowN(ctx context.Context, key string, n uint) (bool, error)
+}
+
+//go:generate mockery --case underscore --output ratelimitmock --outpkg ratelimitmock --name Limiter
+
+// Noop is a limiter that allows all the actions.
+const Noop = noop(0)
+
+type noop int
+
+func (noop) Allow(ctx context.Context, key string) (bool, error)          { return true, nil }
+func (noop) AllowN(ctx context.Context, key string, n uint) (bool, error) { return true, nil }
+
+// Rate is the rate of a token bucket, each action takes a token from the bucket and the bucket is
+// refilled with a token each interval.
+type Rate struct {
+	// Burst is the max number of actions that can be made at once (the bucket size).
+	Burst uint
+	// Interval is the time required to recover an action (refill a token).
+	Interval time.Duration
+}
+
+// Validate validates the rate.
+func (r Rate) Validate() error {
+	if r.Burst == 0 {
+		return fmt.Errorf("rate burst is required")
+	}
+
+	if r.Interval <= 0 {
+		return fmt.Errorf("rate interval is required")
+	}
+
+	return nil
+}
+
+// RefillDuration is the time required to refill an empty bucket.
+func (r Rate) RefillDuration() time.Duration {
+	return time.Duration(r.Burst) * r.Interval
+}
+
+// Bucket is the state of a token bucket.
+type Bucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// NewBucket returns a full bucket.
+func NewBucket(r Rate, now time.Time) Bucket {
+	return Bucket{Tokens: float64(r.Burst), UpdatedAt: now}
+}
+
+// Take refills the bucket with the tokens recovered since the last update and takes a token,
+// it returns false when the bucket is empty.
+func (b *Bucket) Take(r Rate, now time.Time) bool {
+	return b.TakeN(r, now, 1)
+}
+
+// TakeN is like Take but takes n tokens at once, it returns false without taking any token when
+// the bucket doesn't have enough tokens.
+func (b *Bucket) TakeN(r Rate, now time.Time, n uint) bool {
+	if elapsed := now.Sub(b.UpdatedAt); elapsed > 0 {
+		b.Tokens = min(float64(r.Burst), b.Tokens+float64(elapsed)/float64(r.Interval))
+		b.UpdatedAt = now
+	}
+
+	if b.Tokens < float64(n) {
+		return false
+	}
+	b.Tokens -= float64(n)
+
+	return true
+}
@@ -0,0 +1,109 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rollify/rollify/internal/ratelimit"
+)
+
+func TestBucketTake(t *testing.T) {
+	t0 := time.Now()
+	rate := ratelimit.Rate{Burst: 2, Interval: 10 * time.Second}
+
+	tests := map[string]struct {
+		bucket    ratelimit.Bucket
+		takes     []time.Time
+		expTakes  []bool
+		expBucket ratelimit.Bucket
+	}{
+		"Having a full bucket, it should allow the burst and then deny.": {
+			bucket:    ratelimit.NewBucket(rate, t0),
+			takes:     []time.Time{t0, t0, t0},
+			expTakes:  []bool{true, true, false},
+			expBucket: ratelimit.Bucket{Tokens: 0, UpdatedAt: t0},
+		},
+
+		"Having an empty bucket, it should allow once a token has been refilled.": {
+			bucket:    ratelimit.Bucket{Tokens: 0, UpdatedAt: t0},
+			takes:     []time.Time{t0.Add(5 * time.Second), t0.Add(10 * time.Second), t0.Add(15 * time.Second)},
+			expTakes:  []bool{false, true, false},
+			expBucket: ratelimit.Bucket{Tokens: 0.5, UpdatedAt: t0.Add(15 * time.Second)},
+		},
+
+		"Having a bucket not used for a long time, it should not be refilled above the burst.": {
+			bucket:    ratelimit.Bucket{Tokens: 0, UpdatedAt: t0},
+			takes:     []time.Time{t0.Add(time.Hour), t0.Add(time.Hour), t0.Add(time.Hour)},
+			expTakes:  []bool{true, true, false},
+			expBucket: ratelimit.Bucket{Tokens: 0, UpdatedAt: t0.Add(time.Hour)},
+		},
+
+		"Having a take in the past, it should not refill the bucket.": {
+			bucket:    ratelimit.Bucket{Tokens: 1, UpdatedAt: t0},
+			takes:     []time.Time{t0.Add(-time.Hour), t0.Add(-time.Hour)},
+			expTakes:  []bool{true, false},
+			expBucket: ratelimit.Bucket{Tokens: 0, UpdatedAt: t0},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			b := test.bucket
+			gotTakes := []bool{}
+			for _, now := range test.takes {
+				gotTakes = append(gotTakes, b.Take(rate, now))
+			}
+
+			assert.Equal(test.expTakes, gotTakes)
+			assert.Equal(test.expBucket, b)
+		})
+	}
+}
+
+func TestBucketTakeN(t *testing.T) {
+	t0 := time.Now()
+	rate := ratelimit.Rate{Burst: 3, Interval: 10 * time.Second}
+
+	tests := map[string]struct {
+		bucket    ratelimit.Bucket
+		n         uint
+		expTake   bool
+		expBucket ratelimit.Bucket
+	}{
+		"Having enough tokens, it should take all of them.": {
+			bucket:    ratelimit.NewBucket(rate, t0),
+			n:         3,
+			expTake:   true,
+			expBucket: ratelimit.Bucket{Tokens: 0, UpdatedAt: t0},
+		},
+
+		"Not having enough tokens, it should not take any of them.": {
+			bucket:    ratelimit.Bucket{Tokens: 2, UpdatedAt: t0},
+			n:         3,
+			expTake:   false,
+			expBucket: ratelimit.Bucket{Tokens: 2, UpdatedAt: t0},
+		},
+
+		"Having enough tokens after the refill, it should take all of them.": {
+			bucket:    ratelimit.Bucket{Tokens: 0, UpdatedAt: t0},
+			n:         2,
+			expTake:   true,
+			expBucket: ratelimit.Bucket{Tokens: 0, UpdatedAt: t0.Add(20 * time.Second)},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			b := test.bucket
+			now := test.expBucket.UpdatedAt
+			assert.Equal(test.expTake, b.TakeN(rate, now, test.n))
+			assert.Equal(test.expBucket, b)
+		})
+	}
+}
@@ -40,3 +40,43 @@ func (m measuredService) GetRoom(ctx context.Context, req GetRoomRequest) (resp
 
 	return m.next.GetRoom(ctx, req)
 }
+
+func (m measuredService) DeleteRoom(ctx context.Context, req DeleteRoomRequest) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureRoomServiceOpDuration(ctx, "DeleteRoom", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.DeleteRoom(ctx, req)
+}
+
+func (m measuredService) ArchiveRoom(ctx context.Context, req ArchiveRoomRequest) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureRoomServiceOpDuration(ctx, "ArchiveRoom", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.ArchiveRoom(ctx, req)
+}
+
+func (m measuredService) SubscribeRoomClosed(ctx context.Context, req SubscribeRoomClosedRequest) (resp *SubscribeRoomClosedResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureRoomServiceOpDuration(ctx, "SubscribeRoomClosed", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.SubscribeRoomClosed(ctx, req)
+}
+
+func (m measuredService) CreateInviteToken(ctx context.Context, req CreateInviteTokenRequest) (resp *CreateInviteTokenResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureRoomServiceOpDuration(ctx, "CreateInviteToken", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.CreateInviteToken(ctx, req)
+}
+
+func (m measuredService) RevokeInviteToken(ctx context.Context, req RevokeInviteTokenRequest) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureRoomServiceOpDuration(ctx, "RevokeInviteToken", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.RevokeInviteToken(ctx, req)
+}
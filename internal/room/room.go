@@ -2,11 +2,17 @@ package room
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
 
+	"github.com/rollify/rollify/internal/dice"
+	"github.com/rollify/rollify/internal/event"
 	"github.com/rollify/rollify/internal/internalerrors"
 	"github.com/rollify/rollify/internal/log"
 	"github.com/rollify/rollify/internal/model"
@@ -17,16 +23,34 @@ import (
 type Service interface {
 	CreateRoom(ctx context.Context, r CreateRoomRequest) (*CreateRoomResponse, error)
 	GetRoom(ctx context.Context, r GetRoomRequest) (*GetRoomResponse, error)
+	// DeleteRoom deletes the room with all its users and dice rolls, only the room game master can do it.
+	DeleteRoom(ctx context.Context, r DeleteRoomRequest) error
+	// ArchiveRoom makes the room read-only keeping its history, only the room game master can do it.
+	ArchiveRoom(ctx context.Context, r ArchiveRoomRequest) error
+	// SubscribeRoomClosed subscribes to the room closed (deleted or archived) events.
+	SubscribeRoomClosed(ctx context.Context, r SubscribeRoomClosedRequest) (*SubscribeRoomClosedResponse, error)
+	// CreateInviteToken creates a token to join the password protected room without the password,
+	// only the room game master can create them.
+	CreateInviteToken(ctx context.Context, r CreateInviteTokenRequest) (*CreateInviteTokenResponse, error)
+	// RevokeInviteToken revokes a room invite token, it can't be used to join the room anymore, only
+	// the room game master can revoke them.
+	RevokeInviteToken(ctx context.Context, r RevokeInviteTokenRequest) error
 }
 
 //go:generate mockery --case underscore --output roommock --outpkg roommock --name Service
 
 // ServiceConfig is the service configuration.
 type ServiceConfig struct {
-	RoomRepository storage.RoomRepository
-	Logger         log.Logger
-	IDGenerator    func() string
-	TimeNowFunc    func() time.Time
+	RoomRepository  storage.RoomRepository
+	UserRepository  storage.UserRepository
+	EventNotifier   event.Notifier
+	EventSubscriber event.Subscriber
+	Logger          log.Logger
+	IDGenerator     func() string
+	// InviteTokenGenerator generates the room invite tokens, these are secrets so unlike the IDs
+	// they must not be predictable, by default uses crypto/rand.
+	InviteTokenGenerator func() (string, error)
+	TimeNowFunc          func() time.Time
 }
 
 func (c *ServiceConfig) defaults() error {
@@ -34,6 +58,18 @@ func (c *ServiceConfig) defaults() error {
 		return fmt.Errorf("config.RoomRepository is required")
 	}
 
+	if c.UserRepository == nil {
+		return fmt.Errorf("config.UserRepository is required")
+	}
+
+	if c.EventNotifier == nil {
+		return fmt.Errorf("config.EventNotifier is required")
+	}
+
+	if c.EventSubscriber == nil {
+		return fmt.Errorf("config.EventSubscriber is required")
+	}
+
 	if c.Logger == nil {
 		c.Logger = log.Dummy
 	}
@@ -43,6 +79,10 @@ func (c *ServiceConfig) defaults() error {
 		c.IDGenerator = func() string { return uuid.New().String() }
 	}
 
+	if c.InviteTokenGenerator == nil {
+		c.InviteTokenGenerator = newInviteToken
+	}
+
 	if c.TimeNowFunc == nil {
 		c.TimeNowFunc = time.Now
 	}
@@ -50,11 +90,28 @@ func (c *ServiceConfig) defaults() error {
 	return nil
 }
 
+// inviteTokenLength is the number of random bytes of the invite tokens.
+const inviteTokenLength = 32
+
+func newInviteToken() (string, error) {
+	b := make([]byte, inviteTokenLength)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
 type service struct {
-	roomRepo storage.RoomRepository
-	logger   log.Logger
-	idGen    func() string
-	timeNow  func() time.Time
+	roomRepo        storage.RoomRepository
+	userRepo        storage.UserRepository
+	eventNotifier   event.Notifier
+	eventSubscriber event.Subscriber
+	logger          log.Logger
+	idGen           func() string
+	inviteTokenGen  func() (string, error)
+	timeNow         func() time.Time
 }
 
 // NewService returns a new room.Service.
@@ -65,23 +122,44 @@ func NewService(cfg ServiceConfig) (Service, error) {
 	}
 
 	return service{
-		roomRepo: cfg.RoomRepository,
-		logger:   cfg.Logger,
-		idGen:    cfg.IDGenerator,
-		timeNow:  cfg.TimeNowFunc,
+		roomRepo:        cfg.RoomRepository,
+		userRepo:        cfg.UserRepository,
+		eventNotifier:   cfg.EventNotifier,
+		eventSubscriber: cfg.EventSubscriber,
+		logger:          cfg.Logger,
+		idGen:           cfg.IDGenerator,
+		inviteTokenGen:  cfg.InviteTokenGenerator,
+		timeNow:         cfg.TimeNowFunc,
 	}, nil
 }
 
 // CreateRoomRequest is the request to CreateRoom.
 type CreateRoomRequest struct {
 	Name string
+	// Ruleset is the ID of the game system ruleset the room will use, optional.
+	Ruleset string
+	// Password protects the room, the users will need it to join the room, optional.
+	Password string
 }
 
+// maxPasswordLength is the max length in bytes that bcrypt supports.
+const maxPasswordLength = 72
+
 func (r CreateRoomRequest) validate() error {
 	if r.Name == "" {
 		return fmt.Errorf("name is required")
 	}
 
+	if r.Ruleset != "" {
+		if _, ok := dice.GetRuleset(r.Ruleset); !ok {
+			return fmt.Errorf("ruleset %q is not supported", r.Ruleset)
+		}
+	}
+
+	if len(r.Password) > maxPasswordLength {
+		return fmt.Errorf("password can't be longer than %d bytes", maxPasswordLength)
+	}
+
 	return nil
 }
 
@@ -101,6 +179,16 @@ func (s service) CreateRoom(ctx context.Context, r CreateRoomRequest) (*CreateRo
 		ID:        s.idGen(),
 		CreatedAt: s.timeNow().UTC(),
 		Name:      r.Name,
+		Ruleset:   r.Ruleset,
+	}
+
+	// Only the salted hash of the password is stored.
+	if r.Password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(r.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, fmt.Errorf("could not hash room password: %w", err)
+		}
+		room.PasswordHash = string(hash)
 	}
 
 	// Store room.
@@ -147,3 +235,248 @@ func (s service) GetRoom(ctx context.Context, r GetRoomRequest) (*GetRoomRespons
 		Room: *room,
 	}, nil
 }
+
+// DeleteRoomRequest is the request to DeleteRoom.
+type DeleteRoomRequest struct {
+	ID string
+	// UserID is the user requesting it, only the room game master can delete the room.
+	UserID string
+}
+
+func (r DeleteRoomRequest) validate() error {
+	if r.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+
+	if r.UserID == "" {
+		return fmt.Errorf("userID is required")
+	}
+
+	return nil
+}
+
+func (s service) DeleteRoom(ctx context.Context, r DeleteRoomRequest) error {
+	err := r.validate()
+	if err != nil {
+		return fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	err = s.checkGameMaster(ctx, r.ID, r.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = s.roomRepo.DeleteRoom(ctx, r.ID)
+	if err != nil {
+		return fmt.Errorf("could not delete room: %w", err)
+	}
+
+	// Close the room live subscriptions.
+	err = s.eventNotifier.NotifyRoomClosed(ctx, model.EventRoomClosed{RoomID: r.ID, Deleted: true})
+	if err != nil {
+		return fmt.Errorf("could not send room closed event: %w", err)
+	}
+
+	return nil
+}
+
+// ArchiveRoomRequest is the request to ArchiveRoom.
+type ArchiveRoomRequest struct {
+	ID string
+	// UserID is the user requesting it, only the room game master can archive the room.
+	UserID string
+}
+
+func (r ArchiveRoomRequest) validate() error {
+	if r.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+
+	if r.UserID == "" {
+		return fmt.Errorf("userID is required")
+	}
+
+	return nil
+}
+
+func (s service) ArchiveRoom(ctx context.Context, r ArchiveRoomRequest) error {
+	err := r.validate()
+	if err != nil {
+		return fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	err = s.checkGameMaster(ctx, r.ID, r.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = s.roomRepo.ArchiveRoom(ctx, r.ID)
+	if err != nil {
+		return fmt.Errorf("could not archive room: %w", err)
+	}
+
+	// Archived rooms don't have changes, close the room live subscriptions.
+	err = s.eventNotifier.NotifyRoomClosed(ctx, model.EventRoomClosed{RoomID: r.ID})
+	if err != nil {
+		return fmt.Errorf("could not send room closed event: %w", err)
+	}
+
+	return nil
+}
+
+// SubscribeRoomClosedRequest is the request for SubscribeRoomClosed.
+type SubscribeRoomClosedRequest struct {
+	RoomID       string
+	EventHandler func(context.Context, model.EventRoomClosed) error
+}
+
+func (r SubscribeRoomClosedRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("roomID is required")
+	}
+
+	if r.EventHandler == nil {
+		return fmt.Errorf("eventHandler is required")
+	}
+
+	return nil
+}
+
+// SubscribeRoomClosedResponse is the response for SubscribeRoomClosed.
+type SubscribeRoomClosedResponse struct {
+	UnsubscribeFunc func() error
+}
+
+func (s service) SubscribeRoomClosed(ctx context.Context, r SubscribeRoomClosedRequest) (*SubscribeRoomClosedResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	// Create a subscription ID and subscribe.
+	subscriptionID := s.idGen()
+	err = s.eventSubscriber.SubscribeRoomClosed(ctx, subscriptionID, r.RoomID, r.EventHandler)
+	if err != nil {
+		return nil, fmt.Errorf("could not subscribe to roomClosed events: %w", err)
+	}
+
+	return &SubscribeRoomClosedResponse{
+		UnsubscribeFunc: func() error {
+			return s.eventSubscriber.UnsubscribeRoomClosed(ctx, subscriptionID, r.RoomID)
+		},
+	}, nil
+}
+
+// CreateInviteTokenRequest is the request to CreateInviteToken.
+type CreateInviteTokenRequest struct {
+	RoomID string
+	// UserID is the user requesting it, only the room game master can create invite tokens.
+	UserID string
+}
+
+func (r CreateInviteTokenRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("roomID is required")
+	}
+
+	if r.UserID == "" {
+		return fmt.Errorf("userID is required")
+	}
+
+	return nil
+}
+
+// CreateInviteTokenResponse is the response to the CreateInviteToken request.
+type CreateInviteTokenResponse struct {
+	InviteToken model.RoomInviteToken
+}
+
+func (s service) CreateInviteToken(ctx context.Context, r CreateInviteTokenRequest) (*CreateInviteTokenResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	err = s.checkGameMaster(ctx, r.RoomID, r.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	tk, err := s.inviteTokenGen()
+	if err != nil {
+		return nil, fmt.Errorf("could not generate room invite token: %w", err)
+	}
+
+	token := model.RoomInviteToken{
+		Token:     tk,
+		CreatedAt: s.timeNow().UTC(),
+	}
+	err = s.roomRepo.CreateRoomInviteToken(ctx, r.RoomID, token)
+	if err != nil {
+		return nil, fmt.Errorf("could not store room invite token: %w", err)
+	}
+
+	return &CreateInviteTokenResponse{
+		InviteToken: token,
+	}, nil
+}
+
+// RevokeInviteTokenRequest is the request to RevokeInviteToken.
+type RevokeInviteTokenRequest struct {
+	RoomID string
+	Token  string
+	// UserID is the user requesting it, only the room game master can revoke invite tokens.
+	UserID string
+}
+
+func (r RevokeInviteTokenRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("roomID is required")
+	}
+
+	if r.UserID == "" {
+		return fmt.Errorf("userID is required")
+	}
+
+	if r.Token == "" {
+		return fmt.Errorf("token is required")
+	}
+
+	return nil
+}
+
+func (s service) RevokeInviteToken(ctx context.Context, r RevokeInviteTokenRequest) error {
+	err := r.validate()
+	if err != nil {
+		return fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	err = s.checkGameMaster(ctx, r.RoomID, r.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = s.roomRepo.DeleteRoomInviteToken(ctx, r.RoomID, r.Token)
+	if err != nil {
+		return fmt.Errorf("could not delete room invite token: %w", err)
+	}
+
+	return nil
+}
+
+// checkGameMaster checks the user is the game master of the room.
+func (s service) checkGameMaster(ctx context.Context, roomID, userID string) error {
+	u, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, internalerrors.ErrMissing) {
+			return fmt.Errorf("user does not exists: %w", internalerrors.ErrNotAllowed)
+		}
+		return fmt.Errorf("could not get user: %w", err)
+	}
+
+	if u.RoomID != roomID || !u.GameMaster {
+		return fmt.Errorf("only the room game master can do it: %w", internalerrors.ErrNotAllowed)
+	}
+
+	return nil
+}
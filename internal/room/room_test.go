@@ -3,13 +3,17 @@ package room_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 
+	"github.com/rollify/rollify/internal/event/eventmock"
+	"github.com/rollify/rollify/internal/internalerrors"
 	"github.com/rollify/rollify/internal/model"
 	"github.com/rollify/rollify/internal/room"
 	"github.com/rollify/rollify/internal/storage/storagemock"
@@ -56,6 +60,47 @@ func TestServiceCreateRoom(t *testing.T) {
 			},
 		},
 
+		"Having a creation request with an unsupported ruleset, should fail.": {
+			mock: func(r *storagemock.RoomRepository) {},
+			req: func() room.CreateRoomRequest {
+				return room.CreateRoomRequest{Name: "test-room", Ruleset: "unknown"}
+			},
+			expErr: true,
+		},
+
+		"Having a correct room creation with a ruleset it should store the room with the ruleset.": {
+			mock: func(r *storagemock.RoomRepository) {
+				exp := model.Room{
+					ID:        "test",
+					CreatedAt: t0,
+					Name:      "test-room",
+					Ruleset:   "dnd5e",
+				}
+				r.On("CreateRoom", mock.Anything, exp).Once().Return(nil)
+			},
+			req: func() room.CreateRoomRequest {
+				return room.CreateRoomRequest{Name: "test-room", Ruleset: "dnd5e"}
+			},
+			expResp: func() *room.CreateRoomResponse {
+				return &room.CreateRoomResponse{
+					Room: model.Room{
+						ID:        "test",
+						CreatedAt: t0,
+						Name:      "test-room",
+						Ruleset:   "dnd5e",
+					},
+				}
+			},
+		},
+
+		"Having a creation request with a too long password, should fail.": {
+			mock: func(r *storagemock.RoomRepository) {},
+			req: func() room.CreateRoomRequest {
+				return room.CreateRoomRequest{Name: "test-room", Password: strings.Repeat("a", 73)}
+			},
+			expErr: true,
+		},
+
 		"Having a correct request and an error while storing, it should fail.": {
 			mock: func(r *storagemock.RoomRepository) {
 				r.On("CreateRoom", mock.Anything, mock.Anything).Once().Return(errors.New("wanted error"))
@@ -77,6 +122,9 @@ func TestServiceCreateRoom(t *testing.T) {
 			test.mock(mr)
 
 			test.config.RoomRepository = mr
+			test.config.UserRepository = &storagemock.UserRepository{}
+			test.config.EventNotifier = &eventmock.Notifier{}
+			test.config.EventSubscriber = &eventmock.Subscriber{}
 			test.config.IDGenerator = func() string { return "test" }
 			test.config.TimeNowFunc = func() time.Time { return t0 }
 
@@ -94,6 +142,31 @@ func TestServiceCreateRoom(t *testing.T) {
 	}
 }
 
+func TestServiceCreateRoomWithPassword(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// Only the salted password hash should be stored.
+	mr := &storagemock.RoomRepository{}
+	mr.On("CreateRoom", mock.Anything, mock.MatchedBy(func(r model.Room) bool {
+		return r.PasswordHash != "test-password" && bcrypt.CompareHashAndPassword([]byte(r.PasswordHash), []byte("test-password")) == nil
+	})).Once().Return(nil)
+
+	svc, err := room.NewService(room.ServiceConfig{
+		RoomRepository:  mr,
+		UserRepository:  &storagemock.UserRepository{},
+		EventNotifier:   &eventmock.Notifier{},
+		EventSubscriber: &eventmock.Subscriber{},
+	})
+	require.NoError(err)
+
+	gotResp, err := svc.CreateRoom(context.TODO(), room.CreateRoomRequest{Name: "test-room", Password: "test-password"})
+	require.NoError(err)
+
+	assert.True(gotResp.Room.Protected())
+	mr.AssertExpectations(t)
+}
+
 func TestServiceGetRoom(t *testing.T) {
 	tests := map[string]struct {
 		config  room.ServiceConfig
@@ -152,6 +225,9 @@ func TestServiceGetRoom(t *testing.T) {
 			test.mock(mr)
 
 			test.config.RoomRepository = mr
+			test.config.UserRepository = &storagemock.UserRepository{}
+			test.config.EventNotifier = &eventmock.Notifier{}
+			test.config.EventSubscriber = &eventmock.Subscriber{}
 			svc, err := room.NewService(test.config)
 			require.NoError(err)
 
@@ -165,3 +241,408 @@ func TestServiceGetRoom(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceDeleteRoom(t *testing.T) {
+	gm := &model.User{ID: "user1", RoomID: "test", GameMaster: true}
+
+	tests := map[string]struct {
+		mock   func(r *storagemock.RoomRepository, ru *storagemock.UserRepository, en *eventmock.Notifier)
+		req    room.DeleteRoomRequest
+		expErr error
+	}{
+		"Having a delete request without id, should fail.": {
+			mock:   func(r *storagemock.RoomRepository, ru *storagemock.UserRepository, en *eventmock.Notifier) {},
+			req:    room.DeleteRoomRequest{},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a delete request without user, should fail.": {
+			mock:   func(r *storagemock.RoomRepository, ru *storagemock.UserRepository, en *eventmock.Notifier) {},
+			req:    room.DeleteRoomRequest{ID: "test"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a delete request of a missing user, should fail.": {
+			mock: func(r *storagemock.RoomRepository, ru *storagemock.UserRepository, en *eventmock.Notifier) {
+				ru.On("GetUserByID", mock.Anything, "user1").Once().Return(nil, internalerrors.ErrMissing)
+			},
+			req:    room.DeleteRoomRequest{ID: "test", UserID: "user1"},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having a delete request of a user that is not the game master, should fail.": {
+			mock: func(r *storagemock.RoomRepository, ru *storagemock.UserRepository, en *eventmock.Notifier) {
+				ru.On("GetUserByID", mock.Anything, "user1").Once().Return(&model.User{ID: "user1", RoomID: "test"}, nil)
+			},
+			req:    room.DeleteRoomRequest{ID: "test", UserID: "user1"},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having a delete request of the game master of another room, should fail.": {
+			mock: func(r *storagemock.RoomRepository, ru *storagemock.UserRepository, en *eventmock.Notifier) {
+				ru.On("GetUserByID", mock.Anything, "user1").Once().Return(&model.User{ID: "user1", RoomID: "other", GameMaster: true}, nil)
+			},
+			req:    room.DeleteRoomRequest{ID: "test", UserID: "user1"},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having an error while deleting the room, should fail.": {
+			mock: func(r *storagemock.RoomRepository, ru *storagemock.UserRepository, en *eventmock.Notifier) {
+				ru.On("GetUserByID", mock.Anything, "user1").Once().Return(gm, nil)
+				r.On("DeleteRoom", mock.Anything, "test").Once().Return(internalerrors.ErrMissing)
+			},
+			req:    room.DeleteRoomRequest{ID: "test", UserID: "user1"},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Having a correct delete request, should delete the room and notify the room has been closed.": {
+			mock: func(r *storagemock.RoomRepository, ru *storagemock.UserRepository, en *eventmock.Notifier) {
+				ru.On("GetUserByID", mock.Anything, "user1").Once().Return(gm, nil)
+				r.On("DeleteRoom", mock.Anything, "test").Once().Return(nil)
+				en.On("NotifyRoomClosed", mock.Anything, model.EventRoomClosed{RoomID: "test", Deleted: true}).Once().Return(nil)
+			},
+			req: room.DeleteRoomRequest{ID: "test", UserID: "user1"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks
+			mr := &storagemock.RoomRepository{}
+			mu := &storagemock.UserRepository{}
+			men := &eventmock.Notifier{}
+			test.mock(mr, mu, men)
+
+			svc, err := room.NewService(room.ServiceConfig{
+				RoomRepository:  mr,
+				UserRepository:  mu,
+				EventNotifier:   men,
+				EventSubscriber: &eventmock.Subscriber{},
+			})
+			require.NoError(err)
+
+			err = svc.DeleteRoom(context.TODO(), test.req)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mr.AssertExpectations(t)
+				mu.AssertExpectations(t)
+				men.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestServiceArchiveRoom(t *testing.T) {
+	gm := &model.User{ID: "user1", RoomID: "test", GameMaster: true}
+
+	tests := map[string]struct {
+		mock   func(r *storagemock.RoomRepository, ru *storagemock.UserRepository, en *eventmock.Notifier)
+		req    room.ArchiveRoomRequest
+		expErr error
+	}{
+		"Having an archive request without id, should fail.": {
+			mock:   func(r *storagemock.RoomRepository, ru *storagemock.UserRepository, en *eventmock.Notifier) {},
+			req:    room.ArchiveRoomRequest{},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a archive request without user, should fail.": {
+			mock:   func(r *storagemock.RoomRepository, ru *storagemock.UserRepository, en *eventmock.Notifier) {},
+			req:    room.ArchiveRoomRequest{ID: "test"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a archive request of a missing user, should fail.": {
+			mock: func(r *storagemock.RoomRepository, ru *storagemock.UserRepository, en *eventmock.Notifier) {
+				ru.On("GetUserByID", mock.Anything, "user1").Once().Return(nil, internalerrors.ErrMissing)
+			},
+			req:    room.ArchiveRoomRequest{ID: "test", UserID: "user1"},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having a archive request of a user that is not the game master, should fail.": {
+			mock: func(r *storagemock.RoomRepository, ru *storagemock.UserRepository, en *eventmock.Notifier) {
+				ru.On("GetUserByID", mock.Anything, "user1").Once().Return(&model.User{ID: "user1", RoomID: "test"}, nil)
+			},
+			req:    room.ArchiveRoomRequest{ID: "test", UserID: "user1"},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having a archive request of the game master of another room, should fail.": {
+			mock: func(r *storagemock.RoomRepository, ru *storagemock.UserRepository, en *eventmock.Notifier) {
+				ru.On("GetUserByID", mock.Anything, "user1").Once().Return(&model.User{ID: "user1", RoomID: "other", GameMaster: true}, nil)
+			},
+			req:    room.ArchiveRoomRequest{ID: "test", UserID: "user1"},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having an error while archiving the room, should fail.": {
+			mock: func(r *storagemock.RoomRepository, ru *storagemock.UserRepository, en *eventmock.Notifier) {
+				ru.On("GetUserByID", mock.Anything, "user1").Once().Return(gm, nil)
+				r.On("ArchiveRoom", mock.Anything, "test").Once().Return(internalerrors.ErrMissing)
+			},
+			req:    room.ArchiveRoomRequest{ID: "test", UserID: "user1"},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Having a correct archive request, should archive the room and notify the room has been closed.": {
+			mock: func(r *storagemock.RoomRepository, ru *storagemock.UserRepository, en *eventmock.Notifier) {
+				ru.On("GetUserByID", mock.Anything, "user1").Once().Return(gm, nil)
+				r.On("ArchiveRoom", mock.Anything, "test").Once().Return(nil)
+				en.On("NotifyRoomClosed", mock.Anything, model.EventRoomClosed{RoomID: "test"}).Once().Return(nil)
+			},
+			req: room.ArchiveRoomRequest{ID: "test", UserID: "user1"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks
+			mr := &storagemock.RoomRepository{}
+			mu := &storagemock.UserRepository{}
+			men := &eventmock.Notifier{}
+			test.mock(mr, mu, men)
+
+			svc, err := room.NewService(room.ServiceConfig{
+				RoomRepository:  mr,
+				UserRepository:  mu,
+				EventNotifier:   men,
+				EventSubscriber: &eventmock.Subscriber{},
+			})
+			require.NoError(err)
+
+			err = svc.ArchiveRoom(context.TODO(), test.req)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mr.AssertExpectations(t)
+				mu.AssertExpectations(t)
+				men.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestServiceCreateInviteToken(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	errWanted := errors.New("wanted error")
+	gm := &model.User{ID: "user1", RoomID: "test-room", GameMaster: true}
+
+	tests := map[string]struct {
+		mock     func(r *storagemock.RoomRepository, ru *storagemock.UserRepository)
+		tokenGen func() (string, error)
+		req      room.CreateInviteTokenRequest
+		expResp  *room.CreateInviteTokenResponse
+		expErr   error
+	}{
+		"Having a request without room, should fail.": {
+			mock:   func(r *storagemock.RoomRepository, ru *storagemock.UserRepository) {},
+			req:    room.CreateInviteTokenRequest{UserID: "user1"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request without user, should fail.": {
+			mock:   func(r *storagemock.RoomRepository, ru *storagemock.UserRepository) {},
+			req:    room.CreateInviteTokenRequest{RoomID: "test-room"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request of a user that is not the game master, should fail.": {
+			mock: func(r *storagemock.RoomRepository, ru *storagemock.UserRepository) {
+				ru.On("GetUserByID", mock.Anything, "user1").Once().Return(&model.User{ID: "user1", RoomID: "test-room"}, nil)
+			},
+			req:    room.CreateInviteTokenRequest{RoomID: "test-room", UserID: "user1"},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having a request of the game master of another room, should fail.": {
+			mock: func(r *storagemock.RoomRepository, ru *storagemock.UserRepository) {
+				ru.On("GetUserByID", mock.Anything, "user1").Once().Return(&model.User{ID: "user1", RoomID: "other", GameMaster: true}, nil)
+			},
+			req:    room.CreateInviteTokenRequest{RoomID: "test-room", UserID: "user1"},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having an error while generating the token, should fail.": {
+			mock: func(r *storagemock.RoomRepository, ru *storagemock.UserRepository) {
+				ru.On("GetUserByID", mock.Anything, "user1").Once().Return(gm, nil)
+			},
+			tokenGen: func() (string, error) { return "", errWanted },
+			req:      room.CreateInviteTokenRequest{RoomID: "test-room", UserID: "user1"},
+			expErr:   errWanted,
+		},
+
+		"Having an error while storing the token, should fail.": {
+			mock: func(r *storagemock.RoomRepository, ru *storagemock.UserRepository) {
+				ru.On("GetUserByID", mock.Anything, "user1").Once().Return(gm, nil)
+				r.On("CreateRoomInviteToken", mock.Anything, "test-room", mock.Anything).Once().Return(internalerrors.ErrMissing)
+			},
+			req:    room.CreateInviteTokenRequest{RoomID: "test-room", UserID: "user1"},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Having a correct request, should store a new room invite token.": {
+			mock: func(r *storagemock.RoomRepository, ru *storagemock.UserRepository) {
+				ru.On("GetUserByID", mock.Anything, "user1").Once().Return(gm, nil)
+				exp := model.RoomInviteToken{Token: "test-token", CreatedAt: t0}
+				r.On("CreateRoomInviteToken", mock.Anything, "test-room", exp).Once().Return(nil)
+			},
+			req: room.CreateInviteTokenRequest{RoomID: "test-room", UserID: "user1"},
+			expResp: &room.CreateInviteTokenResponse{
+				InviteToken: model.RoomInviteToken{Token: "test-token", CreatedAt: t0},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks
+			mr := &storagemock.RoomRepository{}
+			mu := &storagemock.UserRepository{}
+			test.mock(mr, mu)
+
+			svc, err := room.NewService(room.ServiceConfig{
+				RoomRepository:  mr,
+				UserRepository:  mu,
+				EventNotifier:   &eventmock.Notifier{},
+				EventSubscriber: &eventmock.Subscriber{},
+				IDGenerator:     func() string { return "test" },
+				InviteTokenGenerator: func() (string, error) {
+					if test.tokenGen != nil {
+						return test.tokenGen()
+					}
+					return "test-token", nil
+				},
+				TimeNowFunc: func() time.Time { return t0 },
+			})
+			require.NoError(err)
+
+			gotResp, err := svc.CreateInviteToken(context.TODO(), test.req)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				assert.Equal(test.expResp, gotResp)
+				mr.AssertExpectations(t)
+				mu.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestServiceCreateInviteTokenDefaultGenerator(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	mr := &storagemock.RoomRepository{}
+	mr.On("CreateRoomInviteToken", mock.Anything, "test-room", mock.Anything).Return(nil)
+	mu := &storagemock.UserRepository{}
+	mu.On("GetUserByID", mock.Anything, "user1").Return(&model.User{ID: "user1", RoomID: "test-room", GameMaster: true}, nil)
+
+	svc, err := room.NewService(room.ServiceConfig{
+		RoomRepository:  mr,
+		UserRepository:  mu,
+		EventNotifier:   &eventmock.Notifier{},
+		EventSubscriber: &eventmock.Subscriber{},
+		IDGenerator:     func() string { return "test" },
+	})
+	require.NoError(err)
+
+	// The tokens are 32 random bytes encoded with base64 URL encoding.
+	resp1, err := svc.CreateInviteToken(context.TODO(), room.CreateInviteTokenRequest{RoomID: "test-room", UserID: "user1"})
+	require.NoError(err)
+	resp2, err := svc.CreateInviteToken(context.TODO(), room.CreateInviteTokenRequest{RoomID: "test-room", UserID: "user1"})
+	require.NoError(err)
+
+	assert.Len(resp1.InviteToken.Token, 43)
+	assert.NotEqual("test", resp1.InviteToken.Token)
+	assert.NotEqual(resp1.InviteToken.Token, resp2.InviteToken.Token)
+}
+
+func TestServiceRevokeInviteToken(t *testing.T) {
+	gm := &model.User{ID: "user1", RoomID: "test-room", GameMaster: true}
+
+	tests := map[string]struct {
+		mock   func(r *storagemock.RoomRepository, ru *storagemock.UserRepository)
+		req    room.RevokeInviteTokenRequest
+		expErr error
+	}{
+		"Having a request without token, should fail.": {
+			mock:   func(r *storagemock.RoomRepository, ru *storagemock.UserRepository) {},
+			req:    room.RevokeInviteTokenRequest{RoomID: "test-room", UserID: "user1"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request without user, should fail.": {
+			mock:   func(r *storagemock.RoomRepository, ru *storagemock.UserRepository) {},
+			req:    room.RevokeInviteTokenRequest{RoomID: "test-room", Token: "token1"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request of a user that is not the game master, should fail.": {
+			mock: func(r *storagemock.RoomRepository, ru *storagemock.UserRepository) {
+				ru.On("GetUserByID", mock.Anything, "user1").Once().Return(&model.User{ID: "user1", RoomID: "test-room"}, nil)
+			},
+			req:    room.RevokeInviteTokenRequest{RoomID: "test-room", Token: "token1", UserID: "user1"},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having an error while deleting the token, should fail.": {
+			mock: func(r *storagemock.RoomRepository, ru *storagemock.UserRepository) {
+				ru.On("GetUserByID", mock.Anything, "user1").Once().Return(gm, nil)
+				r.On("DeleteRoomInviteToken", mock.Anything, "test-room", "token1").Once().Return(internalerrors.ErrMissing)
+			},
+			req:    room.RevokeInviteTokenRequest{RoomID: "test-room", Token: "token1", UserID: "user1"},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Having a correct request, should delete the room invite token.": {
+			mock: func(r *storagemock.RoomRepository, ru *storagemock.UserRepository) {
+				ru.On("GetUserByID", mock.Anything, "user1").Once().Return(gm, nil)
+				r.On("DeleteRoomInviteToken", mock.Anything, "test-room", "token1").Once().Return(nil)
+			},
+			req: room.RevokeInviteTokenRequest{RoomID: "test-room", Token: "token1", UserID: "user1"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks
+			mr := &storagemock.RoomRepository{}
+			mu := &storagemock.UserRepository{}
+			test.mock(mr, mu)
+
+			svc, err := room.NewService(room.ServiceConfig{
+				RoomRepository:  mr,
+				UserRepository:  mu,
+				EventNotifier:   &eventmock.Notifier{},
+				EventSubscriber: &eventmock.Subscriber{},
+			})
+			require.NoError(err)
+
+			err = svc.RevokeInviteToken(context.TODO(), test.req)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mr.AssertExpectations(t)
+				mu.AssertExpectations(t)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Package roomcheck has the room checks shared by the application services that change
+// the rooms, it can't be on the room package because the room service depends on them.
+package roomcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/storage"
+)
+
+// Writable checks the room exists and accepts changes, archived rooms are read-only.
+func Writable(ctx context.Context, repo storage.RoomRepository, roomID string) error {
+	room, err := repo.GetRoom(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, internalerrors.ErrMissing) {
+			return fmt.Errorf("room does not exists: %w", internalerrors.ErrNotValid)
+		}
+		return fmt.Errorf("could not get room: %w", err)
+	}
+
+	if room.Archived {
+		return fmt.Errorf("room is archived: %w", internalerrors.ErrNotAllowed)
+	}
+
+	return nil
+}
+
+// WritableRoomUser checks the user is from the room and the room accepts changes.
+func WritableRoomUser(ctx context.Context, userRepo storage.UserRepository, roomRepo storage.RoomRepository, roomID, userID string) error {
+	u, err := userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, internalerrors.ErrMissing) {
+			return fmt.Errorf("user does not exists: %w", internalerrors.ErrNotValid)
+		}
+		return fmt.Errorf("could not get user: %w", err)
+	}
+
+	if u.RoomID != roomID {
+		return fmt.Errorf("user is not from the room: %w", internalerrors.ErrNotValid)
+	}
+
+	return Writable(ctx, roomRepo, roomID)
+}
+
+// GameMaster checks the user is the game master of the room.
+func GameMaster(ctx context.Context, repo storage.UserRepository, roomID, userID string) error {
+	u, err := repo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, internalerrors.ErrMissing) {
+			return fmt.Errorf("user does not exists: %w", internalerrors.ErrNotAllowed)
+		}
+		return fmt.Errorf("could not get user: %w", err)
+	}
+
+	if u.RoomID != roomID || !u.GameMaster {
+		return fmt.Errorf("only the room game master can do it: %w", internalerrors.ErrNotAllowed)
+	}
+
+	return nil
+}
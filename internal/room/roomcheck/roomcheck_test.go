@@ -0,0 +1,201 @@
+package roomcheck_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/room/roomcheck"
+	"github.com/rollify/rollify/internal/storage/storagemock"
+)
+
+func TestWritable(t *testing.T) {
+	tests := map[string]struct {
+		mock   func(m *storagemock.RoomRepository)
+		expErr error
+	}{
+		"A missing room should not be valid.": {
+			mock: func(m *storagemock.RoomRepository) {
+				m.On("GetRoom", mock.Anything, "room-id").Once().Return(nil, internalerrors.ErrMissing)
+			},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having an error while getting the room should fail.": {
+			mock: func(m *storagemock.RoomRepository) {
+				m.On("GetRoom", mock.Anything, "room-id").Once().Return(nil, errors.New("whatever"))
+			},
+			expErr: errors.New("whatever"),
+		},
+
+		"An archived room should not allow changes.": {
+			mock: func(m *storagemock.RoomRepository) {
+				m.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id", Archived: true}, nil)
+			},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"A room should allow changes.": {
+			mock: func(m *storagemock.RoomRepository) {
+				m.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := &storagemock.RoomRepository{}
+			test.mock(m)
+
+			err := roomcheck.Writable(context.TODO(), m, "room-id")
+
+			if test.expErr != nil && assert.Error(err) {
+				if errors.Is(test.expErr, internalerrors.ErrNotValid) || errors.Is(test.expErr, internalerrors.ErrNotAllowed) {
+					assert.ErrorIs(err, test.expErr)
+				} else {
+					assert.ErrorContains(err, test.expErr.Error())
+				}
+			} else {
+				assert.NoError(err)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWritableRoomUser(t *testing.T) {
+	tests := map[string]struct {
+		mock   func(mu *storagemock.UserRepository, mr *storagemock.RoomRepository)
+		expErr error
+	}{
+		"A missing user should not be valid.": {
+			mock: func(mu *storagemock.UserRepository, mr *storagemock.RoomRepository) {
+				mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(nil, internalerrors.ErrMissing)
+			},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having an error while getting the user should fail.": {
+			mock: func(mu *storagemock.UserRepository, mr *storagemock.RoomRepository) {
+				mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(nil, errors.New("whatever"))
+			},
+			expErr: errors.New("whatever"),
+		},
+
+		"A user of another room should not be valid.": {
+			mock: func(mu *storagemock.UserRepository, mr *storagemock.RoomRepository) {
+				mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(&model.User{ID: "user-id", RoomID: "room2-id"}, nil)
+			},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"A user of an archived room should not allow changes.": {
+			mock: func(mu *storagemock.UserRepository, mr *storagemock.RoomRepository) {
+				mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(&model.User{ID: "user-id", RoomID: "room-id"}, nil)
+				mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id", Archived: true}, nil)
+			},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"A user of the room should allow changes.": {
+			mock: func(mu *storagemock.UserRepository, mr *storagemock.RoomRepository) {
+				mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(&model.User{ID: "user-id", RoomID: "room-id"}, nil)
+				mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			mu := &storagemock.UserRepository{}
+			mr := &storagemock.RoomRepository{}
+			test.mock(mu, mr)
+
+			err := roomcheck.WritableRoomUser(context.TODO(), mu, mr, "room-id", "user-id")
+
+			if test.expErr != nil && assert.Error(err) {
+				if errors.Is(test.expErr, internalerrors.ErrNotValid) || errors.Is(test.expErr, internalerrors.ErrNotAllowed) {
+					assert.ErrorIs(err, test.expErr)
+				} else {
+					assert.ErrorContains(err, test.expErr.Error())
+				}
+			} else {
+				assert.NoError(err)
+			}
+			mu.AssertExpectations(t)
+			mr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGameMaster(t *testing.T) {
+	tests := map[string]struct {
+		mock   func(m *storagemock.UserRepository)
+		expErr error
+	}{
+		"A missing user should not be allowed.": {
+			mock: func(m *storagemock.UserRepository) {
+				m.On("GetUserByID", mock.Anything, "user-id").Once().Return(nil, internalerrors.ErrMissing)
+			},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having an error while getting the user should fail.": {
+			mock: func(m *storagemock.UserRepository) {
+				m.On("GetUserByID", mock.Anything, "user-id").Once().Return(nil, errors.New("whatever"))
+			},
+			expErr: errors.New("whatever"),
+		},
+
+		"A user that is not a game master should not be allowed.": {
+			mock: func(m *storagemock.UserRepository) {
+				m.On("GetUserByID", mock.Anything, "user-id").Once().Return(&model.User{ID: "user-id", RoomID: "room-id"}, nil)
+			},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"A game master of another room should not be allowed.": {
+			mock: func(m *storagemock.UserRepository) {
+				m.On("GetUserByID", mock.Anything, "user-id").Once().Return(&model.User{ID: "user-id", RoomID: "room2-id", GameMaster: true}, nil)
+			},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"The room game master should be allowed.": {
+			mock: func(m *storagemock.UserRepository) {
+				m.On("GetUserByID", mock.Anything, "user-id").Once().Return(&model.User{ID: "user-id", RoomID: "room-id", GameMaster: true}, nil)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := &storagemock.UserRepository{}
+			test.mock(m)
+
+			err := roomcheck.GameMaster(context.TODO(), m, "room-id", "user-id")
+
+			if test.expErr != nil && assert.Error(err) {
+				if errors.Is(test.expErr, internalerrors.ErrNotAllowed) {
+					assert.ErrorIs(err, test.expErr)
+				} else {
+					assert.ErrorContains(err, test.expErr.Error())
+				}
+			} else {
+				assert.NoError(err)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
@@ -14,6 +14,46 @@ type Service struct {
 	mock.Mock
 }
 
+// ArchiveRoom provides a mock function with given fields: ctx, r
+func (_m *Service) ArchiveRoom(ctx context.Context, r room.ArchiveRoomRequest) error {
+	ret := _m.Called(ctx, r)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, room.ArchiveRoomRequest) error); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateInviteToken provides a mock function with given fields: ctx, r
+func (_m *Service) CreateInviteToken(ctx context.Context, r room.CreateInviteTokenRequest) (*room.CreateInviteTokenResponse, error) {
+	ret := _m.Called(ctx, r)
+
+	var r0 *room.CreateInviteTokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, room.CreateInviteTokenRequest) (*room.CreateInviteTokenResponse, error)); ok {
+		return rf(ctx, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, room.CreateInviteTokenRequest) *room.CreateInviteTokenResponse); ok {
+		r0 = rf(ctx, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*room.CreateInviteTokenResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, room.CreateInviteTokenRequest) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateRoom provides a mock function with given fields: ctx, r
 func (_m *Service) CreateRoom(ctx context.Context, r room.CreateRoomRequest) (*room.CreateRoomResponse, error) {
 	ret := _m.Called(ctx, r)
@@ -40,6 +80,20 @@ func (_m *Service) CreateRoom(ctx context.Context, r room.CreateRoomRequest) (*r
 	return r0, r1
 }
 
+// DeleteRoom provides a mock function with given fields: ctx, r
+func (_m *Service) DeleteRoom(ctx context.Context, r room.DeleteRoomRequest) error {
+	ret := _m.Called(ctx, r)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, room.DeleteRoomRequest) error); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetRoom provides a mock function with given fields: ctx, r
 func (_m *Service) GetRoom(ctx context.Context, r room.GetRoomRequest) (*room.GetRoomResponse, error) {
 	ret := _m.Called(ctx, r)
@@ -66,6 +120,46 @@ func (_m *Service) GetRoom(ctx context.Context, r room.GetRoomRequest) (*room.Ge
 	return r0, r1
 }
 
+// RevokeInviteToken provides a mock function with given fields: ctx, r
+func (_m *Service) RevokeInviteToken(ctx context.Context, r room.RevokeInviteTokenRequest) error {
+	ret := _m.Called(ctx, r)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, room.RevokeInviteTokenRequest) error); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SubscribeRoomClosed provides a mock function with given fields: ctx, r
+func (_m *Service) SubscribeRoomClosed(ctx context.Context, r room.SubscribeRoomClosedRequest) (*room.SubscribeRoomClosedResponse, error) {
+	ret := _m.Called(ctx, r)
+
+	var r0 *room.SubscribeRoomClosedResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, room.SubscribeRoomClosedRequest) (*room.SubscribeRoomClosedResponse, error)); ok {
+		return rf(ctx, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, room.SubscribeRoomClosedRequest) *room.SubscribeRoomClosedResponse); ok {
+		r0 = rf(ctx, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*room.SubscribeRoomClosedResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, room.SubscribeRoomClosedRequest) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewService(t interface {
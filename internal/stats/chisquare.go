@@ -0,0 +1,76 @@
+package stats
+
+import "math"
+
+const (
+	gammaMaxIterations = 1000
+	gammaEpsilon       = 1e-14
+)
+
+// chiSquarePValue returns the probability of getting a chi-square statistic equal or greater than x
+// with k degrees of freedom, this is the regularized upper incomplete gamma function Q(k/2, x/2).
+func chiSquarePValue(x float64, k uint) float64 {
+	if k == 0 || x <= 0 {
+		return 1
+	}
+
+	return gammaQ(float64(k)/2, x/2)
+}
+
+// gammaQ is the regularized upper incomplete gamma function, it uses the series for x < a+1 and
+// the continued fraction otherwise (Numerical Recipes).
+func gammaQ(a, x float64) float64 {
+	if x < a+1 {
+		return 1 - gammaPSeries(a, x)
+	}
+
+	return gammaQContinuedFraction(a, x)
+}
+
+func gammaPSeries(a, x float64) float64 {
+	lg, _ := math.Lgamma(a)
+	ap := a
+	sum := 1 / a
+	del := sum
+	for i := 0; i < gammaMaxIterations; i++ {
+		ap++
+		del *= x / ap
+		sum += del
+		if math.Abs(del) < math.Abs(sum)*gammaEpsilon {
+			break
+		}
+	}
+
+	return sum * math.Exp(-x+a*math.Log(x)-lg)
+}
+
+// gammaQContinuedFraction uses the modified Lentz's method.
+func gammaQContinuedFraction(a, x float64) float64 {
+	const tiny = 1e-300
+
+	lg, _ := math.Lgamma(a)
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1; i <= gammaMaxIterations; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < gammaEpsilon {
+			break
+		}
+	}
+
+	return math.Exp(-x+a*math.Log(x)-lg) * h
+}
@@ -0,0 +1,34 @@
+package stats
+
+import (
+	"context"
+	"time"
+)
+
+// ServiceMetricsRecorder knows how to record Service metrics.
+type ServiceMetricsRecorder interface {
+	MeasureStatsServiceOpDuration(ctx context.Context, op string, success bool, t time.Duration)
+}
+
+//go:generate mockery --case underscore --output statsmock --outpkg statsmock --name ServiceMetricsRecorder
+
+type measuredService struct {
+	rec  ServiceMetricsRecorder
+	next Service
+}
+
+// NewMeasureService wraps a service and measures.
+func NewMeasureService(rec ServiceMetricsRecorder, next Service) Service {
+	return &measuredService{
+		rec:  rec,
+		next: next,
+	}
+}
+
+func (m measuredService) GetDiceStats(ctx context.Context, req GetDiceStatsRequest) (resp *GetDiceStatsResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureStatsServiceOpDuration(ctx, "GetDiceStats", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.GetDiceStats(ctx, req)
+}
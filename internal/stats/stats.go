@@ -0,0 +1,281 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/log"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+)
+
+// Service is the application service of the dice statistics logic.
+type Service interface {
+	// GetDiceStats computes the statistics of the dice rolled on a room, or by a room user.
+	GetDiceStats(ctx context.Context, r GetDiceStatsRequest) (*GetDiceStatsResponse, error)
+}
+
+//go:generate mockery --case underscore --output statsmock --outpkg statsmock --name Service
+
+// ServiceConfig is the service configuration.
+type ServiceConfig struct {
+	DiceRollRepository storage.DiceRollRepository
+	RoomRepository     storage.RoomRepository
+	UserRepository     storage.UserRepository
+	Logger             log.Logger
+}
+
+func (c *ServiceConfig) defaults() error {
+	if c.DiceRollRepository == nil {
+		return fmt.Errorf("config.DiceRollRepository is required")
+	}
+
+	if c.RoomRepository == nil {
+		return fmt.Errorf("config.RoomRepository is required")
+	}
+
+	if c.UserRepository == nil {
+		return fmt.Errorf("config.UserRepository is required")
+	}
+
+	if c.Logger == nil {
+		c.Logger = log.Dummy
+	}
+	c.Logger = c.Logger.WithKV(log.KV{"svc": "stats.Service"})
+
+	return nil
+}
+
+type service struct {
+	diceRollRepo storage.DiceRollRepository
+	roomRepo     storage.RoomRepository
+	userRepo     storage.UserRepository
+	logger       log.Logger
+}
+
+// NewService returns a new stats.Service.
+func NewService(cfg ServiceConfig) (Service, error) {
+	err := cfg.defaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return service{
+		diceRollRepo: cfg.DiceRollRepository,
+		roomRepo:     cfg.RoomRepository,
+		userRepo:     cfg.UserRepository,
+		logger:       cfg.Logger,
+	}, nil
+}
+
+// DieTypeStats are the statistics of the rolled dice of a die type.
+type DieTypeStats struct {
+	DieType model.DieType
+	// Rolls is the number of rolled dice.
+	Rolls uint
+	// Distribution is the number of times each side has been rolled (index 0 is the side 1).
+	Distribution []uint
+	// Mean is the mean of the rolled sides.
+	Mean float64
+	// ExpectedMean is the mean of the sides of a fair die.
+	ExpectedMean float64
+	// ChiSquare is the chi-square goodness-of-fit statistic of the distribution against the
+	// uniform distribution of a fair die.
+	ChiSquare float64
+	// DegreesOfFreedom are the chi-square degrees of freedom (the die sides - 1).
+	DegreesOfFreedom uint
+	// PValue is the probability of a fair die getting a chi-square statistic at least as big as
+	// the distribution one, the lower it is the less likely the die is fair (e.g: < 0.01).
+	PValue float64
+	// EnoughRolls is true when the rolls are enough for the chi-square test to be reliable (at
+	// least 5 expected rolls per side).
+	EnoughRolls bool
+	// LongestRepeatStreak is the longest streak of the same side rolled in a row.
+	LongestRepeatStreak uint
+	// LongestHighStreak is the longest streak of sides above the expected mean rolled in a row.
+	LongestHighStreak uint
+	// LongestLowStreak is the longest streak of sides below the expected mean rolled in a row.
+	LongestLowStreak uint
+}
+
+// GetDiceStatsRequest is the request for GetDiceStats.
+type GetDiceStatsRequest struct {
+	RoomID string
+	// UserID is optional, if set only the dice rolled by the user are used.
+	UserID string
+}
+
+func (r GetDiceStatsRequest) validate() error {
+	if r.RoomID == "" {
+		return fmt.Errorf("config.RoomID is required")
+	}
+
+	return nil
+}
+
+// GetDiceStatsResponse is the response for GetDiceStats.
+type GetDiceStatsResponse struct {
+	// DieTypes are the statistics of each rolled die type, sorted by sides.
+	DieTypes []DieTypeStats
+}
+
+// maxStatsDieRolls is the max number of the latest rolled dice used on the statistics, so big
+// rooms don't load all their history.
+const maxStatsDieRolls = 10000
+
+// GetDiceStats computes the statistics of the latest public dice rolls, the rerolls and the
+// custom die types are not used.
+func (s service) GetDiceStats(ctx context.Context, r GetDiceStatsRequest) (*GetDiceStatsResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	// Check the room exists.
+	roomExists, err := s.roomRepo.RoomExists(ctx, r.RoomID)
+	if err != nil {
+		return nil, fmt.Errorf("could not check if room exists: %w", err)
+	}
+	if !roomExists {
+		return nil, fmt.Errorf("room does not exists: %w", internalerrors.ErrNotValid)
+	}
+
+	// Check the user is from the room.
+	if r.UserID != "" {
+		u, err := s.userRepo.GetUserByID(ctx, r.UserID)
+		if err != nil {
+			if errors.Is(err, internalerrors.ErrMissing) {
+				return nil, fmt.Errorf("user does not exists: %w", internalerrors.ErrNotValid)
+			}
+			return nil, fmt.Errorf("could not get user: %w", err)
+		}
+		if u.RoomID != r.RoomID {
+			return nil, fmt.Errorf("user is not from the room: %w", internalerrors.ErrNotValid)
+		}
+	}
+
+	opts := storage.DieRollStatsOpts{RoomID: r.RoomID, UserID: r.UserID, Limit: maxStatsDieRolls}
+	sides, err := s.diceRollRepo.ListDieRollSides(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not list die roll sides: %w", err)
+	}
+
+	// Distributions and streaks.
+	stats := map[string]*DieTypeStats{}
+	strks := map[string]*streaks{}
+	for _, sd := range sides {
+		st, ok := dieTypeStats(stats, sd.DieTypeID, sd.Side)
+		if !ok {
+			continue
+		}
+		st.Distribution[sd.Side-1]++
+
+		sk, ok := strks[sd.DieTypeID]
+		if !ok {
+			sk = &streaks{stats: st}
+			strks[sd.DieTypeID] = sk
+		}
+		sk.add(sd.Side)
+	}
+
+	res := make([]DieTypeStats, 0, len(stats))
+	for _, st := range stats {
+		st.compute()
+		res = append(res, *st)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].DieType.Sides() < res[j].DieType.Sides() })
+
+	return &GetDiceStatsResponse{
+		DieTypes: res,
+	}, nil
+}
+
+// dieTypeStats returns the stats of the die type, false if the die type is not a numeric die type
+// (custom die types are not used) or the side is not valid.
+func dieTypeStats(stats map[string]*DieTypeStats, dieTypeID string, side uint) (*DieTypeStats, bool) {
+	st, ok := stats[dieTypeID]
+	if !ok {
+		dt, err := model.DieTypeFromID(dieTypeID)
+		if err != nil {
+			return nil, false
+		}
+
+		st = &DieTypeStats{
+			DieType:      dt,
+			Distribution: make([]uint, dt.Sides()),
+			ExpectedMean: float64(dt.Sides()+1) / 2,
+		}
+		stats[dieTypeID] = st
+	}
+
+	if side < 1 || side > st.DieType.Sides() {
+		return nil, false
+	}
+
+	return st, true
+}
+
+// minExpectedRolls are the expected rolls per side required by the chi-square test.
+const minExpectedRolls = 5
+
+// compute computes the statistics from the distribution.
+func (d *DieTypeStats) compute() {
+	sides := d.DieType.Sides()
+	d.DegreesOfFreedom = sides - 1
+
+	var sum uint
+	for i, c := range d.Distribution {
+		d.Rolls += c
+		sum += uint(i+1) * c
+	}
+	if d.Rolls == 0 {
+		d.PValue = 1
+		return
+	}
+	d.Mean = float64(sum) / float64(d.Rolls)
+
+	expected := float64(d.Rolls) / float64(sides)
+	for _, c := range d.Distribution {
+		diff := float64(c) - expected
+		d.ChiSquare += diff * diff / expected
+	}
+	d.PValue = chiSquarePValue(d.ChiSquare, d.DegreesOfFreedom)
+	d.EnoughRolls = expected >= minExpectedRolls
+}
+
+// streaks tracks the current streaks of the rolled sides of a die type.
+type streaks struct {
+	stats             *DieTypeStats
+	last              uint
+	repeat, high, low uint
+}
+
+func (s *streaks) add(side uint) {
+	st := s.stats
+
+	if side == s.last {
+		s.repeat++
+	} else {
+		s.repeat = 1
+	}
+	s.last = side
+
+	// The sides equal to the expected mean break both streaks.
+	switch v := float64(side); {
+	case v > st.ExpectedMean:
+		s.high++
+		s.low = 0
+	case v < st.ExpectedMean:
+		s.low++
+		s.high = 0
+	default:
+		s.high, s.low = 0, 0
+	}
+
+	st.LongestRepeatStreak = max(st.LongestRepeatStreak, s.repeat)
+	st.LongestHighStreak = max(st.LongestHighStreak, s.high)
+	st.LongestLowStreak = max(st.LongestLowStreak, s.low)
+}
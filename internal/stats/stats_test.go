@@ -0,0 +1,182 @@
+package stats_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/stats"
+	"github.com/rollify/rollify/internal/storage"
+	"github.com/rollify/rollify/internal/storage/storagemock"
+)
+
+type mocks struct {
+	md *storagemock.DiceRollRepository
+	mr *storagemock.RoomRepository
+	mu *storagemock.UserRepository
+}
+
+func TestServiceGetDiceStats(t *testing.T) {
+	tests := map[string]struct {
+		mock      func(m mocks)
+		req       stats.GetDiceStatsRequest
+		expResp   *stats.GetDiceStatsResponse
+		expPValue []float64
+		expErr    error
+	}{
+		"Having a request without room, should fail.": {
+			mock:   func(m mocks) {},
+			req:    stats.GetDiceStatsRequest{},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request of a missing room, should fail.": {
+			mock: func(m mocks) {
+				m.mr.On("RoomExists", mock.Anything, "room-id").Once().Return(false, nil)
+			},
+			req:    stats.GetDiceStatsRequest{RoomID: "room-id"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request of a user from another room, should fail.": {
+			mock: func(m mocks) {
+				m.mr.On("RoomExists", mock.Anything, "room-id").Once().Return(true, nil)
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(&model.User{ID: "user-id", RoomID: "other-room"}, nil)
+			},
+			req:    stats.GetDiceStatsRequest{RoomID: "room-id", UserID: "user-id"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having an error while listing the sides, should fail.": {
+			mock: func(m mocks) {
+				m.mr.On("RoomExists", mock.Anything, "room-id").Once().Return(true, nil)
+				m.md.On("ListDieRollSides", mock.Anything, mock.Anything).Once().Return(nil, errors.New("whatever"))
+			},
+			req:    stats.GetDiceStatsRequest{RoomID: "room-id"},
+			expErr: errors.New("whatever"),
+		},
+
+		"Having a room without dice rolls, should return empty stats.": {
+			mock: func(m mocks) {
+				m.mr.On("RoomExists", mock.Anything, "room-id").Once().Return(true, nil)
+				m.md.On("ListDieRollSides", mock.Anything, storage.DieRollStatsOpts{RoomID: "room-id", Limit: 10000}).Once().Return(nil, nil)
+			},
+			req:     stats.GetDiceStatsRequest{RoomID: "room-id"},
+			expResp: &stats.GetDiceStatsResponse{DieTypes: []stats.DieTypeStats{}},
+		},
+
+		"Having a user with dice rolls, should return the stats of each die type sorted by sides.": {
+			mock: func(m mocks) {
+				m.mr.On("RoomExists", mock.Anything, "room-id").Once().Return(true, nil)
+				m.mu.On("GetUserByID", mock.Anything, "user-id").Once().Return(&model.User{ID: "user-id", RoomID: "room-id"}, nil)
+
+				opts := storage.DieRollStatsOpts{RoomID: "room-id", UserID: "user-id", Limit: 10000}
+				m.md.On("ListDieRollSides", mock.Anything, opts).Once().Return([]storage.DieSide{
+					{DieTypeID: "d6", Side: 4},
+					{DieTypeID: "d2", Side: 1},
+					{DieTypeID: "d6", Side: 5},
+					{DieTypeID: "custom-id", Side: 1},
+					{DieTypeID: "d6", Side: 6},
+					{DieTypeID: "d2", Side: 1},
+					{DieTypeID: "d6", Side: 6},
+					{DieTypeID: "d6", Side: 3},
+					{DieTypeID: "d6", Side: 2},
+					{DieTypeID: "d6", Side: 7},
+					{DieTypeID: "custom-id", Side: 1},
+					{DieTypeID: "d2", Side: 1},
+					{DieTypeID: "d2", Side: 1},
+					{DieTypeID: "d2", Side: 1},
+					{DieTypeID: "d2", Side: 1},
+					{DieTypeID: "d2", Side: 1},
+					{DieTypeID: "d2", Side: 1},
+					{DieTypeID: "d2", Side: 1},
+					{DieTypeID: "d2", Side: 1},
+				}, nil)
+			},
+			req: stats.GetDiceStatsRequest{RoomID: "room-id", UserID: "user-id"},
+			expResp: &stats.GetDiceStatsResponse{DieTypes: []stats.DieTypeStats{
+				{
+					DieType:             model.DieTypeD2,
+					Rolls:               10,
+					Distribution:        []uint{10, 0},
+					Mean:                1,
+					ExpectedMean:        1.5,
+					ChiSquare:           10,
+					DegreesOfFreedom:    1,
+					EnoughRolls:         true,
+					LongestRepeatStreak: 10,
+					LongestLowStreak:    10,
+				},
+				{
+					DieType:             model.DieTypeD6,
+					Rolls:               6,
+					Distribution:        []uint{0, 1, 1, 1, 1, 2},
+					Mean:                26.0 / 6,
+					ExpectedMean:        3.5,
+					ChiSquare:           2,
+					DegreesOfFreedom:    5,
+					LongestRepeatStreak: 2,
+					LongestHighStreak:   4,
+					LongestLowStreak:    2,
+				},
+			}},
+			expPValue: []float64{
+				math.Erfc(math.Sqrt(5)),
+				math.Erfc(1) + math.Exp(-1)*10/(3*math.Sqrt(math.Pi)),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			m := mocks{md: &storagemock.DiceRollRepository{}, mr: &storagemock.RoomRepository{}, mu: &storagemock.UserRepository{}}
+			test.mock(m)
+
+			// Prepare.
+			svc, err := stats.NewService(stats.ServiceConfig{
+				DiceRollRepository: m.md,
+				RoomRepository:     m.mr,
+				UserRepository:     m.mu,
+			})
+			require.NoError(err)
+
+			// Execute.
+			gotResp, err := svc.GetDiceStats(context.TODO(), test.req)
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				if !errors.Is(test.expErr, internalerrors.ErrNotValid) {
+					assert.ErrorContains(err, test.expErr.Error())
+				} else {
+					assert.ErrorIs(err, test.expErr)
+				}
+			} else if assert.NoError(err) {
+				// Check the p-values with a precision and ignore them on the rest of the stats.
+				require.Len(gotResp.DieTypes, len(test.expResp.DieTypes))
+				for i := range gotResp.DieTypes {
+					expPValue := 1.0
+					if i < len(test.expPValue) {
+						expPValue = test.expPValue[i]
+					}
+					assert.InDelta(expPValue, gotResp.DieTypes[i].PValue, 1e-9)
+					gotResp.DieTypes[i].PValue = 0
+				}
+				assert.Equal(test.expResp, gotResp)
+			}
+			m.md.AssertExpectations(t)
+			m.mr.AssertExpectations(t)
+			m.mu.AssertExpectations(t)
+		})
+	}
+}
@@ -2,30 +2,45 @@ package storage
 
 import (
 	"context"
-	"fmt"
 	"strings"
+	"time"
 
-	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/hashicorp/golang-lru/v2/expirable"
 
 	"github.com/rollify/rollify/internal/model"
 )
 
+// cacheTTL is how long the rooms and users are cached. The caches are local to each app
+// instance so the data changed by other instances (e.g archived or deleted rooms) can be
+// stale up to this time.
+const cacheTTL = 30 * time.Second
+
+// roomUsersInvalidator knows how to invalidate the cached users of a room.
+type roomUsersInvalidator interface {
+	invalidateRoomUsers(roomID string)
+}
+
 type cachedRoomRepository struct {
-	roomCache *lru.Cache[string, *model.Room]
+	roomCache *expirable.LRU[string, *model.Room]
+	users     roomUsersInvalidator
 	RoomRepository
 }
 
 // NewCachedRoomRepository wraps a RoomRepository and caches the rooms information in memory
 // is not a cache to try to optimize the query to the original repository but try caching the
 // information of the rooms that are asked frequently and save most of the room info accesses.
-func NewCachedRoomRepository(next RoomRepository) (RoomRepository, error) {
-	c, err := lru.New[string, *model.Room](500)
-	if err != nil {
-		return nil, fmt.Errorf("could not initialize cache")
-	}
+//
+// The rooms changed by this repository are invalidated, the ones changed by others expire
+// after a short time. The invite tokens listed with ListRoomInviteTokens are never cached.
+//
+// The deleted rooms users are deleted on the storage, if users is a cached UserRepository
+// (NewCachedUserRepository) the cached users of the deleted rooms will be invalidated.
+func NewCachedRoomRepository(next RoomRepository, users UserRepository) (RoomRepository, error) {
+	usersInvalidator, _ := users.(roomUsersInvalidator)
 
 	return &cachedRoomRepository{
-		roomCache:      c,
+		roomCache:      expirable.NewLRU[string, *model.Room](500, nil, cacheTTL),
+		users:          usersInvalidator,
 		RoomRepository: next,
 	}, nil
 }
@@ -56,35 +71,69 @@ func (c cachedRoomRepository) RoomExists(ctx context.Context, id string) (exists
 	return c.RoomRepository.RoomExists(ctx, id)
 }
 
-type cachedUserRepository struct {
-	userIDCache         *lru.Cache[string, *model.User]
-	userNameExistsCache *lru.Cache[string, bool]
-	userNameCache       *lru.Cache[string, *model.User]
-	UserRepository
+func (c cachedRoomRepository) DeleteRoom(ctx context.Context, id string) error {
+	err := c.RoomRepository.DeleteRoom(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	c.roomCache.Remove(id)
+	if c.users != nil {
+		c.users.invalidateRoomUsers(id)
+	}
+
+	return nil
 }
 
-// NewCachedUserRepository wraps a UserRepository and caches the users information in memory
-// is not a cache to try to optimize the query to the original repository but try caching the
-// information of the rooms that are asked frequently and save most of the room info accesses.
-func NewCachedUserRepository(next UserRepository) (UserRepository, error) {
-	cui, err := lru.New[string, *model.User](500)
+func (c cachedRoomRepository) ArchiveRoom(ctx context.Context, id string) error {
+	err := c.RoomRepository.ArchiveRoom(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("could not initialize cache")
+		return err
 	}
-	cun, err := lru.New[string, bool](500)
+
+	c.roomCache.Remove(id)
+
+	return nil
+}
+
+func (c cachedRoomRepository) CreateRoomInviteToken(ctx context.Context, roomID string, t model.RoomInviteToken) error {
+	err := c.RoomRepository.CreateRoomInviteToken(ctx, roomID, t)
 	if err != nil {
-		return nil, fmt.Errorf("could not initialize cache")
+		return err
 	}
 
-	cu, err := lru.New[string, *model.User](500)
+	c.roomCache.Remove(roomID)
+
+	return nil
+}
+
+func (c cachedRoomRepository) DeleteRoomInviteToken(ctx context.Context, roomID, token string) error {
+	err := c.RoomRepository.DeleteRoomInviteToken(ctx, roomID, token)
 	if err != nil {
-		return nil, fmt.Errorf("could not initialize cache")
+		return err
 	}
 
+	// The revoked tokens can't be used to join the room from now on.
+	c.roomCache.Remove(roomID)
+
+	return nil
+}
+
+type cachedUserRepository struct {
+	userIDCache         *expirable.LRU[string, *model.User]
+	userNameExistsCache *expirable.LRU[string, bool]
+	userNameCache       *expirable.LRU[string, *model.User]
+	UserRepository
+}
+
+// NewCachedUserRepository wraps a UserRepository and caches the users information in memory
+// is not a cache to try to optimize the query to the original repository but try caching the
+// information of the rooms that are asked frequently and save most of the room info accesses.
+func NewCachedUserRepository(next UserRepository) (UserRepository, error) {
 	return &cachedUserRepository{
-		userIDCache:         cui,
-		userNameExistsCache: cun,
-		userNameCache:       cu,
+		userIDCache:         expirable.NewLRU[string, *model.User](500, nil, cacheTTL),
+		userNameExistsCache: expirable.NewLRU[string, bool](500, nil, cacheTTL),
+		userNameCache:       expirable.NewLRU[string, *model.User](500, nil, cacheTTL),
 		UserRepository:      next,
 	}, nil
 }
@@ -156,3 +205,25 @@ func (c cachedUserRepository) GetUserByNameInsensitive(ctx context.Context, room
 
 	return us, err
 }
+
+// invalidateRoomUsers removes the cached users of a room, the users by name are cached by the room
+// ID and the username.
+func (c cachedUserRepository) invalidateRoomUsers(roomID string) {
+	for _, id := range c.userIDCache.Keys() {
+		if u, ok := c.userIDCache.Peek(id); ok && u.RoomID == roomID {
+			c.userIDCache.Remove(id)
+		}
+	}
+
+	for _, k := range c.userNameExistsCache.Keys() {
+		if strings.HasPrefix(k, roomID) {
+			c.userNameExistsCache.Remove(k)
+		}
+	}
+
+	for _, k := range c.userNameCache.Keys() {
+		if u, ok := c.userNameCache.Peek(k); ok && u.RoomID == roomID {
+			c.userNameCache.Remove(k)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+)
+
+// DeckRepository is a fake repository based on memory.
+// This repository exposes the storage to the public so the users can
+// check the internal data in and maniputale it (e.g tests).
+type DeckRepository struct {
+	// DecksByID is where the decks are stored by ID. Not thread safe.
+	DecksByID map[string]*model.Deck
+	// DecksByRoom is where the decks are stored by room in creation order. Not thread safe.
+	DecksByRoom map[string][]*model.Deck
+
+	mu sync.Mutex
+}
+
+// NewDeckRepository returns a new DeckRepository.
+func NewDeckRepository() *DeckRepository {
+	return &DeckRepository{
+		DecksByID:   map[string]*model.Deck{},
+		DecksByRoom: map[string][]*model.Deck{},
+	}
+}
+
+// CreateDeck satisfies storage.DeckRepository interface.
+func (r *DeckRepository) CreateDeck(ctx context.Context, d model.Deck) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := validateDeck(d)
+	if err != nil {
+		return err
+	}
+
+	_, ok := r.DecksByID[d.ID]
+	if ok {
+		return fmt.Errorf("deck already exists: %w", internalerrors.ErrAlreadyExists)
+	}
+
+	d = copyDeck(d)
+	r.DecksByID[d.ID] = &d
+	r.DecksByRoom[d.RoomID] = append(r.DecksByRoom[d.RoomID], &d)
+
+	return nil
+}
+
+// GetDeck satisfies storage.DeckRepository interface.
+func (r *DeckRepository) GetDeck(ctx context.Context, id string) (*model.Deck, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d, ok := r.DecksByID[id]
+	if !ok {
+		return nil, internalerrors.ErrMissing
+	}
+
+	dc := copyDeck(*d)
+	return &dc, nil
+}
+
+// ListRoomDecks satisfies storage.DeckRepository interface.
+func (r *DeckRepository) ListRoomDecks(ctx context.Context, roomID string) (*storage.DeckList, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	items := []model.Deck{}
+	for _, d := range r.DecksByRoom[roomID] {
+		items = append(items, copyDeck(*d))
+	}
+
+	return &storage.DeckList{
+		Items: items,
+	}, nil
+}
+
+// UpdateDeck satisfies storage.DeckRepository interface.
+func (r *DeckRepository) UpdateDeck(ctx context.Context, d model.Deck) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := validateDeck(d)
+	if err != nil {
+		return err
+	}
+
+	stored, ok := r.DecksByID[d.ID]
+	if !ok {
+		return internalerrors.ErrMissing
+	}
+
+	if stored.Version != d.Version {
+		return fmt.Errorf("deck has been updated: %w", internalerrors.ErrConflict)
+	}
+
+	stored.DrawPile = slices.Clone(d.DrawPile)
+	stored.DiscardPile = slices.Clone(d.DiscardPile)
+	stored.Version++
+
+	return nil
+}
+
+func copyDeck(d model.Deck) model.Deck {
+	d.Cards = slices.Clone(d.Cards)
+	d.DrawPile = slices.Clone(d.DrawPile)
+	d.DiscardPile = slices.Clone(d.DiscardPile)
+	return d
+}
+
+func validateDeck(d model.Deck) error {
+	switch {
+	case d.ID == "":
+		return fmt.Errorf("missing ID: %w", internalerrors.ErrNotValid)
+	case d.RoomID == "":
+		return fmt.Errorf("missing RoomID: %w", internalerrors.ErrNotValid)
+	case d.Name == "":
+		return fmt.Errorf("missing Name: %w", internalerrors.ErrNotValid)
+	case len(d.Cards) == 0:
+		return fmt.Errorf("missing Cards: %w", internalerrors.ErrNotValid)
+	}
+
+	return nil
+}
+
+// deleteRoomDecks deletes the decks of the room.
+func (r *DeckRepository) deleteRoomDecks(roomID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, d := range r.DecksByRoom[roomID] {
+		delete(r.DecksByID, d.ID)
+	}
+	delete(r.DecksByRoom, roomID)
+}
+
+// Implementation assertions.
+var _ storage.DeckRepository = &DeckRepository{}
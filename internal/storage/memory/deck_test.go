@@ -0,0 +1,178 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+	"github.com/rollify/rollify/internal/storage/memory"
+)
+
+func getDeck(id, roomID string) model.Deck {
+	return model.Deck{
+		ID:          id,
+		RoomID:      roomID,
+		Name:        "Action deck",
+		Type:        model.DeckTypeCustom,
+		Cards:       []string{"A", "B", "C", "D"},
+		DrawPile:    []string{"C", "A"},
+		DiscardPile: []string{"D"},
+	}
+}
+
+func TestDeckRepositoryCreateDeck(t *testing.T) {
+	tests := map[string]struct {
+		repo   func() *memory.DeckRepository
+		deck   model.Deck
+		expErr error
+	}{
+		"Having a deck without ID should return a not valid error.": {
+			repo:   memory.NewDeckRepository,
+			deck:   getDeck("", "room-id"),
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a deck without cards should return a not valid error.": {
+			repo:   memory.NewDeckRepository,
+			deck:   model.Deck{ID: "deck-id", RoomID: "room-id", Name: "Action deck"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having an already stored deck should return an already exists error.": {
+			repo: func() *memory.DeckRepository {
+				r := memory.NewDeckRepository()
+				d := getDeck("deck-id", "room-id")
+				r.DecksByID["deck-id"] = &d
+				return r
+			},
+			deck:   getDeck("deck-id", "room-id"),
+			expErr: internalerrors.ErrAlreadyExists,
+		},
+
+		"Having a new deck should be stored.": {
+			repo: memory.NewDeckRepository,
+			deck: getDeck("deck-id", "room-id"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			r := test.repo()
+			err := r.CreateDeck(context.TODO(), test.deck)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				gotDeck := r.DecksByID[test.deck.ID]
+				require.NotNil(gotDeck)
+				assert.Equal(test.deck, *gotDeck)
+				assert.Len(r.DecksByRoom[test.deck.RoomID], 1)
+			}
+		})
+	}
+}
+
+func TestDeckRepositoryListRoomDecks(t *testing.T) {
+	tests := map[string]struct {
+		roomID  string
+		expList *storage.DeckList
+	}{
+		"Listing the decks of a room without decks should return an empty list.": {
+			roomID:  "room2-id",
+			expList: &storage.DeckList{Items: []model.Deck{}},
+		},
+
+		"Listing the decks of a room should return the room decks in creation order.": {
+			roomID: "room-id",
+			expList: &storage.DeckList{Items: []model.Deck{
+				getDeck("deck1-id", "room-id"),
+				getDeck("deck3-id", "room-id"),
+			}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			r := memory.NewDeckRepository()
+			require.NoError(r.CreateDeck(context.TODO(), getDeck("deck1-id", "room-id")))
+			require.NoError(r.CreateDeck(context.TODO(), getDeck("deck2-id", "room1-id")))
+			require.NoError(r.CreateDeck(context.TODO(), getDeck("deck3-id", "room-id")))
+
+			gotList, err := r.ListRoomDecks(context.TODO(), test.roomID)
+			if assert.NoError(err) {
+				assert.Equal(test.expList, gotList)
+			}
+		})
+	}
+}
+
+func TestDeckRepositoryUpdateDeck(t *testing.T) {
+	tests := map[string]struct {
+		deck    func() model.Deck
+		expDeck model.Deck
+		expErr  error
+	}{
+		"Updating a missing deck should return a missing error.": {
+			deck:   func() model.Deck { return getDeck("deck2-id", "room-id") },
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Updating a deck with an old version should return a conflict error.": {
+			deck: func() model.Deck {
+				d := getDeck("deck-id", "room-id")
+				d.Version = 1
+				return d
+			},
+			expErr: internalerrors.ErrConflict,
+		},
+
+		"Updating a deck should update its piles and increase its version.": {
+			deck: func() model.Deck {
+				d := getDeck("deck-id", "room-id")
+				d.Name = "Ignored"
+				d.DrawPile = []string{"A"}
+				d.DiscardPile = []string{"D", "C"}
+				return d
+			},
+			expDeck: func() model.Deck {
+				d := getDeck("deck-id", "room-id")
+				d.DrawPile = []string{"A"}
+				d.DiscardPile = []string{"D", "C"}
+				d.Version = 1
+				return d
+			}(),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			r := memory.NewDeckRepository()
+			require.NoError(r.CreateDeck(context.TODO(), getDeck("deck-id", "room-id")))
+
+			err := r.UpdateDeck(context.TODO(), test.deck())
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				gotDeck, err := r.GetDeck(context.TODO(), "deck-id")
+				require.NoError(err)
+				assert.Equal(test.expDeck, *gotDeck)
+			}
+		})
+	}
+}
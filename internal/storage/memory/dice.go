@@ -43,6 +43,43 @@ func (r *DiceRollRepository) CreateDiceRoll(ctx context.Context, dr model.DiceRo
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	err := r.checkNewDiceRoll(dr)
+	if err != nil {
+		return err
+	}
+
+	r.storeDiceRoll(dr)
+
+	return nil
+}
+
+// CreateDiceRolls satisfies dice.Repository interface.
+func (r *DiceRollRepository) CreateDiceRolls(ctx context.Context, drs []model.DiceRoll) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Check all before storing any so we don't store partial batches.
+	ids := map[string]struct{}{}
+	for _, dr := range drs {
+		err := r.checkNewDiceRoll(dr)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := ids[dr.ID]; ok {
+			return internalerrors.ErrAlreadyExists
+		}
+		ids[dr.ID] = struct{}{}
+	}
+
+	for _, dr := range drs {
+		r.storeDiceRoll(dr)
+	}
+
+	return nil
+}
+
+func (r *DiceRollRepository) checkNewDiceRoll(dr model.DiceRoll) error {
 	switch {
 	case dr.RoomID == "":
 		return fmt.Errorf("missing room ID: %w", internalerrors.ErrNotValid)
@@ -57,6 +94,10 @@ func (r *DiceRollRepository) CreateDiceRoll(ctx context.Context, dr model.DiceRo
 		return internalerrors.ErrAlreadyExists
 	}
 
+	return nil
+}
+
+func (r *DiceRollRepository) storeDiceRoll(dr model.DiceRoll) {
 	r.DiceRollsByID[dr.ID] = &dr
 	r.DiceRollsByRoom[dr.RoomID] = append(r.DiceRollsByRoom[dr.RoomID], &dr)
 	r.DiceRollsByRoomAndUser[dr.RoomID+dr.UserID] = append(r.DiceRollsByRoomAndUser[dr.RoomID+dr.UserID], &dr)
@@ -64,8 +105,18 @@ func (r *DiceRollRepository) CreateDiceRoll(ctx context.Context, dr model.DiceRo
 	// Set up the serial.
 	dr.Serial = r.serialTrack
 	r.serialTrack++
+}
 
-	return nil
+// deleteRoomDiceRolls deletes all the dice rolls of a room.
+func (r *DiceRollRepository) deleteRoomDiceRolls(roomID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, dr := range r.DiceRollsByRoom[roomID] {
+		delete(r.DiceRollsByID, dr.ID)
+		delete(r.DiceRollsByRoomAndUser, dr.RoomID+dr.UserID)
+	}
+	delete(r.DiceRollsByRoom, roomID)
 }
 
 type cursor struct {
@@ -179,5 +230,51 @@ func (r *DiceRollRepository) ListDiceRolls(ctx context.Context, pageOpts model.P
 	}, nil
 }
 
+// GetDiceRoll satisfies storage.DiceRollRepository interface.
+func (r *DiceRollRepository) GetDiceRoll(ctx context.Context, id string) (*model.DiceRoll, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	dr, ok := r.DiceRollsByID[id]
+	if !ok {
+		return nil, internalerrors.ErrMissing
+	}
+
+	res := *dr
+	return &res, nil
+}
+
+// ListDieRollSides satisfies storage.DiceRollRepository interface.
+func (r *DiceRollRepository) ListDieRollSides(ctx context.Context, opts storage.DieRollStatsOpts) ([]storage.DieSide, error) {
+	if opts.RoomID == "" || opts.Limit == 0 {
+		return nil, internalerrors.ErrNotValid
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	items := r.DiceRollsByRoom[opts.RoomID]
+	if opts.UserID != "" {
+		items = r.DiceRollsByRoomAndUser[opts.RoomID+opts.UserID]
+	}
+
+	res := []storage.DieSide{}
+	for _, dr := range items {
+		if dr.Visibility != model.DiceRollVisibilityPublic || dr.ParentID != "" {
+			continue
+		}
+		for _, d := range dr.Dice {
+			res = append(res, storage.DieSide{DieTypeID: d.Type.ID(), Side: d.Side})
+		}
+	}
+
+	// Keep the latest ones.
+	if uint(len(res)) > opts.Limit {
+		res = res[uint(len(res))-opts.Limit:]
+	}
+
+	return res, nil
+}
+
 // Implementation assertions.
 var _ storage.DiceRollRepository = &DiceRollRepository{}
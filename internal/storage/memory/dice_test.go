@@ -91,6 +91,30 @@ func TestDiceRollRepositoryCreateDiceRoll(t *testing.T) {
 				UserID: "user-id",
 			},
 		},
+
+		"Creating a dice roll with a label, a modifier and a total should store them.": {
+			repo: func() *memory.DiceRollRepository {
+				return memory.NewDiceRollRepository()
+			},
+			diceRoll: model.DiceRoll{
+				ID:       "test-id",
+				RoomID:   "room-id",
+				UserID:   "user-id",
+				Label:    "Longsword damage",
+				Modifier: 3,
+				Total:    9,
+				Dice:     []model.DieRoll{{ID: "die-id", Type: model.DieTypeD8, Side: 6}},
+			},
+			expDiceRoll: model.DiceRoll{
+				ID:       "test-id",
+				RoomID:   "room-id",
+				UserID:   "user-id",
+				Label:    "Longsword damage",
+				Modifier: 3,
+				Total:    9,
+				Dice:     []model.DieRoll{{ID: "die-id", Type: model.DieTypeD8, Side: 6}},
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -119,6 +143,90 @@ func TestDiceRollRepositoryCreateDiceRoll(t *testing.T) {
 	}
 }
 
+func TestDiceRollRepositoryCreateDiceRolls(t *testing.T) {
+	tests := map[string]struct {
+		repo         func() *memory.DiceRollRepository
+		diceRolls    []model.DiceRoll
+		expDiceRolls []model.DiceRoll
+		expErr       error
+	}{
+		"Having an invalid dice roll in the batch should not store any dice roll.": {
+			repo: func() *memory.DiceRollRepository {
+				return memory.NewDiceRollRepository()
+			},
+			diceRolls: []model.DiceRoll{
+				{ID: "test-id-1", RoomID: "room-id", UserID: "user-id"},
+				{ID: "test-id-2", RoomID: "room-id", UserID: ""},
+			},
+			expDiceRolls: []model.DiceRoll{},
+			expErr:       internalerrors.ErrNotValid,
+		},
+
+		"Having an existing dice roll in the batch should not store any dice roll.": {
+			repo: func() *memory.DiceRollRepository {
+				r := memory.NewDiceRollRepository()
+				r.DiceRollsByID = map[string]*model.DiceRoll{
+					"test-id-2": {ID: "test-id-2"},
+				}
+				return r
+			},
+			diceRolls: []model.DiceRoll{
+				{ID: "test-id-1", RoomID: "room-id", UserID: "user-id"},
+				{ID: "test-id-2", RoomID: "room-id", UserID: "user-id"},
+			},
+			expDiceRolls: []model.DiceRoll{},
+			expErr:       internalerrors.ErrAlreadyExists,
+		},
+
+		"Having a repeated dice roll in the batch should not store any dice roll.": {
+			repo: func() *memory.DiceRollRepository {
+				return memory.NewDiceRollRepository()
+			},
+			diceRolls: []model.DiceRoll{
+				{ID: "test-id-1", RoomID: "room-id", UserID: "user-id"},
+				{ID: "test-id-1", RoomID: "room-id", UserID: "user-id"},
+			},
+			expDiceRolls: []model.DiceRoll{},
+			expErr:       internalerrors.ErrAlreadyExists,
+		},
+
+		"Creating dice rolls should store all of them in order.": {
+			repo: func() *memory.DiceRollRepository {
+				return memory.NewDiceRollRepository()
+			},
+			diceRolls: []model.DiceRoll{
+				{ID: "test-id-1", RoomID: "room-id", UserID: "user-id", Total: 7},
+				{ID: "test-id-2", RoomID: "room-id", UserID: "user-id", Total: 19},
+			},
+			expDiceRolls: []model.DiceRoll{
+				{ID: "test-id-1", RoomID: "room-id", UserID: "user-id", Total: 7, Serial: 0},
+				{ID: "test-id-2", RoomID: "room-id", UserID: "user-id", Total: 19, Serial: 1},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			r := test.repo()
+			err := r.CreateDiceRolls(context.TODO(), test.diceRolls)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else {
+				assert.NoError(err)
+			}
+
+			gotDiceRolls := []model.DiceRoll{}
+			for _, dr := range r.DiceRollsByRoom["room-id"] {
+				gotDiceRolls = append(gotDiceRolls, *dr)
+			}
+			assert.Equal(test.expDiceRolls, gotDiceRolls)
+		})
+	}
+}
+
 func TestDiceRollRepositoryCreateDiceRollSerial(t *testing.T) {
 	tests := map[string]struct {
 		repo      func() *memory.DiceRollRepository
@@ -508,3 +616,137 @@ func TestDiceRollRepositoryListDiceRoll(t *testing.T) {
 		})
 	}
 }
+
+func TestDiceRollRepositoryGetDiceRoll(t *testing.T) {
+	tests := map[string]struct {
+		repo        func() *memory.DiceRollRepository
+		id          string
+		expDiceRoll *model.DiceRoll
+		expErr      error
+	}{
+		"Having a dice roll ID that does not exists in the repository, it should return an error.": {
+			repo:   memory.NewDiceRollRepository,
+			id:     "dr1",
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Having a dice roll ID that exists in the repository, it should return the dice roll.": {
+			repo: func() *memory.DiceRollRepository {
+				r := memory.NewDiceRollRepository()
+				r.DiceRollsByID["dr1"] = &model.DiceRoll{ID: "dr1", RoomID: "room1", UserID: "user1"}
+				return r
+			},
+			id:          "dr1",
+			expDiceRoll: &model.DiceRoll{ID: "dr1", RoomID: "room1", UserID: "user1"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			r := test.repo()
+			gotDiceRoll, err := r.GetDiceRoll(context.TODO(), test.id)
+
+			if test.expErr != nil {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				assert.Equal(test.expDiceRoll, gotDiceRoll)
+			}
+		})
+	}
+}
+
+func TestDiceRollRepositoryDieRollStats(t *testing.T) {
+	diceRolls := []model.DiceRoll{
+		{ID: "dr1", RoomID: "room1", UserID: "user1", Dice: []model.DieRoll{
+			{ID: "d1", Type: model.DieTypeD6, Side: 3},
+			{ID: "d2", Type: model.DieTypeD20, Side: 20},
+		}},
+		{ID: "dr2", RoomID: "room1", UserID: "user2", Dice: []model.DieRoll{
+			{ID: "d3", Type: model.DieTypeD6, Side: 3},
+		}},
+		{ID: "dr3", RoomID: "room1", UserID: "user1", Visibility: model.DiceRollVisibilityGameMaster, Dice: []model.DieRoll{
+			{ID: "d4", Type: model.DieTypeD6, Side: 1},
+		}},
+		{ID: "dr4", RoomID: "room1", UserID: "user1", ParentID: "dr1", Dice: []model.DieRoll{
+			{ID: "d5", Type: model.DieTypeD6, Side: 3},
+			{ID: "d6", Type: model.DieTypeD20, Side: 20, Status: model.DieRollStatusRerolled},
+			{ID: "d7", Type: model.DieTypeD20, Side: 2},
+		}},
+		{ID: "dr5", RoomID: "room2", UserID: "user3", Dice: []model.DieRoll{
+			{ID: "d8", Type: model.DieTypeD6, Side: 6},
+		}},
+		{ID: "dr6", RoomID: "room1", UserID: "user1", Dice: []model.DieRoll{
+			{ID: "d9", Type: model.DieTypeD6, Side: 5},
+		}},
+	}
+
+	tests := map[string]struct {
+		opts     storage.DieRollStatsOpts
+		expSides []storage.DieSide
+		expErr   error
+	}{
+		"Without room it should fail.": {
+			opts:   storage.DieRollStatsOpts{Limit: 100},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Without limit it should fail.": {
+			opts:   storage.DieRollStatsOpts{RoomID: "room1"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Aggregating a room should aggregate the public dice rolls that are not rerolls of the room.": {
+			opts: storage.DieRollStatsOpts{RoomID: "room1", Limit: 100},
+			expSides: []storage.DieSide{
+				{DieTypeID: "d6", Side: 3},
+				{DieTypeID: "d20", Side: 20},
+				{DieTypeID: "d6", Side: 3},
+				{DieTypeID: "d6", Side: 5},
+			},
+		},
+
+		"Aggregating a user should aggregate the public dice rolls that are not rerolls of the room user.": {
+			opts: storage.DieRollStatsOpts{RoomID: "room1", UserID: "user1", Limit: 100},
+			expSides: []storage.DieSide{
+				{DieTypeID: "d6", Side: 3},
+				{DieTypeID: "d20", Side: 20},
+				{DieTypeID: "d6", Side: 5},
+			},
+		},
+
+		"Aggregating a room with a limit should aggregate only the latest rolled dice.": {
+			opts: storage.DieRollStatsOpts{RoomID: "room1", Limit: 2},
+			expSides: []storage.DieSide{
+				{DieTypeID: "d6", Side: 3},
+				{DieTypeID: "d6", Side: 5},
+			},
+		},
+
+		"Aggregating a room without dice rolls should not return anything.": {
+			opts:     storage.DieRollStatsOpts{RoomID: "room3", Limit: 100},
+			expSides: []storage.DieSide{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			r := memory.NewDiceRollRepository()
+			for _, dr := range diceRolls {
+				err := r.CreateDiceRoll(context.TODO(), dr)
+				require.NoError(err)
+			}
+
+			gotSides, err := r.ListDieRollSides(context.TODO(), test.opts)
+			if test.expErr != nil {
+				assert.ErrorIs(err, test.expErr)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expSides, gotSides)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+)
+
+// CustomDieTypeRepository is a fake repository based on memory.
+// This repository exposes the storage to the public so the users can
+// check the internal data in and maniputale it (e.g tests).
+type CustomDieTypeRepository struct {
+	// CustomDieTypesByRoom is where the custom die types are stored by room in creation order. Not thread safe.
+	CustomDieTypesByRoom map[string][]model.CustomDieType
+	// CustomDieTypesByID is where the custom die types are stored by id. Not thread safe.
+	CustomDieTypesByID map[string]*model.CustomDieType
+
+	mu sync.Mutex
+}
+
+// NewCustomDieTypeRepository returns a new CustomDieTypeRepository.
+func NewCustomDieTypeRepository() *CustomDieTypeRepository {
+	return &CustomDieTypeRepository{
+		CustomDieTypesByRoom: map[string][]model.CustomDieType{},
+		CustomDieTypesByID:   map[string]*model.CustomDieType{},
+	}
+}
+
+// CreateCustomDieType satisfies storage.CustomDieTypeRepository interface.
+func (r *CustomDieTypeRepository) CreateCustomDieType(ctx context.Context, dt model.CustomDieType) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch {
+	case dt.TypeID == "":
+		return fmt.Errorf("missing ID: %w", internalerrors.ErrNotValid)
+	case dt.RoomID == "":
+		return fmt.Errorf("missing RoomID: %w", internalerrors.ErrNotValid)
+	case dt.TypeName == "":
+		return fmt.Errorf("missing Name: %w", internalerrors.ErrNotValid)
+	case len(dt.FaceTable) == 0:
+		return fmt.Errorf("missing faces: %w", internalerrors.ErrNotValid)
+	}
+
+	_, ok := r.CustomDieTypesByID[dt.TypeID]
+	if ok {
+		return fmt.Errorf("custom die type already exists: %w", internalerrors.ErrAlreadyExists)
+	}
+
+	r.CustomDieTypesByRoom[dt.RoomID] = append(r.CustomDieTypesByRoom[dt.RoomID], dt)
+	r.CustomDieTypesByID[dt.TypeID] = &dt
+
+	return nil
+}
+
+// ListRoomCustomDieTypes satisfies storage.CustomDieTypeRepository interface.
+func (r *CustomDieTypeRepository) ListRoomCustomDieTypes(ctx context.Context, roomID string) (*storage.CustomDieTypeList, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if roomID == "" {
+		return nil, fmt.Errorf("missing RoomID: %w", internalerrors.ErrNotValid)
+	}
+
+	dts := r.CustomDieTypesByRoom[roomID]
+	items := make([]model.CustomDieType, 0, len(dts))
+	items = append(items, dts...)
+
+	return &storage.CustomDieTypeList{
+		Items: items,
+	}, nil
+}
+
+// deleteRoomCustomDieTypes deletes the custom die types of the room.
+func (r *CustomDieTypeRepository) deleteRoomCustomDieTypes(roomID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, dt := range r.CustomDieTypesByRoom[roomID] {
+		delete(r.CustomDieTypesByID, dt.TypeID)
+	}
+	delete(r.CustomDieTypesByRoom, roomID)
+}
+
+// Implementation assertions.
+var _ storage.CustomDieTypeRepository = &CustomDieTypeRepository{}
@@ -0,0 +1,156 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+	"github.com/rollify/rollify/internal/storage/memory"
+)
+
+func getFateDieType(id, roomID string) model.CustomDieType {
+	return model.CustomDieType{
+		TypeID:   id,
+		RoomID:   roomID,
+		TypeName: "Fate",
+		FaceTable: []model.DieFace{
+			{Label: "-", Value: -1}, {Label: "-", Value: -1},
+			{Label: "", Value: 0}, {Label: "", Value: 0},
+			{Label: "+", Value: 1}, {Label: "+", Value: 1},
+		},
+	}
+}
+
+func TestCustomDieTypeRepositoryCreateCustomDieType(t *testing.T) {
+	tests := map[string]struct {
+		repo   func() *memory.CustomDieTypeRepository
+		dt     model.CustomDieType
+		expErr error
+	}{
+		"Having a die type without ID should return a not valid error.": {
+			repo: func() *memory.CustomDieTypeRepository {
+				return memory.NewCustomDieTypeRepository()
+			},
+			dt:     getFateDieType("", "room-id"),
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a die type without room ID should return a not valid error.": {
+			repo: func() *memory.CustomDieTypeRepository {
+				return memory.NewCustomDieTypeRepository()
+			},
+			dt:     getFateDieType("dt-id", ""),
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a die type without faces should return a not valid error.": {
+			repo: func() *memory.CustomDieTypeRepository {
+				return memory.NewCustomDieTypeRepository()
+			},
+			dt:     model.CustomDieType{TypeID: "dt-id", RoomID: "room-id", TypeName: "Fate"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having an already stored die type should return an error.": {
+			repo: func() *memory.CustomDieTypeRepository {
+				r := memory.NewCustomDieTypeRepository()
+				dt := getFateDieType("dt-id", "room-id")
+				r.CustomDieTypesByID["dt-id"] = &dt
+				return r
+			},
+			dt:     getFateDieType("dt-id", "room-id"),
+			expErr: internalerrors.ErrAlreadyExists,
+		},
+
+		"Having a die type should be stored.": {
+			repo: func() *memory.CustomDieTypeRepository {
+				return memory.NewCustomDieTypeRepository()
+			},
+			dt: getFateDieType("dt-id", "room-id"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			r := test.repo()
+			err := r.CreateCustomDieType(context.TODO(), test.dt)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				// Check the die type has been created internally.
+				assert.Equal([]model.CustomDieType{test.dt}, r.CustomDieTypesByRoom[test.dt.RoomID])
+				gotDT := r.CustomDieTypesByID[test.dt.TypeID]
+				require.NotNil(gotDT)
+				assert.Equal(test.dt, *gotDT)
+			}
+		})
+	}
+}
+
+func TestCustomDieTypeRepositoryListRoomCustomDieTypes(t *testing.T) {
+	tests := map[string]struct {
+		repo    func() *memory.CustomDieTypeRepository
+		roomID  string
+		expList *storage.CustomDieTypeList
+		expErr  bool
+	}{
+		"Using an empty room ID should return an error.": {
+			repo: func() *memory.CustomDieTypeRepository {
+				return memory.NewCustomDieTypeRepository()
+			},
+			roomID: "",
+			expErr: true,
+		},
+
+		"Using a room without die types should return an empty list.": {
+			repo: func() *memory.CustomDieTypeRepository {
+				return memory.NewCustomDieTypeRepository()
+			},
+			roomID:  "room1-id",
+			expList: &storage.CustomDieTypeList{Items: []model.CustomDieType{}},
+		},
+
+		"Using a room ID should return that room die types in creation order.": {
+			repo: func() *memory.CustomDieTypeRepository {
+				r := memory.NewCustomDieTypeRepository()
+				r.CustomDieTypesByRoom = map[string][]model.CustomDieType{
+					"room1-id": {getFateDieType("dt1-id", "room1-id")},
+					"room2-id": {getFateDieType("dt3-id", "room2-id"), getFateDieType("dt2-id", "room2-id")},
+				}
+				return r
+			},
+			roomID: "room2-id",
+			expList: &storage.CustomDieTypeList{
+				Items: []model.CustomDieType{
+					getFateDieType("dt3-id", "room2-id"),
+					getFateDieType("dt2-id", "room2-id"),
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			r := test.repo()
+			gotList, err := r.ListRoomCustomDieTypes(context.TODO(), test.roomID)
+
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expList, gotList)
+			}
+		})
+	}
+}
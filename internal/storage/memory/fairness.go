@@ -0,0 +1,131 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+)
+
+// ServerSeedRepository is a fake repository based on memory.
+// This repository exposes the storage to the public so the users can
+// check the internal data in and maniputale it (e.g tests).
+type ServerSeedRepository struct {
+	// ServerSeedsByID is where the server seeds are stored by ID. Not thread safe.
+	ServerSeedsByID map[string]*model.ServerSeed
+	// ServerSeedsByRoom is where the server seeds are stored by room in creation order. Not thread safe.
+	ServerSeedsByRoom map[string][]*model.ServerSeed
+
+	mu sync.Mutex
+}
+
+// NewServerSeedRepository returns a new ServerSeedRepository.
+func NewServerSeedRepository() *ServerSeedRepository {
+	return &ServerSeedRepository{
+		ServerSeedsByID:   map[string]*model.ServerSeed{},
+		ServerSeedsByRoom: map[string][]*model.ServerSeed{},
+	}
+}
+
+// CreateServerSeed satisfies storage.ServerSeedRepository interface.
+func (r *ServerSeedRepository) CreateServerSeed(ctx context.Context, s model.ServerSeed) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch {
+	case s.ID == "":
+		return fmt.Errorf("missing ID: %w", internalerrors.ErrNotValid)
+	case s.RoomID == "":
+		return fmt.Errorf("missing RoomID: %w", internalerrors.ErrNotValid)
+	case s.Seed == "":
+		return fmt.Errorf("missing seed: %w", internalerrors.ErrNotValid)
+	case s.Hash == "":
+		return fmt.Errorf("missing hash: %w", internalerrors.ErrNotValid)
+	}
+
+	_, ok := r.ServerSeedsByID[s.ID]
+	if ok {
+		return fmt.Errorf("server seed already exists: %w", internalerrors.ErrAlreadyExists)
+	}
+
+	r.ServerSeedsByID[s.ID] = &s
+	r.ServerSeedsByRoom[s.RoomID] = append(r.ServerSeedsByRoom[s.RoomID], &s)
+
+	return nil
+}
+
+// GetServerSeed satisfies storage.ServerSeedRepository interface.
+func (r *ServerSeedRepository) GetServerSeed(ctx context.Context, id string) (*model.ServerSeed, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.ServerSeedsByID[id]
+	if !ok {
+		return nil, internalerrors.ErrMissing
+	}
+
+	res := *s
+	return &res, nil
+}
+
+// GetRoomActiveServerSeed satisfies storage.ServerSeedRepository interface.
+func (r *ServerSeedRepository) GetRoomActiveServerSeed(ctx context.Context, roomID string) (*model.ServerSeed, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Get the latest not revealed seed.
+	ss := r.ServerSeedsByRoom[roomID]
+	for i := len(ss) - 1; i >= 0; i-- {
+		if !ss[i].Revealed {
+			res := *ss[i]
+			return &res, nil
+		}
+	}
+
+	return nil, internalerrors.ErrMissing
+}
+
+// IncreaseServerSeedNonce satisfies storage.ServerSeedRepository interface.
+func (r *ServerSeedRepository) IncreaseServerSeedNonce(ctx context.Context, id string) (uint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.ServerSeedsByID[id]
+	if !ok || s.Revealed {
+		return 0, internalerrors.ErrMissing
+	}
+	s.Nonce++
+
+	return s.Nonce, nil
+}
+
+// RevealServerSeed satisfies storage.ServerSeedRepository interface.
+func (r *ServerSeedRepository) RevealServerSeed(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.ServerSeedsByID[id]
+	if !ok || s.Revealed {
+		return internalerrors.ErrMissing
+	}
+	s.Revealed = true
+
+	return nil
+}
+
+// deleteRoomServerSeeds deletes the server seeds of the room.
+func (r *ServerSeedRepository) deleteRoomServerSeeds(roomID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, ss := range r.ServerSeedsByRoom[roomID] {
+		delete(r.ServerSeedsByID, ss.ID)
+	}
+	delete(r.ServerSeedsByRoom, roomID)
+}
+
+// Implementation assertions.
+var _ storage.ServerSeedRepository = &ServerSeedRepository{}
@@ -0,0 +1,213 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage/memory"
+)
+
+func TestServerSeedRepositoryCreateServerSeed(t *testing.T) {
+	tests := map[string]struct {
+		repo   func() *memory.ServerSeedRepository
+		seed   model.ServerSeed
+		expErr error
+	}{
+		"Having a server seed without ID should return a not valid error.": {
+			repo:   memory.NewServerSeedRepository,
+			seed:   model.ServerSeed{RoomID: "room1-id", Seed: "s1", Hash: "h1"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a server seed without room should return a not valid error.": {
+			repo:   memory.NewServerSeedRepository,
+			seed:   model.ServerSeed{ID: "ss1-id", Seed: "s1", Hash: "h1"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a server seed without seed should return a not valid error.": {
+			repo:   memory.NewServerSeedRepository,
+			seed:   model.ServerSeed{ID: "ss1-id", RoomID: "room1-id", Hash: "h1"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Creating a server seed that already exists should return an error.": {
+			repo: func() *memory.ServerSeedRepository {
+				r := memory.NewServerSeedRepository()
+				r.ServerSeedsByID["ss1-id"] = &model.ServerSeed{ID: "ss1-id"}
+				return r
+			},
+			seed:   model.ServerSeed{ID: "ss1-id", RoomID: "room1-id", Seed: "s1", Hash: "h1"},
+			expErr: internalerrors.ErrAlreadyExists,
+		},
+
+		"Creating a server seed should store the server seed.": {
+			repo: memory.NewServerSeedRepository,
+			seed: model.ServerSeed{ID: "ss1-id", RoomID: "room1-id", Seed: "s1", Hash: "h1"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			r := test.repo()
+			err := r.CreateServerSeed(context.TODO(), test.seed)
+
+			if test.expErr != nil {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				assert.Equal(&test.seed, r.ServerSeedsByID[test.seed.ID])
+				assert.Equal([]*model.ServerSeed{&test.seed}, r.ServerSeedsByRoom[test.seed.RoomID])
+			}
+		})
+	}
+}
+
+func TestServerSeedRepositoryGetRoomActiveServerSeed(t *testing.T) {
+	tests := map[string]struct {
+		repo    func() *memory.ServerSeedRepository
+		roomID  string
+		expSeed *model.ServerSeed
+		expErr  error
+	}{
+		"A room without server seeds should return a missing error.": {
+			repo:   memory.NewServerSeedRepository,
+			roomID: "room1-id",
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"A room with all the server seeds revealed should return a missing error.": {
+			repo: func() *memory.ServerSeedRepository {
+				r := memory.NewServerSeedRepository()
+				r.ServerSeedsByRoom["room1-id"] = []*model.ServerSeed{{ID: "ss1-id", Revealed: true}}
+				return r
+			},
+			roomID: "room1-id",
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"A room with server seeds should return the latest not revealed one.": {
+			repo: func() *memory.ServerSeedRepository {
+				r := memory.NewServerSeedRepository()
+				r.ServerSeedsByRoom["room1-id"] = []*model.ServerSeed{
+					{ID: "ss1-id", Revealed: true},
+					{ID: "ss2-id"},
+					{ID: "ss3-id"},
+				}
+				return r
+			},
+			roomID:  "room1-id",
+			expSeed: &model.ServerSeed{ID: "ss3-id"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			r := test.repo()
+			gotSeed, err := r.GetRoomActiveServerSeed(context.TODO(), test.roomID)
+
+			if test.expErr != nil {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				assert.Equal(test.expSeed, gotSeed)
+			}
+		})
+	}
+}
+
+func TestServerSeedRepositoryIncreaseServerSeedNonce(t *testing.T) {
+	tests := map[string]struct {
+		repo     func() *memory.ServerSeedRepository
+		id       string
+		expNonce uint
+		expErr   error
+	}{
+		"A missing server seed should return a missing error.": {
+			repo:   memory.NewServerSeedRepository,
+			id:     "ss1-id",
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"A revealed server seed should return a missing error.": {
+			repo: func() *memory.ServerSeedRepository {
+				r := memory.NewServerSeedRepository()
+				r.ServerSeedsByID["ss1-id"] = &model.ServerSeed{ID: "ss1-id", Nonce: 4, Revealed: true}
+				return r
+			},
+			id:     "ss1-id",
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"An active server seed should increase its nonce.": {
+			repo: func() *memory.ServerSeedRepository {
+				r := memory.NewServerSeedRepository()
+				r.ServerSeedsByID["ss1-id"] = &model.ServerSeed{ID: "ss1-id", Nonce: 4}
+				return r
+			},
+			id:       "ss1-id",
+			expNonce: 5,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			r := test.repo()
+			gotNonce, err := r.IncreaseServerSeedNonce(context.TODO(), test.id)
+
+			if test.expErr != nil {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				assert.Equal(test.expNonce, gotNonce)
+				assert.Equal(test.expNonce, r.ServerSeedsByID[test.id].Nonce)
+			}
+		})
+	}
+}
+
+func TestServerSeedRepositoryRevealServerSeed(t *testing.T) {
+	tests := map[string]struct {
+		repo   func() *memory.ServerSeedRepository
+		id     string
+		expErr error
+	}{
+		"A missing server seed should return a missing error.": {
+			repo:   memory.NewServerSeedRepository,
+			id:     "ss1-id",
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"An active server seed should be revealed.": {
+			repo: func() *memory.ServerSeedRepository {
+				r := memory.NewServerSeedRepository()
+				r.ServerSeedsByID["ss1-id"] = &model.ServerSeed{ID: "ss1-id"}
+				return r
+			},
+			id: "ss1-id",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			r := test.repo()
+			err := r.RevealServerSeed(context.TODO(), test.id)
+
+			if test.expErr != nil {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				assert.True(r.ServerSeedsByID[test.id].Revealed)
+			}
+		})
+	}
+}
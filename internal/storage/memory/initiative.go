@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+)
+
+// InitiativeRepository is a fake repository based on memory.
+// This repository exposes the storage to the public so the users can
+// check the internal data in and maniputale it (e.g tests).
+type InitiativeRepository struct {
+	// InitiativesByRoom is where the initiative trackers are stored by room ID. Not thread safe.
+	InitiativesByRoom map[string]*model.Initiative
+
+	mu sync.Mutex
+}
+
+// NewInitiativeRepository returns a new InitiativeRepository.
+func NewInitiativeRepository() *InitiativeRepository {
+	return &InitiativeRepository{
+		InitiativesByRoom: map[string]*model.Initiative{},
+	}
+}
+
+// GetInitiative satisfies storage.InitiativeRepository interface.
+func (r *InitiativeRepository) GetInitiative(ctx context.Context, roomID string) (*model.Initiative, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i, ok := r.InitiativesByRoom[roomID]
+	if !ok {
+		return nil, internalerrors.ErrMissing
+	}
+
+	ic := *i
+	ic.Combatants = slices.Clone(i.Combatants)
+	return &ic, nil
+}
+
+// SaveInitiative satisfies storage.InitiativeRepository interface.
+func (r *InitiativeRepository) SaveInitiative(ctx context.Context, i model.Initiative) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := validateInitiative(i)
+	if err != nil {
+		return err
+	}
+
+	i.Combatants = slices.Clone(i.Combatants)
+	r.InitiativesByRoom[i.RoomID] = &i
+
+	return nil
+}
+
+// DeleteInitiative satisfies storage.InitiativeRepository interface.
+func (r *InitiativeRepository) DeleteInitiative(ctx context.Context, roomID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.InitiativesByRoom[roomID]
+	if !ok {
+		return internalerrors.ErrMissing
+	}
+
+	delete(r.InitiativesByRoom, roomID)
+
+	return nil
+}
+
+func validateInitiative(i model.Initiative) error {
+	if i.RoomID == "" {
+		return fmt.Errorf("missing RoomID: %w", internalerrors.ErrNotValid)
+	}
+
+	for _, c := range i.Combatants {
+		switch {
+		case c.ID == "":
+			return fmt.Errorf("missing combatant ID: %w", internalerrors.ErrNotValid)
+		case c.Name == "":
+			return fmt.Errorf("missing combatant Name: %w", internalerrors.ErrNotValid)
+		}
+	}
+
+	return nil
+}
+
+// deleteRoomInitiative deletes the initiative tracker of the room.
+func (r *InitiativeRepository) deleteRoomInitiative(roomID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.InitiativesByRoom, roomID)
+}
+
+// Implementation assertions.
+var _ storage.InitiativeRepository = &InitiativeRepository{}
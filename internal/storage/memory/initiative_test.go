@@ -0,0 +1,159 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage/memory"
+)
+
+func getInitiative(roomID string) model.Initiative {
+	return model.Initiative{
+		RoomID: roomID,
+		Round:  2,
+		Turn:   1,
+		Combatants: []model.InitiativeCombatant{
+			{ID: "c1", Name: "Goblin", Initiative: 18, DiceRollID: "dr1"},
+			{ID: "c2", Name: "Aragorn", UserID: "user-id", Initiative: 12},
+		},
+	}
+}
+
+func TestInitiativeRepositoryGetInitiative(t *testing.T) {
+	tests := map[string]struct {
+		repo          func() *memory.InitiativeRepository
+		roomID        string
+		expInitiative *model.Initiative
+		expErr        error
+	}{
+		"Getting a missing initiative should return a missing error.": {
+			repo:   memory.NewInitiativeRepository,
+			roomID: "room-id",
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Getting an initiative should return the room initiative.": {
+			repo: func() *memory.InitiativeRepository {
+				r := memory.NewInitiativeRepository()
+				i := getInitiative("room-id")
+				r.InitiativesByRoom["room-id"] = &i
+				return r
+			},
+			roomID: "room-id",
+			expInitiative: func() *model.Initiative {
+				i := getInitiative("room-id")
+				return &i
+			}(),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			r := test.repo()
+			gotInitiative, err := r.GetInitiative(context.TODO(), test.roomID)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				assert.Equal(test.expInitiative, gotInitiative)
+			}
+		})
+	}
+}
+
+func TestInitiativeRepositorySaveInitiative(t *testing.T) {
+	tests := map[string]struct {
+		repo       func() *memory.InitiativeRepository
+		initiative model.Initiative
+		expErr     error
+	}{
+		"Having an initiative without room ID should return a not valid error.": {
+			repo:       memory.NewInitiativeRepository,
+			initiative: getInitiative(""),
+			expErr:     internalerrors.ErrNotValid,
+		},
+
+		"Having an initiative with a combatant without name should return a not valid error.": {
+			repo: memory.NewInitiativeRepository,
+			initiative: model.Initiative{
+				RoomID:     "room-id",
+				Combatants: []model.InitiativeCombatant{{ID: "c1"}},
+			},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a new initiative should be stored.": {
+			repo:       memory.NewInitiativeRepository,
+			initiative: getInitiative("room-id"),
+		},
+
+		"Having an already stored initiative should be replaced.": {
+			repo: func() *memory.InitiativeRepository {
+				r := memory.NewInitiativeRepository()
+				r.InitiativesByRoom["room-id"] = &model.Initiative{RoomID: "room-id", Round: 1}
+				return r
+			},
+			initiative: getInitiative("room-id"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			r := test.repo()
+			err := r.SaveInitiative(context.TODO(), test.initiative)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				gotInitiative := r.InitiativesByRoom[test.initiative.RoomID]
+				require.NotNil(gotInitiative)
+				assert.Equal(test.initiative, *gotInitiative)
+			}
+		})
+	}
+}
+
+func TestInitiativeRepositoryDeleteInitiative(t *testing.T) {
+	tests := map[string]struct {
+		roomID string
+		expErr error
+	}{
+		"Deleting a missing initiative should return a missing error.": {
+			roomID: "room2-id",
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Deleting an initiative should remove the initiative.": {
+			roomID: "room-id",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			r := memory.NewInitiativeRepository()
+			require.NoError(r.SaveInitiative(context.TODO(), getInitiative("room-id")))
+
+			err := r.DeleteInitiative(context.TODO(), test.roomID)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				assert.NotContains(r.InitiativesByRoom, test.roomID)
+			}
+		})
+	}
+}
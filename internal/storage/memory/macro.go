@@ -0,0 +1,157 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+)
+
+// MacroRepository is a fake repository based on memory.
+// This repository exposes the storage to the public so the users can
+// check the internal data in and maniputale it (e.g tests).
+type MacroRepository struct {
+	// MacrosByID is where the macros are stored by ID. Not thread safe.
+	MacrosByID map[string]*model.Macro
+	// MacrosByRoom is where the macros are stored by room in creation order. Not thread safe.
+	MacrosByRoom map[string][]*model.Macro
+
+	mu sync.Mutex
+}
+
+// NewMacroRepository returns a new MacroRepository.
+func NewMacroRepository() *MacroRepository {
+	return &MacroRepository{
+		MacrosByID:   map[string]*model.Macro{},
+		MacrosByRoom: map[string][]*model.Macro{},
+	}
+}
+
+// CreateMacro satisfies storage.MacroRepository interface.
+func (r *MacroRepository) CreateMacro(ctx context.Context, m model.Macro) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := validateMacro(m)
+	if err != nil {
+		return err
+	}
+
+	_, ok := r.MacrosByID[m.ID]
+	if ok {
+		return fmt.Errorf("macro already exists: %w", internalerrors.ErrAlreadyExists)
+	}
+
+	r.MacrosByID[m.ID] = &m
+	r.MacrosByRoom[m.RoomID] = append(r.MacrosByRoom[m.RoomID], &m)
+
+	return nil
+}
+
+// GetMacro satisfies storage.MacroRepository interface.
+func (r *MacroRepository) GetMacro(ctx context.Context, id string) (*model.Macro, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, ok := r.MacrosByID[id]
+	if !ok {
+		return nil, internalerrors.ErrMissing
+	}
+
+	mc := *m
+	return &mc, nil
+}
+
+// ListMacros satisfies storage.MacroRepository interface.
+func (r *MacroRepository) ListMacros(ctx context.Context, filterOpts storage.ListMacrosOpts) (*storage.MacroList, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if filterOpts.RoomID == "" {
+		return nil, fmt.Errorf("missing RoomID: %w", internalerrors.ErrNotValid)
+	}
+
+	items := []model.Macro{}
+	for _, m := range r.MacrosByRoom[filterOpts.RoomID] {
+		if m.Shared || (filterOpts.UserID != "" && m.UserID == filterOpts.UserID) {
+			items = append(items, *m)
+		}
+	}
+
+	return &storage.MacroList{
+		Items: items,
+	}, nil
+}
+
+// UpdateMacro satisfies storage.MacroRepository interface.
+func (r *MacroRepository) UpdateMacro(ctx context.Context, m model.Macro) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := validateMacro(m)
+	if err != nil {
+		return err
+	}
+
+	stored, ok := r.MacrosByID[m.ID]
+	if !ok {
+		return internalerrors.ErrMissing
+	}
+
+	stored.Name = m.Name
+	stored.Expression = m.Expression
+	stored.Shared = m.Shared
+
+	return nil
+}
+
+// DeleteMacro satisfies storage.MacroRepository interface.
+func (r *MacroRepository) DeleteMacro(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, ok := r.MacrosByID[id]
+	if !ok {
+		return internalerrors.ErrMissing
+	}
+
+	delete(r.MacrosByID, id)
+	r.MacrosByRoom[m.RoomID] = slices.DeleteFunc(r.MacrosByRoom[m.RoomID], func(rm *model.Macro) bool { return rm.ID == id })
+
+	return nil
+}
+
+func validateMacro(m model.Macro) error {
+	switch {
+	case m.ID == "":
+		return fmt.Errorf("missing ID: %w", internalerrors.ErrNotValid)
+	case m.RoomID == "":
+		return fmt.Errorf("missing RoomID: %w", internalerrors.ErrNotValid)
+	case m.UserID == "":
+		return fmt.Errorf("missing UserID: %w", internalerrors.ErrNotValid)
+	case m.Name == "":
+		return fmt.Errorf("missing Name: %w", internalerrors.ErrNotValid)
+	case m.Expression == "":
+		return fmt.Errorf("missing Expression: %w", internalerrors.ErrNotValid)
+	}
+
+	return nil
+}
+
+// deleteRoomMacros deletes the macros of the room.
+func (r *MacroRepository) deleteRoomMacros(roomID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, m := range r.MacrosByRoom[roomID] {
+		delete(r.MacrosByID, m.ID)
+	}
+	delete(r.MacrosByRoom, roomID)
+}
+
+// Implementation assertions.
+var _ storage.MacroRepository = &MacroRepository{}
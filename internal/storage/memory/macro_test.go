@@ -0,0 +1,300 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+	"github.com/rollify/rollify/internal/storage/memory"
+)
+
+func getMacro(id, roomID, userID string, shared bool) model.Macro {
+	return model.Macro{
+		ID:         id,
+		RoomID:     roomID,
+		UserID:     userID,
+		Name:       "Attack " + id,
+		Expression: "1d20+5",
+		Shared:     shared,
+	}
+}
+
+func TestMacroRepositoryCreateMacro(t *testing.T) {
+	tests := map[string]struct {
+		repo   func() *memory.MacroRepository
+		macro  model.Macro
+		expErr error
+	}{
+		"Having a macro without ID should return a not valid error.": {
+			repo:   memory.NewMacroRepository,
+			macro:  getMacro("", "room-id", "user-id", false),
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a macro without room ID should return a not valid error.": {
+			repo:   memory.NewMacroRepository,
+			macro:  getMacro("m-id", "", "user-id", false),
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a macro without user ID should return a not valid error.": {
+			repo:   memory.NewMacroRepository,
+			macro:  getMacro("m-id", "room-id", "", false),
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a macro without expression should return a not valid error.": {
+			repo:   memory.NewMacroRepository,
+			macro:  model.Macro{ID: "m-id", RoomID: "room-id", UserID: "user-id", Name: "Attack"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having an already stored macro should return an error.": {
+			repo: func() *memory.MacroRepository {
+				r := memory.NewMacroRepository()
+				m := getMacro("m-id", "room-id", "user-id", false)
+				r.MacrosByID["m-id"] = &m
+				return r
+			},
+			macro:  getMacro("m-id", "room-id", "user-id", false),
+			expErr: internalerrors.ErrAlreadyExists,
+		},
+
+		"Having a macro should be stored.": {
+			repo:  memory.NewMacroRepository,
+			macro: getMacro("m-id", "room-id", "user-id", true),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			r := test.repo()
+			err := r.CreateMacro(context.TODO(), test.macro)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				// Check the macro has been created internally.
+				gotMacro := r.MacrosByID[test.macro.ID]
+				require.NotNil(gotMacro)
+				assert.Equal(test.macro, *gotMacro)
+				assert.Equal([]*model.Macro{gotMacro}, r.MacrosByRoom[test.macro.RoomID])
+			}
+		})
+	}
+}
+
+func TestMacroRepositoryGetMacro(t *testing.T) {
+	tests := map[string]struct {
+		repo     func() *memory.MacroRepository
+		id       string
+		expMacro *model.Macro
+		expErr   error
+	}{
+		"Getting a missing macro should return a missing error.": {
+			repo:   memory.NewMacroRepository,
+			id:     "m-id",
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Getting a macro should return the macro.": {
+			repo: func() *memory.MacroRepository {
+				r := memory.NewMacroRepository()
+				m := getMacro("m-id", "room-id", "user-id", false)
+				r.MacrosByID["m-id"] = &m
+				return r
+			},
+			id: "m-id",
+			expMacro: func() *model.Macro {
+				m := getMacro("m-id", "room-id", "user-id", false)
+				return &m
+			}(),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			r := test.repo()
+			gotMacro, err := r.GetMacro(context.TODO(), test.id)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				assert.Equal(test.expMacro, gotMacro)
+			}
+		})
+	}
+}
+
+func TestMacroRepositoryListMacros(t *testing.T) {
+	newRepo := func() *memory.MacroRepository {
+		r := memory.NewMacroRepository()
+		for _, m := range []model.Macro{
+			getMacro("m1", "room1-id", "user1-id", false),
+			getMacro("m2", "room1-id", "user2-id", false),
+			getMacro("m3", "room1-id", "user2-id", true),
+			getMacro("m4", "room1-id", "user1-id", false),
+			getMacro("m5", "room2-id", "user3-id", true),
+		} {
+			m := m
+			r.MacrosByID[m.ID] = &m
+			r.MacrosByRoom[m.RoomID] = append(r.MacrosByRoom[m.RoomID], &m)
+		}
+		return r
+	}
+
+	tests := map[string]struct {
+		repo    func() *memory.MacroRepository
+		opts    storage.ListMacrosOpts
+		expList *storage.MacroList
+		expErr  bool
+	}{
+		"Using an empty room ID should return an error.": {
+			repo:   newRepo,
+			opts:   storage.ListMacrosOpts{},
+			expErr: true,
+		},
+
+		"Using a room without macros should return an empty list.": {
+			repo:    newRepo,
+			opts:    storage.ListMacrosOpts{RoomID: "room3-id", UserID: "user1-id"},
+			expList: &storage.MacroList{Items: []model.Macro{}},
+		},
+
+		"Without user, only the room shared macros should be listed.": {
+			repo: newRepo,
+			opts: storage.ListMacrosOpts{RoomID: "room1-id"},
+			expList: &storage.MacroList{Items: []model.Macro{
+				getMacro("m3", "room1-id", "user2-id", true),
+			}},
+		},
+
+		"With a user, the user macros and the room shared ones should be listed in creation order.": {
+			repo: newRepo,
+			opts: storage.ListMacrosOpts{RoomID: "room1-id", UserID: "user1-id"},
+			expList: &storage.MacroList{Items: []model.Macro{
+				getMacro("m1", "room1-id", "user1-id", false),
+				getMacro("m3", "room1-id", "user2-id", true),
+				getMacro("m4", "room1-id", "user1-id", false),
+			}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			r := test.repo()
+			gotList, err := r.ListMacros(context.TODO(), test.opts)
+
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expList, gotList)
+			}
+		})
+	}
+}
+
+func TestMacroRepositoryUpdateMacro(t *testing.T) {
+	tests := map[string]struct {
+		macro    model.Macro
+		expMacro model.Macro
+		expErr   error
+	}{
+		"Updating a missing macro should return a missing error.": {
+			macro:  getMacro("m2", "room-id", "user-id", false),
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Updating a macro without expression should return a not valid error.": {
+			macro:  model.Macro{ID: "m1", RoomID: "room-id", UserID: "user-id", Name: "Attack"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Updating a macro should only update the name, expression and shared fields.": {
+			macro: model.Macro{ID: "m1", RoomID: "room-id", UserID: "other-user-id", Name: "Fireball", Expression: "8d6", Shared: true},
+			expMacro: model.Macro{
+				ID:         "m1",
+				RoomID:     "room-id",
+				UserID:     "user-id",
+				Name:       "Fireball",
+				Expression: "8d6",
+				Shared:     true,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			r := memory.NewMacroRepository()
+			err := r.CreateMacro(context.TODO(), getMacro("m1", "room-id", "user-id", false))
+			require.NoError(err)
+
+			err = r.UpdateMacro(context.TODO(), test.macro)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				assert.Equal(test.expMacro, *r.MacrosByID["m1"])
+				assert.Equal(test.expMacro, *r.MacrosByRoom["room-id"][0])
+			}
+		})
+	}
+}
+
+func TestMacroRepositoryDeleteMacro(t *testing.T) {
+	tests := map[string]struct {
+		id     string
+		expIDs []string
+		expErr error
+	}{
+		"Deleting a missing macro should return a missing error.": {
+			id:     "m3",
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Deleting a macro should remove the macro.": {
+			id:     "m1",
+			expIDs: []string{"m2"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			r := memory.NewMacroRepository()
+			require.NoError(r.CreateMacro(context.TODO(), getMacro("m1", "room-id", "user-id", false)))
+			require.NoError(r.CreateMacro(context.TODO(), getMacro("m2", "room-id", "user-id", false)))
+
+			err := r.DeleteMacro(context.TODO(), test.id)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				gotIDs := []string{}
+				for _, m := range r.MacrosByRoom["room-id"] {
+					gotIDs = append(gotIDs, m.ID)
+				}
+				assert.Equal(test.expIDs, gotIDs)
+				assert.NotContains(r.MacrosByID, test.id)
+			}
+		})
+	}
+}
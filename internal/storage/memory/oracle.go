@@ -0,0 +1,191 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+)
+
+// OracleTableRepository is a fake repository based on memory.
+// This repository exposes the storage to the public so the users can
+// check the internal data in and maniputale it (e.g tests).
+type OracleTableRepository struct {
+	// OracleTablesByID is where the oracle tables are stored by ID. Not thread safe.
+	OracleTablesByID map[string]*model.OracleTable
+	// OracleTablesByRoom is where the oracle tables are stored by room in creation order. Not thread safe.
+	OracleTablesByRoom map[string][]*model.OracleTable
+	// OracleTableRollsByRoom is where the oracle table rolls are stored by room in creation order. Not thread safe.
+	OracleTableRollsByRoom map[string][]*model.OracleTableRoll
+
+	mu sync.Mutex
+}
+
+// NewOracleTableRepository returns a new OracleTableRepository.
+func NewOracleTableRepository() *OracleTableRepository {
+	return &OracleTableRepository{
+		OracleTablesByID:       map[string]*model.OracleTable{},
+		OracleTablesByRoom:     map[string][]*model.OracleTable{},
+		OracleTableRollsByRoom: map[string][]*model.OracleTableRoll{},
+	}
+}
+
+// CreateOracleTable satisfies storage.OracleTableRepository interface.
+func (r *OracleTableRepository) CreateOracleTable(ctx context.Context, t model.OracleTable) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := validateOracleTable(t)
+	if err != nil {
+		return err
+	}
+
+	_, ok := r.OracleTablesByID[t.ID]
+	if ok {
+		return fmt.Errorf("oracle table already exists: %w", internalerrors.ErrAlreadyExists)
+	}
+
+	for _, rt := range r.OracleTablesByRoom[t.RoomID] {
+		if rt.Name == t.Name {
+			return fmt.Errorf("oracle table name already exists on room: %w", internalerrors.ErrAlreadyExists)
+		}
+	}
+
+	t.Entries = slices.Clone(t.Entries)
+	r.OracleTablesByID[t.ID] = &t
+	r.OracleTablesByRoom[t.RoomID] = append(r.OracleTablesByRoom[t.RoomID], &t)
+
+	return nil
+}
+
+// GetOracleTable satisfies storage.OracleTableRepository interface.
+func (r *OracleTableRepository) GetOracleTable(ctx context.Context, id string) (*model.OracleTable, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.OracleTablesByID[id]
+	if !ok {
+		return nil, internalerrors.ErrMissing
+	}
+
+	tc := *t
+	tc.Entries = slices.Clone(t.Entries)
+	return &tc, nil
+}
+
+// GetOracleTableByName satisfies storage.OracleTableRepository interface.
+func (r *OracleTableRepository) GetOracleTableByName(ctx context.Context, roomID, name string) (*model.OracleTable, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, t := range r.OracleTablesByRoom[roomID] {
+		if t.Name == name {
+			tc := *t
+			tc.Entries = slices.Clone(t.Entries)
+			return &tc, nil
+		}
+	}
+
+	return nil, internalerrors.ErrMissing
+}
+
+// ListRoomOracleTables satisfies storage.OracleTableRepository interface.
+func (r *OracleTableRepository) ListRoomOracleTables(ctx context.Context, roomID string) (*storage.OracleTableList, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	items := []model.OracleTable{}
+	for _, t := range r.OracleTablesByRoom[roomID] {
+		tc := *t
+		tc.Entries = slices.Clone(t.Entries)
+		items = append(items, tc)
+	}
+
+	return &storage.OracleTableList{
+		Items: items,
+	}, nil
+}
+
+// CreateOracleTableRoll satisfies storage.OracleTableRepository interface.
+func (r *OracleTableRepository) CreateOracleTableRoll(ctx context.Context, tr model.OracleTableRoll) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch {
+	case tr.ID == "":
+		return fmt.Errorf("missing ID: %w", internalerrors.ErrNotValid)
+	case tr.RoomID == "":
+		return fmt.Errorf("missing RoomID: %w", internalerrors.ErrNotValid)
+	case tr.TableID == "":
+		return fmt.Errorf("missing TableID: %w", internalerrors.ErrNotValid)
+	}
+
+	for _, rtr := range r.OracleTableRollsByRoom[tr.RoomID] {
+		if rtr.ID == tr.ID {
+			return fmt.Errorf("oracle table roll already exists: %w", internalerrors.ErrAlreadyExists)
+		}
+	}
+
+	tr.Results = slices.Clone(tr.Results)
+	r.OracleTableRollsByRoom[tr.RoomID] = append(r.OracleTableRollsByRoom[tr.RoomID], &tr)
+
+	return nil
+}
+
+// ListRoomOracleTableRolls satisfies storage.OracleTableRepository interface.
+func (r *OracleTableRepository) ListRoomOracleTableRolls(ctx context.Context, roomID string, limit int) (*storage.OracleTableRollList, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	items := []model.OracleTableRoll{}
+	rolls := r.OracleTableRollsByRoom[roomID]
+	for i := len(rolls) - 1; i >= 0; i-- {
+		if limit > 0 && len(items) >= limit {
+			break
+		}
+
+		tr := *rolls[i]
+		tr.Results = slices.Clone(tr.Results)
+		items = append(items, tr)
+	}
+
+	return &storage.OracleTableRollList{
+		Items: items,
+	}, nil
+}
+
+func validateOracleTable(t model.OracleTable) error {
+	switch {
+	case t.ID == "":
+		return fmt.Errorf("missing ID: %w", internalerrors.ErrNotValid)
+	case t.RoomID == "":
+		return fmt.Errorf("missing RoomID: %w", internalerrors.ErrNotValid)
+	case t.Name == "":
+		return fmt.Errorf("missing Name: %w", internalerrors.ErrNotValid)
+	case t.Dice == "":
+		return fmt.Errorf("missing Dice: %w", internalerrors.ErrNotValid)
+	case len(t.Entries) == 0:
+		return fmt.Errorf("missing Entries: %w", internalerrors.ErrNotValid)
+	}
+
+	return nil
+}
+
+// deleteRoomOracleTables deletes the oracle tables and their rolls of the room.
+func (r *OracleTableRepository) deleteRoomOracleTables(roomID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, t := range r.OracleTablesByRoom[roomID] {
+		delete(r.OracleTablesByID, t.ID)
+	}
+	delete(r.OracleTablesByRoom, roomID)
+	delete(r.OracleTableRollsByRoom, roomID)
+}
+
+// Implementation assertions.
+var _ storage.OracleTableRepository = &OracleTableRepository{}
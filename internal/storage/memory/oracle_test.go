@@ -0,0 +1,243 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+	"github.com/rollify/rollify/internal/storage/memory"
+)
+
+func getOracleTable(id, roomID, name string) model.OracleTable {
+	return model.OracleTable{
+		ID:     id,
+		RoomID: roomID,
+		Name:   name,
+		Dice:   "1d6",
+		Entries: []model.OracleTableEntry{
+			{Min: 1, Max: 3, Result: "Nothing"},
+			{Min: 4, Max: 6, TableName: "Monsters"},
+		},
+	}
+}
+
+func getOracleTableRoll(id, roomID string) model.OracleTableRoll {
+	return model.OracleTableRoll{
+		ID:      id,
+		RoomID:  roomID,
+		UserID:  "user-id",
+		TableID: "table-id",
+		Results: []model.OracleTableResult{
+			{TableName: "Encounters", DiceRollID: "dr1", Value: 5},
+			{TableName: "Monsters", DiceRollID: "dr2", Value: 2, Result: "Goblin"},
+		},
+	}
+}
+
+func TestOracleTableRepositoryCreateOracleTable(t *testing.T) {
+	tests := map[string]struct {
+		repo   func() *memory.OracleTableRepository
+		table  model.OracleTable
+		expErr error
+	}{
+		"Having a table without ID should return a not valid error.": {
+			repo:   memory.NewOracleTableRepository,
+			table:  getOracleTable("", "room-id", "Encounters"),
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a table without entries should return a not valid error.": {
+			repo:   memory.NewOracleTableRepository,
+			table:  model.OracleTable{ID: "table-id", RoomID: "room-id", Name: "Encounters", Dice: "1d6"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having an already stored table should return an already exists error.": {
+			repo: func() *memory.OracleTableRepository {
+				r := memory.NewOracleTableRepository()
+				ot := getOracleTable("table-id", "room-id", "Encounters")
+				r.OracleTablesByID["table-id"] = &ot
+				return r
+			},
+			table:  getOracleTable("table-id", "room-id", "Encounters"),
+			expErr: internalerrors.ErrAlreadyExists,
+		},
+
+		"Having a table with an already used name on the room should return an already exists error.": {
+			repo: func() *memory.OracleTableRepository {
+				r := memory.NewOracleTableRepository()
+				ot := getOracleTable("table1-id", "room-id", "Encounters")
+				r.OracleTablesByID["table1-id"] = &ot
+				r.OracleTablesByRoom["room-id"] = []*model.OracleTable{&ot}
+				return r
+			},
+			table:  getOracleTable("table-id", "room-id", "Encounters"),
+			expErr: internalerrors.ErrAlreadyExists,
+		},
+
+		"Having a new table should be stored.": {
+			repo:  memory.NewOracleTableRepository,
+			table: getOracleTable("table-id", "room-id", "Encounters"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			r := test.repo()
+			err := r.CreateOracleTable(context.TODO(), test.table)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				gotTable := r.OracleTablesByID[test.table.ID]
+				require.NotNil(gotTable)
+				assert.Equal(test.table, *gotTable)
+				assert.Len(r.OracleTablesByRoom[test.table.RoomID], 1)
+			}
+		})
+	}
+}
+
+func TestOracleTableRepositoryGetOracleTableByName(t *testing.T) {
+	tests := map[string]struct {
+		roomID   string
+		name     string
+		expTable *model.OracleTable
+		expErr   error
+	}{
+		"Getting a table of another room should return a missing error.": {
+			roomID: "room2-id",
+			name:   "Monsters",
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Getting a missing table should return a missing error.": {
+			roomID: "room-id",
+			name:   "Weather",
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Getting a table by name should return the room table.": {
+			roomID: "room-id",
+			name:   "Monsters",
+			expTable: func() *model.OracleTable {
+				ot := getOracleTable("table2-id", "room-id", "Monsters")
+				return &ot
+			}(),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			r := memory.NewOracleTableRepository()
+			require.NoError(r.CreateOracleTable(context.TODO(), getOracleTable("table1-id", "room-id", "Encounters")))
+			require.NoError(r.CreateOracleTable(context.TODO(), getOracleTable("table2-id", "room-id", "Monsters")))
+
+			gotTable, err := r.GetOracleTableByName(context.TODO(), test.roomID, test.name)
+
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				assert.Equal(test.expTable, gotTable)
+			}
+		})
+	}
+}
+
+func TestOracleTableRepositoryListRoomOracleTables(t *testing.T) {
+	tests := map[string]struct {
+		roomID  string
+		expList *storage.OracleTableList
+	}{
+		"Listing the tables of a room without tables should return an empty list.": {
+			roomID:  "room2-id",
+			expList: &storage.OracleTableList{Items: []model.OracleTable{}},
+		},
+
+		"Listing the tables of a room should return the room tables in creation order.": {
+			roomID: "room-id",
+			expList: &storage.OracleTableList{Items: []model.OracleTable{
+				getOracleTable("table1-id", "room-id", "Encounters"),
+				getOracleTable("table3-id", "room-id", "Monsters"),
+			}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			r := memory.NewOracleTableRepository()
+			require.NoError(r.CreateOracleTable(context.TODO(), getOracleTable("table1-id", "room-id", "Encounters")))
+			require.NoError(r.CreateOracleTable(context.TODO(), getOracleTable("table2-id", "room1-id", "Encounters")))
+			require.NoError(r.CreateOracleTable(context.TODO(), getOracleTable("table3-id", "room-id", "Monsters")))
+
+			gotList, err := r.ListRoomOracleTables(context.TODO(), test.roomID)
+			if assert.NoError(err) {
+				assert.Equal(test.expList, gotList)
+			}
+		})
+	}
+}
+
+func TestOracleTableRepositoryListRoomOracleTableRolls(t *testing.T) {
+	tests := map[string]struct {
+		roomID  string
+		limit   int
+		expList *storage.OracleTableRollList
+	}{
+		"Listing the rolls of a room without rolls should return an empty list.": {
+			roomID:  "room2-id",
+			expList: &storage.OracleTableRollList{Items: []model.OracleTableRoll{}},
+		},
+
+		"Listing the rolls of a room should return the room rolls newest first.": {
+			roomID: "room-id",
+			expList: &storage.OracleTableRollList{Items: []model.OracleTableRoll{
+				getOracleTableRoll("roll4-id", "room-id"),
+				getOracleTableRoll("roll3-id", "room-id"),
+				getOracleTableRoll("roll1-id", "room-id"),
+			}},
+		},
+
+		"Listing the rolls of a room with a limit should return the latest room rolls.": {
+			roomID: "room-id",
+			limit:  2,
+			expList: &storage.OracleTableRollList{Items: []model.OracleTableRoll{
+				getOracleTableRoll("roll4-id", "room-id"),
+				getOracleTableRoll("roll3-id", "room-id"),
+			}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			r := memory.NewOracleTableRepository()
+			require.NoError(r.CreateOracleTableRoll(context.TODO(), getOracleTableRoll("roll1-id", "room-id")))
+			require.NoError(r.CreateOracleTableRoll(context.TODO(), getOracleTableRoll("roll2-id", "room1-id")))
+			require.NoError(r.CreateOracleTableRoll(context.TODO(), getOracleTableRoll("roll3-id", "room-id")))
+			require.NoError(r.CreateOracleTableRoll(context.TODO(), getOracleTableRoll("roll4-id", "room-id")))
+
+			gotList, err := r.ListRoomOracleTableRolls(context.TODO(), test.roomID, test.limit)
+			if assert.NoError(err) {
+				assert.Equal(test.expList, gotList)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package memory
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/rollify/rollify/internal/internalerrors"
@@ -16,6 +17,16 @@ import (
 type RoomRepository struct {
 	// RoomsByID is where the room data is stored by ID. Not thread safe.
 	RoomsByID map[string]*model.Room
+	// The room data repositories are optional, if set, the room data is deleted from them
+	// when the rooms are deleted.
+	UserRepository          *UserRepository
+	DiceRollRepository      *DiceRollRepository
+	CustomDieTypeRepository *CustomDieTypeRepository
+	ServerSeedRepository    *ServerSeedRepository
+	MacroRepository         *MacroRepository
+	InitiativeRepository    *InitiativeRepository
+	DeckRepository          *DeckRepository
+	OracleTableRepository   *OracleTableRepository
 
 	mu sync.Mutex
 }
@@ -68,5 +79,122 @@ func (r *RoomRepository) RoomExists(_ context.Context, id string) (exists bool,
 	return ok, nil
 }
 
+// DeleteRoom satisfies room.Repository interface.
+func (r *RoomRepository) DeleteRoom(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.RoomsByID[id]
+	if !ok {
+		return internalerrors.ErrMissing
+	}
+
+	delete(r.RoomsByID, id)
+
+	// Cascade.
+	if r.UserRepository != nil {
+		r.UserRepository.deleteRoomUsers(id)
+	}
+	if r.DiceRollRepository != nil {
+		r.DiceRollRepository.deleteRoomDiceRolls(id)
+	}
+	if r.CustomDieTypeRepository != nil {
+		r.CustomDieTypeRepository.deleteRoomCustomDieTypes(id)
+	}
+	if r.ServerSeedRepository != nil {
+		r.ServerSeedRepository.deleteRoomServerSeeds(id)
+	}
+	if r.MacroRepository != nil {
+		r.MacroRepository.deleteRoomMacros(id)
+	}
+	if r.InitiativeRepository != nil {
+		r.InitiativeRepository.deleteRoomInitiative(id)
+	}
+	if r.DeckRepository != nil {
+		r.DeckRepository.deleteRoomDecks(id)
+	}
+	if r.OracleTableRepository != nil {
+		r.OracleTableRepository.deleteRoomOracleTables(id)
+	}
+
+	return nil
+}
+
+// ArchiveRoom satisfies room.Repository interface.
+func (r *RoomRepository) ArchiveRoom(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	room, ok := r.RoomsByID[id]
+	if !ok || room.Archived {
+		return internalerrors.ErrMissing
+	}
+
+	// Store a copy so we don't modify the rooms already returned.
+	archived := *room
+	archived.Archived = true
+	r.RoomsByID[id] = &archived
+
+	return nil
+}
+
+// CreateRoomInviteToken satisfies room.Repository interface.
+func (r *RoomRepository) CreateRoomInviteToken(_ context.Context, roomID string, t model.RoomInviteToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	room, ok := r.RoomsByID[roomID]
+	if !ok {
+		return internalerrors.ErrMissing
+	}
+
+	if slices.ContainsFunc(room.InviteTokens, func(it model.RoomInviteToken) bool { return it.Token == t.Token }) {
+		return internalerrors.ErrAlreadyExists
+	}
+
+	// Store a copy so we don't modify the rooms already returned.
+	updated := *room
+	updated.InviteTokens = append(slices.Clip(room.InviteTokens), t)
+	r.RoomsByID[roomID] = &updated
+
+	return nil
+}
+
+// DeleteRoomInviteToken satisfies room.Repository interface.
+func (r *RoomRepository) DeleteRoomInviteToken(_ context.Context, roomID, token string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	room, ok := r.RoomsByID[roomID]
+	if !ok {
+		return internalerrors.ErrMissing
+	}
+
+	tokens := slices.DeleteFunc(slices.Clone(room.InviteTokens), func(it model.RoomInviteToken) bool { return it.Token == token })
+	if len(tokens) == len(room.InviteTokens) {
+		return internalerrors.ErrMissing
+	}
+
+	// Store a copy so we don't modify the rooms already returned.
+	updated := *room
+	updated.InviteTokens = tokens
+	r.RoomsByID[roomID] = &updated
+
+	return nil
+}
+
+// ListRoomInviteTokens satisfies room.Repository interface.
+func (r *RoomRepository) ListRoomInviteTokens(_ context.Context, roomID string) ([]model.RoomInviteToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	room, ok := r.RoomsByID[roomID]
+	if !ok {
+		return []model.RoomInviteToken{}, nil
+	}
+
+	return append([]model.RoomInviteToken{}, room.InviteTokens...), nil
+}
+
 // Implementation assertions.
 var _ storage.RoomRepository = &RoomRepository{}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/rollify/rollify/internal/internalerrors"
 	"github.com/rollify/rollify/internal/model"
@@ -171,3 +173,364 @@ func TestRoomRepositoryRoomExists(t *testing.T) {
 		})
 	}
 }
+
+func TestRoomRepositoryDeleteRoom(t *testing.T) {
+	tests := map[string]struct {
+		repo         func() *memory.RoomRepository
+		roomID       string
+		expRooms     map[string]*model.Room
+		expUsers     map[string]*model.User
+		expDiceRolls map[string]*model.DiceRoll
+		expErr       error
+	}{
+		"Deleting a missing room should return a missing error.": {
+			repo: func() *memory.RoomRepository {
+				return memory.NewRoomRepository()
+			},
+			roomID: "test-id",
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Deleting a room should delete the room with its users and dice rolls.": {
+			repo: func() *memory.RoomRepository {
+				ur := memory.NewUserRepository()
+				ur.UsersByID = map[string]*model.User{
+					"user-1": {ID: "user-1", RoomID: "test-id"},
+					"user-2": {ID: "user-2", RoomID: "other-id"},
+				}
+				ur.UsersByRoom = map[string]map[string]*model.User{
+					"test-id":  {"user-1": ur.UsersByID["user-1"]},
+					"other-id": {"user-2": ur.UsersByID["user-2"]},
+				}
+
+				dr := memory.NewDiceRollRepository()
+				dr.DiceRollsByID = map[string]*model.DiceRoll{
+					"dice-roll-1": {ID: "dice-roll-1", RoomID: "test-id", UserID: "user-1"},
+					"dice-roll-2": {ID: "dice-roll-2", RoomID: "other-id", UserID: "user-2"},
+				}
+				dr.DiceRollsByRoom = map[string][]*model.DiceRoll{
+					"test-id":  {dr.DiceRollsByID["dice-roll-1"]},
+					"other-id": {dr.DiceRollsByID["dice-roll-2"]},
+				}
+				dr.DiceRollsByRoomAndUser = map[string][]*model.DiceRoll{
+					"test-iduser-1":  {dr.DiceRollsByID["dice-roll-1"]},
+					"other-iduser-2": {dr.DiceRollsByID["dice-roll-2"]},
+				}
+
+				r := memory.NewRoomRepository()
+				r.UserRepository = ur
+				r.DiceRollRepository = dr
+				r.RoomsByID = map[string]*model.Room{
+					"test-id":  {ID: "test-id", Name: "test"},
+					"other-id": {ID: "other-id", Name: "other"},
+				}
+				return r
+			},
+			roomID: "test-id",
+			expRooms: map[string]*model.Room{
+				"other-id": {ID: "other-id", Name: "other"},
+			},
+			expUsers: map[string]*model.User{
+				"user-2": {ID: "user-2", RoomID: "other-id"},
+			},
+			expDiceRolls: map[string]*model.DiceRoll{
+				"dice-roll-2": {ID: "dice-roll-2", RoomID: "other-id", UserID: "user-2"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			r := test.repo()
+			err := r.DeleteRoom(context.TODO(), test.roomID)
+
+			if test.expErr != nil {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				assert.Equal(test.expRooms, r.RoomsByID)
+				assert.Equal(test.expUsers, r.UserRepository.UsersByID)
+				assert.NotContains(r.UserRepository.UsersByRoom, test.roomID)
+				assert.Equal(test.expDiceRolls, r.DiceRollRepository.DiceRollsByID)
+				assert.NotContains(r.DiceRollRepository.DiceRollsByRoom, test.roomID)
+			}
+		})
+	}
+}
+
+func TestRoomRepositoryDeleteRoomData(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.TODO()
+
+	r := memory.NewRoomRepository()
+	r.CustomDieTypeRepository = memory.NewCustomDieTypeRepository()
+	r.ServerSeedRepository = memory.NewServerSeedRepository()
+	r.MacroRepository = memory.NewMacroRepository()
+	r.InitiativeRepository = memory.NewInitiativeRepository()
+	r.DeckRepository = memory.NewDeckRepository()
+	r.OracleTableRepository = memory.NewOracleTableRepository()
+
+	// Store the same data on the deleted room and on another room that should keep it.
+	for _, roomID := range []string{"test-id", "other-id"} {
+		require.NoError(r.CreateRoom(ctx, model.Room{ID: roomID}))
+		require.NoError(r.CustomDieTypeRepository.CreateCustomDieType(ctx, model.CustomDieType{TypeID: roomID + "-coin", RoomID: roomID, TypeName: "coin", FaceTable: []model.DieFace{{Value: 0}, {Value: 1}}}))
+		require.NoError(r.ServerSeedRepository.CreateServerSeed(ctx, model.ServerSeed{ID: roomID + "-seed", RoomID: roomID, Seed: "seed", Hash: "hash"}))
+		require.NoError(r.MacroRepository.CreateMacro(ctx, model.Macro{ID: roomID + "-macro", RoomID: roomID, UserID: "user", Name: "Attack", Expression: "1d20"}))
+		require.NoError(r.InitiativeRepository.SaveInitiative(ctx, model.Initiative{RoomID: roomID}))
+		require.NoError(r.DeckRepository.CreateDeck(ctx, model.Deck{ID: roomID + "-deck", RoomID: roomID, Name: "Cards", Cards: []string{"A"}}))
+		require.NoError(r.OracleTableRepository.CreateOracleTable(ctx, model.OracleTable{ID: roomID + "-table", RoomID: roomID, Name: "Weather", Dice: "1d6", Entries: []model.OracleTableEntry{{Min: 1, Max: 6, Result: "Sun"}}}))
+		require.NoError(r.OracleTableRepository.CreateOracleTableRoll(ctx, model.OracleTableRoll{ID: roomID + "-roll", RoomID: roomID, TableID: roomID + "-table"}))
+	}
+
+	err := r.DeleteRoom(ctx, "test-id")
+	require.NoError(err)
+
+	// The deleted room data is gone.
+	dts, err := r.CustomDieTypeRepository.ListRoomCustomDieTypes(ctx, "test-id")
+	require.NoError(err)
+	assert.Empty(dts.Items)
+	assert.NotContains(r.CustomDieTypeRepository.CustomDieTypesByID, "test-id-coin")
+	assert.NotContains(r.ServerSeedRepository.ServerSeedsByID, "test-id-seed")
+	assert.NotContains(r.ServerSeedRepository.ServerSeedsByRoom, "test-id")
+	assert.NotContains(r.MacroRepository.MacrosByID, "test-id-macro")
+	assert.NotContains(r.MacroRepository.MacrosByRoom, "test-id")
+	assert.NotContains(r.InitiativeRepository.InitiativesByRoom, "test-id")
+	assert.NotContains(r.DeckRepository.DecksByID, "test-id-deck")
+	assert.NotContains(r.DeckRepository.DecksByRoom, "test-id")
+	assert.NotContains(r.OracleTableRepository.OracleTablesByID, "test-id-table")
+	assert.NotContains(r.OracleTableRepository.OracleTablesByRoom, "test-id")
+	assert.NotContains(r.OracleTableRepository.OracleTableRollsByRoom, "test-id")
+
+	// The other room data is kept.
+	assert.Contains(r.CustomDieTypeRepository.CustomDieTypesByID, "other-id-coin")
+	assert.Contains(r.ServerSeedRepository.ServerSeedsByID, "other-id-seed")
+	assert.Contains(r.MacroRepository.MacrosByID, "other-id-macro")
+	assert.Contains(r.InitiativeRepository.InitiativesByRoom, "other-id")
+	assert.Contains(r.DeckRepository.DecksByID, "other-id-deck")
+	assert.Contains(r.OracleTableRepository.OracleTablesByID, "other-id-table")
+	assert.Contains(r.OracleTableRepository.OracleTableRollsByRoom, "other-id")
+}
+func TestRoomRepositoryArchiveRoom(t *testing.T) {
+	tests := map[string]struct {
+		repo    func() *memory.RoomRepository
+		roomID  string
+		expRoom *model.Room
+		expErr  error
+	}{
+		"Archiving a missing room should return a missing error.": {
+			repo: func() *memory.RoomRepository {
+				return memory.NewRoomRepository()
+			},
+			roomID: "test-id",
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Archiving an already archived room should return a missing error.": {
+			repo: func() *memory.RoomRepository {
+				r := memory.NewRoomRepository()
+				r.RoomsByID = map[string]*model.Room{
+					"test-id": {ID: "test-id", Name: "test", Archived: true},
+				}
+				return r
+			},
+			roomID: "test-id",
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Archiving a room should mark the room as archived.": {
+			repo: func() *memory.RoomRepository {
+				r := memory.NewRoomRepository()
+				r.RoomsByID = map[string]*model.Room{
+					"test-id": {ID: "test-id", Name: "test"},
+				}
+				return r
+			},
+			roomID:  "test-id",
+			expRoom: &model.Room{ID: "test-id", Name: "test", Archived: true},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			r := test.repo()
+			err := r.ArchiveRoom(context.TODO(), test.roomID)
+
+			if test.expErr != nil {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				assert.Equal(test.expRoom, r.RoomsByID[test.roomID])
+			}
+		})
+	}
+}
+
+func TestRoomRepositoryCreateRoomInviteToken(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+
+	tests := map[string]struct {
+		repo    func() *memory.RoomRepository
+		roomID  string
+		token   model.RoomInviteToken
+		expRoom *model.Room
+		expErr  error
+	}{
+		"Adding a token to a missing room should return a missing error.": {
+			repo: func() *memory.RoomRepository {
+				return memory.NewRoomRepository()
+			},
+			roomID: "test-id",
+			token:  model.RoomInviteToken{Token: "token1", CreatedAt: t0},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Adding an existing token should return an already exists error.": {
+			repo: func() *memory.RoomRepository {
+				r := memory.NewRoomRepository()
+				r.RoomsByID = map[string]*model.Room{
+					"test-id": {ID: "test-id", InviteTokens: []model.RoomInviteToken{{Token: "token1"}}},
+				}
+				return r
+			},
+			roomID: "test-id",
+			token:  model.RoomInviteToken{Token: "token1", CreatedAt: t0},
+			expErr: internalerrors.ErrAlreadyExists,
+		},
+
+		"Adding a token should add the token to the room.": {
+			repo: func() *memory.RoomRepository {
+				r := memory.NewRoomRepository()
+				r.RoomsByID = map[string]*model.Room{
+					"test-id": {ID: "test-id", InviteTokens: []model.RoomInviteToken{{Token: "token0"}}},
+				}
+				return r
+			},
+			roomID: "test-id",
+			token:  model.RoomInviteToken{Token: "token1", CreatedAt: t0},
+			expRoom: &model.Room{ID: "test-id", InviteTokens: []model.RoomInviteToken{
+				{Token: "token0"},
+				{Token: "token1", CreatedAt: t0},
+			}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			r := test.repo()
+			err := r.CreateRoomInviteToken(context.TODO(), test.roomID, test.token)
+
+			if test.expErr != nil {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				assert.Equal(test.expRoom, r.RoomsByID[test.roomID])
+			}
+		})
+	}
+}
+
+func TestRoomRepositoryDeleteRoomInviteToken(t *testing.T) {
+	tests := map[string]struct {
+		repo    func() *memory.RoomRepository
+		roomID  string
+		token   string
+		expRoom *model.Room
+		expErr  error
+	}{
+		"Revoking a token of a missing room should return a missing error.": {
+			repo: func() *memory.RoomRepository {
+				return memory.NewRoomRepository()
+			},
+			roomID: "test-id",
+			token:  "token1",
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Revoking a missing token should return a missing error.": {
+			repo: func() *memory.RoomRepository {
+				r := memory.NewRoomRepository()
+				r.RoomsByID = map[string]*model.Room{
+					"test-id": {ID: "test-id", InviteTokens: []model.RoomInviteToken{{Token: "token0"}}},
+				}
+				return r
+			},
+			roomID: "test-id",
+			token:  "token1",
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Revoking a token should remove the token from the room.": {
+			repo: func() *memory.RoomRepository {
+				r := memory.NewRoomRepository()
+				r.RoomsByID = map[string]*model.Room{
+					"test-id": {ID: "test-id", InviteTokens: []model.RoomInviteToken{{Token: "token0"}, {Token: "token1"}}},
+				}
+				return r
+			},
+			roomID:  "test-id",
+			token:   "token1",
+			expRoom: &model.Room{ID: "test-id", InviteTokens: []model.RoomInviteToken{{Token: "token0"}}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			r := test.repo()
+			err := r.DeleteRoomInviteToken(context.TODO(), test.roomID, test.token)
+
+			if test.expErr != nil {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				assert.Equal(test.expRoom, r.RoomsByID[test.roomID])
+			}
+		})
+	}
+}
+
+func TestRoomRepositoryListRoomInviteTokens(t *testing.T) {
+	tests := map[string]struct {
+		repo      func() *memory.RoomRepository
+		roomID    string
+		expTokens []model.RoomInviteToken
+	}{
+		"Listing the tokens of a missing room should return an empty list.": {
+			repo: func() *memory.RoomRepository {
+				return memory.NewRoomRepository()
+			},
+			roomID:    "test-id",
+			expTokens: []model.RoomInviteToken{},
+		},
+
+		"Listing the tokens of a room should return the room tokens.": {
+			repo: func() *memory.RoomRepository {
+				r := memory.NewRoomRepository()
+				r.RoomsByID = map[string]*model.Room{
+					"test-id":  {ID: "test-id", InviteTokens: []model.RoomInviteToken{{Token: "token0"}, {Token: "token1"}}},
+					"test-id2": {ID: "test-id2", InviteTokens: []model.RoomInviteToken{{Token: "token2"}}},
+				}
+				return r
+			},
+			roomID:    "test-id",
+			expTokens: []model.RoomInviteToken{{Token: "token0"}, {Token: "token1"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			r := test.repo()
+			gotTokens, err := r.ListRoomInviteTokens(context.TODO(), test.roomID)
+
+			if assert.NoError(err) {
+				assert.Equal(test.expTokens, gotTokens)
+			}
+		})
+	}
+}
@@ -123,5 +123,16 @@ func (r *UserRepository) GetUserByNameInsensitive(ctx context.Context, roomID, u
 	return nil, internalerrors.ErrMissing
 }
 
+// deleteRoomUsers deletes all the users of a room.
+func (r *UserRepository) deleteRoomUsers(roomID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id := range r.UsersByRoom[roomID] {
+		delete(r.UsersByID, id)
+	}
+	delete(r.UsersByRoom, roomID)
+}
+
 // Implementation assertions.
 var _ storage.UserRepository = &UserRepository{}
@@ -37,6 +37,14 @@ func (m measuredDiceRollRepository) CreateDiceRoll(ctx context.Context, dr model
 	return m.next.CreateDiceRoll(ctx, dr)
 }
 
+func (m measuredDiceRollRepository) CreateDiceRolls(ctx context.Context, drs []model.DiceRoll) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureDiceRollRepoOpDuration(ctx, m.storageType, "CreateDiceRolls", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.CreateDiceRolls(ctx, drs)
+}
+
 func (m measuredDiceRollRepository) ListDiceRolls(ctx context.Context, pageOpts model.PaginationOpts, filterOpts ListDiceRollsOpts) (resp *DiceRollList, err error) {
 	defer func(t0 time.Time) {
 		m.rec.MeasureDiceRollRepoOpDuration(ctx, m.storageType, "ListDiceRolls", err == nil, time.Since(t0))
@@ -45,6 +53,22 @@ func (m measuredDiceRollRepository) ListDiceRolls(ctx context.Context, pageOpts
 	return m.next.ListDiceRolls(ctx, pageOpts, filterOpts)
 }
 
+func (m measuredDiceRollRepository) GetDiceRoll(ctx context.Context, id string) (dr *model.DiceRoll, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureDiceRollRepoOpDuration(ctx, m.storageType, "GetDiceRoll", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.GetDiceRoll(ctx, id)
+}
+
+func (m measuredDiceRollRepository) ListDieRollSides(ctx context.Context, opts DieRollStatsOpts) (resp []DieSide, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureDiceRollRepoOpDuration(ctx, m.storageType, "ListDieRollSides", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.ListDieRollSides(ctx, opts)
+}
+
 // RoomRepositoryMetricsRecorder knows how to measure RoomRepository.
 type RoomRepositoryMetricsRecorder interface {
 	MeasureRoomRepoOpDuration(ctx context.Context, storageType, op string, success bool, t time.Duration)
@@ -91,6 +115,46 @@ func (m measuredRoomRepository) RoomExists(ctx context.Context, id string) (exis
 	return m.next.RoomExists(ctx, id)
 }
 
+func (m measuredRoomRepository) DeleteRoom(ctx context.Context, id string) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureRoomRepoOpDuration(ctx, m.storageType, "DeleteRoom", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.DeleteRoom(ctx, id)
+}
+
+func (m measuredRoomRepository) ArchiveRoom(ctx context.Context, id string) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureRoomRepoOpDuration(ctx, m.storageType, "ArchiveRoom", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.ArchiveRoom(ctx, id)
+}
+
+func (m measuredRoomRepository) CreateRoomInviteToken(ctx context.Context, roomID string, tk model.RoomInviteToken) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureRoomRepoOpDuration(ctx, m.storageType, "CreateRoomInviteToken", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.CreateRoomInviteToken(ctx, roomID, tk)
+}
+
+func (m measuredRoomRepository) DeleteRoomInviteToken(ctx context.Context, roomID, token string) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureRoomRepoOpDuration(ctx, m.storageType, "DeleteRoomInviteToken", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.DeleteRoomInviteToken(ctx, roomID, token)
+}
+
+func (m measuredRoomRepository) ListRoomInviteTokens(ctx context.Context, roomID string) (tks []model.RoomInviteToken, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureRoomRepoOpDuration(ctx, m.storageType, "ListRoomInviteTokens", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.ListRoomInviteTokens(ctx, roomID)
+}
+
 // UserRepositoryMetricsRecorder knows how to measure UserRepository.
 type UserRepositoryMetricsRecorder interface {
 	MeasureUserRepoOpDuration(ctx context.Context, storageType, op string, success bool, t time.Duration)
@@ -160,3 +224,335 @@ func (m measuredUserRepository) GetUserByNameInsensitive(ctx context.Context, ro
 
 	return m.next.GetUserByNameInsensitive(ctx, roomID, username)
 }
+
+// CustomDieTypeRepositoryMetricsRecorder knows how to measure CustomDieTypeRepository.
+type CustomDieTypeRepositoryMetricsRecorder interface {
+	MeasureCustomDieTypeRepoOpDuration(ctx context.Context, storageType, op string, success bool, t time.Duration)
+}
+
+//go:generate mockery --case underscore --output storagemock --outpkg storagemock --name CustomDieTypeRepositoryMetricsRecorder
+
+type measuredCustomDieTypeRepository struct {
+	storageType string
+	rec         CustomDieTypeRepositoryMetricsRecorder
+	next        CustomDieTypeRepository
+}
+
+// NewMeasuredCustomDieTypeRepository wraps a CustomDieTypeRepository and measures.
+func NewMeasuredCustomDieTypeRepository(storageType string, rec CustomDieTypeRepositoryMetricsRecorder, next CustomDieTypeRepository) CustomDieTypeRepository {
+	return &measuredCustomDieTypeRepository{
+		storageType: storageType,
+		rec:         rec,
+		next:        next,
+	}
+}
+
+func (m measuredCustomDieTypeRepository) CreateCustomDieType(ctx context.Context, dt model.CustomDieType) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureCustomDieTypeRepoOpDuration(ctx, m.storageType, "CreateCustomDieType", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.CreateCustomDieType(ctx, dt)
+}
+
+func (m measuredCustomDieTypeRepository) ListRoomCustomDieTypes(ctx context.Context, roomID string) (l *CustomDieTypeList, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureCustomDieTypeRepoOpDuration(ctx, m.storageType, "ListRoomCustomDieTypes", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.ListRoomCustomDieTypes(ctx, roomID)
+}
+
+// ServerSeedRepositoryMetricsRecorder knows how to measure ServerSeedRepository.
+type ServerSeedRepositoryMetricsRecorder interface {
+	MeasureServerSeedRepoOpDuration(ctx context.Context, storageType, op string, success bool, t time.Duration)
+}
+
+//go:generate mockery --case underscore --output storagemock --outpkg storagemock --name ServerSeedRepositoryMetricsRecorder
+
+type measuredServerSeedRepository struct {
+	storageType string
+	rec         ServerSeedRepositoryMetricsRecorder
+	next        ServerSeedRepository
+}
+
+// NewMeasuredServerSeedRepository wraps a ServerSeedRepository and measures.
+func NewMeasuredServerSeedRepository(storageType string, rec ServerSeedRepositoryMetricsRecorder, next ServerSeedRepository) ServerSeedRepository {
+	return &measuredServerSeedRepository{
+		storageType: storageType,
+		rec:         rec,
+		next:        next,
+	}
+}
+
+func (m measuredServerSeedRepository) CreateServerSeed(ctx context.Context, s model.ServerSeed) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureServerSeedRepoOpDuration(ctx, m.storageType, "CreateServerSeed", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.CreateServerSeed(ctx, s)
+}
+
+func (m measuredServerSeedRepository) GetServerSeed(ctx context.Context, id string) (s *model.ServerSeed, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureServerSeedRepoOpDuration(ctx, m.storageType, "GetServerSeed", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.GetServerSeed(ctx, id)
+}
+
+func (m measuredServerSeedRepository) GetRoomActiveServerSeed(ctx context.Context, roomID string) (s *model.ServerSeed, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureServerSeedRepoOpDuration(ctx, m.storageType, "GetRoomActiveServerSeed", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.GetRoomActiveServerSeed(ctx, roomID)
+}
+
+func (m measuredServerSeedRepository) IncreaseServerSeedNonce(ctx context.Context, id string) (nonce uint, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureServerSeedRepoOpDuration(ctx, m.storageType, "IncreaseServerSeedNonce", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.IncreaseServerSeedNonce(ctx, id)
+}
+
+func (m measuredServerSeedRepository) RevealServerSeed(ctx context.Context, id string) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureServerSeedRepoOpDuration(ctx, m.storageType, "RevealServerSeed", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.RevealServerSeed(ctx, id)
+}
+
+// MacroRepositoryMetricsRecorder knows how to measure MacroRepository.
+type MacroRepositoryMetricsRecorder interface {
+	MeasureMacroRepoOpDuration(ctx context.Context, storageType, op string, success bool, t time.Duration)
+}
+
+//go:generate mockery --case underscore --output storagemock --outpkg storagemock --name MacroRepositoryMetricsRecorder
+
+type measuredMacroRepository struct {
+	storageType string
+	rec         MacroRepositoryMetricsRecorder
+	next        MacroRepository
+}
+
+// NewMeasuredMacroRepository wraps a MacroRepository and measures.
+func NewMeasuredMacroRepository(storageType string, rec MacroRepositoryMetricsRecorder, next MacroRepository) MacroRepository {
+	return &measuredMacroRepository{
+		storageType: storageType,
+		rec:         rec,
+		next:        next,
+	}
+}
+
+func (m measuredMacroRepository) CreateMacro(ctx context.Context, mc model.Macro) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureMacroRepoOpDuration(ctx, m.storageType, "CreateMacro", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.CreateMacro(ctx, mc)
+}
+
+func (m measuredMacroRepository) GetMacro(ctx context.Context, id string) (mc *model.Macro, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureMacroRepoOpDuration(ctx, m.storageType, "GetMacro", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.GetMacro(ctx, id)
+}
+
+func (m measuredMacroRepository) ListMacros(ctx context.Context, filterOpts ListMacrosOpts) (l *MacroList, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureMacroRepoOpDuration(ctx, m.storageType, "ListMacros", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.ListMacros(ctx, filterOpts)
+}
+
+func (m measuredMacroRepository) UpdateMacro(ctx context.Context, mc model.Macro) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureMacroRepoOpDuration(ctx, m.storageType, "UpdateMacro", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.UpdateMacro(ctx, mc)
+}
+
+func (m measuredMacroRepository) DeleteMacro(ctx context.Context, id string) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureMacroRepoOpDuration(ctx, m.storageType, "DeleteMacro", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.DeleteMacro(ctx, id)
+}
+
+// InitiativeRepositoryMetricsRecorder knows how to measure InitiativeRepository.
+type InitiativeRepositoryMetricsRecorder interface {
+	MeasureInitiativeRepoOpDuration(ctx context.Context, storageType, op string, success bool, t time.Duration)
+}
+
+//go:generate mockery --case underscore --output storagemock --outpkg storagemock --name InitiativeRepositoryMetricsRecorder
+
+type measuredInitiativeRepository struct {
+	storageType string
+	rec         InitiativeRepositoryMetricsRecorder
+	next        InitiativeRepository
+}
+
+// NewMeasuredInitiativeRepository wraps a InitiativeRepository and measures.
+func NewMeasuredInitiativeRepository(storageType string, rec InitiativeRepositoryMetricsRecorder, next InitiativeRepository) InitiativeRepository {
+	return &measuredInitiativeRepository{
+		storageType: storageType,
+		rec:         rec,
+		next:        next,
+	}
+}
+
+func (m measuredInitiativeRepository) GetInitiative(ctx context.Context, roomID string) (i *model.Initiative, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureInitiativeRepoOpDuration(ctx, m.storageType, "GetInitiative", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.GetInitiative(ctx, roomID)
+}
+
+func (m measuredInitiativeRepository) SaveInitiative(ctx context.Context, i model.Initiative) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureInitiativeRepoOpDuration(ctx, m.storageType, "SaveInitiative", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.SaveInitiative(ctx, i)
+}
+
+func (m measuredInitiativeRepository) DeleteInitiative(ctx context.Context, roomID string) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureInitiativeRepoOpDuration(ctx, m.storageType, "DeleteInitiative", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.DeleteInitiative(ctx, roomID)
+}
+
+// DeckRepositoryMetricsRecorder knows how to measure DeckRepository.
+type DeckRepositoryMetricsRecorder interface {
+	MeasureDeckRepoOpDuration(ctx context.Context, storageType, op string, success bool, t time.Duration)
+}
+
+//go:generate mockery --case underscore --output storagemock --outpkg storagemock --name DeckRepositoryMetricsRecorder
+
+type measuredDeckRepository struct {
+	storageType string
+	rec         DeckRepositoryMetricsRecorder
+	next        DeckRepository
+}
+
+// NewMeasuredDeckRepository wraps a DeckRepository and measures.
+func NewMeasuredDeckRepository(storageType string, rec DeckRepositoryMetricsRecorder, next DeckRepository) DeckRepository {
+	return &measuredDeckRepository{
+		storageType: storageType,
+		rec:         rec,
+		next:        next,
+	}
+}
+
+func (m measuredDeckRepository) CreateDeck(ctx context.Context, d model.Deck) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureDeckRepoOpDuration(ctx, m.storageType, "CreateDeck", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.CreateDeck(ctx, d)
+}
+
+func (m measuredDeckRepository) GetDeck(ctx context.Context, id string) (d *model.Deck, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureDeckRepoOpDuration(ctx, m.storageType, "GetDeck", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.GetDeck(ctx, id)
+}
+
+func (m measuredDeckRepository) ListRoomDecks(ctx context.Context, roomID string) (l *DeckList, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureDeckRepoOpDuration(ctx, m.storageType, "ListRoomDecks", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.ListRoomDecks(ctx, roomID)
+}
+
+func (m measuredDeckRepository) UpdateDeck(ctx context.Context, d model.Deck) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureDeckRepoOpDuration(ctx, m.storageType, "UpdateDeck", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.UpdateDeck(ctx, d)
+}
+
+// OracleTableRepositoryMetricsRecorder knows how to measure OracleTableRepository.
+type OracleTableRepositoryMetricsRecorder interface {
+	MeasureOracleTableRepoOpDuration(ctx context.Context, storageType, op string, success bool, t time.Duration)
+}
+
+//go:generate mockery --case underscore --output storagemock --outpkg storagemock --name OracleTableRepositoryMetricsRecorder
+
+type measuredOracleTableRepository struct {
+	storageType string
+	rec         OracleTableRepositoryMetricsRecorder
+	next        OracleTableRepository
+}
+
+// NewMeasuredOracleTableRepository wraps a OracleTableRepository and measures.
+func NewMeasuredOracleTableRepository(storageType string, rec OracleTableRepositoryMetricsRecorder, next OracleTableRepository) OracleTableRepository {
+	return &measuredOracleTableRepository{
+		storageType: storageType,
+		rec:         rec,
+		next:        next,
+	}
+}
+
+func (m measuredOracleTableRepository) CreateOracleTable(ctx context.Context, ot model.OracleTable) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureOracleTableRepoOpDuration(ctx, m.storageType, "CreateOracleTable", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.CreateOracleTable(ctx, ot)
+}
+
+func (m measuredOracleTableRepository) GetOracleTable(ctx context.Context, id string) (ot *model.OracleTable, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureOracleTableRepoOpDuration(ctx, m.storageType, "GetOracleTable", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.GetOracleTable(ctx, id)
+}
+
+func (m measuredOracleTableRepository) GetOracleTableByName(ctx context.Context, roomID, name string) (ot *model.OracleTable, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureOracleTableRepoOpDuration(ctx, m.storageType, "GetOracleTableByName", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.GetOracleTableByName(ctx, roomID, name)
+}
+
+func (m measuredOracleTableRepository) ListRoomOracleTables(ctx context.Context, roomID string) (l *OracleTableList, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureOracleTableRepoOpDuration(ctx, m.storageType, "ListRoomOracleTables", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.ListRoomOracleTables(ctx, roomID)
+}
+
+func (m measuredOracleTableRepository) CreateOracleTableRoll(ctx context.Context, r model.OracleTableRoll) (err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureOracleTableRepoOpDuration(ctx, m.storageType, "CreateOracleTableRoll", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.CreateOracleTableRoll(ctx, r)
+}
+
+func (m measuredOracleTableRepository) ListRoomOracleTableRolls(ctx context.Context, roomID string, limit int) (l *OracleTableRollList, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureOracleTableRepoOpDuration(ctx, m.storageType, "ListRoomOracleTableRolls", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.ListRoomOracleTableRolls(ctx, roomID, limit)
+}
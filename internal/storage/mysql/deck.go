@@ -0,0 +1,275 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/log"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+)
+
+// DeckRepositoryConfig is the DeckRepository configuration.
+type DeckRepositoryConfig struct {
+	DBClient DBClient
+	Table    string
+	Logger   log.Logger
+}
+
+func (c *DeckRepositoryConfig) defaults() error {
+	if c.DBClient == nil {
+		return fmt.Errorf("config.DBClient is required")
+	}
+
+	if c.Table == "" {
+		c.Table = "deck"
+	}
+
+	if c.Logger == nil {
+		c.Logger = log.Dummy
+	}
+
+	c.Logger = c.Logger.WithKV(log.KV{
+		"repository":      "deck",
+		"repository-type": "mysql",
+	})
+
+	return nil
+}
+
+// DeckRepository is a repository with MySQL implementation.
+type DeckRepository struct {
+	db     DBClient
+	table  string
+	logger log.Logger
+}
+
+// NewDeckRepository returns a new DeckRepository.
+func NewDeckRepository(cfg DeckRepositoryConfig) (*DeckRepository, error) {
+	err := cfg.defaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return &DeckRepository{
+		db:     cfg.DBClient,
+		table:  cfg.Table,
+		logger: cfg.Logger,
+	}, nil
+}
+
+// CreateDeck satisfies storage.DeckRepository interface.
+func (r *DeckRepository) CreateDeck(ctx context.Context, d model.Deck) error {
+	// Map and create query.
+	sd, err := modelToSQLDeck(d)
+	if err != nil {
+		return fmt.Errorf("could not map deck: %w", err)
+	}
+	query, args := deckSQLBuilder.InsertInto(r.table, sd).Build()
+
+	// Insert in database.
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return fmt.Errorf("%w: %s", internalerrors.ErrAlreadyExists, err)
+		}
+
+		return fmt.Errorf("could not insert deck: %w", err)
+	}
+
+	return nil
+}
+
+// GetDeck satisfies storage.DeckRepository interface.
+func (r *DeckRepository) GetDeck(ctx context.Context, id string) (*model.Deck, error) {
+	sb := deckSQLBuilder.SelectFrom(r.table)
+	sb.Where(sb.Equal("id", id))
+	query, args := sb.Build()
+
+	// Get from database.
+	row := r.db.QueryRowContext(ctx, query, args...)
+	sd := &sqlDeck{}
+	err := row.Scan(deckSQLBuilder.Addr(sd)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("missing deck: %w: %s", internalerrors.ErrMissing, err)
+		}
+
+		return nil, fmt.Errorf("could not get deck: %w", err)
+	}
+
+	d, err := sqlToModelDeck(sd)
+	if err != nil {
+		return nil, fmt.Errorf("could not map deck: %w", err)
+	}
+
+	return d, nil
+}
+
+// ListRoomDecks satisfies storage.DeckRepository interface.
+func (r *DeckRepository) ListRoomDecks(ctx context.Context, roomID string) (*storage.DeckList, error) {
+	sb := deckSQLBuilder.SelectFrom(r.table)
+	sb.Where(sb.Equal("room_id", roomID))
+	sb.OrderBy("serial ASC")
+	query, args := sb.Build()
+
+	// Get from database.
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("could not list decks: %w", err)
+	}
+	defer rows.Close()
+
+	ds := []model.Deck{}
+	sd := &sqlDeck{} // Reuse this, when mapping to model we will have a new instance.
+	for rows.Next() {
+		err := rows.Scan(deckSQLBuilder.Addr(sd)...)
+		if err != nil {
+			return nil, fmt.Errorf("could not scan SQL decks: %w", err)
+		}
+
+		d, err := sqlToModelDeck(sd)
+		if err != nil {
+			return nil, fmt.Errorf("could not map deck: %w", err)
+		}
+		ds = append(ds, *d)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("could not list decks: %w", err)
+	}
+
+	return &storage.DeckList{
+		Items: ds,
+	}, nil
+}
+
+// UpdateDeck satisfies storage.DeckRepository interface.
+func (r *DeckRepository) UpdateDeck(ctx context.Context, d model.Deck) error {
+	sd, err := modelToSQLDeck(d)
+	if err != nil {
+		return fmt.Errorf("could not map deck: %w", err)
+	}
+
+	// Only update if nobody updated the deck since it was read.
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(r.table).
+		Set(
+			ub.Assign("draw_pile", sd.DrawPile),
+			ub.Assign("discard_pile", sd.DiscardPile),
+			ub.Assign("version", d.Version+1),
+		).
+		Where(ub.Equal("id", d.ID), ub.Equal("version", d.Version))
+	query, args := ub.Build()
+
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not update deck: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get affected rows: %w", err)
+	}
+
+	// The version always changes, so if nothing was updated, the deck is missing or has been updated.
+	if n == 0 {
+		_, err := r.GetDeck(ctx, d.ID)
+		if err != nil {
+			return err
+		}
+
+		return fmt.Errorf("deck has been updated: %w", internalerrors.ErrConflict)
+	}
+
+	return nil
+}
+
+type sqlDeck struct {
+	ID        string    `db:"id"`
+	CreatedAt time.Time `db:"created_at"`
+	RoomID    string    `db:"room_id"`
+	Name      string    `db:"name"`
+	Type      string    `db:"type"`
+	// Cards, DrawPile and DiscardPile are the JSON encoded card lists.
+	Cards       string `db:"cards"`
+	DrawPile    string `db:"draw_pile"`
+	DiscardPile string `db:"discard_pile"`
+	Version     uint   `db:"version"`
+}
+
+func modelToSQLDeck(d model.Deck) (*sqlDeck, error) {
+	sd := &sqlDeck{
+		ID:        d.ID,
+		CreatedAt: d.CreatedAt,
+		RoomID:    d.RoomID,
+		Name:      d.Name,
+		Type:      string(d.Type),
+		Version:   d.Version,
+	}
+
+	for _, f := range []struct {
+		dst   *string
+		cards []string
+	}{
+		{dst: &sd.Cards, cards: d.Cards},
+		{dst: &sd.DrawPile, cards: d.DrawPile},
+		{dst: &sd.DiscardPile, cards: d.DiscardPile},
+	} {
+		cards := f.cards
+		if cards == nil {
+			cards = []string{}
+		}
+
+		bs, err := json.Marshal(cards)
+		if err != nil {
+			return nil, fmt.Errorf("could not marshal cards: %w", err)
+		}
+		*f.dst = string(bs)
+	}
+
+	return sd, nil
+}
+
+func sqlToModelDeck(sd *sqlDeck) (*model.Deck, error) {
+	d := &model.Deck{
+		ID:        sd.ID,
+		CreatedAt: sd.CreatedAt,
+		RoomID:    sd.RoomID,
+		Name:      sd.Name,
+		Type:      model.DeckType(sd.Type),
+		Version:   sd.Version,
+	}
+
+	for _, f := range []struct {
+		src string
+		dst *[]string
+	}{
+		{src: sd.Cards, dst: &d.Cards},
+		{src: sd.DrawPile, dst: &d.DrawPile},
+		{src: sd.DiscardPile, dst: &d.DiscardPile},
+	} {
+		cards := []string{}
+		err := json.Unmarshal([]byte(f.src), &cards)
+		if err != nil {
+			return nil, fmt.Errorf("could not unmarshal cards: %w", err)
+		}
+		*f.dst = cards
+	}
+
+	return d, nil
+}
+
+// Used as a light ORM by sqlbuilder.
+var deckSQLBuilder = sqlbuilder.NewStruct(&sqlDeck{})
+
+// Implementation assertions.
+var _ storage.DeckRepository = &DeckRepository{}
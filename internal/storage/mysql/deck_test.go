@@ -0,0 +1,284 @@
+package mysql_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	drivermysql "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+	"github.com/rollify/rollify/internal/storage/mysql"
+	"github.com/rollify/rollify/internal/storage/mysql/mysqlmock"
+)
+
+var deckColumns = []string{"id", "created_at", "room_id", "name", "type", "cards", "draw_pile", "discard_pile", "version"}
+
+func TestDeckRepositoryCreateDeck(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	wantedErr := fmt.Errorf("wanted error")
+	deck := model.Deck{ID: "deck-id", CreatedAt: t0, RoomID: "room-id", Name: "Action deck", Type: model.DeckTypeCustom, Cards: []string{"A", "B", "C"}, DrawPile: []string{"C", "A", "B"}}
+
+	tests := map[string]struct {
+		config mysql.DeckRepositoryConfig
+		mock   func(*mysqlmock.DBClient)
+		expErr error
+	}{
+		"Having an error while storing the deck, should error.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+			},
+			expErr: wantedErr,
+		},
+
+		"Creating the same deck when already exists, should error.": {
+			mock: func(m *mysqlmock.DBClient) {
+				err := &drivermysql.MySQLError{Number: 1062}
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, err)
+			},
+			expErr: internalerrors.ErrAlreadyExists,
+		},
+
+		"Creating a deck should store the deck.": {
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "INSERT INTO deck (id, created_at, room_id, name, type, cards, draw_pile, discard_pile, version) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "deck-id", t0, "room-id", "Action deck", "custom", `["A","B","C"]`, `["C","A","B"]`, "[]", uint(0)).Once().Return(nil, nil)
+			},
+		},
+
+		"Creating a deck in a custom table should store the deck.": {
+			config: mysql.DeckRepositoryConfig{
+				Table: "custom-table",
+			},
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "INSERT INTO custom-table (id, created_at, room_id, name, type, cards, draw_pile, discard_pile, version) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "deck-id", t0, "room-id", "Action deck", "custom", `["A","B","C"]`, `["C","A","B"]`, "[]", uint(0)).Once().Return(nil, nil)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			test.config.DBClient = mdb
+			r, err := mysql.NewDeckRepository(test.config)
+			require.NoError(err)
+			err = r.CreateDeck(context.TODO(), deck)
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestDeckRepositoryGetDeck(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	wantedErr := fmt.Errorf("wanted error")
+
+	tests := map[string]struct {
+		mock    func(*mysqlmock.DBClient)
+		expDeck *model.Deck
+		expErr  error
+	}{
+		"Having an error while retrieving the deck should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				row := sqlRowErr(wantedErr)
+				m.On("QueryRowContext", mock.Anything, mock.Anything, mock.Anything).Once().Return(row)
+			},
+			expErr: wantedErr,
+		},
+
+		"Retrieving a missing deck, should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				row := sqlRowErr(sql.ErrNoRows)
+				m.On("QueryRowContext", mock.Anything, mock.Anything, mock.Anything).Once().Return(row)
+			},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Retrieving a deck should get the deck with its piles.": {
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "SELECT deck.id, deck.created_at, deck.room_id, deck.name, deck.type, deck.cards, deck.draw_pile, deck.discard_pile, deck.version FROM deck WHERE id = ?"
+				row := sqlmockRowsToStdRow(sqlmock.NewRows(deckColumns).AddRow("deck-id", t0, "room-id", "Action deck", "custom", `["A","B","C"]`, `["C"]`, `["B"]`, 3))
+				m.On("QueryRowContext", mock.Anything, expQuery, "deck-id").Once().Return(row)
+			},
+			expDeck: &model.Deck{
+				ID:          "deck-id",
+				CreatedAt:   t0,
+				RoomID:      "room-id",
+				Name:        "Action deck",
+				Type:        model.DeckTypeCustom,
+				Cards:       []string{"A", "B", "C"},
+				DrawPile:    []string{"C"},
+				DiscardPile: []string{"B"},
+				Version:     3,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			r, err := mysql.NewDeckRepository(mysql.DeckRepositoryConfig{DBClient: mdb})
+			require.NoError(err)
+			gotDeck, err := r.GetDeck(context.TODO(), "deck-id")
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+				assert.Equal(test.expDeck, gotDeck)
+			}
+		})
+	}
+}
+
+func TestDeckRepositoryListRoomDecks(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	wantedErr := fmt.Errorf("wanted error")
+
+	tests := map[string]struct {
+		mock    func(*mysqlmock.DBClient)
+		expList *storage.DeckList
+		expErr  bool
+	}{
+		"Having an error while listing the decks, should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("QueryContext", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+			},
+			expErr: true,
+		},
+
+		"Listing the decks of a room should return the room decks.": {
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "SELECT deck.id, deck.created_at, deck.room_id, deck.name, deck.type, deck.cards, deck.draw_pile, deck.discard_pile, deck.version FROM deck WHERE room_id = ? ORDER BY serial ASC"
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows(deckColumns).
+					AddRow("deck1", t0, "room-id", "Action deck", "standard-jokers", `["A♠"]`, `[]`, `[]`, 1).
+					AddRow("deck2", t0, "room-id", "Fate", "custom", `["A","B"]`, `["B","A"]`, `[]`, 0))
+				m.On("QueryContext", mock.Anything, expQuery, "room-id").Once().Return(rows, nil)
+			},
+			expList: &storage.DeckList{Items: []model.Deck{
+				{ID: "deck1", CreatedAt: t0, RoomID: "room-id", Name: "Action deck", Type: model.DeckTypeStandardJokers, Cards: []string{"A♠"}, DrawPile: []string{}, DiscardPile: []string{}, Version: 1},
+				{ID: "deck2", CreatedAt: t0, RoomID: "room-id", Name: "Fate", Type: model.DeckTypeCustom, Cards: []string{"A", "B"}, DrawPile: []string{"B", "A"}, DiscardPile: []string{}},
+			}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			r, err := mysql.NewDeckRepository(mysql.DeckRepositoryConfig{DBClient: mdb})
+			require.NoError(err)
+			gotList, err := r.ListRoomDecks(context.TODO(), "room-id")
+
+			// Check.
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+				assert.Equal(test.expList, gotList)
+			}
+		})
+	}
+}
+
+func TestDeckRepositoryUpdateDeck(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	wantedErr := fmt.Errorf("wanted error")
+	deck := model.Deck{ID: "deck-id", RoomID: "room-id", Name: "Action deck", Type: model.DeckTypeCustom, Cards: []string{"A", "B", "C"}, DrawPile: []string{"C"}, DiscardPile: []string{"B"}, Version: 3}
+	expQuery := "UPDATE deck SET draw_pile = ?, discard_pile = ?, version = ? WHERE id = ? AND version = ?"
+
+	tests := map[string]struct {
+		mock   func(*mysqlmock.DBClient)
+		expErr error
+	}{
+		"Having an error while updating the deck, should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+			},
+			expErr: wantedErr,
+		},
+
+		"Updating a missing deck, should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, expQuery, `["C"]`, `["B"]`, uint(4), "deck-id", uint(3)).Once().Return(sqlmock.NewResult(0, 0), nil)
+				row := sqlRowErr(sql.ErrNoRows)
+				m.On("QueryRowContext", mock.Anything, mock.Anything, "deck-id").Once().Return(row)
+			},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Updating a deck that has been updated by someone else, should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, expQuery, `["C"]`, `["B"]`, uint(4), "deck-id", uint(3)).Once().Return(sqlmock.NewResult(0, 0), nil)
+				row := sqlmockRowsToStdRow(sqlmock.NewRows(deckColumns).AddRow("deck-id", t0, "room-id", "Action deck", "custom", `["A","B","C"]`, `[]`, `[]`, 4))
+				m.On("QueryRowContext", mock.Anything, mock.Anything, "deck-id").Once().Return(row)
+			},
+			expErr: internalerrors.ErrConflict,
+		},
+
+		"Updating a deck should update the deck piles and version.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, expQuery, `["C"]`, `["B"]`, uint(4), "deck-id", uint(3)).Once().Return(sqlmock.NewResult(0, 1), nil)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			r, err := mysql.NewDeckRepository(mysql.DeckRepositoryConfig{DBClient: mdb})
+			require.NoError(err)
+			err = r.UpdateDeck(context.TODO(), deck)
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+			}
+		})
+	}
+}
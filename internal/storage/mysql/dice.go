@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/huandu/go-sqlbuilder"
@@ -18,10 +19,11 @@ import (
 
 // DiceRollRepositoryConfig is the DiceRollRepository configuration.
 type DiceRollRepositoryConfig struct {
-	DBClient      DBClient
-	DiceRollTable string
-	DieRollTable  string
-	Logger        log.Logger
+	DBClient           DBClient
+	DiceRollTable      string
+	DieRollTable       string
+	CustomDieTypeTable string
+	Logger             log.Logger
 }
 
 func (c *DiceRollRepositoryConfig) defaults() error {
@@ -37,6 +39,10 @@ func (c *DiceRollRepositoryConfig) defaults() error {
 		c.DieRollTable = "die_roll"
 	}
 
+	if c.CustomDieTypeTable == "" {
+		c.CustomDieTypeTable = "custom_die_type"
+	}
+
 	if c.Logger == nil {
 		c.Logger = log.Dummy
 	}
@@ -51,10 +57,11 @@ func (c *DiceRollRepositoryConfig) defaults() error {
 
 // DiceRollRepository is a repository with MySQL implementation.
 type DiceRollRepository struct {
-	db            DBClient
-	diceRollTable string
-	dieRollTable  string
-	logger        log.Logger
+	db                 DBClient
+	diceRollTable      string
+	dieRollTable       string
+	customDieTypeTable string
+	logger             log.Logger
 }
 
 // NewDiceRollRepository returns a new DiceRollRepository.
@@ -65,21 +72,56 @@ func NewDiceRollRepository(cfg DiceRollRepositoryConfig) (*DiceRollRepository, e
 	}
 
 	return &DiceRollRepository{
-		db:            cfg.DBClient,
-		diceRollTable: cfg.DiceRollTable,
-		dieRollTable:  cfg.DieRollTable,
-		logger:        cfg.Logger,
+		db:                 cfg.DBClient,
+		diceRollTable:      cfg.DiceRollTable,
+		dieRollTable:       cfg.DieRollTable,
+		customDieTypeTable: cfg.CustomDieTypeTable,
+		logger:             cfg.Logger,
 	}, nil
 }
 
 // CreateDiceRoll satisfies storage.DiceRollRepository interface.
 // TODO(slok): Would be interesting to add this in transaction mode? for now the simplest way.
 func (d DiceRollRepository) CreateDiceRoll(ctx context.Context, dr model.DiceRoll) error {
+	return d.insertDiceRoll(ctx, d.db, dr)
+}
+
+// CreateDiceRolls satisfies storage.DiceRollRepository interface.
+func (d DiceRollRepository) CreateDiceRolls(ctx context.Context, drs []model.DiceRoll) (err error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			rbErr := tx.Rollback()
+			if rbErr != nil {
+				d.logger.Errorf("could not rollback dice rolls transaction: %s", rbErr)
+			}
+		}
+	}()
+
+	for _, dr := range drs {
+		err = d.insertDiceRoll(ctx, tx, dr)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (d DiceRollRepository) insertDiceRoll(ctx context.Context, db dbExecer, dr model.DiceRoll) error {
 	// Dice roll insert query.
 	sqlDiceRoll := modelToSQLDiceRoll(dr)
 	diceRollQuery, diceRollArgs := insertDiceRollSQLBuilder.InsertInto(d.diceRollTable, sqlDiceRoll).Build()
 
-	_, err := d.db.ExecContext(ctx, diceRollQuery, diceRollArgs...)
+	_, err := db.ExecContext(ctx, diceRollQuery, diceRollArgs...)
 	if err != nil {
 		if isDuplicateKeyError(err) {
 			return fmt.Errorf("%w: %s", internalerrors.ErrAlreadyExists, err)
@@ -92,7 +134,7 @@ func (d DiceRollRepository) CreateDiceRoll(ctx context.Context, dr model.DiceRol
 	sqlDieRolls := modelToSQLDieRolls(dr)
 	dieRollQuery, dieRollArgs := dieRollSQLBuilder.InsertInto(d.dieRollTable, sqlDieRolls...).Build()
 
-	_, err = d.db.ExecContext(ctx, dieRollQuery, dieRollArgs...)
+	_, err = db.ExecContext(ctx, dieRollQuery, dieRollArgs...)
 	if err != nil {
 		if isDuplicateKeyError(err) {
 			return fmt.Errorf("%w: %s", internalerrors.ErrAlreadyExists, err)
@@ -112,28 +154,24 @@ type cursor struct {
 func (d DiceRollRepository) ListDiceRolls(ctx context.Context, pageOpts model.PaginationOpts, filterOpts storage.ListDiceRollsOpts) (*storage.DiceRollList, error) {
 	// We want something similar to this query:
 	//
-	// SELECT drs.id, drs.created_at, drs.room_id, drs.user_id, drs.serial, dr.id, dr.die_type_id, dr.side
+	// SELECT drs.id, drs.created_at, drs.room_id, drs.user_id, drs.expression, drs.label, drs.modifier, drs.total, drs.serial, drs.server_seed_id, drs.server_seed_hash, drs.client_seed, drs.nonce, drs.pool_successes, drs.pool_outcome, drs.check_target, drs.check_comparison, drs.check_margin, drs.check_outcome, drs.visibility, drs.whisper_user_ids, drs.parent_id, dr.id, dr.die_type_id, dr.side, dr.status, cdt.name, cdt.faces
 	// FROM die_roll dr
 	// JOIN (
-	//     SELECT id, created_at, room_id, user_id, serial
+	//     SELECT id, created_at, room_id, user_id, expression, label, modifier, total, serial, server_seed_id, server_seed_hash, client_seed, nonce, pool_successes, pool_outcome, check_target, check_comparison, check_margin, check_outcome, visibility, whisper_user_ids, parent_id
 	//	       FROM dice_roll
 	//  	   WHERE room_id = "f72bebf6-506b-40d3-9772-653204174515"
 	//		   AND serial > 123
 	//     ORDER BY serial ASC
 	//     LIMIT 100
 	// ) AS drs ON dr.dice_roll_id = drs.id
-	// ORDER BY serial ASC
+	// LEFT JOIN custom_die_type cdt ON dr.die_type_id = cdt.id
+	// ORDER BY serial ASC, dr.position ASC
 
-	sb := sqlbuilder.NewSelectBuilder()
 	joinSb := sqlbuilder.NewSelectBuilder()
-
-	sb.Select("drs.id", "drs.created_at", "drs.room_id", "drs.user_id", "drs.serial", "dr.id", "dr.die_type_id", "dr.side").
-		From(d.dieRollTable+" dr").
-		Join(sb.BuilderAs(joinSb, "drs"), "dr.dice_roll_id = drs.id")
-
-	joinSb.Select("id", "created_at", "room_id", "user_id", "serial").
+	joinSb.Select(diceRollColumns...).
 		From(d.diceRollTable).
 		Where(joinSb.Equal("room_id", filterOpts.RoomID))
+	sb := d.newDiceRollsSelectBuilder(joinSb)
 
 	// If limit set.
 	if pageOpts.Size > 0 {
@@ -148,10 +186,10 @@ func (d DiceRollRepository) ListDiceRolls(ctx context.Context, pageOpts model.Pa
 	// Add order.
 	if pageOpts.Order == model.PaginationOrderAsc {
 		joinSb.OrderBy("serial ASC")
-		sb.OrderBy("serial ASC")
+		sb.OrderBy("serial ASC", "dr.position ASC")
 	} else {
 		joinSb.OrderBy("serial DESC")
-		sb.OrderBy("serial DESC")
+		sb.OrderBy("serial DESC", "dr.position ASC")
 	}
 
 	// In case of cursor select from there.
@@ -170,41 +208,7 @@ func (d DiceRollRepository) ListDiceRolls(ctx context.Context, pageOpts model.Pa
 	}
 
 	// Get from database.
-	query, args := sb.Build()
-	rows, err := d.db.QueryContext(ctx, query, args...)
-	if err != nil && err != sql.ErrNoRows {
-		return nil, fmt.Errorf("could not list dice rolls: %w", err)
-	}
-	defer rows.Close()
-
-	// We use resultDiceRolls to maintain the order and the index to get the dice roll to set the die rolls.
-	resultDiceRolls := []*model.DiceRoll{}
-	indexDiceRolls := map[string]*model.DiceRoll{}
-	drs := &sqlDiceRoll{} // Reuse this, when mapping to model we will have a new instance.
-	dr := &sqlDieRoll{}   // Reuse this, when mapping to model we will have a new instance.
-	for rows.Next() {
-		err := rows.Scan(&drs.ID, &drs.CreatedAt, &drs.RoomID, &drs.UserID, &drs.Serial, &dr.ID, &dr.DieTypeID, &dr.Side)
-		if err != nil {
-			return nil, fmt.Errorf("could not scan SQL dice rolls: %w", err)
-		}
-
-		// Get or create the dice roll.
-		diceRoll, ok := indexDiceRolls[drs.ID]
-		if !ok {
-			diceRoll = sqlToModelDiceRoll(drs)
-			indexDiceRolls[drs.ID] = diceRoll
-			resultDiceRolls = append(resultDiceRolls, diceRoll)
-		}
-
-		// Add the die rolls.
-		dieroll, err := sqlToModelDieRoll(dr)
-		if err != nil {
-			return nil, fmt.Errorf("could not map SQL die roll to model: %w", err)
-		}
-		diceRoll.Dice = append(diceRoll.Dice, *dieroll)
-	}
-
-	err = rows.Err()
+	resultDiceRolls, err := d.queryDiceRolls(ctx, sb)
 	if err != nil {
 		return nil, fmt.Errorf("could not list dice rolls: %w", err)
 	}
@@ -242,6 +246,151 @@ func (d DiceRollRepository) ListDiceRolls(ctx context.Context, pageOpts model.Pa
 	}, nil
 }
 
+// GetDiceRoll satisfies storage.DiceRollRepository interface.
+func (d DiceRollRepository) GetDiceRoll(ctx context.Context, id string) (*model.DiceRoll, error) {
+	joinSb := sqlbuilder.NewSelectBuilder()
+	joinSb.Select(diceRollColumns...).
+		From(d.diceRollTable).
+		Where(joinSb.Equal("id", id))
+	sb := d.newDiceRollsSelectBuilder(joinSb)
+	sb.OrderBy("dr.position ASC")
+
+	drs, err := d.queryDiceRolls(ctx, sb)
+	if err != nil {
+		return nil, fmt.Errorf("could not get dice roll: %w", err)
+	}
+	if len(drs) == 0 {
+		return nil, fmt.Errorf("missing dice roll: %w", internalerrors.ErrMissing)
+	}
+
+	return drs[0], nil
+}
+
+// ListDieRollSides satisfies storage.DiceRollRepository interface.
+func (d DiceRollRepository) ListDieRollSides(ctx context.Context, opts storage.DieRollStatsOpts) ([]storage.DieSide, error) {
+	if opts.RoomID == "" {
+		return nil, fmt.Errorf("room ID is required: %w", internalerrors.ErrNotValid)
+	}
+
+	if opts.Limit == 0 {
+		return nil, fmt.Errorf("limit is required: %w", internalerrors.ErrNotValid)
+	}
+
+	// Get the latest ones and reverse them afterwards to the rolled order.
+	sb := d.newDieRollStatsSelectBuilder(opts, "dr.die_type_id", "dr.side")
+	sb.OrderBy("drs.serial DESC", "dr.position DESC").
+		Limit(int(opts.Limit))
+
+	query, args := sb.Build()
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("could not list die roll sides: %w", err)
+	}
+	defer rows.Close()
+
+	res := []storage.DieSide{}
+	for rows.Next() {
+		s := storage.DieSide{}
+		err := rows.Scan(&s.DieTypeID, &s.Side)
+		if err != nil {
+			return nil, fmt.Errorf("could not scan SQL die roll sides: %w", err)
+		}
+		res = append(res, s)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+
+	return res, nil
+}
+
+// newDieRollStatsSelectBuilder returns the query that selects the die rolls that are aggregated
+// on the statistics.
+func (d DiceRollRepository) newDieRollStatsSelectBuilder(opts storage.DieRollStatsOpts, cols ...string) *sqlbuilder.SelectBuilder {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(cols...).
+		From(d.dieRollTable+" dr").
+		Join(d.diceRollTable+" drs", "dr.dice_roll_id = drs.id").
+		Where(
+			sb.Equal("drs.room_id", opts.RoomID),
+			sb.Equal("drs.visibility", uint(model.DiceRollVisibilityPublic)),
+			sb.Equal("drs.parent_id", ""),
+		)
+
+	if opts.UserID != "" {
+		sb.Where(sb.Equal("drs.user_id", opts.UserID))
+	}
+
+	return sb
+}
+
+var diceRollColumns = []string{"id", "created_at", "room_id", "user_id", "expression", "label", "modifier", "total", "serial", "server_seed_id", "server_seed_hash", "client_seed", "nonce", "pool_successes", "pool_outcome", "check_target", "check_comparison", "check_margin", "check_outcome", "visibility", "whisper_user_ids", "parent_id"}
+
+// newDiceRollsSelectBuilder returns the query that selects the die rolls of the dice rolls
+// selected by the dice roll query.
+func (d DiceRollRepository) newDiceRollsSelectBuilder(diceRollSb *sqlbuilder.SelectBuilder) *sqlbuilder.SelectBuilder {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("drs.id", "drs.created_at", "drs.room_id", "drs.user_id", "drs.expression", "drs.label", "drs.modifier", "drs.total", "drs.serial", "drs.server_seed_id", "drs.server_seed_hash", "drs.client_seed", "drs.nonce", "drs.pool_successes", "drs.pool_outcome", "drs.check_target", "drs.check_comparison", "drs.check_margin", "drs.check_outcome", "drs.visibility", "drs.whisper_user_ids", "drs.parent_id", "dr.id", "dr.die_type_id", "dr.side", "dr.status", "cdt.name", "cdt.faces").
+		From(d.dieRollTable+" dr").
+		Join(sb.BuilderAs(diceRollSb, "drs"), "dr.dice_roll_id = drs.id").
+		JoinWithOption(sqlbuilder.LeftJoin, d.customDieTypeTable+" cdt", "dr.die_type_id = cdt.id")
+
+	return sb
+}
+
+// queryDiceRolls gets the dice rolls with their die rolls maintaining the query order.
+func (d DiceRollRepository) queryDiceRolls(ctx context.Context, sb *sqlbuilder.SelectBuilder) ([]*model.DiceRoll, error) {
+	query, args := sb.Build()
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// We use resultDiceRolls to maintain the order and the index to get the dice roll to set the die rolls.
+	resultDiceRolls := []*model.DiceRoll{}
+	indexDiceRolls := map[string]*model.DiceRoll{}
+	drs := &sqlDiceRoll{} // Reuse this, when mapping to model we will have a new instance.
+	dr := &sqlDieRoll{}   // Reuse this, when mapping to model we will have a new instance.
+
+	// Only set on custom die types.
+	var cdtName, cdtFaces sql.NullString
+	for rows.Next() {
+		err := rows.Scan(&drs.ID, &drs.CreatedAt, &drs.RoomID, &drs.UserID, &drs.Expression, &drs.Label, &drs.Modifier, &drs.Total, &drs.Serial, &drs.ServerSeedID, &drs.ServerSeedHash, &drs.ClientSeed, &drs.Nonce, &drs.PoolSuccesses, &drs.PoolOutcome, &drs.CheckTarget, &drs.CheckComparison, &drs.CheckMargin, &drs.CheckOutcome, &drs.Visibility, &drs.WhisperUserIDs, &drs.ParentID, &dr.ID, &dr.DieTypeID, &dr.Side, &dr.Status, &cdtName, &cdtFaces)
+		if err != nil {
+			return nil, fmt.Errorf("could not scan SQL dice rolls: %w", err)
+		}
+
+		// Get or create the dice roll.
+		diceRoll, ok := indexDiceRolls[drs.ID]
+		if !ok {
+			diceRoll = sqlToModelDiceRoll(drs)
+			indexDiceRolls[drs.ID] = diceRoll
+			resultDiceRolls = append(resultDiceRolls, diceRoll)
+		}
+
+		// Add the die rolls.
+		dieroll, err := sqlToModelDieRoll(dr, drs.RoomID, cdtName, cdtFaces)
+		if err != nil {
+			return nil, fmt.Errorf("could not map SQL die roll to model: %w", err)
+		}
+		diceRoll.Dice = append(diceRoll.Dice, *dieroll)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return resultDiceRolls, nil
+}
+
 func strToCursor(s string) (*cursor, error) {
 	c, err := base64.StdEncoding.DecodeString(s)
 	if err != nil {
@@ -269,56 +418,165 @@ func serialToCursorStr(s uint) (string, error) {
 }
 
 func modelToSQLDiceRoll(dr model.DiceRoll) *sqlInsertDiceRoll {
-	return &sqlInsertDiceRoll{
-		ID:        dr.ID,
-		CreatedAt: dr.CreatedAt,
-		RoomID:    dr.RoomID,
-		UserID:    dr.UserID,
+	sdr := &sqlInsertDiceRoll{
+		ID:         dr.ID,
+		CreatedAt:  dr.CreatedAt,
+		RoomID:     dr.RoomID,
+		UserID:     dr.UserID,
+		Expression: dr.Expression,
+		Label:      dr.Label,
+		Modifier:   dr.Modifier,
+		Total:      dr.Total,
+		Visibility: uint(dr.Visibility),
+		ParentID:   dr.ParentID,
+	}
+
+	if dr.Proof != nil {
+		sdr.ServerSeedID = dr.Proof.ServerSeedID
+		sdr.ServerSeedHash = dr.Proof.ServerSeedHash
+		sdr.ClientSeed = dr.Proof.ClientSeed
+		sdr.Nonce = dr.Proof.Nonce
 	}
+
+	if dr.Pool != nil {
+		sdr.PoolSuccesses = dr.Pool.Successes
+		sdr.PoolOutcome = uint(dr.Pool.Outcome)
+	}
+
+	if dr.Check != nil {
+		sdr.CheckTarget = dr.Check.Target
+		sdr.CheckComparison = uint(dr.Check.Comparison)
+		sdr.CheckMargin = dr.Check.Margin
+		sdr.CheckOutcome = uint(dr.Check.Outcome)
+	}
+
+	if len(dr.WhisperUserIDs) > 0 {
+		sdr.WhisperUserIDs = strings.Join(dr.WhisperUserIDs, ",")
+	}
+
+	return sdr
 }
 
 func sqlToModelDiceRoll(dr *sqlDiceRoll) *model.DiceRoll {
-	return &model.DiceRoll{
-		ID:        dr.ID,
-		Serial:    uint(dr.Serial),
-		CreatedAt: dr.CreatedAt,
-		RoomID:    dr.RoomID,
-		UserID:    dr.UserID,
+	mdr := &model.DiceRoll{
+		ID:         dr.ID,
+		Serial:     uint(dr.Serial),
+		CreatedAt:  dr.CreatedAt,
+		RoomID:     dr.RoomID,
+		UserID:     dr.UserID,
+		Expression: dr.Expression,
+		Label:      dr.Label,
+		Modifier:   dr.Modifier,
+		Total:      dr.Total,
+		Visibility: model.DiceRollVisibility(dr.Visibility),
+		ParentID:   dr.ParentID,
+	}
+
+	// Only provably fair dice rolls have server seed.
+	if dr.ServerSeedID != "" {
+		mdr.Proof = &model.DiceRollProof{
+			ServerSeedID:   dr.ServerSeedID,
+			ServerSeedHash: dr.ServerSeedHash,
+			ClientSeed:     dr.ClientSeed,
+			Nonce:          dr.Nonce,
+		}
+	}
+
+	// Only dice pools have outcome.
+	if dr.PoolOutcome != 0 {
+		mdr.Pool = &model.DicePoolResult{
+			Successes: dr.PoolSuccesses,
+			Outcome:   model.DicePoolOutcome(dr.PoolOutcome),
+		}
+	}
+
+	// Only difficulty checks have outcome.
+	if dr.CheckOutcome != 0 {
+		mdr.Check = &model.DiceRollCheck{
+			Target:     dr.CheckTarget,
+			Comparison: model.DiceRollCheckComparison(dr.CheckComparison),
+			Margin:     dr.CheckMargin,
+			Outcome:    model.DiceRollCheckOutcome(dr.CheckOutcome),
+		}
+	}
+
+	// Only whispered dice rolls have whispered users.
+	if dr.WhisperUserIDs != "" {
+		mdr.WhisperUserIDs = strings.Split(dr.WhisperUserIDs, ",")
 	}
+
+	return mdr
 }
 
 // Returns []interface{} to make easier the insertion using sqlbuilder lib.
 func modelToSQLDieRolls(dr model.DiceRoll) []interface{} {
 	res := make([]interface{}, 0, len(dr.Dice))
-	for _, d := range dr.Dice {
+	for i, d := range dr.Dice {
 		res = append(res, &sqlDieRoll{
 			ID:         d.ID,
 			DiceRollID: dr.ID,
 			DieTypeID:  d.Type.ID(),
 			Side:       d.Side,
+			Position:   uint(i),
+			Status:     uint(d.Status),
 		})
 	}
 	return res
 }
 
-func sqlToModelDieRoll(dr *sqlDieRoll) (*model.DieRoll, error) {
-	dt, ok := model.DiceTypes[dr.DieTypeID]
-	if !ok {
-		return nil, fmt.Errorf("invalid dice type: %s", dr.DieTypeID)
+func sqlToModelDieRoll(dr *sqlDieRoll, roomID string, cdtName, cdtFaces sql.NullString) (*model.DieRoll, error) {
+	var dt model.DieType
+
+	// Custom die types are the ones that could be joined with the room custom die types.
+	if cdtFaces.Valid {
+		cdt, err := sqlToModelCustomDieType(dr.DieTypeID, roomID, cdtName.String, cdtFaces.String)
+		if err != nil {
+			return nil, fmt.Errorf("invalid custom dice type: %w", err)
+		}
+		dt = *cdt
+	} else {
+		var err error
+		dt, err = model.DieTypeFromID(dr.DieTypeID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dice type: %w", err)
+		}
 	}
 
 	return &model.DieRoll{
-		ID:   dr.ID,
-		Type: dt,
-		Side: dr.Side,
+		ID:     dr.ID,
+		Type:   dt,
+		Side:   dr.Side,
+		Status: model.DieRollStatus(dr.Status),
 	}, nil
 }
 
 type sqlInsertDiceRoll struct {
-	ID        string    `db:"id"`
-	CreatedAt time.Time `db:"created_at"`
-	RoomID    string    `db:"room_id"`
-	UserID    string    `db:"user_id"`
+	ID         string    `db:"id"`
+	CreatedAt  time.Time `db:"created_at"`
+	RoomID     string    `db:"room_id"`
+	UserID     string    `db:"user_id"`
+	Expression string    `db:"expression"`
+	Label      string    `db:"label"`
+	Modifier   int       `db:"modifier"`
+	Total      int       `db:"total"`
+	// Provably fair proof, empty on the dice rolls that are not provably fair.
+	ServerSeedID   string `db:"server_seed_id"`
+	ServerSeedHash string `db:"server_seed_hash"`
+	ClientSeed     string `db:"client_seed"`
+	Nonce          uint   `db:"nonce"`
+	// Dice pool outcome, empty on the dice rolls that are not dice pools.
+	PoolSuccesses int  `db:"pool_successes"`
+	PoolOutcome   uint `db:"pool_outcome"`
+	// Difficulty check outcome, empty on the dice rolls that are not difficulty checks.
+	CheckTarget     int  `db:"check_target"`
+	CheckComparison uint `db:"check_comparison"`
+	CheckMargin     int  `db:"check_margin"`
+	CheckOutcome    uint `db:"check_outcome"`
+	Visibility      uint `db:"visibility"`
+	// WhisperUserIDs are the comma separated whispered user IDs, empty on the dice rolls that are not whispered.
+	WhisperUserIDs string `db:"whisper_user_ids"`
+	// ParentID is the rerolled dice roll ID, empty on the dice rolls that are not rerolls.
+	ParentID string `db:"parent_id"`
 }
 
 var insertDiceRollSQLBuilder = sqlbuilder.NewStruct(&sqlInsertDiceRoll{})
@@ -333,6 +591,9 @@ type sqlDieRoll struct {
 	DiceRollID string `db:"dice_roll_id"`
 	DieTypeID  string `db:"die_type_id"`
 	Side       uint   `db:"side"`
+	// Position is the order of the die roll in the dice roll.
+	Position uint `db:"position"`
+	Status   uint `db:"status"`
 }
 
 var dieRollSQLBuilder = sqlbuilder.NewStruct(&sqlDieRoll{})
@@ -2,6 +2,7 @@ package mysql_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"testing"
@@ -33,7 +34,7 @@ func TestDiceRollRepositoryCreateDiceRoll(t *testing.T) {
 		"Having an error while storing the dice roll, should error.": {
 			config: mysql.DiceRollRepositoryConfig{},
 			mock: func(m *mysqlmock.DBClient) {
-				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
 			},
 			diceRoll: model.DiceRoll{
 				ID:        "dice-roll-id",
@@ -49,7 +50,7 @@ func TestDiceRollRepositoryCreateDiceRoll(t *testing.T) {
 			config: mysql.DiceRollRepositoryConfig{},
 			mock: func(m *mysqlmock.DBClient) {
 				err := &drivermysql.MySQLError{Number: 1062}
-				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, err)
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, err)
 			},
 			diceRoll: model.DiceRoll{
 				ID:        "dice-roll-id",
@@ -64,8 +65,8 @@ func TestDiceRollRepositoryCreateDiceRoll(t *testing.T) {
 		"Having an error while storing the die rolls, should error.": {
 			config: mysql.DiceRollRepositoryConfig{},
 			mock: func(m *mysqlmock.DBClient) {
-				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, nil)
-				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, nil)
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
 			},
 			diceRoll: model.DiceRoll{
 				ID:        "dice-roll-id",
@@ -81,8 +82,8 @@ func TestDiceRollRepositoryCreateDiceRoll(t *testing.T) {
 			config: mysql.DiceRollRepositoryConfig{},
 			mock: func(m *mysqlmock.DBClient) {
 				err := &drivermysql.MySQLError{Number: 1062}
-				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, nil)
-				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, err)
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, nil)
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, err)
 			},
 			diceRoll: model.DiceRoll{
 				ID:        "dice-roll-id",
@@ -98,15 +99,15 @@ func TestDiceRollRepositoryCreateDiceRoll(t *testing.T) {
 			config: mysql.DiceRollRepositoryConfig{},
 			mock: func(m *mysqlmock.DBClient) {
 				// Expected dice roll.
-				expQuery := "INSERT INTO dice_roll (id, created_at, room_id, user_id) VALUES (?, ?, ?, ?)"
-				m.On("ExecContext", mock.Anything, expQuery, "dice-roll-id", t0, "room-id", "user-id").Once().Return(nil, nil)
+				expQuery := "INSERT INTO dice_roll (id, created_at, room_id, user_id, expression, label, modifier, total, server_seed_id, server_seed_hash, client_seed, nonce, pool_successes, pool_outcome, check_target, check_comparison, check_margin, check_outcome, visibility, whisper_user_ids, parent_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "dice-roll-id", t0, "room-id", "user-id", "", "", 0, 0, "", "", "", uint(0), 0, uint(0), 0, uint(0), 0, uint(0), uint(0), "", "").Once().Return(nil, nil)
 
 				// Expected die rolls.
-				expQuery = "INSERT INTO die_roll (id, dice_roll_id, die_type_id, side) VALUES (?, ?, ?, ?), (?, ?, ?, ?), (?, ?, ?, ?)"
+				expQuery = "INSERT INTO die_roll (id, dice_roll_id, die_type_id, side, position, status) VALUES (?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?)"
 				m.On("ExecContext", mock.Anything, expQuery,
-					"dr1", "dice-roll-id", "d6", uint(5),
-					"dr2", "dice-roll-id", "d10", uint(7),
-					"dr3", "dice-roll-id", "d20", uint(15),
+					"dr1", "dice-roll-id", "d6", uint(5), uint(0), uint(0),
+					"dr2", "dice-roll-id", "d10", uint(7), uint(1), uint(0),
+					"dr3", "dice-roll-id", "d20", uint(15), uint(2), uint(0),
 				).Once().Return(nil, nil)
 			},
 			diceRoll: model.DiceRoll{
@@ -121,6 +122,187 @@ func TestDiceRollRepositoryCreateDiceRoll(t *testing.T) {
 				},
 			},
 		},
+
+		"Creating a provably fair dice roll should store the dice roll proof.": {
+			config: mysql.DiceRollRepositoryConfig{},
+			mock: func(m *mysqlmock.DBClient) {
+				// Expected dice roll.
+				expQuery := "INSERT INTO dice_roll (id, created_at, room_id, user_id, expression, label, modifier, total, server_seed_id, server_seed_hash, client_seed, nonce, pool_successes, pool_outcome, check_target, check_comparison, check_margin, check_outcome, visibility, whisper_user_ids, parent_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "dice-roll-id", t0, "room-id", "user-id", "", "", 0, 0, "seed-id", "seed-hash", "client-seed", uint(7), 0, uint(0), 0, uint(0), 0, uint(0), uint(0), "", "").Once().Return(nil, nil)
+
+				// Expected die rolls.
+				expQuery = "INSERT INTO die_roll (id, dice_roll_id, die_type_id, side, position, status) VALUES (?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "dr1", "dice-roll-id", "d6", uint(5), uint(0), uint(0)).Once().Return(nil, nil)
+			},
+			diceRoll: model.DiceRoll{
+				ID:        "dice-roll-id",
+				RoomID:    "room-id",
+				UserID:    "user-id",
+				CreatedAt: t0,
+				Dice:      []model.DieRoll{{ID: "dr1", Type: model.DieTypeD6, Side: 5}},
+				Proof: &model.DiceRollProof{
+					ServerSeedID:   "seed-id",
+					ServerSeedHash: "seed-hash",
+					ClientSeed:     "client-seed",
+					Nonce:          7,
+				},
+			},
+		},
+
+		"Creating a dice roll with an expression should store the expression, its total and the dice status.": {
+			config: mysql.DiceRollRepositoryConfig{},
+			mock: func(m *mysqlmock.DBClient) {
+				// Expected dice roll.
+				expQuery := "INSERT INTO dice_roll (id, created_at, room_id, user_id, expression, label, modifier, total, server_seed_id, server_seed_hash, client_seed, nonce, pool_successes, pool_outcome, check_target, check_comparison, check_margin, check_outcome, visibility, whisper_user_ids, parent_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "dice-roll-id", t0, "room-id", "user-id", "2d6kh1+3", "", 0, 8, "", "", "", uint(0), 0, uint(0), 0, uint(0), 0, uint(0), uint(0), "", "").Once().Return(nil, nil)
+
+				// Expected die rolls.
+				expQuery = "INSERT INTO die_roll (id, dice_roll_id, die_type_id, side, position, status) VALUES (?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery,
+					"dr1", "dice-roll-id", "d6", uint(5), uint(0), uint(0),
+					"dr2", "dice-roll-id", "d6", uint(4), uint(1), uint(3),
+				).Once().Return(nil, nil)
+			},
+			diceRoll: model.DiceRoll{
+				ID:         "dice-roll-id",
+				RoomID:     "room-id",
+				UserID:     "user-id",
+				CreatedAt:  t0,
+				Expression: "2d6kh1+3",
+				Total:      8,
+				Dice: []model.DieRoll{
+					{ID: "dr1", Type: model.DieTypeD6, Side: 5},
+					{ID: "dr2", Type: model.DieTypeD6, Side: 4, Status: model.DieRollStatusDropped},
+				},
+			},
+		},
+
+		"Creating a dice roll with a label and a modifier should store the label, the modifier and the total.": {
+			config: mysql.DiceRollRepositoryConfig{},
+			mock: func(m *mysqlmock.DBClient) {
+				// Expected dice roll.
+				expQuery := "INSERT INTO dice_roll (id, created_at, room_id, user_id, expression, label, modifier, total, server_seed_id, server_seed_hash, client_seed, nonce, pool_successes, pool_outcome, check_target, check_comparison, check_margin, check_outcome, visibility, whisper_user_ids, parent_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "dice-roll-id", t0, "room-id", "user-id", "", "Attack", -2, 13, "", "", "", uint(0), 0, uint(0), 0, uint(0), 0, uint(0), uint(0), "", "").Once().Return(nil, nil)
+
+				// Expected die rolls.
+				expQuery = "INSERT INTO die_roll (id, dice_roll_id, die_type_id, side, position, status) VALUES (?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "dr1", "dice-roll-id", "d20", uint(15), uint(0), uint(0)).Once().Return(nil, nil)
+			},
+			diceRoll: model.DiceRoll{
+				ID:        "dice-roll-id",
+				RoomID:    "room-id",
+				UserID:    "user-id",
+				CreatedAt: t0,
+				Label:     "Attack",
+				Modifier:  -2,
+				Total:     13,
+				Dice:      []model.DieRoll{{ID: "dr1", Type: model.DieTypeD20, Side: 15}},
+			},
+		},
+
+		"Creating a dice pool should store the dice pool outcome.": {
+			config: mysql.DiceRollRepositoryConfig{},
+			mock: func(m *mysqlmock.DBClient) {
+				// Expected dice roll.
+				expQuery := "INSERT INTO dice_roll (id, created_at, room_id, user_id, expression, label, modifier, total, server_seed_id, server_seed_hash, client_seed, nonce, pool_successes, pool_outcome, check_target, check_comparison, check_margin, check_outcome, visibility, whisper_user_ids, parent_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "dice-roll-id", t0, "room-id", "user-id", "", "", 0, 1, "", "", "", uint(0), 1, uint(3), 0, uint(0), 0, uint(0), uint(0), "", "").Once().Return(nil, nil)
+
+				// Expected die rolls.
+				expQuery = "INSERT INTO die_roll (id, dice_roll_id, die_type_id, side, position, status) VALUES (?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery,
+					"dr1", "dice-roll-id", "d10", uint(9), uint(0), uint(0),
+					"dr2", "dice-roll-id", "d10", uint(3), uint(1), uint(0),
+				).Once().Return(nil, nil)
+			},
+			diceRoll: model.DiceRoll{
+				ID:        "dice-roll-id",
+				RoomID:    "room-id",
+				UserID:    "user-id",
+				CreatedAt: t0,
+				Total:     1,
+				Dice: []model.DieRoll{
+					{ID: "dr1", Type: model.DieTypeD10, Side: 9},
+					{ID: "dr2", Type: model.DieTypeD10, Side: 3},
+				},
+				Pool: &model.DicePoolResult{Successes: 1, Outcome: model.DicePoolOutcomeSuccess},
+			},
+		},
+
+		"Creating a difficulty check should store the check outcome.": {
+			config: mysql.DiceRollRepositoryConfig{},
+			mock: func(m *mysqlmock.DBClient) {
+				// Expected dice roll.
+				expQuery := "INSERT INTO dice_roll (id, created_at, room_id, user_id, expression, label, modifier, total, server_seed_id, server_seed_hash, client_seed, nonce, pool_successes, pool_outcome, check_target, check_comparison, check_margin, check_outcome, visibility, whisper_user_ids, parent_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "dice-roll-id", t0, "room-id", "user-id", "1d20+5", "", 0, 12, "", "", "", uint(0), 0, uint(0), 15, uint(1), -3, uint(2), uint(0), "", "").Once().Return(nil, nil)
+
+				// Expected die rolls.
+				expQuery = "INSERT INTO die_roll (id, dice_roll_id, die_type_id, side, position, status) VALUES (?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "dr1", "dice-roll-id", "d20", uint(7), uint(0), uint(0)).Once().Return(nil, nil)
+			},
+			diceRoll: model.DiceRoll{
+				ID:         "dice-roll-id",
+				RoomID:     "room-id",
+				UserID:     "user-id",
+				CreatedAt:  t0,
+				Expression: "1d20+5",
+				Total:      12,
+				Dice: []model.DieRoll{
+					{ID: "dr1", Type: model.DieTypeD20, Side: 7},
+				},
+				Check: &model.DiceRollCheck{Target: 15, Comparison: model.DiceRollCheckComparisonGreaterOrEqual, Margin: -3, Outcome: model.DiceRollCheckOutcomeFailure},
+			},
+		},
+
+		"Creating a whispered dice roll should store the visibility and the whispered users.": {
+			config: mysql.DiceRollRepositoryConfig{},
+			mock: func(m *mysqlmock.DBClient) {
+				// Expected dice roll.
+				expQuery := "INSERT INTO dice_roll (id, created_at, room_id, user_id, expression, label, modifier, total, server_seed_id, server_seed_hash, client_seed, nonce, pool_successes, pool_outcome, check_target, check_comparison, check_margin, check_outcome, visibility, whisper_user_ids, parent_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "dice-roll-id", t0, "room-id", "user-id", "", "", 0, 15, "", "", "", uint(0), 0, uint(0), 0, uint(0), 0, uint(0), uint(2), "user-1,user-2", "").Once().Return(nil, nil)
+
+				// Expected die rolls.
+				expQuery = "INSERT INTO die_roll (id, dice_roll_id, die_type_id, side, position, status) VALUES (?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "dr1", "dice-roll-id", "d20", uint(15), uint(0), uint(0)).Once().Return(nil, nil)
+			},
+			diceRoll: model.DiceRoll{
+				ID:             "dice-roll-id",
+				RoomID:         "room-id",
+				UserID:         "user-id",
+				CreatedAt:      t0,
+				Total:          15,
+				Dice:           []model.DieRoll{{ID: "dr1", Type: model.DieTypeD20, Side: 15}},
+				Visibility:     model.DiceRollVisibilityWhisper,
+				WhisperUserIDs: []string{"user-1", "user-2"},
+			},
+		},
+
+		"Creating a reroll should store the rerolled dice roll reference.": {
+			config: mysql.DiceRollRepositoryConfig{},
+			mock: func(m *mysqlmock.DBClient) {
+				// Expected dice roll.
+				expQuery := "INSERT INTO dice_roll (id, created_at, room_id, user_id, expression, label, modifier, total, server_seed_id, server_seed_hash, client_seed, nonce, pool_successes, pool_outcome, check_target, check_comparison, check_margin, check_outcome, visibility, whisper_user_ids, parent_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "dice-roll-id", t0, "room-id", "user-id", "", "", 0, 12, "", "", "", uint(0), 0, uint(0), 0, uint(0), 0, uint(0), uint(0), "", "parent-id").Once().Return(nil, nil)
+
+				// Expected die rolls.
+				expQuery = "INSERT INTO die_roll (id, dice_roll_id, die_type_id, side, position, status) VALUES (?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery,
+					"dr1", "dice-roll-id", "d20", uint(3), uint(0), uint(2),
+					"dr2", "dice-roll-id", "d20", uint(12), uint(1), uint(0),
+				).Once().Return(nil, nil)
+			},
+			diceRoll: model.DiceRoll{
+				ID:        "dice-roll-id",
+				RoomID:    "room-id",
+				UserID:    "user-id",
+				CreatedAt: t0,
+				Total:     12,
+				Dice: []model.DieRoll{
+					{ID: "dr1", Type: model.DieTypeD20, Side: 3, Status: model.DieRollStatusRerolled},
+					{ID: "dr2", Type: model.DieTypeD20, Side: 12},
+				},
+				ParentID: "parent-id",
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -148,6 +330,111 @@ func TestDiceRollRepositoryCreateDiceRoll(t *testing.T) {
 	}
 }
 
+func TestDiceRollRepositoryCreateDiceRolls(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	wantedErr := fmt.Errorf("wanted error")
+	expDiceRollQuery := "INSERT INTO dice_roll (id, created_at, room_id, user_id, expression, label, modifier, total, server_seed_id, server_seed_hash, client_seed, nonce, pool_successes, pool_outcome, check_target, check_comparison, check_margin, check_outcome, visibility, whisper_user_ids, parent_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	expDieRollQuery := "INSERT INTO die_roll (id, dice_roll_id, die_type_id, side, position, status) VALUES (?, ?, ?, ?, ?, ?)"
+	diceRolls := []model.DiceRoll{
+		{ID: "dice-roll-id-1", RoomID: "room-id", UserID: "user-id", CreatedAt: t0, Dice: []model.DieRoll{{ID: "dr1", Type: model.DieTypeD20, Side: 5}}},
+		{ID: "dice-roll-id-2", RoomID: "room-id", UserID: "user-id", CreatedAt: t0, Dice: []model.DieRoll{{ID: "dr2", Type: model.DieTypeD20, Side: 17}}},
+	}
+
+	tests := map[string]struct {
+		mock      func(m *mysqlmock.DBClient, db *sql.DB, smock sqlmock.Sqlmock)
+		diceRolls []model.DiceRoll
+		expErr    error
+	}{
+		"Having an error while starting the transaction, should error.": {
+			mock: func(m *mysqlmock.DBClient, db *sql.DB, smock sqlmock.Sqlmock) {
+				m.On("BeginTx", mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+			},
+			diceRolls: diceRolls,
+			expErr:    wantedErr,
+		},
+
+		"Having an already existing dice roll in the batch, should rollback and error.": {
+			mock: func(m *mysqlmock.DBClient, db *sql.DB, smock sqlmock.Sqlmock) {
+				smock.ExpectBegin()
+				smock.ExpectExec(expDiceRollQuery).WillReturnResult(sqlmock.NewResult(1, 1))
+				smock.ExpectExec(expDieRollQuery).WillReturnResult(sqlmock.NewResult(1, 1))
+				smock.ExpectExec(expDiceRollQuery).WillReturnError(&drivermysql.MySQLError{Number: 1062})
+				smock.ExpectRollback()
+
+				tx, _ := db.Begin()
+				m.On("BeginTx", mock.Anything, mock.Anything).Once().Return(tx, nil)
+			},
+			diceRolls: diceRolls,
+			expErr:    internalerrors.ErrAlreadyExists,
+		},
+
+		"Having an error while committing the transaction, should error.": {
+			mock: func(m *mysqlmock.DBClient, db *sql.DB, smock sqlmock.Sqlmock) {
+				smock.ExpectBegin()
+				smock.ExpectExec(expDiceRollQuery).WillReturnResult(sqlmock.NewResult(1, 1))
+				smock.ExpectExec(expDieRollQuery).WillReturnResult(sqlmock.NewResult(1, 1))
+				smock.ExpectCommit().WillReturnError(wantedErr)
+
+				tx, _ := db.Begin()
+				m.On("BeginTx", mock.Anything, mock.Anything).Once().Return(tx, nil)
+			},
+			diceRolls: diceRolls[:1],
+			expErr:    wantedErr,
+		},
+
+		"Creating the dice rolls should store all the dice rolls and die rolls in the same transaction.": {
+			mock: func(m *mysqlmock.DBClient, db *sql.DB, smock sqlmock.Sqlmock) {
+				smock.ExpectBegin()
+				smock.ExpectExec(expDiceRollQuery).
+					WithArgs("dice-roll-id-1", t0, "room-id", "user-id", "", "", 0, 0, "", "", "", 0, 0, 0, 0, 0, 0, 0, 0, "", "").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				smock.ExpectExec(expDieRollQuery).
+					WithArgs("dr1", "dice-roll-id-1", "d20", 5, 0, 0).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				smock.ExpectExec(expDiceRollQuery).
+					WithArgs("dice-roll-id-2", t0, "room-id", "user-id", "", "", 0, 0, "", "", "", 0, 0, 0, 0, 0, 0, 0, 0, "", "").
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				smock.ExpectExec(expDieRollQuery).
+					WithArgs("dr2", "dice-roll-id-2", "d20", 17, 0, 0).
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				smock.ExpectCommit()
+
+				tx, _ := db.Begin()
+				m.On("BeginTx", mock.Anything, mock.Anything).Once().Return(tx, nil)
+			},
+			diceRolls: diceRolls,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			db, smock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(err)
+			defer db.Close()
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb, db, smock)
+
+			// Execute.
+			r, err := mysql.NewDiceRollRepository(mysql.DiceRollRepositoryConfig{DBClient: mdb})
+			require.NoError(err)
+			err = r.CreateDiceRolls(context.TODO(), test.diceRolls)
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else {
+				assert.NoError(err)
+			}
+			mdb.AssertExpectations(t)
+			assert.NoError(smock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestDiceRollRepositoryListUsers(t *testing.T) {
 	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
 	wantedErr := fmt.Errorf("wanted error")
@@ -198,32 +485,39 @@ func TestDiceRollRepositoryListUsers(t *testing.T) {
 				UserID: "",
 			},
 			mock: func(m *mysqlmock.DBClient) {
-				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"drs.id", "drs.created_at", "drs.room_id", "drs.user_id", "drs.serial", "dr.id", "dr.die_type_id", "dr.side"}).
-					AddRow("dr2", t0, "room-1", "user-2", 3, "dr20", "d20", 11).
-					AddRow("dr2", t0, "room-1", "user-2", 3, "dr21", "d20", 17).
-					AddRow("dr1", t0, "room-1", "user-1", 2, "dr10", "d10", 8).
-					AddRow("dr0", t0, "room-1", "user-1", 1, "dr00", "d6", 0).
-					AddRow("dr0", t0, "room-1", "user-1", 1, "dr01", "d6", 4))
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"drs.id", "drs.created_at", "drs.room_id", "drs.user_id", "drs.expression", "drs.label", "drs.modifier", "drs.total", "drs.serial", "drs.server_seed_id", "drs.server_seed_hash", "drs.client_seed", "drs.nonce", "drs.pool_successes", "drs.pool_outcome", "drs.check_target", "drs.check_comparison", "drs.check_margin", "drs.check_outcome", "drs.visibility", "drs.whisper_user_ids", "drs.parent_id", "dr.id", "dr.die_type_id", "dr.side", "dr.status", "cdt.name", "cdt.faces"}).
+					AddRow("dr2", t0, "room-1", "user-2", "2d20kh1+2", "", 0, 19, 3, "", "", "", 0, 0, 0, 0, 0, 0, 0, 0, "", "", "dr20", "d20", 11, 3, nil, nil).
+					AddRow("dr2", t0, "room-1", "user-2", "2d20kh1+2", "", 0, 19, 3, "", "", "", 0, 0, 0, 0, 0, 0, 0, 0, "", "", "dr21", "d20", 17, 0, nil, nil).
+					AddRow("dr1", t0, "room-1", "user-1", "", "", 0, 0, 2, "", "", "", 0, 0, 0, 0, 0, 0, 0, 0, "", "", "dr10", "d100", 88, 0, nil, nil).
+					AddRow("dr1", t0, "room-1", "user-1", "", "", 0, 0, 2, "", "", "", 0, 0, 0, 0, 0, 0, 0, 0, "", "", "dr11", "coin-id", 2, 0, "Coin", `[{"label":"Heads","value":1},{"label":"Tails","value":0}]`).
+					AddRow("dr0", t0, "room-1", "user-1", "", "Stealth check", 5, 9, 1, "", "", "", 0, 0, 0, 0, 0, 0, 0, 0, "", "", "dr00", "d3", 0, 0, nil, nil).
+					AddRow("dr0", t0, "room-1", "user-1", "", "Stealth check", 5, 9, 1, "", "", "", 0, 0, 0, 0, 0, 0, 0, 0, "", "", "dr01", "d6", 4, 0, nil, nil))
 				// Expected dice roll.
-				expQuery := "SELECT drs.id, drs.created_at, drs.room_id, drs.user_id, drs.serial, dr.id, dr.die_type_id, dr.side FROM die_roll dr JOIN (SELECT id, created_at, room_id, user_id, serial FROM dice_roll WHERE room_id = ? ORDER BY serial DESC) AS drs ON dr.dice_roll_id = drs.id ORDER BY serial DESC"
+				expQuery := "SELECT drs.id, drs.created_at, drs.room_id, drs.user_id, drs.expression, drs.label, drs.modifier, drs.total, drs.serial, drs.server_seed_id, drs.server_seed_hash, drs.client_seed, drs.nonce, drs.pool_successes, drs.pool_outcome, drs.check_target, drs.check_comparison, drs.check_margin, drs.check_outcome, drs.visibility, drs.whisper_user_ids, drs.parent_id, dr.id, dr.die_type_id, dr.side, dr.status, cdt.name, cdt.faces FROM die_roll dr JOIN (SELECT id, created_at, room_id, user_id, expression, label, modifier, total, serial, server_seed_id, server_seed_hash, client_seed, nonce, pool_successes, pool_outcome, check_target, check_comparison, check_margin, check_outcome, visibility, whisper_user_ids, parent_id FROM dice_roll WHERE room_id = ? ORDER BY serial DESC) AS drs ON dr.dice_roll_id = drs.id LEFT JOIN custom_die_type cdt ON dr.die_type_id = cdt.id ORDER BY serial DESC, dr.position ASC"
 				m.On("QueryContext", mock.Anything, expQuery, "room-1").Once().Return(rows, nil)
 			},
 			expDiceRollList: &storage.DiceRollList{
 				Items: []model.DiceRoll{
-					{ID: "dr2", RoomID: "room-1", CreatedAt: t0, Serial: 3, UserID: "user-2",
+					{ID: "dr2", RoomID: "room-1", CreatedAt: t0, Serial: 3, UserID: "user-2", Expression: "2d20kh1+2", Total: 19,
 						Dice: []model.DieRoll{
-							{ID: "dr20", Type: model.DieTypeD20, Side: 11},
+							{ID: "dr20", Type: model.DieTypeD20, Side: 11, Status: model.DieRollStatusDropped},
 							{ID: "dr21", Type: model.DieTypeD20, Side: 17},
 						},
 					},
 					{ID: "dr1", RoomID: "room-1", CreatedAt: t0, Serial: 2, UserID: "user-1",
 						Dice: []model.DieRoll{
-							{ID: "dr10", Type: model.DieTypeD10, Side: 8},
+							{ID: "dr10", Type: model.DieTypeD100, Side: 88},
+							{ID: "dr11", Side: 2, Type: model.CustomDieType{
+								TypeID:    "coin-id",
+								RoomID:    "room-1",
+								TypeName:  "Coin",
+								FaceTable: []model.DieFace{{Label: "Heads", Value: 1}, {Label: "Tails", Value: 0}},
+							}},
 						},
 					},
-					{ID: "dr0", RoomID: "room-1", CreatedAt: t0, Serial: 1, UserID: "user-1",
+					{ID: "dr0", RoomID: "room-1", CreatedAt: t0, Serial: 1, UserID: "user-1", Label: "Stealth check", Modifier: 5, Total: 9,
 						Dice: []model.DieRoll{
-							{ID: "dr00", Type: model.DieTypeD6, Side: 0},
+							{ID: "dr00", Type: model.DieTypeD3, Side: 0},
 							{ID: "dr01", Type: model.DieTypeD6, Side: 4},
 						},
 					},
@@ -245,8 +539,8 @@ func TestDiceRollRepositoryListUsers(t *testing.T) {
 			},
 			mock: func(m *mysqlmock.DBClient) {
 				// Expected dice roll.
-				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"drs.id", "drs.created_at", "drs.room_id", "drs.user_id", "drs.serial", "dr.id", "dr.die_type_id", "dr.side"}))
-				expQuery := "SELECT drs.id, drs.created_at, drs.room_id, drs.user_id, drs.serial, dr.id, dr.die_type_id, dr.side FROM die_roll dr JOIN (SELECT id, created_at, room_id, user_id, serial FROM dice_roll WHERE room_id = ? AND user_id = ? ORDER BY serial DESC) AS drs ON dr.dice_roll_id = drs.id ORDER BY serial DESC"
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"drs.id", "drs.created_at", "drs.room_id", "drs.user_id", "drs.expression", "drs.label", "drs.modifier", "drs.total", "drs.serial", "drs.server_seed_id", "drs.server_seed_hash", "drs.client_seed", "drs.nonce", "drs.pool_successes", "drs.pool_outcome", "drs.check_target", "drs.check_comparison", "drs.check_margin", "drs.check_outcome", "drs.visibility", "drs.whisper_user_ids", "drs.parent_id", "dr.id", "dr.die_type_id", "dr.side", "dr.status", "cdt.name", "cdt.faces"}))
+				expQuery := "SELECT drs.id, drs.created_at, drs.room_id, drs.user_id, drs.expression, drs.label, drs.modifier, drs.total, drs.serial, drs.server_seed_id, drs.server_seed_hash, drs.client_seed, drs.nonce, drs.pool_successes, drs.pool_outcome, drs.check_target, drs.check_comparison, drs.check_margin, drs.check_outcome, drs.visibility, drs.whisper_user_ids, drs.parent_id, dr.id, dr.die_type_id, dr.side, dr.status, cdt.name, cdt.faces FROM die_roll dr JOIN (SELECT id, created_at, room_id, user_id, expression, label, modifier, total, serial, server_seed_id, server_seed_hash, client_seed, nonce, pool_successes, pool_outcome, check_target, check_comparison, check_margin, check_outcome, visibility, whisper_user_ids, parent_id FROM dice_roll WHERE room_id = ? AND user_id = ? ORDER BY serial DESC) AS drs ON dr.dice_roll_id = drs.id LEFT JOIN custom_die_type cdt ON dr.die_type_id = cdt.id ORDER BY serial DESC, dr.position ASC"
 				m.On("QueryContext", mock.Anything, expQuery, "room-1", "user-1").Once().Return(rows, nil)
 			},
 			expDiceRollList: &storage.DiceRollList{
@@ -269,8 +563,8 @@ func TestDiceRollRepositoryListUsers(t *testing.T) {
 			},
 			mock: func(m *mysqlmock.DBClient) {
 				// Expected dice roll.
-				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"drs.id", "drs.created_at", "drs.room_id", "drs.user_id", "drs.serial", "dr.id", "dr.die_type_id", "dr.side"}))
-				expQuery := "SELECT drs.id, drs.created_at, drs.room_id, drs.user_id, drs.serial, dr.id, dr.die_type_id, dr.side FROM die_roll dr JOIN (SELECT id, created_at, room_id, user_id, serial FROM dice_roll WHERE room_id = ? ORDER BY serial ASC) AS drs ON dr.dice_roll_id = drs.id ORDER BY serial ASC"
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"drs.id", "drs.created_at", "drs.room_id", "drs.user_id", "drs.expression", "drs.label", "drs.modifier", "drs.total", "drs.serial", "drs.server_seed_id", "drs.server_seed_hash", "drs.client_seed", "drs.nonce", "drs.pool_successes", "drs.pool_outcome", "drs.check_target", "drs.check_comparison", "drs.check_margin", "drs.check_outcome", "drs.visibility", "drs.whisper_user_ids", "drs.parent_id", "dr.id", "dr.die_type_id", "dr.side", "dr.status", "cdt.name", "cdt.faces"}))
+				expQuery := "SELECT drs.id, drs.created_at, drs.room_id, drs.user_id, drs.expression, drs.label, drs.modifier, drs.total, drs.serial, drs.server_seed_id, drs.server_seed_hash, drs.client_seed, drs.nonce, drs.pool_successes, drs.pool_outcome, drs.check_target, drs.check_comparison, drs.check_margin, drs.check_outcome, drs.visibility, drs.whisper_user_ids, drs.parent_id, dr.id, dr.die_type_id, dr.side, dr.status, cdt.name, cdt.faces FROM die_roll dr JOIN (SELECT id, created_at, room_id, user_id, expression, label, modifier, total, serial, server_seed_id, server_seed_hash, client_seed, nonce, pool_successes, pool_outcome, check_target, check_comparison, check_margin, check_outcome, visibility, whisper_user_ids, parent_id FROM dice_roll WHERE room_id = ? ORDER BY serial ASC) AS drs ON dr.dice_roll_id = drs.id LEFT JOIN custom_die_type cdt ON dr.die_type_id = cdt.id ORDER BY serial ASC, dr.position ASC"
 				m.On("QueryContext", mock.Anything, expQuery, "room-1").Once().Return(rows, nil)
 			},
 			expDiceRollList: &storage.DiceRollList{
@@ -293,8 +587,8 @@ func TestDiceRollRepositoryListUsers(t *testing.T) {
 			},
 			mock: func(m *mysqlmock.DBClient) {
 				// Expected dice roll.
-				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"drs.id", "drs.created_at", "drs.room_id", "drs.user_id", "drs.serial", "dr.id", "dr.die_type_id", "dr.side"}))
-				expQuery := "SELECT drs.id, drs.created_at, drs.room_id, drs.user_id, drs.serial, dr.id, dr.die_type_id, dr.side FROM die_roll dr JOIN (SELECT id, created_at, room_id, user_id, serial FROM dice_roll WHERE room_id = ? ORDER BY serial DESC LIMIT 42) AS drs ON dr.dice_roll_id = drs.id ORDER BY serial DESC"
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"drs.id", "drs.created_at", "drs.room_id", "drs.user_id", "drs.expression", "drs.label", "drs.modifier", "drs.total", "drs.serial", "drs.server_seed_id", "drs.server_seed_hash", "drs.client_seed", "drs.nonce", "drs.pool_successes", "drs.pool_outcome", "drs.check_target", "drs.check_comparison", "drs.check_margin", "drs.check_outcome", "drs.visibility", "drs.whisper_user_ids", "drs.parent_id", "dr.id", "dr.die_type_id", "dr.side", "dr.status", "cdt.name", "cdt.faces"}))
+				expQuery := "SELECT drs.id, drs.created_at, drs.room_id, drs.user_id, drs.expression, drs.label, drs.modifier, drs.total, drs.serial, drs.server_seed_id, drs.server_seed_hash, drs.client_seed, drs.nonce, drs.pool_successes, drs.pool_outcome, drs.check_target, drs.check_comparison, drs.check_margin, drs.check_outcome, drs.visibility, drs.whisper_user_ids, drs.parent_id, dr.id, dr.die_type_id, dr.side, dr.status, cdt.name, cdt.faces FROM die_roll dr JOIN (SELECT id, created_at, room_id, user_id, expression, label, modifier, total, serial, server_seed_id, server_seed_hash, client_seed, nonce, pool_successes, pool_outcome, check_target, check_comparison, check_margin, check_outcome, visibility, whisper_user_ids, parent_id FROM dice_roll WHERE room_id = ? ORDER BY serial DESC LIMIT 42) AS drs ON dr.dice_roll_id = drs.id LEFT JOIN custom_die_type cdt ON dr.die_type_id = cdt.id ORDER BY serial DESC, dr.position ASC"
 				m.On("QueryContext", mock.Anything, expQuery, "room-1").Once().Return(rows, nil)
 			},
 			expDiceRollList: &storage.DiceRollList{
@@ -318,8 +612,8 @@ func TestDiceRollRepositoryListUsers(t *testing.T) {
 			},
 			mock: func(m *mysqlmock.DBClient) {
 				// Expected dice roll.
-				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"drs.id", "drs.created_at", "drs.room_id", "drs.user_id", "drs.serial", "dr.id", "dr.die_type_id", "dr.side"}))
-				expQuery := "SELECT drs.id, drs.created_at, drs.room_id, drs.user_id, drs.serial, dr.id, dr.die_type_id, dr.side FROM die_roll dr JOIN (SELECT id, created_at, room_id, user_id, serial FROM dice_roll WHERE room_id = ? AND serial < ? ORDER BY serial DESC) AS drs ON dr.dice_roll_id = drs.id ORDER BY serial DESC"
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"drs.id", "drs.created_at", "drs.room_id", "drs.user_id", "drs.expression", "drs.label", "drs.modifier", "drs.total", "drs.serial", "drs.server_seed_id", "drs.server_seed_hash", "drs.client_seed", "drs.nonce", "drs.pool_successes", "drs.pool_outcome", "drs.check_target", "drs.check_comparison", "drs.check_margin", "drs.check_outcome", "drs.visibility", "drs.whisper_user_ids", "drs.parent_id", "dr.id", "dr.die_type_id", "dr.side", "dr.status", "cdt.name", "cdt.faces"}))
+				expQuery := "SELECT drs.id, drs.created_at, drs.room_id, drs.user_id, drs.expression, drs.label, drs.modifier, drs.total, drs.serial, drs.server_seed_id, drs.server_seed_hash, drs.client_seed, drs.nonce, drs.pool_successes, drs.pool_outcome, drs.check_target, drs.check_comparison, drs.check_margin, drs.check_outcome, drs.visibility, drs.whisper_user_ids, drs.parent_id, dr.id, dr.die_type_id, dr.side, dr.status, cdt.name, cdt.faces FROM die_roll dr JOIN (SELECT id, created_at, room_id, user_id, expression, label, modifier, total, serial, server_seed_id, server_seed_hash, client_seed, nonce, pool_successes, pool_outcome, check_target, check_comparison, check_margin, check_outcome, visibility, whisper_user_ids, parent_id FROM dice_roll WHERE room_id = ? AND serial < ? ORDER BY serial DESC) AS drs ON dr.dice_roll_id = drs.id LEFT JOIN custom_die_type cdt ON dr.die_type_id = cdt.id ORDER BY serial DESC, dr.position ASC"
 				m.On("QueryContext", mock.Anything, expQuery, "room-1", 3).Once().Return(rows, nil)
 			},
 			expDiceRollList: &storage.DiceRollList{
@@ -343,8 +637,8 @@ func TestDiceRollRepositoryListUsers(t *testing.T) {
 			},
 			mock: func(m *mysqlmock.DBClient) {
 				// Expected dice roll.
-				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"drs.id", "drs.created_at", "drs.room_id", "drs.user_id", "drs.serial", "dr.id", "dr.die_type_id", "dr.side"}))
-				expQuery := "SELECT drs.id, drs.created_at, drs.room_id, drs.user_id, drs.serial, dr.id, dr.die_type_id, dr.side FROM die_roll dr JOIN (SELECT id, created_at, room_id, user_id, serial FROM dice_roll WHERE room_id = ? AND serial > ? ORDER BY serial ASC) AS drs ON dr.dice_roll_id = drs.id ORDER BY serial ASC"
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"drs.id", "drs.created_at", "drs.room_id", "drs.user_id", "drs.expression", "drs.label", "drs.modifier", "drs.total", "drs.serial", "drs.server_seed_id", "drs.server_seed_hash", "drs.client_seed", "drs.nonce", "drs.pool_successes", "drs.pool_outcome", "drs.check_target", "drs.check_comparison", "drs.check_margin", "drs.check_outcome", "drs.visibility", "drs.whisper_user_ids", "drs.parent_id", "dr.id", "dr.die_type_id", "dr.side", "dr.status", "cdt.name", "cdt.faces"}))
+				expQuery := "SELECT drs.id, drs.created_at, drs.room_id, drs.user_id, drs.expression, drs.label, drs.modifier, drs.total, drs.serial, drs.server_seed_id, drs.server_seed_hash, drs.client_seed, drs.nonce, drs.pool_successes, drs.pool_outcome, drs.check_target, drs.check_comparison, drs.check_margin, drs.check_outcome, drs.visibility, drs.whisper_user_ids, drs.parent_id, dr.id, dr.die_type_id, dr.side, dr.status, cdt.name, cdt.faces FROM die_roll dr JOIN (SELECT id, created_at, room_id, user_id, expression, label, modifier, total, serial, server_seed_id, server_seed_hash, client_seed, nonce, pool_successes, pool_outcome, check_target, check_comparison, check_margin, check_outcome, visibility, whisper_user_ids, parent_id FROM dice_roll WHERE room_id = ? AND serial > ? ORDER BY serial ASC) AS drs ON dr.dice_roll_id = drs.id LEFT JOIN custom_die_type cdt ON dr.die_type_id = cdt.id ORDER BY serial ASC, dr.position ASC"
 				m.On("QueryContext", mock.Anything, expQuery, "room-1", 3).Once().Return(rows, nil)
 			},
 			expDiceRollList: &storage.DiceRollList{
@@ -384,3 +678,257 @@ func TestDiceRollRepositoryListUsers(t *testing.T) {
 		})
 	}
 }
+
+func TestDiceRollRepositoryGetDiceRoll(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	wantedErr := fmt.Errorf("wanted error")
+	columns := []string{"drs.id", "drs.created_at", "drs.room_id", "drs.user_id", "drs.expression", "drs.label", "drs.modifier", "drs.total", "drs.serial", "drs.server_seed_id", "drs.server_seed_hash", "drs.client_seed", "drs.nonce", "drs.pool_successes", "drs.pool_outcome", "drs.check_target", "drs.check_comparison", "drs.check_margin", "drs.check_outcome", "drs.visibility", "drs.whisper_user_ids", "drs.parent_id", "dr.id", "dr.die_type_id", "dr.side", "dr.status", "cdt.name", "cdt.faces"}
+
+	tests := map[string]struct {
+		config      mysql.DiceRollRepositoryConfig
+		mock        func(*mysqlmock.DBClient)
+		id          string
+		expDiceRoll *model.DiceRoll
+		expErr      error
+	}{
+		"Having an error while retrieving the dice roll should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("QueryContext", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+			},
+			id:     "dr1",
+			expErr: wantedErr,
+		},
+
+		"Having a missing dice roll should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows(columns))
+				m.On("QueryContext", mock.Anything, mock.Anything, mock.Anything).Once().Return(rows, nil)
+			},
+			id:     "dr1",
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Getting a dice roll should return the dice roll with its die rolls and proof correctly mapped.": {
+			mock: func(m *mysqlmock.DBClient) {
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows(columns).
+					AddRow("dr1", t0, "room-1", "user-1", "", "", 0, 9, 2, "seed-id", "seed-hash", "client-seed", 7, 0, 0, 0, 0, 0, 0, 0, "", "", "dr10", "d6", 5, 0, nil, nil).
+					AddRow("dr1", t0, "room-1", "user-1", "", "", 0, 9, 2, "seed-id", "seed-hash", "client-seed", 7, 0, 0, 0, 0, 0, 0, 0, "", "", "dr11", "d4", 4, 0, nil, nil))
+				expQuery := "SELECT drs.id, drs.created_at, drs.room_id, drs.user_id, drs.expression, drs.label, drs.modifier, drs.total, drs.serial, drs.server_seed_id, drs.server_seed_hash, drs.client_seed, drs.nonce, drs.pool_successes, drs.pool_outcome, drs.check_target, drs.check_comparison, drs.check_margin, drs.check_outcome, drs.visibility, drs.whisper_user_ids, drs.parent_id, dr.id, dr.die_type_id, dr.side, dr.status, cdt.name, cdt.faces FROM die_roll dr JOIN (SELECT id, created_at, room_id, user_id, expression, label, modifier, total, serial, server_seed_id, server_seed_hash, client_seed, nonce, pool_successes, pool_outcome, check_target, check_comparison, check_margin, check_outcome, visibility, whisper_user_ids, parent_id FROM dice_roll WHERE id = ?) AS drs ON dr.dice_roll_id = drs.id LEFT JOIN custom_die_type cdt ON dr.die_type_id = cdt.id ORDER BY dr.position ASC"
+				m.On("QueryContext", mock.Anything, expQuery, "dr1").Once().Return(rows, nil)
+			},
+			id: "dr1",
+			expDiceRoll: &model.DiceRoll{
+				ID:        "dr1",
+				RoomID:    "room-1",
+				CreatedAt: t0,
+				Serial:    2,
+				UserID:    "user-1",
+				Total:     9,
+				Dice: []model.DieRoll{
+					{ID: "dr10", Type: model.DieTypeD6, Side: 5},
+					{ID: "dr11", Type: model.DieTypeD4, Side: 4},
+				},
+				Proof: &model.DiceRollProof{
+					ServerSeedID:   "seed-id",
+					ServerSeedHash: "seed-hash",
+					ClientSeed:     "client-seed",
+					Nonce:          7,
+				},
+			},
+		},
+
+		"Getting a dice pool should return the dice roll with its dice pool outcome correctly mapped.": {
+			mock: func(m *mysqlmock.DBClient) {
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows(columns).
+					AddRow("dr1", t0, "room-1", "user-1", "", "", 0, 0, 2, "", "", "", 0, 0, 1, 0, 0, 0, 0, 0, "", "", "dr10", "d10", 1, 0, nil, nil).
+					AddRow("dr1", t0, "room-1", "user-1", "", "", 0, 0, 2, "", "", "", 0, 0, 1, 0, 0, 0, 0, 0, "", "", "dr11", "d10", 4, 0, nil, nil))
+				m.On("QueryContext", mock.Anything, mock.Anything, "dr1").Once().Return(rows, nil)
+			},
+			id: "dr1",
+			expDiceRoll: &model.DiceRoll{
+				ID:        "dr1",
+				RoomID:    "room-1",
+				CreatedAt: t0,
+				Serial:    2,
+				UserID:    "user-1",
+				Dice: []model.DieRoll{
+					{ID: "dr10", Type: model.DieTypeD10, Side: 1},
+					{ID: "dr11", Type: model.DieTypeD10, Side: 4},
+				},
+				Pool: &model.DicePoolResult{Successes: 0, Outcome: model.DicePoolOutcomeCriticalFailure},
+			},
+		},
+
+		"Getting a difficulty check should return the dice roll with its check outcome correctly mapped.": {
+			mock: func(m *mysqlmock.DBClient) {
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows(columns).
+					AddRow("dr1", t0, "room-1", "user-1", "", "", 5, 25, 2, "", "", "", 0, 0, 0, 15, 1, 10, 4, 0, "", "", "dr10", "d20", 20, 0, nil, nil))
+				m.On("QueryContext", mock.Anything, mock.Anything, "dr1").Once().Return(rows, nil)
+			},
+			id: "dr1",
+			expDiceRoll: &model.DiceRoll{
+				ID:        "dr1",
+				RoomID:    "room-1",
+				CreatedAt: t0,
+				Serial:    2,
+				UserID:    "user-1",
+				Modifier:  5,
+				Total:     25,
+				Dice: []model.DieRoll{
+					{ID: "dr10", Type: model.DieTypeD20, Side: 20},
+				},
+				Check: &model.DiceRollCheck{Target: 15, Comparison: model.DiceRollCheckComparisonGreaterOrEqual, Margin: 10, Outcome: model.DiceRollCheckOutcomeCriticalSuccess},
+			},
+		},
+
+		"Getting a whispered dice roll should return the dice roll with its visibility and whispered users correctly mapped.": {
+			mock: func(m *mysqlmock.DBClient) {
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows(columns).
+					AddRow("dr1", t0, "room-1", "user-1", "", "", 0, 4, 2, "", "", "", 0, 0, 0, 0, 0, 0, 0, 2, "user-2,user-3", "", "dr10", "d4", 4, 0, nil, nil))
+				m.On("QueryContext", mock.Anything, mock.Anything, "dr1").Once().Return(rows, nil)
+			},
+			id: "dr1",
+			expDiceRoll: &model.DiceRoll{
+				ID:             "dr1",
+				RoomID:         "room-1",
+				CreatedAt:      t0,
+				Serial:         2,
+				UserID:         "user-1",
+				Total:          4,
+				Dice:           []model.DieRoll{{ID: "dr10", Type: model.DieTypeD4, Side: 4}},
+				Visibility:     model.DiceRollVisibilityWhisper,
+				WhisperUserIDs: []string{"user-2", "user-3"},
+			},
+		},
+
+		"Getting a reroll should return the dice roll with its rerolled dice roll reference correctly mapped.": {
+			mock: func(m *mysqlmock.DBClient) {
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows(columns).
+					AddRow("dr2", t0, "room-1", "user-1", "", "", 0, 12, 3, "", "", "", 0, 0, 0, 0, 0, 0, 0, 0, "", "dr1", "dr20", "d20", 3, 2, nil, nil).
+					AddRow("dr2", t0, "room-1", "user-1", "", "", 0, 12, 3, "", "", "", 0, 0, 0, 0, 0, 0, 0, 0, "", "dr1", "dr21", "d20", 12, 0, nil, nil))
+				m.On("QueryContext", mock.Anything, mock.Anything, "dr2").Once().Return(rows, nil)
+			},
+			id: "dr2",
+			expDiceRoll: &model.DiceRoll{
+				ID:        "dr2",
+				RoomID:    "room-1",
+				CreatedAt: t0,
+				Serial:    3,
+				UserID:    "user-1",
+				Total:     12,
+				Dice: []model.DieRoll{
+					{ID: "dr20", Type: model.DieTypeD20, Side: 3, Status: model.DieRollStatusRerolled},
+					{ID: "dr21", Type: model.DieTypeD20, Side: 12},
+				},
+				ParentID: "dr1",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			test.config.DBClient = mdb
+			r, err := mysql.NewDiceRollRepository(test.config)
+			require.NoError(err)
+			gotDiceRoll, err := r.GetDiceRoll(context.TODO(), test.id)
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+				assert.Equal(test.expDiceRoll, gotDiceRoll)
+			}
+		})
+	}
+}
+
+func TestDiceRollRepositoryListDieRollSides(t *testing.T) {
+	wantedErr := fmt.Errorf("wanted error")
+
+	tests := map[string]struct {
+		mock     func(*mysqlmock.DBClient)
+		opts     storage.DieRollStatsOpts
+		expSides []storage.DieSide
+		expErr   error
+	}{
+		"Without room it should fail.": {
+			mock:   func(m *mysqlmock.DBClient) {},
+			opts:   storage.DieRollStatsOpts{Limit: 100},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Without limit it should fail.": {
+			mock:   func(m *mysqlmock.DBClient) {},
+			opts:   storage.DieRollStatsOpts{RoomID: "room-1"},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having an error while listing the die roll sides should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("QueryContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+			},
+			opts:   storage.DieRollStatsOpts{RoomID: "room-1", Limit: 100},
+			expErr: wantedErr,
+		},
+
+		"Listing the room die roll sides should list the latest public dice rolls that are not rerolls in roll order.": {
+			mock: func(m *mysqlmock.DBClient) {
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"dr.die_type_id", "dr.side"}).
+					AddRow("d6", 3).
+					AddRow("d20", 20).
+					AddRow("d6", 1))
+				expQuery := "SELECT dr.die_type_id, dr.side FROM die_roll dr JOIN dice_roll drs ON dr.dice_roll_id = drs.id WHERE drs.room_id = ? AND drs.visibility = ? AND drs.parent_id = ? ORDER BY drs.serial DESC, dr.position DESC LIMIT 100"
+				m.On("QueryContext", mock.Anything, expQuery, "room-1", uint(0), "").Once().Return(rows, nil)
+			},
+			opts: storage.DieRollStatsOpts{RoomID: "room-1", Limit: 100},
+			expSides: []storage.DieSide{
+				{DieTypeID: "d6", Side: 1},
+				{DieTypeID: "d20", Side: 20},
+				{DieTypeID: "d6", Side: 3},
+			},
+		},
+
+		"Listing the user die roll sides should filter by user.": {
+			mock: func(m *mysqlmock.DBClient) {
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"dr.die_type_id", "dr.side"}))
+				expQuery := "SELECT dr.die_type_id, dr.side FROM die_roll dr JOIN dice_roll drs ON dr.dice_roll_id = drs.id WHERE drs.room_id = ? AND drs.visibility = ? AND drs.parent_id = ? AND drs.user_id = ? ORDER BY drs.serial DESC, dr.position DESC LIMIT 100"
+				m.On("QueryContext", mock.Anything, expQuery, "room-1", uint(0), "", "user-1").Once().Return(rows, nil)
+			},
+			opts:     storage.DieRollStatsOpts{RoomID: "room-1", UserID: "user-1", Limit: 100},
+			expSides: []storage.DieSide{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			r, err := mysql.NewDiceRollRepository(mysql.DiceRollRepositoryConfig{DBClient: mdb})
+			require.NoError(err)
+			gotSides, err := r.ListDieRollSides(context.TODO(), test.opts)
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				assert.Equal(test.expSides, gotSides)
+			}
+			mdb.AssertExpectations(t)
+		})
+	}
+}
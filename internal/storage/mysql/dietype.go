@@ -0,0 +1,182 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/log"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+)
+
+// CustomDieTypeRepositoryConfig is the CustomDieTypeRepository configuration.
+type CustomDieTypeRepositoryConfig struct {
+	DBClient DBClient
+	Table    string
+	Logger   log.Logger
+}
+
+func (c *CustomDieTypeRepositoryConfig) defaults() error {
+	if c.DBClient == nil {
+		return fmt.Errorf("config.DBClient is required")
+	}
+
+	if c.Table == "" {
+		c.Table = "custom_die_type"
+	}
+
+	if c.Logger == nil {
+		c.Logger = log.Dummy
+	}
+
+	c.Logger = c.Logger.WithKV(log.KV{
+		"repository":      "customDieType",
+		"repository-type": "mysql",
+	})
+
+	return nil
+}
+
+// CustomDieTypeRepository is a repository with MySQL implementation.
+type CustomDieTypeRepository struct {
+	db     DBClient
+	table  string
+	logger log.Logger
+}
+
+// NewCustomDieTypeRepository returns a new CustomDieTypeRepository.
+func NewCustomDieTypeRepository(cfg CustomDieTypeRepositoryConfig) (*CustomDieTypeRepository, error) {
+	err := cfg.defaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return &CustomDieTypeRepository{
+		db:     cfg.DBClient,
+		table:  cfg.Table,
+		logger: cfg.Logger,
+	}, nil
+}
+
+// CreateCustomDieType satisfies storage.CustomDieTypeRepository interface.
+func (r *CustomDieTypeRepository) CreateCustomDieType(ctx context.Context, dt model.CustomDieType) error {
+	// Map and create query.
+	sqlDT, err := modelToSQLCustomDieType(dt)
+	if err != nil {
+		return fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+	query, args := customDieTypeSQLBuilder.InsertInto(r.table, sqlDT).Build()
+
+	// Insert in database.
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return fmt.Errorf("%w: %s", internalerrors.ErrAlreadyExists, err)
+		}
+
+		return fmt.Errorf("could not insert custom die type: %w", err)
+	}
+
+	return nil
+}
+
+// ListRoomCustomDieTypes satisfies storage.CustomDieTypeRepository interface.
+func (r *CustomDieTypeRepository) ListRoomCustomDieTypes(ctx context.Context, roomID string) (*storage.CustomDieTypeList, error) {
+	sb := customDieTypeSQLBuilder.SelectFrom(r.table)
+	sb.Where(sb.Equal("room_id", roomID)).OrderBy("serial ASC")
+	query, args := sb.Build()
+
+	// Get from database.
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("could not list custom die types: %w", err)
+	}
+	defer rows.Close()
+
+	dts := []model.CustomDieType{}
+	sdt := &sqlCustomDieType{} // Reuse this, when mapping to model we will have a new instance.
+	for rows.Next() {
+		err := rows.Scan(customDieTypeSQLBuilder.Addr(sdt)...)
+		if err != nil {
+			return nil, fmt.Errorf("could not scan SQL custom die types: %w", err)
+		}
+
+		dt, err := sqlToModelCustomDieType(sdt.ID, sdt.RoomID, sdt.Name, sdt.Faces)
+		if err != nil {
+			return nil, fmt.Errorf("could not map SQL custom die type to model: %w", err)
+		}
+		dts = append(dts, *dt)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("could not list custom die types: %w", err)
+	}
+
+	return &storage.CustomDieTypeList{
+		Items: dts,
+	}, nil
+}
+
+type sqlCustomDieType struct {
+	ID     string `db:"id"`
+	RoomID string `db:"room_id"`
+	Name   string `db:"name"`
+	// Faces is the JSON encoded face table.
+	Faces string `db:"faces"`
+}
+
+type jsonDieFace struct {
+	Label string `json:"label"`
+	Value int    `json:"value"`
+}
+
+func modelToSQLCustomDieType(dt model.CustomDieType) (*sqlCustomDieType, error) {
+	faces := make([]jsonDieFace, 0, len(dt.FaceTable))
+	for _, f := range dt.FaceTable {
+		faces = append(faces, jsonDieFace{Label: f.Label, Value: f.Value})
+	}
+
+	jf, err := json.Marshal(faces)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal faces: %w", err)
+	}
+
+	return &sqlCustomDieType{
+		ID:     dt.TypeID,
+		RoomID: dt.RoomID,
+		Name:   dt.TypeName,
+		Faces:  string(jf),
+	}, nil
+}
+
+func sqlToModelCustomDieType(id, roomID, name, jsonFaces string) (*model.CustomDieType, error) {
+	faces := []jsonDieFace{}
+	err := json.Unmarshal([]byte(jsonFaces), &faces)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal faces: %w", err)
+	}
+
+	ft := make([]model.DieFace, 0, len(faces))
+	for _, f := range faces {
+		ft = append(ft, model.DieFace{Label: f.Label, Value: f.Value})
+	}
+
+	return &model.CustomDieType{
+		TypeID:    id,
+		RoomID:    roomID,
+		TypeName:  name,
+		FaceTable: ft,
+	}, nil
+}
+
+// Used as a light ORM by sqlbuilder.
+var customDieTypeSQLBuilder = sqlbuilder.NewStruct(&sqlCustomDieType{})
+
+// Implementation assertions.
+var _ storage.CustomDieTypeRepository = &CustomDieTypeRepository{}
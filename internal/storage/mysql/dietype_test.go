@@ -0,0 +1,184 @@
+package mysql_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	drivermysql "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+	"github.com/rollify/rollify/internal/storage/mysql"
+	"github.com/rollify/rollify/internal/storage/mysql/mysqlmock"
+)
+
+func TestCustomDieTypeRepositoryCreateCustomDieType(t *testing.T) {
+	wantedErr := fmt.Errorf("wanted error")
+	coin := model.CustomDieType{
+		TypeID:    "coin-id",
+		RoomID:    "room-id",
+		TypeName:  "Coin",
+		FaceTable: []model.DieFace{{Label: "Heads", Value: 1}, {Label: "Tails", Value: 0}},
+	}
+
+	tests := map[string]struct {
+		config mysql.CustomDieTypeRepositoryConfig
+		mock   func(*mysqlmock.DBClient)
+		dt     model.CustomDieType
+		expErr error
+	}{
+		"Having an error while storing the die type, should error.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+			},
+			dt:     coin,
+			expErr: wantedErr,
+		},
+
+		"Creating the same die type when already exists, should error.": {
+			mock: func(m *mysqlmock.DBClient) {
+				err := &drivermysql.MySQLError{Number: 1062}
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, err)
+			},
+			dt:     coin,
+			expErr: internalerrors.ErrAlreadyExists,
+		},
+
+		"Creating a die type should store the die type with the faces.": {
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "INSERT INTO custom_die_type (id, room_id, name, faces) VALUES (?, ?, ?, ?)"
+				expFaces := `[{"label":"Heads","value":1},{"label":"Tails","value":0}]`
+				m.On("ExecContext", mock.Anything, expQuery, "coin-id", "room-id", "Coin", expFaces).Once().Return(nil, nil)
+			},
+			dt: coin,
+		},
+
+		"Creating a die type in a custom table should store the die type.": {
+			config: mysql.CustomDieTypeRepositoryConfig{
+				Table: "custom-table",
+			},
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "INSERT INTO custom-table (id, room_id, name, faces) VALUES (?, ?, ?, ?)"
+				expFaces := `[{"label":"Heads","value":1},{"label":"Tails","value":0}]`
+				m.On("ExecContext", mock.Anything, expQuery, "coin-id", "room-id", "Coin", expFaces).Once().Return(nil, nil)
+			},
+			dt: coin,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			test.config.DBClient = mdb
+			r, err := mysql.NewCustomDieTypeRepository(test.config)
+			require.NoError(err)
+			err = r.CreateCustomDieType(context.TODO(), test.dt)
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestCustomDieTypeRepositoryListRoomCustomDieTypes(t *testing.T) {
+	wantedErr := fmt.Errorf("wanted error")
+
+	tests := map[string]struct {
+		config  mysql.CustomDieTypeRepositoryConfig
+		mock    func(*mysqlmock.DBClient)
+		roomID  string
+		expList *storage.CustomDieTypeList
+		expErr  bool
+	}{
+		"Having an error while listing the die types, should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("QueryContext", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+			},
+			roomID: "room-id",
+			expErr: true,
+		},
+
+		"Having invalid faces stored, should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"id", "room_id", "name", "faces"}).
+					AddRow("coin-id", "room-id", "Coin", "{"))
+
+				m.On("QueryContext", mock.Anything, mock.Anything, mock.Anything).Once().Return(rows, nil)
+			},
+			roomID: "room-id",
+			expErr: true,
+		},
+
+		"Listing the die types of a room should return the room die types.": {
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "SELECT custom_die_type.id, custom_die_type.room_id, custom_die_type.name, custom_die_type.faces FROM custom_die_type WHERE room_id = ? ORDER BY serial ASC"
+
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"id", "room_id", "name", "faces"}).
+					AddRow("coin-id", "room-id", "Coin", `[{"label":"Heads","value":1},{"label":"Tails","value":0}]`).
+					AddRow("fate-id", "room-id", "Fate", `[{"label":"-","value":-1},{"label":"","value":0},{"label":"+","value":1}]`))
+
+				m.On("QueryContext", mock.Anything, expQuery, "room-id").Once().Return(rows, nil)
+			},
+			roomID: "room-id",
+			expList: &storage.CustomDieTypeList{
+				Items: []model.CustomDieType{
+					{
+						TypeID:    "coin-id",
+						RoomID:    "room-id",
+						TypeName:  "Coin",
+						FaceTable: []model.DieFace{{Label: "Heads", Value: 1}, {Label: "Tails", Value: 0}},
+					},
+					{
+						TypeID:    "fate-id",
+						RoomID:    "room-id",
+						TypeName:  "Fate",
+						FaceTable: []model.DieFace{{Label: "-", Value: -1}, {Label: "", Value: 0}, {Label: "+", Value: 1}},
+					},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			test.config.DBClient = mdb
+			r, err := mysql.NewCustomDieTypeRepository(test.config)
+			require.NoError(err)
+			gotList, err := r.ListRoomCustomDieTypes(context.TODO(), test.roomID)
+
+			// Check.
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+				assert.Equal(test.expList, gotList)
+			}
+		})
+	}
+}
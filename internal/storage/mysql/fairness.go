@@ -0,0 +1,215 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/log"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+)
+
+// ServerSeedRepositoryConfig is the ServerSeedRepository configuration.
+type ServerSeedRepositoryConfig struct {
+	DBClient DBClient
+	Table    string
+	Logger   log.Logger
+}
+
+func (c *ServerSeedRepositoryConfig) defaults() error {
+	if c.DBClient == nil {
+		return fmt.Errorf("config.DBClient is required")
+	}
+
+	if c.Table == "" {
+		c.Table = "server_seed"
+	}
+
+	if c.Logger == nil {
+		c.Logger = log.Dummy
+	}
+
+	c.Logger = c.Logger.WithKV(log.KV{
+		"repository":      "serverSeed",
+		"repository-type": "mysql",
+	})
+
+	return nil
+}
+
+// ServerSeedRepository is a repository with MySQL implementation.
+type ServerSeedRepository struct {
+	db     DBClient
+	table  string
+	logger log.Logger
+}
+
+// NewServerSeedRepository returns a new ServerSeedRepository.
+func NewServerSeedRepository(cfg ServerSeedRepositoryConfig) (*ServerSeedRepository, error) {
+	err := cfg.defaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return &ServerSeedRepository{
+		db:     cfg.DBClient,
+		table:  cfg.Table,
+		logger: cfg.Logger,
+	}, nil
+}
+
+// CreateServerSeed satisfies storage.ServerSeedRepository interface.
+func (r *ServerSeedRepository) CreateServerSeed(ctx context.Context, s model.ServerSeed) error {
+	// Map and create query.
+	sqlSS := modelToSQLServerSeed(s)
+	query, args := serverSeedSQLBuilder.InsertInto(r.table, sqlSS).Build()
+
+	// Insert in database.
+	_, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return fmt.Errorf("%w: %s", internalerrors.ErrAlreadyExists, err)
+		}
+
+		return fmt.Errorf("could not insert server seed: %w", err)
+	}
+
+	return nil
+}
+
+// GetServerSeed satisfies storage.ServerSeedRepository interface.
+func (r *ServerSeedRepository) GetServerSeed(ctx context.Context, id string) (*model.ServerSeed, error) {
+	sb := serverSeedSQLBuilder.SelectFrom(r.table)
+	sb.Where(sb.Equal("id", id))
+
+	return r.getServerSeed(ctx, sb)
+}
+
+// GetRoomActiveServerSeed satisfies storage.ServerSeedRepository interface.
+func (r *ServerSeedRepository) GetRoomActiveServerSeed(ctx context.Context, roomID string) (*model.ServerSeed, error) {
+	sb := serverSeedSQLBuilder.SelectFrom(r.table)
+	sb.Where(sb.Equal("room_id", roomID), sb.Equal("revealed", false)).
+		OrderBy("serial DESC").
+		Limit(1)
+
+	return r.getServerSeed(ctx, sb)
+}
+
+func (r *ServerSeedRepository) getServerSeed(ctx context.Context, sb *sqlbuilder.SelectBuilder) (*model.ServerSeed, error) {
+	query, args := sb.Build()
+
+	// Get from database.
+	row := r.db.QueryRowContext(ctx, query, args...)
+	ss := &sqlServerSeed{}
+	err := row.Scan(serverSeedSQLBuilder.Addr(ss)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("missing server seed: %w: %s", internalerrors.ErrMissing, err)
+		}
+
+		return nil, fmt.Errorf("could not get server seed: %w", err)
+	}
+
+	return sqlToModelServerSeed(ss), nil
+}
+
+// IncreaseServerSeedNonce satisfies storage.ServerSeedRepository interface.
+func (r *ServerSeedRepository) IncreaseServerSeedNonce(ctx context.Context, id string) (uint, error) {
+	// Use `LAST_INSERT_ID(expr)` so we get the increased nonce atomically, without
+	// another query that could race with other dice rolls.
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(r.table).
+		Set("nonce = LAST_INSERT_ID(nonce + 1)").
+		Where(ub.Equal("id", id), ub.Equal("revealed", false))
+	query, args := ub.Build()
+
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("could not increase server seed nonce: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("could not get affected rows: %w", err)
+	}
+	if n == 0 {
+		return 0, fmt.Errorf("missing active server seed: %w", internalerrors.ErrMissing)
+	}
+
+	nonce, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("could not get the increased nonce: %w", err)
+	}
+
+	return uint(nonce), nil
+}
+
+// RevealServerSeed satisfies storage.ServerSeedRepository interface.
+func (r *ServerSeedRepository) RevealServerSeed(ctx context.Context, id string) error {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(r.table).
+		Set(ub.Assign("revealed", true)).
+		Where(ub.Equal("id", id), ub.Equal("revealed", false))
+	query, args := ub.Build()
+
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not reveal server seed: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get affected rows: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("missing active server seed: %w", internalerrors.ErrMissing)
+	}
+
+	return nil
+}
+
+type sqlServerSeed struct {
+	ID        string    `db:"id"`
+	RoomID    string    `db:"room_id"`
+	CreatedAt time.Time `db:"created_at"`
+	Seed      string    `db:"seed"`
+	Hash      string    `db:"hash"`
+	Nonce     uint      `db:"nonce"`
+	Revealed  bool      `db:"revealed"`
+}
+
+func modelToSQLServerSeed(s model.ServerSeed) *sqlServerSeed {
+	return &sqlServerSeed{
+		ID:        s.ID,
+		RoomID:    s.RoomID,
+		CreatedAt: s.CreatedAt,
+		Seed:      s.Seed,
+		Hash:      s.Hash,
+		Nonce:     s.Nonce,
+		Revealed:  s.Revealed,
+	}
+}
+
+func sqlToModelServerSeed(s *sqlServerSeed) *model.ServerSeed {
+	return &model.ServerSeed{
+		ID:        s.ID,
+		RoomID:    s.RoomID,
+		CreatedAt: s.CreatedAt,
+		Seed:      s.Seed,
+		Hash:      s.Hash,
+		Nonce:     s.Nonce,
+		Revealed:  s.Revealed,
+	}
+}
+
+// Used as a light ORM by sqlbuilder.
+var serverSeedSQLBuilder = sqlbuilder.NewStruct(&sqlServerSeed{})
+
+// Implementation assertions.
+var _ storage.ServerSeedRepository = &ServerSeedRepository{}
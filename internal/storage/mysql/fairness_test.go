@@ -0,0 +1,281 @@
+package mysql_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	drivermysql "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage/mysql"
+	"github.com/rollify/rollify/internal/storage/mysql/mysqlmock"
+)
+
+func TestServerSeedRepositoryCreateServerSeed(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	wantedErr := fmt.Errorf("wanted error")
+	seed := model.ServerSeed{ID: "seed-id", RoomID: "room-id", CreatedAt: t0, Seed: "seed", Hash: "hash"}
+
+	tests := map[string]struct {
+		config mysql.ServerSeedRepositoryConfig
+		mock   func(*mysqlmock.DBClient)
+		seed   model.ServerSeed
+		expErr error
+	}{
+		"Having an error while storing the server seed, should error.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+			},
+			seed:   seed,
+			expErr: wantedErr,
+		},
+
+		"Creating the same server seed when already exists, should error.": {
+			mock: func(m *mysqlmock.DBClient) {
+				err := &drivermysql.MySQLError{Number: 1062}
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, err)
+			},
+			seed:   seed,
+			expErr: internalerrors.ErrAlreadyExists,
+		},
+
+		"Creating a server seed should store the server seed.": {
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "INSERT INTO server_seed (id, room_id, created_at, seed, hash, nonce, revealed) VALUES (?, ?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "seed-id", "room-id", t0, "seed", "hash", uint(0), false).Once().Return(nil, nil)
+			},
+			seed: seed,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			test.config.DBClient = mdb
+			r, err := mysql.NewServerSeedRepository(test.config)
+			require.NoError(err)
+			err = r.CreateServerSeed(context.TODO(), test.seed)
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestServerSeedRepositoryGetServerSeed(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	wantedErr := fmt.Errorf("wanted error")
+	columns := []string{"id", "room_id", "created_at", "seed", "hash", "nonce", "revealed"}
+
+	tests := map[string]struct {
+		config  mysql.ServerSeedRepositoryConfig
+		mock    func(*mysqlmock.DBClient)
+		active  bool
+		id      string
+		expSeed *model.ServerSeed
+		expErr  error
+	}{
+		"Having an error while retrieving the server seed should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				row := sqlRowErr(wantedErr)
+				m.On("QueryRowContext", mock.Anything, mock.Anything, mock.Anything).Once().Return(row)
+			},
+			id:     "seed-id",
+			expErr: wantedErr,
+		},
+
+		"Retrieving a missing server seed, should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				row := sqlRowErr(sql.ErrNoRows)
+				m.On("QueryRowContext", mock.Anything, mock.Anything, mock.Anything).Once().Return(row)
+			},
+			id:     "seed-id",
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Retrieving a server seed should get the server seed.": {
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "SELECT server_seed.id, server_seed.room_id, server_seed.created_at, server_seed.seed, server_seed.hash, server_seed.nonce, server_seed.revealed FROM server_seed WHERE id = ?"
+				row := sqlmockRowsToStdRow(sqlmock.NewRows(columns).AddRow("seed-id", "room-id", t0, "seed", "hash", 4, true))
+				m.On("QueryRowContext", mock.Anything, expQuery, "seed-id").Once().Return(row)
+			},
+			id:      "seed-id",
+			expSeed: &model.ServerSeed{ID: "seed-id", RoomID: "room-id", CreatedAt: t0, Seed: "seed", Hash: "hash", Nonce: 4, Revealed: true},
+		},
+
+		"Retrieving the active server seed of a room should get the latest not revealed server seed.": {
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "SELECT server_seed.id, server_seed.room_id, server_seed.created_at, server_seed.seed, server_seed.hash, server_seed.nonce, server_seed.revealed FROM server_seed WHERE room_id = ? AND revealed = ? ORDER BY serial DESC LIMIT 1"
+				row := sqlmockRowsToStdRow(sqlmock.NewRows(columns).AddRow("seed-id", "room-id", t0, "seed", "hash", 4, false))
+				m.On("QueryRowContext", mock.Anything, expQuery, "room-id", false).Once().Return(row)
+			},
+			active:  true,
+			id:      "room-id",
+			expSeed: &model.ServerSeed{ID: "seed-id", RoomID: "room-id", CreatedAt: t0, Seed: "seed", Hash: "hash", Nonce: 4},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			test.config.DBClient = mdb
+			r, err := mysql.NewServerSeedRepository(test.config)
+			require.NoError(err)
+
+			var gotSeed *model.ServerSeed
+			if test.active {
+				gotSeed, err = r.GetRoomActiveServerSeed(context.TODO(), test.id)
+			} else {
+				gotSeed, err = r.GetServerSeed(context.TODO(), test.id)
+			}
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+				assert.Equal(test.expSeed, gotSeed)
+			}
+		})
+	}
+}
+
+func TestServerSeedRepositoryIncreaseServerSeedNonce(t *testing.T) {
+	wantedErr := fmt.Errorf("wanted error")
+
+	tests := map[string]struct {
+		config   mysql.ServerSeedRepositoryConfig
+		mock     func(*mysqlmock.DBClient)
+		expNonce uint
+		expErr   error
+	}{
+		"Having an error while increasing the nonce should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+			},
+			expErr: wantedErr,
+		},
+
+		"Increasing the nonce of a missing or revealed server seed should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(sqlmock.NewResult(0, 0), nil)
+			},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Increasing the nonce should return the increased nonce.": {
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "UPDATE server_seed SET nonce = LAST_INSERT_ID(nonce + 1) WHERE id = ? AND revealed = ?"
+				m.On("ExecContext", mock.Anything, expQuery, "seed-id", false).Once().Return(sqlmock.NewResult(5, 1), nil)
+			},
+			expNonce: 5,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			test.config.DBClient = mdb
+			r, err := mysql.NewServerSeedRepository(test.config)
+			require.NoError(err)
+			gotNonce, err := r.IncreaseServerSeedNonce(context.TODO(), "seed-id")
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+				assert.Equal(test.expNonce, gotNonce)
+			}
+		})
+	}
+}
+
+func TestServerSeedRepositoryRevealServerSeed(t *testing.T) {
+	wantedErr := fmt.Errorf("wanted error")
+
+	tests := map[string]struct {
+		config mysql.ServerSeedRepositoryConfig
+		mock   func(*mysqlmock.DBClient)
+		expErr error
+	}{
+		"Having an error while revealing the server seed should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+			},
+			expErr: wantedErr,
+		},
+
+		"Revealing a missing or revealed server seed should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(sqlmock.NewResult(0, 0), nil)
+			},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Revealing a server seed should mark it as revealed.": {
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "UPDATE server_seed SET revealed = ? WHERE id = ? AND revealed = ?"
+				m.On("ExecContext", mock.Anything, expQuery, true, "seed-id", false).Once().Return(sqlmock.NewResult(0, 1), nil)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			test.config.DBClient = mdb
+			r, err := mysql.NewServerSeedRepository(test.config)
+			require.NoError(err)
+			err = r.RevealServerSeed(context.TODO(), "seed-id")
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/log"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+)
+
+// InitiativeRepositoryConfig is the InitiativeRepository configuration.
+type InitiativeRepositoryConfig struct {
+	DBClient DBClient
+	Table    string
+	Logger   log.Logger
+}
+
+func (c *InitiativeRepositoryConfig) defaults() error {
+	if c.DBClient == nil {
+		return fmt.Errorf("config.DBClient is required")
+	}
+
+	if c.Table == "" {
+		c.Table = "initiative"
+	}
+
+	if c.Logger == nil {
+		c.Logger = log.Dummy
+	}
+
+	c.Logger = c.Logger.WithKV(log.KV{
+		"repository":      "initiative",
+		"repository-type": "mysql",
+	})
+
+	return nil
+}
+
+// InitiativeRepository is a repository with MySQL implementation.
+type InitiativeRepository struct {
+	db     DBClient
+	table  string
+	logger log.Logger
+}
+
+// NewInitiativeRepository returns a new InitiativeRepository.
+func NewInitiativeRepository(cfg InitiativeRepositoryConfig) (*InitiativeRepository, error) {
+	err := cfg.defaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return &InitiativeRepository{
+		db:     cfg.DBClient,
+		table:  cfg.Table,
+		logger: cfg.Logger,
+	}, nil
+}
+
+// GetInitiative satisfies storage.InitiativeRepository interface.
+func (r *InitiativeRepository) GetInitiative(ctx context.Context, roomID string) (*model.Initiative, error) {
+	sb := initiativeSQLBuilder.SelectFrom(r.table)
+	sb.Where(sb.Equal("room_id", roomID))
+	query, args := sb.Build()
+
+	// Get from database.
+	row := r.db.QueryRowContext(ctx, query, args...)
+	si := &sqlInitiative{}
+	err := row.Scan(initiativeSQLBuilder.Addr(si)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("missing initiative: %w: %s", internalerrors.ErrMissing, err)
+		}
+
+		return nil, fmt.Errorf("could not get initiative: %w", err)
+	}
+
+	i, err := sqlToModelInitiative(si)
+	if err != nil {
+		return nil, fmt.Errorf("could not map initiative: %w", err)
+	}
+
+	return i, nil
+}
+
+// SaveInitiative satisfies storage.InitiativeRepository interface.
+func (r *InitiativeRepository) SaveInitiative(ctx context.Context, i model.Initiative) error {
+	if i.RoomID == "" {
+		return fmt.Errorf("room ID is required: %w", internalerrors.ErrNotValid)
+	}
+
+	// Map and create query, the room initiative is replaced if already exists.
+	si, err := modelToSQLInitiative(i)
+	if err != nil {
+		return fmt.Errorf("could not map initiative: %w", err)
+	}
+	query, args := initiativeSQLBuilder.ReplaceInto(r.table, si).Build()
+
+	// Store in database.
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not store initiative: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteInitiative satisfies storage.InitiativeRepository interface.
+func (r *InitiativeRepository) DeleteInitiative(ctx context.Context, roomID string) error {
+	db := sqlbuilder.NewDeleteBuilder()
+	db.DeleteFrom(r.table).Where(db.Equal("room_id", roomID))
+	query, args := db.Build()
+
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not delete initiative: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get affected rows: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("missing initiative: %w", internalerrors.ErrMissing)
+	}
+
+	return nil
+}
+
+type sqlInitiative struct {
+	RoomID    string    `db:"room_id"`
+	UpdatedAt time.Time `db:"updated_at"`
+	Round     uint      `db:"round"`
+	Turn      int       `db:"turn"`
+	// Combatants are the JSON encoded combatants in turn order.
+	Combatants string `db:"combatants"`
+}
+
+type jsonInitiativeCombatant struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	UserID     string `json:"user_id,omitempty"`
+	Initiative int    `json:"initiative"`
+	DiceRollID string `json:"dice_roll_id,omitempty"`
+}
+
+func modelToSQLInitiative(i model.Initiative) (*sqlInitiative, error) {
+	cs := make([]jsonInitiativeCombatant, 0, len(i.Combatants))
+	for _, c := range i.Combatants {
+		cs = append(cs, jsonInitiativeCombatant{
+			ID:         c.ID,
+			Name:       c.Name,
+			UserID:     c.UserID,
+			Initiative: c.Initiative,
+			DiceRollID: c.DiceRollID,
+		})
+	}
+
+	jcs, err := json.Marshal(cs)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal combatants: %w", err)
+	}
+
+	return &sqlInitiative{
+		RoomID:     i.RoomID,
+		UpdatedAt:  i.UpdatedAt,
+		Round:      i.Round,
+		Turn:       i.Turn,
+		Combatants: string(jcs),
+	}, nil
+}
+
+func sqlToModelInitiative(si *sqlInitiative) (*model.Initiative, error) {
+	cs := []jsonInitiativeCombatant{}
+	err := json.Unmarshal([]byte(si.Combatants), &cs)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal combatants: %w", err)
+	}
+
+	mcs := make([]model.InitiativeCombatant, 0, len(cs))
+	for _, c := range cs {
+		mcs = append(mcs, model.InitiativeCombatant{
+			ID:         c.ID,
+			Name:       c.Name,
+			UserID:     c.UserID,
+			Initiative: c.Initiative,
+			DiceRollID: c.DiceRollID,
+		})
+	}
+
+	return &model.Initiative{
+		RoomID:     si.RoomID,
+		UpdatedAt:  si.UpdatedAt,
+		Round:      si.Round,
+		Turn:       si.Turn,
+		Combatants: mcs,
+	}, nil
+}
+
+// Used as a light ORM by sqlbuilder.
+var initiativeSQLBuilder = sqlbuilder.NewStruct(&sqlInitiative{})
+
+// Implementation assertions.
+var _ storage.InitiativeRepository = &InitiativeRepository{}
@@ -0,0 +1,225 @@
+package mysql_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage/mysql"
+	"github.com/rollify/rollify/internal/storage/mysql/mysqlmock"
+)
+
+var initiativeColumns = []string{"room_id", "updated_at", "round", "turn", "combatants"}
+
+func TestInitiativeRepositoryGetInitiative(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	wantedErr := fmt.Errorf("wanted error")
+
+	tests := map[string]struct {
+		mock          func(*mysqlmock.DBClient)
+		expInitiative *model.Initiative
+		expErr        error
+	}{
+		"Having an error while retrieving the initiative should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				row := sqlRowErr(wantedErr)
+				m.On("QueryRowContext", mock.Anything, mock.Anything, mock.Anything).Once().Return(row)
+			},
+			expErr: wantedErr,
+		},
+
+		"Retrieving a missing initiative, should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				row := sqlRowErr(sql.ErrNoRows)
+				m.On("QueryRowContext", mock.Anything, mock.Anything, mock.Anything).Once().Return(row)
+			},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Retrieving an initiative should get the initiative with its combatants.": {
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "SELECT initiative.room_id, initiative.updated_at, initiative.round, initiative.turn, initiative.combatants FROM initiative WHERE room_id = ?"
+				combatants := `[{"id":"c1","name":"Goblin","initiative":18,"dice_roll_id":"dr1"},{"id":"c2","name":"Aragorn","user_id":"user-id","initiative":12}]`
+				row := sqlmockRowsToStdRow(sqlmock.NewRows(initiativeColumns).AddRow("room-id", t0, 2, 1, combatants))
+				m.On("QueryRowContext", mock.Anything, expQuery, "room-id").Once().Return(row)
+			},
+			expInitiative: &model.Initiative{
+				RoomID:    "room-id",
+				UpdatedAt: t0,
+				Round:     2,
+				Turn:      1,
+				Combatants: []model.InitiativeCombatant{
+					{ID: "c1", Name: "Goblin", Initiative: 18, DiceRollID: "dr1"},
+					{ID: "c2", Name: "Aragorn", UserID: "user-id", Initiative: 12},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			r, err := mysql.NewInitiativeRepository(mysql.InitiativeRepositoryConfig{DBClient: mdb})
+			require.NoError(err)
+			gotInitiative, err := r.GetInitiative(context.TODO(), "room-id")
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+				assert.Equal(test.expInitiative, gotInitiative)
+			}
+		})
+	}
+}
+
+func TestInitiativeRepositorySaveInitiative(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	wantedErr := fmt.Errorf("wanted error")
+	initiative := model.Initiative{
+		RoomID:    "room-id",
+		UpdatedAt: t0,
+		Round:     2,
+		Turn:      1,
+		Combatants: []model.InitiativeCombatant{
+			{ID: "c1", Name: "Goblin", Initiative: 18, DiceRollID: "dr1"},
+			{ID: "c2", Name: "Aragorn", UserID: "user-id", Initiative: 12},
+		},
+	}
+
+	tests := map[string]struct {
+		config     mysql.InitiativeRepositoryConfig
+		mock       func(*mysqlmock.DBClient)
+		initiative model.Initiative
+		expErr     error
+	}{
+		"Having an initiative without room should fail.": {
+			mock:       func(m *mysqlmock.DBClient) {},
+			initiative: model.Initiative{},
+			expErr:     internalerrors.ErrNotValid,
+		},
+
+		"Having an error while storing the initiative, should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+			},
+			initiative: initiative,
+			expErr:     wantedErr,
+		},
+
+		"Saving an initiative should replace the room initiative.": {
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "REPLACE INTO initiative (room_id, updated_at, round, turn, combatants) VALUES (?, ?, ?, ?, ?)"
+				expCombatants := `[{"id":"c1","name":"Goblin","initiative":18,"dice_roll_id":"dr1"},{"id":"c2","name":"Aragorn","user_id":"user-id","initiative":12}]`
+				m.On("ExecContext", mock.Anything, expQuery, "room-id", t0, uint(2), 1, expCombatants).Once().Return(nil, nil)
+			},
+			initiative: initiative,
+		},
+
+		"Saving an initiative in a custom table should replace the room initiative.": {
+			config: mysql.InitiativeRepositoryConfig{
+				Table: "custom-table",
+			},
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "REPLACE INTO custom-table (room_id, updated_at, round, turn, combatants) VALUES (?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "room-id", t0, uint(1), 0, "[]").Once().Return(nil, nil)
+			},
+			initiative: model.Initiative{RoomID: "room-id", UpdatedAt: t0, Round: 1},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			test.config.DBClient = mdb
+			r, err := mysql.NewInitiativeRepository(test.config)
+			require.NoError(err)
+			err = r.SaveInitiative(context.TODO(), test.initiative)
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestInitiativeRepositoryDeleteInitiative(t *testing.T) {
+	wantedErr := fmt.Errorf("wanted error")
+	expQuery := "DELETE FROM initiative WHERE room_id = ?"
+
+	tests := map[string]struct {
+		mock   func(*mysqlmock.DBClient)
+		expErr error
+	}{
+		"Having an error while deleting the initiative, should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+			},
+			expErr: wantedErr,
+		},
+
+		"Deleting a missing initiative, should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, expQuery, "room-id").Once().Return(sqlmock.NewResult(0, 0), nil)
+			},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Deleting an initiative should delete the initiative.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, expQuery, "room-id").Once().Return(sqlmock.NewResult(0, 1), nil)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			r, err := mysql.NewInitiativeRepository(mysql.InitiativeRepositoryConfig{DBClient: mdb})
+			require.NoError(err)
+			err = r.DeleteInitiative(context.TODO(), "room-id")
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/log"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+)
+
+// MacroRepositoryConfig is the MacroRepository configuration.
+type MacroRepositoryConfig struct {
+	DBClient DBClient
+	Table    string
+	Logger   log.Logger
+}
+
+func (c *MacroRepositoryConfig) defaults() error {
+	if c.DBClient == nil {
+		return fmt.Errorf("config.DBClient is required")
+	}
+
+	if c.Table == "" {
+		c.Table = "macro"
+	}
+
+	if c.Logger == nil {
+		c.Logger = log.Dummy
+	}
+
+	c.Logger = c.Logger.WithKV(log.KV{
+		"repository":      "macro",
+		"repository-type": "mysql",
+	})
+
+	return nil
+}
+
+// MacroRepository is a repository with MySQL implementation.
+type MacroRepository struct {
+	db     DBClient
+	table  string
+	logger log.Logger
+}
+
+// NewMacroRepository returns a new MacroRepository.
+func NewMacroRepository(cfg MacroRepositoryConfig) (*MacroRepository, error) {
+	err := cfg.defaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return &MacroRepository{
+		db:     cfg.DBClient,
+		table:  cfg.Table,
+		logger: cfg.Logger,
+	}, nil
+}
+
+// CreateMacro satisfies storage.MacroRepository interface.
+func (r *MacroRepository) CreateMacro(ctx context.Context, m model.Macro) error {
+	// Map and create query.
+	sqlM := modelToSQLMacro(m)
+	query, args := macroSQLBuilder.InsertInto(r.table, sqlM).Build()
+
+	// Insert in database.
+	_, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return fmt.Errorf("%w: %s", internalerrors.ErrAlreadyExists, err)
+		}
+
+		return fmt.Errorf("could not insert macro: %w", err)
+	}
+
+	return nil
+}
+
+// GetMacro satisfies storage.MacroRepository interface.
+func (r *MacroRepository) GetMacro(ctx context.Context, id string) (*model.Macro, error) {
+	sb := macroSQLBuilder.SelectFrom(r.table)
+	sb.Where(sb.Equal("id", id))
+	query, args := sb.Build()
+
+	// Get from database.
+	row := r.db.QueryRowContext(ctx, query, args...)
+	m := &sqlMacro{}
+	err := row.Scan(macroSQLBuilder.Addr(m)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("missing macro: %w: %s", internalerrors.ErrMissing, err)
+		}
+
+		return nil, fmt.Errorf("could not get macro: %w", err)
+	}
+
+	return sqlToModelMacro(m), nil
+}
+
+// ListMacros satisfies storage.MacroRepository interface.
+func (r *MacroRepository) ListMacros(ctx context.Context, filterOpts storage.ListMacrosOpts) (*storage.MacroList, error) {
+	if filterOpts.RoomID == "" {
+		return nil, fmt.Errorf("room ID is required: %w", internalerrors.ErrNotValid)
+	}
+
+	sb := macroSQLBuilder.SelectFrom(r.table)
+	if filterOpts.UserID != "" {
+		sb.Where(sb.Equal("room_id", filterOpts.RoomID), sb.Or(sb.Equal("shared", true), sb.Equal("user_id", filterOpts.UserID)))
+	} else {
+		sb.Where(sb.Equal("room_id", filterOpts.RoomID), sb.Equal("shared", true))
+	}
+	sb.OrderBy("serial ASC")
+	query, args := sb.Build()
+
+	// Get from database.
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("could not list macros: %w", err)
+	}
+	defer rows.Close()
+
+	ms := []model.Macro{}
+	sm := &sqlMacro{} // Reuse this, when mapping to model we will have a new instance.
+	for rows.Next() {
+		err := rows.Scan(macroSQLBuilder.Addr(sm)...)
+		if err != nil {
+			return nil, fmt.Errorf("could not scan SQL macros: %w", err)
+		}
+		ms = append(ms, *sqlToModelMacro(sm))
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("could not list macros: %w", err)
+	}
+
+	return &storage.MacroList{
+		Items: ms,
+	}, nil
+}
+
+// UpdateMacro satisfies storage.MacroRepository interface.
+func (r *MacroRepository) UpdateMacro(ctx context.Context, m model.Macro) error {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(r.table).
+		Set(
+			ub.Assign("name", m.Name),
+			ub.Assign("expression", m.Expression),
+			ub.Assign("shared", m.Shared),
+		).
+		Where(ub.Equal("id", m.ID))
+	query, args := ub.Build()
+
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not update macro: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get affected rows: %w", err)
+	}
+
+	// MySQL doesn't count the matched rows that are not changed, check if it exists.
+	if n == 0 {
+		_, err := r.GetMacro(ctx, m.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteMacro satisfies storage.MacroRepository interface.
+func (r *MacroRepository) DeleteMacro(ctx context.Context, id string) error {
+	db := sqlbuilder.NewDeleteBuilder()
+	db.DeleteFrom(r.table).Where(db.Equal("id", id))
+	query, args := db.Build()
+
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not delete macro: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get affected rows: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("missing macro: %w", internalerrors.ErrMissing)
+	}
+
+	return nil
+}
+
+type sqlMacro struct {
+	ID         string    `db:"id"`
+	CreatedAt  time.Time `db:"created_at"`
+	RoomID     string    `db:"room_id"`
+	UserID     string    `db:"user_id"`
+	Name       string    `db:"name"`
+	Expression string    `db:"expression"`
+	Shared     bool      `db:"shared"`
+}
+
+func modelToSQLMacro(m model.Macro) *sqlMacro {
+	return &sqlMacro{
+		ID:         m.ID,
+		CreatedAt:  m.CreatedAt,
+		RoomID:     m.RoomID,
+		UserID:     m.UserID,
+		Name:       m.Name,
+		Expression: m.Expression,
+		Shared:     m.Shared,
+	}
+}
+
+func sqlToModelMacro(m *sqlMacro) *model.Macro {
+	return &model.Macro{
+		ID:         m.ID,
+		CreatedAt:  m.CreatedAt,
+		RoomID:     m.RoomID,
+		UserID:     m.UserID,
+		Name:       m.Name,
+		Expression: m.Expression,
+		Shared:     m.Shared,
+	}
+}
+
+// Used as a light ORM by sqlbuilder.
+var macroSQLBuilder = sqlbuilder.NewStruct(&sqlMacro{})
+
+// Implementation assertions.
+var _ storage.MacroRepository = &MacroRepository{}
@@ -0,0 +1,357 @@
+package mysql_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	drivermysql "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+	"github.com/rollify/rollify/internal/storage/mysql"
+	"github.com/rollify/rollify/internal/storage/mysql/mysqlmock"
+)
+
+var macroColumns = []string{"id", "created_at", "room_id", "user_id", "name", "expression", "shared"}
+
+func TestMacroRepositoryCreateMacro(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	wantedErr := fmt.Errorf("wanted error")
+	macro := model.Macro{ID: "m-id", CreatedAt: t0, RoomID: "room-id", UserID: "user-id", Name: "Attack", Expression: "1d20+5", Shared: true}
+
+	tests := map[string]struct {
+		config mysql.MacroRepositoryConfig
+		mock   func(*mysqlmock.DBClient)
+		macro  model.Macro
+		expErr error
+	}{
+		"Having an error while storing the macro, should error.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+			},
+			macro:  macro,
+			expErr: wantedErr,
+		},
+
+		"Creating the same macro when already exists, should error.": {
+			mock: func(m *mysqlmock.DBClient) {
+				err := &drivermysql.MySQLError{Number: 1062}
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, err)
+			},
+			macro:  macro,
+			expErr: internalerrors.ErrAlreadyExists,
+		},
+
+		"Creating a macro should store the macro.": {
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "INSERT INTO macro (id, created_at, room_id, user_id, name, expression, shared) VALUES (?, ?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "m-id", t0, "room-id", "user-id", "Attack", "1d20+5", true).Once().Return(nil, nil)
+			},
+			macro: macro,
+		},
+
+		"Creating a macro in a custom table should store the macro.": {
+			config: mysql.MacroRepositoryConfig{
+				Table: "custom-table",
+			},
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "INSERT INTO custom-table (id, created_at, room_id, user_id, name, expression, shared) VALUES (?, ?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "m-id", t0, "room-id", "user-id", "Attack", "1d20+5", true).Once().Return(nil, nil)
+			},
+			macro: macro,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			test.config.DBClient = mdb
+			r, err := mysql.NewMacroRepository(test.config)
+			require.NoError(err)
+			err = r.CreateMacro(context.TODO(), test.macro)
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestMacroRepositoryGetMacro(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	wantedErr := fmt.Errorf("wanted error")
+
+	tests := map[string]struct {
+		mock     func(*mysqlmock.DBClient)
+		id       string
+		expMacro *model.Macro
+		expErr   error
+	}{
+		"Having an error while retrieving the macro should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				row := sqlRowErr(wantedErr)
+				m.On("QueryRowContext", mock.Anything, mock.Anything, mock.Anything).Once().Return(row)
+			},
+			id:     "m-id",
+			expErr: wantedErr,
+		},
+
+		"Retrieving a missing macro, should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				row := sqlRowErr(sql.ErrNoRows)
+				m.On("QueryRowContext", mock.Anything, mock.Anything, mock.Anything).Once().Return(row)
+			},
+			id:     "m-id",
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Retrieving a macro should get the macro.": {
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "SELECT macro.id, macro.created_at, macro.room_id, macro.user_id, macro.name, macro.expression, macro.shared FROM macro WHERE id = ?"
+				row := sqlmockRowsToStdRow(sqlmock.NewRows(macroColumns).AddRow("m-id", t0, "room-id", "user-id", "Attack", "1d20+5", true))
+				m.On("QueryRowContext", mock.Anything, expQuery, "m-id").Once().Return(row)
+			},
+			id:       "m-id",
+			expMacro: &model.Macro{ID: "m-id", CreatedAt: t0, RoomID: "room-id", UserID: "user-id", Name: "Attack", Expression: "1d20+5", Shared: true},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			r, err := mysql.NewMacroRepository(mysql.MacroRepositoryConfig{DBClient: mdb})
+			require.NoError(err)
+			gotMacro, err := r.GetMacro(context.TODO(), test.id)
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+				assert.Equal(test.expMacro, gotMacro)
+			}
+		})
+	}
+}
+
+func TestMacroRepositoryListMacros(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	wantedErr := fmt.Errorf("wanted error")
+
+	tests := map[string]struct {
+		mock    func(*mysqlmock.DBClient)
+		opts    storage.ListMacrosOpts
+		expList *storage.MacroList
+		expErr  bool
+	}{
+		"Listing without room should fail.": {
+			mock:   func(m *mysqlmock.DBClient) {},
+			opts:   storage.ListMacrosOpts{},
+			expErr: true,
+		},
+
+		"Having an error while listing the macros, should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("QueryContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+			},
+			opts:   storage.ListMacrosOpts{RoomID: "room-id"},
+			expErr: true,
+		},
+
+		"Listing the macros of a room without user should return the room shared macros.": {
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "SELECT macro.id, macro.created_at, macro.room_id, macro.user_id, macro.name, macro.expression, macro.shared FROM macro WHERE room_id = ? AND shared = ? ORDER BY serial ASC"
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows(macroColumns).
+					AddRow("m1", t0, "room-id", "user1", "Attack", "1d20+5", true))
+				m.On("QueryContext", mock.Anything, expQuery, "room-id", true).Once().Return(rows, nil)
+			},
+			opts: storage.ListMacrosOpts{RoomID: "room-id"},
+			expList: &storage.MacroList{Items: []model.Macro{
+				{ID: "m1", CreatedAt: t0, RoomID: "room-id", UserID: "user1", Name: "Attack", Expression: "1d20+5", Shared: true},
+			}},
+		},
+
+		"Listing the macros of a room with a user should return the user and room shared macros.": {
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "SELECT macro.id, macro.created_at, macro.room_id, macro.user_id, macro.name, macro.expression, macro.shared FROM macro WHERE room_id = ? AND (shared = ? OR user_id = ?) ORDER BY serial ASC"
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows(macroColumns).
+					AddRow("m1", t0, "room-id", "user1", "Attack", "1d20+5", true).
+					AddRow("m2", t0, "room-id", "user2", "Fireball", "8d6", false))
+				m.On("QueryContext", mock.Anything, expQuery, "room-id", true, "user2").Once().Return(rows, nil)
+			},
+			opts: storage.ListMacrosOpts{RoomID: "room-id", UserID: "user2"},
+			expList: &storage.MacroList{Items: []model.Macro{
+				{ID: "m1", CreatedAt: t0, RoomID: "room-id", UserID: "user1", Name: "Attack", Expression: "1d20+5", Shared: true},
+				{ID: "m2", CreatedAt: t0, RoomID: "room-id", UserID: "user2", Name: "Fireball", Expression: "8d6"},
+			}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			r, err := mysql.NewMacroRepository(mysql.MacroRepositoryConfig{DBClient: mdb})
+			require.NoError(err)
+			gotList, err := r.ListMacros(context.TODO(), test.opts)
+
+			// Check.
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+				assert.Equal(test.expList, gotList)
+			}
+		})
+	}
+}
+
+func TestMacroRepositoryUpdateMacro(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	wantedErr := fmt.Errorf("wanted error")
+	macro := model.Macro{ID: "m-id", RoomID: "room-id", UserID: "user-id", Name: "Fireball", Expression: "8d6", Shared: true}
+	expQuery := "UPDATE macro SET name = ?, expression = ?, shared = ? WHERE id = ?"
+
+	tests := map[string]struct {
+		mock   func(*mysqlmock.DBClient)
+		expErr error
+	}{
+		"Having an error while updating the macro, should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+			},
+			expErr: wantedErr,
+		},
+
+		"Updating a missing macro, should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, expQuery, "Fireball", "8d6", true, "m-id").Once().Return(sqlmock.NewResult(0, 0), nil)
+				row := sqlRowErr(sql.ErrNoRows)
+				m.On("QueryRowContext", mock.Anything, mock.Anything, "m-id").Once().Return(row)
+			},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Updating a macro without changes, should not fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, expQuery, "Fireball", "8d6", true, "m-id").Once().Return(sqlmock.NewResult(0, 0), nil)
+				row := sqlmockRowsToStdRow(sqlmock.NewRows(macroColumns).AddRow("m-id", t0, "room-id", "user-id", "Fireball", "8d6", true))
+				m.On("QueryRowContext", mock.Anything, mock.Anything, "m-id").Once().Return(row)
+			},
+		},
+
+		"Updating a macro should update the macro.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, expQuery, "Fireball", "8d6", true, "m-id").Once().Return(sqlmock.NewResult(0, 1), nil)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			r, err := mysql.NewMacroRepository(mysql.MacroRepositoryConfig{DBClient: mdb})
+			require.NoError(err)
+			err = r.UpdateMacro(context.TODO(), macro)
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestMacroRepositoryDeleteMacro(t *testing.T) {
+	wantedErr := fmt.Errorf("wanted error")
+	expQuery := "DELETE FROM macro WHERE id = ?"
+
+	tests := map[string]struct {
+		mock   func(*mysqlmock.DBClient)
+		expErr error
+	}{
+		"Having an error while deleting the macro, should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+			},
+			expErr: wantedErr,
+		},
+
+		"Deleting a missing macro, should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, expQuery, "m-id").Once().Return(sqlmock.NewResult(0, 0), nil)
+			},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Deleting a macro should delete the macro.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, expQuery, "m-id").Once().Return(sqlmock.NewResult(0, 1), nil)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			r, err := mysql.NewMacroRepository(mysql.MacroRepositoryConfig{DBClient: mdb})
+			require.NoError(err)
+			err = r.DeleteMacro(context.TODO(), "m-id")
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 // DBClient is the Database client.
 type DBClient interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
 	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
 	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
 	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
@@ -17,6 +18,11 @@ type DBClient interface {
 
 //go:generate mockery --case underscore --output mysqlmock --outpkg mysqlmock --name DBClient
 
+// dbExecer executes queries, satisfied by DBClient and the transactions.
+type dbExecer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
 func isDuplicateKeyError(err error) bool {
 	const mysqlErrCode = 1062 // `ER_DUP_ENTRY`
 
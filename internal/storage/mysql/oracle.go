@@ -0,0 +1,386 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/log"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+)
+
+// OracleTableRepositoryConfig is the OracleTableRepository configuration.
+type OracleTableRepositoryConfig struct {
+	DBClient             DBClient
+	OracleTableTable     string
+	OracleTableRollTable string
+	Logger               log.Logger
+}
+
+func (c *OracleTableRepositoryConfig) defaults() error {
+	if c.DBClient == nil {
+		return fmt.Errorf("config.DBClient is required")
+	}
+
+	if c.OracleTableTable == "" {
+		c.OracleTableTable = "oracle_table"
+	}
+
+	if c.OracleTableRollTable == "" {
+		c.OracleTableRollTable = "oracle_table_roll"
+	}
+
+	if c.Logger == nil {
+		c.Logger = log.Dummy
+	}
+
+	c.Logger = c.Logger.WithKV(log.KV{
+		"repository":      "oracleTable",
+		"repository-type": "mysql",
+	})
+
+	return nil
+}
+
+// OracleTableRepository is a repository with MySQL implementation.
+type OracleTableRepository struct {
+	db                   DBClient
+	oracleTableTable     string
+	oracleTableRollTable string
+	logger               log.Logger
+}
+
+// NewOracleTableRepository returns a new OracleTableRepository.
+func NewOracleTableRepository(cfg OracleTableRepositoryConfig) (*OracleTableRepository, error) {
+	err := cfg.defaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return &OracleTableRepository{
+		db:                   cfg.DBClient,
+		oracleTableTable:     cfg.OracleTableTable,
+		oracleTableRollTable: cfg.OracleTableRollTable,
+		logger:               cfg.Logger,
+	}, nil
+}
+
+// CreateOracleTable satisfies storage.OracleTableRepository interface.
+func (r *OracleTableRepository) CreateOracleTable(ctx context.Context, t model.OracleTable) error {
+	// Map and create query.
+	st, err := modelToSQLOracleTable(t)
+	if err != nil {
+		return fmt.Errorf("could not map oracle table: %w", err)
+	}
+	query, args := oracleTableSQLBuilder.InsertInto(r.oracleTableTable, st).Build()
+
+	// Insert in database, the room table name is unique so duplicated names will fail.
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return fmt.Errorf("%w: %s", internalerrors.ErrAlreadyExists, err)
+		}
+
+		return fmt.Errorf("could not insert oracle table: %w", err)
+	}
+
+	return nil
+}
+
+// GetOracleTable satisfies storage.OracleTableRepository interface.
+func (r *OracleTableRepository) GetOracleTable(ctx context.Context, id string) (*model.OracleTable, error) {
+	sb := oracleTableSQLBuilder.SelectFrom(r.oracleTableTable)
+	sb.Where(sb.Equal("id", id))
+
+	return r.getOracleTable(ctx, sb)
+}
+
+// GetOracleTableByName satisfies storage.OracleTableRepository interface.
+func (r *OracleTableRepository) GetOracleTableByName(ctx context.Context, roomID, name string) (*model.OracleTable, error) {
+	sb := oracleTableSQLBuilder.SelectFrom(r.oracleTableTable)
+	sb.Where(sb.Equal("room_id", roomID), sb.Equal("name", name))
+
+	return r.getOracleTable(ctx, sb)
+}
+
+func (r *OracleTableRepository) getOracleTable(ctx context.Context, sb *sqlbuilder.SelectBuilder) (*model.OracleTable, error) {
+	query, args := sb.Build()
+
+	// Get from database.
+	row := r.db.QueryRowContext(ctx, query, args...)
+	st := &sqlOracleTable{}
+	err := row.Scan(oracleTableSQLBuilder.Addr(st)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("missing oracle table: %w: %s", internalerrors.ErrMissing, err)
+		}
+
+		return nil, fmt.Errorf("could not get oracle table: %w", err)
+	}
+
+	t, err := sqlToModelOracleTable(st)
+	if err != nil {
+		return nil, fmt.Errorf("could not map oracle table: %w", err)
+	}
+
+	return t, nil
+}
+
+// ListRoomOracleTables satisfies storage.OracleTableRepository interface.
+func (r *OracleTableRepository) ListRoomOracleTables(ctx context.Context, roomID string) (*storage.OracleTableList, error) {
+	sb := oracleTableSQLBuilder.SelectFrom(r.oracleTableTable)
+	sb.Where(sb.Equal("room_id", roomID))
+	sb.OrderBy("serial ASC")
+	query, args := sb.Build()
+
+	// Get from database.
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("could not list oracle tables: %w", err)
+	}
+	defer rows.Close()
+
+	ts := []model.OracleTable{}
+	st := &sqlOracleTable{} // Reuse this, when mapping to model we will have a new instance.
+	for rows.Next() {
+		err := rows.Scan(oracleTableSQLBuilder.Addr(st)...)
+		if err != nil {
+			return nil, fmt.Errorf("could not scan SQL oracle tables: %w", err)
+		}
+
+		t, err := sqlToModelOracleTable(st)
+		if err != nil {
+			return nil, fmt.Errorf("could not map oracle table: %w", err)
+		}
+		ts = append(ts, *t)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("could not list oracle tables: %w", err)
+	}
+
+	return &storage.OracleTableList{
+		Items: ts,
+	}, nil
+}
+
+// CreateOracleTableRoll satisfies storage.OracleTableRepository interface.
+func (r *OracleTableRepository) CreateOracleTableRoll(ctx context.Context, tr model.OracleTableRoll) error {
+	// Map and create query.
+	str, err := modelToSQLOracleTableRoll(tr)
+	if err != nil {
+		return fmt.Errorf("could not map oracle table roll: %w", err)
+	}
+	query, args := oracleTableRollSQLBuilder.InsertInto(r.oracleTableRollTable, str).Build()
+
+	// Insert in database.
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return fmt.Errorf("%w: %s", internalerrors.ErrAlreadyExists, err)
+		}
+
+		return fmt.Errorf("could not insert oracle table roll: %w", err)
+	}
+
+	return nil
+}
+
+// ListRoomOracleTableRolls satisfies storage.OracleTableRepository interface.
+func (r *OracleTableRepository) ListRoomOracleTableRolls(ctx context.Context, roomID string, limit int) (*storage.OracleTableRollList, error) {
+	sb := oracleTableRollSQLBuilder.SelectFrom(r.oracleTableRollTable)
+	sb.Where(sb.Equal("room_id", roomID))
+	sb.OrderBy("serial DESC")
+	if limit > 0 {
+		sb.Limit(limit)
+	}
+	query, args := sb.Build()
+
+	// Get from database.
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("could not list oracle table rolls: %w", err)
+	}
+	defer rows.Close()
+
+	trs := []model.OracleTableRoll{}
+	str := &sqlOracleTableRoll{} // Reuse this, when mapping to model we will have a new instance.
+	for rows.Next() {
+		err := rows.Scan(oracleTableRollSQLBuilder.Addr(str)...)
+		if err != nil {
+			return nil, fmt.Errorf("could not scan SQL oracle table rolls: %w", err)
+		}
+
+		tr, err := sqlToModelOracleTableRoll(str)
+		if err != nil {
+			return nil, fmt.Errorf("could not map oracle table roll: %w", err)
+		}
+		trs = append(trs, *tr)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("could not list oracle table rolls: %w", err)
+	}
+
+	return &storage.OracleTableRollList{
+		Items: trs,
+	}, nil
+}
+
+type sqlOracleTable struct {
+	ID        string    `db:"id"`
+	CreatedAt time.Time `db:"created_at"`
+	RoomID    string    `db:"room_id"`
+	Name      string    `db:"name"`
+	Dice      string    `db:"dice"`
+	// Entries are the JSON encoded table entries.
+	Entries string `db:"entries"`
+}
+
+type jsonOracleTableEntry struct {
+	Min       int    `json:"min"`
+	Max       int    `json:"max"`
+	Result    string `json:"result,omitempty"`
+	TableName string `json:"table_name,omitempty"`
+}
+
+func modelToSQLOracleTable(t model.OracleTable) (*sqlOracleTable, error) {
+	es := make([]jsonOracleTableEntry, 0, len(t.Entries))
+	for _, e := range t.Entries {
+		es = append(es, jsonOracleTableEntry{
+			Min:       e.Min,
+			Max:       e.Max,
+			Result:    e.Result,
+			TableName: e.TableName,
+		})
+	}
+
+	jes, err := json.Marshal(es)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal entries: %w", err)
+	}
+
+	return &sqlOracleTable{
+		ID:        t.ID,
+		CreatedAt: t.CreatedAt,
+		RoomID:    t.RoomID,
+		Name:      t.Name,
+		Dice:      t.Dice,
+		Entries:   string(jes),
+	}, nil
+}
+
+func sqlToModelOracleTable(st *sqlOracleTable) (*model.OracleTable, error) {
+	es := []jsonOracleTableEntry{}
+	err := json.Unmarshal([]byte(st.Entries), &es)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal entries: %w", err)
+	}
+
+	mes := make([]model.OracleTableEntry, 0, len(es))
+	for _, e := range es {
+		mes = append(mes, model.OracleTableEntry{
+			Min:       e.Min,
+			Max:       e.Max,
+			Result:    e.Result,
+			TableName: e.TableName,
+		})
+	}
+
+	return &model.OracleTable{
+		ID:        st.ID,
+		CreatedAt: st.CreatedAt,
+		RoomID:    st.RoomID,
+		Name:      st.Name,
+		Dice:      st.Dice,
+		Entries:   mes,
+	}, nil
+}
+
+type sqlOracleTableRoll struct {
+	ID        string    `db:"id"`
+	CreatedAt time.Time `db:"created_at"`
+	RoomID    string    `db:"room_id"`
+	UserID    string    `db:"user_id"`
+	TableID   string    `db:"table_id"`
+	// Results are the JSON encoded roll results.
+	Results string `db:"results"`
+}
+
+type jsonOracleTableResult struct {
+	TableName  string `json:"table_name"`
+	DiceRollID string `json:"dice_roll_id"`
+	Value      int    `json:"value"`
+	Result     string `json:"result,omitempty"`
+}
+
+func modelToSQLOracleTableRoll(tr model.OracleTableRoll) (*sqlOracleTableRoll, error) {
+	rs := make([]jsonOracleTableResult, 0, len(tr.Results))
+	for _, r := range tr.Results {
+		rs = append(rs, jsonOracleTableResult{
+			TableName:  r.TableName,
+			DiceRollID: r.DiceRollID,
+			Value:      r.Value,
+			Result:     r.Result,
+		})
+	}
+
+	jrs, err := json.Marshal(rs)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal results: %w", err)
+	}
+
+	return &sqlOracleTableRoll{
+		ID:        tr.ID,
+		CreatedAt: tr.CreatedAt,
+		RoomID:    tr.RoomID,
+		UserID:    tr.UserID,
+		TableID:   tr.TableID,
+		Results:   string(jrs),
+	}, nil
+}
+
+func sqlToModelOracleTableRoll(str *sqlOracleTableRoll) (*model.OracleTableRoll, error) {
+	rs := []jsonOracleTableResult{}
+	err := json.Unmarshal([]byte(str.Results), &rs)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal results: %w", err)
+	}
+
+	mrs := make([]model.OracleTableResult, 0, len(rs))
+	for _, r := range rs {
+		mrs = append(mrs, model.OracleTableResult{
+			TableName:  r.TableName,
+			DiceRollID: r.DiceRollID,
+			Value:      r.Value,
+			Result:     r.Result,
+		})
+	}
+
+	return &model.OracleTableRoll{
+		ID:        str.ID,
+		CreatedAt: str.CreatedAt,
+		RoomID:    str.RoomID,
+		UserID:    str.UserID,
+		TableID:   str.TableID,
+		Results:   mrs,
+	}, nil
+}
+
+// Used as a light ORM by sqlbuilder.
+var (
+	oracleTableSQLBuilder     = sqlbuilder.NewStruct(&sqlOracleTable{})
+	oracleTableRollSQLBuilder = sqlbuilder.NewStruct(&sqlOracleTableRoll{})
+)
+
+// Implementation assertions.
+var _ storage.OracleTableRepository = &OracleTableRepository{}
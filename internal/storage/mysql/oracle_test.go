@@ -0,0 +1,348 @@
+package mysql_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	drivermysql "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollify/rollify/internal/internalerrors"
+	"github.com/rollify/rollify/internal/model"
+	"github.com/rollify/rollify/internal/storage"
+	"github.com/rollify/rollify/internal/storage/mysql"
+	"github.com/rollify/rollify/internal/storage/mysql/mysqlmock"
+)
+
+var (
+	oracleTableColumns     = []string{"id", "created_at", "room_id", "name", "dice", "entries"}
+	oracleTableRollColumns = []string{"id", "created_at", "room_id", "user_id", "table_id", "results"}
+)
+
+func TestOracleTableRepositoryCreateOracleTable(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	wantedErr := fmt.Errorf("wanted error")
+	table := model.OracleTable{
+		ID:        "table-id",
+		CreatedAt: t0,
+		RoomID:    "room-id",
+		Name:      "Encounters",
+		Dice:      "1d6",
+		Entries: []model.OracleTableEntry{
+			{Min: 1, Max: 3, Result: "Nothing"},
+			{Min: 4, Max: 6, TableName: "Monsters"},
+		},
+	}
+	expEntries := `[{"min":1,"max":3,"result":"Nothing"},{"min":4,"max":6,"table_name":"Monsters"}]`
+
+	tests := map[string]struct {
+		config mysql.OracleTableRepositoryConfig
+		mock   func(*mysqlmock.DBClient)
+		expErr error
+	}{
+		"Having an error while storing the table, should error.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+			},
+			expErr: wantedErr,
+		},
+
+		"Creating a table with an already used name on the room, should error.": {
+			mock: func(m *mysqlmock.DBClient) {
+				err := &drivermysql.MySQLError{Number: 1062}
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, err)
+			},
+			expErr: internalerrors.ErrAlreadyExists,
+		},
+
+		"Creating a table should store the table.": {
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "INSERT INTO oracle_table (id, created_at, room_id, name, dice, entries) VALUES (?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "table-id", t0, "room-id", "Encounters", "1d6", expEntries).Once().Return(nil, nil)
+			},
+		},
+
+		"Creating a table in a custom table should store the table.": {
+			config: mysql.OracleTableRepositoryConfig{
+				OracleTableTable: "custom-table",
+			},
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "INSERT INTO custom-table (id, created_at, room_id, name, dice, entries) VALUES (?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "table-id", t0, "room-id", "Encounters", "1d6", expEntries).Once().Return(nil, nil)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			test.config.DBClient = mdb
+			r, err := mysql.NewOracleTableRepository(test.config)
+			require.NoError(err)
+			err = r.CreateOracleTable(context.TODO(), table)
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestOracleTableRepositoryGetOracleTableByName(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	wantedErr := fmt.Errorf("wanted error")
+
+	tests := map[string]struct {
+		mock     func(*mysqlmock.DBClient)
+		expTable *model.OracleTable
+		expErr   error
+	}{
+		"Having an error while retrieving the table should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				row := sqlRowErr(wantedErr)
+				m.On("QueryRowContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(row)
+			},
+			expErr: wantedErr,
+		},
+
+		"Retrieving a missing table, should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				row := sqlRowErr(sql.ErrNoRows)
+				m.On("QueryRowContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(row)
+			},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Retrieving a table should get the table with its entries.": {
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "SELECT oracle_table.id, oracle_table.created_at, oracle_table.room_id, oracle_table.name, oracle_table.dice, oracle_table.entries FROM oracle_table WHERE room_id = ? AND name = ?"
+				entries := `[{"min":11,"max":36,"result":"Goblin"},{"min":41,"max":66,"result":"Orc"}]`
+				row := sqlmockRowsToStdRow(sqlmock.NewRows(oracleTableColumns).AddRow("table-id", t0, "room-id", "Monsters", "d66", entries))
+				m.On("QueryRowContext", mock.Anything, expQuery, "room-id", "Monsters").Once().Return(row)
+			},
+			expTable: &model.OracleTable{
+				ID:        "table-id",
+				CreatedAt: t0,
+				RoomID:    "room-id",
+				Name:      "Monsters",
+				Dice:      model.OracleTableDiceD66,
+				Entries: []model.OracleTableEntry{
+					{Min: 11, Max: 36, Result: "Goblin"},
+					{Min: 41, Max: 66, Result: "Orc"},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			r, err := mysql.NewOracleTableRepository(mysql.OracleTableRepositoryConfig{DBClient: mdb})
+			require.NoError(err)
+			gotTable, err := r.GetOracleTableByName(context.TODO(), "room-id", "Monsters")
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+				assert.Equal(test.expTable, gotTable)
+			}
+		})
+	}
+}
+
+func TestOracleTableRepositoryListRoomOracleTables(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	wantedErr := fmt.Errorf("wanted error")
+
+	tests := map[string]struct {
+		mock    func(*mysqlmock.DBClient)
+		expList *storage.OracleTableList
+		expErr  bool
+	}{
+		"Having an error while listing the tables, should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("QueryContext", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+			},
+			expErr: true,
+		},
+
+		"Listing the tables of a room should return the room tables.": {
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "SELECT oracle_table.id, oracle_table.created_at, oracle_table.room_id, oracle_table.name, oracle_table.dice, oracle_table.entries FROM oracle_table WHERE room_id = ? ORDER BY serial ASC"
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows(oracleTableColumns).
+					AddRow("table1", t0, "room-id", "Encounters", "1d6", `[{"min":1,"max":6,"table_name":"Monsters"}]`).
+					AddRow("table2", t0, "room-id", "Monsters", "1d2", `[{"min":1,"max":2,"result":"Goblin"}]`))
+				m.On("QueryContext", mock.Anything, expQuery, "room-id").Once().Return(rows, nil)
+			},
+			expList: &storage.OracleTableList{Items: []model.OracleTable{
+				{ID: "table1", CreatedAt: t0, RoomID: "room-id", Name: "Encounters", Dice: "1d6", Entries: []model.OracleTableEntry{{Min: 1, Max: 6, TableName: "Monsters"}}},
+				{ID: "table2", CreatedAt: t0, RoomID: "room-id", Name: "Monsters", Dice: "1d2", Entries: []model.OracleTableEntry{{Min: 1, Max: 2, Result: "Goblin"}}},
+			}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			r, err := mysql.NewOracleTableRepository(mysql.OracleTableRepositoryConfig{DBClient: mdb})
+			require.NoError(err)
+			gotList, err := r.ListRoomOracleTables(context.TODO(), "room-id")
+
+			// Check.
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+				assert.Equal(test.expList, gotList)
+			}
+		})
+	}
+}
+
+func TestOracleTableRepositoryCreateOracleTableRoll(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	wantedErr := fmt.Errorf("wanted error")
+	roll := model.OracleTableRoll{
+		ID:        "roll-id",
+		CreatedAt: t0,
+		RoomID:    "room-id",
+		UserID:    "user-id",
+		TableID:   "table-id",
+		Results: []model.OracleTableResult{
+			{TableName: "Encounters", DiceRollID: "dr1", Value: 5},
+			{TableName: "Monsters", DiceRollID: "dr2", Value: 2, Result: "Goblin"},
+		},
+	}
+
+	tests := map[string]struct {
+		mock   func(*mysqlmock.DBClient)
+		expErr error
+	}{
+		"Having an error while storing the roll, should error.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+			},
+			expErr: wantedErr,
+		},
+
+		"Creating a roll should store the roll.": {
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "INSERT INTO oracle_table_roll (id, created_at, room_id, user_id, table_id, results) VALUES (?, ?, ?, ?, ?, ?)"
+				expResults := `[{"table_name":"Encounters","dice_roll_id":"dr1","value":5},{"table_name":"Monsters","dice_roll_id":"dr2","value":2,"result":"Goblin"}]`
+				m.On("ExecContext", mock.Anything, expQuery, "roll-id", t0, "room-id", "user-id", "table-id", expResults).Once().Return(nil, nil)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			r, err := mysql.NewOracleTableRepository(mysql.OracleTableRepositoryConfig{DBClient: mdb})
+			require.NoError(err)
+			err = r.CreateOracleTableRoll(context.TODO(), roll)
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestOracleTableRepositoryListRoomOracleTableRolls(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	wantedErr := fmt.Errorf("wanted error")
+
+	tests := map[string]struct {
+		mock    func(*mysqlmock.DBClient)
+		expList *storage.OracleTableRollList
+		expErr  bool
+	}{
+		"Having an error while listing the rolls, should fail.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("QueryContext", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+			},
+			expErr: true,
+		},
+
+		"Listing the rolls of a room should return the latest room rolls.": {
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "SELECT oracle_table_roll.id, oracle_table_roll.created_at, oracle_table_roll.room_id, oracle_table_roll.user_id, oracle_table_roll.table_id, oracle_table_roll.results FROM oracle_table_roll WHERE room_id = ? ORDER BY serial DESC LIMIT 10"
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows(oracleTableRollColumns).
+					AddRow("roll2", t0, "room-id", "user-id", "table-id", `[{"table_name":"Monsters","dice_roll_id":"dr2","value":2,"result":"Goblin"}]`).
+					AddRow("roll1", t0, "room-id", "user-id", "table-id", `[{"table_name":"Monsters","dice_roll_id":"dr1","value":1,"result":"Orc"}]`))
+				m.On("QueryContext", mock.Anything, expQuery, "room-id").Once().Return(rows, nil)
+			},
+			expList: &storage.OracleTableRollList{Items: []model.OracleTableRoll{
+				{ID: "roll2", CreatedAt: t0, RoomID: "room-id", UserID: "user-id", TableID: "table-id", Results: []model.OracleTableResult{{TableName: "Monsters", DiceRollID: "dr2", Value: 2, Result: "Goblin"}}},
+				{ID: "roll1", CreatedAt: t0, RoomID: "room-id", UserID: "user-id", TableID: "table-id", Results: []model.OracleTableResult{{TableName: "Monsters", DiceRollID: "dr1", Value: 1, Result: "Orc"}}},
+			}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			r, err := mysql.NewOracleTableRepository(mysql.OracleTableRepositoryConfig{DBClient: mdb})
+			require.NoError(err)
+			gotList, err := r.ListRoomOracleTableRolls(context.TODO(), "room-id", 10)
+
+			// Check.
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+				assert.Equal(test.expList, gotList)
+			}
+		})
+	}
+}
@@ -19,7 +19,22 @@ import (
 type RoomRepositoryConfig struct {
 	DBClient DBClient
 	Table    string
-	Logger   log.Logger
+	// UserTable, DiceRollTable and DieRollTable are the tables of the room data deleted with the rooms.
+	UserTable     string
+	DiceRollTable string
+	DieRollTable  string
+	// InviteTokenTable is the table of the room invite tokens.
+	InviteTokenTable string
+	// CustomDieTypeTable, ServerSeedTable, MacroTable, InitiativeTable, DeckTable, OracleTableTable
+	// and OracleTableRollTable are the tables of the rest of the room data deleted with the rooms.
+	CustomDieTypeTable   string
+	ServerSeedTable      string
+	MacroTable           string
+	InitiativeTable      string
+	DeckTable            string
+	OracleTableTable     string
+	OracleTableRollTable string
+	Logger               log.Logger
 }
 
 func (c *RoomRepositoryConfig) defaults() error {
@@ -31,6 +46,50 @@ func (c *RoomRepositoryConfig) defaults() error {
 		c.Table = "room"
 	}
 
+	if c.UserTable == "" {
+		c.UserTable = "user"
+	}
+
+	if c.DiceRollTable == "" {
+		c.DiceRollTable = "dice_roll"
+	}
+
+	if c.DieRollTable == "" {
+		c.DieRollTable = "die_roll"
+	}
+
+	if c.InviteTokenTable == "" {
+		c.InviteTokenTable = "room_invite_token"
+	}
+
+	if c.CustomDieTypeTable == "" {
+		c.CustomDieTypeTable = "custom_die_type"
+	}
+
+	if c.ServerSeedTable == "" {
+		c.ServerSeedTable = "server_seed"
+	}
+
+	if c.MacroTable == "" {
+		c.MacroTable = "macro"
+	}
+
+	if c.InitiativeTable == "" {
+		c.InitiativeTable = "initiative"
+	}
+
+	if c.DeckTable == "" {
+		c.DeckTable = "deck"
+	}
+
+	if c.OracleTableTable == "" {
+		c.OracleTableTable = "oracle_table"
+	}
+
+	if c.OracleTableRollTable == "" {
+		c.OracleTableRollTable = "oracle_table_roll"
+	}
+
 	if c.Logger == nil {
 		c.Logger = log.Dummy
 	}
@@ -45,9 +104,15 @@ func (c *RoomRepositoryConfig) defaults() error {
 
 // RoomRepository is a repository with MySQL implementation.
 type RoomRepository struct {
-	db     DBClient
-	table  string
-	logger log.Logger
+	db            DBClient
+	table         string
+	userTable     string
+	diceRollTable string
+	dieRollTable  string
+	tokenTable    string
+	// dataTables are the rest of the tables with room data, all of them have a room_id column.
+	dataTables []string
+	logger     log.Logger
 }
 
 // NewRoomRepository returns a new RoomRepository.
@@ -58,8 +123,21 @@ func NewRoomRepository(cfg RoomRepositoryConfig) (*RoomRepository, error) {
 	}
 
 	return &RoomRepository{
-		db:     cfg.DBClient,
-		table:  cfg.Table,
+		db:            cfg.DBClient,
+		table:         cfg.Table,
+		userTable:     cfg.UserTable,
+		diceRollTable: cfg.DiceRollTable,
+		dieRollTable:  cfg.DieRollTable,
+		tokenTable:    cfg.InviteTokenTable,
+		dataTables: []string{
+			cfg.CustomDieTypeTable,
+			cfg.ServerSeedTable,
+			cfg.MacroTable,
+			cfg.InitiativeTable,
+			cfg.DeckTable,
+			cfg.OracleTableTable,
+			cfg.OracleTableRollTable,
+		},
 		logger: cfg.Logger,
 	}, nil
 }
@@ -105,9 +183,44 @@ func (r *RoomRepository) GetRoom(ctx context.Context, id string) (*model.Room, e
 	// Map.
 	room := sqlRoomToModel(sr)
 
+	room.InviteTokens, err = r.ListRoomInviteTokens(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
 	return room, nil
 }
 
+// ListRoomInviteTokens satisfies storage.RoomRepository interface.
+func (r *RoomRepository) ListRoomInviteTokens(ctx context.Context, roomID string) ([]model.RoomInviteToken, error) {
+	sb := roomInviteTokenSQLBuilder.SelectFrom(r.tokenTable)
+	sb.Where(sb.Equal("room_id", roomID)).OrderBy("created_at").Asc()
+	query, args := sb.Build()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not list room invite tokens: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := []model.RoomInviteToken{}
+	st := &sqlRoomInviteToken{}
+	for rows.Next() {
+		err := rows.Scan(roomInviteTokenSQLBuilder.Addr(st)...)
+		if err != nil {
+			return nil, fmt.Errorf("could not scan SQL room invite tokens: %w", err)
+		}
+		tokens = append(tokens, model.RoomInviteToken{Token: st.Token, CreatedAt: st.CreatedAt})
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("could not list room invite tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
 // RoomExists satisfies storage.RoomRepository interface.
 func (r *RoomRepository) RoomExists(ctx context.Context, id string) (bool, error) {
 	// Create query.
@@ -129,30 +242,212 @@ func (r *RoomRepository) RoomExists(ctx context.Context, id string) (bool, error
 	return exists, nil
 }
 
+// DeleteRoom satisfies storage.RoomRepository interface.
+func (r *RoomRepository) DeleteRoom(ctx context.Context, id string) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			rbErr := tx.Rollback()
+			if rbErr != nil {
+				r.logger.Errorf("could not rollback room deletion transaction: %s", rbErr)
+			}
+		}
+	}()
+
+	// Room.
+	db := sqlbuilder.NewDeleteBuilder()
+	db.DeleteFrom(r.table).Where(db.Equal("id", id))
+	query, args := db.Build()
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not delete room: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get affected rows: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("missing room: %w", internalerrors.ErrMissing)
+	}
+
+	// Dice of the room dice rolls.
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("id").From(r.diceRollTable).Where(sb.Equal("room_id", id))
+	db = sqlbuilder.NewDeleteBuilder()
+	db.DeleteFrom(r.dieRollTable).Where(db.In("dice_roll_id", sb))
+	query, args = db.Build()
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not delete room die rolls: %w", err)
+	}
+
+	// Dice rolls.
+	db = sqlbuilder.NewDeleteBuilder()
+	db.DeleteFrom(r.diceRollTable).Where(db.Equal("room_id", id))
+	query, args = db.Build()
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not delete room dice rolls: %w", err)
+	}
+
+	// Users.
+	db = sqlbuilder.NewDeleteBuilder()
+	db.DeleteFrom(r.userTable).Where(db.Equal("room_id", id))
+	query, args = db.Build()
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not delete room users: %w", err)
+	}
+
+	// Invite tokens.
+	db = sqlbuilder.NewDeleteBuilder()
+	db.DeleteFrom(r.tokenTable).Where(db.Equal("room_id", id))
+	query, args = db.Build()
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not delete room invite tokens: %w", err)
+	}
+
+	// Rest of the room data.
+	for _, table := range r.dataTables {
+		db = sqlbuilder.NewDeleteBuilder()
+		db.DeleteFrom(table).Where(db.Equal("room_id", id))
+		query, args = db.Build()
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("could not delete room %s data: %w", table, err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ArchiveRoom satisfies storage.RoomRepository interface.
+func (r *RoomRepository) ArchiveRoom(ctx context.Context, id string) error {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(r.table).
+		Set(ub.Assign("archived", true)).
+		Where(ub.Equal("id", id), ub.Equal("archived", false))
+	query, args := ub.Build()
+
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not archive room: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get affected rows: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("missing not archived room: %w", internalerrors.ErrMissing)
+	}
+
+	return nil
+}
+
+// CreateRoomInviteToken satisfies storage.RoomRepository interface.
+func (r *RoomRepository) CreateRoomInviteToken(ctx context.Context, roomID string, t model.RoomInviteToken) error {
+	exists, err := r.RoomExists(ctx, roomID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("missing room: %w", internalerrors.ErrMissing)
+	}
+
+	query, args := roomInviteTokenSQLBuilder.InsertInto(r.tokenTable, &sqlRoomInviteToken{
+		Token:     t.Token,
+		RoomID:    roomID,
+		CreatedAt: t.CreatedAt,
+	}).Build()
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return fmt.Errorf("%w: %s", internalerrors.ErrAlreadyExists, err)
+		}
+
+		return fmt.Errorf("could not create room invite token: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteRoomInviteToken satisfies storage.RoomRepository interface.
+func (r *RoomRepository) DeleteRoomInviteToken(ctx context.Context, roomID, token string) error {
+	db := sqlbuilder.NewDeleteBuilder()
+	db.DeleteFrom(r.tokenTable).Where(db.Equal("token", token), db.Equal("room_id", roomID))
+	query, args := db.Build()
+
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not delete room invite token: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get affected rows: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("missing room invite token: %w", internalerrors.ErrMissing)
+	}
+
+	return nil
+}
+
 type sqlRoom struct {
 	ID        string    `db:"id"`
 	Name      string    `db:"name"`
 	CreatedAt time.Time `db:"created_at"`
+	Ruleset   string    `db:"ruleset"`
+	Archived  bool      `db:"archived"`
+	// PasswordHash is the salted bcrypt hash of the room password.
+	PasswordHash string `db:"password_hash"`
 }
 
 func modelToSQLRoom(r model.Room) *sqlRoom {
 	return &sqlRoom{
-		ID:        r.ID,
-		Name:      r.Name,
-		CreatedAt: r.CreatedAt,
+		ID:           r.ID,
+		Name:         r.Name,
+		CreatedAt:    r.CreatedAt,
+		Ruleset:      r.Ruleset,
+		Archived:     r.Archived,
+		PasswordHash: r.PasswordHash,
 	}
 }
 
 func sqlRoomToModel(r *sqlRoom) *model.Room {
 	return &model.Room{
-		ID:        r.ID,
-		Name:      r.Name,
-		CreatedAt: r.CreatedAt,
+		ID:           r.ID,
+		Name:         r.Name,
+		CreatedAt:    r.CreatedAt,
+		Ruleset:      r.Ruleset,
+		Archived:     r.Archived,
+		PasswordHash: r.PasswordHash,
 	}
 }
 
+type sqlRoomInviteToken struct {
+	Token     string    `db:"token"`
+	RoomID    string    `db:"room_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
 // Used as a light ORM by sqlbuilder.
-var roomSQLBuilder = sqlbuilder.NewStruct(&sqlRoom{})
+var (
+	roomSQLBuilder            = sqlbuilder.NewStruct(&sqlRoom{})
+	roomInviteTokenSQLBuilder = sqlbuilder.NewStruct(&sqlRoomInviteToken{})
+)
 
 // Implementation assertions.
 var _ storage.RoomRepository = &RoomRepository{}
@@ -33,7 +33,7 @@ func TestRoomRepositoryCreateRoom(t *testing.T) {
 		"Having an error while storing the room, should error.": {
 			config: mysql.RoomRepositoryConfig{},
 			mock: func(m *mysqlmock.DBClient) {
-				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
 			},
 			room: model.Room{
 				ID:        "test-id",
@@ -47,7 +47,7 @@ func TestRoomRepositoryCreateRoom(t *testing.T) {
 			config: mysql.RoomRepositoryConfig{},
 			mock: func(m *mysqlmock.DBClient) {
 				err := &drivermysql.MySQLError{Number: 1062}
-				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, err)
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, err)
 			},
 			room: model.Room{
 				ID:        "test-id",
@@ -60,13 +60,15 @@ func TestRoomRepositoryCreateRoom(t *testing.T) {
 		"Creating a room should store the room.": {
 			config: mysql.RoomRepositoryConfig{},
 			mock: func(m *mysqlmock.DBClient) {
-				expQuery := "INSERT INTO room (id, name, created_at) VALUES (?, ?, ?)"
-				m.On("ExecContext", mock.Anything, expQuery, "test-id", "test", t0).Once().Return(nil, nil)
+				expQuery := "INSERT INTO room (id, name, created_at, ruleset, archived, password_hash) VALUES (?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "test-id", "test", t0, "pbta", false, "hash").Once().Return(nil, nil)
 			},
 			room: model.Room{
-				ID:        "test-id",
-				CreatedAt: t0,
-				Name:      "test",
+				ID:           "test-id",
+				CreatedAt:    t0,
+				Name:         "test",
+				Ruleset:      "pbta",
+				PasswordHash: "hash",
 			},
 		},
 
@@ -75,13 +77,15 @@ func TestRoomRepositoryCreateRoom(t *testing.T) {
 				Table: "custom-table",
 			},
 			mock: func(m *mysqlmock.DBClient) {
-				expQuery := "INSERT INTO custom-table (id, name, created_at) VALUES (?, ?, ?)"
-				m.On("ExecContext", mock.Anything, expQuery, "test-id", "test", t0).Once().Return(nil, nil)
+				expQuery := "INSERT INTO custom-table (id, name, created_at, ruleset, archived, password_hash) VALUES (?, ?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "test-id", "test", t0, "pbta", false, "hash").Once().Return(nil, nil)
 			},
 			room: model.Room{
-				ID:        "test-id",
-				CreatedAt: t0,
-				Name:      "test",
+				ID:           "test-id",
+				CreatedAt:    t0,
+				Name:         "test",
+				Ruleset:      "pbta",
+				PasswordHash: "hash",
 			},
 		},
 	}
@@ -145,18 +149,25 @@ func TestRoomRepositoryGetRoom(t *testing.T) {
 		"Retrieving a room should get the room.": {
 			config: mysql.RoomRepositoryConfig{},
 			mock: func(m *mysqlmock.DBClient) {
-				expQuery := "SELECT room.id, room.name, room.created_at FROM room WHERE id = ?"
-
-				row := sqlmockRowsToStdRow(sqlmock.NewRows([]string{"id", "name", "created_at"}).
-					AddRow("test-id", "test", t0))
+				expQuery := "SELECT room.id, room.name, room.created_at, room.ruleset, room.archived, room.password_hash FROM room WHERE id = ?"
 
+				row := sqlmockRowsToStdRow(sqlmock.NewRows([]string{"id", "name", "created_at", "ruleset", "archived", "password_hash"}).
+					AddRow("test-id", "test", t0, "pbta", false, "hash"))
 				m.On("QueryRowContext", mock.Anything, expQuery, "test-id").Once().Return(row)
+
+				expTokensQuery := "SELECT room_invite_token.token, room_invite_token.room_id, room_invite_token.created_at FROM room_invite_token WHERE room_id = ? ORDER BY created_at ASC"
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"token", "room_id", "created_at"}).
+					AddRow("token1", "test-id", t0))
+				m.On("QueryContext", mock.Anything, expTokensQuery, "test-id").Once().Return(rows, nil)
 			},
 			id: "test-id",
 			expRoom: &model.Room{
-				ID:        "test-id",
-				CreatedAt: t0,
-				Name:      "test",
+				ID:           "test-id",
+				CreatedAt:    t0,
+				Name:         "test",
+				Ruleset:      "pbta",
+				PasswordHash: "hash",
+				InviteTokens: []model.RoomInviteToken{{Token: "token1", CreatedAt: t0}},
 			},
 		},
 
@@ -165,18 +176,25 @@ func TestRoomRepositoryGetRoom(t *testing.T) {
 				Table: "custom-table",
 			},
 			mock: func(m *mysqlmock.DBClient) {
-				expQuery := "SELECT custom-table.id, custom-table.name, custom-table.created_at FROM custom-table WHERE id = ?"
-
-				row := sqlmockRowsToStdRow(sqlmock.NewRows([]string{"id", "name", "created_at"}).
-					AddRow("test-id", "test", t0))
+				expQuery := "SELECT custom-table.id, custom-table.name, custom-table.created_at, custom-table.ruleset, custom-table.archived, custom-table.password_hash FROM custom-table WHERE id = ?"
 
+				row := sqlmockRowsToStdRow(sqlmock.NewRows([]string{"id", "name", "created_at", "ruleset", "archived", "password_hash"}).
+					AddRow("test-id", "test", t0, "pbta", false, "hash"))
 				m.On("QueryRowContext", mock.Anything, expQuery, "test-id").Once().Return(row)
+
+				expTokensQuery := "SELECT room_invite_token.token, room_invite_token.room_id, room_invite_token.created_at FROM room_invite_token WHERE room_id = ? ORDER BY created_at ASC"
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"token", "room_id", "created_at"}).
+					AddRow("token1", "test-id", t0))
+				m.On("QueryContext", mock.Anything, expTokensQuery, "test-id").Once().Return(rows, nil)
 			},
 			id: "test-id",
 			expRoom: &model.Room{
-				ID:        "test-id",
-				CreatedAt: t0,
-				Name:      "test",
+				ID:           "test-id",
+				CreatedAt:    t0,
+				Name:         "test",
+				Ruleset:      "pbta",
+				PasswordHash: "hash",
+				InviteTokens: []model.RoomInviteToken{{Token: "token1", CreatedAt: t0}},
 			},
 		},
 	}
@@ -279,3 +297,383 @@ func TestRoomRepositoryRoomExists(t *testing.T) {
 		})
 	}
 }
+
+func TestRoomRepositoryDeleteRoom(t *testing.T) {
+	wantedErr := fmt.Errorf("wanted error")
+	expRoomQuery := "DELETE FROM room WHERE id = ?"
+	expDieRollQuery := "DELETE FROM die_roll WHERE dice_roll_id IN (SELECT id FROM dice_roll WHERE room_id = ?)"
+	expDiceRollQuery := "DELETE FROM dice_roll WHERE room_id = ?"
+	expUserQuery := "DELETE FROM user WHERE room_id = ?"
+	expTokenQuery := "DELETE FROM room_invite_token WHERE room_id = ?"
+	expDataQueries := []string{
+		"DELETE FROM custom_die_type WHERE room_id = ?",
+		"DELETE FROM server_seed WHERE room_id = ?",
+		"DELETE FROM macro WHERE room_id = ?",
+		"DELETE FROM initiative WHERE room_id = ?",
+		"DELETE FROM deck WHERE room_id = ?",
+		"DELETE FROM oracle_table WHERE room_id = ?",
+		"DELETE FROM oracle_table_roll WHERE room_id = ?",
+	}
+
+	tests := map[string]struct {
+		mock   func(m *mysqlmock.DBClient, db *sql.DB, smock sqlmock.Sqlmock)
+		id     string
+		expErr error
+	}{
+		"Having an error while starting the transaction, should error.": {
+			mock: func(m *mysqlmock.DBClient, db *sql.DB, smock sqlmock.Sqlmock) {
+				m.On("BeginTx", mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+			},
+			id:     "test-id",
+			expErr: wantedErr,
+		},
+
+		"Deleting a missing room, should rollback and error.": {
+			mock: func(m *mysqlmock.DBClient, db *sql.DB, smock sqlmock.Sqlmock) {
+				smock.ExpectBegin()
+				smock.ExpectExec(expRoomQuery).WithArgs("test-id").WillReturnResult(sqlmock.NewResult(0, 0))
+				smock.ExpectRollback()
+
+				tx, _ := db.Begin()
+				m.On("BeginTx", mock.Anything, mock.Anything).Once().Return(tx, nil)
+			},
+			id:     "test-id",
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Having an error while deleting the room users, should rollback and error.": {
+			mock: func(m *mysqlmock.DBClient, db *sql.DB, smock sqlmock.Sqlmock) {
+				smock.ExpectBegin()
+				smock.ExpectExec(expRoomQuery).WithArgs("test-id").WillReturnResult(sqlmock.NewResult(0, 1))
+				smock.ExpectExec(expDieRollQuery).WithArgs("test-id").WillReturnResult(sqlmock.NewResult(0, 3))
+				smock.ExpectExec(expDiceRollQuery).WithArgs("test-id").WillReturnResult(sqlmock.NewResult(0, 2))
+				smock.ExpectExec(expUserQuery).WithArgs("test-id").WillReturnError(wantedErr)
+				smock.ExpectRollback()
+
+				tx, _ := db.Begin()
+				m.On("BeginTx", mock.Anything, mock.Anything).Once().Return(tx, nil)
+			},
+			id:     "test-id",
+			expErr: wantedErr,
+		},
+
+		"Having an error while deleting the room oracle tables, should rollback and error.": {
+			mock: func(m *mysqlmock.DBClient, db *sql.DB, smock sqlmock.Sqlmock) {
+				smock.ExpectBegin()
+				smock.ExpectExec(expRoomQuery).WithArgs("test-id").WillReturnResult(sqlmock.NewResult(0, 1))
+				smock.ExpectExec(expDieRollQuery).WithArgs("test-id").WillReturnResult(sqlmock.NewResult(0, 3))
+				smock.ExpectExec(expDiceRollQuery).WithArgs("test-id").WillReturnResult(sqlmock.NewResult(0, 2))
+				smock.ExpectExec(expUserQuery).WithArgs("test-id").WillReturnResult(sqlmock.NewResult(0, 2))
+				smock.ExpectExec(expTokenQuery).WithArgs("test-id").WillReturnResult(sqlmock.NewResult(0, 1))
+				for _, q := range expDataQueries[:5] {
+					smock.ExpectExec(q).WithArgs("test-id").WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+				smock.ExpectExec(expDataQueries[5]).WithArgs("test-id").WillReturnError(wantedErr)
+				smock.ExpectRollback()
+
+				tx, _ := db.Begin()
+				m.On("BeginTx", mock.Anything, mock.Anything).Once().Return(tx, nil)
+			},
+			id:     "test-id",
+			expErr: wantedErr,
+		},
+
+		"Deleting a room should delete the room with all its data in the same transaction.": {
+			mock: func(m *mysqlmock.DBClient, db *sql.DB, smock sqlmock.Sqlmock) {
+				smock.ExpectBegin()
+				smock.ExpectExec(expRoomQuery).WithArgs("test-id").WillReturnResult(sqlmock.NewResult(0, 1))
+				smock.ExpectExec(expDieRollQuery).WithArgs("test-id").WillReturnResult(sqlmock.NewResult(0, 3))
+				smock.ExpectExec(expDiceRollQuery).WithArgs("test-id").WillReturnResult(sqlmock.NewResult(0, 2))
+				smock.ExpectExec(expUserQuery).WithArgs("test-id").WillReturnResult(sqlmock.NewResult(0, 2))
+				smock.ExpectExec(expTokenQuery).WithArgs("test-id").WillReturnResult(sqlmock.NewResult(0, 1))
+				for _, q := range expDataQueries {
+					smock.ExpectExec(q).WithArgs("test-id").WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+				smock.ExpectCommit()
+
+				tx, _ := db.Begin()
+				m.On("BeginTx", mock.Anything, mock.Anything).Once().Return(tx, nil)
+			},
+			id: "test-id",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			db, smock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(err)
+			defer db.Close()
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb, db, smock)
+
+			// Execute.
+			r, err := mysql.NewRoomRepository(mysql.RoomRepositoryConfig{DBClient: mdb})
+			require.NoError(err)
+			err = r.DeleteRoom(context.TODO(), test.id)
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else {
+				assert.NoError(err)
+			}
+			mdb.AssertExpectations(t)
+			assert.NoError(smock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRoomRepositoryArchiveRoom(t *testing.T) {
+	wantedErr := fmt.Errorf("wanted error")
+	expQuery := "UPDATE room SET archived = ? WHERE id = ? AND archived = ?"
+
+	tests := map[string]struct {
+		mock   func(*mysqlmock.DBClient)
+		id     string
+		expErr error
+	}{
+		"Having an error while archiving the room, should error.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, expQuery, true, "test-id", false).Once().Return(nil, wantedErr)
+			},
+			id:     "test-id",
+			expErr: wantedErr,
+		},
+
+		"Archiving a missing or already archived room, should error.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, expQuery, true, "test-id", false).Once().Return(sqlmock.NewResult(0, 0), nil)
+			},
+			id:     "test-id",
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Archiving a room should mark the room as archived.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, expQuery, true, "test-id", false).Once().Return(sqlmock.NewResult(0, 1), nil)
+			},
+			id: "test-id",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			r, err := mysql.NewRoomRepository(mysql.RoomRepositoryConfig{DBClient: mdb})
+			require.NoError(err)
+			err = r.ArchiveRoom(context.TODO(), test.id)
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestRoomRepositoryCreateRoomInviteToken(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	wantedErr := fmt.Errorf("wanted error")
+	expExistsQuery := "SELECT(EXISTS(SELECT * FROM room WHERE id = ?))"
+	expQuery := "INSERT INTO room_invite_token (token, room_id, created_at) VALUES (?, ?, ?)"
+
+	tests := map[string]struct {
+		mock   func(*mysqlmock.DBClient)
+		roomID string
+		token  model.RoomInviteToken
+		expErr error
+	}{
+		"Adding a token to a missing room, should error.": {
+			mock: func(m *mysqlmock.DBClient) {
+				row := sqlmockRowsToStdRow(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				m.On("QueryRowContext", mock.Anything, expExistsQuery, "test-id").Once().Return(row)
+			},
+			roomID: "test-id",
+			token:  model.RoomInviteToken{Token: "token1", CreatedAt: t0},
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Having an error while storing the token, should error.": {
+			mock: func(m *mysqlmock.DBClient) {
+				row := sqlmockRowsToStdRow(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				m.On("QueryRowContext", mock.Anything, expExistsQuery, "test-id").Once().Return(row)
+				m.On("ExecContext", mock.Anything, expQuery, "token1", "test-id", t0).Once().Return(nil, wantedErr)
+			},
+			roomID: "test-id",
+			token:  model.RoomInviteToken{Token: "token1", CreatedAt: t0},
+			expErr: wantedErr,
+		},
+
+		"Adding a token should store the token.": {
+			mock: func(m *mysqlmock.DBClient) {
+				row := sqlmockRowsToStdRow(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				m.On("QueryRowContext", mock.Anything, expExistsQuery, "test-id").Once().Return(row)
+				m.On("ExecContext", mock.Anything, expQuery, "token1", "test-id", t0).Once().Return(sqlmock.NewResult(0, 1), nil)
+			},
+			roomID: "test-id",
+			token:  model.RoomInviteToken{Token: "token1", CreatedAt: t0},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			r, err := mysql.NewRoomRepository(mysql.RoomRepositoryConfig{DBClient: mdb})
+			require.NoError(err)
+			err = r.CreateRoomInviteToken(context.TODO(), test.roomID, test.token)
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestRoomRepositoryDeleteRoomInviteToken(t *testing.T) {
+	wantedErr := fmt.Errorf("wanted error")
+	expQuery := "DELETE FROM room_invite_token WHERE token = ? AND room_id = ?"
+
+	tests := map[string]struct {
+		mock   func(*mysqlmock.DBClient)
+		roomID string
+		token  string
+		expErr error
+	}{
+		"Having an error while revoking the token, should error.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, expQuery, "token1", "test-id").Once().Return(nil, wantedErr)
+			},
+			roomID: "test-id",
+			token:  "token1",
+			expErr: wantedErr,
+		},
+
+		"Revoking a missing token, should error.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, expQuery, "token1", "test-id").Once().Return(sqlmock.NewResult(0, 0), nil)
+			},
+			roomID: "test-id",
+			token:  "token1",
+			expErr: internalerrors.ErrMissing,
+		},
+
+		"Revoking a token should delete the token.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("ExecContext", mock.Anything, expQuery, "token1", "test-id").Once().Return(sqlmock.NewResult(0, 1), nil)
+			},
+			roomID: "test-id",
+			token:  "token1",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			r, err := mysql.NewRoomRepository(mysql.RoomRepositoryConfig{DBClient: mdb})
+			require.NoError(err)
+			err = r.DeleteRoomInviteToken(context.TODO(), test.roomID, test.token)
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestRoomRepositoryListRoomInviteTokens(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
+	wantedErr := fmt.Errorf("wanted error")
+	expQuery := "SELECT room_invite_token.token, room_invite_token.room_id, room_invite_token.created_at FROM room_invite_token WHERE room_id = ? ORDER BY created_at ASC"
+
+	tests := map[string]struct {
+		mock      func(*mysqlmock.DBClient)
+		roomID    string
+		expTokens []model.RoomInviteToken
+		expErr    error
+	}{
+		"Having an error while listing the tokens, should error.": {
+			mock: func(m *mysqlmock.DBClient) {
+				m.On("QueryContext", mock.Anything, expQuery, "test-id").Once().Return(nil, wantedErr)
+			},
+			roomID: "test-id",
+			expErr: wantedErr,
+		},
+
+		"Listing the tokens of a room without tokens should return an empty list.": {
+			mock: func(m *mysqlmock.DBClient) {
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"token", "room_id", "created_at"}))
+				m.On("QueryContext", mock.Anything, expQuery, "test-id").Once().Return(rows, nil)
+			},
+			roomID:    "test-id",
+			expTokens: []model.RoomInviteToken{},
+		},
+
+		"Listing the tokens of a room should return the room tokens.": {
+			mock: func(m *mysqlmock.DBClient) {
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"token", "room_id", "created_at"}).
+					AddRow("token0", "test-id", t0).
+					AddRow("token1", "test-id", t0))
+				m.On("QueryContext", mock.Anything, expQuery, "test-id").Once().Return(rows, nil)
+			},
+			roomID:    "test-id",
+			expTokens: []model.RoomInviteToken{{Token: "token0", CreatedAt: t0}, {Token: "token1", CreatedAt: t0}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mdb := &mysqlmock.DBClient{}
+			test.mock(mdb)
+
+			// Execute.
+			r, err := mysql.NewRoomRepository(mysql.RoomRepositoryConfig{DBClient: mdb})
+			require.NoError(err)
+			gotTokens, err := r.ListRoomInviteTokens(context.TODO(), test.roomID)
+
+			// Check.
+			if test.expErr != nil && assert.Error(err) {
+				assert.True(errors.Is(err, test.expErr))
+			} else if assert.NoError(err) {
+				mdb.AssertExpectations(t)
+				assert.Equal(test.expTokens, gotTokens)
+			}
+		})
+	}
+}
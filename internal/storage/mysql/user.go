@@ -221,27 +221,30 @@ func (r *UserRepository) GetUserByNameInsensitive(ctx context.Context, roomID, u
 }
 
 type sqlUser struct {
-	ID        string    `db:"id"`
-	Name      string    `db:"name"`
-	RoomID    string    `db:"room_id"`
-	CreatedAt time.Time `db:"created_at"`
+	ID         string    `db:"id"`
+	Name       string    `db:"name"`
+	RoomID     string    `db:"room_id"`
+	CreatedAt  time.Time `db:"created_at"`
+	GameMaster bool      `db:"game_master"`
 }
 
 func modelToSQLUser(r model.User) *sqlUser {
 	return &sqlUser{
-		ID:        r.ID,
-		Name:      r.Name,
-		RoomID:    r.RoomID,
-		CreatedAt: r.CreatedAt,
+		ID:         r.ID,
+		Name:       r.Name,
+		RoomID:     r.RoomID,
+		CreatedAt:  r.CreatedAt,
+		GameMaster: r.GameMaster,
 	}
 }
 
 func sqlToModelUser(r *sqlUser) model.User {
 	return model.User{
-		ID:        r.ID,
-		Name:      r.Name,
-		RoomID:    r.RoomID,
-		CreatedAt: r.CreatedAt,
+		ID:         r.ID,
+		Name:       r.Name,
+		RoomID:     r.RoomID,
+		CreatedAt:  r.CreatedAt,
+		GameMaster: r.GameMaster,
 	}
 }
 
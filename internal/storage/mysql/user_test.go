@@ -34,7 +34,7 @@ func TestUserRepositoryCreateUser(t *testing.T) {
 		"Having an error while storing the user, should error.": {
 			config: mysql.UserRepositoryConfig{},
 			mock: func(m *mysqlmock.DBClient) {
-				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, wantedErr)
 			},
 			user: model.User{
 				ID:        "test-id",
@@ -49,7 +49,7 @@ func TestUserRepositoryCreateUser(t *testing.T) {
 			config: mysql.UserRepositoryConfig{},
 			mock: func(m *mysqlmock.DBClient) {
 				err := &drivermysql.MySQLError{Number: 1062}
-				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, err)
+				m.On("ExecContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, err)
 			},
 			user: model.User{
 				ID:        "test-id",
@@ -63,8 +63,8 @@ func TestUserRepositoryCreateUser(t *testing.T) {
 		"Creating a user should store the user.": {
 			config: mysql.UserRepositoryConfig{},
 			mock: func(m *mysqlmock.DBClient) {
-				expQuery := "INSERT INTO user (id, name, room_id, created_at) VALUES (?, ?, ?, ?)"
-				m.On("ExecContext", mock.Anything, expQuery, "test-id", "test", "room-id", t0).Once().Return(nil, nil)
+				expQuery := "INSERT INTO user (id, name, room_id, created_at, game_master) VALUES (?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "test-id", "test", "room-id", t0, false).Once().Return(nil, nil)
 			},
 			user: model.User{
 				ID:        "test-id",
@@ -79,8 +79,8 @@ func TestUserRepositoryCreateUser(t *testing.T) {
 				Table: "custom-table",
 			},
 			mock: func(m *mysqlmock.DBClient) {
-				expQuery := "INSERT INTO custom-table (id, name, room_id, created_at) VALUES (?, ?, ?, ?)"
-				m.On("ExecContext", mock.Anything, expQuery, "test-id", "test", "room-id", t0).Once().Return(nil, nil)
+				expQuery := "INSERT INTO custom-table (id, name, room_id, created_at, game_master) VALUES (?, ?, ?, ?, ?)"
+				m.On("ExecContext", mock.Anything, expQuery, "test-id", "test", "room-id", t0, false).Once().Return(nil, nil)
 			},
 			user: model.User{
 				ID:        "test-id",
@@ -139,8 +139,8 @@ func TestUserRepositoryListUsers(t *testing.T) {
 		"Retrieving the users with rows error should fail.": {
 			config: mysql.UserRepositoryConfig{},
 			mock: func(m *mysqlmock.DBClient) {
-				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"id", "name", "room_id", "created_at"}).
-					AddRow("test0-id", "test0", "room-id", t0).
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"id", "name", "room_id", "created_at", "game_master"}).
+					AddRow("test0-id", "test0", "room-id", t0, false).
 					RowError(0, wantedErr))
 
 				m.On("QueryContext", mock.Anything, mock.Anything, mock.Anything).Once().Return(rows, nil)
@@ -152,14 +152,14 @@ func TestUserRepositoryListUsers(t *testing.T) {
 		"Retrieving the users from a room should get the users.": {
 			config: mysql.UserRepositoryConfig{},
 			mock: func(m *mysqlmock.DBClient) {
-				expQuery := "SELECT user.id, user.name, user.room_id, user.created_at FROM user WHERE room_id = ?"
+				expQuery := "SELECT user.id, user.name, user.room_id, user.created_at, user.game_master FROM user WHERE room_id = ?"
 
-				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"id", "name", "room_id", "created_at"}).
-					AddRow("test0-id", "test0", "room-id", t0).
-					AddRow("test1-id", "test1", "room-id", t0).
-					AddRow("test2-id", "test2", "room-id", t0).
-					AddRow("test3-id", "", "room-id", t0).
-					AddRow("test4-id", "test4", "room-id", t0))
+				rows := sqlmockRowsToStdRows(sqlmock.NewRows([]string{"id", "name", "room_id", "created_at", "game_master"}).
+					AddRow("test0-id", "test0", "room-id", t0, false).
+					AddRow("test1-id", "test1", "room-id", t0, false).
+					AddRow("test2-id", "test2", "room-id", t0, false).
+					AddRow("test3-id", "", "room-id", t0, false).
+					AddRow("test4-id", "test4", "room-id", t0, false))
 
 				m.On("QueryContext", mock.Anything, expQuery, "room-id").Once().Return(rows, nil)
 			},
@@ -226,9 +226,9 @@ func TestUserRepositoryGetUserByID(t *testing.T) {
 		"Retrieving a existing user using should return the user.": {
 			config: mysql.UserRepositoryConfig{},
 			mock: func(m *mysqlmock.DBClient) {
-				expQuery := "SELECT user.id, user.name, user.room_id, user.created_at FROM user WHERE id = ?"
-				row := sqlmockRowsToStdRow(sqlmock.NewRows([]string{"id", "name", "room_id", "created_at"}).
-					AddRow("test0-id", "test0", "room0", t0))
+				expQuery := "SELECT user.id, user.name, user.room_id, user.created_at, user.game_master FROM user WHERE id = ?"
+				row := sqlmockRowsToStdRow(sqlmock.NewRows([]string{"id", "name", "room_id", "created_at", "game_master"}).
+					AddRow("test0-id", "test0", "room0", t0, false))
 
 				m.On("QueryRowContext", mock.Anything, expQuery, "test-id").Once().Return(row)
 			},
@@ -241,12 +241,31 @@ func TestUserRepositoryGetUserByID(t *testing.T) {
 			},
 		},
 
+		"Retrieving a game master user should return the user as game master.": {
+			config: mysql.UserRepositoryConfig{},
+			mock: func(m *mysqlmock.DBClient) {
+				expQuery := "SELECT user.id, user.name, user.room_id, user.created_at, user.game_master FROM user WHERE id = ?"
+				row := sqlmockRowsToStdRow(sqlmock.NewRows([]string{"id", "name", "room_id", "created_at", "game_master"}).
+					AddRow("test0-id", "test0", "room0", t0, true))
+
+				m.On("QueryRowContext", mock.Anything, expQuery, "test-id").Once().Return(row)
+			},
+			id: "test-id",
+			expUser: model.User{
+				ID:         "test0-id",
+				Name:       "test0",
+				RoomID:     "room0",
+				CreatedAt:  t0,
+				GameMaster: true,
+			},
+		},
+
 		"Retrieving a non existing user from a custom table should fail.": {
 			config: mysql.UserRepositoryConfig{
 				Table: "custom-table",
 			},
 			mock: func(m *mysqlmock.DBClient) {
-				expQuery := "SELECT custom-table.id, custom-table.name, custom-table.room_id, custom-table.created_at FROM custom-table WHERE id = ?"
+				expQuery := "SELECT custom-table.id, custom-table.name, custom-table.room_id, custom-table.created_at, custom-table.game_master FROM custom-table WHERE id = ?"
 				row := sqlRowErr(sql.ErrNoRows)
 				m.On("QueryRowContext", mock.Anything, expQuery, "test-id").Once().Return(row)
 			},
@@ -457,9 +476,9 @@ func TestUserRepositoryGetUserByNameInsensitive(t *testing.T) {
 		"Retrieving a existing user using should return the user.": {
 			config: mysql.UserRepositoryConfig{},
 			mock: func(m *mysqlmock.DBClient) {
-				expQuery := "SELECT user.id, user.name, user.room_id, user.created_at FROM user WHERE room_id = ? AND name = ?"
-				row := sqlmockRowsToStdRow(sqlmock.NewRows([]string{"id", "name", "room_id", "created_at"}).
-					AddRow("test0-id", "test0", "room0", t0))
+				expQuery := "SELECT user.id, user.name, user.room_id, user.created_at, user.game_master FROM user WHERE room_id = ? AND name = ?"
+				row := sqlmockRowsToStdRow(sqlmock.NewRows([]string{"id", "name", "room_id", "created_at", "game_master"}).
+					AddRow("test0-id", "test0", "room0", t0, false))
 
 				m.On("QueryRowContext", mock.Anything, expQuery, "room1", "user1").Once().Return(row)
 			},
@@ -478,7 +497,7 @@ func TestUserRepositoryGetUserByNameInsensitive(t *testing.T) {
 				Table: "custom-table",
 			},
 			mock: func(m *mysqlmock.DBClient) {
-				expQuery := "SELECT custom-table.id, custom-table.name, custom-table.room_id, custom-table.created_at FROM custom-table WHERE room_id = ? AND name = ?"
+				expQuery := "SELECT custom-table.id, custom-table.name, custom-table.room_id, custom-table.created_at, custom-table.game_master FROM custom-table WHERE room_id = ? AND name = ?"
 				row := sqlRowErr(sql.ErrNoRows)
 				m.On("QueryRowContext", mock.Anything, expQuery, "room1", "user1").Once().Return(row)
 			},
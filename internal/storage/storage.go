@@ -18,6 +18,23 @@ type ListDiceRollsOpts struct {
 	UserID string
 }
 
+// DieRollStatsOpts are the options used by the storage to aggregate the rolled dice, only the
+// dice of the public dice rolls that are not rerolls (that would count twice the rerolled dice
+// roll dice) are aggregated.
+type DieRollStatsOpts struct {
+	RoomID string
+	// UserID is optional, if empty the whole room is aggregated.
+	UserID string
+	// Limit is the max number of the latest rolled dice that are aggregated.
+	Limit uint
+}
+
+// DieSide is a rolled die type side.
+type DieSide struct {
+	DieTypeID string
+	Side      uint
+}
+
 // DiceRollRepository is the repository interface that implementations need to
 // implement to manage dice rolls in storage.
 type DiceRollRepository interface {
@@ -25,9 +42,21 @@ type DiceRollRepository interface {
 	// If the dice data is missing or not valid it will return a internalerrors.NotValid error kind.
 	// If the dice roll already exists it returns a internalerrors.AlreadyExists error kind.
 	CreateDiceRoll(ctx context.Context, dr model.DiceRoll) error
+	// CreateDiceRolls creates multiple dice rolls atomically in the same order, if any of
+	// them can't be created none will be created.
+	// If the dice data is missing or not valid it will return a internalerrors.NotValid error kind.
+	// If any of the dice rolls already exists it returns a internalerrors.AlreadyExists error kind.
+	CreateDiceRolls(ctx context.Context, drs []model.DiceRoll) error
 	// ListDiceRolls lists dice rolls, by default in descendant order (newest first).
 	// If the dice roomID option is empty it returns a internalerrors.NotValid error kind.
 	ListDiceRolls(ctx context.Context, pageOpts model.PaginationOpts, filterOpts ListDiceRollsOpts) (*DiceRollList, error)
+	// GetDiceRoll returns the dice roll.
+	// If the dice roll does not exist it returns internalerrors.ErrMissing.
+	GetDiceRoll(ctx context.Context, id string) (*model.DiceRoll, error)
+	// ListDieRollSides lists the latest rolled die type sides (up to the limit) in the same order
+	// they were rolled.
+	// If the roomID or the limit options are empty it returns a internalerrors.NotValid error kind.
+	ListDieRollSides(ctx context.Context, opts DieRollStatsOpts) ([]DieSide, error)
 }
 
 //go:generate mockery --case underscore --output storagemock --outpkg storagemock --name DiceRollRepository
@@ -44,6 +73,24 @@ type RoomRepository interface {
 	GetRoom(ctx context.Context, id string) (*model.Room, error)
 	// RoomExists returns true if the room exists.
 	RoomExists(ctx context.Context, id string) (exists bool, err error)
+	// DeleteRoom deletes the room with all its data (users, dice rolls, server seeds, custom die
+	// types, macros, initiative, decks and oracle tables) atomically.
+	// If the room does not exist it returns internalerrors.ErrMissing.
+	DeleteRoom(ctx context.Context, id string) error
+	// ArchiveRoom marks the room as archived.
+	// If the room does not exist or is already archived it returns internalerrors.ErrMissing.
+	ArchiveRoom(ctx context.Context, id string) error
+	// CreateRoomInviteToken adds an invite token to the room.
+	// If the room does not exist it returns internalerrors.ErrMissing.
+	// If the token already exists it returns a internalerrors.AlreadyExists error kind.
+	CreateRoomInviteToken(ctx context.Context, roomID string, t model.RoomInviteToken) error
+	// DeleteRoomInviteToken revokes an invite token of the room.
+	// If the room token does not exist it returns internalerrors.ErrMissing.
+	DeleteRoomInviteToken(ctx context.Context, roomID, token string) error
+	// ListRoomInviteTokens returns the current invite tokens of the room, unlike GetRoom these are
+	// never cached so the revoked tokens can't be used to join the room.
+	// If the room doesn't have invite tokens it returns an empty list.
+	ListRoomInviteTokens(ctx context.Context, roomID string) ([]model.RoomInviteToken, error)
 }
 
 //go:generate mockery --case underscore --output storagemock --outpkg storagemock --name RoomRepository
@@ -74,3 +121,155 @@ type UserRepository interface {
 }
 
 //go:generate mockery --case underscore --output storagemock --outpkg storagemock --name UserRepository
+
+// CustomDieTypeList is a list of custom die types.
+type CustomDieTypeList struct {
+	Items []model.CustomDieType
+}
+
+// CustomDieTypeRepository is the repository interface that implementations need to
+// implement to manage the custom die types registered by the rooms in storage.
+type CustomDieTypeRepository interface {
+	// CreateCustomDieType creates a new custom die type.
+	// If the die type data is missing or not valid it will return a internalerrors.NotValid error kind.
+	// If the die type already exists it returns a internalerrors.AlreadyExists error kind.
+	CreateCustomDieType(ctx context.Context, dt model.CustomDieType) error
+	// ListRoomCustomDieTypes returns the custom die types of a room.
+	ListRoomCustomDieTypes(ctx context.Context, roomID string) (*CustomDieTypeList, error)
+}
+
+//go:generate mockery --case underscore --output storagemock --outpkg storagemock --name CustomDieTypeRepository
+
+// ServerSeedRepository is the repository interface that implementations need to
+// implement to manage the provably fair server seeds of the rooms in storage.
+type ServerSeedRepository interface {
+	// CreateServerSeed creates a new server seed.
+	// If the server seed data is missing or not valid it will return a internalerrors.NotValid error kind.
+	// If the server seed already exists it returns a internalerrors.AlreadyExists error kind.
+	CreateServerSeed(ctx context.Context, s model.ServerSeed) error
+	// GetServerSeed returns the server seed.
+	// If the server seed does not exist it returns internalerrors.ErrMissing.
+	GetServerSeed(ctx context.Context, id string) (*model.ServerSeed, error)
+	// GetRoomActiveServerSeed returns the latest not revealed server seed of a room.
+	// If the room doesn't have an active server seed it returns internalerrors.ErrMissing.
+	GetRoomActiveServerSeed(ctx context.Context, roomID string) (*model.ServerSeed, error)
+	// IncreaseServerSeedNonce increases atomically the nonce of a server seed and returns the new nonce.
+	// If the server seed does not exist or is already revealed it returns internalerrors.ErrMissing.
+	IncreaseServerSeedNonce(ctx context.Context, id string) (nonce uint, err error)
+	// RevealServerSeed marks the server seed as revealed.
+	// If the server seed does not exist or is already revealed it returns internalerrors.ErrMissing.
+	RevealServerSeed(ctx context.Context, id string) error
+}
+
+//go:generate mockery --case underscore --output storagemock --outpkg storagemock --name ServerSeedRepository
+
+// MacroList is a list of macros.
+type MacroList struct {
+	Items []model.Macro
+}
+
+// ListMacrosOpts are the options used by the storage to list macros.
+type ListMacrosOpts struct {
+	RoomID string
+	// UserID lists the user macros and the room shared ones, if empty only the shared ones are listed.
+	UserID string
+}
+
+// MacroRepository is the repository interface that implementations need to
+// implement to manage the users and rooms saved dice roll macros in storage.
+type MacroRepository interface {
+	// CreateMacro creates a new macro.
+	// If the macro data is missing or not valid it will return a internalerrors.NotValid error kind.
+	// If the macro already exists it returns a internalerrors.AlreadyExists error kind.
+	CreateMacro(ctx context.Context, m model.Macro) error
+	// GetMacro returns the macro.
+	// If the macro does not exist it returns internalerrors.ErrMissing.
+	GetMacro(ctx context.Context, id string) (*model.Macro, error)
+	// ListMacros lists the macros of a room in creation order.
+	// If the roomID option is empty it returns a internalerrors.NotValid error kind.
+	ListMacros(ctx context.Context, filterOpts ListMacrosOpts) (*MacroList, error)
+	// UpdateMacro updates the name, expression and shared fields of a macro.
+	// If the macro does not exist it returns internalerrors.ErrMissing.
+	UpdateMacro(ctx context.Context, m model.Macro) error
+	// DeleteMacro deletes a macro.
+	// If the macro does not exist it returns internalerrors.ErrMissing.
+	DeleteMacro(ctx context.Context, id string) error
+}
+
+//go:generate mockery --case underscore --output storagemock --outpkg storagemock --name MacroRepository
+
+// InitiativeRepository is the repository interface that implementations need to
+// implement to manage the rooms initiative trackers in storage.
+type InitiativeRepository interface {
+	// GetInitiative returns the initiative tracker of a room.
+	// If the room doesn't have an initiative tracker it returns internalerrors.ErrMissing.
+	GetInitiative(ctx context.Context, roomID string) (*model.Initiative, error)
+	// SaveInitiative creates or replaces the initiative tracker of a room.
+	// If the initiative data is missing or not valid it will return a internalerrors.NotValid error kind.
+	SaveInitiative(ctx context.Context, i model.Initiative) error
+	// DeleteInitiative deletes the initiative tracker of a room.
+	// If the room doesn't have an initiative tracker it returns internalerrors.ErrMissing.
+	DeleteInitiative(ctx context.Context, roomID string) error
+}
+
+//go:generate mockery --case underscore --output storagemock --outpkg storagemock --name InitiativeRepository
+
+// DeckList is a list of card decks.
+type DeckList struct {
+	Items []model.Deck
+}
+
+// DeckRepository is the repository interface that implementations need to
+// implement to manage the rooms card decks and their shuffled state in storage.
+type DeckRepository interface {
+	// CreateDeck creates a new deck.
+	// If the deck data is missing or not valid it will return a internalerrors.NotValid error kind.
+	// If the deck already exists it returns a internalerrors.AlreadyExists error kind.
+	CreateDeck(ctx context.Context, d model.Deck) error
+	// GetDeck returns the deck.
+	// If the deck does not exist it returns internalerrors.ErrMissing.
+	GetDeck(ctx context.Context, id string) (*model.Deck, error)
+	// ListRoomDecks lists the decks of a room in creation order.
+	ListRoomDecks(ctx context.Context, roomID string) (*DeckList, error)
+	// UpdateDeck updates the draw and discard piles of a deck increasing its version.
+	// The deck version must be the stored one (the one read before the update), otherwise
+	// it returns a internalerrors.ErrConflict error kind.
+	// If the deck does not exist it returns internalerrors.ErrMissing.
+	UpdateDeck(ctx context.Context, d model.Deck) error
+}
+
+//go:generate mockery --case underscore --output storagemock --outpkg storagemock --name DeckRepository
+
+// OracleTableList is a list of oracle tables.
+type OracleTableList struct {
+	Items []model.OracleTable
+}
+
+// OracleTableRollList is a list of oracle table rolls.
+type OracleTableRollList struct {
+	Items []model.OracleTableRoll
+}
+
+// OracleTableRepository is the repository interface that implementations need to
+// implement to manage the rooms oracle tables and their rolls history in storage.
+type OracleTableRepository interface {
+	// CreateOracleTable creates a new oracle table.
+	// If the table data is missing or not valid it will return a internalerrors.NotValid error kind.
+	// If the table (or a room table with the same name) already exists it returns a internalerrors.AlreadyExists error kind.
+	CreateOracleTable(ctx context.Context, t model.OracleTable) error
+	// GetOracleTable returns the oracle table.
+	// If the table does not exist it returns internalerrors.ErrMissing.
+	GetOracleTable(ctx context.Context, id string) (*model.OracleTable, error)
+	// GetOracleTableByName returns the room oracle table with the name.
+	// If the table does not exist it returns internalerrors.ErrMissing.
+	GetOracleTableByName(ctx context.Context, roomID, name string) (*model.OracleTable, error)
+	// ListRoomOracleTables lists the oracle tables of a room in creation order.
+	ListRoomOracleTables(ctx context.Context, roomID string) (*OracleTableList, error)
+	// CreateOracleTableRoll stores a new oracle table roll on the room history.
+	// If the roll data is missing or not valid it will return a internalerrors.NotValid error kind.
+	CreateOracleTableRoll(ctx context.Context, r model.OracleTableRoll) error
+	// ListRoomOracleTableRolls lists the latest oracle table rolls of a room, newest first.
+	ListRoomOracleTableRolls(ctx context.Context, roomID string, limit int) (*OracleTableRollList, error)
+}
+
+//go:generate mockery --case underscore --output storagemock --outpkg storagemock --name OracleTableRepository
@@ -30,6 +30,46 @@ func (_m *DiceRollRepository) CreateDiceRoll(ctx context.Context, dr model.DiceR
 	return r0
 }
 
+// CreateDiceRolls provides a mock function with given fields: ctx, drs
+func (_m *DiceRollRepository) CreateDiceRolls(ctx context.Context, drs []model.DiceRoll) error {
+	ret := _m.Called(ctx, drs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []model.DiceRoll) error); ok {
+		r0 = rf(ctx, drs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDiceRoll provides a mock function with given fields: ctx, id
+func (_m *DiceRollRepository) GetDiceRoll(ctx context.Context, id string) (*model.DiceRoll, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.DiceRoll
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.DiceRoll, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.DiceRoll); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DiceRoll)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListDiceRolls provides a mock function with given fields: ctx, pageOpts, filterOpts
 func (_m *DiceRollRepository) ListDiceRolls(ctx context.Context, pageOpts model.PaginationOpts, filterOpts storage.ListDiceRollsOpts) (*storage.DiceRollList, error) {
 	ret := _m.Called(ctx, pageOpts, filterOpts)
@@ -56,6 +96,32 @@ func (_m *DiceRollRepository) ListDiceRolls(ctx context.Context, pageOpts model.
 	return r0, r1
 }
 
+// ListDieRollSides provides a mock function with given fields: ctx, opts
+func (_m *DiceRollRepository) ListDieRollSides(ctx context.Context, opts storage.DieRollStatsOpts) ([]storage.DieSide, error) {
+	ret := _m.Called(ctx, opts)
+
+	var r0 []storage.DieSide
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.DieRollStatsOpts) ([]storage.DieSide, error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.DieRollStatsOpts) []storage.DieSide); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.DieSide)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.DieRollStatsOpts) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewDiceRollRepository creates a new instance of DiceRollRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewDiceRollRepository(t interface {
@@ -14,6 +14,20 @@ type RoomRepository struct {
 	mock.Mock
 }
 
+// ArchiveRoom provides a mock function with given fields: ctx, id
+func (_m *RoomRepository) ArchiveRoom(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateRoom provides a mock function with given fields: ctx, r
 func (_m *RoomRepository) CreateRoom(ctx context.Context, r model.Room) error {
 	ret := _m.Called(ctx, r)
@@ -28,6 +42,48 @@ func (_m *RoomRepository) CreateRoom(ctx context.Context, r model.Room) error {
 	return r0
 }
 
+// CreateRoomInviteToken provides a mock function with given fields: ctx, roomID, t
+func (_m *RoomRepository) CreateRoomInviteToken(ctx context.Context, roomID string, t model.RoomInviteToken) error {
+	ret := _m.Called(ctx, roomID, t)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.RoomInviteToken) error); ok {
+		r0 = rf(ctx, roomID, t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRoom provides a mock function with given fields: ctx, id
+func (_m *RoomRepository) DeleteRoom(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRoomInviteToken provides a mock function with given fields: ctx, roomID, token
+func (_m *RoomRepository) DeleteRoomInviteToken(ctx context.Context, roomID string, token string) error {
+	ret := _m.Called(ctx, roomID, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, roomID, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetRoom provides a mock function with given fields: ctx, id
 func (_m *RoomRepository) GetRoom(ctx context.Context, id string) (*model.Room, error) {
 	ret := _m.Called(ctx, id)
@@ -54,6 +110,32 @@ func (_m *RoomRepository) GetRoom(ctx context.Context, id string) (*model.Room,
 	return r0, r1
 }
 
+// ListRoomInviteTokens provides a mock function with given fields: ctx, roomID
+func (_m *RoomRepository) ListRoomInviteTokens(ctx context.Context, roomID string) ([]model.RoomInviteToken, error) {
+	ret := _m.Called(ctx, roomID)
+
+	var r0 []model.RoomInviteToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.RoomInviteToken, error)); ok {
+		return rf(ctx, roomID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.RoomInviteToken); ok {
+		r0 = rf(ctx, roomID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.RoomInviteToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, roomID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RoomExists provides a mock function with given fields: ctx, id
 func (_m *RoomRepository) RoomExists(ctx context.Context, id string) (bool, error) {
 	ret := _m.Called(ctx, id)
@@ -26,12 +26,30 @@ func (t timeoutDiceRollRepository) CreateDiceRoll(ctx context.Context, dr model.
 	return t.next.CreateDiceRoll(ctx, dr)
 }
 
+func (t timeoutDiceRollRepository) CreateDiceRolls(ctx context.Context, drs []model.DiceRoll) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.CreateDiceRolls(ctx, drs)
+}
+
 func (t timeoutDiceRollRepository) ListDiceRolls(ctx context.Context, pageOpts model.PaginationOpts, filterOpts ListDiceRollsOpts) (resp *DiceRollList, err error) {
 	ctx, cancel := context.WithTimeout(ctx, t.timeout)
 	defer cancel()
 	return t.next.ListDiceRolls(ctx, pageOpts, filterOpts)
 }
 
+func (t timeoutDiceRollRepository) GetDiceRoll(ctx context.Context, id string) (dr *model.DiceRoll, err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.GetDiceRoll(ctx, id)
+}
+
+func (t timeoutDiceRollRepository) ListDieRollSides(ctx context.Context, opts DieRollStatsOpts) (resp []DieSide, err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.ListDieRollSides(ctx, opts)
+}
+
 type timeoutRoomRepository struct {
 	timeout time.Duration
 	next    RoomRepository
@@ -63,6 +81,36 @@ func (t timeoutRoomRepository) RoomExists(ctx context.Context, id string) (exist
 	return t.next.RoomExists(ctx, id)
 }
 
+func (t timeoutRoomRepository) DeleteRoom(ctx context.Context, id string) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.DeleteRoom(ctx, id)
+}
+
+func (t timeoutRoomRepository) ArchiveRoom(ctx context.Context, id string) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.ArchiveRoom(ctx, id)
+}
+
+func (t timeoutRoomRepository) CreateRoomInviteToken(ctx context.Context, roomID string, tk model.RoomInviteToken) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.CreateRoomInviteToken(ctx, roomID, tk)
+}
+
+func (t timeoutRoomRepository) DeleteRoomInviteToken(ctx context.Context, roomID, token string) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.DeleteRoomInviteToken(ctx, roomID, token)
+}
+
+func (t timeoutRoomRepository) ListRoomInviteTokens(ctx context.Context, roomID string) (tks []model.RoomInviteToken, err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.ListRoomInviteTokens(ctx, roomID)
+}
+
 type timeoutUserRepository struct {
 	timeout time.Duration
 	next    UserRepository
@@ -111,3 +159,231 @@ func (t timeoutUserRepository) GetUserByNameInsensitive(ctx context.Context, roo
 	defer cancel()
 	return t.next.GetUserByNameInsensitive(ctx, roomID, username)
 }
+
+type timeoutCustomDieTypeRepository struct {
+	timeout time.Duration
+	next    CustomDieTypeRepository
+}
+
+// NewTimeoutCustomDieTypeRepository wraps a CustomDieTypeRepository and timeouts.
+func NewTimeoutCustomDieTypeRepository(timeout time.Duration, next CustomDieTypeRepository) CustomDieTypeRepository {
+	return &timeoutCustomDieTypeRepository{
+		timeout: timeout,
+		next:    next,
+	}
+}
+
+func (t timeoutCustomDieTypeRepository) CreateCustomDieType(ctx context.Context, dt model.CustomDieType) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.CreateCustomDieType(ctx, dt)
+}
+
+func (t timeoutCustomDieTypeRepository) ListRoomCustomDieTypes(ctx context.Context, roomID string) (l *CustomDieTypeList, err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.ListRoomCustomDieTypes(ctx, roomID)
+}
+
+type timeoutServerSeedRepository struct {
+	timeout time.Duration
+	next    ServerSeedRepository
+}
+
+// NewTimeoutServerSeedRepository wraps a ServerSeedRepository and timeouts.
+func NewTimeoutServerSeedRepository(timeout time.Duration, next ServerSeedRepository) ServerSeedRepository {
+	return &timeoutServerSeedRepository{
+		timeout: timeout,
+		next:    next,
+	}
+}
+
+func (t timeoutServerSeedRepository) CreateServerSeed(ctx context.Context, s model.ServerSeed) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.CreateServerSeed(ctx, s)
+}
+
+func (t timeoutServerSeedRepository) GetServerSeed(ctx context.Context, id string) (s *model.ServerSeed, err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.GetServerSeed(ctx, id)
+}
+
+func (t timeoutServerSeedRepository) GetRoomActiveServerSeed(ctx context.Context, roomID string) (s *model.ServerSeed, err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.GetRoomActiveServerSeed(ctx, roomID)
+}
+
+func (t timeoutServerSeedRepository) IncreaseServerSeedNonce(ctx context.Context, id string) (nonce uint, err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.IncreaseServerSeedNonce(ctx, id)
+}
+
+func (t timeoutServerSeedRepository) RevealServerSeed(ctx context.Context, id string) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.RevealServerSeed(ctx, id)
+}
+
+type timeoutMacroRepository struct {
+	timeout time.Duration
+	next    MacroRepository
+}
+
+// NewTimeoutMacroRepository wraps a MacroRepository and timeouts.
+func NewTimeoutMacroRepository(timeout time.Duration, next MacroRepository) MacroRepository {
+	return &timeoutMacroRepository{
+		timeout: timeout,
+		next:    next,
+	}
+}
+
+func (t timeoutMacroRepository) CreateMacro(ctx context.Context, m model.Macro) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.CreateMacro(ctx, m)
+}
+
+func (t timeoutMacroRepository) GetMacro(ctx context.Context, id string) (m *model.Macro, err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.GetMacro(ctx, id)
+}
+
+func (t timeoutMacroRepository) ListMacros(ctx context.Context, filterOpts ListMacrosOpts) (l *MacroList, err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.ListMacros(ctx, filterOpts)
+}
+
+func (t timeoutMacroRepository) UpdateMacro(ctx context.Context, m model.Macro) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.UpdateMacro(ctx, m)
+}
+
+func (t timeoutMacroRepository) DeleteMacro(ctx context.Context, id string) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.DeleteMacro(ctx, id)
+}
+
+type timeoutInitiativeRepository struct {
+	timeout time.Duration
+	next    InitiativeRepository
+}
+
+// NewTimeoutInitiativeRepository wraps a InitiativeRepository and timeouts.
+func NewTimeoutInitiativeRepository(timeout time.Duration, next InitiativeRepository) InitiativeRepository {
+	return &timeoutInitiativeRepository{
+		timeout: timeout,
+		next:    next,
+	}
+}
+
+func (t timeoutInitiativeRepository) GetInitiative(ctx context.Context, roomID string) (i *model.Initiative, err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.GetInitiative(ctx, roomID)
+}
+
+func (t timeoutInitiativeRepository) SaveInitiative(ctx context.Context, i model.Initiative) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.SaveInitiative(ctx, i)
+}
+
+func (t timeoutInitiativeRepository) DeleteInitiative(ctx context.Context, roomID string) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.DeleteInitiative(ctx, roomID)
+}
+
+type timeoutDeckRepository struct {
+	timeout time.Duration
+	next    DeckRepository
+}
+
+// NewTimeoutDeckRepository wraps a DeckRepository and timeouts.
+func NewTimeoutDeckRepository(timeout time.Duration, next DeckRepository) DeckRepository {
+	return &timeoutDeckRepository{
+		timeout: timeout,
+		next:    next,
+	}
+}
+
+func (t timeoutDeckRepository) CreateDeck(ctx context.Context, d model.Deck) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.CreateDeck(ctx, d)
+}
+
+func (t timeoutDeckRepository) GetDeck(ctx context.Context, id string) (d *model.Deck, err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.GetDeck(ctx, id)
+}
+
+func (t timeoutDeckRepository) ListRoomDecks(ctx context.Context, roomID string) (l *DeckList, err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.ListRoomDecks(ctx, roomID)
+}
+
+func (t timeoutDeckRepository) UpdateDeck(ctx context.Context, d model.Deck) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.UpdateDeck(ctx, d)
+}
+
+type timeoutOracleTableRepository struct {
+	timeout time.Duration
+	next    OracleTableRepository
+}
+
+// NewTimeoutOracleTableRepository wraps a OracleTableRepository and timeouts.
+func NewTimeoutOracleTableRepository(timeout time.Duration, next OracleTableRepository) OracleTableRepository {
+	return &timeoutOracleTableRepository{
+		timeout: timeout,
+		next:    next,
+	}
+}
+
+func (t timeoutOracleTableRepository) CreateOracleTable(ctx context.Context, ot model.OracleTable) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.CreateOracleTable(ctx, ot)
+}
+
+func (t timeoutOracleTableRepository) GetOracleTable(ctx context.Context, id string) (ot *model.OracleTable, err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.GetOracleTable(ctx, id)
+}
+
+func (t timeoutOracleTableRepository) GetOracleTableByName(ctx context.Context, roomID, name string) (ot *model.OracleTable, err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.GetOracleTableByName(ctx, roomID, name)
+}
+
+func (t timeoutOracleTableRepository) ListRoomOracleTables(ctx context.Context, roomID string) (l *OracleTableList, err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.ListRoomOracleTables(ctx, roomID)
+}
+
+func (t timeoutOracleTableRepository) CreateOracleTableRoll(ctx context.Context, r model.OracleTableRoll) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.CreateOracleTableRoll(ctx, r)
+}
+
+func (t timeoutOracleTableRepository) ListRoomOracleTableRolls(ctx context.Context, roomID string, limit int) (l *OracleTableRollList, err error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.next.ListRoomOracleTableRolls(ctx, roomID, limit)
+}
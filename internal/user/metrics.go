@@ -48,3 +48,11 @@ func (m measuredService) GetUser(ctx context.Context, req GetUserRequest) (resp
 
 	return m.next.GetUser(ctx, req)
 }
+
+func (m measuredService) AuthenticateUser(ctx context.Context, req AuthenticateUserRequest) (resp *AuthenticateUserResponse, err error) {
+	defer func(t0 time.Time) {
+		m.rec.MeasureUserServiceOpDuration(ctx, "AuthenticateUser", err == nil, time.Since(t0))
+	}(time.Now())
+
+	return m.next.AuthenticateUser(ctx, req)
+}
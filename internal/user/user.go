@@ -2,12 +2,19 @@ package user
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
 
 	"github.com/rollify/rollify/internal/internalerrors"
 	"github.com/rollify/rollify/internal/log"
@@ -23,6 +30,8 @@ type Service interface {
 	ListUsers(ctx context.Context, r ListUsersRequest) (*ListUsersResponse, error)
 	// Get an user by its ID.
 	GetUser(ctx context.Context, r GetUserRequest) (*GetUserResponse, error)
+	// Authenticates an user using the session token returned on the user creation.
+	AuthenticateUser(ctx context.Context, r AuthenticateUserRequest) (*AuthenticateUserResponse, error)
 }
 
 //go:generate mockery --case underscore --output usermock --outpkg usermock --name Service
@@ -31,9 +40,12 @@ type Service interface {
 type ServiceConfig struct {
 	UserRepository storage.UserRepository
 	RoomRepository storage.RoomRepository
-	Logger         log.Logger
-	IDGenerator    func() string
-	TimeNowFunc    func() time.Time
+	// SessionKey is the key used to sign the user session tokens, all the instances of the
+	// application need the same key. By default a random one.
+	SessionKey  []byte
+	Logger      log.Logger
+	IDGenerator func() string
+	TimeNowFunc func() time.Time
 }
 
 func (c *ServiceConfig) defaults() error {
@@ -45,6 +57,14 @@ func (c *ServiceConfig) defaults() error {
 		return fmt.Errorf("config.RoomRepository is required")
 	}
 
+	if len(c.SessionKey) == 0 {
+		c.SessionKey = make([]byte, 32)
+		_, err := rand.Read(c.SessionKey)
+		if err != nil {
+			return fmt.Errorf("could not generate session key: %w", err)
+		}
+	}
+
 	if c.Logger == nil {
 		c.Logger = log.Dummy
 	}
@@ -62,11 +82,12 @@ func (c *ServiceConfig) defaults() error {
 }
 
 type service struct {
-	userRepo storage.UserRepository
-	roomRepo storage.RoomRepository
-	logger   log.Logger
-	idGen    func() string
-	timeNow  func() time.Time
+	userRepo   storage.UserRepository
+	roomRepo   storage.RoomRepository
+	sessionKey []byte
+	logger     log.Logger
+	idGen      func() string
+	timeNow    func() time.Time
 }
 
 // NewService returns a new user.Service.
@@ -77,11 +98,12 @@ func NewService(cfg ServiceConfig) (Service, error) {
 	}
 
 	return service{
-		userRepo: cfg.UserRepository,
-		roomRepo: cfg.RoomRepository,
-		logger:   cfg.Logger,
-		idGen:    cfg.IDGenerator,
-		timeNow:  cfg.TimeNowFunc,
+		userRepo:   cfg.UserRepository,
+		roomRepo:   cfg.RoomRepository,
+		sessionKey: cfg.SessionKey,
+		logger:     cfg.Logger,
+		idGen:      cfg.IDGenerator,
+		timeNow:    cfg.TimeNowFunc,
 	}, nil
 }
 
@@ -89,6 +111,16 @@ func NewService(cfg ServiceConfig) (Service, error) {
 type CreateUserRequest struct {
 	Name   string
 	RoomID string
+	// GameMaster creates the user as a game master of the room, only the room creator (the first
+	// user of the room) can be game master. Ignored if the user already exists.
+	GameMaster bool
+	// Password or InviteToken are required to join password protected rooms, including the
+	// users that already exist.
+	Password    string
+	InviteToken string
+	// SessionToken is required to log in again as a user that already exists, it needs to be
+	// the session of that user.
+	SessionToken string
 }
 
 var userNameRegex = regexp.MustCompile(`^[a-zA-Z0-9 _\-.']+$`)
@@ -111,6 +143,9 @@ func (r CreateUserRequest) validate() error {
 // CreateUserResponse is the response to the CreateUser request.
 type CreateUserResponse struct {
 	User model.User
+	// SessionToken is the secret token that authenticates the user, only the user that
+	// joined the room should know it.
+	SessionToken string
 }
 
 func (s service) CreateUser(ctx context.Context, r CreateUserRequest) (*CreateUserResponse, error) {
@@ -120,13 +155,24 @@ func (s service) CreateUser(ctx context.Context, r CreateUserRequest) (*CreateUs
 	}
 
 	// Check the room exists.
-	exists, err := s.roomRepo.RoomExists(ctx, r.RoomID)
-	if err != nil {
-		return nil, fmt.Errorf("could not check room exists: %w", err)
+	room, err := s.roomRepo.GetRoom(ctx, r.RoomID)
+	switch {
+	case errors.Is(err, internalerrors.ErrMissing):
+		return nil, fmt.Errorf("room does not exist: %w", internalerrors.ErrNotValid)
+	case err != nil:
+		return nil, fmt.Errorf("could not get room: %w", err)
 	}
 
-	if !exists {
-		return nil, fmt.Errorf("room does not exist: %w", internalerrors.ErrNotValid)
+	if room.Archived {
+		return nil, fmt.Errorf("room is archived: %w", internalerrors.ErrNotAllowed)
+	}
+
+	ok, err := s.canJoinRoom(ctx, *room, r.Password, r.InviteToken)
+	if err != nil {
+		return nil, fmt.Errorf("could not check the room credentials: %w", err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("wrong room password or invite token: %w", internalerrors.ErrNotAllowed)
 	}
 
 	// Check user exists by room and being case insensitive.
@@ -135,17 +181,36 @@ func (s service) CreateUser(ctx context.Context, r CreateUserRequest) (*CreateUs
 	case err != nil && !errors.Is(err, internalerrors.ErrMissing):
 		return nil, fmt.Errorf("could check user already exists: %w", err)
 	case err == nil:
+		// Anyone can know the users names, so logging in again as an existing user needs its credentials.
+		if !s.canResumeUser(*storedUser, r.SessionToken) {
+			return nil, fmt.Errorf("user already exists, its session is required to log in again: %w", internalerrors.ErrAlreadyExists)
+		}
+
 		return &CreateUserResponse{
-			User: *storedUser,
+			User:         *storedUser,
+			SessionToken: s.newSessionToken(storedUser.ID),
 		}, nil
 	}
 
+	// Only the room creator can be a game master, otherwise anyone could see the hidden dice rolls.
+	if r.GameMaster {
+		us, err := s.userRepo.ListRoomUsers(ctx, r.RoomID)
+		if err != nil {
+			return nil, fmt.Errorf("could not list room users: %w", err)
+		}
+
+		if len(us.Items) > 0 {
+			return nil, fmt.Errorf("only the room creator can be game master: %w", internalerrors.ErrNotAllowed)
+		}
+	}
+
 	// Create a new user.
 	user := model.User{
-		ID:        s.idGen(),
-		CreatedAt: s.timeNow().UTC(),
-		RoomID:    r.RoomID,
-		Name:      r.Name,
+		ID:         s.idGen(),
+		CreatedAt:  s.timeNow().UTC(),
+		RoomID:     r.RoomID,
+		Name:       r.Name,
+		GameMaster: r.GameMaster,
 	}
 	err = s.userRepo.CreateUser(ctx, user)
 	if err != nil {
@@ -153,13 +218,54 @@ func (s service) CreateUser(ctx context.Context, r CreateUserRequest) (*CreateUs
 	}
 
 	return &CreateUserResponse{
-		User: user,
+		User:         user,
+		SessionToken: s.newSessionToken(user.ID),
 	}, nil
 }
 
+// canJoinRoom returns true if the room is not protected or the password or the invite token are valid.
+//
+// The invite tokens are read from the repository instead of the (maybe cached) room, so the
+// revoked tokens can't be used on any app instance.
+func (s service) canJoinRoom(ctx context.Context, room model.Room, password, inviteToken string) (bool, error) {
+	if !room.Protected() {
+		return true, nil
+	}
+
+	if inviteToken != "" {
+		tokens, err := s.roomRepo.ListRoomInviteTokens(ctx, room.ID)
+		if err != nil {
+			return false, fmt.Errorf("could not list room invite tokens: %w", err)
+		}
+
+		for _, t := range tokens {
+			if subtle.ConstantTimeCompare([]byte(t.Token), []byte(inviteToken)) == 1 {
+				return true, nil
+			}
+		}
+	}
+
+	return checkRoomPassword(room, password), nil
+}
+
+// canResumeUser returns true if the session token can log in again as the existing user. The session is
+// also the game master credential, the room password is known by all the room users and rooms may not have one.
+func (s service) canResumeUser(u model.User, sessionToken string) bool {
+	userID, ok := s.verifySessionToken(sessionToken)
+	return ok && userID == u.ID
+}
+
+// checkRoomPassword returns true if the room has a password and it matches.
+func checkRoomPassword(room model.Room, password string) bool {
+	return room.PasswordHash != "" && password != "" && bcrypt.CompareHashAndPassword([]byte(room.PasswordHash), []byte(password)) == nil
+}
+
 // ListUsersRequest is the request to ListUsers.
 type ListUsersRequest struct {
 	RoomID string
+	// SessionToken is required to list the users of password protected rooms, it needs to be
+	// the session of a room user.
+	SessionToken string
 }
 
 func (r ListUsersRequest) validate() error {
@@ -181,13 +287,24 @@ func (s service) ListUsers(ctx context.Context, r ListUsersRequest) (*ListUsersR
 		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
 	}
 
-	exists, err := s.roomRepo.RoomExists(ctx, r.RoomID)
-	if err != nil {
-		return nil, fmt.Errorf("could not check if room exists: %w", err)
+	room, err := s.roomRepo.GetRoom(ctx, r.RoomID)
+	switch {
+	case errors.Is(err, internalerrors.ErrMissing):
+		return nil, fmt.Errorf("%w: room does not exist", internalerrors.ErrNotValid)
+	case err != nil:
+		return nil, fmt.Errorf("could not get room: %w", err)
 	}
 
-	if !exists {
-		return nil, fmt.Errorf("%w: room does not exist", internalerrors.ErrNotValid)
+	// Knowing the users of a protected room is enough to act on it, so only its users can list them.
+	if room.Protected() {
+		if r.SessionToken == "" {
+			return nil, fmt.Errorf("session token is required on protected rooms: %w", internalerrors.ErrNotAllowed)
+		}
+
+		_, err := s.AuthenticateUser(ctx, AuthenticateUserRequest{SessionToken: r.SessionToken, RoomID: r.RoomID})
+		if err != nil {
+			return nil, fmt.Errorf("could not authenticate the room user: %w", err)
+		}
 	}
 
 	us, err := s.userRepo.ListRoomUsers(ctx, r.RoomID)
@@ -234,3 +351,78 @@ func (s service) GetUser(ctx context.Context, r GetUserRequest) (*GetUserRespons
 		User: *user,
 	}, nil
 }
+
+// AuthenticateUserRequest is the request to AuthenticateUser.
+type AuthenticateUserRequest struct {
+	SessionToken string
+	// RoomID is optional, if set the user needs to be of the room.
+	RoomID string
+}
+
+func (r AuthenticateUserRequest) validate() error {
+	if r.SessionToken == "" {
+		return fmt.Errorf("session token is required")
+	}
+
+	return nil
+}
+
+// AuthenticateUserResponse is the response to the AuthenticateUser request.
+type AuthenticateUserResponse struct {
+	User model.User
+}
+
+func (s service) AuthenticateUser(ctx context.Context, r AuthenticateUserRequest) (*AuthenticateUserResponse, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", internalerrors.ErrNotValid, err)
+	}
+
+	userID, ok := s.verifySessionToken(r.SessionToken)
+	if !ok {
+		return nil, fmt.Errorf("invalid session token: %w", internalerrors.ErrNotAllowed)
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	switch {
+	case errors.Is(err, internalerrors.ErrMissing):
+		return nil, fmt.Errorf("session user does not exist: %w", internalerrors.ErrNotAllowed)
+	case err != nil:
+		return nil, fmt.Errorf("could not get the user: %w", err)
+	}
+
+	if r.RoomID != "" && user.RoomID != r.RoomID {
+		return nil, fmt.Errorf("session user is not of the room: %w", internalerrors.ErrNotAllowed)
+	}
+
+	return &AuthenticateUserResponse{
+		User: *user,
+	}, nil
+}
+
+// newSessionToken returns the session token of the user, it's the user ID signed with the
+// session key, so we don't need to store the sessions.
+func (s service) newSessionToken(userID string) string {
+	return userID + "." + base64.RawURLEncoding.EncodeToString(s.signSession(userID))
+}
+
+// verifySessionToken returns the user ID of the session token, false if the signature is not valid.
+func (s service) verifySessionToken(token string) (string, bool) {
+	userID, sig, ok := strings.Cut(token, ".")
+	if !ok || userID == "" {
+		return "", false
+	}
+
+	gotSig, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil {
+		return "", false
+	}
+
+	return userID, hmac.Equal(gotSig, s.signSession(userID))
+}
+
+func (s service) signSession(userID string) []byte {
+	mac := hmac.New(sha256.New, s.sessionKey)
+	_, _ = mac.Write([]byte("session:" + userID))
+	return mac.Sum(nil)
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 
 	"github.com/rollify/rollify/internal/internalerrors"
 	"github.com/rollify/rollify/internal/model"
@@ -20,17 +22,25 @@ import (
 
 func TestServiceCreateUser(t *testing.T) {
 	t0 := time.Now().UTC()
+	passwordHash, _ := bcrypt.GenerateFromPassword([]byte("test-password"), bcrypt.MinCost)
+	// The room can be cached with already revoked tokens (token0), the current ones are listed uncached.
+	protectedRoom := &model.Room{
+		ID:           "room-id",
+		PasswordHash: string(passwordHash),
+		InviteTokens: []model.RoomInviteToken{{Token: "token0"}, {Token: "token1"}},
+	}
+	inviteTokens := []model.RoomInviteToken{{Token: "token1"}}
 
 	tests := map[string]struct {
 		config  user.ServiceConfig
 		mock    func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository)
-		req     func() user.CreateUserRequest
+		req     func(token string) user.CreateUserRequest
 		expResp func() *user.CreateUserResponse
 		expErr  bool
 	}{
 		"Having a creation request without name, should fail.": {
 			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {},
-			req: func() user.CreateUserRequest {
+			req: func(token string) user.CreateUserRequest {
 				return user.CreateUserRequest{Name: "", RoomID: "test-room"}
 			},
 			expErr: true,
@@ -38,7 +48,7 @@ func TestServiceCreateUser(t *testing.T) {
 
 		"Having a creation request without room id, should fail.": {
 			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {},
-			req: func() user.CreateUserRequest {
+			req: func(token string) user.CreateUserRequest {
 				return user.CreateUserRequest{Name: "us-e_r.n'ame 42", RoomID: ""}
 			},
 			expErr: true,
@@ -46,7 +56,7 @@ func TestServiceCreateUser(t *testing.T) {
 
 		"Having a creation request with not valid user name, should fail.": {
 			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {},
-			req: func() user.CreateUserRequest {
+			req: func(token string) user.CreateUserRequest {
 				return user.CreateUserRequest{Name: "usernamé", RoomID: "room-id"}
 			},
 			expErr: true,
@@ -54,9 +64,9 @@ func TestServiceCreateUser(t *testing.T) {
 
 		"Having a creation request with in a room that does not exists, should error.": {
 			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
-				rr.On("RoomExists", mock.Anything, "room-id").Once().Return(false, nil)
+				rr.On("GetRoom", mock.Anything, "room-id").Once().Return(nil, internalerrors.ErrMissing)
 			},
-			req: func() user.CreateUserRequest {
+			req: func(token string) user.CreateUserRequest {
 				return user.CreateUserRequest{Name: "us-e_r.n'ame 42", RoomID: "room-id"}
 			},
 			expErr: true,
@@ -64,17 +74,142 @@ func TestServiceCreateUser(t *testing.T) {
 
 		"Having a creation request with an error while checking database, should error.": {
 			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
-				rr.On("RoomExists", mock.Anything, "room-id").Once().Return(false, errors.New("wanted error"))
+				rr.On("GetRoom", mock.Anything, "room-id").Once().Return(nil, errors.New("wanted error"))
+			},
+			req: func(token string) user.CreateUserRequest {
+				return user.CreateUserRequest{Name: "us-e_r.n'ame 42", RoomID: "room-id"}
+			},
+			expErr: true,
+		},
+
+		"Having a creation request in an archived room, should fail.": {
+			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
+				rr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id", Archived: true}, nil)
+			},
+			req: func(token string) user.CreateUserRequest {
+				return user.CreateUserRequest{Name: "us-e_r.n'ame 42", RoomID: "room-id"}
+			},
+			expErr: true,
+		},
+
+		"Having a creation request in a password protected room without credentials, should fail.": {
+			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
+				rr.On("GetRoom", mock.Anything, "room-id").Once().Return(protectedRoom, nil)
+			},
+			req: func(token string) user.CreateUserRequest {
+				return user.CreateUserRequest{Name: "us-e_r.n'ame 42", RoomID: "room-id"}
+			},
+			expErr: true,
+		},
+
+		"Having a creation request in a password protected room with a wrong password, should fail.": {
+			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
+				rr.On("GetRoom", mock.Anything, "room-id").Once().Return(protectedRoom, nil)
+			},
+			req: func(token string) user.CreateUserRequest {
+				return user.CreateUserRequest{Name: "us-e_r.n'ame 42", RoomID: "room-id", Password: "wrong"}
+			},
+			expErr: true,
+		},
+
+		"Having a creation request in a password protected room with a revoked invite token, should fail.": {
+			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
+				rr.On("GetRoom", mock.Anything, "room-id").Once().Return(protectedRoom, nil)
+				rr.On("ListRoomInviteTokens", mock.Anything, "room-id").Once().Return(inviteTokens, nil)
+			},
+			req: func(token string) user.CreateUserRequest {
+				return user.CreateUserRequest{Name: "us-e_r.n'ame 42", RoomID: "room-id", InviteToken: "token0"}
+			},
+			expErr: true,
+		},
+
+		"Having a creation request in a password protected room with an error while listing the invite tokens, should fail.": {
+			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
+				rr.On("GetRoom", mock.Anything, "room-id").Once().Return(protectedRoom, nil)
+				rr.On("ListRoomInviteTokens", mock.Anything, "room-id").Once().Return(nil, errors.New("wanted error"))
+			},
+			req: func(token string) user.CreateUserRequest {
+				return user.CreateUserRequest{Name: "us-e_r.n'ame 42", RoomID: "room-id", InviteToken: "token1"}
+			},
+			expErr: true,
+		},
+
+		"Having a creation request in a password protected room with the password, should create the user.": {
+			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
+				rr.On("GetRoom", mock.Anything, "room-id").Once().Return(protectedRoom, nil)
+				ru.On("GetUserByNameInsensitive", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, internalerrors.ErrMissing)
+				ru.On("CreateUser", mock.Anything, mock.Anything).Once().Return(nil)
+			},
+			req: func(token string) user.CreateUserRequest {
+				return user.CreateUserRequest{Name: "us-e_r.n'ame 42", RoomID: "room-id", Password: "test-password"}
+			},
+			expResp: func() *user.CreateUserResponse {
+				return &user.CreateUserResponse{
+					User: model.User{
+						ID:        "test",
+						Name:      "us-e_r.n'ame 42",
+						RoomID:    "room-id",
+						CreatedAt: t0,
+					},
+				}
+			},
+		},
+
+		"Having a creation request in a password protected room with an invite token, should create the user.": {
+			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
+				rr.On("GetRoom", mock.Anything, "room-id").Once().Return(protectedRoom, nil)
+				rr.On("ListRoomInviteTokens", mock.Anything, "room-id").Once().Return(inviteTokens, nil)
+				ru.On("GetUserByNameInsensitive", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, internalerrors.ErrMissing)
+				ru.On("CreateUser", mock.Anything, mock.Anything).Once().Return(nil)
+			},
+			req: func(token string) user.CreateUserRequest {
+				return user.CreateUserRequest{Name: "us-e_r.n'ame 42", RoomID: "room-id", InviteToken: "token1"}
+			},
+			expResp: func() *user.CreateUserResponse {
+				return &user.CreateUserResponse{
+					User: model.User{
+						ID:        "test",
+						Name:      "us-e_r.n'ame 42",
+						RoomID:    "room-id",
+						CreatedAt: t0,
+					},
+				}
+			},
+		},
+
+		"Having a creation request with an user that already exists without its session, should fail.": {
+			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
+				rr.On("GetRoom", mock.Anything, mock.Anything).Once().Return(&model.Room{ID: "room-id"}, nil)
+				ru.On("GetUserByNameInsensitive", mock.Anything, "room-id", "us-e_r.n'ame 42").Once().Return(&model.User{
+					ID:     "test",
+					Name:   "us-e_r.n'ame 42",
+					RoomID: "room-id",
+				}, nil)
 			},
-			req: func() user.CreateUserRequest {
+			req: func(token string) user.CreateUserRequest {
 				return user.CreateUserRequest{Name: "us-e_r.n'ame 42", RoomID: "room-id"}
 			},
 			expErr: true,
 		},
 
-		"Having a creation request with an user that already exists, should return the user.": {
+		"Having a creation request with an user that already exists with the session of another user, should fail.": {
+			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
+				rr.On("GetRoom", mock.Anything, mock.Anything).Once().Return(&model.Room{ID: "room-id"}, nil)
+				ru.On("GetUserByNameInsensitive", mock.Anything, "room-id", "us-e_r.n'ame 42").Once().Return(&model.User{
+					ID:     "other",
+					Name:   "us-e_r.n'ame 42",
+					RoomID: "room-id",
+				}, nil)
+			},
+			req: func(token string) user.CreateUserRequest {
+				return user.CreateUserRequest{Name: "us-e_r.n'ame 42", RoomID: "room-id", SessionToken: token}
+			},
+			expErr: true,
+		},
+
+		"Having a creation request with an user that already exists with its session, should return the user.": {
 			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
-				rr.On("RoomExists", mock.Anything, mock.Anything).Once().Return(true, nil)
+				rr.On("GetRoom", mock.Anything, mock.Anything).Once().Return(&model.Room{ID: "room-id"}, nil)
 				ru.On("GetUserByNameInsensitive", mock.Anything, "room-id", "us-e_r.n'ame 42").Once().Return(&model.User{
 					ID:        "test",
 					Name:      "us-e_r.n'ame 42",
@@ -82,8 +217,8 @@ func TestServiceCreateUser(t *testing.T) {
 					CreatedAt: t0,
 				}, nil)
 			},
-			req: func() user.CreateUserRequest {
-				return user.CreateUserRequest{Name: "us-e_r.n'ame 42", RoomID: "room-id"}
+			req: func(token string) user.CreateUserRequest {
+				return user.CreateUserRequest{Name: "us-e_r.n'ame 42", RoomID: "room-id", SessionToken: token}
 			},
 			expResp: func() *user.CreateUserResponse {
 				return &user.CreateUserResponse{
@@ -97,12 +232,70 @@ func TestServiceCreateUser(t *testing.T) {
 			},
 		},
 
+		"Having a creation request with a game master that already exists without the room password, should fail.": {
+			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
+				rr.On("GetRoom", mock.Anything, mock.Anything).Once().Return(protectedRoom, nil)
+				rr.On("ListRoomInviteTokens", mock.Anything, "room-id").Once().Return(inviteTokens, nil)
+				ru.On("GetUserByNameInsensitive", mock.Anything, "room-id", "gm").Once().Return(&model.User{
+					ID:         "test",
+					Name:       "gm",
+					RoomID:     "room-id",
+					GameMaster: true,
+				}, nil)
+			},
+			req: func(token string) user.CreateUserRequest {
+				return user.CreateUserRequest{Name: "gm", RoomID: "room-id", InviteToken: "token1"}
+			},
+			expErr: true,
+		},
+
+		"Having a creation request with a game master that already exists with the room password, should fail.": {
+			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
+				rr.On("GetRoom", mock.Anything, mock.Anything).Once().Return(protectedRoom, nil)
+				ru.On("GetUserByNameInsensitive", mock.Anything, "room-id", "gm").Once().Return(&model.User{
+					ID:         "test",
+					Name:       "gm",
+					RoomID:     "room-id",
+					GameMaster: true,
+				}, nil)
+			},
+			req: func(token string) user.CreateUserRequest {
+				return user.CreateUserRequest{Name: "gm", RoomID: "room-id", Password: "test-password"}
+			},
+			expErr: true,
+		},
+
+		"Having a creation request with a game master that already exists on a room without password with its session, should return the user.": {
+			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
+				rr.On("GetRoom", mock.Anything, mock.Anything).Once().Return(&model.Room{ID: "room-id"}, nil)
+				ru.On("GetUserByNameInsensitive", mock.Anything, "room-id", "gm").Once().Return(&model.User{
+					ID:         "test",
+					Name:       "gm",
+					RoomID:     "room-id",
+					GameMaster: true,
+				}, nil)
+			},
+			req: func(token string) user.CreateUserRequest {
+				return user.CreateUserRequest{Name: "gm", RoomID: "room-id", SessionToken: token}
+			},
+			expResp: func() *user.CreateUserResponse {
+				return &user.CreateUserResponse{
+					User: model.User{
+						ID:         "test",
+						Name:       "gm",
+						RoomID:     "room-id",
+						GameMaster: true,
+					},
+				}
+			},
+		},
+
 		"Having a creation request with an error while checking the user that already exists, should error.": {
 			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
-				rr.On("RoomExists", mock.Anything, mock.Anything).Once().Return(true, nil)
+				rr.On("GetRoom", mock.Anything, mock.Anything).Once().Return(&model.Room{ID: "room-id"}, nil)
 				ru.On("GetUserByNameInsensitive", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("something"))
 			},
-			req: func() user.CreateUserRequest {
+			req: func(token string) user.CreateUserRequest {
 				return user.CreateUserRequest{Name: "us-e_r.n'ame 42", RoomID: "room-id"}
 			},
 			expErr: true,
@@ -110,7 +303,7 @@ func TestServiceCreateUser(t *testing.T) {
 
 		"Having a creation request with an user, should create the user.": {
 			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
-				rr.On("RoomExists", mock.Anything, mock.Anything).Once().Return(true, nil)
+				rr.On("GetRoom", mock.Anything, mock.Anything).Once().Return(&model.Room{ID: "room-id"}, nil)
 				ru.On("GetUserByNameInsensitive", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, internalerrors.ErrMissing)
 				expUser := model.User{
 					ID:        "test",
@@ -120,7 +313,7 @@ func TestServiceCreateUser(t *testing.T) {
 				}
 				ru.On("CreateUser", mock.Anything, expUser).Once().Return(nil)
 			},
-			req: func() user.CreateUserRequest {
+			req: func(token string) user.CreateUserRequest {
 				return user.CreateUserRequest{Name: "us-e_r.n'ame 42", RoomID: "room-id"}
 			},
 			expResp: func() *user.CreateUserResponse {
@@ -135,13 +328,57 @@ func TestServiceCreateUser(t *testing.T) {
 			},
 		},
 
+		"Having a creation request with a game master user, should create the user as game master.": {
+			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
+				rr.On("GetRoom", mock.Anything, mock.Anything).Once().Return(&model.Room{ID: "room-id"}, nil)
+				ru.On("GetUserByNameInsensitive", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, internalerrors.ErrMissing)
+				expUser := model.User{
+					ID:         "test",
+					Name:       "gm",
+					RoomID:     "room-id",
+					CreatedAt:  t0,
+					GameMaster: true,
+				}
+				ru.On("ListRoomUsers", mock.Anything, "room-id").Once().Return(&storage.UserList{}, nil)
+				ru.On("CreateUser", mock.Anything, expUser).Once().Return(nil)
+			},
+			req: func(token string) user.CreateUserRequest {
+				return user.CreateUserRequest{Name: "gm", RoomID: "room-id", GameMaster: true}
+			},
+			expResp: func() *user.CreateUserResponse {
+				return &user.CreateUserResponse{
+					User: model.User{
+						ID:         "test",
+						Name:       "gm",
+						RoomID:     "room-id",
+						CreatedAt:  t0,
+						GameMaster: true,
+					},
+				}
+			},
+		},
+
+		"Having a creation request with a game master user on a room that already has users, should fail.": {
+			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
+				rr.On("GetRoom", mock.Anything, mock.Anything).Once().Return(&model.Room{ID: "room-id"}, nil)
+				ru.On("GetUserByNameInsensitive", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, internalerrors.ErrMissing)
+				ru.On("ListRoomUsers", mock.Anything, "room-id").Once().Return(&storage.UserList{
+					Items: []model.User{{ID: "creator", RoomID: "room-id", GameMaster: true}},
+				}, nil)
+			},
+			req: func(token string) user.CreateUserRequest {
+				return user.CreateUserRequest{Name: "gm", RoomID: "room-id", GameMaster: true}
+			},
+			expErr: true,
+		},
+
 		"Having a creation request with an error while storing the user, should fail.": {
 			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
-				rr.On("RoomExists", mock.Anything, mock.Anything).Once().Return(true, nil)
+				rr.On("GetRoom", mock.Anything, mock.Anything).Once().Return(&model.Room{ID: "room-id"}, nil)
 				ru.On("GetUserByNameInsensitive", mock.Anything, "room-id", "us-e_r.n'ame 42").Once().Return(nil, internalerrors.ErrMissing)
 				ru.On("CreateUser", mock.Anything, mock.Anything).Once().Return(fmt.Errorf("wanted error"))
 			},
-			req: func() user.CreateUserRequest {
+			req: func(token string) user.CreateUserRequest {
 				return user.CreateUserRequest{Name: "us-e_r.n'ame 42", RoomID: "room-id"}
 			},
 			expErr: true,
@@ -156,7 +393,6 @@ func TestServiceCreateUser(t *testing.T) {
 			// Mocks
 			mr := &storagemock.RoomRepository{}
 			mu := &storagemock.UserRepository{}
-			test.mock(mu, mr)
 
 			test.config.RoomRepository = mr
 			test.config.UserRepository = mu
@@ -166,88 +402,143 @@ func TestServiceCreateUser(t *testing.T) {
 			svc, err := user.NewService(test.config)
 			require.NoError(err)
 
-			gotResp, err := svc.CreateUser(context.TODO(), test.req())
+			// Get the session token of the "test" user joining the room.
+			mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+			mu.On("GetUserByNameInsensitive", mock.Anything, "room-id", "test").Once().Return(nil, internalerrors.ErrMissing)
+			mu.On("CreateUser", mock.Anything, mock.Anything).Once().Return(nil)
+			tResp, err := svc.CreateUser(context.TODO(), user.CreateUserRequest{Name: "test", RoomID: "room-id"})
+			require.NoError(err)
+
+			test.mock(mu, mr)
+			gotResp, err := svc.CreateUser(context.TODO(), test.req(tResp.SessionToken))
 
 			if test.expErr {
 				assert.Error(err)
 			} else if assert.NoError(err) {
+				// The session token is random, is checked on the authentication tests.
+				assert.NotEmpty(gotResp.SessionToken)
+				gotResp.SessionToken = ""
 				assert.Equal(test.expResp(), gotResp)
 			}
+
+			mr.AssertExpectations(t)
 		})
 	}
 }
 
 func TestServiceListUsers(t *testing.T) {
 	t0 := time.Now().UTC()
+	users := &storage.UserList{
+		Items: []model.User{
+			{ID: "user1", Name: "username1", RoomID: "room-id"},
+			{ID: "user2", Name: "username2", RoomID: "room-id"},
+		},
+	}
+	protectedRoom := &model.Room{ID: "room-id", PasswordHash: "hash"}
+	errWanted := errors.New("wanted error")
 
 	tests := map[string]struct {
 		config  user.ServiceConfig
 		mock    func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository)
-		req     func() user.ListUsersRequest
+		req     func(token string) user.ListUsersRequest
 		expResp func() *user.ListUsersResponse
-		expErr  bool
+		expErr  error
 	}{
 		"Having a list request without room ID, should fail.": {
 			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {},
-			req: func() user.ListUsersRequest {
+			req: func(token string) user.ListUsersRequest {
 				return user.ListUsersRequest{RoomID: ""}
 			},
-			expErr: true,
+			expErr: internalerrors.ErrNotValid,
 		},
 
 		"Having a list request for a non existent room, should fail.": {
 			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
-				rr.On("RoomExists", mock.Anything, "room-id").Once().Return(false, nil)
+				rr.On("GetRoom", mock.Anything, "room-id").Once().Return(nil, internalerrors.ErrMissing)
 			},
-			req: func() user.ListUsersRequest {
+			req: func(token string) user.ListUsersRequest {
 				return user.ListUsersRequest{RoomID: "room-id"}
 			},
-			expErr: true,
+			expErr: internalerrors.ErrNotValid,
 		},
 
-		"Having a list request with an error while checking the room exists, should fail.": {
+		"Having a list request with an error while getting the room, should fail.": {
 			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
-				rr.On("RoomExists", mock.Anything, mock.Anything).Once().Return(false, errors.New("wanted error"))
+				rr.On("GetRoom", mock.Anything, mock.Anything).Once().Return(nil, errWanted)
 			},
-			req: func() user.ListUsersRequest {
+			req: func(token string) user.ListUsersRequest {
 				return user.ListUsersRequest{RoomID: "room-id"}
 			},
-			expErr: true,
+			expErr: errWanted,
 		},
 
 		"Having a list request, should list the users.": {
 			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
-				rr.On("RoomExists", mock.Anything, mock.Anything).Return(true, nil)
-				users := &storage.UserList{
-					Items: []model.User{
-						{ID: "user1", Name: "username1"},
-						{ID: "user2", Name: "username2"},
-					},
-				}
-				ru.On("ListRoomUsers", mock.Anything, "room-id").Return(users, nil)
+				rr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				ru.On("ListRoomUsers", mock.Anything, "room-id").Once().Return(users, nil)
 			},
-			req: func() user.ListUsersRequest {
+			req: func(token string) user.ListUsersRequest {
 				return user.ListUsersRequest{RoomID: "room-id"}
 			},
 			expResp: func() *user.ListUsersResponse {
-				return &user.ListUsersResponse{
-					Users: []model.User{
-						{ID: "user1", Name: "username1"},
-						{ID: "user2", Name: "username2"},
-					},
-				}
+				return &user.ListUsersResponse{Users: users.Items}
 			},
 		},
 
 		"Having a list request with an error while listing the users, should fail.": {
 			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
-				rr.On("RoomExists", mock.Anything, mock.Anything).Return(true, nil)
-				ru.On("ListRoomUsers", mock.Anything, mock.Anything).Return(nil, errors.New("wanted error"))
+				rr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+				ru.On("ListRoomUsers", mock.Anything, mock.Anything).Once().Return(nil, errWanted)
 			},
-			req: func() user.ListUsersRequest {
+			req: func(token string) user.ListUsersRequest {
 				return user.ListUsersRequest{RoomID: "room-id"}
 			},
-			expErr: true,
+			expErr: errWanted,
+		},
+
+		"Having a list request of a protected room without session, should not be allowed.": {
+			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
+				rr.On("GetRoom", mock.Anything, "room-id").Once().Return(protectedRoom, nil)
+			},
+			req: func(token string) user.ListUsersRequest {
+				return user.ListUsersRequest{RoomID: "room-id"}
+			},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having a list request of a protected room with an invalid session, should not be allowed.": {
+			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
+				rr.On("GetRoom", mock.Anything, "room-id").Once().Return(protectedRoom, nil)
+			},
+			req: func(token string) user.ListUsersRequest {
+				return user.ListUsersRequest{RoomID: "room-id", SessionToken: "user1.dGVzdA"}
+			},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having a list request of a protected room with the session of another room user, should not be allowed.": {
+			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
+				rr.On("GetRoom", mock.Anything, "room-id").Once().Return(protectedRoom, nil)
+				ru.On("GetUserByID", mock.Anything, "user1").Once().Return(&model.User{ID: "user1", RoomID: "other-room-id"}, nil)
+			},
+			req: func(token string) user.ListUsersRequest {
+				return user.ListUsersRequest{RoomID: "room-id", SessionToken: token}
+			},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having a list request of a protected room with the session of a room user, should list the users.": {
+			mock: func(ru *storagemock.UserRepository, rr *storagemock.RoomRepository) {
+				rr.On("GetRoom", mock.Anything, "room-id").Once().Return(protectedRoom, nil)
+				ru.On("GetUserByID", mock.Anything, "user1").Once().Return(&users.Items[0], nil)
+				ru.On("ListRoomUsers", mock.Anything, "room-id").Once().Return(users, nil)
+			},
+			req: func(token string) user.ListUsersRequest {
+				return user.ListUsersRequest{RoomID: "room-id", SessionToken: token}
+			},
+			expResp: func() *user.ListUsersResponse {
+				return &user.ListUsersResponse{Users: users.Items}
+			},
 		},
 	}
 
@@ -259,23 +550,33 @@ func TestServiceListUsers(t *testing.T) {
 			// Mocks
 			mr := &storagemock.RoomRepository{}
 			mu := &storagemock.UserRepository{}
-			test.mock(mu, mr)
 
 			test.config.RoomRepository = mr
 			test.config.UserRepository = mu
-			test.config.IDGenerator = func() string { return "test" }
+			test.config.IDGenerator = func() string { return "user1" }
 			test.config.TimeNowFunc = func() time.Time { return t0 }
 
 			svc, err := user.NewService(test.config)
 			require.NoError(err)
 
-			gotResp, err := svc.ListUsers(context.TODO(), test.req())
+			// Get a valid session token joining the room.
+			mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+			mu.On("GetUserByNameInsensitive", mock.Anything, "room-id", "username1").Once().Return(nil, internalerrors.ErrMissing)
+			mu.On("CreateUser", mock.Anything, mock.Anything).Once().Return(nil)
+			cResp, err := svc.CreateUser(context.TODO(), user.CreateUserRequest{Name: "username1", RoomID: "room-id"})
+			require.NoError(err)
 
-			if test.expErr {
-				assert.Error(err)
+			test.mock(mu, mr)
+			gotResp, err := svc.ListUsers(context.TODO(), test.req(cResp.SessionToken))
+
+			if test.expErr != nil {
+				assert.ErrorIs(err, test.expErr)
 			} else if assert.NoError(err) {
 				assert.Equal(test.expResp(), gotResp)
 			}
+
+			mr.AssertExpectations(t)
+			mu.AssertExpectations(t)
 		})
 	}
 }
@@ -362,3 +663,115 @@ func TestServiceGetUser(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceAuthenticateUser(t *testing.T) {
+	u := &model.User{ID: "user-id", RoomID: "room-id", Name: "user"}
+
+	tests := map[string]struct {
+		mock    func(ru *storagemock.UserRepository)
+		req     func(token string) user.AuthenticateUserRequest
+		expResp *user.AuthenticateUserResponse
+		expErr  error
+	}{
+		"Having a request without session token, should fail.": {
+			mock: func(ru *storagemock.UserRepository) {},
+			req: func(token string) user.AuthenticateUserRequest {
+				return user.AuthenticateUserRequest{RoomID: "room-id"}
+			},
+			expErr: internalerrors.ErrNotValid,
+		},
+
+		"Having a request with a session token that is not signed by us, should not be allowed.": {
+			mock: func(ru *storagemock.UserRepository) {},
+			req: func(token string) user.AuthenticateUserRequest {
+				return user.AuthenticateUserRequest{RoomID: "room-id", SessionToken: "user-id.dGVzdA"}
+			},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having a request with the session token of another user, should not be allowed.": {
+			mock: func(ru *storagemock.UserRepository) {},
+			req: func(token string) user.AuthenticateUserRequest {
+				_, sig, _ := strings.Cut(token, ".")
+				return user.AuthenticateUserRequest{RoomID: "room-id", SessionToken: "gm-id." + sig}
+			},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having a request with a session token of a missing user, should not be allowed.": {
+			mock: func(ru *storagemock.UserRepository) {
+				ru.On("GetUserByID", mock.Anything, "user-id").Once().Return(nil, internalerrors.ErrMissing)
+			},
+			req: func(token string) user.AuthenticateUserRequest {
+				return user.AuthenticateUserRequest{RoomID: "room-id", SessionToken: token}
+			},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having a request with a session token of another room user, should not be allowed.": {
+			mock: func(ru *storagemock.UserRepository) {
+				ru.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+			},
+			req: func(token string) user.AuthenticateUserRequest {
+				return user.AuthenticateUserRequest{RoomID: "other-room-id", SessionToken: token}
+			},
+			expErr: internalerrors.ErrNotAllowed,
+		},
+
+		"Having a request with a valid session token, should return the user.": {
+			mock: func(ru *storagemock.UserRepository) {
+				ru.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+			},
+			req: func(token string) user.AuthenticateUserRequest {
+				return user.AuthenticateUserRequest{RoomID: "room-id", SessionToken: token}
+			},
+			expResp: &user.AuthenticateUserResponse{User: *u},
+		},
+
+		"Having a request with a valid session token without room, should return the user.": {
+			mock: func(ru *storagemock.UserRepository) {
+				ru.On("GetUserByID", mock.Anything, "user-id").Once().Return(u, nil)
+			},
+			req: func(token string) user.AuthenticateUserRequest {
+				return user.AuthenticateUserRequest{SessionToken: token}
+			},
+			expResp: &user.AuthenticateUserResponse{User: *u},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Mocks.
+			mr := &storagemock.RoomRepository{}
+			mu := &storagemock.UserRepository{}
+
+			svc, err := user.NewService(user.ServiceConfig{
+				RoomRepository: mr,
+				UserRepository: mu,
+				IDGenerator:    func() string { return "user-id" },
+			})
+			require.NoError(err)
+
+			// Get a valid session token joining the room.
+			mr.On("GetRoom", mock.Anything, "room-id").Once().Return(&model.Room{ID: "room-id"}, nil)
+			mu.On("GetUserByNameInsensitive", mock.Anything, "room-id", "user").Once().Return(nil, internalerrors.ErrMissing)
+			mu.On("CreateUser", mock.Anything, mock.Anything).Once().Return(nil)
+			cResp, err := svc.CreateUser(context.TODO(), user.CreateUserRequest{Name: "user", RoomID: "room-id"})
+			require.NoError(err)
+
+			test.mock(mu)
+			gotResp, err := svc.AuthenticateUser(context.TODO(), test.req(cResp.SessionToken))
+
+			if test.expErr != nil {
+				assert.ErrorIs(err, test.expErr)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expResp, gotResp)
+			}
+
+			mu.AssertExpectations(t)
+		})
+	}
+}
@@ -14,6 +14,32 @@ type Service struct {
 	mock.Mock
 }
 
+// AuthenticateUser provides a mock function with given fields: ctx, r
+func (_m *Service) AuthenticateUser(ctx context.Context, r user.AuthenticateUserRequest) (*user.AuthenticateUserResponse, error) {
+	ret := _m.Called(ctx, r)
+
+	var r0 *user.AuthenticateUserResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.AuthenticateUserRequest) (*user.AuthenticateUserResponse, error)); ok {
+		return rf(ctx, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.AuthenticateUserRequest) *user.AuthenticateUserResponse); ok {
+		r0 = rf(ctx, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.AuthenticateUserResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.AuthenticateUserRequest) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateUser provides a mock function with given fields: ctx, r
 func (_m *Service) CreateUser(ctx context.Context, r user.CreateUserRequest) (*user.CreateUserResponse, error) {
 	ret := _m.Called(ctx, r)
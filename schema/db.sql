@@ -3,17 +3,33 @@ CREATE TABLE IF NOT EXISTS `room`
     `id` VARCHAR(255) NOT NULL,
     `created_at` DATETIME NOT NULL,
     `name` VARCHAR(255) NOT NULL,
+    `ruleset` VARCHAR(255) NOT NULL DEFAULT '',
+    `archived` BOOLEAN NOT NULL DEFAULT FALSE,
+    `password_hash` VARCHAR(255) NOT NULL DEFAULT '',
     
     PRIMARY KEY(`id`)
 
 ) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin;
 
+CREATE TABLE IF NOT EXISTS `room_invite_token`
+(
+    `token` VARCHAR(255) NOT NULL,
+    `room_id` VARCHAR(255) NOT NULL,
+    `created_at` DATETIME(3) NOT NULL,
+
+    PRIMARY KEY(`token`),
+
+    INDEX `idx_room_invite_token_room_id` (`room_id`)
+
+) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin;
+
 CREATE TABLE IF NOT EXISTS  `user`
 (
     `id` VARCHAR(255) NOT NULL,
     `created_at` DATETIME(3) NOT NULL,
     `name` VARCHAR(255) NOT NULL COLLATE utf8mb4_0900_ai_ci,
     `room_id` VARCHAR(255) NOT NULL,
+    `game_master` BOOLEAN NOT NULL DEFAULT FALSE,
 
     PRIMARY KEY(`id`),
 
@@ -29,6 +45,23 @@ CREATE TABLE IF NOT EXISTS  `dice_roll`
     `serial` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT UNIQUE,
     `user_id` VARCHAR(255) NOT NULL,
     `room_id` VARCHAR(255) NOT NULL,
+    `expression` VARCHAR(255) NOT NULL DEFAULT '',
+    `label` VARCHAR(255) NOT NULL DEFAULT '',
+    `modifier` INT NOT NULL DEFAULT 0,
+    `total` INT NOT NULL DEFAULT 0,
+    `server_seed_id` VARCHAR(255) NOT NULL DEFAULT '',
+    `server_seed_hash` VARCHAR(255) NOT NULL DEFAULT '',
+    `client_seed` VARCHAR(255) NOT NULL DEFAULT '',
+    `nonce` INT UNSIGNED NOT NULL DEFAULT 0,
+    `pool_successes` INT NOT NULL DEFAULT 0,
+    `pool_outcome` TINYINT UNSIGNED NOT NULL DEFAULT 0,
+    `check_target` INT NOT NULL DEFAULT 0,
+    `check_comparison` TINYINT UNSIGNED NOT NULL DEFAULT 0,
+    `check_margin` INT NOT NULL DEFAULT 0,
+    `check_outcome` TINYINT UNSIGNED NOT NULL DEFAULT 0,
+    `visibility` TINYINT UNSIGNED NOT NULL DEFAULT 0,
+    `whisper_user_ids` TEXT NOT NULL,
+    `parent_id` VARCHAR(255) NOT NULL DEFAULT '',
 
     PRIMARY KEY(`id`),
 
@@ -42,9 +75,122 @@ CREATE TABLE IF NOT EXISTS  `die_roll`
     `dice_roll_id` VARCHAR(255) NOT NULL,
     `die_type_id` VARCHAR(255) NOT NULL,
     `side` SMALLINT UNSIGNED NOT NULL,
+    `position` SMALLINT UNSIGNED NOT NULL DEFAULT 0,
+    `status` TINYINT UNSIGNED NOT NULL DEFAULT 0,
 
     PRIMARY KEY(`id`),
 
     INDEX `idx_dice_roll_id` (`dice_roll_id`)
 
 ) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin;
+
+CREATE TABLE IF NOT EXISTS  `custom_die_type`
+(
+    `id` VARCHAR(255) NOT NULL,
+    `serial` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT UNIQUE,
+    `room_id` VARCHAR(255) NOT NULL,
+    `name` VARCHAR(255) NOT NULL,
+    `faces` TEXT NOT NULL,
+
+    PRIMARY KEY(`id`),
+
+    INDEX `idx_custom_die_type_room_id` (`room_id`)
+
+) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin;
+
+CREATE TABLE IF NOT EXISTS  `server_seed`
+(
+    `id` VARCHAR(255) NOT NULL,
+    `serial` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT UNIQUE,
+    `room_id` VARCHAR(255) NOT NULL,
+    `created_at` DATETIME(3) NOT NULL,
+    `seed` VARCHAR(255) NOT NULL,
+    `hash` VARCHAR(255) NOT NULL,
+    `nonce` INT UNSIGNED NOT NULL DEFAULT 0,
+    `revealed` BOOLEAN NOT NULL DEFAULT FALSE,
+
+    PRIMARY KEY(`id`),
+
+    INDEX `idx_server_seed_room_id` (`room_id`)
+
+) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin;
+
+CREATE TABLE IF NOT EXISTS  `macro`
+(
+    `id` VARCHAR(255) NOT NULL,
+    `serial` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT UNIQUE,
+    `created_at` DATETIME(3) NOT NULL,
+    `room_id` VARCHAR(255) NOT NULL,
+    `user_id` VARCHAR(255) NOT NULL,
+    `name` VARCHAR(255) NOT NULL,
+    `expression` VARCHAR(255) NOT NULL,
+    `shared` BOOLEAN NOT NULL DEFAULT FALSE,
+
+    PRIMARY KEY(`id`),
+
+    INDEX `idx_macro_room_id` (`room_id`)
+
+) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin;
+
+CREATE TABLE IF NOT EXISTS  `initiative`
+(
+    `room_id` VARCHAR(255) NOT NULL,
+    `updated_at` DATETIME(3) NOT NULL,
+    `round` INT UNSIGNED NOT NULL DEFAULT 0,
+    `turn` INT NOT NULL DEFAULT 0,
+    `combatants` TEXT NOT NULL,
+
+    PRIMARY KEY(`room_id`)
+
+) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin;
+
+CREATE TABLE IF NOT EXISTS  `deck`
+(
+    `id` VARCHAR(255) NOT NULL,
+    `serial` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT UNIQUE,
+    `created_at` DATETIME(3) NOT NULL,
+    `room_id` VARCHAR(255) NOT NULL,
+    `name` VARCHAR(255) NOT NULL,
+    `type` VARCHAR(64) NOT NULL,
+    `cards` TEXT NOT NULL,
+    `draw_pile` TEXT NOT NULL,
+    `discard_pile` TEXT NOT NULL,
+    `version` INT UNSIGNED NOT NULL DEFAULT 0,
+
+    PRIMARY KEY(`id`),
+
+    INDEX `idx_deck_room_id` (`room_id`)
+
+) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin;
+
+CREATE TABLE IF NOT EXISTS  `oracle_table`
+(
+    `id` VARCHAR(255) NOT NULL,
+    `serial` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT UNIQUE,
+    `created_at` DATETIME(3) NOT NULL,
+    `room_id` VARCHAR(255) NOT NULL,
+    `name` VARCHAR(255) NOT NULL,
+    `dice` VARCHAR(255) NOT NULL,
+    `entries` MEDIUMTEXT NOT NULL,
+
+    PRIMARY KEY(`id`),
+
+    UNIQUE INDEX `idx_oracle_table_room_id_name` (`room_id`, `name`)
+
+) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin;
+
+CREATE TABLE IF NOT EXISTS  `oracle_table_roll`
+(
+    `id` VARCHAR(255) NOT NULL,
+    `serial` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT UNIQUE,
+    `created_at` DATETIME(3) NOT NULL,
+    `room_id` VARCHAR(255) NOT NULL,
+    `user_id` VARCHAR(255) NOT NULL,
+    `table_id` VARCHAR(255) NOT NULL,
+    `results` TEXT NOT NULL,
+
+    PRIMARY KEY(`id`),
+
+    INDEX `idx_oracle_table_roll_room_id` (`room_id`)
+
+) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin;